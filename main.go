@@ -3,19 +3,41 @@ package main
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"log/slog"
+	"maps"
+	"net/http"
+	"net/url"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"reflect"
+	"regexp"
+	"runtime/debug"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	yaml "github.com/goccy/go-yaml"
 	"github.com/meitner-se/publicapis-gen/specification"
+	"github.com/meitner-se/publicapis-gen/specification/changeloggen"
+	"github.com/meitner-se/publicapis-gen/specification/conformance"
+	"github.com/meitner-se/publicapis-gen/specification/coveragegen"
+	"github.com/meitner-se/publicapis-gen/specification/gatewaygen"
+	"github.com/meitner-se/publicapis-gen/specification/mockserver"
 	"github.com/meitner-se/publicapis-gen/specification/openapigen"
 	"github.com/meitner-se/publicapis-gen/specification/schemagen"
 	"github.com/meitner-se/publicapis-gen/specification/servergen"
+	"github.com/meitner-se/publicapis-gen/specification/terraformgen"
 	"github.com/meitner-se/publicapis-gen/specification/testgen"
 )
 
@@ -31,6 +53,9 @@ const (
 	logKeyError         = "error"
 	logKeyFile          = "file"
 	logKeyMode          = "mode"
+	logKeyPlugin        = "plugin"
+	logKeyMethod        = "method"
+	logKeyPath          = "path"
 )
 
 // Diff output constants
@@ -43,14 +68,109 @@ const (
 	diffLinePrefix      = "  "
 	diffMaxLinesToShow  = 10
 	diffContextLines    = 3
+	diffSemanticDiffers = "content differs semantically"
+	diffDiskUnparsable  = "file on disk could not be parsed"
+	diffRootPath        = "/"
+)
+
+// Diff report constants
+const (
+	quietFlag           = "quiet"
+	strictFlag          = "strict"
+	reportFlag          = "report"
+	reportFormatText    = "text"
+	reportFormatJSON    = "json"
+	errorInvalidReport  = "invalid report format"
+	diffStatusOK        = "ok"
+	diffStatusChanged   = "changed"
+	diffStatusMissing   = "missing"
+	diffStatusOutdated  = "outdated"
+	artifactOpenAPIJSON = "OpenAPI JSON"
+	artifactOpenAPIYAML = "OpenAPI YAML"
+	artifactSchemaJSON  = "Schema JSON"
+	artifactOverlayYAML = "Overlay YAML"
+	artifactOverlayJSON = "Overlay JSON"
+	artifactServerGo    = "Server Go"
+	artifactGateway     = "Gateway routes"
+	artifactTerraform   = "Terraform schema"
+	diffNotGenerated    = "file is no longer generated: regenerate to remove it"
+	artifactPlugin      = "Plugin"
+	artifactShared      = "Shared package"
+)
+
+// Provenance header constants
+const (
+	defaultToolVersion    = "dev"
+	provenanceJSONKey     = "x-generated-by"
+	provenanceSearchLimit = 1024
+	diffOtherVersion      = "generated by publicapis-gen %s, this is %s: regenerate the file"
+	diffNoProvenance      = "generated without a provenance header by an older publicapis-gen: regenerate the file"
+)
+
+// version is the version of publicapis-gen, set at build time with -ldflags "-X main.version=v1.2.3".
+// When it is not set, the module version from the build info is used, e.g. after go install.
+var version string
+
+// provenancePattern matches the provenance header of a generated file, capturing the tool version and specification hash
+var provenancePattern = regexp.MustCompile(`publicapis-gen (\S+) from specification sha256:([0-9a-f]{64})`)
+
+// envPattern matches the ${VAR} references of the config. A bare $ is left alone, so that plugin commands
+// such as awk '{print $1}' keep their shell variables.
+var envPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// Plugin constants
+const (
+	errorInvalidPlugin     = "invalid plugin"
+	errorPluginFailed      = "plugin failed"
+	envPluginSpecification = "PUBLICAPIS_SPECIFICATION"
+	envPluginOutput        = "PUBLICAPIS_OUTPUT"
+)
+
+// Shared package constants
+const (
+	errorInvalidSharedPackage = "invalid shared package"
 )
 
 // Operation modes
 const (
-	modeOverlay = "overlay"
-	modeOpenAPI = "openapi"
-	modeSchema  = "schema"
-	modeServer  = "server"
+	modeOverlay     = "overlay"
+	modeOpenAPI     = "openapi"
+	modeOpenAPIYAML = "openapi-yaml"
+	modeSchema      = "schema"
+	modeServer      = "server"
+	modeGateway     = "gateway-routes"
+	modeTerraform   = "terraform-schema"
+)
+
+// Selective generation constants
+const (
+	onlyFlag              = "only"
+	resourceFlag          = "resource"
+	onlyPlugins           = "plugins"
+	errorInvalidOnly      = "invalid artifact"
+	errorUnknownResource  = "unknown resource"
+	errorSpecAndSelection = "the -only, -resource and -force flags cannot be combined with -spec"
+	logKeyResource        = "resource"
+)
+
+// onlyArtifacts are the artifacts accepted by the -only flag: the -mode values and the plugin outputs
+var onlyArtifacts = []string{modeOpenAPI, modeOpenAPIYAML, modeSchema, modeOverlay, modeServer, modeGateway, modeTerraform, onlyPlugins}
+
+// Cache constants
+const (
+	forceFlag       = "force"
+	cacheFileName   = ".publicapis-cache"
+	errorCacheWrite = "failed to write cache file"
+)
+
+// Single specification mode constants
+const (
+	specFlag           = "spec"
+	modeFlag           = "mode"
+	outputFlag         = "o"
+	stdioPath          = "-"
+	errorMissingMode   = "missing mode"
+	errorSpecAndConfig = "the -spec and -config flags cannot be combined"
 )
 
 // File extensions
@@ -58,6 +178,7 @@ const (
 	extYAML = ".yaml"
 	extYML  = ".yml"
 	extJSON = ".json"
+	extGo   = ".go"
 )
 
 // Output file suffixes
@@ -80,7 +201,7 @@ const (
 // Usage messages
 const (
 	usageDescription = "publicapis-gen - Generate API specifications and OpenAPI documents"
-	usageExample     = "\nExamples:\n  # Using config file\n  publicapis-gen generate -config=build-config.yaml\n  publicapis-gen generate -config=build-config.yaml -log-level=info\n\n  # Using default config file (automatically detects publicapis.yaml or publicapis.yml)\n  publicapis-gen generate\n  publicapis-gen generate -log-level=info"
+	usageExample     = "\nExamples:\n  # Using config file\n  publicapis-gen generate -config=build-config.yaml\n  publicapis-gen generate -config=build-config.yaml -log-level=info\n\n  # Using default config file (automatically detects publicapis.yaml or publicapis.yml)\n  publicapis-gen generate\n  publicapis-gen generate -log-level=info\n\n  # Single specification from stdin to stdout\n  cat users-api.yaml | publicapis-gen generate -spec - -mode openapi -o -"
 )
 
 // Config file constants
@@ -92,10 +213,122 @@ const (
 	defaultConfigYML   = "publicapis.yml"
 )
 
+// Config path expansion constants
+const (
+	globMetaCharacters  = "*?["
+	placeholderName     = "{name}"
+	placeholderService  = "{service}"
+	placeholderVersion  = "{version}"
+	errorGlobExpansion  = "failed to expand specification pattern"
+	errorOutputConflict = "conflicting output paths"
+	errorUndefinedEnv   = "undefined environment variable"
+)
+
+// Format command constants
+const (
+	checkFlag           = "check"
+	errorNoFiles        = "no specification files"
+	errorNotFormatted   = "specification files are not formatted"
+	fmtUsageDescription = "Format specification files with canonical key ordering and casing, keeping comments"
+)
+
+// Migrate command constants
+const (
+	errorNotMigrated        = "specification files are not migrated"
+	migrateUsageDescription = "Migrate specification files to the current schema version, keeping comments"
+)
+
+// Changelog command constants
+const (
+	errorNoOpenAPIJobs        = "no jobs with OpenAPI output"
+	changelogUsageDescription = "Write a Markdown changelog of the OpenAPI documents generated from the specifications against the files on disk"
+)
+
+// Schema command constants
+const (
+	subcommandExport       = "export"
+	subcommandServe        = "serve"
+	baseURLFlag            = "base-url"
+	defaultSchemaDir       = "schemas"
+	defaultSchemaAddress   = "localhost:8080"
+	contentTypeSchemaJSON  = "application/schema+json"
+	errorInvalidSubcommand = "invalid subcommand"
+	schemaUsageDescription = "Export or serve the JSON schemas of the specification files, for autocomplete and validation in editors"
+)
+
+// Mock command constants
+const (
+	portFlag             = "port"
+	defaultMockPort      = 8080
+	errorMissingSpec     = "missing specification"
+	errorInvalidPort     = "invalid port"
+	mockUsageDescription = "Serve a mock of the API of a specification, responding with the examples of the specification"
+)
+
+// Proxy command constants
+const (
+	targetFlag            = "target"
+	errorMissingTarget    = "missing target"
+	errorInvalidTarget    = "invalid target"
+	proxyUsageDescription = "Proxy an implementation of the API of a specification, reporting the requests and responses that violate the specification"
+)
+
+// Coverage command constants
+const (
+	implFlag                 = "impl"
+	testsFlag                = "tests"
+	formatFlag               = "format"
+	reportFormatMarkdown     = "markdown"
+	errorMissingPackage      = "missing package"
+	coverageUsageDescription = "Report the endpoints of a specification that lack an implementation or a test"
+)
+
+// Lint command constants
+const (
+	pruneFlag            = "prune"
+	lintUsageDescription = "Warn about the enums and objects of specification files that nothing references"
+)
+
+// specificationRewrite is a rewrite of specification files in place, shared by the fmt and migrate commands.
+type specificationRewrite struct {
+	// verb and pastTense describe the rewrite in errors and output, e.g. "format" and "Formatted"
+	verb      string
+	pastTense string
+
+	// errorPending is returned in check mode when files still need the rewrite
+	errorPending string
+
+	// apply rewrites the content of a file with the given extension
+	apply func(data []byte, fileExtension string) ([]byte, error)
+}
+
+var (
+	formatRewrite = specificationRewrite{
+		verb:         "format",
+		pastTense:    "Formatted",
+		errorPending: errorNotFormatted,
+		apply:        specification.FormatService,
+	}
+	migrateRewrite = specificationRewrite{
+		verb:         "migrate",
+		pastTense:    "Migrated",
+		errorPending: errorNotMigrated,
+		apply:        specification.MigrateService,
+	}
+)
+
 // Command constants
 const (
 	commandGenerate     = "generate"
 	commandDiff         = "diff"
+	commandFmt          = "fmt"
+	commandMigrate      = "migrate"
+	commandChangelog    = "changelog"
+	commandSchema       = "schema"
+	commandMock         = "mock"
+	commandProxy        = "proxy"
+	commandCoverage     = "coverage"
+	commandLint         = "lint"
 	commandHelp         = "help"
 	errorInvalidCommand = "invalid command"
 	errorMissingCommand = "missing command"
@@ -104,7 +337,7 @@ const (
 // Command usage messages
 const (
 	mainUsageDescription     = "publicapis-gen - Generate API specifications and OpenAPI documents"
-	mainUsageCommands        = "\nAvailable Commands:\n  generate    Generate API specifications and OpenAPI documents\n  diff        Check for differences between generated files and files on disk\n  help        Show help for commands\n\nUse \"publicapis-gen [command] --help\" for more information about a command."
+	mainUsageCommands        = "\nAvailable Commands:\n  generate    Generate API specifications and OpenAPI documents\n  diff        Check for differences between generated files and files on disk\n  fmt         Format specification files\n  migrate     Migrate specification files to the current schema version\n  changelog   Write a changelog of the OpenAPI documents against the files on disk\n  schema      Export or serve the JSON schemas of the specification files for editors\n  mock        Serve a mock of the API of a specification\n  proxy       Proxy an implementation, reporting violations of the specification\n  coverage    Report the endpoints that lack an implementation or a test\n  lint        Warn about the enums and objects that nothing references\n  help        Show help for commands\n\nUse \"publicapis-gen [command] --help\" for more information about a command."
 	generateUsageDescription = "Generate API specifications and OpenAPI documents from specification files"
 	diffUsageDescription     = "Check for differences between generated files and files on disk"
 )
@@ -119,15 +352,196 @@ type Job struct {
 	OverlayJSON   string `yaml:"overlay_json,omitempty" json:"overlay_json,omitempty"`
 	ServerGo      string `yaml:"server_go,omitempty" json:"server_go,omitempty"`
 	ServerPackage string `yaml:"server_package,omitempty" json:"server_package,omitempty"`
+
+	// ServerDir is a directory the server is generated into, split across files, see servergen.GenerateServerFiles
+	ServerDir string `yaml:"server_dir,omitempty" json:"server_dir,omitempty"`
+
+	// TemplatesDir is a directory with templates overriding parts of the generated server, see servergen.Options
+	TemplatesDir string `yaml:"templates_dir,omitempty" json:"templates_dir,omitempty"`
+
+	// TypesPackage is the import path of the types package the generated server uses instead of go-types
+	TypesPackage string `yaml:"types_package,omitempty" json:"types_package,omitempty"`
+
+	// StdlibTypes generates the server with standard library types instead of a types package
+	StdlibTypes bool `yaml:"stdlib_types,omitempty" json:"stdlib_types,omitempty"`
+
+	// TypedIDs declares the UUID fields referencing a resource with the ID type of the resource, see servergen.Types
+	TypedIDs bool `yaml:"typed_ids,omitempty" json:"typed_ids,omitempty"`
+
+	// ServerLifecycle generates RunServer with the server, see servergen.Options
+	ServerLifecycle bool `yaml:"server_lifecycle,omitempty" json:"server_lifecycle,omitempty"`
+
+	// Benchmarks also generates benchmarks of the endpoints with the internal tests, see testgen.Options
+	Benchmarks bool `yaml:"benchmarks,omitempty" json:"benchmarks,omitempty"`
+
+	// GoldenFiles makes the generated tests compare the responses with golden files, see testgen.Options
+	GoldenFiles bool `yaml:"golden_files,omitempty" json:"golden_files,omitempty"`
+
+	// GatewayRoutes is the file the Kubernetes Gateway API routes of the endpoints are generated into, see gatewaygen
+	GatewayRoutes string `yaml:"gateway_routes,omitempty" json:"gateway_routes,omitempty"`
+
+	// TerraformSchema is the file the Terraform provider schema of the resources is generated into, see terraformgen
+	TerraformSchema string `yaml:"terraform_schema,omitempty" json:"terraform_schema,omitempty"`
+
+	// TerraformResources are the resources exposed through the Terraform provider, see terraformgen.Options
+	TerraformResources []string `yaml:"terraform_resources,omitempty" json:"terraform_resources,omitempty"`
+
+	// Extensions is the extensions profile of the OpenAPI documents generated by the job
+	Extensions *openapigen.Extensions `yaml:"extensions,omitempty" json:"extensions,omitempty"`
+
+	// OpenAPIOverlay is an OpenAPI Overlay document applied to the OpenAPI documents generated by the job
+	OpenAPIOverlay string `yaml:"openapi_overlay,omitempty" json:"openapi_overlay,omitempty"`
+
+	// ExcludeFeatureFlagged leaves the endpoints gated behind a feature flag out of the OpenAPI documents of the job,
+	// see openapigen.Options
+	ExcludeFeatureFlagged bool `yaml:"exclude_feature_flagged,omitempty" json:"exclude_feature_flagged,omitempty"`
+
+	// Plugins are custom generators run by the job, each writing one output file
+	Plugins []Plugin `yaml:"plugins,omitempty" json:"plugins,omitempty"`
+
+	// SharedPackages are the Go packages the types of the shared libraries of the specification are generated into,
+	// which the generated server imports instead of declaring the types itself, see servergen.GenerateSharedPackage
+	SharedPackages []SharedPackage `yaml:"shared_packages,omitempty" json:"shared_packages,omitempty"`
+
+	// Prune leaves the enums and objects that nothing references out of the outputs of the job,
+	// see specification.PruneUnusedDefinitions
+	Prune bool `yaml:"prune,omitempty" json:"prune,omitempty"`
+}
+
+// SharedPackage is a Go package the enums and objects of a shared library are generated into, so that the servers
+// of all the specifications using the library share the same types.
+type SharedPackage struct {
+	// Library is the name of the shared library
+	Library string `yaml:"library" json:"library"`
+
+	// Import is the import path of the package, e.g. "github.com/org/repo/shared/common"
+	Import string `yaml:"import" json:"import"`
+
+	// Output is the path the package is generated into
+	Output string `yaml:"output" json:"output"`
+}
+
+// Validate validates that the shared package has a library, an import path and an output path.
+func (p SharedPackage) Validate() error {
+	if p.Library == "" {
+		return fmt.Errorf("%s: 'library' is required", errorInvalidSharedPackage)
+	}
+
+	if p.Import == "" {
+		return fmt.Errorf("%s: '%s' is missing required 'import' field", errorInvalidSharedPackage, p.Library)
+	}
+
+	if p.Output == "" {
+		return fmt.Errorf("%s: '%s' is missing required 'output' field", errorInvalidSharedPackage, p.Library)
+	}
+
+	return nil
+}
+
+// Plugin is a custom generator, run as an external command. The command receives the specification, with
+// overlays applied, as JSON on stdin and writes the generated content to stdout, which is written to Output.
+type Plugin struct {
+	// Command is the executable and its arguments, separated by spaces, e.g. "./my-generator --flag"
+	Command string `yaml:"plugin" json:"plugin"`
+
+	// Output is the path the content written by the command is written to
+	Output string `yaml:"output" json:"output"`
+}
+
+// Validate validates that the plugin has a command and an output path.
+func (p Plugin) Validate() error {
+	if len(strings.Fields(p.Command)) == 0 {
+		return fmt.Errorf("%s: 'plugin' command is required", errorInvalidPlugin)
+	}
+
+	if p.Output == "" {
+		return fmt.Errorf("%s: '%s' is missing required 'output' field", errorInvalidPlugin, p.Command)
+	}
+
+	return nil
 }
 
 // Config represents the configuration file structure
 type Config []Job
 
+// generateOptions selects the outputs written by the generate command
+type generateOptions struct {
+	// Only limits the outputs of each job to these artifacts, all outputs are written when it is empty
+	Only []string
+
+	// Resource limits the jobs to those whose specification defines this resource
+	Resource string
+
+	// Force regenerates the jobs whose inputs have not changed since they were cached
+	Force bool
+
+	// Prune prunes the unused enums and objects of every job, as if they all set Job.Prune
+	Prune bool
+}
+
+// generationCache records the inputs and outputs of the jobs generated from a config file, so that jobs whose
+// inputs have not changed are skipped. It is stored as JSON in a .publicapis-cache file next to the config file.
+type generationCache struct {
+	Jobs map[string]cacheEntry `json:"jobs"`
+}
+
+// cacheEntry is the cached generation of a job
+type cacheEntry struct {
+	// Inputs is the hash of the specification, the publicapis-gen version and the job options
+	Inputs string `json:"inputs"`
+
+	// Outputs are the files written by the job, which must still exist for the job to be skipped
+	Outputs []string `json:"outputs"`
+}
+
+// diffOptions controls how the diff command reports its results
+type diffOptions struct {
+	Quiet  bool
+	Report string
+	Strict bool
+}
+
+// diffReport is the machine-readable summary produced by the diff command
+type diffReport struct {
+	HasDifferences bool            `json:"has_differences"`
+	Jobs           []jobDiffReport `json:"jobs"`
+}
+
+// jobDiffReport holds the diff results for a single job
+type jobDiffReport struct {
+	Job           int                  `json:"job"`
+	Specification string               `json:"specification"`
+	Artifacts     []artifactDiffReport `json:"artifacts"`
+}
+
+// artifactDiffReport holds the diff result for a single generated artifact
+type artifactDiffReport struct {
+	Artifact string `json:"artifact"`
+	Path     string `json:"path"`
+	Status   string `json:"status"`
+	Diff     string `json:"diff,omitempty"`
+}
+
+// HasDifference returns true if the artifact is missing or differs from the generated content.
+func (a artifactDiffReport) HasDifference() bool {
+	return a.Status != diffStatusOK
+}
+
+// HasDifferences returns true if any artifact of the job differs from the generated content.
+func (j jobDiffReport) HasDifferences() bool {
+	for _, artifact := range j.Artifacts {
+		if artifact.HasDifference() {
+			return true
+		}
+	}
+	return false
+}
+
 func main() {
 	ctx := context.Background()
 
 	if err := run(ctx); err != nil {
+		printValidationErrors(os.Stderr, err)
 		slog.ErrorContext(ctx, errorFailedToRun, logKeyError, err)
 		os.Exit(1)
 	}
@@ -147,6 +561,22 @@ func run(ctx context.Context) error {
 		return runGenerateCommand(ctx, os.Args[2:])
 	case commandDiff:
 		return runDiffCommand(ctx, os.Args[2:])
+	case commandFmt:
+		return runFmtCommand(ctx, os.Args[2:], os.Stdout)
+	case commandMigrate:
+		return runMigrateCommand(ctx, os.Args[2:], os.Stdout)
+	case commandChangelog:
+		return runChangelogCommand(ctx, os.Args[2:], os.Stdout)
+	case commandSchema:
+		return runSchemaCommand(ctx, os.Args[2:], os.Stdout)
+	case commandMock:
+		return runMockCommand(ctx, os.Args[2:], os.Stdin)
+	case commandProxy:
+		return runProxyCommand(ctx, os.Args[2:], os.Stdin)
+	case commandCoverage:
+		return runCoverageCommand(ctx, os.Args[2:], os.Stdin, os.Stdout)
+	case commandLint:
+		return runLintCommand(ctx, os.Args[2:], os.Stdout)
 	case commandHelp:
 		if len(os.Args) >= 3 {
 			return showCommandHelp(os.Args[2])
@@ -162,6 +592,27 @@ func run(ctx context.Context) error {
 	}
 }
 
+// printValidationErrors prints the validation errors of a specification as a list, one error per line
+// annotated with its location as file:line:column, so that they can all be fixed at once.
+func printValidationErrors(w io.Writer, err error) {
+	var validationErrors specification.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return
+	}
+
+	fmt.Fprintf(w, "%d validation error(s):\n", len(validationErrors))
+	for _, validationErr := range validationErrors {
+		location := validationErr.Path
+		if validationErr.Line > 0 {
+			location = fmt.Sprintf("%d:%d", validationErr.Line, validationErr.Column)
+		}
+		if validationErr.File != "" {
+			location = validationErr.File + ":" + location
+		}
+		fmt.Fprintf(w, "  %s: %s\n", location, validationErr.Message)
+	}
+}
+
 func showMainUsage() {
 	fmt.Fprintf(os.Stderr, "%s\n\n", mainUsageDescription)
 	fmt.Fprintf(os.Stderr, "Usage: %s <command> [options]\n", os.Args[0])
@@ -176,6 +627,30 @@ func showCommandHelp(command string) error {
 	case commandDiff:
 		showDiffUsage()
 		return nil
+	case commandFmt:
+		showFmtUsage()
+		return nil
+	case commandMigrate:
+		showMigrateUsage()
+		return nil
+	case commandChangelog:
+		showChangelogUsage()
+		return nil
+	case commandSchema:
+		showSchemaUsage()
+		return nil
+	case commandMock:
+		showMockUsage()
+		return nil
+	case commandProxy:
+		showProxyUsage()
+		return nil
+	case commandCoverage:
+		showCoverageUsage()
+		return nil
+	case commandLint:
+		showLintUsage()
+		return nil
 	default:
 		showMainUsage()
 		return fmt.Errorf("%s: unknown command '%s'", errorInvalidCommand, command)
@@ -187,6 +662,13 @@ func showGenerateUsage() {
 	fmt.Fprintf(os.Stderr, "Usage: %s generate [options]\n\n", os.Args[0])
 	fmt.Fprintf(os.Stderr, "Options:\n")
 	fmt.Fprintf(os.Stderr, "  -config string\n        Path to YAML config file containing multiple jobs\n")
+	fmt.Fprintf(os.Stderr, "  -spec string\n        Path to a single specification file, or '-' to read from stdin\n")
+	fmt.Fprintf(os.Stderr, "  -mode string\n        Artifact to generate with -spec: 'openapi', 'openapi-yaml', 'schema', 'overlay', 'server', 'gateway-routes', or 'terraform-schema'\n")
+	fmt.Fprintf(os.Stderr, "  -o string\n        Output file for -spec, or '-' to write to stdout (default: -)\n")
+	fmt.Fprintf(os.Stderr, "  -only string\n        Comma-separated artifacts to write with -config: %s\n", strings.Join(onlyArtifacts, ", "))
+	fmt.Fprintf(os.Stderr, "  -resource string\n        Only run the jobs with -config whose specification defines this resource\n")
+	fmt.Fprintf(os.Stderr, "  -force\n        Regenerate the jobs with -config whose inputs have not changed since the last run\n")
+	fmt.Fprintf(os.Stderr, "  -prune\n        Leave the enums and objects that nothing references out of the generated artifacts\n")
 	fmt.Fprintf(os.Stderr, "  -log-level string\n        Log level: 'debug', 'info', 'warn', 'error', or 'off' (default: off)\n")
 	fmt.Fprintf(os.Stderr, "  -help\n        Show this help message\n")
 	fmt.Fprintf(os.Stderr, "%s\n", usageExample)
@@ -198,6 +680,9 @@ func showDiffUsage() {
 	fmt.Fprintf(os.Stderr, "Options:\n")
 	fmt.Fprintf(os.Stderr, "  -config string\n        Path to YAML config file containing multiple jobs\n")
 	fmt.Fprintf(os.Stderr, "  -log-level string\n        Log level: 'debug', 'info', 'warn', 'error', or 'off' (default: off)\n")
+	fmt.Fprintf(os.Stderr, "  -quiet\n        Print nothing and only report differences through the exit code\n")
+	fmt.Fprintf(os.Stderr, "  -report string\n        Report format: 'text' or 'json' (default: text)\n")
+	fmt.Fprintf(os.Stderr, "  -strict\n        Compare JSON and YAML files byte by byte instead of semantically\n")
 	fmt.Fprintf(os.Stderr, "  -help\n        Show this help message\n")
 	fmt.Fprintf(os.Stderr, "\nExamples:\n")
 	fmt.Fprintf(os.Stderr, "  # Using config file\n")
@@ -205,7 +690,141 @@ func showDiffUsage() {
 	fmt.Fprintf(os.Stderr, "  publicapis-gen diff -config=build-config.yaml -log-level=info\n\n")
 	fmt.Fprintf(os.Stderr, "  # Using default config file (automatically detects publicapis.yaml or publicapis.yml)\n")
 	fmt.Fprintf(os.Stderr, "  publicapis-gen diff\n")
-	fmt.Fprintf(os.Stderr, "  publicapis-gen diff -log-level=info\n")
+	fmt.Fprintf(os.Stderr, "  publicapis-gen diff -log-level=info\n\n")
+	fmt.Fprintf(os.Stderr, "  # For CI: exit code only, or a machine-readable report\n")
+	fmt.Fprintf(os.Stderr, "  publicapis-gen diff -quiet\n")
+	fmt.Fprintf(os.Stderr, "  publicapis-gen diff -report=json\n")
+}
+
+func showFmtUsage() {
+	fmt.Fprintf(os.Stderr, "%s\n\n", fmtUsageDescription)
+	fmt.Fprintf(os.Stderr, "Usage: %s fmt [options] <file>...\n\n", os.Args[0])
+	fmt.Fprintf(os.Stderr, "Options:\n")
+	fmt.Fprintf(os.Stderr, "  -check\n        List files that are not formatted and exit with an error instead of rewriting them\n")
+	fmt.Fprintf(os.Stderr, "  -log-level string\n        Log level: 'debug', 'info', 'warn', 'error', or 'off' (default: off)\n")
+	fmt.Fprintf(os.Stderr, "  -help\n        Show this help message\n")
+	fmt.Fprintf(os.Stderr, "\nExamples:\n")
+	fmt.Fprintf(os.Stderr, "  publicapis-gen fmt users-api.yaml products-api.yaml\n\n")
+	fmt.Fprintf(os.Stderr, "  # For CI: fail if a specification is not formatted\n")
+	fmt.Fprintf(os.Stderr, "  publicapis-gen fmt -check specs/*.yaml\n")
+}
+
+func showMigrateUsage() {
+	fmt.Fprintf(os.Stderr, "%s\n\n", migrateUsageDescription)
+	fmt.Fprintf(os.Stderr, "Usage: %s migrate [options] <file>...\n\n", os.Args[0])
+	fmt.Fprintf(os.Stderr, "Options:\n")
+	fmt.Fprintf(os.Stderr, "  -check\n        List files that are not on the current schema version and exit with an error instead of rewriting them\n")
+	fmt.Fprintf(os.Stderr, "  -log-level string\n        Log level: 'debug', 'info', 'warn', 'error', or 'off' (default: off)\n")
+	fmt.Fprintf(os.Stderr, "  -help\n        Show this help message\n")
+	fmt.Fprintf(os.Stderr, "\nThe current schema version is %d. Files without schema_version are read as version 1.\n", specification.CurrentSchemaVersion)
+	fmt.Fprintf(os.Stderr, "\nExamples:\n")
+	fmt.Fprintf(os.Stderr, "  publicapis-gen migrate users-api.yaml products-api.yaml\n\n")
+	fmt.Fprintf(os.Stderr, "  # For CI: fail if a specification is not on the current schema version\n")
+	fmt.Fprintf(os.Stderr, "  publicapis-gen migrate -check specs/*.yaml\n")
+}
+
+func showChangelogUsage() {
+	fmt.Fprintf(os.Stderr, "%s\n\n", changelogUsageDescription)
+	fmt.Fprintf(os.Stderr, "Usage: %s changelog [options]\n\n", os.Args[0])
+	fmt.Fprintf(os.Stderr, "Options:\n")
+	fmt.Fprintf(os.Stderr, "  -config string\n        Path to YAML config file containing multiple jobs\n")
+	fmt.Fprintf(os.Stderr, "  -o string\n        Output file for the changelog, or '-' to write to stdout (default: -)\n")
+	fmt.Fprintf(os.Stderr, "  -log-level string\n        Log level: 'debug', 'info', 'warn', 'error', or 'off' (default: off)\n")
+	fmt.Fprintf(os.Stderr, "  -help\n        Show this help message\n")
+	fmt.Fprintf(os.Stderr, "\nThe OpenAPI document of every job with openapi_json or openapi_yaml is generated and compared with the\n")
+	fmt.Fprintf(os.Stderr, "file on disk, so run it before generate, while the files on disk are the previous version.\n")
+	fmt.Fprintf(os.Stderr, "\nExamples:\n")
+	fmt.Fprintf(os.Stderr, "  publicapis-gen changelog -config=build-config.yaml\n\n")
+	fmt.Fprintf(os.Stderr, "  # Release notes for the next version\n")
+	fmt.Fprintf(os.Stderr, "  publicapis-gen changelog -o CHANGELOG-next.md\n")
+}
+
+func showSchemaUsage() {
+	fmt.Fprintf(os.Stderr, "%s\n\n", schemaUsageDescription)
+	fmt.Fprintf(os.Stderr, "Usage: %s schema <export|serve> [options]\n\n", os.Args[0])
+	fmt.Fprintf(os.Stderr, "Subcommands:\n")
+	fmt.Fprintf(os.Stderr, "  export    Write a JSON schema file per specification type to a directory\n")
+	fmt.Fprintf(os.Stderr, "  serve     Serve the JSON schema files over HTTP\n")
+	fmt.Fprintf(os.Stderr, "\nOptions:\n")
+	fmt.Fprintf(os.Stderr, "  -o string\n        Directory to export the schema files to (default: %s)\n", defaultSchemaDir)
+	fmt.Fprintf(os.Stderr, "  -addr string\n        Address to serve the schema files on (default: %s)\n", defaultSchemaAddress)
+	fmt.Fprintf(os.Stderr, "  -base-url string\n        URL the schema files are published under, for their $id (default for serve: http://<addr>/)\n")
+	fmt.Fprintf(os.Stderr, "  -log-level string\n        Log level: 'debug', 'info', 'warn', 'error', or 'off' (default: off)\n")
+	fmt.Fprintf(os.Stderr, "  -help\n        Show this help message\n")
+	fmt.Fprintf(os.Stderr, "\nReference the schema of the specification files with a header in the files, for yaml-language-server:\n")
+	fmt.Fprintf(os.Stderr, "  # yaml-language-server: $schema=<base-url>/%s\n", schemagen.ServiceSchemaFile)
+	fmt.Fprintf(os.Stderr, "\nExamples:\n")
+	fmt.Fprintf(os.Stderr, "  publicapis-gen schema export -o schemas -base-url https://example.com/schemas\n\n")
+	fmt.Fprintf(os.Stderr, "  # Serve the schemas locally while authoring specifications\n")
+	fmt.Fprintf(os.Stderr, "  publicapis-gen schema serve -addr localhost:8080\n")
+}
+
+func showMockUsage() {
+	fmt.Fprintf(os.Stderr, "%s\n\n", mockUsageDescription)
+	fmt.Fprintf(os.Stderr, "Usage: %s mock [options]\n\n", os.Args[0])
+	fmt.Fprintf(os.Stderr, "Options:\n")
+	fmt.Fprintf(os.Stderr, "  -spec string\n        Path to the specification file, or '-' to read from stdin\n")
+	fmt.Fprintf(os.Stderr, "  -port int\n        Port to serve the mock on (default: %d)\n", defaultMockPort)
+	fmt.Fprintf(os.Stderr, "  -log-level string\n        Log level: 'debug', 'info', 'warn', 'error', or 'off' (default: off)\n")
+	fmt.Fprintf(os.Stderr, "  -help\n        Show this help message\n")
+	fmt.Fprintf(os.Stderr, "\nEvery endpoint responds with the status code and an example response of the specification. Malformed\n")
+	fmt.Fprintf(os.Stderr, "parameters and bodies are rejected with 400 Bad Request like the generated server does. The mock also\n")
+	fmt.Fprintf(os.Stderr, "rejects bodies with missing required fields, including those required on the operation with required_on,\n")
+	fmt.Fprintf(os.Stderr, "or with invalid values with 422 Unprocessable Entity, which the generated server leaves to the service.\n")
+	fmt.Fprintf(os.Stderr, "Nothing is generated.\n")
+	fmt.Fprintf(os.Stderr, "\nExamples:\n")
+	fmt.Fprintf(os.Stderr, "  publicapis-gen mock -spec users-api.yaml -port 8080\n")
+}
+
+func showProxyUsage() {
+	fmt.Fprintf(os.Stderr, "%s\n\n", proxyUsageDescription)
+	fmt.Fprintf(os.Stderr, "Usage: %s proxy [options]\n\n", os.Args[0])
+	fmt.Fprintf(os.Stderr, "Options:\n")
+	fmt.Fprintf(os.Stderr, "  -spec string\n        Path to the specification file, or '-' to read from stdin\n")
+	fmt.Fprintf(os.Stderr, "  -target string\n        URL of the implementation to proxy, e.g. http://localhost:9000\n")
+	fmt.Fprintf(os.Stderr, "  -port int\n        Port to serve the proxy on (default: %d)\n", defaultMockPort)
+	fmt.Fprintf(os.Stderr, "  -report string\n        File to append the violations to, one JSON report per line\n")
+	fmt.Fprintf(os.Stderr, "  -log-level string\n        Log level: 'debug', 'info', 'warn', 'error', or 'off' (default: off)\n")
+	fmt.Fprintf(os.Stderr, "  -help\n        Show this help message\n")
+	fmt.Fprintf(os.Stderr, "\nEvery request and response is forwarded unchanged and validated against the specification, and the\n")
+	fmt.Fprintf(os.Stderr, "violations are printed to stderr, to catch drift between an implementation and its specification.\n")
+	fmt.Fprintf(os.Stderr, "\nExamples:\n")
+	fmt.Fprintf(os.Stderr, "  publicapis-gen proxy -spec users-api.yaml -target http://localhost:9000 -port 8080\n\n")
+	fmt.Fprintf(os.Stderr, "  # Record the violations of a staging environment\n")
+	fmt.Fprintf(os.Stderr, "  publicapis-gen proxy -spec users-api.yaml -target https://staging.example.com -report violations.jsonl\n")
+}
+
+func showCoverageUsage() {
+	fmt.Fprintf(os.Stderr, "%s\n\n", coverageUsageDescription)
+	fmt.Fprintf(os.Stderr, "Usage: %s coverage [options]\n\n", os.Args[0])
+	fmt.Fprintf(os.Stderr, "Options:\n")
+	fmt.Fprintf(os.Stderr, "  -spec string\n        Path to the specification file, or '-' to read from stdin\n")
+	fmt.Fprintf(os.Stderr, "  -impl string\n        Directory of the package implementing the API interfaces of the generated server\n")
+	fmt.Fprintf(os.Stderr, "  -tests string\n        Directory of the package with the tests of the endpoints, such as the generated tests\n")
+	fmt.Fprintf(os.Stderr, "  -format string\n        Report format: 'markdown' or 'json' (default: markdown)\n")
+	fmt.Fprintf(os.Stderr, "  -o string\n        Output file for the report, or '-' to write to stdout (default: -)\n")
+	fmt.Fprintf(os.Stderr, "  -log-level string\n        Log level: 'debug', 'info', 'warn', 'error', or 'off' (default: off)\n")
+	fmt.Fprintf(os.Stderr, "  -help\n        Show this help message\n")
+	fmt.Fprintf(os.Stderr, "\nAn endpoint is implemented by a method with its name in the -impl package, on a receiver type whose name\n")
+	fmt.Fprintf(os.Stderr, "contains the name of the resource when several resources have the endpoint name, and tested by a test\n")
+	fmt.Fprintf(os.Stderr, "function named after the resource and the endpoint in the -tests package, such as TestUsersGet.\n")
+	fmt.Fprintf(os.Stderr, "\nExamples:\n")
+	fmt.Fprintf(os.Stderr, "  publicapis-gen coverage -spec users-api.yaml -impl internal/users -tests dist\n\n")
+	fmt.Fprintf(os.Stderr, "  # For dashboards\n")
+	fmt.Fprintf(os.Stderr, "  publicapis-gen coverage -spec users-api.yaml -impl internal/users -tests dist -format json -o coverage.json\n")
+}
+
+func showLintUsage() {
+	fmt.Fprintf(os.Stderr, "%s\n\n", lintUsageDescription)
+	fmt.Fprintf(os.Stderr, "Usage: %s lint [options] <file>...\n\n", os.Args[0])
+	fmt.Fprintf(os.Stderr, "Options:\n")
+	fmt.Fprintf(os.Stderr, "  -log-level string\n        Log level: 'debug', 'info', 'warn', 'error', or 'off' (default: off)\n")
+	fmt.Fprintf(os.Stderr, "  -help\n        Show this help message\n")
+	fmt.Fprintf(os.Stderr, "\nAn enum or object is unused when no resource, endpoint, response header, parameter group or used object\n")
+	fmt.Fprintf(os.Stderr, "references it. The enums and objects of shared libraries are not reported, since other services may use them.\n")
+	fmt.Fprintf(os.Stderr, "Generate with -prune, or set prune in a job, to leave them out of the generated artifacts.\n")
+	fmt.Fprintf(os.Stderr, "\nExamples:\n")
+	fmt.Fprintf(os.Stderr, "  publicapis-gen lint specs/*.yaml\n")
 }
 
 func runGenerateCommand(ctx context.Context, args []string) error {
@@ -216,6 +835,13 @@ func runGenerateCommand(ctx context.Context, args []string) error {
 	// Parse command line flags for generate command
 	var (
 		configFlag   = generateFlags.String(configFileFlag, "", "Path to YAML config file containing multiple jobs")
+		specPathFlag = generateFlags.String(specFlag, "", "Path to a single specification file, or '-' to read from stdin")
+		modeNameFlag = generateFlags.String(modeFlag, "", "Artifact to generate with -spec")
+		outputFile   = generateFlags.String(outputFlag, stdioPath, "Output file for -spec, or '-' to write to stdout")
+		onlyNames    = generateFlags.String(onlyFlag, "", "Comma-separated artifacts to write with -config")
+		forceRun     = generateFlags.Bool(forceFlag, false, "Regenerate the jobs with -config whose inputs have not changed")
+		resourceName = generateFlags.String(resourceFlag, "", "Only run the jobs with -config whose specification defines this resource")
+		prune        = generateFlags.Bool(pruneFlag, false, "Leave the enums and objects that nothing references out of the generated artifacts")
 		logLevelFlag = generateFlags.String("log-level", logLevelOff, "Log level: 'debug', 'info', 'warn', 'error', or 'off' (default: off)")
 		helpFlag     = generateFlags.Bool("help", false, "Show help message")
 	)
@@ -224,6 +850,11 @@ func runGenerateCommand(ctx context.Context, args []string) error {
 		return err
 	}
 
+	only, err := parseOnlyFlag(*onlyNames)
+	if err != nil {
+		return err
+	}
+
 	// Configure logging
 	if err := configureLogging(*logLevelFlag); err != nil {
 		return fmt.Errorf("invalid log level: %w", err)
@@ -235,6 +866,17 @@ func runGenerateCommand(ctx context.Context, args []string) error {
 		return nil
 	}
 
+	// Generate a single artifact when a specification is given directly
+	if *specPathFlag != "" {
+		if *configFlag != "" {
+			return fmt.Errorf("%s: %s", errorInvalidConfig, errorSpecAndConfig)
+		}
+		if len(only) > 0 || *resourceName != "" || *forceRun {
+			return fmt.Errorf("%s: %s", errorInvalidConfig, errorSpecAndSelection)
+		}
+		return runSingleSpecMode(ctx, *specPathFlag, *modeNameFlag, *outputFile, *prune, os.Stdin, os.Stdout)
+	}
+
 	// Determine config file path
 	configPath := *configFlag
 	if configPath == "" {
@@ -250,7 +892,25 @@ func runGenerateCommand(ctx context.Context, args []string) error {
 		}
 	}
 
-	return runConfigMode(ctx, configPath)
+	return runConfigMode(ctx, configPath, generateOptions{Only: only, Resource: *resourceName, Force: *forceRun, Prune: *prune})
+}
+
+// parseOnlyFlag parses the comma-separated artifacts of the -only flag, returning nil for an empty value.
+func parseOnlyFlag(value string) ([]string, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+
+	var artifacts []string
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		if !slices.Contains(onlyArtifacts, name) {
+			return nil, fmt.Errorf("%s: '%s', expected one of: %s", errorInvalidOnly, name, strings.Join(onlyArtifacts, ", "))
+		}
+		artifacts = append(artifacts, name)
+	}
+
+	return artifacts, nil
 }
 
 func runDiffCommand(ctx context.Context, args []string) error {
@@ -262,6 +922,9 @@ func runDiffCommand(ctx context.Context, args []string) error {
 	var (
 		configFlag   = diffFlags.String(configFileFlag, "", "Path to YAML config file containing multiple jobs")
 		logLevelFlag = diffFlags.String("log-level", logLevelOff, "Log level: 'debug', 'info', 'warn', 'error', or 'off' (default: off)")
+		quiet        = diffFlags.Bool(quietFlag, false, "Only report differences through the exit code")
+		report       = diffFlags.String(reportFlag, reportFormatText, "Report format: 'text' or 'json'")
+		strict       = diffFlags.Bool(strictFlag, false, "Compare JSON and YAML files byte by byte instead of semantically")
 		helpFlag     = diffFlags.Bool("help", false, "Show help message")
 	)
 
@@ -295,63 +958,1002 @@ func runDiffCommand(ctx context.Context, args []string) error {
 		}
 	}
 
-	return runDiffMode(ctx, configPath)
+	return runDiffMode(ctx, configPath, diffOptions{Quiet: *quiet, Report: *report, Strict: *strict})
 }
 
-// runConfigMode processes jobs from a config file
-func runConfigMode(ctx context.Context, configPath string) error {
-	// Parse config file
-	config, err := parseConfigFile(configPath)
-	if err != nil {
+func runFmtCommand(ctx context.Context, args []string, stdout io.Writer) error {
+	// Create a new FlagSet for the fmt command
+	fmtFlags := flag.NewFlagSet(commandFmt, flag.ContinueOnError)
+	fmtFlags.Usage = showFmtUsage
+
+	// Parse command line flags for fmt command
+	var (
+		check        = fmtFlags.Bool(checkFlag, false, "List files that are not formatted instead of rewriting them")
+		logLevelFlag = fmtFlags.String("log-level", logLevelOff, "Log level: 'debug', 'info', 'warn', 'error', or 'off' (default: off)")
+		helpFlag     = fmtFlags.Bool("help", false, "Show help message")
+	)
+
+	if err := fmtFlags.Parse(args); err != nil {
 		return err
 	}
 
-	slog.InfoContext(ctx, "Successfully parsed config file", logKeyFile, configPath)
-
-	// Process each job in the config
-	for i, job := range config {
-		slog.InfoContext(ctx, "Processing job", "job_index", i+1, "specification", job.Specification)
+	// Configure logging
+	if err := configureLogging(*logLevelFlag); err != nil {
+		return fmt.Errorf("invalid log level: %w", err)
+	}
 
-		if err := processJob(ctx, job); err != nil {
-			return fmt.Errorf("failed to process job %d (spec: %s): %w", i+1, job.Specification, err)
-		}
+	// Show help if requested
+	if *helpFlag {
+		showFmtUsage()
+		return nil
 	}
 
-	slog.InfoContext(ctx, "Successfully processed all jobs", "total_jobs", len(config))
-	fmt.Printf("Successfully processed %d jobs from config file: %s\n", len(config), configPath)
+	if fmtFlags.NArg() == 0 {
+		showFmtUsage()
+		return fmt.Errorf("%s: at least one file is required", errorNoFiles)
+	}
 
-	return nil
+	return rewriteSpecificationFiles(ctx, fmtFlags.Args(), *check, stdout, formatRewrite)
 }
 
-// findDefaultConfigFile searches for default config files in the current directory
-// Returns the path to the first found config file, or empty string if none found
-func findDefaultConfigFile() string {
-	// Check for publicapis.yaml first, then publicapis.yml
-	candidates := []string{defaultConfigYAML, defaultConfigYML}
-
-	for _, candidate := range candidates {
-		if _, err := os.Stat(candidate); err == nil {
-			return candidate
-		}
-	}
+func runMigrateCommand(ctx context.Context, args []string, stdout io.Writer) error {
+	// Create a new FlagSet for the migrate command
+	migrateFlags := flag.NewFlagSet(commandMigrate, flag.ContinueOnError)
+	migrateFlags.Usage = showMigrateUsage
 
-	return ""
-}
+	// Parse command line flags for migrate command
+	var (
+		check        = migrateFlags.Bool(checkFlag, false, "List files that are not on the current schema version instead of rewriting them")
+		logLevelFlag = migrateFlags.String("log-level", logLevelOff, "Log level: 'debug', 'info', 'warn', 'error', or 'off' (default: off)")
+		helpFlag     = migrateFlags.Bool("help", false, "Show help message")
+	)
 
-// parseConfigFile reads and parses a YAML config file
-func parseConfigFile(configPath string) (Config, error) {
-	// Check if file exists
-	if _, err := os.Stat(configPath); err != nil {
-		if os.IsNotExist(err) {
-			return nil, fmt.Errorf("%s: config file does not exist: %s", errorInvalidConfig, configPath)
-		}
-		return nil, fmt.Errorf("%s: cannot access config file: %w", errorInvalidConfig, err)
+	if err := migrateFlags.Parse(args); err != nil {
+		return err
 	}
 
-	// Read file content
-	data, err := os.ReadFile(configPath)
-	if err != nil {
-		return nil, fmt.Errorf("%s: failed to read config file: %w", errorConfigParsing, err)
+	// Configure logging
+	if err := configureLogging(*logLevelFlag); err != nil {
+		return fmt.Errorf("invalid log level: %w", err)
+	}
+
+	// Show help if requested
+	if *helpFlag {
+		showMigrateUsage()
+		return nil
+	}
+
+	if migrateFlags.NArg() == 0 {
+		showMigrateUsage()
+		return fmt.Errorf("%s: at least one file is required", errorNoFiles)
+	}
+
+	return rewriteSpecificationFiles(ctx, migrateFlags.Args(), *check, stdout, migrateRewrite)
+}
+
+func runChangelogCommand(ctx context.Context, args []string, stdout io.Writer) error {
+	// Create a new FlagSet for the changelog command
+	changelogFlags := flag.NewFlagSet(commandChangelog, flag.ContinueOnError)
+	changelogFlags.Usage = showChangelogUsage
+
+	// Parse command line flags for changelog command
+	var (
+		configFlag   = changelogFlags.String(configFileFlag, "", "Path to YAML config file containing multiple jobs")
+		outputPath   = changelogFlags.String(outputFlag, stdioPath, "Output file for the changelog, or '-' to write to stdout")
+		logLevelFlag = changelogFlags.String("log-level", logLevelOff, "Log level: 'debug', 'info', 'warn', 'error', or 'off' (default: off)")
+		helpFlag     = changelogFlags.Bool("help", false, "Show help message")
+	)
+
+	if err := changelogFlags.Parse(args); err != nil {
+		return err
+	}
+
+	// Configure logging
+	if err := configureLogging(*logLevelFlag); err != nil {
+		return fmt.Errorf("invalid log level: %w", err)
+	}
+
+	// Show help if requested
+	if *helpFlag {
+		showChangelogUsage()
+		return nil
+	}
+
+	// Determine config file path
+	configPath := *configFlag
+	if configPath == "" {
+		// Try to find default config file
+		defaultConfigPath := findDefaultConfigFile()
+		if defaultConfigPath != "" {
+			slog.InfoContext(ctx, "Using default config file", logKeyFile, defaultConfigPath)
+			configPath = defaultConfigPath
+		} else {
+			// No default config file found, require explicit configuration
+			showChangelogUsage()
+			return fmt.Errorf("%s: config file is required", errorInvalidConfig)
+		}
+	}
+
+	config, err := parseConfigFile(configPath)
+	if err != nil {
+		return err
+	}
+
+	changelog, err := buildChangelog(ctx, config)
+	if err != nil {
+		return err
+	}
+
+	if *outputPath == stdioPath {
+		_, err := stdout.Write(changelog)
+		return err
+	}
+
+	if err := os.WriteFile(*outputPath, changelog, 0644); err != nil {
+		return fmt.Errorf("%s: %w", errorFileWrite, err)
+	}
+
+	slog.InfoContext(ctx, "Changelog written", logKeyFile, *outputPath)
+
+	return nil
+}
+
+func runSchemaCommand(ctx context.Context, args []string, stdout io.Writer) error {
+	if len(args) == 0 {
+		showSchemaUsage()
+		return fmt.Errorf("%s: must specify '%s' or '%s'", errorMissingCommand, subcommandExport, subcommandServe)
+	}
+
+	subcommand := args[0]
+	if subcommand == "-help" || subcommand == "--help" || subcommand == "-h" {
+		showSchemaUsage()
+		return nil
+	}
+	if subcommand != subcommandExport && subcommand != subcommandServe {
+		showSchemaUsage()
+		return fmt.Errorf("%s: unknown schema subcommand '%s'", errorInvalidSubcommand, subcommand)
+	}
+
+	// Create a new FlagSet for the schema command
+	schemaFlags := flag.NewFlagSet(commandSchema+" "+subcommand, flag.ContinueOnError)
+	schemaFlags.Usage = showSchemaUsage
+
+	// Parse command line flags for schema command
+	var (
+		outputDir    = schemaFlags.String(outputFlag, defaultSchemaDir, "Directory to export the schema files to")
+		address      = schemaFlags.String("addr", defaultSchemaAddress, "Address to serve the schema files on")
+		baseURL      = schemaFlags.String(baseURLFlag, "", "URL the schema files are published under, for their $id")
+		logLevelFlag = schemaFlags.String("log-level", logLevelOff, "Log level: 'debug', 'info', 'warn', 'error', or 'off' (default: off)")
+		helpFlag     = schemaFlags.Bool("help", false, "Show help message")
+	)
+
+	if err := schemaFlags.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	// Configure logging
+	if err := configureLogging(*logLevelFlag); err != nil {
+		return fmt.Errorf("invalid log level: %w", err)
+	}
+
+	// Show help if requested
+	if *helpFlag {
+		showSchemaUsage()
+		return nil
+	}
+
+	if subcommand == subcommandServe {
+		if *baseURL == "" {
+			*baseURL = "http://" + *address
+		}
+		return serveSchemas(ctx, *address, strings.TrimSuffix(*baseURL, "/")+"/")
+	}
+
+	return exportSchemas(ctx, *outputDir, *baseURL, stdout)
+}
+
+// exportSchemas writes the schema file of every specification type to the directory, and the header that
+// references the schema of the specification files to stdout.
+func exportSchemas(ctx context.Context, outputDir, baseURL string, stdout io.Writer) error {
+	files, err := schemagen.GenerateSchemaFiles(baseURL)
+	if err != nil {
+		return fmt.Errorf("failed to generate schemas: %w", err)
+	}
+
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("%s: %w", errorFileWrite, err)
+	}
+
+	for _, fileName := range slices.Sorted(maps.Keys(files)) {
+		filePath := filepath.Join(outputDir, fileName)
+		if err := os.WriteFile(filePath, files[fileName], 0644); err != nil {
+			return fmt.Errorf("%s: %w", errorFileWrite, err)
+		}
+		slog.InfoContext(ctx, "Schema written", logKeyFile, filePath)
+	}
+
+	// Without a base URL, the header references the exported file by its path
+	schemaURL := filepath.ToSlash(filepath.Join(outputDir, schemagen.ServiceSchemaFile))
+	if baseURL != "" {
+		schemaURL = strings.TrimSuffix(baseURL, "/") + "/" + schemagen.ServiceSchemaFile
+	}
+
+	fmt.Fprintf(stdout, "Exported %d schema files to %s\n", len(files), outputDir)
+	fmt.Fprintf(stdout, "Add this header to the specification files for autocomplete and validation in editors:\n")
+	fmt.Fprintf(stdout, "# yaml-language-server: $schema=%s\n", schemaURL)
+
+	return nil
+}
+
+// serveSchemas serves the schema file of every specification type on the address, until the context is done.
+func serveSchemas(ctx context.Context, address, baseURL string) error {
+	files, err := schemagen.GenerateSchemaFiles(baseURL)
+	if err != nil {
+		return fmt.Errorf("failed to generate schemas: %w", err)
+	}
+
+	server := &http.Server{Addr: address, Handler: newSchemaHandler(files), ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+		_ = server.Shutdown(context.Background())
+	}()
+
+	fmt.Fprintf(os.Stderr, "Serving schemas on %s\n# yaml-language-server: $schema=%s%s\n", address, baseURL, schemagen.ServiceSchemaFile)
+
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to serve schemas: %w", err)
+	}
+
+	return nil
+}
+
+func runMockCommand(ctx context.Context, args []string, stdin io.Reader) error {
+	// Create a new FlagSet for the mock command
+	mockFlags := flag.NewFlagSet(commandMock, flag.ContinueOnError)
+	mockFlags.Usage = showMockUsage
+
+	// Parse command line flags for mock command
+	var (
+		specPathFlag = mockFlags.String(specFlag, "", "Path to the specification file, or '-' to read from stdin")
+		port         = mockFlags.Int(portFlag, defaultMockPort, "Port to serve the mock on")
+		logLevelFlag = mockFlags.String("log-level", logLevelOff, "Log level: 'debug', 'info', 'warn', 'error', or 'off' (default: off)")
+		helpFlag     = mockFlags.Bool("help", false, "Show help message")
+	)
+
+	if err := mockFlags.Parse(args); err != nil {
+		return err
+	}
+
+	// Configure logging
+	if err := configureLogging(*logLevelFlag); err != nil {
+		return fmt.Errorf("invalid log level: %w", err)
+	}
+
+	// Show help if requested
+	if *helpFlag {
+		showMockUsage()
+		return nil
+	}
+
+	if *specPathFlag == "" {
+		showMockUsage()
+		return fmt.Errorf("%s: -%s is required", errorMissingSpec, specFlag)
+	}
+
+	if *port < 1 || *port > 65535 {
+		return fmt.Errorf("%s: %d, expected 1-65535", errorInvalidPort, *port)
+	}
+
+	service, _, err := readSpecification(*specPathFlag, stdin)
+	if err != nil {
+		return err
+	}
+
+	handler, err := mockserver.NewHandler(service)
+	if err != nil {
+		return fmt.Errorf("failed to create mock: %w", err)
+	}
+
+	return serveMock(ctx, ":"+strconv.Itoa(*port), handler)
+}
+
+// serveMock serves the mock on the address until the context is done, logging the requests.
+func serveMock(ctx context.Context, address string, handler http.Handler) error {
+	logged := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		slog.InfoContext(r.Context(), "Mock request", logKeyMethod, r.Method, logKeyPath, r.URL.Path)
+		handler.ServeHTTP(w, r)
+	})
+
+	server := &http.Server{Addr: address, Handler: logged, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+		_ = server.Shutdown(context.Background())
+	}()
+
+	fmt.Fprintf(os.Stderr, "Serving the mock on %s\n", address)
+
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to serve the mock: %w", err)
+	}
+
+	return nil
+}
+
+func runProxyCommand(ctx context.Context, args []string, stdin io.Reader) error {
+	// Create a new FlagSet for the proxy command
+	proxyFlags := flag.NewFlagSet(commandProxy, flag.ContinueOnError)
+	proxyFlags.Usage = showProxyUsage
+
+	// Parse command line flags for proxy command
+	var (
+		specPathFlag = proxyFlags.String(specFlag, "", "Path to the specification file, or '-' to read from stdin")
+		targetURL    = proxyFlags.String(targetFlag, "", "URL of the implementation to proxy")
+		port         = proxyFlags.Int(portFlag, defaultMockPort, "Port to serve the proxy on")
+		reportPath   = proxyFlags.String(reportFlag, "", "File to append the violations to, one JSON report per line")
+		logLevelFlag = proxyFlags.String("log-level", logLevelOff, "Log level: 'debug', 'info', 'warn', 'error', or 'off' (default: off)")
+		helpFlag     = proxyFlags.Bool("help", false, "Show help message")
+	)
+
+	if err := proxyFlags.Parse(args); err != nil {
+		return err
+	}
+
+	// Configure logging
+	if err := configureLogging(*logLevelFlag); err != nil {
+		return fmt.Errorf("invalid log level: %w", err)
+	}
+
+	// Show help if requested
+	if *helpFlag {
+		showProxyUsage()
+		return nil
+	}
+
+	if *specPathFlag == "" {
+		showProxyUsage()
+		return fmt.Errorf("%s: -%s is required", errorMissingSpec, specFlag)
+	}
+
+	if *targetURL == "" {
+		showProxyUsage()
+		return fmt.Errorf("%s: -%s is required", errorMissingTarget, targetFlag)
+	}
+
+	target, err := url.Parse(*targetURL)
+	if err != nil || target.Scheme == "" || target.Host == "" {
+		return fmt.Errorf("%s: '%s' must be an absolute URL, e.g. http://localhost:9000", errorInvalidTarget, *targetURL)
+	}
+
+	if *port < 1 || *port > 65535 {
+		return fmt.Errorf("%s: %d, expected 1-65535", errorInvalidPort, *port)
+	}
+
+	service, _, err := readSpecification(*specPathFlag, stdin)
+	if err != nil {
+		return err
+	}
+
+	var reportFile io.Writer
+	if *reportPath != "" {
+		file, err := os.OpenFile(*reportPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+		if err != nil {
+			return fmt.Errorf("failed to open report file: %w", err)
+		}
+		defer file.Close()
+		reportFile = file
+	}
+
+	handler, err := conformance.NewProxy(service, target, newViolationReporter(os.Stderr, reportFile))
+	if err != nil {
+		return fmt.Errorf("failed to create proxy: %w", err)
+	}
+
+	return serveProxy(ctx, ":"+strconv.Itoa(*port), target, handler)
+}
+
+// newViolationReporter returns a function printing the violations of a report to w, a line per violation, and
+// appending the report as a line of JSON to the report file when it is set.
+func newViolationReporter(w io.Writer, reportFile io.Writer) func(conformance.Report) {
+	var mu sync.Mutex
+	return func(report conformance.Report) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		subject := report.Method + " " + report.Path
+		if report.Endpoint != "" {
+			subject += " (" + report.Endpoint + ")"
+		}
+		if report.StatusCode != 0 {
+			subject += " " + strconv.Itoa(report.StatusCode)
+		}
+
+		for _, violation := range report.Violations {
+			location := violation.Code
+			if violation.Path != "" {
+				location = violation.Path + ": " + location
+			}
+			fmt.Fprintf(w, "%s: %s: %s: %s\n", subject, report.Direction, location, violation.Message)
+		}
+
+		if reportFile != nil {
+			if err := json.NewEncoder(reportFile).Encode(report); err != nil {
+				slog.Error("Failed to write report", logKeyError, err)
+			}
+		}
+	}
+}
+
+func runCoverageCommand(ctx context.Context, args []string, stdin io.Reader, stdout io.Writer) error {
+	// Create a new FlagSet for the coverage command
+	coverageFlags := flag.NewFlagSet(commandCoverage, flag.ContinueOnError)
+	coverageFlags.Usage = showCoverageUsage
+
+	// Parse command line flags for coverage command
+	var (
+		specPathFlag = coverageFlags.String(specFlag, "", "Path to the specification file, or '-' to read from stdin")
+		implDir      = coverageFlags.String(implFlag, "", "Directory of the package implementing the API interfaces of the generated server")
+		testsDir     = coverageFlags.String(testsFlag, "", "Directory of the package with the tests of the endpoints")
+		format       = coverageFlags.String(formatFlag, reportFormatMarkdown, "Report format: 'markdown' or 'json'")
+		outputPath   = coverageFlags.String(outputFlag, stdioPath, "Output file for the report, or '-' to write to stdout")
+		logLevelFlag = coverageFlags.String("log-level", logLevelOff, "Log level: 'debug', 'info', 'warn', 'error', or 'off' (default: off)")
+		helpFlag     = coverageFlags.Bool("help", false, "Show help message")
+	)
+
+	if err := coverageFlags.Parse(args); err != nil {
+		return err
+	}
+
+	// Configure logging
+	if err := configureLogging(*logLevelFlag); err != nil {
+		return fmt.Errorf("invalid log level: %w", err)
+	}
+
+	// Show help if requested
+	if *helpFlag {
+		showCoverageUsage()
+		return nil
+	}
+
+	if *specPathFlag == "" {
+		showCoverageUsage()
+		return fmt.Errorf("%s: -%s is required", errorMissingSpec, specFlag)
+	}
+
+	if *implDir == "" || *testsDir == "" {
+		showCoverageUsage()
+		return fmt.Errorf("%s: -%s and -%s are required", errorMissingPackage, implFlag, testsFlag)
+	}
+
+	if *format != reportFormatMarkdown && *format != reportFormatJSON {
+		return fmt.Errorf("%s: '%s', expected '%s' or '%s'", errorInvalidReport, *format, reportFormatMarkdown, reportFormatJSON)
+	}
+
+	service, _, err := readSpecification(*specPathFlag, stdin)
+	if err != nil {
+		return err
+	}
+
+	implementation, err := coveragegen.ReadPackage(*implDir)
+	if err != nil {
+		return err
+	}
+
+	tests, err := coveragegen.ReadPackage(*testsDir)
+	if err != nil {
+		return err
+	}
+
+	report := coveragegen.NewReport(service, implementation, tests)
+
+	var buf bytes.Buffer
+	if *format == reportFormatJSON {
+		if err := coveragegen.GenerateJSON(&buf, report); err != nil {
+			return err
+		}
+	} else {
+		coveragegen.GenerateMarkdown(&buf, report)
+	}
+
+	if *outputPath == stdioPath {
+		_, err := stdout.Write(buf.Bytes())
+		return err
+	}
+
+	if err := os.WriteFile(*outputPath, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("%s: %w", errorFileWrite, err)
+	}
+
+	slog.InfoContext(ctx, "Coverage report written", logKeyFile, *outputPath)
+
+	return nil
+}
+
+func runLintCommand(ctx context.Context, args []string, stdout io.Writer) error {
+	// Create a new FlagSet for the lint command
+	lintFlags := flag.NewFlagSet(commandLint, flag.ContinueOnError)
+	lintFlags.Usage = showLintUsage
+
+	// Parse command line flags for lint command
+	var (
+		logLevelFlag = lintFlags.String("log-level", logLevelOff, "Log level: 'debug', 'info', 'warn', 'error', or 'off' (default: off)")
+		helpFlag     = lintFlags.Bool("help", false, "Show help message")
+	)
+
+	if err := lintFlags.Parse(args); err != nil {
+		return err
+	}
+
+	// Configure logging
+	if err := configureLogging(*logLevelFlag); err != nil {
+		return fmt.Errorf("invalid log level: %w", err)
+	}
+
+	// Show help if requested
+	if *helpFlag {
+		showLintUsage()
+		return nil
+	}
+
+	if lintFlags.NArg() == 0 {
+		showLintUsage()
+		return fmt.Errorf("%s: at least one file is required", errorNoFiles)
+	}
+
+	// Unused definitions are warnings, so only a specification that cannot be read fails the command
+	for _, path := range lintFlags.Args() {
+		service, err := readSpecificationFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read specification file '%s': %w", path, err)
+		}
+
+		for _, name := range service.GetUnusedEnums() {
+			fmt.Fprintf(stdout, "%s: warning: enum '%s' is not used\n", path, name)
+		}
+		for _, name := range service.GetUnusedObjects() {
+			fmt.Fprintf(stdout, "%s: warning: object '%s' is not used\n", path, name)
+		}
+
+		slog.InfoContext(ctx, "Linted specification file", logKeyFile, path)
+	}
+
+	return nil
+}
+
+// serveProxy serves the proxy on the address until the context is done, logging the requests.
+func serveProxy(ctx context.Context, address string, target *url.URL, handler http.Handler) error {
+	logged := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		slog.InfoContext(r.Context(), "Proxy request", logKeyMethod, r.Method, logKeyPath, r.URL.Path)
+		handler.ServeHTTP(w, r)
+	})
+
+	server := &http.Server{Addr: address, Handler: logged, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+		_ = server.Shutdown(context.Background())
+	}()
+
+	fmt.Fprintf(os.Stderr, "Serving the proxy to %s on %s\n", target, address)
+
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to serve the proxy: %w", err)
+	}
+
+	return nil
+}
+
+// newSchemaHandler returns a handler serving the schema files by their names, e.g. /service.schema.json.
+func newSchemaHandler(files map[string][]byte) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, ok := files[strings.TrimPrefix(r.URL.Path, "/")]
+		if !ok || (r.Method != http.MethodGet && r.Method != http.MethodHead) {
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Header().Set("Content-Type", contentTypeSchemaJSON)
+		_, _ = w.Write(data)
+	})
+}
+
+// buildChangelog returns the changelog of the OpenAPI document of every job with OpenAPI output, comparing the
+// generated document with its file on disk. The JSON document is compared when a job writes both formats, and
+// a document without a file on disk is new, so all of it is added.
+func buildChangelog(ctx context.Context, config Config) ([]byte, error) {
+	var buf bytes.Buffer
+	hasOpenAPIJobs := false
+
+	for i, job := range config {
+		if job.OpenAPIJSON == "" && job.OpenAPIYAML == "" {
+			continue
+		}
+		hasOpenAPIJobs = true
+
+		service, err := readSpecificationFile(job.Specification)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read specification file '%s': %w", job.Specification, err)
+		}
+
+		if job.Prune {
+			service = specification.PruneUnusedDefinitions(service)
+		}
+
+		// Resolve {service} and {version} placeholders now that the specification is parsed
+		job = job.withService(service)
+
+		openAPIOptions, err := job.openAPIOptions()
+		if err != nil {
+			return nil, err
+		}
+
+		path := job.OpenAPIJSON
+		generate := generateOpenAPIBytes
+		if path == "" {
+			path = job.OpenAPIYAML
+			generate = generateOpenAPIYAMLBytes
+		}
+
+		generated, err := generate(ctx, service, openAPIOptions)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate OpenAPI document of job %d (spec: %s): %w", i+1, job.Specification, err)
+		}
+
+		previous, err := os.ReadFile(path)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("%s: %w", errorFileRead, err)
+		}
+
+		if err := changeloggen.GenerateChangelog(&buf, previous, generated); err != nil {
+			return nil, fmt.Errorf("failed to compare '%s': %w", path, err)
+		}
+
+		slog.InfoContext(ctx, "Compared OpenAPI document", logKeyFile, path)
+	}
+
+	if !hasOpenAPIJobs {
+		return nil, fmt.Errorf("%s: the changelog compares the files of openapi_json and openapi_yaml", errorNoOpenAPIJobs)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// rewriteSpecificationFiles applies the rewrite to the specification files in place, or with check only lists
+// the files that would change and returns an error if there are any.
+func rewriteSpecificationFiles(ctx context.Context, paths []string, check bool, stdout io.Writer, rewrite specificationRewrite) error {
+	var pending []string
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("%s: %w", errorFileRead, err)
+		}
+
+		rewritten, err := rewrite.apply(data, filepath.Ext(path))
+		if err != nil {
+			return fmt.Errorf("failed to %s '%s': %w", rewrite.verb, path, err)
+		}
+
+		if bytes.Equal(data, rewritten) {
+			slog.InfoContext(ctx, "Specification file is up to date", logKeyFile, path)
+			continue
+		}
+
+		if check {
+			pending = append(pending, path)
+			fmt.Fprintln(stdout, path)
+			continue
+		}
+
+		if err := os.WriteFile(path, rewritten, 0644); err != nil {
+			return fmt.Errorf("%s: %w", errorFileWrite, err)
+		}
+
+		slog.InfoContext(ctx, rewrite.pastTense+" specification file", logKeyFile, path)
+		fmt.Fprintf(stdout, "%s: %s\n", rewrite.pastTense, path)
+	}
+
+	if len(pending) > 0 {
+		return fmt.Errorf("%s: %s", rewrite.errorPending, strings.Join(pending, ", "))
+	}
+
+	return nil
+}
+
+// runSingleSpecMode generates a single artifact from one specification without a config file.
+// A specPath of "-" reads the specification from stdin and an outputPath of "-" writes to stdout.
+func runSingleSpecMode(ctx context.Context, specPath, mode, outputPath string, prune bool, stdin io.Reader, stdout io.Writer) error {
+	if mode == "" {
+		return fmt.Errorf("%s: -%s is required when using -%s", errorMissingMode, modeFlag, specFlag)
+	}
+
+	service, specData, err := readSpecification(specPath, stdin)
+	if err != nil {
+		return err
+	}
+
+	if prune {
+		service = specification.PruneUnusedDefinitions(service)
+	}
+
+	outputData, err := generateArtifactBytes(ctx, service, mode)
+	if err != nil {
+		return err
+	}
+	outputData = newProvenance(specData).apply(modeFileExtension(mode), outputData)
+
+	if outputPath == stdioPath || outputPath == "" {
+		if _, err := stdout.Write(outputData); err != nil {
+			return fmt.Errorf("%s: %w", errorFileWrite, err)
+		}
+		return nil
+	}
+
+	if err := os.WriteFile(outputPath, outputData, 0644); err != nil {
+		return fmt.Errorf("%s: %w", errorFileWrite, err)
+	}
+
+	slog.InfoContext(ctx, "Successfully generated artifact", logKeyMode, mode, logKeyFile, outputPath)
+
+	return nil
+}
+
+// readSpecification reads a specification from a file, or from stdin when specPath is "-", and returns it with
+// its content, from which the provenance header is created. Specifications read from stdin are parsed as YAML,
+// which also accepts JSON input.
+func readSpecification(specPath string, stdin io.Reader) (*specification.Service, []byte, error) {
+	var data []byte
+	var err error
+	if specPath == stdioPath {
+		data, err = io.ReadAll(stdin)
+	} else {
+		data, err = os.ReadFile(specPath)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", errorFileRead, err)
+	}
+
+	fileExtension, dir := extYAML, ""
+	if specPath != stdioPath {
+		fileExtension, dir = strings.ToLower(filepath.Ext(specPath)), filepath.Dir(specPath)
+	}
+
+	service, err := specification.ParseServiceFromBytesInDir(data, fileExtension, dir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return service, data, nil
+}
+
+// modeFileExtension returns the file extension of the artifact generated by the mode.
+func modeFileExtension(mode string) string {
+	switch mode {
+	case modeOpenAPI, modeSchema, modeTerraform:
+		return extJSON
+	case modeOpenAPIYAML, modeOverlay, modeGateway:
+		return extYAML
+	case modeServer:
+		return extGo
+	default:
+		return ""
+	}
+}
+
+// generateArtifactBytes generates the artifact for the given mode and returns it as bytes.
+func generateArtifactBytes(ctx context.Context, service *specification.Service, mode string) ([]byte, error) {
+	switch mode {
+	case modeOpenAPI:
+		return generateOpenAPIBytes(ctx, service, openapigen.Options{})
+	case modeOpenAPIYAML:
+		return generateOpenAPIYAMLBytes(ctx, service, openapigen.Options{})
+	case modeSchema:
+		var buf bytes.Buffer
+		if err := schemagen.GenerateSchemas(&buf); err != nil {
+			return nil, fmt.Errorf("failed to generate schemas: %w", err)
+		}
+		return buf.Bytes(), nil
+	case modeOverlay:
+		outputData, err := specification.MarshalServiceYAML(service)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal specification to YAML: %w", err)
+		}
+		return outputData, nil
+	case modeServer:
+		var buf bytes.Buffer
+		if err := servergen.GenerateServer(&buf, service); err != nil {
+			return nil, fmt.Errorf("failed to generate server code: %w", err)
+		}
+		return buf.Bytes(), nil
+	case modeGateway:
+		var buf bytes.Buffer
+		if err := gatewaygen.GenerateRoutes(&buf, service); err != nil {
+			return nil, fmt.Errorf("failed to generate gateway routes: %w", err)
+		}
+		return buf.Bytes(), nil
+	case modeTerraform:
+		var buf bytes.Buffer
+		if err := terraformgen.GenerateSchema(&buf, service); err != nil {
+			return nil, fmt.Errorf("failed to generate Terraform schema: %w", err)
+		}
+		return buf.Bytes(), nil
+	default:
+		return nil, fmt.Errorf("%s: %s", errorInvalidMode, mode)
+	}
+}
+
+// runConfigMode processes jobs from a config file
+func runConfigMode(ctx context.Context, configPath string, options generateOptions) error {
+	// Parse config file
+	config, err := parseConfigFile(configPath)
+	if err != nil {
+		return err
+	}
+
+	slog.InfoContext(ctx, "Successfully parsed config file", logKeyFile, configPath)
+
+	// The cache is only used when all jobs are generated, a selection always regenerates the selected outputs
+	useCache := len(options.Only) == 0 && options.Resource == ""
+	cachePath := filepath.Join(filepath.Dir(configPath), cacheFileName)
+	cache := loadGenerationCache(ctx, cachePath)
+	updatedCache := generationCache{Jobs: make(map[string]cacheEntry)}
+
+	// Process each job in the config, skipping the jobs without selected outputs
+	processed, skipped := 0, 0
+	for i, job := range config {
+		job = job.withOnly(options.Only)
+		job.Prune = job.Prune || options.Prune
+		if len(job.getOutputPaths()) == 0 {
+			slog.InfoContext(ctx, "Skipping job without selected outputs", "job_index", i+1, "specification", job.Specification)
+			continue
+		}
+
+		if options.Resource != "" {
+			defined, err := definesResource(job.Specification, options.Resource)
+			if err != nil {
+				return fmt.Errorf("failed to process job %d (spec: %s): %w", i+1, job.Specification, err)
+			}
+			if !defined {
+				slog.InfoContext(ctx, "Skipping job without the resource", "job_index", i+1, "specification", job.Specification, logKeyResource, options.Resource)
+				continue
+			}
+		}
+
+		// Jobs with plugins are not cached, since their output depends on the plugin commands
+		var key, inputs string
+		if useCache && len(job.Plugins) == 0 {
+			key = job.cacheKey()
+			inputs, err = job.inputHash()
+			if err != nil {
+				return fmt.Errorf("failed to process job %d (spec: %s): %w", i+1, job.Specification, err)
+			}
+
+			if entry, ok := cache.Jobs[key]; ok && !options.Force && entry.isUpToDate(inputs) {
+				slog.InfoContext(ctx, "Skipping unchanged job", "job_index", i+1, "specification", job.Specification)
+				updatedCache.Jobs[key] = entry
+				skipped++
+				continue
+			}
+		}
+
+		slog.InfoContext(ctx, "Processing job", "job_index", i+1, "specification", job.Specification)
+
+		outputPaths, err := processJob(ctx, job)
+		if err != nil {
+			return fmt.Errorf("failed to process job %d (spec: %s): %w", i+1, job.Specification, err)
+		}
+		processed++
+
+		if key != "" {
+			updatedCache.Jobs[key] = cacheEntry{Inputs: inputs, Outputs: outputPaths}
+		}
+	}
+
+	if options.Resource != "" && processed == 0 {
+		return fmt.Errorf("%s: no job in '%s' defines '%s'", errorUnknownResource, configPath, options.Resource)
+	}
+
+	if useCache {
+		if err := saveGenerationCache(cachePath, updatedCache); err != nil {
+			return err
+		}
+	}
+
+	slog.InfoContext(ctx, "Successfully processed all jobs", "total_jobs", processed, "skipped_jobs", skipped)
+	fmt.Printf("Successfully processed %d jobs from config file: %s\n", processed, configPath)
+	if skipped > 0 {
+		fmt.Printf("Skipped %d unchanged jobs, use -%s to regenerate them\n", skipped, forceFlag)
+	}
+
+	return nil
+}
+
+// loadGenerationCache reads the cache file, returning an empty cache if it does not exist or cannot be read,
+// in which case all jobs are generated.
+func loadGenerationCache(ctx context.Context, cachePath string) generationCache {
+	cache := generationCache{Jobs: make(map[string]cacheEntry)}
+
+	data, err := os.ReadFile(cachePath)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			slog.WarnContext(ctx, "Ignoring unreadable cache file", logKeyFile, cachePath, logKeyError, err)
+		}
+		return cache
+	}
+
+	if err := json.Unmarshal(data, &cache); err != nil || cache.Jobs == nil {
+		slog.WarnContext(ctx, "Ignoring invalid cache file", logKeyFile, cachePath, logKeyError, err)
+		return generationCache{Jobs: make(map[string]cacheEntry)}
+	}
+
+	return cache
+}
+
+// saveGenerationCache writes the cache file.
+func saveGenerationCache(cachePath string, cache generationCache) error {
+	data, err := json.MarshalIndent(cache, "", "  ")
+	if err != nil {
+		return fmt.Errorf("%s: %w", errorCacheWrite, err)
+	}
+
+	if err := os.WriteFile(cachePath, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("%s: %w", errorCacheWrite, err)
+	}
+
+	return nil
+}
+
+// isUpToDate reports whether the cached job has the same inputs and all of its outputs still exist.
+func (e cacheEntry) isUpToDate(inputs string) bool {
+	if e.Inputs != inputs {
+		return false
+	}
+
+	for _, path := range e.Outputs {
+		if _, err := os.Stat(path); err != nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+// definesResource reports whether the specification file defines the resource.
+func definesResource(specPath, resourceName string) (bool, error) {
+	service, err := readSpecificationFile(specPath)
+	if err != nil {
+		return false, fmt.Errorf("failed to read specification file '%s': %w", specPath, err)
+	}
+
+	return service.GetResource(resourceName) != nil, nil
+}
+
+// findDefaultConfigFile searches for default config files in the current directory
+// Returns the path to the first found config file, or empty string if none found
+func findDefaultConfigFile() string {
+	// Check for publicapis.yaml first, then publicapis.yml
+	candidates := []string{defaultConfigYAML, defaultConfigYML}
+
+	for _, candidate := range candidates {
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate
+		}
+	}
+
+	return ""
+}
+
+// parseConfigFile reads and parses a YAML config file
+func parseConfigFile(configPath string) (Config, error) {
+	// Check if file exists
+	if _, err := os.Stat(configPath); err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%s: config file does not exist: %s", errorInvalidConfig, configPath)
+		}
+		return nil, fmt.Errorf("%s: cannot access config file: %w", errorInvalidConfig, err)
+	}
+
+	// Read file content
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to read config file: %w", errorConfigParsing, err)
 	}
 
 	// Parse YAML
@@ -360,83 +1962,835 @@ func parseConfigFile(configPath string) (Config, error) {
 		return nil, fmt.Errorf("%s: failed to parse YAML: %w", errorConfigParsing, err)
 	}
 
-	// Validate config
-	if len(config) == 0 {
-		return nil, fmt.Errorf("%s: config file must contain at least one job", errorInvalidConfig)
+	// Validate config
+	if len(config) == 0 {
+		return nil, fmt.Errorf("%s: config file must contain at least one job", errorInvalidConfig)
+	}
+
+	// Validate each job
+	for i, job := range config {
+		expandedJob, err := job.expandEnv()
+		if err != nil {
+			return nil, fmt.Errorf("%s: job %d: %w", errorInvalidConfig, i+1, err)
+		}
+		config[i] = expandedJob
+		job = expandedJob
+
+		if job.Specification == "" {
+			return nil, fmt.Errorf("%s: job %d is missing required 'specification' field", errorInvalidConfig, i+1)
+		}
+
+		if err := job.Extensions.Validate(); err != nil {
+			return nil, fmt.Errorf("%s: job %d: %w", errorInvalidConfig, i+1, err)
+		}
+
+		if job.ServerGo != "" && job.ServerDir != "" {
+			return nil, fmt.Errorf("%s: job %d: server_go and server_dir cannot be combined", errorInvalidConfig, i+1)
+		}
+
+		if job.TypesPackage != "" && job.StdlibTypes {
+			return nil, fmt.Errorf("%s: job %d: types_package and stdlib_types cannot be combined", errorInvalidConfig, i+1)
+		}
+
+		if job.TemplatesDir != "" {
+			if info, err := os.Stat(job.TemplatesDir); err != nil || !info.IsDir() {
+				return nil, fmt.Errorf("%s: job %d: templates_dir '%s' is not a directory", errorInvalidConfig, i+1, job.TemplatesDir)
+			}
+		}
+
+		if len(job.TerraformResources) > 0 && job.TerraformSchema == "" {
+			return nil, fmt.Errorf("%s: job %d: terraform_resources requires terraform_schema", errorInvalidConfig, i+1)
+		}
+
+		if job.OpenAPIOverlay != "" {
+			if job.OpenAPIJSON == "" && job.OpenAPIYAML == "" {
+				return nil, fmt.Errorf("%s: job %d: openapi_overlay requires openapi_json or openapi_yaml", errorInvalidConfig, i+1)
+			}
+
+			// The overlay is parsed up front, so that an invalid one fails before anything is generated
+			if _, err := job.openAPIOptions(); err != nil {
+				return nil, fmt.Errorf("%s: job %d: %w", errorInvalidConfig, i+1, err)
+			}
+		}
+
+		for _, plugin := range job.Plugins {
+			if err := plugin.Validate(); err != nil {
+				return nil, fmt.Errorf("%s: job %d: %w", errorInvalidConfig, i+1, err)
+			}
+		}
+
+		for _, sharedPackage := range job.SharedPackages {
+			if err := sharedPackage.Validate(); err != nil {
+				return nil, fmt.Errorf("%s: job %d: %w", errorInvalidConfig, i+1, err)
+			}
+		}
+
+		// Check if at least one output format is specified
+		if len(job.getOutputPaths()) == 0 {
+			return nil, fmt.Errorf("%s: job %d must specify at least one output format (openapi_json, openapi_yaml, schema_json, overlay_yaml, overlay_json, server_go, server_dir, gateway_routes, terraform_schema, plugins, shared_packages)", errorInvalidConfig, i+1)
+		}
+	}
+
+	// Expand glob patterns and directories in specification paths into one job per match
+	expanded, err := expandConfigJobs(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return expanded, nil
+}
+
+// expandConfigJobs expands jobs whose specification is a glob pattern or a directory into one job per
+// matching file, and substitutes the {name} placeholder in all output paths.
+func expandConfigJobs(config Config) (Config, error) {
+	var expanded Config
+
+	for i, job := range config {
+		if !hasGlobPattern(job.Specification) && !isDirectory(job.Specification) {
+			expanded = append(expanded, job.withName(specificationName(job.Specification)))
+			continue
+		}
+
+		matches, err := matchSpecificationFiles(job.Specification)
+		if err != nil {
+			return nil, fmt.Errorf("%s: job %d: %s '%s': %w", errorInvalidConfig, i+1, errorGlobExpansion, job.Specification, err)
+		}
+
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("%s: job %d: %s '%s': no files match", errorInvalidConfig, i+1, errorGlobExpansion, job.Specification)
+		}
+
+		if len(matches) > 1 && !job.hasNamePlaceholder() {
+			return nil, fmt.Errorf("%s: job %d: pattern '%s' matches %d files but output paths do not contain %s", errorInvalidConfig, i+1, job.Specification, len(matches), placeholderName)
+		}
+
+		for _, match := range matches {
+			expandedJob := job.withName(specificationName(match))
+			expandedJob.Specification = match
+			expanded = append(expanded, expandedJob)
+		}
+	}
+
+	if err := validateUniqueOutputs(expanded); err != nil {
+		return nil, err
+	}
+
+	return expanded, nil
+}
+
+// matchSpecificationFiles returns the files matching the glob pattern, or the YAML specification files directly in
+// the directory, in lexical order. The JSON files of a directory are left out, since they are usually generated.
+func matchSpecificationFiles(specPath string) ([]string, error) {
+	if !isDirectory(specPath) {
+		return filepath.Glob(specPath)
 	}
 
-	// Validate each job
-	for i, job := range config {
-		if job.Specification == "" {
-			return nil, fmt.Errorf("%s: job %d is missing required 'specification' field", errorInvalidConfig, i+1)
+	var matches []string
+	for _, ext := range []string{extYAML, extYML} {
+		extMatches, err := filepath.Glob(filepath.Join(globEscape(specPath), "*"+ext))
+		if err != nil {
+			return nil, err
 		}
+		matches = append(matches, extMatches...)
+	}
+	slices.Sort(matches)
 
-		// Check if at least one output format is specified
-		if job.OpenAPIJSON == "" && job.OpenAPIYAML == "" && job.SchemaJSON == "" && job.OverlayYAML == "" && job.OverlayJSON == "" && job.ServerGo == "" {
-			return nil, fmt.Errorf("%s: job %d must specify at least one output format (openapi_json, openapi_yaml, schema_json, overlay_yaml, overlay_json, server_go)", errorInvalidConfig, i+1)
+	return matches, nil
+}
+
+// isDirectory reports whether the path is an existing directory.
+func isDirectory(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+// globEscape escapes the glob meta characters of a path, so that it is matched literally.
+func globEscape(path string) string {
+	var escaped strings.Builder
+	for _, r := range path {
+		if strings.ContainsRune(globMetaCharacters, r) {
+			escaped.WriteRune('\\')
+		}
+		escaped.WriteRune(r)
+	}
+	return escaped.String()
+}
+
+// hasGlobPattern reports whether the path contains glob meta characters.
+func hasGlobPattern(path string) bool {
+	return strings.ContainsAny(path, globMetaCharacters)
+}
+
+// specificationName returns the name used for the {name} placeholder, which is the kebab-case name of the service
+// like {service}, or the file name of the specification without its extension if the file cannot be read or has no
+// name. Only the name is read, the specification is parsed and validated when the job runs.
+func specificationName(specPath string) string {
+	if data, err := os.ReadFile(specPath); err == nil {
+		var named struct {
+			Name string `yaml:"name"`
+		}
+		if yaml.Unmarshal(data, &named) == nil && named.Name != "" {
+			return specification.Service{Name: named.Name}.PathName()
+		}
+	}
+
+	base := filepath.Base(specPath)
+	return strings.TrimSuffix(base, filepath.Ext(base))
+}
+
+// openAPIOptions returns the options of the OpenAPI documents generated by the job, with the overlay of its
+// openapi_overlay file.
+func (j Job) openAPIOptions() (openapigen.Options, error) {
+	options := openapigen.Options{
+		Extensions:                     j.Extensions,
+		ExcludeFeatureFlaggedEndpoints: j.ExcludeFeatureFlagged,
+	}
+
+	if j.OpenAPIOverlay != "" {
+		data, err := os.ReadFile(j.OpenAPIOverlay)
+		if err != nil {
+			return openapigen.Options{}, fmt.Errorf("%s: %w", errorFileRead, err)
+		}
+
+		overlay, err := openapigen.ParseOverlay(data)
+		if err != nil {
+			return openapigen.Options{}, fmt.Errorf("openapi_overlay '%s': %w", j.OpenAPIOverlay, err)
+		}
+		options.Overlay = overlay
+	}
+
+	return options, nil
+}
+
+// serverOptions returns the servergen options of the job, with the templates of its templates directory.
+func (j Job) serverOptions() servergen.Options {
+	options := servergen.Options{
+		Types: servergen.Types{
+			Package:  j.TypesPackage,
+			Stdlib:   j.StdlibTypes,
+			TypedIDs: j.TypedIDs,
+		},
+		Lifecycle: j.ServerLifecycle,
+	}
+	if j.TemplatesDir != "" {
+		options.Templates = os.DirFS(j.TemplatesDir)
+	}
+	for _, sharedPackage := range j.SharedPackages {
+		if options.SharedPackages == nil {
+			options.SharedPackages = make(map[string]string)
+		}
+		options.SharedPackages[sharedPackage.Library] = sharedPackage.Import
+	}
+
+	return options
+}
+
+// testOptions returns the testgen options of the job, matching the types of its server.
+func (j Job) testOptions() testgen.Options {
+	return testgen.Options{
+		Types:       j.serverOptions().Types,
+		Benchmarks:  j.Benchmarks,
+		GoldenFiles: j.GoldenFiles,
+	}
+}
+
+// terraformOptions returns the terraformgen options of the job.
+func (j Job) terraformOptions() terraformgen.Options {
+	return terraformgen.Options{Resources: j.TerraformResources}
+}
+
+// getOutputPaths returns all non-empty output paths of the job.
+func (j Job) getOutputPaths() []string {
+	var paths []string
+	for _, path := range []string{j.OpenAPIJSON, j.OpenAPIYAML, j.SchemaJSON, j.OverlayYAML, j.OverlayJSON, j.ServerGo, j.ServerDir, j.GatewayRoutes, j.TerraformSchema} {
+		if path != "" {
+			paths = append(paths, path)
+		}
+	}
+	for _, plugin := range j.Plugins {
+		if plugin.Output != "" {
+			paths = append(paths, plugin.Output)
+		}
+	}
+	for _, sharedPackage := range j.SharedPackages {
+		if sharedPackage.Output != "" {
+			paths = append(paths, sharedPackage.Output)
+		}
+	}
+	return paths
+}
+
+// cacheKey returns the key of the job in the cache: its specification and output paths as written in the config.
+func (j Job) cacheKey() string {
+	return j.Specification + " -> " + strings.Join(j.getOutputPaths(), ", ")
+}
+
+// inputHash returns a hash of everything the output of the job depends on: the specification, the version of
+// publicapis-gen, the job options, the shared libraries, the description files, the OpenAPI overlay and the templates
+// of the templates directory.
+func (j Job) inputHash() (string, error) {
+	hash := sha256.New()
+
+	specData, err := os.ReadFile(j.Specification)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", errorFileRead, err)
+	}
+
+	options, err := json.Marshal(j)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal job: %w", err)
+	}
+
+	fmt.Fprintf(hash, "%s\n%s\n", toolVersion(), options)
+	hash.Write(specData)
+
+	// The shared libraries are read relative to the specification, a specification that fails to parse has none
+	var shared struct {
+		Shared []string `yaml:"shared"`
+	}
+	_ = yaml.Unmarshal(specData, &shared)
+	if err := hashDescriptionFiles(hash, specData, filepath.Dir(j.Specification)); err != nil {
+		return "", err
+	}
+	for _, file := range shared.Shared {
+		libraryPath := filepath.Join(filepath.Dir(j.Specification), file)
+		libraryData, err := os.ReadFile(libraryPath)
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", errorFileRead, err)
+		}
+		fmt.Fprintf(hash, "\n%s\n", file)
+		hash.Write(libraryData)
+
+		// The description files of a shared library are read relative to the library
+		if err := hashDescriptionFiles(hash, libraryData, filepath.Dir(libraryPath)); err != nil {
+			return "", err
+		}
+	}
+
+	if j.OpenAPIOverlay != "" {
+		overlayData, err := os.ReadFile(j.OpenAPIOverlay)
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", errorFileRead, err)
+		}
+		fmt.Fprintf(hash, "\n%s\n", j.OpenAPIOverlay)
+		hash.Write(overlayData)
+	}
+
+	if j.TemplatesDir != "" {
+		entries, err := os.ReadDir(j.TemplatesDir)
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", errorFileRead, err)
+		}
+
+		// Entries are sorted by name, keeping the hash stable
+		for _, entry := range entries {
+			if entry.IsDir() {
+				continue
+			}
+
+			data, err := os.ReadFile(filepath.Join(j.TemplatesDir, entry.Name()))
+			if err != nil {
+				return "", fmt.Errorf("%s: %w", errorFileRead, err)
+			}
+			fmt.Fprintf(hash, "\n%s\n", entry.Name())
+			hash.Write(data)
+		}
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// hashDescriptionFiles writes the description files of the enums, objects, resources and endpoints of a specification
+// or shared library to the hash, resolved relative to dir. Data that fails to parse has none.
+func hashDescriptionFiles(hash io.Writer, data []byte, dir string) error {
+	type described struct {
+		DescriptionFile string `yaml:"description_file"`
+	}
+	var specification struct {
+		Enums     []described `yaml:"enums"`
+		Objects   []described `yaml:"objects"`
+		Resources []struct {
+			DescriptionFile string      `yaml:"description_file"`
+			Endpoints       []described `yaml:"endpoints"`
+		} `yaml:"resources"`
+	}
+	_ = yaml.Unmarshal(data, &specification)
+
+	files := []string{}
+	for _, items := range [][]described{specification.Enums, specification.Objects} {
+		for _, item := range items {
+			files = append(files, item.DescriptionFile)
+		}
+	}
+	for _, resource := range specification.Resources {
+		files = append(files, resource.DescriptionFile)
+		for _, endpoint := range resource.Endpoints {
+			files = append(files, endpoint.DescriptionFile)
+		}
+	}
+
+	for _, file := range files {
+		if file == "" {
+			continue
+		}
+
+		content, err := os.ReadFile(filepath.Join(dir, file))
+		if err != nil {
+			return fmt.Errorf("%s: %w", errorFileRead, err)
+		}
+		fmt.Fprintf(hash, "\n%s\n", filepath.Join(dir, file))
+		hash.Write(content)
+	}
+
+	return nil
+}
+
+// withOnly returns a copy of the job with only the outputs of the given -only artifacts. The overlay artifact
+// selects both overlay outputs and the server artifact includes its internal tests. No artifacts keeps all outputs.
+func (j Job) withOnly(artifacts []string) Job {
+	if len(artifacts) == 0 {
+		return j
+	}
+
+	if !slices.Contains(artifacts, modeOpenAPI) {
+		j.OpenAPIJSON = ""
+	}
+	if !slices.Contains(artifacts, modeOpenAPIYAML) {
+		j.OpenAPIYAML = ""
+	}
+	if !slices.Contains(artifacts, modeSchema) {
+		j.SchemaJSON = ""
+	}
+	if !slices.Contains(artifacts, modeOverlay) {
+		j.OverlayYAML = ""
+		j.OverlayJSON = ""
+	}
+	if !slices.Contains(artifacts, modeServer) {
+		j.ServerGo = ""
+		j.ServerDir = ""
+		j.SharedPackages = nil
+	}
+	if !slices.Contains(artifacts, modeGateway) {
+		j.GatewayRoutes = ""
+	}
+	if !slices.Contains(artifacts, modeTerraform) {
+		j.TerraformSchema = ""
+	}
+	if !slices.Contains(artifacts, onlyPlugins) {
+		j.Plugins = nil
+	}
+
+	return j
+}
+
+// hasNamePlaceholder reports whether every output path of the job contains the {name} placeholder.
+func (j Job) hasNamePlaceholder() bool {
+	for _, path := range j.getOutputPaths() {
+		if !strings.Contains(path, placeholderName) {
+			return false
+		}
+	}
+	return true
+}
+
+// withName returns a copy of the job with the {name} placeholder replaced in all output and package fields.
+func (j Job) withName(name string) Job {
+	return j.mapOutputFields(func(value string) string {
+		return strings.ReplaceAll(value, placeholderName, name)
+	})
+}
+
+// withService returns a copy of the job with the {service} and {version} placeholders
+// replaced in all output and package fields, using the parsed specification.
+func (j Job) withService(service *specification.Service) Job {
+	replacer := strings.NewReplacer(placeholderService, service.PathName(), placeholderVersion, service.Version)
+	return j.mapOutputFields(replacer.Replace)
+}
+
+// expandEnv returns a copy of the job with ${VAR} references replaced by environment variables.
+// Referencing a variable that is not set is an error, to avoid silently writing to unexpected paths.
+func (j Job) expandEnv() (Job, error) {
+	var missing []string
+	expand := func(value string) string {
+		return envPattern.ReplaceAllStringFunc(value, func(reference string) string {
+			key := envPattern.FindStringSubmatch(reference)[1]
+			envValue, ok := os.LookupEnv(key)
+			if !ok {
+				missing = append(missing, key)
+			}
+			return envValue
+		})
+	}
+
+	j.Specification = expand(j.Specification)
+	j.TemplatesDir = expand(j.TemplatesDir)
+	j.OpenAPIOverlay = expand(j.OpenAPIOverlay)
+	j = j.mapOutputFields(expand)
+	for i := range j.Plugins {
+		j.Plugins[i].Command = expand(j.Plugins[i].Command)
+	}
+
+	if len(missing) > 0 {
+		return Job{}, fmt.Errorf("%s: %s", errorUndefinedEnv, strings.Join(missing, ", "))
+	}
+
+	return j, nil
+}
+
+// mapOutputFields returns a copy of the job with fn applied to all output paths and the server package.
+// The plugins and shared packages are copied, so that jobs expanded from the same job do not share them.
+func (j Job) mapOutputFields(fn func(string) string) Job {
+	j.OpenAPIJSON = fn(j.OpenAPIJSON)
+	j.OpenAPIYAML = fn(j.OpenAPIYAML)
+	j.SchemaJSON = fn(j.SchemaJSON)
+	j.OverlayYAML = fn(j.OverlayYAML)
+	j.OverlayJSON = fn(j.OverlayJSON)
+	j.ServerGo = fn(j.ServerGo)
+	j.ServerDir = fn(j.ServerDir)
+	j.GatewayRoutes = fn(j.GatewayRoutes)
+	j.TerraformSchema = fn(j.TerraformSchema)
+	j.ServerPackage = fn(j.ServerPackage)
+
+	if j.Plugins != nil {
+		plugins := make([]Plugin, len(j.Plugins))
+		for i, plugin := range j.Plugins {
+			plugin.Output = fn(plugin.Output)
+			plugins[i] = plugin
 		}
+		j.Plugins = plugins
 	}
 
-	return config, nil
+	if j.SharedPackages != nil {
+		sharedPackages := make([]SharedPackage, len(j.SharedPackages))
+		for i, sharedPackage := range j.SharedPackages {
+			sharedPackage.Output = fn(sharedPackage.Output)
+			sharedPackages[i] = sharedPackage
+		}
+		j.SharedPackages = sharedPackages
+	}
+
+	return j
+}
+
+// validateUniqueOutputs ensures that no two jobs write to the same output path.
+// Paths using {service} or {version} are skipped since they are only resolved once the specification is parsed.
+func validateUniqueOutputs(config Config) error {
+	seen := make(map[string]string)
+	for _, job := range config {
+		for _, path := range job.getOutputPaths() {
+			if strings.Contains(path, placeholderService) || strings.Contains(path, placeholderVersion) {
+				continue
+			}
+
+			cleaned := filepath.Clean(path)
+			if previous, exists := seen[cleaned]; exists && previous != job.Specification {
+				return fmt.Errorf("%s: %s: '%s' is written by both '%s' and '%s'", errorInvalidConfig, errorOutputConflict, path, previous, job.Specification)
+			}
+			seen[cleaned] = job.Specification
+		}
+	}
+	return nil
 }
 
-// processJob processes a single job from the config file
-func processJob(ctx context.Context, job Job) error {
+// processJob processes a single job from the config file and returns the paths of the files it wrote
+func processJob(ctx context.Context, job Job) ([]string, error) {
 	// Read and parse the specification file
 	service, err := readSpecificationFile(job.Specification)
 	if err != nil {
-		return fmt.Errorf("failed to read specification file '%s': %w", job.Specification, err)
+		return nil, fmt.Errorf("failed to read specification file '%s': %w", job.Specification, err)
 	}
 
 	slog.InfoContext(ctx, "Successfully parsed specification file", logKeyFile, job.Specification)
 
+	header, err := readProvenance(job.Specification)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read specification file '%s': %w", job.Specification, err)
+	}
+
+	if job.Prune {
+		service = specification.PruneUnusedDefinitions(service)
+	}
+
+	// Resolve {service} and {version} placeholders now that the specification is parsed
+	job = job.withService(service)
+	outputPaths := job.getOutputPaths()
+
+	openAPIOptions, err := job.openAPIOptions()
+	if err != nil {
+		return nil, err
+	}
+
 	// Generate each requested output format
 	if job.OpenAPIJSON != "" {
-		if err := generateOpenAPI(ctx, service, job.Specification, job.OpenAPIJSON); err != nil {
-			return fmt.Errorf("failed to generate OpenAPI JSON to '%s': %w", job.OpenAPIJSON, err)
+		if err := generateOpenAPI(ctx, service, openAPIOptions, header, job.Specification, job.OpenAPIJSON); err != nil {
+			return nil, fmt.Errorf("failed to generate OpenAPI JSON to '%s': %w", job.OpenAPIJSON, err)
 		}
 	}
 
 	if job.OpenAPIYAML != "" {
-		if err := generateOpenAPIYAML(ctx, service, job.Specification, job.OpenAPIYAML); err != nil {
-			return fmt.Errorf("failed to generate OpenAPI YAML to '%s': %w", job.OpenAPIYAML, err)
+		if err := generateOpenAPIYAML(ctx, service, openAPIOptions, header, job.Specification, job.OpenAPIYAML); err != nil {
+			return nil, fmt.Errorf("failed to generate OpenAPI YAML to '%s': %w", job.OpenAPIYAML, err)
 		}
 	}
 
 	if job.SchemaJSON != "" {
-		if err := generateSchema(ctx, service, job.Specification, job.SchemaJSON); err != nil {
-			return fmt.Errorf("failed to generate schema JSON to '%s': %w", job.SchemaJSON, err)
+		if err := generateSchema(ctx, service, header, job.Specification, job.SchemaJSON); err != nil {
+			return nil, fmt.Errorf("failed to generate schema JSON to '%s': %w", job.SchemaJSON, err)
 		}
 	}
 
 	if job.OverlayYAML != "" {
-		if err := generateOverlay(ctx, service, job.Specification, job.OverlayYAML); err != nil {
-			return fmt.Errorf("failed to generate overlay YAML to '%s': %w", job.OverlayYAML, err)
+		if err := generateOverlay(ctx, service, header, job.Specification, job.OverlayYAML); err != nil {
+			return nil, fmt.Errorf("failed to generate overlay YAML to '%s': %w", job.OverlayYAML, err)
 		}
 	}
 
 	if job.OverlayJSON != "" {
-		if err := generateOverlay(ctx, service, job.Specification, job.OverlayJSON); err != nil {
-			return fmt.Errorf("failed to generate overlay JSON to '%s': %w", job.OverlayJSON, err)
+		if err := generateOverlay(ctx, service, header, job.Specification, job.OverlayJSON); err != nil {
+			return nil, fmt.Errorf("failed to generate overlay JSON to '%s': %w", job.OverlayJSON, err)
 		}
 	}
 
 	if job.ServerGo != "" {
 		// Generate server code using servergen from the specification
-		if err := generateServerFromSpecification(ctx, service, job.Specification, job.ServerGo, job.ServerPackage); err != nil {
-			return fmt.Errorf("failed to generate Go server to '%s': %w", job.ServerGo, err)
+		if err := generateServerFromSpecification(ctx, service, job.serverOptions(), header, job.Specification, job.ServerGo, job.ServerPackage); err != nil {
+			return nil, fmt.Errorf("failed to generate Go server to '%s': %w", job.ServerGo, err)
 		}
 
 		// Automatically generate internal tests for the server
 		testFilePath := generateTestFilePath(job.ServerGo)
-		if err := generateInternalTestsFromSpecification(ctx, service, job.Specification, testFilePath); err != nil {
-			return fmt.Errorf("failed to generate Go tests to '%s': %w", testFilePath, err)
+		if err := generateInternalTestsFromSpecification(ctx, service, job.testOptions(), header, job.Specification, testFilePath); err != nil {
+			return nil, fmt.Errorf("failed to generate Go tests to '%s': %w", testFilePath, err)
+		}
+		outputPaths = append(outputPaths, testFilePath)
+	}
+
+	if job.ServerDir != "" {
+		serverPaths, err := generateServerDirFromSpecification(ctx, service, job.serverOptions(), header, job.ServerDir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate Go server to '%s': %w", job.ServerDir, err)
+		}
+		outputPaths = append(outputPaths, serverPaths...)
+
+		// Automatically generate internal tests for the server
+		testFilePath := generateTestFilePath(filepath.Join(job.ServerDir, servergen.FileServer))
+		if err := generateInternalTestsFromSpecification(ctx, service, job.testOptions(), header, job.Specification, testFilePath); err != nil {
+			return nil, fmt.Errorf("failed to generate Go tests to '%s': %w", testFilePath, err)
+		}
+		outputPaths = append(outputPaths, testFilePath)
+	}
+
+	if job.GatewayRoutes != "" {
+		if err := generateGatewayRoutes(ctx, service, header, job.GatewayRoutes); err != nil {
+			return nil, fmt.Errorf("failed to generate gateway routes to '%s': %w", job.GatewayRoutes, err)
+		}
+	}
+
+	if job.TerraformSchema != "" {
+		if err := generateTerraformSchema(ctx, service, job.terraformOptions(), header, job.TerraformSchema); err != nil {
+			return nil, fmt.Errorf("failed to generate Terraform schema to '%s': %w", job.TerraformSchema, err)
+		}
+	}
+
+	for _, sharedPackage := range job.SharedPackages {
+		if err := generateSharedPackageFromSpecification(ctx, service, job.serverOptions(), header, sharedPackage); err != nil {
+			return nil, fmt.Errorf("failed to generate shared package to '%s': %w", sharedPackage.Output, err)
+		}
+	}
+
+	for _, plugin := range job.Plugins {
+		if err := generatePlugin(ctx, service, plugin, job.Specification); err != nil {
+			return nil, fmt.Errorf("failed to generate plugin output to '%s': %w", plugin.Output, err)
+		}
+	}
+
+	return outputPaths, nil
+}
+
+// runPlugin runs the plugin command with the specification as JSON on stdin and returns what it writes to stdout.
+// The paths of the specification and the output are passed in the PUBLICAPIS_SPECIFICATION and PUBLICAPIS_OUTPUT
+// environment variables. What the command writes to stderr is included in the error if it fails.
+func runPlugin(ctx context.Context, service *specification.Service, plugin Plugin, specPath string) ([]byte, error) {
+	slog.InfoContext(ctx, "Running plugin", logKeyPlugin, plugin.Command)
+
+	args := strings.Fields(plugin.Command)
+	if len(args) == 0 {
+		return nil, fmt.Errorf("%s: 'plugin' command is required", errorInvalidPlugin)
+	}
+
+	input, err := specification.MarshalServiceJSON(service)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal specification: %w", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	cmd.Env = append(os.Environ(), envPluginSpecification+"="+specPath, envPluginOutput+"="+plugin.Output)
+
+	if err := cmd.Run(); err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return nil, fmt.Errorf("%s: '%s': %w: %s", errorPluginFailed, plugin.Command, err, message)
 		}
+		return nil, fmt.Errorf("%s: '%s': %w", errorPluginFailed, plugin.Command, err)
+	}
+
+	return stdout.Bytes(), nil
+}
+
+// generatePlugin runs the plugin and writes its output file.
+func generatePlugin(ctx context.Context, service *specification.Service, plugin Plugin, specPath string) error {
+	outputData, err := runPlugin(ctx, service, plugin, specPath)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(plugin.Output, outputData, 0644); err != nil {
+		return fmt.Errorf("%s: %w", errorFileWrite, err)
+	}
+
+	slog.InfoContext(ctx, "Successfully generated plugin output", logKeyFile, plugin.Output)
+	fmt.Printf("Plugin output generated: %s\n", plugin.Output)
+
+	return nil
+}
+
+// toolVersion returns the version of publicapis-gen recorded in the provenance header of generated files.
+func toolVersion() string {
+	if version != "" {
+		return version
+	}
+
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" && info.Main.Version != "(devel)" {
+		return info.Main.Version
+	}
+
+	return defaultToolVersion
+}
+
+// provenance identifies the version of publicapis-gen and the specification a file was generated from.
+// It is written as a header in every generated file; the generation time is left out to keep the output deterministic.
+type provenance struct {
+	ToolVersion       string
+	SpecificationHash string
+}
+
+// newProvenance creates the provenance of the files generated from the specification content.
+func newProvenance(specData []byte) provenance {
+	sum := sha256.Sum256(specData)
+	return provenance{
+		ToolVersion:       toolVersion(),
+		SpecificationHash: hex.EncodeToString(sum[:]),
+	}
+}
+
+// readProvenance creates the provenance of the files generated from the specification file.
+func readProvenance(specPath string) (provenance, error) {
+	data, err := os.ReadFile(specPath)
+	if err != nil {
+		return provenance{}, fmt.Errorf("%s: %w", errorFileRead, err)
+	}
+
+	return newProvenance(data), nil
+}
+
+// String returns the provenance as written in the header, matched by provenancePattern.
+func (p provenance) String() string {
+	return fmt.Sprintf("publicapis-gen %s from specification sha256:%s", p.ToolVersion, p.SpecificationHash)
+}
+
+// apply returns the data with the provenance header for the file extension: a comment in Go and YAML files, and
+// an x-generated-by member in JSON objects. Other files, and data without provenance, are returned unchanged.
+func (p provenance) apply(fileExtension string, data []byte) []byte {
+	if p.ToolVersion == "" {
+		return data
+	}
+
+	switch strings.ToLower(fileExtension) {
+	case extGo:
+		return append([]byte("// Generated by "+p.String()+".\n"), data...)
+	case extYAML, extYML:
+		return append([]byte("# Generated by "+p.String()+".\n"), data...)
+	case extJSON:
+		return addJSONMember(data, provenanceJSONKey, p.String())
+	default:
+		return data
+	}
+}
+
+// addJSONMember adds a string member as the first member of a JSON object, keeping the indentation of the object.
+// Data that is not a JSON object is returned unchanged.
+func addJSONMember(data []byte, key, value string) []byte {
+	trimmed := bytes.TrimLeft(data, " \t\r\n")
+	if len(trimmed) == 0 || trimmed[0] != '{' {
+		return data
+	}
+
+	rest := trimmed[1:]
+	body := bytes.TrimLeft(rest, " \t\r\n")
+	whitespace := rest[:len(rest)-len(body)]
+
+	encodedKey, err := json.Marshal(key)
+	if err != nil {
+		return data
+	}
+	encodedValue, err := json.Marshal(value)
+	if err != nil {
+		return data
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	buf.Write(whitespace)
+	buf.Write(encodedKey)
+	buf.WriteString(": ")
+	buf.Write(encodedValue)
+	if len(body) > 0 && body[0] != '}' {
+		buf.WriteByte(',')
+		buf.Write(whitespace)
+	}
+	buf.Write(body)
+
+	return buf.Bytes()
+}
+
+// writeGeneratedFile writes the generated data with the provenance header for the extension of the output path.
+func writeGeneratedFile(outputPath string, data []byte, header provenance) error {
+	if err := os.WriteFile(outputPath, header.apply(filepath.Ext(outputPath), data), 0644); err != nil {
+		return fmt.Errorf("%s: %w", errorFileWrite, err)
 	}
 
 	return nil
 }
 
+// checkProvenance returns a message if the file on disk was generated by another version of publicapis-gen, or has
+// no provenance header, and the header to compare its content with, so that the content diff does not repeat the
+// version difference. Returns an empty message if the file was generated by this version or does not exist.
+func checkProvenance(filePath string, header provenance) (string, provenance, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", header, nil
+		}
+		return "", header, fmt.Errorf("%s: %w", errorFileRead, err)
+	}
+
+	match := provenancePattern.FindSubmatch(data[:min(len(data), provenanceSearchLimit)])
+	if match == nil {
+		return diffNoProvenance, provenance{}, nil
+	}
+
+	if generatedVersion := string(match[1]); generatedVersion != header.ToolVersion {
+		compareHeader := header
+		compareHeader.ToolVersion = generatedVersion
+		return fmt.Sprintf(diffOtherVersion, generatedVersion, header.ToolVersion), compareHeader, nil
+	}
+
+	return "", header, nil
+}
+
 // readSpecificationFile reads and parses a YAML or JSON specification file
 // with overlays automatically applied.
 func readSpecificationFile(filePath string) (*specification.Service, error) {
@@ -444,7 +2798,7 @@ func readSpecificationFile(filePath string) (*specification.Service, error) {
 }
 
 // generateOverlay generates a specification with overlay applied.
-func generateOverlay(ctx context.Context, service *specification.Service, inputFile, outputFile string) error {
+func generateOverlay(ctx context.Context, service *specification.Service, header provenance, inputFile, outputFile string) error {
 	slog.InfoContext(ctx, "Generating specification with overlay", logKeyMode, modeOverlay)
 
 	// Service already has overlays applied from parsing
@@ -457,16 +2811,16 @@ func generateOverlay(ctx context.Context, service *specification.Service, inputF
 	}
 
 	// Write output file
-	return writeSpecificationFile(ctx, service, outputPath)
+	return writeSpecificationFile(ctx, service, header, outputPath)
 }
 
 // generateOpenAPIBytes generates an OpenAPI document from the specification and returns it as bytes.
-func generateOpenAPIBytes(ctx context.Context, service *specification.Service) ([]byte, error) {
+func generateOpenAPIBytes(ctx context.Context, service *specification.Service, options openapigen.Options) ([]byte, error) {
 	slog.InfoContext(ctx, "Generating OpenAPI document bytes", logKeyMode, modeOpenAPI)
 
 	// Generate OpenAPI document as JSON using the new API pattern
 	var buf bytes.Buffer
-	err := openapigen.GenerateOpenAPI(&buf, service)
+	err := openapigen.GenerateOpenAPIWithOptions(&buf, service, options)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate OpenAPI document: %w", err)
 	}
@@ -475,11 +2829,11 @@ func generateOpenAPIBytes(ctx context.Context, service *specification.Service) (
 }
 
 // generateOpenAPI generates an OpenAPI document from the specification.
-func generateOpenAPI(ctx context.Context, service *specification.Service, inputFile, outputFile string) error {
+func generateOpenAPI(ctx context.Context, service *specification.Service, options openapigen.Options, header provenance, inputFile, outputFile string) error {
 	slog.InfoContext(ctx, "Generating OpenAPI document", logKeyMode, modeOpenAPI)
 
 	// Generate OpenAPI document as bytes
-	outputData, err := generateOpenAPIBytes(ctx, service)
+	outputData, err := generateOpenAPIBytes(ctx, service, options)
 	if err != nil {
 		return err
 	}
@@ -494,38 +2848,40 @@ func generateOpenAPI(ctx context.Context, service *specification.Service, inputF
 	}
 
 	// Write output file
-	if err := os.WriteFile(outputPath, outputData, 0644); err != nil {
-		return fmt.Errorf("%s: %w", errorFileWrite, err)
+	if err := writeGeneratedFile(outputPath, outputData, header); err != nil {
+		return err
 	}
 
 	slog.InfoContext(ctx, "Successfully generated OpenAPI document", logKeyFile, outputPath)
 	fmt.Printf("OpenAPI document generated: %s\n", outputPath)
 
-	return nil
+	return nil
+}
+
+// generateOpenAPIYAMLBytes generates an OpenAPI document in YAML format and returns the bytes.
+// The YAML is rendered natively by openapigen, keeping the key ordering and number formatting of the document.
+func generateOpenAPIYAMLBytes(ctx context.Context, service *specification.Service, options openapigen.Options) ([]byte, error) {
+	slog.InfoContext(ctx, "Generating OpenAPI YAML document bytes", logKeyMode, modeOpenAPIYAML)
+
+	options.Format = openapigen.FormatYAML
+
+	var buf bytes.Buffer
+	if err := openapigen.GenerateOpenAPIWithOptions(&buf, service, options); err != nil {
+		return nil, fmt.Errorf("failed to generate OpenAPI document: %w", err)
+	}
+
+	return buf.Bytes(), nil
 }
 
 // generateOpenAPIYAML generates an OpenAPI document in YAML format from the specification.
-func generateOpenAPIYAML(ctx context.Context, service *specification.Service, inputFile, outputFile string) error {
-	slog.InfoContext(ctx, "Generating OpenAPI YAML document", logKeyMode, "openapi-yaml")
+func generateOpenAPIYAML(ctx context.Context, service *specification.Service, options openapigen.Options, header provenance, inputFile, outputFile string) error {
+	slog.InfoContext(ctx, "Generating OpenAPI YAML document", logKeyMode, modeOpenAPIYAML)
 
-	// Generate OpenAPI document as JSON bytes first
-	outputData, err := generateOpenAPIBytes(ctx, service)
+	yamlData, err := generateOpenAPIYAMLBytes(ctx, service, options)
 	if err != nil {
 		return err
 	}
 
-	// Parse JSON to interface{} so we can convert to YAML
-	var openAPIDoc interface{}
-	if err := json.Unmarshal(outputData, &openAPIDoc); err != nil {
-		return fmt.Errorf("failed to parse generated OpenAPI JSON: %w", err)
-	}
-
-	// Convert to YAML
-	yamlData, err := yaml.Marshal(openAPIDoc)
-	if err != nil {
-		return fmt.Errorf("failed to convert OpenAPI document to YAML: %w", err)
-	}
-
 	// Determine output file path - ensure YAML extension
 	outputPath := outputFile
 	if outputPath == "" {
@@ -536,8 +2892,8 @@ func generateOpenAPIYAML(ctx context.Context, service *specification.Service, in
 	}
 
 	// Write output file
-	if err := os.WriteFile(outputPath, yamlData, 0644); err != nil {
-		return fmt.Errorf("%s: %w", errorFileWrite, err)
+	if err := writeGeneratedFile(outputPath, yamlData, header); err != nil {
+		return err
 	}
 
 	slog.InfoContext(ctx, "Successfully generated OpenAPI YAML document", logKeyFile, outputPath)
@@ -547,7 +2903,7 @@ func generateOpenAPIYAML(ctx context.Context, service *specification.Service, in
 }
 
 // generateSchema generates JSON schemas from the specification.
-func generateSchema(ctx context.Context, service *specification.Service, inputFile, outputFile string) error {
+func generateSchema(ctx context.Context, service *specification.Service, header provenance, inputFile, outputFile string) error {
 	slog.InfoContext(ctx, "Generating JSON schemas", logKeyMode, modeSchema)
 
 	// Create buffer for schema generation
@@ -571,8 +2927,8 @@ func generateSchema(ctx context.Context, service *specification.Service, inputFi
 	}
 
 	// Write output file
-	if err := os.WriteFile(outputPath, outputData, 0644); err != nil {
-		return fmt.Errorf("%s: %w", errorFileWrite, err)
+	if err := writeGeneratedFile(outputPath, outputData, header); err != nil {
+		return err
 	}
 
 	slog.InfoContext(ctx, "Successfully generated JSON schemas", logKeyFile, outputPath)
@@ -581,8 +2937,46 @@ func generateSchema(ctx context.Context, service *specification.Service, inputFi
 	return nil
 }
 
+// generateGatewayRoutes generates the Kubernetes Gateway API routes of the endpoints of the specification.
+func generateGatewayRoutes(ctx context.Context, service *specification.Service, header provenance, outputPath string) error {
+	slog.InfoContext(ctx, "Generating gateway routes", logKeyMode, modeGateway)
+
+	var buf bytes.Buffer
+	if err := gatewaygen.GenerateRoutes(&buf, service); err != nil {
+		return fmt.Errorf("failed to generate gateway routes: %w", err)
+	}
+
+	if err := writeGeneratedFile(outputPath, buf.Bytes(), header); err != nil {
+		return err
+	}
+
+	slog.InfoContext(ctx, "Successfully generated gateway routes", logKeyFile, outputPath)
+	fmt.Printf("Gateway routes generated: %s\n", outputPath)
+
+	return nil
+}
+
+// generateTerraformSchema generates the Terraform provider schema of the resources of the specification.
+func generateTerraformSchema(ctx context.Context, service *specification.Service, options terraformgen.Options, header provenance, outputPath string) error {
+	slog.InfoContext(ctx, "Generating Terraform schema", logKeyMode, modeTerraform)
+
+	var buf bytes.Buffer
+	if err := terraformgen.GenerateSchemaWithOptions(&buf, service, options); err != nil {
+		return fmt.Errorf("failed to generate Terraform schema: %w", err)
+	}
+
+	if err := writeGeneratedFile(outputPath, buf.Bytes(), header); err != nil {
+		return err
+	}
+
+	slog.InfoContext(ctx, "Successfully generated Terraform schema", logKeyFile, outputPath)
+	fmt.Printf("Terraform schema generated: %s\n", outputPath)
+
+	return nil
+}
+
 // writeSpecificationFile writes a specification to a file in the appropriate format.
-func writeSpecificationFile(ctx context.Context, service *specification.Service, outputPath string) error {
+func writeSpecificationFile(ctx context.Context, service *specification.Service, header provenance, outputPath string) error {
 	// Determine output format based on extension
 	ext := strings.ToLower(filepath.Ext(outputPath))
 	var outputData []byte
@@ -590,27 +2984,27 @@ func writeSpecificationFile(ctx context.Context, service *specification.Service,
 
 	switch ext {
 	case extYAML, extYML:
-		outputData, err = yaml.Marshal(service)
+		outputData, err = specification.MarshalServiceYAML(service)
 		if err != nil {
 			return fmt.Errorf("failed to marshal specification to YAML: %w", err)
 		}
 	case extJSON:
-		outputData, err = json.MarshalIndent(service, "", "  ")
+		outputData, err = specification.MarshalServiceJSON(service)
 		if err != nil {
 			return fmt.Errorf("failed to marshal specification to JSON: %w", err)
 		}
 	default:
 		// Default to YAML if extension is not recognized
 		outputPath = strings.TrimSuffix(outputPath, filepath.Ext(outputPath)) + extYAML
-		outputData, err = yaml.Marshal(service)
+		outputData, err = specification.MarshalServiceYAML(service)
 		if err != nil {
 			return fmt.Errorf("failed to marshal specification to YAML: %w", err)
 		}
 	}
 
 	// Write output file
-	if err := os.WriteFile(outputPath, outputData, 0644); err != nil {
-		return fmt.Errorf("%s: %w", errorFileWrite, err)
+	if err := writeGeneratedFile(outputPath, outputData, header); err != nil {
+		return err
 	}
 
 	slog.InfoContext(ctx, "Successfully generated specification with overlay", logKeyFile, outputPath)
@@ -666,7 +3060,7 @@ func ensureYAMLExtension(outputPath string) string {
 
 // generateServerFromSpecification generates Go server code from a specification (for config mode).
 // It uses servergen to generate the server code directly from the specification.
-func generateServerFromSpecification(ctx context.Context, service *specification.Service, specPath, outputPath, packageName string) error {
+func generateServerFromSpecification(ctx context.Context, service *specification.Service, options servergen.Options, header provenance, specPath, outputPath, packageName string) error {
 	slog.InfoContext(ctx, "Generating Go server code from specification using servergen", logKeyMode, modeServer)
 
 	// Note: packageName parameter is currently not used as servergen hardcodes the package to "api"
@@ -674,13 +3068,13 @@ func generateServerFromSpecification(ctx context.Context, service *specification
 
 	// Generate server code using servergen
 	var buf bytes.Buffer
-	if err := servergen.GenerateServer(&buf, service); err != nil {
+	if err := servergen.GenerateServerWithOptions(&buf, service, options); err != nil {
 		return fmt.Errorf("failed to generate server code: %w", err)
 	}
 
 	// Write the generated code to file
-	if err := os.WriteFile(outputPath, buf.Bytes(), 0644); err != nil {
-		return fmt.Errorf("%s: %w", errorFileWrite, err)
+	if err := writeGeneratedFile(outputPath, buf.Bytes(), header); err != nil {
+		return err
 	}
 
 	slog.InfoContext(ctx, "Successfully generated Go server code", logKeyFile, outputPath)
@@ -689,6 +3083,96 @@ func generateServerFromSpecification(ctx context.Context, service *specification
 	return nil
 }
 
+// generateSharedPackageFromSpecification generates the Go package of the types of a shared library of the specification.
+func generateSharedPackageFromSpecification(ctx context.Context, service *specification.Service, options servergen.Options, header provenance, sharedPackage SharedPackage) error {
+	slog.InfoContext(ctx, "Generating Go shared package from specification using servergen", logKeyMode, modeServer)
+
+	var buf bytes.Buffer
+	if err := servergen.GenerateSharedPackage(&buf, service, sharedPackage.Library, sharedPackage.Import, options.Types); err != nil {
+		return fmt.Errorf("failed to generate shared package: %w", err)
+	}
+
+	if err := writeGeneratedFile(sharedPackage.Output, buf.Bytes(), header); err != nil {
+		return err
+	}
+
+	slog.InfoContext(ctx, "Successfully generated Go shared package", logKeyFile, sharedPackage.Output)
+	fmt.Printf("Go shared package generated: %s\n", sharedPackage.Output)
+
+	return nil
+}
+
+// generateServerDirFromSpecification generates the server split across files into the directory, removing the
+// resource files of resources that no longer exist, and returns the paths of the files it wrote.
+func generateServerDirFromSpecification(ctx context.Context, service *specification.Service, options servergen.Options, header provenance, outputDir string) ([]string, error) {
+	slog.InfoContext(ctx, "Generating Go server files from specification using servergen", logKeyMode, modeServer)
+
+	files, err := servergen.GenerateServerFiles(service, options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate server code: %w", err)
+	}
+
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return nil, fmt.Errorf("%s: %w", errorFileWrite, err)
+	}
+
+	staleFiles, err := findStaleServerFiles(outputDir, files)
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range staleFiles {
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("%s: %w", errorFileWrite, err)
+		}
+		slog.InfoContext(ctx, "Removed Go server file that is no longer generated", logKeyFile, path)
+	}
+
+	var paths []string
+	for _, name := range slices.Sorted(maps.Keys(files)) {
+		path := filepath.Join(outputDir, name)
+		if err := writeGeneratedFile(path, files[name], header); err != nil {
+			return nil, err
+		}
+		paths = append(paths, path)
+	}
+
+	slog.InfoContext(ctx, "Successfully generated Go server files", logKeyFile, outputDir)
+	fmt.Printf("Go server code generated: %s (%d files)\n", outputDir, len(paths))
+
+	return paths, nil
+}
+
+// findStaleServerFiles returns the resource files in the directory that were generated by publicapis-gen but are
+// not part of the given files, because their resource was removed or renamed, and the lifecycle file when it is no
+// longer generated. Other files are left alone.
+func findStaleServerFiles(outputDir string, files map[string][]byte) ([]string, error) {
+	paths, err := filepath.Glob(filepath.Join(outputDir, "*"+servergen.ResourceFileSuffix))
+	if err != nil {
+		return nil, err
+	}
+	lifecyclePath := filepath.Join(outputDir, servergen.FileLifecycle)
+	if _, err := os.Stat(lifecyclePath); err == nil {
+		paths = append(paths, lifecyclePath)
+	}
+
+	var stale []string
+	for _, path := range paths {
+		if _, ok := files[filepath.Base(path)]; ok {
+			continue
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", errorFileRead, err)
+		}
+		if provenancePattern.Match(data[:min(len(data), provenanceSearchLimit)]) {
+			stale = append(stale, path)
+		}
+	}
+
+	return stale, nil
+}
+
 // generateTestFilePath converts a server file path to a test file path by adding _test before the first dot.
 func generateTestFilePath(serverGoPath string) string {
 	// Find the first dot in the filename
@@ -714,7 +3198,7 @@ func generateTestFilePath(serverGoPath string) string {
 }
 
 // generateInternalTestsFromSpecification generates internal HTTP API tests from a service specification using testgen.
-func generateInternalTestsFromSpecification(ctx context.Context, service *specification.Service, specPath, outputPath string) error {
+func generateInternalTestsFromSpecification(ctx context.Context, service *specification.Service, options testgen.Options, header provenance, specPath, outputPath string) error {
 	slog.InfoContext(ctx, "Generating internal Go test code from specification using testgen", logKeyMode, "test")
 
 	// Internal tests should always use "api" package name to match servergen
@@ -723,13 +3207,13 @@ func generateInternalTestsFromSpecification(ctx context.Context, service *specif
 
 	// Generate test code using testgen (internal tests don't need imports)
 	var buf bytes.Buffer
-	if err := testgen.GenerateInternalTests(&buf, service, packageName); err != nil {
+	if err := testgen.GenerateInternalTestsWithOptions(&buf, service, packageName, options); err != nil {
 		return fmt.Errorf("failed to generate test code: %w", err)
 	}
 
 	// Write the generated code to file
-	if err := os.WriteFile(outputPath, buf.Bytes(), 0644); err != nil {
-		return fmt.Errorf("%s: %w", errorFileWrite, err)
+	if err := writeGeneratedFile(outputPath, buf.Bytes(), header); err != nil {
+		return err
 	}
 
 	slog.InfoContext(ctx, "Successfully generated internal Go test code", logKeyFile, outputPath)
@@ -739,7 +3223,11 @@ func generateInternalTestsFromSpecification(ctx context.Context, service *specif
 }
 
 // runDiffMode processes jobs from a config file and checks for differences
-func runDiffMode(ctx context.Context, configPath string) error {
+func runDiffMode(ctx context.Context, configPath string, options diffOptions) error {
+	if options.Report != reportFormatText && options.Report != reportFormatJSON {
+		return fmt.Errorf("%s: %s", errorInvalidReport, options.Report)
+	}
+
 	// Parse config file
 	config, err := parseConfigFile(configPath)
 	if err != nil {
@@ -748,44 +3236,101 @@ func runDiffMode(ctx context.Context, configPath string) error {
 
 	slog.InfoContext(ctx, "Successfully parsed config file", logKeyFile, configPath)
 
-	var hasDifferences bool
-	var diffResults []string
+	report, err := buildDiffReport(ctx, config, options)
+	if err != nil {
+		return err
+	}
+
+	slog.InfoContext(ctx, "Successfully checked all jobs", "total_jobs", len(config))
+
+	if err := writeDiffReport(os.Stdout, report, options); err != nil {
+		return err
+	}
+
+	if report.HasDifferences {
+		return fmt.Errorf("%s: generated content differs from files on disk", errorFilesDiffer)
+	}
+
+	return nil
+}
+
+// buildDiffReport checks every job in the config and collects the status of each artifact.
+func buildDiffReport(ctx context.Context, config Config, options diffOptions) (diffReport, error) {
+	report := diffReport{Jobs: make([]jobDiffReport, 0, len(config))}
 
-	// Check each job in the config
 	for i, job := range config {
 		slog.InfoContext(ctx, "Checking job", "job_index", i+1, "specification", job.Specification)
 
-		jobDiffs, err := checkJobDifferences(ctx, job)
+		artifacts, err := checkJobDifferences(ctx, job, options.Strict)
 		if err != nil {
-			return fmt.Errorf("failed to check job %d (spec: %s): %w", i+1, job.Specification, err)
+			return diffReport{}, fmt.Errorf("failed to check job %d (spec: %s): %w", i+1, job.Specification, err)
 		}
 
-		if len(jobDiffs) > 0 {
-			hasDifferences = true
-			diffResults = append(diffResults, fmt.Sprintf("Job %d (spec: %s):", i+1, job.Specification))
-			diffResults = append(diffResults, jobDiffs...)
-			diffResults = append(diffResults, "")
+		for _, artifact := range artifacts {
+			if artifact.HasDifference() {
+				report.HasDifferences = true
+			}
 		}
+
+		report.Jobs = append(report.Jobs, jobDiffReport{
+			Job:           i + 1,
+			Specification: job.Specification,
+			Artifacts:     artifacts,
+		})
 	}
 
-	slog.InfoContext(ctx, "Successfully checked all jobs", "total_jobs", len(config))
+	return report, nil
+}
 
-	if hasDifferences {
-		fmt.Printf("Differences found:\n\n")
-		for _, result := range diffResults {
-			fmt.Println(result)
-		}
-		return fmt.Errorf("%s: generated content differs from files on disk", errorFilesDiffer)
+// writeDiffReport writes the diff report in the requested format.
+// Nothing is written in quiet mode, so only the exit code signals differences.
+func writeDiffReport(w io.Writer, report diffReport, options diffOptions) error {
+	if options.Quiet {
+		return nil
 	}
 
-	fmt.Printf("No differences found. All generated files match the files on disk.\n")
-	return nil
+	switch options.Report {
+	case reportFormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			return fmt.Errorf("failed to encode diff report: %w", err)
+		}
+		return nil
+	case reportFormatText:
+		writeDiffReportText(w, report)
+		return nil
+	default:
+		return fmt.Errorf("%s: %s", errorInvalidReport, options.Report)
+	}
 }
 
-// checkJobDifferences checks a single job for differences between generated content and disk files
-func checkJobDifferences(ctx context.Context, job Job) ([]string, error) {
-	var differences []string
+// writeDiffReportText writes the human-readable diff report.
+func writeDiffReportText(w io.Writer, report diffReport) {
+	if !report.HasDifferences {
+		fmt.Fprintf(w, "No differences found. All generated files match the files on disk.\n")
+		return
+	}
+
+	fmt.Fprintf(w, "Differences found:\n\n")
+	for _, job := range report.Jobs {
+		if !job.HasDifferences() {
+			continue
+		}
+
+		fmt.Fprintf(w, "Job %d (spec: %s):\n", job.Job, job.Specification)
+		for _, artifact := range job.Artifacts {
+			if artifact.HasDifference() {
+				fmt.Fprintf(w, "  %s (%s): %s\n", artifact.Artifact, artifact.Path, artifact.Diff)
+			}
+		}
+		fmt.Fprintln(w)
+	}
+}
 
+// checkJobDifferences checks a single job for differences between generated content and disk files.
+// JSON and YAML artifacts are compared semantically unless strict is set.
+func checkJobDifferences(ctx context.Context, job Job, strict bool) ([]artifactDiffReport, error) {
 	// Read and parse the specification file
 	service, err := readSpecificationFile(job.Specification)
 	if err != nil {
@@ -794,110 +3339,215 @@ func checkJobDifferences(ctx context.Context, job Job) ([]string, error) {
 
 	slog.InfoContext(ctx, "Successfully parsed specification file", logKeyFile, job.Specification)
 
-	// Check OpenAPI JSON output
-	if job.OpenAPIJSON != "" {
-		if diff, err := checkOpenAPIJSONDifference(ctx, service, job.OpenAPIJSON); err != nil {
-			return nil, fmt.Errorf("failed to check OpenAPI JSON '%s': %w", job.OpenAPIJSON, err)
-		} else if diff != "" {
-			differences = append(differences, fmt.Sprintf("  OpenAPI JSON (%s): %s", job.OpenAPIJSON, diff))
-		}
+	header, err := readProvenance(job.Specification)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read specification file '%s': %w", job.Specification, err)
 	}
 
-	// Check OpenAPI YAML output
-	if job.OpenAPIYAML != "" {
-		if diff, err := checkOpenAPIYAMLDifference(ctx, service, job.OpenAPIYAML); err != nil {
-			return nil, fmt.Errorf("failed to check OpenAPI YAML '%s': %w", job.OpenAPIYAML, err)
-		} else if diff != "" {
-			differences = append(differences, fmt.Sprintf("  OpenAPI YAML (%s): %s", job.OpenAPIYAML, diff))
-		}
+	if job.Prune {
+		service = specification.PruneUnusedDefinitions(service)
 	}
 
-	// Check Schema JSON output
-	if job.SchemaJSON != "" {
-		if diff, err := checkSchemaJSONDifference(ctx, service, job.SchemaJSON); err != nil {
-			return nil, fmt.Errorf("failed to check Schema JSON '%s': %w", job.SchemaJSON, err)
-		} else if diff != "" {
-			differences = append(differences, fmt.Sprintf("  Schema JSON (%s): %s", job.SchemaJSON, diff))
+	// Resolve {service} and {version} placeholders now that the specification is parsed
+	job = job.withService(service)
+
+	openAPIOptions, err := job.openAPIOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	checks := []struct {
+		artifact string
+		path     string
+		check    func(context.Context, *specification.Service, string, bool, provenance) (string, error)
+	}{
+		{artifactOpenAPIJSON, job.OpenAPIJSON, checkOpenAPIJSONDifference(openAPIOptions)},
+		{artifactOpenAPIYAML, job.OpenAPIYAML, checkOpenAPIYAMLDifference(openAPIOptions)},
+		{artifactSchemaJSON, job.SchemaJSON, checkSchemaJSONDifference},
+		{artifactOverlayYAML, job.OverlayYAML, checkOverlayDifference},
+		{artifactOverlayJSON, job.OverlayJSON, checkOverlayDifference},
+		{artifactServerGo, job.ServerGo, checkServerGoDifference(job.serverOptions())},
+		{artifactGateway, job.GatewayRoutes, checkGatewayRoutesDifference},
+		{artifactTerraform, job.TerraformSchema, checkTerraformSchemaDifference(job.terraformOptions())},
+	}
+
+	var artifacts []artifactDiffReport
+	for _, c := range checks {
+		if c.path == "" {
+			continue
+		}
+
+		// Files generated by another version of publicapis-gen are outdated, even if their content is the same
+		outdated, compareHeader, err := checkProvenance(c.path, header)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check %s '%s': %w", c.artifact, c.path, err)
 		}
+
+		diff, err := c.check(ctx, service, c.path, strict, compareHeader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check %s '%s': %w", c.artifact, c.path, err)
+		}
+
+		artifacts = append(artifacts, createArtifactDiffReport(c.artifact, c.path, diff, outdated))
 	}
 
-	// Check Overlay YAML output
-	if job.OverlayYAML != "" {
-		if diff, err := checkOverlayDifference(ctx, service, job.OverlayYAML); err != nil {
-			return nil, fmt.Errorf("failed to check Overlay YAML '%s': %w", job.OverlayYAML, err)
-		} else if diff != "" {
-			differences = append(differences, fmt.Sprintf("  Overlay YAML (%s): %s", job.OverlayYAML, diff))
+	if job.ServerDir != "" {
+		serverArtifacts, err := checkServerDirDifferences(service, job.serverOptions(), job.ServerDir, header)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check %s '%s': %w", artifactServerGo, job.ServerDir, err)
 		}
+		artifacts = append(artifacts, serverArtifacts...)
 	}
 
-	// Check Overlay JSON output
-	if job.OverlayJSON != "" {
-		if diff, err := checkOverlayDifference(ctx, service, job.OverlayJSON); err != nil {
-			return nil, fmt.Errorf("failed to check Overlay JSON '%s': %w", job.OverlayJSON, err)
-		} else if diff != "" {
-			differences = append(differences, fmt.Sprintf("  Overlay JSON (%s): %s", job.OverlayJSON, diff))
+	for _, sharedPackage := range job.SharedPackages {
+		outdated, compareHeader, err := checkProvenance(sharedPackage.Output, header)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check %s '%s': %w", artifactShared, sharedPackage.Output, err)
 		}
+
+		diff, err := checkSharedPackageDifference(service, job.serverOptions(), sharedPackage, strict, compareHeader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check %s '%s': %w", artifactShared, sharedPackage.Output, err)
+		}
+
+		artifacts = append(artifacts, createArtifactDiffReport(artifactShared, sharedPackage.Output, diff, outdated))
 	}
 
-	// Check Server Go output
-	if job.ServerGo != "" {
-		if diff, err := checkServerGoDifference(ctx, service, job.ServerGo); err != nil {
-			return nil, fmt.Errorf("failed to check Server Go '%s': %w", job.ServerGo, err)
-		} else if diff != "" {
-			differences = append(differences, fmt.Sprintf("  Server Go (%s): %s", job.ServerGo, diff))
+	for _, plugin := range job.Plugins {
+		generatedData, err := runPlugin(ctx, service, plugin, job.Specification)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check %s '%s': %w", artifactPlugin, plugin.Output, err)
+		}
+
+		diff, err := compareGeneratedWithDiskFile(plugin.Output, generatedData, strict)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check %s '%s': %w", artifactPlugin, plugin.Output, err)
 		}
+
+		artifacts = append(artifacts, createArtifactDiffReport(artifactPlugin, plugin.Output, diff, ""))
 	}
 
-	return differences, nil
+	return artifacts, nil
 }
 
-// checkOpenAPIJSONDifference checks if the generated OpenAPI JSON differs from the file on disk
-func checkOpenAPIJSONDifference(ctx context.Context, service *specification.Service, filePath string) (string, error) {
-	// Generate OpenAPI content in memory
-	generatedData, err := generateOpenAPIBytes(ctx, service)
+// checkServerDirDifferences checks each file of the server split across files in the directory, and reports the
+// resource files that are no longer generated as changed.
+func checkServerDirDifferences(service *specification.Service, options servergen.Options, outputDir string, header provenance) ([]artifactDiffReport, error) {
+	files, err := servergen.GenerateServerFiles(service, options)
 	if err != nil {
-		return "", err
+		return nil, fmt.Errorf("failed to generate server code: %w", err)
 	}
 
-	return compareWithDiskFile(filePath, generatedData)
-}
+	var artifacts []artifactDiffReport
+	for _, name := range slices.Sorted(maps.Keys(files)) {
+		path := filepath.Join(outputDir, name)
+
+		outdated, compareHeader, err := checkProvenance(path, header)
+		if err != nil {
+			return nil, err
+		}
+
+		diff, err := compareGeneratedWithDiskFile(path, compareHeader.apply(extGo, files[name]), false)
+		if err != nil {
+			return nil, err
+		}
+
+		artifacts = append(artifacts, createArtifactDiffReport(artifactServerGo, path, diff, outdated))
+	}
 
-// checkOpenAPIYAMLDifference checks if the generated OpenAPI YAML differs from the file on disk
-func checkOpenAPIYAMLDifference(ctx context.Context, service *specification.Service, filePath string) (string, error) {
-	// Generate OpenAPI JSON bytes first
-	jsonData, err := generateOpenAPIBytes(ctx, service)
+	staleFiles, err := findStaleServerFiles(outputDir, files)
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+	for _, path := range staleFiles {
+		artifacts = append(artifacts, createArtifactDiffReport(artifactServerGo, path, diffNotGenerated, ""))
 	}
 
-	// Parse JSON to interface{} so we can convert to YAML
-	var openAPIDoc interface{}
-	if err := json.Unmarshal(jsonData, &openAPIDoc); err != nil {
-		return "", fmt.Errorf("failed to parse generated OpenAPI JSON: %w", err)
+	return artifacts, nil
+}
+
+// createArtifactDiffReport creates the report entry for a single artifact from its diff output,
+// and the message of checkProvenance if the file was generated by another version of publicapis-gen.
+func createArtifactDiffReport(artifact, path, diff, outdated string) artifactDiffReport {
+	status := diffStatusChanged
+	switch {
+	case diff == diffFileNotExist:
+		status = diffStatusMissing
+	case outdated != "":
+		status = diffStatusOutdated
+		diff = strings.TrimSuffix(outdated+"\n"+diff, "\n")
+	case diff == "":
+		status = diffStatusOK
+	}
+
+	return artifactDiffReport{
+		Artifact: artifact,
+		Path:     path,
+		Status:   status,
+		Diff:     diff,
 	}
+}
 
-	// Convert to YAML
-	yamlData, err := yaml.Marshal(openAPIDoc)
-	if err != nil {
-		return "", fmt.Errorf("failed to convert OpenAPI document to YAML: %w", err)
+// checkOpenAPIJSONDifference returns a check of whether the OpenAPI JSON generated with the options differs from the file on disk
+func checkOpenAPIJSONDifference(options openapigen.Options) func(context.Context, *specification.Service, string, bool, provenance) (string, error) {
+	return func(ctx context.Context, service *specification.Service, filePath string, strict bool, header provenance) (string, error) {
+		// Generate OpenAPI content in memory
+		generatedData, err := generateOpenAPIBytes(ctx, service, options)
+		if err != nil {
+			return "", err
+		}
+
+		return compareGeneratedWithDiskFile(filePath, header.apply(filepath.Ext(filePath), generatedData), strict)
 	}
+}
+
+// checkOpenAPIYAMLDifference returns a check of whether the OpenAPI YAML generated with the options differs from the file on disk
+func checkOpenAPIYAMLDifference(options openapigen.Options) func(context.Context, *specification.Service, string, bool, provenance) (string, error) {
+	return func(ctx context.Context, service *specification.Service, filePath string, strict bool, header provenance) (string, error) {
+		yamlData, err := generateOpenAPIYAMLBytes(ctx, service, options)
+		if err != nil {
+			return "", err
+		}
 
-	return compareWithDiskFile(filePath, yamlData)
+		return compareGeneratedWithDiskFile(filePath, header.apply(filepath.Ext(filePath), yamlData), strict)
+	}
 }
 
 // checkSchemaJSONDifference checks if the generated Schema JSON differs from the file on disk
-func checkSchemaJSONDifference(ctx context.Context, service *specification.Service, filePath string) (string, error) {
+func checkSchemaJSONDifference(ctx context.Context, service *specification.Service, filePath string, strict bool, header provenance) (string, error) {
 	// Generate schemas in memory
 	var buf bytes.Buffer
 	if err := schemagen.GenerateSchemas(&buf); err != nil {
 		return "", fmt.Errorf("failed to generate schemas: %w", err)
 	}
 
-	return compareWithDiskFile(filePath, buf.Bytes())
+	return compareGeneratedWithDiskFile(filePath, header.apply(filepath.Ext(filePath), buf.Bytes()), strict)
+}
+
+// checkGatewayRoutesDifference checks if the generated gateway routes differ from the file on disk
+func checkGatewayRoutesDifference(ctx context.Context, service *specification.Service, filePath string, strict bool, header provenance) (string, error) {
+	var buf bytes.Buffer
+	if err := gatewaygen.GenerateRoutes(&buf, service); err != nil {
+		return "", fmt.Errorf("failed to generate gateway routes: %w", err)
+	}
+
+	return compareGeneratedWithDiskFile(filePath, header.apply(filepath.Ext(filePath), buf.Bytes()), strict)
+}
+
+// checkTerraformSchemaDifference returns a check of whether the generated Terraform schema differs from the file on disk
+func checkTerraformSchemaDifference(options terraformgen.Options) func(context.Context, *specification.Service, string, bool, provenance) (string, error) {
+	return func(ctx context.Context, service *specification.Service, filePath string, strict bool, header provenance) (string, error) {
+		var buf bytes.Buffer
+		if err := terraformgen.GenerateSchemaWithOptions(&buf, service, options); err != nil {
+			return "", fmt.Errorf("failed to generate Terraform schema: %w", err)
+		}
+
+		return compareGeneratedWithDiskFile(filePath, header.apply(filepath.Ext(filePath), buf.Bytes()), strict)
+	}
 }
 
 // checkOverlayDifference checks if the generated overlay differs from the file on disk
-func checkOverlayDifference(ctx context.Context, service *specification.Service, filePath string) (string, error) {
+func checkOverlayDifference(ctx context.Context, service *specification.Service, filePath string, strict bool, header provenance) (string, error) {
 	// Determine output format based on extension
 	ext := strings.ToLower(filepath.Ext(filePath))
 	var generatedData []byte
@@ -905,35 +3555,212 @@ func checkOverlayDifference(ctx context.Context, service *specification.Service,
 
 	switch ext {
 	case extYAML, extYML:
-		generatedData, err = yaml.Marshal(service)
+		generatedData, err = specification.MarshalServiceYAML(service)
 		if err != nil {
 			return "", fmt.Errorf("failed to marshal specification to YAML: %w", err)
 		}
 	case extJSON:
-		generatedData, err = json.MarshalIndent(service, "", "  ")
+		generatedData, err = specification.MarshalServiceJSON(service)
 		if err != nil {
 			return "", fmt.Errorf("failed to marshal specification to JSON: %w", err)
 		}
 	default:
 		// Default to YAML if extension is not recognized
-		generatedData, err = yaml.Marshal(service)
+		generatedData, err = specification.MarshalServiceYAML(service)
 		if err != nil {
 			return "", fmt.Errorf("failed to marshal specification to YAML: %w", err)
 		}
 	}
 
-	return compareWithDiskFile(filePath, generatedData)
+	return compareGeneratedWithDiskFile(filePath, header.apply(filepath.Ext(filePath), generatedData), strict)
 }
 
-// checkServerGoDifference checks if the generated Server Go code differs from the file on disk
-func checkServerGoDifference(ctx context.Context, service *specification.Service, filePath string) (string, error) {
-	// Generate server code in memory
+// checkServerGoDifference returns a check of whether the Server Go code generated with the options differs from the file on disk
+func checkServerGoDifference(options servergen.Options) func(context.Context, *specification.Service, string, bool, provenance) (string, error) {
+	return func(ctx context.Context, service *specification.Service, filePath string, strict bool, header provenance) (string, error) {
+		// Generate server code in memory
+		var buf bytes.Buffer
+		if err := servergen.GenerateServerWithOptions(&buf, service, options); err != nil {
+			return "", fmt.Errorf("failed to generate server code: %w", err)
+		}
+
+		return compareGeneratedWithDiskFile(filePath, header.apply(filepath.Ext(filePath), buf.Bytes()), strict)
+	}
+}
+
+// checkSharedPackageDifference returns the difference between the shared package generated with the options and the file on disk
+func checkSharedPackageDifference(service *specification.Service, options servergen.Options, sharedPackage SharedPackage, strict bool, header provenance) (string, error) {
 	var buf bytes.Buffer
-	if err := servergen.GenerateServer(&buf, service); err != nil {
-		return "", fmt.Errorf("failed to generate server code: %w", err)
+	if err := servergen.GenerateSharedPackage(&buf, service, sharedPackage.Library, sharedPackage.Import, options.Types); err != nil {
+		return "", fmt.Errorf("failed to generate shared package: %w", err)
+	}
+
+	return compareGeneratedWithDiskFile(sharedPackage.Output, header.apply(filepath.Ext(sharedPackage.Output), buf.Bytes()), strict)
+}
+
+// compareGeneratedWithDiskFile compares generated content with a file on disk.
+// JSON and YAML files are compared semantically, ignoring key ordering and formatting,
+// unless strict is set, in which case they are compared byte by byte like all other files.
+func compareGeneratedWithDiskFile(filePath string, generatedData []byte, strict bool) (string, error) {
+	ext := strings.ToLower(filepath.Ext(filePath))
+	if strict || (ext != extJSON && ext != extYAML && ext != extYML) {
+		return compareWithDiskFile(filePath, generatedData)
+	}
+
+	return compareSemanticWithDiskFile(filePath, generatedData)
+}
+
+// compareSemanticWithDiskFile parses the generated content and the file on disk
+// and reports the structural differences between the two documents.
+func compareSemanticWithDiskFile(filePath string, generatedData []byte) (string, error) {
+	// Check if file exists
+	if _, err := os.Stat(filePath); err != nil {
+		if os.IsNotExist(err) {
+			return diffFileNotExist, nil
+		}
+		return "", fmt.Errorf("%s: cannot access file: %w", errorFileRead, err)
+	}
+
+	// Read file content from disk
+	diskData, err := os.ReadFile(filePath)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", errorFileRead, err)
+	}
+
+	generatedDoc, err := parseDocument(generatedData)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse generated content: %w", err)
+	}
+
+	diskDoc, err := parseDocument(diskData)
+	if err != nil {
+		return fmt.Sprintf("%s: %v", diffDiskUnparsable, err), nil
+	}
+
+	differences := findStructuralDifferences(diffRootPath, generatedDoc, diskDoc, nil)
+	if len(differences) == 0 {
+		return "", nil
+	}
+
+	var diffOutput strings.Builder
+	diffOutput.WriteString(diffSemanticDiffers)
+	diffOutput.WriteString("\n")
+	for i, difference := range differences {
+		if i == diffMaxLinesToShow {
+			diffOutput.WriteString(fmt.Sprintf("\n... and %d more difference(s)\n", len(differences)-diffMaxLinesToShow))
+			break
+		}
+		diffOutput.WriteString(diffLinePrefix)
+		diffOutput.WriteString(difference)
+		diffOutput.WriteString("\n")
+	}
+
+	return diffOutput.String(), nil
+}
+
+// parseDocument parses JSON or YAML content into generic values.
+// The content is normalized through JSON so that both formats produce comparable values.
+func parseDocument(data []byte) (any, error) {
+	var document any
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+
+	normalized, err := json.Marshal(document)
+	if err != nil {
+		return nil, err
+	}
+
+	var result any
+	if err := json.Unmarshal(normalized, &result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// findStructuralDifferences walks both documents and returns a description of every
+// path where they differ, using JSON pointer notation.
+func findStructuralDifferences(path string, generated, disk any, differences []string) []string {
+	switch generatedValue := generated.(type) {
+	case map[string]any:
+		diskValue, ok := disk.(map[string]any)
+		if !ok {
+			return append(differences, describeDifference(path, generated, disk))
+		}
+
+		keys := make([]string, 0, len(generatedValue)+len(diskValue))
+		for key := range generatedValue {
+			keys = append(keys, key)
+		}
+		for key := range diskValue {
+			if _, exists := generatedValue[key]; !exists {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			childPath := joinPointer(path, key)
+			generatedChild, inGenerated := generatedValue[key]
+			diskChild, inDisk := diskValue[key]
+			switch {
+			case !inDisk:
+				differences = append(differences, fmt.Sprintf("%s: missing on disk", childPath))
+			case !inGenerated:
+				differences = append(differences, fmt.Sprintf("%s: not in generated content", childPath))
+			default:
+				differences = findStructuralDifferences(childPath, generatedChild, diskChild, differences)
+			}
+		}
+		return differences
+	case []any:
+		diskValue, ok := disk.([]any)
+		if !ok || len(generatedValue) != len(diskValue) {
+			return append(differences, describeDifference(path, generated, disk))
+		}
+
+		for i := range generatedValue {
+			differences = findStructuralDifferences(joinPointer(path, strconv.Itoa(i)), generatedValue[i], diskValue[i], differences)
+		}
+		return differences
+	default:
+		if !reflect.DeepEqual(generated, disk) {
+			return append(differences, describeDifference(path, generated, disk))
+		}
+		return differences
+	}
+}
+
+// describeDifference formats a single value difference.
+func describeDifference(path string, generated, disk any) string {
+	return fmt.Sprintf("%s: generated %s, on disk %s", path, summarizeValue(generated), summarizeValue(disk))
+}
+
+// summarizeValue returns a short description of a value for diff output.
+func summarizeValue(value any) string {
+	switch v := value.(type) {
+	case map[string]any:
+		return fmt.Sprintf("object with %d key(s)", len(v))
+	case []any:
+		return fmt.Sprintf("array with %d item(s)", len(v))
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprintf("%v", v)
+		}
+		return string(data)
 	}
+}
 
-	return compareWithDiskFile(filePath, buf.Bytes())
+// joinPointer appends an escaped token to a JSON pointer.
+func joinPointer(path, token string) string {
+	token = strings.ReplaceAll(token, "~", "~0")
+	token = strings.ReplaceAll(token, "/", "~1")
+	if path == diffRootPath {
+		return diffRootPath + token
+	}
+	return path + "/" + token
 }
 
 // compareWithDiskFile compares generated content with the content of a file on disk
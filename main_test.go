@@ -4,13 +4,26 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"flag"
+	"fmt"
+	"io"
 	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	yaml "github.com/goccy/go-yaml"
 	"github.com/meitner-se/publicapis-gen/specification"
+	"github.com/meitner-se/publicapis-gen/specification/conformance"
+	"github.com/meitner-se/publicapis-gen/specification/coveragegen"
+	"github.com/meitner-se/publicapis-gen/specification/schemagen"
+	"github.com/meitner-se/publicapis-gen/specification/servergen"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -457,6 +470,239 @@ func Test_run(t *testing.T) {
 	})
 }
 
+func Test_runSingleSpecMode(t *testing.T) {
+	const specYAML = `name: Pipeline
+version: v1
+resources:
+  - name: Note
+    operations: [Get]
+    fields:
+      - name: Title
+        type: String
+`
+
+	t.Run("reads from stdin and writes to stdout", func(t *testing.T) {
+		// Arrange
+		stdin := strings.NewReader(specYAML)
+		var stdout bytes.Buffer
+
+		// Act
+		err := runSingleSpecMode(context.Background(), stdioPath, modeOpenAPI, stdioPath, false, stdin, &stdout)
+
+		// Assert
+		require.NoError(t, err)
+		var document map[string]any
+		require.NoError(t, json.Unmarshal(stdout.Bytes(), &document), "stdout should contain only the OpenAPI JSON document")
+		assert.Contains(t, document, "openapi")
+		assert.Contains(t, document[provenanceJSONKey], newProvenance([]byte(specYAML)).String())
+	})
+
+	t.Run("writes to output file", func(t *testing.T) {
+		// Arrange
+		outputPath := filepath.Join(t.TempDir(), "server.go")
+		var stdout bytes.Buffer
+
+		// Act
+		err := runSingleSpecMode(context.Background(), stdioPath, modeServer, outputPath, false, strings.NewReader(specYAML), &stdout)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Empty(t, stdout.String(), "Nothing should be written to stdout")
+		content, err := os.ReadFile(outputPath)
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "package api")
+	})
+
+	t.Run("writes gateway routes", func(t *testing.T) {
+		// Arrange
+		var stdout bytes.Buffer
+
+		// Act
+		err := runSingleSpecMode(context.Background(), stdioPath, modeGateway, stdioPath, false, strings.NewReader(specYAML), &stdout)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Contains(t, stdout.String(), "kind: HTTPRoute")
+		assert.Contains(t, stdout.String(), "name: pipeline-note")
+		assert.Contains(t, stdout.String(), newProvenance([]byte(specYAML)).String())
+	})
+
+	t.Run("writes a Terraform schema", func(t *testing.T) {
+		// Arrange
+		const manageableSpecYAML = `name: Pipeline
+version: v1
+resources:
+  - name: Note
+    operations: [Create, Get]
+    fields:
+      - name: Title
+        type: String
+        operations: [Create, Read]
+`
+		var stdout bytes.Buffer
+
+		// Act
+		err := runSingleSpecMode(context.Background(), stdioPath, modeTerraform, stdioPath, false, strings.NewReader(manageableSpecYAML), &stdout)
+
+		// Assert
+		require.NoError(t, err)
+		var schema map[string]any
+		require.NoError(t, json.Unmarshal(stdout.Bytes(), &schema), "stdout should contain only the Terraform schema")
+		assert.Equal(t, map[string]any{"name": "pipeline"}, schema["provider"])
+		assert.Contains(t, stdout.String(), `"name": "title"`)
+		assert.Contains(t, schema[provenanceJSONKey], newProvenance([]byte(manageableSpecYAML)).String())
+	})
+
+	t.Run("returns error for missing mode", func(t *testing.T) {
+		// Act
+		err := runSingleSpecMode(context.Background(), stdioPath, "", stdioPath, false, strings.NewReader(specYAML), io.Discard)
+
+		// Assert
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), errorMissingMode)
+	})
+
+	t.Run("returns error for invalid mode", func(t *testing.T) {
+		// Act
+		err := runSingleSpecMode(context.Background(), stdioPath, "invalid", stdioPath, false, strings.NewReader(specYAML), io.Discard)
+
+		// Assert
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), errorInvalidMode)
+	})
+
+	t.Run("prunes unused definitions", func(t *testing.T) {
+		// Arrange
+		spec := specYAML + "objects:\n  - name: Legacy\n    fields:\n      - name: Name\n        type: String\n"
+		var stdout, prunedStdout bytes.Buffer
+
+		// Act
+		err := runSingleSpecMode(context.Background(), stdioPath, modeOverlay, stdioPath, false, strings.NewReader(spec), &stdout)
+		require.NoError(t, err)
+		err = runSingleSpecMode(context.Background(), stdioPath, modeOverlay, stdioPath, true, strings.NewReader(spec), &prunedStdout)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Contains(t, stdout.String(), "name: Legacy")
+		assert.NotContains(t, prunedStdout.String(), "name: Legacy")
+	})
+}
+
+func Test_provenance_apply(t *testing.T) {
+	header := newProvenance([]byte("name: Test"))
+	line := "Generated by " + header.String() + "."
+
+	testCases := []struct {
+		name          string
+		fileExtension string
+		data          string
+		expected      string
+	}{
+		{name: "Go comment", fileExtension: extGo, data: "package api\n", expected: "// " + line + "\npackage api\n"},
+		{name: "YAML comment", fileExtension: extYAML, data: "name: Test\n", expected: "# " + line + "\nname: Test\n"},
+		{name: "YML comment", fileExtension: ".YML", data: "name: Test\n", expected: "# " + line + "\nname: Test\n"},
+		{
+			name:          "indented JSON object",
+			fileExtension: extJSON,
+			data:          "{\n  \"name\": \"Test\"\n}\n",
+			expected:      "{\n  \"x-generated-by\": \"" + header.String() + "\",\n  \"name\": \"Test\"\n}\n",
+		},
+		{name: "empty JSON object", fileExtension: extJSON, data: "{}", expected: "{\"x-generated-by\": \"" + header.String() + "\"}"},
+		{name: "JSON array unchanged", fileExtension: extJSON, data: "[]", expected: "[]"},
+		{name: "unknown extension unchanged", fileExtension: ".txt", data: "text", expected: "text"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			result := header.apply(tc.fileExtension, []byte(tc.data))
+
+			// Assert
+			assert.Equal(t, tc.expected, string(result))
+		})
+	}
+
+	t.Run("returns data unchanged without provenance", func(t *testing.T) {
+		// Act
+		result := provenance{}.apply(extGo, []byte("package api\n"))
+
+		// Assert
+		assert.Equal(t, "package api\n", string(result))
+	})
+
+	t.Run("keeps JSON valid", func(t *testing.T) {
+		// Act
+		result := header.apply(extJSON, []byte(`{"a": 1, "b": [2]}`))
+
+		// Assert
+		var document map[string]any
+		require.NoError(t, json.Unmarshal(result, &document))
+		assert.Equal(t, header.String(), document[provenanceJSONKey])
+		assert.Len(t, document, 3)
+	})
+}
+
+func Test_checkProvenance(t *testing.T) {
+	header := provenance{ToolVersion: "v1.2.0", SpecificationHash: strings.Repeat("a", 64)}
+	olderHeader := provenance{ToolVersion: "v1.1.0", SpecificationHash: header.SpecificationHash}
+
+	writeFile := func(t *testing.T, content []byte) string {
+		path := filepath.Join(t.TempDir(), "server.go")
+		require.NoError(t, os.WriteFile(path, content, 0644))
+		return path
+	}
+
+	t.Run("file generated by this version", func(t *testing.T) {
+		// Arrange
+		path := writeFile(t, header.apply(extGo, []byte("package api\n")))
+
+		// Act
+		outdated, compareHeader, err := checkProvenance(path, header)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Empty(t, outdated)
+		assert.Equal(t, header, compareHeader)
+	})
+
+	t.Run("file generated by another version", func(t *testing.T) {
+		// Arrange
+		path := writeFile(t, olderHeader.apply(extGo, []byte("package api\n")))
+
+		// Act
+		outdated, compareHeader, err := checkProvenance(path, header)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Contains(t, outdated, "v1.1.0")
+		assert.Contains(t, outdated, "v1.2.0")
+		assert.Equal(t, olderHeader, compareHeader, "Content should be compared as generated by the version on disk")
+	})
+
+	t.Run("file without provenance header", func(t *testing.T) {
+		// Arrange
+		path := writeFile(t, []byte("package api\n"))
+
+		// Act
+		outdated, compareHeader, err := checkProvenance(path, header)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, diffNoProvenance, outdated)
+		assert.Equal(t, provenance{}, compareHeader)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		// Act
+		outdated, compareHeader, err := checkProvenance(filepath.Join(t.TempDir(), "server.go"), header)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Empty(t, outdated)
+		assert.Equal(t, header, compareHeader)
+	})
+}
+
 func Test_readSpecificationFile(t *testing.T) {
 	t.Run("reads valid YAML file", func(t *testing.T) {
 		// Create a temporary YAML file
@@ -545,6 +791,61 @@ func Test_readSpecificationFile(t *testing.T) {
 	})
 }
 
+func Test_printValidationErrors(t *testing.T) {
+	t.Run("prints the validation errors with their locations", func(t *testing.T) {
+		// Arrange
+		specPath := filepath.Join(t.TempDir(), "spec.yaml")
+		spec := `name: Library
+resources:
+  - name: Books
+    description: Books of the library
+    operations: [Create, Fetch]
+    fields:
+      - name: title
+        description: Title of the book
+        type: Strin
+        operations: [Create, Read]
+`
+		require.NoError(t, os.WriteFile(specPath, []byte(spec), 0644))
+		_, err := readSpecificationFile(specPath)
+		require.Error(t, err)
+		var buf bytes.Buffer
+
+		// Act
+		printValidationErrors(&buf, fmt.Errorf("failed to read specification file: %w", err))
+
+		// Assert
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		require.Len(t, lines, 3)
+		assert.Equal(t, "2 validation error(s):", lines[0])
+		assert.True(t, strings.HasPrefix(lines[1], "  "+specPath+":5:17: resource 0 (Books): resource operations: invalid operation"), lines[1])
+		assert.True(t, strings.HasPrefix(lines[2], "  "+specPath+":9:15: resource 0 (Books): field 0 (title): field type: invalid field type"), lines[2])
+	})
+
+	t.Run("prints the path without a position", func(t *testing.T) {
+		// Arrange
+		err := specification.ValidationErrors{{Message: "invalid", Path: "$.resources[0]"}}
+		var buf bytes.Buffer
+
+		// Act
+		printValidationErrors(&buf, err)
+
+		// Assert
+		assert.Equal(t, "1 validation error(s):\n  $.resources[0]: invalid\n", buf.String())
+	})
+
+	t.Run("prints nothing for other errors", func(t *testing.T) {
+		// Arrange
+		var buf bytes.Buffer
+
+		// Act
+		printValidationErrors(&buf, errors.New("failed"))
+
+		// Assert
+		assert.Empty(t, buf.String())
+	})
+}
+
 func Test_generateOutputPath(t *testing.T) {
 	testCases := []struct {
 		name      string
@@ -1003,621 +1304,2672 @@ func Test_parseConfigFile(t *testing.T) {
 		assert.Contains(t, err.Error(), errorInvalidConfig, "Error should mention invalid config")
 		assert.Contains(t, err.Error(), "at least one output format", "Error should mention missing output formats")
 	})
-}
 
-func Test_generateOpenAPIYAMLOutputPath(t *testing.T) {
-	testCases := []struct {
-		name      string
-		inputFile string
-		expected  string
-	}{
-		{
-			name:      "YAML file generates YAML OpenAPI",
-			inputFile: "spec.yaml",
-			expected:  "spec-openapi.yaml",
-		},
-		{
-			name:      "JSON file generates YAML OpenAPI",
-			inputFile: "api.json",
-			expected:  "api-openapi.yaml",
-		},
-		{
-			name:      "File with path generates YAML OpenAPI",
-			inputFile: "/path/to/spec.yml",
-			expected:  "/path/to/spec-openapi.yaml",
-		},
-		{
-			name:      "File without extension generates YAML OpenAPI",
-			inputFile: "spec",
-			expected:  "spec-openapi.yaml",
-		},
-	}
+	t.Run("parses extensions profile", func(t *testing.T) {
+		tmpConfigFile, err := os.CreateTemp("", "test-extensions-*.yaml")
+		require.NoError(t, err)
+		defer os.Remove(tmpConfigFile.Name())
 
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			// Act
-			result := generateOpenAPIYAMLOutputPath(tc.inputFile)
+		_, err = tmpConfigFile.Write([]byte(`- specification: spec.yaml
+  openapi_json: partner.json
+  extensions:
+    speakeasy: false
+    operations:
+      "*":
+        x-internal: false
+`))
+		require.NoError(t, err)
+		tmpConfigFile.Close()
 
-			// Assert
-			assert.Equal(t, tc.expected, result)
-		})
-	}
-}
+		// Act
+		config, err := parseConfigFile(tmpConfigFile.Name())
 
-func Test_ensureYAMLExtension(t *testing.T) {
-	testCases := []struct {
-		name       string
-		outputPath string
-		expected   string
-	}{
-		{
-			name:       "YAML extension unchanged",
-			outputPath: "api-spec.yaml",
-			expected:   "api-spec.yaml",
-		},
-		{
-			name:       "YML extension unchanged",
-			outputPath: "api-spec.yml",
-			expected:   "api-spec.yml",
-		},
-		{
-			name:       "JSON extension changed to YAML",
-			outputPath: "api-spec.json",
-			expected:   "api-spec.yaml",
-		},
-		{
-			name:       "No extension gets YAML extension",
-			outputPath: "api-spec",
-			expected:   "api-spec.yaml",
-		},
-		{
-			name:       "Other extension changed to YAML",
-			outputPath: "api-spec.xml",
-			expected:   "api-spec.yaml",
-		},
-		{
-			name:       "Path with YAML extension unchanged",
-			outputPath: "/path/to/api-spec.yaml",
-			expected:   "/path/to/api-spec.yaml",
-		},
-		{
-			name:       "Path with JSON extension changed to YAML",
-			outputPath: "/path/to/api-spec.json",
-			expected:   "/path/to/api-spec.yaml",
-		},
-	}
+		// Assert
+		require.NoError(t, err)
+		require.NotNil(t, config[0].Extensions, "Extensions profile should be parsed")
+		assert.False(t, config[0].Extensions.IsSpeakeasyEnabled(), "Speakeasy extensions should be disabled")
+		assert.Equal(t, false, config[0].Extensions.Operations["*"]["x-internal"])
+	})
 
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			// Act
-			result := ensureYAMLExtension(tc.outputPath)
+	t.Run("returns error for extension without x- prefix", func(t *testing.T) {
+		tmpConfigFile, err := os.CreateTemp("", "test-invalid-extensions-*.yaml")
+		require.NoError(t, err)
+		defer os.Remove(tmpConfigFile.Name())
 
-			// Assert
-			assert.Equal(t, tc.expected, result)
-		})
-	}
-}
+		_, err = tmpConfigFile.Write([]byte(`- specification: spec.yaml
+  openapi_json: partner.json
+  extensions:
+    document:
+      internal: true
+`))
+		require.NoError(t, err)
+		tmpConfigFile.Close()
 
-func TestGenerateTestFilePath(t *testing.T) {
-	testCases := []struct {
-		name           string
-		serverGoPath   string
-		expectedResult string
-	}{
-		{
-			name:           "basic .go file",
-			serverGoPath:   "api.go",
-			expectedResult: "api_test.go",
-		},
-		{
-			name:           "generated .gen.go file",
-			serverGoPath:   "api.gen.go",
-			expectedResult: "api_test.gen.go",
-		},
-		{
+		// Act
+		config, err := parseConfigFile(tmpConfigFile.Name())
+
+		// Assert
+		require.Error(t, err)
+		assert.Nil(t, config, "Config should be nil on error")
+		assert.Contains(t, err.Error(), "name must start with x-", "Error should mention the extension prefix")
+	})
+
+	t.Run("parses plugins as outputs", func(t *testing.T) {
+		tmpConfigFile, err := os.CreateTemp("", "test-plugins-*.yaml")
+		require.NoError(t, err)
+		defer os.Remove(tmpConfigFile.Name())
+
+		_, err = tmpConfigFile.Write([]byte(`- specification: spec.yaml
+  plugins:
+    - plugin: ./gateway-generator --format yaml
+      output: gateway.yaml
+`))
+		require.NoError(t, err)
+		tmpConfigFile.Close()
+
+		// Act
+		config, err := parseConfigFile(tmpConfigFile.Name())
+
+		// Assert
+		require.NoError(t, err, "A job with only plugins should be valid")
+		require.Len(t, config[0].Plugins, 1)
+		assert.Equal(t, Plugin{Command: "./gateway-generator --format yaml", Output: "gateway.yaml"}, config[0].Plugins[0])
+	})
+
+	t.Run("returns error for plugin without output", func(t *testing.T) {
+		tmpConfigFile, err := os.CreateTemp("", "test-invalid-plugins-*.yaml")
+		require.NoError(t, err)
+		defer os.Remove(tmpConfigFile.Name())
+
+		_, err = tmpConfigFile.Write([]byte(`- specification: spec.yaml
+  openapi_json: openapi.json
+  plugins:
+    - plugin: ./gateway-generator
+`))
+		require.NoError(t, err)
+		tmpConfigFile.Close()
+
+		// Act
+		config, err := parseConfigFile(tmpConfigFile.Name())
+
+		// Assert
+		require.Error(t, err)
+		assert.Nil(t, config, "Config should be nil on error")
+		assert.Contains(t, err.Error(), errorInvalidPlugin)
+	})
+
+	t.Run("parses shared packages", func(t *testing.T) {
+		tmpConfigFile, err := os.CreateTemp("", "test-shared-packages-*.yaml")
+		require.NoError(t, err)
+		defer os.Remove(tmpConfigFile.Name())
+
+		_, err = tmpConfigFile.Write([]byte(`- specification: spec.yaml
+  server_go: api/server.go
+  shared_packages:
+    - library: Common
+      import: example.com/shared/common
+      output: shared/common/common.go
+`))
+		require.NoError(t, err)
+		tmpConfigFile.Close()
+
+		// Act
+		config, err := parseConfigFile(tmpConfigFile.Name())
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, config[0].SharedPackages, 1)
+		assert.Equal(t, SharedPackage{Library: "Common", Import: "example.com/shared/common", Output: "shared/common/common.go"}, config[0].SharedPackages[0])
+		assert.Equal(t, map[string]string{"Common": "example.com/shared/common"}, config[0].serverOptions().SharedPackages)
+		assert.Contains(t, config[0].getOutputPaths(), "shared/common/common.go", "The shared package should be an output of the job")
+	})
+
+	t.Run("returns error for shared package without import path", func(t *testing.T) {
+		tmpConfigFile, err := os.CreateTemp("", "test-invalid-shared-packages-*.yaml")
+		require.NoError(t, err)
+		defer os.Remove(tmpConfigFile.Name())
+
+		_, err = tmpConfigFile.Write([]byte(`- specification: spec.yaml
+  server_go: api/server.go
+  shared_packages:
+    - library: Common
+      output: shared/common/common.go
+`))
+		require.NoError(t, err)
+		tmpConfigFile.Close()
+
+		// Act
+		config, err := parseConfigFile(tmpConfigFile.Name())
+
+		// Assert
+		require.Error(t, err)
+		assert.Nil(t, config, "Config should be nil on error")
+		assert.Contains(t, err.Error(), errorInvalidSharedPackage)
+	})
+
+	t.Run("returns error for missing templates directory", func(t *testing.T) {
+		tmpConfigFile, err := os.CreateTemp("", "test-templates-*.yaml")
+		require.NoError(t, err)
+		defer os.Remove(tmpConfigFile.Name())
+
+		_, err = tmpConfigFile.Write([]byte(`- specification: spec.yaml
+  server_go: server.go
+  templates_dir: does-not-exist
+`))
+		require.NoError(t, err)
+		tmpConfigFile.Close()
+
+		// Act
+		config, err := parseConfigFile(tmpConfigFile.Name())
+
+		// Assert
+		require.Error(t, err)
+		assert.Nil(t, config, "Config should be nil on error")
+		assert.Contains(t, err.Error(), "templates_dir 'does-not-exist' is not a directory")
+	})
+
+	t.Run("returns error for invalid OpenAPI overlay", func(t *testing.T) {
+		overlayPath := filepath.Join(t.TempDir(), "patches.yaml")
+		require.NoError(t, os.WriteFile(overlayPath, []byte("overlay: 1.0.0\nactions: []\n"), 0644))
+		tmpConfigFile, err := os.CreateTemp("", "test-openapi-overlay-*.yaml")
+		require.NoError(t, err)
+		defer os.Remove(tmpConfigFile.Name())
+
+		_, err = tmpConfigFile.Write([]byte(`- specification: spec.yaml
+  openapi_json: openapi.json
+  openapi_overlay: ` + overlayPath + `
+`))
+		require.NoError(t, err)
+		tmpConfigFile.Close()
+
+		// Act
+		config, err := parseConfigFile(tmpConfigFile.Name())
+
+		// Assert
+		require.Error(t, err)
+		assert.Nil(t, config, "Config should be nil on error")
+		assert.Contains(t, err.Error(), "overlay info title must be defined")
+	})
+
+	t.Run("returns error for OpenAPI overlay without OpenAPI output", func(t *testing.T) {
+		tmpConfigFile, err := os.CreateTemp("", "test-openapi-overlay-*.yaml")
+		require.NoError(t, err)
+		defer os.Remove(tmpConfigFile.Name())
+
+		_, err = tmpConfigFile.Write([]byte(`- specification: spec.yaml
+  server_go: server.go
+  openapi_overlay: patches.yaml
+`))
+		require.NoError(t, err)
+		tmpConfigFile.Close()
+
+		// Act
+		config, err := parseConfigFile(tmpConfigFile.Name())
+
+		// Assert
+		require.Error(t, err)
+		assert.Nil(t, config, "Config should be nil on error")
+		assert.Contains(t, err.Error(), "openapi_overlay requires openapi_json or openapi_yaml")
+	})
+
+	t.Run("returns error for server_go combined with server_dir", func(t *testing.T) {
+		tmpConfigFile, err := os.CreateTemp("", "test-server-dir-*.yaml")
+		require.NoError(t, err)
+		defer os.Remove(tmpConfigFile.Name())
+
+		_, err = tmpConfigFile.Write([]byte(`- specification: spec.yaml
+  server_go: server.go
+  server_dir: server
+`))
+		require.NoError(t, err)
+		tmpConfigFile.Close()
+
+		// Act
+		config, err := parseConfigFile(tmpConfigFile.Name())
+
+		// Assert
+		require.Error(t, err)
+		assert.Nil(t, config, "Config should be nil on error")
+		assert.Contains(t, err.Error(), "server_go and server_dir cannot be combined")
+	})
+
+	t.Run("returns error for types_package combined with stdlib_types", func(t *testing.T) {
+		tmpConfigFile, err := os.CreateTemp("", "test-types-*.yaml")
+		require.NoError(t, err)
+		defer os.Remove(tmpConfigFile.Name())
+
+		_, err = tmpConfigFile.Write([]byte(`- specification: spec.yaml
+  server_go: server.go
+  types_package: example.com/adopter/types
+  stdlib_types: true
+`))
+		require.NoError(t, err)
+		tmpConfigFile.Close()
+
+		// Act
+		config, err := parseConfigFile(tmpConfigFile.Name())
+
+		// Assert
+		require.Error(t, err)
+		assert.Nil(t, config, "Config should be nil on error")
+		assert.Contains(t, err.Error(), "types_package and stdlib_types cannot be combined")
+	})
+
+	t.Run("returns error for terraform_resources without terraform_schema", func(t *testing.T) {
+		tmpConfigFile, err := os.CreateTemp("", "test-terraform-*.yaml")
+		require.NoError(t, err)
+		defer os.Remove(tmpConfigFile.Name())
+
+		_, err = tmpConfigFile.Write([]byte(`- specification: spec.yaml
+  openapi_json: openapi.json
+  terraform_resources: [User]
+`))
+		require.NoError(t, err)
+		tmpConfigFile.Close()
+
+		// Act
+		config, err := parseConfigFile(tmpConfigFile.Name())
+
+		// Assert
+		require.Error(t, err)
+		assert.Nil(t, config, "Config should be nil on error")
+		assert.Contains(t, err.Error(), "terraform_resources requires terraform_schema")
+	})
+}
+
+func TestJob_serverOptions(t *testing.T) {
+	t.Run("uses default templates without templates directory", func(t *testing.T) {
+		assert.Nil(t, Job{}.serverOptions().Templates)
+	})
+
+	t.Run("reads templates from templates directory", func(t *testing.T) {
+		// Arrange
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, servergen.TemplateErrorHook), []byte("// custom error hook\n"), 0644))
+
+		// Act
+		options := Job{TemplatesDir: dir}.serverOptions()
+
+		// Assert
+		require.NotNil(t, options.Templates)
+		var buf bytes.Buffer
+		require.NoError(t, servergen.GenerateServerWithOptions(&buf, &specification.Service{Name: "Users", Version: "v1"}, options))
+		assert.Contains(t, buf.String(), "// custom error hook")
+	})
+
+	t.Run("sets the types of the job", func(t *testing.T) {
+		// Act
+		options := Job{TypesPackage: "example.com/adopter/types"}.serverOptions()
+
+		// Assert
+		assert.Equal(t, servergen.Types{Package: "example.com/adopter/types"}, options.Types)
+		assert.True(t, Job{StdlibTypes: true}.serverOptions().Types.Stdlib)
+	})
+
+	t.Run("sets the lifecycle of the job", func(t *testing.T) {
+		assert.True(t, Job{ServerLifecycle: true}.serverOptions().Lifecycle)
+		assert.False(t, Job{}.serverOptions().Lifecycle)
+	})
+}
+
+func TestJob_testOptions(t *testing.T) {
+	t.Run("matches the types of the server", func(t *testing.T) {
+		assert.True(t, Job{StdlibTypes: true}.testOptions().Types.Stdlib)
+	})
+
+	t.Run("sets the benchmarks of the job", func(t *testing.T) {
+		assert.True(t, Job{Benchmarks: true}.testOptions().Benchmarks)
+		assert.False(t, Job{}.testOptions().Benchmarks)
+	})
+
+	t.Run("sets the golden files of the job", func(t *testing.T) {
+		assert.True(t, Job{GoldenFiles: true}.testOptions().GoldenFiles)
+		assert.False(t, Job{}.testOptions().GoldenFiles)
+	})
+}
+
+func Test_runPlugin(t *testing.T) {
+	service := &specification.Service{Name: "Users API"}
+
+	writeScript := func(t *testing.T, content string) string {
+		path := filepath.Join(t.TempDir(), "plugin.sh")
+		require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\n"+content), 0755))
+		return path
+	}
+
+	t.Run("passes specification on stdin and returns stdout", func(t *testing.T) {
+		// Arrange
+		plugin := Plugin{Command: writeScript(t, "cat"), Output: "gateway.json"}
+
+		// Act
+		output, err := runPlugin(context.Background(), service, plugin, "users.yaml")
+
+		// Assert
+		require.NoError(t, err)
+		expected, err := specification.MarshalServiceJSON(service)
+		require.NoError(t, err)
+		assert.Equal(t, string(expected), string(output))
+	})
+
+	t.Run("passes arguments and environment", func(t *testing.T) {
+		// Arrange
+		script := writeScript(t, `echo "$1 $`+envPluginSpecification+` $`+envPluginOutput+`"`)
+		plugin := Plugin{Command: script + " --flag", Output: "gateway.yaml"}
+
+		// Act
+		output, err := runPlugin(context.Background(), service, plugin, "users.yaml")
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "--flag users.yaml gateway.yaml\n", string(output))
+	})
+
+	t.Run("returns error with stderr when command fails", func(t *testing.T) {
+		// Arrange
+		plugin := Plugin{Command: writeScript(t, "echo 'unknown resource' >&2; exit 3"), Output: "gateway.yaml"}
+
+		// Act
+		_, err := runPlugin(context.Background(), service, plugin, "users.yaml")
+
+		// Assert
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), errorPluginFailed)
+		assert.Contains(t, err.Error(), "unknown resource")
+	})
+}
+
+func Test_expandConfigJobs(t *testing.T) {
+	createSpecDir := func(t *testing.T, names ...string) string {
+		dir := t.TempDir()
+		for _, name := range names {
+			require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("version: v1"), 0644))
+		}
+		return dir
+	}
+
+	t.Run("expands glob pattern into one job per match", func(t *testing.T) {
+		// Arrange
+		dir := createSpecDir(t, "users.yaml", "products.yaml", "README.md")
+		config := Config{
+			{
+				Specification: filepath.Join(dir, "*.yaml"),
+				OpenAPIJSON:   "gen/{name}-openapi.json",
+				ServerGo:      "gen/{name}/server.go",
+			},
+		}
+
+		// Act
+		expanded, err := expandConfigJobs(config)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, expanded, 2, "Should create one job per matching file")
+		assert.Equal(t, filepath.Join(dir, "products.yaml"), expanded[0].Specification)
+		assert.Equal(t, "gen/products-openapi.json", expanded[0].OpenAPIJSON)
+		assert.Equal(t, "gen/products/server.go", expanded[0].ServerGo)
+		assert.Equal(t, filepath.Join(dir, "users.yaml"), expanded[1].Specification)
+		assert.Equal(t, "gen/users-openapi.json", expanded[1].OpenAPIJSON)
+		assert.Equal(t, "gen/users/server.go", expanded[1].ServerGo)
+	})
+
+	t.Run("expands directory into one job per specification file", func(t *testing.T) {
+		// Arrange
+		dir := createSpecDir(t, "users.yaml", "products.yml", "openapi.json", "README.md")
+		config := Config{
+			{
+				Specification: dir,
+				OpenAPIJSON:   "gen/{name}-openapi.json",
+			},
+		}
+
+		// Act
+		expanded, err := expandConfigJobs(config)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, expanded, 2, "Should create one job per YAML file in the directory")
+		assert.Equal(t, filepath.Join(dir, "products.yml"), expanded[0].Specification)
+		assert.Equal(t, "gen/products-openapi.json", expanded[0].OpenAPIJSON)
+		assert.Equal(t, filepath.Join(dir, "users.yaml"), expanded[1].Specification)
+		assert.Equal(t, "gen/users-openapi.json", expanded[1].OpenAPIJSON)
+	})
+
+	t.Run("returns error when directory has no specification files", func(t *testing.T) {
+		// Arrange
+		dir := createSpecDir(t, "README.md")
+		config := Config{
+			{
+				Specification: dir,
+				OpenAPIJSON:   "gen/{name}.json",
+			},
+		}
+
+		// Act
+		expanded, err := expandConfigJobs(config)
+
+		// Assert
+		require.Error(t, err)
+		assert.Nil(t, expanded)
+		assert.Contains(t, err.Error(), errorGlobExpansion)
+		assert.Contains(t, err.Error(), "no files match")
+	})
+
+	t.Run("substitutes name placeholder with the service name", func(t *testing.T) {
+		// Arrange
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "orders.yaml"), []byte("name: Order Management\nversion: v1"), 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "users.yaml"), []byte("version: v1"), 0644))
+		config := Config{
+			{
+				Specification: filepath.Join(dir, "*.yaml"),
+				OpenAPIJSON:   "gen/{name}-openapi.json",
+			},
+		}
+
+		// Act
+		expanded, err := expandConfigJobs(config)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, expanded, 2)
+		assert.Equal(t, "gen/order-management-openapi.json", expanded[0].OpenAPIJSON, "Should use the kebab-case service name")
+		assert.Equal(t, "gen/users-openapi.json", expanded[1].OpenAPIJSON, "Should fall back to the file name without a service name")
+	})
+
+	t.Run("substitutes name placeholder for plain specification paths", func(t *testing.T) {
+		// Arrange
+		config := Config{
+			{
+				Specification: "specs/orders.yaml",
+				SchemaJSON:    "gen/{name}-schema.json",
+			},
+		}
+
+		// Act
+		expanded, err := expandConfigJobs(config)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, expanded, 1)
+		assert.Equal(t, "specs/orders.yaml", expanded[0].Specification)
+		assert.Equal(t, "gen/orders-schema.json", expanded[0].SchemaJSON)
+	})
+
+	t.Run("returns error when pattern matches no files", func(t *testing.T) {
+		// Arrange
+		dir := createSpecDir(t)
+		config := Config{
+			{
+				Specification: filepath.Join(dir, "*.yaml"),
+				OpenAPIJSON:   "gen/{name}.json",
+			},
+		}
+
+		// Act
+		expanded, err := expandConfigJobs(config)
+
+		// Assert
+		require.Error(t, err)
+		assert.Nil(t, expanded)
+		assert.Contains(t, err.Error(), errorGlobExpansion)
+		assert.Contains(t, err.Error(), "no files match")
+	})
+
+	t.Run("returns error when multiple matches share output paths", func(t *testing.T) {
+		// Arrange
+		dir := createSpecDir(t, "a.yaml", "b.yaml")
+		config := Config{
+			{
+				Specification: filepath.Join(dir, "*.yaml"),
+				OpenAPIJSON:   "gen/openapi.json",
+			},
+		}
+
+		// Act
+		expanded, err := expandConfigJobs(config)
+
+		// Assert
+		require.Error(t, err)
+		assert.Nil(t, expanded)
+		assert.Contains(t, err.Error(), placeholderName)
+	})
+
+	t.Run("returns error when different specifications write the same output", func(t *testing.T) {
+		// Arrange
+		config := Config{
+			{Specification: "a.yaml", OpenAPIJSON: "gen/openapi.json"},
+			{Specification: "b.yaml", OpenAPIJSON: "gen/openapi.json"},
+		}
+
+		// Act
+		expanded, err := expandConfigJobs(config)
+
+		// Assert
+		require.Error(t, err)
+		assert.Nil(t, expanded)
+		assert.Contains(t, err.Error(), errorOutputConflict)
+	})
+}
+
+func TestJob_expandEnv(t *testing.T) {
+	t.Run("replaces environment variables in all fields", func(t *testing.T) {
+		// Arrange
+		t.Setenv("PUBLICAPIS_TEST_SPEC_DIR", "specs")
+		t.Setenv("PUBLICAPIS_TEST_OUT_DIR", "dist")
+		job := Job{
+			Specification: "${PUBLICAPIS_TEST_SPEC_DIR}/users.yaml",
+			OpenAPIJSON:   "${PUBLICAPIS_TEST_OUT_DIR}/openapi.json",
+			ServerGo:      "${PUBLICAPIS_TEST_OUT_DIR}/server.go",
+			ServerPackage: "api${PUBLICAPIS_TEST_SPEC_DIR}",
+		}
+
+		// Act
+		expanded, err := job.expandEnv()
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "specs/users.yaml", expanded.Specification)
+		assert.Equal(t, "dist/openapi.json", expanded.OpenAPIJSON)
+		assert.Equal(t, "dist/server.go", expanded.ServerGo)
+		assert.Equal(t, "apispecs", expanded.ServerPackage)
+	})
+
+	t.Run("keeps a bare dollar sign", func(t *testing.T) {
+		// Arrange
+		t.Setenv("PUBLICAPIS_TEST_OUT_DIR", "dist")
+		job := Job{
+			Specification: "spec.yaml",
+			OpenAPIJSON:   "$PUBLICAPIS_TEST_OUT_DIR/openapi.json",
+			Plugins:       []Plugin{{Command: `sh -c 'awk "{print $1}"'`, Output: "${PUBLICAPIS_TEST_OUT_DIR}/plugin.txt"}},
+		}
+
+		// Act
+		expanded, err := job.expandEnv()
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "$PUBLICAPIS_TEST_OUT_DIR/openapi.json", expanded.OpenAPIJSON)
+		assert.Equal(t, `sh -c 'awk "{print $1}"'`, expanded.Plugins[0].Command)
+		assert.Equal(t, "dist/plugin.txt", expanded.Plugins[0].Output)
+	})
+
+	t.Run("returns error for undefined environment variable", func(t *testing.T) {
+		// Arrange
+		job := Job{
+			Specification: "spec.yaml",
+			OpenAPIJSON:   "${PUBLICAPIS_TEST_UNDEFINED_VARIABLE}/openapi.json",
+		}
+
+		// Act
+		_, err := job.expandEnv()
+
+		// Assert
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), errorUndefinedEnv)
+		assert.Contains(t, err.Error(), "PUBLICAPIS_TEST_UNDEFINED_VARIABLE")
+	})
+}
+
+func TestJob_withService(t *testing.T) {
+	// Arrange
+	service := &specification.Service{Name: "UserManagement", Version: "v2"}
+	job := Job{
+		Specification: "spec.yaml",
+		OpenAPIJSON:   "dist/{service}-{version}.json",
+		ServerGo:      "dist/{service}/{version}/server.go",
+	}
+
+	// Act
+	resolved := job.withService(service)
+
+	// Assert
+	assert.Equal(t, "spec.yaml", resolved.Specification)
+	assert.Equal(t, "dist/user-management-v2.json", resolved.OpenAPIJSON)
+	assert.Equal(t, "dist/user-management/v2/server.go", resolved.ServerGo)
+}
+
+func TestJob_withOnly(t *testing.T) {
+	job := Job{
+		Specification:   "spec.yaml",
+		OpenAPIJSON:     "dist/openapi.json",
+		OpenAPIYAML:     "dist/openapi.yaml",
+		SchemaJSON:      "dist/schema.json",
+		OverlayYAML:     "dist/overlay.yaml",
+		OverlayJSON:     "dist/overlay.json",
+		ServerGo:        "dist/server.go",
+		ServerPackage:   "api",
+		GatewayRoutes:   "dist/routes.yaml",
+		TerraformSchema: "dist/terraform.json",
+		Plugins:         []Plugin{{Command: "./gateway", Output: "dist/gateway.yaml"}},
+	}
+
+	t.Run("keeps all outputs without artifacts", func(t *testing.T) {
+		// Act
+		selected := job.withOnly(nil)
+
+		// Assert
+		assert.Equal(t, job, selected)
+	})
+
+	t.Run("keeps only the selected artifacts", func(t *testing.T) {
+		// Act
+		selected := job.withOnly([]string{modeOverlay, modeServer})
+
+		// Assert
+		assert.Equal(t, []string{"dist/overlay.yaml", "dist/overlay.json", "dist/server.go"}, selected.getOutputPaths())
+		assert.Equal(t, "api", selected.ServerPackage)
+		assert.Equal(t, "spec.yaml", selected.Specification)
+	})
+
+	t.Run("keeps only the plugin outputs", func(t *testing.T) {
+		// Act
+		selected := job.withOnly([]string{onlyPlugins})
+
+		// Assert
+		assert.Equal(t, []string{"dist/gateway.yaml"}, selected.getOutputPaths())
+	})
+
+	t.Run("keeps only the gateway routes", func(t *testing.T) {
+		// Act
+		selected := job.withOnly([]string{modeGateway})
+
+		// Assert
+		assert.Equal(t, []string{"dist/routes.yaml"}, selected.getOutputPaths())
+	})
+
+	t.Run("keeps only the Terraform schema", func(t *testing.T) {
+		// Act
+		selected := job.withOnly([]string{modeTerraform})
+
+		// Assert
+		assert.Equal(t, []string{"dist/terraform.json"}, selected.getOutputPaths())
+	})
+}
+
+func Test_parseOnlyFlag(t *testing.T) {
+	testCases := []struct {
+		name          string
+		value         string
+		expected      []string
+		expectedError string
+	}{
+		{name: "empty value", value: "", expected: nil},
+		{name: "single artifact", value: modeOpenAPI, expected: []string{modeOpenAPI}},
+		{name: "comma-separated artifacts", value: "openapi, server,plugins", expected: []string{modeOpenAPI, modeServer, onlyPlugins}},
+		{name: "unknown artifact", value: "openapi,client", expectedError: errorInvalidOnly},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			artifacts, err := parseOnlyFlag(tc.value)
+
+			// Assert
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, artifacts)
+		})
+	}
+}
+
+func Test_runConfigMode_selection(t *testing.T) {
+	writeConfig := func(t *testing.T, dir string) string {
+		config := Config{
+			{
+				Specification: "testdata/school-management-api.yaml",
+				OpenAPIJSON:   filepath.Join(dir, "school-openapi.json"),
+				SchemaJSON:    filepath.Join(dir, "school-schema.json"),
+			},
+			{
+				Specification: "testdata/timeout-example-api.yaml",
+				OpenAPIJSON:   filepath.Join(dir, "timeout-openapi.json"),
+			},
+		}
+		data, err := yaml.Marshal(&config)
+		require.NoError(t, err)
+		configPath := filepath.Join(dir, "publicapis.yaml")
+		require.NoError(t, os.WriteFile(configPath, data, 0644))
+		return configPath
+	}
+
+	t.Run("writes only the selected artifacts", func(t *testing.T) {
+		// Arrange
+		dir := t.TempDir()
+		configPath := writeConfig(t, dir)
+
+		// Act
+		err := runConfigMode(context.Background(), configPath, generateOptions{Only: []string{modeSchema}})
+
+		// Assert
+		require.NoError(t, err)
+		assert.FileExists(t, filepath.Join(dir, "school-schema.json"))
+		assert.NoFileExists(t, filepath.Join(dir, "school-openapi.json"))
+		assert.NoFileExists(t, filepath.Join(dir, "timeout-openapi.json"))
+	})
+
+	t.Run("runs only the jobs defining the resource", func(t *testing.T) {
+		// Arrange
+		dir := t.TempDir()
+		configPath := writeConfig(t, dir)
+
+		// Act
+		err := runConfigMode(context.Background(), configPath, generateOptions{Resource: "Students"})
+
+		// Assert
+		require.NoError(t, err)
+		assert.FileExists(t, filepath.Join(dir, "school-openapi.json"))
+		assert.FileExists(t, filepath.Join(dir, "school-schema.json"))
+		assert.NoFileExists(t, filepath.Join(dir, "timeout-openapi.json"))
+	})
+
+	t.Run("returns error when no job defines the resource", func(t *testing.T) {
+		// Arrange
+		dir := t.TempDir()
+		configPath := writeConfig(t, dir)
+
+		// Act
+		err := runConfigMode(context.Background(), configPath, generateOptions{Resource: "Unknown"})
+
+		// Assert
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), errorUnknownResource)
+	})
+}
+
+func Test_runConfigMode_cache(t *testing.T) {
+	const staleContent = "stale"
+
+	setup := func(t *testing.T) (configPath, specPath, outputPath string) {
+		dir := t.TempDir()
+		specData, err := os.ReadFile("testdata/timeout-example-api.yaml")
+		require.NoError(t, err)
+		specPath = filepath.Join(dir, "api.yaml")
+		require.NoError(t, os.WriteFile(specPath, specData, 0644))
+
+		outputPath = filepath.Join(dir, "openapi.json")
+		config := Config{{Specification: specPath, OpenAPIJSON: outputPath}}
+		data, err := yaml.Marshal(&config)
+		require.NoError(t, err)
+		configPath = filepath.Join(dir, "publicapis.yaml")
+		require.NoError(t, os.WriteFile(configPath, data, 0644))
+
+		// Generate once and mark the output, to see whether the next run rewrites it
+		require.NoError(t, runConfigMode(context.Background(), configPath, generateOptions{}))
+		require.FileExists(t, filepath.Join(dir, cacheFileName))
+		require.NoError(t, os.WriteFile(outputPath, []byte(staleContent), 0644))
+
+		return configPath, specPath, outputPath
+	}
+
+	assertRegenerated := func(t *testing.T, outputPath string, expected bool) {
+		content, err := os.ReadFile(outputPath)
+		require.NoError(t, err)
+		assert.Equal(t, expected, string(content) != staleContent)
+	}
+
+	t.Run("skips unchanged job", func(t *testing.T) {
+		// Arrange
+		configPath, _, outputPath := setup(t)
+
+		// Act
+		err := runConfigMode(context.Background(), configPath, generateOptions{})
+
+		// Assert
+		require.NoError(t, err)
+		assertRegenerated(t, outputPath, false)
+	})
+
+	t.Run("regenerates with force", func(t *testing.T) {
+		// Arrange
+		configPath, _, outputPath := setup(t)
+
+		// Act
+		err := runConfigMode(context.Background(), configPath, generateOptions{Force: true})
+
+		// Assert
+		require.NoError(t, err)
+		assertRegenerated(t, outputPath, true)
+	})
+
+	t.Run("regenerates changed specification", func(t *testing.T) {
+		// Arrange
+		configPath, specPath, outputPath := setup(t)
+		specData, err := os.ReadFile(specPath)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(specPath, append(specData, []byte("# changed\n")...), 0644))
+
+		// Act
+		err = runConfigMode(context.Background(), configPath, generateOptions{})
+
+		// Assert
+		require.NoError(t, err)
+		assertRegenerated(t, outputPath, true)
+	})
+
+	t.Run("regenerates changed description file", func(t *testing.T) {
+		// Arrange
+		configPath, specPath, outputPath := setup(t)
+		specData, err := os.ReadFile(specPath)
+		require.NoError(t, err)
+		specData = []byte(strings.Replace(string(specData), `description: "User management with custom timeout configuration"`, `description_file: "users.md"`, 1))
+		require.NoError(t, os.WriteFile(specPath, specData, 0644))
+		descriptionPath := filepath.Join(filepath.Dir(specPath), "users.md")
+		require.NoError(t, os.WriteFile(descriptionPath, []byte("Old description of the users"), 0644))
+		require.NoError(t, runConfigMode(context.Background(), configPath, generateOptions{}))
+		require.NoError(t, os.WriteFile(outputPath, []byte(staleContent), 0644))
+		require.NoError(t, os.WriteFile(descriptionPath, []byte("New description of the users"), 0644))
+
+		// Act
+		err = runConfigMode(context.Background(), configPath, generateOptions{})
+
+		// Assert
+		require.NoError(t, err)
+		assertRegenerated(t, outputPath, true)
+		content, err := os.ReadFile(outputPath)
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "New description of the users")
+	})
+
+	t.Run("regenerates deleted output", func(t *testing.T) {
+		// Arrange
+		configPath, _, outputPath := setup(t)
+		require.NoError(t, os.Remove(outputPath))
+
+		// Act
+		err := runConfigMode(context.Background(), configPath, generateOptions{})
+
+		// Assert
+		require.NoError(t, err)
+		assert.FileExists(t, outputPath)
+	})
+
+	t.Run("ignores invalid cache file", func(t *testing.T) {
+		// Arrange
+		configPath, _, outputPath := setup(t)
+		require.NoError(t, os.WriteFile(filepath.Join(filepath.Dir(configPath), cacheFileName), []byte("{"), 0644))
+
+		// Act
+		err := runConfigMode(context.Background(), configPath, generateOptions{})
+
+		// Assert
+		require.NoError(t, err)
+		assertRegenerated(t, outputPath, true)
+	})
+}
+
+func TestJob_inputHash(t *testing.T) {
+	job := Job{Specification: "testdata/timeout-example-api.yaml", OpenAPIJSON: "openapi.json"}
+
+	t.Run("is stable for the same inputs", func(t *testing.T) {
+		// Act
+		first, err := job.inputHash()
+		require.NoError(t, err)
+		second, err := job.inputHash()
+		require.NoError(t, err)
+
+		// Assert
+		assert.Equal(t, first, second)
+	})
+
+	t.Run("changes with the job options", func(t *testing.T) {
+		// Arrange
+		withPackage := job
+		withPackage.ServerPackage = "api"
+
+		// Act
+		first, err := job.inputHash()
+		require.NoError(t, err)
+		second, err := withPackage.inputHash()
+		require.NoError(t, err)
+
+		// Assert
+		assert.NotEqual(t, first, second)
+	})
+
+	t.Run("changes with the templates", func(t *testing.T) {
+		// Arrange
+		withTemplates := job
+		withTemplates.TemplatesDir = t.TempDir()
+		templatePath := filepath.Join(withTemplates.TemplatesDir, servergen.TemplateErrorHook)
+		require.NoError(t, os.WriteFile(templatePath, []byte("a"), 0644))
+
+		// Act
+		first, err := withTemplates.inputHash()
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(templatePath, []byte("b"), 0644))
+		second, err := withTemplates.inputHash()
+		require.NoError(t, err)
+
+		// Assert
+		assert.NotEqual(t, first, second)
+	})
+
+	t.Run("changes with the OpenAPI overlay", func(t *testing.T) {
+		// Arrange
+		withOverlay := job
+		withOverlay.OpenAPIOverlay = filepath.Join(t.TempDir(), "patches.yaml")
+		require.NoError(t, os.WriteFile(withOverlay.OpenAPIOverlay, []byte("a"), 0644))
+
+		// Act
+		first, err := withOverlay.inputHash()
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(withOverlay.OpenAPIOverlay, []byte("b"), 0644))
+		second, err := withOverlay.inputHash()
+		require.NoError(t, err)
+
+		// Assert
+		assert.NotEqual(t, first, second)
+	})
+
+	t.Run("changes with the description files of the shared libraries", func(t *testing.T) {
+		// Arrange
+		dir := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, "shared", "docs"), 0755))
+		specPath := filepath.Join(dir, "api.yaml")
+		require.NoError(t, os.WriteFile(specPath, []byte("name: API\nshared: [\"shared/common.yaml\"]\n"), 0644))
+		library := "name: Common\nobjects:\n  - name: Address\n    description_file: \"docs/address.md\"\n"
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "shared", "common.yaml"), []byte(library), 0644))
+		descriptionPath := filepath.Join(dir, "shared", "docs", "address.md")
+		require.NoError(t, os.WriteFile(descriptionPath, []byte("a"), 0644))
+		withLibrary := Job{Specification: specPath, OpenAPIJSON: "openapi.json"}
+
+		// Act
+		first, err := withLibrary.inputHash()
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(descriptionPath, []byte("b"), 0644))
+		second, err := withLibrary.inputHash()
+		require.NoError(t, err)
+
+		// Assert
+		assert.NotEqual(t, first, second)
+	})
+
+	t.Run("returns error for missing specification", func(t *testing.T) {
+		// Arrange
+		missing := Job{Specification: "testdata/missing.yaml"}
+
+		// Act
+		_, err := missing.inputHash()
+
+		// Assert
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), errorFileRead)
+	})
+}
+
+func Test_generateOpenAPIYAMLOutputPath(t *testing.T) {
+	testCases := []struct {
+		name      string
+		inputFile string
+		expected  string
+	}{
+		{
+			name:      "YAML file generates YAML OpenAPI",
+			inputFile: "spec.yaml",
+			expected:  "spec-openapi.yaml",
+		},
+		{
+			name:      "JSON file generates YAML OpenAPI",
+			inputFile: "api.json",
+			expected:  "api-openapi.yaml",
+		},
+		{
+			name:      "File with path generates YAML OpenAPI",
+			inputFile: "/path/to/spec.yml",
+			expected:  "/path/to/spec-openapi.yaml",
+		},
+		{
+			name:      "File without extension generates YAML OpenAPI",
+			inputFile: "spec",
+			expected:  "spec-openapi.yaml",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			result := generateOpenAPIYAMLOutputPath(tc.inputFile)
+
+			// Assert
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func Test_ensureYAMLExtension(t *testing.T) {
+	testCases := []struct {
+		name       string
+		outputPath string
+		expected   string
+	}{
+		{
+			name:       "YAML extension unchanged",
+			outputPath: "api-spec.yaml",
+			expected:   "api-spec.yaml",
+		},
+		{
+			name:       "YML extension unchanged",
+			outputPath: "api-spec.yml",
+			expected:   "api-spec.yml",
+		},
+		{
+			name:       "JSON extension changed to YAML",
+			outputPath: "api-spec.json",
+			expected:   "api-spec.yaml",
+		},
+		{
+			name:       "No extension gets YAML extension",
+			outputPath: "api-spec",
+			expected:   "api-spec.yaml",
+		},
+		{
+			name:       "Other extension changed to YAML",
+			outputPath: "api-spec.xml",
+			expected:   "api-spec.yaml",
+		},
+		{
+			name:       "Path with YAML extension unchanged",
+			outputPath: "/path/to/api-spec.yaml",
+			expected:   "/path/to/api-spec.yaml",
+		},
+		{
+			name:       "Path with JSON extension changed to YAML",
+			outputPath: "/path/to/api-spec.json",
+			expected:   "/path/to/api-spec.yaml",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			result := ensureYAMLExtension(tc.outputPath)
+
+			// Assert
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestGenerateTestFilePath(t *testing.T) {
+	testCases := []struct {
+		name           string
+		serverGoPath   string
+		expectedResult string
+	}{
+		{
+			name:           "basic .go file",
+			serverGoPath:   "api.go",
+			expectedResult: "api_test.go",
+		},
+		{
+			name:           "generated .gen.go file",
+			serverGoPath:   "api.gen.go",
+			expectedResult: "api_test.gen.go",
+		},
+		{
 			name:           "file with path",
 			serverGoPath:   "directory/api.go",
 			expectedResult: "directory/api_test.go",
 		},
 		{
-			name:           "file with path and .gen.go",
-			serverGoPath:   "directory/api/api.gen.go",
-			expectedResult: "directory/api/api_test.gen.go",
+			name:           "file with path and .gen.go",
+			serverGoPath:   "directory/api/api.gen.go",
+			expectedResult: "directory/api/api_test.gen.go",
+		},
+		{
+			name:           "file without extension",
+			serverGoPath:   "api",
+			expectedResult: "api_test.go",
+		},
+		{
+			name:           "multiple dots in filename",
+			serverGoPath:   "api.v1.gen.go",
+			expectedResult: "api_test.v1.gen.go",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			result := generateTestFilePath(tc.serverGoPath)
+
+			// Assert
+			assert.Equal(t, tc.expectedResult, result, "Test file path should be generated correctly")
+		})
+	}
+}
+
+// stringPtr returns a pointer to a string value - helper for tests
+func stringPtr(s string) *string {
+	return &s
+}
+
+// Test_generateServerFromSpecification_e2e tests the servergen functionality end-to-end
+func Test_generateServerFromSpecification_e2e(t *testing.T) {
+	// Test constants to avoid hardcoded strings
+	const (
+		testServerPackage    = "testapi"
+		expectedPackageDecl  = "package api"
+		expectedImportGin    = `"github.com/gin-gonic/gin"`
+		expectedImportTypes  = `"github.com/meitner-se/go-types"`
+		expectedRegisterFunc = "func RegisterTestServiceAPI[Session any]"
+		expectedErrorType    = "type Error struct {"
+		expectedOpenAPIRoute = `routerGroup.StaticFileFS("/openapi.json", "openapi.json", http.FS(api.OpenAPI_JSON))`
+	)
+
+	// Create a test service with various features
+	service := &specification.Service{
+		Name:    "TestService",
+		Version: "v1",
+		Enums: []specification.Enum{
+			{
+				Name:        "Status",
+				Description: "Status enumeration",
+				Values: []specification.EnumValue{
+					{Name: "Active", Description: "Active status"},
+					{Name: "Inactive", Description: "Inactive status"},
+				},
+			},
+			{
+				Name:        "ErrorCode",
+				Description: "Error codes",
+				Values: []specification.EnumValue{
+					{Name: "BadRequest", Description: "Bad request"},
+					{Name: "Unauthorized", Description: "Unauthorized"},
+					{Name: "Forbidden", Description: "Forbidden"},
+					{Name: "NotFound", Description: "Not found"},
+					{Name: "Conflict", Description: "Conflict"},
+					{Name: "UnprocessableEntity", Description: "Unprocessable entity"},
+					{Name: "RateLimited", Description: "Rate limited"},
+					{Name: "Internal", Description: "Internal error"},
+				},
+			},
+		},
+		Objects: []specification.Object{
+			{
+				Name:        "User",
+				Description: "User object",
+				Fields: []specification.Field{
+					{
+						Name:        "ID",
+						Description: "User ID",
+						Type:        "UUID",
+					},
+					{
+						Name:        "Name",
+						Description: "User name",
+						Type:        "String",
+					},
+					{
+						Name:        "Status",
+						Description: "User status",
+						Type:        "Status",
+					},
+				},
+			},
+			{
+				Name:        "Error",
+				Description: "Error object",
+				Fields: []specification.Field{
+					{
+						Name:        "Code",
+						Description: "Error code",
+						Type:        "ErrorCode",
+					},
+					{
+						Name:        "Message",
+						Description: "Error message",
+						Type:        "String",
+					},
+					{
+						Name:        "RequestID",
+						Description: "Request ID",
+						Type:        "String",
+					},
+				},
+			},
+		},
+		Resources: []specification.Resource{
+			{
+				Name:        "User",
+				Description: "User resource",
+				Operations:  []string{"Create", "Read", "Update", "Delete"},
+				Fields: []specification.ResourceField{
+					{
+						Field: specification.Field{
+							Name:        "ID",
+							Description: "User ID",
+							Type:        "UUID",
+						},
+						Operations: []string{"Read"},
+					},
+					{
+						Field: specification.Field{
+							Name:        "Name",
+							Description: "User name",
+							Type:        "String",
+						},
+						Operations: []string{"Create", "Read", "Update"},
+					},
+				},
+				Endpoints: []specification.Endpoint{
+					{
+						Name:        "CreateUser",
+						Method:      "POST",
+						Path:        "/users",
+						Summary:     "Create a new user",
+						Description: "Creates a new user in the system",
+						Request: specification.EndpointRequest{
+							BodyParams: []specification.Field{
+								{
+									Name:        "Name",
+									Description: "User name",
+									Type:        "String",
+								},
+							},
+						},
+						Response: specification.EndpointResponse{
+							StatusCode: 201,
+							BodyObject: stringPtr("User"),
+						},
+					},
+					{
+						Name:        "GetUser",
+						Method:      "GET",
+						Path:        "/users/{id}",
+						Summary:     "Get a user by ID",
+						Description: "Retrieves a user by their ID",
+						Request: specification.EndpointRequest{
+							PathParams: []specification.Field{
+								{
+									Name:        "ID",
+									Description: "User ID",
+									Type:        "UUID",
+								},
+							},
+						},
+						Response: specification.EndpointResponse{
+							StatusCode: 200,
+							BodyObject: stringPtr("User"),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	// Create a temporary directory for test output
+	tempDir := os.TempDir()
+	outputPath := tempDir + "/test_server.go"
+	defer os.Remove(outputPath) // Clean up after test
+
+	ctx := context.Background()
+
+	// Act - Generate server code
+	err := generateServerFromSpecification(ctx, service, servergen.Options{}, provenance{}, "test-spec.yaml", outputPath, testServerPackage)
+
+	// Assert
+	assert.Nil(t, err, "Expected no error when generating server code")
+
+	// Verify file was created
+	_, statErr := os.Stat(outputPath)
+	assert.Nil(t, statErr, "Expected generated file to exist")
+
+	// Read and verify generated content
+	content, readErr := os.ReadFile(outputPath)
+	assert.Nil(t, readErr, "Expected no error reading generated file")
+
+	generatedCode := string(content)
+	assert.NotEmpty(t, generatedCode, "Expected generated code to be non-empty")
+
+	// Verify key components of generated code
+	assert.Contains(t, generatedCode, expectedPackageDecl, "Generated code should contain package declaration")
+	assert.Contains(t, generatedCode, expectedImportGin, "Generated code should import gin")
+	assert.Contains(t, generatedCode, expectedImportTypes, "Generated code should import go-types")
+	assert.Contains(t, generatedCode, expectedRegisterFunc, "Generated code should contain RegisterAPI function")
+	assert.Contains(t, generatedCode, expectedErrorType, "Generated code should contain Error type")
+	assert.Contains(t, generatedCode, expectedOpenAPIRoute, "Generated code should contain OpenAPI route")
+
+	// Verify enum generation
+	assert.Contains(t, generatedCode, "StatusActive", "Generated code should contain StatusActive")
+	assert.Contains(t, generatedCode, "types.NewString(\"Active\")", "Generated code should contain enum values")
+
+	// Verify object generation
+	assert.Contains(t, generatedCode, "type User struct {", "Generated code should contain User object")
+	assert.Contains(t, generatedCode, "ID types.UUID `json:\"id\"`", "Generated code should contain User fields")
+
+	// Verify endpoint generation (Note: servergen includes resource name in path)
+	assert.Contains(t, generatedCode, "routerGroup.POST(\"/user/users\", serveWithResponse(201, api.Server, api.User.CreateUser, api.UserMiddlewares...))", "Generated code should contain POST endpoint")
+	assert.Contains(t, generatedCode, "routerGroup.GET(\"/user/users/:id\", serveWithResponse(200, api.Server, api.User.GetUser, api.UserMiddlewares...))", "Generated code should contain GET endpoint")
+
+	// Verify request/response types
+	assert.Contains(t, generatedCode, "type UserCreateUserBodyParams struct {", "Generated code should contain request body type")
+	assert.Contains(t, generatedCode, "type UserGetUserPathParams struct {", "Generated code should contain path params type")
+	// Note: Response types are not generated when using BodyObject
+
+	t.Run("edge cases", func(t *testing.T) {
+		t.Run("empty service", func(t *testing.T) {
+			// Arrange
+			emptyService := &specification.Service{
+				Name:    "EmptyService",
+				Version: "v1",
+			}
+			outputPath := tempDir + "/empty_server.go"
+			defer os.Remove(outputPath)
+
+			// Act
+			err := generateServerFromSpecification(ctx, emptyService, servergen.Options{}, provenance{}, "empty-spec.yaml", outputPath, "emptyapi")
+
+			// Assert
+			assert.Nil(t, err, "Expected no error with empty service")
+
+			// Verify file was created
+			_, statErr := os.Stat(outputPath)
+			assert.Nil(t, statErr, "Expected generated file to exist")
+
+			// Read and verify basic structure
+			content, _ := os.ReadFile(outputPath)
+			generatedCode := string(content)
+			assert.Contains(t, generatedCode, expectedPackageDecl, "Should still generate basic structure")
+			assert.Contains(t, generatedCode, "func RegisterEmptyServiceAPI[Session any]", "Should generate register function for empty service")
+		})
+
+		t.Run("service with no endpoints", func(t *testing.T) {
+			// Arrange
+			serviceNoEndpoints := &specification.Service{
+				Name:    "NoEndpointService",
+				Version: "v1",
+				Resources: []specification.Resource{
+					{
+						Name:        "Resource",
+						Description: "Resource with no endpoints",
+						Endpoints:   []specification.Endpoint{}, // No endpoints
+					},
+				},
+			}
+			outputPath := tempDir + "/no_endpoints_server.go"
+			defer os.Remove(outputPath)
+
+			// Act
+			err := generateServerFromSpecification(ctx, serviceNoEndpoints, servergen.Options{}, provenance{}, "no-endpoints-spec.yaml", outputPath, "noendpointsapi")
+
+			// Assert
+			assert.Nil(t, err, "Expected no error with no endpoints")
+
+			// Verify file was created
+			_, statErr := os.Stat(outputPath)
+			assert.Nil(t, statErr, "Expected generated file to exist")
+		})
+	})
+}
+
+// ============================================================================
+// Diff Functionality Tests
+// ============================================================================
+
+func Test_generateServerDirFromSpecification(t *testing.T) {
+	readService := func(t *testing.T) *specification.Service {
+		service, err := readSpecificationFile("testdata/school-management-api.yaml")
+		require.NoError(t, err)
+		return service
+	}
+
+	t.Run("writes one file per resource and the shared files", func(t *testing.T) {
+		// Arrange
+		outputDir := filepath.Join(t.TempDir(), "server")
+		service := readService(t)
+
+		// Act
+		paths, err := generateServerDirFromSpecification(context.Background(), service, servergen.Options{}, provenance{}, outputDir)
+
+		// Assert
+		require.NoError(t, err)
+		expected := []string{
+			filepath.Join(outputDir, servergen.FileServer),
+			filepath.Join(outputDir, "students_resource.go"),
+			filepath.Join(outputDir, servergen.FileTypes),
+		}
+		assert.Equal(t, expected, paths)
+		for _, path := range paths {
+			assert.FileExists(t, path)
+		}
+	})
+
+	t.Run("removes generated files of removed resources only", func(t *testing.T) {
+		// Arrange
+		outputDir := t.TempDir()
+		header := newProvenance([]byte("spec"))
+		stalePath := filepath.Join(outputDir, "teachers_resource.go")
+		handwrittenPath := filepath.Join(outputDir, "custom_resource.go")
+		require.NoError(t, os.WriteFile(stalePath, header.apply(extGo, []byte("package api\n")), 0644))
+		require.NoError(t, os.WriteFile(handwrittenPath, []byte("package api\n"), 0644))
+
+		// Act
+		_, err := generateServerDirFromSpecification(context.Background(), readService(t), servergen.Options{}, header, outputDir)
+
+		// Assert
+		require.NoError(t, err)
+		assert.NoFileExists(t, stalePath, "Generated file of a removed resource should be removed")
+		assert.FileExists(t, handwrittenPath, "Files not generated by publicapis-gen should be kept")
+	})
+
+	t.Run("generates and removes the lifecycle file with the lifecycle option", func(t *testing.T) {
+		// Arrange
+		outputDir := t.TempDir()
+		service := readService(t)
+		header := newProvenance([]byte("spec"))
+		lifecyclePath := filepath.Join(outputDir, servergen.FileLifecycle)
+
+		// Act
+		paths, err := generateServerDirFromSpecification(context.Background(), service, servergen.Options{Lifecycle: true}, header, outputDir)
+		require.NoError(t, err)
+		assert.Contains(t, paths, lifecyclePath)
+		assert.FileExists(t, lifecyclePath)
+
+		_, err = generateServerDirFromSpecification(context.Background(), service, servergen.Options{}, header, outputDir)
+
+		// Assert
+		require.NoError(t, err)
+		assert.NoFileExists(t, lifecyclePath, "Lifecycle file should be removed when it is no longer generated")
+	})
+
+	t.Run("diff reports no differences after generation", func(t *testing.T) {
+		// Arrange
+		outputDir := t.TempDir()
+		service := readService(t)
+		header := newProvenance([]byte("spec"))
+		_, err := generateServerDirFromSpecification(context.Background(), service, servergen.Options{}, header, outputDir)
+		require.NoError(t, err)
+
+		// Act
+		artifacts, err := checkServerDirDifferences(service, servergen.Options{}, outputDir, header)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, artifacts, 3)
+		for _, artifact := range artifacts {
+			assert.Equal(t, diffStatusOK, artifact.Status, artifact.Path)
+		}
+	})
+
+	t.Run("diff reports missing and stale files", func(t *testing.T) {
+		// Arrange
+		outputDir := t.TempDir()
+		header := newProvenance([]byte("spec"))
+		stalePath := filepath.Join(outputDir, "teachers_resource.go")
+		require.NoError(t, os.WriteFile(stalePath, header.apply(extGo, []byte("package api\n")), 0644))
+
+		// Act
+		artifacts, err := checkServerDirDifferences(readService(t), servergen.Options{}, outputDir, header)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, artifacts, 4)
+		for _, artifact := range artifacts[:3] {
+			assert.Equal(t, diffStatusMissing, artifact.Status, artifact.Path)
+		}
+		assert.Equal(t, stalePath, artifacts[3].Path)
+		assert.Equal(t, diffStatusChanged, artifacts[3].Status)
+		assert.Equal(t, diffNotGenerated, artifacts[3].Diff)
+	})
+}
+
+// generatedServerRequirements are the modules the tests of a server generated with stdlib_types require
+var generatedServerRequirements = []string{
+	"github.com/gin-gonic/gin@v1.12.0",
+	"github.com/google/uuid@v1.6.0",
+	"github.com/stretchr/testify@v1.11.1",
+}
+
+// goTestGeneratedServer generates the server and its tests of the specification with stdlib_types into a module in
+// a temporary directory and runs go test on it, running the generated benchmarks once if benchmarks is set. The test
+// is skipped when the modules it requires are unavailable.
+func goTestGeneratedServer(t *testing.T, specification string, benchmarks bool) {
+	t.Helper()
+
+	dir := t.TempDir()
+	specPath := filepath.Join(dir, "api.yaml")
+	require.NoError(t, os.WriteFile(specPath, []byte(specification), 0644))
+
+	config := Config{{Specification: specPath, ServerDir: filepath.Join(dir, "api"), ServerPackage: "api", StdlibTypes: true, Benchmarks: benchmarks}}
+	data, err := yaml.Marshal(&config)
+	require.NoError(t, err)
+	configPath := filepath.Join(dir, "publicapis.yaml")
+	require.NoError(t, os.WriteFile(configPath, data, 0644))
+	require.NoError(t, runConfigMode(context.Background(), configPath, generateOptions{}))
+
+	runGo := func(args ...string) ([]byte, error) {
+		cmd := exec.CommandContext(t.Context(), "go", args...)
+		cmd.Dir = dir
+		return cmd.CombinedOutput()
+	}
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/generated\n"), 0644))
+	if output, err := runGo(append([]string{"get"}, generatedServerRequirements...)...); err != nil {
+		t.Skipf("modules of the generated server are unavailable: %s", output)
+	}
+
+	args := []string{"test", "./..."}
+	if benchmarks {
+		args = []string{"test", "-bench", ".", "-benchtime", "1x", "./..."}
+	}
+	output, err := runGo(args...)
+	assert.NoError(t, err, string(output))
+}
+
+func Test_generatedServer_goTest(t *testing.T) {
+	readTestdata := func(path string) string {
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		return string(data)
+	}
+
+	testCases := []struct {
+		name          string
+		specification string
+		benchmarks    bool
+	}{
+		{name: "school management api", specification: readTestdata("testdata/school-management-api.yaml")},
+		{name: "timeout example api", specification: readTestdata("testdata/timeout-example-api.yaml")},
+		{
+			name: "scoped endpoints",
+			specification: `name: "Scoped API"
+version: "v1"
+securitySchemes:
+  oauth:
+    type: "oauth2"
+    flows:
+      clientCredentials:
+        tokenUrl: "https://auth.example.com/token"
+        scopes:
+          users:read: "Read users"
+security:
+  - ["oauth"]
+resources:
+  - name: "Users"
+    description: "User accounts"
+    operations: ["Get", "List"]
+    scopes: ["users:read"]
+    fields:
+      - name: "email"
+        type: "String"
+        description: "User email address"
+`,
+		},
+		{
+			name: "xml and form bodies",
+			specification: `name: "Orders API"
+version: "v1"
+objects:
+  - name: "Order"
+    description: "An order"
+    fields:
+      - name: "Reference"
+        type: "String"
+        description: "Reference of the order"
+resources:
+  - name: "Orders"
+    description: "Orders of partners"
+    endpoints:
+      - name: "Submit"
+        title: "Submit order"
+        description: "Submit an order as XML"
+        method: "POST"
+        path: "/_submit"
+        request:
+          content_type: "application/xml"
+          body_params:
+            - name: "Reference"
+              type: "String"
+              description: "Reference of the order"
+        response:
+          content_type: "application/xml"
+          status_code: 200
+          body_object: "Order"
+      - name: "Subscribe"
+        title: "Subscribe"
+        description: "Subscribe to the orders with a form"
+        method: "POST"
+        path: "/_subscribe"
+        request:
+          content_type: "application/x-www-form-urlencoded"
+          body_params:
+            - name: "Email"
+              type: "String"
+              description: "Email address"
+        response:
+          status_code: 204
+`,
 		},
 		{
-			name:           "file without extension",
-			serverGoPath:   "api",
-			expectedResult: "api_test.go",
+			name: "expansions",
+			specification: `name: "Orders API"
+version: "v1"
+resources:
+  - name: "Users"
+    description: "Users"
+    operations: ["Get"]
+    fields:
+      - name: "Name"
+        type: "String"
+        description: "Name"
+        operations: ["Read"]
+  - name: "Orders"
+    description: "Orders"
+    operations: ["Get", "List", "Search"]
+    fields:
+      - name: "BuyerID"
+        type: "UUID"
+        description: "Buyer of the order"
+        references: "Users"
+        operations: ["Read"]
+    expansions:
+      - name: "Buyer"
+        field: "BuyerID"
+        description: "The user who placed the order"
+`,
 		},
 		{
-			name:           "multiple dots in filename",
-			serverGoPath:   "api.v1.gen.go",
-			expectedResult: "api_test.v1.gen.go",
+			name: "expansions of searchable resources",
+			specification: `name: "Orders API"
+version: "v1"
+resources:
+  - name: "Users"
+    description: "Users"
+    operations: ["Get", "Search"]
+    fields:
+      - name: "Name"
+        type: "String"
+        description: "Name"
+        operations: ["Read"]
+  - name: "Orders"
+    description: "Orders"
+    operations: ["Get", "Search"]
+    fields:
+      - name: "BuyerID"
+        type: "UUID"
+        description: "Buyer of the order"
+        references: "Users"
+        operations: ["Read"]
+    expansions:
+      - name: "Buyer"
+        field: "BuyerID"
+        description: "The user who placed the order"
+`,
+		},
+		{
+			name: "integer-backed enums",
+			specification: `name: "Tickets API"
+version: "v1"
+enums:
+  - name: "Priority"
+    description: "Priority of the ticket"
+    enum_type: "int"
+    values:
+      - name: "Low"
+        value: "1"
+        description: "Low priority"
+        aliases: ["0"]
+      - name: "High"
+        value: "2"
+        description: "High priority"
+resources:
+  - name: "Tickets"
+    description: "Support tickets"
+    operations: ["Create", "Get", "List"]
+    fields:
+      - name: "Priority"
+        type: "Priority"
+        description: "Priority of the ticket"
+        operations: ["Create", "Read"]
+`,
+			benchmarks: true,
+		},
+		{
+			name: "max limit",
+			specification: `name: "Users API"
+version: "v1"
+max_limit: 100
+resources:
+  - name: "Users"
+    description: "User accounts"
+    operations: ["Get", "List", "Search"]
+    fields:
+      - name: "Name"
+        type: "String"
+        description: "Name of the user"
+        operations: ["Read"]
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			goTestGeneratedServer(t, tc.specification, tc.benchmarks)
+		})
+	}
+}
+
+func Test_createArtifactDiffReport(t *testing.T) {
+	testCases := []struct {
+		name           string
+		diff           string
+		outdated       string
+		expectedStatus string
+	}{
+		{name: "no difference", diff: "", expectedStatus: diffStatusOK},
+		{name: "missing file", diff: diffFileNotExist, expectedStatus: diffStatusMissing},
+		{name: "changed content", diff: diffContentDiffers + "\n", expectedStatus: diffStatusChanged},
+		{name: "outdated file", outdated: diffNoProvenance, expectedStatus: diffStatusOutdated},
+		{name: "missing file generated by another version", diff: diffFileNotExist, outdated: diffNoProvenance, expectedStatus: diffStatusMissing},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			artifact := createArtifactDiffReport(artifactOpenAPIJSON, "openapi.json", tc.diff, tc.outdated)
+
+			// Assert
+			assert.Equal(t, tc.expectedStatus, artifact.Status)
+			assert.Equal(t, tc.expectedStatus != diffStatusOK, artifact.HasDifference())
+		})
+	}
+}
+
+func Test_writeDiffReport(t *testing.T) {
+	report := diffReport{
+		HasDifferences: true,
+		Jobs: []jobDiffReport{
+			{
+				Job:           1,
+				Specification: "users.yaml",
+				Artifacts: []artifactDiffReport{
+					{Artifact: artifactOpenAPIJSON, Path: "users.json", Status: diffStatusOK},
+					{Artifact: artifactServerGo, Path: "server.go", Status: diffStatusMissing, Diff: diffFileNotExist},
+				},
+			},
 		},
 	}
 
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			// Act
-			result := generateTestFilePath(tc.serverGoPath)
+	t.Run("writes nothing in quiet mode", func(t *testing.T) {
+		// Arrange
+		var buf bytes.Buffer
+
+		// Act
+		err := writeDiffReport(&buf, report, diffOptions{Quiet: true, Report: reportFormatJSON})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Empty(t, buf.String())
+	})
+
+	t.Run("writes JSON report", func(t *testing.T) {
+		// Arrange
+		var buf bytes.Buffer
+
+		// Act
+		err := writeDiffReport(&buf, report, diffOptions{Report: reportFormatJSON})
+
+		// Assert
+		require.NoError(t, err)
+		var decoded diffReport
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+		assert.Equal(t, report, decoded)
+		assert.Contains(t, buf.String(), `"status": "missing"`)
+	})
+
+	t.Run("writes text report with only differing artifacts", func(t *testing.T) {
+		// Arrange
+		var buf bytes.Buffer
+
+		// Act
+		err := writeDiffReport(&buf, report, diffOptions{Report: reportFormatText})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Contains(t, buf.String(), "Job 1 (spec: users.yaml):")
+		assert.Contains(t, buf.String(), "Server Go (server.go): "+diffFileNotExist)
+		assert.NotContains(t, buf.String(), "users.json")
+	})
+
+	t.Run("returns error for unknown report format", func(t *testing.T) {
+		// Act
+		err := writeDiffReport(io.Discard, report, diffOptions{Report: "xml"})
+
+		// Assert
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), errorInvalidReport)
+	})
+}
+
+func TestCompareWithDiskFile(t *testing.T) {
+	// Create a temporary directory for test files
+	tempDir, err := os.MkdirTemp("", "diff_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	t.Run("identical files return no difference", func(t *testing.T) {
+		// Arrange
+		content := "line 1\nline 2\nline 3\n"
+		testFile := tempDir + "/identical.txt"
+		err := os.WriteFile(testFile, []byte(content), 0644)
+		require.NoError(t, err)
+
+		// Act
+		diff, err := compareWithDiskFile(testFile, []byte(content))
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Empty(t, diff, "Identical files should produce no diff output")
+	})
+
+	t.Run("non-existent file returns appropriate message", func(t *testing.T) {
+		// Arrange
+		nonExistentFile := tempDir + "/does_not_exist.txt"
+		content := []byte("some content")
+
+		// Act
+		diff, err := compareWithDiskFile(nonExistentFile, content)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, diffFileNotExist, diff)
+	})
+
+	t.Run("different files show detailed diff", func(t *testing.T) {
+		// Arrange
+		diskContent := "line 1\noriginal line 2\nline 3\nline 4\n"
+		generatedContent := "line 1\nmodified line 2\nline 3\nline 4\n"
+		testFile := tempDir + "/different.txt"
+		err := os.WriteFile(testFile, []byte(diskContent), 0644)
+		require.NoError(t, err)
+
+		// Act
+		diff, err := compareWithDiskFile(testFile, []byte(generatedContent))
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Contains(t, diff, diffContentDiffers, "Should indicate content differs")
+		assert.Contains(t, diff, "First difference found at line 2", "Should show line number of first difference")
+		assert.Contains(t, diff, diffGeneratedHeader, "Should show generated content header")
+		assert.Contains(t, diff, diffDiskHeader, "Should show disk content header")
+		assert.Contains(t, diff, "modified line 2", "Should show generated content")
+		assert.Contains(t, diff, "original line 2", "Should show disk content")
+		assert.Contains(t, diff, "→ 2:", "Should mark the differing line with arrow")
+	})
+
+	t.Run("files with different lengths show diff", func(t *testing.T) {
+		// Arrange
+		diskContent := "line 1\nline 2\n"
+		generatedContent := "line 1\nline 2\nline 3\nextra line\n"
+		testFile := tempDir + "/different_length.txt"
+		err := os.WriteFile(testFile, []byte(diskContent), 0644)
+		require.NoError(t, err)
+
+		// Act
+		diff, err := compareWithDiskFile(testFile, []byte(generatedContent))
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Contains(t, diff, diffContentDiffers, "Should indicate content differs")
+		assert.Contains(t, diff, "First difference found at line 3", "Should show first difference at end of shorter file")
+		assert.Contains(t, diff, "more line(s) differ", "Should indicate additional differences")
+	})
+}
+
+func Test_compareGeneratedWithDiskFile(t *testing.T) {
+	writeFile := func(t *testing.T, name, content string) string {
+		path := filepath.Join(t.TempDir(), name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+		return path
+	}
+
+	t.Run("ignores key ordering and formatting for JSON", func(t *testing.T) {
+		// Arrange
+		path := writeFile(t, "doc.json", "{\"b\": [1, 2], \"a\": \"x\"}")
+
+		// Act
+		diff, err := compareGeneratedWithDiskFile(path, []byte("{\n  \"a\": \"x\",\n  \"b\": [1, 2]\n}\n"), false)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Empty(t, diff)
+	})
+
+	t.Run("ignores key ordering for YAML", func(t *testing.T) {
+		// Arrange
+		path := writeFile(t, "doc.yaml", "b: 2\na: 1\n")
+
+		// Act
+		diff, err := compareGeneratedWithDiskFile(path, []byte("a: 1\nb: 2"), false)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Empty(t, diff)
+	})
+
+	t.Run("reports structural differences with paths", func(t *testing.T) {
+		// Arrange
+		path := writeFile(t, "doc.json", `{"info": {"title": "Old"}, "paths": {"/users": {}}, "extra": true}`)
+
+		// Act
+		diff, err := compareGeneratedWithDiskFile(path, []byte(`{"info": {"title": "New"}, "paths": {"/users": {}, "/orders": {}}}`), false)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Contains(t, diff, diffSemanticDiffers)
+		assert.Contains(t, diff, `/info/title: generated "New", on disk "Old"`)
+		assert.Contains(t, diff, "/paths/~1orders: missing on disk")
+		assert.Contains(t, diff, "/extra: not in generated content")
+	})
+
+	t.Run("compares byte by byte in strict mode", func(t *testing.T) {
+		// Arrange
+		path := writeFile(t, "doc.json", `{"b": 1, "a": 2}`)
+
+		// Act
+		diff, err := compareGeneratedWithDiskFile(path, []byte(`{"a": 2, "b": 1}`), true)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Contains(t, diff, diffContentDiffers)
+	})
+
+	t.Run("compares byte by byte for non JSON or YAML files", func(t *testing.T) {
+		// Arrange
+		path := writeFile(t, "server.go", "package api\n")
+
+		// Act
+		diff, err := compareGeneratedWithDiskFile(path, []byte("package api\n\n"), false)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Contains(t, diff, diffContentDiffers)
+	})
+
+	t.Run("reports unparsable file on disk", func(t *testing.T) {
+		// Arrange
+		path := writeFile(t, "doc.json", "{unclosed: [")
+
+		// Act
+		diff, err := compareGeneratedWithDiskFile(path, []byte(`{"a": 1}`), false)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Contains(t, diff, diffDiskUnparsable)
+	})
+
+	t.Run("reports missing file", func(t *testing.T) {
+		// Act
+		diff, err := compareGeneratedWithDiskFile(filepath.Join(t.TempDir(), "missing.json"), []byte(`{}`), false)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, diffFileNotExist, diff)
+	})
+}
+
+func TestGenerateDetailedDiff(t *testing.T) {
+	t.Run("single line difference", func(t *testing.T) {
+		// Arrange
+		generated := []byte("line 1\nmodified line\nline 3")
+		disk := []byte("line 1\noriginal line\nline 3")
+
+		// Act
+		diff := generateDetailedDiff(generated, disk)
+
+		// Assert
+		assert.Contains(t, diff, "First difference found at line 2", "Should identify correct line number")
+		assert.Contains(t, diff, "modified line", "Should show generated content")
+		assert.Contains(t, diff, "original line", "Should show disk content")
+		assert.Contains(t, diff, "→ 2:", "Should mark differing line")
+	})
+
+	t.Run("difference at beginning", func(t *testing.T) {
+		// Arrange
+		generated := []byte("modified first line\nline 2\nline 3")
+		disk := []byte("original first line\nline 2\nline 3")
+
+		// Act
+		diff := generateDetailedDiff(generated, disk)
+
+		// Assert
+		assert.Contains(t, diff, "First difference found at line 1", "Should identify first line difference")
+		assert.Contains(t, diff, "→ 1:", "Should mark first line")
+	})
+
+	t.Run("multiple differences shows additional count", func(t *testing.T) {
+		// Arrange
+		generated := []byte("line 1\nmodified 2\nline 3\nmodified 4\nmodified 5")
+		disk := []byte("line 1\noriginal 2\nline 3\noriginal 4\noriginal 5")
+
+		// Act
+		diff := generateDetailedDiff(generated, disk)
+
+		// Assert
+		assert.Contains(t, diff, "First difference found at line 2", "Should show first difference")
+		assert.Contains(t, diff, "more line(s) differ", "Should indicate additional differences")
+	})
+
+	t.Run("files with different lengths", func(t *testing.T) {
+		// Arrange
+		generated := []byte("line 1\nline 2\nextra line")
+		disk := []byte("line 1\nline 2")
+
+		// Act
+		diff := generateDetailedDiff(generated, disk)
+
+		// Assert
+		assert.Contains(t, diff, "First difference found at line 3", "Should identify length difference")
+		assert.Contains(t, diff, "more line(s) differ", "Should indicate length difference")
+	})
+}
+
+func TestFindFirstDifference(t *testing.T) {
+	t.Run("identical content returns -1", func(t *testing.T) {
+		// Arrange
+		lines1 := []string{"line 1", "line 2", "line 3"}
+		lines2 := []string{"line 1", "line 2", "line 3"}
+
+		// Act
+		diff := findFirstDifference(lines1, lines2)
+
+		// Assert
+		assert.Equal(t, -1, diff, "Identical content should return -1")
+	})
+
+	t.Run("different content returns correct line", func(t *testing.T) {
+		// Arrange
+		lines1 := []string{"line 1", "modified", "line 3"}
+		lines2 := []string{"line 1", "original", "line 3"}
+
+		// Act
+		diff := findFirstDifference(lines1, lines2)
+
+		// Assert
+		assert.Equal(t, 1, diff, "Should return index of first different line")
+	})
+
+	t.Run("different lengths returns correct position", func(t *testing.T) {
+		// Arrange
+		lines1 := []string{"line 1", "line 2", "extra"}
+		lines2 := []string{"line 1", "line 2"}
+
+		// Act
+		diff := findFirstDifference(lines1, lines2)
+
+		// Assert
+		assert.Equal(t, 2, diff, "Should return position where lengths differ")
+	})
+}
+
+func TestCountAdditionalDifferences(t *testing.T) {
+	t.Run("no additional differences", func(t *testing.T) {
+		// Arrange
+		lines1 := []string{"line 1", "different", "line 3"}
+		lines2 := []string{"line 1", "original", "line 3"}
+
+		// Act
+		count := countAdditionalDifferences(lines1, lines2, 2) // Start after first diff at index 1
+
+		// Assert
+		assert.Equal(t, 0, count, "Should count no additional differences")
+	})
+
+	t.Run("multiple differences", func(t *testing.T) {
+		// Arrange
+		lines1 := []string{"line 1", "diff1", "line 3", "diff2", "diff3"}
+		lines2 := []string{"line 1", "orig1", "line 3", "orig2", "orig3"}
+
+		// Act
+		count := countAdditionalDifferences(lines1, lines2, 2) // Start after first diff at index 1
+
+		// Assert
+		assert.Equal(t, 2, count, "Should count remaining differences")
+	})
+
+	t.Run("different lengths", func(t *testing.T) {
+		// Arrange
+		lines1 := []string{"line 1", "diff", "line 3", "extra1", "extra2"}
+		lines2 := []string{"line 1", "orig", "line 3"}
 
-			// Assert
-			assert.Equal(t, tc.expectedResult, result, "Test file path should be generated correctly")
-		})
-	}
-}
+		// Act
+		count := countAdditionalDifferences(lines1, lines2, 2) // Start after first diff at index 1
 
-// stringPtr returns a pointer to a string value - helper for tests
-func stringPtr(s string) *string {
-	return &s
+		// Assert
+		assert.Equal(t, 2, count, "Should count extra lines as differences")
+	})
 }
 
-// Test_generateServerFromSpecification_e2e tests the servergen functionality end-to-end
-func Test_generateServerFromSpecification_e2e(t *testing.T) {
-	// Test constants to avoid hardcoded strings
-	const (
-		testServerPackage    = "testapi"
-		expectedPackageDecl  = "package api"
-		expectedImportGin    = `"github.com/gin-gonic/gin"`
-		expectedImportTypes  = `"github.com/meitner-se/go-types"`
-		expectedRegisterFunc = "func RegisterTestServiceAPI[Session any]"
-		expectedErrorType    = "type Error struct {"
-		expectedOpenAPIRoute = `routerGroup.StaticFileFS("/openapi.json", "openapi.json", http.FS(api.OpenAPI_JSON))`
-	)
+// ============================================================================
+// Format Command Tests
+// ============================================================================
 
-	// Create a test service with various features
-	service := &specification.Service{
-		Name:    "TestService",
-		Version: "v1",
-		Enums: []specification.Enum{
-			{
-				Name:        "Status",
-				Description: "Status enumeration",
-				Values: []specification.EnumValue{
-					{Name: "Active", Description: "Active status"},
-					{Name: "Inactive", Description: "Inactive status"},
-				},
-			},
-			{
-				Name:        "ErrorCode",
-				Description: "Error codes",
-				Values: []specification.EnumValue{
-					{Name: "BadRequest", Description: "Bad request"},
-					{Name: "Unauthorized", Description: "Unauthorized"},
-					{Name: "Forbidden", Description: "Forbidden"},
-					{Name: "NotFound", Description: "Not found"},
-					{Name: "Conflict", Description: "Conflict"},
-					{Name: "UnprocessableEntity", Description: "Unprocessable entity"},
-					{Name: "RateLimited", Description: "Rate limited"},
-					{Name: "Internal", Description: "Internal error"},
-				},
-			},
-		},
-		Objects: []specification.Object{
-			{
-				Name:        "User",
-				Description: "User object",
-				Fields: []specification.Field{
-					{
-						Name:        "ID",
-						Description: "User ID",
-						Type:        "UUID",
-					},
-					{
-						Name:        "Name",
-						Description: "User name",
-						Type:        "String",
-					},
-					{
-						Name:        "Status",
-						Description: "User status",
-						Type:        "Status",
-					},
-				},
-			},
-			{
-				Name:        "Error",
-				Description: "Error object",
-				Fields: []specification.Field{
-					{
-						Name:        "Code",
-						Description: "Error code",
-						Type:        "ErrorCode",
-					},
-					{
-						Name:        "Message",
-						Description: "Error message",
-						Type:        "String",
-					},
-					{
-						Name:        "RequestID",
-						Description: "Request ID",
-						Type:        "String",
-					},
-				},
-			},
-		},
-		Resources: []specification.Resource{
-			{
-				Name:        "User",
-				Description: "User resource",
-				Operations:  []string{"Create", "Read", "Update", "Delete"},
-				Fields: []specification.ResourceField{
-					{
-						Field: specification.Field{
-							Name:        "ID",
-							Description: "User ID",
-							Type:        "UUID",
-						},
-						Operations: []string{"Read"},
-					},
-					{
-						Field: specification.Field{
-							Name:        "Name",
-							Description: "User name",
-							Type:        "String",
-						},
-						Operations: []string{"Create", "Read", "Update"},
-					},
-				},
-				Endpoints: []specification.Endpoint{
-					{
-						Name:        "CreateUser",
-						Method:      "POST",
-						Path:        "/users",
-						Summary:     "Create a new user",
-						Description: "Creates a new user in the system",
-						Request: specification.EndpointRequest{
-							BodyParams: []specification.Field{
-								{
-									Name:        "Name",
-									Description: "User name",
-									Type:        "String",
-								},
-							},
-						},
-						Response: specification.EndpointResponse{
-							StatusCode: 201,
-							BodyObject: stringPtr("User"),
-						},
-					},
-					{
-						Name:        "GetUser",
-						Method:      "GET",
-						Path:        "/users/{id}",
-						Summary:     "Get a user by ID",
-						Description: "Retrieves a user by their ID",
-						Request: specification.EndpointRequest{
-							PathParams: []specification.Field{
-								{
-									Name:        "ID",
-									Description: "User ID",
-									Type:        "UUID",
-								},
-							},
-						},
-						Response: specification.EndpointResponse{
-							StatusCode: 200,
-							BodyObject: stringPtr("User"),
-						},
-					},
-				},
-			},
-		},
-	}
+func Test_runFmtCommand(t *testing.T) {
+	unformatted := "version: 1.0.0\nname: Users API\n"
+	formatted := "name: Users API\nversion: 1.0.0\n"
 
-	// Create a temporary directory for test output
-	tempDir := os.TempDir()
-	outputPath := tempDir + "/test_server.go"
-	defer os.Remove(outputPath) // Clean up after test
+	t.Run("formats files in place", func(t *testing.T) {
+		// Arrange
+		path := filepath.Join(t.TempDir(), "users.yaml")
+		require.NoError(t, os.WriteFile(path, []byte(unformatted), 0644))
+		var stdout bytes.Buffer
 
-	ctx := context.Background()
+		// Act
+		err := runFmtCommand(context.Background(), []string{path}, &stdout)
 
-	// Act - Generate server code
-	err := generateServerFromSpecification(ctx, service, "test-spec.yaml", outputPath, testServerPackage)
+		// Assert
+		require.NoError(t, err)
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, formatted, string(content))
+		assert.Contains(t, stdout.String(), "Formatted: "+path)
+	})
 
-	// Assert
-	assert.Nil(t, err, "Expected no error when generating server code")
+	t.Run("check lists unformatted files without rewriting them", func(t *testing.T) {
+		// Arrange
+		dir := t.TempDir()
+		unformattedPath := filepath.Join(dir, "users.yaml")
+		formattedPath := filepath.Join(dir, "products.yaml")
+		require.NoError(t, os.WriteFile(unformattedPath, []byte(unformatted), 0644))
+		require.NoError(t, os.WriteFile(formattedPath, []byte(formatted), 0644))
+		var stdout bytes.Buffer
 
-	// Verify file was created
-	_, statErr := os.Stat(outputPath)
-	assert.Nil(t, statErr, "Expected generated file to exist")
+		// Act
+		err := runFmtCommand(context.Background(), []string{"-" + checkFlag, unformattedPath, formattedPath}, &stdout)
 
-	// Read and verify generated content
-	content, readErr := os.ReadFile(outputPath)
-	assert.Nil(t, readErr, "Expected no error reading generated file")
+		// Assert
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), errorNotFormatted)
+		assert.Equal(t, unformattedPath+"\n", stdout.String())
+		content, err := os.ReadFile(unformattedPath)
+		require.NoError(t, err)
+		assert.Equal(t, unformatted, string(content), "Check mode should not rewrite files")
+	})
 
-	generatedCode := string(content)
-	assert.NotEmpty(t, generatedCode, "Expected generated code to be non-empty")
+	t.Run("check passes for formatted files", func(t *testing.T) {
+		// Arrange
+		path := filepath.Join(t.TempDir(), "users.yaml")
+		require.NoError(t, os.WriteFile(path, []byte(formatted), 0644))
+		var stdout bytes.Buffer
 
-	// Verify key components of generated code
-	assert.Contains(t, generatedCode, expectedPackageDecl, "Generated code should contain package declaration")
-	assert.Contains(t, generatedCode, expectedImportGin, "Generated code should import gin")
-	assert.Contains(t, generatedCode, expectedImportTypes, "Generated code should import go-types")
-	assert.Contains(t, generatedCode, expectedRegisterFunc, "Generated code should contain RegisterAPI function")
-	assert.Contains(t, generatedCode, expectedErrorType, "Generated code should contain Error type")
-	assert.Contains(t, generatedCode, expectedOpenAPIRoute, "Generated code should contain OpenAPI route")
+		// Act
+		err := runFmtCommand(context.Background(), []string{"-" + checkFlag, path}, &stdout)
 
-	// Verify enum generation
-	assert.Contains(t, generatedCode, "StatusActive", "Generated code should contain StatusActive")
-	assert.Contains(t, generatedCode, "types.NewString(\"Active\")", "Generated code should contain enum values")
+		// Assert
+		require.NoError(t, err)
+		assert.Empty(t, stdout.String())
+	})
 
-	// Verify object generation
-	assert.Contains(t, generatedCode, "type User struct {", "Generated code should contain User object")
-	assert.Contains(t, generatedCode, "ID types.UUID `json:\"id\"`", "Generated code should contain User fields")
+	t.Run("returns error without files", func(t *testing.T) {
+		// Act
+		err := runFmtCommand(context.Background(), nil, io.Discard)
 
-	// Verify endpoint generation (Note: servergen includes resource name in path)
-	assert.Contains(t, generatedCode, "routerGroup.POST(\"/user/users\", serveWithResponse(201, api.Server, api.User.CreateUser))", "Generated code should contain POST endpoint")
-	assert.Contains(t, generatedCode, "routerGroup.GET(\"/user/users/:id\", serveWithResponse(200, api.Server, api.User.GetUser))", "Generated code should contain GET endpoint")
+		// Assert
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), errorNoFiles)
+	})
 
-	// Verify request/response types
-	assert.Contains(t, generatedCode, "type UserCreateUserBodyParams struct {", "Generated code should contain request body type")
-	assert.Contains(t, generatedCode, "type UserGetUserPathParams struct {", "Generated code should contain path params type")
-	// Note: Response types are not generated when using BodyObject
+	t.Run("returns error for missing file", func(t *testing.T) {
+		// Act
+		err := runFmtCommand(context.Background(), []string{filepath.Join(t.TempDir(), "missing.yaml")}, io.Discard)
 
-	t.Run("edge cases", func(t *testing.T) {
-		t.Run("empty service", func(t *testing.T) {
-			// Arrange
-			emptyService := &specification.Service{
-				Name:    "EmptyService",
-				Version: "v1",
-			}
-			outputPath := tempDir + "/empty_server.go"
-			defer os.Remove(outputPath)
+		// Assert
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), errorFileRead)
+	})
+}
+
+func Test_runMigrateCommand(t *testing.T) {
+	t.Run("migrates files in place", func(t *testing.T) {
+		// Arrange
+		path := filepath.Join(t.TempDir(), "users.yaml")
+		require.NoError(t, os.WriteFile(path, []byte("name: Users API\n"), 0644))
+		var stdout bytes.Buffer
+
+		// Act
+		err := runMigrateCommand(context.Background(), []string{path}, &stdout)
+
+		// Assert
+		require.NoError(t, err)
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "name: Users API\nschema_version: 1\n", string(content))
+		assert.Contains(t, stdout.String(), "Migrated: "+path)
+	})
 
-			// Act
-			err := generateServerFromSpecification(ctx, emptyService, "empty-spec.yaml", outputPath, "emptyapi")
+	t.Run("check lists files that are not migrated", func(t *testing.T) {
+		// Arrange
+		dir := t.TempDir()
+		oldPath := filepath.Join(dir, "users.yaml")
+		currentPath := filepath.Join(dir, "products.yaml")
+		require.NoError(t, os.WriteFile(oldPath, []byte("name: Users API\n"), 0644))
+		require.NoError(t, os.WriteFile(currentPath, []byte("name: Products API\nschema_version: 1\n"), 0644))
+		var stdout bytes.Buffer
 
-			// Assert
-			assert.Nil(t, err, "Expected no error with empty service")
+		// Act
+		err := runMigrateCommand(context.Background(), []string{"-" + checkFlag, oldPath, currentPath}, &stdout)
 
-			// Verify file was created
-			_, statErr := os.Stat(outputPath)
-			assert.Nil(t, statErr, "Expected generated file to exist")
+		// Assert
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), errorNotMigrated)
+		assert.Equal(t, oldPath+"\n", stdout.String())
+	})
 
-			// Read and verify basic structure
-			content, _ := os.ReadFile(outputPath)
-			generatedCode := string(content)
-			assert.Contains(t, generatedCode, expectedPackageDecl, "Should still generate basic structure")
-			assert.Contains(t, generatedCode, "func RegisterEmptyServiceAPI[Session any]", "Should generate register function for empty service")
-		})
+	t.Run("returns error for newer schema version", func(t *testing.T) {
+		// Arrange
+		path := filepath.Join(t.TempDir(), "users.yaml")
+		require.NoError(t, os.WriteFile(path, []byte("name: Users API\nschema_version: 99\n"), 0644))
 
-		t.Run("service with no endpoints", func(t *testing.T) {
-			// Arrange
-			serviceNoEndpoints := &specification.Service{
-				Name:    "NoEndpointService",
-				Version: "v1",
-				Resources: []specification.Resource{
-					{
-						Name:        "Resource",
-						Description: "Resource with no endpoints",
-						Endpoints:   []specification.Endpoint{}, // No endpoints
-					},
-				},
-			}
-			outputPath := tempDir + "/no_endpoints_server.go"
-			defer os.Remove(outputPath)
+		// Act
+		err := runMigrateCommand(context.Background(), []string{path}, io.Discard)
 
-			// Act
-			err := generateServerFromSpecification(ctx, serviceNoEndpoints, "no-endpoints-spec.yaml", outputPath, "noendpointsapi")
+		// Assert
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to migrate")
+	})
 
-			// Assert
-			assert.Nil(t, err, "Expected no error with no endpoints")
+	t.Run("returns error without files", func(t *testing.T) {
+		// Act
+		err := runMigrateCommand(context.Background(), nil, io.Discard)
 
-			// Verify file was created
-			_, statErr := os.Stat(outputPath)
-			assert.Nil(t, statErr, "Expected generated file to exist")
-		})
+		// Assert
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), errorNoFiles)
 	})
 }
 
-// ============================================================================
-// Diff Functionality Tests
-// ============================================================================
+func Test_runChangelogCommand(t *testing.T) {
+	writeConfig := func(t *testing.T, job string) string {
+		configPath := filepath.Join(t.TempDir(), "publicapis.yaml")
+		require.NoError(t, os.WriteFile(configPath, []byte(job), 0644))
+		return configPath
+	}
 
-func TestCompareWithDiskFile(t *testing.T) {
-	// Create a temporary directory for test files
-	tempDir, err := os.MkdirTemp("", "diff_test")
-	require.NoError(t, err)
-	defer os.RemoveAll(tempDir)
+	t.Run("compares the generated document with the file on disk", func(t *testing.T) {
+		// Arrange
+		openAPIPath := filepath.Join(t.TempDir(), "openapi.json")
+		require.NoError(t, os.WriteFile(openAPIPath, []byte(`{"info": {"title": "School Management API", "version": "0.9.0"}}`), 0644))
+		configPath := writeConfig(t, "- specification: testdata/school-management-api.yaml\n  openapi_json: "+openAPIPath+"\n")
+		var stdout bytes.Buffer
 
-	t.Run("identical files return no difference", func(t *testing.T) {
+		// Act
+		err := runChangelogCommand(context.Background(), []string{"-" + configFileFlag, configPath}, &stdout)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Contains(t, stdout.String(), "(from 0.9.0)")
+		assert.Contains(t, stdout.String(), "### Added endpoints\n")
+		assert.Contains(t, stdout.String(), "### Added schemas\n")
+	})
+
+	t.Run("reports no changes after generate", func(t *testing.T) {
 		// Arrange
-		content := "line 1\nline 2\nline 3\n"
-		testFile := tempDir + "/identical.txt"
-		err := os.WriteFile(testFile, []byte(content), 0644)
+		openAPIPath := filepath.Join(t.TempDir(), "openapi.yaml")
+		configPath := writeConfig(t, "- specification: testdata/school-management-api.yaml\n  openapi_yaml: "+openAPIPath+"\n")
+		config, err := parseConfigFile(configPath)
+		require.NoError(t, err)
+		_, err = processJob(context.Background(), config[0])
 		require.NoError(t, err)
+		outputPath := filepath.Join(t.TempDir(), "CHANGELOG.md")
 
 		// Act
-		diff, err := compareWithDiskFile(testFile, []byte(content))
+		err = runChangelogCommand(context.Background(), []string{"-" + configFileFlag, configPath, "-" + outputFlag, outputPath}, io.Discard)
 
 		// Assert
-		assert.NoError(t, err)
-		assert.Empty(t, diff, "Identical files should produce no diff output")
+		require.NoError(t, err)
+		content, err := os.ReadFile(outputPath)
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "No changes.")
 	})
 
-	t.Run("non-existent file returns appropriate message", func(t *testing.T) {
+	t.Run("returns error without OpenAPI jobs", func(t *testing.T) {
 		// Arrange
-		nonExistentFile := tempDir + "/does_not_exist.txt"
-		content := []byte("some content")
+		configPath := writeConfig(t, "- specification: testdata/school-management-api.yaml\n  schema_json: "+filepath.Join(t.TempDir(), "schema.json")+"\n")
 
 		// Act
-		diff, err := compareWithDiskFile(nonExistentFile, content)
+		err := runChangelogCommand(context.Background(), []string{"-" + configFileFlag, configPath}, io.Discard)
 
 		// Assert
-		assert.NoError(t, err)
-		assert.Equal(t, diffFileNotExist, diff)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), errorNoOpenAPIJobs)
 	})
+}
 
-	t.Run("different files show detailed diff", func(t *testing.T) {
+func Test_runSchemaCommand(t *testing.T) {
+	t.Run("exports the schema files with their ids", func(t *testing.T) {
 		// Arrange
-		diskContent := "line 1\noriginal line 2\nline 3\nline 4\n"
-		generatedContent := "line 1\nmodified line 2\nline 3\nline 4\n"
-		testFile := tempDir + "/different.txt"
-		err := os.WriteFile(testFile, []byte(diskContent), 0644)
-		require.NoError(t, err)
+		outputDir := filepath.Join(t.TempDir(), "schemas")
+		var stdout bytes.Buffer
 
 		// Act
-		diff, err := compareWithDiskFile(testFile, []byte(generatedContent))
+		err := runSchemaCommand(context.Background(), []string{subcommandExport, "-" + outputFlag, outputDir, "-" + baseURLFlag, "https://example.com/schemas/"}, &stdout)
 
 		// Assert
-		assert.NoError(t, err)
-		assert.Contains(t, diff, diffContentDiffers, "Should indicate content differs")
-		assert.Contains(t, diff, "First difference found at line 2", "Should show line number of first difference")
-		assert.Contains(t, diff, diffGeneratedHeader, "Should show generated content header")
-		assert.Contains(t, diff, diffDiskHeader, "Should show disk content header")
-		assert.Contains(t, diff, "modified line 2", "Should show generated content")
-		assert.Contains(t, diff, "original line 2", "Should show disk content")
-		assert.Contains(t, diff, "→ 2:", "Should mark the differing line with arrow")
+		require.NoError(t, err)
+		data, err := os.ReadFile(filepath.Join(outputDir, schemagen.ServiceSchemaFile))
+		require.NoError(t, err)
+		var schema map[string]any
+		require.NoError(t, json.Unmarshal(data, &schema))
+		assert.Equal(t, "https://example.com/schemas/service.schema.json", schema["$id"])
+		assert.FileExists(t, filepath.Join(outputDir, "resource-field.schema.json"))
+		assert.Contains(t, stdout.String(), "# yaml-language-server: $schema=https://example.com/schemas/service.schema.json\n")
 	})
 
-	t.Run("files with different lengths show diff", func(t *testing.T) {
+	t.Run("references the exported file without a base URL", func(t *testing.T) {
 		// Arrange
-		diskContent := "line 1\nline 2\n"
-		generatedContent := "line 1\nline 2\nline 3\nextra line\n"
-		testFile := tempDir + "/different_length.txt"
-		err := os.WriteFile(testFile, []byte(diskContent), 0644)
+		outputDir := t.TempDir()
+		var stdout bytes.Buffer
+
+		// Act
+		err := runSchemaCommand(context.Background(), []string{subcommandExport, "-" + outputFlag, outputDir}, &stdout)
+
+		// Assert
 		require.NoError(t, err)
+		assert.Contains(t, stdout.String(), "$schema="+filepath.ToSlash(filepath.Join(outputDir, schemagen.ServiceSchemaFile))+"\n")
+	})
 
+	t.Run("unknown subcommand returns error", func(t *testing.T) {
 		// Act
-		diff, err := compareWithDiskFile(testFile, []byte(generatedContent))
+		err := runSchemaCommand(context.Background(), []string{"publish"}, io.Discard)
 
 		// Assert
-		assert.NoError(t, err)
-		assert.Contains(t, diff, diffContentDiffers, "Should indicate content differs")
-		assert.Contains(t, diff, "First difference found at line 3", "Should show first difference at end of shorter file")
-		assert.Contains(t, diff, "more line(s) differ", "Should indicate additional differences")
+		assert.ErrorContains(t, err, errorInvalidSubcommand)
+	})
+
+	t.Run("missing subcommand returns error", func(t *testing.T) {
+		// Act
+		err := runSchemaCommand(context.Background(), nil, io.Discard)
+
+		// Assert
+		assert.ErrorContains(t, err, errorMissingCommand)
 	})
 }
 
-func TestGenerateDetailedDiff(t *testing.T) {
-	t.Run("single line difference", func(t *testing.T) {
-		// Arrange
-		generated := []byte("line 1\nmodified line\nline 3")
-		disk := []byte("line 1\noriginal line\nline 3")
+func Test_newSchemaHandler(t *testing.T) {
+	// Arrange
+	handler := newSchemaHandler(map[string][]byte{schemagen.ServiceSchemaFile: []byte(`{"$ref": "#/$defs/Service"}`)})
 
+	t.Run("serves the schema files", func(t *testing.T) {
 		// Act
-		diff := generateDetailedDiff(generated, disk)
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/"+schemagen.ServiceSchemaFile, nil))
 
 		// Assert
-		assert.Contains(t, diff, "First difference found at line 2", "Should identify correct line number")
-		assert.Contains(t, diff, "modified line", "Should show generated content")
-		assert.Contains(t, diff, "original line", "Should show disk content")
-		assert.Contains(t, diff, "→ 2:", "Should mark differing line")
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, contentTypeSchemaJSON, recorder.Header().Get("Content-Type"))
+		assert.Equal(t, `{"$ref": "#/$defs/Service"}`, recorder.Body.String())
 	})
 
-	t.Run("difference at beginning", func(t *testing.T) {
-		// Arrange
-		generated := []byte("modified first line\nline 2\nline 3")
-		disk := []byte("original first line\nline 2\nline 3")
+	t.Run("unknown file is not found", func(t *testing.T) {
+		// Act
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/other.schema.json", nil))
+
+		// Assert
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+	})
+}
 
+func Test_runMockCommand(t *testing.T) {
+	t.Run("returns error without specification", func(t *testing.T) {
 		// Act
-		diff := generateDetailedDiff(generated, disk)
+		err := runMockCommand(context.Background(), nil, strings.NewReader(""))
 
 		// Assert
-		assert.Contains(t, diff, "First difference found at line 1", "Should identify first line difference")
-		assert.Contains(t, diff, "→ 1:", "Should mark first line")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), errorMissingSpec)
 	})
 
-	t.Run("multiple differences shows additional count", func(t *testing.T) {
-		// Arrange
-		generated := []byte("line 1\nmodified 2\nline 3\nmodified 4\nmodified 5")
-		disk := []byte("line 1\noriginal 2\nline 3\noriginal 4\noriginal 5")
+	t.Run("returns error for invalid port", func(t *testing.T) {
+		// Act
+		err := runMockCommand(context.Background(), []string{"-" + specFlag, stdioPath, "-" + portFlag, "70000"}, strings.NewReader(""))
+
+		// Assert
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), errorInvalidPort)
+	})
 
+	t.Run("returns error for invalid specification", func(t *testing.T) {
 		// Act
-		diff := generateDetailedDiff(generated, disk)
+		err := runMockCommand(context.Background(), []string{"-" + specFlag, stdioPath}, strings.NewReader("name: [invalid"))
 
 		// Assert
-		assert.Contains(t, diff, "First difference found at line 2", "Should show first difference")
-		assert.Contains(t, diff, "more line(s) differ", "Should indicate additional differences")
+		require.Error(t, err)
 	})
+}
 
-	t.Run("files with different lengths", func(t *testing.T) {
+func Test_serveMock(t *testing.T) {
+	t.Run("stops when the context is done", func(t *testing.T) {
 		// Arrange
-		generated := []byte("line 1\nline 2\nextra line")
-		disk := []byte("line 1\nline 2")
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
 
 		// Act
-		diff := generateDetailedDiff(generated, disk)
+		err := serveMock(ctx, "127.0.0.1:0", http.NotFoundHandler())
 
 		// Assert
-		assert.Contains(t, diff, "First difference found at line 3", "Should identify length difference")
-		assert.Contains(t, diff, "more line(s) differ", "Should indicate length difference")
+		assert.NoError(t, err)
 	})
 }
 
-func TestFindFirstDifference(t *testing.T) {
-	t.Run("identical content returns -1", func(t *testing.T) {
+func Test_runProxyCommand(t *testing.T) {
+	t.Run("returns error without specification", func(t *testing.T) {
+		// Act
+		err := runProxyCommand(context.Background(), []string{"-" + targetFlag, "http://localhost:9000"}, strings.NewReader(""))
+
+		// Assert
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), errorMissingSpec)
+	})
+
+	t.Run("returns error without target", func(t *testing.T) {
+		// Act
+		err := runProxyCommand(context.Background(), []string{"-" + specFlag, stdioPath}, strings.NewReader(""))
+
+		// Assert
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), errorMissingTarget)
+	})
+
+	t.Run("returns error for relative target", func(t *testing.T) {
+		// Act
+		err := runProxyCommand(context.Background(), []string{"-" + specFlag, stdioPath, "-" + targetFlag, "localhost"}, strings.NewReader(""))
+
+		// Assert
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), errorInvalidTarget)
+	})
+
+	t.Run("returns error for invalid port", func(t *testing.T) {
+		// Act
+		err := runProxyCommand(context.Background(), []string{"-" + specFlag, stdioPath, "-" + targetFlag, "http://localhost:9000", "-" + portFlag, "0"}, strings.NewReader(""))
+
+		// Assert
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), errorInvalidPort)
+	})
+}
+
+func Test_runCoverageCommand(t *testing.T) {
+	const spec = `name: Users API
+version: v1
+resources:
+  - name: Users
+    operations: [Create, Get]
+    fields:
+      - name: Name
+        type: String
+        operations: [Create, Read]
+`
+	writePackages := func(t *testing.T) (string, string) {
+		implDir, testsDir := t.TempDir(), t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(implDir, "users.go"), []byte("package users\n\ntype service struct{}\n\nfunc (s *service) Create() {}\n"), 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(testsDir, "api_test.go"), []byte("package api\n\nimport \"testing\"\n\nfunc TestUsersGet(t *testing.T) {}\n"), 0644))
+		return implDir, testsDir
+	}
+
+	t.Run("writes a Markdown report", func(t *testing.T) {
 		// Arrange
-		lines1 := []string{"line 1", "line 2", "line 3"}
-		lines2 := []string{"line 1", "line 2", "line 3"}
+		implDir, testsDir := writePackages(t)
+		var stdout bytes.Buffer
 
 		// Act
-		diff := findFirstDifference(lines1, lines2)
+		err := runCoverageCommand(context.Background(), []string{"-" + specFlag, stdioPath, "-" + implFlag, implDir, "-" + testsFlag, testsDir}, strings.NewReader(spec), &stdout)
 
 		// Assert
-		assert.Equal(t, -1, diff, "Identical content should return -1")
+		require.NoError(t, err)
+		assert.Contains(t, stdout.String(), "1 of 2 endpoints implemented, 1 of 2 tested.")
+		assert.Contains(t, stdout.String(), "| Users | Create | POST | `/users-api/v1/users` | yes | no |")
 	})
 
-	t.Run("different content returns correct line", func(t *testing.T) {
+	t.Run("writes a JSON report to a file", func(t *testing.T) {
 		// Arrange
-		lines1 := []string{"line 1", "modified", "line 3"}
-		lines2 := []string{"line 1", "original", "line 3"}
+		implDir, testsDir := writePackages(t)
+		outputPath := filepath.Join(t.TempDir(), "coverage.json")
 
 		// Act
-		diff := findFirstDifference(lines1, lines2)
+		err := runCoverageCommand(context.Background(), []string{"-" + specFlag, stdioPath, "-" + implFlag, implDir, "-" + testsFlag, testsDir, "-" + formatFlag, reportFormatJSON, "-" + outputFlag, outputPath}, strings.NewReader(spec), io.Discard)
 
 		// Assert
-		assert.Equal(t, 1, diff, "Should return index of first different line")
+		require.NoError(t, err)
+		content, err := os.ReadFile(outputPath)
+		require.NoError(t, err)
+		var report coveragegen.Report
+		require.NoError(t, json.Unmarshal(content, &report))
+		assert.Equal(t, 2, report.Total)
+		assert.Equal(t, 1, report.Implemented)
+		assert.Equal(t, 1, report.Tested)
 	})
 
-	t.Run("different lengths returns correct position", func(t *testing.T) {
+	t.Run("returns error without specification", func(t *testing.T) {
+		// Act
+		err := runCoverageCommand(context.Background(), nil, strings.NewReader(""), io.Discard)
+
+		// Assert
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), errorMissingSpec)
+	})
+
+	t.Run("returns error without packages", func(t *testing.T) {
+		// Act
+		err := runCoverageCommand(context.Background(), []string{"-" + specFlag, stdioPath, "-" + implFlag, t.TempDir()}, strings.NewReader(spec), io.Discard)
+
+		// Assert
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), errorMissingPackage)
+	})
+
+	t.Run("returns error for invalid format", func(t *testing.T) {
 		// Arrange
-		lines1 := []string{"line 1", "line 2", "extra"}
-		lines2 := []string{"line 1", "line 2"}
+		implDir, testsDir := writePackages(t)
 
 		// Act
-		diff := findFirstDifference(lines1, lines2)
+		err := runCoverageCommand(context.Background(), []string{"-" + specFlag, stdioPath, "-" + implFlag, implDir, "-" + testsFlag, testsDir, "-" + formatFlag, "html"}, strings.NewReader(spec), io.Discard)
 
 		// Assert
-		assert.Equal(t, 2, diff, "Should return position where lengths differ")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), errorInvalidReport)
 	})
 }
 
-func TestCountAdditionalDifferences(t *testing.T) {
-	t.Run("no additional differences", func(t *testing.T) {
+func Test_runLintCommand(t *testing.T) {
+	const spec = `name: Users API
+version: v1
+enums:
+  - name: Color
+    values:
+      - name: Red
+objects:
+  - name: Legacy
+    fields:
+      - name: Color
+        type: Color
+resources:
+  - name: Users
+    operations: [Get]
+    fields:
+      - name: Name
+        type: String
+        operations: [Read]
+`
+
+	t.Run("warns about unused definitions", func(t *testing.T) {
 		// Arrange
-		lines1 := []string{"line 1", "different", "line 3"}
-		lines2 := []string{"line 1", "original", "line 3"}
+		path := filepath.Join(t.TempDir(), "users.yaml")
+		require.NoError(t, os.WriteFile(path, []byte(spec), 0644))
+		var stdout bytes.Buffer
 
 		// Act
-		count := countAdditionalDifferences(lines1, lines2, 2) // Start after first diff at index 1
+		err := runLintCommand(context.Background(), []string{path}, &stdout)
 
 		// Assert
-		assert.Equal(t, 0, count, "Should count no additional differences")
+		require.NoError(t, err, "Unused definitions should only be warnings")
+		assert.Equal(t, path+": warning: enum 'Color' is not used\n"+path+": warning: object 'Legacy' is not used\n", stdout.String())
 	})
 
-	t.Run("multiple differences", func(t *testing.T) {
+	t.Run("returns error for a missing file", func(t *testing.T) {
+		// Act
+		err := runLintCommand(context.Background(), []string{filepath.Join(t.TempDir(), "missing.yaml")}, io.Discard)
+
+		// Assert
+		require.Error(t, err)
+	})
+
+	t.Run("returns error without files", func(t *testing.T) {
+		// Act
+		err := runLintCommand(context.Background(), nil, io.Discard)
+
+		// Assert
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), errorNoFiles)
+	})
+}
+
+func Test_newViolationReporter(t *testing.T) {
+	t.Run("prints a line per violation and appends the report as JSON", func(t *testing.T) {
 		// Arrange
-		lines1 := []string{"line 1", "diff1", "line 3", "diff2", "diff3"}
-		lines2 := []string{"line 1", "orig1", "line 3", "orig2", "orig3"}
+		var output, reportFile bytes.Buffer
+		report := newViolationReporter(&output, &reportFile)
 
 		// Act
-		count := countAdditionalDifferences(lines1, lines2, 2) // Start after first diff at index 1
+		report(conformance.Report{
+			Method:     http.MethodGet,
+			Path:       "/user/42",
+			Endpoint:   "User.Get",
+			Direction:  conformance.DirectionResponse,
+			StatusCode: http.StatusOK,
+			Violations: []conformance.Violation{
+				{Path: "role", Code: conformance.CodeInvalidValue, Message: "Role must be one of the values of Role"},
+				{Code: conformance.CodeUnexpectedContentType, Message: "content type 'text/html', expected 'application/json'"},
+			},
+		})
 
 		// Assert
-		assert.Equal(t, 2, count, "Should count remaining differences")
+		assert.Equal(t, "GET /user/42 (User.Get) 200: response: role: invalid_value: Role must be one of the values of Role\n"+
+			"GET /user/42 (User.Get) 200: response: unexpected_content_type: content type 'text/html', expected 'application/json'\n", output.String())
+
+		var decoded conformance.Report
+		require.NoError(t, json.Unmarshal(reportFile.Bytes(), &decoded))
+		assert.Equal(t, "User.Get", decoded.Endpoint)
+		assert.Len(t, decoded.Violations, 2)
 	})
+}
 
-	t.Run("different lengths", func(t *testing.T) {
+func Test_serveProxy(t *testing.T) {
+	t.Run("stops when the context is done", func(t *testing.T) {
 		// Arrange
-		lines1 := []string{"line 1", "diff", "line 3", "extra1", "extra2"}
-		lines2 := []string{"line 1", "orig", "line 3"}
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
 
 		// Act
-		count := countAdditionalDifferences(lines1, lines2, 2) // Start after first diff at index 1
+		err := serveProxy(ctx, "127.0.0.1:0", &url.URL{Scheme: "http", Host: "localhost:9000"}, http.NotFoundHandler())
 
 		// Assert
-		assert.Equal(t, 2, count, "Should count extra lines as differences")
+		assert.NoError(t, err)
 	})
 }
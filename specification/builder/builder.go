@@ -0,0 +1,673 @@
+package builder
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/meitner-se/publicapis-gen/specification"
+)
+
+// Error messages
+const (
+	errorMissingName      = "name is required"
+	errorDuplicateName    = "duplicate name"
+	errorMisplacedRequire = "required_on is only allowed for resource fields"
+	errorInvalidMethod    = "invalid HTTP method"
+	errorNilBuilder       = "builder is nil"
+)
+
+// contentTypeJSON is the content type of the requests and responses of the endpoints
+const contentTypeJSON = "application/json"
+
+// httpMethods are the methods accepted for endpoints
+var httpMethods = []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete}
+
+// ServiceBuilder builds a specification.Service.
+type ServiceBuilder struct {
+	service   specification.Service
+	enums     []*EnumBuilder
+	objects   []*ObjectBuilder
+	resources []*ResourceBuilder
+	configure []func(*specification.Service)
+}
+
+// NewService returns a builder of a service with the name.
+func NewService(name string) *ServiceBuilder {
+	return &ServiceBuilder{service: specification.Service{Name: name}}
+}
+
+// Version sets the version of the service.
+func (b *ServiceBuilder) Version(version string) *ServiceBuilder {
+	b.service.Version = version
+	return b
+}
+
+// BasePath sets the path prefixing all routes of the service.
+func (b *ServiceBuilder) BasePath(basePath string) *ServiceBuilder {
+	b.service.BasePath = basePath
+	return b
+}
+
+// Strict makes the objects and request bodies of the service reject unknown fields.
+func (b *ServiceBuilder) Strict() *ServiceBuilder {
+	b.service.Strict = true
+	return b
+}
+
+// AddServer adds a server the service is available on, with the variables of the placeholders in its URL.
+func (b *ServiceBuilder) AddServer(url, description string, variables ...specification.ServerVariable) *ServiceBuilder {
+	b.service.Servers = append(b.service.Servers, specification.ServiceServer{URL: url, Description: description, Variables: variables})
+	return b
+}
+
+// AddEnum adds an enum to the service.
+func (b *ServiceBuilder) AddEnum(enum *EnumBuilder) *ServiceBuilder {
+	b.enums = append(b.enums, enum)
+	return b
+}
+
+// AddObject adds an object to the service.
+func (b *ServiceBuilder) AddObject(object *ObjectBuilder) *ServiceBuilder {
+	b.objects = append(b.objects, object)
+	return b
+}
+
+// AddResource adds a resource to the service.
+func (b *ServiceBuilder) AddResource(resource *ResourceBuilder) *ServiceBuilder {
+	b.resources = append(b.resources, resource)
+	return b
+}
+
+// Configure sets the parts of the service without a builder method, e.g. security, retry or tenancy.
+// The functions run in order on the service, after the enums, objects and resources are added.
+func (b *ServiceBuilder) Configure(configure func(service *specification.Service)) *ServiceBuilder {
+	b.configure = append(b.configure, configure)
+	return b
+}
+
+// Service returns the service as declared, before validation and the overlays, e.g. to write it as a
+// specification file with specification.MarshalServiceYAML.
+func (b *ServiceBuilder) Service() (*specification.Service, error) {
+	if b.service.Name == "" {
+		return nil, fmt.Errorf("service: %s", errorMissingName)
+	}
+
+	service := b.service
+	service.Servers = slices.Clone(b.service.Servers)
+	service.Enums = []specification.Enum{}
+	service.Objects = []specification.Object{}
+	service.Resources = []specification.Resource{}
+
+	// Enums, objects and resources are all types of the service, so their names must be distinct
+	names := make(map[string]bool)
+
+	for i, enumBuilder := range b.enums {
+		enum, err := enumBuilder.build()
+		if err != nil {
+			return nil, fmt.Errorf("enum %d: %w", i, err)
+		}
+		if err := addName(names, enum.Name); err != nil {
+			return nil, fmt.Errorf("enum %d: %w", i, err)
+		}
+		service.Enums = append(service.Enums, enum)
+	}
+
+	for i, objectBuilder := range b.objects {
+		object, err := objectBuilder.build()
+		if err != nil {
+			return nil, fmt.Errorf("object %d: %w", i, err)
+		}
+		if err := addName(names, object.Name); err != nil {
+			return nil, fmt.Errorf("object %d: %w", i, err)
+		}
+		service.Objects = append(service.Objects, object)
+	}
+
+	for i, resourceBuilder := range b.resources {
+		resource, err := resourceBuilder.build()
+		if err != nil {
+			return nil, fmt.Errorf("resource %d: %w", i, err)
+		}
+		if err := addName(names, resource.Name); err != nil {
+			return nil, fmt.Errorf("resource %d: %w", i, err)
+		}
+		service.Resources = append(service.Resources, resource)
+	}
+
+	for _, configure := range b.configure {
+		configure(&service)
+	}
+
+	return &service, nil
+}
+
+// Validate returns an error if the service is not valid, by the rules of the builder and the rules of the
+// specification files.
+func (b *ServiceBuilder) Validate() error {
+	service, err := b.Service()
+	if err != nil {
+		return err
+	}
+
+	return specification.ValidateService(service)
+}
+
+// Build validates the service and returns it with the default overlays applied, the same as a specification
+// parsed from a file, ready for the generators.
+func (b *ServiceBuilder) Build() (*specification.Service, error) {
+	service, err := b.Service()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := specification.ValidateService(service); err != nil {
+		return nil, err
+	}
+
+	return specification.ApplyDefaultOverlays(service), nil
+}
+
+// EnumBuilder builds a specification.Enum.
+type EnumBuilder struct {
+	enum specification.Enum
+}
+
+// NewEnum returns a builder of an enum with the name.
+func NewEnum(name string) *EnumBuilder {
+	return &EnumBuilder{enum: specification.Enum{Name: name}}
+}
+
+// Description sets the description of the enum.
+func (b *EnumBuilder) Description(description string) *EnumBuilder {
+	b.enum.Description = description
+	return b
+}
+
+// Int makes the enum backed by integers, whose values must all set an integer wire value with AddWireValue.
+func (b *EnumBuilder) Int() *EnumBuilder {
+	b.enum.EnumType = specification.EnumTypeInt
+	return b
+}
+
+// Development excludes the enum from the generated OpenAPI document.
+func (b *EnumBuilder) Development() *EnumBuilder {
+	b.enum.Development = true
+	return b
+}
+
+// AddValue adds a value to the enum, whose wire value is its name.
+func (b *EnumBuilder) AddValue(name, description string) *EnumBuilder {
+	b.enum.Values = append(b.enum.Values, specification.EnumValue{Name: name, Description: description})
+	return b
+}
+
+// AddWireValue adds a value to the enum with an explicit wire value, and the deprecated wire values it still
+// accepts as aliases.
+func (b *EnumBuilder) AddWireValue(name, value, description string, aliases ...string) *EnumBuilder {
+	b.enum.Values = append(b.enum.Values, specification.EnumValue{Name: name, Description: description, Value: value, Aliases: aliases})
+	return b
+}
+
+// build returns the enum, or an error for a missing name or duplicate values.
+func (b *EnumBuilder) build() (specification.Enum, error) {
+	if b == nil {
+		return specification.Enum{}, errors.New(errorNilBuilder)
+	}
+
+	if b.enum.Name == "" {
+		return specification.Enum{}, errors.New(errorMissingName)
+	}
+
+	names := make(map[string]bool)
+	for i, value := range b.enum.Values {
+		if err := addName(names, value.Name); err != nil {
+			return specification.Enum{}, fmt.Errorf("%s: value %d: %w", b.enum.Name, i, err)
+		}
+	}
+
+	enum := b.enum
+	enum.Values = slices.Clone(b.enum.Values)
+	return enum, nil
+}
+
+// ObjectBuilder builds a specification.Object.
+type ObjectBuilder struct {
+	object specification.Object
+	fields []*FieldBuilder
+}
+
+// NewObject returns a builder of an object with the name.
+func NewObject(name string) *ObjectBuilder {
+	return &ObjectBuilder{object: specification.Object{Name: name}}
+}
+
+// Description sets the description of the object.
+func (b *ObjectBuilder) Description(description string) *ObjectBuilder {
+	b.object.Description = description
+	return b
+}
+
+// Extends makes the object inherit the fields of the parent object.
+func (b *ObjectBuilder) Extends(parent string) *ObjectBuilder {
+	b.object.Extends = parent
+	return b
+}
+
+// Strict overrides the strict setting of the service for the object.
+func (b *ObjectBuilder) Strict(strict bool) *ObjectBuilder {
+	b.object.Strict = &strict
+	return b
+}
+
+// Development excludes the object from the generated OpenAPI document.
+func (b *ObjectBuilder) Development() *ObjectBuilder {
+	b.object.Development = true
+	return b
+}
+
+// AddField adds a field to the object.
+func (b *ObjectBuilder) AddField(field *FieldBuilder) *ObjectBuilder {
+	b.fields = append(b.fields, field)
+	return b
+}
+
+// build returns the object, or an error for a missing name or invalid fields.
+func (b *ObjectBuilder) build() (specification.Object, error) {
+	if b == nil {
+		return specification.Object{}, errors.New(errorNilBuilder)
+	}
+
+	if b.object.Name == "" {
+		return specification.Object{}, errors.New(errorMissingName)
+	}
+
+	fields, err := buildFields(b.fields)
+	if err != nil {
+		return specification.Object{}, fmt.Errorf("%s: %w", b.object.Name, err)
+	}
+
+	object := b.object
+	object.Fields = fields
+	return object, nil
+}
+
+// ResourceBuilder builds a specification.Resource.
+type ResourceBuilder struct {
+	resource  specification.Resource
+	fields    []resourceField
+	endpoints []*EndpointBuilder
+}
+
+// resourceField is a field of a resource with the operations it is allowed in.
+type resourceField struct {
+	field      *FieldBuilder
+	operations []string
+}
+
+// NewResource returns a builder of a resource with the name and the operations generated for it, e.g.
+// specification.OperationCreate and specification.OperationGet.
+func NewResource(name string, operations ...string) *ResourceBuilder {
+	return &ResourceBuilder{resource: specification.Resource{Name: name, Operations: operations}}
+}
+
+// Description sets the description of the resource.
+func (b *ResourceBuilder) Description(description string) *ResourceBuilder {
+	b.resource.Description = description
+	return b
+}
+
+// Parent nests the resource under the parent resource.
+func (b *ResourceBuilder) Parent(parent string) *ResourceBuilder {
+	b.resource.Parent = parent
+	return b
+}
+
+// Scopes sets the OAuth2 scopes required by the endpoints of the resource.
+func (b *ResourceBuilder) Scopes(scopes ...string) *ResourceBuilder {
+	b.resource.Scopes = scopes
+	return b
+}
+
+// Permissions sets the application permissions required by the endpoints of the resource.
+func (b *ResourceBuilder) Permissions(permissions ...string) *ResourceBuilder {
+	b.resource.Permissions = permissions
+	return b
+}
+
+// SoftDelete makes Delete a soft delete, see specification.Resource.
+func (b *ResourceBuilder) SoftDelete() *ResourceBuilder {
+	b.resource.SoftDelete = true
+	return b
+}
+
+// Exportable adds the Export endpoint streaming the List results as CSV, see specification.Resource.
+func (b *ResourceBuilder) Exportable() *ResourceBuilder {
+	b.resource.Exportable = true
+	return b
+}
+
+// SkipAutoColumns skips the auto columns (ID, CreatedAt, etc.) of the resource.
+func (b *ResourceBuilder) SkipAutoColumns() *ResourceBuilder {
+	b.resource.SkipAutoColumns = true
+	return b
+}
+
+// Development excludes the resource from the generated OpenAPI document.
+func (b *ResourceBuilder) Development() *ResourceBuilder {
+	b.resource.Development = true
+	return b
+}
+
+// AddField adds a field to the resource, allowed in the operations, e.g. specification.OperationCreate and
+// specification.OperationRead.
+func (b *ResourceBuilder) AddField(field *FieldBuilder, operations ...string) *ResourceBuilder {
+	b.fields = append(b.fields, resourceField{field: field, operations: operations})
+	return b
+}
+
+// AddEndpoint adds a custom endpoint to the resource.
+func (b *ResourceBuilder) AddEndpoint(endpoint *EndpointBuilder) *ResourceBuilder {
+	b.endpoints = append(b.endpoints, endpoint)
+	return b
+}
+
+// build returns the resource, or an error for a missing name or invalid fields and endpoints.
+func (b *ResourceBuilder) build() (specification.Resource, error) {
+	if b == nil {
+		return specification.Resource{}, errors.New(errorNilBuilder)
+	}
+
+	if b.resource.Name == "" {
+		return specification.Resource{}, errors.New(errorMissingName)
+	}
+
+	resource := b.resource
+	resource.Operations = slices.Clone(b.resource.Operations)
+	resource.Scopes = slices.Clone(b.resource.Scopes)
+	resource.Permissions = slices.Clone(b.resource.Permissions)
+	resource.Fields = []specification.ResourceField{}
+	resource.Endpoints = []specification.Endpoint{}
+
+	names := make(map[string]bool)
+	for i, f := range b.fields {
+		if f.field == nil {
+			return specification.Resource{}, fmt.Errorf("%s: field %d: %s", resource.Name, i, errorNilBuilder)
+		}
+
+		field, err := f.field.build()
+		if err != nil {
+			return specification.Resource{}, fmt.Errorf("%s: field %d: %w", resource.Name, i, err)
+		}
+		if err := addName(names, field.Name); err != nil {
+			return specification.Resource{}, fmt.Errorf("%s: field %d: %w", resource.Name, i, err)
+		}
+
+		resource.Fields = append(resource.Fields, specification.ResourceField{
+			Field:      field,
+			Operations: slices.Clone(f.operations),
+			RequiredOn: slices.Clone(f.field.requiredOn),
+		})
+	}
+
+	endpointNames := make(map[string]bool)
+	for i, endpointBuilder := range b.endpoints {
+		endpoint, err := endpointBuilder.build()
+		if err != nil {
+			return specification.Resource{}, fmt.Errorf("%s: endpoint %d: %w", resource.Name, i, err)
+		}
+		if err := addName(endpointNames, endpoint.Name); err != nil {
+			return specification.Resource{}, fmt.Errorf("%s: endpoint %d: %w", resource.Name, i, err)
+		}
+		resource.Endpoints = append(resource.Endpoints, endpoint)
+	}
+
+	return resource, nil
+}
+
+// EndpointBuilder builds a specification.Endpoint.
+type EndpointBuilder struct {
+	endpoint       specification.Endpoint
+	pathParams     []*FieldBuilder
+	queryParams    []*FieldBuilder
+	bodyParams     []*FieldBuilder
+	responseFields []*FieldBuilder
+}
+
+// NewEndpoint returns a builder of an endpoint with the name, HTTP method and path relative to the resource,
+// e.g. "/{id}/archive". The endpoint responds with 200 OK and JSON unless set otherwise.
+func NewEndpoint(name, method, path string) *EndpointBuilder {
+	return &EndpointBuilder{endpoint: specification.Endpoint{
+		Name:     name,
+		Method:   method,
+		Path:     path,
+		Request:  specification.EndpointRequest{ContentType: contentTypeJSON},
+		Response: specification.EndpointResponse{ContentType: contentTypeJSON, StatusCode: http.StatusOK},
+	}}
+}
+
+// Summary sets the short plain text description of the endpoint.
+func (b *EndpointBuilder) Summary(summary string) *EndpointBuilder {
+	b.endpoint.Summary = summary
+	return b
+}
+
+// Description sets the description of the endpoint.
+func (b *EndpointBuilder) Description(description string) *EndpointBuilder {
+	b.endpoint.Description = description
+	return b
+}
+
+// Scopes sets the OAuth2 scopes required by the endpoint, overriding the scopes of the resource.
+func (b *EndpointBuilder) Scopes(scopes ...string) *EndpointBuilder {
+	b.endpoint.Scopes = scopes
+	return b
+}
+
+// Permissions sets the application permissions required by the endpoint, overriding the permissions of the resource.
+func (b *EndpointBuilder) Permissions(permissions ...string) *EndpointBuilder {
+	b.endpoint.Permissions = permissions
+	return b
+}
+
+// MaxBodyBytes limits the size of the request body in bytes, overriding the limit of the service.
+func (b *EndpointBuilder) MaxBodyBytes(maxBodyBytes int64) *EndpointBuilder {
+	b.endpoint.MaxBodyBytes = maxBodyBytes
+	return b
+}
+
+// HandlerTimeout limits the time of the handler, overriding the timeout of the service.
+func (b *EndpointBuilder) HandlerTimeout(timeout time.Duration) *EndpointBuilder {
+	b.endpoint.HandlerTimeout = int(timeout.Milliseconds())
+	return b
+}
+
+// AddPathParam adds a path parameter to the endpoint.
+func (b *EndpointBuilder) AddPathParam(field *FieldBuilder) *EndpointBuilder {
+	b.pathParams = append(b.pathParams, field)
+	return b
+}
+
+// AddQueryParam adds a query parameter to the endpoint.
+func (b *EndpointBuilder) AddQueryParam(field *FieldBuilder) *EndpointBuilder {
+	b.queryParams = append(b.queryParams, field)
+	return b
+}
+
+// AddBodyParam adds a parameter of the request body to the endpoint.
+func (b *EndpointBuilder) AddBodyParam(field *FieldBuilder) *EndpointBuilder {
+	b.bodyParams = append(b.bodyParams, field)
+	return b
+}
+
+// StatusCode sets the status code of the successful response.
+func (b *EndpointBuilder) StatusCode(statusCode int) *EndpointBuilder {
+	b.endpoint.Response.StatusCode = statusCode
+	return b
+}
+
+// ResponseObject makes the response body the object or resource with the name.
+func (b *EndpointBuilder) ResponseObject(name string) *EndpointBuilder {
+	b.endpoint.Response.BodyObject = &name
+	return b
+}
+
+// AddResponseField adds a field to the response body.
+func (b *EndpointBuilder) AddResponseField(field *FieldBuilder) *EndpointBuilder {
+	b.responseFields = append(b.responseFields, field)
+	return b
+}
+
+// build returns the endpoint, or an error for a missing name, an invalid method or invalid fields.
+func (b *EndpointBuilder) build() (specification.Endpoint, error) {
+	if b == nil {
+		return specification.Endpoint{}, errors.New(errorNilBuilder)
+	}
+
+	if b.endpoint.Name == "" {
+		return specification.Endpoint{}, errors.New(errorMissingName)
+	}
+
+	if !slices.Contains(httpMethods, b.endpoint.Method) {
+		return specification.Endpoint{}, fmt.Errorf("%s: %s '%s' must be one of: %v", b.endpoint.Name, errorInvalidMethod, b.endpoint.Method, httpMethods)
+	}
+
+	endpoint := b.endpoint
+	endpoint.Scopes = slices.Clone(b.endpoint.Scopes)
+	endpoint.Permissions = slices.Clone(b.endpoint.Permissions)
+
+	params := []struct {
+		kind     string
+		builders []*FieldBuilder
+		fields   *[]specification.Field
+	}{
+		{"path param", b.pathParams, &endpoint.Request.PathParams},
+		{"query param", b.queryParams, &endpoint.Request.QueryParams},
+		{"body param", b.bodyParams, &endpoint.Request.BodyParams},
+		{"response field", b.responseFields, &endpoint.Response.BodyFields},
+	}
+	for _, param := range params {
+		fields, err := buildFields(param.builders)
+		if err != nil {
+			return specification.Endpoint{}, fmt.Errorf("%s: %s %w", endpoint.Name, param.kind, err)
+		}
+		*param.fields = fields
+	}
+
+	return endpoint, nil
+}
+
+// FieldBuilder builds a specification.Field.
+type FieldBuilder struct {
+	field      specification.Field
+	requiredOn []string
+}
+
+// NewField returns a builder of a field with the name and type, one of the specification.FieldType constants
+// or the name of an enum or object of the service.
+func NewField(name, fieldType string) *FieldBuilder {
+	return &FieldBuilder{field: specification.Field{Name: name, Type: fieldType}}
+}
+
+// Description sets the description of the field.
+func (b *FieldBuilder) Description(description string) *FieldBuilder {
+	b.field.Description = description
+	return b
+}
+
+// Default sets the default value of the field.
+func (b *FieldBuilder) Default(value string) *FieldBuilder {
+	b.field.Default = value
+	return b
+}
+
+// Example sets the example value of the field.
+func (b *FieldBuilder) Example(value string) *FieldBuilder {
+	b.field.Example = value
+	return b
+}
+
+// Nullable allows the field to be null.
+func (b *FieldBuilder) Nullable() *FieldBuilder {
+	return b.addModifier(specification.ModifierNullable)
+}
+
+// Array makes the field a list of its type.
+func (b *FieldBuilder) Array() *FieldBuilder {
+	return b.addModifier(specification.ModifierArray)
+}
+
+// Scopes sets the OAuth2 scopes required to read the field, which must be nullable.
+func (b *FieldBuilder) Scopes(scopes ...string) *FieldBuilder {
+	b.field.Scopes = scopes
+	return b
+}
+
+// RequiredOn sets the operations in which a resource field is required, making it optional in the other
+// operations it is allowed in. Only resource fields can set it.
+func (b *FieldBuilder) RequiredOn(operations ...string) *FieldBuilder {
+	b.requiredOn = operations
+	return b
+}
+
+// addModifier adds the modifier to the field once.
+func (b *FieldBuilder) addModifier(modifier string) *FieldBuilder {
+	if !slices.Contains(b.field.Modifiers, modifier) {
+		b.field.Modifiers = append(b.field.Modifiers, modifier)
+	}
+	return b
+}
+
+// build returns the field, or an error for a missing name.
+func (b *FieldBuilder) build() (specification.Field, error) {
+	if b.field.Name == "" {
+		return specification.Field{}, errors.New(errorMissingName)
+	}
+
+	field := b.field
+	field.Modifiers = slices.Clone(b.field.Modifiers)
+	field.Scopes = slices.Clone(b.field.Scopes)
+	return field, nil
+}
+
+// buildFields returns the fields of an object or endpoint, or an error for invalid or duplicate fields and for
+// fields setting RequiredOn, which is only allowed for resource fields.
+func buildFields(builders []*FieldBuilder) ([]specification.Field, error) {
+	fields := []specification.Field{}
+	names := make(map[string]bool)
+
+	for i, builder := range builders {
+		if builder == nil {
+			return nil, fmt.Errorf("field %d: %s", i, errorNilBuilder)
+		}
+
+		field, err := builder.build()
+		if err != nil {
+			return nil, fmt.Errorf("field %d: %w", i, err)
+		}
+		if len(builder.requiredOn) > 0 {
+			return nil, fmt.Errorf("field %d (%s): %s", i, field.Name, errorMisplacedRequire)
+		}
+		if err := addName(names, field.Name); err != nil {
+			return nil, fmt.Errorf("field %d: %w", i, err)
+		}
+
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
+
+// addName adds the name to the names, returning an error for a missing or duplicate name.
+func addName(names map[string]bool, name string) error {
+	if name == "" {
+		return errors.New(errorMissingName)
+	}
+	if names[name] {
+		return fmt.Errorf("%s '%s'", errorDuplicateName, name)
+	}
+	names[name] = true
+	return nil
+}
@@ -0,0 +1,194 @@
+package builder
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/meitner-se/publicapis-gen/specification"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newLibraryService returns a builder of a valid service with an enum, an object and a resource.
+func newLibraryService() *ServiceBuilder {
+	return NewService("Library").
+		Version("1.0.0").
+		AddEnum(NewEnum("Genre").
+			Description("Genre of a book").
+			AddValue("Fiction", "Fiction books").
+			AddValue("NonFiction", "Non-fiction books")).
+		AddObject(NewObject("Publisher").
+			Description("Publisher of a book").
+			AddField(NewField("name", specification.FieldTypeString).Description("Name of the publisher"))).
+		AddResource(NewResource("Books", specification.OperationCreate, specification.OperationGet, specification.OperationUpdate).
+			Description("Books of the library").
+			AddField(NewField("title", specification.FieldTypeString).Description("Title of the book").RequiredOn(specification.OperationCreate),
+				specification.OperationCreate, specification.OperationUpdate, specification.OperationRead).
+			AddField(NewField("genre", "Genre").Description("Genre of the book").Nullable(),
+				specification.OperationCreate, specification.OperationRead).
+			AddEndpoint(NewEndpoint("Archive", http.MethodPost, "/{id}/archive").
+				Summary("Archive a book").
+				Description("Archives the book").
+				AddPathParam(NewField("id", specification.FieldTypeUUID).Description("ID of the book")).
+				StatusCode(http.StatusNoContent)))
+}
+
+func TestServiceBuilder_Service(t *testing.T) {
+	t.Run("returns the declared service", func(t *testing.T) {
+		// Arrange
+		b := newLibraryService()
+
+		// Act
+		service, err := b.Service()
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "Library", service.Name)
+		assert.Equal(t, "1.0.0", service.Version)
+		require.Len(t, service.Enums, 1)
+		assert.Equal(t, []specification.EnumValue{
+			{Name: "Fiction", Description: "Fiction books"},
+			{Name: "NonFiction", Description: "Non-fiction books"},
+		}, service.Enums[0].Values)
+		require.Len(t, service.Objects, 1)
+		assert.Equal(t, "name", service.Objects[0].Fields[0].Name)
+		require.Len(t, service.Resources, 1)
+
+		resource := service.Resources[0]
+		assert.Equal(t, []string{specification.OperationCreate, specification.OperationGet, specification.OperationUpdate}, resource.Operations)
+		require.Len(t, resource.Fields, 2)
+		assert.Equal(t, []string{specification.OperationCreate}, resource.Fields[0].RequiredOn)
+		assert.Equal(t, []string{specification.ModifierNullable}, resource.Fields[1].Modifiers)
+		require.Len(t, resource.Endpoints, 1)
+
+		endpoint := resource.Endpoints[0]
+		assert.Equal(t, http.MethodPost, endpoint.Method)
+		assert.Equal(t, contentTypeJSON, endpoint.Request.ContentType)
+		assert.Equal(t, contentTypeJSON, endpoint.Response.ContentType)
+		assert.Equal(t, http.StatusNoContent, endpoint.Response.StatusCode)
+		assert.Equal(t, "id", endpoint.Request.PathParams[0].Name)
+	})
+
+	t.Run("is not changed by later calls on the builders", func(t *testing.T) {
+		// Arrange
+		enum := NewEnum("Genre").AddValue("Fiction", "Fiction books")
+		b := NewService("Library").AddEnum(enum)
+		service, err := b.Service()
+		require.NoError(t, err)
+
+		// Act
+		enum.AddValue("NonFiction", "Non-fiction books")
+
+		// Assert
+		assert.Len(t, service.Enums[0].Values, 1)
+	})
+
+	t.Run("configure sets the service after the builders", func(t *testing.T) {
+		// Arrange
+		b := NewService("Library").Configure(func(service *specification.Service) {
+			service.Security = []specification.SecurityRequirement{{"bearerAuth"}}
+		})
+
+		// Act
+		service, err := b.Service()
+
+		// Assert
+		require.NoError(t, err)
+		assert.Len(t, service.Security, 1)
+	})
+
+	errorCases := []struct {
+		name     string
+		builder  *ServiceBuilder
+		expected string
+	}{
+		{
+			name:     "missing service name",
+			builder:  NewService(""),
+			expected: "service: " + errorMissingName,
+		},
+		{
+			name:     "duplicate type names",
+			builder:  NewService("Library").AddEnum(NewEnum("Books").AddValue("A", "A")).AddResource(NewResource("Books")),
+			expected: "resource 0: " + errorDuplicateName + " 'Books'",
+		},
+		{
+			name:     "duplicate enum values",
+			builder:  NewService("Library").AddEnum(NewEnum("Genre").AddValue("A", "A").AddValue("A", "A")),
+			expected: "enum 0: Genre: value 1: " + errorDuplicateName + " 'A'",
+		},
+		{
+			name:     "duplicate object fields",
+			builder:  NewService("Library").AddObject(NewObject("Publisher").AddField(NewField("name", "String")).AddField(NewField("name", "String"))),
+			expected: "object 0: Publisher: field 1: " + errorDuplicateName + " 'name'",
+		},
+		{
+			name:     "required on outside a resource",
+			builder:  NewService("Library").AddObject(NewObject("Publisher").AddField(NewField("name", "String").RequiredOn(specification.OperationCreate))),
+			expected: errorMisplacedRequire,
+		},
+		{
+			name:     "invalid endpoint method",
+			builder:  NewService("Library").AddResource(NewResource("Books").AddEndpoint(NewEndpoint("Archive", "FETCH", "/archive"))),
+			expected: errorInvalidMethod + " 'FETCH'",
+		},
+		{
+			name:     "duplicate endpoint names",
+			builder:  NewService("Library").AddResource(NewResource("Books").AddEndpoint(NewEndpoint("Archive", http.MethodPost, "/a")).AddEndpoint(NewEndpoint("Archive", http.MethodPost, "/b"))),
+			expected: "resource 0: Books: endpoint 1: " + errorDuplicateName + " 'Archive'",
+		},
+		{
+			name:     "nil builder",
+			builder:  NewService("Library").AddObject(nil),
+			expected: "object 0: " + errorNilBuilder,
+		},
+	}
+
+	for _, tc := range errorCases {
+		t.Run(tc.name+" returns error", func(t *testing.T) {
+			// Act
+			service, err := tc.builder.Service()
+
+			// Assert
+			assert.Nil(t, service)
+			assert.ErrorContains(t, err, tc.expected)
+		})
+	}
+}
+
+func TestServiceBuilder_Build(t *testing.T) {
+	t.Run("returns the service with the default overlays", func(t *testing.T) {
+		// Arrange
+		b := newLibraryService()
+
+		// Act
+		service, err := b.Build()
+
+		// Assert
+		require.NoError(t, err)
+		assert.True(t, service.HasObject("Books"), "the overlays should add the resource object")
+		endpoints := []string{}
+		for _, endpoint := range service.GetResource("Books").Endpoints {
+			endpoints = append(endpoints, endpoint.Name)
+		}
+		assert.Contains(t, endpoints, "Create", "the overlays should add the CRUD endpoints")
+		assert.Contains(t, endpoints, "Archive")
+	})
+
+	t.Run("validates by the rules of the specification files", func(t *testing.T) {
+		// Arrange
+		b := NewService("Library").
+			AddResource(NewResource("Books", specification.OperationCreate).
+				Description("Books of the library").
+				AddField(NewField("genre", "Genre").Description("Genre of the book"), specification.OperationCreate))
+
+		// Act
+		service, err := b.Build()
+		validateErr := b.Validate()
+
+		// Assert
+		assert.Nil(t, service)
+		assert.Error(t, err)
+		assert.Equal(t, err, validateErr)
+	})
+}
@@ -0,0 +1,31 @@
+// Package builder constructs specifications in Go, for tools that generate services programmatically
+// instead of templating specification files.
+//
+// The builders mirror the sections of a specification file: a service with enums, objects and resources,
+// and resources with fields and custom endpoints. Build checks what the builders can check (missing and
+// duplicate names, invalid methods, RequiredOn on fields that are not resource fields), validates the
+// service by the same rules as the specification files, and applies the default overlays, returning a
+// service that is ready for the generators.
+//
+// # Usage
+//
+//	service, err := builder.NewService("Library").
+//	    Version("1.0.0").
+//	    AddEnum(builder.NewEnum("Genre").
+//	        AddValue("Fiction", "Fiction books").
+//	        AddValue("NonFiction", "Non-fiction books")).
+//	    AddResource(builder.NewResource("Books", specification.OperationCreate, specification.OperationGet).
+//	        Description("Books of the library").
+//	        AddField(builder.NewField("title", specification.FieldTypeString).Description("Title of the book"),
+//	            specification.OperationCreate, specification.OperationRead).
+//	        AddField(builder.NewField("genre", "Genre").Description("Genre of the book"),
+//	            specification.OperationCreate, specification.OperationRead)).
+//	    Build()
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+// Settings without a builder method, e.g. security or tenancy, are set with ServiceBuilder.Configure.
+// ServiceBuilder.Service returns the service as declared, which specification.MarshalServiceYAML writes
+// as a specification file.
+package builder
@@ -0,0 +1,641 @@
+package changeloggen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	libopenapijson "github.com/pb33f/libopenapi/json"
+	yaml "gopkg.in/yaml.v3"
+)
+
+// Error messages
+const (
+	errorInvalidPrevious = "invalid previous document"
+	errorInvalidCurrent  = "invalid current document"
+)
+
+// Changelog section titles
+const (
+	sectionAddedEndpoints      = "Added endpoints"
+	sectionRemovedEndpoints    = "Removed endpoints"
+	sectionDeprecatedEndpoints = "Deprecated endpoints"
+	sectionAddedSchemas        = "Added schemas"
+	sectionRemovedSchemas      = "Removed schemas"
+	sectionChangedSchemas      = "Changed schemas"
+	sectionStableWarnings      = "Warnings: incompatible changes of stable endpoints"
+	noChanges                  = "No changes."
+	schemaRefPrefix            = "#/components/schemas/"
+	stabilityStable            = "stable"
+)
+
+// httpMethods are the operations of a path item, in the order they are listed in the changelog
+var httpMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// document is the part of an OpenAPI document the changelog compares.
+type document struct {
+	Info       info                                  `json:"info"`
+	Paths      map[string]map[string]json.RawMessage `json:"paths"`
+	Components components                            `json:"components"`
+}
+
+type info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type components struct {
+	Schemas map[string]*schema `json:"schemas"`
+}
+
+// operation is an operation of a path item.
+type operation struct {
+	Summary    string      `json:"summary"`
+	Deprecated bool        `json:"deprecated"`
+	Stability  string      `json:"x-stability"`
+	Parameters []parameter `json:"parameters"`
+}
+
+// parameter is an inline parameter of an operation.
+type parameter struct {
+	Name     string `json:"name"`
+	In       string `json:"in"`
+	Required bool   `json:"required"`
+}
+
+// schema is a schema of the document, reduced to what a changelog reader cares about.
+type schema struct {
+	Ref        string             `json:"$ref"`
+	Type       any                `json:"type"`
+	Format     string             `json:"format"`
+	Items      *schema            `json:"items"`
+	Properties map[string]*schema `json:"properties"`
+	Required   []string           `json:"required"`
+	Enum       []any              `json:"enum"`
+	AllOf      []*schema          `json:"allOf"`
+	OneOf      []*schema          `json:"oneOf"`
+	Nullable   bool               `json:"nullable"`
+	Deprecated bool               `json:"deprecated"`
+}
+
+// endpoint is an operation by its method and path.
+type endpoint struct {
+	key       string
+	operation operation
+	schemas   []string
+}
+
+// change is a change of a schema, incompatible when clients written against the previous schema may break.
+type change struct {
+	description  string
+	incompatible bool
+}
+
+// GenerateChangelog compares two OpenAPI documents in JSON or YAML and writes the changes from previous to current
+// as Markdown to the buffer: the added, removed and deprecated endpoints, the added and removed schemas, and the
+// changed properties and enum values of the other schemas. An empty previous document is a document without
+// endpoints and schemas, so everything in current is added. Incompatible changes of the endpoints that were
+// stable in previous (x-stability: stable) are listed first, as warnings.
+func GenerateChangelog(buf *bytes.Buffer, previous, current []byte) error {
+	previousDocument, err := parseDocument(previous)
+	if err != nil {
+		return fmt.Errorf("%s: %w", errorInvalidPrevious, err)
+	}
+
+	currentDocument, err := parseDocument(current)
+	if err != nil {
+		return fmt.Errorf("%s: %w", errorInvalidCurrent, err)
+	}
+
+	writeTitle(buf, previousDocument.Info, currentDocument.Info)
+
+	sections := []struct {
+		title string
+		items []string
+	}{
+		{sectionStableWarnings, stableEndpointWarnings(previousDocument, currentDocument)},
+		{sectionAddedEndpoints, describeEndpoints(addedEndpoints(previousDocument, currentDocument))},
+		{sectionRemovedEndpoints, describeEndpoints(addedEndpoints(currentDocument, previousDocument))},
+		{sectionDeprecatedEndpoints, describeEndpoints(deprecatedEndpoints(previousDocument, currentDocument))},
+		{sectionAddedSchemas, quoteNames(addedSchemas(previousDocument, currentDocument))},
+		{sectionRemovedSchemas, quoteNames(addedSchemas(currentDocument, previousDocument))},
+		{sectionChangedSchemas, changedSchemas(previousDocument, currentDocument)},
+	}
+
+	hasChanges := false
+	for _, section := range sections {
+		if len(section.items) == 0 {
+			continue
+		}
+		hasChanges = true
+
+		buf.WriteString(fmt.Sprintf("### %s\n\n", section.title))
+		for _, item := range section.items {
+			buf.WriteString(fmt.Sprintf("- %s\n", item))
+		}
+		buf.WriteString("\n")
+	}
+
+	if !hasChanges {
+		buf.WriteString(noChanges + "\n\n")
+	}
+
+	return nil
+}
+
+// parseDocument parses a JSON or YAML document, normalized through JSON so that both formats give the same values.
+// YAML is converted from its nodes, which unlike a map allow the duplicate keys of generated examples.
+func parseDocument(data []byte) (document, error) {
+	var result document
+
+	if len(bytes.TrimSpace(data)) == 0 {
+		return result, nil
+	}
+
+	if json.Valid(data) {
+		err := json.Unmarshal(data, &result)
+		return result, err
+	}
+
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return result, err
+	}
+
+	normalized, err := libopenapijson.YAMLNodeToJSON(&node, "")
+	if err != nil {
+		return result, err
+	}
+
+	if err := json.Unmarshal(normalized, &result); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+// writeTitle writes the title of the API with the versions compared.
+func writeTitle(buf *bytes.Buffer, previous, current info) {
+	title := current.Title
+	if title == "" {
+		title = previous.Title
+	}
+
+	if previous.Version != "" && previous.Version != current.Version {
+		buf.WriteString(fmt.Sprintf("## %s %s (from %s)\n\n", title, current.Version, previous.Version))
+		return
+	}
+
+	buf.WriteString(strings.TrimSpace(fmt.Sprintf("## %s %s", title, current.Version)) + "\n\n")
+}
+
+// endpoints returns the operations of the document, sorted by path and method.
+func endpoints(doc document) []endpoint {
+	paths := make([]string, 0, len(doc.Paths))
+	for path := range doc.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var result []endpoint
+	for _, path := range paths {
+		for _, method := range httpMethods {
+			data, ok := doc.Paths[path][method]
+			if !ok {
+				continue
+			}
+
+			var op operation
+			if err := json.Unmarshal(data, &op); err != nil {
+				continue
+			}
+
+			result = append(result, endpoint{key: strings.ToUpper(method) + " " + path, operation: op, schemas: usedSchemas(doc, data)})
+		}
+	}
+
+	return result
+}
+
+// addedEndpoints returns the endpoints of current that are not in previous.
+func addedEndpoints(previous, current document) []endpoint {
+	existing := make(map[string]bool)
+	for _, e := range endpoints(previous) {
+		existing[e.key] = true
+	}
+
+	var result []endpoint
+	for _, e := range endpoints(current) {
+		if !existing[e.key] {
+			result = append(result, e)
+		}
+	}
+
+	return result
+}
+
+// deprecatedEndpoints returns the endpoints that are deprecated in current but were not in previous.
+func deprecatedEndpoints(previous, current document) []endpoint {
+	deprecated := make(map[string]bool)
+	for _, e := range endpoints(previous) {
+		deprecated[e.key] = e.operation.Deprecated
+	}
+
+	var result []endpoint
+	for _, e := range endpoints(current) {
+		if wasDeprecated, ok := deprecated[e.key]; ok && !wasDeprecated && e.operation.Deprecated {
+			result = append(result, e)
+		}
+	}
+
+	return result
+}
+
+// stableEndpointWarnings returns a warning for every incompatible change of the endpoints that were stable in
+// previous: removing them, lowering their stability, requiring new parameters, and incompatible changes of the
+// schemas they use.
+func stableEndpointWarnings(previous, current document) []string {
+	currentEndpoints := make(map[string]endpoint)
+	for _, e := range endpoints(current) {
+		currentEndpoints[e.key] = e
+	}
+
+	var warnings []string
+	for _, e := range endpoints(previous) {
+		if e.operation.Stability != stabilityStable {
+			continue
+		}
+
+		currentEndpoint, ok := currentEndpoints[e.key]
+		if !ok {
+			warnings = append(warnings, fmt.Sprintf("`%s` was removed", e.key))
+			continue
+		}
+
+		if currentEndpoint.operation.Stability != stabilityStable {
+			warnings = append(warnings, fmt.Sprintf("`%s` is no longer stable", e.key))
+		}
+
+		for _, name := range newlyRequiredParameters(e.operation.Parameters, currentEndpoint.operation.Parameters) {
+			warnings = append(warnings, fmt.Sprintf("`%s` requires the parameter %s", e.key, name))
+		}
+
+		for _, name := range e.schemas {
+			previousSchema, currentSchema := previous.Components.Schemas[name], current.Components.Schemas[name]
+			if previousSchema == nil || currentSchema == nil {
+				continue
+			}
+			for _, c := range compareSchemas(previousSchema, currentSchema) {
+				if c.incompatible {
+					warnings = append(warnings, fmt.Sprintf("`%s` uses `%s`: %s", e.key, name, c.description))
+				}
+			}
+		}
+	}
+
+	return warnings
+}
+
+// newlyRequiredParameters returns the parameters that are required in current but were optional or missing
+// in previous, formatted for the changelog.
+func newlyRequiredParameters(previous, current []parameter) []string {
+	var result []string
+	for _, p := range current {
+		if !p.Required {
+			continue
+		}
+		wasRequired := slices.ContainsFunc(previous, func(previous parameter) bool {
+			return previous.Name == p.Name && previous.In == p.In && previous.Required
+		})
+		if !wasRequired {
+			result = append(result, fmt.Sprintf("`%s` (%s)", p.Name, p.In))
+		}
+	}
+	return result
+}
+
+// usedSchemas returns the names of the schemas an operation references, directly or through other schemas, sorted.
+func usedSchemas(doc document, data json.RawMessage) []string {
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil
+	}
+
+	used := make(map[string]bool)
+	var pending []string
+	collectRefs(value, &pending)
+	for len(pending) > 0 {
+		name := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		if used[name] {
+			continue
+		}
+		used[name] = true
+		if s := doc.Components.Schemas[name]; s != nil {
+			s.collectRefs(&pending)
+		}
+	}
+
+	names := make([]string, 0, len(used))
+	for name := range used {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// collectRefs appends the names of the schemas referenced anywhere in a decoded JSON value.
+func collectRefs(value any, names *[]string) {
+	switch v := value.(type) {
+	case map[string]any:
+		if ref, ok := v["$ref"].(string); ok && strings.HasPrefix(ref, schemaRefPrefix) {
+			*names = append(*names, strings.TrimPrefix(ref, schemaRefPrefix))
+		}
+		for _, child := range v {
+			collectRefs(child, names)
+		}
+	case []any:
+		for _, child := range v {
+			collectRefs(child, names)
+		}
+	}
+}
+
+// describeEndpoints returns the changelog items of the endpoints, with their summaries.
+func describeEndpoints(endpoints []endpoint) []string {
+	items := make([]string, 0, len(endpoints))
+	for _, e := range endpoints {
+		item := fmt.Sprintf("`%s`", e.key)
+		if e.operation.Summary != "" {
+			item += ": " + e.operation.Summary
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// schemaNames returns the names of the schemas of the document, sorted.
+func schemaNames(doc document) []string {
+	names := make([]string, 0, len(doc.Components.Schemas))
+	for name := range doc.Components.Schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// addedSchemas returns the names of the schemas of current that are not in previous.
+func addedSchemas(previous, current document) []string {
+	var result []string
+	for _, name := range schemaNames(current) {
+		if _, ok := previous.Components.Schemas[name]; !ok {
+			result = append(result, name)
+		}
+	}
+	return result
+}
+
+// changedSchemas returns a changelog item for every schema in both documents that changed, with the changes as
+// a nested list.
+func changedSchemas(previous, current document) []string {
+	var result []string
+	for _, name := range schemaNames(current) {
+		previousSchema, ok := previous.Components.Schemas[name]
+		if !ok {
+			continue
+		}
+
+		changes := compareSchemas(previousSchema, current.Components.Schemas[name])
+		if len(changes) == 0 {
+			continue
+		}
+
+		descriptions := make([]string, 0, len(changes))
+		for _, c := range changes {
+			descriptions = append(descriptions, c.description)
+		}
+		result = append(result, fmt.Sprintf("`%s`\n  - %s", name, strings.Join(descriptions, "\n  - ")))
+	}
+	return result
+}
+
+// compareSchemas returns the changes from the previous schema to the current one: its enum values, properties
+// and the types, required state and enum values of the properties in both. Removed properties and enum values,
+// changed types and newly required properties are incompatible.
+func compareSchemas(previous, current *schema) []change {
+	var changes []change
+
+	if !previous.Deprecated && current.Deprecated {
+		changes = append(changes, change{description: "Deprecated"})
+	}
+
+	changes = append(changes, compareEnums("", previous.Enum, current.Enum)...)
+
+	previousProperties, previousRequired := previous.collectProperties()
+	currentProperties, currentRequired := current.collectProperties()
+
+	for _, name := range sortedKeys(currentProperties) {
+		property := currentProperties[name]
+		previousProperty, ok := previousProperties[name]
+		if !ok {
+			changes = append(changes, change{
+				description:  fmt.Sprintf("Added %sproperty `%s` (%s)", requiredLabel(currentRequired[name]), name, property.describeType()),
+				incompatible: currentRequired[name],
+			})
+			continue
+		}
+
+		if previousType, currentType := previousProperty.describeType(), property.describeType(); previousType != currentType {
+			changes = append(changes, change{
+				description:  fmt.Sprintf("Changed the type of property `%s` from %s to %s", name, previousType, currentType),
+				incompatible: true,
+			})
+		}
+
+		if !previousRequired[name] && currentRequired[name] {
+			changes = append(changes, change{description: fmt.Sprintf("Property `%s` is now required", name), incompatible: true})
+		} else if previousRequired[name] && !currentRequired[name] {
+			changes = append(changes, change{description: fmt.Sprintf("Property `%s` is no longer required", name)})
+		}
+
+		if !previousProperty.Deprecated && property.Deprecated {
+			changes = append(changes, change{description: fmt.Sprintf("Deprecated property `%s`", name)})
+		}
+
+		changes = append(changes, compareEnums(name, previousProperty.enumValues(), property.enumValues())...)
+	}
+
+	for _, name := range sortedKeys(previousProperties) {
+		if _, ok := currentProperties[name]; !ok {
+			changes = append(changes, change{description: fmt.Sprintf("Removed property `%s`", name), incompatible: true})
+		}
+	}
+
+	return changes
+}
+
+// compareEnums returns the added and removed enum values, of the schema itself when property is empty.
+func compareEnums(property string, previous, current []any) []change {
+	target := ""
+	if property != "" {
+		target = fmt.Sprintf(" of property `%s`", property)
+	}
+
+	var changes []change
+	if added := missingValues(previous, current); len(added) > 0 {
+		changes = append(changes, change{description: fmt.Sprintf("Added enum values%s: %s", target, strings.Join(added, ", "))})
+	}
+	if removed := missingValues(current, previous); len(removed) > 0 {
+		changes = append(changes, change{description: fmt.Sprintf("Removed enum values%s: %s", target, strings.Join(removed, ", ")), incompatible: true})
+	}
+	return changes
+}
+
+// missingValues returns the values of current that are not in previous, formatted for the changelog.
+func missingValues(previous, current []any) []string {
+	existing := make([]string, 0, len(previous))
+	for _, value := range previous {
+		existing = append(existing, fmt.Sprint(value))
+	}
+
+	var result []string
+	for _, value := range current {
+		if !slices.Contains(existing, fmt.Sprint(value)) {
+			result = append(result, fmt.Sprintf("`%v`", value))
+		}
+	}
+	return result
+}
+
+// collectProperties returns the properties of the schema and whether they are required, including the properties
+// of the inline schemas it composes with allOf.
+func (s *schema) collectProperties() (map[string]*schema, map[string]bool) {
+	properties := make(map[string]*schema)
+	required := make(map[string]bool)
+
+	schemas := append([]*schema{s}, s.AllOf...)
+	for _, part := range schemas {
+		if part == nil {
+			continue
+		}
+		for name, property := range part.Properties {
+			if property != nil {
+				properties[name] = property
+			}
+		}
+		for _, name := range part.Required {
+			required[name] = true
+		}
+	}
+
+	return properties, required
+}
+
+// collectRefs appends the names of the schemas the schema references.
+func (s *schema) collectRefs(names *[]string) {
+	if s == nil {
+		return
+	}
+	if strings.HasPrefix(s.Ref, schemaRefPrefix) {
+		*names = append(*names, strings.TrimPrefix(s.Ref, schemaRefPrefix))
+	}
+	s.Items.collectRefs(names)
+	for _, property := range s.Properties {
+		property.collectRefs(names)
+	}
+	for _, part := range slices.Concat(s.AllOf, s.OneOf) {
+		part.collectRefs(names)
+	}
+}
+
+// enumValues returns the inline enum values of a property, or of the items of an array property.
+func (s *schema) enumValues() []any {
+	if len(s.Enum) == 0 && s.Items != nil {
+		return s.Items.Enum
+	}
+	return s.Enum
+}
+
+// describeType returns a readable type of the schema, e.g. "array of `User`" or "string (date-time)".
+func (s *schema) describeType() string {
+	if s == nil {
+		return "any"
+	}
+
+	if s.Ref != "" {
+		return fmt.Sprintf("`%s`", strings.TrimPrefix(s.Ref, schemaRefPrefix))
+	}
+
+	if len(s.OneOf) > 0 {
+		types := make([]string, 0, len(s.OneOf))
+		for _, option := range s.OneOf {
+			types = append(types, option.describeType())
+		}
+		return strings.Join(types, " or ")
+	}
+
+	if len(s.AllOf) == 1 {
+		return s.AllOf[0].describeType()
+	}
+
+	var types []string
+	switch value := s.Type.(type) {
+	case string:
+		types = []string{value}
+	case []any:
+		for _, t := range value {
+			types = append(types, fmt.Sprint(t))
+		}
+	}
+
+	// A nullable type may be listed with null instead of the nullable keyword
+	nullable := s.Nullable || slices.Contains(types, "null")
+	types = slices.DeleteFunc(types, func(t string) bool { return t == "null" })
+
+	description := "any"
+	if len(types) > 0 {
+		description = strings.Join(types, " or ")
+	}
+
+	if slices.Contains(types, "array") && s.Items != nil {
+		description = "array of " + s.Items.describeType()
+	} else if s.Format != "" {
+		description = fmt.Sprintf("%s (%s)", description, s.Format)
+	}
+
+	if nullable {
+		description = "nullable " + description
+	}
+
+	return description
+}
+
+// requiredLabel returns the label of a required property in the changelog.
+func requiredLabel(required bool) string {
+	if required {
+		return "required "
+	}
+	return ""
+}
+
+// sortedKeys returns the keys of the properties, sorted.
+func sortedKeys(properties map[string]*schema) []string {
+	keys := make([]string, 0, len(properties))
+	for key := range properties {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// quoteNames returns the names formatted as code.
+func quoteNames(names []string) []string {
+	items := make([]string, 0, len(names))
+	for _, name := range names {
+		items = append(items, fmt.Sprintf("`%s`", name))
+	}
+	return items
+}
@@ -0,0 +1,258 @@
+package changeloggen
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const previousDocument = `{
+  "openapi": "3.1.0",
+  "info": {"title": "Users API", "version": "1.0.0"},
+  "paths": {
+    "/users": {
+      "get": {"summary": "List users"},
+      "post": {"summary": "Create a user"}
+    },
+    "/users/{id}": {
+      "get": {"summary": "Get a user"},
+      "delete": {"summary": "Delete a user"}
+    }
+  },
+  "components": {
+    "schemas": {
+      "User": {
+        "type": "object",
+        "required": ["id", "name"],
+        "properties": {
+          "id": {"type": "string", "format": "uuid"},
+          "name": {"type": "string"},
+          "nickname": {"type": "string"},
+          "age": {"type": "string"},
+          "status": {"$ref": "#/components/schemas/Status"}
+        }
+      },
+      "Status": {"type": "string", "enum": ["Active", "Inactive"]},
+      "Legacy": {"type": "object"}
+    }
+  }
+}`
+
+const currentDocument = `openapi: 3.1.0
+info:
+  title: Users API
+  version: 1.1.0
+paths:
+  /users:
+    get:
+      summary: List users
+    post:
+      summary: Create a user
+      deprecated: true
+  /users/{id}:
+    get:
+      summary: Get a user
+    patch:
+      summary: Update a user
+components:
+  schemas:
+    User:
+      type: object
+      required: [id, name, email]
+      properties:
+        id:
+          type: string
+          format: uuid
+        name:
+          type: string
+        age:
+          type: integer
+        email:
+          type: string
+        tags:
+          type: array
+          items:
+            type: string
+            enum: [vip]
+        status:
+          $ref: "#/components/schemas/Status"
+    Status:
+      type: string
+      enum: [Active, Inactive, Suspended]
+    Address:
+      type: object
+`
+
+func TestGenerateChangelog(t *testing.T) {
+	t.Run("lists the changes between the documents", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := GenerateChangelog(&buf, []byte(previousDocument), []byte(currentDocument))
+		assert.NoError(t, err)
+
+		assert.Equal(t, "## Users API 1.1.0 (from 1.0.0)\n"+
+			"\n"+
+			"### Added endpoints\n"+
+			"\n"+
+			"- `PATCH /users/{id}`: Update a user\n"+
+			"\n"+
+			"### Removed endpoints\n"+
+			"\n"+
+			"- `DELETE /users/{id}`: Delete a user\n"+
+			"\n"+
+			"### Deprecated endpoints\n"+
+			"\n"+
+			"- `POST /users`: Create a user\n"+
+			"\n"+
+			"### Added schemas\n"+
+			"\n"+
+			"- `Address`\n"+
+			"\n"+
+			"### Removed schemas\n"+
+			"\n"+
+			"- `Legacy`\n"+
+			"\n"+
+			"### Changed schemas\n"+
+			"\n"+
+			"- `Status`\n"+
+			"  - Added enum values: `Suspended`\n"+
+			"- `User`\n"+
+			"  - Changed the type of property `age` from string to integer\n"+
+			"  - Added required property `email` (string)\n"+
+			"  - Added property `tags` (array of string)\n"+
+			"  - Removed property `nickname`\n"+
+			"\n", buf.String())
+	})
+
+	t.Run("lists everything as added without a previous document", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := GenerateChangelog(&buf, nil, []byte(currentDocument))
+		assert.NoError(t, err)
+
+		assert.Contains(t, buf.String(), "## Users API 1.1.0\n")
+		assert.Contains(t, buf.String(), "- `GET /users`: List users\n- `POST /users`: Create a user\n")
+		assert.Contains(t, buf.String(), "### Added schemas\n\n- `Address`\n- `Status`\n- `User`\n")
+		assert.NotContains(t, buf.String(), "### Changed schemas")
+	})
+
+	t.Run("reports no changes for the same document", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := GenerateChangelog(&buf, []byte(previousDocument), []byte(previousDocument))
+		assert.NoError(t, err)
+
+		assert.Equal(t, "## Users API 1.0.0\n\nNo changes.\n\n", buf.String())
+	})
+
+	t.Run("invalid document returns error", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := GenerateChangelog(&buf, []byte("paths: ["), []byte(currentDocument))
+
+		assert.ErrorContains(t, err, errorInvalidPrevious)
+	})
+}
+
+func TestCompareSchemas(t *testing.T) {
+	t.Run("property enum values and required state", func(t *testing.T) {
+		previous := &schema{
+			Required:   []string{"kind"},
+			Properties: map[string]*schema{"kind": {Type: "string", Enum: []any{"a", "b"}}},
+		}
+		current := &schema{
+			Properties: map[string]*schema{"kind": {Type: "string", Enum: []any{"a", "c"}, Deprecated: true}},
+		}
+
+		changes := compareSchemas(previous, current)
+
+		assert.Equal(t, []change{
+			{description: "Property `kind` is no longer required"},
+			{description: "Deprecated property `kind`"},
+			{description: "Added enum values of property `kind`: `c`"},
+			{description: "Removed enum values of property `kind`: `b`", incompatible: true},
+		}, changes)
+	})
+
+	t.Run("properties of allOf compositions", func(t *testing.T) {
+		previous := &schema{AllOf: []*schema{{Ref: schemaRefPrefix + "Base"}, {Properties: map[string]*schema{"name": {Type: "string"}}}}}
+		current := &schema{AllOf: []*schema{{Ref: schemaRefPrefix + "Base"}, {Properties: map[string]*schema{"name": {Type: []any{"string", "null"}}}}}}
+
+		changes := compareSchemas(previous, current)
+
+		assert.Equal(t, []change{{description: "Changed the type of property `name` from string to nullable string", incompatible: true}}, changes)
+	})
+}
+
+const previousStableDocument = `{
+  "info": {"title": "Users API", "version": "1.0.0"},
+  "paths": {
+    "/users": {
+      "get": {"summary": "List users", "x-stability": "stable", "parameters": [{"name": "limit", "in": "query"}],
+        "responses": {"200": {"content": {"application/json": {"schema": {"$ref": "#/components/schemas/UserList"}}}}}},
+      "post": {"summary": "Create a user", "x-stability": "beta"}
+    },
+    "/users/{id}": {
+      "get": {"summary": "Get a user", "x-stability": "stable"},
+      "delete": {"summary": "Delete a user", "x-stability": "stable"}
+    }
+  },
+  "components": {
+    "schemas": {
+      "UserList": {"type": "object", "properties": {"data": {"type": "array", "items": {"$ref": "#/components/schemas/User"}}}},
+      "User": {"type": "object", "properties": {"id": {"type": "string"}, "name": {"type": "string"}}},
+      "Draft": {"type": "object", "properties": {"id": {"type": "string"}}}
+    }
+  }
+}`
+
+const currentStableDocument = `{
+  "info": {"title": "Users API", "version": "2.0.0"},
+  "paths": {
+    "/users": {
+      "get": {"summary": "List users", "x-stability": "stable", "parameters": [{"name": "limit", "in": "query", "required": true}],
+        "responses": {"200": {"content": {"application/json": {"schema": {"$ref": "#/components/schemas/UserList"}}}}}},
+      "post": {"summary": "Create a user"}
+    },
+    "/users/{id}": {
+      "get": {"summary": "Get a user", "x-stability": "beta"}
+    }
+  },
+  "components": {
+    "schemas": {
+      "UserList": {"type": "object", "properties": {"data": {"type": "array", "items": {"$ref": "#/components/schemas/User"}}}},
+      "User": {"type": "object", "properties": {"id": {"type": "string"}, "email": {"type": "string"}}},
+      "Draft": {"type": "object", "properties": {"id": {"type": "integer"}}}
+    }
+  }
+}`
+
+func TestGenerateChangelog_StableEndpoints(t *testing.T) {
+	t.Run("warns about incompatible changes of stable endpoints", func(t *testing.T) {
+		// Act
+		var buf bytes.Buffer
+		err := GenerateChangelog(&buf, []byte(previousStableDocument), []byte(currentStableDocument))
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), "## Users API 2.0.0 (from 1.0.0)\n"+
+			"\n"+
+			"### "+sectionStableWarnings+"\n"+
+			"\n"+
+			"- `GET /users` requires the parameter `limit` (query)\n"+
+			"- `GET /users` uses `User`: Removed property `name`\n"+
+			"- `GET /users/{id}` is no longer stable\n"+
+			"- `DELETE /users/{id}` was removed\n"+
+			"\n"+
+			"### Removed endpoints\n")
+		assert.NotContains(t, buf.String(), "`Draft`:", "Schemas not used by stable endpoints should not be warned about")
+		assert.NotContains(t, buf.String(), "- `POST /users` ", "Endpoints that were not stable should not be warned about")
+	})
+
+	t.Run("no warnings without stable endpoints", func(t *testing.T) {
+		// Act
+		var buf bytes.Buffer
+		err := GenerateChangelog(&buf, []byte(previousDocument), []byte(currentDocument))
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NotContains(t, buf.String(), sectionStableWarnings)
+	})
+}
@@ -0,0 +1,34 @@
+// Package changeloggen generates a human-readable changelog of the changes between two OpenAPI documents.
+//
+// The changelog is written in Markdown, suitable for release notes, with a section for each kind of
+// change that is present:
+//   - Added, removed and deprecated endpoints, by method and path with their summaries
+//   - Added and removed schemas of the components
+//   - Changed schemas: added and removed properties, changed property types, properties that became
+//     required or optional, deprecated properties, and added and removed enum values
+//
+// Endpoints that were stable in the previous document (x-stability: stable) are promised not to break, so their
+// incompatible changes are listed first as warnings: removing them, lowering their stability, requiring new
+// parameters, and removed properties and enum values, changed types and newly required properties of the
+// schemas they use, directly or through other schemas.
+//
+// # Usage
+//
+//	previous, err := os.ReadFile("openapi.json")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	var current bytes.Buffer
+//	if err := openapigen.GenerateOpenAPI(&current, service); err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	var buf bytes.Buffer
+//	if err := changeloggen.GenerateChangelog(&buf, previous, current.Bytes()); err != nil {
+//	    log.Fatal(err)
+//	}
+//
+// Both documents can be JSON or YAML. An empty previous document lists everything in the current one
+// as added, for the first release of an API.
+package changeloggen
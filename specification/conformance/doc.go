@@ -0,0 +1,34 @@
+// Package conformance validates the requests and responses of an API against its specification.
+//
+// The Validator matches requests to the endpoints the generated server serves, with the same paths as the
+// OpenAPI document, and validates them against the specification the OpenAPI schemas are generated from:
+//   - Path and query parameters are checked against their types and enums
+//   - Request bodies must be JSON objects, with the required fields set and values of the right type, format
+//     and enum values, with the path of each violation in the body, e.g. address.street
+//   - Successful responses must have the status code of the endpoint and its content type and body, and
+//     responses without a body must be empty
+//   - Error responses must be the Error object of the service, or Problem Details (RFC 9457)
+//   - Only JSON and form bodies are validated field by field, the XML and CSV responses only by their content type
+//   - Fields of responses that are not in the specification are violations, since the clients generated from
+//     the specification do not know them
+//
+// NewProxy runs the Validator in front of an implementation of the API, reporting the violations of the traffic
+// without blocking it, to catch drift between an implementation and its specification without changing its code.
+// The mockserver package uses the Validator to reject invalid requests, including the fields of the bodies that
+// the generated server leaves to the service.
+//
+// # Usage
+//
+//	target, _ := url.Parse("http://localhost:9000")
+//	handler, err := conformance.NewProxy(service, target, func(report conformance.Report) {
+//	    log.Printf("%s %s: %v", report.Method, report.Path, report.Violations)
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	log.Fatal(http.ListenAndServe(":8080", handler))
+//
+// The proxy command of publicapis-gen serves it for a specification file:
+//
+//	publicapis-gen proxy -spec users-api.yaml -target http://localhost:9000 -port 8080
+package conformance
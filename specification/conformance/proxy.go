@@ -0,0 +1,116 @@
+package conformance
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+
+	"github.com/meitner-se/publicapis-gen/specification"
+)
+
+// Error messages
+const (
+	errorInvalidTarget = "invalid target"
+)
+
+// messageUndocumentedEndpoint is the message of the violation of a request to an endpoint the specification lacks
+const messageUndocumentedEndpoint = "%s %s is not an endpoint of the specification"
+
+// Directions of the reports
+const (
+	DirectionRequest  = "request"
+	DirectionResponse = "response"
+)
+
+// Report is the violations of a request or its response.
+type Report struct {
+	// Method and Path of the request
+	Method string `json:"method"`
+	Path   string `json:"path"`
+
+	// Endpoint is the resource and endpoint of the request, e.g. User.Create, empty when no endpoint matches
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// Direction tells whether the violations are in the request or the response
+	Direction string `json:"direction"`
+
+	// StatusCode of the response, zero for the violations of requests
+	StatusCode int `json:"status_code,omitempty"`
+
+	// Violations of the request or response
+	Violations []Violation `json:"violations"`
+}
+
+// NewProxy returns an http.Handler forwarding the requests to the target, an implementation of the API of the
+// service, and validating the requests and their responses against the specification. The violations are passed
+// to onViolation, a report per request and response, and never block the traffic: the requests and responses
+// are forwarded unchanged, so that the proxy can run in front of an implementation in staging to catch drift from
+// the specification. Requests that match no endpoint are reported when the target does not respond with 404 Not
+// Found, since the implementation then serves an endpoint that is not in the specification.
+func NewProxy(service *specification.Service, target *url.URL, onViolation func(Report)) (http.Handler, error) {
+	if target == nil || target.Scheme == "" || target.Host == "" {
+		return nil, fmt.Errorf("%s: target must be an absolute URL", errorInvalidTarget)
+	}
+
+	validator, err := NewValidator(service)
+	if err != nil {
+		return nil, err
+	}
+
+	proxy := httputil.NewSingleHostReverseProxy(target)
+	proxy.ModifyResponse = func(response *http.Response) error {
+		request := response.Request
+		match := validator.Match(request.Method, request.URL.Path)
+		report := Report{Method: request.Method, Path: request.URL.Path, Direction: DirectionResponse, StatusCode: response.StatusCode}
+
+		if match == nil {
+			if response.StatusCode != http.StatusNotFound {
+				report.Violations = []Violation{{Code: CodeUndocumentedEndpoint, Message: fmt.Sprintf(messageUndocumentedEndpoint, request.Method, request.URL.Path)}}
+				onViolation(report)
+			}
+			return nil
+		}
+
+		body, err := io.ReadAll(response.Body)
+		_ = response.Body.Close()
+		if err != nil {
+			return err
+		}
+		response.Body = io.NopCloser(bytes.NewReader(body))
+
+		report.Endpoint = endpointName(match)
+		if report.Violations = validator.ValidateResponse(match, response.StatusCode, response.Header, body); len(report.Violations) > 0 {
+			onViolation(report)
+		}
+		return nil
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if match := validator.Match(r.Method, r.URL.Path); match != nil && r.Method != http.MethodOptions {
+			body, err := io.ReadAll(r.Body)
+			_ = r.Body.Close()
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			if violations := validator.ValidateRequest(match, r.URL.Query(), body); len(violations) > 0 {
+				onViolation(Report{Method: r.Method, Path: r.URL.Path, Endpoint: endpointName(match), Direction: DirectionRequest, Violations: violations})
+			}
+		}
+
+		proxy.ServeHTTP(w, r)
+	}), nil
+}
+
+// endpointName returns the name of the endpoint of the match, e.g. User.Create.
+func endpointName(match *Match) string {
+	if match.Operational {
+		return match.Path
+	}
+	return match.Resource + "." + match.Endpoint.Name
+}
@@ -0,0 +1,146 @@
+package conformance
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// proxyTo serves a proxy for the test service in front of the implementation, returning the URL of the proxy
+// and a function returning the reports of the proxy.
+func proxyTo(t *testing.T, implementation http.HandlerFunc) (string, func() []Report) {
+	t.Helper()
+
+	target := httptest.NewServer(implementation)
+	t.Cleanup(target.Close)
+
+	targetURL, err := url.Parse(target.URL)
+	require.NoError(t, err)
+
+	var mu sync.Mutex
+	var reports []Report
+	handler, err := NewProxy(createTestService(), targetURL, func(report Report) {
+		mu.Lock()
+		defer mu.Unlock()
+		reports = append(reports, report)
+	})
+	require.NoError(t, err)
+
+	proxy := httptest.NewServer(handler)
+	t.Cleanup(proxy.Close)
+
+	return proxy.URL, func() []Report {
+		mu.Lock()
+		defer mu.Unlock()
+		return reports
+	}
+}
+
+func TestNewProxy(t *testing.T) {
+	t.Run("forwards valid traffic without reports", func(t *testing.T) {
+		// Arrange
+		user := exampleUser(t, "", nil)
+		proxyURL, reports := proxyTo(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set(headerContentType, contentTypeJSON)
+			_, _ = w.Write(user)
+		})
+
+		// Act
+		response, err := http.Get(proxyURL + "/api/v1/user/" + testUserID)
+		require.NoError(t, err)
+		defer response.Body.Close()
+		body, err := io.ReadAll(response.Body)
+		require.NoError(t, err)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, user, body, "The response should be forwarded unchanged")
+		assert.Empty(t, reports())
+	})
+
+	t.Run("reports invalid requests and still forwards them", func(t *testing.T) {
+		// Arrange
+		var forwarded string
+		proxyURL, reports := proxyTo(t, func(w http.ResponseWriter, r *http.Request) {
+			data, _ := io.ReadAll(r.Body)
+			forwarded = string(data)
+			w.WriteHeader(http.StatusUnprocessableEntity)
+		})
+
+		// Act
+		response, err := http.Post(proxyURL+"/api/v1/user", contentTypeJSON, strings.NewReader(`{"age": "old"}`))
+		require.NoError(t, err)
+		defer response.Body.Close()
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, response.StatusCode)
+		assert.Equal(t, `{"age": "old"}`, forwarded, "The request body should be forwarded unchanged")
+		require.Len(t, reports(), 1)
+		report := reports()[0]
+		assert.Equal(t, DirectionRequest, report.Direction)
+		assert.Equal(t, "User.Create", report.Endpoint)
+		assert.Equal(t, []Violation{
+			{Path: "name", Code: CodeRequired, Message: "Name is required"},
+			{Path: "age", Code: CodeInvalidType, Message: "Age must be a number"},
+		}, report.Violations)
+	})
+
+	t.Run("reports responses that drift from the specification", func(t *testing.T) {
+		// Arrange
+		proxyURL, reports := proxyTo(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+
+		// Act
+		response, err := http.Post(proxyURL+"/api/v1/user", contentTypeJSON, strings.NewReader(`{"name": "Ada"}`))
+		require.NoError(t, err)
+		defer response.Body.Close()
+
+		// Assert
+		require.Len(t, reports(), 1)
+		report := reports()[0]
+		assert.Equal(t, DirectionResponse, report.Direction)
+		assert.Equal(t, http.StatusOK, report.StatusCode)
+		assert.Equal(t, CodeUnexpectedStatus, report.Violations[0].Code)
+	})
+
+	t.Run("reports undocumented endpoints", func(t *testing.T) {
+		// Arrange
+		proxyURL, reports := proxyTo(t, func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/api/v1/missing" {
+				http.NotFound(w, r)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		})
+
+		// Act
+		for _, target := range []string{"/api/v1/internal", "/api/v1/missing"} {
+			response, err := http.Get(proxyURL + target)
+			require.NoError(t, err)
+			_ = response.Body.Close()
+		}
+
+		// Assert
+		require.Len(t, reports(), 1, "Endpoints the implementation does not serve should not be reported")
+		assert.Equal(t, "/api/v1/internal", reports()[0].Path)
+		assert.Equal(t, CodeUndocumentedEndpoint, reports()[0].Violations[0].Code)
+	})
+
+	t.Run("relative target returns error", func(t *testing.T) {
+		_, err := NewProxy(createTestService(), &url.URL{Path: "/api"}, func(Report) {})
+		assert.ErrorContains(t, err, errorInvalidTarget)
+	})
+
+	t.Run("nil service returns error", func(t *testing.T) {
+		_, err := NewProxy(nil, &url.URL{Scheme: "http", Host: "localhost"}, func(Report) {})
+		assert.ErrorContains(t, err, errorInvalidService)
+	})
+}
@@ -0,0 +1,700 @@
+package conformance
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/meitner-se/publicapis-gen/specification"
+)
+
+// Error messages
+const (
+	errorInvalidService = "invalid service"
+)
+
+// Codes of the violations, the codes of the field errors are those of the ErrorField object of the specification
+const (
+	CodeRequired              = "required"
+	CodeInvalidType           = "invalid_type"
+	CodeInvalidFormat         = "invalid_format"
+	CodeInvalidValue          = "invalid_value"
+	CodeUnknownField          = "unknown_field"
+	CodeInvalidParameter      = "invalid_parameter"
+	CodeInvalidJSON           = "invalid_json"
+	CodeUnexpectedStatus      = "unexpected_status"
+	CodeUnexpectedContentType = "unexpected_content_type"
+	CodeUnexpectedBody        = "unexpected_body"
+	CodeUndocumentedEndpoint  = "undocumented_endpoint"
+)
+
+// Messages of the violations, formatted with the details of the violation
+const (
+	messageInvalidPathParams          = "invalid path params: "
+	messageInvalidQueryParams         = "invalid query params: "
+	messageInvalidFormBodyParams      = "invalid form body params: "
+	messageInvalidJSONBodyParams      = "invalid json body params: "
+	messageInvalidJSONBody            = "invalid json body: "
+	messageInvalidJSONErrorBody       = "invalid json error body: "
+	messageUnexpectedStatus           = "status code %d, expected %d"
+	messageUnexpectedBody             = "the response has a body, expected none"
+	messageUnexpectedContentType      = "content type '%s', expected '%s'"
+	messageUnexpectedErrorContentType = "content type '%s' of the error, expected '%s'"
+	messageRequired                   = "%s is required"
+	messageUnknownField               = "%s is not in the specification"
+	messageFieldViolation             = "%s %v"
+	messageParameterViolation         = "%s: %w"
+	messageInvalidParameterValue      = "'%s' %w"
+	messageNotEnumValue               = "'%s' is not one of the values of %s"
+	messageNotBoolean                 = "'%s' is not a boolean"
+	messageBodyNotObject              = "the body must be a JSON object"
+)
+
+// Messages of the invalid values of fields and parameters, following the name of the field
+const (
+	messageMustBeArray       = "must be an array"
+	messageCannotContainNull = "cannot contain null"
+	messageMustBeObject      = "must be an object"
+	messageMustBeEnumValue   = "must be one of the values of %s"
+	messageMustBeBoolean     = "must be a boolean"
+	messageMustBeNumber      = "must be a number"
+	messageMustBeInteger     = "must be an integer"
+	messageMustBeInt32       = "must be a 32-bit integer"
+	messageMustNotBeNegative = "must not be negative"
+	messageMustBeString      = "must be a string"
+	messageMustBeUUID        = "must be a UUID"
+	messageMustBeDate        = "must be a date, e.g. 2024-01-15"
+	messageMustBeTimestamp   = "must be an RFC 3339 timestamp, e.g. 2024-01-15T10:30:00Z"
+	messageMustBeDecimal     = "must be a decimal, e.g. 19.99"
+)
+
+// HTTP constants
+const (
+	contentTypeJSON        = "application/json"
+	contentTypeProblemJSON = "application/problem+json"
+	headerContentType      = "Content-Type"
+	pathSeparator          = "/"
+	errorObjectName        = "Error"
+)
+
+// servedPaths are the paths the generated server serves besides the endpoints and operational endpoints, the
+// OpenAPI document and its documentation page
+var servedPaths = []string{"/openapi.json", "/docs"}
+
+// uuidPattern matches a UUID in its canonical form
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// Violation is a difference between a request or response and the specification.
+type Violation struct {
+	// Path of the invalid field in the body, with dots between nested fields and array indexes, e.g. address.street,
+	// or the name of the invalid parameter. Empty for violations of the whole request or response.
+	Path string `json:"path,omitempty"`
+
+	// Code of the violation, e.g. required or unexpected_status
+	Code string `json:"code"`
+
+	// Message describing the violation
+	Message string `json:"message"`
+}
+
+// Match is the endpoint a request is matched to.
+type Match struct {
+	// Resource is the name of the resource of the endpoint, empty for the operational endpoints
+	Resource string
+
+	// Endpoint of the resource
+	Endpoint specification.Endpoint
+
+	// Operational is set for the operational endpoints and the documentation the generated server serves,
+	// which are not endpoints of a resource. Path is their path under the base path, e.g. /healthz.
+	Operational bool
+	Path        string
+
+	// PathValues are the values of the path parameters in the request, by their names in the path
+	PathValues map[string]string
+}
+
+// route is an endpoint of the service, matched by its method and the segments of its full path.
+type route struct {
+	method   string
+	segments []string
+	match    Match
+}
+
+// Validator validates the requests and responses of the endpoints of a service against its specification.
+type Validator struct {
+	service *specification.Service
+	routes  []route
+}
+
+// NewValidator creates a Validator for the endpoints the generated server of the service serves. Development
+// resources are left out, like in the OpenAPI document.
+func NewValidator(service *specification.Service) (*Validator, error) {
+	if service == nil {
+		return nil, fmt.Errorf("%s: service cannot be nil", errorInvalidService)
+	}
+
+	v := &Validator{service: service}
+	for _, resource := range service.Resources {
+		if resource.Development {
+			continue
+		}
+
+		for _, endpoint := range resource.Endpoints {
+			endpointPath := service.Tenancy.GetPathPrefix() + service.GetEndpointPath(resource, endpoint)
+			v.routes = append(v.routes, route{
+				method:   strings.ToUpper(endpoint.Method),
+				segments: splitPath(joinPaths(service.GetBasePath(), endpointPath)),
+				match:    Match{Resource: resource.Name, Endpoint: endpoint},
+			})
+		}
+	}
+
+	var operationalPaths []string
+	if service.Operational.HasHealth() {
+		operationalPaths = append(operationalPaths, specification.OperationalPathHealth)
+	}
+	if service.Operational.HasReadiness() {
+		operationalPaths = append(operationalPaths, specification.OperationalPathReadiness)
+	}
+	if service.Operational.HasVersion() {
+		operationalPaths = append(operationalPaths, specification.OperationalPathVersion)
+	}
+	for _, operationalPath := range append(operationalPaths, servedPaths...) {
+		v.routes = append(v.routes, route{
+			method:   http.MethodGet,
+			segments: splitPath(joinPaths(service.GetBasePath(), operationalPath)),
+			match:    Match{Operational: true, Path: operationalPath},
+		})
+	}
+
+	return v, nil
+}
+
+// Match returns the endpoint of the method and path with the values of its path parameters, or nil when no
+// endpoint matches. Endpoints with more static segments win, so that /user/_search is matched before /user/{id}.
+func (v *Validator) Match(method, requestPath string) *Match {
+	segments := splitPath(requestPath)
+
+	var best *Match
+	bestStatic := -1
+	for _, candidate := range v.routes {
+		if candidate.method != method || len(candidate.segments) != len(segments) {
+			continue
+		}
+
+		values := map[string]string{}
+		static := 0
+		matches := true
+		for i, segment := range candidate.segments {
+			if name, ok := parameterName(segment); ok {
+				values[name] = segments[i]
+				continue
+			}
+			if segment != segments[i] {
+				matches = false
+				break
+			}
+			static++
+		}
+
+		if matches && static > bestStatic {
+			match := candidate.match
+			match.PathValues = values
+			best, bestStatic = &match, static
+		}
+	}
+
+	return best
+}
+
+// ParsePathParams validates the path parameters of the request of the endpoint, returning them as the values of
+// their JSON types by their JSON names.
+func (v *Validator) ParsePathParams(match *Match) (map[string]any, error) {
+	params := make(map[string]any, len(match.Endpoint.Request.PathParams))
+	for _, param := range match.Endpoint.Request.PathParams {
+		value, err := v.parseParameter(param, match.PathValues[param.TagJSON()])
+		if err != nil {
+			return nil, fmt.Errorf(messageParameterViolation, param.TagJSON(), err)
+		}
+		params[param.TagJSON()] = value
+	}
+	return params, nil
+}
+
+// ValidateQueryParams validates the query parameters of the request of the endpoint that are set.
+func (v *Validator) ValidateQueryParams(match *Match, query url.Values) error {
+	for _, param := range match.Endpoint.Request.QueryParams {
+		for _, value := range query[param.TagJSON()] {
+			if _, err := v.parseParameter(param, value); err != nil {
+				return fmt.Errorf(messageParameterViolation, param.TagJSON(), err)
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateBody decodes the JSON or form body of the request of the endpoint and validates its fields, returning
+// the decoded body and the violations of its fields. An error is returned when the body is not a JSON object, or
+// not a form for the endpoints with form requests.
+func (v *Validator) ValidateBody(match *Match, data []byte) (map[string]any, []Violation, error) {
+	var body map[string]any
+	var err error
+	if match.Endpoint.HasFormRequest() {
+		body, err = v.decodeForm(match.Endpoint.Request.BodyParams, data)
+	} else {
+		body, err = decodeObject(data)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return body, v.validateFields(match.Endpoint.Request.BodyParams, body, "", false), nil
+}
+
+// ValidateRequest validates the parameters and body of a request of the endpoint, returning the violations.
+func (v *Validator) ValidateRequest(match *Match, query url.Values, body []byte) []Violation {
+	if match.Operational {
+		return nil
+	}
+
+	var violations []Violation
+	if _, err := v.ParsePathParams(match); err != nil {
+		violations = append(violations, Violation{Code: CodeInvalidParameter, Message: messageInvalidPathParams + err.Error()})
+	}
+	if err := v.ValidateQueryParams(match, query); err != nil {
+		violations = append(violations, Violation{Code: CodeInvalidParameter, Message: messageInvalidQueryParams + err.Error()})
+	}
+
+	// The fields of XML bodies are not validated, only JSON
+	if len(match.Endpoint.Request.BodyParams) > 0 && !match.Endpoint.HasXMLRequest() {
+		_, fieldViolations, err := v.ValidateBody(match, body)
+		if err != nil && match.Endpoint.HasFormRequest() {
+			return append(violations, Violation{Code: CodeInvalidParameter, Message: messageInvalidFormBodyParams + err.Error()})
+		}
+		if err != nil {
+			return append(violations, Violation{Code: CodeInvalidJSON, Message: messageInvalidJSONBodyParams + err.Error()})
+		}
+		violations = append(violations, fieldViolations...)
+	}
+
+	return violations
+}
+
+// ValidateResponse validates a response of the endpoint, returning the violations. Successful responses must have
+// the status code of the endpoint and its body, and error responses the Error object of the service. Fields that
+// are not in the specification are violations too, since the clients generated from it do not know them.
+func (v *Validator) ValidateResponse(match *Match, statusCode int, header http.Header, body []byte) []Violation {
+	if match.Operational {
+		return nil
+	}
+
+	if statusCode >= http.StatusBadRequest {
+		return v.validateErrorResponse(header, body)
+	}
+
+	response := match.Endpoint.Response
+	expectedStatus := response.StatusCode
+	if expectedStatus == 0 {
+		expectedStatus = http.StatusOK
+	}
+
+	// Not Modified answers a conditional request, without a body
+	if statusCode == http.StatusNotModified {
+		return nil
+	}
+
+	if statusCode != expectedStatus {
+		return []Violation{{Code: CodeUnexpectedStatus, Message: fmt.Sprintf(messageUnexpectedStatus, statusCode, expectedStatus)}}
+	}
+
+	hasBody := response.BodyObject != nil || len(response.BodyFields) > 0
+	if statusCode == http.StatusNoContent || !hasBody {
+		if len(bytes.TrimSpace(body)) > 0 {
+			return []Violation{{Code: CodeUnexpectedBody, Message: messageUnexpectedBody}}
+		}
+		return nil
+	}
+
+	mediaType := getMediaType(header)
+	if slices.Contains(response.AlternativeContentTypes, mediaType) {
+		return nil
+	}
+
+	expectedType := contentTypeJSON
+	if response.ContentType != "" {
+		expectedType = response.ContentType
+	}
+	if mediaType != expectedType {
+		return []Violation{{Code: CodeUnexpectedContentType, Message: fmt.Sprintf(messageUnexpectedContentType, mediaType, expectedType)}}
+	}
+
+	// The fields of XML and CSV bodies are not validated, only JSON
+	if expectedType != contentTypeJSON {
+		return nil
+	}
+
+	decoded, err := decodeObject(body)
+	if err != nil {
+		return []Violation{{Code: CodeInvalidJSON, Message: messageInvalidJSONBody + err.Error()}}
+	}
+
+	fields := response.BodyFields
+	if response.BodyObject != nil {
+		object := v.service.GetObject(*response.BodyObject)
+		if object == nil {
+			return nil
+		}
+		fields = object.Fields
+	}
+
+	return v.validateFields(fields, decoded, "", true)
+}
+
+// validateErrorResponse validates an error response against the Error object of the service.
+func (v *Validator) validateErrorResponse(header http.Header, body []byte) []Violation {
+	errorObject := v.service.GetObject(errorObjectName)
+	if errorObject == nil || len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+
+	expectedType := contentTypeJSON
+	if v.service.IsProblemDetails() {
+		expectedType = contentTypeProblemJSON
+	}
+	if mediaType := getMediaType(header); mediaType != expectedType {
+		return []Violation{{Code: CodeUnexpectedContentType, Message: fmt.Sprintf(messageUnexpectedErrorContentType, mediaType, expectedType)}}
+	}
+
+	decoded, err := decodeObject(body)
+	if err != nil {
+		return []Violation{{Code: CodeInvalidJSON, Message: messageInvalidJSONErrorBody + err.Error()}}
+	}
+
+	return v.validateFields(errorObject.Fields, decoded, "", true)
+}
+
+// validateFields validates the fields of a JSON object against the fields of the specification, returning the
+// violations in order. Nested objects and arrays are validated too, with their path in the body. Fields that are
+// not in the specification are reported when unknown is set.
+func (v *Validator) validateFields(fields []specification.Field, values map[string]any, prefix string, unknown bool) []Violation {
+	var violations []Violation
+	for _, field := range fields {
+		fieldPath := prefix + field.TagJSON()
+		value, ok := values[field.TagJSON()]
+		if !ok || value == nil {
+			if field.IsRequired(v.service) && !(ok && field.IsNullable()) {
+				violations = append(violations, Violation{Path: fieldPath, Code: CodeRequired, Message: fmt.Sprintf(messageRequired, field.Name)})
+			}
+			continue
+		}
+
+		violations = append(violations, v.validateValue(field, value, fieldPath, unknown)...)
+	}
+
+	if unknown {
+		known := make(map[string]bool, len(fields))
+		for _, field := range fields {
+			known[field.TagJSON()] = true
+		}
+
+		var names []string
+		for name := range values {
+			if !known[name] {
+				names = append(names, name)
+			}
+		}
+		slices.Sort(names)
+
+		for _, name := range names {
+			violations = append(violations, Violation{Path: prefix + name, Code: CodeUnknownField, Message: fmt.Sprintf(messageUnknownField, name)})
+		}
+	}
+
+	return violations
+}
+
+// validateValue validates the value of a field, which is not null.
+func (v *Validator) validateValue(field specification.Field, value any, fieldPath string, unknown bool) []Violation {
+	if field.IsArray() {
+		items, ok := value.([]any)
+		if !ok {
+			return []Violation{{Path: fieldPath, Code: CodeInvalidType, Message: fmt.Sprintf(messageFieldViolation, field.Name, messageMustBeArray)}}
+		}
+
+		item := field
+		item.Modifiers = nil
+		var violations []Violation
+		for i, itemValue := range items {
+			itemPath := fieldPath + "." + strconv.Itoa(i)
+			if itemValue == nil {
+				violations = append(violations, Violation{Path: itemPath, Code: CodeInvalidType, Message: fmt.Sprintf(messageFieldViolation, field.Name, messageCannotContainNull)})
+				continue
+			}
+			violations = append(violations, v.validateValue(item, itemValue, itemPath, unknown)...)
+		}
+		return violations
+	}
+
+	if object := v.service.GetObject(field.Type); object != nil {
+		nested, ok := value.(map[string]any)
+		if !ok {
+			return []Violation{{Path: fieldPath, Code: CodeInvalidType, Message: fmt.Sprintf(messageFieldViolation, field.Name, messageMustBeObject)}}
+		}
+		return v.validateFields(object.Fields, nested, fieldPath+".", unknown)
+	}
+
+	if code, err := v.validatePrimitive(field, value); err != nil {
+		return []Violation{{Path: fieldPath, Code: code, Message: fmt.Sprintf(messageFieldViolation, field.Name, err)}}
+	}
+	return nil
+}
+
+// validatePrimitive validates the JSON value of a primitive or enum field, returning the code of the violation.
+func (v *Validator) validatePrimitive(field specification.Field, value any) (string, error) {
+	if enum := v.service.GetEnum(field.Type); enum != nil {
+		var wireValue string
+		switch typed := value.(type) {
+		case string:
+			if enum.IsInt() {
+				return CodeInvalidType, errors.New(messageMustBeInteger)
+			}
+			wireValue = typed
+		case json.Number:
+			if !enum.IsInt() {
+				return CodeInvalidType, errors.New(messageMustBeString)
+			}
+			wireValue = typed.String()
+		default:
+			return CodeInvalidType, fmt.Errorf(messageMustBeEnumValue, enum.Name)
+		}
+
+		for _, enumValue := range enum.Values {
+			if enumValue.GetValue() == wireValue {
+				return "", nil
+			}
+		}
+		return CodeInvalidValue, fmt.Errorf(messageMustBeEnumValue, enum.Name)
+	}
+
+	switch field.Type {
+	case specification.FieldTypeBool:
+		if _, ok := value.(bool); !ok {
+			return CodeInvalidType, errors.New(messageMustBeBoolean)
+		}
+		return "", nil
+	case specification.FieldTypeInt, specification.FieldTypeInt32, specification.FieldTypeUInt, specification.FieldTypeFloat64:
+		number, ok := value.(json.Number)
+		if !ok {
+			return CodeInvalidType, errors.New(messageMustBeNumber)
+		}
+		if err := checkNumber(field.Type, number.String()); err != nil {
+			return CodeInvalidType, err
+		}
+		return "", nil
+	}
+
+	text, ok := value.(string)
+	if !ok {
+		return CodeInvalidType, errors.New(messageMustBeString)
+	}
+	if err := checkFormat(field.Type, text); err != nil {
+		return CodeInvalidFormat, err
+	}
+	return "", nil
+}
+
+// parseParameter validates the value of a path or query parameter, returning it as the value of its JSON type.
+func (v *Validator) parseParameter(param specification.Field, raw string) (any, error) {
+	fieldType := v.service.GetPrimitiveType(param.Type)
+	if enum := v.service.GetEnum(param.Type); enum != nil {
+		for _, enumValue := range enum.Values {
+			if enumValue.GetValue() == raw {
+				return typedParameter(fieldType, raw), nil
+			}
+		}
+		return nil, fmt.Errorf(messageNotEnumValue, raw, enum.Name)
+	}
+
+	switch fieldType {
+	case specification.FieldTypeBool:
+		if _, err := strconv.ParseBool(raw); err != nil {
+			return nil, fmt.Errorf(messageNotBoolean, raw)
+		}
+	case specification.FieldTypeInt, specification.FieldTypeInt32, specification.FieldTypeUInt, specification.FieldTypeFloat64:
+		if err := checkNumber(fieldType, raw); err != nil {
+			return nil, fmt.Errorf(messageInvalidParameterValue, raw, err)
+		}
+	default:
+		if err := checkFormat(fieldType, raw); err != nil {
+			return nil, fmt.Errorf(messageInvalidParameterValue, raw, err)
+		}
+	}
+
+	return typedParameter(fieldType, raw), nil
+}
+
+// checkNumber checks that the number fits the numeric field type.
+func checkNumber(fieldType, raw string) error {
+	if fieldType == specification.FieldTypeFloat64 {
+		if _, err := strconv.ParseFloat(raw, 64); err != nil {
+			return errors.New(messageMustBeNumber)
+		}
+		return nil
+	}
+
+	value, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		return errors.New(messageMustBeInteger)
+	}
+	if fieldType == specification.FieldTypeInt32 && (value < math.MinInt32 || value > math.MaxInt32) {
+		return errors.New(messageMustBeInt32)
+	}
+	if fieldType == specification.FieldTypeUInt && value < 0 {
+		return errors.New(messageMustNotBeNegative)
+	}
+	return nil
+}
+
+// checkFormat checks that the string is in the format of the field type, for the types with a format.
+func checkFormat(fieldType, value string) error {
+	switch fieldType {
+	case specification.FieldTypeUUID:
+		if !uuidPattern.MatchString(value) {
+			return errors.New(messageMustBeUUID)
+		}
+	case specification.FieldTypeDate:
+		if _, err := time.Parse(time.DateOnly, value); err != nil {
+			return errors.New(messageMustBeDate)
+		}
+	case specification.FieldTypeTimestamp:
+		if _, err := time.Parse(time.RFC3339, value); err != nil {
+			return errors.New(messageMustBeTimestamp)
+		}
+	case specification.FieldTypeDecimal:
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return errors.New(messageMustBeDecimal)
+		}
+	}
+	return nil
+}
+
+// typedParameter returns the value of a valid parameter as the value of its JSON type.
+func typedParameter(fieldType, raw string) any {
+	switch fieldType {
+	case specification.FieldTypeBool:
+		value, _ := strconv.ParseBool(raw)
+		return value
+	case specification.FieldTypeInt, specification.FieldTypeInt32, specification.FieldTypeUInt:
+		value, _ := strconv.ParseInt(raw, 10, 64)
+		return value
+	case specification.FieldTypeFloat64:
+		value, _ := strconv.ParseFloat(raw, 64)
+		return value
+	}
+	return raw
+}
+
+// decodeObject decodes a JSON object, keeping numbers as json.Number so that integers are told apart from floats.
+func decodeObject(data []byte) (map[string]any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var object map[string]any
+	if err := decoder.Decode(&object); err != nil {
+		return nil, err
+	}
+	if object == nil {
+		return nil, errors.New(messageBodyNotObject)
+	}
+	return object, nil
+}
+
+// decodeForm decodes a form body as the JSON object of its fields, so that it is validated like a JSON body.
+// Numbers become json.Number and valid booleans bool, while the keys of the arrays are repeated for each value.
+func (v *Validator) decodeForm(fields []specification.Field, data []byte) (map[string]any, error) {
+	form, err := url.ParseQuery(string(data))
+	if err != nil {
+		return nil, err
+	}
+
+	body := make(map[string]any, len(form))
+	for name, values := range form {
+		body[name] = values[0]
+	}
+	for _, field := range fields {
+		values, ok := form[field.TagJSON()]
+		if !ok {
+			continue
+		}
+
+		fieldType := v.service.GetPrimitiveType(field.Type)
+		if !field.IsArray() {
+			body[field.TagJSON()] = formValue(fieldType, values[0])
+			continue
+		}
+
+		items := make([]any, 0, len(values))
+		for _, value := range values {
+			items = append(items, formValue(fieldType, value))
+		}
+		body[field.TagJSON()] = items
+	}
+	return body, nil
+}
+
+// formValue returns the value of a form field as the value of its JSON type, or as the string when it is invalid
+// for the type, so that the validation of the field reports it.
+func formValue(fieldType, raw string) any {
+	switch fieldType {
+	case specification.FieldTypeBool:
+		if value, err := strconv.ParseBool(raw); err == nil {
+			return value
+		}
+	case specification.FieldTypeInt, specification.FieldTypeInt32, specification.FieldTypeUInt, specification.FieldTypeFloat64:
+		return json.Number(raw)
+	}
+	return raw
+}
+
+// getMediaType returns the media type of the Content-Type header, without its parameters.
+func getMediaType(header http.Header) string {
+	mediaType, _, err := mime.ParseMediaType(header.Get(headerContentType))
+	if err != nil {
+		return header.Get(headerContentType)
+	}
+	return mediaType
+}
+
+// parameterName returns the name of the parameter of a path segment, e.g. id for {id}.
+func parameterName(segment string) (string, bool) {
+	if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+		return segment[1 : len(segment)-1], true
+	}
+	return "", false
+}
+
+// joinPaths joins the base path and the path of an endpoint like the router of the generated server.
+func joinPaths(basePath, endpointPath string) string {
+	return path.Join(pathSeparator, basePath, endpointPath)
+}
+
+// splitPath returns the segments of a path, ignoring leading and trailing slashes.
+func splitPath(value string) []string {
+	value = strings.Trim(value, pathSeparator)
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, pathSeparator)
+}
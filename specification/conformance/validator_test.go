@@ -0,0 +1,331 @@
+package conformance
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/meitner-se/publicapis-gen/specification"
+	"github.com/meitner-se/publicapis-gen/specification/examplegen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testUserID = "123e4567-e89b-12d3-a456-426614174000"
+
+// createTestService creates a service whose User has a field of each kind the validator checks values of: a
+// string, an enum, a 32-bit integer and an object, next to a development resource and a health endpoint.
+func createTestService() *specification.Service {
+	return specification.ApplyDefaultOverlays(&specification.Service{
+		Name:        "Users API",
+		Version:     "1.0.0",
+		BasePath:    "/api/v1",
+		Operational: &specification.OperationalEndpoints{Health: true},
+		Enums: []specification.Enum{
+			{Name: "Role", Description: "Role of a user", Values: []specification.EnumValue{{Name: "Admin"}, {Name: "Member"}}},
+		},
+		Objects: []specification.Object{
+			{
+				Name:        "Address",
+				Description: "Postal address",
+				Fields: []specification.Field{
+					{Name: "Street", Description: "Street", Type: specification.FieldTypeString},
+				},
+			},
+		},
+		Resources: []specification.Resource{
+			{
+				Name:        "User",
+				Description: "Users",
+				Operations:  []string{specification.OperationCreate, specification.OperationGet, specification.OperationList, specification.OperationUpdate, specification.OperationDelete},
+				Fields: []specification.ResourceField{
+					{Field: specification.Field{Name: "Name", Description: "Name", Type: specification.FieldTypeString}, Operations: []string{specification.OperationCreate, specification.OperationUpdate, specification.OperationRead}},
+					{Field: specification.Field{Name: "Role", Description: "Role", Type: "Role", Modifiers: []string{specification.ModifierNullable}}, Operations: []string{specification.OperationCreate, specification.OperationRead}},
+					{Field: specification.Field{Name: "Age", Description: "Age", Type: specification.FieldTypeInt32, Modifiers: []string{specification.ModifierNullable}}, Operations: []string{specification.OperationCreate, specification.OperationRead}},
+					{Field: specification.Field{Name: "Address", Description: "Address", Type: "Address", Modifiers: []string{specification.ModifierNullable}}, Operations: []string{specification.OperationCreate, specification.OperationRead}},
+				},
+			},
+			{
+				Name:        "Draft",
+				Description: "Drafts",
+				Development: true,
+				Operations:  []string{specification.OperationGet},
+			},
+		},
+	})
+}
+
+// newTestValidator creates a validator for the test service.
+func newTestValidator(t *testing.T) *Validator {
+	t.Helper()
+
+	validator, err := NewValidator(createTestService())
+	require.NoError(t, err)
+	return validator
+}
+
+// exampleUser returns a valid User object as JSON, with a field set.
+func exampleUser(t *testing.T, key string, value any) []byte {
+	t.Helper()
+
+	user := examplegen.New(createTestService(), examplegen.DefaultSeed).Object("User")
+	if key != "" {
+		user[key] = value
+	}
+
+	data, err := json.Marshal(user)
+	require.NoError(t, err)
+	return data
+}
+
+// jsonHeader returns the header of a JSON response with the content type.
+func jsonHeader(contentType string) http.Header {
+	header := http.Header{}
+	header.Set(headerContentType, contentType)
+	return header
+}
+
+func TestNewValidator(t *testing.T) {
+	t.Run("nil service returns error", func(t *testing.T) {
+		_, err := NewValidator(nil)
+		assert.ErrorContains(t, err, errorInvalidService)
+	})
+}
+
+func TestValidator_Match(t *testing.T) {
+	validator := newTestValidator(t)
+
+	t.Run("matches the endpoint with its path values", func(t *testing.T) {
+		match := validator.Match(http.MethodGet, "/api/v1/user/42/")
+		require.NotNil(t, match)
+		assert.Equal(t, "User", match.Resource)
+		assert.Equal(t, "Get", match.Endpoint.Name)
+		assert.Equal(t, map[string]string{"id": "42"}, match.PathValues)
+	})
+
+	t.Run("static segments win over parameters", func(t *testing.T) {
+		// Arrange
+		v := &Validator{routes: []route{
+			{method: http.MethodGet, segments: splitPath("/user/{id}"), match: Match{Resource: "User", Endpoint: specification.Endpoint{Name: "Get"}}},
+			{method: http.MethodGet, segments: splitPath("/user/_export"), match: Match{Resource: "User", Endpoint: specification.Endpoint{Name: "Export"}}},
+		}}
+
+		// Act
+		match := v.Match(http.MethodGet, "/user/_export")
+
+		// Assert
+		require.NotNil(t, match)
+		assert.Equal(t, "Export", match.Endpoint.Name)
+		assert.Empty(t, match.PathValues)
+	})
+
+	t.Run("matches the operational endpoints and documentation", func(t *testing.T) {
+		for _, target := range []string{"/api/v1/healthz", "/api/v1/openapi.json"} {
+			match := validator.Match(http.MethodGet, target)
+			require.NotNil(t, match, target)
+			assert.True(t, match.Operational, target)
+		}
+	})
+
+	t.Run("method must match", func(t *testing.T) {
+		assert.Nil(t, validator.Match(http.MethodPut, "/api/v1/user/42"))
+	})
+
+	t.Run("development resources are not matched", func(t *testing.T) {
+		assert.Nil(t, validator.Match(http.MethodGet, "/api/v1/draft/"+testUserID))
+	})
+}
+
+func TestValidator_ValidateRequest(t *testing.T) {
+	validator := newTestValidator(t)
+
+	t.Run("valid request has no violations", func(t *testing.T) {
+		// Arrange
+		match := validator.Match(http.MethodPost, "/api/v1/user")
+		require.NotNil(t, match)
+
+		// Act
+		violations := validator.ValidateRequest(match, nil, []byte(`{"name": "Ada", "role": "Admin", "age": 36}`))
+
+		// Assert
+		assert.Empty(t, violations)
+	})
+
+	t.Run("reports invalid parameters", func(t *testing.T) {
+		// Arrange
+		match := validator.Match(http.MethodGet, "/api/v1/user/not-a-uuid")
+		require.NotNil(t, match)
+
+		// Act
+		violations := validator.ValidateRequest(match, url.Values{}, nil)
+
+		// Assert
+		require.Len(t, violations, 1)
+		assert.Equal(t, CodeInvalidParameter, violations[0].Code)
+		assert.Contains(t, violations[0].Message, "must be a UUID")
+	})
+
+	t.Run("reports invalid query parameters", func(t *testing.T) {
+		// Arrange
+		match := validator.Match(http.MethodGet, "/api/v1/user")
+		require.NotNil(t, match)
+
+		// Act
+		violations := validator.ValidateRequest(match, url.Values{"limit": {"ten"}}, nil)
+
+		// Assert
+		require.Len(t, violations, 1)
+		assert.Equal(t, CodeInvalidParameter, violations[0].Code)
+	})
+
+	t.Run("reports the invalid fields of the body", func(t *testing.T) {
+		// Arrange
+		match := validator.Match(http.MethodPost, "/api/v1/user")
+		require.NotNil(t, match)
+
+		// Act
+		violations := validator.ValidateRequest(match, nil, []byte(`{"role": "Owner", "age": 3000000000, "address": {"street": 3}}`))
+
+		// Assert
+		assert.Equal(t, []Violation{
+			{Path: "name", Code: CodeRequired, Message: "Name is required"},
+			{Path: "role", Code: CodeInvalidValue, Message: "Role must be one of the values of Role"},
+			{Path: "age", Code: CodeInvalidType, Message: "Age must be a 32-bit integer"},
+			{Path: "address.street", Code: CodeInvalidType, Message: "Street must be a string"},
+		}, violations)
+	})
+
+	t.Run("XML bodies are not validated as JSON", func(t *testing.T) {
+		// Arrange
+		match := validator.Match(http.MethodPost, "/api/v1/user")
+		require.NotNil(t, match)
+		match.Endpoint.Request.ContentType = specification.ContentTypeXML
+
+		// Act
+		violations := validator.ValidateRequest(match, nil, []byte("<UserCreateBodyParams><name>Ada</name></UserCreateBodyParams>"))
+
+		// Assert
+		assert.Empty(t, violations)
+	})
+
+	t.Run("validates the fields of form bodies", func(t *testing.T) {
+		// Arrange
+		match := validator.Match(http.MethodPost, "/api/v1/user")
+		require.NotNil(t, match)
+		match.Endpoint.Request.ContentType = specification.ContentTypeForm
+
+		// Act
+		valid := validator.ValidateRequest(match, nil, []byte("name=Ada&age=36"))
+		invalid := validator.ValidateRequest(match, nil, []byte("name=Ada&role=Owner&age=ten"))
+
+		// Assert
+		assert.Empty(t, valid)
+		assert.Equal(t, []Violation{
+			{Path: "role", Code: CodeInvalidValue, Message: "Role must be one of the values of Role"},
+			{Path: "age", Code: CodeInvalidType, Message: "Age must be an integer"},
+		}, invalid)
+	})
+
+	t.Run("reports malformed bodies", func(t *testing.T) {
+		// Arrange
+		match := validator.Match(http.MethodPost, "/api/v1/user")
+		require.NotNil(t, match)
+
+		// Act
+		violations := validator.ValidateRequest(match, nil, []byte(`{"name": `))
+
+		// Assert
+		require.Len(t, violations, 1)
+		assert.Equal(t, CodeInvalidJSON, violations[0].Code)
+	})
+}
+
+func TestValidator_ValidateResponse(t *testing.T) {
+	validator := newTestValidator(t)
+	getMatch := validator.Match(http.MethodGet, "/api/v1/user/"+testUserID)
+	require.NotNil(t, getMatch)
+
+	t.Run("valid response has no violations", func(t *testing.T) {
+		violations := validator.ValidateResponse(getMatch, http.StatusOK, jsonHeader(contentTypeJSON+"; charset=utf-8"), exampleUser(t, "", nil))
+		assert.Empty(t, violations)
+	})
+
+	t.Run("reports unexpected status codes", func(t *testing.T) {
+		// Arrange
+		createMatch := validator.Match(http.MethodPost, "/api/v1/user")
+		require.NotNil(t, createMatch)
+
+		// Act
+		violations := validator.ValidateResponse(createMatch, http.StatusOK, jsonHeader(contentTypeJSON), exampleUser(t, "", nil))
+
+		// Assert
+		require.Len(t, violations, 1)
+		assert.Equal(t, CodeUnexpectedStatus, violations[0].Code)
+		assert.Equal(t, "status code 200, expected 201", violations[0].Message)
+	})
+
+	t.Run("reports invalid and unknown fields", func(t *testing.T) {
+		// Arrange
+		body := exampleUser(t, "nickname", "ada")
+		var user map[string]any
+		require.NoError(t, json.Unmarshal(body, &user))
+		user["role"] = "Owner"
+		body, err := json.Marshal(user)
+		require.NoError(t, err)
+
+		// Act
+		violations := validator.ValidateResponse(getMatch, http.StatusOK, jsonHeader(contentTypeJSON), body)
+
+		// Assert
+		assert.Equal(t, []Violation{
+			{Path: "role", Code: CodeInvalidValue, Message: "Role must be one of the values of Role"},
+			{Path: "nickname", Code: CodeUnknownField, Message: "nickname is not in the specification"},
+		}, violations)
+	})
+
+	t.Run("reports unexpected content types", func(t *testing.T) {
+		violations := validator.ValidateResponse(getMatch, http.StatusOK, jsonHeader("text/html"), []byte("<html></html>"))
+		require.Len(t, violations, 1)
+		assert.Equal(t, CodeUnexpectedContentType, violations[0].Code)
+	})
+
+	t.Run("reports bodies of responses without content", func(t *testing.T) {
+		// Arrange
+		deleteMatch := validator.Match(http.MethodDelete, "/api/v1/user/"+testUserID)
+		require.NotNil(t, deleteMatch)
+
+		// Act
+		violations := validator.ValidateResponse(deleteMatch, http.StatusNoContent, http.Header{}, []byte(`{}`))
+
+		// Assert
+		require.Len(t, violations, 1)
+		assert.Equal(t, CodeUnexpectedBody, violations[0].Code)
+	})
+
+	t.Run("validates error responses against the Error object", func(t *testing.T) {
+		violations := validator.ValidateResponse(getMatch, http.StatusNotFound, jsonHeader(contentTypeJSON), []byte(`{"message": "not found"}`))
+		assert.Contains(t, violations, Violation{Path: "code", Code: CodeRequired, Message: "Code is required"})
+	})
+
+	t.Run("XML responses are only checked by their content type", func(t *testing.T) {
+		// Arrange
+		user := "User"
+		xmlMatch := &Match{Resource: "User", Endpoint: specification.Endpoint{Name: "Get", Response: specification.EndpointResponse{ContentType: specification.ContentTypeXML, StatusCode: http.StatusOK, BodyObject: &user}}}
+
+		// Act
+		violations := validator.ValidateResponse(xmlMatch, http.StatusOK, jsonHeader(specification.ContentTypeXML), []byte("<User></User>"))
+		jsonViolations := validator.ValidateResponse(xmlMatch, http.StatusOK, jsonHeader(contentTypeJSON), exampleUser(t, "", nil))
+
+		// Assert
+		assert.Empty(t, violations)
+		require.Len(t, jsonViolations, 1)
+		assert.Equal(t, CodeUnexpectedContentType, jsonViolations[0].Code)
+	})
+
+	t.Run("operational responses are not validated", func(t *testing.T) {
+		match := validator.Match(http.MethodGet, "/api/v1/healthz")
+		require.NotNil(t, match)
+		assert.Empty(t, validator.ValidateResponse(match, http.StatusOK, jsonHeader("text/plain"), []byte("ok")))
+	})
+}
@@ -0,0 +1,225 @@
+package coveragegen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/meitner-se/publicapis-gen/specification"
+)
+
+// Error messages
+const (
+	errorReadPackage  = "failed to read package"
+	errorParseFile    = "failed to parse file"
+	errorEncodeReport = "failed to encode report"
+)
+
+// File name constants
+const (
+	goFileSuffix   = ".go"
+	testFileSuffix = "_test.go"
+	testPrefix     = "Test"
+)
+
+// Markdown constants
+const (
+	markdownHeader    = "| Resource | Endpoint | Method | Path | Implemented | Tested |\n"
+	markdownSeparator = "| --- | --- | --- | --- | --- | --- |\n"
+	markdownYes       = "yes"
+	markdownNo        = "no"
+)
+
+// Package holds the declarations of a Go package that are cross-referenced with the endpoints.
+type Package struct {
+	// Methods are the names of the methods declared in the files other than tests, by the name of their receiver type
+	Methods map[string][]string
+
+	// Tests are the names of the test functions declared in the test files
+	Tests []string
+}
+
+// Report is the coverage of the endpoints of a service by an implementation and tests.
+type Report struct {
+	Service     string     `json:"service"`
+	Total       int        `json:"total"`
+	Implemented int        `json:"implemented"`
+	Tested      int        `json:"tested"`
+	Endpoints   []Endpoint `json:"endpoints"`
+}
+
+// Endpoint is the coverage of an endpoint by an implementation and tests.
+type Endpoint struct {
+	Resource    string `json:"resource"`
+	Endpoint    string `json:"endpoint"`
+	Method      string `json:"method"`
+	Path        string `json:"path"`
+	Implemented bool   `json:"implemented"`
+	Tested      bool   `json:"tested"`
+}
+
+// ReadPackage reads the method and test function declarations of the Go files in the directory,
+// without its subdirectories. The files are only parsed, so the package does not have to build.
+func ReadPackage(dir string) (Package, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return Package{}, fmt.Errorf("%s: %w", errorReadPackage, err)
+	}
+
+	pkg := Package{Methods: map[string][]string{}}
+	fileSet := token.NewFileSet()
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), goFileSuffix) {
+			continue
+		}
+
+		path := filepath.Join(dir, entry.Name())
+		file, err := parser.ParseFile(fileSet, path, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return Package{}, fmt.Errorf("%s: %w", errorParseFile, err)
+		}
+
+		isTestFile := strings.HasSuffix(entry.Name(), testFileSuffix)
+		for _, decl := range file.Decls {
+			function, ok := decl.(*ast.FuncDecl)
+			if !ok {
+				continue
+			}
+
+			switch {
+			case isTestFile && function.Recv == nil && strings.HasPrefix(function.Name.Name, testPrefix):
+				pkg.Tests = append(pkg.Tests, function.Name.Name)
+			case !isTestFile && function.Recv != nil && len(function.Recv.List) > 0:
+				receiver := receiverTypeName(function.Recv.List[0].Type)
+				pkg.Methods[receiver] = append(pkg.Methods[receiver], function.Name.Name)
+			}
+		}
+	}
+
+	return pkg, nil
+}
+
+// receiverTypeName returns the name of the type of a receiver, without its pointer and type parameters.
+func receiverTypeName(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return receiverTypeName(t.X)
+	case *ast.IndexExpr:
+		return receiverTypeName(t.X)
+	case *ast.IndexListExpr:
+		return receiverTypeName(t.X)
+	case *ast.Ident:
+		return t.Name
+	}
+	return ""
+}
+
+// NewReport cross-references the endpoints of the service with the methods of the implementation
+// and the test functions of the tests.
+func NewReport(service *specification.Service, implementation, tests Package) Report {
+	report := Report{Service: service.Name, Endpoints: []Endpoint{}}
+
+	for _, resource := range service.Resources {
+		for _, endpoint := range resource.Endpoints {
+			coverage := Endpoint{
+				Resource:    resource.Name,
+				Endpoint:    endpoint.Name,
+				Method:      strings.ToUpper(endpoint.Method),
+				Path:        service.GetBasePath() + service.GetEndpointPath(resource, endpoint),
+				Implemented: isImplemented(service, resource, endpoint, implementation),
+				Tested:      isTested(resource, endpoint, tests),
+			}
+
+			report.Total++
+			if coverage.Implemented {
+				report.Implemented++
+			}
+			if coverage.Tested {
+				report.Tested++
+			}
+
+			report.Endpoints = append(report.Endpoints, coverage)
+		}
+	}
+
+	return report
+}
+
+// isImplemented checks if the implementation has a method with the name of the endpoint. When another resource
+// has an endpoint with the same name, the name of the receiver type must also contain the name of the resource.
+func isImplemented(service *specification.Service, resource specification.Resource, endpoint specification.Endpoint, implementation Package) bool {
+	shared := slices.ContainsFunc(service.Resources, func(other specification.Resource) bool {
+		return other.Name != resource.Name && slices.ContainsFunc(other.Endpoints, func(otherEndpoint specification.Endpoint) bool {
+			return otherEndpoint.Name == endpoint.Name
+		})
+	})
+
+	for receiver, methods := range implementation.Methods {
+		if !slices.Contains(methods, endpoint.Name) {
+			continue
+		}
+		if !shared || strings.Contains(strings.ToLower(receiver), strings.ToLower(resource.Name)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isTested checks if the tests have a test function named after the resource and the endpoint,
+// as generated by testgen, or one starting with that name and an underscore.
+func isTested(resource specification.Resource, endpoint specification.Endpoint, tests Package) bool {
+	testName := testPrefix + resource.Name + endpoint.Name
+
+	return slices.ContainsFunc(tests.Tests, func(name string) bool {
+		return name == testName || strings.HasPrefix(name, testName+"_")
+	})
+}
+
+// GenerateJSON writes the report as indented JSON.
+func GenerateJSON(buf *bytes.Buffer, report Report) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("%s: %w", errorEncodeReport, err)
+	}
+
+	buf.Write(data)
+	buf.WriteString("\n")
+
+	return nil
+}
+
+// GenerateMarkdown writes the report as a Markdown summary and a table of the endpoints.
+func GenerateMarkdown(buf *bytes.Buffer, report Report) {
+	buf.WriteString(fmt.Sprintf("## %s endpoint coverage\n\n", report.Service))
+	buf.WriteString(fmt.Sprintf("%d of %d endpoints implemented, %d of %d tested.\n\n", report.Implemented, report.Total, report.Tested, report.Total))
+
+	buf.WriteString(markdownHeader)
+	buf.WriteString(markdownSeparator)
+	for _, endpoint := range report.Endpoints {
+		buf.WriteString(fmt.Sprintf("| %s | %s | %s | `%s` | %s | %s |\n",
+			endpoint.Resource,
+			endpoint.Endpoint,
+			endpoint.Method,
+			endpoint.Path,
+			yesNo(endpoint.Implemented),
+			yesNo(endpoint.Tested),
+		))
+	}
+}
+
+// yesNo returns the Markdown cell of a boolean.
+func yesNo(value bool) string {
+	if value {
+		return markdownYes
+	}
+	return markdownNo
+}
@@ -0,0 +1,187 @@
+package coveragegen
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/meitner-se/publicapis-gen/specification"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestService() *specification.Service {
+	return specification.ApplyOverlay(&specification.Service{
+		Name:    "Users API",
+		Version: "v1",
+		Resources: []specification.Resource{
+			{
+				Name:       "Users",
+				Operations: []string{"Create", "Get"},
+				Fields: []specification.ResourceField{
+					{Field: specification.Field{Name: "Name", Type: specification.FieldTypeString}, Operations: []string{"Create", "Read"}},
+				},
+			},
+			{
+				Name:       "Groups",
+				Operations: []string{"Get"},
+				Fields: []specification.ResourceField{
+					{Field: specification.Field{Name: "Name", Type: specification.FieldTypeString}, Operations: []string{"Read"}},
+				},
+			},
+		},
+	})
+}
+
+func writeFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+}
+
+func TestReadPackage(t *testing.T) {
+	t.Run("reads the methods and the test functions", func(t *testing.T) {
+		// Arrange
+		dir := t.TempDir()
+		writeFile(t, dir, "users.go", `package users
+
+type usersService struct{}
+
+func (s *usersService) Create() error { return nil }
+
+type store[T any] struct{}
+
+func (s store[T]) Get() {}
+
+func Create() {}
+`)
+		writeFile(t, dir, "users_test.go", `package users
+
+import "testing"
+
+type mockUsers struct{}
+
+func (m *mockUsers) Get() {}
+
+func TestUsersCreate(t *testing.T) {}
+
+func helper() {}
+`)
+		require.NoError(t, os.Mkdir(filepath.Join(dir, "nested"), 0755))
+		writeFile(t, filepath.Join(dir, "nested"), "nested.go", "package nested\n\nfunc (n nested) Delete() {}\n")
+
+		// Act
+		pkg, err := ReadPackage(dir)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, map[string][]string{"usersService": {"Create"}, "store": {"Get"}}, pkg.Methods, "Methods of the test files and subdirectories should be ignored")
+		assert.Equal(t, []string{"TestUsersCreate"}, pkg.Tests)
+	})
+
+	t.Run("returns error for a missing directory", func(t *testing.T) {
+		// Act
+		_, err := ReadPackage(filepath.Join(t.TempDir(), "missing"))
+
+		// Assert
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), errorReadPackage)
+	})
+
+	t.Run("returns error for a file that does not parse", func(t *testing.T) {
+		// Arrange
+		dir := t.TempDir()
+		writeFile(t, dir, "broken.go", "package broken\n\nfunc (\n")
+
+		// Act
+		_, err := ReadPackage(dir)
+
+		// Assert
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), errorParseFile)
+	})
+}
+
+func TestNewReport(t *testing.T) {
+	// Arrange
+	service := createTestService()
+	implementation := Package{Methods: map[string][]string{
+		"usersService":  {"Create", "Get"},
+		"groupsHandler": {"List"},
+	}}
+	tests := Package{Tests: []string{"TestUsersCreate", "TestGroupsGet_NotFound", "TestUsersGetAll"}}
+
+	// Act
+	report := NewReport(service, implementation, tests)
+
+	// Assert
+	assert.Equal(t, "Users API", report.Service)
+	assert.Equal(t, 3, report.Total)
+	assert.Equal(t, 2, report.Implemented)
+	assert.Equal(t, 2, report.Tested)
+	assert.Equal(t, []Endpoint{
+		{Resource: "Users", Endpoint: "Create", Method: "POST", Path: "/users-api/v1/users", Implemented: true, Tested: true},
+		{Resource: "Users", Endpoint: "Get", Method: "GET", Path: "/users-api/v1/users/{id}", Implemented: true, Tested: false},
+		{Resource: "Groups", Endpoint: "Get", Method: "GET", Path: "/users-api/v1/groups/{id}", Implemented: false, Tested: true},
+	}, report.Endpoints, "A shared endpoint name should only match the receiver of its resource, and a test name prefix should need an underscore")
+}
+
+func TestNewReport_UniqueEndpointName(t *testing.T) {
+	// Arrange
+	service := createTestService()
+	implementation := Package{Methods: map[string][]string{"service": {"Create"}}}
+
+	// Act
+	report := NewReport(service, implementation, Package{})
+
+	// Assert
+	assert.True(t, report.Endpoints[0].Implemented, "An endpoint name of a single resource should match any receiver")
+	assert.Equal(t, 1, report.Implemented)
+	assert.Equal(t, 0, report.Tested)
+}
+
+func TestGenerateMarkdown(t *testing.T) {
+	// Arrange
+	report := Report{
+		Service:     "Users API",
+		Total:       2,
+		Implemented: 1,
+		Tested:      0,
+		Endpoints: []Endpoint{
+			{Resource: "Users", Endpoint: "Create", Method: "POST", Path: "/users", Implemented: true},
+			{Resource: "Users", Endpoint: "Get", Method: "GET", Path: "/users/{id}"},
+		},
+	}
+	buf := &bytes.Buffer{}
+
+	// Act
+	GenerateMarkdown(buf, report)
+
+	// Assert
+	assert.Equal(t, `## Users API endpoint coverage
+
+1 of 2 endpoints implemented, 0 of 2 tested.
+
+| Resource | Endpoint | Method | Path | Implemented | Tested |
+| --- | --- | --- | --- | --- | --- |
+| Users | Create | POST | `+"`/users`"+` | yes | no |
+| Users | Get | GET | `+"`/users/{id}`"+` | no | no |
+`, buf.String())
+}
+
+func TestGenerateJSON(t *testing.T) {
+	// Arrange
+	report := NewReport(createTestService(), Package{}, Package{})
+	buf := &bytes.Buffer{}
+
+	// Act
+	err := GenerateJSON(buf, report)
+
+	// Assert
+	require.NoError(t, err)
+	var decoded Report
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, report, decoded)
+	assert.Contains(t, buf.String(), `"implemented": false`)
+}
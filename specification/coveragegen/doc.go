@@ -0,0 +1,32 @@
+// Package coveragegen generates a report of the endpoints of a specification that lack an implementation or a test.
+//
+// The endpoints are cross-referenced with two Go packages, read with ReadPackage without building them:
+//   - The implementation package, where an endpoint is implemented by a method with the name of the endpoint,
+//     as in the API interface of the generated server. When several resources have an endpoint with the same
+//     name, such as Get, the name of the receiver type must also contain the name of the resource.
+//   - The package of the tests, where an endpoint is tested by a test function named after the resource and
+//     the endpoint, as generated by testgen, such as TestUsersGet, or by one starting with that name and an
+//     underscore, such as TestUsersGet_NotFound.
+//
+// Only the declarations of the packages are read, so the report tells which endpoints have no implementation or
+// test at all, not whether the implementations are complete or how much of them the tests run.
+//
+// # Usage
+//
+//	implementation, err := coveragegen.ReadPackage("internal/users")
+//	if err != nil {
+//		return err
+//	}
+//
+//	tests, err := coveragegen.ReadPackage("api")
+//	if err != nil {
+//		return err
+//	}
+//
+//	report := coveragegen.NewReport(service, implementation, tests)
+//
+//	var buf bytes.Buffer
+//	coveragegen.GenerateMarkdown(&buf, report)
+//
+// GenerateJSON writes the same report as JSON, for dashboards.
+package coveragegen
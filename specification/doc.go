@@ -74,6 +74,7 @@
 //
 //   - ParseServiceFromFile(filePath) - Parse from YAML or JSON file
 //   - ParseServiceFromBytes(data, fileExtension) - Parse from byte data
+//   - ParseServiceFromBytesInDir(data, fileExtension, dir) - Parse from byte data of a file in dir
 //   - ParseServiceFromJSON(data) - Parse from JSON byte data
 //   - ParseServiceFromYAML(data) - Parse from YAML byte data
 //
@@ -83,6 +84,12 @@
 //   - Add default objects (Error, Pagination, Meta)
 //   - Generate filter objects for searchable resources
 //
+// # Shared Libraries
+//
+// The shared list of a specification names library files, relative to the specification, that declare
+// enums and objects reused by several services. Each library is validated on its own and its types are
+// merged into the service with Library set to the library name. See Service.GetSharedLibrary.
+//
 // # Error Handling
 //
 // Parsing functions return detailed error information when validation fails,
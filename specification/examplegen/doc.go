@@ -0,0 +1,33 @@
+// Package examplegen provides deterministic example generation for specification types.
+//
+// The examples are realistic, type-aware values for the fields and objects of a
+// specification.Service. They are used as a fallback by openapigen when a field has
+// no Example, and are exported for generators that need sample data, such as test
+// payloads or fake data.
+//
+// # Determinism
+//
+// Every value is drawn from a random number generator seeded with the seed of the
+// Generator and the name and type of the field. The same seed always gives the same
+// examples, independent of the order in which fields and objects are generated, so
+// regenerating a document does not produce a diff.
+//
+//	generator := examplegen.New(service, examplegen.DefaultSeed)
+//
+//	// Example of a single field, formatted like specification.Field.Example
+//	email := generator.Field(specification.Field{Name: "Email", Type: "String"}) // e.g. "emma.berg@example.com"
+//
+//	// Example of a full object, keyed by the JSON tags of the fields
+//	user := generator.Object("User")
+//
+// # Type Awareness
+//
+// Values always respect the field type: integers fit in Int32 fields, UInt values are
+// never negative, UUIDs, dates and timestamps are well-formed, decimals are strings
+// with two decimal places and enum fields get one of the enum values. Well-known field
+// names (email, name, phone, url, city, age, ...) get values that look like real data.
+//
+// Nested objects are generated recursively, with circular references cut off, and array
+// fields get a single element. Value and Object keep the Example of a field when it has
+// one, so that payloads built from them use the data of the specification.
+package examplegen
@@ -0,0 +1,291 @@
+package examplegen
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/meitner-se/publicapis-gen/specification"
+)
+
+// DefaultSeed is the seed used by the generators of the repository, so that the examples stay stable between runs
+const DefaultSeed uint64 = 20240115
+
+// Ranges of the generated values
+const (
+	maxInt        = 1000
+	maxAmount     = 1000
+	minBirthYear  = 1950
+	maxBirthYear  = 2005
+	dateRangeDays = 5 * 365
+	baseYear      = 2020
+	codeLength    = 6
+	codeAlphabet  = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	exampleDomain = "example.com"
+)
+
+// Realistic values for well-known field names
+var (
+	firstNames = []string{"Emma", "Liam", "Olivia", "Noah", "Alice", "Oscar", "Maja", "Hugo", "Elsa", "William"}
+	lastNames  = []string{"Berg", "Lindqvist", "Andersson", "Johansson", "Karlsson", "Nilsson", "Eriksson", "Larsson"}
+	cities     = []string{"Stockholm", "Gothenburg", "Malmö", "Uppsala", "Lund", "Umeå", "Örebro", "Linköping"}
+	streets    = []string{"Storgatan", "Drottninggatan", "Kungsgatan", "Sveavägen", "Vasagatan", "Parkvägen"}
+	countries  = []string{"SE", "NO", "DK", "FI", "DE", "NL"}
+	words      = []string{"alpha", "bravo", "delta", "echo", "nova", "orbit", "pixel", "quartz", "river", "summit"}
+	sentences  = []string{
+		"A short description of the item.",
+		"Created as part of the onboarding.",
+		"Updated after the yearly review.",
+		"Shared with the rest of the team.",
+	}
+)
+
+// Generator generates deterministic examples for the fields and objects of a service.
+type Generator struct {
+	service *specification.Service
+	seed    uint64
+}
+
+// New creates a Generator for the service, the same seed always gives the same examples.
+func New(service *specification.Service, seed uint64) *Generator {
+	if service == nil {
+		service = &specification.Service{}
+	}
+
+	return &Generator{
+		service: service,
+		seed:    seed,
+	}
+}
+
+// Field returns an example for a primitive or enum field, formatted like specification.Field.Example.
+// Returns an empty string for object fields and unknown types.
+func (g *Generator) Field(field specification.Field) string {
+	r := g.random(field)
+	name := strings.ToLower(field.Name)
+
+	switch field.Type {
+	case specification.FieldTypeString:
+		return stringExample(r, name)
+	case specification.FieldTypeInt, specification.FieldTypeInt32, specification.FieldTypeUInt:
+		// Values are positive and small enough for every integer type
+		return strconv.Itoa(intExample(r, name))
+	case specification.FieldTypeFloat64:
+		return strconv.FormatFloat(floatExample(r, name), 'f', -1, 64)
+	case specification.FieldTypeDecimal:
+		return fmt.Sprintf("%d.%02d", 1+r.IntN(maxAmount), r.IntN(100))
+	case specification.FieldTypeBool:
+		return strconv.FormatBool(r.IntN(2) == 1)
+	case specification.FieldTypeUUID:
+		return uuidExample(r)
+	case specification.FieldTypeDate:
+		return dateExample(r, name).Format(time.DateOnly)
+	case specification.FieldTypeTimestamp:
+		timestamp := time.Date(baseYear, time.January, 1, 0, 0, 0, 0, time.UTC)
+		return timestamp.Add(time.Duration(r.IntN(dateRangeDays*24*60*60)) * time.Second).Format(time.RFC3339)
+	}
+
+	for _, enum := range g.service.Enums {
+		if enum.Name == field.Type && len(enum.Values) > 0 {
+			return enum.Values[r.IntN(len(enum.Values))].GetValue()
+		}
+	}
+
+	return ""
+}
+
+// Value returns an example for the field as a typed value: int64, float64, bool or string for primitive
+// and enum fields (int64 for integer-backed enums), map[string]any for objects and []any for arrays. The Example of a field is used when it has one.
+// Returns nil if no example can be generated.
+func (g *Generator) Value(field specification.Field) any {
+	return g.value(field, map[string]bool{})
+}
+
+// Object returns an example of the object with the given name, keyed by the JSON tags of the fields,
+// using the Examples of the fields that have one. Returns nil if the object does not exist.
+func (g *Generator) Object(name string) map[string]any {
+	return g.object(name, map[string]bool{})
+}
+
+// value returns the typed example of a field, with circular reference protection for objects.
+func (g *Generator) value(field specification.Field, visited map[string]bool) any {
+	var value any
+	if g.service.HasObject(field.Type) {
+		object := g.object(field.Type, visited)
+		if object == nil {
+			return nil
+		}
+		value = object
+	} else {
+		example := field.Example
+		if example == "" {
+			example = g.Field(field)
+		}
+		if example == "" {
+			return nil
+		}
+		value = typedValue(g.valueType(field), example)
+	}
+
+	if field.IsArray() {
+		return []any{value}
+	}
+
+	return value
+}
+
+// object returns the example of an object, or nil if it does not exist or is already being generated.
+func (g *Generator) object(name string, visited map[string]bool) map[string]any {
+	object := g.service.GetObject(name)
+	if object == nil || visited[name] {
+		return nil
+	}
+
+	visited[name] = true
+	defer delete(visited, name)
+
+	result := make(map[string]any, len(object.Fields))
+	for _, field := range object.Fields {
+		if value := g.value(field, visited); value != nil {
+			result[field.TagJSON()] = value
+		}
+	}
+
+	return result
+}
+
+// random returns the random number generator of a field, seeded by the seed of the generator and the field.
+func (g *Generator) random(field specification.Field) *rand.Rand {
+	hash := fnv.New64a()
+	hash.Write([]byte(field.Name))
+	hash.Write([]byte{0})
+	hash.Write([]byte(field.Type))
+
+	return rand.New(rand.NewPCG(g.seed, hash.Sum64()))
+}
+
+// valueType returns the type of the JSON value of a field, the Int type for integer-backed enums.
+func (g *Generator) valueType(field specification.Field) string {
+	if enum := g.service.GetEnum(field.Type); enum != nil && enum.IsInt() {
+		return specification.FieldTypeInt
+	}
+
+	return field.Type
+}
+
+// typedValue converts an example to the Go type it has in JSON.
+func typedValue(fieldType, example string) any {
+	switch fieldType {
+	case specification.FieldTypeInt, specification.FieldTypeInt32, specification.FieldTypeUInt:
+		if value, err := strconv.ParseInt(example, 10, 64); err == nil {
+			return value
+		}
+	case specification.FieldTypeFloat64:
+		if value, err := strconv.ParseFloat(example, 64); err == nil {
+			return value
+		}
+	case specification.FieldTypeBool:
+		if value, err := strconv.ParseBool(example); err == nil {
+			return value
+		}
+	}
+
+	return example
+}
+
+// stringExample returns a realistic string for well-known field names.
+func stringExample(r *rand.Rand, name string) string {
+	firstName := pick(r, firstNames)
+	lastName := pick(r, lastNames)
+
+	switch {
+	case strings.Contains(name, "email"):
+		return strings.ToLower(firstName+"."+lastName) + "@" + exampleDomain
+	case strings.Contains(name, "firstname"), strings.Contains(name, "givenname"):
+		return firstName
+	case strings.Contains(name, "lastname"), strings.Contains(name, "surname"), strings.Contains(name, "familyname"):
+		return lastName
+	case strings.Contains(name, "phone"), strings.Contains(name, "mobile"):
+		return fmt.Sprintf("+46 70 %03d %02d %02d", r.IntN(1000), r.IntN(100), r.IntN(100))
+	case strings.Contains(name, "url"), strings.Contains(name, "website"), strings.Contains(name, "link"):
+		return "https://" + exampleDomain + "/" + pick(r, words)
+	case strings.Contains(name, "city"):
+		return pick(r, cities)
+	case strings.Contains(name, "street"), strings.Contains(name, "address"):
+		return fmt.Sprintf("%s %d", pick(r, streets), 1+r.IntN(99))
+	case strings.Contains(name, "zip"), strings.Contains(name, "postal"):
+		return fmt.Sprintf("%03d %02d", 100+r.IntN(900), r.IntN(100))
+	case strings.Contains(name, "country"):
+		return pick(r, countries)
+	case strings.Contains(name, "description"), strings.Contains(name, "comment"), strings.Contains(name, "note"):
+		return pick(r, sentences)
+	case strings.Contains(name, "code"):
+		code := make([]byte, codeLength)
+		for i := range code {
+			code[i] = codeAlphabet[r.IntN(len(codeAlphabet))]
+		}
+		return string(code)
+	case strings.Contains(name, "name"):
+		return firstName + " " + lastName
+	default:
+		return pick(r, words) + "-" + strconv.Itoa(1+r.IntN(maxInt))
+	}
+}
+
+// intExample returns a realistic, non-negative integer for well-known field names.
+func intExample(r *rand.Rand, name string) int {
+	switch {
+	case strings.Contains(name, "percent"):
+		return r.IntN(101)
+	case name == "age" || strings.HasSuffix(name, "age") && !strings.HasSuffix(name, "page"):
+		return 18 + r.IntN(63)
+	case strings.Contains(name, "year"):
+		return baseYear + r.IntN(10)
+	default:
+		return 1 + r.IntN(maxInt)
+	}
+}
+
+// floatExample returns a realistic float with at most two decimals for well-known field names.
+func floatExample(r *rand.Rand, name string) float64 {
+	switch {
+	case strings.Contains(name, "latitude"):
+		return float64(r.IntN(18000)-9000) / 100
+	case strings.Contains(name, "longitude"):
+		return float64(r.IntN(36000)-18000) / 100
+	case strings.Contains(name, "percent"), strings.Contains(name, "rate"):
+		return float64(r.IntN(10001)) / 100
+	default:
+		return float64(r.IntN(maxAmount*100)) / 100
+	}
+}
+
+// dateExample returns a date within the last years, or a birth date for birth fields.
+func dateExample(r *rand.Rand, name string) time.Time {
+	if strings.Contains(name, "birth") {
+		year := minBirthYear + r.IntN(maxBirthYear-minBirthYear)
+		return time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, r.IntN(365))
+	}
+
+	return time.Date(baseYear, time.January, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, r.IntN(dateRangeDays))
+}
+
+// uuidExample returns a version 4 UUID drawn from the random number generator.
+func uuidExample(r *rand.Rand) string {
+	var b [16]byte
+	for i := range b {
+		b[i] = byte(r.IntN(256))
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// pick returns a random element of the values.
+func pick(r *rand.Rand, values []string) string {
+	return values[r.IntN(len(values))]
+}
@@ -0,0 +1,221 @@
+package examplegen
+
+import (
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/meitner-se/publicapis-gen/specification"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ============================================================================
+// Field Tests
+// ============================================================================
+
+func TestGenerator_Field(t *testing.T) {
+	// Arrange
+	service := &specification.Service{
+		Enums: []specification.Enum{
+			{
+				Name:   "Status",
+				Values: []specification.EnumValue{{Name: "Active"}, {Name: "Inactive"}},
+			},
+		},
+	}
+	generator := New(service, DefaultSeed)
+	uuidPattern := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+	t.Run("Int fits in Int32", func(t *testing.T) {
+		for _, fieldType := range []string{specification.FieldTypeInt, specification.FieldTypeInt32, specification.FieldTypeUInt} {
+			value, err := strconv.ParseInt(generator.Field(specification.Field{Name: "Count", Type: fieldType}), 10, 32)
+			require.NoError(t, err, "Type %s should give a parseable Int32", fieldType)
+			assert.GreaterOrEqual(t, value, int64(0), "Type %s should not be negative", fieldType)
+		}
+	})
+
+	t.Run("Float64", func(t *testing.T) {
+		_, err := strconv.ParseFloat(generator.Field(specification.Field{Name: "Score", Type: specification.FieldTypeFloat64}), 64)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Decimal", func(t *testing.T) {
+		assert.Regexp(t, `^\d+\.\d{2}$`, generator.Field(specification.Field{Name: "Price", Type: specification.FieldTypeDecimal}))
+	})
+
+	t.Run("Bool", func(t *testing.T) {
+		_, err := strconv.ParseBool(generator.Field(specification.Field{Name: "Active", Type: specification.FieldTypeBool}))
+		assert.NoError(t, err)
+	})
+
+	t.Run("UUID", func(t *testing.T) {
+		assert.Regexp(t, uuidPattern, generator.Field(specification.Field{Name: "ID", Type: specification.FieldTypeUUID}))
+	})
+
+	t.Run("Date", func(t *testing.T) {
+		_, err := time.Parse(time.DateOnly, generator.Field(specification.Field{Name: "BirthDate", Type: specification.FieldTypeDate}))
+		assert.NoError(t, err)
+	})
+
+	t.Run("Timestamp", func(t *testing.T) {
+		_, err := time.Parse(time.RFC3339, generator.Field(specification.Field{Name: "CreatedAt", Type: specification.FieldTypeTimestamp}))
+		assert.NoError(t, err)
+	})
+
+	t.Run("Email", func(t *testing.T) {
+		assert.Regexp(t, `^[a-z]+\.[a-z]+@example\.com$`, generator.Field(specification.Field{Name: "Email", Type: specification.FieldTypeString}))
+	})
+
+	t.Run("Enum", func(t *testing.T) {
+		assert.Contains(t, []string{"Active", "Inactive"}, generator.Field(specification.Field{Name: "Status", Type: "Status"}))
+	})
+
+	t.Run("unknown type", func(t *testing.T) {
+		assert.Empty(t, generator.Field(specification.Field{Name: "Unknown", Type: "Unknown"}))
+	})
+}
+
+func TestGenerator_Field_Deterministic(t *testing.T) {
+	// Arrange
+	fields := []specification.Field{
+		{Name: "Name", Type: specification.FieldTypeString},
+		{Name: "ID", Type: specification.FieldTypeUUID},
+		{Name: "CreatedAt", Type: specification.FieldTypeTimestamp},
+	}
+
+	// Act
+	first := New(nil, DefaultSeed)
+	second := New(nil, DefaultSeed)
+
+	// Assert
+	for _, field := range fields {
+		assert.Equal(t, first.Field(field), second.Field(field), "Same seed should give the same example for %s", field.Name)
+	}
+
+	// Generating in reverse order should not change the examples
+	for i := len(fields) - 1; i >= 0; i-- {
+		assert.Equal(t, first.Field(fields[i]), second.Field(fields[i]))
+	}
+
+	assert.NotEqual(t, first.Field(fields[1]), New(nil, DefaultSeed+1).Field(fields[1]), "Different seeds should give different examples")
+}
+
+// ============================================================================
+// Object Tests
+// ============================================================================
+
+func TestGenerator_Object(t *testing.T) {
+	// Arrange
+	service := &specification.Service{
+		Objects: []specification.Object{
+			{
+				Name: "Address",
+				Fields: []specification.Field{
+					{Name: "City", Type: specification.FieldTypeString},
+				},
+			},
+			{
+				Name: "Person",
+				Fields: []specification.Field{
+					{Name: "Age", Type: specification.FieldTypeInt},
+					{Name: "Address", Type: "Address"},
+					{Name: "Tags", Type: specification.FieldTypeString, Modifiers: []string{specification.ModifierArray}},
+					{Name: "Parent", Type: "Person"},
+				},
+			},
+		},
+	}
+	generator := New(service, DefaultSeed)
+
+	// Act
+	person := generator.Object("Person")
+
+	// Assert
+	require.NotNil(t, person)
+	assert.IsType(t, int64(0), person["age"])
+	assert.Contains(t, person["address"], "city")
+	assert.Len(t, person["tags"], 1)
+	assert.NotContains(t, person, "parent", "Circular references should be cut off")
+	assert.Equal(t, person, generator.Object("Person"), "Objects should be deterministic")
+	assert.Nil(t, generator.Object("Unknown"))
+}
+
+func TestGenerator_ObjectWithNestedFilters(t *testing.T) {
+	// Arrange
+	service := specification.ApplyOverlay(&specification.Service{
+		Name:           "TestService",
+		FilterMaxDepth: 2,
+		Resources: []specification.Resource{
+			{
+				Name:       "Users",
+				Operations: []string{specification.OperationSearch},
+				Fields: []specification.ResourceField{
+					{Field: specification.Field{Name: "Name", Type: specification.FieldTypeString}, Operations: []string{specification.OperationRead}},
+				},
+			},
+		},
+	})
+	generator := New(service, DefaultSeed)
+
+	// Act
+	filter := generator.Object("UsersFilter")
+
+	// Assert
+	require.NotNil(t, filter)
+	assert.IsType(t, true, filter["notCondition"])
+	assert.Contains(t, filter["equals"], "name")
+	assert.NotContains(t, filter, "nestedFilters", "Recursive nested filters should be cut off")
+}
+
+func TestGenerator_ObjectWithExamples(t *testing.T) {
+	// Arrange
+	service := &specification.Service{
+		Objects: []specification.Object{
+			{
+				Name: "Person",
+				Fields: []specification.Field{
+					{Name: "Age", Type: specification.FieldTypeInt, Example: "42"},
+					{Name: "Name", Type: specification.FieldTypeString, Example: "Ada Lovelace"},
+					{Name: "Tags", Type: specification.FieldTypeString, Modifiers: []string{specification.ModifierArray}, Example: "math"},
+				},
+			},
+		},
+	}
+
+	// Act
+	person := New(service, DefaultSeed).Object("Person")
+
+	// Assert
+	assert.Equal(t, int64(42), person["age"], "The example of the field should be typed")
+	assert.Equal(t, "Ada Lovelace", person["name"])
+	assert.Equal(t, []any{"math"}, person["tags"])
+}
+
+func TestGenerator_ValueWithIntEnums(t *testing.T) {
+	// Arrange
+	service := &specification.Service{
+		Enums: []specification.Enum{
+			{
+				Name:     "Priority",
+				EnumType: specification.EnumTypeInt,
+				Values:   []specification.EnumValue{{Name: "Low", Value: "1"}, {Name: "High", Value: "2"}},
+			},
+			{
+				Name:   "Status",
+				Values: []specification.EnumValue{{Name: "Active"}, {Name: "Inactive"}},
+			},
+		},
+	}
+	generator := New(service, DefaultSeed)
+
+	// Act & Assert
+	assert.Contains(t, []any{int64(1), int64(2)}, generator.Value(specification.Field{Name: "Priority", Type: "Priority"}),
+		"Integer-backed enums should give integers")
+	assert.Equal(t, int64(2), generator.Value(specification.Field{Name: "Priority", Type: "Priority", Example: "2"}),
+		"The example of an integer-backed enum should be an integer")
+	assert.Equal(t, []any{int64(1)}, generator.Value(specification.Field{Name: "Priorities", Type: "Priority", Modifiers: []string{specification.ModifierArray}, Example: "1"}))
+	assert.Contains(t, []any{"Active", "Inactive"}, generator.Value(specification.Field{Name: "Status", Type: "Status"}),
+		"String enums should give strings")
+}
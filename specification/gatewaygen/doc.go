@@ -0,0 +1,31 @@
+// Package gatewaygen generates Kubernetes Gateway API route manifests from a specification.
+//
+// The routes are generated from the same endpoints as the generated server, so the configuration of the
+// gateway cannot drift from the API:
+//   - An HTTPRoute per resource, named after the service and the resource, e.g. users-api-user
+//   - An HTTPRoute for the operational endpoints (health, readiness and version), when the service has any
+//   - A match per endpoint, by its method and full path under the base path of the service, including the
+//     paths of the parents of sub-resources and the tenant prefix of path tenancy
+//   - Paths without parameters are matched exactly, paths with parameters with a regular expression
+//   - The hostnames of the servers of the service, a hostname per enum value of their variables, leaving out
+//     servers with variables without an enum, IP addresses and localhost
+//
+// Development resources are left out, like in the OpenAPI document. The matches are split into rules and
+// routes within the limits of the Gateway API, 64 matches per rule and 16 rules per route.
+//
+// # Usage
+//
+//	var buf bytes.Buffer
+//	if err := gatewaygen.GenerateRoutes(&buf, service); err != nil {
+//	    log.Fatal(err)
+//	}
+//
+// The routes attach to the Gateway named "gateway" and send the requests to port 80 of the Kubernetes Service
+// named after the service in kebab-case. Options overrides them:
+//
+//	err := gatewaygen.GenerateRoutesWithOptions(&buf, service, gatewaygen.Options{
+//	    Gateway: "public-gateway",
+//	    Backend: "users-api",
+//	    Port:    8080,
+//	})
+package gatewaygen
@@ -0,0 +1,298 @@
+package gatewaygen
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"net/url"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/meitner-se/publicapis-gen/specification"
+	yaml "gopkg.in/yaml.v3"
+)
+
+// Error messages
+const (
+	errorInvalidService = "invalid service"
+	errorMarshalRoute   = "failed to marshal route"
+)
+
+// Gateway API constants
+const (
+	apiVersion            = "gateway.networking.k8s.io/v1"
+	kindHTTPRoute         = "HTTPRoute"
+	pathMatchExact        = "Exact"
+	pathMatchRegex        = "RegularExpression"
+	managedByLabel        = "app.kubernetes.io/managed-by"
+	managedByValue        = "publicapis-gen"
+	maxMatchesPerRule     = 64
+	maxRulesPerRoute      = 16
+	maxNameLength         = 63
+	operationalRouteName  = "operational"
+	documentSeparator     = "---\n"
+	yamlIndent            = 2
+	pathParamReplacement  = "[^/]+"
+	defaultGatewayName    = "gateway"
+	defaultBackendPort    = 80
+	localhostHostname     = "localhost"
+	pathSeparator         = "/"
+	routeNameSeparator    = "-"
+	routeNamePartTemplate = "%s-%d"
+)
+
+// pathParamPattern matches the path parameters of an endpoint path, e.g. {id}
+var pathParamPattern = regexp.MustCompile(`\{[^}]+\}`)
+
+// invalidNameCharacters matches the characters that are not allowed in the names of Kubernetes objects
+var invalidNameCharacters = regexp.MustCompile(`[^a-z0-9]+`)
+
+// Options configures the generated routes.
+type Options struct {
+	// Gateway is the name of the Gateway the routes attach to, defaults to "gateway"
+	Gateway string
+
+	// Backend is the name of the Kubernetes Service serving the API, defaults to the service name in kebab-case
+	Backend string
+
+	// Port of the Kubernetes Service serving the API, defaults to 80
+	Port int
+}
+
+// httpRoute is a Gateway API HTTPRoute, reduced to the fields the generator sets.
+type httpRoute struct {
+	APIVersion string    `yaml:"apiVersion"`
+	Kind       string    `yaml:"kind"`
+	Metadata   metadata  `yaml:"metadata"`
+	Spec       routeSpec `yaml:"spec"`
+}
+
+type metadata struct {
+	Name   string            `yaml:"name"`
+	Labels map[string]string `yaml:"labels,omitempty"`
+}
+
+type routeSpec struct {
+	ParentRefs []parentRef `yaml:"parentRefs"`
+	Hostnames  []string    `yaml:"hostnames,omitempty"`
+	Rules      []rule      `yaml:"rules"`
+}
+
+type parentRef struct {
+	Name string `yaml:"name"`
+}
+
+type rule struct {
+	Matches     []match      `yaml:"matches"`
+	BackendRefs []backendRef `yaml:"backendRefs"`
+}
+
+type match struct {
+	Path   pathMatch `yaml:"path"`
+	Method string    `yaml:"method"`
+}
+
+type pathMatch struct {
+	Type  string `yaml:"type"`
+	Value string `yaml:"value"`
+}
+
+type backendRef struct {
+	Name string `yaml:"name"`
+	Port int    `yaml:"port"`
+}
+
+// GenerateRoutes writes the Gateway API HTTPRoutes of the service as YAML to the buffer, with the default options.
+func GenerateRoutes(buf *bytes.Buffer, service *specification.Service) error {
+	return GenerateRoutesWithOptions(buf, service, Options{})
+}
+
+// GenerateRoutesWithOptions writes the Gateway API HTTPRoutes of the service as YAML to the buffer: an HTTPRoute
+// per resource, and one for the operational endpoints, matching the method and full path of every endpoint the
+// generated server serves. Development resources are left out, like in the OpenAPI document.
+func GenerateRoutesWithOptions(buf *bytes.Buffer, service *specification.Service, options Options) error {
+	if service == nil {
+		return fmt.Errorf("%s: service cannot be nil", errorInvalidService)
+	}
+
+	options = options.withDefaults(service)
+	serviceName := kubernetesName(service.Naming.FormatPathName(service.Name))
+	hostnames := getHostnames(service)
+
+	var routes []httpRoute
+	for _, resource := range service.Resources {
+		if resource.Development {
+			continue
+		}
+
+		matches := make([]match, 0, len(resource.Endpoints))
+		for _, endpoint := range resource.Endpoints {
+			matches = append(matches, createMatch(service, endpoint.Method, service.Tenancy.GetPathPrefix()+service.GetEndpointPath(resource, endpoint)))
+		}
+
+		name := kubernetesName(serviceName + routeNameSeparator + service.Naming.FormatPathName(resource.Name))
+		routes = append(routes, createRoutes(name, hostnames, matches, options)...)
+	}
+
+	if service.Operational.HasEndpoints() {
+		var matches []match
+		if service.Operational.HasHealth() {
+			matches = append(matches, createMatch(service, "GET", specification.OperationalPathHealth))
+		}
+		if service.Operational.HasReadiness() {
+			matches = append(matches, createMatch(service, "GET", specification.OperationalPathReadiness))
+		}
+		if service.Operational.HasVersion() {
+			matches = append(matches, createMatch(service, "GET", specification.OperationalPathVersion))
+		}
+
+		name := kubernetesName(serviceName + routeNameSeparator + operationalRouteName)
+		routes = append(routes, createRoutes(name, hostnames, matches, options)...)
+	}
+
+	// The encoder separates the routes as YAML documents, indented like Kubernetes manifests
+	encoder := yaml.NewEncoder(buf)
+	encoder.SetIndent(yamlIndent)
+	for _, route := range routes {
+		if err := encoder.Encode(route); err != nil {
+			return fmt.Errorf("%s: %w", errorMarshalRoute, err)
+		}
+	}
+
+	if err := encoder.Close(); err != nil {
+		return fmt.Errorf("%s: %w", errorMarshalRoute, err)
+	}
+
+	return nil
+}
+
+// withDefaults returns the options with the defaults of the service for the options that are not set.
+func (o Options) withDefaults(service *specification.Service) Options {
+	if o.Gateway == "" {
+		o.Gateway = defaultGatewayName
+	}
+	if o.Backend == "" {
+		o.Backend = kubernetesName(service.Naming.FormatPathName(service.Name))
+	}
+	if o.Port == 0 {
+		o.Port = defaultBackendPort
+	}
+	return o
+}
+
+// createRoutes creates the routes with the matches, split into rules and routes within the limits of the Gateway
+// API. The routes are suffixed with their number when the matches do not fit in one.
+func createRoutes(name string, hostnames []string, matches []match, options Options) []httpRoute {
+	if len(matches) == 0 {
+		return nil
+	}
+
+	var rules []rule
+	for start := 0; start < len(matches); start += maxMatchesPerRule {
+		end := min(start+maxMatchesPerRule, len(matches))
+		rules = append(rules, rule{
+			Matches:     matches[start:end],
+			BackendRefs: []backendRef{{Name: options.Backend, Port: options.Port}},
+		})
+	}
+
+	var routes []httpRoute
+	for start := 0; start < len(rules); start += maxRulesPerRoute {
+		end := min(start+maxRulesPerRoute, len(rules))
+		routeName := name
+		if len(rules) > maxRulesPerRoute {
+			routeName = kubernetesName(fmt.Sprintf(routeNamePartTemplate, name, start/maxRulesPerRoute+1))
+		}
+
+		routes = append(routes, httpRoute{
+			APIVersion: apiVersion,
+			Kind:       kindHTTPRoute,
+			Metadata: metadata{
+				Name:   routeName,
+				Labels: map[string]string{managedByLabel: managedByValue},
+			},
+			Spec: routeSpec{
+				ParentRefs: []parentRef{{Name: options.Gateway}},
+				Hostnames:  hostnames,
+				Rules:      rules[start:end],
+			},
+		})
+	}
+
+	return routes
+}
+
+// createMatch creates the match of an endpoint, by its method and the full path under the base path of the
+// service. Paths with parameters are matched with a regular expression, the others exactly.
+func createMatch(service *specification.Service, method, endpointPath string) match {
+	fullPath := joinPaths(service.GetBasePath(), endpointPath)
+
+	if !pathParamPattern.MatchString(fullPath) {
+		return match{Path: pathMatch{Type: pathMatchExact, Value: fullPath}, Method: strings.ToUpper(method)}
+	}
+
+	var pattern strings.Builder
+	pattern.WriteString("^")
+	last := 0
+	for _, location := range pathParamPattern.FindAllStringIndex(fullPath, -1) {
+		pattern.WriteString(regexp.QuoteMeta(fullPath[last:location[0]]))
+		pattern.WriteString(pathParamReplacement)
+		last = location[1]
+	}
+	pattern.WriteString(regexp.QuoteMeta(fullPath[last:]))
+	pattern.WriteString("$")
+
+	return match{Path: pathMatch{Type: pathMatchRegex, Value: pattern.String()}, Method: strings.ToUpper(method)}
+}
+
+// joinPaths joins the base path and the path of an endpoint like the router of the generated server,
+// keeping a trailing slash of the endpoint path.
+func joinPaths(basePath, endpointPath string) string {
+	joined := path.Join(pathSeparator, basePath, endpointPath)
+	if strings.HasSuffix(endpointPath, pathSeparator) && !strings.HasSuffix(joined, pathSeparator) {
+		return joined + pathSeparator
+	}
+	return joined
+}
+
+// getHostnames returns the hostnames of the servers of the service, in order and without duplicates. The servers
+// with variables get a hostname per enum value. Servers with variables without an enum, IP addresses or localhost
+// are left out, since they can not be matched by a route.
+func getHostnames(service *specification.Service) []string {
+	var hostnames []string
+	for _, server := range service.Servers {
+		for _, serverURL := range server.GetEnumeratedURLs() {
+			if strings.Contains(serverURL, "{") {
+				continue
+			}
+
+			parsed, err := url.Parse(serverURL)
+			if err != nil {
+				continue
+			}
+
+			hostname := strings.ToLower(parsed.Hostname())
+			if hostname == "" || hostname == localhostHostname || net.ParseIP(hostname) != nil {
+				continue
+			}
+
+			if !slices.Contains(hostnames, hostname) {
+				hostnames = append(hostnames, hostname)
+			}
+		}
+	}
+	return hostnames
+}
+
+// kubernetesName returns the name as the name of a Kubernetes object: lowercase letters, digits and dashes,
+// at most 63 characters, where the letters with diacritics are transliterated.
+func kubernetesName(name string) string {
+	name = invalidNameCharacters.ReplaceAllString(strings.ToLower(specification.Transliterate(name)), routeNameSeparator)
+	name = strings.Trim(name, routeNameSeparator)
+	if len(name) > maxNameLength {
+		name = strings.TrimRight(name[:maxNameLength], routeNameSeparator)
+	}
+	return name
+}
@@ -0,0 +1,193 @@
+package gatewaygen
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/meitner-se/publicapis-gen/specification"
+	"github.com/stretchr/testify/assert"
+	yaml "gopkg.in/yaml.v3"
+)
+
+// createTestService creates a service with a server for each kind of hostname the routes expand, deduplicate or
+// skip, a sub-resource, a development resource and a health endpoint.
+func createTestService() *specification.Service {
+	return specification.ApplyDefaultOverlays(&specification.Service{
+		Name:     "Users API",
+		Version:  "1.0.0",
+		BasePath: "/api/v1",
+		Servers: []specification.ServiceServer{
+			{URL: "https://api.example.com"},
+			{URL: "https://API.example.com/v2"},
+			{URL: "https://{region}.example.com"},
+			{URL: "https://{region}.api.example.com", Variables: []specification.ServerVariable{{Name: "region", Default: "eu", Enum: []string{"eu", "us"}}}},
+			{URL: "https://{tenant}.api.example.com", Variables: []specification.ServerVariable{{Name: "tenant", Default: "acme"}}},
+			{URL: "http://localhost:8080"},
+			{URL: "http://127.0.0.1:8080"},
+		},
+		Operational: &specification.OperationalEndpoints{Health: true},
+		Resources: []specification.Resource{
+			{
+				Name:        "User",
+				Description: "Users",
+				Operations:  []string{specification.OperationGet, specification.OperationCreate},
+				Fields: []specification.ResourceField{
+					{Field: specification.Field{Name: "Name", Type: specification.FieldTypeString}, Operations: []string{specification.OperationCreate, specification.OperationRead}},
+				},
+			},
+			{
+				Name:        "Comment",
+				Description: "Comments of a user",
+				Parent:      "User",
+				Operations:  []string{specification.OperationGet},
+				Fields: []specification.ResourceField{
+					{Field: specification.Field{Name: "Text", Type: specification.FieldTypeString}, Operations: []string{specification.OperationRead}},
+				},
+			},
+			{
+				Name:        "Draft",
+				Description: "Drafts",
+				Development: true,
+				Operations:  []string{specification.OperationGet},
+			},
+		},
+	})
+}
+
+// decodeRoutes decodes the routes of the generated YAML documents.
+func decodeRoutes(t *testing.T, data []byte) []httpRoute {
+	t.Helper()
+
+	var routes []httpRoute
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		var route httpRoute
+		if err := decoder.Decode(&route); err != nil {
+			break
+		}
+		routes = append(routes, route)
+	}
+	return routes
+}
+
+func TestGenerateRoutes(t *testing.T) {
+	t.Run("generates a route per resource and for the operational endpoints", func(t *testing.T) {
+		// Arrange
+		service := createTestService()
+		var buf bytes.Buffer
+
+		// Act
+		err := GenerateRoutes(&buf, service)
+
+		// Assert
+		assert.NoError(t, err)
+		routes := decodeRoutes(t, buf.Bytes())
+		assert.Len(t, routes, 3, "Development resources should not get a route")
+		assert.Equal(t, "users-api-user", routes[0].Metadata.Name)
+		assert.Equal(t, "users-api-comment", routes[1].Metadata.Name)
+		assert.Equal(t, "users-api-operational", routes[2].Metadata.Name)
+
+		user := routes[0]
+		assert.Equal(t, apiVersion, user.APIVersion)
+		assert.Equal(t, kindHTTPRoute, user.Kind)
+		assert.Equal(t, managedByValue, user.Metadata.Labels[managedByLabel])
+		assert.Equal(t, []parentRef{{Name: defaultGatewayName}}, user.Spec.ParentRefs)
+		assert.Equal(t, []string{"api.example.com", "eu.api.example.com", "us.api.example.com"}, user.Spec.Hostnames, "Hostnames should be deduplicated, expand enum variables and skip other variables, localhost and IP addresses")
+		assert.Len(t, user.Spec.Rules, 1)
+		assert.Equal(t, []backendRef{{Name: "users-api", Port: defaultBackendPort}}, user.Spec.Rules[0].BackendRefs)
+		assert.Contains(t, user.Spec.Rules[0].Matches, match{Path: pathMatch{Type: pathMatchExact, Value: "/api/v1/user"}, Method: "POST"})
+		assert.Contains(t, user.Spec.Rules[0].Matches, match{Path: pathMatch{Type: pathMatchRegex, Value: `^/api/v1/user/[^/]+$`}, Method: "GET"})
+
+		comment := routes[1]
+		assert.Contains(t, comment.Spec.Rules[0].Matches, match{Path: pathMatch{Type: pathMatchRegex, Value: `^/api/v1/user/[^/]+/comment/[^/]+$`}, Method: "GET"}, "Sub-resource paths should include their parents")
+
+		operational := routes[2]
+		assert.Equal(t, []match{{Path: pathMatch{Type: pathMatchExact, Value: "/api/v1" + specification.OperationalPathHealth}, Method: "GET"}}, operational.Spec.Rules[0].Matches)
+	})
+
+	t.Run("options override the gateway and backend", func(t *testing.T) {
+		// Arrange
+		service := createTestService()
+		var buf bytes.Buffer
+
+		// Act
+		err := GenerateRoutesWithOptions(&buf, service, Options{Gateway: "public", Backend: "users", Port: 8080})
+
+		// Assert
+		assert.NoError(t, err)
+		route := decodeRoutes(t, buf.Bytes())[0]
+		assert.Equal(t, []parentRef{{Name: "public"}}, route.Spec.ParentRefs)
+		assert.Equal(t, []backendRef{{Name: "users", Port: 8080}}, route.Spec.Rules[0].BackendRefs)
+	})
+
+	t.Run("output is deterministic", func(t *testing.T) {
+		// Arrange
+		service := createTestService()
+		var first, second bytes.Buffer
+
+		// Act
+		assert.NoError(t, GenerateRoutes(&first, service))
+		assert.NoError(t, GenerateRoutes(&second, service))
+
+		// Assert
+		assert.Equal(t, first.String(), second.String())
+		assert.Equal(t, 2, strings.Count(first.String(), documentSeparator), "Routes should be separate YAML documents")
+	})
+
+	t.Run("nil service returns error", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := GenerateRoutes(&buf, nil)
+		assert.ErrorContains(t, err, errorInvalidService)
+	})
+}
+
+func TestCreateRoutes(t *testing.T) {
+	t.Run("splits the matches within the limits of the Gateway API", func(t *testing.T) {
+		// Arrange
+		matches := make([]match, maxMatchesPerRule*maxRulesPerRoute+1)
+		for i := range matches {
+			matches[i] = match{Path: pathMatch{Type: pathMatchExact, Value: fmt.Sprintf("/path-%d", i)}, Method: "GET"}
+		}
+
+		// Act
+		routes := createRoutes("users-api-user", nil, matches, Options{Gateway: "gateway", Backend: "users-api", Port: 80})
+
+		// Assert
+		assert.Len(t, routes, 2)
+		assert.Equal(t, "users-api-user-1", routes[0].Metadata.Name)
+		assert.Equal(t, "users-api-user-2", routes[1].Metadata.Name)
+		assert.Len(t, routes[0].Spec.Rules, maxRulesPerRoute)
+		assert.Len(t, routes[0].Spec.Rules[0].Matches, maxMatchesPerRule)
+		assert.Len(t, routes[1].Spec.Rules, 1)
+		assert.Len(t, routes[1].Spec.Rules[0].Matches, 1)
+	})
+
+	t.Run("no matches give no routes", func(t *testing.T) {
+		assert.Empty(t, createRoutes("users-api-user", nil, nil, Options{}))
+	})
+}
+
+func TestCreateMatch(t *testing.T) {
+	service := &specification.Service{Name: "Users API", BasePath: "/"}
+
+	t.Run("static path is matched exactly", func(t *testing.T) {
+		assert.Equal(t, match{Path: pathMatch{Type: pathMatchExact, Value: "/user/_search"}, Method: "POST"}, createMatch(service, "post", "/user/_search"))
+	})
+
+	t.Run("parameters and special characters in regular expressions", func(t *testing.T) {
+		assert.Equal(t, `^/user/[^/]+/avatar\.png$`, createMatch(service, "GET", "/user/{id}/avatar.png").Path.Value)
+	})
+
+	t.Run("trailing slash is kept", func(t *testing.T) {
+		assert.Equal(t, "/user/", createMatch(service, "GET", "/user/").Path.Value)
+	})
+}
+
+func TestKubernetesName(t *testing.T) {
+	assert.Equal(t, "users-api-user-account", kubernetesName("Users API--user_account"))
+	assert.Equal(t, maxNameLength, len(kubernetesName(strings.Repeat("a", 100))))
+	assert.Equal(t, "a", kubernetesName("a-"+strings.Repeat("-", 70)))
+	assert.Equal(t, "skolans-tjanst-arende", kubernetesName("Skolans tjänst-Ärende"))
+}
@@ -0,0 +1,33 @@
+// Package mockserver serves a mock of the API of a specification, without generating code.
+//
+// The mock is built from the same endpoints as the generated server, so frontends can be developed against an
+// API before it is implemented:
+//   - Every endpoint responds with the status code of its response and a body built from the examples of the
+//     specification, generated with examplegen for the fields without one
+//   - The path parameters and the fields of the request body replace the examples of the fields with the same
+//     name in a response object, so that a created or fetched object reflects the request
+//   - Path and query parameters are checked against their types, and malformed ones are rejected with
+//     400 Bad Request, like the generated server does
+//   - Request bodies that are not JSON objects are rejected with 400 Bad Request, like the generated server does
+//   - Unlike the generated server, which leaves them to the service, bodies with missing required fields,
+//     including the fields required on the operation with required_on, values of the wrong type or format, or
+//     unknown enum values are rejected with 422 Unprocessable Entity and an error per field, with its path in
+//     the body, e.g. address.street
+//   - Errors are written in the error format of the service, the Error object or Problem Details (RFC 9457)
+//   - The health, readiness and version endpoints respond like in the generated server
+//
+// Development resources are left out, like in the OpenAPI document. Authentication is not checked, and the
+// responses allow requests from any origin, so that a frontend served from another origin can call the mock.
+//
+// # Usage
+//
+//	handler, err := mockserver.NewHandler(service)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	log.Fatal(http.ListenAndServe(":8080", handler))
+//
+// The mock command of publicapis-gen serves it for a specification file:
+//
+//	publicapis-gen mock -spec users-api.yaml -port 8080
+package mockserver
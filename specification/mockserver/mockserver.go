@@ -0,0 +1,261 @@
+package mockserver
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/meitner-se/publicapis-gen/specification"
+	"github.com/meitner-se/publicapis-gen/specification/conformance"
+	"github.com/meitner-se/publicapis-gen/specification/examplegen"
+)
+
+// Error codes of the responses, the values of the default ErrorCode enum of the specification
+const (
+	errorCodeBadRequest          = "BadRequest"
+	errorCodeNotFound            = "NotFound"
+	errorCodeUnprocessableEntity = "UnprocessableEntity"
+)
+
+// Names of the members of the error responses, the fields of the default Error object of the specification
+const (
+	errorCodeField      = "Code"
+	errorRequestIDField = "RequestID"
+	errorFieldsField    = "Fields"
+	fieldErrorPath      = "Path"
+	fieldErrorCode      = "Code"
+	fieldErrorMessage   = "Message"
+	problemType         = "type"
+	problemTypeBlank    = "about:blank"
+	problemTitle        = "title"
+	problemStatus       = "status"
+	problemInstance     = "instance"
+)
+
+// HTTP constants
+const (
+	contentTypeJSON        = "application/json"
+	contentTypeProblemJSON = "application/problem+json"
+	headerContentType      = "Content-Type"
+	headerAllowOrigin      = "Access-Control-Allow-Origin"
+	headerAllowMethods     = "Access-Control-Allow-Methods"
+	headerAllowHeaders     = "Access-Control-Allow-Headers"
+	headerRequestHeaders   = "Access-Control-Request-Headers"
+	allowedMethods         = "GET, POST, PUT, PATCH, DELETE, OPTIONS"
+	allowedOrigins         = "*"
+)
+
+// Members of the responses of the operational endpoints, like those of the generated server
+const (
+	checkStatus    = "status"
+	checkStatusOK  = "ok"
+	versionName    = "name"
+	versionVersion = "version"
+)
+
+// Messages of the error responses
+const (
+	messageNoEndpoint         = "no endpoint %s %s"
+	messageInvalidPathParams  = "cannot decode path params: "
+	messageInvalidQueryParams = "cannot decode query params: "
+	messageInvalidBodyParams  = "cannot decode json body params: "
+	messageInvalidFields      = "the request body has invalid fields"
+)
+
+// handler serves the mock responses of the endpoints of a service.
+type handler struct {
+	service   *specification.Service
+	validator *conformance.Validator
+	generator *examplegen.Generator
+
+	// operational are the bodies of the operational endpoints by their path, which are not endpoints of a resource
+	operational map[string]any
+}
+
+// NewHandler returns an http.Handler serving a mock of the API of the service, without generating code. Every
+// endpoint the generated server serves responds with the status code of its response and a body built from the
+// examples of the specification. Malformed path and query parameters and bodies are rejected with 400 Bad Request
+// like the generated server does, and bodies with missing or invalid fields, which the generated server leaves to
+// the service, with 422 Unprocessable Entity and the errors of the fields. Development resources are left out,
+// like in the OpenAPI document. The responses allow requests from any origin, so frontends can call the mock directly.
+func NewHandler(service *specification.Service) (http.Handler, error) {
+	validator, err := conformance.NewValidator(service)
+	if err != nil {
+		return nil, err
+	}
+
+	return &handler{
+		service:   service,
+		validator: validator,
+		generator: examplegen.New(service, examplegen.DefaultSeed),
+		operational: map[string]any{
+			specification.OperationalPathHealth:    map[string]any{checkStatus: checkStatusOK},
+			specification.OperationalPathReadiness: map[string]any{checkStatus: checkStatusOK},
+			specification.OperationalPathVersion:   map[string]any{versionName: service.Name, versionVersion: service.Version},
+		},
+	}, nil
+}
+
+// ServeHTTP validates the request against its endpoint and responds with the example response of the endpoint.
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set(headerAllowOrigin, allowedOrigins)
+	if r.Method == http.MethodOptions {
+		w.Header().Set(headerAllowMethods, allowedMethods)
+		w.Header().Set(headerAllowHeaders, r.Header.Get(headerRequestHeaders))
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	match := h.validator.Match(r.Method, r.URL.Path)
+	if match != nil && match.Operational {
+		if body, ok := h.operational[match.Path]; ok {
+			writeJSON(w, http.StatusOK, contentTypeJSON, body)
+			return
+		}
+		match = nil
+	}
+	if match == nil {
+		h.writeError(w, r, http.StatusNotFound, errorCodeNotFound, fmt.Sprintf(messageNoEndpoint, r.Method, r.URL.Path), nil)
+		return
+	}
+
+	pathParams, err := h.validator.ParsePathParams(match)
+	if err != nil {
+		h.writeError(w, r, http.StatusBadRequest, errorCodeBadRequest, messageInvalidPathParams+err.Error(), nil)
+		return
+	}
+
+	if err := h.validator.ValidateQueryParams(match, r.URL.Query()); err != nil {
+		h.writeError(w, r, http.StatusBadRequest, errorCodeBadRequest, messageInvalidQueryParams+err.Error(), nil)
+		return
+	}
+
+	var body map[string]any
+	if len(match.Endpoint.Request.BodyParams) > 0 {
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			h.writeError(w, r, http.StatusBadRequest, errorCodeBadRequest, messageInvalidBodyParams+err.Error(), nil)
+			return
+		}
+
+		decoded, violations, err := h.validator.ValidateBody(match, data)
+		if err != nil {
+			h.writeError(w, r, http.StatusBadRequest, errorCodeBadRequest, messageInvalidBodyParams+err.Error(), nil)
+			return
+		}
+		if len(violations) > 0 {
+			h.writeError(w, r, http.StatusUnprocessableEntity, errorCodeUnprocessableEntity, messageInvalidFields, violations)
+			return
+		}
+		body = decoded
+	}
+
+	h.writeResponse(w, match.Endpoint, pathParams, body)
+}
+
+// writeResponse writes the example response of the endpoint. The values of the path parameters and the request
+// body replace the examples of the fields with the same name in a response object, so that a created or fetched
+// object reflects the request.
+func (h *handler) writeResponse(w http.ResponseWriter, endpoint specification.Endpoint, pathParams, body map[string]any) {
+	response := endpoint.Response
+	statusCode := response.StatusCode
+	if statusCode == 0 {
+		statusCode = http.StatusOK
+	}
+
+	for _, header := range response.Headers {
+		if value := h.parameterExample(header); value != "" {
+			// The header map is set directly to keep the casing of the header name on the wire
+			w.Header()[header.GetHeaderName()] = []string{value}
+		}
+	}
+
+	if statusCode == http.StatusNoContent || (response.BodyObject == nil && len(response.BodyFields) == 0) {
+		w.WriteHeader(statusCode)
+		return
+	}
+
+	var example map[string]any
+	if response.BodyObject != nil {
+		example = h.generator.Object(*response.BodyObject)
+		for _, values := range []map[string]any{pathParams, body} {
+			for key, value := range values {
+				if _, ok := example[key]; ok {
+					example[key] = value
+				}
+			}
+		}
+	} else {
+		example = make(map[string]any, len(response.BodyFields))
+		for _, field := range response.BodyFields {
+			if value := h.generator.Value(field); value != nil {
+				example[field.TagJSON()] = value
+			}
+		}
+	}
+
+	contentType := response.ContentType
+	if contentType == "" {
+		contentType = contentTypeJSON
+	}
+
+	writeJSON(w, statusCode, contentType, example)
+}
+
+// writeError writes an error response in the error format of the service: the Error object, or Problem Details
+// (RFC 9457) with the members of the Error object as extension members.
+func (h *handler) writeError(w http.ResponseWriter, r *http.Request, statusCode int, code, message string, violations []conformance.Violation) {
+	naming := h.service.Naming
+	fields := make([]map[string]any, 0, len(violations))
+	for _, violation := range violations {
+		fields = append(fields, map[string]any{
+			naming.FormatJSONName(fieldErrorPath):    violation.Path,
+			naming.FormatJSONName(fieldErrorCode):    violation.Code,
+			naming.FormatJSONName(fieldErrorMessage): violation.Message,
+		})
+	}
+
+	body := map[string]any{
+		naming.FormatJSONName(errorCodeField):                       code,
+		naming.FormatJSONName(h.service.GetErrorMessageFieldName()): message,
+		naming.FormatJSONName(errorRequestIDField):                  newRequestID(),
+		naming.FormatJSONName(errorFieldsField):                     fields,
+	}
+
+	contentType := contentTypeJSON
+	if h.service.IsProblemDetails() {
+		contentType = contentTypeProblemJSON
+		body[problemType] = problemTypeBlank
+		body[problemTitle] = http.StatusText(statusCode)
+		body[problemStatus] = statusCode
+		body[problemInstance] = r.URL.Path
+	}
+
+	writeJSON(w, statusCode, contentType, body)
+}
+
+// parameterExample returns the example of a header or parameter, generated when it has none.
+func (h *handler) parameterExample(field specification.Field) string {
+	if field.Example != "" {
+		return field.Example
+	}
+	return h.generator.Field(field)
+}
+
+// writeJSON writes the body as JSON with the status code.
+func writeJSON(w http.ResponseWriter, statusCode int, contentType string, body any) {
+	w.Header().Set(headerContentType, contentType)
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// newRequestID returns a random UUID identifying a request in the error responses.
+func newRequestID() string {
+	var id [16]byte
+	_, _ = rand.Read(id[:])
+	id[6] = (id[6] & 0x0f) | 0x40
+	id[8] = (id[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", id[0:4], id[4:6], id[6:8], id[8:10], id[10:])
+}
@@ -0,0 +1,299 @@
+package mockserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/meitner-se/publicapis-gen/specification"
+	"github.com/meitner-se/publicapis-gen/specification/conformance"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testUserID = "123e4567-e89b-12d3-a456-426614174000"
+
+// createTestService creates a service with a resource with all operations, a development resource and a health
+// endpoint. The validation of the fields is tested in the conformance package, so the mock only needs fields to
+// echo and to reject.
+func createTestService() *specification.Service {
+	return specification.ApplyDefaultOverlays(&specification.Service{
+		Name:        "Users API",
+		Version:     "1.0.0",
+		BasePath:    "/api/v1",
+		Operational: &specification.OperationalEndpoints{Health: true},
+		Resources: []specification.Resource{
+			{
+				Name:        "User",
+				Description: "Users",
+				Operations:  []string{specification.OperationCreate, specification.OperationGet, specification.OperationList, specification.OperationUpdate, specification.OperationDelete},
+				Fields: []specification.ResourceField{
+					{Field: specification.Field{Name: "Name", Description: "Name", Type: specification.FieldTypeString}, Operations: []string{specification.OperationCreate, specification.OperationUpdate, specification.OperationRead}},
+					{Field: specification.Field{Name: "Age", Description: "Age", Type: specification.FieldTypeInt32, Modifiers: []string{specification.ModifierNullable}}, Operations: []string{specification.OperationCreate, specification.OperationRead}},
+				},
+			},
+			{
+				Name:        "Draft",
+				Description: "Drafts",
+				Development: true,
+				Operations:  []string{specification.OperationGet},
+			},
+		},
+	})
+}
+
+// serve serves the request with a mock of the service and returns the response and its decoded JSON body.
+func serve(t *testing.T, service *specification.Service, method, target, body string) (*httptest.ResponseRecorder, map[string]any) {
+	t.Helper()
+
+	handler, err := NewHandler(service)
+	require.NoError(t, err)
+
+	request := httptest.NewRequest(method, target, strings.NewReader(body))
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+
+	var decoded map[string]any
+	if recorder.Body.Len() > 0 {
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &decoded))
+	}
+	return recorder, decoded
+}
+
+func TestNewHandler(t *testing.T) {
+	t.Run("responds with the example of the endpoint", func(t *testing.T) {
+		// Act
+		recorder, body := serve(t, createTestService(), http.MethodGet, "/api/v1/user/"+testUserID, "")
+
+		// Assert
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, contentTypeJSON, recorder.Header().Get(headerContentType))
+		assert.Equal(t, "*", recorder.Header().Get(headerAllowOrigin))
+		assert.Equal(t, testUserID, body["id"], "The path parameters should be echoed in the response object")
+		assert.NotEmpty(t, body["name"])
+	})
+
+	t.Run("responds with the status code of the endpoint and the request body", func(t *testing.T) {
+		// Act
+		recorder, body := serve(t, createTestService(), http.MethodPost, "/api/v1/user", `{"name": "Ada", "age": 36}`)
+
+		// Assert
+		assert.Equal(t, http.StatusCreated, recorder.Code)
+		assert.Equal(t, "Ada", body["name"])
+		assert.Equal(t, float64(36), body["age"])
+	})
+
+	t.Run("responds without body for no content", func(t *testing.T) {
+		// Act
+		recorder, _ := serve(t, createTestService(), http.MethodDelete, "/api/v1/user/"+testUserID, "")
+
+		// Assert
+		assert.Equal(t, http.StatusNoContent, recorder.Code)
+		assert.Empty(t, recorder.Body.String())
+	})
+
+	t.Run("responds with the body fields of list endpoints", func(t *testing.T) {
+		// Act
+		recorder, body := serve(t, createTestService(), http.MethodGet, "/api/v1/user?limit=10&offset=0", "")
+
+		// Assert
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Len(t, body["data"], 1)
+		assert.Contains(t, body, "pagination")
+	})
+
+	t.Run("serves the operational endpoints", func(t *testing.T) {
+		// Act
+		recorder, body := serve(t, createTestService(), http.MethodGet, "/api/v1"+specification.OperationalPathHealth, "")
+
+		// Assert
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, map[string]any{"status": checkStatusOK}, body)
+	})
+
+	t.Run("rejects invalid path parameters", func(t *testing.T) {
+		// Act
+		recorder, body := serve(t, createTestService(), http.MethodGet, "/api/v1/user/not-a-uuid", "")
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		assert.Equal(t, errorCodeBadRequest, body["code"])
+		assert.Contains(t, body["message"], "cannot decode path params")
+		assert.NotEmpty(t, body["requestID"])
+	})
+
+	t.Run("rejects invalid query parameters", func(t *testing.T) {
+		// Act
+		recorder, body := serve(t, createTestService(), http.MethodGet, "/api/v1/user?limit=ten", "")
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		assert.Contains(t, body["message"], "cannot decode query params")
+	})
+
+	t.Run("rejects malformed bodies", func(t *testing.T) {
+		// Act
+		recorder, body := serve(t, createTestService(), http.MethodPost, "/api/v1/user", `{"name": `)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		assert.Contains(t, body["message"], "cannot decode json body params")
+	})
+
+	t.Run("rejects bodies with invalid fields", func(t *testing.T) {
+		// Act
+		recorder, body := serve(t, createTestService(), http.MethodPost, "/api/v1/user", `{"age": 3000000000}`)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+		assert.Equal(t, errorCodeUnprocessableEntity, body["code"])
+		assert.Equal(t, messageInvalidFields, body["message"])
+		assert.Equal(t, []any{
+			map[string]any{"path": "name", "code": conformance.CodeRequired, "message": "Name is required"},
+			map[string]any{"path": "age", "code": conformance.CodeInvalidType, "message": "Age must be a 32-bit integer"},
+		}, body["fields"])
+	})
+
+	t.Run("rejects bodies without the fields required on the operation", func(t *testing.T) {
+		// Arrange
+		service := specification.ApplyDefaultOverlays(&specification.Service{
+			Name:    "Users API",
+			Version: "1.0.0",
+			Resources: []specification.Resource{
+				{
+					Name:       "User",
+					Operations: []string{specification.OperationCreate, specification.OperationUpdate},
+					Fields: []specification.ResourceField{
+						{
+							Field:      specification.Field{Name: "Email", Type: specification.FieldTypeString},
+							Operations: []string{specification.OperationCreate, specification.OperationUpdate, specification.OperationRead},
+							RequiredOn: []string{specification.OperationCreate},
+						},
+					},
+				},
+			},
+		})
+
+		// Act
+		created, body := serve(t, service, http.MethodPost, "/users-api/1.0.0/user", `{}`)
+		updated, _ := serve(t, service, http.MethodPatch, "/users-api/1.0.0/user/"+testUserID, `{}`)
+
+		// Assert
+		assert.Equal(t, http.StatusUnprocessableEntity, created.Code)
+		assert.Equal(t, []any{map[string]any{"path": "email", "code": conformance.CodeRequired, "message": "Email is required"}}, body["fields"])
+		assert.Equal(t, http.StatusOK, updated.Code, "The field is optional on Update")
+	})
+
+	t.Run("unknown endpoints and development resources are not found", func(t *testing.T) {
+		for _, target := range []string{"/api/v1/unknown", "/api/v1/draft/" + testUserID} {
+			recorder, body := serve(t, createTestService(), http.MethodGet, target, "")
+			assert.Equal(t, http.StatusNotFound, recorder.Code, target)
+			assert.Equal(t, errorCodeNotFound, body["code"], target)
+		}
+	})
+
+	t.Run("answers preflight requests", func(t *testing.T) {
+		// Arrange
+		handler, err := NewHandler(createTestService())
+		require.NoError(t, err)
+		request := httptest.NewRequest(http.MethodOptions, "/api/v1/user", nil)
+		request.Header.Set(headerRequestHeaders, "Authorization, Content-Type")
+		recorder := httptest.NewRecorder()
+
+		// Act
+		handler.ServeHTTP(recorder, request)
+
+		// Assert
+		assert.Equal(t, http.StatusNoContent, recorder.Code)
+		assert.Equal(t, "*", recorder.Header().Get(headerAllowOrigin))
+		assert.Equal(t, allowedMethods, recorder.Header().Get(headerAllowMethods))
+		assert.Equal(t, "Authorization, Content-Type", recorder.Header().Get(headerAllowHeaders))
+	})
+
+	t.Run("writes errors as Problem Details", func(t *testing.T) {
+		// Arrange
+		service := createTestService()
+		service.ErrorFormat = specification.ErrorFormatProblemJSON
+
+		// Act
+		recorder, body := serve(t, service, http.MethodGet, "/api/v1/user/not-a-uuid", "")
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		assert.Equal(t, contentTypeProblemJSON, recorder.Header().Get(headerContentType))
+		assert.Equal(t, problemTypeBlank, body["type"])
+		assert.Equal(t, float64(http.StatusBadRequest), body["status"])
+		assert.Equal(t, "/api/v1/user/not-a-uuid", body["instance"])
+		assert.Equal(t, errorCodeBadRequest, body["code"])
+	})
+
+	t.Run("nil service returns error", func(t *testing.T) {
+		_, err := NewHandler(nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestNewHandler_JSONNaming(t *testing.T) {
+	// Arrange
+	service := specification.ApplyDefaultOverlays(&specification.Service{
+		Name:     "Users API",
+		Version:  "1.0.0",
+		BasePath: "/api/v1",
+		Naming:   &specification.Naming{JSON: specification.JSONCaseSnake},
+		Resources: []specification.Resource{
+			{
+				Name:       "User",
+				Operations: []string{specification.OperationCreate, specification.OperationGet},
+				Fields: []specification.ResourceField{
+					{Field: specification.Field{Name: "FirstName", Type: specification.FieldTypeString}, Operations: []string{specification.OperationCreate, specification.OperationRead}},
+				},
+			},
+		},
+	})
+
+	// Act
+	created, createdBody := serve(t, service, http.MethodPost, "/api/v1/user", `{"first_name": "Ada"}`)
+	rejected, rejectedBody := serve(t, service, http.MethodGet, "/api/v1/user/not-a-uuid", "")
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, created.Code)
+	assert.Equal(t, "Ada", createdBody["first_name"], "The body should use the JSON names of the naming")
+	assert.Equal(t, http.StatusBadRequest, rejected.Code)
+	assert.NotEmpty(t, rejectedBody["request_id"], "The error should use the JSON names of the naming")
+}
+
+func TestNewHandler_ResponseHeaderNames(t *testing.T) {
+	// Arrange
+	service := specification.ApplyDefaultOverlays(&specification.Service{
+		Name:     "Users API",
+		Version:  "1.0.0",
+		BasePath: "/api/v1",
+		Resources: []specification.Resource{
+			{
+				Name: "User",
+				Endpoints: []specification.Endpoint{
+					{
+						Name:   "Count",
+						Method: http.MethodGet,
+						Path:   "/count",
+						Response: specification.EndpointResponse{
+							StatusCode: http.StatusNoContent,
+							Headers: []specification.Field{
+								{Name: "TotalCount", HeaderName: "x-total-count", Type: specification.FieldTypeInt, Example: "42"},
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+
+	// Act
+	recorder, _ := serve(t, service, http.MethodGet, "/api/v1/user/count", "")
+
+	// Assert
+	assert.Equal(t, http.StatusNoContent, recorder.Code)
+	assert.Equal(t, []string{"42"}, recorder.Header()["x-total-count"], "The header should keep the casing of its header name")
+}
@@ -0,0 +1,257 @@
+package openapigen
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/meitner-se/publicapis-gen/specification"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/orderedmap"
+	yaml "gopkg.in/yaml.v3"
+)
+
+// codeSamplesExtension lists copy-pasteable requests of an operation, shown by documentation portals such as Redoc
+const codeSamplesExtension = "x-codeSamples"
+
+// Code sample constants
+const (
+	codeSampleServerURL    = "https://api.example.com"
+	codeSampleIndent       = "  "
+	codeSampleContentType  = "application/json"
+	codeSampleLangShell    = "Shell"
+	codeSampleLangGo       = "Go"
+	codeSampleLangTS       = "TypeScript"
+	codeSampleLabelCurl    = "curl"
+	codeSampleTokenEnv     = "API_TOKEN"
+	codeSampleBasicEnv     = "API_CREDENTIALS"
+	codeSampleAPIKeyEnv    = "API_KEY"
+	codeSampleAPIKeyValue  = "YOUR_API_KEY"
+	codeSampleBearerPrefix = "Bearer "
+	codeSampleBasicPrefix  = "Basic "
+)
+
+// codeSampleHeader is a header of a code sample. The value of credentials is read from the environment variable
+// env, after the prefix, so that the samples do not contain secrets.
+type codeSampleHeader struct {
+	name   string
+	value  string
+	prefix string
+	env    string
+}
+
+// codeSampleRequest is the request of an operation that the code samples send.
+type codeSampleRequest struct {
+	method  string
+	url     string
+	headers []codeSampleHeader
+	body    string
+}
+
+// addCodeSamplesExtension adds curl, Go and TypeScript samples of the request of the endpoint to the operation.
+func (g *generator) addCodeSamplesExtension(operation *v3.Operation, endpoint specification.Endpoint, resource specification.Resource, service *specification.Service) {
+	if !g.CodeSamples {
+		return
+	}
+
+	request := g.createCodeSampleRequest(endpoint, resource, service)
+	samples := []struct{ lang, label, source string }{
+		{codeSampleLangShell, codeSampleLabelCurl, request.curl()},
+		{codeSampleLangGo, codeSampleLangGo, request.golang()},
+		{codeSampleLangTS, codeSampleLangTS, request.typescript()},
+	}
+
+	samplesNode := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+	for _, sample := range samples {
+		samplesNode.Content = append(samplesNode.Content, &yaml.Node{
+			Kind: yaml.MappingNode,
+			Tag:  "!!map",
+			Content: []*yaml.Node{
+				{Kind: yaml.ScalarNode, Tag: tagString, Value: "lang"},
+				{Kind: yaml.ScalarNode, Tag: tagString, Value: sample.lang},
+				{Kind: yaml.ScalarNode, Tag: tagString, Value: "label"},
+				{Kind: yaml.ScalarNode, Tag: tagString, Value: sample.label},
+				{Kind: yaml.ScalarNode, Tag: tagString, Value: "source"},
+				{Kind: yaml.ScalarNode, Tag: tagString, Value: sample.source, Style: yaml.LiteralStyle},
+			},
+		})
+	}
+
+	if operation.Extensions == nil {
+		operation.Extensions = orderedmap.New[string, *yaml.Node]()
+	}
+	operation.Extensions.Set(codeSamplesExtension, samplesNode)
+}
+
+// createCodeSampleRequest creates the request of the code samples of the endpoint, sent to the first server of
+// the service with the defaults of its variables, and the examples of its path parameters, required query parameters
+// and request body.
+func (g *generator) createCodeSampleRequest(endpoint specification.Endpoint, resource specification.Resource, service *specification.Service) codeSampleRequest {
+	serverURL := codeSampleServerURL
+	if len(service.Servers) > 0 {
+		serverURL = service.Servers[0].GetDefaultURL()
+	} else if g.ServerURL != "" {
+		serverURL = g.ServerURL
+	}
+
+	request := codeSampleRequest{method: strings.ToUpper(endpoint.Method)}
+
+	endpointPath := service.Tenancy.GetPathPrefix() + service.GetEndpointPath(resource, endpoint)
+	if service.Tenancy != nil {
+		tenant := g.fieldExample(service.Tenancy.GetField(), service)
+		if service.Tenancy.IsPath() {
+			endpointPath = strings.ReplaceAll(endpointPath, "{"+service.Tenancy.GetParameterName()+"}", url.PathEscape(tenant))
+		} else {
+			request.headers = append(request.headers, codeSampleHeader{name: service.Tenancy.GetParameterName(), value: tenant})
+		}
+	}
+	for _, param := range endpoint.Request.PathParams {
+		endpointPath = strings.ReplaceAll(endpointPath, "{"+param.TagJSON()+"}", url.PathEscape(g.fieldExample(param, service)))
+	}
+
+	query := url.Values{}
+	for _, param := range endpoint.Request.QueryParams {
+		if param.IsRequired(service) {
+			query.Set(param.TagJSON(), g.fieldExample(param, service))
+		}
+	}
+
+	if header, ok := createCodeSampleAuthHeader(service, query); ok {
+		request.headers = append(request.headers, header)
+	}
+
+	request.url = getServerURL(serverURL, service) + endpointPath
+	if len(query) > 0 {
+		request.url += "?" + query.Encode()
+	}
+
+	if example := g.generateRequestBodyExample(endpoint.Request.BodyParams, service); example != nil {
+		if body, err := renderJSON(example, codeSampleIndent); err == nil {
+			request.body = string(body)
+			request.headers = append(request.headers, codeSampleHeader{name: "Content-Type", value: codeSampleContentType})
+		}
+	}
+
+	return request
+}
+
+// createCodeSampleAuthHeader returns the header with the credentials of the first security scheme of the service,
+// reading them from an environment variable. API keys in the query are added to the query instead, as a
+// placeholder to replace.
+func createCodeSampleAuthHeader(service *specification.Service, query url.Values) (codeSampleHeader, bool) {
+	if len(service.Security) == 0 || len(service.Security[0]) == 0 {
+		return codeSampleHeader{}, false
+	}
+
+	scheme, ok := service.SecuritySchemes[service.Security[0][0]]
+	if !ok {
+		return codeSampleHeader{}, false
+	}
+
+	switch scheme.Type {
+	case securityTypeAPIKey:
+		switch scheme.In {
+		case "query":
+			query.Set(scheme.Name, codeSampleAPIKeyValue)
+			return codeSampleHeader{}, false
+		case "cookie":
+			return codeSampleHeader{name: "Cookie", prefix: scheme.Name + "=", env: codeSampleAPIKeyEnv}, true
+		}
+		return codeSampleHeader{name: scheme.Name, env: codeSampleAPIKeyEnv}, true
+	case securityTypeHTTP:
+		if strings.EqualFold(scheme.Scheme, "basic") {
+			return codeSampleHeader{name: "Authorization", prefix: codeSampleBasicPrefix, env: codeSampleBasicEnv}, true
+		}
+		return codeSampleHeader{name: "Authorization", prefix: codeSampleBearerPrefix, env: codeSampleTokenEnv}, true
+	case securityTypeOAuth2, securityTypeOpenIDConnect:
+		return codeSampleHeader{name: "Authorization", prefix: codeSampleBearerPrefix, env: codeSampleTokenEnv}, true
+	}
+
+	return codeSampleHeader{}, false
+}
+
+// curl returns the request as a curl command, reading the credentials from the environment of the shell.
+func (r codeSampleRequest) curl() string {
+	var sample strings.Builder
+	fmt.Fprintf(&sample, "curl -X %s %s", r.method, shellQuote(r.url))
+	for _, header := range r.headers {
+		if header.env != "" {
+			fmt.Fprintf(&sample, " \\\n  -H \"%s: %s$%s\"", header.name, header.prefix, header.env)
+			continue
+		}
+		fmt.Fprintf(&sample, " \\\n  -H %s", shellQuote(header.name+": "+header.value))
+	}
+	if r.body != "" {
+		fmt.Fprintf(&sample, " \\\n  -d %s", shellQuote(r.body))
+	}
+	return sample.String()
+}
+
+// golang returns the request as Go code using net/http, reading the credentials from the environment.
+func (r codeSampleRequest) golang() string {
+	var sample strings.Builder
+	body := "nil"
+	if r.body != "" {
+		quoted := "`" + r.body + "`"
+		if strings.Contains(r.body, "`") {
+			quoted = strconv.Quote(r.body)
+		}
+		fmt.Fprintf(&sample, "body := strings.NewReader(%s)\n", quoted)
+		body = "body"
+	}
+
+	fmt.Fprintf(&sample, "req, err := http.NewRequest(%s, %s, %s)\n", goMethod(r.method), strconv.Quote(r.url), body)
+	sample.WriteString("if err != nil {\n\tlog.Fatal(err)\n}\n")
+	for _, header := range r.headers {
+		value := strconv.Quote(header.value)
+		if header.env != "" {
+			value = fmt.Sprintf("os.Getenv(%s)", strconv.Quote(header.env))
+			if header.prefix != "" {
+				value = strconv.Quote(header.prefix) + "+" + value
+			}
+		}
+		fmt.Fprintf(&sample, "req.Header.Set(%s, %s)\n", strconv.Quote(header.name), value)
+	}
+
+	sample.WriteString("\nresp, err := http.DefaultClient.Do(req)\n")
+	sample.WriteString("if err != nil {\n\tlog.Fatal(err)\n}\n")
+	sample.WriteString("defer resp.Body.Close()")
+	return sample.String()
+}
+
+// typescript returns the request as TypeScript code using fetch, reading the credentials from the environment.
+func (r codeSampleRequest) typescript() string {
+	var sample strings.Builder
+	fmt.Fprintf(&sample, "const response = await fetch(%s, {\n", strconv.Quote(r.url))
+	fmt.Fprintf(&sample, "  method: %s,\n", strconv.Quote(r.method))
+	if len(r.headers) > 0 {
+		sample.WriteString("  headers: {\n")
+		for _, header := range r.headers {
+			value := strconv.Quote(header.value)
+			if header.env != "" {
+				value = fmt.Sprintf("`%s${process.env.%s}`", header.prefix, header.env)
+			}
+			fmt.Fprintf(&sample, "    %s: %s,\n", strconv.Quote(header.name), value)
+		}
+		sample.WriteString("  },\n")
+	}
+	if r.body != "" {
+		fmt.Fprintf(&sample, "  body: JSON.stringify(%s),\n", strings.ReplaceAll(r.body, "\n", "\n  "))
+	}
+	sample.WriteString("});")
+	return sample.String()
+}
+
+// goMethod returns the net/http constant of the method, e.g. http.MethodPost for POST.
+func goMethod(method string) string {
+	if method == "" {
+		return strconv.Quote(method)
+	}
+	return "http.Method" + method[:1] + strings.ToLower(method[1:])
+}
+
+// shellQuote quotes the value for a POSIX shell, in single quotes.
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
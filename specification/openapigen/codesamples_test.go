@@ -0,0 +1,189 @@
+package openapigen
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/meitner-se/publicapis-gen/specification"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// codeSample is an entry of x-codeSamples.
+type codeSample struct {
+	Lang   string `yaml:"lang"`
+	Label  string `yaml:"label"`
+	Source string `yaml:"source"`
+}
+
+// createCodeSamplesService creates a service with a bearer security scheme, a server and a resource with examples.
+func createCodeSamplesService() *specification.Service {
+	return specification.ApplyOverlay(&specification.Service{
+		Name:     "Code Samples API",
+		Version:  "1.0.0",
+		BasePath: "/api/v1",
+		Servers:  []specification.ServiceServer{{URL: "https://api.example.org"}},
+		SecuritySchemes: map[string]specification.SecurityScheme{
+			"bearerAuth": {Type: securityTypeHTTP, Scheme: "bearer"},
+		},
+		Security: []specification.SecurityRequirement{{"bearerAuth"}},
+		Resources: []specification.Resource{
+			{
+				Name:        "Users",
+				Description: "Users resource",
+				Operations:  []string{specification.OperationCreate, specification.OperationGet},
+				Fields: []specification.ResourceField{
+					{
+						Field:      specification.Field{Name: "Name", Type: specification.FieldTypeString, Description: "Name", Example: "Ada"},
+						Operations: []string{specification.OperationCreate, specification.OperationRead},
+					},
+				},
+			},
+		},
+	})
+}
+
+// decodeCodeSamples decodes the x-codeSamples of an operation by their label.
+func decodeCodeSamples(t *testing.T, generator *generator, service *specification.Service, path, method string) map[string]codeSample {
+	t.Helper()
+
+	document, err := generator.generateFromService(service)
+	require.NoError(t, err)
+
+	pathItem := document.Paths.PathItems.GetOrZero(path)
+	require.NotNil(t, pathItem, path)
+	operation := pathItem.GetOperations().GetOrZero(method)
+	require.NotNil(t, operation, method)
+
+	node := operation.Extensions.GetOrZero(codeSamplesExtension)
+	require.NotNil(t, node, "The operation should have x-codeSamples")
+
+	var samples []codeSample
+	require.NoError(t, node.Decode(&samples))
+
+	byLabel := make(map[string]codeSample, len(samples))
+	for _, sample := range samples {
+		byLabel[sample.Label] = sample
+	}
+	return byLabel
+}
+
+func TestGenerator_GenerateFromServiceWithCodeSamples(t *testing.T) {
+	t.Run("adds curl, Go and TypeScript samples of the request", func(t *testing.T) {
+		// Arrange
+		generator := newGenerator()
+		generator.CodeSamples = true
+
+		// Act
+		samples := decodeCodeSamples(t, generator, createCodeSamplesService(), "/users", "post")
+
+		// Assert
+		require.Len(t, samples, 3)
+		assert.Equal(t, codeSampleLangShell, samples[codeSampleLabelCurl].Lang)
+		assert.Equal(t, "curl -X POST 'https://api.example.org/api/v1/users' \\\n"+
+			"  -H \"Authorization: Bearer $API_TOKEN\" \\\n"+
+			"  -H 'Content-Type: application/json' \\\n"+
+			"  -d '{\n  \"name\": \"Ada\"\n}'", samples[codeSampleLabelCurl].Source)
+
+		assert.Equal(t, "body := strings.NewReader(`{\n  \"name\": \"Ada\"\n}`)\n"+
+			"req, err := http.NewRequest(http.MethodPost, \"https://api.example.org/api/v1/users\", body)\n"+
+			"if err != nil {\n\tlog.Fatal(err)\n}\n"+
+			"req.Header.Set(\"Authorization\", \"Bearer \"+os.Getenv(\"API_TOKEN\"))\n"+
+			"req.Header.Set(\"Content-Type\", \"application/json\")\n"+
+			"\nresp, err := http.DefaultClient.Do(req)\n"+
+			"if err != nil {\n\tlog.Fatal(err)\n}\n"+
+			"defer resp.Body.Close()", samples[codeSampleLangGo].Source)
+
+		assert.Equal(t, "const response = await fetch(\"https://api.example.org/api/v1/users\", {\n"+
+			"  method: \"POST\",\n"+
+			"  headers: {\n"+
+			"    \"Authorization\": `Bearer ${process.env.API_TOKEN}`,\n"+
+			"    \"Content-Type\": \"application/json\",\n"+
+			"  },\n"+
+			"  body: JSON.stringify({\n    \"name\": \"Ada\"\n  }),\n"+
+			"});", samples[codeSampleLangTS].Source)
+	})
+
+	t.Run("fills in the examples of the path parameters", func(t *testing.T) {
+		// Arrange
+		generator := newGenerator()
+		generator.CodeSamples = true
+
+		// Act
+		samples := decodeCodeSamples(t, generator, createCodeSamplesService(), "/users/{id}", "get")
+
+		// Assert
+		curl := samples[codeSampleLabelCurl].Source
+		assert.Contains(t, curl, "curl -X GET 'https://api.example.org/api/v1/users/")
+		assert.NotContains(t, curl, "{id}", "The path parameters should be replaced by examples")
+		assert.NotContains(t, curl, "Content-Type", "Requests without a body should not have a content type")
+		assert.Contains(t, samples[codeSampleLangGo].Source, "http.NewRequest(http.MethodGet, ")
+		assert.Contains(t, samples[codeSampleLangGo].Source, ", nil)")
+	})
+
+	t.Run("puts API keys in the query as a placeholder", func(t *testing.T) {
+		// Arrange
+		service := createCodeSamplesService()
+		service.SecuritySchemes = map[string]specification.SecurityScheme{
+			"apiKey": {Type: securityTypeAPIKey, Name: "api_key", In: "query"},
+		}
+		service.Security = []specification.SecurityRequirement{{"apiKey"}}
+		generator := newGenerator()
+		generator.CodeSamples = true
+
+		// Act
+		samples := decodeCodeSamples(t, generator, service, "/users/{id}", "get")
+
+		// Assert
+		assert.Contains(t, samples[codeSampleLabelCurl].Source, "?api_key="+codeSampleAPIKeyValue+"'")
+		assert.NotContains(t, samples[codeSampleLabelCurl].Source, "Authorization")
+	})
+
+	t.Run("sends the requests with the defaults of the server variables", func(t *testing.T) {
+		// Arrange
+		service := createCodeSamplesService()
+		service.Servers = []specification.ServiceServer{{
+			URL:       "https://{region}.api.example.org",
+			Variables: []specification.ServerVariable{{Name: "region", Default: "eu", Enum: []string{"eu", "us"}}},
+		}}
+		generator := newGenerator()
+		generator.CodeSamples = true
+
+		// Act
+		samples := decodeCodeSamples(t, generator, service, "/users", "post")
+
+		// Assert
+		assert.Contains(t, samples[codeSampleLabelCurl].Source, "curl -X POST 'https://eu.api.example.org/api/v1/users'")
+	})
+
+	t.Run("omitted by default", func(t *testing.T) {
+		// Act
+		document, err := newGenerator().generateFromService(createCodeSamplesService())
+
+		// Assert
+		require.NoError(t, err)
+		operation := document.Paths.PathItems.GetOrZero("/users").Post
+		assert.Nil(t, operation.Extensions.GetOrZero(codeSamplesExtension))
+	})
+
+	t.Run("enabled by the extensions profile", func(t *testing.T) {
+		// Arrange
+		var buf bytes.Buffer
+
+		// Act
+		err := GenerateOpenAPIWithOptions(&buf, createCodeSamplesService(), Options{Extensions: &Extensions{CodeSamples: true}})
+
+		// Assert
+		require.NoError(t, err)
+		var document struct {
+			Paths map[string]map[string]map[string]any `json:"paths"`
+		}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &document))
+		assert.Len(t, document.Paths["/users"]["post"][codeSamplesExtension], 3)
+	})
+}
+
+func TestShellQuote(t *testing.T) {
+	assert.Equal(t, `'{"name": "O'\''Brien"}'`, shellQuote(`{"name": "O'Brien"}`))
+}
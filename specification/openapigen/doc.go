@@ -41,6 +41,53 @@
 //	    log.Fatal(err)
 //	}
 //
+// GenerateOpenAPIYAML writes the same document as YAML, rendered natively from the document
+// so the key ordering and number formatting match the JSON output.
+//
+// # Options
+//
+// GenerateOpenAPIWithOptions accepts an Options value to override the title, description
+// and contact of the document, disable the x-speakeasy-* vendor extensions for consumers
+// that reject them, and choose the output format and JSON indentation:
+//
+//	err = openapigen.GenerateOpenAPIWithOptions(&buf, service, openapigen.Options{
+//	    Title:                      "Partner API",
+//	    DisableSpeakeasyExtensions: true,
+//	    Format:                     openapigen.FormatYAML,
+//	})
+//
+// Options.Extensions configures the vendor extensions of the document. With CodeSamples set, every
+// operation gets x-codeSamples with curl, Go and TypeScript requests built from the examples of the
+// specification, which documentation portals such as Redoc show as copy-pasteable samples:
+//
+//	err = openapigen.GenerateOpenAPIWithOptions(&buf, service, openapigen.Options{
+//	    Extensions: &openapigen.Extensions{CodeSamples: true},
+//	})
+//
+// The operations of the endpoints gated behind a feature flag have the flag in an x-feature-flag extension.
+// Options.ExcludeFeatureFlaggedEndpoints leaves them out of the document instead, to publish it before
+// the flags are enabled for everyone.
+//
+// Options.Overlay applies an OpenAPI Overlay document, parsed with ParseOverlay, to the generated
+// document before it is written. Its actions update or remove the parts of the document selected
+// by their JSONPath targets, in order, so consumers can customize the document without changing
+// the generator:
+//
+//	overlay, err := openapigen.ParseOverlay(data)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	err = openapigen.GenerateOpenAPIWithOptions(&buf, service, openapigen.Options{Overlay: overlay})
+//
+// A Generator validates the options once and can be reused for many services, also from
+// several goroutines, e.g. by a service that generates documents on request:
+//
+//	generator, err := openapigen.NewGenerator(openapigen.Options{Format: openapigen.FormatYAML})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	err = generator.Generate(&buf, service)
+//
 // # libopenapi Integration
 //
 // This package uses types from github.com/pb33f/libopenapi:
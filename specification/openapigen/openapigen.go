@@ -2,24 +2,33 @@ package openapigen
 
 import (
 	"bytes"
+	"encoding/csv"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/meitner-se/publicapis-gen/specification"
+	"github.com/meitner-se/publicapis-gen/specification/examplegen"
 	"github.com/pb33f/libopenapi/datamodel/high/base"
 	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
 	"github.com/pb33f/libopenapi/orderedmap"
+	openapioverlay "github.com/speakeasy-api/jsonpath/pkg/overlay"
 	yaml "gopkg.in/yaml.v3"
 )
 
 // Error constants
 const (
-	errorInvalidService  = "invalid service: service cannot be nil"
-	errorInvalidDocument = "invalid document: document cannot be nil"
+	errorInvalidService   = "invalid service: service cannot be nil"
+	errorInvalidDocument  = "invalid document: document cannot be nil"
+	errorInvalidFormat    = "invalid format: must be one of json, yaml"
+	errorInvalidExtension = "invalid extension: name must start with x-"
+	errorInvalidOverlay   = "invalid overlay"
+	errorInvalidNodeKind  = "invalid node kind"
 )
 
 // HTTP Status Code constants
@@ -28,7 +37,9 @@ const (
 	httpStatus401 = "401"
 	httpStatus403 = "403"
 	httpStatus404 = "404"
+	httpStatus408 = "408"
 	httpStatus409 = "409"
+	httpStatus413 = "413"
 	httpStatus422 = "422"
 	httpStatus429 = "429"
 	httpStatus500 = "500"
@@ -44,11 +55,62 @@ const (
 const (
 	apiTitleSuffix        = " API"
 	defaultAPIDescription = "Generated API documentation"
+	defaultJSONIndent     = "  "
+)
+
+// Custom extension constants
+const (
+	extensionPrefix  = "x-"
+	allOperationsKey = "*"
+)
+
+// Output format constants
+const (
+	FormatJSON = "json"
+	FormatYAML = "yaml"
+)
+
+// Request ID constants
+const (
+	requestIDParameterDescription = "Identifier of the request, used for logging and debugging. A new one is generated when absent"
+	requestIDHeaderDescription    = "Identifier of the request, as sent in the request header or else generated, also returned in the requestID of errors"
+)
+
+// Conditional GET constants
+const (
+	httpStatus304              = "304"
+	ifModifiedSinceDescription = "Time of the copy of the resource the client has, as an HTTP date such as Wed, 21 Oct 2015 07:28:00 GMT. The response is 304 Not Modified when the resource has not been updated since"
+	lastModifiedDescription    = "Time the resource was last updated, as an HTTP date, to send as the If-Modified-Since header of later requests"
+	notModifiedDescription     = "The resource has not been updated since the time of the If-Modified-Since header, so the response has no body"
+)
+
+// Async endpoint constants
+const (
+	locationHeaderDescription   = "Path the operation started by the request is polled at"
+	retryAfterHeaderDescription = "Seconds to wait before polling the operation again, absent once it is completed"
+	operationLinkName           = "GetOperation"
+	operationLinkDescription    = "Polls the operation until it is completed"
+	speakeasyPollingExtension   = "x-speakeasy-polling"
+	speakeasyPollingName        = "WaitForCompleted"
+)
+
+// Callback constants
+const (
+	callbackURLExpressionTemplate = "{$request.body#/%s}"
+	callbackRequestDescription    = "Payload of the callback"
+	callbackResponseDescription   = "The subscriber received the callback"
+)
+
+// Localization constants
+const (
+	acceptLanguageHeaderName  = "Accept-Language"
+	acceptLanguageDescription = "Preferred locales of the error messages, as an Accept-Language header. The available locales are %s, and %s is used when none of them is accepted"
 )
 
 // Content type constants
 const (
-	contentTypeJSON = "application/json"
+	contentTypeJSON        = "application/json"
+	contentTypeProblemJSON = "application/problem+json"
 )
 
 // Standard response descriptions
@@ -77,6 +139,10 @@ const (
 	autoErrorUnprocessableTemplate = "Validation error for %s %s operation - request data failed validation"
 	autoErrorRateLimitTemplate     = "Rate Limit error for %s %s operation - too many requests"
 	autoErrorInternalTemplate      = "Internal Server error for %s %s operation - unexpected server error"
+
+	// Request limits
+	autoErrorRequestTimeoutTemplate  = "Request Timeout error for %s %s operation - the handler did not complete within the timeout"
+	autoErrorPayloadTooLargeTemplate = "Payload Too Large error for %s %s operation - request body exceeds the size limit"
 )
 
 // Schema reference format constants
@@ -84,13 +150,48 @@ const (
 	schemaReferencePrefix       = "#/components/schemas/"
 	responseBodyReferencePrefix = "#/components/responses/"
 	requestBodyReferencePrefix  = "#/components/requestBodies/"
+	parameterReferencePrefix    = "#/components/parameters/"
 )
 
 // Server description template
 const (
 	serverDescriptionTemplate = "%s server"
+
+	enumAliasesDescriptionTemplate = "%s\n\nDeprecated aliases, still accepted: %s."
+
+	referencesDescriptionTemplate = "%s\n\nThe ID of a [%s](%s)."
 )
 
+// enumVarNamesExtension names the values of an integer enum, since the values themselves are only numbers
+const enumVarNamesExtension = "x-enum-varnames"
+
+// permissionsExtension lists the application permissions required by an operation, which OpenAPI has no field for
+const permissionsExtension = "x-permissions"
+
+// tagGroupsExtension groups the tags into sections in documentation portals such as Redoc
+const tagGroupsExtension = "x-tagGroups"
+
+// otherTagGroupName is the tag group of the tags without one, since portals hide the tags that are not in a group
+const otherTagGroupName = "Other"
+
+// stabilityExtension is the stability of an operation, experimental, beta or stable
+const stabilityExtension = "x-stability"
+
+// featureFlagExtension is the feature flag an operation is gated behind, which responds 404 Not Found while it is disabled
+const featureFlagExtension = "x-feature-flag"
+
+// maxBodyBytesExtension is the size limit of the request body of an operation in bytes
+const maxBodyBytesExtension = "x-max-body-bytes"
+
+// handlerTimeoutExtension is the time limit of the handler of an operation in milliseconds
+const handlerTimeoutExtension = "x-handler-timeout"
+
+// requiredScopesExtension lists the scopes required to read a field, which is null in the responses without them
+const requiredScopesExtension = "x-required-scopes"
+
+// referencesExtension is the resource whose ID a field holds, so that clients can follow the relationship
+const referencesExtension = "x-references"
+
 // Error response descriptions
 const (
 	badRequestDescription    = "Bad Request - The request was malformed or contained invalid parameters"
@@ -109,6 +210,8 @@ const (
 	errorCodeUnprocessableEntity = "UnprocessableEntity"
 	errorCodeRateLimited         = "RateLimited"
 	errorCodeInternal            = "Internal"
+	errorCodeRequestTimeout      = "RequestTimeout"
+	errorCodePayloadTooLarge     = "PayloadTooLarge"
 )
 
 // Schema types
@@ -124,6 +227,7 @@ const (
 // YAML tag constants
 const (
 	tagString = "!!str"
+	tagInt    = "!!int"
 )
 
 // Schema formats
@@ -132,8 +236,14 @@ const (
 	schemaFormatDate     = "date"
 	schemaFormatDateTime = "date-time"
 	schemaFormatDouble   = "double"
+	schemaFormatInt32    = "int32"
+	schemaFormatInt64    = "int64"
+	schemaFormatDecimal  = "decimal"
 )
 
+// decimalPattern is the pattern of a decimal serialized as a string, e.g. "-19.99"
+const decimalPattern = `^-?[0-9]+(\.[0-9]+)?$`
+
 // Speakeasy retry configuration constants
 const (
 	speakeasyRetriesExtension = "x-speakeasy-retries"
@@ -156,6 +266,27 @@ const (
 	speakeasyNameOverrideExtension = "x-speakeasy-name-override"
 )
 
+// Operational endpoint constants
+const (
+	speakeasyIgnoreExtension          = "x-speakeasy-ignore"
+	operationalTagName                = "Operational"
+	operationalTagDescription         = "Operational endpoints for health, readiness and version checks"
+	operationalHealthName             = "Health"
+	operationalHealthSummary          = "Liveness check"
+	operationalReadinessName          = "Readiness"
+	operationalReadinessSummary       = "Readiness check"
+	operationalVersionName            = "Version"
+	operationalVersionSummary         = "Service name and version"
+	operationalCheckOKDescription     = "The check passed"
+	operationalCheckFailedDescription = "The check failed"
+	operationalVersionDescription     = "The service name and version"
+	operationalStatusFieldName        = "status"
+	operationalNameFieldName          = "name"
+	operationalVersionFieldName       = "version"
+	httpStatus200                     = "200"
+	httpStatus503                     = "503"
+)
+
 // Retry configuration field names
 const (
 	retryFieldStrategy              = "strategy"
@@ -195,6 +326,15 @@ const (
 	requestIDFieldName      = "requestID"
 	errorFieldName          = "error"
 	errorFieldsFieldName    = "errorFields"
+	fieldsFieldName         = "fields"
+	pathFieldName           = "path"
+	typeFieldName           = "type"
+	titleFieldName          = "title"
+	statusFieldName         = "status"
+	detailFieldName         = "detail"
+	instanceFieldName       = "instance"
+	problemTypeDefault      = "about:blank"
+	problemInstanceExample  = "/v1/resources/123"
 	errorCodeEnumName       = "ErrorCode"
 	requestBodySuffix       = "RequestBody"
 	responseBodySuffix      = "ResponseBody"
@@ -204,9 +344,10 @@ const (
 
 // Security scheme constants
 const (
-	securityTypeHTTP   = "http"
-	securityTypeAPIKey = "apiKey"
-	securityTypeOAuth2 = "oauth2"
+	securityTypeHTTP          = "http"
+	securityTypeAPIKey        = "apiKey"
+	securityTypeOAuth2        = "oauth2"
+	securityTypeOpenIDConnect = "openIdConnect"
 )
 
 // generator handles OpenAPI 3.1 specification generation from specification.Service.
@@ -222,6 +363,36 @@ type generator struct {
 
 	// ServerURL specifies the base server URL for the API
 	ServerURL string
+
+	// ComposeExtendedObjects emits objects that extend another object as an allOf composition
+	// of the parent schema and their own fields, instead of a flattened schema
+	ComposeExtendedObjects bool
+
+	// Contact overrides the contact information of the service when set
+	Contact *specification.ServiceContact
+
+	// DisableSpeakeasyExtensions omits all x-speakeasy-* vendor extensions from the document
+	DisableSpeakeasyExtensions bool
+
+	// ExcludeFeatureFlaggedEndpoints omits the endpoints gated behind a feature flag from the document
+	ExcludeFeatureFlaggedEndpoints bool
+
+	// Indent specifies the indentation of the JSON output (default: two spaces)
+	Indent string
+
+	// Extensions adds custom vendor extensions to the document and its operations
+	Extensions *Extensions
+
+	// CodeSamples adds curl, Go and TypeScript samples of the requests to the operations, as x-codeSamples
+	CodeSamples bool
+
+	// objectExamples memoizes the examples of the objects of exampleService by exampleKey, since the same
+	// objects are referenced by many schemas, request and response bodies
+	objectExamples map[string]*yaml.Node
+	exampleService *specification.Service
+
+	// referencedObjects memoizes the objects that are referenced by each object of exampleService, directly or not
+	referencedObjects map[string][]string
 }
 
 // newGenerator creates a new OpenAPI generator with default settings.
@@ -237,12 +408,102 @@ func (g *generator) generateFromService(service *specification.Service) (*v3.Doc
 		return nil, errors.New(errorInvalidService)
 	}
 
+	// The service may have been modified since the last document was generated
+	g.objectExamples, g.referencedObjects, g.exampleService = nil, nil, nil
+
+	if g.ExcludeFeatureFlaggedEndpoints {
+		service = withoutFeatureFlaggedEndpoints(service)
+	}
+
 	// Build document using native libopenapi v3 types
-	return g.buildV3Document(service), nil
+	document := g.buildV3Document(service)
+
+	if err := g.addCustomExtensions(document); err != nil {
+		return nil, err
+	}
+
+	return document, nil
+}
+
+// withoutFeatureFlaggedEndpoints returns a copy of the service without the endpoints gated behind a feature flag,
+// so they are left out of the paths and of the components of their requests and responses.
+func withoutFeatureFlaggedEndpoints(service *specification.Service) *specification.Service {
+	filtered := *service
+	filtered.Resources = make([]specification.Resource, len(service.Resources))
+	for i, resource := range service.Resources {
+		resource.Endpoints = slices.DeleteFunc(slices.Clone(resource.Endpoints), func(endpoint specification.Endpoint) bool {
+			return endpoint.FeatureFlag != ""
+		})
+		filtered.Resources[i] = resource
+	}
+	return &filtered
+}
+
+// addCustomExtensions adds the custom extensions of the generator to the document and its operations.
+// Extensions for all operations ("*") are added before the extensions of a specific operation, so the latter take precedence.
+func (g *generator) addCustomExtensions(document *v3.Document) error {
+	if g.Extensions == nil {
+		return nil
+	}
+
+	if len(g.Extensions.Document) > 0 {
+		if document.Extensions == nil {
+			document.Extensions = orderedmap.New[string, *yaml.Node]()
+		}
+		if err := setExtensions(document.Extensions, g.Extensions.Document); err != nil {
+			return err
+		}
+	}
+
+	if len(g.Extensions.Operations) == 0 || document.Paths == nil {
+		return nil
+	}
+
+	for _, pathItem := range document.Paths.PathItems.FromOldest() {
+		for _, operation := range pathItem.GetOperations().FromOldest() {
+			for _, operationID := range []string{allOperationsKey, operation.OperationId} {
+				extensions, ok := g.Extensions.Operations[operationID]
+				if !ok {
+					continue
+				}
+				if operation.Extensions == nil {
+					operation.Extensions = orderedmap.New[string, *yaml.Node]()
+				}
+				if err := setExtensions(operation.Extensions, extensions); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// setExtensions encodes the extension values as YAML nodes and sets them in sorted order, for a deterministic output.
+func setExtensions(target *orderedmap.Map[string, *yaml.Node], extensions map[string]any) error {
+	names := make([]string, 0, len(extensions))
+	for name := range extensions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		node := &yaml.Node{}
+		if err := node.Encode(extensions[name]); err != nil {
+			return fmt.Errorf("failed to encode extension %s: %w", name, err)
+		}
+		target.Set(name, node)
+	}
+
+	return nil
 }
 
 // addSpeakeasyRetryExtension adds Speakeasy retry configuration extension to the OpenAPI document.
 func (g *generator) addSpeakeasyRetryExtension(document *v3.Document, service *specification.Service) {
+	if g.DisableSpeakeasyExtensions {
+		return
+	}
+
 	// Initialize extensions map if it doesn't exist
 	if document.Extensions == nil {
 		document.Extensions = orderedmap.New[string, *yaml.Node]()
@@ -309,6 +570,10 @@ func (g *generator) addSpeakeasyRetryExtension(document *v3.Document, service *s
 
 // addSpeakeasyTimeoutExtension adds Speakeasy timeout configuration extension to the OpenAPI document.
 func (g *generator) addSpeakeasyTimeoutExtension(document *v3.Document, service *specification.Service) {
+	if g.DisableSpeakeasyExtensions {
+		return
+	}
+
 	// Initialize extensions map if it doesn't exist
 	if document.Extensions == nil {
 		document.Extensions = orderedmap.New[string, *yaml.Node]()
@@ -332,6 +597,10 @@ func (g *generator) addSpeakeasyTimeoutExtension(document *v3.Document, service
 
 // addSpeakeasyPaginationExtension adds Speakeasy pagination configuration extension to an operation.
 func (g *generator) addSpeakeasyPaginationExtension(operation *v3.Operation) {
+	if g.DisableSpeakeasyExtensions {
+		return
+	}
+
 	// Initialize extensions map if it doesn't exist
 	if operation.Extensions == nil {
 		operation.Extensions = orderedmap.New[string, *yaml.Node]()
@@ -405,23 +674,103 @@ func (g *generator) addSpeakeasyPaginationExtension(operation *v3.Operation) {
 	operation.Extensions.Set(speakeasyPaginationExtension, paginationNode)
 }
 
+// createCallbacks creates the callbacks of the operation, requests to the URL of the subscriber in the request body
+// with the payload of the callback as their JSON body.
+func (g *generator) createCallbacks(endpoint specification.Endpoint, resource specification.Resource) *orderedmap.Map[string, *v3.Callback] {
+	callbacks := orderedmap.New[string, *v3.Callback]()
+	for _, callback := range endpoint.Callbacks {
+		urlField := endpoint.GetCallbackURLField(callback)
+		if urlField == nil {
+			continue
+		}
+
+		isRequired := true
+		content := orderedmap.New[string, *v3.MediaType]()
+		content.Set(contentTypeJSON, &v3.MediaType{Schema: base.CreateSchemaProxyRef(schemaReferencePrefix + callback.Payload)})
+		responses := orderedmap.New[string, *v3.Response]()
+		responses.Set(httpStatus200, &v3.Response{Description: callbackResponseDescription})
+		operation := &v3.Operation{
+			OperationId: resource.Name + endpoint.Name + callback.Name,
+			Description: callback.Description,
+			RequestBody: &v3.RequestBody{Description: callbackRequestDescription, Content: content, Required: &isRequired},
+			Responses:   &v3.Responses{Codes: responses},
+		}
+
+		pathItem := &v3.PathItem{}
+		switch callback.GetMethod() {
+		case http.MethodPut:
+			pathItem.Put = operation
+		case http.MethodPatch:
+			pathItem.Patch = operation
+		default:
+			pathItem.Post = operation
+		}
+
+		expression := orderedmap.New[string, *v3.PathItem]()
+		expression.Set(fmt.Sprintf(callbackURLExpressionTemplate, urlField.TagJSON()), pathItem)
+		callbacks.Set(callback.Name, &v3.Callback{Expression: expression})
+	}
+	return callbacks
+}
+
+// addSpeakeasyPollingExtension adds the Speakeasy polling configuration to the operation polling the operations of
+// the async endpoints, which waits until the status of the operation is succeeded or failed.
+func (g *generator) addSpeakeasyPollingExtension(operation *v3.Operation, endpoint specification.Endpoint) {
+	if g.DisableSpeakeasyExtensions {
+		return
+	}
+
+	// Initialize extensions map if it doesn't exist
+	if operation.Extensions == nil {
+		operation.Extensions = orderedmap.New[string, *yaml.Node]()
+	}
+
+	statusCondition := "$statusCode == " + strconv.Itoa(endpoint.Response.StatusCode)
+	statusField := specification.Field{Name: "Status"}
+	createCriteriaNode := func(status string) *yaml.Node {
+		criteriaNode := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		for _, condition := range []string{statusCondition, fmt.Sprintf("$response.body#/%s == %q", statusField.TagJSON(), status)} {
+			criteriaNode.Content = append(criteriaNode.Content, &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Content: []*yaml.Node{
+				{Kind: yaml.ScalarNode, Value: "condition"},
+				{Kind: yaml.ScalarNode, Tag: tagString, Value: condition},
+			}})
+		}
+		return criteriaNode
+	}
+
+	pollingNode := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Content: []*yaml.Node{
+		{Kind: yaml.ScalarNode, Value: "name"},
+		{Kind: yaml.ScalarNode, Tag: tagString, Value: speakeasyPollingName},
+		{Kind: yaml.ScalarNode, Value: "successCriteria"},
+		createCriteriaNode(specification.OperationStatusSucceeded),
+		{Kind: yaml.ScalarNode, Value: "failureCriteria"},
+		createCriteriaNode(specification.OperationStatusFailed),
+	}}
+
+	operation.Extensions.Set(speakeasyPollingExtension, &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Content: []*yaml.Node{pollingNode}})
+}
+
 // addSpeakeasyOperationNamingExtensions adds Speakeasy operation naming extensions to an operation.
 func (g *generator) addSpeakeasyOperationNamingExtensions(operation *v3.Operation, endpoint specification.Endpoint, resource specification.Resource) {
+	if g.DisableSpeakeasyExtensions {
+		return
+	}
+
 	// Initialize extensions map if it doesn't exist
 	if operation.Extensions == nil {
 		operation.Extensions = orderedmap.New[string, *yaml.Node]()
 	}
 
-	// Add x-speakeasy-group extension (resource name in camelCase and plural)
-	groupValue := specification.CamelCase(resource.GetPluralName())
+	// Add x-speakeasy-group extension (sdk_group, or resource name in camelCase and plural)
+	groupValue := resource.GetSDKGroup()
 	groupNode := &yaml.Node{
 		Kind:  yaml.ScalarNode,
 		Value: groupValue,
 	}
 	operation.Extensions.Set(speakeasyGroupExtension, groupNode)
 
-	// Add x-speakeasy-name-override extension (method name in camelCase)
-	nameOverrideValue := specification.CamelCase(endpoint.Name)
+	// Add x-speakeasy-name-override extension (sdk_method_name, or endpoint name in camelCase)
+	nameOverrideValue := endpoint.GetSDKMethodName()
 	nameOverrideNode := &yaml.Node{
 		Kind:  yaml.ScalarNode,
 		Value: nameOverrideValue,
@@ -484,25 +833,30 @@ func (g *generator) buildV3Document(service *specification.Service) *v3.Document
 	}
 
 	info := &base.Info{
-		Title:       title,
-		Description: g.Description,
-		Version:     version,
+		Title:          title,
+		Description:    g.Description,
+		TermsOfService: service.TermsOfService,
+		Version:        version,
 	}
 
-	// Add contact information if available in the service
-	if service.Contact != nil {
+	// Add contact information if available in the service, or the override of the generator
+	serviceContact := service.Contact
+	if g.Contact != nil {
+		serviceContact = g.Contact
+	}
+	if serviceContact != nil {
 		contact := &base.Contact{}
-		if service.Contact.Name != "" {
-			contact.Name = service.Contact.Name
+		if serviceContact.Name != "" {
+			contact.Name = serviceContact.Name
 		}
-		if service.Contact.URL != "" {
-			contact.URL = service.Contact.URL
+		if serviceContact.URL != "" {
+			contact.URL = serviceContact.URL
 		}
-		if service.Contact.Email != "" {
-			contact.Email = service.Contact.Email
+		if serviceContact.Email != "" {
+			contact.Email = serviceContact.Email
 		}
 		// Only set contact if at least one field is provided
-		if service.Contact.Name != "" || service.Contact.URL != "" || service.Contact.Email != "" {
+		if serviceContact.Name != "" || serviceContact.URL != "" || serviceContact.Email != "" {
 			info.Contact = contact
 		}
 	}
@@ -523,8 +877,9 @@ func (g *generator) buildV3Document(service *specification.Service) *v3.Document
 
 	// Create Document
 	document := &v3.Document{
-		Version: g.Version,
-		Info:    info,
+		Version:      g.Version,
+		Info:         info,
+		ExternalDocs: g.createExternalDocs(service.ExternalDocs),
 	}
 
 	// Add Speakeasy retry configuration as extension
@@ -538,12 +893,13 @@ func (g *generator) buildV3Document(service *specification.Service) *v3.Document
 		servers := make([]*v3.Server, len(service.Servers))
 		for i, server := range service.Servers {
 			openAPIServer := &v3.Server{
-				URL:         server.URL,
+				URL:         getServerURL(server.URL, service),
 				Description: server.Description,
+				Variables:   createServerVariables(server.Variables),
 			}
 
 			// Add x-speakeasy-server-id extension if server has an ID
-			if server.ID != "" {
+			if server.ID != "" && !g.DisableSpeakeasyExtensions {
 				if openAPIServer.Extensions == nil {
 					openAPIServer.Extensions = orderedmap.New[string, *yaml.Node]()
 				}
@@ -561,11 +917,19 @@ func (g *generator) buildV3Document(service *specification.Service) *v3.Document
 		// Fallback to generator's ServerURL for backwards compatibility
 		servers := []*v3.Server{
 			{
-				URL:         g.ServerURL,
+				URL:         getServerURL(g.ServerURL, service),
 				Description: fmt.Sprintf(serverDescriptionTemplate, title),
 			},
 		}
 		document.Servers = servers
+	} else if service.BasePath != "" && service.GetBasePath() != "" {
+		// Without servers, the base path is the URL of the server relative to the document
+		document.Servers = []*v3.Server{
+			{
+				URL:         service.GetBasePath(),
+				Description: fmt.Sprintf(serverDescriptionTemplate, title),
+			},
+		}
 	}
 
 	// Create Components
@@ -605,6 +969,9 @@ func (g *generator) buildV3Document(service *specification.Service) *v3.Document
 	// Add security schemes to components
 	g.addSecuritySchemesToComponents(components, service)
 
+	// Add parameter groups to components
+	g.addParameterGroupsToComponents(components, service)
+
 	document.Components = components
 
 	// Add security requirements to document
@@ -618,16 +985,179 @@ func (g *generator) buildV3Document(service *specification.Service) *v3.Document
 		}
 		g.addResourceToPaths(resource, paths, service)
 	}
+	g.addOperationalEndpointsToPaths(paths, service)
 	document.Paths = &v3.Paths{
 		PathItems: paths,
 	}
 
 	// Create tags from resources
 	document.Tags = g.createTagsFromResources(service)
+	if service.Operational.HasEndpoints() {
+		document.Tags = append(document.Tags, &base.Tag{
+			Name:        operationalTagName,
+			Description: operationalTagDescription,
+		})
+	}
+
+	// Group the tags into the tag groups of the resources
+	g.addTagGroupsExtension(document, service)
 
 	return document
 }
 
+// addTagGroupsExtension adds the x-tagGroups extension when any resource has a tag group. The groups are ordered
+// by their first resource, and the tags without a group are listed in a last group, named Other.
+func (g *generator) addTagGroupsExtension(document *v3.Document, service *specification.Service) {
+	var names []string
+	groups := make(map[string][]string)
+	for _, tag := range document.Tags {
+		group := otherTagGroupName
+		if resource := service.GetResource(tag.Name); resource != nil {
+			if tagGroup := service.GetTagGroup(*resource); tagGroup != "" {
+				group = tagGroup
+			}
+		}
+		if _, ok := groups[group]; !ok && group != otherTagGroupName {
+			names = append(names, group)
+		}
+		groups[group] = append(groups[group], tag.Name)
+	}
+
+	if len(names) == 0 {
+		return
+	}
+	if _, ok := groups[otherTagGroupName]; ok {
+		names = append(names, otherTagGroupName)
+	}
+
+	node := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+	for _, name := range names {
+		node.Content = append(node.Content, &yaml.Node{
+			Kind: yaml.MappingNode,
+			Tag:  "!!map",
+			Content: []*yaml.Node{
+				{Kind: yaml.ScalarNode, Tag: tagString, Value: "name"},
+				{Kind: yaml.ScalarNode, Tag: tagString, Value: name},
+				{Kind: yaml.ScalarNode, Tag: tagString, Value: "tags"},
+				createStringsNode(groups[name]),
+			},
+		})
+	}
+
+	if document.Extensions == nil {
+		document.Extensions = orderedmap.New[string, *yaml.Node]()
+	}
+	document.Extensions.Set(tagGroupsExtension, node)
+}
+
+// addOperationalEndpointsToPaths adds the health, readiness and version endpoints to paths.
+func (g *generator) addOperationalEndpointsToPaths(paths *orderedmap.Map[string, *v3.PathItem], service *specification.Service) {
+	if service.Operational.HasHealth() {
+		operation := g.createOperationalOperation(operationalHealthName, operationalHealthSummary, service)
+		operation.Responses = g.createOperationalCheckResponses()
+		paths.Set(specification.OperationalPathHealth, &v3.PathItem{Get: operation})
+	}
+
+	if service.Operational.HasReadiness() {
+		operation := g.createOperationalOperation(operationalReadinessName, operationalReadinessSummary, service)
+		operation.Responses = g.createOperationalCheckResponses()
+		paths.Set(specification.OperationalPathReadiness, &v3.PathItem{Get: operation})
+	}
+
+	if service.Operational.HasVersion() {
+		operation := g.createOperationalOperation(operationalVersionName, operationalVersionSummary, service)
+		responses := orderedmap.New[string, *v3.Response]()
+		responses.Set(httpStatus200, g.createOperationalResponse(operationalVersionDescription, operationalNameFieldName, operationalVersionFieldName))
+		operation.Responses = &v3.Responses{Codes: responses}
+		paths.Set(specification.OperationalPathVersion, &v3.PathItem{Get: operation})
+	}
+}
+
+// createOperationalOperation creates an unauthenticated operation for an operational endpoint.
+func (g *generator) createOperationalOperation(name, summary string, service *specification.Service) *v3.Operation {
+	operation := &v3.Operation{
+		OperationId: operationalTagName + name,
+		Summary:     summary,
+		Tags:        []string{operationalTagName},
+		Parameters:  []*v3.Parameter{g.createRequestIDParameter()},
+		Extensions:  orderedmap.New[string, *yaml.Node](),
+	}
+
+	// Operational endpoints are served without authentication
+	if len(service.Security) > 0 {
+		operation.Security = []*base.SecurityRequirement{{ContainsEmptyRequirement: true}}
+	}
+
+	if g.DisableSpeakeasyExtensions {
+		return operation
+	}
+
+	operation.Extensions.Set(speakeasyGroupExtension, &yaml.Node{Kind: yaml.ScalarNode, Value: specification.CamelCase(operationalTagName)})
+	operation.Extensions.Set(speakeasyNameOverrideExtension, &yaml.Node{Kind: yaml.ScalarNode, Value: specification.CamelCase(name)})
+
+	if service.Operational.IsExcludedFromSDK() {
+		operation.Extensions.Set(speakeasyIgnoreExtension, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: "true"})
+	}
+
+	return operation
+}
+
+// createOperationalCheckResponses creates the responses of the health and readiness endpoints.
+func (g *generator) createOperationalCheckResponses() *v3.Responses {
+	responses := orderedmap.New[string, *v3.Response]()
+	responses.Set(httpStatus200, g.createOperationalResponse(operationalCheckOKDescription, operationalStatusFieldName))
+	responses.Set(httpStatus503, g.createOperationalResponse(operationalCheckFailedDescription, operationalStatusFieldName))
+	return &v3.Responses{Codes: responses}
+}
+
+// createOperationalResponse creates a JSON response with an object of required string properties.
+func (g *generator) createOperationalResponse(description string, propertyNames ...string) *v3.Response {
+	properties := orderedmap.New[string, *base.SchemaProxy]()
+	for _, name := range propertyNames {
+		properties.Set(name, base.CreateSchemaProxy(&base.Schema{Type: []string{schemaTypeString}}))
+	}
+
+	content := orderedmap.New[string, *v3.MediaType]()
+	content.Set(contentTypeJSON, &v3.MediaType{
+		Schema: base.CreateSchemaProxy(&base.Schema{
+			Type:       []string{schemaTypeObject},
+			Properties: properties,
+			Required:   propertyNames,
+		}),
+	})
+
+	return &v3.Response{
+		Description: description,
+		Headers:     g.createRequestIDHeaders(),
+		Content:     content,
+	}
+}
+
+// getServerURL returns the URL of the server followed by the base path of the service, when the service sets one.
+func getServerURL(url string, service *specification.Service) string {
+	if service.BasePath == "" {
+		return url
+	}
+	return strings.TrimSuffix(url, "/") + service.GetBasePath()
+}
+
+// createServerVariables creates the variables of a server in the order of the specification, or nil without any.
+func createServerVariables(variables []specification.ServerVariable) *orderedmap.Map[string, *v3.ServerVariable] {
+	if len(variables) == 0 {
+		return nil
+	}
+
+	serverVariables := orderedmap.New[string, *v3.ServerVariable]()
+	for _, variable := range variables {
+		serverVariables.Set(variable.Name, &v3.ServerVariable{
+			Enum:        variable.Enum,
+			Default:     variable.Default,
+			Description: variable.Description,
+		})
+	}
+	return serverVariables
+}
+
 // createTagsFromResources creates a tags array from service resources for top-level document organization.
 func (g *generator) createTagsFromResources(service *specification.Service) []*base.Tag {
 	if len(service.Resources) == 0 {
@@ -639,10 +1169,16 @@ func (g *generator) createTagsFromResources(service *specification.Service) []*b
 		if resource.Development {
 			continue
 		}
-		tags = append(tags, &base.Tag{
-			Name:        resource.Name,
-			Description: resource.Description,
-		})
+		tag := &base.Tag{
+			Name:         resource.Name,
+			Description:  resource.Description,
+			ExternalDocs: g.createExternalDocs(resource.ExternalDocs),
+		}
+		// Sub-resources are nested under the tag of their parent, unless the parent is left out of the document
+		if parent := service.GetResource(resource.Parent); parent != nil && !parent.Development {
+			tag.Parent = parent.Name
+		}
+		tags = append(tags, tag)
 	}
 
 	if len(tags) == 0 {
@@ -652,6 +1188,18 @@ func (g *generator) createTagsFromResources(service *specification.Service) []*b
 	return tags
 }
 
+// createExternalDocs creates a base.ExternalDoc from the external documentation of the specification,
+// nil when there is none.
+func (g *generator) createExternalDocs(docs *specification.ExternalDocs) *base.ExternalDoc {
+	if docs == nil {
+		return nil
+	}
+	return &base.ExternalDoc{
+		Description: docs.Description,
+		URL:         docs.URL,
+	}
+}
+
 // createEnumSchema creates a base.Schema for an enum using native types.
 func (g *generator) createEnumSchema(enum specification.Enum) *base.Schema {
 	schema := &base.Schema{
@@ -659,31 +1207,111 @@ func (g *generator) createEnumSchema(enum specification.Enum) *base.Schema {
 		Description: enum.Description,
 	}
 
-	// Add enum values
+	valueTag := tagString // Ensure enum values are treated as strings
+	if enum.IsInt() {
+		schema.Type = []string{schemaTypeInteger}
+		valueTag = tagInt
+	}
+
+	// Add enum values, the wire values of the values
 	enumValues := make([]*yaml.Node, len(enum.Values))
 	for i, value := range enum.Values {
 		node := &yaml.Node{
 			Kind:  yaml.ScalarNode,
-			Value: value.Name,
-			Tag:   tagString, // Ensure enum values are treated as strings
+			Value: value.GetValue(),
+			Tag:   valueTag,
 		}
 		enumValues[i] = node
 	}
 	schema.Enum = enumValues
 
+	// Integer values say nothing about their meaning, so the names are added for code generators
+	if enum.IsInt() {
+		varNames := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		for _, value := range enum.Values {
+			varNames.Content = append(varNames.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: tagString, Value: value.Name})
+		}
+		schema.Extensions = orderedmap.New[string, *yaml.Node]()
+		schema.Extensions.Set(enumVarNamesExtension, varNames)
+	}
+
+	// Deprecated aliases are accepted but not listed as values, so they are only documented
+	if enum.HasAliases() {
+		aliases := []string{}
+		for _, value := range enum.Values {
+			for _, alias := range value.Aliases {
+				aliases = append(aliases, fmt.Sprintf("`%s` for `%s`", alias, value.GetValue()))
+			}
+		}
+		schema.Description = strings.TrimSpace(fmt.Sprintf(enumAliasesDescriptionTemplate, schema.Description, strings.Join(aliases, ", ")))
+	}
+
 	return schema
 }
 
 // createObjectSchema creates a base.Schema for an object using native types.
 func (g *generator) createObjectSchema(obj specification.Object, service *specification.Service) *base.Schema {
+	schema := g.createObjectFieldsSchema(obj, service)
+
+	// Objects serialized as XML are elements named by the object, like the types of the generated server
+	if service.HasXML() {
+		schema.XML = &base.XML{Name: obj.Name}
+	}
+
+	return schema
+}
+
+// createObjectFieldsSchema creates the schema of the fields of an object, composed with the object it extends when
+// ComposeExtendedObjects is set.
+func (g *generator) createObjectFieldsSchema(obj specification.Object, service *specification.Service) *base.Schema {
+	strict := service.IsStrict(obj)
+
+	if g.ComposeExtendedObjects && obj.HasParent() && service.HasObject(obj.Extends) {
+		ownSchema := g.createFieldsSchema(obj.GetOwnFields(service), "", service)
+		schema := &base.Schema{
+			Description: obj.Description,
+			AllOf: []*base.SchemaProxy{
+				base.CreateSchemaProxyRef(schemaReferencePrefix + obj.Extends),
+				base.CreateSchemaProxy(ownSchema),
+			},
+		}
+		// additionalProperties does not see the properties of the allOf schemas, unevaluatedProperties does
+		if strict {
+			schema.UnevaluatedProperties = &base.DynamicValue[*base.SchemaProxy, bool]{N: 1, B: false}
+		}
+		return schema
+	}
+
+	schema := g.createFieldsSchema(obj.Fields, obj.Description, service)
+
+	// A composed object includes the schema of the object it extends, which must then accept the fields of the extending object
+	if strict && !(g.ComposeExtendedObjects && isExtendedObject(obj, service)) {
+		schema.AdditionalProperties = &base.DynamicValue[*base.SchemaProxy, bool]{N: 1, B: false}
+	}
+
+	return schema
+}
+
+// isExtendedObject returns true if another object of the service extends the object.
+func isExtendedObject(obj specification.Object, service *specification.Service) bool {
+	for _, other := range service.Objects {
+		if other.Extends == obj.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// createFieldsSchema creates an object base.Schema with the given fields as properties.
+func (g *generator) createFieldsSchema(fields []specification.Field, description string, service *specification.Service) *base.Schema {
 	schema := &base.Schema{
 		Type:        []string{schemaTypeObject},
-		Description: obj.Description,
+		Description: description,
 		Properties:  orderedmap.New[string, *base.SchemaProxy](),
 	}
 
 	requiredFields := []string{}
-	for _, field := range obj.Fields {
+	for _, field := range fields {
 		fieldSchema := g.createFieldSchema(field, service)
 		proxy := base.CreateSchemaProxy(fieldSchema)
 		schema.Properties.Set(field.TagJSON(), proxy)
@@ -721,6 +1349,12 @@ func (g *generator) createFieldSchema(field specification.Field, service *specif
 		schema.Description = field.Description
 	}
 
+	// Link the resource the field references
+	if field.References != "" {
+		schema.Description = getReferencesDescription(field)
+		setReferencesExtension(schema, field)
+	}
+
 	// Handle nullable modifier
 	if field.IsNullable() {
 		// Use the Nullable field instead of appending "null" to type array
@@ -728,18 +1362,22 @@ func (g *generator) createFieldSchema(field specification.Field, service *specif
 		schema.Nullable = &nullable
 	}
 
-	// Add default value if present
-	if field.Default != "" {
-		defaultNode := &yaml.Node{
-			Kind:  yaml.ScalarNode,
-			Value: field.Default,
+	// Document the scopes required to read the field
+	if len(field.Scopes) > 0 {
+		if schema.Extensions == nil {
+			schema.Extensions = orderedmap.New[string, *yaml.Node]()
 		}
-		schema.Default = defaultNode
+		schema.Extensions.Set(requiredScopesExtension, createStringsNode(field.Scopes))
+	}
+
+	// Add default value if present, typed the same way as examples so that e.g. integers aren't quoted
+	if field.Default != "" {
+		schema.Default = g.createTypedExampleNode(service.GetPrimitiveType(field.Type), field.Default)
 	}
 
 	// Add example if present
 	if field.Example != "" {
-		exampleNode := g.createTypedExampleNode(field.Type, field.Example)
+		exampleNode := g.createTypedExampleNode(service.GetPrimitiveType(field.Type), field.Example)
 
 		// Handle array modifier - wrap the value in an array if needed
 		if field.IsArray() {
@@ -804,6 +1442,15 @@ func (g *generator) createFieldSchema(field specification.Field, service *specif
 	return schema
 }
 
+// createStringsNode creates a YAML sequence node of the strings, for the values of extensions.
+func createStringsNode(values []string) *yaml.Node {
+	node := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+	for _, value := range values {
+		node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: tagString, Value: value})
+	}
+	return node
+}
+
 // createParameterSchema creates a base.Schema for a field used in parameters, without description to avoid duplication.
 func (g *generator) createParameterSchema(field specification.Field, service *specification.Service) *base.Schema {
 	var schema *base.Schema
@@ -825,6 +1472,11 @@ func (g *generator) createParameterSchema(field specification.Field, service *sp
 		// Note: No description set here to avoid duplication with parameter description
 	}
 
+	// Name the resource the parameter references, which the parameter description links
+	if field.References != "" {
+		setReferencesExtension(schema, field)
+	}
+
 	// Handle nullable modifier
 	if field.IsNullable() {
 		// Use the Nullable field instead of appending "null" to type array
@@ -832,18 +1484,14 @@ func (g *generator) createParameterSchema(field specification.Field, service *sp
 		schema.Nullable = &nullable
 	}
 
-	// Add default value if present
+	// Add default value if present, typed the same way as examples so that e.g. integers aren't quoted
 	if field.Default != "" {
-		defaultNode := &yaml.Node{
-			Kind:  yaml.ScalarNode,
-			Value: field.Default,
-		}
-		schema.Default = defaultNode
+		schema.Default = g.createTypedExampleNode(service.GetPrimitiveType(field.Type), field.Default)
 	}
 
 	// Add example if present
 	if field.Example != "" {
-		exampleNode := g.createTypedExampleNode(field.Type, field.Example)
+		exampleNode := g.createTypedExampleNode(service.GetPrimitiveType(field.Type), field.Example)
 
 		// Handle array modifier - wrap the value in an array if needed
 		if field.IsArray() {
@@ -877,11 +1525,29 @@ func (g *generator) getTypeSchema(fieldType string, service *specification.Servi
 		return &base.Schema{Type: []string{schemaTypeString}}
 	case specification.FieldTypeInt:
 		return &base.Schema{Type: []string{schemaTypeInteger}}
+	case specification.FieldTypeInt32:
+		return &base.Schema{
+			Type:   []string{schemaTypeInteger},
+			Format: schemaFormatInt32,
+		}
+	case specification.FieldTypeUInt:
+		minimum := float64(0)
+		return &base.Schema{
+			Type:    []string{schemaTypeInteger},
+			Format:  schemaFormatInt64,
+			Minimum: &minimum,
+		}
 	case specification.FieldTypeFloat64:
 		return &base.Schema{
 			Type:   []string{schemaTypeNumber},
 			Format: schemaFormatDouble,
 		}
+	case specification.FieldTypeDecimal:
+		return &base.Schema{
+			Type:    []string{schemaTypeString},
+			Format:  schemaFormatDecimal,
+			Pattern: decimalPattern,
+		}
 	case specification.FieldTypeBool:
 		return &base.Schema{Type: []string{schemaTypeBoolean}}
 	case specification.FieldTypeUUID:
@@ -921,7 +1587,7 @@ func (g *generator) addResourceToPaths(resource specification.Resource, paths *o
 	// Group endpoints by path
 	pathGroups := make(map[string][]*specification.Endpoint)
 	for _, endpoint := range resource.Endpoints {
-		fullPath := endpoint.GetFullPath(resource.Name)
+		fullPath := service.Tenancy.GetPathPrefix() + service.GetEndpointPath(resource, endpoint)
 		pathGroups[fullPath] = append(pathGroups[fullPath], &endpoint)
 	}
 
@@ -962,25 +1628,53 @@ func (g *generator) addResourceToPaths(resource specification.Resource, paths *o
 // createOperation creates a v3.Operation from an endpoint using native types.
 func (g *generator) createOperation(endpoint specification.Endpoint, resource specification.Resource, service *specification.Service) *v3.Operation {
 	operation := &v3.Operation{
-		OperationId: resource.Name + endpoint.Name,
-		Summary:     endpoint.Summary,
-		Description: endpoint.Description,
-		Tags:        []string{resource.Name},
+		OperationId:  resource.Name + endpoint.Name,
+		Summary:      endpoint.Summary,
+		Description:  endpoint.Description,
+		Tags:         []string{resource.Name},
+		ExternalDocs: g.createExternalDocs(endpoint.ExternalDocs),
 	}
 
 	// Add parameters
 	parameters := []*v3.Parameter{}
 
-	// Path parameters
+	// Tenant parameter
+	if service.Tenancy != nil {
+		parameters = append(parameters, g.createTenantParameter(service))
+	}
+
+	// Path parameters
 	for _, param := range endpoint.Request.PathParams {
 		parameters = append(parameters, g.createParameter(param, "path", service))
 	}
 
-	// Query parameters
+	// Query parameters, referencing the components of parameter groups
 	for _, param := range endpoint.Request.QueryParams {
+		if groupName := endpoint.GetParameterGroupName(service, param.Name); groupName != "" {
+			parameters = append(parameters, g.createParameterReference(groupName, param.Name))
+			continue
+		}
+		if service.MaxLimit > 0 && param.TagJSON() == speakeasyLimitParamName && g.isPaginatedOperation(endpoint) {
+			parameters = append(parameters, g.createLimitParameter(param, service))
+			continue
+		}
 		parameters = append(parameters, g.createParameter(param, "query", service))
 	}
 
+	// Conditional GET of the resources that know when they were last updated
+	isConditionalGet := endpoint.IsConditionalGet(service)
+	if isConditionalGet {
+		parameters = append(parameters, g.createIfModifiedSinceParameter())
+	}
+
+	// Request ID, propagated to the response
+	parameters = append(parameters, g.createRequestIDParameter())
+
+	// Locale of the error messages
+	if service.Localization != nil {
+		parameters = append(parameters, g.createAcceptLanguageParameter(service))
+	}
+
 	operation.Parameters = parameters
 
 	// Request body - use reference to components section instead of inline definition
@@ -995,6 +1689,10 @@ func (g *generator) createOperation(endpoint specification.Endpoint, resource sp
 	successResponse := g.createResponseReference(endpoint.Response, resource.Name, endpoint.Name, service)
 	responses.Set(strconv.Itoa(endpoint.Response.StatusCode), successResponse)
 
+	if isConditionalGet {
+		responses.Set(httpStatus304, g.createNotModifiedResponse())
+	}
+
 	// Add error responses
 	g.addErrorResponses(responses, endpoint, resource, service)
 
@@ -1007,6 +1705,62 @@ func (g *generator) createOperation(endpoint specification.Endpoint, resource sp
 		g.addSpeakeasyPaginationExtension(operation)
 	}
 
+	// Document the requests sent to the URLs of the subscribers
+	if len(endpoint.Callbacks) > 0 {
+		operation.Callbacks = g.createCallbacks(endpoint, resource)
+	}
+
+	// Add Speakeasy polling extension to the operation polling the operations of the async endpoints
+	if service.IsOperationPoll(resource, endpoint) {
+		g.addSpeakeasyPollingExtension(operation, endpoint)
+	}
+
+	// Add operation level security when the endpoint requires scopes
+	if scopes := endpoint.GetRequiredScopes(resource); len(scopes) > 0 && len(service.Security) > 0 {
+		operation.Security = g.createSecurityRequirements(service, scopes)
+	}
+
+	// Document the permissions required by the endpoint
+	if permissions := endpoint.GetRequiredPermissions(resource); len(permissions) > 0 {
+		if operation.Extensions == nil {
+			operation.Extensions = orderedmap.New[string, *yaml.Node]()
+		}
+		operation.Extensions.Set(permissionsExtension, createStringsNode(permissions))
+	}
+
+	// Document the stability of the endpoint
+	if stability := endpoint.GetStability(resource); stability != "" {
+		if operation.Extensions == nil {
+			operation.Extensions = orderedmap.New[string, *yaml.Node]()
+		}
+		operation.Extensions.Set(stabilityExtension, &yaml.Node{Kind: yaml.ScalarNode, Tag: tagString, Value: stability})
+	}
+
+	// Document the feature flag the endpoint is gated behind
+	if endpoint.FeatureFlag != "" {
+		if operation.Extensions == nil {
+			operation.Extensions = orderedmap.New[string, *yaml.Node]()
+		}
+		operation.Extensions.Set(featureFlagExtension, &yaml.Node{Kind: yaml.ScalarNode, Tag: tagString, Value: endpoint.FeatureFlag})
+	}
+
+	// Document the request limits of the endpoint
+	if maxBodyBytes := endpoint.GetMaxBodyBytes(service); maxBodyBytes > 0 && len(endpoint.Request.BodyParams) > 0 {
+		if operation.Extensions == nil {
+			operation.Extensions = orderedmap.New[string, *yaml.Node]()
+		}
+		operation.Extensions.Set(maxBodyBytesExtension, &yaml.Node{Kind: yaml.ScalarNode, Tag: tagInt, Value: strconv.FormatInt(maxBodyBytes, 10)})
+	}
+	if timeout := endpoint.GetHandlerTimeout(service); timeout > 0 {
+		if operation.Extensions == nil {
+			operation.Extensions = orderedmap.New[string, *yaml.Node]()
+		}
+		operation.Extensions.Set(handlerTimeoutExtension, &yaml.Node{Kind: yaml.ScalarNode, Tag: tagInt, Value: strconv.Itoa(timeout)})
+	}
+
+	// Add samples of the request for documentation portals
+	g.addCodeSamplesExtension(operation, endpoint, resource, service)
+
 	// Add Speakeasy operation naming extensions
 	g.addSpeakeasyOperationNamingExtensions(operation, endpoint, resource)
 
@@ -1023,10 +1777,122 @@ func (g *generator) createParameter(field specification.Field, location string,
 		Required:    &isRequired,
 		Schema:      base.CreateSchemaProxy(g.createParameterSchema(field, service)),
 	}
+	if field.References != "" {
+		param.Description = getReferencesDescription(field)
+	}
+
+	return param
+}
+
+// createLimitParameter creates the limit query parameter of a paginated operation, with the max limit of the service
+// as the maximum of its schema.
+func (g *generator) createLimitParameter(field specification.Field, service *specification.Service) *v3.Parameter {
+	param := g.createParameter(field, "query", service)
+
+	schema := g.createParameterSchema(field, service)
+	minimum, maximum := float64(1), float64(service.MaxLimit)
+	schema.Minimum = &minimum
+	schema.Maximum = &maximum
+	param.Schema = base.CreateSchemaProxy(schema)
 
 	return param
 }
 
+// getReferencesDescription returns the description of a field referencing a resource, linking the schema of the resource.
+func getReferencesDescription(field specification.Field) string {
+	return strings.TrimSpace(fmt.Sprintf(referencesDescriptionTemplate, field.Description, field.References, schemaReferencePrefix+field.References))
+}
+
+// setReferencesExtension sets the resource the field references on its schema.
+func setReferencesExtension(schema *base.Schema, field specification.Field) {
+	if schema.Extensions == nil {
+		schema.Extensions = orderedmap.New[string, *yaml.Node]()
+	}
+	schema.Extensions.Set(referencesExtension, &yaml.Node{Kind: yaml.ScalarNode, Tag: tagString, Value: field.References})
+}
+
+// addParameterGroupsToComponents adds the fields of all parameter groups to the parameters section of the components.
+func (g *generator) addParameterGroupsToComponents(components *v3.Components, service *specification.Service) {
+	if len(service.ParameterGroups) == 0 {
+		return
+	}
+
+	components.Parameters = orderedmap.New[string, *v3.Parameter]()
+	for _, group := range service.ParameterGroups {
+		for _, field := range group.Fields {
+			components.Parameters.Set(g.createParameterName(group.Name, field.Name), g.createParameter(field, "query", service))
+		}
+	}
+}
+
+// createParameterName creates a systematic name for parameter group components.
+func (g *generator) createParameterName(groupName, fieldName string) string {
+	return groupName + fieldName
+}
+
+// createParameterReference creates a reference to a parameter group component.
+func (g *generator) createParameterReference(groupName, fieldName string) *v3.Parameter {
+	// Since v3.Parameter doesn't directly support references, we use the Extensions field
+	// with a $ref YAML node to create a reference that serializes properly
+	extensions := orderedmap.New[string, *yaml.Node]()
+	extensions.Set("$ref", &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: parameterReferencePrefix + g.createParameterName(groupName, fieldName)})
+
+	return &v3.Parameter{
+		Extensions: extensions,
+	}
+}
+
+// createTenantParameter creates the v3.Parameter identifying the tenant of the request.
+func (g *generator) createTenantParameter(service *specification.Service) *v3.Parameter {
+	isRequired := true
+	return &v3.Parameter{
+		Name:        service.Tenancy.GetParameterName(),
+		In:          service.Tenancy.In,
+		Description: service.Tenancy.GetField().Description,
+		Required:    &isRequired,
+		Schema:      base.CreateSchemaProxy(&base.Schema{Type: []string{schemaTypeString}}),
+	}
+}
+
+// createRequestIDParameter creates the v3.Parameter of the request ID, which is optional on every operation.
+func (g *generator) createRequestIDParameter() *v3.Parameter {
+	isRequired := false
+	return &v3.Parameter{
+		Name:        specification.RequestIDHeaderName,
+		In:          "header",
+		Description: requestIDParameterDescription,
+		Required:    &isRequired,
+		Schema:      base.CreateSchemaProxy(&base.Schema{Type: []string{schemaTypeString}}),
+	}
+}
+
+// createIfModifiedSinceParameter creates the v3.Parameter of the If-Modified-Since header of conditional GET requests.
+func (g *generator) createIfModifiedSinceParameter() *v3.Parameter {
+	isRequired := false
+	return &v3.Parameter{
+		Name:        specification.IfModifiedSinceHeaderName,
+		In:          "header",
+		Description: ifModifiedSinceDescription,
+		Required:    &isRequired,
+		Schema:      base.CreateSchemaProxy(&base.Schema{Type: []string{schemaTypeString}}),
+	}
+}
+
+// createAcceptLanguageParameter creates the v3.Parameter selecting the locale of the error messages,
+// documenting the locales of the message catalog of the service.
+func (g *generator) createAcceptLanguageParameter(service *specification.Service) *v3.Parameter {
+	isRequired := false
+	locales := service.Localization.GetLocales()
+	defaultNode := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: service.Localization.DefaultLocale}
+	return &v3.Parameter{
+		Name:        acceptLanguageHeaderName,
+		In:          "header",
+		Description: fmt.Sprintf(acceptLanguageDescription, strings.Join(locales, ", "), service.Localization.DefaultLocale),
+		Required:    &isRequired,
+		Schema:      base.CreateSchemaProxy(&base.Schema{Type: []string{schemaTypeString}, Default: defaultNode}),
+	}
+}
+
 // addRequestBodiesToComponents extracts request bodies from all endpoints and adds them to the components section.
 func (g *generator) addRequestBodiesToComponents(components *v3.Components, service *specification.Service) {
 	// Track unique request bodies to avoid duplicates
@@ -1043,7 +1909,7 @@ func (g *generator) addRequestBodiesToComponents(components *v3.Components, serv
 
 				// Only add if we haven't seen this request body before
 				if _, exists := requestBodyMap[requestBodyName]; !exists {
-					requestBody := g.createComponentRequestBody(endpoint.Request.BodyParams, service)
+					requestBody := g.createComponentRequestBody(endpoint, resource, service)
 					requestBodyMap[requestBodyName] = requestBody
 					components.RequestBodies.Set(requestBodyName, requestBody)
 				}
@@ -1061,7 +1927,8 @@ func (g *generator) createRequestBodyName(resourceName, endpointName string) str
 func (g *generator) isPrimitiveType(fieldType string) bool {
 	switch fieldType {
 	case specification.FieldTypeUUID, specification.FieldTypeDate, specification.FieldTypeTimestamp,
-		specification.FieldTypeString, specification.FieldTypeInt, specification.FieldTypeFloat64, specification.FieldTypeBool:
+		specification.FieldTypeString, specification.FieldTypeInt, specification.FieldTypeInt32, specification.FieldTypeUInt,
+		specification.FieldTypeFloat64, specification.FieldTypeDecimal, specification.FieldTypeBool:
 		return true
 	default:
 		return false
@@ -1071,7 +1938,7 @@ func (g *generator) isPrimitiveType(fieldType string) bool {
 // createTypedExampleNode creates a properly typed YAML node for an example value based on the field type.
 func (g *generator) createTypedExampleNode(fieldType, exampleValue string) *yaml.Node {
 	switch fieldType {
-	case specification.FieldTypeInt:
+	case specification.FieldTypeInt, specification.FieldTypeInt32, specification.FieldTypeUInt:
 		// For integer types, create a numeric node
 		return &yaml.Node{
 			Kind:  yaml.ScalarNode,
@@ -1093,7 +1960,7 @@ func (g *generator) createTypedExampleNode(fieldType, exampleValue string) *yaml
 			Value: exampleValue,
 		}
 	case specification.FieldTypeString, specification.FieldTypeUUID,
-		specification.FieldTypeDate, specification.FieldTypeTimestamp:
+		specification.FieldTypeDate, specification.FieldTypeTimestamp, specification.FieldTypeDecimal:
 		// For string-based types, create a string node
 		return &yaml.Node{
 			Kind:  yaml.ScalarNode,
@@ -1133,12 +2000,25 @@ func (g *generator) generateRequestBodyExample(bodyParams []specification.Field,
 }
 
 // generateObjectExampleWithVisited generates an example from an object definition with circular reference protection.
+// The example is memoized by exampleKey, so circular references are cut the same way as when it is generated.
+// The returned node is shared and must not be modified.
 func (g *generator) generateObjectExampleWithVisited(obj specification.Object, service *specification.Service, visited map[string]bool) *yaml.Node {
 	// Check for circular reference
 	if visited[obj.Name] {
 		return nil
 	}
 
+	if g.exampleService != service {
+		g.objectExamples = make(map[string]*yaml.Node)
+		g.referencedObjects = make(map[string][]string)
+		g.exampleService = service
+	}
+
+	key := g.exampleKey(obj, service, visited)
+	if example, ok := g.objectExamples[key]; ok {
+		return example
+	}
+
 	// Mark this object as visited
 	visited[obj.Name] = true
 	defer func() {
@@ -1146,7 +2026,62 @@ func (g *generator) generateObjectExampleWithVisited(obj specification.Object, s
 		delete(visited, obj.Name)
 	}()
 
-	return g.generateObjectExampleFromFieldsWithVisited(obj.Fields, service, visited)
+	example := g.generateObjectExampleFromFieldsWithVisited(obj.Fields, service, visited)
+	g.objectExamples[key] = example
+
+	return example
+}
+
+// exampleKey returns the key of the example of the object for the visited objects. Circular reference protection
+// only cuts the visited objects that the object references, so the example is the same for all visited objects
+// with the same referenced ones, e.g. none of them, which is the case for every object without circular references.
+func (g *generator) exampleKey(obj specification.Object, service *specification.Service, visited map[string]bool) string {
+	key := obj.Name
+	for _, name := range g.getReferencedObjects(obj, service) {
+		if visited[name] {
+			key += "/" + name
+		}
+	}
+	return key
+}
+
+// getReferencedObjects returns the names of the objects that the fields of the object reference, directly or
+// through other objects, in the order they are found.
+func (g *generator) getReferencedObjects(obj specification.Object, service *specification.Service) []string {
+	if referenced, ok := g.referencedObjects[obj.Name]; ok {
+		return referenced
+	}
+
+	referenced := []string{}
+	found := map[string]bool{obj.Name: true}
+
+	var addFields func(fields []specification.Field)
+	addFields = func(fields []specification.Field) {
+		for _, field := range fields {
+			if found[field.Type] {
+				continue
+			}
+
+			if fieldObject := service.GetObject(field.Type); fieldObject != nil {
+				found[field.Type] = true
+				referenced = append(referenced, field.Type)
+				addFields(fieldObject.Fields)
+			}
+		}
+	}
+	addFields(obj.Fields)
+
+	g.referencedObjects[obj.Name] = referenced
+	return referenced
+}
+
+// fieldExample returns the example of the field, or a deterministic generated example if the field has none.
+func (g *generator) fieldExample(field specification.Field, service *specification.Service) string {
+	if field.Example != "" {
+		return field.Example
+	}
+
+	return examplegen.New(service, examplegen.DefaultSeed).Field(field)
 }
 
 // generateObjectExampleFromFields generates an example object from a slice of fields.
@@ -1172,15 +2107,14 @@ func (g *generator) generateObjectExampleFromFieldsWithVisited(fields []specific
 	for _, field := range fields {
 		var valueNode *yaml.Node
 
-		// For enum or primitive types, use field example if available
+		// For enum or primitive types, use field example or fall back to a generated example
 		if g.isPrimitiveType(field.Type) || service.HasEnum(field.Type) {
-			if field.Example != "" {
-				valueNode = g.createTypedExampleNode(field.Type, field.Example)
+			if example := g.fieldExample(field, service); example != "" {
+				valueNode = g.createTypedExampleNode(service.GetPrimitiveType(field.Type), example)
 			}
 		} else if service.HasObject(field.Type) {
 			// For object types, recursively generate example from object definition with circular reference protection
-			obj := service.GetObject(field.Type)
-			if obj != nil && !visited[field.Type] {
+			if obj := service.GetObject(field.Type); obj != nil {
 				valueNode = g.generateObjectExampleWithVisited(*obj, service, visited)
 			}
 		}
@@ -1216,6 +2150,113 @@ func (g *generator) generateObjectExampleFromFieldsWithVisited(fields []specific
 	return objNode
 }
 
+// createRequestExamples creates the examples of a request body. The named examples of the resource are used
+// when they have values for the body parameters, otherwise a single example is synthesized from the fields.
+func (g *generator) createRequestExamples(bodyParams []specification.Field, resource specification.Resource, service *specification.Service) *orderedmap.Map[string, *base.Example] {
+	if examples := g.createResourceExamples(resource, bodyParams, service); examples != nil {
+		return examples
+	}
+
+	example := g.generateRequestBodyExample(bodyParams, service)
+	if example == nil {
+		return nil
+	}
+
+	// Use Examples (plural) for complex objects per OpenAPI 3.0+ specification
+	examples := orderedmap.New[string, *base.Example]()
+	examples.Set("requestExample", &base.Example{
+		Summary: "Request body example",
+		Value:   example,
+	})
+	return examples
+}
+
+// createResponseExamples creates the examples of a response body. The named examples of the resource are used
+// when the response returns the resource, otherwise a single example is synthesized from the response definition.
+func (g *generator) createResponseExamples(response specification.EndpointResponse, resourceName string, service *specification.Service) *orderedmap.Map[string, *base.Example] {
+	if response.BodyObject != nil && *response.BodyObject == resourceName {
+		resource, object := service.GetResource(resourceName), service.GetObject(resourceName)
+		if resource != nil && object != nil {
+			if examples := g.createResourceExamples(*resource, object.Fields, service); examples != nil {
+				return examples
+			}
+		}
+	}
+
+	responseExample := g.generateResponseBodyExample(response, service)
+	if responseExample == nil {
+		return nil
+	}
+
+	// Use Examples (plural) for complex objects per OpenAPI 3.0+ specification
+	examples := orderedmap.New[string, *base.Example]()
+	examples.Set("responseExample", &base.Example{
+		Summary: "Response body example",
+		Value:   responseExample,
+	})
+	return examples
+}
+
+// createResourceExamples creates the named examples of a resource for a body with the given fields.
+// Only the values of the given fields are included, and examples without any of them are skipped.
+// Returns nil if no example has values for the fields.
+func (g *generator) createResourceExamples(resource specification.Resource, fields []specification.Field, service *specification.Service) *orderedmap.Map[string, *base.Example] {
+	examples := orderedmap.New[string, *base.Example]()
+
+	for _, example := range resource.Examples {
+		valueNode := &yaml.Node{
+			Kind: yaml.MappingNode,
+			Tag:  "!!map",
+		}
+
+		for _, field := range fields {
+			value, ok := example.GetValue(field)
+			if !ok {
+				continue
+			}
+
+			valueNode.Content = append(valueNode.Content,
+				&yaml.Node{Kind: yaml.ScalarNode, Tag: tagString, Value: field.TagJSON()},
+				g.createExampleValueNode(field, value, service),
+			)
+		}
+
+		if len(valueNode.Content) == 0 {
+			continue
+		}
+
+		examples.Set(example.Name, &base.Example{
+			Summary:     example.Summary,
+			Description: example.Description,
+			Value:       valueNode,
+		})
+	}
+
+	if examples.Len() == 0 {
+		return nil
+	}
+
+	return examples
+}
+
+// createExampleValueNode creates the YAML node of a value from a resource example. Scalar values are typed
+// after the field type like the field examples, while objects and arrays are encoded as they are written.
+func (g *generator) createExampleValueNode(field specification.Field, value any, service *specification.Service) *yaml.Node {
+	switch v := value.(type) {
+	case nil:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}
+	case map[string]any, []any:
+		node := &yaml.Node{}
+		if err := node.Encode(v); err == nil {
+			return node
+		}
+	case float64:
+		return g.createTypedExampleNode(service.GetPrimitiveType(field.Type), strconv.FormatFloat(v, 'f', -1, 64))
+	}
+
+	return g.createTypedExampleNode(service.GetPrimitiveType(field.Type), fmt.Sprint(value))
+}
+
 // generateResponseBodyExample generates an example value for a response body based on the response definition.
 // For responses with BodyObject, it generates an example from the object definition.
 // For responses with BodyFields, it generates an example from the field definitions.
@@ -1241,8 +2282,11 @@ func (g *generator) generateResponseBodyExample(response specification.EndpointR
 	return nil
 }
 
-// createComponentRequestBody creates a v3.RequestBody for the components section.
-func (g *generator) createComponentRequestBody(bodyParams []specification.Field, service *specification.Service) *v3.RequestBody {
+// createComponentRequestBody creates a v3.RequestBody for the components section, as XML for the endpoints with
+// XML requests and JSON otherwise.
+func (g *generator) createComponentRequestBody(endpoint specification.Endpoint, resource specification.Resource, service *specification.Service) *v3.RequestBody {
+	bodyParams := endpoint.Request.BodyParams
+
 	// Always wrap body parameters in an object with field names as properties
 	// This ensures consistency between schema and examples
 	schema := &base.Schema{
@@ -1265,6 +2309,10 @@ func (g *generator) createComponentRequestBody(bodyParams []specification.Field,
 		schema.Required = requiredFields
 	}
 
+	if service.Strict {
+		schema.AdditionalProperties = &base.DynamicValue[*base.SchemaProxy, bool]{N: 1, B: false}
+	}
+
 	isRequired := len(requiredFields) > 0
 
 	// Create media type
@@ -1273,18 +2321,20 @@ func (g *generator) createComponentRequestBody(bodyParams []specification.Field,
 	}
 
 	// Generate examples for the request body
-	if example := g.generateRequestBodyExample(bodyParams, service); example != nil {
-		// Use Examples (plural) for complex objects per OpenAPI 3.0+ specification
-		examples := orderedmap.New[string, *base.Example]()
-		examples.Set("requestExample", &base.Example{
-			Summary: "Request body example",
-			Value:   example,
-		})
-		mediaType.Examples = examples
-	}
+	mediaType.Examples = g.createRequestExamples(bodyParams, resource, service)
 
 	content := orderedmap.New[string, *v3.MediaType]()
-	content.Set(contentTypeJSON, mediaType)
+	switch {
+	case endpoint.HasXMLRequest():
+		schema.XML = &base.XML{Name: endpoint.GetBodyParamsType(resource.Name)}
+		content.Set(specification.ContentTypeXML, mediaType)
+	case endpoint.HasFormRequest():
+		// The default encoding of form bodies (style form, exploded) repeats the key for each value of an array,
+		// which is how the generated server parses them, so no encoding object is needed
+		content.Set(specification.ContentTypeForm, mediaType)
+	default:
+		content.Set(contentTypeJSON, mediaType)
+	}
 
 	return &v3.RequestBody{
 		Description: requestBodyDescription,
@@ -1311,6 +2361,17 @@ func (g *generator) addResponseBodiesToComponents(components *v3.Components, ser
 				// Only add if we haven't seen this response body before
 				if _, exists := responseBodyMap[responseBodyName]; !exists {
 					responseBody := g.createComponentResponse(endpoint.Response, resource.Name, endpoint.Name, service)
+					if endpoint.IsConditionalGet(service) {
+						responseBody.Headers.Set(specification.LastModifiedHeaderName, g.createLastModifiedHeader())
+					}
+					if endpoint.Async {
+						responseBody.Headers.Set(specification.LocationHeaderName, g.createHeader(locationHeaderDescription, schemaTypeString))
+						responseBody.Headers.Set(specification.RetryAfterHeaderName, g.createHeader(retryAfterHeaderDescription, schemaTypeInteger))
+						responseBody.Links = g.createOperationLinks(service)
+					}
+					if service.IsOperationPoll(resource, endpoint) {
+						responseBody.Headers.Set(specification.RetryAfterHeaderName, g.createHeader(retryAfterHeaderDescription, schemaTypeInteger))
+					}
 					responseBodyMap[responseBodyName] = responseBody
 					components.Responses.Set(responseBodyName, responseBody)
 				}
@@ -1375,8 +2436,12 @@ func (g *generator) generateAutoErrorDescription(resourceName, endpointName, sta
 		return fmt.Sprintf(autoErrorForbiddenTemplate, resourceName, endpointName)
 	case httpStatus404:
 		return fmt.Sprintf(autoErrorNotFoundTemplate, resourceName, endpointName)
+	case httpStatus408:
+		return fmt.Sprintf(autoErrorRequestTimeoutTemplate, resourceName, endpointName)
 	case httpStatus409:
 		return fmt.Sprintf(autoErrorConflictTemplate, resourceName, endpointName)
+	case httpStatus413:
+		return fmt.Sprintf(autoErrorPayloadTooLargeTemplate, resourceName, endpointName)
 	case httpStatus422:
 		return fmt.Sprintf(autoErrorUnprocessableTemplate, resourceName, endpointName)
 	case httpStatus429:
@@ -1389,23 +2454,92 @@ func (g *generator) generateAutoErrorDescription(resourceName, endpointName, sta
 	}
 }
 
-// createResponseHeaders creates an ordered map of response headers from the service's common response headers.
-func (g *generator) createResponseHeaders(service *specification.Service) *orderedmap.Map[string, *v3.Header] {
-	if len(service.ResponseHeaders) == 0 {
-		return nil
+// createRequestIDHeaders creates an ordered map of response headers with the request ID header.
+func (g *generator) createRequestIDHeaders() *orderedmap.Map[string, *v3.Header] {
+	headers := orderedmap.New[string, *v3.Header]()
+	headers.Set(specification.RequestIDHeaderName, &v3.Header{
+		Description: requestIDHeaderDescription,
+		Schema:      base.CreateSchemaProxy(&base.Schema{Type: []string{schemaTypeString}}),
+	})
+	return headers
+}
+
+// createLastModifiedHeader creates the v3.Header of the time the resource of a conditional GET was last updated.
+func (g *generator) createLastModifiedHeader() *v3.Header {
+	return &v3.Header{
+		Description: lastModifiedDescription,
+		Schema:      base.CreateSchemaProxy(&base.Schema{Type: []string{schemaTypeString}}),
 	}
+}
+
+// createHeader creates a v3.Header of the schema type with the description.
+func (g *generator) createHeader(description, schemaType string) *v3.Header {
+	return &v3.Header{
+		Description: description,
+		Schema:      base.CreateSchemaProxy(&base.Schema{Type: []string{schemaType}}),
+	}
+}
+
+// createOperationLinks creates the link of the responses of the asynchronous endpoints to the operation polling the
+// Operation they started, passing the ID of the response as the path parameter.
+func (g *generator) createOperationLinks(service *specification.Service) *orderedmap.Map[string, *v3.Link] {
+	links := orderedmap.New[string, *v3.Link]()
+	for _, resource := range service.Resources {
+		for _, endpoint := range resource.Endpoints {
+			if !service.IsOperationPoll(resource, endpoint) {
+				continue
+			}
+
+			parameters := orderedmap.New[string, string]()
+			for _, param := range endpoint.Request.PathParams {
+				parameters.Set(param.TagJSON(), "$response.body#/"+param.TagJSON())
+			}
+			links.Set(operationLinkName, &v3.Link{
+				OperationId: resource.Name + endpoint.Name,
+				Parameters:  parameters,
+				Description: operationLinkDescription,
+			})
+		}
+	}
+	return links
+}
+
+// createNotModifiedResponse creates the v3.Response of a conditional GET of a resource that has not been updated
+// since the If-Modified-Since header, which has no body.
+func (g *generator) createNotModifiedResponse() *v3.Response {
+	headers := g.createRequestIDHeaders()
+	headers.Set(specification.LastModifiedHeaderName, g.createLastModifiedHeader())
+
+	return &v3.Response{
+		Description: notModifiedDescription,
+		Headers:     headers,
+	}
+}
+
+// createResponseHeaders creates an ordered map of response headers, the request ID header that is set on every
+// response followed by the service's common response headers.
+func (g *generator) createResponseHeaders(service *specification.Service) *orderedmap.Map[string, *v3.Header] {
+	headers := g.createRequestIDHeaders()
 
-	headers := orderedmap.New[string, *v3.Header]()
 	for _, headerField := range service.ResponseHeaders {
-		header := &v3.Header{
-			Description: headerField.Description,
-			Schema:      base.CreateSchemaProxy(g.createParameterSchema(headerField, service)),
+		// The request ID header is documented once, whatever case the service declares it in
+		if strings.EqualFold(headerField.GetHeaderName(), specification.RequestIDHeaderName) {
+			continue
 		}
-		headers.Set(headerField.Name, header)
+
+		headers.Set(headerField.GetHeaderName(), g.createFieldHeader(headerField, service))
 	}
 	return headers
 }
 
+// createFieldHeader creates a v3.Header of the response header field.
+func (g *generator) createFieldHeader(headerField specification.Field, service *specification.Service) *v3.Header {
+	return &v3.Header{
+		Description: headerField.Description,
+		Schema:      base.CreateSchemaProxy(g.createParameterSchema(headerField, service)),
+	}
+}
+
 // createComponentResponse creates a v3.Response for the components section.
 func (g *generator) createComponentResponse(response specification.EndpointResponse, resourceName, endpointName string, service *specification.Service) *v3.Response {
 	componentResponse := &v3.Response{}
@@ -1416,9 +2550,18 @@ func (g *generator) createComponentResponse(response specification.EndpointRespo
 		componentResponse.Description = description
 	}
 
-	// Add common response headers
-	if headers := g.createResponseHeaders(service); headers != nil {
-		componentResponse.Headers = headers
+	// Add common response headers, followed by the headers the response sets through its header fields
+	componentResponse.Headers = g.createResponseHeaders(service)
+	for _, headerField := range response.GetHeaderFields() {
+		componentResponse.Headers.Set(headerField.GetHeaderName(), g.createFieldHeader(headerField, service))
+	}
+
+	// CSV responses are documented as text with the rows of the body object
+	if response.ContentType == specification.ContentTypeCSV && response.BodyObject != nil {
+		content := orderedmap.New[string, *v3.MediaType]()
+		content.Set(specification.ContentTypeCSV, g.createCSVMediaType(*response.BodyObject, service))
+		componentResponse.Content = content
+		return componentResponse
 	}
 
 	// Add response content if present
@@ -1445,7 +2588,7 @@ func (g *generator) createComponentResponse(response specification.EndpointRespo
 
 				// Add example to field property if field has example
 				if field.Example != "" {
-					exampleNode := g.createTypedExampleNode(field.Type, field.Example)
+					exampleNode := g.createTypedExampleNode(service.GetPrimitiveType(field.Type), field.Example)
 					// Handle array modifier - wrap the value in an array if needed
 					if field.IsArray() {
 						arrayNode := &yaml.Node{
@@ -1487,18 +2630,33 @@ func (g *generator) createComponentResponse(response specification.EndpointRespo
 				Schema: base.CreateSchemaProxy(schema),
 			}
 
-			// Generate response example
-			if responseExample := g.generateResponseBodyExample(response, service); responseExample != nil {
-				// Use Examples (plural) for complex objects per OpenAPI 3.0+ specification
-				examples := orderedmap.New[string, *base.Example]()
-				examples.Set("responseExample", &base.Example{
-					Summary: "Response body example",
-					Value:   responseExample,
-				})
-				mediaType.Examples = examples
+			// Generate response examples
+			mediaType.Examples = g.createResponseExamples(response, resourceName, service)
+
+			// XML responses have the name of the response type as their root element
+			if response.ContentType == specification.ContentTypeXML {
+				schema.XML = &base.XML{Name: specification.Endpoint{Name: endpointName, Response: response}.GetResponseType(resourceName)}
+				content.Set(specification.ContentTypeXML, mediaType)
+				componentResponse.Content = content
+				return componentResponse
 			}
 
 			content.Set(contentTypeJSON, mediaType)
+
+			// The alternative content types are documented as text, since their format is up to the server, except XML
+			// which has the schema of the response
+			for _, contentType := range response.AlternativeContentTypes {
+				if contentType == specification.ContentTypeXML {
+					xmlSchema := *schema
+					xmlSchema.XML = &base.XML{Name: specification.Endpoint{Name: endpointName, Response: response}.GetResponseType(resourceName)}
+					content.Set(contentType, &v3.MediaType{Schema: base.CreateSchemaProxy(&xmlSchema)})
+					continue
+				}
+				content.Set(contentType, &v3.MediaType{
+					Schema: base.CreateSchemaProxy(&base.Schema{Type: []string{schemaTypeString}}),
+				})
+			}
+
 			componentResponse.Content = content
 		}
 	}
@@ -1506,6 +2664,33 @@ func (g *generator) createComponentResponse(response specification.EndpointRespo
 	return componentResponse
 }
 
+// createCSVMediaType creates the media type of a CSV response with rows of the object, a string with the header row
+// and a row of example values as its example, see specification.Service.GetCSVColumns.
+func (g *generator) createCSVMediaType(objectName string, service *specification.Service) *v3.MediaType {
+	columns := service.GetCSVColumns(objectName)
+
+	header := make([]string, len(columns))
+	row := make([]string, len(columns))
+	for i, column := range columns {
+		header[i] = column.Name
+		if !column.Field.IsArray() && !service.HasObject(column.Field.Type) {
+			row[i] = g.fieldExample(column.Field, service)
+		}
+	}
+
+	var example bytes.Buffer
+	csvWriter := csv.NewWriter(&example)
+	_ = csvWriter.WriteAll([][]string{header, row})
+
+	return &v3.MediaType{
+		Schema: base.CreateSchemaProxy(&base.Schema{
+			Type:        []string{schemaTypeString},
+			Description: fmt.Sprintf("CSV with a header row and a row per %s", objectName),
+		}),
+		Example: &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: example.String()},
+	}
+}
+
 // generate422ErrorExample generates a realistic example for a 422 error response.
 func (g *generator) generate422ErrorExample(resourceName, endpointName string, service *specification.Service) *yaml.Node {
 	// Create the root object
@@ -1525,7 +2710,7 @@ func (g *generator) generate422ErrorExample(resourceName, endpointName string, s
 	return rootNode
 }
 
-// generateErrorObjectExample generates an Error object example with code, message, and requestID fields.
+// generateErrorObjectExample generates an Error object example with code, message, requestID, and fields fields.
 func (g *generator) generateErrorObjectExample(resourceName, endpointName string) *yaml.Node {
 	errorObjectNode := &yaml.Node{
 		Kind: yaml.MappingNode,
@@ -1570,42 +2755,182 @@ func (g *generator) generateErrorObjectExample(resourceName, endpointName string
 	}
 	errorObjectNode.Content = append(errorObjectNode.Content, requestIDKeyNode, requestIDValueNode)
 
+	// Add fields field with the error of an invalid field
+	fieldsKeyNode := &yaml.Node{
+		Kind:  yaml.ScalarNode,
+		Tag:   "!!str",
+		Value: fieldsFieldName,
+	}
+	errorObjectNode.Content = append(errorObjectNode.Content, fieldsKeyNode, generateFieldErrorsExample())
+
 	return errorObjectNode
 }
 
-// createEndpointSpecific422ErrorResponse creates a 422 error response component for an endpoint.
-func (g *generator) createEndpointSpecific422ErrorResponse(resourceName, endpointName string, service *specification.Service) *v3.Response {
-	// Create schema with only error field (ErrorCode)
-	schema := &base.Schema{
-		Type:       []string{schemaTypeObject},
-		Properties: orderedmap.New[string, *base.SchemaProxy](),
+// generateFieldErrorsExample generates an example of the field errors of an error, with the error of an invalid field.
+func generateFieldErrorsExample() *yaml.Node {
+	fieldErrorNode := &yaml.Node{
+		Kind: yaml.MappingNode,
+		Content: []*yaml.Node{
+			{Kind: yaml.ScalarNode, Tag: "!!str", Value: pathFieldName},
+			{Kind: yaml.ScalarNode, Tag: "!!str", Value: "name"},
+			{Kind: yaml.ScalarNode, Tag: "!!str", Value: codeFieldName},
+			{Kind: yaml.ScalarNode, Tag: "!!str", Value: "required"},
+			{Kind: yaml.ScalarNode, Tag: "!!str", Value: messageFieldName},
+			{Kind: yaml.ScalarNode, Tag: "!!str", Value: "Name is required"},
+		},
 	}
 
-	// Add error field using Error object
-	var errorSchema *base.Schema
+	return &yaml.Node{
+		Kind:    yaml.SequenceNode,
+		Content: []*yaml.Node{fieldErrorNode},
+	}
+}
+
+// generateProblemExample generates a Problem Details example of an error response of the status code, which is the
+// Error object itself. The example has the error of an invalid field when fieldErrors is set.
+func (g *generator) generateProblemExample(statusCode, errorCode, detail string, fieldErrors bool) *yaml.Node {
+	status, _ := strconv.Atoi(statusCode)
+
+	problemNode := &yaml.Node{
+		Kind: yaml.MappingNode,
+		Content: []*yaml.Node{
+			{Kind: yaml.ScalarNode, Tag: "!!str", Value: typeFieldName},
+			{Kind: yaml.ScalarNode, Tag: "!!str", Value: problemTypeDefault},
+			{Kind: yaml.ScalarNode, Tag: "!!str", Value: titleFieldName},
+			{Kind: yaml.ScalarNode, Tag: "!!str", Value: http.StatusText(status)},
+			{Kind: yaml.ScalarNode, Tag: "!!str", Value: statusFieldName},
+			{Kind: yaml.ScalarNode, Tag: "!!int", Value: statusCode},
+			{Kind: yaml.ScalarNode, Tag: "!!str", Value: detailFieldName},
+			{Kind: yaml.ScalarNode, Tag: "!!str", Value: detail},
+			{Kind: yaml.ScalarNode, Tag: "!!str", Value: instanceFieldName},
+			{Kind: yaml.ScalarNode, Tag: "!!str", Value: problemInstanceExample},
+			{Kind: yaml.ScalarNode, Tag: "!!str", Value: codeFieldName},
+			{Kind: yaml.ScalarNode, Tag: "!!str", Value: errorCode},
+			{Kind: yaml.ScalarNode, Tag: "!!str", Value: requestIDFieldName},
+			{Kind: yaml.ScalarNode, Tag: "!!str", Value: "550e8400-e29b-41d4-a716-446655440000"},
+		},
+	}
+
+	if fieldErrors {
+		fieldsKeyNode := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: fieldsFieldName}
+		problemNode.Content = append(problemNode.Content, fieldsKeyNode, generateFieldErrorsExample())
+	}
+
+	return problemNode
+}
+
+// createProblemSchema creates the schema of Problem Details error responses, which are the Error object itself
+// instead of the Error object wrapped in an "error" object.
+func (g *generator) createProblemSchema(service *specification.Service) *base.Schema {
 	if service.HasObject(errorObjectName) {
-		// Reference the Error object
 		refString := schemaReferencePrefix + errorObjectName
 		refProxy := base.CreateSchemaProxyRef(refString)
-		errorSchema = &base.Schema{
+		return &base.Schema{
 			AllOf: []*base.SchemaProxy{refProxy},
 		}
-	} else {
-		// Fallback to generic error object schema
-		errorSchema = &base.Schema{
-			Type:       []string{schemaTypeObject},
-			Properties: orderedmap.New[string, *base.SchemaProxy](),
-		}
-		messageSchema := &base.Schema{Type: []string{schemaTypeString}}
-		codeSchema := &base.Schema{Type: []string{schemaTypeString}}
-		errorSchema.Properties.Set(messageFieldName, base.CreateSchemaProxy(messageSchema))
-		errorSchema.Properties.Set(codeFieldName, base.CreateSchemaProxy(codeSchema))
-		errorSchema.Required = []string{messageFieldName, codeFieldName}
 	}
 
-	// Add example to error property
-	if errorExampleNode := g.generateErrorObjectExample(resourceName, endpointName); errorExampleNode != nil {
-		errorSchema.Examples = []*yaml.Node{errorExampleNode}
+	// Fallback generic Problem Details schema
+	problemSchema := &base.Schema{
+		Type:       []string{schemaTypeObject},
+		Properties: orderedmap.New[string, *base.SchemaProxy](),
+	}
+	for _, name := range []string{typeFieldName, titleFieldName} {
+		problemSchema.Properties.Set(name, base.CreateSchemaProxy(&base.Schema{Type: []string{schemaTypeString}}))
+	}
+	problemSchema.Properties.Set(statusFieldName, base.CreateSchemaProxy(&base.Schema{Type: []string{schemaTypeInteger}}))
+	for _, name := range []string{detailFieldName, instanceFieldName, codeFieldName} {
+		problemSchema.Properties.Set(name, base.CreateSchemaProxy(&base.Schema{Type: []string{schemaTypeString}}))
+	}
+	problemSchema.Properties.Set(fieldsFieldName, base.CreateSchemaProxy(createFieldErrorsSchema()))
+	problemSchema.Required = []string{typeFieldName, titleFieldName, statusFieldName, codeFieldName}
+
+	return problemSchema
+}
+
+// createProblemResponse creates a Problem Details error response with the application/problem+json content type.
+func (g *generator) createProblemResponse(description, exampleName, exampleSummary string, example *yaml.Node, service *specification.Service) *v3.Response {
+	examples := orderedmap.New[string, *base.Example]()
+	examples.Set(exampleName, &base.Example{
+		Summary: exampleSummary,
+		Value:   example,
+	})
+
+	content := orderedmap.New[string, *v3.MediaType]()
+	content.Set(contentTypeProblemJSON, &v3.MediaType{
+		Schema:   base.CreateSchemaProxy(g.createProblemSchema(service)),
+		Examples: examples,
+	})
+
+	response := &v3.Response{
+		Description: description,
+		Content:     content,
+	}
+
+	// Add common response headers
+	response.Headers = g.createResponseHeaders(service)
+
+	return response
+}
+
+// createFieldErrorsSchema creates the schema of the validation errors of the fields of the request,
+// used by the fallback error schema of 422 responses when the service has no Error object.
+func createFieldErrorsSchema() *base.Schema {
+	fieldErrorSchema := &base.Schema{
+		Type:       []string{schemaTypeObject},
+		Properties: orderedmap.New[string, *base.SchemaProxy](),
+	}
+	for _, name := range []string{pathFieldName, codeFieldName, messageFieldName} {
+		fieldErrorSchema.Properties.Set(name, base.CreateSchemaProxy(&base.Schema{Type: []string{schemaTypeString}}))
+	}
+	fieldErrorSchema.Required = []string{pathFieldName, codeFieldName, messageFieldName}
+
+	return &base.Schema{
+		Type:  []string{schemaTypeArray},
+		Items: &base.DynamicValue[*base.SchemaProxy, bool]{A: base.CreateSchemaProxy(fieldErrorSchema)},
+	}
+}
+
+// createEndpointSpecific422ErrorResponse creates a 422 error response component for an endpoint.
+func (g *generator) createEndpointSpecific422ErrorResponse(resourceName, endpointName string, service *specification.Service) *v3.Response {
+	if service.IsProblemDetails() {
+		detail := fmt.Sprintf("Validation failed for %s %s endpoint", resourceName, endpointName)
+		example := g.generateProblemExample(httpStatus422, errorCodeUnprocessableEntity, detail, true)
+		return g.createProblemResponse(g.generateAutoErrorDescription(resourceName, endpointName, httpStatus422), "validationError", "Validation error example", example, service)
+	}
+
+	// Create schema with only error field (ErrorCode)
+	schema := &base.Schema{
+		Type:       []string{schemaTypeObject},
+		Properties: orderedmap.New[string, *base.SchemaProxy](),
+	}
+
+	// Add error field using Error object
+	var errorSchema *base.Schema
+	if service.HasObject(errorObjectName) {
+		// Reference the Error object
+		refString := schemaReferencePrefix + errorObjectName
+		refProxy := base.CreateSchemaProxyRef(refString)
+		errorSchema = &base.Schema{
+			AllOf: []*base.SchemaProxy{refProxy},
+		}
+	} else {
+		// Fallback to generic error object schema
+		errorSchema = &base.Schema{
+			Type:       []string{schemaTypeObject},
+			Properties: orderedmap.New[string, *base.SchemaProxy](),
+		}
+		messageSchema := &base.Schema{Type: []string{schemaTypeString}}
+		codeSchema := &base.Schema{Type: []string{schemaTypeString}}
+		errorSchema.Properties.Set(messageFieldName, base.CreateSchemaProxy(messageSchema))
+		errorSchema.Properties.Set(codeFieldName, base.CreateSchemaProxy(codeSchema))
+		errorSchema.Properties.Set(fieldsFieldName, base.CreateSchemaProxy(createFieldErrorsSchema()))
+		errorSchema.Required = []string{messageFieldName, codeFieldName}
+	}
+
+	// Add example to error property
+	if errorExampleNode := g.generateErrorObjectExample(resourceName, endpointName); errorExampleNode != nil {
+		errorSchema.Examples = []*yaml.Node{errorExampleNode}
 	}
 
 	schema.Properties.Set(errorFieldName, base.CreateSchemaProxy(errorSchema))
@@ -1635,9 +2960,7 @@ func (g *generator) createEndpointSpecific422ErrorResponse(resourceName, endpoin
 	}
 
 	// Add common response headers
-	if headers := g.createResponseHeaders(service); headers != nil {
-		response.Headers = headers
-	}
+	response.Headers = g.createResponseHeaders(service)
 
 	return response
 }
@@ -1839,13 +3162,14 @@ func (g *generator) addErrorResponseBodiesToComponents(components *v3.Components
 	standardErrorSchema := g.createWrappedErrorSchema(service)
 
 	// Define common error responses with examples in deterministic order
-	errorResponses := []struct {
+	type commonErrorResponse struct {
 		description    string
 		statusCode     string
 		errorCode      string
 		message        string
 		exampleSummary string
-	}{
+	}
+	errorResponses := []commonErrorResponse{
 		{
 			badRequestDescription,
 			httpStatus400,
@@ -1897,7 +3221,35 @@ func (g *generator) addErrorResponseBodiesToComponents(components *v3.Components
 		},
 	}
 
+	// The responses of the request limits are only used by the services with limits
+	if service.HasHandlerTimeouts() {
+		errorResponses = append(errorResponses, commonErrorResponse{
+			"Request Timeout - The handler did not complete within the timeout of the endpoint",
+			httpStatus408,
+			errorCodeRequestTimeout,
+			"the request timed out",
+			"Request Timeout error example",
+		})
+	}
+	if service.HasBodyLimits() {
+		errorResponses = append(errorResponses, commonErrorResponse{
+			"Payload Too Large - The request body exceeds the size limit of the endpoint",
+			httpStatus413,
+			errorCodePayloadTooLarge,
+			"the request body exceeds the limit of 1048576 bytes",
+			"Payload Too Large error example",
+		})
+	}
+
 	for _, errorResponse := range errorResponses {
+		standardResponseBodyName := errorResponseBodyPrefix + errorResponse.statusCode + responseBodySuffix
+
+		if service.IsProblemDetails() {
+			example := g.generateProblemExample(errorResponse.statusCode, errorResponse.errorCode, errorResponse.message, false)
+			components.Responses.Set(standardResponseBodyName, g.createProblemResponse(errorResponse.description, "errorExample", errorResponse.exampleSummary, example, service))
+			continue
+		}
+
 		// Generate error example using YAML nodes
 		errorExample := g.generateStandardErrorExample(errorResponse.errorCode, errorResponse.message)
 
@@ -1920,16 +3272,13 @@ func (g *generator) addErrorResponseBodiesToComponents(components *v3.Components
 		content.Set(contentTypeJSON, mediaType)
 
 		// Add standard error response
-		standardResponseBodyName := errorResponseBodyPrefix + errorResponse.statusCode + responseBodySuffix
 		standardResponse := &v3.Response{
 			Description: errorResponse.description,
 			Content:     content,
 		}
 
 		// Add common response headers to error responses as well
-		if headers := g.createResponseHeaders(service); headers != nil {
-			standardResponse.Headers = headers
-		}
+		standardResponse.Headers = g.createResponseHeaders(service)
 
 		components.Responses.Set(standardResponseBodyName, standardResponse)
 	}
@@ -1946,9 +3295,7 @@ func (g *generator) createResponse(response specification.EndpointResponse, reso
 	}
 
 	// Add common response headers
-	if headers := g.createResponseHeaders(service); headers != nil {
-		openAPIResponse.Headers = headers
-	}
+	openAPIResponse.Headers = g.createResponseHeaders(service)
 
 	// Add response content if present
 	if response.BodyObject != nil || len(response.BodyFields) > 0 {
@@ -1974,7 +3321,7 @@ func (g *generator) createResponse(response specification.EndpointResponse, reso
 
 				// Add example to field property if field has example
 				if field.Example != "" {
-					exampleNode := g.createTypedExampleNode(field.Type, field.Example)
+					exampleNode := g.createTypedExampleNode(service.GetPrimitiveType(field.Type), field.Example)
 					// Handle array modifier - wrap the value in an array if needed
 					if field.IsArray() {
 						arrayNode := &yaml.Node{
@@ -2016,16 +3363,8 @@ func (g *generator) createResponse(response specification.EndpointResponse, reso
 				Schema: base.CreateSchemaProxy(schema),
 			}
 
-			// Generate response example
-			if responseExample := g.generateResponseBodyExample(response, service); responseExample != nil {
-				// Use Examples (plural) for complex objects per OpenAPI 3.0+ specification
-				examples := orderedmap.New[string, *base.Example]()
-				examples.Set("responseExample", &base.Example{
-					Summary: "Response body example",
-					Value:   responseExample,
-				})
-				mediaType.Examples = examples
-			}
+			// Generate response examples
+			mediaType.Examples = g.createResponseExamples(response, resourceName, service)
 
 			content.Set(contentTypeJSON, mediaType)
 			openAPIResponse.Content = content
@@ -2064,6 +3403,14 @@ func (g *generator) addErrorResponses(responses *orderedmap.Map[string, *v3.Resp
 			continue
 		}
 
+		// Skip 408 and 413 unless the endpoint has a handler timeout or a body to limit
+		if statusCode == httpStatus408 && endpoint.GetHandlerTimeout(service) == 0 {
+			continue
+		}
+		if statusCode == httpStatus413 && (!hasBodyParams || endpoint.GetMaxBodyBytes(service) == 0) {
+			continue
+		}
+
 		var errorResponse *v3.Response
 		if statusCode == httpStatus422 && hasBodyParams {
 			// Use endpoint-specific error response for 422 validation errors
@@ -2118,6 +3465,10 @@ func (g *generator) mapErrorCodeToStatusAndDescription(errorCodeName, errorCodeD
 		return httpStatus429, errorCodeDescription
 	case errorCodeInternal:
 		return httpStatus500, errorCodeDescription
+	case errorCodeRequestTimeout:
+		return httpStatus408, errorCodeDescription
+	case errorCodePayloadTooLarge:
+		return httpStatus413, errorCodeDescription
 	default:
 		// Default to 500 for unknown error codes
 		return httpStatus500, errorCodeDescription
@@ -2130,8 +3481,12 @@ func (g *generator) toYAML(document *v3.Document) ([]byte, error) {
 		return nil, errors.New(errorInvalidDocument)
 	}
 
-	// Use libopenapi's native Render method
-	return document.Render()
+	root, err := renderDocument(document)
+	if err != nil {
+		return nil, err
+	}
+
+	return yaml.Marshal(root)
 }
 
 // toJSON converts an OpenAPI document to JSON format.
@@ -2140,17 +3495,619 @@ func (g *generator) toJSON(document *v3.Document) ([]byte, error) {
 		return nil, errors.New(errorInvalidDocument)
 	}
 
-	// Use libopenapi's native RenderJSON method
-	return document.RenderJSON("  ")
+	indent := g.Indent
+	if indent == "" {
+		indent = defaultJSONIndent
+	}
+
+	root, err := renderDocument(document)
+	if err != nil {
+		return nil, err
+	}
+
+	return renderJSON(root, indent)
+}
+
+// largeMappingSize is the number of keys above which the keys of a mapping are indexed when it is written as JSON.
+const largeMappingSize = 32
+
+// examplePlaceholderPrefix prefixes the values of the placeholders of examples while a document is rendered.
+const examplePlaceholderPrefix = "\x00example:"
+
+// renderDocument renders the document to a YAML node, like libopenapi's Render and RenderJSON.
+// libopenapi copies every YAML node of the document by encoding and parsing it again, which takes most of
+// the rendering time of large documents since examples of objects are repeated in schemas, request and
+// response bodies. The mapping and sequence examples are therefore swapped for scalar placeholders while
+// the document is rendered, and the placeholders of the rendered node are replaced by the examples.
+func renderDocument(document *v3.Document) (*yaml.Node, error) {
+	placeholders := make(map[string]*yaml.Node)
+	tokens := make(map[*yaml.Node]string)
+
+	slots := collectExampleSlots(document)
+	originals := make([]*yaml.Node, len(slots))
+	for i, slot := range slots {
+		originals[i] = *slot
+
+		// Shared examples, e.g. memoized object examples, get the same placeholder
+		token, ok := tokens[*slot]
+		if !ok {
+			token = examplePlaceholderPrefix + strconv.Itoa(len(tokens))
+			tokens[*slot] = token
+			placeholders[token] = *slot
+		}
+
+		*slot = &yaml.Node{Kind: yaml.ScalarNode, Tag: tagString, Value: token}
+	}
+
+	// Put the examples back, as the document can be rendered again
+	defer func() {
+		for i, slot := range slots {
+			*slot = originals[i]
+		}
+	}()
+
+	rendered, err := document.MarshalYAML()
+	if err != nil {
+		return nil, err
+	}
+
+	root, ok := rendered.(*yaml.Node)
+	if !ok {
+		return nil, errors.New(errorInvalidDocument)
+	}
+
+	replaceExamplePlaceholders(root, placeholders)
+
+	return root, nil
+}
+
+// collectExampleSlots returns the slots of the mapping and sequence examples of the schemas, parameters,
+// request bodies and responses of the document, in the components and in the operations.
+func collectExampleSlots(document *v3.Document) []**yaml.Node {
+	var slots []**yaml.Node
+
+	// Schemas, responses and examples can be shared, e.g. the error responses, so slots are only collected once
+	collected := make(map[**yaml.Node]bool)
+	visited := make(map[*base.Schema]bool)
+
+	addExample := func(slot **yaml.Node) {
+		if *slot == nil || collected[slot] {
+			return
+		}
+		if (*slot).Kind == yaml.MappingNode || (*slot).Kind == yaml.SequenceNode {
+			collected[slot] = true
+			slots = append(slots, slot)
+		}
+	}
+
+	var addSchema func(proxy *base.SchemaProxy)
+	addSchema = func(proxy *base.SchemaProxy) {
+		if proxy == nil || proxy.IsReference() {
+			return
+		}
+
+		schema := proxy.Schema()
+		if schema == nil || visited[schema] {
+			return
+		}
+		visited[schema] = true
+
+		addExample(&schema.Example)
+		addExample(&schema.Default)
+		for i := range schema.Examples {
+			addExample(&schema.Examples[i])
+		}
+
+		if schema.Properties != nil {
+			for _, property := range schema.Properties.FromOldest() {
+				addSchema(property)
+			}
+		}
+		if schema.Items != nil && schema.Items.IsA() {
+			addSchema(schema.Items.A)
+		}
+		if schema.AdditionalProperties != nil && schema.AdditionalProperties.IsA() {
+			addSchema(schema.AdditionalProperties.A)
+		}
+		for _, composed := range [][]*base.SchemaProxy{schema.AllOf, schema.OneOf, schema.AnyOf} {
+			for _, proxy := range composed {
+				addSchema(proxy)
+			}
+		}
+	}
+
+	addMediaTypes := func(content *orderedmap.Map[string, *v3.MediaType]) {
+		if content == nil {
+			return
+		}
+		for _, mediaType := range content.FromOldest() {
+			addSchema(mediaType.Schema)
+			addExample(&mediaType.Example)
+			if mediaType.Examples != nil {
+				for _, example := range mediaType.Examples.FromOldest() {
+					addExample(&example.Value)
+				}
+			}
+		}
+	}
+
+	addParameter := func(parameter *v3.Parameter) {
+		if parameter == nil {
+			return
+		}
+		addSchema(parameter.Schema)
+		addExample(&parameter.Example)
+	}
+
+	addRequestBody := func(requestBody *v3.RequestBody) {
+		if requestBody != nil {
+			addMediaTypes(requestBody.Content)
+		}
+	}
+
+	addResponse := func(response *v3.Response) {
+		if response != nil {
+			addMediaTypes(response.Content)
+		}
+	}
+
+	if components := document.Components; components != nil {
+		if components.Schemas != nil {
+			for _, schema := range components.Schemas.FromOldest() {
+				addSchema(schema)
+			}
+		}
+		if components.Parameters != nil {
+			for _, parameter := range components.Parameters.FromOldest() {
+				addParameter(parameter)
+			}
+		}
+		if components.RequestBodies != nil {
+			for _, requestBody := range components.RequestBodies.FromOldest() {
+				addRequestBody(requestBody)
+			}
+		}
+		if components.Responses != nil {
+			for _, response := range components.Responses.FromOldest() {
+				addResponse(response)
+			}
+		}
+	}
+
+	if document.Paths != nil {
+		for _, pathItem := range document.Paths.PathItems.FromOldest() {
+			for _, operation := range pathItem.GetOperations().FromOldest() {
+				for _, parameter := range operation.Parameters {
+					addParameter(parameter)
+				}
+				addRequestBody(operation.RequestBody)
+				if operation.Responses != nil {
+					addResponse(operation.Responses.Default)
+					if operation.Responses.Codes != nil {
+						for _, response := range operation.Responses.Codes.FromOldest() {
+							addResponse(response)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return slots
+}
+
+// replaceExamplePlaceholders replaces the placeholders of examples in the rendered node by the examples.
+func replaceExamplePlaceholders(node *yaml.Node, placeholders map[string]*yaml.Node) {
+	for i, child := range node.Content {
+		if child.Kind == yaml.ScalarNode && strings.HasPrefix(child.Value, examplePlaceholderPrefix) {
+			if example, ok := placeholders[child.Value]; ok {
+				node.Content[i] = example
+			}
+			continue
+		}
+
+		replaceExamplePlaceholders(child, placeholders)
+	}
+}
+
+// cloneNode returns a deep copy of the node, where nodes that are shared in the tree are copied for every occurrence.
+func cloneNode(node *yaml.Node) *yaml.Node {
+	clone := *node
+	if node.Content != nil {
+		clone.Content = make([]*yaml.Node, len(node.Content))
+		for i, child := range node.Content {
+			clone.Content[i] = cloneNode(child)
+		}
+	}
+	return &clone
+}
+
+// renderJSON renders a YAML node as indented JSON, with the same output as libopenapi's RenderJSON.
+// The node is written directly instead of being converted to ordered maps that are marshaled and then
+// indented, which is most of the rendering time of large documents.
+func renderJSON(node *yaml.Node, indent string) ([]byte, error) {
+	writer := &jsonWriter{written: make(map[*yaml.Node][2]int)}
+	if err := writer.write(node); err != nil {
+		return nil, err
+	}
+
+	var output bytes.Buffer
+	output.Grow(writer.buf.Len() * 2)
+	if err := json.Indent(&output, writer.buf.Bytes(), "", indent); err != nil {
+		return nil, err
+	}
+
+	return output.Bytes(), nil
+}
+
+// jsonWriter writes YAML nodes as compact JSON, decoding scalars the same way as yaml.Node.Decode.
+type jsonWriter struct {
+	buf bytes.Buffer
+
+	// written holds the range of the output of the mapping and sequence nodes that have been written,
+	// so that nodes shared in the tree, e.g. examples, are copied instead of written again
+	written map[*yaml.Node][2]int
+}
+
+// write writes the node.
+func (w *jsonWriter) write(node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		return w.write(node.Content[0])
+	case yaml.AliasNode:
+		return w.write(node.Alias)
+	case yaml.MappingNode, yaml.SequenceNode:
+		if written, ok := w.written[node]; ok {
+			w.buf.Write(w.buf.Bytes()[written[0]:written[1]])
+			return nil
+		}
+
+		start := w.buf.Len()
+		if err := w.writeCollection(node); err != nil {
+			return err
+		}
+		w.written[node] = [2]int{start, w.buf.Len()}
+		return nil
+	case yaml.ScalarNode:
+		value, err := decodeJSONScalar(node)
+		if err != nil {
+			return err
+		}
+		return w.writeValue(value)
+	default:
+		return fmt.Errorf("%s: %v", errorInvalidNodeKind, node.Kind)
+	}
+}
+
+// writeCollection writes a mapping or sequence node.
+func (w *jsonWriter) writeCollection(node *yaml.Node) error {
+	if node.Kind == yaml.SequenceNode {
+		w.buf.WriteByte('[')
+		for i, item := range node.Content {
+			if i > 0 {
+				w.buf.WriteByte(',')
+			}
+			if err := w.write(item); err != nil {
+				return err
+			}
+		}
+		w.buf.WriteByte(']')
+		return nil
+	}
+
+	keys := make([]string, 0, len(node.Content)/2)
+	values := make([]*yaml.Node, 0, len(node.Content)/2)
+
+	// Keys are looked up by scanning, except for large mappings like the paths and schemas
+	var indexes map[string]int
+	if len(node.Content) > 2*largeMappingSize {
+		indexes = make(map[string]int, len(node.Content)/2)
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, err := decodeJSONScalar(node.Content[i])
+		if err != nil {
+			return err
+		}
+
+		// Keys that aren't strings are written as their JSON encoding, e.g. status codes
+		keyString, ok := key.(string)
+		if !ok {
+			data, err := json.Marshal(key)
+			if err != nil {
+				return err
+			}
+			keyString = string(data)
+		}
+
+		// A duplicated key keeps its first position with its last value, like an ordered map
+		index, ok := indexes[keyString]
+		if indexes == nil {
+			index = slices.Index(keys, keyString)
+			ok = index >= 0
+		}
+		if ok {
+			values[index] = node.Content[i+1]
+			continue
+		}
+
+		if indexes != nil {
+			indexes[keyString] = len(keys)
+		}
+		keys = append(keys, keyString)
+		values = append(values, node.Content[i+1])
+	}
+
+	w.buf.WriteByte('{')
+	for i, key := range keys {
+		if i > 0 {
+			w.buf.WriteByte(',')
+		}
+
+		if err := w.writeValue(key); err != nil {
+			return err
+		}
+		w.buf.WriteByte(':')
+
+		if err := w.write(values[i]); err != nil {
+			return err
+		}
+	}
+	w.buf.WriteByte('}')
+	return nil
+}
+
+// decodeJSONScalar decodes a scalar YAML node, skipping the decoder for strings, which most scalars are.
+func decodeJSONScalar(node *yaml.Node) (any, error) {
+	if node.Kind == yaml.ScalarNode && node.ShortTag() == tagString {
+		return node.Value, nil
+	}
+
+	var value any
+	if err := node.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+// writeValue writes the JSON encoding of a decoded scalar.
+func (w *jsonWriter) writeValue(value any) error {
+	// Strings without characters that json.Marshal escapes, which most are, are written as they are
+	if s, ok := value.(string); ok && !needsJSONEscape(s) {
+		w.buf.WriteByte('"')
+		w.buf.WriteString(s)
+		w.buf.WriteByte('"')
+		return nil
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	w.buf.Write(data)
+	return nil
+}
+
+// needsJSONEscape returns true if json.Marshal escapes any character of the string: quotes, backslashes,
+// control characters, HTML characters and anything outside of printable ASCII.
+func needsJSONEscape(s string) bool {
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c < 0x20 || c > 0x7e, c == '"', c == '\\', c == '<', c == '>', c == '&':
+			return true
+		}
+	}
+	return false
+}
+
+// toOverlaidOutput applies the overlay to the document and converts the result to the format, rendered
+// the same way as toYAML and toJSON.
+func (g *generator) toOverlaidOutput(document *v3.Document, overlay *Overlay, format string) ([]byte, error) {
+	if document == nil {
+		return nil, errors.New(errorInvalidDocument)
+	}
+
+	root, err := overlay.applyTo(document)
+	if err != nil {
+		return nil, err
+	}
+
+	if format == FormatYAML {
+		return yaml.Marshal(root)
+	}
+
+	indent := g.Indent
+	if indent == "" {
+		indent = defaultJSONIndent
+	}
+
+	return renderJSON(root, indent)
 }
 
 // GenerateOpenAPI generates an OpenAPI 3.1 document from a specification.Service and writes it as JSON to the provided buffer.
 // This is the main exported function following the same pattern as servergen.GenerateServer.
 func GenerateOpenAPI(buf *bytes.Buffer, service *specification.Service) error {
+	return GenerateOpenAPIWithOptions(buf, service, Options{})
+}
+
+// GenerateOpenAPIYAML generates an OpenAPI 3.1 document from a specification.Service and writes it as YAML to the provided buffer.
+// The YAML is rendered natively from the document, keeping the key ordering of the JSON output.
+func GenerateOpenAPIYAML(buf *bytes.Buffer, service *specification.Service) error {
+	return GenerateOpenAPIWithOptions(buf, service, Options{Format: FormatYAML})
+}
+
+// Options configures the OpenAPI document generated by GenerateOpenAPIWithOptions.
+// The zero value gives the same document as GenerateOpenAPI.
+type Options struct {
+	// Title overrides the API title (default: service name with an " API" suffix)
+	Title string
+
+	// Description overrides the API description (default: "Generated API documentation")
+	Description string
+
+	// Contact overrides the contact information of the service
+	Contact *specification.ServiceContact
+
+	// DisableSpeakeasyExtensions omits all x-speakeasy-* vendor extensions, for consumers that reject them
+	DisableSpeakeasyExtensions bool
+
+	// ExcludeFeatureFlaggedEndpoints omits the endpoints gated behind a feature flag, for publishing the document
+	// before the flags are enabled for everyone
+	ExcludeFeatureFlaggedEndpoints bool
+
+	// ComposeExtendedObjects emits objects that extend another object as an allOf composition
+	// of the parent schema and their own fields, instead of a flattened schema
+	ComposeExtendedObjects bool
+
+	// Format specifies the output format, FormatJSON or FormatYAML (default: FormatJSON)
+	Format string
+
+	// Indent specifies the indentation of the JSON output (default: two spaces)
+	Indent string
+
+	// Extensions configures the vendor extensions of the document, disabling the Speakeasy
+	// extensions when Extensions.Speakeasy is false
+	Extensions *Extensions
+
+	// Overlay is applied to the generated document before it is written, see ParseOverlay
+	Overlay *Overlay
+}
+
+// Overlay is an OpenAPI Overlay document (https://spec.openapis.org/overlay/v1.0.0), whose actions update or
+// remove the parts of a generated document selected by their JSONPath targets, for consumers customizing
+// the document without changing the generator.
+type Overlay struct {
+	document *openapioverlay.Overlay
+}
+
+// ParseOverlay parses an OpenAPI Overlay document in YAML or JSON, returning an error if it is not a valid
+// overlay of version 1.0.0.
+func ParseOverlay(data []byte) (*Overlay, error) {
+	var document openapioverlay.Overlay
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("%s: %w", errorInvalidOverlay, err)
+	}
+
+	if err := document.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", errorInvalidOverlay, err)
+	}
+
+	return &Overlay{document: &document}, nil
+}
+
+// applyTo applies the actions of the overlay in order to the document, returning the root node of the result.
+func (o *Overlay) applyTo(document *v3.Document) (*yaml.Node, error) {
+	rendered, err := renderDocument(document)
+	if err != nil {
+		return nil, err
+	}
+
+	// The examples of the rendered node are shared, also with the document, but actions must only modify their target
+	root := cloneNode(rendered)
+
+	// The targets are queried from the document node, holding the root of the document
+	if err := o.document.ApplyTo(&yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{root}}); err != nil {
+		return nil, fmt.Errorf("failed to apply overlay: %w", err)
+	}
+
+	return root, nil
+}
+
+// Extensions is an extensions profile, configuring the vendor extensions of a generated document
+// so that one specification can produce documents for consumers with different tooling.
+type Extensions struct {
+	// Speakeasy enables the x-speakeasy-* vendor extensions (default: true)
+	Speakeasy *bool `yaml:"speakeasy,omitempty" json:"speakeasy,omitempty"`
+
+	// CodeSamples adds x-codeSamples to the operations, with curl, Go and TypeScript samples of their requests
+	// built from the examples of the specification, for documentation portals (default: false)
+	CodeSamples bool `yaml:"code_samples,omitempty" json:"code_samples,omitempty"`
+
+	// Document adds custom extensions to the root of the document
+	Document map[string]any `yaml:"document,omitempty" json:"document,omitempty"`
+
+	// Operations adds custom extensions to operations, keyed by operation ID, or "*" for all operations
+	Operations map[string]map[string]any `yaml:"operations,omitempty" json:"operations,omitempty"`
+}
+
+// IsSpeakeasyEnabled returns true if the x-speakeasy-* extensions should be generated, which is the default.
+func (e *Extensions) IsSpeakeasyEnabled() bool {
+	return e == nil || e.Speakeasy == nil || *e.Speakeasy
+}
+
+// HasCodeSamples returns true if the operations should have x-codeSamples.
+func (e *Extensions) HasCodeSamples() bool {
+	return e != nil && e.CodeSamples
+}
+
+// Validate returns an error if the name of a custom extension does not start with x-.
+func (e *Extensions) Validate() error {
+	if e == nil {
+		return nil
+	}
+
+	extensionSets := []map[string]any{e.Document}
+	for _, extensions := range e.Operations {
+		extensionSets = append(extensionSets, extensions)
+	}
+
+	for _, extensions := range extensionSets {
+		for name := range extensions {
+			if !strings.HasPrefix(name, extensionPrefix) {
+				return fmt.Errorf("%s: %s", errorInvalidExtension, name)
+			}
+		}
+	}
+
+	return nil
+}
+
+// GenerateOpenAPIWithOptions generates an OpenAPI 3.1 document from a specification.Service and writes it
+// to the provided buffer, configured by the options.
+func GenerateOpenAPIWithOptions(buf *bytes.Buffer, service *specification.Service, options Options) error {
+	if service == nil {
+		return errors.New(errorInvalidService)
+	}
+
+	generator, err := NewGenerator(options)
+	if err != nil {
+		return err
+	}
+
+	return generator.Generate(buf, service)
+}
+
+// Generator generates OpenAPI documents with the same options, which are validated once, so that it can be reused
+// for every specification of a process instead of calling GenerateOpenAPIWithOptions, e.g. by a long-running service.
+// A Generator is safe for concurrent use, as long as the options it was created with are not modified.
+type Generator struct {
+	options Options
+}
+
+// NewGenerator creates a Generator with the options, returning an error if the format or the extensions are invalid.
+func NewGenerator(options Options) (*Generator, error) {
+	if options.Format != "" && options.Format != FormatJSON && options.Format != FormatYAML {
+		return nil, errors.New(errorInvalidFormat)
+	}
+
+	if err := options.Extensions.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &Generator{options: options}, nil
+}
+
+// Generate generates an OpenAPI 3.1 document from a specification.Service and writes it to the provided buffer,
+// like GenerateOpenAPIWithOptions.
+func (g *Generator) Generate(buf *bytes.Buffer, service *specification.Service) error {
 	if service == nil {
 		return errors.New(errorInvalidService)
 	}
 
+	options := g.options
+
 	// Create generator with default configuration
 	generator := newGenerator()
 
@@ -2158,12 +4115,51 @@ func GenerateOpenAPI(buf *bytes.Buffer, service *specification.Service) error {
 	generator.Title = service.Name + apiTitleSuffix
 	generator.Description = defaultAPIDescription
 
+	// Apply options
+	if options.Title != "" {
+		generator.Title = options.Title
+	}
+	if options.Description != "" {
+		generator.Description = options.Description
+	}
+	if options.Indent != "" {
+		generator.Indent = options.Indent
+	}
+	generator.Contact = options.Contact
+	generator.DisableSpeakeasyExtensions = options.DisableSpeakeasyExtensions || !options.Extensions.IsSpeakeasyEnabled()
+	generator.Extensions = options.Extensions
+	generator.CodeSamples = options.Extensions.HasCodeSamples()
+	generator.ComposeExtendedObjects = options.ComposeExtendedObjects
+	generator.ExcludeFeatureFlaggedEndpoints = options.ExcludeFeatureFlaggedEndpoints
+
 	// Generate OpenAPI document
 	document, err := generator.generateFromService(service)
 	if err != nil {
 		return fmt.Errorf("failed to generate OpenAPI document: %w", err)
 	}
 
+	if options.Overlay != nil {
+		output, err := generator.toOverlaidOutput(document, options.Overlay, options.Format)
+		if err != nil {
+			return err
+		}
+
+		buf.Write(output)
+
+		return nil
+	}
+
+	if options.Format == FormatYAML {
+		yamlBytes, err := generator.toYAML(document)
+		if err != nil {
+			return fmt.Errorf("failed to convert OpenAPI document to YAML: %w", err)
+		}
+
+		buf.Write(yamlBytes)
+
+		return nil
+	}
+
 	// Convert to JSON
 	jsonBytes, err := generator.toJSON(document)
 	if err != nil {
@@ -2289,6 +4285,8 @@ func (g *generator) createSecurityScheme(scheme specification.SecurityScheme) *v
 		securityScheme.In = scheme.In
 	case securityTypeOAuth2:
 		securityScheme.Flows = g.createOAuthFlows(scheme.Flows)
+	case securityTypeOpenIDConnect:
+		securityScheme.OpenIdConnectUrl = scheme.OpenIDConnectURL
 	}
 	// mutualTLS type doesn't require additional fields
 
@@ -2335,8 +4333,15 @@ func (g *generator) createOAuthFlow(flow *specification.OAuth2Flow) *v3.OAuthFlo
 		Scopes:           orderedmap.New[string, string](),
 	}
 
-	for scopeName, scopeDesc := range flow.Scopes {
-		oauthFlow.Scopes.Set(scopeName, scopeDesc)
+	// Add scopes in sorted order for deterministic output
+	scopeNames := make([]string, 0, len(flow.Scopes))
+	for scopeName := range flow.Scopes {
+		scopeNames = append(scopeNames, scopeName)
+	}
+	sort.Strings(scopeNames)
+
+	for _, scopeName := range scopeNames {
+		oauthFlow.Scopes.Set(scopeName, flow.Scopes[scopeName])
 	}
 
 	return oauthFlow
@@ -2348,7 +4353,12 @@ func (g *generator) addSecurityToDocument(document *v3.Document, service *specif
 		return
 	}
 
-	// Convert simplified SecurityRequirement to base.SecurityRequirement
+	document.Security = g.createSecurityRequirements(service, nil)
+}
+
+// createSecurityRequirements converts the service security requirements to OpenAPI security requirements.
+// The given scopes are required for OAuth2 and OpenID Connect schemes, other schemes never have scopes.
+func (g *generator) createSecurityRequirements(service *specification.Service, scopes []string) []*base.SecurityRequirement {
 	securityRequirements := make([]*base.SecurityRequirement, len(service.Security))
 	for i, requirement := range service.Security {
 		securityRequirement := &base.SecurityRequirement{
@@ -2357,11 +4367,15 @@ func (g *generator) addSecurityToDocument(document *v3.Document, service *specif
 
 		// Each requirement is a list of scheme names that must be satisfied together
 		for _, schemeName := range requirement {
-			securityRequirement.Requirements.Set(schemeName, []string{}) // Empty scopes for non-OAuth schemes
+			schemeScopes := []string{}
+			if service.SecuritySchemes[schemeName].HasScopes() {
+				schemeScopes = append(schemeScopes, scopes...)
+			}
+			securityRequirement.Requirements.Set(schemeName, schemeScopes)
 		}
 
 		securityRequirements[i] = securityRequirement
 	}
 
-	document.Security = securityRequirements
+	return securityRequirements
 }
@@ -4,14 +4,19 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
+	"sync"
 	"testing"
+	"unicode/utf8"
 
 	"github.com/meitner-se/publicapis-gen/specification"
 	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
 	"github.com/pb33f/libopenapi/orderedmap"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	yaml "gopkg.in/yaml.v3"
 )
 
 // ============================================================================
@@ -280,6 +285,36 @@ func TestGenerator_GenerateFromService(t *testing.T) {
 		assert.Contains(t, jsonString, "prod", "JSON should contain 'prod' server ID")
 		assert.Contains(t, jsonString, "staging", "JSON should contain 'staging' server ID")
 	})
+
+	t.Run("service with server variables", func(t *testing.T) {
+		generator := newGenerator()
+		service := &specification.Service{
+			Name:    "UserAPI",
+			Version: "2.0.0",
+			Servers: []specification.ServiceServer{
+				{
+					URL: "https://{region}.api.example.com/{version}",
+					Variables: []specification.ServerVariable{
+						{Name: "region", Description: "Region of the API", Default: "eu", Enum: []string{"eu", "us"}},
+						{Name: "version", Default: "v1"},
+					},
+				},
+			},
+		}
+
+		document, err := generator.generateFromService(service)
+
+		assert.NoError(t, err, "Should generate document successfully")
+		require.Len(t, document.Servers, 1)
+		assert.Equal(t, "https://{region}.api.example.com/{version}", document.Servers[0].URL, "Server URL should keep the placeholders of the variables")
+		require.NotNil(t, document.Servers[0].Variables, "Server should have variables")
+		assert.Equal(t, []string{"region", "version"}, slices.Collect(document.Servers[0].Variables.KeysFromOldest()), "Variables should keep the order of the specification")
+		region := document.Servers[0].Variables.GetOrZero("region")
+		assert.Equal(t, "eu", region.Default)
+		assert.Equal(t, []string{"eu", "us"}, region.Enum)
+		assert.Equal(t, "Region of the API", region.Description)
+		assert.Empty(t, document.Servers[0].Variables.GetOrZero("version").Enum, "Variables without an enum should accept any value")
+	})
 }
 
 // TestGenerator_ToYAML tests YAML conversion functionality.
@@ -655,6 +690,90 @@ func TestEndToEndErrorResponseGeneration(t *testing.T) {
 	t.Logf("Generated OpenAPI JSON:\n%s", jsonString)
 }
 
+func TestGenerator_createEndpointSpecific422ErrorResponse(t *testing.T) {
+	t.Run("example includes field errors", func(t *testing.T) {
+		// Arrange
+		generator := newGenerator()
+		service := specification.ApplyOverlay(&specification.Service{Name: "UserAPI", Version: "1.0.0"})
+
+		// Act
+		response := generator.createEndpointSpecific422ErrorResponse("User", "Create", service)
+
+		// Assert
+		example := response.Content.GetOrZero(contentTypeJSON).Examples.GetOrZero("validationError")
+		if !assert.NotNil(t, example) {
+			return
+		}
+		exampleYAML, err := yaml.Marshal(example.Value)
+		assert.NoError(t, err)
+		assert.Contains(t, string(exampleYAML), "fields:\n        - path: name\n          code: required\n          message: Name is required")
+	})
+
+	t.Run("fallback schema includes field errors", func(t *testing.T) {
+		// Arrange
+		generator := newGenerator()
+		service := &specification.Service{Name: "UserAPI", Version: "1.0.0"}
+
+		// Act
+		response := generator.createEndpointSpecific422ErrorResponse("User", "Create", service)
+
+		// Assert
+		schema := response.Content.GetOrZero(contentTypeJSON).Schema.Schema()
+		errorSchema := schema.Properties.GetOrZero(errorFieldName).Schema()
+		fieldsSchema := errorSchema.Properties.GetOrZero(fieldsFieldName).Schema()
+		if !assert.NotNil(t, fieldsSchema) {
+			return
+		}
+		assert.Equal(t, []string{schemaTypeArray}, fieldsSchema.Type)
+		assert.Equal(t, []string{pathFieldName, codeFieldName, messageFieldName}, fieldsSchema.Items.A.Schema().Required)
+	})
+
+	t.Run("problem details", func(t *testing.T) {
+		// Arrange
+		generator := newGenerator()
+		service := specification.ApplyOverlay(&specification.Service{Name: "UserAPI", Version: "1.0.0", ErrorFormat: specification.ErrorFormatProblemJSON})
+
+		// Act
+		response := generator.createEndpointSpecific422ErrorResponse("User", "Create", service)
+
+		// Assert
+		assert.Nil(t, response.Content.GetOrZero(contentTypeJSON))
+		mediaType := response.Content.GetOrZero(contentTypeProblemJSON)
+		if !assert.NotNil(t, mediaType) {
+			return
+		}
+		assert.Equal(t, "#/components/schemas/Error", mediaType.Schema.Schema().AllOf[0].GetReference(), "Problem should not be wrapped in an error object")
+		exampleYAML, err := yaml.Marshal(mediaType.Examples.GetOrZero("validationError").Value)
+		assert.NoError(t, err)
+		assert.Contains(t, string(exampleYAML), "type: about:blank\ntitle: Unprocessable Entity\nstatus: 422\n")
+		assert.Contains(t, string(exampleYAML), "fields:\n    - path: name")
+	})
+}
+
+func TestGenerator_addErrorResponseBodiesToComponents_ProblemDetails(t *testing.T) {
+	// Arrange
+	generator := newGenerator()
+	service := specification.ApplyOverlay(&specification.Service{Name: "UserAPI", Version: "1.0.0", ErrorFormat: specification.ErrorFormatProblemJSON})
+	components := &v3.Components{Responses: orderedmap.New[string, *v3.Response]()}
+
+	// Act
+	generator.addErrorResponseBodiesToComponents(components, service)
+
+	// Assert
+	response := components.Responses.GetOrZero("Error404ResponseBody")
+	if !assert.NotNil(t, response) {
+		return
+	}
+	mediaType := response.Content.GetOrZero(contentTypeProblemJSON)
+	if !assert.NotNil(t, mediaType) {
+		return
+	}
+	exampleYAML, err := yaml.Marshal(mediaType.Examples.GetOrZero("errorExample").Value)
+	assert.NoError(t, err)
+	assert.Contains(t, string(exampleYAML), "title: Not Found\nstatus: 404\n")
+	assert.Contains(t, string(exampleYAML), "code: NotFound\n")
+}
+
 // TestCamelCaseParametersInOpenAPI verifies that parameters use camelCase in OpenAPI output
 func TestCamelCaseParametersInOpenAPI(t *testing.T) {
 	generator := newGenerator()
@@ -1316,6 +1435,473 @@ func TestGenerateOpenAPI(t *testing.T) {
 	})
 }
 
+// TestGenerateOpenAPI_NonASCIINames tests that names, descriptions and examples written in other languages than
+// English pass through as UTF-8, while the paths derived from the names are transliterated.
+func TestGenerateOpenAPI_NonASCIINames(t *testing.T) {
+	service := specification.ApplyDefaultOverlays(&specification.Service{
+		Name:    "Skolans tjänst",
+		Version: "v1",
+		Resources: []specification.Resource{{
+			Name:        "Ärende",
+			Description: "Ett ärende för en elev — på svenska",
+			Operations:  []string{specification.OperationCreate, specification.OperationGet},
+			Fields: []specification.ResourceField{{
+				Field:      specification.Field{Name: "Förnamn", Description: "Elevens förnamn", Type: specification.FieldTypeString, Example: "Åsa"},
+				Operations: []string{specification.OperationCreate, specification.OperationRead},
+			}},
+		}},
+	})
+
+	var jsonBuf, yamlBuf bytes.Buffer
+	require.NoError(t, GenerateOpenAPI(&jsonBuf, service))
+	require.NoError(t, GenerateOpenAPIYAML(&yamlBuf, service))
+
+	for _, output := range []string{jsonBuf.String(), yamlBuf.String()} {
+		assert.True(t, utf8.ValidString(output), "Output should be valid UTF-8")
+		assert.Contains(t, output, "förnamn", "Should keep the letters of the JSON name")
+		assert.Contains(t, output, "Åsa", "Should keep the letters of the example")
+		assert.Contains(t, output, "Ett ärende för en elev — på svenska", "Should keep the letters of the description")
+		assert.Contains(t, output, "/arende", "Should transliterate the path")
+		assert.NotContains(t, output, "/ärende", "Should transliterate the path")
+	}
+}
+
+// TestGenerateOpenAPI_JSONNaming tests that the properties and their examples follow the JSON naming of the service.
+func TestGenerateOpenAPI_JSONNaming(t *testing.T) {
+	service := specification.ApplyDefaultOverlays(&specification.Service{
+		Name:    "TestAPI",
+		Version: "v1",
+		Naming:  &specification.Naming{JSON: specification.JSONCaseSnake},
+		Resources: []specification.Resource{{
+			Name:       "User",
+			Operations: []string{specification.OperationCreate, specification.OperationGet},
+			Fields: []specification.ResourceField{
+				{Field: specification.Field{Name: "FirstName", Type: specification.FieldTypeString, Example: "Ada"}, Operations: []string{specification.OperationCreate, specification.OperationRead}},
+			},
+		}},
+	})
+
+	var buf bytes.Buffer
+	require.NoError(t, GenerateOpenAPI(&buf, service))
+
+	var document map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &document))
+	schemas := document["components"].(map[string]any)["schemas"].(map[string]any)
+	properties := schemas["User"].(map[string]any)["properties"].(map[string]any)
+	assert.Contains(t, properties, "first_name", "Properties should follow the naming")
+	assert.NotContains(t, properties, "firstName", "Properties should not use camelCase")
+	assert.Contains(t, buf.String(), `"first_name": "Ada"`, "Examples should follow the naming")
+	assert.NotContains(t, buf.String(), `"firstName"`, "Examples should not use camelCase")
+}
+
+func TestGenerateOpenAPI_ResponseHeaderNames(t *testing.T) {
+	service := specification.ApplyDefaultOverlays(&specification.Service{
+		Name:    "TestAPI",
+		Version: "v1",
+		ResponseHeaders: []specification.Field{
+			{Name: "TotalCount", HeaderName: "X-Total-Count", Type: specification.FieldTypeInt, Description: "Total count"},
+			{Name: "Request ID", HeaderName: "x-request-id", Type: specification.FieldTypeString, Description: "Request ID"},
+		},
+		Resources: []specification.Resource{{
+			Name:       "Users",
+			Operations: []string{specification.OperationGet},
+			Fields: []specification.ResourceField{
+				{Field: specification.Field{Name: "Name", Type: specification.FieldTypeString}, Operations: []string{specification.OperationRead}},
+			},
+		}},
+	})
+
+	var buf bytes.Buffer
+	require.NoError(t, GenerateOpenAPI(&buf, service))
+
+	var document map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &document))
+	responses := document["components"].(map[string]any)["responses"].(map[string]any)
+	headers := responses["UsersGet"].(map[string]any)["headers"].(map[string]any)
+	assert.Contains(t, headers, "X-Total-Count", "Headers should be documented with their header names")
+	assert.NotContains(t, headers, "TotalCount", "Headers should not be documented with their field names")
+	assert.NotContains(t, headers, "x-request-id", "The request ID header should be documented once")
+}
+
+func TestGenerateOpenAPI_TotalCountHeader(t *testing.T) {
+	service := specification.ApplyDefaultOverlays(&specification.Service{
+		Name:    "TestAPI",
+		Version: "v1",
+		Resources: []specification.Resource{{
+			Name:       "Users",
+			Operations: []string{specification.OperationList},
+			TotalCount: specification.TotalCountHeader,
+			Fields: []specification.ResourceField{
+				{Field: specification.Field{Name: "Name", Type: specification.FieldTypeString}, Operations: []string{specification.OperationRead}},
+			},
+		}},
+	})
+
+	var buf bytes.Buffer
+	require.NoError(t, GenerateOpenAPI(&buf, service))
+
+	var document map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &document))
+	components := document["components"].(map[string]any)
+	headers := components["responses"].(map[string]any)["UsersList"].(map[string]any)["headers"].(map[string]any)
+	require.Contains(t, headers, "X-Total-Count", "The total should be documented as a header of List")
+	schema := headers["X-Total-Count"].(map[string]any)["schema"].(map[string]any)
+	assert.Equal(t, "integer", schema["type"])
+
+	properties := components["schemas"].(map[string]any)["PaginationWithoutTotal"].(map[string]any)["properties"].(map[string]any)
+	assert.Contains(t, properties, "offset")
+	assert.NotContains(t, properties, "total", "The pagination should not have the total")
+}
+
+func TestGenerateOpenAPI_MaxLimit(t *testing.T) {
+	service := specification.ApplyDefaultOverlays(&specification.Service{
+		Name:     "TestAPI",
+		Version:  "v1",
+		MaxLimit: 100,
+		Resources: []specification.Resource{{
+			Name:       "Users",
+			Operations: []string{specification.OperationList},
+			Fields: []specification.ResourceField{
+				{Field: specification.Field{Name: "Name", Type: specification.FieldTypeString}, Operations: []string{specification.OperationRead}},
+			},
+		}},
+	})
+
+	var buf bytes.Buffer
+	require.NoError(t, GenerateOpenAPI(&buf, service))
+
+	var document map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &document))
+	parameters := document["paths"].(map[string]any)["/users"].(map[string]any)["get"].(map[string]any)["parameters"].([]any)
+
+	limit := parameters[0].(map[string]any)
+	require.Equal(t, "limit", limit["name"])
+	assert.True(t, strings.HasSuffix(limit["description"].(string), ", at most 100"), "The description should document the max limit")
+	schema := limit["schema"].(map[string]any)
+	assert.Equal(t, float64(100), schema["maximum"], "The schema should have the max limit as maximum")
+	assert.Equal(t, float64(1), schema["minimum"])
+
+	offset := parameters[1].(map[string]any)
+	require.Equal(t, "offset", offset["name"])
+	assert.NotContains(t, offset["schema"].(map[string]any), "maximum", "Only the limit should have the maximum")
+}
+
+// TestGenerateOpenAPIYAML tests that the YAML output is the same document as the JSON output, in the same key order.
+func TestGenerateOpenAPIYAML(t *testing.T) {
+	service := specification.ApplyOverlay(&specification.Service{
+		Name:    "TestAPI",
+		Version: "v1",
+		Resources: []specification.Resource{
+			{
+				Name:       "Users",
+				Operations: []string{specification.OperationGet},
+				Fields: []specification.ResourceField{
+					{
+						Field:      specification.Field{Name: "Score", Type: specification.FieldTypeFloat64, Example: "1.50"},
+						Operations: []string{specification.OperationRead},
+					},
+				},
+			},
+		},
+	})
+
+	var yamlBuf, jsonBuf bytes.Buffer
+	assert.NoError(t, GenerateOpenAPIYAML(&yamlBuf, service))
+	assert.NoError(t, GenerateOpenAPI(&jsonBuf, service))
+
+	var yamlDocument, jsonDocument yaml.Node
+	assert.NoError(t, yaml.Unmarshal(yamlBuf.Bytes(), &yamlDocument))
+	assert.NoError(t, yaml.Unmarshal(jsonBuf.Bytes(), &jsonDocument))
+
+	keys := func(node *yaml.Node) []string {
+		var result []string
+		for i := 0; i < len(node.Content[0].Content); i += 2 {
+			result = append(result, node.Content[0].Content[i].Value)
+		}
+		return result
+	}
+	assert.Equal(t, keys(&jsonDocument), keys(&yamlDocument), "YAML should keep the key order of the document")
+
+	var yamlValue, jsonValue any
+	assert.NoError(t, yamlDocument.Decode(&yamlValue))
+	assert.NoError(t, jsonDocument.Decode(&jsonValue))
+	assert.Equal(t, jsonValue, yamlValue, "YAML and JSON should describe the same document")
+}
+
+// TestGenerateOpenAPIWithOptions tests that the options configure the generated document.
+func TestGenerateOpenAPIWithOptions(t *testing.T) {
+	service := &specification.Service{
+		Name:    "TestAPI",
+		Version: "v1",
+		Contact: &specification.ServiceContact{Name: "Service Team"},
+		Servers: []specification.ServiceServer{{URL: "https://api.example.com", ID: "production"}},
+		Resources: []specification.Resource{
+			{
+				Name:       "Users",
+				Operations: []string{specification.OperationGet},
+				Fields: []specification.ResourceField{
+					{
+						Field:      specification.Field{Name: "Name", Type: specification.FieldTypeString},
+						Operations: []string{specification.OperationRead},
+					},
+				},
+			},
+		},
+	}
+
+	t.Run("zero options match GenerateOpenAPI", func(t *testing.T) {
+		var defaultBuf, optionsBuf bytes.Buffer
+		assert.NoError(t, GenerateOpenAPI(&defaultBuf, service))
+		assert.NoError(t, GenerateOpenAPIWithOptions(&optionsBuf, service, Options{}))
+
+		assert.Equal(t, defaultBuf.String(), optionsBuf.String())
+		assert.Contains(t, optionsBuf.String(), "x-speakeasy-", "Speakeasy extensions should be enabled by default")
+	})
+
+	t.Run("info overrides", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := GenerateOpenAPIWithOptions(&buf, service, Options{
+			Title:       "Partner API",
+			Description: "API for partners",
+			Contact:     &specification.ServiceContact{Name: "Partner Support", Email: "partners@example.com"},
+		})
+		assert.NoError(t, err)
+
+		var result map[string]any
+		assert.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+		info := result["info"].(map[string]any)
+		assert.Equal(t, "Partner API", info["title"])
+		assert.Equal(t, "API for partners", info["description"])
+		assert.Equal(t, map[string]any{"name": "Partner Support", "email": "partners@example.com"}, info["contact"])
+	})
+
+	t.Run("disable speakeasy extensions", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := GenerateOpenAPIWithOptions(&buf, service, Options{DisableSpeakeasyExtensions: true})
+		assert.NoError(t, err)
+
+		assert.NotContains(t, buf.String(), "x-speakeasy-", "No Speakeasy extension should be generated")
+	})
+
+	t.Run("YAML format and indentation", func(t *testing.T) {
+		var yamlBuf bytes.Buffer
+		assert.NoError(t, GenerateOpenAPIWithOptions(&yamlBuf, service, Options{Format: FormatYAML}))
+		assert.True(t, strings.HasPrefix(yamlBuf.String(), "openapi: 3.1.0"), "Output should be YAML")
+
+		var jsonBuf bytes.Buffer
+		assert.NoError(t, GenerateOpenAPIWithOptions(&jsonBuf, service, Options{Indent: "\t"}))
+		assert.Contains(t, jsonBuf.String(), "\n\t\"openapi\"", "Output should be indented with tabs")
+	})
+
+	t.Run("invalid format returns error", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := GenerateOpenAPIWithOptions(&buf, service, Options{Format: "xml"})
+
+		assert.EqualError(t, err, errorInvalidFormat)
+		assert.Equal(t, 0, buf.Len(), "Buffer should be empty on error")
+	})
+}
+
+// TestGenerateOpenAPIWithOptions_Extensions tests that the extensions profile configures the vendor extensions.
+func TestGenerateOpenAPIWithOptions_Extensions(t *testing.T) {
+	service := specification.ApplyOverlay(&specification.Service{
+		Name: "TestAPI",
+		Resources: []specification.Resource{
+			{
+				Name:       "Users",
+				Operations: []string{specification.OperationGet, specification.OperationDelete},
+				Fields: []specification.ResourceField{
+					{
+						Field:      specification.Field{Name: "Name", Type: specification.FieldTypeString},
+						Operations: []string{specification.OperationRead},
+					},
+				},
+			},
+		},
+	})
+	speakeasy := false
+
+	t.Run("custom extensions and disabled speakeasy", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := GenerateOpenAPIWithOptions(&buf, service, Options{
+			Extensions: &Extensions{
+				Speakeasy: &speakeasy,
+				Document:  map[string]any{"x-portal": map[string]any{"visible": true}},
+				Operations: map[string]map[string]any{
+					"*":           {"x-audience": "partner"},
+					"UsersDelete": {"x-audience": "internal"},
+				},
+			},
+		})
+		assert.NoError(t, err)
+		assert.NotContains(t, buf.String(), "x-speakeasy-", "Speakeasy extensions should be disabled by the profile")
+
+		var result map[string]any
+		assert.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+		assert.Equal(t, map[string]any{"visible": true}, result["x-portal"])
+
+		paths := result["paths"].(map[string]any)
+		get := paths["/users/{id}"].(map[string]any)["get"].(map[string]any)
+		del := paths["/users/{id}"].(map[string]any)["delete"].(map[string]any)
+		assert.Equal(t, "partner", get["x-audience"], "Wildcard extensions should apply to all operations")
+		assert.Equal(t, "internal", del["x-audience"], "Operation extensions should take precedence over wildcard extensions")
+	})
+
+	t.Run("speakeasy enabled by default", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := GenerateOpenAPIWithOptions(&buf, service, Options{Extensions: &Extensions{}})
+		assert.NoError(t, err)
+
+		assert.Contains(t, buf.String(), "x-speakeasy-")
+	})
+
+	t.Run("invalid extension name returns error", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := GenerateOpenAPIWithOptions(&buf, service, Options{
+			Extensions: &Extensions{Document: map[string]any{"portal": true}},
+		})
+
+		assert.ErrorContains(t, err, errorInvalidExtension)
+	})
+}
+
+func TestGenerateOpenAPIWithOptions_Overlay(t *testing.T) {
+	service := specification.ApplyOverlay(&specification.Service{
+		Name: "TestAPI",
+		Resources: []specification.Resource{
+			{
+				Name:       "Users",
+				Operations: []string{specification.OperationGet, specification.OperationDelete},
+				Fields: []specification.ResourceField{
+					{
+						Field:      specification.Field{Name: "Name", Type: specification.FieldTypeString},
+						Operations: []string{specification.OperationRead},
+					},
+				},
+			},
+		},
+	})
+	overlay, err := ParseOverlay([]byte(`overlay: 1.0.0
+info:
+  title: Partner patches
+  version: 1.0.0
+actions:
+  - target: $.info
+    update:
+      title: Partner API
+      x-logo:
+        url: https://example.com/logo.png
+  - target: $.paths.*[?@.operationId == 'UsersDelete']
+    remove: true
+`))
+	assert.NoError(t, err)
+
+	t.Run("applies the actions to JSON", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := GenerateOpenAPIWithOptions(&buf, service, Options{Overlay: overlay})
+		assert.NoError(t, err)
+
+		var result map[string]any
+		assert.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+		info := result["info"].(map[string]any)
+		assert.Equal(t, "Partner API", info["title"], "Update actions should merge into the target")
+		assert.Equal(t, map[string]any{"url": "https://example.com/logo.png"}, info["x-logo"], "Update actions should add new keys")
+		assert.Equal(t, "Generated API documentation", info["description"], "Keys absent from the update should be kept")
+
+		operations := result["paths"].(map[string]any)["/users/{id}"].(map[string]any)
+		assert.Contains(t, operations, "get")
+		assert.NotContains(t, operations, "delete", "Remove actions should remove the target")
+		assert.True(t, strings.HasPrefix(buf.String(), "{\n  \"openapi\""), "JSON should keep the key order and indentation")
+	})
+
+	t.Run("applies the actions to YAML", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := GenerateOpenAPIWithOptions(&buf, service, Options{Format: FormatYAML, Overlay: overlay})
+		assert.NoError(t, err)
+
+		assert.True(t, strings.HasPrefix(buf.String(), "openapi: 3.1.0\ninfo:\n    title: Partner API\n"))
+		assert.NotContains(t, buf.String(), "UsersDelete")
+	})
+
+	t.Run("invalid overlay returns error", func(t *testing.T) {
+		_, err := ParseOverlay([]byte(`overlay: 2.0.0
+actions:
+  - update:
+      title: Partner API
+`))
+
+		assert.ErrorContains(t, err, errorInvalidOverlay)
+		assert.ErrorContains(t, err, "overlay version must be 1.0.0")
+		assert.ErrorContains(t, err, "overlay action at index 0 target must be defined")
+	})
+}
+
+// TestNewGenerator_Options tests that a Generator validates its options once and can be reused concurrently.
+func TestNewGenerator_Options(t *testing.T) {
+	service := &specification.Service{
+		Name: "TestAPI",
+		Resources: []specification.Resource{
+			{
+				Name:       "Users",
+				Operations: []string{specification.OperationGet},
+				Fields: []specification.ResourceField{
+					{
+						Field:      specification.Field{Name: "Name", Type: specification.FieldTypeString},
+						Operations: []string{specification.OperationRead},
+					},
+				},
+			},
+		},
+	}
+
+	t.Run("reused concurrently", func(t *testing.T) {
+		var expected bytes.Buffer
+		assert.NoError(t, GenerateOpenAPI(&expected, service))
+
+		generator, err := NewGenerator(Options{})
+		assert.NoError(t, err)
+
+		var wg sync.WaitGroup
+		results := make([]string, 8)
+		for i := range results {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				var buf bytes.Buffer
+				assert.NoError(t, generator.Generate(&buf, service))
+				results[i] = buf.String()
+			}()
+		}
+		wg.Wait()
+
+		for _, result := range results {
+			assert.Equal(t, expected.String(), result, "Every generation should match GenerateOpenAPI")
+		}
+	})
+
+	t.Run("nil service returns error", func(t *testing.T) {
+		generator, err := NewGenerator(Options{})
+		assert.NoError(t, err)
+
+		var buf bytes.Buffer
+		assert.EqualError(t, generator.Generate(&buf, nil), errorInvalidService)
+	})
+
+	t.Run("invalid format returns error", func(t *testing.T) {
+		generator, err := NewGenerator(Options{Format: "xml"})
+
+		assert.Nil(t, generator)
+		assert.EqualError(t, err, errorInvalidFormat)
+	})
+
+	t.Run("invalid extension name returns error", func(t *testing.T) {
+		generator, err := NewGenerator(Options{Extensions: &Extensions{Document: map[string]any{"portal": true}}})
+
+		assert.Nil(t, generator)
+		assert.ErrorContains(t, err, errorInvalidExtension)
+	})
+}
+
 // TestGenerateFromSpecificationToJSON tests the convenience method for generating JSON from a specification.
 func TestGenerateFromSpecificationToJSON(t *testing.T) {
 	// Test with nil service
@@ -1769,6 +2355,56 @@ func TestGenerator_GenerateFromService_IncludesTags(t *testing.T) {
 // ============================================================================
 
 // TestGenerator_DevelopmentFlag tests that resources marked as development are excluded from generated output.
+func TestGenerator_GenerateFromService_TagGroups(t *testing.T) {
+	t.Run("tags are grouped in the order of the groups", func(t *testing.T) {
+		// Arrange
+		service := &specification.Service{
+			Name: "Directory API",
+			Resources: []specification.Resource{
+				{Name: "Users", Description: "Users", TagGroup: "Identity"},
+				{Name: "Schools", Description: "Schools", TagGroup: "Organization"},
+				{Name: "Groups", Description: "Groups", TagGroup: "Identity"},
+				{Name: "Members", Description: "Members", Parent: "Groups"},
+				{Name: "Drafts", Description: "Drafts", TagGroup: "Internal", Development: true},
+				{Name: "Settings", Description: "Settings"},
+			},
+		}
+
+		// Act
+		document, err := newGenerator().generateFromService(service)
+
+		// Assert
+		assert.NoError(t, err, "Should generate document without error")
+		var groups []struct {
+			Name string   `yaml:"name"`
+			Tags []string `yaml:"tags"`
+		}
+		assert.NoError(t, document.Extensions.GetOrZero("x-tagGroups").Decode(&groups), "x-tagGroups should be a list of groups")
+		assert.Len(t, groups, 3, "Development resources should not add tag groups")
+		assert.Equal(t, "Identity", groups[0].Name)
+		assert.Equal(t, []string{"Users", "Groups", "Members"}, groups[0].Tags, "Sub-resources should be grouped with their parent")
+		assert.Equal(t, "Organization", groups[1].Name)
+		assert.Equal(t, []string{"Schools"}, groups[1].Tags)
+		assert.Equal(t, "Other", groups[2].Name, "Tags without a group should be in the last group")
+		assert.Equal(t, []string{"Settings"}, groups[2].Tags)
+	})
+
+	t.Run("no tag groups without grouped resources", func(t *testing.T) {
+		// Arrange
+		service := &specification.Service{
+			Name:      "Directory API",
+			Resources: []specification.Resource{{Name: "Users", Description: "Users"}},
+		}
+
+		// Act
+		document, err := newGenerator().generateFromService(service)
+
+		// Assert
+		assert.NoError(t, err, "Should generate document without error")
+		assert.Nil(t, document.Extensions.GetOrZero("x-tagGroups"), "x-tagGroups should not be added without tag groups")
+	})
+}
+
 func TestGenerator_DevelopmentFlag(t *testing.T) {
 	const (
 		devResourceName    = "GradeElementary"
@@ -3187,15 +3823,69 @@ func TestGenerator_GenerateFromService_WithLicense(t *testing.T) {
 	})
 }
 
-// TestParameterDescriptionNotDuplicated verifies that parameter descriptions are not duplicated in schema objects
-func TestParameterDescriptionNotDuplicated(t *testing.T) {
+func TestGenerator_GenerateFromService_WithExternalDocs(t *testing.T) {
+	// Arrange
 	generator := newGenerator()
 	service := &specification.Service{
-		Name:    "TestAPI",
-		Version: "1.0.0",
+		Name:         "TestService",
+		Version:      "1.0.0",
+		ExternalDocs: &specification.ExternalDocs{URL: "https://docs.example.com", Description: "Guides"},
 		Resources: []specification.Resource{
 			{
-				Name:        "School",
+				Name:         "User",
+				Description:  "Users of the service",
+				ExternalDocs: &specification.ExternalDocs{URL: "https://docs.example.com/users"},
+				Endpoints: []specification.Endpoint{
+					{
+						Name:         "Invite",
+						Method:       "POST",
+						Path:         "/invite",
+						Response:     specification.EndpointResponse{StatusCode: 204},
+						ExternalDocs: &specification.ExternalDocs{URL: "https://docs.example.com/users/invite", Description: "Inviting users"},
+					},
+					{
+						Name:     "Archive",
+						Method:   "POST",
+						Path:     "/archive",
+						Response: specification.EndpointResponse{StatusCode: 204},
+					},
+				},
+			},
+		},
+	}
+
+	// Act
+	document, err := generator.generateFromService(service)
+
+	// Assert
+	assert.NoError(t, err, "Should generate document successfully")
+	assert.Equal(t, "https://docs.example.com", document.ExternalDocs.URL, "Service external docs should be on the document")
+	assert.Equal(t, "Guides", document.ExternalDocs.Description)
+	assert.Equal(t, "https://docs.example.com/users", document.Tags[0].ExternalDocs.URL, "Resource external docs should be on its tag")
+
+	invite, ok := document.Paths.PathItems.Get("/user/invite")
+	assert.True(t, ok, "Invite path should exist")
+	assert.Equal(t, "https://docs.example.com/users/invite", invite.Post.ExternalDocs.URL, "Endpoint external docs should be on its operation")
+	assert.Equal(t, "Inviting users", invite.Post.ExternalDocs.Description)
+
+	archive, ok := document.Paths.PathItems.Get("/user/archive")
+	assert.True(t, ok, "Archive path should exist")
+	assert.Nil(t, archive.Post.ExternalDocs, "Operations without external docs should not have them")
+
+	jsonBytes, err := generator.toJSON(document)
+	assert.NoError(t, err, "Should convert to JSON successfully")
+	assert.Contains(t, string(jsonBytes), "\"externalDocs\"", "JSON should contain externalDocs")
+}
+
+// TestParameterDescriptionNotDuplicated verifies that parameter descriptions are not duplicated in schema objects
+func TestParameterDescriptionNotDuplicated(t *testing.T) {
+	generator := newGenerator()
+	service := &specification.Service{
+		Name:    "TestAPI",
+		Version: "1.0.0",
+		Resources: []specification.Resource{
+			{
+				Name:        "School",
 				Description: "School resource for testing parameter descriptions",
 				Operations:  []string{specification.OperationRead},
 				Endpoints: []specification.Endpoint{
@@ -3270,7 +3960,7 @@ func TestParameterDescriptionNotDuplicated(t *testing.T) {
 
 	parameters, ok := getOp["parameters"].([]interface{})
 	assert.True(t, ok, "Should have parameters array")
-	assert.Len(t, parameters, 3, "Should have exactly 3 parameters")
+	assert.Len(t, parameters, 4, "Should have the 3 query parameters and the request ID header")
 
 	// Check each parameter
 	for i, param := range parameters {
@@ -3438,85 +4128,1438 @@ func TestGenerator_GenerateFromServiceWithSecurity(t *testing.T) {
 		}
 	}
 
-	assert.Equal(t, 4, schemeCount, "Should have 4 security schemes")
+	assert.Equal(t, 4, schemeCount, "Should have 4 security schemes")
+
+	// Verify security requirements in document
+	assert.NotNil(t, document.Security, "Document should have security requirements")
+	assert.Len(t, document.Security, 2, "Should have 2 security requirements")
+
+	// Verify first security requirement (mtls + bearerAuth)
+	firstReq := document.Security[0]
+	assert.NotNil(t, firstReq.Requirements, "First security requirement should have requirements")
+
+	mtlsScopes, mtlsExists := firstReq.Requirements.Get("mtls")
+	bearerScopes, bearerExists := firstReq.Requirements.Get("bearerAuth")
+	assert.True(t, mtlsExists, "First requirement should contain mtls")
+	assert.True(t, bearerExists, "First requirement should contain bearerAuth")
+	assert.Len(t, mtlsScopes, 0, "MTLS should have empty scopes")
+	assert.Len(t, bearerScopes, 0, "Bearer auth should have empty scopes")
+
+	// Verify second security requirement (clientId + clientSecret)
+	secondReq := document.Security[1]
+	assert.NotNil(t, secondReq.Requirements, "Second security requirement should have requirements")
+
+	clientIdScopes, clientIdExists := secondReq.Requirements.Get("clientId")
+	clientSecretScopes, clientSecretExists := secondReq.Requirements.Get("clientSecret")
+	assert.True(t, clientIdExists, "Second requirement should contain clientId")
+	assert.True(t, clientSecretExists, "Second requirement should contain clientSecret")
+	assert.Len(t, clientIdScopes, 0, "Client ID should have empty scopes")
+	assert.Len(t, clientSecretScopes, 0, "Client secret should have empty scopes")
+}
+
+// TestGenerator_GenerateFromServiceSecurityYAML tests that security generates correct YAML output.
+func TestGenerator_GenerateFromServiceSecurityYAML(t *testing.T) {
+	// Create a minimal service with security
+	service := &specification.Service{
+		Name:    "Security YAML Test",
+		Version: "1.0.0",
+		SecuritySchemes: map[string]specification.SecurityScheme{
+			"mtls": {
+				Type:        "mutualTLS",
+				Description: "Client TLS certificate required.",
+			},
+			"bearerAuth": {
+				Type:         "http",
+				Scheme:       "bearer",
+				BearerFormat: "JWT",
+				Description:  "Bearer access token in Authorization header.",
+			},
+		},
+		Security: []specification.SecurityRequirement{
+			{"mtls", "bearerAuth"},
+		},
+		Enums:     []specification.Enum{},
+		Objects:   []specification.Object{},
+		Resources: []specification.Resource{},
+	}
+
+	// Generate document
+	generator := newGenerator()
+	document, err := generator.generateFromService(service)
+
+	assert.NoError(t, err, "Should generate document without error")
+	assert.NotNil(t, document, "Generated document should not be nil")
+
+	// Render to YAML and verify structure
+	yamlBytes, err := document.Render()
+	assert.NoError(t, err, "Should render document to YAML without error")
+
+	yamlStr := string(yamlBytes)
+
+	// Verify the YAML contains expected security structures
+	assert.Contains(t, yamlStr, "components:", "YAML should contain components section")
+	assert.Contains(t, yamlStr, "securitySchemes:", "YAML should contain securitySchemes")
+	assert.Contains(t, yamlStr, "security:", "YAML should contain security requirements")
+	assert.Contains(t, yamlStr, "mtls:", "YAML should contain mtls scheme")
+	assert.Contains(t, yamlStr, "bearerAuth:", "YAML should contain bearerAuth scheme")
+	assert.Contains(t, yamlStr, "mutualTLS", "YAML should contain mutualTLS type")
+	assert.Contains(t, yamlStr, "Client TLS certificate required.", "YAML should contain MTLS description")
+	assert.Contains(t, yamlStr, "Bearer access token in Authorization header.", "YAML should contain bearer auth description")
+
+	t.Logf("Generated Security YAML:\n%s", yamlStr)
+}
+
+// TestGenerator_GenerateFromServiceWithOAuth2Scopes tests OpenID Connect schemes and operation level scopes.
+func TestGenerator_GenerateFromServiceWithOAuth2Scopes(t *testing.T) {
+	// Arrange
+	service := specification.ApplyOverlay(&specification.Service{
+		Name:    "Scopes Test API",
+		Version: "1.0.0",
+		SecuritySchemes: map[string]specification.SecurityScheme{
+			"oauth": {
+				Type: "oauth2",
+				Flows: &specification.OAuth2Flows{
+					ClientCredentials: &specification.OAuth2Flow{
+						TokenURL: "https://auth.example.com/token",
+						Scopes: map[string]string{
+							"users:write": "Modify users",
+							"users:read":  "Read users",
+						},
+					},
+				},
+			},
+			"oidc": {
+				Type:             "openIdConnect",
+				OpenIDConnectURL: "https://auth.example.com/.well-known/openid-configuration",
+			},
+			"apiKey": {
+				Type: "apiKey",
+				In:   "header",
+				Name: "X-API-Key",
+			},
+		},
+		Security: []specification.SecurityRequirement{
+			{"oauth"},
+			{"oidc", "apiKey"},
+		},
+		Resources: []specification.Resource{
+			{
+				Name:        "Users",
+				Description: "Users resource",
+				Operations:  []string{"Get", "Create"},
+				Scopes:      []string{"users:read"},
+				Fields: []specification.ResourceField{
+					{
+						Field:      specification.Field{Name: "Name", Type: "String", Description: "Name"},
+						Operations: []string{"Create", "Read"},
+					},
+				},
+				Endpoints: []specification.Endpoint{
+					{
+						Name:     "Archive",
+						Method:   "POST",
+						Path:     "/{id}/archive",
+						Scopes:   []string{"users:write"},
+						Response: specification.EndpointResponse{StatusCode: 204},
+					},
+				},
+			},
+		},
+	})
+
+	// Act
+	document, err := newGenerator().generateFromService(service)
+
+	// Assert
+	assert.NoError(t, err, "Should generate document without error")
+
+	oidcScheme, exists := document.Components.SecuritySchemes.Get("oidc")
+	assert.True(t, exists, "Components should contain the OpenID Connect scheme")
+	assert.Equal(t, "https://auth.example.com/.well-known/openid-configuration", oidcScheme.OpenIdConnectUrl)
+
+	oauthScheme, exists := document.Components.SecuritySchemes.Get("oauth")
+	assert.True(t, exists, "Components should contain the OAuth2 scheme")
+	var scopeNames []string
+	for pair := oauthScheme.Flows.ClientCredentials.Scopes.First(); pair != nil; pair = pair.Next() {
+		scopeNames = append(scopeNames, pair.Key())
+	}
+	assert.Equal(t, []string{"users:read", "users:write"}, scopeNames, "Scopes should be sorted")
+
+	getOperation := document.Paths.PathItems.GetOrZero("/users/{id}").Get
+	assert.Len(t, getOperation.Security, 2, "Operation should repeat the service security requirements")
+	oauthScopes, _ := getOperation.Security[0].Requirements.Get("oauth")
+	assert.Equal(t, []string{"users:read"}, oauthScopes, "Resource scopes should apply to generated endpoints")
+	oidcScopes, _ := getOperation.Security[1].Requirements.Get("oidc")
+	assert.Equal(t, []string{"users:read"}, oidcScopes, "OpenID Connect schemes should carry scopes")
+	apiKeyScopes, _ := getOperation.Security[1].Requirements.Get("apiKey")
+	assert.Empty(t, apiKeyScopes, "API key schemes should not carry scopes")
+
+	archiveOperation := document.Paths.PathItems.GetOrZero("/users/{id}/archive").Post
+	archiveScopes, _ := archiveOperation.Security[0].Requirements.Get("oauth")
+	assert.Equal(t, []string{"users:write"}, archiveScopes, "Endpoint scopes should override resource scopes")
+
+	documentScopes, _ := document.Security[0].Requirements.Get("oauth")
+	assert.Empty(t, documentScopes, "Document level security should not require scopes")
+}
+
+func TestGenerator_GenerateFromServiceWithPermissions(t *testing.T) {
+	// Arrange
+	service := specification.ApplyOverlay(&specification.Service{
+		Name:    "Permissions Test API",
+		Version: "1.0.0",
+		Resources: []specification.Resource{
+			{
+				Name:        "Users",
+				Description: "Users resource",
+				Operations:  []string{"Get"},
+				Permissions: []string{"users:view"},
+				Fields: []specification.ResourceField{
+					{
+						Field:      specification.Field{Name: "Name", Type: "String", Description: "Name"},
+						Operations: []string{"Read"},
+					},
+				},
+				Endpoints: []specification.Endpoint{
+					{
+						Name:        "Archive",
+						Method:      "POST",
+						Path:        "/{id}/archive",
+						Permissions: []string{"users:archive", "users:edit"},
+						Response:    specification.EndpointResponse{StatusCode: 204},
+					},
+				},
+			},
+			{
+				Name:        "Schools",
+				Description: "Schools resource",
+				Operations:  []string{"Get"},
+				Fields: []specification.ResourceField{
+					{
+						Field:      specification.Field{Name: "Name", Type: "String", Description: "Name"},
+						Operations: []string{"Read"},
+					},
+				},
+			},
+		},
+	})
+
+	// Act
+	document, err := newGenerator().generateFromService(service)
+
+	// Assert
+	assert.NoError(t, err, "Should generate document without error")
+
+	getPermissions := document.Paths.PathItems.GetOrZero("/users/{id}").Get.Extensions.GetOrZero("x-permissions")
+	var permissions []string
+	assert.NoError(t, getPermissions.Decode(&permissions), "x-permissions should be a list")
+	assert.Equal(t, []string{"users:view"}, permissions, "Resource permissions should apply to generated endpoints")
+
+	archivePermissions := document.Paths.PathItems.GetOrZero("/users/{id}/archive").Post.Extensions.GetOrZero("x-permissions")
+	assert.NoError(t, archivePermissions.Decode(&permissions), "x-permissions should be a list")
+	assert.Equal(t, []string{"users:archive", "users:edit"}, permissions, "Endpoint permissions should override resource permissions")
+
+	schoolOperation := document.Paths.PathItems.GetOrZero("/schools/{id}").Get
+	assert.Nil(t, schoolOperation.Extensions.GetOrZero("x-permissions"), "Operations without permissions should not have x-permissions")
+}
+
+func TestGenerator_GenerateFromServiceWithStability(t *testing.T) {
+	// Arrange
+	service := specification.ApplyOverlay(&specification.Service{
+		Name:           "Stability Test API",
+		Version:        "1.0.0",
+		TermsOfService: "https://example.com/terms",
+		Resources: []specification.Resource{
+			{
+				Name:        "Users",
+				Description: "Users resource",
+				Operations:  []string{"Get"},
+				Stability:   specification.StabilityStable,
+				Fields: []specification.ResourceField{
+					{
+						Field:      specification.Field{Name: "Name", Type: "String", Description: "Name"},
+						Operations: []string{"Read"},
+					},
+				},
+				Endpoints: []specification.Endpoint{
+					{
+						Name:      "Archive",
+						Method:    "POST",
+						Path:      "/{id}/archive",
+						Stability: specification.StabilityExperimental,
+						Response:  specification.EndpointResponse{StatusCode: 204},
+					},
+				},
+			},
+			{
+				Name:        "Schools",
+				Description: "Schools resource",
+				Operations:  []string{"Get"},
+				Fields: []specification.ResourceField{
+					{
+						Field:      specification.Field{Name: "Name", Type: "String", Description: "Name"},
+						Operations: []string{"Read"},
+					},
+				},
+			},
+		},
+	})
+
+	// Act
+	document, err := newGenerator().generateFromService(service)
+
+	// Assert
+	assert.NoError(t, err, "Should generate document without error")
+	assert.Equal(t, "https://example.com/terms", document.Info.TermsOfService, "Terms of service should be in the info")
+
+	getStability := document.Paths.PathItems.GetOrZero("/users/{id}").Get.Extensions.GetOrZero("x-stability")
+	assert.Equal(t, specification.StabilityStable, getStability.Value, "Resource stability should apply to generated endpoints")
+
+	archiveStability := document.Paths.PathItems.GetOrZero("/users/{id}/archive").Post.Extensions.GetOrZero("x-stability")
+	assert.Equal(t, specification.StabilityExperimental, archiveStability.Value, "Endpoint stability should override resource stability")
+
+	schoolOperation := document.Paths.PathItems.GetOrZero("/schools/{id}").Get
+	assert.Nil(t, schoolOperation.Extensions.GetOrZero("x-stability"), "Operations without stability should not have x-stability")
+}
+
+func TestGenerator_GenerateFromServiceWithRequestLimits(t *testing.T) {
+	// Arrange
+	service := specification.ApplyOverlay(&specification.Service{
+		Name:           "Limits Test API",
+		Version:        "1.0.0",
+		MaxBodyBytes:   1024,
+		HandlerTimeout: 5000,
+		Resources: []specification.Resource{
+			{
+				Name:        "Users",
+				Description: "Users resource",
+				Operations:  []string{"Create", "Get"},
+				Fields: []specification.ResourceField{
+					{
+						Field:      specification.Field{Name: "Name", Type: "String", Description: "Name"},
+						Operations: []string{"Create", "Read"},
+					},
+				},
+				Endpoints: []specification.Endpoint{
+					{
+						Name:           "Import",
+						Method:         "POST",
+						Path:           "/import",
+						MaxBodyBytes:   1 << 20,
+						HandlerTimeout: 60000,
+						Request: specification.EndpointRequest{
+							BodyParams: []specification.Field{{Name: "Data", Type: "String", Description: "Data"}},
+						},
+						Response: specification.EndpointResponse{StatusCode: 204},
+					},
+				},
+			},
+		},
+	})
+
+	// Act
+	document, err := newGenerator().generateFromService(service)
+
+	// Assert
+	assert.NoError(t, err, "Should generate document without error")
+	assert.NotNil(t, document.Components.Responses.GetOrZero("Error408ResponseBody"), "Should define the 408 response")
+	assert.NotNil(t, document.Components.Responses.GetOrZero("Error413ResponseBody"), "Should define the 413 response")
+
+	createOperation := document.Paths.PathItems.GetOrZero("/users").Post
+	assert.Equal(t, "1024", createOperation.Extensions.GetOrZero("x-max-body-bytes").Value, "Service limit should apply to generated endpoints")
+	assert.Equal(t, "5000", createOperation.Extensions.GetOrZero("x-handler-timeout").Value, "Service timeout should apply to generated endpoints")
+	assert.NotNil(t, createOperation.Responses.Codes.GetOrZero("408"), "Should document the timeout")
+	assert.NotNil(t, createOperation.Responses.Codes.GetOrZero("413"), "Should document the body limit")
+
+	importOperation := document.Paths.PathItems.GetOrZero("/users/import").Post
+	assert.Equal(t, "1048576", importOperation.Extensions.GetOrZero("x-max-body-bytes").Value, "Endpoint limit should override the service limit")
+	assert.Equal(t, "60000", importOperation.Extensions.GetOrZero("x-handler-timeout").Value, "Endpoint timeout should override the service timeout")
+
+	getOperation := document.Paths.PathItems.GetOrZero("/users/{id}").Get
+	assert.Nil(t, getOperation.Extensions.GetOrZero("x-max-body-bytes"), "Operations without a body should not have x-max-body-bytes")
+	assert.Nil(t, getOperation.Responses.Codes.GetOrZero("413"), "Operations without a body should not document 413")
+	assert.NotNil(t, getOperation.Responses.Codes.GetOrZero("408"), "Should document the timeout")
+}
+
+func TestGenerator_GenerateFromServiceWithoutRequestLimits(t *testing.T) {
+	// Arrange
+	service := specification.ApplyOverlay(&specification.Service{
+		Name:      "Limits Test API",
+		Version:   "1.0.0",
+		Resources: []specification.Resource{{Name: "Users", Description: "Users resource", Operations: []string{"Create"}}},
+	})
+
+	// Act
+	document, err := newGenerator().generateFromService(service)
+
+	// Assert
+	assert.NoError(t, err, "Should generate document without error")
+	assert.Nil(t, document.Components.Responses.GetOrZero("Error408ResponseBody"), "Should not define the 408 response without timeouts")
+	assert.Nil(t, document.Components.Responses.GetOrZero("Error413ResponseBody"), "Should not define the 413 response without body limits")
+	assert.Nil(t, document.Paths.PathItems.GetOrZero("/users").Post.Responses.Codes.GetOrZero("413"), "Should not document 413 without body limits")
+}
+
+func TestGenerator_GenerateFromServiceWithFieldScopes(t *testing.T) {
+	// Arrange
+	service := &specification.Service{
+		Name:    "Field Scopes Test API",
+		Version: "1.0.0",
+		Objects: []specification.Object{
+			{
+				Name:        "User",
+				Description: "User",
+				Fields: []specification.Field{
+					{Name: "Name", Type: "String", Description: "Name"},
+					{Name: "SSN", Type: "String", Description: "Social security number", Modifiers: []string{"Nullable"}, Scopes: []string{"users:ssn", "users:pii"}},
+				},
+			},
+		},
+	}
+
+	// Act
+	document, err := newGenerator().generateFromService(service)
+
+	// Assert
+	assert.NoError(t, err, "Should generate document without error")
+	userSchema := document.Components.Schemas.GetOrZero("User").Schema()
+
+	ssnScopes := userSchema.Properties.GetOrZero("ssn").Schema().Extensions.GetOrZero("x-required-scopes")
+	var scopes []string
+	assert.NoError(t, ssnScopes.Decode(&scopes), "x-required-scopes should be a list")
+	assert.Equal(t, []string{"users:ssn", "users:pii"}, scopes, "Should document the scopes of the field")
+
+	assert.Nil(t, userSchema.Properties.GetOrZero("name").Schema().Extensions, "Fields without scopes should not have x-required-scopes")
+}
+
+func TestGenerator_GenerateFromServiceWithReferences(t *testing.T) {
+	// Arrange
+	service := specification.ApplyOverlay(&specification.Service{
+		Name:    "References Test API",
+		Version: "1.0.0",
+		Resources: []specification.Resource{
+			{Name: "Users", Description: "Users resource", Operations: []string{"Get"}},
+			{
+				Name:        "Orders",
+				Description: "Orders resource",
+				Operations:  []string{"Get"},
+				Fields: []specification.ResourceField{
+					{Field: specification.Field{Name: "BuyerID", Type: "UUID", Description: "Buyer of the order", References: "Users"}, Operations: []string{"Read"}},
+					{Field: specification.Field{Name: "Note", Type: "String", Description: "Note"}, Operations: []string{"Read"}},
+				},
+				Endpoints: []specification.Endpoint{
+					{
+						Name:        "ByBuyer",
+						Summary:     "By buyer",
+						Description: "Orders of a buyer",
+						Method:      "GET",
+						Path:        "/by-buyer/{buyerID}",
+						Request: specification.EndpointRequest{
+							PathParams: []specification.Field{{Name: "BuyerID", Type: "UUID", Description: "Buyer", References: "Users"}},
+						},
+						Response: specification.EndpointResponse{StatusCode: 200, BodyObject: stringPtr("Orders")},
+					},
+				},
+			},
+		},
+	})
+
+	// Act
+	document, err := newGenerator().generateFromService(service)
+
+	// Assert
+	assert.NoError(t, err, "Should generate document without error")
+	ordersSchema := document.Components.Schemas.GetOrZero("Orders").Schema()
+	buyerSchema := ordersSchema.Properties.GetOrZero("buyerID").Schema()
+	assert.Equal(t, "Users", buyerSchema.Extensions.GetOrZero("x-references").Value, "Should name the referenced resource")
+	assert.Equal(t, "Buyer of the order\n\nThe ID of a [Users](#/components/schemas/Users).", buyerSchema.Description, "Should link the referenced resource")
+	assert.Nil(t, ordersSchema.Properties.GetOrZero("note").Schema().Extensions, "Fields without references should not have x-references")
+
+	param := document.Paths.PathItems.GetOrZero("/orders/by-buyer/{buyerID}").Get.Parameters[0]
+	assert.Equal(t, "Buyer\n\nThe ID of a [Users](#/components/schemas/Users).", param.Description, "Should link the resource referenced by the parameter")
+	assert.Equal(t, "Users", param.Schema.Schema().Extensions.GetOrZero("x-references").Value, "Should name the resource referenced by the parameter")
+}
+
+func TestGenerator_GenerateFromServiceWithAsyncEndpoints(t *testing.T) {
+	// Arrange
+	service := specification.ApplyOverlay(&specification.Service{
+		Name:    "Async Test API",
+		Version: "1.0.0",
+		Resources: []specification.Resource{
+			{
+				Name:        "Reports",
+				Description: "Reports resource",
+				Endpoints:   []specification.Endpoint{{Name: "Generate", Method: "POST", Path: "/_generate", Async: true}},
+			},
+		},
+	})
+
+	// Act
+	document, err := newGenerator().generateFromService(service)
+
+	// Assert
+	assert.NoError(t, err, "Should generate document without error")
+	generate := document.Paths.PathItems.GetOrZero("/reports/_generate").Post
+	require.NotNil(t, generate)
+	assert.NotNil(t, generate.Responses.Codes.GetOrZero("202"), "Should respond with 202 Accepted")
+
+	accepted := document.Components.Responses.GetOrZero("ReportsGenerate")
+	require.NotNil(t, accepted)
+	assert.NotNil(t, accepted.Headers.GetOrZero("Location"), "Should document the path the operation is polled at")
+	assert.NotNil(t, accepted.Headers.GetOrZero("Retry-After"), "Should document when to poll the operation")
+	link := accepted.Links.GetOrZero("GetOperation")
+	require.NotNil(t, link, "Should link the operation polling the operation")
+	assert.Equal(t, "OperationGet", link.OperationId)
+	assert.Equal(t, "$response.body#/id", link.Parameters.GetOrZero("id"))
+
+	poll := document.Paths.PathItems.GetOrZero("/operation/{id}").Get
+	require.NotNil(t, poll, "Should document the operation polling the operations")
+	assert.NotNil(t, document.Components.Responses.GetOrZero("OperationGet").Headers.GetOrZero("Retry-After"))
+	polling, ok := poll.Extensions.Get("x-speakeasy-polling")
+	require.True(t, ok, "Should configure the polling of the SDKs")
+	assert.Equal(t, `$response.body#/status == "Succeeded"`, polling.Content[0].Content[3].Content[1].Content[1].Value)
+}
+
+func TestGenerator_GenerateFromServiceWithCallbacks(t *testing.T) {
+	// Arrange
+	service := specification.ApplyOverlay(&specification.Service{
+		Name:    "Callbacks Test API",
+		Version: "1.0.0",
+		Objects: []specification.Object{{Name: "Shipment", Description: "Shipment of an order", Fields: []specification.Field{{Name: "TrackingCode", Type: "String", Description: "Tracking code"}}}},
+		Resources: []specification.Resource{
+			{
+				Name:        "Orders",
+				Description: "Orders resource",
+				Endpoints: []specification.Endpoint{
+					{
+						Name:      "Subscribe",
+						Method:    "POST",
+						Path:      "/_subscribe",
+						Request:   specification.EndpointRequest{BodyParams: []specification.Field{{Name: "CallbackURL", Type: "String", Description: "URL of the subscriber"}}},
+						Response:  specification.EndpointResponse{StatusCode: 204},
+						Callbacks: []specification.Callback{{Name: "OrderShipped", Description: "Sent when the order is shipped", URLField: "CallbackURL", Payload: "Shipment"}},
+					},
+				},
+			},
+		},
+	})
+
+	// Act
+	document, err := newGenerator().generateFromService(service)
+
+	// Assert
+	assert.NoError(t, err, "Should generate document without error")
+	subscribe := document.Paths.PathItems.GetOrZero("/orders/_subscribe").Post
+	require.NotNil(t, subscribe)
+	callback := subscribe.Callbacks.GetOrZero("OrderShipped")
+	require.NotNil(t, callback, "Should document the callback")
+
+	pathItem := callback.Expression.GetOrZero("{$request.body#/callbackURL}")
+	require.NotNil(t, pathItem, "Should send the callback to the URL of the request body")
+	require.NotNil(t, pathItem.Post, "Should send the callback with POST by default")
+	assert.Equal(t, "OrdersSubscribeOrderShipped", pathItem.Post.OperationId)
+	assert.Equal(t, "Sent when the order is shipped", pathItem.Post.Description)
+	assert.Equal(t, "#/components/schemas/Shipment", pathItem.Post.RequestBody.Content.GetOrZero("application/json").Schema.GetReference())
+	assert.NotNil(t, pathItem.Post.Responses.Codes.GetOrZero("200"))
+}
+
+func TestGenerator_GenerateFromServiceWithFeatureFlags(t *testing.T) {
+	service := specification.ApplyOverlay(&specification.Service{
+		Name:    "Feature Flags Test API",
+		Version: "1.0.0",
+		Resources: []specification.Resource{
+			{
+				Name:        "Users",
+				Description: "Users resource",
+				Operations:  []string{"Get"},
+				Fields: []specification.ResourceField{
+					{Field: specification.Field{Name: "Name", Type: "String", Description: "Name of the user"}, Operations: []string{"Read"}},
+				},
+				Endpoints: []specification.Endpoint{
+					{
+						Name:        "Export",
+						Method:      "POST",
+						Path:        "/_export",
+						FeatureFlag: "users-export",
+						Request:     specification.EndpointRequest{BodyParams: []specification.Field{{Name: "Format", Type: "String", Description: "Format of the export"}}},
+						Response:    specification.EndpointResponse{StatusCode: 204},
+					},
+				},
+			},
+		},
+	})
+
+	t.Run("documents the feature flag of the operations", func(t *testing.T) {
+		// Act
+		document, err := newGenerator().generateFromService(service)
+
+		// Assert
+		assert.NoError(t, err, "Should generate document without error")
+		export := document.Paths.PathItems.GetOrZero("/users/_export").Post
+		require.NotNil(t, export)
+		featureFlag := export.Extensions.GetOrZero(featureFlagExtension)
+		require.NotNil(t, featureFlag, "Should document the feature flag")
+		assert.Equal(t, "users-export", featureFlag.Value)
+		assert.NotNil(t, document.Components.RequestBodies.GetOrZero("UsersExport"), "Should document the request body of the flagged endpoint")
+	})
+
+	t.Run("excludes the flagged endpoints", func(t *testing.T) {
+		// Arrange
+		generator := newGenerator()
+		generator.ExcludeFeatureFlaggedEndpoints = true
+
+		// Act
+		document, err := generator.generateFromService(service)
+
+		// Assert
+		assert.NoError(t, err, "Should generate document without error")
+		assert.Nil(t, document.Paths.PathItems.GetOrZero("/users/_export"), "Should leave the flagged endpoint out of the paths")
+		assert.NotNil(t, document.Paths.PathItems.GetOrZero("/users/{id}"), "Should keep the endpoints without a feature flag")
+		assert.Nil(t, document.Components.RequestBodies.GetOrZero("UsersExport"), "Should leave the request body of the flagged endpoint out of the components")
+		assert.Len(t, service.Resources[0].Endpoints, 2, "Should not modify the service")
+	})
+}
+
+func TestGenerator_GenerateFromServiceWithExpansions(t *testing.T) {
+	// Arrange
+	service := specification.ApplyOverlay(&specification.Service{
+		Name:    "Expansions Test API",
+		Version: "1.0.0",
+		Resources: []specification.Resource{
+			{Name: "Users", Description: "Users resource", Operations: []string{"Get"}},
+			{
+				Name:        "Orders",
+				Description: "Orders resource",
+				Operations:  []string{"Get"},
+				Fields: []specification.ResourceField{
+					{Field: specification.Field{Name: "BuyerID", Type: "UUID", Description: "Buyer of the order", References: "Users"}, Operations: []string{"Read"}},
+				},
+				Expansions: []specification.Expansion{{Name: "Buyer", Field: "BuyerID"}},
+			},
+		},
+	})
+
+	// Act
+	document, err := newGenerator().generateFromService(service)
+
+	// Assert
+	assert.NoError(t, err, "Should generate document without error")
+	enumSchema := document.Components.Schemas.GetOrZero("OrdersExpansion").Schema()
+	require.NotNil(t, enumSchema, "Should document the allowed expansions")
+	require.Len(t, enumSchema.Enum, 1)
+	assert.Equal(t, "Buyer", enumSchema.Enum[0].Value)
+
+	buyerSchema := document.Components.Schemas.GetOrZero("Orders").Schema().Properties.GetOrZero("buyer")
+	require.NotNil(t, buyerSchema, "Should document the expanded resource")
+	require.Len(t, buyerSchema.Schema().AllOf, 1)
+	assert.Equal(t, "#/components/schemas/Users", buyerSchema.Schema().AllOf[0].GetReference())
+
+	var expand *v3.Parameter
+	for _, param := range document.Paths.PathItems.GetOrZero("/orders/{id}").Get.Parameters {
+		if param.Name == "expand" {
+			expand = param
+		}
+	}
+	require.NotNil(t, expand, "Should document the expand query parameter")
+	assert.Equal(t, "query", expand.In)
+	items := expand.Schema.Schema().Items.A.Schema()
+	require.Len(t, items.AllOf, 1)
+	assert.Equal(t, "#/components/schemas/OrdersExpansion", items.AllOf[0].GetReference(), "Should only allow the expansions of the resource")
+}
+
+func TestGenerator_GenerateFromServiceWithParameterGroups(t *testing.T) {
+	// Arrange
+	service := specification.ApplyOverlay(&specification.Service{
+		Name:    "Parameter Groups Test API",
+		Version: "1.0.0",
+		ParameterGroups: []specification.ParameterGroup{
+			{Name: "Include", Fields: []specification.Field{{Name: "IncludeArchived", Type: "Bool", Description: "Include archived"}}},
+		},
+		Resources: []specification.Resource{
+			{
+				Name:        "Users",
+				Description: "Users resource",
+				Endpoints: []specification.Endpoint{
+					{
+						Name:   "Export",
+						Method: "GET",
+						Path:   "/export",
+						Request: specification.EndpointRequest{
+							QueryParams:     []specification.Field{{Name: "Format", Type: "String", Description: "Format"}},
+							ParameterGroups: []string{"Include"},
+						},
+						Response: specification.EndpointResponse{StatusCode: 204},
+					},
+				},
+			},
+		},
+	})
+	buf := &bytes.Buffer{}
+
+	// Act
+	err := GenerateOpenAPI(buf, service)
+
+	// Assert
+	assert.NoError(t, err, "Should generate document without error")
+
+	var document map[string]any
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &document))
+	components := document["components"].(map[string]any)
+	parameters := components["parameters"].(map[string]any)
+	assert.Contains(t, parameters, "IncludeIncludeArchived", "Group fields should be added to the parameters components")
+
+	operation := document["paths"].(map[string]any)["/users/export"].(map[string]any)["get"].(map[string]any)
+	operationParameters := operation["parameters"].([]any)
+	assert.Len(t, operationParameters, 3)
+	assert.Equal(t, "format", operationParameters[0].(map[string]any)["name"], "Own query params should be inlined")
+	assert.Equal(t, map[string]any{"$ref": "#/components/parameters/IncludeIncludeArchived"}, operationParameters[1], "Group fields should be referenced")
+}
+
+func TestGenerator_GenerateFromServiceWithExtendedObjects(t *testing.T) {
+	service := specification.ApplyOverlay(&specification.Service{
+		Name:    "Extends Test API",
+		Version: "1.0.0",
+		Objects: []specification.Object{
+			{Name: "Audited", Description: "Audit fields", Fields: []specification.Field{{Name: "CreatedAt", Type: "Timestamp", Description: "Creation time"}}},
+			{Name: "Person", Description: "A person", Extends: "Audited", Fields: []specification.Field{{Name: "Name", Type: "String", Description: "Name"}}},
+		},
+	})
+
+	t.Run("flattened schema by default", func(t *testing.T) {
+		// Act
+		document, err := newGenerator().generateFromService(service)
+
+		// Assert
+		assert.NoError(t, err, "Should generate document without error")
+		person := document.Components.Schemas.GetOrZero("Person").Schema()
+		assert.Empty(t, person.AllOf, "Schema should not be composed")
+		assert.Equal(t, 2, person.Properties.Len(), "Schema should contain inherited and own properties")
+	})
+
+	t.Run("allOf composition", func(t *testing.T) {
+		// Arrange
+		generator := newGenerator()
+		generator.ComposeExtendedObjects = true
+
+		// Act
+		document, err := generator.generateFromService(service)
+
+		// Assert
+		assert.NoError(t, err, "Should generate document without error")
+		person := document.Components.Schemas.GetOrZero("Person").Schema()
+		assert.Len(t, person.AllOf, 2, "Schema should compose the parent and own fields")
+		assert.Equal(t, "#/components/schemas/Audited", person.AllOf[0].GetReference())
+		ownSchema := person.AllOf[1].Schema()
+		_, hasName := ownSchema.Properties.Get("name")
+		_, hasCreatedAt := ownSchema.Properties.Get("createdAt")
+		assert.True(t, hasName, "Own schema should contain own properties")
+		assert.False(t, hasCreatedAt, "Own schema should not repeat inherited properties")
+	})
+}
+
+func TestGenerator_GenerateFromServiceWithOperationalEndpoints(t *testing.T) {
+	createService := func(operational *specification.OperationalEndpoints) *specification.Service {
+		return specification.ApplyOverlay(&specification.Service{
+			Name:        "Operational Test API",
+			Version:     "1.0.0",
+			Operational: operational,
+			SecuritySchemes: map[string]specification.SecurityScheme{
+				"apiKey": {Type: "apiKey", In: "header", Name: "X-API-Key"},
+			},
+			Security: []specification.SecurityRequirement{{"apiKey"}},
+		})
+	}
+
+	t.Run("documents the enabled endpoints", func(t *testing.T) {
+		// Arrange
+		service := createService(&specification.OperationalEndpoints{Health: true, Version: true})
+
+		// Act
+		document, err := newGenerator().generateFromService(service)
+
+		// Assert
+		assert.NoError(t, err, "Should generate document without error")
+
+		health := document.Paths.PathItems.GetOrZero("/healthz")
+		assert.NotNil(t, health, "Health endpoint should be documented")
+		assert.Equal(t, "OperationalHealth", health.Get.OperationId)
+		assert.Equal(t, []string{"Operational"}, health.Get.Tags)
+		assert.NotNil(t, health.Get.Responses.Codes.GetOrZero("503"), "Health endpoint should document the failed check")
+		assert.True(t, health.Get.Security[0].ContainsEmptyRequirement, "Health endpoint should not require authentication")
+		_, ignored := health.Get.Extensions.Get("x-speakeasy-ignore")
+		assert.False(t, ignored, "Health endpoint should be part of the SDK by default")
+
+		version := document.Paths.PathItems.GetOrZero("/version")
+		assert.NotNil(t, version, "Version endpoint should be documented")
+
+		assert.Nil(t, document.Paths.PathItems.GetOrZero("/readyz"), "Readiness endpoint should not be documented when disabled")
+		assert.Equal(t, "Operational", document.Tags[len(document.Tags)-1].Name, "Operational tag should be added")
+	})
+
+	t.Run("excludes endpoints from the SDK", func(t *testing.T) {
+		// Arrange
+		service := createService(&specification.OperationalEndpoints{Readiness: true, ExcludeFromSDK: true})
+
+		// Act
+		document, err := newGenerator().generateFromService(service)
+
+		// Assert
+		assert.NoError(t, err, "Should generate document without error")
+		readiness := document.Paths.PathItems.GetOrZero("/readyz")
+		ignore, exists := readiness.Get.Extensions.Get("x-speakeasy-ignore")
+		assert.True(t, exists, "Readiness endpoint should be ignored by the SDK")
+		assert.Equal(t, "true", ignore.Value)
+	})
+}
+
+func TestGenerator_GenerateFromServiceWithTenancy(t *testing.T) {
+	createService := func(tenancy *specification.Tenancy) *specification.Service {
+		return specification.ApplyOverlay(&specification.Service{
+			Name:    "Tenancy Test API",
+			Version: "1.0.0",
+			Tenancy: tenancy,
+			Resources: []specification.Resource{
+				{
+					Name:        "Users",
+					Description: "Users resource",
+					Operations:  []string{"Get"},
+					Fields: []specification.ResourceField{
+						{
+							Field:      specification.Field{Name: "Name", Type: "String", Description: "Name"},
+							Operations: []string{"Read"},
+						},
+					},
+				},
+			},
+		})
+	}
+
+	t.Run("path tenancy prefixes every path", func(t *testing.T) {
+		// Arrange
+		service := createService(&specification.Tenancy{In: specification.TenancyInPath, Name: "TenantID"})
+
+		// Act
+		document, err := newGenerator().generateFromService(service)
+
+		// Assert
+		assert.NoError(t, err, "Should generate document without error")
+		pathItem := document.Paths.PathItems.GetOrZero("/{tenantID}/users/{id}")
+		assert.NotNil(t, pathItem, "Path should be prefixed with the tenant parameter")
+		assert.Equal(t, "tenantID", pathItem.Get.Parameters[0].Name)
+		assert.Equal(t, "path", pathItem.Get.Parameters[0].In)
+		assert.True(t, *pathItem.Get.Parameters[0].Required, "Tenant path parameter should be required")
+	})
+
+	t.Run("header tenancy documents the header", func(t *testing.T) {
+		// Arrange
+		service := createService(&specification.Tenancy{In: specification.TenancyInHeader, Name: "X-Tenant-ID", Description: "Tenant"})
+
+		// Act
+		document, err := newGenerator().generateFromService(service)
+
+		// Assert
+		assert.NoError(t, err, "Should generate document without error")
+		pathItem := document.Paths.PathItems.GetOrZero("/users/{id}")
+		assert.NotNil(t, pathItem, "Path should not be prefixed")
+		assert.Equal(t, "X-Tenant-ID", pathItem.Get.Parameters[0].Name)
+		assert.Equal(t, "header", pathItem.Get.Parameters[0].In)
+		assert.Equal(t, "Tenant", pathItem.Get.Parameters[0].Description)
+	})
+}
+
+func TestGenerator_GenerateFromServiceWithRequestID(t *testing.T) {
+	// Arrange
+	service := specification.ApplyOverlay(&specification.Service{
+		Name:            "Request ID Test API",
+		Version:         "1.0.0",
+		ResponseHeaders: []specification.Field{{Name: "X-Request-ID", Type: "String", Description: "Request ID"}},
+		Resources: []specification.Resource{
+			{
+				Name:        "Users",
+				Description: "Users resource",
+				Operations:  []string{"Get"},
+				Fields: []specification.ResourceField{
+					{
+						Field:      specification.Field{Name: "Name", Type: "String", Description: "Name"},
+						Operations: []string{"Read"},
+					},
+				},
+			},
+		},
+	})
+
+	// Act
+	document, err := newGenerator().generateFromService(service)
+
+	// Assert
+	assert.NoError(t, err, "Should generate document without error")
+	pathItem := document.Paths.PathItems.GetOrZero("/users/{id}")
+	if !assert.NotNil(t, pathItem, "Path should exist") {
+		return
+	}
+	parameters := pathItem.Get.Parameters
+	parameter := parameters[len(parameters)-1]
+	assert.Equal(t, specification.RequestIDHeaderName, parameter.Name)
+	assert.Equal(t, "header", parameter.In)
+	assert.False(t, *parameter.Required, "Request ID header should be optional")
+
+	response := document.Components.Responses.GetOrZero("UsersGet")
+	if !assert.NotNil(t, response, "Success response should exist") {
+		return
+	}
+	assert.Equal(t, 2, response.Headers.Len(), "The declared request ID header should not be documented twice, next to Last-Modified")
+	assert.NotNil(t, response.Headers.GetOrZero(specification.RequestIDHeaderName), "Responses should document the request ID header")
+}
+
+func TestGenerator_GenerateFromServiceWithAlternativeContentTypes(t *testing.T) {
+	// Arrange
+	service := &specification.Service{
+		Name:    "Content Types Test API",
+		Version: "1.0.0",
+		Resources: []specification.Resource{
+			{
+				Name:        "Users",
+				Description: "Users resource",
+				Endpoints: []specification.Endpoint{
+					{
+						Name:   "Export",
+						Method: "GET",
+						Path:   "/export",
+						Response: specification.EndpointResponse{
+							StatusCode:              200,
+							BodyFields:              []specification.Field{{Name: "total", Type: "Int", Description: "Total"}},
+							AlternativeContentTypes: []string{"text/csv"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	// Act
+	document, err := newGenerator().generateFromService(service)
+
+	// Assert
+	assert.NoError(t, err, "Should generate document without error")
+	response := document.Components.Responses.GetOrZero("UsersExport")
+	if !assert.NotNil(t, response, "Export response should exist") {
+		return
+	}
+	assert.Equal(t, 2, response.Content.Len(), "Should document JSON and the alternative content type")
+	assert.Equal(t, "application/json", response.Content.First().Key(), "JSON should stay the first content type")
+	csv := response.Content.GetOrZero("text/csv")
+	assert.Equal(t, []string{"string"}, csv.Schema.Schema().Type, "Alternative content types should be documented as text")
+}
+
+func TestGenerator_GenerateFromServiceWithXML(t *testing.T) {
+	// Arrange
+	order := "Order"
+	service := &specification.Service{
+		Name:    "XML Test API",
+		Version: "1.0.0",
+		Objects: []specification.Object{
+			{Name: order, Description: "Order", Fields: []specification.Field{{Name: "Reference", Type: "String", Description: "Reference"}}},
+		},
+		Resources: []specification.Resource{
+			{
+				Name:        "Orders",
+				Description: "Orders resource",
+				Endpoints: []specification.Endpoint{
+					{
+						Name:   "Submit",
+						Method: "POST",
+						Path:   "/_submit",
+						Request: specification.EndpointRequest{
+							ContentType: specification.ContentTypeXML,
+							BodyParams:  []specification.Field{{Name: "Reference", Type: "String", Description: "Reference"}},
+						},
+						Response: specification.EndpointResponse{
+							ContentType: specification.ContentTypeXML,
+							StatusCode:  200,
+							BodyObject:  &order,
+						},
+					},
+					{
+						Name:   "Summary",
+						Method: "GET",
+						Path:   "/_summary",
+						Response: specification.EndpointResponse{
+							StatusCode:              200,
+							BodyFields:              []specification.Field{{Name: "Total", Type: "Int", Description: "Total"}},
+							AlternativeContentTypes: []string{specification.ContentTypeXML},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	// Act
+	document, err := newGenerator().generateFromService(service)
+
+	// Assert
+	require.NoError(t, err, "Should generate document without error")
+
+	requestBody := document.Components.RequestBodies.GetOrZero("OrdersSubmit")
+	require.NotNil(t, requestBody, "Submit request body should exist")
+	assert.Equal(t, 1, requestBody.Content.Len(), "Should only document the XML request")
+	requestSchema := requestBody.Content.GetOrZero(specification.ContentTypeXML).Schema.Schema()
+	assert.Equal(t, "OrdersSubmitBodyParams", requestSchema.XML.Name, "The request should be named by the body params type")
+
+	response := document.Components.Responses.GetOrZero("OrdersSubmit")
+	require.NotNil(t, response, "Submit response should exist")
+	assert.Equal(t, 1, response.Content.Len(), "Should only document the XML response")
+	assert.Equal(t, order, response.Content.GetOrZero(specification.ContentTypeXML).Schema.Schema().XML.Name, "The response should be named by its object")
+
+	summary := document.Components.Responses.GetOrZero("OrdersSummary")
+	require.NotNil(t, summary, "Summary response should exist")
+	alternative := summary.Content.GetOrZero(specification.ContentTypeXML).Schema.Schema()
+	assert.Equal(t, "OrdersSummaryResponse", alternative.XML.Name, "XML as alternative content type should have the schema of the response")
+	assert.Nil(t, summary.Content.GetOrZero("application/json").Schema.Schema().XML, "The JSON response should not have XML metadata")
+
+	objectSchema := document.Components.Schemas.GetOrZero(order).Schema()
+	assert.Equal(t, order, objectSchema.XML.Name, "Objects should be named by the object in XML")
+}
+
+func TestGenerator_GenerateFromServiceWithFormRequest(t *testing.T) {
+	// Arrange
+	service := &specification.Service{
+		Name:    "Form Test API",
+		Version: "1.0.0",
+		Resources: []specification.Resource{
+			{
+				Name:        "Orders",
+				Description: "Orders resource",
+				Endpoints: []specification.Endpoint{
+					{
+						Name:   "Submit",
+						Method: "POST",
+						Path:   "/_submit",
+						Request: specification.EndpointRequest{
+							ContentType: specification.ContentTypeForm,
+							BodyParams: []specification.Field{
+								{Name: "Reference", Type: "String", Description: "Reference"},
+								{Name: "Tags", Type: "String", Description: "Tags", Modifiers: []string{specification.ModifierArray}},
+							},
+						},
+						Response: specification.EndpointResponse{StatusCode: 204},
+					},
+				},
+			},
+		},
+	}
+
+	// Act
+	document, err := newGenerator().generateFromService(service)
+
+	// Assert
+	require.NoError(t, err, "Should generate document without error")
+
+	requestBody := document.Components.RequestBodies.GetOrZero("OrdersSubmit")
+	require.NotNil(t, requestBody, "Submit request body should exist")
+	assert.Equal(t, 1, requestBody.Content.Len(), "Should only document the form request")
+	mediaType := requestBody.Content.GetOrZero(specification.ContentTypeForm)
+	require.NotNil(t, mediaType, "The request should be documented as a form")
+	schema := mediaType.Schema.Schema()
+	assert.NotNil(t, schema.Properties.GetOrZero("reference"), "The form fields should be the body params")
+	assert.NotNil(t, schema.Properties.GetOrZero("tags"), "The form fields should be the body params")
+	assert.Nil(t, schema.XML, "The form request should not have XML metadata")
+}
+
+func TestGenerator_GenerateFromServiceWithStrictObjects(t *testing.T) {
+	// Arrange
+	lenient := false
+	service := specification.ApplyOverlay(&specification.Service{
+		Name:    "Strict Test API",
+		Version: "1.0.0",
+		Strict:  true,
+		Objects: []specification.Object{
+			{Name: "Person", Fields: []specification.Field{{Name: "Name", Type: "String"}}},
+			{Name: "Employee", Extends: "Person", Fields: []specification.Field{{Name: "Title", Type: "String"}}},
+			{Name: "Metadata", Strict: &lenient, Fields: []specification.Field{{Name: "Key", Type: "String"}}},
+		},
+		Resources: []specification.Resource{
+			{
+				Name:       "Users",
+				Operations: []string{"Create"},
+				Fields: []specification.ResourceField{
+					{Field: specification.Field{Name: "Name", Type: "String"}, Operations: []string{"Create", "Read"}},
+				},
+			},
+		},
+	})
+
+	t.Run("flattened objects", func(t *testing.T) {
+		// Act
+		document, err := newGenerator().generateFromService(service)
+
+		// Assert
+		assert.NoError(t, err, "Should generate document without error")
+		person := document.Components.Schemas.GetOrZero("Person").Schema()
+		if assert.NotNil(t, person.AdditionalProperties, "Strict object should not allow additional properties") {
+			assert.False(t, person.AdditionalProperties.B)
+		}
+		assert.Nil(t, document.Components.Schemas.GetOrZero("Metadata").Schema().AdditionalProperties, "Object should override the service")
+
+		requestBody := document.Components.RequestBodies.GetOrZero("UsersCreate")
+		if assert.NotNil(t, requestBody, "Request body should exist") {
+			schema := requestBody.Content.GetOrZero("application/json").Schema.Schema()
+			if assert.NotNil(t, schema.AdditionalProperties, "Request body of a strict service should not allow additional properties") {
+				assert.False(t, schema.AdditionalProperties.B)
+			}
+		}
+	})
+
+	t.Run("composed objects", func(t *testing.T) {
+		// Arrange
+		generator := newGenerator()
+		generator.ComposeExtendedObjects = true
+
+		// Act
+		document, err := generator.generateFromService(service)
+
+		// Assert
+		assert.NoError(t, err, "Should generate document without error")
+		assert.Nil(t, document.Components.Schemas.GetOrZero("Person").Schema().AdditionalProperties, "Extended object should accept the fields of the extending objects")
+		employee := document.Components.Schemas.GetOrZero("Employee").Schema()
+		if assert.NotNil(t, employee.UnevaluatedProperties, "Composed strict object should not allow unevaluated properties") {
+			assert.False(t, employee.UnevaluatedProperties.B)
+		}
+	})
+}
+
+func TestGenerator_GenerateFromServiceWithConditionalGet(t *testing.T) {
+	// Arrange
+	service := specification.ApplyOverlay(&specification.Service{
+		Name:    "Conditional Test API",
+		Version: "1.0.0",
+		Resources: []specification.Resource{
+			{
+				Name:        "Users",
+				Description: "Users resource",
+				Operations:  []string{"Get", "Update"},
+				Fields: []specification.ResourceField{
+					{
+						Field:      specification.Field{Name: "Name", Type: "String", Description: "Name"},
+						Operations: []string{"Read", "Update"},
+					},
+				},
+			},
+		},
+	})
+
+	// Act
+	document, err := newGenerator().generateFromService(service)
+
+	// Assert
+	assert.NoError(t, err, "Should generate document without error")
+	pathItem := document.Paths.PathItems.GetOrZero("/users/{id}")
+	if !assert.NotNil(t, pathItem, "Path should exist") {
+		return
+	}
+
+	parameterNames := []string{}
+	for _, parameter := range pathItem.Get.Parameters {
+		parameterNames = append(parameterNames, parameter.Name)
+	}
+	assert.Contains(t, parameterNames, specification.IfModifiedSinceHeaderName, "Get should document the If-Modified-Since header")
+	notModified := pathItem.Get.Responses.Codes.GetOrZero("304")
+	if assert.NotNil(t, notModified, "Get should document the 304 Not Modified response") {
+		assert.Nil(t, notModified.Content, "304 Not Modified should have no body")
+		assert.NotNil(t, notModified.Headers.GetOrZero(specification.LastModifiedHeaderName), "304 Not Modified should have the Last-Modified header")
+	}
+	assert.Nil(t, pathItem.Patch.Responses.Codes.GetOrZero("304"), "Update should not be conditional")
+
+	response := document.Components.Responses.GetOrZero("UsersGet")
+	if assert.NotNil(t, response, "Success response should exist") {
+		assert.NotNil(t, response.Headers.GetOrZero(specification.LastModifiedHeaderName), "Get should document the Last-Modified header")
+	}
+	response = document.Components.Responses.GetOrZero("UsersUpdate")
+	if assert.NotNil(t, response, "Update response should exist") {
+		assert.Nil(t, response.Headers.GetOrZero(specification.LastModifiedHeaderName), "Update should not document the Last-Modified header")
+	}
+}
+
+func TestGenerator_GenerateFromServiceWithSoftDelete(t *testing.T) {
+	// Arrange
+	service := specification.ApplyOverlay(&specification.Service{
+		Name:    "Soft Delete Test API",
+		Version: "1.0.0",
+		Resources: []specification.Resource{
+			{
+				Name:        "Users",
+				Description: "Users resource",
+				Operations:  []string{"Get", "List", "Delete"},
+				SoftDelete:  true,
+				Fields: []specification.ResourceField{
+					{
+						Field:      specification.Field{Name: "Name", Type: "String", Description: "Name"},
+						Operations: []string{"Read"},
+					},
+				},
+			},
+		},
+	})
+
+	// Act
+	document, err := newGenerator().generateFromService(service)
+
+	// Assert
+	assert.NoError(t, err, "Should generate document without error")
+	restorePath := document.Paths.PathItems.GetOrZero("/users/{id}/restore")
+	if assert.NotNil(t, restorePath, "Restore path should exist") && assert.NotNil(t, restorePath.Post, "Restore should be a POST operation") {
+		assert.Equal(t, "UsersRestore", restorePath.Post.OperationId)
+		assert.NotNil(t, restorePath.Post.Responses.Codes.GetOrZero("200"), "Restore should respond with the restored resource")
+	}
+
+	listPath := document.Paths.PathItems.GetOrZero("/users")
+	if !assert.NotNil(t, listPath, "List path should exist") {
+		return
+	}
+	parameterNames := []string{}
+	for _, parameter := range listPath.Get.Parameters {
+		parameterNames = append(parameterNames, parameter.Name)
+	}
+	assert.Contains(t, parameterNames, "includeDeleted", "List should document the includeDeleted flag")
+	assert.NotNil(t, document.Components.Schemas.GetOrZero("Users").Schema().Properties.GetOrZero("deletedAt"), "Users should have the deletedAt property")
+}
+
+func TestGenerator_GenerateFromServiceWithExportable(t *testing.T) {
+	// Arrange
+	service := specification.ApplyOverlay(&specification.Service{
+		Name:    "Export Test API",
+		Version: "1.0.0",
+		Resources: []specification.Resource{
+			{
+				Name:            "Users",
+				Description:     "Users resource",
+				Operations:      []string{"List"},
+				Exportable:      true,
+				SkipAutoColumns: true,
+				Fields: []specification.ResourceField{
+					{
+						Field:      specification.Field{Name: "Name", Type: "String", Description: "Name", Example: "Alice"},
+						Operations: []string{"Read"},
+					},
+				},
+			},
+		},
+	})
+
+	// Act
+	document, err := newGenerator().generateFromService(service)
+
+	// Assert
+	assert.NoError(t, err, "Should generate document without error")
+	exportPath := document.Paths.PathItems.GetOrZero("/users/_export")
+	if assert.NotNil(t, exportPath, "Export path should exist") && assert.NotNil(t, exportPath.Get, "Export should be a GET operation") {
+		assert.Equal(t, "UsersExport", exportPath.Get.OperationId)
+	}
+
+	response := document.Components.Responses.GetOrZero("UsersExport")
+	if !assert.NotNil(t, response, "Export response should exist") {
+		return
+	}
+	assert.Nil(t, response.Content.GetOrZero("application/json"), "Export should not respond with JSON")
+	mediaType := response.Content.GetOrZero("text/csv")
+	if assert.NotNil(t, mediaType, "Export should respond with CSV") {
+		assert.Equal(t, []string{"string"}, mediaType.Schema.Schema().Type)
+		assert.Equal(t, "name\nAlice\n", mediaType.Example.Value, "Example should have the header row and a row")
+	}
+}
+
+func TestGenerator_GenerateFromServiceWithSubResource(t *testing.T) {
+	// Arrange
+	service := specification.ApplyOverlay(&specification.Service{
+		Name:    "Sub Resource Test API",
+		Version: "1.0.0",
+		Resources: []specification.Resource{
+			{Name: "Post", Description: "Posts resource", Operations: []string{"Get"}},
+			{Name: "Comment", Description: "Comments resource", Parent: "Post", Operations: []string{"Get"}},
+		},
+	})
+
+	// Act
+	document, err := newGenerator().generateFromService(service)
+
+	// Assert
+	assert.NoError(t, err, "Should generate document without error")
+	commentPath := document.Paths.PathItems.GetOrZero("/post/{postID}/comment/{id}")
+	if assert.NotNil(t, commentPath, "Comment path should be nested under the post") && assert.NotNil(t, commentPath.Get) {
+		assert.Len(t, commentPath.Get.Parameters, 4, "Should have the post ID, ID, If-Modified-Since and request ID parameters")
+		assert.Equal(t, "postID", commentPath.Get.Parameters[0].Name)
+		assert.Equal(t, "path", commentPath.Get.Parameters[0].In)
+	}
+
+	if assert.Len(t, document.Tags, 2) {
+		assert.Equal(t, "Post", document.Tags[0].Name)
+		assert.Empty(t, document.Tags[0].Parent, "Post should be a top-level tag")
+		assert.Equal(t, "Comment", document.Tags[1].Name)
+		assert.Equal(t, "Post", document.Tags[1].Parent, "Comment should be nested under the Post tag")
+	}
+}
 
-	// Verify security requirements in document
-	assert.NotNil(t, document.Security, "Document should have security requirements")
-	assert.Len(t, document.Security, 2, "Should have 2 security requirements")
+func TestGenerator_GenerateFromServiceWithBasePath(t *testing.T) {
+	t.Run("servers", func(t *testing.T) {
+		// Arrange
+		service := &specification.Service{
+			Name:     "Base Path Test API",
+			BasePath: "/api/v1",
+			Servers: []specification.ServiceServer{
+				{URL: "https://api.example.com", Description: "Production"},
+				{URL: "https://staging.example.com/", Description: "Staging"},
+			},
+		}
 
-	// Verify first security requirement (mtls + bearerAuth)
-	firstReq := document.Security[0]
-	assert.NotNil(t, firstReq.Requirements, "First security requirement should have requirements")
+		// Act
+		document, err := newGenerator().generateFromService(service)
 
-	mtlsScopes, mtlsExists := firstReq.Requirements.Get("mtls")
-	bearerScopes, bearerExists := firstReq.Requirements.Get("bearerAuth")
-	assert.True(t, mtlsExists, "First requirement should contain mtls")
-	assert.True(t, bearerExists, "First requirement should contain bearerAuth")
-	assert.Len(t, mtlsScopes, 0, "MTLS should have empty scopes")
-	assert.Len(t, bearerScopes, 0, "Bearer auth should have empty scopes")
+		// Assert
+		assert.NoError(t, err, "Should generate document without error")
+		if assert.Len(t, document.Servers, 2) {
+			assert.Equal(t, "https://api.example.com/api/v1", document.Servers[0].URL, "Server URL should end with the base path")
+			assert.Equal(t, "https://staging.example.com/api/v1", document.Servers[1].URL, "Server URL should end with the base path")
+		}
+	})
 
-	// Verify second security requirement (clientId + clientSecret)
-	secondReq := document.Security[1]
-	assert.NotNil(t, secondReq.Requirements, "Second security requirement should have requirements")
+	t.Run("without servers", func(t *testing.T) {
+		// Arrange
+		service := &specification.Service{Name: "Base Path Test API", BasePath: "/api/v1"}
 
-	clientIdScopes, clientIdExists := secondReq.Requirements.Get("clientId")
-	clientSecretScopes, clientSecretExists := secondReq.Requirements.Get("clientSecret")
-	assert.True(t, clientIdExists, "Second requirement should contain clientId")
-	assert.True(t, clientSecretExists, "Second requirement should contain clientSecret")
-	assert.Len(t, clientIdScopes, 0, "Client ID should have empty scopes")
-	assert.Len(t, clientSecretScopes, 0, "Client secret should have empty scopes")
+		// Act
+		document, err := newGenerator().generateFromService(service)
+
+		// Assert
+		assert.NoError(t, err, "Should generate document without error")
+		if assert.Len(t, document.Servers, 1) {
+			assert.Equal(t, "/api/v1", document.Servers[0].URL, "Base path should be the relative server URL")
+		}
+	})
+
+	t.Run("without base path", func(t *testing.T) {
+		// Arrange
+		service := &specification.Service{
+			Name:    "Base Path Test API",
+			Servers: []specification.ServiceServer{{URL: "https://api.example.com"}},
+		}
+
+		// Act
+		document, err := newGenerator().generateFromService(service)
+
+		// Assert
+		assert.NoError(t, err, "Should generate document without error")
+		if assert.Len(t, document.Servers, 1) {
+			assert.Equal(t, "https://api.example.com", document.Servers[0].URL, "Server URL should be kept as-is")
+		}
+	})
 }
 
-// TestGenerator_GenerateFromServiceSecurityYAML tests that security generates correct YAML output.
-func TestGenerator_GenerateFromServiceSecurityYAML(t *testing.T) {
-	// Create a minimal service with security
-	service := &specification.Service{
-		Name:    "Security YAML Test",
-		Version: "1.0.0",
-		SecuritySchemes: map[string]specification.SecurityScheme{
-			"mtls": {
-				Type:        "mutualTLS",
-				Description: "Client TLS certificate required.",
-			},
-			"bearerAuth": {
-				Type:         "http",
-				Scheme:       "bearer",
-				BearerFormat: "JWT",
-				Description:  "Bearer access token in Authorization header.",
-			},
+func TestGenerator_CreateEnumSchemaWithWireValues(t *testing.T) {
+	// Arrange
+	enum := specification.Enum{
+		Name:        "Status",
+		Description: "Status of the user",
+		Values: []specification.EnumValue{
+			{Name: "Active", Value: "active", Aliases: []string{"enabled"}},
+			{Name: "Inactive"},
 		},
-		Security: []specification.SecurityRequirement{
-			{"mtls", "bearerAuth"},
+	}
+
+	// Act
+	schema := newGenerator().createEnumSchema(enum)
+
+	// Assert
+	if assert.Len(t, schema.Enum, 2) {
+		assert.Equal(t, "active", schema.Enum[0].Value, "Enum should list the wire value")
+		assert.Equal(t, "Inactive", schema.Enum[1].Value, "Enum should list the name without a wire value")
+	}
+	assert.Equal(t, "Status of the user\n\nDeprecated aliases, still accepted: `enabled` for `active`.", schema.Description,
+		"Description should document the deprecated aliases")
+}
+
+func TestGenerator_CreateEnumSchemaWithIntValues(t *testing.T) {
+	// Arrange
+	enum := specification.Enum{
+		Name:     "Grade",
+		EnumType: specification.EnumTypeInt,
+		Values: []specification.EnumValue{
+			{Name: "Pass", Value: "1"},
+			{Name: "Fail", Value: "0"},
 		},
-		Enums:     []specification.Enum{},
-		Objects:   []specification.Object{},
-		Resources: []specification.Resource{},
 	}
 
-	// Generate document
-	generator := newGenerator()
-	document, err := generator.generateFromService(service)
+	// Act
+	schema := newGenerator().createEnumSchema(enum)
 
-	assert.NoError(t, err, "Should generate document without error")
-	assert.NotNil(t, document, "Generated document should not be nil")
+	// Assert
+	assert.Equal(t, []string{"integer"}, schema.Type, "Int enum should be an integer schema")
+	if assert.Len(t, schema.Enum, 2) {
+		assert.Equal(t, "1", schema.Enum[0].Value, "Enum should list the wire value")
+		assert.Equal(t, "!!int", schema.Enum[0].Tag, "Enum values should be integers")
+	}
+	varNames, ok := schema.Extensions.Get("x-enum-varnames")
+	if assert.True(t, ok, "Int enum should name its values") && assert.Len(t, varNames.Content, 2) {
+		assert.Equal(t, "Pass", varNames.Content[0].Value)
+		assert.Equal(t, "Fail", varNames.Content[1].Value)
+	}
+}
 
-	// Render to YAML and verify structure
-	yamlBytes, err := document.Render()
-	assert.NoError(t, err, "Should render document to YAML without error")
+func TestGenerator_GenerateFromServiceWithNaming(t *testing.T) {
+	// Arrange
+	service := specification.ApplyOverlay(&specification.Service{
+		Name:      "Naming Test API",
+		Version:   "1.0.0",
+		Naming:    &specification.Naming{PathCase: specification.PathCaseSnake, PluralPaths: true},
+		Resources: []specification.Resource{{Name: "UserAccount", Operations: []string{"Get", "Search"}}},
+	})
 
-	yamlStr := string(yamlBytes)
+	// Act
+	document, err := newGenerator().generateFromService(service)
 
-	// Verify the YAML contains expected security structures
-	assert.Contains(t, yamlStr, "components:", "YAML should contain components section")
-	assert.Contains(t, yamlStr, "securitySchemes:", "YAML should contain securitySchemes")
-	assert.Contains(t, yamlStr, "security:", "YAML should contain security requirements")
-	assert.Contains(t, yamlStr, "mtls:", "YAML should contain mtls scheme")
-	assert.Contains(t, yamlStr, "bearerAuth:", "YAML should contain bearerAuth scheme")
-	assert.Contains(t, yamlStr, "mutualTLS", "YAML should contain mutualTLS type")
-	assert.Contains(t, yamlStr, "Client TLS certificate required.", "YAML should contain MTLS description")
-	assert.Contains(t, yamlStr, "Bearer access token in Authorization header.", "YAML should contain bearer auth description")
+	// Assert
+	assert.NoError(t, err, "Should generate document without error")
+	assert.NotNil(t, document.Paths.PathItems.GetOrZero("/user_accounts/{id}"), "Get path should follow the naming conventions")
+	assert.NotNil(t, document.Paths.PathItems.GetOrZero("/user_accounts/_search"), "Search path should follow the naming conventions")
+	assert.Nil(t, document.Paths.PathItems.GetOrZero("/user-account/{id}"), "Default path should not exist")
+}
 
-	t.Logf("Generated Security YAML:\n%s", yamlStr)
+func TestGenerator_GenerateFromServiceWithLocalization(t *testing.T) {
+	// Arrange
+	service := specification.ApplyOverlay(&specification.Service{
+		Name:    "Localization Test API",
+		Version: "1.0.0",
+		Localization: &specification.Localization{
+			DefaultLocale: "en",
+			Messages:      map[string]map[string]string{"NotFound": {"en": "Not found", "sv": "Hittades inte"}},
+		},
+		Resources: []specification.Resource{
+			{
+				Name:        "Users",
+				Description: "Users resource",
+				Operations:  []string{"Get"},
+				Fields: []specification.ResourceField{
+					{
+						Field:      specification.Field{Name: "Name", Type: "String", Description: "Name"},
+						Operations: []string{"Read"},
+					},
+				},
+			},
+		},
+	})
+
+	// Act
+	document, err := newGenerator().generateFromService(service)
+
+	// Assert
+	assert.NoError(t, err, "Should generate document without error")
+	pathItem := document.Paths.PathItems.GetOrZero("/users/{id}")
+	if !assert.NotNil(t, pathItem, "Path should exist") {
+		return
+	}
+	parameters := pathItem.Get.Parameters
+	parameter := parameters[len(parameters)-1]
+	assert.Equal(t, "Accept-Language", parameter.Name)
+	assert.Equal(t, "header", parameter.In)
+	assert.Contains(t, parameter.Description, "en, sv")
+	assert.Equal(t, "en", parameter.Schema.Schema().Default.Value)
 }
 
 // TestStringFieldsWithNumericExamples tests that string fields with numeric examples are properly typed as strings.
@@ -4109,6 +6152,51 @@ func TestCircularReferenceHandling(t *testing.T) {
 	t.Logf("Generated JSON for circular reference test (should not hang):\n%s", jsonString)
 }
 
+// TestGenerator_ObjectExamplesMemoized tests that the example of an object is generated once for the objects
+// it is referenced from, and that circular references are still cut depending on the visited objects.
+func TestGenerator_ObjectExamplesMemoized(t *testing.T) {
+	// Arrange
+	generator := newGenerator()
+	service := &specification.Service{
+		Name: "CircularTestAPI",
+		Objects: []specification.Object{
+			{Name: "PersonA", Fields: []specification.Field{
+				{Name: "name", Type: specification.FieldTypeString, Example: "John Doe"},
+				{Name: "friend", Type: "PersonB"},
+			}},
+			{Name: "PersonB", Fields: []specification.Field{
+				{Name: "name", Type: specification.FieldTypeString, Example: "Jane Smith"},
+				{Name: "bestFriend", Type: "PersonA"},
+			}},
+			{Name: "Address", Fields: []specification.Field{
+				{Name: "street", Type: specification.FieldTypeString, Example: "Main Street 1"},
+			}},
+		},
+	}
+	personB, address := *service.GetObject("PersonB"), *service.GetObject("Address")
+
+	// Act
+	topLevel := generator.generateObjectExampleWithVisited(personB, service, map[string]bool{})
+	topLevelAgain := generator.generateObjectExampleWithVisited(personB, service, map[string]bool{})
+	fromPersonA := generator.generateObjectExampleWithVisited(personB, service, map[string]bool{"PersonA": true})
+	fromPersonAAgain := generator.generateObjectExampleWithVisited(personB, service, map[string]bool{"PersonA": true, "Address": true})
+	addressExample := generator.generateObjectExampleWithVisited(address, service, map[string]bool{"PersonA": true})
+
+	// Assert
+	assert.Same(t, topLevel, topLevelAgain, "Examples should be reused for the same visited objects")
+	assert.Same(t, fromPersonA, fromPersonAAgain, "Visited objects that aren't referenced should not affect the example")
+	assert.NotSame(t, topLevel, fromPersonA, "Examples should depend on the visited objects that are referenced")
+	assert.Same(t, addressExample, generator.generateObjectExampleWithVisited(address, service, map[string]bool{}))
+
+	var fromPersonAValue map[string]any
+	assert.NoError(t, fromPersonA.Decode(&fromPersonAValue))
+	assert.Equal(t, map[string]any{"name": "Jane Smith"}, fromPersonAValue, "The reference back to PersonA should be cut")
+
+	var topLevelValue map[string]any
+	assert.NoError(t, topLevel.Decode(&topLevelValue))
+	assert.Equal(t, map[string]any{"name": "John Doe"}, topLevelValue["bestFriend"], "PersonA should be included once")
+}
+
 // TestDeterministicGeneration tests that OpenAPI generation produces identical output across multiple runs
 func TestDeterministicGeneration(t *testing.T) {
 	// Create a service with multiple resources and endpoints to test path ordering
@@ -4470,3 +6558,355 @@ func TestGenerator_OAuth2WithMultipleFlows(t *testing.T) {
 	assert.Equal(t, "/oauth/authorize", oauth2Scheme.Flows.AuthorizationCode.AuthorizationUrl, "Authorization code auth URL should match")
 	assert.Equal(t, "/oauth/token", oauth2Scheme.Flows.AuthorizationCode.TokenUrl, "Authorization code token URL should match")
 }
+
+func TestGenerator_getTypeSchema_NumericTypes(t *testing.T) {
+	generator := newGenerator()
+	service := &specification.Service{Name: "TestService"}
+
+	testCases := []struct {
+		fieldType      string
+		expectedType   string
+		expectedFormat string
+		expectedMin    *float64
+	}{
+		{specification.FieldTypeInt, "integer", "", nil},
+		{specification.FieldTypeInt32, "integer", "int32", nil},
+		{specification.FieldTypeUInt, "integer", "int64", func() *float64 { minimum := float64(0); return &minimum }()},
+		{specification.FieldTypeFloat64, "number", "double", nil},
+		{specification.FieldTypeDecimal, "string", "decimal", nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.fieldType, func(t *testing.T) {
+			// Act
+			schema := generator.getTypeSchema(tc.fieldType, service)
+
+			// Assert
+			assert.Equal(t, []string{tc.expectedType}, schema.Type, "Unexpected schema type for %s", tc.fieldType)
+			assert.Equal(t, tc.expectedFormat, schema.Format, "Unexpected schema format for %s", tc.fieldType)
+			assert.Equal(t, tc.expectedMin, schema.Minimum, "Unexpected minimum for %s", tc.fieldType)
+		})
+	}
+
+	t.Run("decimal examples are strings", func(t *testing.T) {
+		// Act
+		node := generator.createTypedExampleNode(specification.FieldTypeDecimal, "19.99")
+
+		// Assert
+		assert.Equal(t, "!!str", node.Tag, "Decimal examples should be serialized as strings")
+	})
+}
+
+func TestGenerator_GenerateFromServiceWithRequiredOn(t *testing.T) {
+	// Arrange
+	service := specification.ApplyOverlay(&specification.Service{
+		Name:    "Required On Test API",
+		Version: "1.0.0",
+		Resources: []specification.Resource{
+			{
+				Name:        "Users",
+				Description: "Users resource",
+				Operations:  []string{specification.OperationCreate, specification.OperationUpdate},
+				Fields: []specification.ResourceField{
+					{
+						Field:      specification.Field{Name: "Email", Type: "String", Description: "Email"},
+						Operations: []string{specification.OperationCreate, specification.OperationUpdate, specification.OperationRead},
+						RequiredOn: []string{specification.OperationCreate},
+					},
+				},
+			},
+		},
+	})
+	buf := &bytes.Buffer{}
+
+	// Act
+	err := GenerateOpenAPI(buf, service)
+
+	// Assert
+	assert.NoError(t, err, "Should generate document without error")
+
+	var document map[string]any
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &document))
+	requestBodies := document["components"].(map[string]any)["requestBodies"].(map[string]any)
+	requestSchema := func(name string) map[string]any {
+		content := requestBodies[name].(map[string]any)["content"].(map[string]any)
+		return content["application/json"].(map[string]any)["schema"].(map[string]any)
+	}
+
+	assert.Equal(t, []any{"email"}, requestSchema("UsersCreate")["required"], "Email should be required on Create")
+	assert.NotContains(t, requestSchema("UsersUpdate"), "required", "Email should be optional on Update")
+}
+
+func TestGenerator_GenerateFromServiceWithResourceExamples(t *testing.T) {
+	// Arrange
+	service := specification.ApplyOverlay(&specification.Service{
+		Name:    "Resource Examples Test API",
+		Version: "1.0.0",
+		Resources: []specification.Resource{
+			{
+				Name:        "Users",
+				Description: "Users resource",
+				Operations:  []string{specification.OperationCreate, specification.OperationGet},
+				Fields: []specification.ResourceField{
+					{
+						Field:      specification.Field{Name: "Name", Type: "String", Description: "Name"},
+						Operations: []string{specification.OperationCreate, specification.OperationRead},
+					},
+					{
+						Field:      specification.Field{Name: "Age", Type: "Int", Description: "Age"},
+						Operations: []string{specification.OperationCreate, specification.OperationRead},
+					},
+				},
+				Examples: []specification.ResourceExample{
+					{Name: "alice", Summary: "Alice", Values: map[string]any{"name": "Alice", "age": float64(34)}},
+					{Name: "bob", Summary: "Bob", Values: map[string]any{"name": "Bob", "age": float64(28)}},
+				},
+			},
+		},
+	})
+	buf := &bytes.Buffer{}
+
+	// Act
+	err := GenerateOpenAPI(buf, service)
+
+	// Assert
+	assert.NoError(t, err, "Should generate document without error")
+
+	var document map[string]any
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &document))
+	components := document["components"].(map[string]any)
+	mediaExamples := func(kind, name string) map[string]any {
+		content := components[kind].(map[string]any)[name].(map[string]any)["content"].(map[string]any)
+		return content["application/json"].(map[string]any)["examples"].(map[string]any)
+	}
+
+	requestExamples := mediaExamples("requestBodies", "UsersCreate")
+	assert.Len(t, requestExamples, 2, "Request body should have the named examples of the resource")
+	assert.Equal(t, map[string]any{"name": "Alice", "age": float64(34)}, requestExamples["alice"].(map[string]any)["value"])
+	assert.Equal(t, "Bob", requestExamples["bob"].(map[string]any)["summary"])
+
+	responseExamples := mediaExamples("responses", "UsersGet")
+	assert.Contains(t, responseExamples, "alice", "Response body should have the named examples of the resource")
+	assert.NotContains(t, responseExamples, "responseExample", "Named examples should replace the synthesized example")
+}
+
+// TestGenerator_GenerateFromServiceWithSDKNames tests that sdk_group and sdk_method_name override the Speakeasy naming extensions.
+func TestGenerator_GenerateFromServiceWithSDKNames(t *testing.T) {
+	// Arrange
+	service := specification.ApplyOverlay(&specification.Service{
+		Name:    "SDK Names Test API",
+		Version: "1.0.0",
+		Resources: []specification.Resource{
+			{
+				Name:       "Users",
+				Operations: []string{specification.OperationGet, specification.OperationDelete},
+				SDKGroup:   "people",
+				Fields: []specification.ResourceField{
+					{
+						Field:      specification.Field{Name: "Name", Type: "String"},
+						Operations: []string{specification.OperationRead},
+					},
+				},
+				Endpoints: []specification.Endpoint{
+					{
+						Name:          "Delete",
+						Method:        "DELETE",
+						Path:          "/{id}",
+						SDKMethodName: "remove",
+						Request: specification.EndpointRequest{
+							PathParams: []specification.Field{{Name: "id", Type: "UUID"}},
+						},
+						Response: specification.EndpointResponse{StatusCode: 204},
+					},
+				},
+			},
+		},
+	})
+	buf := &bytes.Buffer{}
+
+	// Act
+	err := GenerateOpenAPI(buf, service)
+
+	// Assert
+	assert.NoError(t, err, "Should generate document without error")
+
+	var document map[string]any
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &document))
+	pathItem := document["paths"].(map[string]any)["/users/{id}"].(map[string]any)
+	get := pathItem["get"].(map[string]any)
+	del := pathItem["delete"].(map[string]any)
+
+	assert.Equal(t, "people", get["x-speakeasy-group"], "sdk_group should override the group of all resource operations")
+	assert.Equal(t, "people", del["x-speakeasy-group"])
+	assert.Equal(t, "get", get["x-speakeasy-name-override"], "Endpoints without sdk_method_name should use the endpoint name")
+	assert.Equal(t, "remove", del["x-speakeasy-name-override"], "sdk_method_name should override the method name")
+}
+
+// ============================================================================
+// Performance Tests
+// ============================================================================
+
+// createLargeService creates a synthetic service with six endpoints per resource. Every resource references
+// the first resource, or the previous resource when nested, so that examples of objects are deeply nested.
+// Address and Coordinates reference each other, so that circular reference protection cuts examples.
+func createLargeService(resources int, nested bool) *specification.Service {
+	allOperations := []string{specification.OperationCreate, specification.OperationRead, specification.OperationUpdate}
+
+	service := &specification.Service{
+		Name:    "Large API",
+		Version: "1.0.0",
+		Enums: []specification.Enum{
+			{Name: "Status", Values: []specification.EnumValue{{Name: "Active"}, {Name: "Inactive"}}},
+		},
+		Objects: []specification.Object{
+			{Name: "Coordinates", Fields: []specification.Field{
+				{Name: "Latitude", Type: specification.FieldTypeFloat64, Example: "59.3"},
+				{Name: "Longitude", Type: specification.FieldTypeFloat64, Example: "18.1"},
+				{Name: "Near", Type: "Address", Modifiers: []string{specification.ModifierNullable}},
+			}},
+			{Name: "Address", Fields: []specification.Field{
+				{Name: "Street", Type: specification.FieldTypeString, Example: "Main Street 1 <b>"},
+				{Name: "City", Type: specification.FieldTypeString, Example: "Göteborg"},
+				{Name: "Coordinates", Type: "Coordinates", Modifiers: []string{specification.ModifierNullable}},
+			}},
+		},
+	}
+
+	for i := range resources {
+		fields := []specification.ResourceField{
+			{Field: specification.Field{Name: "Name", Type: specification.FieldTypeString, Example: "Name"}, Operations: allOperations},
+			{Field: specification.Field{Name: "Count", Type: specification.FieldTypeInt, Example: "5"}, Operations: allOperations},
+			{Field: specification.Field{Name: "Status", Type: "Status"}, Operations: allOperations},
+			{Field: specification.Field{Name: "Addresses", Type: "Address", Modifiers: []string{specification.ModifierArray}}, Operations: allOperations},
+		}
+
+		if i > 0 {
+			owner := "Resource0"
+			if nested {
+				owner = fmt.Sprintf("Resource%d", i-1)
+			}
+			fields = append(fields, specification.ResourceField{
+				Field:      specification.Field{Name: "Owner", Type: owner, Modifiers: []string{specification.ModifierNullable}},
+				Operations: []string{specification.OperationRead},
+			})
+		}
+
+		service.Resources = append(service.Resources, specification.Resource{
+			Name: fmt.Sprintf("Resource%d", i),
+			Operations: []string{
+				specification.OperationCreate, specification.OperationGet, specification.OperationList,
+				specification.OperationSearch, specification.OperationUpdate, specification.OperationDelete,
+			},
+			Fields: fields,
+		})
+	}
+
+	return specification.ApplyOverlay(service)
+}
+
+// TestGenerator_RenderMatchesLibOpenAPI tests that the memoized examples and the direct rendering of the document
+// give the same output as rendering the document with libopenapi.
+func TestGenerator_RenderMatchesLibOpenAPI(t *testing.T) {
+	for _, nested := range []bool{false, true} {
+		t.Run(fmt.Sprintf("nested=%v", nested), func(t *testing.T) {
+			// Arrange
+			generator := newGenerator()
+			generator.Title = "Large API"
+			document, err := generator.generateFromService(createLargeService(5, nested))
+			assert.NoError(t, err, "Should generate document without error")
+
+			expectedJSON, err := document.RenderJSON(defaultJSONIndent)
+			assert.NoError(t, err)
+			expectedYAML, err := document.Render()
+			assert.NoError(t, err)
+
+			// Act
+			jsonBytes, jsonErr := generator.toJSON(document)
+			yamlBytes, yamlErr := generator.toYAML(document)
+
+			// Assert
+			assert.NoError(t, jsonErr, "Should render JSON without error")
+			assert.NoError(t, yamlErr, "Should render YAML without error")
+			assert.Equal(t, string(expectedJSON), string(jsonBytes), "JSON should match libopenapi's RenderJSON")
+			assert.Equal(t, string(expectedYAML), string(yamlBytes), "YAML should match libopenapi's Render")
+
+			renderedAgain, err := document.RenderJSON(defaultJSONIndent)
+			assert.NoError(t, err)
+			assert.Equal(t, string(expectedJSON), string(renderedAgain), "Examples of the document should be restored after rendering")
+		})
+	}
+}
+
+// BenchmarkGenerateOpenAPI_LargeService benchmarks the generation of a document with 900 endpoints.
+func BenchmarkGenerateOpenAPI_LargeService(b *testing.B) {
+	service := createLargeService(150, false)
+
+	b.ReportAllocs()
+	for b.Loop() {
+		var buf bytes.Buffer
+		if err := GenerateOpenAPI(&buf, service); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkGenerateOpenAPI_NestedExamples benchmarks the generation of a document with deeply nested examples.
+func BenchmarkGenerateOpenAPI_NestedExamples(b *testing.B) {
+	service := createLargeService(40, true)
+
+	b.ReportAllocs()
+	for b.Loop() {
+		var buf bytes.Buffer
+		if err := GenerateOpenAPI(&buf, service); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// TestGenerateOpenAPIWithOptions_OverlayOnSharedExample tests that overlay actions only modify their target,
+// although the examples of objects are shared in the rendered document.
+func TestGenerateOpenAPIWithOptions_OverlayOnSharedExample(t *testing.T) {
+	// Arrange
+	overlay, err := ParseOverlay([]byte(`overlay: 1.0.0
+info:
+  title: Example patches
+  version: 1.0.0
+actions:
+  - target: $.components.responses.Resource0Get.content['application/json'].examples.responseExample.value
+    update:
+      name: Patched
+`))
+	assert.NoError(t, err)
+	service := specification.ApplyOverlay(&specification.Service{
+		Name: "TestAPI",
+		Resources: []specification.Resource{
+			{
+				Name:       "Resource0",
+				Operations: []string{specification.OperationCreate, specification.OperationGet},
+				Fields: []specification.ResourceField{
+					{
+						Field:      specification.Field{Name: "Name", Type: specification.FieldTypeString, Example: "Name"},
+						Operations: []string{specification.OperationCreate, specification.OperationRead},
+					},
+				},
+			},
+		},
+	})
+	var buf bytes.Buffer
+
+	// Act
+	err = GenerateOpenAPIWithOptions(&buf, service, Options{Overlay: overlay})
+
+	// Assert
+	assert.NoError(t, err, "Should generate document without error")
+
+	var document map[string]any
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &document))
+	exampleName := func(responseName string) any {
+		response := document["components"].(map[string]any)["responses"].(map[string]any)[responseName].(map[string]any)
+		examples := response["content"].(map[string]any)["application/json"].(map[string]any)["examples"].(map[string]any)
+		return examples["responseExample"].(map[string]any)["value"].(map[string]any)["name"]
+	}
+	assert.Equal(t, "Patched", exampleName("Resource0Get"), "Update actions should modify the target example")
+	assert.Equal(t, "Name", exampleName("Resource0Create"), "Update actions should not modify the same example elsewhere")
+}
@@ -1,9 +1,10 @@
 // Package schemagen provides JSON schema generation functionality for specification types.
 //
 // This package follows the same minimalistic API pattern as the servergen package,
-// providing a single exported function that generates JSON schemas for all
-// specification struct types including Service, Enum, Object, Resource, Field,
-// ResourceField, Endpoint, EndpointRequest, and EndpointResponse.
+// providing a single exported function that generates the JSON schema of the
+// specification files, with definitions for all specification struct types including
+// Service, Enum, Object, Resource, Field, ResourceField, Endpoint, EndpointRequest,
+// and EndpointResponse, and a function validating specification files against it.
 //
 // # Schema Generation
 //
@@ -21,22 +22,25 @@
 //	    log.Fatal(err)
 //	}
 //
-// The generated output is a JSON object with schema names as keys and their
-// JSON schema definitions as values:
+// The generated output is a single schema document validating a Service, with a named
+// definition under "$defs" for each specification type and internal "$ref"s between them:
 //
 //	{
-//	  "Service": {
-//	    "$schema": "https://json-schema.org/draft/2020-12/schema",
-//	    "type": "object",
-//	    "properties": {
-//	      "name": { "type": "string" },
-//	      "enums": { "type": "array", "items": { "$ref": "#/$defs/Enum" } },
-//	      ...
+//	  "$schema": "https://json-schema.org/draft/2020-12/schema",
+//	  "$ref": "#/$defs/Service",
+//	  "$defs": {
+//	    "Service": {
+//	      "type": "object",
+//	      "properties": {
+//	        "name": { "type": "string" },
+//	        "enums": { "type": "array", "items": { "$ref": "#/$defs/Enum" } },
+//	        ...
+//	      },
+//	      "required": ["name"]
 //	    },
-//	    "required": ["name"]
-//	  },
-//	  "Enum": { ... },
-//	  ...
+//	    "Enum": { ... },
+//	    ...
+//	  }
 //	}
 //
 // # Usage Pattern
@@ -78,6 +82,34 @@
 //
 // All schemas are generated with the following configuration:
 //   - Additional properties are not allowed (strict validation)
-//   - References are expanded inline for clarity
+//   - Nested types reference their definitions instead of being inlined
+//   - Lists and nested objects are optional, since the specification files can omit them
 //   - Proper JSON Schema draft 2020-12 compatibility
+//
+// # Schema Files for Editors
+//
+// GenerateSchemaFiles generates a self-contained schema file per specification type, each with
+// its $id under a base URL, for editors with yaml-language-server. The schemas list the valid
+// operations, modifiers and enum types, and suggest HTTP methods and the primitive field types,
+// for autocomplete:
+//
+//	files, err := schemagen.GenerateSchemaFiles("https://example.com/schemas/")
+//	// files["service.schema.json"] is referenced from the specification files with:
+//	// # yaml-language-server: $schema=https://example.com/schemas/service.schema.json
+//
+// # Validating Specification Files
+//
+// ValidateServiceFile validates a YAML or JSON specification file against the schema and
+// reports all violations, not just the first, each with the JSON Pointer of the violating value:
+//
+//	err := schemagen.ValidateServiceFile("api.yaml")
+//	var validationErr *schemagen.ValidationError
+//	if errors.As(err, &validationErr) {
+//	    for _, violation := range validationErr.Violations {
+//	        fmt.Println(violation.Path, violation.Message) // /resources/0/operations expected array, got string
+//	    }
+//	}
+//
+// The rules that the schema cannot express, e.g. references to undefined types, are
+// checked when the file is parsed by the specification package.
 package schemagen
@@ -4,6 +4,11 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
 
 	"github.com/invopop/jsonschema"
 
@@ -17,163 +22,238 @@ const (
 	errorFailedToConvert  = "failed to convert schema to JSON"
 )
 
-// GenerateSchemas generates JSON schemas for all specification types and writes them to the buffer.
-// The output is a JSON object with schema names as keys and their JSON schema definitions as values.
+// Patterns that the default value of a field must match for each primitive field type
+var defaultValuePatterns = []struct {
+	fieldTypes []string
+	pattern    string
+}{
+	{[]string{specification.FieldTypeInt, specification.FieldTypeInt32}, `^-?[0-9]+$`},
+	{[]string{specification.FieldTypeUInt}, `^[0-9]+$`},
+	{[]string{specification.FieldTypeFloat64, specification.FieldTypeDecimal}, `^-?[0-9]+(\.[0-9]+)?$`},
+	{[]string{specification.FieldTypeBool}, `^(true|false)$`},
+	{[]string{specification.FieldTypeUUID}, `^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`},
+	{[]string{specification.FieldTypeDate}, `^[0-9]{4}-[0-9]{2}-[0-9]{2}$`},
+	{[]string{specification.FieldTypeTimestamp}, `^[0-9]{4}-[0-9]{2}-[0-9]{2}T[0-9]{2}:[0-9]{2}:[0-9]{2}(\.[0-9]+)?(Z|[+-][0-9]{2}:[0-9]{2})$`},
+}
+
+// ServiceSchemaFile is the name of the schema file of the Service, the schema of the specification files,
+// referenced from the files with a header like:
+//
+//	# yaml-language-server: $schema=https://example.com/schemas/service.schema.json
+const ServiceSchemaFile = "service.schema.json"
+
+// schemaFileExtension is the extension of the files generated by GenerateSchemaFiles
+const schemaFileExtension = ".schema.json"
+
+// schemaFileTypes are the specification types with a schema file
+var schemaFileTypes = []string{
+	"Service", "Enum", "Object", "Resource", "Field", "ResourceField", "Endpoint", "EndpointRequest", "EndpointResponse",
+}
+
+// primitiveFieldTypes are the field types that are not enums or objects of the service
+var primitiveFieldTypes = []string{
+	specification.FieldTypeUUID, specification.FieldTypeDate, specification.FieldTypeTimestamp, specification.FieldTypeString,
+	specification.FieldTypeInt, specification.FieldTypeInt32, specification.FieldTypeUInt, specification.FieldTypeFloat64,
+	specification.FieldTypeDecimal, specification.FieldTypeBool,
+}
+
+// Completions of the properties of the definitions, exhaustive when the property accepts no other values
+var completions = []struct {
+	definition string
+	property   string
+	values     []string
+	exhaustive bool
+}{
+	{"Resource", "operations", []string{specification.OperationCreate, specification.OperationGet, specification.OperationList, specification.OperationSearch, specification.OperationUpdate, specification.OperationDelete}, true},
+	{"ResourceField", "operations", []string{specification.OperationCreate, specification.OperationRead, specification.OperationUpdate, specification.OperationDelete}, true},
+	{"ResourceField", "required_on", []string{specification.OperationCreate, specification.OperationUpdate}, true},
+	{"ResourceField", "modifiers", []string{specification.ModifierNullable, specification.ModifierArray}, true},
+	{"Field", "modifiers", []string{specification.ModifierNullable, specification.ModifierArray}, true},
+	{"Enum", "enum_type", []string{specification.EnumTypeString, specification.EnumTypeInt}, true},
+	{"ResourceField", "type", primitiveFieldTypes, false},
+	{"Field", "type", primitiveFieldTypes, false},
+	{"Endpoint", "method", []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete}, false},
+}
+
+// GenerateSchemas generates the JSON schema of the specification files and writes it to the buffer.
+// The output is a single schema document validating a Service, with a named definition under "$defs"
+// for each specification type (Service, Resource, Field, etc.) and internal "$ref"s between them.
 func GenerateSchemas(buf *bytes.Buffer) error {
+	schema, err := schemaDocument()
+	if err != nil {
+		return err
+	}
+
+	schemaJSON, err := schemaToJSON(schema)
+	if err != nil {
+		return fmt.Errorf("%s Service: %w", errorFailedToConvert, err)
+	}
+
+	buf.WriteString(schemaJSON)
+
+	return nil
+}
+
+// schemaDocument returns the schema document of the Service, reflected once per process since it only depends on
+// the specification types, so that generating and validating many specifications does not repeat the reflection.
+// The document is shared by the callers, which must not modify it.
+var schemaDocument = sync.OnceValues(generateSchemaDocument)
+
+// generateSchemaDocument reflects the schema document of the Service, referencing the definitions of the
+// nested specification types instead of inlining them.
+func generateSchemaDocument() (*jsonschema.Schema, error) {
 	reflector := &jsonschema.Reflector{
 		AllowAdditionalProperties: false,
 		DoNotReference:            false,
-		ExpandedStruct:            true,
 	}
 
-	// Generate all schemas
-	schemas := make(map[string]interface{})
+	schema := reflector.Reflect(&specification.Service{})
+	if schema == nil {
+		return nil, fmt.Errorf("%s Service", errorFailedToGenerate)
+	}
 
-	// Service schema
-	if schema := reflector.Reflect(&specification.Service{}); schema != nil {
-		schemaJSON, err := schemaToJSON(schema)
-		if err != nil {
-			return fmt.Errorf("%s Service: %w", errorFailedToConvert, err)
-		}
-		var schemaData interface{}
-		if err := json.Unmarshal([]byte(schemaJSON), &schemaData); err != nil {
-			return fmt.Errorf("%s Service: %w", errorFailedToMarshal, err)
-		}
-		schemas["Service"] = schemaData
-	} else {
-		return fmt.Errorf("%s Service", errorFailedToGenerate)
+	addDefaultValueRules(schema)
+	removeRequiredCollections(schema)
+	addCompletions(schema)
+
+	return schema, nil
+}
+
+// GenerateSchemaFiles generates a JSON schema file per specification type, by file name, e.g. "service.schema.json"
+// and "resource-field.schema.json". Each file has all the definitions of the schema document, and the $id of its file
+// name under the base URL, so that editors can load it from a yaml-language-server header, see ServiceSchemaFile.
+func GenerateSchemaFiles(baseURL string) (map[string][]byte, error) {
+	document, err := schemaDocument()
+	if err != nil {
+		return nil, err
 	}
 
-	// Enum schema
-	if schema := reflector.Reflect(&specification.Enum{}); schema != nil {
-		schemaJSON, err := schemaToJSON(schema)
-		if err != nil {
-			return fmt.Errorf("%s Enum: %w", errorFailedToConvert, err)
-		}
-		var schemaData interface{}
-		if err := json.Unmarshal([]byte(schemaJSON), &schemaData); err != nil {
-			return fmt.Errorf("%s Enum: %w", errorFailedToMarshal, err)
-		}
-		schemas["Enum"] = schemaData
-	} else {
-		return fmt.Errorf("%s Enum", errorFailedToGenerate)
+	if baseURL != "" && !strings.HasSuffix(baseURL, "/") {
+		baseURL += "/"
 	}
 
-	// Object schema
-	if schema := reflector.Reflect(&specification.Object{}); schema != nil {
-		schemaJSON, err := schemaToJSON(schema)
-		if err != nil {
-			return fmt.Errorf("%s Object: %w", errorFailedToConvert, err)
-		}
-		var schemaData interface{}
-		if err := json.Unmarshal([]byte(schemaJSON), &schemaData); err != nil {
-			return fmt.Errorf("%s Object: %w", errorFailedToMarshal, err)
+	files := make(map[string][]byte, len(schemaFileTypes))
+	for _, typeName := range schemaFileTypes {
+		fileName := schemaFileName(typeName)
+
+		schema := &jsonschema.Schema{
+			Version:     jsonschema.Version,
+			ID:          jsonschema.ID(baseURL + fileName),
+			Ref:         definitionsRefPrefix + typeName,
+			Title:       typeName,
+			Definitions: document.Definitions,
 		}
-		schemas["Object"] = schemaData
-	} else {
-		return fmt.Errorf("%s Object", errorFailedToGenerate)
-	}
 
-	// Resource schema
-	if schema := reflector.Reflect(&specification.Resource{}); schema != nil {
 		schemaJSON, err := schemaToJSON(schema)
 		if err != nil {
-			return fmt.Errorf("%s Resource: %w", errorFailedToConvert, err)
-		}
-		var schemaData interface{}
-		if err := json.Unmarshal([]byte(schemaJSON), &schemaData); err != nil {
-			return fmt.Errorf("%s Resource: %w", errorFailedToMarshal, err)
+			return nil, fmt.Errorf("%s %s: %w", errorFailedToConvert, typeName, err)
 		}
-		schemas["Resource"] = schemaData
-	} else {
-		return fmt.Errorf("%s Resource", errorFailedToGenerate)
+
+		files[fileName] = []byte(schemaJSON + "\n")
 	}
 
-	// Field schema
-	if schema := reflector.Reflect(&specification.Field{}); schema != nil {
-		schemaJSON, err := schemaToJSON(schema)
-		if err != nil {
-			return fmt.Errorf("%s Field: %w", errorFailedToConvert, err)
-		}
-		var schemaData interface{}
-		if err := json.Unmarshal([]byte(schemaJSON), &schemaData); err != nil {
-			return fmt.Errorf("%s Field: %w", errorFailedToMarshal, err)
+	return files, nil
+}
+
+// schemaFileName returns the file name of the schema of a specification type, e.g. "resource-field.schema.json"
+// for ResourceField.
+func schemaFileName(typeName string) string {
+	var name strings.Builder
+	for i, r := range typeName {
+		if i > 0 && unicode.IsUpper(r) {
+			name.WriteByte('-')
 		}
-		schemas["Field"] = schemaData
-	} else {
-		return fmt.Errorf("%s Field", errorFailedToGenerate)
+		name.WriteRune(unicode.ToLower(r))
 	}
+	return name.String() + schemaFileExtension
+}
 
-	// ResourceField schema
-	if schema := reflector.Reflect(&specification.ResourceField{}); schema != nil {
-		schemaJSON, err := schemaToJSON(schema)
-		if err != nil {
-			return fmt.Errorf("%s ResourceField: %w", errorFailedToConvert, err)
-		}
-		var schemaData interface{}
-		if err := json.Unmarshal([]byte(schemaJSON), &schemaData); err != nil {
-			return fmt.Errorf("%s ResourceField: %w", errorFailedToMarshal, err)
+// addCompletions adds the values that the specification files accept for operations, modifiers, methods and
+// types to the definitions, as enums where no other value is valid and as examples otherwise,
+// so that editors can complete them.
+func addCompletions(schema *jsonschema.Schema) {
+	for _, completion := range completions {
+		definition, ok := schema.Definitions[completion.definition]
+		if !ok || definition.Properties == nil {
+			continue
 		}
-		schemas["ResourceField"] = schemaData
-	} else {
-		return fmt.Errorf("%s ResourceField", errorFailedToGenerate)
-	}
 
-	// Endpoint schema
-	if schema := reflector.Reflect(&specification.Endpoint{}); schema != nil {
-		schemaJSON, err := schemaToJSON(schema)
-		if err != nil {
-			return fmt.Errorf("%s Endpoint: %w", errorFailedToConvert, err)
+		property, ok := definition.Properties.Get(completion.property)
+		if !ok {
+			continue
 		}
-		var schemaData interface{}
-		if err := json.Unmarshal([]byte(schemaJSON), &schemaData); err != nil {
-			return fmt.Errorf("%s Endpoint: %w", errorFailedToMarshal, err)
+
+		// The values of lists are completed on their items
+		if property.Items != nil {
+			property = property.Items
 		}
-		schemas["Endpoint"] = schemaData
-	} else {
-		return fmt.Errorf("%s Endpoint", errorFailedToGenerate)
-	}
 
-	// EndpointRequest schema
-	if schema := reflector.Reflect(&specification.EndpointRequest{}); schema != nil {
-		schemaJSON, err := schemaToJSON(schema)
-		if err != nil {
-			return fmt.Errorf("%s EndpointRequest: %w", errorFailedToConvert, err)
+		values := make([]any, len(completion.values))
+		for i, value := range completion.values {
+			values[i] = value
 		}
-		var schemaData interface{}
-		if err := json.Unmarshal([]byte(schemaJSON), &schemaData); err != nil {
-			return fmt.Errorf("%s EndpointRequest: %w", errorFailedToMarshal, err)
+
+		if completion.exhaustive {
+			property.Enum = values
+		} else {
+			property.Examples = values
 		}
-		schemas["EndpointRequest"] = schemaData
-	} else {
-		return fmt.Errorf("%s EndpointRequest", errorFailedToGenerate)
 	}
+}
 
-	// EndpointResponse schema
-	if schema := reflector.Reflect(&specification.EndpointResponse{}); schema != nil {
-		schemaJSON, err := schemaToJSON(schema)
-		if err != nil {
-			return fmt.Errorf("%s EndpointResponse: %w", errorFailedToConvert, err)
+// removeRequiredCollections makes the properties holding lists or nested objects optional in every definition,
+// since the specification files can omit them, e.g. the enums of a service or the request of an endpoint,
+// which are then empty.
+func removeRequiredCollections(schema *jsonschema.Schema) {
+	for _, definition := range schema.Definitions {
+		if definition.Properties == nil {
+			continue
 		}
-		var schemaData interface{}
-		if err := json.Unmarshal([]byte(schemaJSON), &schemaData); err != nil {
-			return fmt.Errorf("%s EndpointResponse: %w", errorFailedToMarshal, err)
-		}
-		schemas["EndpointResponse"] = schemaData
-	} else {
-		return fmt.Errorf("%s EndpointResponse", errorFailedToGenerate)
+
+		definition.Required = slices.DeleteFunc(definition.Required, func(name string) bool {
+			property, ok := definition.Properties.Get(name)
+			return ok && (property.Type == "array" || property.Type == "object" || property.Ref != "")
+		})
 	}
+}
 
-	// Marshal the combined schema map to JSON with proper indentation
-	outputData, err := json.MarshalIndent(schemas, "", "  ")
-	if err != nil {
-		return fmt.Errorf("failed to marshal combined schemas to JSON: %w", err)
+// addDefaultValueRules validates the default value of fields against their type, by adding
+// an if/then rule per primitive type to every schema (or definition) that has both a type and a default property.
+func addDefaultValueRules(schema *jsonschema.Schema) {
+	schemas := []*jsonschema.Schema{schema}
+	for _, definition := range schema.Definitions {
+		schemas = append(schemas, definition)
 	}
 
-	// Write to buffer
-	buf.Write(outputData)
+	for _, s := range schemas {
+		if s.Properties == nil {
+			continue
+		}
+		if _, ok := s.Properties.Get("type"); !ok {
+			continue
+		}
+		if _, ok := s.Properties.Get("default"); !ok {
+			continue
+		}
 
-	return nil
+		for _, rule := range defaultValuePatterns {
+			typeValues := make([]any, len(rule.fieldTypes))
+			for i, fieldType := range rule.fieldTypes {
+				typeValues[i] = fieldType
+			}
+
+			ifProperties := jsonschema.NewProperties()
+			ifProperties.Set("type", &jsonschema.Schema{Enum: typeValues})
+
+			thenProperties := jsonschema.NewProperties()
+			thenProperties.Set("default", &jsonschema.Schema{Pattern: rule.pattern})
+
+			s.AllOf = append(s.AllOf, &jsonschema.Schema{
+				If:   &jsonschema.Schema{Properties: ifProperties, Required: []string{"type"}},
+				Then: &jsonschema.Schema{Properties: thenProperties},
+			})
+		}
+	}
 }
 
 // schemaToJSON converts a JSON schema to a JSON string.
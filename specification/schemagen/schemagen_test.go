@@ -22,10 +22,16 @@ func TestGenerateSchemas(t *testing.T) {
 	assert.NotEmpty(t, buf.String(), "Buffer should contain generated schemas")
 
 	// Parse the generated JSON
-	var schemas map[string]interface{}
-	err = json.Unmarshal(buf.Bytes(), &schemas)
+	var document map[string]interface{}
+	err = json.Unmarshal(buf.Bytes(), &document)
 	require.NoError(t, err, "Generated output should be valid JSON")
 
+	// The document validates a Service, with the specification types as definitions
+	assert.Contains(t, document, "$schema", "Document should have $schema property")
+	assert.Equal(t, "#/$defs/Service", document["$ref"], "Document should reference the Service definition")
+	schemas, ok := document["$defs"].(map[string]interface{})
+	require.True(t, ok, "Document should have definitions")
+
 	// Check that all expected schemas are present
 	expectedSchemas := []string{
 		"Service", "Enum", "Object", "Resource",
@@ -33,7 +39,21 @@ func TestGenerateSchemas(t *testing.T) {
 		"EndpointRequest", "EndpointResponse",
 	}
 
-	assert.Equal(t, len(expectedSchemas), len(schemas), "Should generate exactly %d schemas", len(expectedSchemas))
+	// The types the expected schemas reference are definitions of the document too
+	referencedSchemas := []string{
+		"Aggregations", "Callback", "EnumValue", "Expansion", "ExternalDocs",
+		"Localization", "Naming", "OAuth2Flow", "OAuth2Flows", "OperationalEndpoints",
+		"ParameterGroup", "ResourceExample", "RetryBackoffConfiguration", "RetryConfiguration",
+		"SecurityRequirement", "SecurityScheme", "ServerVariable", "ServiceContact",
+		"ServiceLicense", "ServiceServer", "Tenancy", "TimeoutConfiguration",
+	}
+
+	definitionNames := make([]string, 0, len(schemas))
+	for name := range schemas {
+		definitionNames = append(definitionNames, name)
+	}
+	assert.Equal(t, len(expectedSchemas)+len(referencedSchemas), len(schemas), "Should generate exactly %d schemas", len(expectedSchemas)+len(referencedSchemas))
+	assert.ElementsMatch(t, append(expectedSchemas, referencedSchemas...), definitionNames, "Should generate exactly the expected definitions")
 
 	for _, expectedSchemaName := range expectedSchemas {
 		schema, exists := schemas[expectedSchemaName]
@@ -103,15 +123,27 @@ func TestGenerateSchemas(t *testing.T) {
 // Schema Structure Tests
 // ============================================================================
 
-func TestGenerateSchemasStructure(t *testing.T) {
+// generateDefinitions returns the definitions of the generated schema document by name.
+func generateDefinitions(t *testing.T) map[string]interface{} {
+	t.Helper()
+
 	var buf bytes.Buffer
 	err := GenerateSchemas(&buf)
 	require.NoError(t, err)
 
-	var schemas map[string]interface{}
-	err = json.Unmarshal(buf.Bytes(), &schemas)
+	var document map[string]interface{}
+	err = json.Unmarshal(buf.Bytes(), &document)
 	require.NoError(t, err)
 
+	definitions, ok := document["$defs"].(map[string]interface{})
+	require.True(t, ok, "Document should have definitions")
+
+	return definitions
+}
+
+func TestGenerateSchemasStructure(t *testing.T) {
+	schemas := generateDefinitions(t)
+
 	// Test Service schema structure
 	t.Run("Service schema has correct properties", func(t *testing.T) {
 		serviceSchema := schemas["Service"].(map[string]interface{})
@@ -250,20 +282,14 @@ func TestGenerateSchemasFormattedJSON(t *testing.T) {
 // ============================================================================
 
 func TestGenerateSchemasValidSchema(t *testing.T) {
-	var buf bytes.Buffer
-	err := GenerateSchemas(&buf)
-	require.NoError(t, err)
-
-	var schemas map[string]interface{}
-	err = json.Unmarshal(buf.Bytes(), &schemas)
-	require.NoError(t, err)
+	schemas := generateDefinitions(t)
 
-	// Each schema should have basic JSON Schema properties
+	// Each schema of a struct should have basic JSON Schema properties
 	for name, schema := range schemas {
 		schemaMap := schema.(map[string]interface{})
-
-		// Should have $schema property
-		assert.Contains(t, schemaMap, "$schema", "Schema '%s' should have $schema property", name)
+		if schemaMap["type"] != "object" {
+			continue
+		}
 
 		// Should have type property
 		assert.Contains(t, schemaMap, "type", "Schema '%s' should have type property", name)
@@ -304,3 +330,118 @@ func TestSchemaToJSON(t *testing.T) {
 	err = json.Unmarshal(buf.Bytes(), &schemas)
 	require.NoError(t, err, "schemaToJSON must be producing valid JSON")
 }
+
+func TestAddDefaultValueRules(t *testing.T) {
+	// Arrange
+	schemas := generateDefinitions(t)
+
+	for _, schemaName := range []string{"Field", "ResourceField"} {
+		t.Run(schemaName, func(t *testing.T) {
+			// Act
+			rules, ok := schemas[schemaName].(map[string]interface{})["allOf"].([]interface{})
+
+			// Assert
+			require.True(t, ok, "Schema '%s' should have default value rules", schemaName)
+			assert.Len(t, rules, len(defaultValuePatterns), "Should have one rule per pattern")
+
+			rule := rules[0].(map[string]interface{})
+			assert.Contains(t, rule, "if", "Rule should have an if condition on the field type")
+			assert.Contains(t, rule, "then", "Rule should constrain the default value")
+		})
+	}
+
+	t.Run("schemas without a default are left untouched", func(t *testing.T) {
+		// Assert
+		assert.NotContains(t, schemas["Enum"], "allOf", "Enum schema has no default property")
+	})
+}
+
+func TestGenerateSchemasEnumValueAliases(t *testing.T) {
+	// Arrange
+	definitions := generateDefinitions(t)
+
+	// Act
+	properties := definitions["EnumValue"].(map[string]interface{})["properties"].(map[string]interface{})
+
+	// Assert
+	assert.Contains(t, properties, "value", "EnumValue should have the wire value")
+	aliases, ok := properties["aliases"].(map[string]interface{})
+	require.True(t, ok, "EnumValue should have the aliases")
+	assert.Equal(t, true, aliases["uniqueItems"], "Aliases of a value should be unique")
+}
+
+func TestGenerateSchemasReferences(t *testing.T) {
+	// Arrange
+	schemas := generateDefinitions(t)
+
+	// Act
+	properties := schemas["Resource"].(map[string]interface{})["properties"].(map[string]interface{})
+	fields := properties["fields"].(map[string]interface{})
+
+	// Assert
+	assert.Equal(t, "#/$defs/ResourceField", fields["items"].(map[string]interface{})["$ref"], "Resource fields should reference the ResourceField definition")
+}
+
+func TestRemoveRequiredCollections(t *testing.T) {
+	// Arrange
+	schemas := generateDefinitions(t)
+
+	// Act
+	serviceRequired := schemas["Service"].(map[string]interface{})["required"]
+	endpointRequired := schemas["Endpoint"].(map[string]interface{})["required"]
+
+	// Assert
+	assert.Equal(t, []interface{}{"name"}, serviceRequired, "Lists of the service should be optional")
+	assert.NotContains(t, endpointRequired, "request", "Nested objects should be optional")
+	assert.Contains(t, endpointRequired, "method", "Scalars should stay required")
+}
+
+func TestGenerateSchemasCompletions(t *testing.T) {
+	// Arrange
+	schemas := generateDefinitions(t)
+	property := func(definition, name string) map[string]interface{} {
+		return schemas[definition].(map[string]interface{})["properties"].(map[string]interface{})[name].(map[string]interface{})
+	}
+
+	// Act
+	resourceOperations := property("Resource", "operations")["items"].(map[string]interface{})
+	modifiers := property("Field", "modifiers")["items"].(map[string]interface{})
+	fieldType := property("ResourceField", "type")
+
+	// Assert
+	assert.Equal(t, []interface{}{"Create", "Get", "List", "Search", "Update", "Delete"}, resourceOperations["enum"], "Resource operations should be completed")
+	assert.Equal(t, []interface{}{"Nullable", "Array"}, modifiers["enum"], "Modifiers should be completed")
+	assert.Contains(t, fieldType["examples"], "UUID", "Field types should be suggested")
+	assert.NotContains(t, fieldType, "enum", "Field types can also be enums and objects of the service")
+}
+
+func TestGenerateSchemaFiles(t *testing.T) {
+	t.Run("generates a self-contained file per type", func(t *testing.T) {
+		// Act
+		files, err := GenerateSchemaFiles("https://example.com/schemas")
+
+		// Assert
+		require.NoError(t, err)
+		assert.Len(t, files, len(schemaFileTypes))
+
+		var schema map[string]interface{}
+		require.NoError(t, json.Unmarshal(files["resource-field.schema.json"], &schema))
+		assert.Equal(t, "https://example.com/schemas/resource-field.schema.json", schema["$id"])
+		assert.Equal(t, "#/$defs/ResourceField", schema["$ref"])
+		assert.Contains(t, schema["$defs"], "Field", "Files should have all the definitions")
+
+		require.NoError(t, json.Unmarshal(files[ServiceSchemaFile], &schema))
+		assert.Equal(t, "#/$defs/Service", schema["$ref"])
+	})
+
+	t.Run("relative ids without a base URL", func(t *testing.T) {
+		// Act
+		files, err := GenerateSchemaFiles("")
+
+		// Assert
+		require.NoError(t, err)
+		var schema map[string]interface{}
+		require.NoError(t, json.Unmarshal(files["endpoint-request.schema.json"], &schema))
+		assert.Equal(t, "endpoint-request.schema.json", schema["$id"])
+	})
+}
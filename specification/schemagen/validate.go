@@ -0,0 +1,380 @@
+package schemagen
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	yaml "github.com/goccy/go-yaml"
+)
+
+// Error messages of the validation
+const (
+	errorFileRead          = "failed to read file"
+	errorFileParse         = "failed to parse file"
+	errorUnsupportedFormat = "unsupported file format"
+	errorInvalidReference  = "invalid schema reference"
+	errorInvalidPattern    = "invalid schema pattern"
+)
+
+// definitionsRefPrefix prefixes the "$ref"s to the definitions of the schema document
+const definitionsRefPrefix = "#/$defs/"
+
+// Violation is a part of a specification file that does not match the schema.
+type Violation struct {
+	// Path is the JSON Pointer of the violating value, e.g. "/resources/0/fields/1/field/type",
+	// or "" for the root of the file
+	Path string
+
+	// Message describes the violation
+	Message string
+}
+
+// String returns the violation as "path: message", with "/" as the path of the root.
+func (v Violation) String() string {
+	path := v.Path
+	if path == "" {
+		path = "/"
+	}
+	return path + ": " + v.Message
+}
+
+// ValidationError is returned by ValidateServiceFile with all the violations of the file.
+type ValidationError struct {
+	Violations []Violation
+}
+
+func (e *ValidationError) Error() string {
+	lines := make([]string, len(e.Violations))
+	for i, violation := range e.Violations {
+		lines[i] = violation.String()
+	}
+	return fmt.Sprintf("%d schema violation(s):\n%s", len(e.Violations), strings.Join(lines, "\n"))
+}
+
+// ValidateServiceFile validates a YAML or JSON specification file against the schema generated by
+// GenerateSchemas. Unlike the parsing functions of the specification package, which stop at the first error,
+// it reports all violations of the schema, as a *ValidationError. The rules that the schema cannot express,
+// e.g. references to undefined types, are checked when the file is parsed.
+func ValidateServiceFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("%s: %w", errorFileRead, err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		data, err = yaml.YAMLToJSON(data)
+		if err != nil {
+			return fmt.Errorf("%s: YAML parsing error: %w", errorFileParse, err)
+		}
+	case ".json":
+	default:
+		return fmt.Errorf("%s: file must have .yaml, .yml, or .json extension", errorUnsupportedFormat)
+	}
+
+	var document any
+	if err := json.Unmarshal(data, &document); err != nil {
+		return fmt.Errorf("%s: JSON parsing error: %w", errorFileParse, err)
+	}
+
+	violations, err := validateDocument(document)
+	if err != nil {
+		return err
+	}
+
+	if len(violations) > 0 {
+		return &ValidationError{Violations: violations}
+	}
+
+	return nil
+}
+
+// validateDocument returns the violations of the schema document by the decoded JSON document.
+func validateDocument(document any) ([]Violation, error) {
+	root, err := schemaRoot()
+	if err != nil {
+		return nil, err
+	}
+
+	definitions, _ := root["$defs"].(map[string]any)
+	v := &validator{definitions: definitions, patterns: make(map[string]*regexp.Regexp)}
+	if err := v.validate(root, document, ""); err != nil {
+		return nil, err
+	}
+
+	return v.violations, nil
+}
+
+// schemaRoot returns the schema document decoded from JSON, as the validator reads it. It is decoded once per process
+// and shared by the validations, which only read it.
+var schemaRoot = sync.OnceValues(func() (map[string]any, error) {
+	schema, err := schemaDocument()
+	if err != nil {
+		return nil, err
+	}
+
+	schemaJSON, err := json.Marshal(schema)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errorFailedToMarshal, err)
+	}
+
+	var root map[string]any
+	if err := json.Unmarshal(schemaJSON, &root); err != nil {
+		return nil, fmt.Errorf("%s: %w", errorFailedToMarshal, err)
+	}
+
+	return root, nil
+})
+
+// validator validates decoded JSON values against the keywords of the generated schema document:
+// "$ref", "type", "properties", "required", "additionalProperties", "items", "uniqueItems", "enum",
+// "pattern", "allOf" and "if"/"then"/"else".
+type validator struct {
+	definitions map[string]any
+	patterns    map[string]*regexp.Regexp
+	violations  []Violation
+}
+
+// validate adds the violations of the schema by the value at the JSON Pointer path.
+func (v *validator) validate(schema map[string]any, value any, path string) error {
+	if ref, ok := schema["$ref"].(string); ok {
+		definition, ok := v.definitions[strings.TrimPrefix(ref, definitionsRefPrefix)].(map[string]any)
+		if !ok || !strings.HasPrefix(ref, definitionsRefPrefix) {
+			return fmt.Errorf("%s: %s", errorInvalidReference, ref)
+		}
+		if err := v.validate(definition, value, path); err != nil {
+			return err
+		}
+	}
+
+	if schemaType, ok := schema["type"]; ok && !matchesType(schemaType, value) {
+		v.addViolation(path, "expected %s, got %s", formatType(schemaType), jsonType(value))
+		return nil
+	}
+
+	if values, ok := schema["enum"].([]any); ok && !slices.ContainsFunc(values, func(enumValue any) bool { return reflect.DeepEqual(enumValue, value) }) {
+		v.addViolation(path, "value %s must be one of: %s", formatValue(value), formatValue(values))
+	}
+
+	if pattern, ok := schema["pattern"].(string); ok {
+		if s, ok := value.(string); ok {
+			re, err := v.compile(pattern)
+			if err != nil {
+				return err
+			}
+			if !re.MatchString(s) {
+				v.addViolation(path, "value %s does not match the pattern %s", formatValue(s), pattern)
+			}
+		}
+	}
+
+	switch value := value.(type) {
+	case map[string]any:
+		if err := v.validateObject(schema, value, path); err != nil {
+			return err
+		}
+	case []any:
+		if err := v.validateArray(schema, value, path); err != nil {
+			return err
+		}
+	}
+
+	if allOf, ok := schema["allOf"].([]any); ok {
+		for _, subschema := range allOf {
+			if subschema, ok := subschema.(map[string]any); ok {
+				if err := v.validate(subschema, value, path); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	if condition, ok := schema["if"].(map[string]any); ok {
+		matches, err := v.matches(condition, value, path)
+		if err != nil {
+			return err
+		}
+
+		branch := "else"
+		if matches {
+			branch = "then"
+		}
+		if subschema, ok := schema[branch].(map[string]any); ok {
+			if err := v.validate(subschema, value, path); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateObject adds the violations of the object keywords of the schema by the object.
+func (v *validator) validateObject(schema map[string]any, object map[string]any, path string) error {
+	if required, ok := schema["required"].([]any); ok {
+		for _, name := range required {
+			if name, ok := name.(string); ok {
+				if _, exists := object[name]; !exists {
+					v.addViolation(path, "missing required property '%s'", name)
+				}
+			}
+		}
+	}
+
+	properties, _ := schema["properties"].(map[string]any)
+	additionalProperties := schema["additionalProperties"]
+
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		propertyPath := path + "/" + escapePointer(name)
+
+		if property, ok := properties[name].(map[string]any); ok {
+			if err := v.validate(property, object[name], propertyPath); err != nil {
+				return err
+			}
+			continue
+		}
+
+		switch additional := additionalProperties.(type) {
+		case bool:
+			if !additional {
+				v.addViolation(propertyPath, "unknown property '%s'", name)
+			}
+		case map[string]any:
+			if err := v.validate(additional, object[name], propertyPath); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateArray adds the violations of the array keywords of the schema by the array.
+func (v *validator) validateArray(schema map[string]any, array []any, path string) error {
+	if items, ok := schema["items"].(map[string]any); ok {
+		for i, item := range array {
+			if err := v.validate(items, item, fmt.Sprintf("%s/%d", path, i)); err != nil {
+				return err
+			}
+		}
+	}
+
+	if unique, ok := schema["uniqueItems"].(bool); ok && unique {
+		for i := range array {
+			for j := range i {
+				if reflect.DeepEqual(array[i], array[j]) {
+					v.addViolation(fmt.Sprintf("%s/%d", path, i), "duplicate of item %d", j)
+					break
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// matches returns whether the value is valid by the schema, without adding the violations.
+func (v *validator) matches(schema map[string]any, value any, path string) (bool, error) {
+	violations := v.violations
+	v.violations = nil
+
+	err := v.validate(schema, value, path)
+	matches := len(v.violations) == 0
+
+	v.violations = violations
+	return matches, err
+}
+
+// compile returns the compiled regular expression of the pattern, compiling each pattern once.
+func (v *validator) compile(pattern string) (*regexp.Regexp, error) {
+	if re, ok := v.patterns[pattern]; ok {
+		return re, nil
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s: %w", errorInvalidPattern, pattern, err)
+	}
+
+	v.patterns[pattern] = re
+	return re, nil
+}
+
+// addViolation adds a violation at the JSON Pointer path.
+func (v *validator) addViolation(path, format string, args ...any) {
+	v.violations = append(v.violations, Violation{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// matchesType returns whether the value has the type, or one of the types, of a schema.
+func matchesType(schemaType any, value any) bool {
+	switch schemaType := schemaType.(type) {
+	case string:
+		actual := jsonType(value)
+		return actual == schemaType || (schemaType == "number" && actual == "integer")
+	case []any:
+		return slices.ContainsFunc(schemaType, func(t any) bool { return matchesType(t, value) })
+	}
+	return true
+}
+
+// jsonType returns the JSON Schema type of a decoded JSON value.
+func jsonType(value any) string {
+	switch value := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		if value == math.Trunc(value) {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+// formatType returns the type, or types, of a schema for a message.
+func formatType(schemaType any) string {
+	if types, ok := schemaType.([]any); ok {
+		names := make([]string, len(types))
+		for i, t := range types {
+			names[i] = fmt.Sprint(t)
+		}
+		return strings.Join(names, " or ")
+	}
+	return fmt.Sprint(schemaType)
+}
+
+// formatValue returns a value as JSON for a message.
+func formatValue(value any) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+// escapePointer escapes a property name as a reference token of a JSON Pointer (RFC 6901).
+func escapePointer(name string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(name)
+}
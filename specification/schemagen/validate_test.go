@@ -0,0 +1,157 @@
+package schemagen
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeFile writes the content to a file with the name in a temporary directory and returns its path.
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+
+	return path
+}
+
+func TestValidateServiceFile(t *testing.T) {
+	t.Run("valid YAML file", func(t *testing.T) {
+		// Arrange
+		path := writeFile(t, "service.yaml", `name: Library
+enums:
+  - name: Genre
+    description: Genre of a book
+    values:
+      - name: Fiction
+        description: Fiction books
+resources:
+  - name: Books
+    description: Books of the library
+    operations: [Create, Get]
+    fields:
+      - name: pages
+        description: Number of pages
+        type: Int
+        default: "100"
+        operations: [Create, Read]
+`)
+
+		// Act
+		err := ValidateServiceFile(path)
+
+		// Assert
+		assert.Nil(t, err)
+	})
+
+	t.Run("valid JSON file", func(t *testing.T) {
+		// Arrange
+		path := writeFile(t, "service.json", `{"name": "Library"}`)
+
+		// Act
+		err := ValidateServiceFile(path)
+
+		// Assert
+		assert.Nil(t, err)
+	})
+
+	t.Run("reports all violations with JSON Pointer paths", func(t *testing.T) {
+		// Arrange
+		path := writeFile(t, "service.yaml", `name: Library
+title: Unknown
+resources:
+  - description: Books of the library
+    operations: Create
+    fields:
+      - name: pages
+        description: Number of pages
+        type: Int
+        default: many
+        operations: [Create]
+    endpoints:
+      - name: Archive
+        summary: Archive a book
+        description: Archives the book
+        method: POST
+        path: /{id}/archive
+        response:
+          status_code: "204"
+`)
+
+		// Act
+		err := ValidateServiceFile(path)
+
+		// Assert
+		var validationErr *ValidationError
+		require.True(t, errors.As(err, &validationErr))
+		assert.Equal(t, []Violation{
+			{Path: "/resources/0", Message: "missing required property 'name'"},
+			{Path: "/resources/0/endpoints/0/response", Message: "missing required property 'content_type'"},
+			{Path: "/resources/0/endpoints/0/response", Message: "missing required property 'description'"},
+			{Path: "/resources/0/endpoints/0/response/status_code", Message: "expected integer, got string"},
+			{Path: "/resources/0/fields/0/default", Message: `value "many" does not match the pattern ^-?[0-9]+$`},
+			{Path: "/resources/0/operations", Message: "expected array, got string"},
+			{Path: "/title", Message: "unknown property 'title'"},
+		}, validationErr.Violations)
+		assert.Contains(t, err.Error(), "7 schema violation(s):\n/resources/0: missing required property 'name'\n")
+	})
+
+	t.Run("escapes property names of the paths", func(t *testing.T) {
+		// Arrange
+		path := writeFile(t, "service.json", `{"name": "Library", "a/b~c": true}`)
+
+		// Act
+		err := ValidateServiceFile(path)
+
+		// Assert
+		var validationErr *ValidationError
+		require.True(t, errors.As(err, &validationErr))
+		assert.Equal(t, "/a~1b~0c", validationErr.Violations[0].Path)
+	})
+
+	t.Run("reports violations of the root", func(t *testing.T) {
+		// Arrange
+		path := writeFile(t, "service.json", `[]`)
+
+		// Act
+		err := ValidateServiceFile(path)
+
+		// Assert
+		assert.EqualError(t, err, "1 schema violation(s):\n/: expected object, got array")
+	})
+
+	t.Run("unsupported extension returns error", func(t *testing.T) {
+		// Arrange
+		path := writeFile(t, "service.txt", `name: Library`)
+
+		// Act
+		err := ValidateServiceFile(path)
+
+		// Assert
+		assert.ErrorContains(t, err, errorUnsupportedFormat)
+	})
+
+	t.Run("invalid YAML returns error", func(t *testing.T) {
+		// Arrange
+		path := writeFile(t, "service.yaml", "name: [")
+
+		// Act
+		err := ValidateServiceFile(path)
+
+		// Assert
+		assert.ErrorContains(t, err, errorFileParse)
+	})
+
+	t.Run("missing file returns error", func(t *testing.T) {
+		// Act
+		err := ValidateServiceFile(filepath.Join(t.TempDir(), "missing.yaml"))
+
+		// Assert
+		assert.ErrorContains(t, err, errorFileRead)
+	})
+}
@@ -12,12 +12,13 @@
 // - Built-in error handling with HTTP status code mapping
 // - Session management support with generic session types
 // - Enum types based on github.com/meitner-se/go-types
-// - Embedded OpenAPI specification support
+// - Embedded OpenAPI specification served on /openapi.json, with an optional Swagger UI or Redoc page
+// - Optional /healthz, /readyz and /version endpoints with pluggable check functions
+// - Deterministic, gofmt-formatted output; generation fails if the result does not parse as Go
 //
 // # Generation Process
 //
-// The package exports a single function GenerateServer that writes the generated
-// code to a bytes.Buffer:
+// GenerateServer writes the generated code to a bytes.Buffer:
 //
 //	import (
 //	    "bytes"
@@ -44,6 +45,64 @@
 //	    log.Fatal(err)
 //	}
 //
+// GenerateServerFiles generates the same server split across files of one package, keeping large
+// servers reviewable: FileServer with the register function, server configuration and helpers,
+// FileTypes with the enums and objects, and a file per resource, named by ResourceFileName, with its
+// API interface and request and response types. Every file starts with the header template and only
+// keeps the imports it uses.
+//
+//	files, err := servergen.GenerateServerFiles(service, servergen.Options{})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for name, content := range files {
+//	    err = os.WriteFile(filepath.Join("generated", name), content, 0644)
+//	}
+//
+// # Template Overrides
+//
+// Parts of the generated server are rendered from templates embedded in the package, which can be
+// overridden with GenerateServerWithOptions. Options.Templates holds the overrides by file name, usually
+// os.DirFS of a templates directory; templates that are absent use the default:
+//
+//   - header.go.tmpl (TemplateHeader): the code generation comment, package clause and imports
+//   - error_hook.go.tmpl (TemplateErrorHook): the default ErrorHook set when Server.ErrorHook is nil
+//   - middleware.go.tmpl (TemplateMiddleware): handleRequest, which runs the hooks, authentication
+//     and scope checks and decodes the parameters of every request
+//
+// The templates are executed with text/template and receive the Service, and for the header the
+// StandardImports and Imports. The defaults in the templates directory of the package are the starting
+// point for an override. A file name that is not a known template is an error, and the output must
+// still be valid Go, since it is formatted with gofmt.
+//
+//	var buf bytes.Buffer
+//	err = servergen.GenerateServerWithOptions(&buf, service, servergen.Options{
+//	    Templates: os.DirFS("templates"),
+//	})
+//
+// The default templates are parsed once per process. A Generator also reads and parses the
+// overrides once, so it can be reused for many services, also from several goroutines:
+//
+//	generator, err := servergen.NewGenerator(servergen.Options{Templates: os.DirFS("templates")})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	files, err := generator.GenerateFiles(service)
+//
+// # Field Types
+//
+// The fields are declared with github.com/meitner-se/go-types by default. Options.Types selects another
+// package with the same API, named types, or the standard library types, with pointers for nullable and
+// optional fields. DefaultTypesPackage is used when neither is set:
+//
+//	err = servergen.GenerateServerWithOptions(&buf, service, servergen.Options{
+//	    Types: servergen.Types{Stdlib: true},
+//	})
+//
+// Templates get the Types too, so an override can fill the string fields of Error with
+// {{.Types.String "err.Error()"}} whatever types are selected. The field holding the message
+// is {{.ErrorMessageField}}, which is Detail with the problem+json error format.
+//
 // # Generated Code Structure
 //
 // The generated server includes:
@@ -83,6 +142,16 @@
 //
 //	func RegisterServiceAPI[Session any](router *gin.Engine, api *ServiceAPI[Session])
 //
+// Middleware hooks into the handling of the requests without editing the generated code, with
+// PreParse before authentication and decoding, PreHandle with the decoded request and PostHandle
+// with the response and error of the endpoint. Middlewares are registered for every endpoint with
+// Server.Middlewares, or for the endpoints of a resource with its Middlewares field of the API, e.g.
+// UserMiddlewares. PreHandleOf and PostHandleOf give the hooks the typed Request of an endpoint.
+//
+// With Options.Lifecycle, RunServer registers the API on a gin engine and serves it with the address,
+// TLS and timeouts of a ServerConfig until its context is done or the process is signalled to stop,
+// then shuts down gracefully, calling the OnStartup and OnShutdown hooks of the config around it.
+//
 // # Session Management
 //
 // The generated server supports generic session management. Each endpoint receives
@@ -94,6 +163,11 @@
 //	    ErrorHook  ErrorHook[Session]
 //	}
 //
+// Every request is given a request ID by middleware registered on the router group. It is read
+// from the X-Request-Id header, or generated with GetRequestIDFunc when the header is absent or
+// is not at most 128 visible ASCII characters, and set on the response. The request ID is in the
+// RequestContext, and written errors without a RequestID are given it.
+//
 // # Error Handling
 //
 // Errors are automatically converted to API error responses with appropriate
@@ -106,9 +180,130 @@
 // - ErrorCodeNotFound: Returns HTTP 404 Not Found
 // - ErrorCodeInternal: Returns HTTP 500 Internal Server Error
 //
+// The default Error carries the errors of individual request fields in Fields, as ErrorField
+// objects with a Path, Code and Message. An Error created from another error keeps it as its
+// cause through WithCause, which Unwrap returns for errors.Is and errors.As; the cause is never
+// written to the response. The default ErrorHook returns an *Error found in the chain as is and
+// wraps any other error in an Internal Error with the error as its cause.
+//
+// With the error_format problem+json of the service, the default Error is a Problem Details (RFC 9457)
+// object with Type, Title, Status, Detail and Instance, and Code, RequestID and Fields as extension
+// members. Errors are written without the "error" wrapper and with the application/problem+json
+// content type; Response fills in the Status from the Code, and the Type and Title when empty, and
+// the Instance is the path of the request unless the error has one.
+//
+// With the localization of the service, the locale of each request is resolved from its
+// Accept-Language header into RequestContext.Locale, and the message of every written error is
+// replaced with the message of its code in that locale, or in the default locale when it has none.
+//
+// # Content Negotiation
+//
+// With Server.Compression, responses are compressed with gzip or deflate as accepted by the
+// Accept-Encoding header of the request. Endpoints with alternative content types negotiate the
+// content type of their response with the Accept header; JSON is written by default, and the other
+// content types with the ResponseEncoder of Server.ResponseEncoders, which Register requires.
+//
+// Endpoints with the application/xml content type decode their body params and encode their
+// response with encoding/xml, using the xml tags that the fields get next to their json tags, and
+// the name of the type as root element. The response is encoded with the ResponseEncoder of
+// application/xml when one is set. Unknown fields are only rejected in JSON, and errors are still
+// written as JSON. The field types must implement encoding.TextMarshaler to be encoded as XML.
+//
+// Endpoints with the application/x-www-form-urlencoded request content type parse the form into
+// their body params like query parameters: a scalar takes the first value, an array every value of
+// its key, and absent fields keep their defaults. Unknown form fields are ignored.
+//
+// GET endpoints responding with an object whose Meta has an UpdatedAt timestamp set the
+// Last-Modified header to it, and answer requests whose If-Modified-Since header is not older with
+// 304 Not Modified and no body. If-Modified-Since is ignored on requests with If-None-Match.
+//
+// # Response Validation
+//
+// With Server.ValidateResponses, the response of every handler is checked against the specification
+// before it is written: the required fields must be set, and enum fields must hold values of their
+// enums, in nested objects and arrays too. An invalid response is passed to Server.InvalidResponseHook
+// and written anyway, or panics when the hook is nil. It is meant for development, to catch handlers
+// drifting from the specification, since every response is encoded an extra time.
+//
+// # Shared Packages
+//
+// The types of a shared library of the specification can be generated into their own package with
+// GenerateSharedPackage, and Options.SharedPackages maps the library to the import path of the package.
+// The server then declares aliases of the shared types instead of the types, so that the servers of
+// several specifications share them. Since methods cannot be declared on the types of another package,
+// the helpers of the shared objects are generated as functions, e.g. setDefaultsAddress.
+//
+// # Typed IDs
+//
+// With Types.TypedIDs, the UUID fields referencing a resource are declared with an ID type of the resource,
+// e.g. UsersID, so that the IDs of different resources cannot be mixed up. The ID type embeds the UUID type of
+// the other fields, and is encoded like it.
+//
+// # Expansions
+//
+// Resources with Expansions get a struct of a flag per expansion, e.g. OrdersExpansions, and the query
+// parameter types of their Get, List and Search endpoints get an Expansions method that returns the flags of the
+// values of the expand query parameter. The handler loads the related resources that are flagged into the
+// optional fields of the response, which are null otherwise.
+//
+// # Async Endpoints
+//
+// The async endpoints of the specification return the Operation they started, which is polled at the Get
+// endpoint of the Operation resource. The responses with an Operation get the Retry-After header of its
+// RetryAfter, and the responses of the async endpoints the Location header of the path it is polled at.
+//
+// # Callbacks
+//
+// With callbacks in the specification, the server gets a CallbackClient with a method per callback, named after
+// the resource, endpoint and callback, e.g. OrdersSubscribeOrderShipped. It sends the payload as JSON to the URL
+// of the subscriber, and returns an error unless the subscriber responds with a 2xx status code.
+//
+// # Retries
+//
+// With a retry configuration in the specification, the server gets a RetryTransport, an http.RoundTripper that
+// retries the requests with the exponential backoff of DefaultRetryPolicy, the configuration the SDKs retry with.
+// Calls to other services, or the callbacks of CallbackClient, then retry like the published SDKs:
+//
+//	client := &http.Client{Transport: RetryTransport{Policy: DefaultRetryPolicy}}
+//
+// # Feature Flags
+//
+// With endpoints gated behind a feature_flag in the specification, Server.FeatureEnabledFunc is required and is
+// called with the flag before anything else handles a request to a flagged endpoint. While it returns false, the
+// endpoint responds 404 Not Found, as if it did not exist:
+//
+//	FeatureEnabledFunc: func(ctx context.Context, flag string) bool { return flags.IsEnabled(ctx, flag) },
+//
+// # Filter Depth
+//
+// With a FilterMaxDepth in the specification, the body params of the Search endpoints get a checkFilterDepth
+// method, and requests whose nested filters are nested deeper than the limit are rejected with BadRequest
+// before the handler is called.
+//
+// # Pagination
+//
+// With List or Search endpoints, the package has helpers for their handlers: ClampPagination clamps the offset and
+// limit of the request, with the MaxLimit of the specification when it has one, NewPage returns the items of the
+// page with its Pagination, and PageItems windows a slice of all the items:
+//
+//	offset, limit := api.ClampPagination(offset, limit)
+//	data, pagination := api.NewPage(users, total, offset, limit)
+//
+// A limit above the MaxLimit is already clamped by the parseQueryParams method of the query parameters of the
+// request, before the handler is called.
+//
+// # Performance
+//
+// The path and query parameter types get parsePathParams and parseQueryParams methods, which parse
+// every parameter into its type directly instead of through reflection. As with the form binding of
+// gin, absent query parameters keep their defaults, scalars take the first value and empty numbers and
+// booleans are zero. Types with parameters of object types are decoded through JSON and the form
+// binding instead. The JSON responses are encoded into buffers pooled across requests. The benchmarks
+// of the generated tests measure both, see testgen.Options.Benchmarks.
+//
 // # Type Safety
 //
-// The package leverages github.com/meitner-se/go-types for type-safe handling of:
+// By default, the package leverages github.com/meitner-se/go-types for type-safe handling of:
 // - UUIDs
 // - Nullable values
 // - Arrays
@@ -222,7 +222,7 @@ func TestErrorMethodCorrectness(t *testing.T) {
 
 	// Act
 	var buf bytes.Buffer
-	err := generateObjects(&buf, service)
+	err := generateObjects(&buf, service, Types{})
 
 	// Assert
 	assert.Nil(t, err, "Expected no error when generating objects")
@@ -288,7 +288,7 @@ func TestResponseMethod(t *testing.T) {
 
 	// Act
 	var buf bytes.Buffer
-	err := generateObjects(&buf, service)
+	err := generateObjects(&buf, service, Types{})
 
 	// Assert
 	assert.Nil(t, err, "Expected no error when generating objects")
@@ -416,10 +416,119 @@ func TestErrorInterfaceIntegration(t *testing.T) {
 		"Error handling should create Error instances")
 
 	// Verify the Response method is used in error handling with nil session for pre-auth errors
-	assert.Contains(t, generatedCode, "c.JSON(server.ErrorHook(c.Request.Context(), requestContext, nil, err).Response())",
+	assert.Contains(t, generatedCode, "c.JSON(withRequestID(server.ErrorHook(c.Request.Context(), requestContext, nil, err), requestContext.RequestID).Response())",
 		"Should use Response() method for pre-auth error responses with nil session")
 
 	// Verify the Response method is used in error handling with session for post-auth errors
-	assert.Contains(t, generatedCode, "c.JSON(server.ErrorHook(c.Request.Context(), requestContext, &request.Session, err).Response())",
+	assert.Contains(t, generatedCode, "c.JSON(withRequestID(server.ErrorHook(c.Request.Context(), requestContext, &request.Session, err), requestContext.RequestID).Response())",
 		"Should use Response() method for post-auth error responses with session")
 }
+
+// ============================================================================
+// Cause and Field Error Tests
+// ============================================================================
+
+func TestErrorCauseAndFields(t *testing.T) {
+	// Arrange
+	service := &specification.Service{
+		Name:    "TestAPI",
+		Version: "v1",
+		Objects: []specification.Object{
+			{
+				Name: testErrorStructName,
+				Fields: []specification.Field{
+					{Name: testErrorCodeField, Type: testErrorCodeEnumName},
+					{Name: testErrorMessageField, Type: "String"},
+					{Name: "Fields", Type: "ErrorField", Modifiers: []string{specification.ModifierArray}},
+				},
+			},
+			{
+				Name: "ErrorField",
+				Fields: []specification.Field{
+					{Name: "Path", Type: "String"},
+				},
+			},
+		},
+	}
+
+	// Act
+	var buf bytes.Buffer
+	err := generateObjects(&buf, service, Types{})
+
+	// Assert
+	assert.Nil(t, err, "Expected no error when generating objects")
+	generatedCode := buf.String()
+
+	assert.Contains(t, generatedCode, "\tcause error\n", "Error should hold its cause in an unexported field")
+	assert.Contains(t, generatedCode, "func (e *Error) WithCause(cause error) *Error {")
+	assert.Contains(t, generatedCode, "func (e *Error) Unwrap() error {\n\treturn e.cause\n}")
+	assert.Contains(t, generatedCode, "\tif e.Fields == nil {\n\t\te.Fields = []ErrorField{}\n\t}\n",
+		"Response() should send the field errors as an empty array when there are none")
+
+	t.Run("without field errors", func(t *testing.T) {
+		// Arrange
+		var buf bytes.Buffer
+		service.Objects[0].Fields = service.Objects[0].Fields[:2]
+
+		// Act
+		err := generateObjects(&buf, service, Types{})
+
+		// Assert
+		assert.Nil(t, err)
+		assert.NotContains(t, buf.String(), "e.Fields")
+	})
+}
+
+func TestDefaultErrorHook(t *testing.T) {
+	// Arrange
+	var buf bytes.Buffer
+
+	// Act
+	err := GenerateServer(&buf, createTestServiceWithEndpoints())
+
+	// Assert
+	assert.Nil(t, err)
+	generatedCode := buf.String()
+	assert.Contains(t, generatedCode, "if errors.As(err, &apiError) {\n\t\t\t\treturn apiError\n\t\t\t}",
+		"The default ErrorHook should return API errors as they are")
+	assert.Contains(t, generatedCode, "cause:     err,", "Errors should wrap the error they were created from")
+	assert.Contains(t, generatedCode, "\t\"errors\"\n")
+}
+
+func TestGenerateServer_ProblemDetails(t *testing.T) {
+	// Arrange
+	service := createTestServiceWithEndpoints()
+	service.ErrorFormat = specification.ErrorFormatProblemJSON
+	service = specification.ApplyOverlay(service)
+	var buf bytes.Buffer
+
+	// Act
+	err := GenerateServer(&buf, service)
+
+	// Assert
+	assert.Nil(t, err)
+	generatedCode := buf.String()
+	assert.Contains(t, generatedCode, "func (e *Error) Response() (int, *Error) {", "Problems should not be wrapped in an error object")
+	assert.Contains(t, generatedCode, "\te.Status = types.NewInt(int64(status))\n")
+	assert.Contains(t, generatedCode, "\t\te.Title = types.NewString(http.StatusText(status))\n")
+	assert.Contains(t, generatedCode, "func (e *Error) Error() string {\n\treturn e.Detail.String()\n}")
+	assert.Contains(t, generatedCode, "c.Header(\"Content-Type\", \"application/problem+json\")")
+	assert.Contains(t, generatedCode, "writeProblem(c, withRequestID(server.ErrorHook(c.Request.Context(), requestContext, nil, err), requestContext.RequestID))")
+	assert.Contains(t, generatedCode, "Detail:    types.NewString(err.Error()),", "Templates should fill in the Detail of the problem")
+	assert.NotContains(t, generatedCode, "Message:   ")
+	assert.NotContains(t, generatedCode, "map[string]*Error")
+
+	t.Run("standard library types", func(t *testing.T) {
+		// Arrange
+		var buf bytes.Buffer
+
+		// Act
+		err := GenerateServerWithOptions(&buf, service, Options{Types: Types{Stdlib: true}})
+
+		// Assert
+		assert.Nil(t, err)
+		generatedCode := buf.String()
+		assert.Contains(t, generatedCode, "\te.Status = int64(status)\n")
+		assert.Contains(t, generatedCode, "\tif problem.Instance == \"\" {\n\t\tproblem.Instance = c.Request.URL.Path\n\t}\n")
+	})
+}
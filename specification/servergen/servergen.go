@@ -2,16 +2,220 @@ package servergen
 
 import (
 	"bytes"
+	"embed"
 	"fmt"
+	"go/ast"
 	"go/format"
+	"go/parser"
+	"go/token"
+	"html"
+	"io/fs"
+	"maps"
+	"path"
+	"slices"
 	"strings"
+	"text/template"
+	"unicode"
 
 	"github.com/aarondl/strmangle"
 	"github.com/meitner-se/publicapis-gen/specification"
 )
 
+// Names of the templates that can be overridden, see Options
 const (
-	disclaimerComment = "// Code generated by publicapis-gen servergen. DO NOT EDIT.\n// This file is automatically generated from the API specification.\n// Any changes made to this file will be overwritten on the next generation.\n\n"
+	// TemplateHeader renders the code generation comment, the package clause and the imports
+	TemplateHeader = "header.go.tmpl"
+
+	// TemplateErrorHook renders the default ErrorHook set by the register function when Server.ErrorHook is nil
+	TemplateErrorHook = "error_hook.go.tmpl"
+
+	// TemplateMiddleware renders handleRequest, which runs the hooks, authentication and scope checks
+	// and decodes the parameters of every request
+	TemplateMiddleware = "middleware.go.tmpl"
+)
+
+// Template error constants
+const (
+	errorUnknownTemplate = "unknown template"
+	errorTemplate        = "failed to render template"
+)
+
+// errorUnknownLibrary is returned when the shared library of a shared package has no types in the service
+const errorUnknownLibrary = "unknown shared library"
+
+// Names of the shared files of the server split across files, see GenerateServerFiles
+const (
+	// FileServer holds the register function, the server configuration, the request types and the helpers
+	FileServer = "server.go"
+
+	// FileTypes holds the enums and objects of the specification
+	FileTypes = "types.go"
+
+	// FileLifecycle holds RunServer and its ServerConfig, only generated with Options.Lifecycle
+	FileLifecycle = "lifecycle.go"
+
+	// ResourceFileSuffix ends the files of the resources, so that they cannot collide with the shared files
+	// or end in a suffix the Go tool treats specially, such as _test or _linux
+	ResourceFileSuffix = "_resource.go"
+)
+
+// Error message prefixes of the request bodies that cannot be decoded, see BodyParamsDecodeError
+const (
+	bodyParamsDecodeError     = "cannot decode body params: "
+	jsonBodyParamsDecodeError = "cannot decode json body params: "
+)
+
+// paginationLimitFieldName is the name of the limit query parameter of the List and Search endpoints
+const paginationLimitFieldName = "Limit"
+
+// templateNames are the names of all templates that can be overridden
+var templateNames = []string{TemplateHeader, TemplateErrorHook, TemplateMiddleware}
+
+// defaultTemplates are the templates used when no override is given
+//
+//go:embed templates/*.go.tmpl
+var defaultTemplates embed.FS
+
+// Options configures the generation of the server.
+type Options struct {
+	// Templates holds templates overriding the defaults by file name (TemplateHeader, TemplateErrorHook or
+	// TemplateMiddleware), e.g. os.DirFS of a templates directory. Templates that are absent use the default.
+	// The templates are executed with text/template, see templateData for the data they receive.
+	Templates fs.FS
+
+	// Types selects the Go types the fields are declared with, defaulting to DefaultTypesPackage
+	Types Types
+
+	// Lifecycle generates RunServer, which serves the API on a configurable address, optionally with TLS, and shuts
+	// it down gracefully on a signal, with hooks for the startup and shutdown tasks of the service
+	Lifecycle bool
+
+	// SharedPackages maps the names of shared libraries to the import paths of the packages generated for them with
+	// GenerateSharedPackage, which the server imports their enums and objects from. The types of the other shared
+	// libraries are generated with the server, like the types of the service.
+	SharedPackages map[string]string
+}
+
+// templateData is the data the templates are executed with.
+type templateData struct {
+	// Service is the specification the server is generated from
+	Service *specification.Service
+
+	// StandardImports and Imports are the import paths of the standard library and of other modules
+	StandardImports []string
+	Imports         []string
+
+	// Types converts strings to the type of the string fields, e.g. {{.Types.String "err.Error()"}}
+	Types Types
+}
+
+// ErrorMessageField returns the name of the field of the Error object holding the message, e.g. {{.ErrorMessageField}}.
+func (d templateData) ErrorMessageField() string {
+	return d.Service.GetErrorMessageFieldName()
+}
+
+// BodyParamsDecodeError returns the prefix of the error message of the generated server for a request body that
+// cannot be decoded, which only mentions JSON when the service has no XML or form request bodies, e.g.
+// {{.BodyParamsDecodeError}}.
+func (d templateData) BodyParamsDecodeError() string {
+	return BodyParamsDecodeError(d.Service)
+}
+
+// BodyParamsDecodeError returns the prefix of the error message the generated server responds with for a request
+// body that cannot be decoded, the error itself follows it.
+func BodyParamsDecodeError(service *specification.Service) string {
+	if service.HasXMLRequests() || service.HasFormRequests() {
+		return bodyParamsDecodeError
+	}
+	return jsonBodyParamsDecodeError
+}
+
+// parsedDefaultTemplates are the default templates by name, parsed once since they are embedded in the package
+var parsedDefaultTemplates = mustParseDefaultTemplates()
+
+// mustParseDefaultTemplates parses the default templates, panicking if one of them is invalid.
+func mustParseDefaultTemplates() map[string]*template.Template {
+	parsed := make(map[string]*template.Template, len(templateNames))
+	for _, name := range templateNames {
+		source, err := defaultTemplates.ReadFile("templates/" + name)
+		if err != nil {
+			panic(err)
+		}
+		parsed[name] = template.Must(parseTemplate(name, source))
+	}
+	return parsed
+}
+
+// parseTemplate parses the source of the template with the given name.
+func parseTemplate(name string, source []byte) (*template.Template, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(string(source))
+	if err != nil {
+		return nil, fmt.Errorf("%s '%s': %w", errorTemplate, name, err)
+	}
+	return tmpl, nil
+}
+
+// templateSet executes the templates of the server, preferring the overrides over the defaults.
+// The overrides are parsed when the set is created, so a set can be executed any number of times, concurrently too.
+type templateSet struct {
+	overrides map[string]*template.Template
+}
+
+// newTemplateSet creates a template set, returning an error if the overrides contain a template that does not exist,
+// so that a misspelled template name does not silently fall back to the default, or a template that does not parse.
+func newTemplateSet(overrides fs.FS) (templateSet, error) {
+	if overrides == nil {
+		return templateSet{}, nil
+	}
+
+	names, err := fs.Glob(overrides, "*.tmpl")
+	if err != nil {
+		return templateSet{}, err
+	}
+
+	set := templateSet{overrides: make(map[string]*template.Template, len(names))}
+	for _, name := range names {
+		if !slices.Contains(templateNames, name) {
+			return templateSet{}, fmt.Errorf("%s: '%s', expected one of %s", errorUnknownTemplate, name, strings.Join(templateNames, ", "))
+		}
+
+		source, err := fs.ReadFile(overrides, name)
+		if err != nil {
+			return templateSet{}, fmt.Errorf("%s '%s': %w", errorTemplate, name, err)
+		}
+
+		tmpl, err := parseTemplate(name, source)
+		if err != nil {
+			return templateSet{}, err
+		}
+		set.overrides[name] = tmpl
+	}
+
+	return set, nil
+}
+
+// execute executes the template with the given name, from the overrides if it exists there.
+func (t templateSet) execute(buf *bytes.Buffer, name string, data templateData) error {
+	tmpl, ok := t.overrides[name]
+	if !ok {
+		tmpl, ok = parsedDefaultTemplates[name]
+	}
+	if !ok {
+		return fmt.Errorf("%s: '%s'", errorUnknownTemplate, name)
+	}
+
+	if err := tmpl.Execute(buf, data); err != nil {
+		return fmt.Errorf("%s '%s': %w", errorTemplate, name, err)
+	}
+
+	return nil
+}
+
+// Documentation page assets, loaded from a CDN by the generated HTML pages
+const (
+	swaggerUIStylesheetURL = "https://unpkg.com/swagger-ui-dist@5/swagger-ui.css"
+	swaggerUIBundleURL     = "https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js"
+	redocBundleURL         = "https://cdn.redoc.ly/redoc/latest/bundles/redoc.standalone.js"
 )
 
 // convertOpenAPIPathToGin converts OpenAPI-style path parameters {param} to Gin-style :param
@@ -38,62 +242,162 @@ func convertOpenAPIPathToGin(path string) string {
 	return result
 }
 
-// sanitizeHeaderName converts a header name to a valid Go identifier by removing hyphens
-func sanitizeHeaderName(name string) string {
-	return strings.ReplaceAll(name, "-", "")
+// ginRoutes holds the name of the wildcard of the gin routes by the path before it. Gin requires the routes sharing
+// a path to name their wildcards alike, e.g. /post/:id and /post/:id/comment, so the parameters of sub-resources
+// are routed with the wildcard names of their parents, and renamed back with renamePathParams.
+type ginRoutes map[string]string
+
+// add converts the OpenAPI path to the gin path of the route, naming its wildcards like the routes added before.
+// It returns the names of the path parameters in order when any of them is renamed, otherwise nil.
+func (r ginRoutes) add(path string) (string, []string) {
+	segments := strings.Split(convertOpenAPIPathToGin(path), "/")
+	names := []string{}
+	renamed := false
+	for i, segment := range segments {
+		name, isWildcard := strings.CutPrefix(segment, ":")
+		if !isWildcard {
+			continue
+		}
+
+		prefix := strings.Join(segments[:i], "/")
+		if _, exists := r[prefix]; !exists {
+			r[prefix] = name
+		}
+		segments[i] = ":" + r[prefix]
+		names = append(names, name)
+		renamed = renamed || r[prefix] != name
+	}
+
+	if !renamed {
+		return strings.Join(segments, "/"), nil
+	}
+	return strings.Join(segments, "/"), names
+}
+
+// hasRenamedPathParams checks if any route of the service renames its path parameters.
+func hasRenamedPathParams(service *specification.Service) bool {
+	routes := ginRoutes{}
+	for _, resource := range service.Resources {
+		for _, endpoint := range resource.Endpoints {
+			if _, renamedParams := routes.add(service.Tenancy.GetPathPrefix() + service.GetEndpointPath(resource, endpoint)); renamedParams != nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// getRenamePathParamsHandler returns the renamePathParams handler of the route, or an empty string when its path
+// parameters are not renamed.
+func getRenamePathParamsHandler(names []string) string {
+	if len(names) == 0 {
+		return ""
+	}
+
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = fmt.Sprintf("%q", name)
+	}
+
+	return fmt.Sprintf("renamePathParams(%s), ", strings.Join(quoted, ", "))
 }
 
+// GenerateServer generates the server with the default templates.
 func GenerateServer(buf *bytes.Buffer, service *specification.Service) error {
-	buf.WriteString(disclaimerComment)
-	buf.WriteString("package api\n\n")
+	return GenerateServerWithOptions(buf, service, Options{})
+}
 
-	buf.WriteString("import (\n")
-	buf.WriteString("\t\"context\"\n")
-	buf.WriteString("\t\"embed\"\n")
-	buf.WriteString("\t\"encoding/json\"\n")
-	buf.WriteString("\t\"net/http\"\n\n")
-	buf.WriteString(fmt.Sprintf("\t\"%s\"\n", "github.com/google/uuid"))
-	buf.WriteString(fmt.Sprintf("\t\"%s\"\n", "github.com/gin-gonic/gin"))
-	buf.WriteString(fmt.Sprintf("\t\"%s\"\n", "github.com/meitner-se/go-types"))
-	buf.WriteString(")\n\n")
+// GenerateServerWithOptions generates the server, rendering the overridable parts with the templates of the options.
+func GenerateServerWithOptions(buf *bytes.Buffer, service *specification.Service, options Options) error {
+	generator, err := NewGenerator(options)
+	if err != nil {
+		return err
+	}
+
+	return generator.Generate(buf, service)
+}
+
+// Generator generates servers with the same options, parsing their templates once, so that it can be reused for
+// every specification of a process instead of calling GenerateServerWithOptions and GenerateServerFiles, e.g. by a
+// long-running service. A Generator is safe for concurrent use.
+type Generator struct {
+	options   Options
+	templates templateSet
+}
+
+// NewGenerator creates a Generator with the options, returning an error if a template of the options is unknown or
+// does not parse.
+func NewGenerator(options Options) (*Generator, error) {
+	templates, err := newTemplateSet(options.Templates)
+	if err != nil {
+		return nil, err
+	}
+
+	options.Types.shared = options.SharedPackages
+
+	return &Generator{options: options, templates: templates}, nil
+}
+
+// Generate generates the server in a single file, like GenerateServerWithOptions.
+func (g *Generator) Generate(buf *bytes.Buffer, service *specification.Service) error {
+	options, templates, types := g.options, g.templates, g.options.Types
 
-	err := generateServer(buf, service)
+	err := templates.execute(buf, TemplateHeader, newHeaderData(service, options))
 	if err != nil {
 		return err
 	}
 
-	err = generateEnums(buf, service.Enums)
+	err = generateServer(buf, service, types, templates)
 	if err != nil {
 		return err
 	}
 
-	err = generateObjects(buf, service)
+	err = generateEnums(buf, service.Enums, types)
 	if err != nil {
 		return err
 	}
 
-	err = generateRequestTypes(buf, service)
+	err = generateObjects(buf, service, types)
 	if err != nil {
 		return err
 	}
 
-	err = generateResponseTypes(buf, service)
+	err = generateRequestTypes(buf, service, types)
 	if err != nil {
 		return err
 	}
 
-	err = generateResponseHeaderTypes(buf, service)
+	err = generateResponseTypes(buf, service, types)
 	if err != nil {
 		return err
 	}
 
-	err = generateUtils(buf, service)
+	err = generateResponseHeaderTypes(buf, service, types)
 	if err != nil {
 		return err
 	}
 
-	// Format the buffer content
-	formatted, err := format.Source(buf.Bytes())
+	err = generateOperationalEndpoints(buf, service)
+	if err != nil {
+		return err
+	}
+
+	err = generateDocsUI(buf, service)
+	if err != nil {
+		return err
+	}
+
+	err = generateUtils(buf, service, types, templates)
+	if err != nil {
+		return err
+	}
+
+	if options.Lifecycle {
+		generateLifecycle(buf, service)
+	}
+
+	// Format the buffer content, removing the imports that are not used with the selected types
+	formatted, err := removeUnusedImports(buf.Bytes())
 	if err != nil {
 		return fmt.Errorf("gofmt failed: %w", err)
 	}
@@ -105,160 +409,326 @@ func GenerateServer(buf *bytes.Buffer, service *specification.Service) error {
 	return nil
 }
 
-func generateEnums(buf *bytes.Buffer, enums []specification.Enum) error {
-	for _, enumStruct := range enums {
-		buf.WriteString("var (\n")
-		for _, value := range enumStruct.Values {
-			buf.WriteString(fmt.Sprintf("\t%s%s = types.NewString(\"%s\") // %s\n", enumStruct.Name, value.Name, value.Name, value.Description))
-		}
-		buf.WriteString(")\n\n")
+// GenerateServerFiles generates the server split across files of the same package, returned by file name:
+// FileServer with the register function, the server configuration and the helpers, FileTypes with the enums
+// and objects, FileLifecycle with Options.Lifecycle, and a <name>_resource.go file per resource with its API
+// interface and request and response types.
+// Every file starts with the header template and only keeps the imports it uses.
+func GenerateServerFiles(service *specification.Service, options Options) (map[string][]byte, error) {
+	generator, err := NewGenerator(options)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	return generator.GenerateFiles(service)
 }
 
-func getTypeForGo(field specification.Field, service *specification.Service) string {
-	fieldType := field.Type
+// GenerateSharedPackage generates the enums and objects of a shared library of the service into a package of their
+// own, for the servers of the services sharing the library to import with Options.SharedPackages. The package name
+// is assumed from the import path like goimports does. The package declares the types, with json and xml tags, and
+// the methods of the integer enums, while every server generates the helpers it needs for them, so Types must be the
+// types of the servers.
+func GenerateSharedPackage(buf *bytes.Buffer, service *specification.Service, library, importPath string, types Types) error {
+	shared := service.GetSharedLibrary(library)
+	if shared == nil {
+		return fmt.Errorf("%s: '%s' has no enums or objects in the service", errorUnknownLibrary, library)
+	}
+
+	// The types of the library are declared here, not imported
+	types.shared = nil
 
-	if service.HasEnum(fieldType) {
-		fieldType = "String"
+	buf.WriteString("// Code generated by publicapis-gen servergen. DO NOT EDIT.\n")
+	buf.WriteString("// This file is automatically generated from a shared library of the API specifications.\n")
+	buf.WriteString("// Any changes made to this file will be overwritten on the next generation.\n\n")
+	buf.WriteString(fmt.Sprintf("package %s\n\n", packageName(importPath)))
+
+	buf.WriteString("import (\n")
+	buf.WriteString("\t\"encoding/json\"\n")
+	buf.WriteString("\t\"fmt\"\n")
+	buf.WriteString("\t\"strconv\"\n")
+	buf.WriteString("\t\"time\"\n\n")
+	buf.WriteString("\t\"github.com/google/uuid\"\n")
+	buf.WriteString("\t\"github.com/shopspring/decimal\"\n")
+	if typesImport := types.ImportPath(); typesImport != "" {
+		buf.WriteString(fmt.Sprintf("\t%q\n", typesImport))
 	}
+	buf.WriteString(")\n\n")
 
-	return getTypePrefix(field, service) + fieldType
-}
+	if err := generateEnums(buf, shared.Enums, types); err != nil {
+		return err
+	}
 
-// getTypeForGoFilter generates Go type for fields in filter objects with special pointer handling.
-func getTypeForGoFilter(field specification.Field, service *specification.Service, parentObject specification.Object) string {
-	fieldType := field.Type
+	// The fields are always tagged for XML, since any of the services may exchange XML
+	for _, object := range shared.Objects {
+		fields := object.Fields
+		if object.HasParent() && service.HasObject(object.Extends) {
+			fields = object.GetOwnFields(service)
+		}
+		generateObjectStruct(buf, object, fields, service, types, true)
+	}
 
-	if service.HasEnum(fieldType) {
-		fieldType = "String"
+	formatted, err := removeUnusedImports(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("gofmt failed: %w", err)
 	}
 
-	return getTypePrefixForFilter(field, service, parentObject) + fieldType
+	buf.Reset()
+	buf.Write(formatted)
+
+	return nil
 }
 
-func getTypePrefix(field specification.Field, service *specification.Service) string {
-	isObject := service.IsObject(field.Type)
+// GenerateFiles generates the server split across files, like GenerateServerFiles.
+func (g *Generator) GenerateFiles(service *specification.Service) (map[string][]byte, error) {
+	options, templates, types := g.options, g.templates, g.options.Types
 
-	prefixes := []string{}
+	files := make(map[string][]byte)
+	addFile := func(name string, generate func(buf *bytes.Buffer) error) error {
+		var buf bytes.Buffer
+		if err := templates.execute(&buf, TemplateHeader, newHeaderData(service, options)); err != nil {
+			return err
+		}
 
-	if field.IsArray() {
-		prefixes = append(prefixes, "[]")
+		if err := generate(&buf); err != nil {
+			return err
+		}
+
+		source, err := removeUnusedImports(buf.Bytes())
+		if err != nil {
+			return fmt.Errorf("gofmt failed for '%s': %w", name, err)
+		}
+
+		files[name] = source
+		return nil
 	}
 
-	// Note: Removed pointer prefix for nullable objects as per INF-407
-	// if field.IsNullable() && isObject {
-	//     prefixes = append(prefixes, "*")
-	// }
+	err := addFile(FileServer, func(buf *bytes.Buffer) error {
+		if err := generateRegister(buf, service, types, templates); err != nil {
+			return err
+		}
+		if err := generateRequestContextTypes(buf, service, types); err != nil {
+			return err
+		}
+		if err := generateResponseHeaderTypes(buf, service, types); err != nil {
+			return err
+		}
+		if err := generateOperationalEndpoints(buf, service); err != nil {
+			return err
+		}
+		if err := generateDocsUI(buf, service); err != nil {
+			return err
+		}
+		return generateUtils(buf, service, types, templates)
+	})
+	if err != nil {
+		return nil, err
+	}
 
-	if !isObject {
-		prefixes = append(prefixes, "types.")
+	err = addFile(FileTypes, func(buf *bytes.Buffer) error {
+		if err := generateEnums(buf, service.Enums, types); err != nil {
+			return err
+		}
+		return generateObjects(buf, service, types)
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	typePrefix := strings.Join(prefixes, "")
+	if options.Lifecycle {
+		err = addFile(FileLifecycle, func(buf *bytes.Buffer) error {
+			generateLifecycle(buf, service)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
 
-	return typePrefix
-}
+	for _, resource := range service.Resources {
+		err = addFile(ResourceFileName(resource.Name), func(buf *bytes.Buffer) error {
+			generateResourceInterface(buf, resource)
+			generateResourceRequestTypes(buf, service, resource, types)
+			generateResourceResponseTypes(buf, service, resource, types)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
 
-// getTypePrefixForFilter handles filter objects specifically.
-// Filter type fields (Equals, NotEquals, etc.) should be pointers.
-// Nested filter object fields should NOT be pointers.
-func getTypePrefixForFilter(field specification.Field, service *specification.Service, parentObject specification.Object) string {
-	isObject := service.IsObject(field.Type)
+	return files, nil
+}
 
-	prefixes := []string{}
+// ResourceFileName returns the name of the file of the resource in GenerateServerFiles, e.g. user_roles_resource.go
+func ResourceFileName(resourceName string) string {
+	runes := []rune(resourceName)
 
-	if field.IsArray() {
-		prefixes = append(prefixes, "[]")
+	var name strings.Builder
+	for i, r := range runes {
+		// A word starts at an upper case letter after a lower case letter or digit, or before a lower case letter
+		// ending an acronym, e.g. APIKeys is api_keys
+		if i > 0 && unicode.IsUpper(r) && (!unicode.IsUpper(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+			name.WriteByte('_')
+		}
+		name.WriteRune(unicode.ToLower(r))
 	}
 
-	// Special logic for filter objects
-	if parentObject.IsFilter() {
-		// Check if this is a filter type field (Equals, NotEquals, etc.)
-		if isFilterTypeField(field.Name) && field.IsNullable() && isObject {
-			prefixes = append(prefixes, "*")
+	return name.String() + ResourceFileSuffix
+}
+
+// newHeaderData returns the data of the header template, with the imports used by the generated server.
+func newHeaderData(service *specification.Service, options Options) templateData {
+	types := options.Types
+	data := templateData{
+		Service:         service,
+		StandardImports: []string{"context", "embed", "encoding/json", "errors", "fmt", "net/http"},
+		Imports:         []string{"github.com/google/uuid", "github.com/gin-gonic/gin"},
+		Types:           types,
+	}
+	if types.Stdlib {
+		data.StandardImports = append(data.StandardImports, "strconv", "time")
+	} else {
+		data.Imports = append(data.Imports, types.ImportPath())
+	}
+	if service.HasFieldType(specification.FieldTypeDecimal) {
+		data.Imports = append(data.Imports, "github.com/shopspring/decimal")
+	}
+	for _, library := range service.GetSharedLibraries() {
+		if importPath := options.SharedPackages[library]; importPath != "" {
+			data.Imports = append(data.Imports, importPath)
 		}
-		// For nested filter object fields (like Meta, External), don't add pointer
-		// even if they're nullable - this follows the user's requirement
+	}
+	if service.Localization != nil {
+		data.StandardImports = append(data.StandardImports, "strings", "strconv")
+	}
+	if options.Lifecycle {
+		data.StandardImports = append(data.StandardImports, "crypto/tls", "fmt", "os", "os/signal", "syscall", "time")
 	}
 
-	if !isObject {
-		prefixes = append(prefixes, "types.")
+	if len(getLastModifiedObjects(service)) > 0 {
+		data.StandardImports = append(data.StandardImports, "time")
 	}
 
-	typePrefix := strings.Join(prefixes, "")
+	if len(getCSVObjects(service)) > 0 {
+		data.StandardImports = append(data.StandardImports, "encoding/csv")
+	}
 
-	return typePrefix
+	if service.HasXMLRequests() || service.HasXMLResponses() {
+		data.StandardImports = append(data.StandardImports, "encoding/xml")
+	}
+
+	if service.HasFormRequests() {
+		data.StandardImports = append(data.StandardImports, "mime")
+	}
+
+	if service.HasRequestLimits() {
+		data.StandardImports = append(data.StandardImports, "time")
+	}
+
+	// Compression and content negotiation
+	data.StandardImports = append(data.StandardImports, "bytes", "compress/gzip", "compress/zlib", "io", "strconv", "strings")
+
+	// Parameter parsing and pooled response buffers
+	data.StandardImports = append(data.StandardImports, "net/url", "strconv", "sync", "time")
+
+	slices.Sort(data.StandardImports)
+	data.StandardImports = slices.Compact(data.StandardImports)
+
+	return data
 }
 
-// isFilterTypeField checks if a field name is one of the standard filter type fields.
-func isFilterTypeField(fieldName string) bool {
-	filterFields := []string{
-		"Equals", "NotEquals", "GreaterThan", "SmallerThan",
-		"GreaterOrEqual", "SmallerOrEqual", "Contains", "NotContains",
-		"Like", "NotLike", "Null", "NotNull",
+// removeUnusedImports removes the imports that are not referenced by the source and formats it,
+// since every file of GenerateServerFiles starts with the imports of the whole server
+// and not every import is used with every choice of Types.
+func removeUnusedImports(source []byte) ([]byte, error) {
+	fileSet := token.NewFileSet()
+	file, err := parser.ParseFile(fileSet, "", source, parser.ParseComments)
+	if err != nil {
+		return nil, err
 	}
 
-	for _, filterField := range filterFields {
-		if fieldName == filterField {
-			return true
+	used := make(map[string]bool)
+	ast.Inspect(file, func(node ast.Node) bool {
+		if selector, ok := node.(*ast.SelectorExpr); ok {
+			if ident, ok := selector.X.(*ast.Ident); ok {
+				used[ident.Name] = true
+			}
+		}
+		return true
+	})
+
+	// The lines of the unused imports are removed from the source, since printing the modified syntax tree
+	// would leave blank lines in their place
+	unused := make(map[int]bool)
+	for _, spec := range file.Imports {
+		if !used[importName(spec)] {
+			unused[fileSet.Position(spec.Pos()).Line] = true
 		}
 	}
-	return false
+
+	var buf bytes.Buffer
+	for i, line := range bytes.SplitAfter(source, []byte("\n")) {
+		if !unused[i+1] {
+			buf.Write(line)
+		}
+	}
+
+	return format.Source(buf.Bytes())
 }
 
-func generateObjects(buf *bytes.Buffer, service *specification.Service) error {
-	for _, object := range service.Objects {
-		buf.WriteString(fmt.Sprintf("%s\n", object.GetComment()))
-		buf.WriteString(fmt.Sprintf("type %s struct {\n", object.Name))
+// importName returns the name the import is referenced by in the source.
+func importName(spec *ast.ImportSpec) string {
+	if spec.Name != nil {
+		return spec.Name.Name
+	}
+
+	return packageName(strings.Trim(spec.Path.Value, `"`))
+}
 
-		for _, field := range object.Fields {
-			buf.WriteString(fmt.Sprintf("%s\n", field.GetComment("\t")))
+// packageName assumes the name of the package from its import path like goimports does, e.g.
+// github.com/meitner-se/go-types is types.
+func packageName(importPath string) string {
+	name := strings.TrimPrefix(path.Base(importPath), "go-")
+	if i := strings.IndexAny(name, ".-"); i > 0 {
+		name = name[:i]
+	}
 
-			// Use filter-aware type generation for filter objects
-			var fieldType string
-			if object.IsFilter() {
-				fieldType = getTypeForGoFilter(field, service, object)
-			} else {
-				fieldType = getTypeForGo(field, service)
-			}
+	return name
+}
+
+func generateEnums(buf *bytes.Buffer, enums []specification.Enum, types Types) error {
+	// Enum values are strings, which are constants with standard library types
+	declaration := "var"
+	if types.Stdlib {
+		declaration = "const"
+	}
 
-			buf.WriteString(fmt.Sprintf("\t%s %s `json:\"%s\"`\n\n", field.Name, fieldType, field.TagJSON()))
+	for _, enumStruct := range enums {
+		if pkg := types.sharedPackage(enumStruct.Library); pkg != "" {
+			generateEnumAlias(buf, enumStruct, pkg, declaration)
+			continue
 		}
 
-		buf.WriteString("}\n\n")
+		if enumStruct.IsInt() {
+			generateIntEnum(buf, enumStruct, types)
+			continue
+		}
 
-		if object.Name == "Error" {
-			buf.WriteString("func (e *Error) Error() string {\n")
-			buf.WriteString("\treturn e.Message.String()\n")
-			buf.WriteString("}\n\n")
+		buf.WriteString(declaration + " (\n")
+		for _, value := range enumStruct.Values {
+			buf.WriteString(fmt.Sprintf("\t%s = %s // %s\n", value.GetGoName(enumStruct.Name), types.String(fmt.Sprintf("%q", value.GetValue())), value.Description))
+		}
+		buf.WriteString(")\n\n")
 
-			buf.WriteString("func (e *Error) HTTPStatusCode() int {\n")
-			buf.WriteString("\tswitch e.Code {\n")
-			buf.WriteString("\tcase ErrorCodeBadRequest:\n")
-			buf.WriteString("\t\treturn http.StatusBadRequest\n")
-			buf.WriteString("\tcase ErrorCodeUnauthorized:\n")
-			buf.WriteString("\t\treturn http.StatusUnauthorized\n")
-			buf.WriteString("\tcase ErrorCodeForbidden:\n")
-			buf.WriteString("\t\treturn http.StatusForbidden\n")
-			buf.WriteString("\tcase ErrorCodeNotFound:\n")
-			buf.WriteString("\t\treturn http.StatusNotFound\n")
-			buf.WriteString("\tcase ErrorCodeConflict:\n")
-			buf.WriteString("\t\treturn http.StatusConflict\n")
-			buf.WriteString("\tcase ErrorCodeUnprocessableEntity:\n")
-			buf.WriteString("\t\treturn http.StatusUnprocessableEntity\n")
-			buf.WriteString("\tcase ErrorCodeRateLimited:\n")
-			buf.WriteString("\t\treturn http.StatusTooManyRequests\n")
-			buf.WriteString("\tcase ErrorCodeInternal:\n")
-			buf.WriteString("\t\treturn http.StatusInternalServerError\n")
-			buf.WriteString("\tdefault:\n")
-			buf.WriteString("\t\treturn http.StatusInternalServerError\n")
-			buf.WriteString("\t}\n")
-			buf.WriteString("}\n\n")
-
-			buf.WriteString("func (e *Error) Response() (int, map[string]*Error) {\n")
-			buf.WriteString("\treturn e.HTTPStatusCode(), map[string]*Error{\"error\": e}\n")
+		// The deprecated aliases are mapped to the wire values, to accept them in requests
+		if enumStruct.HasAliases() {
+			buf.WriteString(fmt.Sprintf("// %sAliases maps the deprecated aliases of the %s values to their wire values\n", enumStruct.Name, enumStruct.Name))
+			buf.WriteString(fmt.Sprintf("var %sAliases = map[string]string{\n", enumStruct.Name))
+			for _, value := range enumStruct.Values {
+				for _, alias := range value.Aliases {
+					buf.WriteString(fmt.Sprintf("\t%q: %q,\n", alias, value.GetValue()))
+				}
+			}
 			buf.WriteString("}\n\n")
 		}
 	}
@@ -266,290 +736,3463 @@ func generateObjects(buf *bytes.Buffer, service *specification.Service) error {
 	return nil
 }
 
-func generateServer(buf *bytes.Buffer, service *specification.Service) error {
-	serviceName := strmangle.TitleCase(service.Name)
-	buf.WriteString(fmt.Sprintf("func Register%sAPI[Session any](router *gin.Engine, api *%sAPI[Session]) {\n", serviceName, serviceName))
-	buf.WriteString("\tif api.Server.ErrorHook == nil {\n")
-	buf.WriteString("\t\tapi.Server.ErrorHook = func(ctx context.Context, requestContext RequestContext, session *Session, err error) *Error {\n")
-	buf.WriteString("\t\t\treturn &Error{\n")
-	buf.WriteString("\t\t\t\tCode:    ErrorCodeInternal,\n")
-	buf.WriteString("\t\t\t\tMessage: types.NewString(err.Error()),\n")
-	buf.WriteString("\t\t\t\tRequestID: types.NewString(requestContext.RequestID),\n")
-	buf.WriteString("\t\t\t}\n")
-	buf.WriteString("\t\t}\n")
-	buf.WriteString("\t}\n\n")
+// generateEnumAlias generates the enum of a shared library imported from the package of the library, as aliases of
+// its values, and of its type and Parse function for an integer-backed enum, so the server references it by name.
+func generateEnumAlias(buf *bytes.Buffer, enum specification.Enum, pkg, declaration string) {
+	name := enum.Name
 
-	buf.WriteString("\tif api.Server.GetSessionFunc == nil {\n")
-	buf.WriteString("\t\tpanic(\"GetSessionFunc is nil\")\n")
-	buf.WriteString("\t}\n\n")
+	if enum.IsInt() {
+		if enum.Description != "" {
+			buf.WriteString(fmt.Sprintf("// %s: %s\n", name, enum.Description))
+		}
+		buf.WriteString(fmt.Sprintf("type %s = %s.%s\n\n", name, pkg, name))
+		declaration = "const"
+	}
 
-	buf.WriteString(fmt.Sprintf("\trouterGroup := router.Group(\"/%s/%s\")\n\n", service.PathName(), service.Version))
+	buf.WriteString(declaration + " (\n")
+	for _, value := range enum.Values {
+		buf.WriteString(fmt.Sprintf("\t%s = %s.%s // %s\n", value.GetGoName(name), pkg, value.GetGoName(name), value.Description))
+	}
+	buf.WriteString(")\n\n")
 
-	buf.WriteString("\t// OpenAPI Documentation in JSON format\n")
-	buf.WriteString("\trouterGroup.StaticFileFS(\"/openapi.json\", \"openapi.json\", http.FS(api.OpenAPI_JSON))\n\n")
+	if enum.IsInt() {
+		buf.WriteString(fmt.Sprintf("// Parse%s returns the %s of an integer value, which may be one of the deprecated aliases\n", name, name))
+		buf.WriteString(fmt.Sprintf("func Parse%s(value int64) (%s, error) {\n", name, name))
+		buf.WriteString(fmt.Sprintf("\treturn %s.Parse%s(value)\n", pkg, name))
+		buf.WriteString("}\n\n")
+	} else if enum.HasAliases() {
+		buf.WriteString(fmt.Sprintf("// %sAliases maps the deprecated aliases of the %s values to their wire values\n", name, name))
+		buf.WriteString(fmt.Sprintf("var %sAliases = %s.%sAliases\n\n", name, pkg, name))
+	}
+}
 
-	for _, resource := range service.Resources {
-		for _, endpoint := range resource.Endpoints {
-			if endpoint.HasResponseType() {
-				buf.WriteString(fmt.Sprintf("\trouterGroup.%s(\"%s\", serveWithResponse(%d, api.Server, api.%s.%s))\n",
-					endpoint.Method,
-					convertOpenAPIPathToGin(endpoint.GetFullPath(resource.Name)),
-					endpoint.Response.StatusCode,
-					resource.Name,
-					endpoint.Name,
-				))
-			} else {
-				buf.WriteString(fmt.Sprintf("\trouterGroup.%s(\"%s\", serveWithoutResponse(%d, api.Server, api.%s.%s))\n",
-					endpoint.Method,
-					convertOpenAPIPathToGin(endpoint.GetFullPath(resource.Name)),
-					endpoint.Response.StatusCode,
-					resource.Name,
-					endpoint.Name,
-				))
-			}
-		}
-		buf.WriteString("\n")
+// generateIntEnum generates an integer-backed enum as a named type with typed constants. The fields of the enum
+// are Int fields, so the type has helpers to parse a field value, accepting the deprecated aliases, and to convert
+// back to it. It marshals to its integer value, which is what the fields hold.
+func generateIntEnum(buf *bytes.Buffer, enum specification.Enum, types Types) {
+	name := enum.Name
+
+	if enum.Description != "" {
+		buf.WriteString(fmt.Sprintf("// %s: %s\n", name, enum.Description))
+	}
+	buf.WriteString(fmt.Sprintf("type %s int64\n\n", name))
+
+	buf.WriteString("const (\n")
+	for _, value := range enum.Values {
+		buf.WriteString(fmt.Sprintf("\t%s %s = %s // %s\n", value.GetGoName(name), name, value.GetValue(), value.Description))
+	}
+	buf.WriteString(")\n\n")
+
+	buf.WriteString(fmt.Sprintf("// Parse%s returns the %s of an integer value, which may be one of the deprecated aliases\n", name, name))
+	buf.WriteString(fmt.Sprintf("func Parse%s(value int64) (%s, error) {\n", name, name))
+	buf.WriteString("\tswitch value {\n")
+	for _, value := range enum.Values {
+		buf.WriteString(fmt.Sprintf("\tcase %s:\n", strings.Join(append([]string{value.GetValue()}, value.Aliases...), ", ")))
+		buf.WriteString(fmt.Sprintf("\t\treturn %s, nil\n", value.GetGoName(name)))
 	}
+	buf.WriteString("\t}\n")
+	buf.WriteString(fmt.Sprintf("\treturn 0, fmt.Errorf(\"invalid %s value: %%d\", value)\n", name))
 	buf.WriteString("}\n\n")
 
-	buf.WriteString("// getSessionFunc is a function that is used on each endpoint to set the session to the request\n")
-	buf.WriteString("type getSessionFunc[T any] func(ctx context.Context, headers http.Header) (T, error)\n\n")
+	buf.WriteString(fmt.Sprintf("// String returns the name of the %s value\n", name))
+	buf.WriteString(fmt.Sprintf("func (e %s) String() string {\n", name))
+	buf.WriteString("\tswitch e {\n")
+	for _, value := range enum.Values {
+		buf.WriteString(fmt.Sprintf("\tcase %s:\n", value.GetGoName(name)))
+		buf.WriteString(fmt.Sprintf("\t\treturn %q\n", value.Name))
+	}
+	buf.WriteString("\t}\n")
+	buf.WriteString("\treturn strconv.FormatInt(int64(e), 10)\n")
+	buf.WriteString("}\n\n")
 
-	buf.WriteString(fmt.Sprintf("type %sAPI[Session any] struct {\n", serviceName))
-	buf.WriteString("\t// Server is the server configuration for the API\n")
-	buf.WriteString("\tServer Server[Session]\n")
+	buf.WriteString(fmt.Sprintf("// Int returns the %s value as the value of an Int field\n", name))
+	buf.WriteString(fmt.Sprintf("func (e %s) Int() %s {\n", name, types.prefix(false)+types.typeName(specification.FieldTypeInt)))
+	buf.WriteString(fmt.Sprintf("\treturn %s\n", types.Int("int64(e)")))
+	buf.WriteString("}\n\n")
 
-	buf.WriteString("\tOpenAPI_JSON embed.FS\n")
-	for _, resource := range service.Resources {
-		buf.WriteString(fmt.Sprintf("\t%s %sAPI[Session] // Endpoints for the %s resource\n", resource.Name, resource.Name, resource.Name))
+	buf.WriteString(fmt.Sprintf("// MarshalJSON encodes the %s as its integer value\n", name))
+	buf.WriteString(fmt.Sprintf("func (e %s) MarshalJSON() ([]byte, error) {\n", name))
+	buf.WriteString("\treturn json.Marshal(int64(e))\n")
+	buf.WriteString("}\n\n")
+
+	buf.WriteString(fmt.Sprintf("// UnmarshalJSON decodes the %s from its integer value, or one of the deprecated aliases\n", name))
+	buf.WriteString(fmt.Sprintf("func (e *%s) UnmarshalJSON(data []byte) error {\n", name))
+	buf.WriteString("\tvar value int64\n")
+	buf.WriteString("\tif err := json.Unmarshal(data, &value); err != nil {\n")
+	buf.WriteString("\t\treturn err\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString(fmt.Sprintf("\tparsed, err := Parse%s(value)\n", name))
+	buf.WriteString("\tif err != nil {\n")
+	buf.WriteString("\t\treturn err\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\t*e = parsed\n")
+	buf.WriteString("\treturn nil\n")
+	buf.WriteString("}\n\n")
+}
+
+// generateImportedResponseValidation generates getResponseValidator, which also returns the validation of the
+// imported objects of the shared packages, since they cannot implement responseValidator.
+func generateImportedResponseValidation(buf *bytes.Buffer, importedObjects []string) {
+	buf.WriteString("// getResponseValidator returns the validation of the response, with the functions generated for the objects of the\n")
+	buf.WriteString("// shared packages, which cannot implement responseValidator\n")
+	buf.WriteString("func getResponseValidator(response any) (responseValidator, bool) {\n")
+	buf.WriteString("\tswitch response := response.(type) {\n")
+	buf.WriteString("\tcase responseValidator:\n")
+	buf.WriteString("\t\treturn response, true\n")
+	for _, name := range importedObjects {
+		buf.WriteString(fmt.Sprintf("\tcase *%s:\n", name))
+		buf.WriteString(fmt.Sprintf("\t\treturn responseValidatorFunc(validateResponse%s), true\n", name))
 	}
+	buf.WriteString("\t}\n")
+	buf.WriteString("\treturn nil, false\n")
 	buf.WriteString("}\n\n")
 
-	buf.WriteString("type Server[Session any] struct {\n")
-	buf.WriteString("\t// GetRequestIDFunc is a function that generates a request ID for each request\n")
-	buf.WriteString("\t// If nil, a default UUID-based request ID generator will be used\n")
-	buf.WriteString("\tGetRequestIDFunc func(ctx context.Context) string\n")
+	buf.WriteString(`// responseValidatorFunc implements responseValidator with a function
+type responseValidatorFunc func(data []byte) error
 
-	buf.WriteString("\t// GetSessionFunc is a function that is used on each endpoint to set the session to the request\n")
-	buf.WriteString("\tGetSessionFunc getSessionFunc[Session]\n")
+func (f responseValidatorFunc) validateResponse(data []byte) error {
+	return f(data)
+}` + "\n\n")
+}
 
-	buf.WriteString("\t// ErrorHook is a function that is used on each endpoint to convert an error to an Error object\n")
-	buf.WriteString("\tErrorHook ErrorHook[Session]\n\n")
+// generateResponseValidation generates the validation of the responses enabled by Server.ValidateResponses. The
+// response is validated in its encoded form, so that the JSON names and the wire values of the enums are checked.
+func generateResponseValidation(buf *bytes.Buffer, importedObjects []string) {
+	buf.WriteString(`// responseValidator is implemented by the response types and objects, to validate them against the specification
+type responseValidator interface {
+	validateResponse(data []byte) error
+}` + "\n\n")
 
-	// Always add ResponseHeaderHook
-	buf.WriteString("\t// ResponseHeaderHook is a function that returns common response headers for each request\n")
-	buf.WriteString("\tResponseHeaderHook ResponseHeaderHook\n\n")
+	getValidator := "response.(responseValidator)"
+	if len(importedObjects) > 0 {
+		getValidator = "getResponseValidator(response)"
+		generateImportedResponseValidation(buf, importedObjects)
+	}
 
-	buf.WriteString("\t// PreHooks are executed before endpoint logic. The first non-nil error aborts request processing.\n")
-	buf.WriteString("\tPreHooks []PreHook\n")
+	buf.WriteString(`// validateResponse validates the response and reports an invalid one to the hook, or panics if the hook is nil
+func validateResponse(ctx context.Context, requestContext RequestContext, hook InvalidResponseHook, response any) {
+	validator, ok := ` + getValidator + `
+	if !ok {
+		return
+	}
 
-	buf.WriteString("\t// SessionHooks are executed after authentication. The first non-nil error aborts request processing.\n")
-	buf.WriteString("\t// Example usages:\n")
-	buf.WriteString("\t//   - Logging: Log authenticated requests with user information from the session\n")
-	buf.WriteString("\t//   - Rate Limiting: Check if the user has exceeded their API rate limit based on session data\n")
-	buf.WriteString("\t//   - Authorization: Verify the user has permission to access the requested resource\n")
-	buf.WriteString("\t// \n")
-	buf.WriteString("\t// Example implementation:\n")
-	buf.WriteString("\t//   SessionHooks: []SessionHook[MySession]{\n")
-	buf.WriteString("\t//     // Logging hook\n")
-	buf.WriteString("\t//     func(ctx context.Context, reqCtx RequestContext, session MySession) error {\n")
-	buf.WriteString("\t//       log.Printf(\"User %s accessed %s %s\", session.UserID, reqCtx.HTTPMethod, reqCtx.Path)\n")
-	buf.WriteString("\t//       return nil\n")
-	buf.WriteString("\t//     },\n")
-	buf.WriteString("\t//     // Rate limiting hook\n")
-	buf.WriteString("\t//     func(ctx context.Context, reqCtx RequestContext, session MySession) error {\n")
-	buf.WriteString("\t//       if rateLimiter.IsExceeded(session.UserID) {\n")
-	buf.WriteString("\t//         return errors.New(\"rate limit exceeded\")\n")
-	buf.WriteString("\t//       }\n")
-	buf.WriteString("\t//       return nil\n")
-	buf.WriteString("\t//     },\n")
-	buf.WriteString("\t//     // Authorization hook\n")
-	buf.WriteString("\t//     func(ctx context.Context, reqCtx RequestContext, session MySession) error {\n")
-	buf.WriteString("\t//       if !session.HasPermission(reqCtx.Route) {\n")
-	buf.WriteString("\t//         return errors.New(\"insufficient permissions\")\n")
-	buf.WriteString("\t//       }\n")
-	buf.WriteString("\t//       return nil\n")
-	buf.WriteString("\t//     },\n")
-	buf.WriteString("\t//   },\n")
-	buf.WriteString("\tSessionHooks []SessionHook[Session]\n")
-	buf.WriteString("}\n\n")
+	data, err := json.Marshal(response)
+	if err == nil {
+		err = validator.validateResponse(data)
+	}
+	if err == nil {
+		return
+	}
 
-	for _, resource := range service.Resources {
-		buf.WriteString(fmt.Sprintf("type %sAPI[Session any] interface {\n", resource.Name))
-		for _, endpoint := range resource.Endpoints {
-			if endpoint.HasResponseType() {
-				buf.WriteString(fmt.Sprintf("\t%s(ctx context.Context, request Request[Session, %s, %s, %s]) (*%s, error)\n",
-					endpoint.Name,
-					endpoint.GetPathParamsType(resource.Name),
-					endpoint.GetQueryParamsType(resource.Name),
-					endpoint.GetBodyParamsType(resource.Name),
-					endpoint.GetResponseType(resource.Name),
-				))
-			} else {
-				buf.WriteString(fmt.Sprintf("\t%s(ctx context.Context, request Request[Session, %s, %s, %s]) error\n",
-					endpoint.Name,
-					endpoint.GetPathParamsType(resource.Name),
-					endpoint.GetQueryParamsType(resource.Name),
-					endpoint.GetBodyParamsType(resource.Name),
-				))
+	err = fmt.Errorf("invalid response of %s %s: %w", requestContext.HTTPMethod, requestContext.Path, err)
+	if hook == nil {
+		panic(err)
+	}
+	hook(ctx, requestContext, err)
+}` + "\n\n")
+
+	buf.WriteString(`// responseFieldRule is the validation of a field of a response by its JSON name, validate is nil for values that
+// are not validated
+type responseFieldRule struct {
+	name     string
+	required bool
+	validate func([]byte) error
+}` + "\n\n")
+
+	buf.WriteString(`// validateResponseFields validates the fields of the JSON object in data against the rules, in order
+func validateResponseFields(data []byte, rules []responseFieldRule) error {
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(data, &values); err != nil || values == nil {
+		return nil
+	}
+
+	for _, rule := range rules {
+		value, ok := values[rule.name]
+		if !ok || string(value) == "null" {
+			if rule.required {
+				return fmt.Errorf("%s: required field is not set", rule.name)
 			}
+			continue
+		}
+
+		if rule.validate != nil {
+			if err := rule.validate(value); err != nil {
+				return fmt.Errorf("%s: %w", rule.name, err)
+			}
+		}
+	}
+
+	return nil
+}` + "\n\n")
+
+	buf.WriteString(`// validateResponseArray validates every item of the JSON array in data
+func validateResponseArray(data []byte, validate func([]byte) error) error {
+	var items []json.RawMessage
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil
+	}
+
+	for i, item := range items {
+		if err := validate(item); err != nil {
+			return fmt.Errorf("%d: %w", i, err)
 		}
-		buf.WriteString("}\n\n")
 	}
 
 	return nil
+}` + "\n\n")
+
+	buf.WriteString(`// validateEnumValue returns the validation of a string enum with the values
+func validateEnumValue(values ...string) func([]byte) error {
+	return func(data []byte) error {
+		var value *string
+		if err := json.Unmarshal(data, &value); err != nil || value == nil {
+			return err
+		}
+
+		for _, v := range values {
+			if *value == v {
+				return nil
+			}
+		}
+
+		return fmt.Errorf("%q is not a value of the enum", *value)
+	}
+}` + "\n\n")
+
+	buf.WriteString(`// validateIntEnumValue returns the validation of an integer enum with the values
+func validateIntEnumValue(values ...int64) func([]byte) error {
+	return func(data []byte) error {
+		var value *int64
+		if err := json.Unmarshal(data, &value); err != nil || value == nil {
+			return err
+		}
+
+		for _, v := range values {
+			if *value == v {
+				return nil
+			}
+		}
+
+		return fmt.Errorf("%d is not a value of the enum", *value)
+	}
+}` + "\n\n")
 }
 
-func generateRequestTypes(buf *bytes.Buffer, service *specification.Service) error {
-	// Generate ErrorHook type
-	buf.WriteString("// ErrorHook converts application errors into API Error responses.\n")
-	buf.WriteString("// This hook is called whenever an error occurs during request processing,\n")
-	buf.WriteString("// allowing you to customize error formatting, logging, and HTTP status codes.\n")
-	buf.WriteString("type ErrorHook[Session any] func(ctx context.Context, requestContext RequestContext, session *Session, err error) *Error\n\n")
+// generateStrictDecoding generates the decoding of the body params for a service with strict objects, which checks
+// the unknown fields of the body before decoding it. The check is separate from the decoding, since
+// DisallowUnknownFields would apply to the lenient objects nested in a strict one as well.
+func generateStrictDecoding(buf *bytes.Buffer) {
+	buf.WriteString(`// unknownFieldsChecker is implemented by the request types and objects, to reject the unknown fields of the strict ones
+type unknownFieldsChecker interface {
+	checkUnknownFields(data []byte) error
+}` + "\n\n")
 
-	// Generate PreHook types
-	buf.WriteString("// PreHook runs before endpoint logic. Return nil to continue; non-nil to abort.\n")
-	buf.WriteString("type PreHook func(ctx context.Context, requestContext RequestContext) error\n\n")
+	buf.WriteString(`// checkUnknownFields checks the fields of the JSON object in data, where fields maps the known fields to the check
+// of their values, nil for fields that are not objects. Unknown fields are an error if strict is true.
+// Values that are not JSON objects are left to the decoding to report.
+func checkUnknownFields(data []byte, strict bool, fields map[string]func([]byte) error) error {
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil
+	}
+
+	for name, value := range values {
+		check, ok := fields[name]
+		if !ok {
+			if strict {
+				return fmt.Errorf("json: unknown field %q", name)
+			}
+			continue
+		}
+
+		if check != nil {
+			if err := check(value); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}` + "\n\n")
+
+	buf.WriteString(`// checkUnknownFieldsArray checks the unknown fields of every object of the JSON array in data
+func checkUnknownFieldsArray(data []byte, check func([]byte) error) error {
+	var items []json.RawMessage
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil
+	}
+
+	for _, item := range items {
+		if err := check(item); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}` + "\n\n")
+
+	buf.WriteString(`func decodeBodyParams[T any](r *http.Request) (T, error) {
+	var v T
+	setDefaults(&v)
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		return v, err
+	}
+
+	if checker, ok := any(v).(unknownFieldsChecker); ok {
+		if err := checker.checkUnknownFields(data); err != nil {
+			return v, err
+		}
+	}
+
+	if err := json.Unmarshal(data, &v); err != nil {
+		return v, err
+	}
+
+	return v, nil
+}` + "\n\n")
+}
+
+// getStructTag returns the struct tag of a field of the objects, body params and response types, with an xml tag
+// of the same name as the json tag when the service serializes any body as XML.
+func getStructTag(field specification.Field, service *specification.Service) string {
+	return getFieldTag(field, service.HasXML())
+}
+
+// getHeaderFieldTag returns the struct tag of the response header fields of a response type, which are not encoded
+// in the body, with an xml tag next to the json tag when xml is true.
+func getHeaderFieldTag(xml bool) string {
+	if xml {
+		return "`json:\"-\" xml:\"-\"`"
+	}
+	return "`json:\"-\"`"
+}
+
+// getFieldTag returns the struct tag of the field, with an xml tag next to the json tag when xml is true.
+func getFieldTag(field specification.Field, xml bool) string {
+	if xml {
+		return fmt.Sprintf("`json:\"%s\" xml:\"%s\"`", field.TagJSON(), field.TagJSON())
+	}
+	return fmt.Sprintf("`json:\"%s\"`", field.TagJSON())
+}
+
+func getTypeForGo(field specification.Field, service *specification.Service, types Types) string {
+	fieldType := service.GetPrimitiveType(field.Type)
+
+	if fieldType == specification.FieldTypeDecimal {
+		return getDecimalTypeForGo(field)
+	}
+
+	if id := types.idType(field); id != "" {
+		return getIDTypePrefix(field, types.isPointer(field)) + id
+	}
+
+	if !service.IsObject(fieldType) {
+		fieldType = types.typeName(fieldType)
+	}
+
+	return getTypePrefix(field, service, types) + fieldType
+}
+
+// getTypeForGoFilter generates Go type for fields in filter objects with special pointer handling.
+func getTypeForGoFilter(field specification.Field, service *specification.Service, parentObject specification.Object, types Types) string {
+	fieldType := service.GetPrimitiveType(field.Type)
+
+	if fieldType == specification.FieldTypeDecimal {
+		return getDecimalTypeForGo(field)
+	}
+
+	if id := types.idType(field); id != "" {
+		return getIDTypePrefix(field, types.Stdlib && !field.IsArray()) + id
+	}
+
+	if !service.IsObject(fieldType) {
+		fieldType = types.typeName(fieldType)
+	}
+
+	return getTypePrefixForFilter(field, service, parentObject, types) + fieldType
+}
+
+// generateIDTypes generates the ID types of the resources referenced by the fields of the service, see Types.TypedIDs.
+// The UUID is embedded, so that the ID types are encoded like it and have its methods, e.g. String.
+func generateIDTypes(buf *bytes.Buffer, service *specification.Service, types Types) {
+	if !types.TypedIDs {
+		return
+	}
+
+	for _, resource := range service.GetReferencedResources() {
+		buf.WriteString(fmt.Sprintf("// %sID is the ID of a %s, which cannot be used as the ID of another resource\n", resource, resource))
+		buf.WriteString(fmt.Sprintf("type %sID struct {\n", resource))
+		buf.WriteString(fmt.Sprintf("\t%s%s\n", types.prefix(false), types.typeName(specification.FieldTypeUUID)))
+		buf.WriteString("}\n\n")
+	}
+}
+
+// getIDTypePrefix returns the prefix of the Go type of a field declared with an ID type, which is declared by the
+// server instead of the types package.
+func getIDTypePrefix(field specification.Field, pointer bool) string {
+	if field.IsArray() {
+		return "[]"
+	}
+	if pointer {
+		return "*"
+	}
+	return ""
+}
+
+// getDecimalTypeForGo generates the Go type for decimal fields, which use shopspring/decimal
+// instead of go-types to keep arbitrary precision. Nullable decimals use decimal.NullDecimal.
+func getDecimalTypeForGo(field specification.Field) string {
+	goType := "decimal.Decimal"
+	if field.IsNullable() {
+		goType = "decimal.NullDecimal"
+	}
+
+	if field.IsArray() {
+		return "[]" + goType
+	}
+
+	return goType
+}
+
+func getTypePrefix(field specification.Field, service *specification.Service, types Types) string {
+	isObject := service.IsObject(field.Type)
+
+	prefixes := []string{}
+
+	if field.IsArray() {
+		prefixes = append(prefixes, "[]")
+	}
+
+	// Note: Removed pointer prefix for nullable objects as per INF-407
+	// if field.IsNullable() && isObject {
+	//     prefixes = append(prefixes, "*")
+	// }
+
+	// Objects that are optional for the operation (PATCH semantics) are pointers, so an absent object can be told apart
+	if field.IsOptional() && isObject && !field.IsArray() {
+		prefixes = append(prefixes, "*")
+	}
+
+	if !isObject {
+		prefixes = append(prefixes, types.prefix(types.isPointer(field)))
+	}
+
+	typePrefix := strings.Join(prefixes, "")
+
+	return typePrefix
+}
+
+// getTypePrefixForFilter handles filter objects specifically.
+// Filter type fields (Equals, NotEquals, etc.) should be pointers.
+// Nested filter object fields should NOT be pointers.
+// With standard library types, the scalar fields are always pointers, so that an unset filter can be told apart.
+func getTypePrefixForFilter(field specification.Field, service *specification.Service, parentObject specification.Object, types Types) string {
+	isObject := service.IsObject(field.Type)
+
+	prefixes := []string{}
+
+	if field.IsArray() {
+		prefixes = append(prefixes, "[]")
+	}
+
+	// Special logic for filter objects
+	if parentObject.IsFilter() {
+		// Check if this is a filter type field (Equals, NotEquals, etc.)
+		if isFilterTypeField(field.Name) && field.IsNullable() && isObject {
+			prefixes = append(prefixes, "*")
+		}
+		// For nested filter object fields (like Meta, External), don't add pointer
+		// even if they're nullable - this follows the user's requirement
+	}
+
+	if !isObject {
+		prefixes = append(prefixes, types.prefix(!field.IsArray()))
+	}
+
+	typePrefix := strings.Join(prefixes, "")
+
+	return typePrefix
+}
+
+// isFilterTypeField checks if a field name is one of the standard filter type fields.
+func isFilterTypeField(fieldName string) bool {
+	filterFields := []string{
+		"Equals", "NotEquals", "GreaterThan", "SmallerThan",
+		"GreaterOrEqual", "SmallerOrEqual", "Contains", "NotContains",
+		"Like", "NotLike", "StartsWith", "NotStartsWith", "Null", "NotNull",
+	}
+
+	for _, filterField := range filterFields {
+		if fieldName == filterField {
+			return true
+		}
+	}
+	return false
+}
+
+// getDefaultValueForGo returns the Go expression of the default value of a field,
+// or false if the field has no default that can be applied.
+func getDefaultValueForGo(field specification.Field, service *specification.Service, types Types) (string, bool) {
+	if field.Default == "" || field.IsArray() || service.IsObject(field.Type) {
+		return "", false
+	}
+
+	// The default of an enum field is a wire value, of the type the enum is backed by
+	field.Type = service.GetPrimitiveType(field.Type)
+
+	return types.Literal(field, field.Default)
+}
+
+// fieldsHaveDefaults returns true if any of the fields, or the fields of a nested object, has a default value.
+func fieldsHaveDefaults(fields []specification.Field, service *specification.Service, types Types, visited map[string]bool) bool {
+	for _, field := range fields {
+		if _, ok := getDefaultValueForGo(field, service, types); ok {
+			return true
+		}
+		if hasNestedDefaults(field) && objectHasDefaults(field.Type, service, types, visited) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasNestedDefaults returns false for the fields whose nested object defaults cannot be set before decoding:
+// arrays get new elements when decoded, and optional objects are nil pointers.
+func hasNestedDefaults(field specification.Field) bool {
+	return !field.IsArray() && !field.IsOptional()
+}
+
+// objectHasDefaults returns true if the object with the given name gets a setDefaults method.
+func objectHasDefaults(objectName string, service *specification.Service, types Types, visited map[string]bool) bool {
+	object := service.GetObject(objectName)
+	if object == nil || object.IsFilter() || visited[objectName] {
+		return false
+	}
+	visited[objectName] = true
+
+	return fieldsHaveDefaults(object.Fields, service, types, visited)
+}
+
+// isImportedObject reports whether the object is imported from the package of its shared library. The server cannot
+// declare methods on the objects of another package, so their helpers are functions named by the object instead.
+func isImportedObject(name string, service *specification.Service, types Types) bool {
+	object := service.GetObject(name)
+	return object != nil && types.sharedPackage(object.Library) != ""
+}
+
+// getImportedObjects returns the names of the imported objects that can be responses, which are all but the filter
+// objects, keeping only the names in only when it is not nil.
+func getImportedObjects(service *specification.Service, types Types, only map[string]bool) []string {
+	var names []string
+	for _, object := range service.Objects {
+		if object.IsFilter() || types.sharedPackage(object.Library) == "" || (only != nil && !only[object.Name]) {
+			continue
+		}
+		names = append(names, object.Name)
+	}
+	return names
+}
+
+// getObjectMethodValue returns the helper method of the object as a function value, e.g. Address{}.validateResponse,
+// or the function generated for an imported object, e.g. validateResponseAddress.
+func getObjectMethodValue(typeName, method string, service *specification.Service, types Types) string {
+	if isImportedObject(typeName, service, types) {
+		return method + typeName
+	}
+	return typeName + "{}." + method
+}
+
+// getObjectMethodCall returns the call of the helper method of the object on the addressable receiver, e.g.
+// v.Address.setDefaults(), or of the function generated for an imported object, e.g. setDefaultsAddress(&v.Address).
+func getObjectMethodCall(typeName, method, receiver, args string, service *specification.Service, types Types) string {
+	if isImportedObject(typeName, service, types) {
+		if args != "" {
+			args = ", " + args
+		}
+		return fmt.Sprintf("%s%s(&%s%s)", method, typeName, receiver, args)
+	}
+	return fmt.Sprintf("%s.%s(%s)", receiver, method, args)
+}
+
+// generateSetDefaults generates a setDefaults method for the type, which sets the default values of the fields
+// and calls setDefaults on the embedded types and nested objects that have defaults. The request is decoded
+// on top of the defaults, so only the fields absent from the request keep them. Nothing is generated without defaults.
+func generateSetDefaults(buf *bytes.Buffer, typeName string, embedded []string, fields []specification.Field, service *specification.Service, types Types) {
+	lines := []string{}
+	for _, embeddedType := range embedded {
+		lines = append(lines, "\t"+getObjectMethodCall(embeddedType, "setDefaults", "v."+embeddedType, "", service, types)+"\n")
+	}
+
+	for _, field := range fields {
+		if value, ok := getDefaultValueForGo(field, service, types); ok {
+			lines = append(lines, fmt.Sprintf("\tv.%s = %s\n", field.GetGoName(), value))
+			continue
+		}
+
+		if hasNestedDefaults(field) && objectHasDefaults(field.Type, service, types, map[string]bool{}) {
+			lines = append(lines, "\t"+getObjectMethodCall(field.Type, "setDefaults", "v."+field.GetGoName(), "", service, types)+"\n")
+		}
+	}
+
+	if len(lines) == 0 {
+		return
+	}
+
+	if isImportedObject(typeName, service, types) {
+		buf.WriteString(fmt.Sprintf("func setDefaults%s(v *%s) {\n", typeName, typeName))
+	} else {
+		buf.WriteString(fmt.Sprintf("func (v *%s) setDefaults() {\n", typeName))
+	}
+	for _, line := range lines {
+		buf.WriteString(line)
+	}
+	buf.WriteString("}\n\n")
+}
+
+// generateParsePathParams generates the parsePathParams method of the path parameter type, which parses the parameters
+// into their types directly instead of through JSON. Nothing is generated if a parameter cannot be parsed directly.
+func generateParsePathParams(buf *bytes.Buffer, typeName string, fields []specification.Field, service *specification.Service, types Types) {
+	var body strings.Builder
+	for _, field := range fields {
+		parse, ok := types.parseParam(field, service, "v."+field.GetGoName()+" = %s", "\t\t")
+		if !ok || field.IsArray() {
+			return
+		}
+
+		body.WriteString(fmt.Sprintf("\tif value, ok := params.Get(%q); ok {\n", field.TagJSON()))
+		body.WriteString(parse)
+		body.WriteString("\t}\n")
+	}
+
+	buf.WriteString(fmt.Sprintf("func (v *%s) parsePathParams(params gin.Params) error {\n", typeName))
+	buf.WriteString(body.String())
+	buf.WriteString("\n\treturn nil\n")
+	buf.WriteString("}\n\n")
+}
+
+// generateParseQueryParams generates the parseQueryParams method of the query parameter type, which parses the
+// parameters, including the ones of the embedded parameter groups, into their types directly instead of through the
+// form binding of gin. Like the form binding, absent parameters keep their defaults and a scalar takes the first value.
+// Nothing is generated if a parameter cannot be parsed directly. With clampLimit, see ClampsLimit, the limit is clamped
+// to MaxLimit, so the handler never gets a larger limit than the OpenAPI document allows.
+func generateParseQueryParams(buf *bytes.Buffer, typeName string, fields []specification.Field, clampLimit bool, service *specification.Service, types Types) {
+	clampedLimit := ""
+	if clampLimit {
+		clampedLimit = paginationLimitFieldName
+	}
+	generateParseValues(buf, typeName, "parseQueryParams", "query", fields, clampedLimit, service, types)
+}
+
+// generateParseForm generates the parseForm method of the body params type of an endpoint with form requests, which
+// parses the values of the form like generateParseQueryParams parses the query parameters.
+func generateParseForm(buf *bytes.Buffer, typeName string, fields []specification.Field, service *specification.Service, types Types) {
+	generateParseValues(buf, typeName, "parseForm", "form", fields, "", service, types)
+}
+
+// generateParseValues generates the method of the type parsing the url.Values named valuesName into the fields,
+// clamping the parsed value of the integer field named clampedLimit to MaxLimit.
+func generateParseValues(buf *bytes.Buffer, typeName, methodName, valuesName string, fields []specification.Field, clampedLimit string, service *specification.Service, types Types) {
+	var body strings.Builder
+	for _, field := range fields {
+		if field.IsArray() {
+			parse, ok := types.parseParam(field, service, "v."+field.GetGoName()+" = append(v."+field.GetGoName()+", %s)", "\t\t\t")
+			if !ok {
+				return
+			}
+
+			body.WriteString(fmt.Sprintf("\tif values := %s[%q]; len(values) > 0 {\n", valuesName, field.TagJSON()))
+			body.WriteString(fmt.Sprintf("\t\tv.%s = make(%s, 0, len(values))\n", field.GetGoName(), getTypeForGo(field, service, types)))
+			body.WriteString("\t\tfor _, value := range values {\n")
+			body.WriteString(parse)
+			body.WriteString("\t\t}\n")
+			body.WriteString("\t}\n")
+			continue
+		}
+
+		assign := "v." + field.GetGoName() + " = %s"
+		if field.Name == clampedLimit && field.Type == specification.FieldTypeInt && !types.isPointer(field) {
+			assign = "parsed = min(parsed, MaxLimit)\n\t\t" + assign
+		}
+
+		parse, ok := types.parseParam(field, service, assign, "\t\t")
+		if !ok {
+			return
+		}
+
+		body.WriteString(fmt.Sprintf("\tif values := %s[%q]; len(values) > 0 {\n", valuesName, field.TagJSON()))
+		body.WriteString("\t\tvalue := values[0]\n")
+		body.WriteString(parse)
+		body.WriteString("\t}\n")
+	}
+
+	buf.WriteString(fmt.Sprintf("func (v *%s) %s(%s url.Values) error {\n", typeName, methodName, valuesName))
+	buf.WriteString(body.String())
+	buf.WriteString("\n\treturn nil\n")
+	buf.WriteString("}\n\n")
+}
+
+// generateCheckUnknownFields generates the checkUnknownFields method of a type, which returns an error for the fields
+// of the JSON object that are not fields of the type if it is strict, and checks the values of the fields of object types.
+func generateCheckUnknownFields(buf *bytes.Buffer, typeName string, strict bool, fields []specification.Field, service *specification.Service, types Types) {
+	if isImportedObject(typeName, service, types) {
+		buf.WriteString(fmt.Sprintf("func checkUnknownFields%s(data []byte) error {\n", typeName))
+	} else {
+		buf.WriteString(fmt.Sprintf("func (%s) checkUnknownFields(data []byte) error {\n", typeName))
+	}
+	buf.WriteString(fmt.Sprintf("\treturn checkUnknownFields(data, %t, map[string]func([]byte) error{\n", strict))
+	seen := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		// A JSON name can only be a key of the map once
+		if seen[field.TagJSON()] {
+			continue
+		}
+		seen[field.TagJSON()] = true
+
+		check := "nil"
+		if service.IsObject(field.Type) {
+			check = getObjectMethodValue(field.Type, "checkUnknownFields", service, types)
+			if field.IsArray() {
+				check = fmt.Sprintf("func(data []byte) error { return checkUnknownFieldsArray(data, %s) }", check)
+			}
+		}
+		buf.WriteString(fmt.Sprintf("\t\t%q: %s,\n", field.TagJSON(), check))
+	}
+	buf.WriteString("\t})\n")
+	buf.WriteString("}\n\n")
+}
+
+// generateValidateResponse generates the validateResponse method of a type, which returns an error for the required
+// fields that are not set and the enum fields holding values that are not values of their enums.
+func generateValidateResponse(buf *bytes.Buffer, typeName string, fields []specification.Field, service *specification.Service, types Types) {
+	if isImportedObject(typeName, service, types) {
+		buf.WriteString(fmt.Sprintf("func validateResponse%s(data []byte) error {\n", typeName))
+	} else {
+		buf.WriteString(fmt.Sprintf("func (%s) validateResponse(data []byte) error {\n", typeName))
+	}
+	buf.WriteString("\treturn validateResponseFields(data, []responseFieldRule{\n")
+	seen := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		// A JSON name is only validated once
+		if seen[field.TagJSON()] {
+			continue
+		}
+		seen[field.TagJSON()] = true
+
+		required := field.IsRequired(service) && !field.IsNullable()
+		validate := getResponseFieldValidator(field, service, types)
+		if !required && validate == "" {
+			continue
+		}
+
+		if validate == "" {
+			validate = "nil"
+		} else if field.IsArray() {
+			validate = fmt.Sprintf("func(data []byte) error { return validateResponseArray(data, %s) }", validate)
+		}
+		buf.WriteString(fmt.Sprintf("\t\t{name: %q, required: %t, validate: %s},\n", field.TagJSON(), required, validate))
+	}
+	buf.WriteString("\t})\n")
+	buf.WriteString("}\n\n")
+}
+
+// getResponseFieldValidator returns the validation of the value of a field in a response, or an empty string if its
+// value is not validated.
+func getResponseFieldValidator(field specification.Field, service *specification.Service, types Types) string {
+	if service.IsObject(field.Type) {
+		return getObjectMethodValue(field.Type, "validateResponse", service, types)
+	}
+
+	enum := service.GetEnum(field.Type)
+	if enum == nil {
+		return ""
+	}
+
+	values := make([]string, 0, len(enum.Values))
+	for _, value := range enum.Values {
+		if enum.IsInt() {
+			values = append(values, value.GetValue())
+		} else {
+			values = append(values, fmt.Sprintf("%q", value.GetValue()))
+		}
+	}
+
+	if enum.IsInt() {
+		return fmt.Sprintf("validateIntEnumValue(%s)", strings.Join(values, ", "))
+	}
+	return fmt.Sprintf("validateEnumValue(%s)", strings.Join(values, ", "))
+}
+
+// getRedactedObjects returns the names of the objects with fields that require scopes, directly or in the objects
+// they hold, which are redacted before they are written.
+func getRedactedObjects(service *specification.Service) map[string]bool {
+	redacted := map[string]bool{}
+	for changed := true; changed; {
+		changed = false
+		for _, object := range service.Objects {
+			if redacted[object.Name] || object.IsFilter() {
+				continue
+			}
+			if hasRedactedFields(object.Fields, redacted) {
+				redacted[object.Name] = true
+				changed = true
+			}
+		}
+	}
+	return redacted
+}
+
+// hasRedactedFields returns true if any of the fields requires scopes or holds a redacted object.
+func hasRedactedFields(fields []specification.Field, redactedObjects map[string]bool) bool {
+	return slices.ContainsFunc(fields, func(field specification.Field) bool {
+		return len(field.Scopes) > 0 || redactedObjects[field.Type]
+	})
+}
+
+// generateRedact generates the redact method of a type, which sets the fields requiring scopes the session has not
+// been granted to null and redacts the embedded types and the objects held by the fields.
+func generateRedact(buf *bytes.Buffer, typeName string, embedded []string, fields []specification.Field, redactedObjects map[string]bool, service *specification.Service, types Types) {
+	if isImportedObject(typeName, service, types) {
+		buf.WriteString(fmt.Sprintf("func redact%s(v *%s, hasScope func(scope string) bool) {\n", typeName, typeName))
+	} else {
+		buf.WriteString(fmt.Sprintf("func (v *%s) redact(hasScope func(scope string) bool) {\n", typeName))
+	}
+	buf.WriteString("\tif v == nil {\n")
+	buf.WriteString("\t\treturn\n")
+	buf.WriteString("\t}\n\n")
+	for _, embeddedType := range embedded {
+		buf.WriteString("\t" + getObjectMethodCall(embeddedType, "redact", "v."+embeddedType, "hasScope", service, types) + "\n")
+	}
+
+	for _, field := range fields {
+		if len(field.Scopes) > 0 {
+			conditions := make([]string, len(field.Scopes))
+			for i, scope := range field.Scopes {
+				conditions[i] = fmt.Sprintf("!hasScope(%q)", scope)
+			}
+			buf.WriteString(fmt.Sprintf("\tif %s {\n", strings.Join(conditions, " || ")))
+			buf.WriteString(fmt.Sprintf("\t\tredactField(&v.%s)\n", field.GetGoName()))
+			buf.WriteString("\t}\n")
+		}
+
+		if !redactedObjects[field.Type] {
+			continue
+		}
+		if field.IsArray() {
+			buf.WriteString(fmt.Sprintf("\tfor i := range v.%s {\n", field.GetGoName()))
+			buf.WriteString("\t\t" + getObjectMethodCall(field.Type, "redact", "v."+field.GetGoName()+"[i]", "hasScope", service, types) + "\n")
+			buf.WriteString("\t}\n")
+		} else {
+			buf.WriteString("\t" + getObjectMethodCall(field.Type, "redact", "v."+field.GetGoName(), "hasScope", service, types) + "\n")
+		}
+	}
+	buf.WriteString("}\n\n")
+}
+
+func generateObjects(buf *bytes.Buffer, service *specification.Service, types Types) error {
+	csvObjects := getCSVObjects(service)
+	redactedObjects := getRedactedObjects(service)
+
+	generateIDTypes(buf, service, types)
+
+	for _, object := range service.Objects {
+		// The fields inherited from the parent object are promoted from the embedded parent
+		fields := object.Fields
+		if object.HasParent() && service.HasObject(object.Extends) {
+			fields = object.GetOwnFields(service)
+		}
+
+		// The objects of the shared packages are aliased, and only get the helpers of the server
+		if pkg := types.sharedPackage(object.Library); pkg != "" {
+			buf.WriteString(fmt.Sprintf("%s\n", object.GetComment()))
+			buf.WriteString(fmt.Sprintf("type %s = %s.%s\n\n", object.Name, pkg, object.Name))
+		} else {
+			generateObjectStruct(buf, object, fields, service, types, service.HasXML())
+		}
+
+		// Every object checks its fields, since a strict object can be nested in any of them
+		if service.HasStrictObjects() {
+			generateCheckUnknownFields(buf, object.Name, service.IsStrict(object), object.Fields, service, types)
+		}
+
+		// Filter objects are never part of a response
+		if !object.IsFilter() {
+			generateValidateResponse(buf, object.Name, object.Fields, service, types)
+		}
+
+		// Filter fields must stay unset unless the client sends them, so they never get defaults
+		if !object.IsFilter() {
+			parents := []string{}
+			if object.HasParent() && objectHasDefaults(object.Extends, service, types, map[string]bool{}) {
+				parents = append(parents, object.Extends)
+			}
+			generateSetDefaults(buf, object.Name, parents, fields, service, types)
+		}
+
+		// The objects with fields that require scopes are redacted before they are written
+		if redactedObjects[object.Name] {
+			parents := []string{}
+			if object.HasParent() && redactedObjects[object.Extends] {
+				parents = append(parents, object.Extends)
+			}
+			generateRedact(buf, object.Name, parents, fields, redactedObjects, service, types)
+		}
+
+		// The objects of the rows of CSV responses are written as records
+		if slices.Contains(csvObjects, object.Name) {
+			generateCSVRecord(buf, object, service)
+		}
+
+		if object.Name == "Error" {
+			buf.WriteString("func (e *Error) Error() string {\n")
+			buf.WriteString(fmt.Sprintf("\treturn %s\n", types.StringValue("e."+service.GetErrorMessageFieldName())))
+			buf.WriteString("}\n\n")
+
+			buf.WriteString("// WithCause sets the error this error was created from, so that it can be inspected\n")
+			buf.WriteString("// with errors.Is and errors.As, e.g. for logging in the ErrorHook. The cause is not sent to the client.\n")
+			buf.WriteString("func (e *Error) WithCause(cause error) *Error {\n")
+			buf.WriteString("\te.cause = cause\n")
+			buf.WriteString("\treturn e\n")
+			buf.WriteString("}\n\n")
+
+			buf.WriteString("// Unwrap returns the error this error was created from, if any.\n")
+			buf.WriteString("func (e *Error) Unwrap() error {\n")
+			buf.WriteString("\treturn e.cause\n")
+			buf.WriteString("}\n\n")
+
+			buf.WriteString("func (e *Error) HTTPStatusCode() int {\n")
+			buf.WriteString("\tswitch e.Code {\n")
+			buf.WriteString("\tcase ErrorCodeBadRequest:\n")
+			buf.WriteString("\t\treturn http.StatusBadRequest\n")
+			buf.WriteString("\tcase ErrorCodeUnauthorized:\n")
+			buf.WriteString("\t\treturn http.StatusUnauthorized\n")
+			buf.WriteString("\tcase ErrorCodeForbidden:\n")
+			buf.WriteString("\t\treturn http.StatusForbidden\n")
+			buf.WriteString("\tcase ErrorCodeNotFound:\n")
+			buf.WriteString("\t\treturn http.StatusNotFound\n")
+			buf.WriteString("\tcase ErrorCodeConflict:\n")
+			buf.WriteString("\t\treturn http.StatusConflict\n")
+			buf.WriteString("\tcase ErrorCodeUnprocessableEntity:\n")
+			buf.WriteString("\t\treturn http.StatusUnprocessableEntity\n")
+			buf.WriteString("\tcase ErrorCodeRateLimited:\n")
+			buf.WriteString("\t\treturn http.StatusTooManyRequests\n")
+			buf.WriteString("\tcase ErrorCodeInternal:\n")
+			buf.WriteString("\t\treturn http.StatusInternalServerError\n")
+			if service.HasHandlerTimeouts() {
+				buf.WriteString("\tcase ErrorCodeRequestTimeout:\n")
+				buf.WriteString("\t\treturn http.StatusRequestTimeout\n")
+			}
+			if service.HasBodyLimits() {
+				buf.WriteString("\tcase ErrorCodePayloadTooLarge:\n")
+				buf.WriteString("\t\treturn http.StatusRequestEntityTooLarge\n")
+			}
+			buf.WriteString("\tdefault:\n")
+			buf.WriteString("\t\treturn http.StatusInternalServerError\n")
+			buf.WriteString("\t}\n")
+			buf.WriteString("}\n\n")
+
+			if service.IsProblemDetails() {
+				generateProblemResponse(buf, object, service, types)
+				continue
+			}
+
+			buf.WriteString("func (e *Error) Response() (int, map[string]*Error) {\n")
+			if fieldErrors := getErrorFieldsField(object); fieldErrors != nil {
+				// Field errors are always an array in responses, also when there are none
+				buf.WriteString(fmt.Sprintf("\tif e.%s == nil {\n", fieldErrors.GetGoName()))
+				buf.WriteString(fmt.Sprintf("\t\te.%s = %s{}\n", fieldErrors.GetGoName(), getTypeForGo(*fieldErrors, service, types)))
+				buf.WriteString("\t}\n")
+			}
+			buf.WriteString("\treturn e.HTTPStatusCode(), map[string]*Error{\"error\": e}\n")
+			buf.WriteString("}\n\n")
+		}
+	}
+
+	return nil
+}
+
+// generateObjectStruct generates the struct of the object, embedding its parent object, so the inherited fields are
+// promoted and flattened in JSON. The fields are declared with xml tags next to their json tags when xml is true.
+func generateObjectStruct(buf *bytes.Buffer, object specification.Object, fields []specification.Field, service *specification.Service, types Types, xml bool) {
+	buf.WriteString(fmt.Sprintf("%s\n", object.GetComment()))
+	buf.WriteString(fmt.Sprintf("type %s struct {\n", object.Name))
+
+	if object.HasParent() && service.HasObject(object.Extends) {
+		buf.WriteString(fmt.Sprintf("\t%s\n\n", object.Extends))
+	}
+
+	for _, field := range fields {
+		buf.WriteString(fmt.Sprintf("%s\n", field.GetComment("\t")))
+
+		// Use filter-aware type generation for filter objects
+		var fieldType string
+		if object.IsFilter() {
+			fieldType = getTypeForGoFilter(field, service, object, types)
+		} else {
+			fieldType = getTypeForGo(field, service, types)
+		}
+
+		buf.WriteString(fmt.Sprintf("\t%s %s %s\n\n", field.GetGoName(), fieldType, getFieldTag(field, xml)))
+	}
+
+	if object.Name == "Error" {
+		buf.WriteString("\t// cause is the error this error was created from, returned by Unwrap\n")
+		buf.WriteString("\tcause error\n")
+	}
+
+	buf.WriteString("}\n\n")
+}
+
+// generateProblemResponse generates the Response method of the Error object with the problem+json error format,
+// which fills in the members of RFC 9457 derived from the code and returns the error itself as the response body.
+func generateProblemResponse(buf *bytes.Buffer, object specification.Object, service *specification.Service, types Types) {
+	buf.WriteString("func (e *Error) Response() (int, *Error) {\n")
+	buf.WriteString("\tstatus := e.HTTPStatusCode()\n")
+	if object.HasField("Type") {
+		buf.WriteString(fmt.Sprintf("\tif %s == \"\" {\n", types.StringValue("e.Type")))
+		buf.WriteString(fmt.Sprintf("\t\te.Type = %s\n", types.String(`"about:blank"`)))
+		buf.WriteString("\t}\n")
+	}
+	if object.HasField("Title") {
+		buf.WriteString(fmt.Sprintf("\tif %s == \"\" {\n", types.StringValue("e.Title")))
+		buf.WriteString(fmt.Sprintf("\t\te.Title = %s\n", types.String("http.StatusText(status)")))
+		buf.WriteString("\t}\n")
+	}
+	if object.HasField("Status") {
+		buf.WriteString(fmt.Sprintf("\te.Status = %s\n", types.Int("int64(status)")))
+	}
+	if fieldErrors := getErrorFieldsField(object); fieldErrors != nil {
+		// Field errors are always an array in responses, also when there are none
+		buf.WriteString(fmt.Sprintf("\tif e.%s == nil {\n", fieldErrors.GetGoName()))
+		buf.WriteString(fmt.Sprintf("\t\te.%s = %s{}\n", fieldErrors.GetGoName(), getTypeForGo(*fieldErrors, service, types)))
+		buf.WriteString("\t}\n")
+	}
+	buf.WriteString("\treturn status, e\n")
+	buf.WriteString("}\n\n")
+}
+
+// getErrorFieldsField returns the field of the Error object holding the field errors, or nil if it has none.
+func getErrorFieldsField(object specification.Object) *specification.Field {
+	for _, field := range object.Fields {
+		if field.Name == "Fields" && field.IsArray() {
+			return &field
+		}
+	}
+	return nil
+}
+
+func generateServer(buf *bytes.Buffer, service *specification.Service, types Types, templates templateSet) error {
+	if err := generateRegister(buf, service, types, templates); err != nil {
+		return err
+	}
+
+	for _, resource := range service.Resources {
+		generateResourceInterface(buf, resource)
+	}
+
+	return nil
+}
+
+// generateRegister generates the register function of the API and the API and server configuration types.
+func generateRegister(buf *bytes.Buffer, service *specification.Service, types Types, templates templateSet) error {
+	serviceName := strmangle.TitleCase(service.Name)
+	buf.WriteString(fmt.Sprintf("func Register%sAPI[Session any](router *gin.Engine, api *%sAPI[Session]) {\n", serviceName, serviceName))
+	if err := templates.execute(buf, TemplateErrorHook, templateData{Service: service, Types: types}); err != nil {
+		return err
+	}
+
+	buf.WriteString("\tif api.Server.GetSessionFunc == nil {\n")
+	buf.WriteString("\t\tpanic(\"GetSessionFunc is nil\")\n")
+	buf.WriteString("\t}\n\n")
+
+	if service.HasScopedEndpoints() {
+		buf.WriteString("\tif api.Server.CheckScopesFunc == nil {\n")
+		buf.WriteString("\t\tpanic(\"CheckScopesFunc is nil\")\n")
+		buf.WriteString("\t}\n\n")
+	}
+
+	if service.HasFeatureFlags() {
+		buf.WriteString("\tif api.Server.FeatureEnabledFunc == nil {\n")
+		buf.WriteString("\t\tpanic(\"FeatureEnabledFunc is nil\")\n")
+		buf.WriteString("\t}\n\n")
+	}
+
+	if service.HasScopedFields() {
+		buf.WriteString("\tif api.Server.HasScopeFunc == nil {\n")
+		buf.WriteString("\t\tpanic(\"HasScopeFunc is nil\")\n")
+		buf.WriteString("\t}\n\n")
+	}
+
+	for _, contentType := range service.GetAlternativeContentTypes() {
+		buf.WriteString(fmt.Sprintf("\tif api.Server.ResponseEncoders[%q] == nil {\n", contentType))
+		buf.WriteString(fmt.Sprintf("\t\tpanic(\"ResponseEncoders has no encoder for %s\")\n", contentType))
+		buf.WriteString("\t}\n\n")
+	}
+
+	buf.WriteString(fmt.Sprintf("\trouterGroup := router.Group(%q)\n\n", service.GetBasePath()))
+
+	buf.WriteString("\t// Request ID of every request, set on its response\n")
+	buf.WriteString("\trouterGroup.Use(propagateRequestID(api.Server.GetRequestIDFunc))\n\n")
+
+	buf.WriteString("\tif api.Server.Compression {\n")
+	buf.WriteString("\t\trouterGroup.Use(compressResponses)\n")
+	buf.WriteString("\t}\n\n")
+
+	buf.WriteString("\t// OpenAPI Documentation in JSON format\n")
+	buf.WriteString("\trouterGroup.StaticFileFS(\"/openapi.json\", \"openapi.json\", http.FS(api.OpenAPI_JSON))\n\n")
+
+	buf.WriteString("\t// HTML documentation page rendering the OpenAPI specification\n")
+	buf.WriteString("\tswitch api.Server.DocsUI {\n")
+	buf.WriteString("\tcase DocsUINone:\n")
+	buf.WriteString("\tcase DocsUISwagger, DocsUIRedoc:\n")
+	buf.WriteString("\t\trouterGroup.GET(\"/docs\", serveDocsUI(api.Server.DocsUI))\n")
+	buf.WriteString("\tdefault:\n")
+	buf.WriteString("\t\tpanic(\"unsupported DocsUI: \" + string(api.Server.DocsUI))\n")
+	buf.WriteString("\t}\n\n")
+
+	if service.Operational.HasEndpoints() {
+		buf.WriteString("\t// Operational endpoints, served without authentication\n")
+		if service.Operational.HasHealth() {
+			buf.WriteString(fmt.Sprintf("\trouterGroup.GET(\"%s\", serveCheck(api.Server.HealthCheckFunc))\n", specification.OperationalPathHealth))
+		}
+		if service.Operational.HasReadiness() {
+			buf.WriteString(fmt.Sprintf("\trouterGroup.GET(\"%s\", serveCheck(api.Server.ReadinessCheckFunc))\n", specification.OperationalPathReadiness))
+		}
+		if service.Operational.HasVersion() {
+			buf.WriteString(fmt.Sprintf("\trouterGroup.GET(\"%s\", serveVersion)\n", specification.OperationalPathVersion))
+		}
+		buf.WriteString("\n")
+	}
+
+	routes := ginRoutes{}
+	endpointInfos := &bytes.Buffer{}
+	for _, resource := range service.Resources {
+		for _, endpoint := range resource.Endpoints {
+			path, renamedParams := routes.add(service.Tenancy.GetPathPrefix() + service.GetEndpointPath(resource, endpoint))
+			endpointInfos.WriteString(fmt.Sprintf("\t%q: {Resource: %q, Operation: %q%s},\n",
+				endpoint.Method+" "+joinGinPaths(service.GetBasePath(), path),
+				resource.Name,
+				endpoint.Name,
+				getPermissionsValue(endpoint.GetRequiredPermissions(resource)),
+			))
+			scopesHandler := getRenamePathParamsHandler(renamedParams) + getLimitRequestHandler(service, endpoint) + getRequireScopesHandler(endpoint.GetRequiredScopes(resource)) + getRequireFeatureHandler(endpoint.FeatureFlag) + getOfferContentTypesHandler(endpoint.Response.AlternativeContentTypes) + getContentTypeHandler(endpoint) + getAuditHandler(resource, endpoint)
+			if endpoint.HasCSVResponse() {
+				buf.WriteString(fmt.Sprintf("\trouterGroup.%s(\"%s\", %sserveCSV(%d, api.Server, api.%s.%s, csvHeader%s, csvRecord%s, api.%sMiddlewares...))\n",
+					endpoint.Method,
+					path,
+					scopesHandler,
+					endpoint.Response.StatusCode,
+					resource.Name,
+					endpoint.Name,
+					*endpoint.Response.BodyObject,
+					*endpoint.Response.BodyObject,
+					resource.Name,
+				))
+			} else if endpoint.HasResponseType() {
+				buf.WriteString(fmt.Sprintf("\trouterGroup.%s(\"%s\", %sserveWithResponse(%d, api.Server, api.%s.%s, api.%sMiddlewares...))\n",
+					endpoint.Method,
+					path,
+					scopesHandler,
+					endpoint.Response.StatusCode,
+					resource.Name,
+					endpoint.Name,
+					resource.Name,
+				))
+			} else {
+				buf.WriteString(fmt.Sprintf("\trouterGroup.%s(\"%s\", %sserveWithoutResponse(%d, api.Server, api.%s.%s, api.%sMiddlewares...))\n",
+					endpoint.Method,
+					path,
+					scopesHandler,
+					endpoint.Response.StatusCode,
+					resource.Name,
+					endpoint.Name,
+					resource.Name,
+				))
+			}
+		}
+		buf.WriteString("\n")
+	}
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// endpointInfos are the endpoints of the API by HTTP method and route, see getEndpointInfo\n")
+	buf.WriteString("var endpointInfos = map[string]EndpointInfo{\n")
+	buf.WriteString(endpointInfos.String())
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// getSessionFunc is a function that is used on each endpoint to set the session to the request\n")
+	buf.WriteString("type getSessionFunc[T any] func(ctx context.Context, headers http.Header) (T, error)\n\n")
+
+	buf.WriteString(fmt.Sprintf("type %sAPI[Session any] struct {\n", serviceName))
+	buf.WriteString("\t// Server is the server configuration for the API\n")
+	buf.WriteString("\tServer Server[Session]\n")
+
+	buf.WriteString("\tOpenAPI_JSON embed.FS\n")
+	for _, resource := range service.Resources {
+		buf.WriteString(fmt.Sprintf("\t%s %sAPI[Session] // Endpoints for the %s resource\n", resource.Name, resource.Name, resource.Name))
+	}
+	for _, resource := range service.Resources {
+		buf.WriteString(fmt.Sprintf("\t%sMiddlewares []Middleware[Session] // Middlewares of the endpoints of the %s resource, run after Server.Middlewares\n", resource.Name, resource.Name))
+	}
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("type Server[Session any] struct {\n")
+	buf.WriteString("\t// GetRequestIDFunc is a function that generates a request ID for each request without a valid X-Request-Id header\n")
+	buf.WriteString("\t// If nil, a default UUID-based request ID generator will be used\n")
+	buf.WriteString("\tGetRequestIDFunc func(ctx context.Context) string\n")
+
+	buf.WriteString("\t// GetSessionFunc is a function that is used on each endpoint to set the session to the request\n")
+	buf.WriteString("\tGetSessionFunc getSessionFunc[Session]\n")
+
+	buf.WriteString("\t// ErrorHook is a function that is used on each endpoint to convert an error to an Error object\n")
+	buf.WriteString("\tErrorHook ErrorHook[Session]\n\n")
+
+	buf.WriteString("\t// CheckScopesFunc verifies that the session has been granted the OAuth2 scopes required by the endpoint.\n")
+	buf.WriteString("\t// It is only called for endpoints that require scopes, and is required if any endpoint does.\n")
+	buf.WriteString("\tCheckScopesFunc CheckScopesFunc[Session]\n\n")
+
+	buf.WriteString("\t// AuthorizeFunc is called before the handler of every endpoint, after the scopes are verified, with the endpoint\n")
+	buf.WriteString("\t// of the request and the permissions it requires. An error rejects the request as forbidden.\n")
+	buf.WriteString("\t// If nil, every authenticated request is authorized.\n")
+	buf.WriteString("\tAuthorizeFunc AuthorizeFunc[Session]\n\n")
+
+	if service.HasFeatureFlags() {
+		buf.WriteString("\t// FeatureEnabledFunc reports whether a feature flag is enabled, before the session of the endpoints gated behind it\n")
+		buf.WriteString("\t// is authenticated. The endpoints behind disabled flags respond 404 Not Found. It is required if any endpoint is flagged.\n")
+		buf.WriteString("\tFeatureEnabledFunc FeatureEnabledFunc\n\n")
+	}
+
+	if service.HasScopedFields() {
+		buf.WriteString("\t// HasScopeFunc reports whether the session has been granted a scope required to read a field of the responses.\n")
+		buf.WriteString("\t// The fields requiring scopes the session has not been granted are redacted to null. It is required if any field does.\n")
+		buf.WriteString("\t// Unlike CheckScopesFunc, which rejects the requests to an endpoint lacking any of its scopes, it never fails a request.\n")
+		buf.WriteString("\tHasScopeFunc HasScopeFunc[Session]\n\n")
+	}
+
+	if service.Operational.HasHealth() {
+		buf.WriteString("\t// HealthCheckFunc reports whether the service is alive, served on " + specification.OperationalPathHealth + ".\n")
+		buf.WriteString("\t// If nil, the endpoint always reports the service as alive.\n")
+		buf.WriteString("\tHealthCheckFunc CheckFunc\n\n")
+	}
+
+	if service.Operational.HasReadiness() {
+		buf.WriteString("\t// ReadinessCheckFunc reports whether the service is ready to serve traffic, served on " + specification.OperationalPathReadiness + ".\n")
+		buf.WriteString("\t// If nil, the endpoint always reports the service as ready.\n")
+		buf.WriteString("\tReadinessCheckFunc CheckFunc\n\n")
+	}
+
+	buf.WriteString("\t// DocsUI selects the HTML documentation page served on /docs, rendering /openapi.json.\n")
+	buf.WriteString("\t// If empty, no documentation page is served.\n")
+	buf.WriteString("\tDocsUI DocsUI\n\n")
+
+	buf.WriteString("\t// Compression compresses the responses with gzip or deflate when the Accept-Encoding header of the request accepts it.\n")
+	buf.WriteString("\tCompression bool\n\n")
+
+	buf.WriteString("\t// ValidateResponses validates the responses of the endpoints against the specification before they are written:\n")
+	buf.WriteString("\t// the required fields must be set and the enum fields must hold values of their enums. It is meant for development,\n")
+	buf.WriteString("\t// to catch handlers drifting from the specification, since every response is encoded an extra time.\n")
+	buf.WriteString("\tValidateResponses bool\n\n")
+
+	buf.WriteString("\t// InvalidResponseHook is called with the error of a response that fails validation, e.g. to log it, and the response\n")
+	buf.WriteString("\t// is written anyway. If nil, an invalid response panics.\n")
+	buf.WriteString("\tInvalidResponseHook InvalidResponseHook\n\n")
+
+	if hasResponseEncoders(service) {
+		buf.WriteString("\t// ResponseEncoders encode the responses in the alternative content types of the endpoints, keyed by content type.\n")
+		buf.WriteString("\t// An encoder is required for every alternative content type. The responses of the endpoints with XML responses\n")
+		buf.WriteString("\t// are encoded with encoding/xml, unless an encoder is set for application/xml.\n")
+		buf.WriteString("\tResponseEncoders map[string]ResponseEncoder\n\n")
+	}
+
+	if hasAuditedEndpoints(service) {
+		buf.WriteString("\t// AuditFunc is called after every successful Create, Update and Delete request with the event of the request,\n")
+		buf.WriteString("\t// before the response is written, e.g. to write an audit log. If nil, the requests are not audited.\n")
+		buf.WriteString("\tAuditFunc AuditFunc[Session]\n\n")
+	}
+
+	// Always add ResponseHeaderHook
+	buf.WriteString("\t// ResponseHeaderHook is a function that returns common response headers for each request\n")
+	buf.WriteString("\tResponseHeaderHook ResponseHeaderHook\n\n")
+
+	buf.WriteString("\t// PreHooks are executed before endpoint logic. The first non-nil error aborts request processing.\n")
+	buf.WriteString("\tPreHooks []PreHook\n")
+
+	buf.WriteString("\t// SessionHooks are executed after authentication. The first non-nil error aborts request processing.\n")
+	buf.WriteString("\t// Example usages:\n")
+	buf.WriteString("\t//   - Logging: Log authenticated requests with user information from the session\n")
+	buf.WriteString("\t//   - Rate Limiting: Check if the user has exceeded their API rate limit based on session data\n")
+	buf.WriteString("\t//   - Authorization: Verify the user has permission to access the requested resource\n")
+	buf.WriteString("\t// \n")
+	buf.WriteString("\t// Example implementation:\n")
+	buf.WriteString("\t//   SessionHooks: []SessionHook[MySession]{\n")
+	buf.WriteString("\t//     // Logging hook\n")
+	buf.WriteString("\t//     func(ctx context.Context, reqCtx RequestContext, session MySession) error {\n")
+	buf.WriteString("\t//       log.Printf(\"User %s accessed %s %s\", session.UserID, reqCtx.HTTPMethod, reqCtx.Path)\n")
+	buf.WriteString("\t//       return nil\n")
+	buf.WriteString("\t//     },\n")
+	buf.WriteString("\t//     // Rate limiting hook\n")
+	buf.WriteString("\t//     func(ctx context.Context, reqCtx RequestContext, session MySession) error {\n")
+	buf.WriteString("\t//       if rateLimiter.IsExceeded(session.UserID) {\n")
+	buf.WriteString("\t//         return errors.New(\"rate limit exceeded\")\n")
+	buf.WriteString("\t//       }\n")
+	buf.WriteString("\t//       return nil\n")
+	buf.WriteString("\t//     },\n")
+	buf.WriteString("\t//     // Authorization hook\n")
+	buf.WriteString("\t//     func(ctx context.Context, reqCtx RequestContext, session MySession) error {\n")
+	buf.WriteString("\t//       if !session.HasPermission(reqCtx.Route) {\n")
+	buf.WriteString("\t//         return errors.New(\"insufficient permissions\")\n")
+	buf.WriteString("\t//       }\n")
+	buf.WriteString("\t//       return nil\n")
+	buf.WriteString("\t//     },\n")
+	buf.WriteString("\t//   },\n")
+	buf.WriteString("\tSessionHooks []SessionHook[Session]\n\n")
+
+	buf.WriteString("\t// Middlewares hook into the handling of the requests of every endpoint, before the middlewares of the resource.\n")
+	buf.WriteString("\tMiddlewares []Middleware[Session]\n")
+	buf.WriteString("}\n\n")
+
+	return nil
+}
+
+// generateResourceInterface generates the API interface with the endpoints of the resource.
+func generateResourceInterface(buf *bytes.Buffer, resource specification.Resource) {
+	buf.WriteString(fmt.Sprintf("type %sAPI[Session any] interface {\n", resource.Name))
+	for _, endpoint := range resource.Endpoints {
+		if endpoint.HasCSVResponse() {
+			// CSV responses are streamed, with each row written as it is ready
+			buf.WriteString(fmt.Sprintf("\t%s(ctx context.Context, request Request[Session, %s, %s, %s], write func(row *%s) error) error\n",
+				endpoint.Name,
+				endpoint.GetPathParamsType(resource.Name),
+				endpoint.GetQueryParamsType(resource.Name),
+				endpoint.GetBodyParamsType(resource.Name),
+				*endpoint.Response.BodyObject,
+			))
+		} else if endpoint.HasResponseType() {
+			buf.WriteString(fmt.Sprintf("\t%s(ctx context.Context, request Request[Session, %s, %s, %s]) (*%s, error)\n",
+				endpoint.Name,
+				endpoint.GetPathParamsType(resource.Name),
+				endpoint.GetQueryParamsType(resource.Name),
+				endpoint.GetBodyParamsType(resource.Name),
+				endpoint.GetResponseType(resource.Name),
+			))
+		} else {
+			buf.WriteString(fmt.Sprintf("\t%s(ctx context.Context, request Request[Session, %s, %s, %s]) error\n",
+				endpoint.Name,
+				endpoint.GetPathParamsType(resource.Name),
+				endpoint.GetQueryParamsType(resource.Name),
+				endpoint.GetBodyParamsType(resource.Name),
+			))
+		}
+	}
+	buf.WriteString("}\n\n")
+}
+
+// joinGinPaths joins the base path of the router group and the path of a route like gin does,
+// into the full path of the route returned by gin.Context.FullPath.
+func joinGinPaths(basePath, relativePath string) string {
+	joined := path.Join(basePath, relativePath)
+	if strings.HasSuffix(relativePath, "/") && !strings.HasSuffix(joined, "/") {
+		return joined + "/"
+	}
+	return joined
+}
+
+// getPermissionsValue returns the Permissions field of the EndpointInfo of an endpoint,
+// or an empty string if the endpoint does not require any permissions.
+func getPermissionsValue(permissions []string) string {
+	if len(permissions) == 0 {
+		return ""
+	}
+
+	quoted := make([]string, len(permissions))
+	for i, permission := range permissions {
+		quoted[i] = fmt.Sprintf("%q", permission)
+	}
+
+	return fmt.Sprintf(", Permissions: []string{%s}", strings.Join(quoted, ", "))
+}
+
+// getRequireScopesHandler returns the requireScopes handler to register before the endpoint handler,
+// or an empty string if the endpoint does not require any scopes.
+func getRequireScopesHandler(scopes []string) string {
+	if len(scopes) == 0 {
+		return ""
+	}
+
+	quoted := make([]string, len(scopes))
+	for i, scope := range scopes {
+		quoted[i] = fmt.Sprintf("%q", scope)
+	}
+
+	return fmt.Sprintf("requireScopes(%s), ", strings.Join(quoted, ", "))
+}
+
+// getRequireFeatureHandler returns the requireFeature handler to register before the endpoint handler,
+// or an empty string if the endpoint is not gated behind a feature flag.
+func getRequireFeatureHandler(featureFlag string) string {
+	if featureFlag == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("requireFeature(%q), ", featureFlag)
+}
+
+// getLastModifiedObjects returns the names of the response objects of the conditional GET endpoints of the service,
+// sorted and without duplicates.
+func getLastModifiedObjects(service *specification.Service) []string {
+	var objectNames []string
+	for _, resource := range service.Resources {
+		for _, endpoint := range resource.Endpoints {
+			if endpoint.IsConditionalGet(service) {
+				objectNames = append(objectNames, *endpoint.Response.BodyObject)
+			}
+		}
+	}
+
+	slices.Sort(objectNames)
+	return slices.Compact(objectNames)
+}
+
+// hasAuditedEndpoints returns true if any endpoint of the service is audited, see specification.Endpoint.GetAuditOperation.
+func hasAuditedEndpoints(service *specification.Service) bool {
+	for _, resource := range service.Resources {
+		for _, endpoint := range resource.Endpoints {
+			if endpoint.GetAuditOperation(resource) != "" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// getContentTypeHandler returns the acceptXML, acceptForm and respondXML handlers to register before the endpoint
+// handler, for the endpoints with XML or form requests and XML responses, or an empty string if the endpoint has none.
+func getContentTypeHandler(endpoint specification.Endpoint) string {
+	handler := ""
+	if endpoint.HasXMLRequest() {
+		handler += "acceptXML, "
+	}
+	if endpoint.HasFormRequest() {
+		handler += "acceptForm, "
+	}
+	if endpoint.HasXMLResponse() {
+		handler += "respondXML, "
+	}
+	return handler
+}
+
+// hasResponseEncoders returns whether any response of the service is encoded with a ResponseEncoder, the responses
+// with alternative content types and the XML responses.
+func hasResponseEncoders(service *specification.Service) bool {
+	return len(service.GetAlternativeContentTypes()) > 0 || service.HasXMLResponses()
+}
+
+// getAuditHandler returns the audit handler to register before the endpoint handler,
+// or an empty string if the endpoint is not audited.
+func getAuditHandler(resource specification.Resource, endpoint specification.Endpoint) string {
+	operation := endpoint.GetAuditOperation(resource)
+	if operation == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("audit(%q, %q), ", resource.Name, operation)
+}
+
+// getLimitRequestHandler returns the limitRequest handler to register before the endpoint handler,
+// or an empty string if the endpoint limits neither the size of the request body nor the time of the handler.
+func getLimitRequestHandler(service *specification.Service, endpoint specification.Endpoint) string {
+	maxBodyBytes, timeout := endpoint.GetMaxBodyBytes(service), endpoint.GetHandlerTimeout(service)
+	if maxBodyBytes == 0 && timeout == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("limitRequest(%d, %d*time.Millisecond), ", maxBodyBytes, timeout)
+}
+
+// getCSVObjects returns the names of the objects of the rows of the CSV responses of the service,
+// sorted and without duplicates.
+func getCSVObjects(service *specification.Service) []string {
+	var objectNames []string
+	for _, resource := range service.Resources {
+		for _, endpoint := range resource.Endpoints {
+			if endpoint.HasCSVResponse() {
+				objectNames = append(objectNames, *endpoint.Response.BodyObject)
+			}
+		}
+	}
+
+	slices.Sort(objectNames)
+	return slices.Compact(objectNames)
+}
+
+// generateCSVRecord generates the header row of the CSV responses with rows of the object, and the function
+// returning the row of an object, with a column per column of Service.GetCSVColumns.
+func generateCSVRecord(buf *bytes.Buffer, object specification.Object, service *specification.Service) {
+	columns := service.GetCSVColumns(object.Name)
+
+	buf.WriteString(fmt.Sprintf("// csvHeader%s is the header row of the CSV responses with rows of %s\n", object.Name, object.Name))
+	buf.WriteString(fmt.Sprintf("var csvHeader%s = []string{\n", object.Name))
+	for _, column := range columns {
+		buf.WriteString(fmt.Sprintf("\t%q,\n", column.Name))
+	}
+	buf.WriteString("}\n\n")
+
+	buf.WriteString(fmt.Sprintf("// csvRecord%s returns the row of the %s in the CSV responses, in the order of csvHeader%s\n", object.Name, object.Name, object.Name))
+	buf.WriteString(fmt.Sprintf("func csvRecord%s(row *%s) []string {\n", object.Name, object.Name))
+	buf.WriteString("\treturn []string{\n")
+	for _, column := range columns {
+		buf.WriteString(fmt.Sprintf("\t\tcsvValue(row.%s),\n", strings.Join(column.FieldPath, ".")))
+	}
+	buf.WriteString("\t}\n")
+	buf.WriteString("}\n\n")
+}
+
+// getOfferContentTypesHandler returns the offerContentTypes handler to register before the endpoint handler,
+// or an empty string if the response of the endpoint has no alternative content types.
+func getOfferContentTypesHandler(contentTypes []string) string {
+	if len(contentTypes) == 0 {
+		return ""
+	}
+
+	quoted := make([]string, len(contentTypes))
+	for i, contentType := range contentTypes {
+		quoted[i] = fmt.Sprintf("%q", contentType)
+	}
+
+	return fmt.Sprintf("offerContentTypes(%s), ", strings.Join(quoted, ", "))
+}
+
+// getTenantIDExtraction returns the RequestContext field assignment reading the tenant identifier
+// from the gin context, or an empty string when the service has no tenancy configured.
+func getTenantIDExtraction(service *specification.Service) string {
+	switch {
+	case service.Tenancy.IsHeader():
+		return fmt.Sprintf("\t\tTenantID:       c.GetHeader(%q),\n", service.Tenancy.GetParameterName())
+	case service.Tenancy.IsPath():
+		return fmt.Sprintf("\t\tTenantID:       c.Param(%q),\n", service.Tenancy.GetParameterName())
+	default:
+		return ""
+	}
+}
+
+// getLocaleExtraction returns the RequestContext field assignment resolving the locale of the error messages
+// from the Accept-Language header, or an empty string when the service has no localization configured.
+func getLocaleExtraction(service *specification.Service) string {
+	if service.Localization == nil {
+		return ""
+	}
+	return "\t\tLocale:         resolveLocale(c.GetHeader(\"Accept-Language\")),\n"
+}
+
+// generateLocalization generates the message catalog of the localization of the service, the resolver of the locale
+// of the request from the Accept-Language header and localizeError, which replaces the message of the errors.
+func generateLocalization(buf *bytes.Buffer, service *specification.Service, types Types) {
+	localization := service.Localization
+	locales := localization.GetLocales()
+
+	buf.WriteString("// defaultLocale is the locale of the error messages when the request accepts none of the locales\n")
+	buf.WriteString(fmt.Sprintf("const defaultLocale = %q\n\n", localization.DefaultLocale))
+
+	buf.WriteString("// locales are the locales of the error messages, in the order they are matched\n")
+	quoted := make([]string, len(locales))
+	for i, locale := range locales {
+		quoted[i] = fmt.Sprintf("%q", locale)
+	}
+	buf.WriteString(fmt.Sprintf("var locales = []string{%s}\n\n", strings.Join(quoted, ", ")))
+
+	// The messages are keyed by the names of the error codes, the errors have their wire values
+	errorCodeEnum := service.GetEnum("ErrorCode")
+
+	buf.WriteString("// errorMessages holds the localized messages of the error codes, keyed by locale and then by error code\n")
+	buf.WriteString("var errorMessages = map[string]map[string]string{\n")
+	for _, locale := range locales {
+		messages := localization.GetMessages(locale)
+		buf.WriteString(fmt.Sprintf("\t%q: {\n", locale))
+		for _, code := range slices.Sorted(maps.Keys(messages)) {
+			wireValue := code
+			if errorCodeEnum != nil {
+				wireValue = errorCodeEnum.GetWireValue(code)
+			}
+			buf.WriteString(fmt.Sprintf("\t\t%q: %q,\n", wireValue, messages[code]))
+		}
+		buf.WriteString("\t},\n")
+	}
+	buf.WriteString("}\n\n")
+
+	buf.WriteString(`// resolveLocale returns the locale of the error messages best matching the Accept-Language header,
+// by the quality of the language ranges, or defaultLocale when none of the locales is accepted
+func resolveLocale(acceptLanguage string) string {
+	resolved, resolvedQuality := defaultLocale, 0.0
+	for _, languageRange := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(languageRange), ";")
+		quality := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+
+		if locale, ok := matchLocale(strings.TrimSpace(tag)); ok && quality > resolvedQuality {
+			resolved, resolvedQuality = locale, quality
+		}
+	}
+
+	return resolved
+}
+
+// matchLocale returns the locale matching the language tag, exactly or else by the primary language subtag,
+// so that sv-SE matches sv and sv matches sv-SE
+func matchLocale(tag string) (string, bool) {
+	for _, locale := range locales {
+		if strings.EqualFold(locale, tag) {
+			return locale, true
+		}
+	}
+
+	language, _, _ := strings.Cut(tag, "-")
+	for _, locale := range locales {
+		localeLanguage, _, _ := strings.Cut(locale, "-")
+		if strings.EqualFold(localeLanguage, language) {
+			return locale, true
+		}
+	}
+
+	return "", false
+}
+
+`)
+
+	messageField := service.GetErrorMessageFieldName()
+	buf.WriteString("// localizeError replaces the message of the error with the message of its code in the locale,\n")
+	buf.WriteString("// or in defaultLocale when the locale has none. Errors with codes without messages are kept as they are.\n")
+	buf.WriteString("func localizeError(locale string, err *Error) *Error {\n")
+	buf.WriteString(fmt.Sprintf("\tcode := %s\n", types.StringValue("err.Code")))
+	buf.WriteString("\tmessage, ok := errorMessages[locale][code]\n")
+	buf.WriteString("\tif !ok {\n")
+	buf.WriteString("\t\tmessage, ok = errorMessages[defaultLocale][code]\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\tif ok {\n")
+	buf.WriteString(fmt.Sprintf("\t\terr.%s = %s\n", messageField, types.String("message")))
+	buf.WriteString("\t}\n")
+	buf.WriteString("\treturn err\n")
+	buf.WriteString("}\n\n")
+}
+
+func generateRequestTypes(buf *bytes.Buffer, service *specification.Service, types Types) error {
+	if err := generateRequestContextTypes(buf, service, types); err != nil {
+		return err
+	}
+
+	for _, resource := range service.Resources {
+		generateResourceRequestTypes(buf, service, resource, types)
+	}
+
+	return nil
+}
+
+// generateRequestContextTypes generates the hook, request context and request types shared by all endpoints,
+// and the parameter groups embedded in their query parameters.
+func generateRequestContextTypes(buf *bytes.Buffer, service *specification.Service, types Types) error {
+	// Generate ErrorHook type
+	buf.WriteString("// ErrorHook converts application errors into API Error responses.\n")
+	buf.WriteString("// This hook is called whenever an error occurs during request processing,\n")
+	buf.WriteString("// allowing you to customize error formatting, logging, and HTTP status codes.\n")
+	buf.WriteString("type ErrorHook[Session any] func(ctx context.Context, requestContext RequestContext, session *Session, err error) *Error\n\n")
+
+	// Generate PreHook types
+	buf.WriteString("// PreHook runs before endpoint logic. Return nil to continue; non-nil to abort.\n")
+	buf.WriteString("type PreHook func(ctx context.Context, requestContext RequestContext) error\n\n")
+
+	buf.WriteString("// PreHooks is an optional helper type if you prefer a named slice.\n")
+	buf.WriteString("type PreHooks []PreHook\n\n")
+
+	// Generate SessionHook types
+	buf.WriteString("// SessionHook runs after authentication. Return nil to continue; non-nil to abort.\n")
+	buf.WriteString("// SessionHooks have access to both the RequestContext and the authenticated Session,\n")
+	buf.WriteString("// enabling use cases such as:\n")
+	buf.WriteString("//   - Logging authenticated requests with session/user information\n")
+	buf.WriteString("//   - Rate limiting based on user or tenant from the session\n")
+	buf.WriteString("//   - Authorization checks to verify the user can access the requested resource\n")
+	buf.WriteString("//   - Metrics collection for authenticated API usage\n")
+	buf.WriteString("//   - Session validation or enrichment\n")
+	buf.WriteString("type SessionHook[Session any] func(ctx context.Context, requestContext RequestContext, session Session) error\n\n")
+
+	buf.WriteString("// SessionHooks is an optional helper type if you prefer a named slice.\n")
+	buf.WriteString("type SessionHooks[Session any] []SessionHook[Session]\n\n")
+
+	// Generate Middleware type
+	buf.WriteString(`// Middleware hooks into the handling of the requests of the endpoints it is registered for, globally with
+// Server.Middlewares or per resource with the Middlewares of the resource in the API, e.g. for audit logging or
+// feature flags. The hooks run in the order of registration, and PostHandle in the reverse order. Every hook is optional.
+type Middleware[Session any] struct {
+	// PreParse runs before the pre-hooks, authentication and decoding of the request. A non-nil error aborts the request.
+	PreParse func(ctx context.Context, requestContext RequestContext) error
+
+	// PreHandle runs with the decoded request before the endpoint. The request is the Request of the endpoint,
+	// see PreHandleOf for typed access. A non-nil error aborts the request.
+	PreHandle func(ctx context.Context, requestContext RequestContext, request any) error
+
+	// PostHandle runs after the endpoint with its response, which is nil for endpoints without one, and error.
+	// The returned error replaces the error of the endpoint, so return err to keep it.
+	PostHandle func(ctx context.Context, requestContext RequestContext, request any, response any, err error) error
+}
+
+// PreHandleOf returns a Middleware.PreHandle calling fn with the requests of the type Request[Session, P, Q, B]
+// and skipping the requests of other endpoints.
+func PreHandleOf[Session, P, Q, B any](fn func(ctx context.Context, request Request[Session, P, Q, B]) error) func(ctx context.Context, requestContext RequestContext, request any) error {
+	return func(ctx context.Context, requestContext RequestContext, request any) error {
+		if typed, ok := request.(Request[Session, P, Q, B]); ok {
+			return fn(ctx, typed)
+		}
+		return nil
+	}
+}
+
+// PostHandleOf returns a Middleware.PostHandle calling fn with the requests of the type Request[Session, P, Q, B]
+// and their response, which is nil unless it is a *R, and keeping the error of the requests of other endpoints.
+func PostHandleOf[Session, P, Q, B, R any](fn func(ctx context.Context, request Request[Session, P, Q, B], response *R, err error) error) func(ctx context.Context, requestContext RequestContext, request any, response any, err error) error {
+	return func(ctx context.Context, requestContext RequestContext, request any, response any, err error) error {
+		typed, ok := request.(Request[Session, P, Q, B])
+		if !ok {
+			return err
+		}
+		typedResponse, _ := response.(*R)
+		return fn(ctx, typed, typedResponse, err)
+	}
+}
+
+`)
+
+	// Generate CheckScopesFunc type
+	buf.WriteString("// CheckScopesFunc runs after the session hooks for endpoints that require OAuth2 scopes.\n")
+	buf.WriteString("// Return nil if the session has been granted all required scopes; non-nil to reject the request as forbidden.\n")
+	buf.WriteString("type CheckScopesFunc[Session any] func(ctx context.Context, requestContext RequestContext, session Session, requiredScopes []string) error\n\n")
+
+	// Generate FeatureEnabledFunc type
+	if service.HasFeatureFlags() {
+		buf.WriteString("// FeatureEnabledFunc runs first for endpoints gated behind a feature flag, see Server.FeatureEnabledFunc.\n")
+		buf.WriteString("// Return true if the flag is enabled; false to respond as if the endpoint did not exist.\n")
+		buf.WriteString("type FeatureEnabledFunc func(ctx context.Context, flag string) bool\n\n")
+	}
+
+	// Generate HasScopeFunc type
+	if service.HasScopedFields() {
+		buf.WriteString("// HasScopeFunc runs before the responses with fields that require scopes are written, once per scope and request.\n")
+		buf.WriteString("// Return true if the session has been granted the scope; false to redact the fields requiring it.\n")
+		buf.WriteString("type HasScopeFunc[Session any] func(ctx context.Context, session Session, scope string) bool\n\n")
+	}
+
+	// Generate the authorization types
+	buf.WriteString("// EndpointInfo describes the endpoint of a request, see Server.AuthorizeFunc.\n")
+	buf.WriteString("type EndpointInfo struct {\n")
+	buf.WriteString("\t// Resource is the name of the resource of the endpoint, e.g. Users\n")
+	buf.WriteString("\tResource string\n\n")
+	buf.WriteString("\t// Operation is the name of the endpoint, e.g. Create, or Archive for a custom endpoint\n")
+	buf.WriteString("\tOperation string\n\n")
+	buf.WriteString("\t// Permissions are the permissions required by the endpoint in the specification, if any\n")
+	buf.WriteString("\tPermissions []string\n\n")
+	buf.WriteString("\t// PathParams are the path parameters of the request by name, e.g. id\n")
+	buf.WriteString("\tPathParams map[string]string\n")
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// AuthorizeFunc runs after the session hooks and the scope check of every endpoint.\n")
+	buf.WriteString("// Return nil if the session may call the endpoint; non-nil to reject the request as forbidden.\n")
+	buf.WriteString("type AuthorizeFunc[Session any] func(ctx context.Context, session Session, endpoint EndpointInfo) error\n\n")
+
+	// Generate the audit types of the mutating endpoints
+	if hasAuditedEndpoints(service) {
+		buf.WriteString("// AuditEvent is a successful Create, Update or Delete request, see Server.AuditFunc.\n")
+		buf.WriteString("type AuditEvent[Session any] struct {\n")
+		buf.WriteString("\t// Resource is the name of the resource of the endpoint, e.g. Users\n")
+		buf.WriteString("\tResource string\n\n")
+		buf.WriteString("\t// Operation is the operation of the endpoint: Create, Update or Delete\n")
+		buf.WriteString("\tOperation string\n\n")
+		buf.WriteString("\t// EntityID is the ID of the entity, from the path of Update and Delete and from the response of Create\n")
+		buf.WriteString("\tEntityID string\n\n")
+		buf.WriteString("\t// Session is the session of the request, identifying who made it\n")
+		buf.WriteString("\tSession Session\n\n")
+		buf.WriteString("\t// RequestID is the ID of the request\n")
+		buf.WriteString("\tRequestID string\n")
+		if service.Tenancy != nil {
+			buf.WriteString("\n\t// TenantID is the identifier of the tenant of the request\n")
+			buf.WriteString("\tTenantID string\n")
+		}
+		buf.WriteString("}\n\n")
+
+		buf.WriteString("// AuditFunc receives the events of the successful Create, Update and Delete requests, see Server.AuditFunc.\n")
+		buf.WriteString("type AuditFunc[Session any] func(ctx context.Context, event AuditEvent[Session])\n\n")
+	}
+
+	// Generate RequestContext struct
+	buf.WriteString("type RequestContext struct {\n")
+	buf.WriteString("\t// ID of the request, can be used for debugging.\n")
+	buf.WriteString("\tRequestID string `json:\"requestId\"`\n\n")
+	buf.WriteString("\t// Path of the request. For example, /employee/123/archive\n")
+	buf.WriteString("\tPath string `json:\"path\"`\n\n")
+	buf.WriteString("\t// Route of the request. For example, /employee/:employee_id/archive\n")
+	buf.WriteString("\tRoute string `json:\"route\"`\n\n")
+	buf.WriteString("\t// UserAgent of the request\n")
+	buf.WriteString("\tUserAgent string `json:\"userAgent\"`\n\n")
+	buf.WriteString("\t// HTTPMethod of the request. For example, POST\n")
+	buf.WriteString("\tHTTPMethod string `json:\"httpMethod\"`\n\n")
+	buf.WriteString("\t// IPAddress of the request.\n")
+	buf.WriteString("\tIPAddress string `json:\"ipAddress\"`\n\n")
+	buf.WriteString("\t// RequiredScopes are the OAuth2 scopes required by the endpoint, if any.\n")
+	buf.WriteString("\tRequiredScopes []string `json:\"requiredScopes,omitempty\"`\n")
+	if service.Tenancy != nil {
+		buf.WriteString("\n\t// TenantID is the identifier of the tenant the request is scoped to.\n")
+		buf.WriteString("\tTenantID string `json:\"tenantId\"`\n")
+	}
+	if service.Localization != nil {
+		buf.WriteString("\n\t// Locale of the error messages, resolved from the Accept-Language header of the request.\n")
+		buf.WriteString("\tLocale string `json:\"locale\"`\n")
+	}
+	buf.WriteString("}\n\n")
+
+	// Generate Request struct
+	buf.WriteString("type Request[sessionType, pathParamsType, queryParamsType, bodyParamsType any] struct {\n")
+	buf.WriteString("\trequestContext RequestContext `json:\"-\"` // Unexported field\n")
+	buf.WriteString("\tSession sessionType `json:\"-\"`\n")
+	buf.WriteString("\tPathParams pathParamsType `json:\"-\"`\n")
+	buf.WriteString("\tQueryParams queryParamsType `json:\"-\"`\n")
+	buf.WriteString("\tBodyParams bodyParamsType `json:\"-\"`\n")
+	if service.Tenancy != nil {
+		buf.WriteString("\tTenantID string `json:\"-\"`\n")
+	}
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("func (r Request[sessionType, pathParamsType, queryParamsType, bodyParamsType]) Context() RequestContext {\n")
+	buf.WriteString("\treturn r.requestContext\n")
+	buf.WriteString("}\n\n")
+
+	// Parameter groups are generated once and embedded in the query params of the endpoints referencing them
+	for _, group := range service.ParameterGroups {
+		if group.Description != "" {
+			buf.WriteString(fmt.Sprintf("// %s: %s\n", group.GetTypeName(), group.Description))
+		}
+		buf.WriteString(fmt.Sprintf("type %s struct {\n", group.GetTypeName()))
+		for _, field := range group.Fields {
+			buf.WriteString(fmt.Sprintf("\t%s %s `form:\"%s\" json:\"%s\"`\n", field.GetGoName(), getTypeForGo(field, service, types), field.TagJSON(), field.TagJSON()))
+		}
+		buf.WriteString("}\n\n")
+
+		generateSetDefaults(buf, group.GetTypeName(), nil, group.Fields, service, types)
+	}
+
+	return nil
+}
+
+// generateResourceRequestTypes generates the path, query and body parameter types of the endpoints of the resource.
+func generateResourceRequestTypes(buf *bytes.Buffer, service *specification.Service, resource specification.Resource, types Types) {
+	generateExpansions(buf, resource)
+
+	for _, endpoint := range resource.Endpoints {
+		if len(endpoint.Request.PathParams) > 0 {
+			buf.WriteString(fmt.Sprintf("type %s struct {\n", endpoint.GetPathParamsType(resource.Name)))
+			for _, field := range endpoint.Request.PathParams {
+				buf.WriteString(fmt.Sprintf("\t%s %s `json:\"%s\"`\n", field.GetGoName(), getTypeForGo(field, service, types), types.pathParamTag(field)))
+			}
+			buf.WriteString("}\n\n")
+
+			generateParsePathParams(buf, endpoint.GetPathParamsType(resource.Name), endpoint.Request.PathParams, service, types)
+		}
+
+		if len(endpoint.Request.QueryParams) > 0 {
+			buf.WriteString(fmt.Sprintf("type %s struct {\n", endpoint.GetQueryParamsType(resource.Name)))
+			for _, groupName := range endpoint.Request.ParameterGroups {
+				if group := service.GetParameterGroup(groupName); group != nil {
+					buf.WriteString(fmt.Sprintf("\t%s\n", group.GetTypeName()))
+				}
+			}
+			for _, field := range endpoint.GetOwnQueryParams(service) {
+				buf.WriteString(fmt.Sprintf("\t%s %s `form:\"%s\" json:\"%s\"`\n", field.GetGoName(), getTypeForGo(field, service, types), field.TagJSON(), field.TagJSON()))
+			}
+			buf.WriteString("}\n\n")
+
+			// Every embedded group with defaults is called explicitly, since the promoted methods would be ambiguous
+			groups := []string{}
+			for _, groupName := range endpoint.Request.ParameterGroups {
+				if group := service.GetParameterGroup(groupName); group != nil && fieldsHaveDefaults(group.Fields, service, types, map[string]bool{}) {
+					groups = append(groups, group.GetTypeName())
+				}
+			}
+			generateSetDefaults(buf, endpoint.GetQueryParamsType(resource.Name), groups, endpoint.GetOwnQueryParams(service), service, types)
+			generateParseQueryParams(buf, endpoint.GetQueryParamsType(resource.Name), endpoint.Request.QueryParams, ClampsLimit(service, endpoint), service, types)
+
+			if param := endpoint.GetExpandParam(resource); param != nil {
+				generateExpansionsMethod(buf, endpoint.GetQueryParamsType(resource.Name), resource, *param, types)
+			}
+		}
+
+		if len(endpoint.Request.BodyParams) > 0 {
+			buf.WriteString(fmt.Sprintf("type %s struct {\n", endpoint.GetBodyParamsType(resource.Name)))
+			for _, field := range endpoint.Request.BodyParams {
+				tag := getStructTag(field, service)
+				if endpoint.HasFormRequest() {
+					tag = fmt.Sprintf("`form:\"%s\" json:\"%s\"`", field.TagJSON(), field.TagJSON())
+				}
+				buf.WriteString(fmt.Sprintf("\t%s %s %s\n", field.GetGoName(), getTypeForGo(field, service, types), tag))
+			}
+			buf.WriteString("}\n\n")
+
+			generateSetDefaults(buf, endpoint.GetBodyParamsType(resource.Name), nil, endpoint.Request.BodyParams, service, types)
+
+			if endpoint.HasFormRequest() {
+				generateParseForm(buf, endpoint.GetBodyParamsType(resource.Name), endpoint.Request.BodyParams, service, types)
+			}
+
+			if service.HasStrictObjects() {
+				generateCheckUnknownFields(buf, endpoint.GetBodyParamsType(resource.Name), service.Strict, endpoint.Request.BodyParams, service, types)
+			}
+
+			if param := endpoint.GetFilterParam(resource); param != nil && service.FilterMaxDepth > 0 {
+				generateCheckFilterDepth(buf, endpoint.GetBodyParamsType(resource.Name), *param, service.FilterMaxDepth)
+			}
+		}
+	}
+}
+
+// generateCheckFilterDepth generates the depth method of the filter of a Search endpoint, and the checkFilterDepth
+// method of its body params, which returns an error if the nested filters are nested deeper than the max depth.
+func generateCheckFilterDepth(buf *bytes.Buffer, typeName string, param specification.Field, maxDepth int) {
+	buf.WriteString(fmt.Sprintf("// depth returns how many levels deep the nested filters of the %s are nested\n", param.Type))
+	buf.WriteString(fmt.Sprintf("func (f %s) depth() int {\n", param.Type))
+	buf.WriteString("\tdepth := 0\n")
+	buf.WriteString("\tfor _, nested := range f.NestedFilters {\n")
+	buf.WriteString("\t\tif nestedDepth := nested.depth() + 1; nestedDepth > depth {\n")
+	buf.WriteString("\t\t\tdepth = nestedDepth\n")
+	buf.WriteString("\t\t}\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\treturn depth\n")
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// checkFilterDepth returns an error if the nested filters are nested deeper than the max depth of the service\n")
+	buf.WriteString(fmt.Sprintf("func (v %s) checkFilterDepth() error {\n", typeName))
+	buf.WriteString(fmt.Sprintf("\tif depth := v.%s.depth(); depth > %d {\n", param.GetGoName(), maxDepth))
+	buf.WriteString(fmt.Sprintf("\t\treturn fmt.Errorf(\"the nested filters are %%d levels deep, at most %d levels are allowed\", depth)\n", maxDepth))
+	buf.WriteString("\t}\n")
+	buf.WriteString("\treturn nil\n")
+	buf.WriteString("}\n\n")
+}
+
+// generateExpansions generates the type of the expansions of the resource, with a flag per related resource that can
+// be included in its responses, see specification.Resource.Expansions.
+func generateExpansions(buf *bytes.Buffer, resource specification.Resource) {
+	if len(resource.Expansions) == 0 {
+		return
+	}
+
+	buf.WriteString(fmt.Sprintf("// %sExpansions are the related resources the client asked to include in the response with the expand query parameter\n", resource.Name))
+	buf.WriteString(fmt.Sprintf("type %sExpansions struct {\n", resource.Name))
+	for i, expansion := range resource.Expansions {
+		if i > 0 {
+			buf.WriteString("\n")
+		}
+		buf.WriteString(fmt.Sprintf("\t// %s is set when %s should be included\n", specification.GoIdentifier(expansion.Name), expansion.Name))
+		buf.WriteString(fmt.Sprintf("\t%s bool\n", specification.GoIdentifier(expansion.Name)))
+	}
+	buf.WriteString("}\n\n")
+}
+
+// generateExpansionsMethod generates the Expansions method of the query parameter type, which returns the flags of
+// the values of the expand query parameter. Unknown values are ignored.
+func generateExpansionsMethod(buf *bytes.Buffer, typeName string, resource specification.Resource, param specification.Field, types Types) {
+	enumName := resource.GetExpansionEnumName()
+
+	buf.WriteString("// Expansions returns the related resources to include in the response\n")
+	buf.WriteString(fmt.Sprintf("func (v %s) Expansions() %sExpansions {\n", typeName, resource.Name))
+	buf.WriteString(fmt.Sprintf("\tvar expansions %sExpansions\n", resource.Name))
+	buf.WriteString(fmt.Sprintf("\tfor _, value := range v.%s {\n", param.GetGoName()))
+	buf.WriteString(fmt.Sprintf("\t\tswitch %s {\n", types.StringValue("value")))
+	for _, expansion := range resource.Expansions {
+		buf.WriteString(fmt.Sprintf("\t\tcase %s:\n", specification.EnumValue{Name: expansion.Name}.GetGoName(enumName)))
+		buf.WriteString(fmt.Sprintf("\t\t\texpansions.%s = true\n", specification.GoIdentifier(expansion.Name)))
+	}
+	buf.WriteString("\t\t}\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\treturn expansions\n")
+	buf.WriteString("}\n\n")
+}
+
+func generateResponseTypes(buf *bytes.Buffer, service *specification.Service, types Types) error {
+	for _, resource := range service.Resources {
+		generateResourceResponseTypes(buf, service, resource, types)
+	}
+
+	return nil
+}
+
+// generateResourceResponseTypes generates the response types of the endpoints of the resource.
+func generateResourceResponseTypes(buf *bytes.Buffer, service *specification.Service, resource specification.Resource, types Types) {
+	redactedObjects := getRedactedObjects(service)
+	for _, endpoint := range resource.Endpoints {
+		if len(endpoint.Response.BodyFields) == 0 {
+			continue
+		}
+
+		buf.WriteString(fmt.Sprintf("type %s struct {\n", endpoint.GetResponseType(resource.Name)))
+		for _, field := range endpoint.Response.BodyFields {
+			buf.WriteString(fmt.Sprintf("\t%s %s %s\n", field.GetGoName(), getTypeForGo(field, service, types), getStructTag(field, service)))
+		}
+		for _, field := range endpoint.Response.GetHeaderFields() {
+			buf.WriteString(fmt.Sprintf("\n\t// %s is written to the %s header, not to the body\n", field.GetGoName(), field.GetHeaderName()))
+			buf.WriteString(fmt.Sprintf("\t%s %s %s\n", field.GetGoName(), getTypeForGo(field, service, types), getHeaderFieldTag(service.HasXML())))
+		}
+		buf.WriteString("}\n\n")
+
+		generateWriteResponseHeaders(buf, endpoint.GetResponseType(resource.Name), endpoint.Response.GetHeaderFields(), types)
+
+		generateValidateResponse(buf, endpoint.GetResponseType(resource.Name), endpoint.Response.BodyFields, service, types)
+
+		if hasRedactedFields(endpoint.Response.BodyFields, redactedObjects) {
+			generateRedact(buf, endpoint.GetResponseType(resource.Name), nil, endpoint.Response.BodyFields, redactedObjects, service, types)
+		}
+	}
+}
+
+// generateWriteResponseHeaders generates the writeResponseHeaders method of the response type of an endpoint with
+// response headers, which sets the headers to the fields of the response, see responseHeadersWriter.
+func generateWriteResponseHeaders(buf *bytes.Buffer, responseType string, headers []specification.Field, types Types) {
+	if len(headers) == 0 {
+		return
+	}
+
+	buf.WriteString(fmt.Sprintf("// writeResponseHeaders sets the response headers to the header fields of the %s\n", responseType))
+	buf.WriteString(fmt.Sprintf("func (response *%s) writeResponseHeaders(c *gin.Context) {\n", responseType))
+	for _, field := range headers {
+		buf.WriteString(types.headerAssignment(field.GetHeaderName(), field, "response."+field.GetGoName()))
+	}
+	buf.WriteString("}\n\n")
+}
+
+// hasResponseHeaderFields returns whether any endpoint of the service sets response headers through fields of its
+// response type, see specification.EndpointResponse.GetHeaderFields.
+func hasResponseHeaderFields(service *specification.Service) bool {
+	for _, resource := range service.Resources {
+		for _, endpoint := range resource.Endpoints {
+			if len(endpoint.Response.GetHeaderFields()) > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func generateResponseHeaderTypes(buf *bytes.Buffer, service *specification.Service, types Types) error {
+	// Always generate ResponseHeaders struct (empty if no headers defined)
+	buf.WriteString("// ResponseHeaders contains the common response headers returned by all endpoints\n")
+	buf.WriteString("type ResponseHeaders struct {\n")
+	for _, field := range service.ResponseHeaders {
+		// Sanitize field name to ensure it's a valid Go identifier (see specification.GoIdentifier)
+		fieldName := field.GetGoName()
+		buf.WriteString(fmt.Sprintf("\t%s %s\n", fieldName, getTypeForGo(field, service, types)))
+	}
+	buf.WriteString("}\n\n")
+
+	// Always generate ResponseHeaderHook type
+	buf.WriteString("// ResponseHeaderHook returns the common response headers for each request.\n")
+	buf.WriteString("// This hook is called for every successful request to generate standard headers\n")
+	buf.WriteString("// such as RateLimit-Reset, TraceID, etc.\n")
+	buf.WriteString("type ResponseHeaderHook func(ctx context.Context, requestContext RequestContext) ResponseHeaders\n\n")
+
+	buf.WriteString("// InvalidResponseHook receives the error of a response that fails the validation of Server.ValidateResponses.\n")
+	buf.WriteString("type InvalidResponseHook func(ctx context.Context, requestContext RequestContext, err error)\n\n")
+
+	return nil
+}
+
+// generateOperationalEndpoints generates the types and handlers of the health, readiness and version endpoints.
+func generateOperationalEndpoints(buf *bytes.Buffer, service *specification.Service) error {
+	if service.Operational.HasChecks() {
+		buf.WriteString("// CheckFunc reports the health of the service. Return nil if the check passes; non-nil to respond with 503 Service Unavailable.\n")
+		buf.WriteString("type CheckFunc func(ctx context.Context) error\n\n")
+
+		buf.WriteString("const (\n")
+		buf.WriteString("\tcheckStatusOK          = \"ok\"\n")
+		buf.WriteString("\tcheckStatusUnavailable = \"unavailable\"\n")
+		buf.WriteString(")\n\n")
+
+		buf.WriteString("// CheckResponse is the response of the health and readiness endpoints\n")
+		buf.WriteString("type CheckResponse struct {\n")
+		buf.WriteString("\tStatus string `json:\"status\"`\n")
+		buf.WriteString("}\n\n")
+
+		buf.WriteString(`// serveCheck responds with 200 OK if the check passes or is nil, and 503 Service Unavailable otherwise
+func serveCheck(check CheckFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if check != nil {
+			if err := check(c.Request.Context()); err != nil {
+				c.JSON(http.StatusServiceUnavailable, CheckResponse{Status: checkStatusUnavailable})
+				return
+			}
+		}
+
+		c.JSON(http.StatusOK, CheckResponse{Status: checkStatusOK})
+	}
+}` + "\n\n")
+	}
+
+	if service.Operational.HasVersion() {
+		buf.WriteString("// VersionResponse is the response of the version endpoint\n")
+		buf.WriteString("type VersionResponse struct {\n")
+		buf.WriteString("\tName string `json:\"name\"`\n")
+		buf.WriteString("\tVersion string `json:\"version\"`\n")
+		buf.WriteString("}\n\n")
+
+		buf.WriteString("// serveVersion responds with the name and version of the service\n")
+		buf.WriteString("func serveVersion(c *gin.Context) {\n")
+		buf.WriteString(fmt.Sprintf("\tc.JSON(http.StatusOK, VersionResponse{Name: %q, Version: %q})\n", service.Name, service.Version))
+		buf.WriteString("}\n\n")
+	}
+
+	return nil
+}
+
+// generateDocsUI generates the Swagger UI and Redoc pages rendering the embedded OpenAPI specification.
+func generateDocsUI(buf *bytes.Buffer, service *specification.Service) error {
+	// The pages are generated as raw string literals, so backticks are escaped as HTML entities
+	title := strings.ReplaceAll(html.EscapeString(service.Name+" API"), "`", "&#96;")
+
+	buf.WriteString("// DocsUI is the HTML documentation page served on /docs\n")
+	buf.WriteString("type DocsUI string\n\n")
+
+	buf.WriteString("const (\n")
+	buf.WriteString("\tDocsUINone    DocsUI = \"\"\n")
+	buf.WriteString("\tDocsUISwagger DocsUI = \"swagger\"\n")
+	buf.WriteString("\tDocsUIRedoc   DocsUI = \"redoc\"\n")
+	buf.WriteString(")\n\n")
+
+	buf.WriteString("// swaggerUIPage renders openapi.json, relative to /docs, with Swagger UI\n")
+	buf.WriteString("const swaggerUIPage = `<!DOCTYPE html>\n")
+	buf.WriteString("<html lang=\"en\">\n")
+	buf.WriteString("<head>\n")
+	buf.WriteString("  <meta charset=\"utf-8\">\n")
+	buf.WriteString(fmt.Sprintf("  <title>%s</title>\n", title))
+	buf.WriteString("  <link rel=\"stylesheet\" href=\"" + swaggerUIStylesheetURL + "\">\n")
+	buf.WriteString("</head>\n")
+	buf.WriteString("<body>\n")
+	buf.WriteString("  <div id=\"swagger-ui\"></div>\n")
+	buf.WriteString("  <script src=\"" + swaggerUIBundleURL + "\"></script>\n")
+	buf.WriteString("  <script>window.ui = SwaggerUIBundle({ url: \"openapi.json\", dom_id: \"#swagger-ui\" });</script>\n")
+	buf.WriteString("</body>\n")
+	buf.WriteString("</html>`\n\n")
+
+	buf.WriteString("// redocPage renders openapi.json, relative to /docs, with Redoc\n")
+	buf.WriteString("const redocPage = `<!DOCTYPE html>\n")
+	buf.WriteString("<html lang=\"en\">\n")
+	buf.WriteString("<head>\n")
+	buf.WriteString("  <meta charset=\"utf-8\">\n")
+	buf.WriteString(fmt.Sprintf("  <title>%s</title>\n", title))
+	buf.WriteString("</head>\n")
+	buf.WriteString("<body>\n")
+	buf.WriteString("  <redoc spec-url=\"openapi.json\"></redoc>\n")
+	buf.WriteString("  <script src=\"" + redocBundleURL + "\"></script>\n")
+	buf.WriteString("</body>\n")
+	buf.WriteString("</html>`\n\n")
+
+	buf.WriteString(`// serveDocsUI serves the HTML documentation page of the given DocsUI
+func serveDocsUI(docsUI DocsUI) gin.HandlerFunc {
+	page := swaggerUIPage
+	if docsUI == DocsUIRedoc {
+		page = redocPage
+	}
+
+	return func(c *gin.Context) {
+		c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(page))
+	}
+}` + "\n\n")
+
+	return nil
+}
+
+// generateLifecycle generates RunServer, which registers the API on a gin engine and serves it until the context is
+// done or the process is signalled to stop, and its ServerConfig.
+func generateLifecycle(buf *bytes.Buffer, service *specification.Service) {
+	serviceName := strmangle.TitleCase(service.Name)
+
+	buf.WriteString(`// Defaults of ServerConfig
+const (
+	defaultServerAddr              = ":8080"
+	defaultServerReadHeaderTimeout = 10 * time.Second
+	defaultServerShutdownTimeout   = 30 * time.Second
+)
+
+// ServerConfig configures RunServer, the zero value serves HTTP on :8080 until SIGINT or SIGTERM
+type ServerConfig struct {
+	// Router is the engine the API is registered on, e.g. with additional middleware or routes.
+	// If nil, gin.Default() is used.
+	Router *gin.Engine
+
+	// Addr is the TCP address the server listens on. If empty, ":8080" is used.
+	Addr string
+
+	// TLSCertFile and TLSKeyFile are the certificate and key files the server serves HTTPS with
+	TLSCertFile string
+	TLSKeyFile  string
+
+	// TLSConfig is the TLS configuration of the server. If set, the server serves HTTPS, with the certificates
+	// of the configuration when TLSCertFile and TLSKeyFile are empty.
+	TLSConfig *tls.Config
+
+	// ReadHeaderTimeout is how long the server waits for the headers of a request. If zero, 10 seconds is used.
+	ReadHeaderTimeout time.Duration
+
+	// ShutdownTimeout is how long the in-flight requests and OnShutdown are given to complete once the server
+	// stops. If zero, 30 seconds is used.
+	ShutdownTimeout time.Duration
+
+	// Signals are the signals that stop the server. If empty, os.Interrupt and syscall.SIGTERM are used.
+	// A second signal terminates the process without waiting for the shutdown.
+	Signals []os.Signal
+
+	// OnStartup is called before the server starts listening, e.g. to connect to a database.
+	// An error aborts the start and is returned by RunServer.
+	OnStartup func(ctx context.Context) error
+
+	// OnShutdown is called after the server has stopped serving, e.g. to close database connections,
+	// with a context that expires with the ShutdownTimeout.
+	OnShutdown func(ctx context.Context) error
+}
+
+// RunServer registers the API on the router of the config and serves it until ctx is done or the process receives
+// one of the signals of the config. The server then stops accepting requests, waits for the in-flight requests and
+// calls OnShutdown. It returns the errors of starting, serving and shutting down, or nil after a graceful shutdown.
+func RunServer[Session any](ctx context.Context, api *` + serviceName + `API[Session], config ServerConfig) error {
+	router := config.Router
+	if router == nil {
+		router = gin.Default()
+	}
+	Register` + serviceName + `API(router, api)
+
+	server := &http.Server{
+		Addr:              config.Addr,
+		Handler:           router,
+		TLSConfig:         config.TLSConfig,
+		ReadHeaderTimeout: config.ReadHeaderTimeout,
+	}
+	if server.Addr == "" {
+		server.Addr = defaultServerAddr
+	}
+	if server.ReadHeaderTimeout == 0 {
+		server.ReadHeaderTimeout = defaultServerReadHeaderTimeout
+	}
+
+	signals := config.Signals
+	if len(signals) == 0 {
+		signals = []os.Signal{os.Interrupt, syscall.SIGTERM}
+	}
+	ctx, stop := signal.NotifyContext(ctx, signals...)
+	defer stop()
+
+	if config.OnStartup != nil {
+		if err := config.OnStartup(ctx); err != nil {
+			return fmt.Errorf("startup: %w", err)
+		}
+	}
+
+	serveErrors := make(chan error, 1)
+	go func() {
+		serveErrors <- listenAndServe(server, config)
+	}()
+
+	var serveErr error
+	select {
+	case serveErr = <-serveErrors:
+	case <-ctx.Done():
+	}
+
+	// Restore the default behavior of the signals, so that a second signal terminates the process
+	stop()
+
+	shutdownTimeout := config.ShutdownTimeout
+	if shutdownTimeout == 0 {
+		shutdownTimeout = defaultServerShutdownTimeout
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), shutdownTimeout)
+	defer cancel()
+
+	shutdownErr := server.Shutdown(shutdownCtx)
+	if shutdownErr != nil {
+		shutdownErr = fmt.Errorf("shutdown: %w", shutdownErr)
+	}
+	if config.OnShutdown != nil {
+		if err := config.OnShutdown(shutdownCtx); err != nil {
+			shutdownErr = errors.Join(shutdownErr, fmt.Errorf("shutdown: %w", err))
+		}
+	}
+
+	return errors.Join(serveErr, shutdownErr)
+}
+
+// listenAndServe serves HTTPS when the config has TLS and HTTP otherwise, returning nil once the server is shut down
+func listenAndServe(server *http.Server, config ServerConfig) error {
+	var err error
+	if config.TLSCertFile != "" || config.TLSKeyFile != "" || config.TLSConfig != nil {
+		err = server.ListenAndServeTLS(config.TLSCertFile, config.TLSKeyFile)
+	} else {
+		err = server.ListenAndServe()
+	}
+
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return fmt.Errorf("serve: %w", err)
+}` + "\n\n")
+}
+
+// generateCompression generates compressResponses, the middleware compressing the responses with gzip or deflate
+// as negotiated with the Accept-Encoding header, and parseAcceptHeader, which it shares with the content negotiation.
+func generateCompression(buf *bytes.Buffer) {
+	buf.WriteString(`// parseAcceptHeader returns the quality of each lower case value of an Accept or Accept-Encoding header
+func parseAcceptHeader(header string) map[string]float64 {
+	qualities := make(map[string]float64)
+	for _, part := range strings.Split(header, ",") {
+		params := strings.Split(part, ";")
+		name := strings.ToLower(strings.TrimSpace(params[0]))
+		if name == "" {
+			continue
+		}
+
+		quality := 1.0
+		for _, param := range params[1:] {
+			if value, ok := strings.CutPrefix(strings.TrimSpace(param), "q="); ok {
+				if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+					quality = parsed
+				}
+			}
+		}
+		qualities[name] = quality
+	}
+
+	return qualities
+}
+
+// negotiateEncoding returns the encoding of the response accepted with the highest quality by the Accept-Encoding
+// header, gzip before deflate, or an empty string when neither is accepted
+func negotiateEncoding(acceptEncoding string) string {
+	qualities := parseAcceptHeader(acceptEncoding)
+
+	encoding, encodingQuality := "", 0.0
+	for _, candidate := range []string{"gzip", "deflate"} {
+		quality, ok := qualities[candidate]
+		if !ok {
+			quality = qualities["*"]
+		}
+		if quality > encodingQuality {
+			encoding, encodingQuality = candidate, quality
+		}
+	}
+
+	return encoding
+}
+
+// compressWriter compresses what is written to the response, starting on the first write so that responses
+// without a body, such as 204 No Content, are left as they are
+type compressWriter struct {
+	gin.ResponseWriter
+	encoding   string
+	compressor interface {
+		io.WriteCloser
+		Flush() error
+	}
+}
+
+func (w *compressWriter) Write(data []byte) (int, error) {
+	if w.compressor == nil {
+		// Responses that are already encoded are written as they are
+		if w.Header().Get("Content-Encoding") != "" {
+			return w.ResponseWriter.Write(data)
+		}
+
+		w.Header().Set("Content-Encoding", w.encoding)
+		w.Header().Del("Content-Length")
+		if w.encoding == "gzip" {
+			w.compressor = gzip.NewWriter(w.ResponseWriter)
+		} else {
+			w.compressor = zlib.NewWriter(w.ResponseWriter)
+		}
+	}
+
+	return w.compressor.Write(data)
+}
+
+func (w *compressWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *compressWriter) Flush() {
+	if w.compressor != nil {
+		_ = w.compressor.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+// compressResponses compresses the responses with gzip or deflate when the request accepts it, see Server.Compression
+func compressResponses(c *gin.Context) {
+	c.Writer.Header().Add("Vary", "Accept-Encoding")
+
+	encoding := negotiateEncoding(c.GetHeader("Accept-Encoding"))
+	if encoding == "" {
+		return
+	}
+
+	writer := &compressWriter{ResponseWriter: c.Writer, encoding: encoding}
+	c.Writer = writer
+	defer func() {
+		if writer.compressor != nil {
+			_ = writer.compressor.Close()
+		}
+	}()
+
+	c.Next()
+}
+
+`)
+}
+
+// generateConditionalGet generates the lastModified methods of the response objects of the conditional GET endpoints,
+// and writeLastModified, which sets their Last-Modified header and compares it with the If-Modified-Since header.
+func generateConditionalGet(buf *bytes.Buffer, service *specification.Service, types Types, objectNames []string) {
+	buf.WriteString("// lastModifiedResponse is implemented by the responses that know when they were last updated\n")
+	buf.WriteString("type lastModifiedResponse interface {\n")
+	buf.WriteString("\tlastModified() (time.Time, bool)\n")
+	buf.WriteString("}\n\n")
+
+	for _, objectName := range objectNames {
+		field := service.GetLastModifiedField(objectName)
+		buf.WriteString(fmt.Sprintf("// lastModified returns the time the %s was last updated, or false if it is unknown\n", objectName))
+		buf.WriteString(fmt.Sprintf("func (r *%s) lastModified() (time.Time, bool) {\n", objectName))
+		buf.WriteString("\tif r == nil {\n")
+		buf.WriteString("\t\treturn time.Time{}, false\n")
+		buf.WriteString("\t}\n\n")
+		buf.WriteString(types.timeValue(*field, "r.Meta."+field.GetGoName()))
+		buf.WriteString("}\n\n")
+	}
+
+	buf.WriteString(`// writeLastModified sets the Last-Modified header of the response to a GET request when the response knows when it
+// was last updated, and reports whether it has not been updated since the If-Modified-Since header of the request
+func writeLastModified(c *gin.Context, response any) bool {
+	if c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead {
+		return false
+	}
+
+	modified, ok := response.(lastModifiedResponse)
+	if !ok {
+		return false
+	}
+
+	lastModified, ok := modified.lastModified()
+	if !ok {
+		return false
+	}
+
+	// HTTP dates have a precision of seconds
+	lastModified = lastModified.UTC().Truncate(time.Second)
+	c.Header("Last-Modified", lastModified.Format(http.TimeFormat))
+
+	// If-Modified-Since is ignored when the request has If-None-Match, as entity tags take precedence (RFC 9110)
+	if c.GetHeader("If-None-Match") != "" {
+		return false
+	}
+
+	since, err := http.ParseTime(c.GetHeader("If-Modified-Since"))
+	return err == nil && !lastModified.After(since)
+}
+
+`)
+}
+
+// generateAsyncOperations generates writeOperationHeaders, which sets the Location header of the responses of the
+// asynchronous endpoints to the path their Operation is polled at, and the Retry-After header of the responses with
+// an Operation to the seconds to wait before polling it again.
+func generateAsyncOperations(buf *bytes.Buffer, service *specification.Service, types Types) {
+	operation := service.GetObject(specification.OperationObjectName)
+	if operation == nil {
+		return
+	}
+
+	buf.WriteString(`// writeOperationHeaders sets the Location header of the responses of the asynchronous endpoints to the path their
+// operation is polled at, and the Retry-After header to the seconds to wait before polling the operation again
+func writeOperationHeaders(c *gin.Context, successStatusCode int, response any) {
+	operation, ok := response.(*` + specification.OperationObjectName + `)
+	if !ok || operation == nil {
+		return
+	}
+
+	if successStatusCode == http.StatusAccepted {
+		c.Header(` + fmt.Sprintf("%q", specification.LocationHeaderName) + `, ` + getOperationLocation(service) + `)
+	}
+
+`)
+	if field := operation.GetField(specification.OperationRetryAfterFieldName); field != nil {
+		buf.WriteString(types.headerAssignment(specification.RetryAfterHeaderName, *field, "operation."+field.GetGoName()))
+	}
+	buf.WriteString("}\n\n")
+}
+
+// generateCallbackClient generates CallbackClient, with a method per callback of the endpoints sending its payload
+// to the URL of a subscriber.
+func generateCallbackClient(buf *bytes.Buffer, service *specification.Service) {
+	buf.WriteString(`// CallbackClient sends the callbacks of the endpoints to the URLs of their subscribers, with the payload as JSON
+type CallbackClient struct {
+	// HTTPClient sends the callback requests, http.DefaultClient when nil
+	HTTPClient *http.Client
+}
+
+`)
+
+	for _, resource := range service.Resources {
+		for _, endpoint := range resource.Endpoints {
+			for _, callback := range endpoint.Callbacks {
+				method := callback.GetMethod()
+				name := resource.Name + endpoint.Name + callback.Name
+				buf.WriteString(fmt.Sprintf("// %s sends the %s callback of %s %s to the URL of the subscriber\n", name, callback.Name, resource.Name, endpoint.Name))
+				if callback.Description != "" {
+					buf.WriteString(fmt.Sprintf("//\n// %s\n", strings.ReplaceAll(strings.TrimSpace(callback.Description), "\n", "\n// ")))
+				}
+				buf.WriteString(fmt.Sprintf("func (c CallbackClient) %s(ctx context.Context, subscriberURL string, payload *%s) error {\n", name, callback.Payload))
+				buf.WriteString(fmt.Sprintf("\treturn c.send(ctx, http.Method%s%s, subscriberURL, payload)\n", method[:1], strings.ToLower(method[1:])))
+				buf.WriteString("}\n\n")
+			}
+		}
+	}
+
+	buf.WriteString(`// send sends the payload as JSON to the URL of the subscriber, and returns an error unless the subscriber
+// responds with a 2xx status code
+func (c CallbackClient) send(ctx context.Context, method, subscriberURL string, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode the callback payload: %w", err)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, subscriberURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create the callback request: %w", err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	client := c.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		return fmt.Errorf("failed to send the callback: %w", err)
+	}
+	defer response.Body.Close()
+
+	// The body is drained so that the connection can be reused
+	_, _ = io.Copy(io.Discard, response.Body)
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return fmt.Errorf("callback %s %s responded with status %d", method, subscriberURL, response.StatusCode)
+	}
+	return nil
+}
+
+`)
+}
+
+// generateRetryPolicy generates RetryPolicy and RetryTransport, which retry the outbound requests of an HTTP client
+// with the retry configuration of the service, the configuration the SDKs retry with.
+func generateRetryPolicy(buf *bytes.Buffer, service *specification.Service) {
+	retry := service.GetRetryConfigurationWithDefaults()
+
+	statusCodes := make([]string, len(retry.StatusCodes))
+	for i, statusCode := range retry.StatusCodes {
+		statusCodes[i] = fmt.Sprintf("%q", statusCode)
+	}
+
+	buf.WriteString(`// RetryPolicy is the exponential backoff the requests of RetryTransport are retried with
+type RetryPolicy struct {
+	// InitialInterval is the time to wait before the first retry
+	InitialInterval time.Duration
+
+	// MaxInterval is the longest time to wait between retries
+	MaxInterval time.Duration
+
+	// MaxElapsedTime is the longest time to retry a request for
+	MaxElapsedTime time.Duration
+
+	// Exponent multiplies the time to wait after every retry
+	Exponent float64
+
+	// StatusCodes are the status codes of the responses that are retried, e.g. 429, or 5XX for all 5xx codes
+	StatusCodes []string
+
+	// RetryConnectionErrors retries the requests that failed without a response
+	RetryConnectionErrors bool
+}
+
+// DefaultRetryPolicy is the retry configuration of the specification, which the SDKs of the service retry with
+var DefaultRetryPolicy = RetryPolicy{
+	InitialInterval:       ` + fmt.Sprint(retry.Backoff.InitialInterval) + ` * time.Millisecond,
+	MaxInterval:           ` + fmt.Sprint(retry.Backoff.MaxInterval) + ` * time.Millisecond,
+	MaxElapsedTime:        ` + fmt.Sprint(retry.Backoff.MaxElapsedTime) + ` * time.Millisecond,
+	Exponent:              ` + fmt.Sprint(retry.Backoff.Exponent) + `,
+	StatusCodes:           []string{` + strings.Join(statusCodes, ", ") + `},
+	RetryConnectionErrors: ` + fmt.Sprint(retry.RetryConnectionErrors) + `,
+}
+
+// shouldRetry reports whether the attempt is retried, when its response has one of the status codes or it failed
+// without a response for another reason than the request being canceled
+func (p RetryPolicy) shouldRetry(response *http.Response, err error) bool {
+	if err != nil {
+		return p.RetryConnectionErrors && !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+
+	statusCode := strconv.Itoa(response.StatusCode)
+	for _, code := range p.StatusCodes {
+		if code == statusCode || (strings.HasSuffix(code, "XX") && code[0] == statusCode[0]) {
+			return true
+		}
+	}
+	return false
+}
+
+// RetryTransport is an http.RoundTripper retrying the requests of Base with Policy, so that the calls to other
+// services retry like the SDKs, e.g. &http.Client{Transport: RetryTransport{Policy: DefaultRetryPolicy}}
+type RetryTransport struct {
+	// Base sends the attempts, http.DefaultTransport when nil
+	Base http.RoundTripper
+
+	// Policy is the backoff the requests are retried with
+	Policy RetryPolicy
+}
+
+// RoundTrip sends the request until it is not retried by the policy, its max elapsed time has passed or the request
+// is canceled. Requests with a body are only retried when the body can be read again with GetBody.
+func (t RetryTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	if request.Body != nil && request.Body != http.NoBody && request.GetBody == nil {
+		return base.RoundTrip(request)
+	}
+
+	start := time.Now()
+	interval := t.Policy.InitialInterval
+	attempt := request
+	for {
+		response, err := base.RoundTrip(attempt)
+		if !t.Policy.shouldRetry(response, err) || time.Since(start)+interval > t.Policy.MaxElapsedTime {
+			return response, err
+		}
+
+		// The body of a retried response is drained so that the connection can be reused
+		if response != nil {
+			_, _ = io.Copy(io.Discard, response.Body)
+			_ = response.Body.Close()
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-request.Context().Done():
+			timer.Stop()
+			return nil, request.Context().Err()
+		case <-timer.C:
+		}
+		interval = min(time.Duration(float64(interval)*t.Policy.Exponent), t.Policy.MaxInterval)
+
+		if request.GetBody != nil {
+			body, err := request.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attempt = request.Clone(request.Context())
+			attempt.Body = body
+		}
+	}
+}
+
+`)
+}
+
+// getOperationLocation returns the Go expression of the path the operation is polled at, the path of the Get endpoint
+// of the Operation resource with the ID of the operation and the tenant of the request.
+func getOperationLocation(service *specification.Service) string {
+	for _, resource := range service.Resources {
+		for _, endpoint := range resource.Endpoints {
+			if !service.IsOperationPoll(resource, endpoint) {
+				continue
+			}
+
+			location := fmt.Sprintf("%q", joinGinPaths(service.GetBasePath(), service.Tenancy.GetPathPrefix()+service.GetEndpointPath(resource, endpoint)))
+			for _, param := range endpoint.Request.PathParams {
+				location = strings.ReplaceAll(location, "{"+param.TagJSON()+"}", `" + operation.`+param.GetGoName()+`.String() + "`)
+			}
+			if service.Tenancy.IsPath() {
+				location = strings.ReplaceAll(location, "{"+service.Tenancy.GetParameterName()+"}", fmt.Sprintf(`" + c.Param(%q) + "`, service.Tenancy.GetParameterName()))
+			}
+			return strings.TrimSuffix(location, ` + ""`)
+		}
+	}
+	return `""`
+}
+
+// generateContentNegotiation generates ResponseEncoder and offerContentTypes, which negotiates the content type of
+// the responses of the endpoints with alternative content types with the Accept header.
+func generateContentNegotiation(buf *bytes.Buffer) {
+	buf.WriteString(`// ResponseEncoder writes the response of an endpoint in an alternative content type, see Server.ResponseEncoders.
+// The response is the pointer to the response type of the endpoint, e.g. *UserExportResponse.
+type ResponseEncoder func(ctx context.Context, w io.Writer, response any) error
+
+// responseContentTypeContextKey is the gin context key holding the content type of the response when it is not JSON,
+// the negotiated alternative content type or application/xml of the endpoints with XML responses
+const responseContentTypeContextKey = "responseContentType"
+
+// negotiateContentType returns the offer accepted with the highest quality by the Accept header, preferring the
+// earlier offers, or the first offer when the header is empty or accepts none of them
+func negotiateContentType(accept string, offers []string) string {
+	if accept == "" {
+		return offers[0]
+	}
+
+	qualities := parseAcceptHeader(accept)
+
+	contentType, contentTypeQuality := offers[0], 0.0
+	for _, offer := range offers {
+		mainType, _, _ := strings.Cut(offer, "/")
+		quality, ok := qualities[offer]
+		if !ok {
+			quality, ok = qualities[mainType+"/*"]
+		}
+		if !ok {
+			quality = qualities["*/*"]
+		}
+		if quality > contentTypeQuality {
+			contentType, contentTypeQuality = offer, quality
+		}
+	}
+
+	return contentType
+}
+
+// offerContentTypes negotiates the content type of the response between JSON and the alternative content types of
+// the endpoint, and stores it in the gin context when it is an alternative one, so serveWithResponse encodes it
+func offerContentTypes(contentTypes ...string) gin.HandlerFunc {
+	offers := append([]string{"application/json"}, contentTypes...)
+
+	return func(c *gin.Context) {
+		if contentType := negotiateContentType(c.GetHeader("Accept"), offers); contentType != offers[0] {
+			c.Set(responseContentTypeContextKey, contentType)
+		}
+	}
+}
+
+`)
+}
+
+// generateXMLRequests generates acceptXML and decodeXMLBodyParams, which decodes the body params of the endpoints
+// with XML requests.
+func generateXMLRequests(buf *bytes.Buffer) {
+	buf.WriteString(`// xmlRequestContextKey is the gin context key set for the endpoints whose body params are decoded from XML
+const xmlRequestContextKey = "xmlRequest"
+
+// acceptXML marks the request of an endpoint with XML requests, so its body params are decoded with decodeXMLBodyParams
+func acceptXML(c *gin.Context) {
+	c.Set(xmlRequestContextKey, true)
+}
+
+// decodeXMLBodyParams decodes the body params from XML, with the xml tags of the fields. Elements that are not
+// fields are ignored, since unknown fields are only checked in JSON
+func decodeXMLBodyParams[T any](r *http.Request) (T, error) {
+	var v T
+	setDefaults(&v)
+
+	if err := xml.NewDecoder(r.Body).Decode(&v); err != nil {
+		return v, err
+	}
+
+	return v, nil
+}
+
+`)
+}
+
+// generateFormRequests generates acceptForm and decodeFormBodyParams, which decodes the body params of the endpoints
+// with form requests.
+func generateFormRequests(buf *bytes.Buffer) {
+	buf.WriteString(`// formRequestContextKey is the gin context key set for the endpoints whose body params are decoded from a form
+const formRequestContextKey = "formRequest"
+
+// acceptForm marks the request of an endpoint with form requests, so its body params are decoded with decodeFormBodyParams
+func acceptForm(c *gin.Context) {
+	c.Set(formRequestContextKey, true)
+}
+
+// formParser is implemented by the body params types of the endpoints with form requests, to parse the form without reflection
+type formParser interface {
+	parseForm(form url.Values) error
+}
+
+// decodeFormBodyParams decodes the body params from an application/x-www-form-urlencoded body with the parseForm
+// method of the type. Like the query parameters, absent fields keep their defaults and a scalar takes the first value
+func decodeFormBodyParams[T any](r *http.Request) (T, error) {
+	var v T
+	setDefaults(&v)
+
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType != "application/x-www-form-urlencoded" {
+		return v, fmt.Errorf("the content type must be application/x-www-form-urlencoded, got '%s'", mediaType)
+	}
+
+	if err := r.ParseForm(); err != nil {
+		return v, err
+	}
+
+	if parser, ok := any(&v).(formParser); ok {
+		err := parser.parseForm(r.PostForm)
+		return v, err
+	}
+
+	return v, nil
+}
+
+`)
+}
+
+// generateXMLResponses generates respondXML and the default encoder of the XML responses.
+func generateXMLResponses(buf *bytes.Buffer) {
+	buf.WriteString(`// respondXML sets the content type of the response of an endpoint with XML responses, so serveWithResponse encodes it
+func respondXML(c *gin.Context) {
+	c.Set(responseContentTypeContextKey, "application/xml")
+}
+
+// encodeXML encodes the response with encoding/xml after the XML declaration, with the name of the response type
+// as the root element, e.g. <User> for *User
+func encodeXML(ctx context.Context, w io.Writer, response any) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	return xml.NewEncoder(w).Encode(response)
+}
+
+// getResponseEncoder returns the encoder of the content type, or encodeXML for application/xml without an encoder
+func getResponseEncoder(encoders map[string]ResponseEncoder, contentType string) ResponseEncoder {
+	if encoder := encoders[contentType]; encoder != nil {
+		return encoder
+	}
+
+	return encodeXML
+}
+
+`)
+}
+
+// isPaginationObject reports whether the object has the non-nullable Int fields Offset and Limit of the default
+// Pagination object, and Total when withTotal is true, which are the fields the pagination helpers fill.
+func isPaginationObject(service *specification.Service, name string, withTotal bool) bool {
+	object := service.GetObject(name)
+	if object == nil {
+		return false
+	}
+
+	names := []string{"Offset", paginationLimitFieldName}
+	if withTotal {
+		names = append(names, "Total")
+	}
+
+	for _, fieldName := range names {
+		index := slices.IndexFunc(object.Fields, func(field specification.Field) bool { return field.Name == fieldName })
+		if index < 0 {
+			return false
+		}
+
+		field := object.Fields[index]
+		if field.Type != specification.FieldTypeInt || field.IsArray() || field.IsNullable() || field.IsOptional() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ClampsLimit reports whether the generated server clamps the limit query parameter of the endpoint to the MaxLimit
+// of the service before calling the handler, which it does for the List and Search endpoints whose query parameters
+// are all parsed directly by parseQueryParams, instead of through the form binding of gin.
+func ClampsLimit(service *specification.Service, endpoint specification.Endpoint) bool {
+	paginated := endpoint.Name == specification.OperationList || endpoint.Name == specification.OperationSearch
+	if !paginated || !hasMaxLimit(service) {
+		return false
+	}
+
+	return !slices.ContainsFunc(endpoint.Request.QueryParams, func(field specification.Field) bool {
+		return service.IsObject(service.GetPrimitiveType(field.Type))
+	})
+}
+
+// hasMaxLimit reports whether the server declares the MaxLimit of the service with its pagination helpers.
+func hasMaxLimit(service *specification.Service) bool {
+	return service.MaxLimit > 0 && service.HasPaginatedEndpoints() && isPaginationObject(service, specification.PaginationObjectName, true)
+}
+
+// generatePaginationHelpers generates the helpers the handlers of the List and Search endpoints build their pages
+// with: ClampPagination clamping the offset and limit to the bounds of the pagination, with the max limit of the
+// service, PageItems windowing a slice and NewPage returning the page with its Pagination, and NewPageWithoutTotal
+// its PaginationWithoutTotal when a resource returns the total in a header.
+func generatePaginationHelpers(buf *bytes.Buffer, service *specification.Service, types Types) {
+	buf.WriteString("// DefaultLimit is the limit of the List and Search endpoints without a limit in the request\n")
+	buf.WriteString(fmt.Sprintf("const DefaultLimit = %d\n\n", specification.DefaultLimit))
+
+	clampDoc, clampMax := "", ""
+	if hasMaxLimit(service) {
+		buf.WriteString("// MaxLimit is the maximum limit of the List and Search endpoints, larger limits are clamped to it\n")
+		buf.WriteString(fmt.Sprintf("const MaxLimit = %d\n\n", service.MaxLimit))
+
+		clampDoc = " and a limit above MaxLimit is MaxLimit"
+		clampMax = `
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}`
+	}
+
+	buf.WriteString(`// ClampPagination clamps the offset and limit of a List or Search request to the bounds of the pagination: a negative
+// offset is 0, a limit below 1 is DefaultLimit` + clampDoc + `. The handlers clamp the
+// pagination of the request before fetching the items of the page
+func ClampPagination(offset, limit int64) (int64, int64) {
+	if offset < 0 {
+		offset = 0
+	}
+	if limit < 1 {
+		limit = DefaultLimit
+	}` + clampMax + `
+	return offset, limit
+}
+
+// PageItems returns the items of the page from the offset with at most limit items, clamped with ClampPagination,
+// for the handlers paginating all the items in memory
+func PageItems[T any](items []T, offset, limit int64) []T {
+	offset, limit = ClampPagination(offset, limit)
+	if offset >= int64(len(items)) {
+		return []T{}
+	}
+
+	return items[offset:min(offset+limit, int64(len(items)))]
+}
+
+`)
+
+	buf.WriteString(`// NewPage returns the items of the page, at most limit of them, and the Pagination of the page, where total is the
+// number of items of all the pages. The offset and limit are clamped with ClampPagination
+func NewPage[T any](items []T, total, offset, limit int64) ([]T, Pagination) {
+	offset, limit = ClampPagination(offset, limit)
+	if int64(len(items)) > limit {
+		items = items[:limit]
+	}
+
+	return items, Pagination{
+		Offset: ` + types.Int("offset") + `,
+		Limit:  ` + types.Int("limit") + `,
+		Total:  ` + types.Int("total") + `,
+	}
+}
+
+`)
+
+	if !isPaginationObject(service, specification.PaginationWithoutTotalObjectName, false) {
+		return
+	}
+
+	buf.WriteString(`// NewPageWithoutTotal returns the items of the page like NewPage, with the PaginationWithoutTotal of the resources
+// that return the total in the ` + specification.TotalCountHeaderName + ` header instead
+func NewPageWithoutTotal[T any](items []T, offset, limit int64) ([]T, PaginationWithoutTotal) {
+	offset, limit = ClampPagination(offset, limit)
+	if int64(len(items)) > limit {
+		items = items[:limit]
+	}
+
+	return items, PaginationWithoutTotal{
+		Offset: ` + types.Int("offset") + `,
+		Limit:  ` + types.Int("limit") + `,
+	}
+}
+
+`)
+}
+
+// generateRequestLimits generates the limitRequest handler limiting the size of the request body and the time of the
+// handler of the endpoints with request limits, and requestTimeoutError when any endpoint has a timeout.
+func generateRequestLimits(buf *bytes.Buffer, service *specification.Service, types Types) {
+	buf.WriteString(`// limitRequest limits the size of the request body to maxBodyBytes and the time of the handler to timeout, zero
+// means no limit. Reading a larger body fails with http.MaxBytesError, responded with ErrorCodePayloadTooLarge,
+// and the context of the request is canceled when the timeout has passed
+func limitRequest(maxBodyBytes int64, timeout time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if maxBodyBytes > 0 {
+			c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxBodyBytes)
+		}
+
+		if timeout > 0 {
+			ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+			defer cancel()
+
+			c.Request = c.Request.WithContext(ctx)
+		}
+
+		c.Next()
+	}
+}
+
+`)
+
+	if !service.HasHandlerTimeouts() {
+		return
+	}
+
+	buf.WriteString("// requestTimeoutError returns the error of a handler as an Error with ErrorCodeRequestTimeout when the timeout of\n")
+	buf.WriteString("// the request has passed, unless it is an Error already\n")
+	buf.WriteString("func requestTimeoutError(ctx context.Context, err error) error {\n")
+	buf.WriteString("\tif err == nil || !errors.Is(ctx.Err(), context.DeadlineExceeded) {\n")
+	buf.WriteString("\t\treturn err\n")
+	buf.WriteString("\t}\n\n")
+	buf.WriteString("\tvar apiError *Error\n")
+	buf.WriteString("\tif errors.As(err, &apiError) {\n")
+	buf.WriteString("\t\treturn err\n")
+	buf.WriteString("\t}\n\n")
+	buf.WriteString("\treturn &Error{\n")
+	buf.WriteString("\t\tCode: ErrorCodeRequestTimeout,\n")
+	buf.WriteString(fmt.Sprintf("\t\t%s: %s,\n", service.GetErrorMessageFieldName(), types.String(`"the request timed out"`)))
+	buf.WriteString("\t\tcause: err,\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("}\n\n")
+}
+
+// generateResponseBuffers generates writeJSON, which writes the responses like c.JSON from a pool of buffers,
+// that the responses of the alternative content types are encoded into as well.
+func generateResponseBuffers(buf *bytes.Buffer) {
+	buf.WriteString(`// maxPooledResponseBufferSize is the capacity above which a response buffer is not returned to the pool,
+// so that the memory of a single large response is not kept
+const maxPooledResponseBufferSize = 64 << 10
+
+// responseBuffers pools the buffers the responses are encoded into, so that a buffer is not allocated per response
+var responseBuffers = sync.Pool{
+	New: func() any {
+		return new(bytes.Buffer)
+	},
+}
+
+// getResponseBuffer returns an empty buffer from the pool, to be returned with putResponseBuffer
+func getResponseBuffer() *bytes.Buffer {
+	buf := responseBuffers.Get().(*bytes.Buffer)
+	buf.Reset()
+	return buf
+}
+
+// putResponseBuffer returns the buffer to the pool, unless it has grown beyond maxPooledResponseBufferSize
+func putResponseBuffer(buf *bytes.Buffer) {
+	if buf.Cap() <= maxPooledResponseBufferSize {
+		responseBuffers.Put(buf)
+	}
+}
+
+// writeJSON writes the response as JSON with the status code like c.JSON, encoding it into a pooled buffer
+func writeJSON(c *gin.Context, status int, response any) {
+	buf := getResponseBuffer()
+	defer putResponseBuffer(buf)
+
+	if err := json.NewEncoder(buf).Encode(response); err != nil {
+		c.Status(status)
+		_ = c.Error(err)
+		c.Abort()
+		return
+	}
+
+	// The encoder ends the JSON with a newline, which c.JSON does not write
+	c.Data(status, "application/json; charset=utf-8", bytes.TrimSuffix(buf.Bytes(), []byte("\n")))
+}
+
+`)
+}
+
+// generateRedaction generates the helpers redacting the fields of the responses that require scopes, calling the
+// redact methods of the response types.
+func generateRedaction(buf *bytes.Buffer, importedObjects []string) {
+	buf.WriteString(`// redacter is implemented by the response types with fields that require scopes
+type redacter interface {
+	redact(hasScope func(scope string) bool)
+}
+
+// newHasScope returns whether the session has been granted a scope, asking Server.HasScopeFunc once per scope
+func newHasScope[Session any](ctx context.Context, hasScopeFunc HasScopeFunc[Session], session Session) func(scope string) bool {
+	granted := map[string]bool{}
+	return func(scope string) bool {
+		if _, ok := granted[scope]; !ok {
+			granted[scope] = hasScopeFunc(ctx, session, scope)
+		}
+		return granted[scope]
+	}
+}
+
+`)
+
+	// The imported objects of the shared packages cannot implement redacter, and are redacted by their functions
+	buf.WriteString("// redactResponse sets the fields of the response requiring scopes the session has not been granted to null\n")
+	buf.WriteString("func redactResponse(response any, hasScope func(scope string) bool) {\n")
+	if len(importedObjects) == 0 {
+		buf.WriteString("\tif r, ok := response.(redacter); ok {\n")
+		buf.WriteString("\t\tr.redact(hasScope)\n")
+		buf.WriteString("\t}\n")
+	} else {
+		buf.WriteString("\tswitch r := response.(type) {\n")
+		buf.WriteString("\tcase redacter:\n")
+		buf.WriteString("\t\tr.redact(hasScope)\n")
+		for _, name := range importedObjects {
+			buf.WriteString(fmt.Sprintf("\tcase *%s:\n", name))
+			buf.WriteString(fmt.Sprintf("\t\tredact%s(r, hasScope)\n", name))
+		}
+		buf.WriteString("\t}\n")
+	}
+	buf.WriteString("}\n\n")
+
+	buf.WriteString(`// redactField sets the field to its zero value, which is null for the nullable fields
+func redactField[T any](field *T) {
+	var zero T
+	*field = zero
+}
+
+`)
+}
+
+// generateAudit generates the audit handler marking the requests of the audited endpoints, and writeAuditEvent,
+// which calls Server.AuditFunc with the event of a successful request of an audited endpoint.
+func generateAudit(buf *bytes.Buffer, service *specification.Service) {
+	tenantID := ""
+	if service.Tenancy != nil {
+		tenantID = "\n\t\tTenantID:  request.TenantID,"
+	}
+
+	buf.WriteString(`// auditContextKey is the gin context key holding the auditedOperation of the endpoint of the request
+const auditContextKey = "auditedOperation"
+
+// auditedOperation is the resource and operation of an endpoint audited with Server.AuditFunc
+type auditedOperation struct {
+	resource  string
+	operation string
+}
+
+// audit marks the requests of the endpoint as the operation of the resource, so serveWithResponse and
+// serveWithoutResponse audit them when they succeed
+func audit(resource, operation string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(auditContextKey, auditedOperation{resource: resource, operation: operation})
+	}
+}
+
+// writeAuditEvent calls the audit function with the event of a successful request of an audited endpoint. The ID
+// of the entity is the id path parameter of the request, or else the id of the response, e.g. of a created entity.
+func writeAuditEvent[sessionType, pathParamsType, queryParamsType, bodyParamsType any](c *gin.Context, auditFunc AuditFunc[sessionType], request Request[sessionType, pathParamsType, queryParamsType, bodyParamsType], response any) {
+	value, ok := c.Get(auditContextKey)
+	if !ok {
+		return
+	}
+	audited := value.(auditedOperation)
+
+	entityID := c.Param("id")
+	if entityID == "" {
+		entityID = getEntityID(response)
+	}
+
+	auditFunc(c.Request.Context(), AuditEvent[sessionType]{
+		Resource:  audited.resource,
+		Operation: audited.operation,
+		EntityID:  entityID,
+		Session:   request.Session,
+		RequestID: request.requestContext.RequestID,` + tenantID + `
+	})
+}
+
+// getEntityID returns the id of the entity of a response, or an empty string if it has none
+func getEntityID(response any) string {
+	data, err := json.Marshal(response)
+	if err != nil {
+		return ""
+	}
+
+	var entity struct {
+		ID string ` + "`json:\"id\"`" + `
+	}
+	if err := json.Unmarshal(data, &entity); err != nil {
+		return ""
+	}
+	return entity.ID
+}
+
+`)
+}
+
+func generateUtils(buf *bytes.Buffer, service *specification.Service, types Types, templates templateSet) error {
+	buf.WriteString(`// defaultGetRequestID is the default request ID generator function
+// It generates a new UUID for each request
+func defaultGetRequestID(ctx context.Context) string {
+	return uuid.New().String()
+}
+
+// requestIDHeader is the header the request ID is read from, and written to on every response
+const requestIDHeader = "` + specification.RequestIDHeaderName + `"
+
+// requestIDContextKey is the gin context key holding the request ID
+const requestIDContextKey = "requestID"
+
+// maxRequestIDLength is the maximum length of a request ID read from the request
+const maxRequestIDLength = 128
+
+// propagateRequestID reads the request ID from the request header, or generates one with getRequestID when it is
+// absent or invalid, stores it in the gin context for the RequestContext and sets it on the response
+func propagateRequestID(getRequestID func(ctx context.Context) string) gin.HandlerFunc {
+	if getRequestID == nil {
+		getRequestID = defaultGetRequestID
+	}
+
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(requestIDHeader)
+		if !isValidRequestID(requestID) {
+			requestID = getRequestID(c.Request.Context())
+		}
+
+		c.Set(requestIDContextKey, requestID)
+		c.Header(requestIDHeader, requestID)
+		c.Next()
+	}
+}
+
+// getContextRequestID returns the request ID stored in the gin context by propagateRequestID, or generates one with
+// getRequestID when the handler is served without the middleware, like when calling it directly in tests
+func getContextRequestID(c *gin.Context, getRequestID func(ctx context.Context) string) string {
+	if requestID := c.GetString(requestIDContextKey); requestID != "" {
+		return requestID
+	}
+
+	if getRequestID == nil {
+		getRequestID = defaultGetRequestID
+	}
+
+	return getRequestID(c.Request.Context())
+}
+
+// isValidRequestID reports whether the request ID read from the request is safe to propagate,
+// which is when it is at most maxRequestIDLength visible ASCII characters
+func isValidRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		return false
+	}
+
+	for i := 0; i < len(requestID); i++ {
+		if requestID[i] < '!' || requestID[i] > '~' {
+			return false
+		}
+	}
+
+	return true
+}
+
+`)
+
+	// Always generate setResponseHeaders function
+	buf.WriteString(`// setResponseHeaders sets the response headers from ResponseHeaders struct to gin context
+func setResponseHeaders(c *gin.Context, headers ResponseHeaders) {
+`)
+
+	// Generate explicit header setting code for each response header
+	if len(service.ResponseHeaders) > 0 {
+		for _, field := range service.ResponseHeaders {
+			// Use the header name on the wire for the HTTP header, sanitized name for Go struct field
+			fieldName := field.GetGoName()
+			buf.WriteString(types.headerAssignment(field.GetHeaderName(), field, "headers."+fieldName))
+		}
+	}
+
+	buf.WriteString("}\n\n")
+
+	errorObject := service.GetObject("Error")
+	hasRequestID := errorObject != nil && errorObject.HasField("RequestID")
+
+	// writeError returns the statement writing the error response of the *Error expression
+	writeError := func(apiError string) string {
+		if hasRequestID {
+			apiError = "withRequestID(" + apiError + ", requestContext.RequestID)"
+		}
+		if service.Localization != nil {
+			apiError = "localizeError(requestContext.Locale, " + apiError + ")"
+		}
+		if service.IsProblemDetails() {
+			return "writeProblem(c, " + apiError + ")"
+		}
+		return "c.JSON(" + apiError + ".Response())"
+	}
+
+	if hasRequestID {
+		buf.WriteString("// withRequestID sets the request ID on the error unless it has one, so that every error body carries it\n")
+		buf.WriteString("func withRequestID(err *Error, requestID string) *Error {\n")
+		buf.WriteString(fmt.Sprintf("\tif %s == \"\" {\n", types.StringValue("err.RequestID")))
+		buf.WriteString(fmt.Sprintf("\t\terr.RequestID = %s\n", types.String("requestID")))
+		buf.WriteString("\t}\n")
+		buf.WriteString("\treturn err\n")
+		buf.WriteString("}\n\n")
+	}
+
+	if service.Localization != nil {
+		generateLocalization(buf, service, types)
+	}
 
-	buf.WriteString("// PreHooks is an optional helper type if you prefer a named slice.\n")
-	buf.WriteString("type PreHooks []PreHook\n\n")
+	if service.IsProblemDetails() {
+		buf.WriteString("// writeProblem writes the error as a Problem Details (RFC 9457) response with the application/problem+json\n")
+		buf.WriteString("// content type, with the path of the request as the instance unless the error has one\n")
+		buf.WriteString("func writeProblem(c *gin.Context, err *Error) {\n")
+		buf.WriteString("\tstatus, problem := err.Response()\n")
+		if errorObject != nil && errorObject.HasField("Instance") {
+			buf.WriteString(fmt.Sprintf("\tif %s == \"\" {\n", types.StringValue("problem.Instance")))
+			buf.WriteString(fmt.Sprintf("\t\tproblem.Instance = %s\n", types.String("c.Request.URL.Path")))
+			buf.WriteString("\t}\n")
+		}
+		buf.WriteString("\n")
+		buf.WriteString("\tc.Header(\"Content-Type\", \"application/problem+json\")\n")
+		buf.WriteString("\tc.JSON(status, problem)\n")
+		buf.WriteString("}\n\n")
+	}
 
-	// Generate SessionHook types
-	buf.WriteString("// SessionHook runs after authentication. Return nil to continue; non-nil to abort.\n")
-	buf.WriteString("// SessionHooks have access to both the RequestContext and the authenticated Session,\n")
-	buf.WriteString("// enabling use cases such as:\n")
-	buf.WriteString("//   - Logging authenticated requests with session/user information\n")
-	buf.WriteString("//   - Rate limiting based on user or tenant from the session\n")
-	buf.WriteString("//   - Authorization checks to verify the user can access the requested resource\n")
-	buf.WriteString("//   - Metrics collection for authenticated API usage\n")
-	buf.WriteString("//   - Session validation or enrichment\n")
-	buf.WriteString("type SessionHook[Session any] func(ctx context.Context, requestContext RequestContext, session Session) error\n\n")
+	generateCompression(buf)
 
-	buf.WriteString("// SessionHooks is an optional helper type if you prefer a named slice.\n")
-	buf.WriteString("type SessionHooks[Session any] []SessionHook[Session]\n\n")
+	// The path parameters of sub-resources routed with the wildcard names of their parents are renamed back
+	if hasRenamedPathParams(service) {
+		buf.WriteString(`// renamePathParams names the path parameters of the request by their position, for the routes of sub-resources
+// that share the wildcards of the routes of their parents, e.g. /post/:id/comment/:id for /post/{postID}/comment/{id}
+func renamePathParams(names ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		for i := range c.Params {
+			c.Params[i].Key = names[i]
+		}
+	}
+}
 
-	// Generate RequestContext struct
-	buf.WriteString("type RequestContext struct {\n")
-	buf.WriteString("\t// ID of the request, can be used for debugging.\n")
-	buf.WriteString("\tRequestID string `json:\"requestId\"`\n\n")
-	buf.WriteString("\t// Path of the request. For example, /employee/123/archive\n")
-	buf.WriteString("\tPath string `json:\"path\"`\n\n")
-	buf.WriteString("\t// Route of the request. For example, /employee/:employee_id/archive\n")
-	buf.WriteString("\tRoute string `json:\"route\"`\n\n")
-	buf.WriteString("\t// UserAgent of the request\n")
-	buf.WriteString("\tUserAgent string `json:\"userAgent\"`\n\n")
-	buf.WriteString("\t// HTTPMethod of the request. For example, POST\n")
-	buf.WriteString("\tHTTPMethod string `json:\"httpMethod\"`\n\n")
-	buf.WriteString("\t// IPAddress of the request.\n")
-	buf.WriteString("\tIPAddress string `json:\"ipAddress\"`\n")
-	buf.WriteString("}\n\n")
+`)
+	}
 
-	// Generate Request struct
-	buf.WriteString("type Request[sessionType, pathParamsType, queryParamsType, bodyParamsType any] struct {\n")
-	buf.WriteString("\trequestContext RequestContext `json:\"-\"` // Unexported field\n")
-	buf.WriteString("\tSession sessionType `json:\"-\"`\n")
-	buf.WriteString("\tPathParams pathParamsType `json:\"-\"`\n")
-	buf.WriteString("\tQueryParams queryParamsType `json:\"-\"`\n")
-	buf.WriteString("\tBodyParams bodyParamsType `json:\"-\"`\n")
-	buf.WriteString("}\n\n")
+	// The responses of endpoints with alternative content types are encoded in the negotiated content type
+	writeResponse := ""
+	if hasResponseEncoders(service) {
+		generateContentNegotiation(buf)
 
-	buf.WriteString("func (r Request[sessionType, pathParamsType, queryParamsType, bodyParamsType]) Context() RequestContext {\n")
-	buf.WriteString("\treturn r.requestContext\n")
-	buf.WriteString("}\n\n")
+		encode := "server.ResponseEncoders[contentType]"
+		if service.HasXMLResponses() {
+			generateXMLResponses(buf)
+			encode = "getResponseEncoder(server.ResponseEncoders, contentType)"
+		}
 
-	for _, resource := range service.Resources {
-		for _, endpoint := range resource.Endpoints {
-			if len(endpoint.Request.PathParams) > 0 {
-				buf.WriteString(fmt.Sprintf("type %s struct {\n", endpoint.GetPathParamsType(resource.Name)))
-				for _, field := range endpoint.Request.PathParams {
-					buf.WriteString(fmt.Sprintf("\t%s %s `json:\"%s\"`\n", field.Name, getTypeForGo(field, service), field.TagJSON()))
-				}
-				buf.WriteString("}\n\n")
-			}
+		writeResponse = `if contentType := c.GetString(responseContentTypeContextKey); contentType != "" {
+			body := getResponseBuffer()
+			defer putResponseBuffer(body)
 
-			if len(endpoint.Request.QueryParams) > 0 {
-				buf.WriteString(fmt.Sprintf("type %s struct {\n", endpoint.GetQueryParamsType(resource.Name)))
-				for _, field := range endpoint.Request.QueryParams {
-					buf.WriteString(fmt.Sprintf("\t%s %s `form:\"%s\" json:\"%s\"`\n", field.Name, getTypeForGo(field, service), field.TagJSON(), field.TagJSON()))
-				}
-				buf.WriteString("}\n\n")
+			if err := ` + encode + `(c.Request.Context(), body, response); err != nil {
+				` + writeError("server.ErrorHook(c.Request.Context(), requestContext, &request.Session, err)") + `
+				return
 			}
 
-			if len(endpoint.Request.BodyParams) > 0 {
-				buf.WriteString(fmt.Sprintf("type %s struct {\n", endpoint.GetBodyParamsType(resource.Name)))
-				for _, field := range endpoint.Request.BodyParams {
-					buf.WriteString(fmt.Sprintf("\t%s %s `json:\"%s\"`\n", field.Name, getTypeForGo(field, service), field.TagJSON()))
-				}
-				buf.WriteString("}\n\n")
-			}
+			c.Data(successStatusCode, contentType, body.Bytes())
+			return
 		}
+
+		`
 	}
 
-	return nil
-}
+	// The successful requests of the mutating endpoints are audited
+	writeAudit, writeAuditWithoutResponse := "", ""
+	if hasAuditedEndpoints(service) {
+		generateAudit(buf, service)
 
-func generateResponseTypes(buf *bytes.Buffer, service *specification.Service) error {
-	for _, resource := range service.Resources {
-		for _, endpoint := range resource.Endpoints {
-			if len(endpoint.Response.BodyFields) == 0 {
-				continue
-			}
+		writeAudit = `if server.AuditFunc != nil {
+			writeAuditEvent(c, server.AuditFunc, request, response)
+		}
 
-			buf.WriteString(fmt.Sprintf("type %s struct {\n", endpoint.GetResponseType(resource.Name)))
-			for _, field := range endpoint.Response.BodyFields {
-				buf.WriteString(fmt.Sprintf("\t%s %s `json:\"%s\"`\n", field.Name, getTypeForGo(field, service), field.TagJSON()))
-			}
-			buf.WriteString("}\n\n")
+		`
+		writeAuditWithoutResponse = `if server.AuditFunc != nil {
+			writeAuditEvent(c, server.AuditFunc, request, nil)
 		}
+
+		`
 	}
 
-	return nil
-}
+	// The errors of the handlers whose timeout has passed are responded as timeouts
+	mapTimeout := ""
+	if service.HasRequestLimits() {
+		generateRequestLimits(buf, service, types)
+	}
+	if service.HasHandlerTimeouts() {
+		mapTimeout = `err = requestTimeoutError(c.Request.Context(), err)
+		`
+	}
 
-func generateResponseHeaderTypes(buf *bytes.Buffer, service *specification.Service) error {
-	// Always generate ResponseHeaders struct (empty if no headers defined)
-	buf.WriteString("// ResponseHeaders contains the common response headers returned by all endpoints\n")
-	buf.WriteString("type ResponseHeaders struct {\n")
-	for _, field := range service.ResponseHeaders {
-		// Sanitize field name to ensure it's a valid Go identifier (remove hyphens)
-		fieldName := sanitizeHeaderName(field.Name)
-		buf.WriteString(fmt.Sprintf("\t%s %s\n", fieldName, getTypeForGo(field, service)))
+	// The fields requiring scopes the session has not been granted are redacted
+	writeRedacted, redactRows, redactRow := "", "", ""
+	if service.HasScopedFields() {
+		generateRedaction(buf, getImportedObjects(service, types, getRedactedObjects(service)))
+
+		writeRedacted = `redactResponse(response, newHasScope(c.Request.Context(), server.HasScopeFunc, request.Session))
+
+		`
+		redactRows = `hasScope := newHasScope(c.Request.Context(), server.HasScopeFunc, request.Session)
+		`
+		redactRow = `redactResponse(row, hasScope)
+			`
 	}
-	buf.WriteString("}\n\n")
 
-	// Always generate ResponseHeaderHook type
-	buf.WriteString("// ResponseHeaderHook returns the common response headers for each request.\n")
-	buf.WriteString("// This hook is called for every successful request to generate standard headers\n")
-	buf.WriteString("// such as RateLimit-Reset, TraceID, etc.\n")
-	buf.WriteString("type ResponseHeaderHook func(ctx context.Context, requestContext RequestContext) ResponseHeaders\n\n")
+	// The responses with an Operation tell where and when to poll it
+	writeOperation := ""
+	if service.HasAsyncEndpoints() {
+		generateAsyncOperations(buf, service, types)
 
-	return nil
-}
+		writeOperation = `writeOperationHeaders(c, successStatusCode, response)
 
-func generateUtils(buf *bytes.Buffer, service *specification.Service) error {
-	buf.WriteString(`// defaultGetRequestID is the default request ID generator function
-// It generates a new UUID for each request
-func defaultGetRequestID(ctx context.Context) string {
-	return uuid.New().String()
+		`
+	}
+
+	// The responses with header fields set the headers before they are written
+	writeHeaders := ""
+	if hasResponseHeaderFields(service) {
+		buf.WriteString(`// responseHeadersWriter is implemented by the response types of the endpoints with response headers,
+// which set the headers to the header fields of the response
+type responseHeadersWriter interface {
+	writeResponseHeaders(c *gin.Context)
 }
 
 `)
 
-	// Always generate setResponseHeaders function
-	buf.WriteString(`// setResponseHeaders sets the response headers from ResponseHeaders struct to gin context
-func setResponseHeaders(c *gin.Context, headers ResponseHeaders) {
-`)
+		writeHeaders = `if headers, ok := any(response).(responseHeadersWriter); ok && response != nil {
+			headers.writeResponseHeaders(c)
+		}
 
-	// Generate explicit header setting code for each response header
-	if len(service.ResponseHeaders) > 0 {
-		for _, field := range service.ResponseHeaders {
-			// Use original name for HTTP header, sanitized name for Go struct field
-			fieldName := sanitizeHeaderName(field.Name)
-			buf.WriteString(fmt.Sprintf("\tc.Header(\"%s\", headers.%s.String())\n", field.Name, fieldName))
+		`
+	}
+
+	// The callbacks of the endpoints are sent to the subscribers by a typed client
+	if service.HasCallbacks() {
+		generateCallbackClient(buf, service)
+	}
+
+	// The outbound requests retry like the SDKs of the service
+	if service.HasRetryConfiguration() {
+		generateRetryPolicy(buf, service)
+	}
+
+	// The responses of conditional GET endpoints are not written when the client has them already
+	writeNotModified := ""
+	if lastModifiedObjects := getLastModifiedObjects(service); len(lastModifiedObjects) > 0 {
+		generateConditionalGet(buf, service, types, lastModifiedObjects)
+
+		writeNotModified = `if writeLastModified(c, response) {
+			c.Status(http.StatusNotModified)
+			return
 		}
+
+		`
 	}
 
-	buf.WriteString("}\n\n")
+	generateResponseBuffers(buf)
 
 	// Always generate serveWithResponse with ResponseHeaderHook call
 	buf.WriteString(`func serveWithResponse[
@@ -562,33 +4205,46 @@ func setResponseHeaders(c *gin.Context, headers ResponseHeaders) {
 	successStatusCode int,
 	server Server[sessionType],
 	function func(ctx context.Context, request Request[sessionType, pathParamsType, queryParamsType, bodyParamsType]) (*responseType, error),
+	middlewares ...Middleware[sessionType],
 ) gin.HandlerFunc {
+	middlewares = append(server.Middlewares[:len(server.Middlewares):len(server.Middlewares)], middlewares...)
+
 	return func(c *gin.Context) {
-		getRequestID := server.GetRequestIDFunc
-		if getRequestID == nil {
-			getRequestID = defaultGetRequestID
-		}
-		requestID := getRequestID(c.Request.Context())
-		requestContext := getRequestContext(c, requestID)
+		requestContext := getRequestContext(c, getContextRequestID(c, server.GetRequestIDFunc))
 
 		// Set response headers as the last operation before returning
 		if server.ResponseHeaderHook != nil {
 			defer setResponseHeaders(c, server.ResponseHeaderHook(c.Request.Context(), requestContext))
 		}
 
+		if err := runPreParse(c.Request.Context(), requestContext, middlewares); err != nil {
+			` + writeError("server.ErrorHook(c.Request.Context(), requestContext, nil, err)") + `
+			return
+		}
+
 		request, err := handleRequest[sessionType, pathParamsType, queryParamsType, bodyParamsType](c, requestContext, server)
 		if err != nil {
-			c.JSON(server.ErrorHook(c.Request.Context(), requestContext, nil, err).Response())
+			` + writeError("server.ErrorHook(c.Request.Context(), requestContext, nil, err)") + `
+			return
+		}
+
+		if err := runPreHandle(c.Request.Context(), requestContext, middlewares, request); err != nil {
+			` + writeError("server.ErrorHook(c.Request.Context(), requestContext, &request.Session, err)") + `
 			return
 		}
 
 		response, err := function(c.Request.Context(), request)
-		if err != nil {
-			c.JSON(server.ErrorHook(c.Request.Context(), requestContext, &request.Session, err).Response())
+		err = runPostHandle(c.Request.Context(), requestContext, middlewares, request, response, err)
+		` + mapTimeout + `if err != nil {
+			` + writeError("server.ErrorHook(c.Request.Context(), requestContext, &request.Session, err)") + `
 			return
 		}
 
-		c.JSON(successStatusCode, response)
+		if server.ValidateResponses {
+			validateResponse(c.Request.Context(), requestContext, server.InvalidResponseHook, response)
+		}
+
+		` + writeAudit + writeRedacted + writeOperation + writeHeaders + writeNotModified + writeResponse + `writeJSON(c, successStatusCode, response)
 	}
 }` + "\n\n")
 
@@ -602,133 +4258,296 @@ func setResponseHeaders(c *gin.Context, headers ResponseHeaders) {
 	successStatusCode int,
 	server Server[sessionType],
 	function func(ctx context.Context, request Request[sessionType, pathParamsType, queryParamsType, bodyParamsType]) error,
+	middlewares ...Middleware[sessionType],
 ) gin.HandlerFunc {
+	middlewares = append(server.Middlewares[:len(server.Middlewares):len(server.Middlewares)], middlewares...)
+
 	return func(c *gin.Context) {
-		getRequestID := server.GetRequestIDFunc
-		if getRequestID == nil {
-			getRequestID = defaultGetRequestID
-		}
-		requestID := getRequestID(c.Request.Context())
-		requestContext := getRequestContext(c, requestID)
+		requestContext := getRequestContext(c, getContextRequestID(c, server.GetRequestIDFunc))
 
 		// Set response headers as the last operation before returning
 		if server.ResponseHeaderHook != nil {
 			defer setResponseHeaders(c, server.ResponseHeaderHook(c.Request.Context(), requestContext))
 		}
 
+		if err := runPreParse(c.Request.Context(), requestContext, middlewares); err != nil {
+			` + writeError("server.ErrorHook(c.Request.Context(), requestContext, nil, err)") + `
+			return
+		}
+
 		request, err := handleRequest[sessionType, pathParamsType, queryParamsType, bodyParamsType](c, requestContext, server)
 		if err != nil {
-			c.JSON(server.ErrorHook(c.Request.Context(), requestContext, nil, err).Response())
+			` + writeError("server.ErrorHook(c.Request.Context(), requestContext, nil, err)") + `
 			return
 		}
 
-		err = function(c.Request.Context(), request)
-		if err != nil {
-			c.JSON(server.ErrorHook(c.Request.Context(), requestContext, &request.Session, err).Response())
+		if err := runPreHandle(c.Request.Context(), requestContext, middlewares, request); err != nil {
+			` + writeError("server.ErrorHook(c.Request.Context(), requestContext, &request.Session, err)") + `
 			return
 		}
 
-		c.JSON(successStatusCode, nil)
-	}
-}` + "\n\n")
+		err = function(c.Request.Context(), request)
+		err = runPostHandle(c.Request.Context(), requestContext, middlewares, request, nil, err)
+		` + mapTimeout + `if err != nil {
+			` + writeError("server.ErrorHook(c.Request.Context(), requestContext, &request.Session, err)") + `
+			return
+		}
 
-	buf.WriteString(`func getRequestContext(c *gin.Context, requestID string) RequestContext {
-	return RequestContext{
-		RequestID:  requestID,
-		Path:       c.Request.URL.Path,
-		Route:      c.FullPath(),
-		UserAgent:  c.Request.UserAgent(),
-		HTTPMethod: c.Request.Method,
-		IPAddress:  c.ClientIP(),
+		` + writeAuditWithoutResponse + `c.JSON(successStatusCode, nil)
 	}
 }` + "\n\n")
 
-	buf.WriteString(`func handleRequest[
+	// The CSV responses are streamed, so they are written by their own handler
+	if len(getCSVObjects(service)) > 0 {
+		buf.WriteString(`// serveCSV serves an endpoint streaming its response as CSV, a header row followed by the rows written by the
+// function. The status code and the header row are written with the first row, or when the function returns without
+// rows, so an error before it is responded as usual. An error after it ends the response early, since the status
+// code has been sent, and is added to the errors of the gin context.
+func serveCSV[
 	sessionType any,
 	pathParamsType any,
 	queryParamsType any,
 	bodyParamsType any,
+	rowType any,
 ](
-	c *gin.Context,
-	requestContext RequestContext,
+	successStatusCode int,
 	server Server[sessionType],
-) (Request[sessionType, pathParamsType, queryParamsType, bodyParamsType], error) {
-	var nilRequest Request[sessionType, pathParamsType, queryParamsType, bodyParamsType]
+	function func(ctx context.Context, request Request[sessionType, pathParamsType, queryParamsType, bodyParamsType], write func(row *rowType) error) error,
+	header []string,
+	record func(row *rowType) []string,
+	middlewares ...Middleware[sessionType],
+) gin.HandlerFunc {
+	middlewares = append(server.Middlewares[:len(server.Middlewares):len(server.Middlewares)], middlewares...)
+
+	return func(c *gin.Context) {
+		requestContext := getRequestContext(c, getContextRequestID(c, server.GetRequestIDFunc))
+
+		// Set response headers before the response is written, which is when the first row is written
+		started := false
+		if server.ResponseHeaderHook != nil {
+			defer func() {
+				if !started {
+					setResponseHeaders(c, server.ResponseHeaderHook(c.Request.Context(), requestContext))
+				}
+			}()
+		}
+
+		if err := runPreParse(c.Request.Context(), requestContext, middlewares); err != nil {
+			` + writeError("server.ErrorHook(c.Request.Context(), requestContext, nil, err)") + `
+			return
+		}
+
+		request, err := handleRequest[sessionType, pathParamsType, queryParamsType, bodyParamsType](c, requestContext, server)
+		if err != nil {
+			` + writeError("server.ErrorHook(c.Request.Context(), requestContext, nil, err)") + `
+			return
+		}
+
+		if err := runPreHandle(c.Request.Context(), requestContext, middlewares, request); err != nil {
+			` + writeError("server.ErrorHook(c.Request.Context(), requestContext, &request.Session, err)") + `
+			return
+		}
+
+		` + redactRows + `csvWriter := csv.NewWriter(c.Writer)
+		start := func() error {
+			started = true
+			if server.ResponseHeaderHook != nil {
+				setResponseHeaders(c, server.ResponseHeaderHook(c.Request.Context(), requestContext))
+			}
+			c.Header("Content-Type", "text/csv; charset=utf-8")
+			c.Status(successStatusCode)
+			return csvWriter.Write(header)
+		}
+		write := func(row *rowType) error {
+			if !started {
+				if err := start(); err != nil {
+					return err
+				}
+			}
+			` + redactRow + `return csvWriter.Write(record(row))
+		}
+
+		err = function(c.Request.Context(), request, write)
+		err = runPostHandle(c.Request.Context(), requestContext, middlewares, request, nil, err)
+		` + mapTimeout + `if err == nil && !started {
+			err = start()
+		}
+		if err != nil && !started {
+			` + writeError("server.ErrorHook(c.Request.Context(), requestContext, &request.Session, err)") + `
+			return
+		}
 
-	// Run pre-hooks before parsing request
-	for _, preHook := range server.PreHooks {
-		if err := preHook(c.Request.Context(), requestContext); err != nil {
-			return nilRequest, err
+		csvWriter.Flush()
+		if err == nil {
+			err = csvWriter.Error()
+		}
+		if err != nil {
+			_ = c.Error(err)
+			c.Abort()
 		}
 	}
+}
 
-	session, err := server.GetSessionFunc(c.Request.Context(), c.Request.Header)
+// csvValue returns the value of a field as a CSV column: strings as they are, null as an empty column,
+// and the other values as JSON
+func csvValue(value any) string {
+	data, err := json.Marshal(value)
 	if err != nil {
-		return nilRequest, &Error{
-			Code:      ErrorCodeUnauthorized,
-			Message:   types.NewString(err.Error()),
-			RequestID: types.NewString(requestContext.RequestID),
-		}
+		return fmt.Sprint(value)
+	}
+	if string(data) == "null" {
+		return ""
 	}
 
-	// Run session hooks after successful authentication
-	// These hooks can perform logging, rate limiting, authorization checks, etc.
-	// The hooks are executed in the order they are defined in the SessionHooks slice
-	for _, sessionHook := range server.SessionHooks {
-		if err := sessionHook(c.Request.Context(), requestContext, session); err != nil {
-			return nilRequest, err
-		}
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		return s
 	}
+	return string(data)
+}
 
-	request := Request[sessionType, pathParamsType, queryParamsType, bodyParamsType]{
-		requestContext: requestContext,
-		Session: session,
+`)
 	}
 
-	if _, ok := any(request.BodyParams).(struct{}); !ok {
-		bodyParams, err := decodeBodyParams[bodyParamsType](c.Request)
-		if err != nil {
-			return nilRequest, &Error{
-				Code:      ErrorCodeBadRequest,
-				Message:   types.NewString("cannot decode json body params: " + err.Error()),
-				RequestID: types.NewString(requestContext.RequestID),
-			}
+	buf.WriteString(`// runPreParse runs the PreParse hooks of the middlewares, returning the first error
+func runPreParse[Session any](ctx context.Context, requestContext RequestContext, middlewares []Middleware[Session]) error {
+	for _, middleware := range middlewares {
+		if middleware.PreParse == nil {
+			continue
+		}
+		if err := middleware.PreParse(ctx, requestContext); err != nil {
+			return err
 		}
+	}
+	return nil
+}
 
-		request.BodyParams = bodyParams
+// runPreHandle runs the PreHandle hooks of the middlewares with the decoded request, returning the first error
+func runPreHandle[Session any](ctx context.Context, requestContext RequestContext, middlewares []Middleware[Session], request any) error {
+	for _, middleware := range middlewares {
+		if middleware.PreHandle == nil {
+			continue
+		}
+		if err := middleware.PreHandle(ctx, requestContext, request); err != nil {
+			return err
+		}
 	}
+	return nil
+}
 
-	if _, ok := any(request.PathParams).(struct{}); !ok {
-		pathParams, err := decodePathParams[pathParamsType](c)
-		if err != nil {
-			return nilRequest, &Error{
-				Code:      ErrorCodeBadRequest,
-				Message:   types.NewString("cannot decode path params: " + err.Error()),
-				RequestID: types.NewString(requestContext.RequestID),
-			}
+// runPostHandle runs the PostHandle hooks of the middlewares in reverse order, each with the error returned by the previous one
+func runPostHandle[Session any](ctx context.Context, requestContext RequestContext, middlewares []Middleware[Session], request any, response any, err error) error {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		if middlewares[i].PostHandle == nil {
+			continue
 		}
+		err = middlewares[i].PostHandle(ctx, requestContext, request, response, err)
+	}
+	return err
+}
+
+`)
+
+	buf.WriteString(`func getRequestContext(c *gin.Context, requestID string) RequestContext {
+	return RequestContext{
+		RequestID:      requestID,
+		Path:           c.Request.URL.Path,
+		Route:          c.FullPath(),
+		UserAgent:      c.Request.UserAgent(),
+		HTTPMethod:     c.Request.Method,
+		IPAddress:      c.ClientIP(),
+		RequiredScopes: c.GetStringSlice(requiredScopesContextKey),
+` + getTenantIDExtraction(service) + getLocaleExtraction(service) + `	}
+}` + "\n\n")
+
+	buf.WriteString(`// requiredScopesContextKey is the gin context key holding the scopes required by the endpoint
+const requiredScopesContextKey = "requiredScopes"
 
-		request.PathParams = pathParams
+// requireScopes stores the OAuth2 scopes required by the endpoint in the gin context,
+// so they are available in the RequestContext and verified by Server.CheckScopesFunc
+func requireScopes(scopes ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(requiredScopesContextKey, scopes)
 	}
+}
 
-	if _, ok := any(request.QueryParams).(struct{}); !ok {
-		queryParams, err := decodeQueryParams[queryParamsType](c)
-		if err != nil {
-			return nilRequest, &Error{
-				Code:      ErrorCodeBadRequest,
-				Message:   types.NewString("cannot decode query params: " + err.Error()),
-				RequestID: types.NewString(requestContext.RequestID),
-			}
-		}
+`)
+
+	if service.HasFeatureFlags() {
+		buf.WriteString(`// featureFlagContextKey is the gin context key holding the feature flag the endpoint is gated behind
+const featureFlagContextKey = "featureFlag"
+
+// requireFeature stores the feature flag the endpoint is gated behind in the gin context,
+// so it is checked by Server.FeatureEnabledFunc before the request is handled
+func requireFeature(flag string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(featureFlagContextKey, flag)
+	}
+}
 
-		request.QueryParams = queryParams
+`)
 	}
 
-	return request, nil
+	buf.WriteString(`// getEndpointInfo returns the endpoint of the request with its path parameters, verified by Server.AuthorizeFunc
+func getEndpointInfo(c *gin.Context) EndpointInfo {
+	endpoint := endpointInfos[c.Request.Method+" "+c.FullPath()]
+	endpoint.PathParams = make(map[string]string, len(c.Params))
+	for _, param := range c.Params {
+		endpoint.PathParams[param.Key] = param.Value
+	}
+	return endpoint
 }` + "\n\n")
 
-	buf.WriteString(`func decodeBodyParams[T any](r *http.Request) (T, error) {
+	if err := templates.execute(buf, TemplateMiddleware, templateData{Service: service, Types: types}); err != nil {
+		return err
+	}
+
+	if service.HasXMLRequests() {
+		generateXMLRequests(buf)
+	}
+
+	if service.HasFormRequests() {
+		generateFormRequests(buf)
+	}
+
+	if service.HasFilterMaxDepth() {
+		buf.WriteString(`// filterDepthChecker is implemented by the body params of the Search endpoints, to reject filters nested too deep
+type filterDepthChecker interface {
+	checkFilterDepth() error
+}` + "\n\n")
+	}
+
+	if service.HasPaginatedEndpoints() && isPaginationObject(service, specification.PaginationObjectName, true) {
+		generatePaginationHelpers(buf, service, types)
+	}
+
+	buf.WriteString(`// defaulter is implemented by the request types that have fields with default values
+type defaulter interface {
+	setDefaults()
+}` + "\n\n")
+
+	buf.WriteString(`// setDefaults sets the default values on v before decoding, so that only the fields absent from the request keep them
+func setDefaults(v any) {
+	if d, ok := v.(defaulter); ok {
+		d.setDefaults()
+	}
+}` + "\n\n")
+
+	if types.Stdlib {
+		buf.WriteString(`// ptr returns a pointer to v, used for the default values of the nullable and optional fields
+func ptr[T any](v T) *T {
+	return &v
+}` + "\n\n")
+	}
+
+	generateResponseValidation(buf, getImportedObjects(service, types, nil))
+
+	if service.HasStrictObjects() {
+		generateStrictDecoding(buf)
+	} else {
+		buf.WriteString(`func decodeBodyParams[T any](r *http.Request) (T, error) {
 	var v T
+	setDefaults(&v)
 
 	if err := json.NewDecoder(r.Body).Decode(&v); err != nil {
 		return v, err
@@ -736,10 +4555,60 @@ func setResponseHeaders(c *gin.Context, headers ResponseHeaders) {
 
 	return v, nil
 }` + "\n\n")
+	}
+
+	buf.WriteString(`// pathParamsParser is implemented by the path parameter types, to parse the parameters without reflection
+type pathParamsParser interface {
+	parsePathParams(params gin.Params) error
+}
+
+// queryParamsParser is implemented by the query parameter types, to parse the parameters without reflection
+type queryParamsParser interface {
+	parseQueryParams(query url.Values) error
+}
+
+// parseIntParam parses an integer parameter, where an empty value is zero as with the form binding of gin
+func parseIntParam(value string, bitSize int) (int64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.ParseInt(value, 10, bitSize)
+}
+
+// parseUintParam parses an unsigned integer parameter, where an empty value is zero as with the form binding of gin
+func parseUintParam(value string) (uint64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.ParseUint(value, 10, 64)
+}
+
+// parseFloatParam parses a floating point parameter, where an empty value is zero as with the form binding of gin
+func parseFloatParam(value string) (float64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.ParseFloat(value, 64)
+}
+
+// parseBoolParam parses a boolean parameter, where an empty value is false as with the form binding of gin
+func parseBoolParam(value string) (bool, error) {
+	if value == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(value)
+}` + "\n\n")
 
-	buf.WriteString(`func decodePathParams[T any](c *gin.Context) (T, error) {
+	buf.WriteString(`// decodePathParams decodes the path parameters of the request into the given type, parsing them directly
+// with the parsePathParams method of the type and through JSON otherwise
+func decodePathParams[T any](c *gin.Context) (T, error) {
 	var result T
 
+	if parser, ok := any(&result).(pathParamsParser); ok {
+		err := parser.parsePathParams(c.Params)
+		return result, err
+	}
+
 	m := make(map[string]string, len(c.Params))
 	for _, p := range c.Params {
 		m[p.Key] = p.Value
@@ -758,9 +4627,16 @@ func setResponseHeaders(c *gin.Context, headers ResponseHeaders) {
 }` + "\n\n")
 
 	buf.WriteString(`// decodeQueryParams decodes the query parameters from the request into the given type,
-// it can be limit & offset keys for example and they should be parsed into the correct type
+// it can be limit & offset keys for example and they should be parsed into the correct type,
+// directly with the parseQueryParams method of the type and through the form binding of gin otherwise
 func decodeQueryParams[T any](c *gin.Context) (T, error) {
 	var result T
+	setDefaults(&result)
+
+	if parser, ok := any(&result).(queryParamsParser); ok {
+		err := parser.parseQueryParams(c.Request.URL.Query())
+		return result, err
+	}
 
 	if err := c.ShouldBindQuery(&result); err != nil {
 		return result, err
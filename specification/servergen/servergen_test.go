@@ -2,8 +2,17 @@ package servergen
 
 import (
 	"bytes"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
+	"testing/fstest"
 
 	"github.com/meitner-se/publicapis-gen/specification"
 	"github.com/stretchr/testify/assert"
@@ -194,6 +203,25 @@ func TestGenerateServer(t *testing.T) {
 		})
 	})
 
+	t.Run("request ID propagation", func(t *testing.T) {
+		// Arrange
+		service := specification.ApplyOverlay(createTestServiceWithEndpoints())
+		buf := &bytes.Buffer{}
+
+		// Act
+		err := GenerateServer(buf, service)
+
+		// Assert
+		assert.Nil(t, err, "Expected no error when generating server")
+		generatedCode := buf.String()
+		assert.Contains(t, generatedCode, `const requestIDHeader = "X-Request-Id"`, "Should read and write the X-Request-Id header")
+		assert.Contains(t, generatedCode, "if !isValidRequestID(requestID) {", "Should only propagate valid request IDs")
+		assert.Contains(t, generatedCode, "c.Set(requestIDContextKey, requestID)", "Should store the request ID for the RequestContext")
+		assert.Contains(t, generatedCode, "func withRequestID(err *Error, requestID string) *Error {", "Should define withRequestID")
+		assert.Contains(t, generatedCode, "\t\terr.RequestID = types.NewString(requestID)\n", "Should fill in the request ID of errors without one")
+		assert.Equal(t, 8, strings.Count(generatedCode, "withRequestID(server.ErrorHook("), "Every written error should carry the request ID")
+	})
+
 	t.Run("GetRequestIDFunc functionality", func(t *testing.T) {
 		// Arrange
 		service := createTestServiceWithEndpoints()
@@ -216,23 +244,332 @@ func TestGenerateServer(t *testing.T) {
 		assert.Contains(t, generatedCode, "// defaultGetRequestID is the default request ID generator function",
 			"Should document defaultGetRequestID function")
 
-		// Verify GetRequestIDFunc usage in serve functions with nil check
-		assert.Contains(t, generatedCode, "getRequestID := server.GetRequestIDFunc",
-			"Should assign GetRequestIDFunc to local variable")
+		// Verify GetRequestIDFunc is used by the request ID middleware with nil check
+		assert.Contains(t, generatedCode, "routerGroup.Use(propagateRequestID(api.Server.GetRequestIDFunc))",
+			"Should register the request ID middleware with GetRequestIDFunc")
 		assert.Contains(t, generatedCode, "if getRequestID == nil {",
 			"Should check if GetRequestIDFunc is nil")
 		assert.Contains(t, generatedCode, "getRequestID = defaultGetRequestID",
 			"Should use defaultGetRequestID when GetRequestIDFunc is nil")
-		assert.Contains(t, generatedCode, "requestID := getRequestID(c.Request.Context())",
-			"Should call getRequestID with context")
+		assert.Contains(t, generatedCode, "requestID = getRequestID(c.Request.Context())",
+			"Should call getRequestID with context when the request has no request ID")
+
+		// Verify the serve functions fall back to GetRequestIDFunc when served without the middleware
+		assert.Contains(t, generatedCode, "func getContextRequestID(c *gin.Context, getRequestID func(ctx context.Context) string) string {",
+			"Should define getContextRequestID")
+		assert.Contains(t, generatedCode, "return getRequestID(c.Request.Context())",
+			"Should generate a request ID when the gin context has none")
+
+		// Count occurrences to ensure the request ID is read in both serve functions
+		requestIDUsageCount := strings.Count(generatedCode, "requestContext := getRequestContext(c, getContextRequestID(c, server.GetRequestIDFunc))")
+		assert.Equal(t, 2, requestIDUsageCount,
+			"Request ID should be read in both serveWithResponse and serveWithoutResponse")
+	})
+}
+
+func TestGenerateServer_FormattedOutput(t *testing.T) {
+	// Arrange
+	service := createTestServiceWithEndpoints()
+	first := &bytes.Buffer{}
+	second := &bytes.Buffer{}
+
+	// Act
+	errFirst := GenerateServer(first, service)
+	errSecond := GenerateServer(second, service)
+
+	// Assert
+	assert.Nil(t, errFirst, "Expected no error when generating server code")
+	assert.Nil(t, errSecond, "Expected no error when generating server code")
+
+	formatted, err := format.Source(first.Bytes())
+	assert.Nil(t, err, "Generated code should parse as valid Go")
+	assert.Equal(t, string(formatted), first.String(), "Generated code should already be gofmt-formatted")
+	assert.Equal(t, first.String(), second.String(), "Generated code should be deterministic")
+}
+
+func TestGenerateServerWithOptions_Templates(t *testing.T) {
+	t.Run("without overrides matches GenerateServer", func(t *testing.T) {
+		// Arrange
+		service := createTestServiceWithEndpoints()
+		expected := &bytes.Buffer{}
+		actual := &bytes.Buffer{}
+
+		// Act
+		errExpected := GenerateServer(expected, service)
+		errActual := GenerateServerWithOptions(actual, service, Options{Templates: fstest.MapFS{}})
+
+		// Assert
+		assert.Nil(t, errExpected)
+		assert.Nil(t, errActual)
+		assert.Equal(t, expected.String(), actual.String(), "Missing overrides should use the default templates")
+	})
+
+	t.Run("overrides templates", func(t *testing.T) {
+		// Arrange
+		templates := fstest.MapFS{
+			TemplateHeader: {Data: []byte(`// Code generated for {{.Service.Name}}. DO NOT EDIT.
+
+package api
+
+import (
+	"log"
+{{- range .StandardImports}}
+	"{{.}}"
+{{- end}}
+{{range .Imports}}
+	"{{.}}"
+{{- end}}
+)
+`)},
+			TemplateErrorHook: {Data: []byte(`	if api.Server.ErrorHook == nil {
+		api.Server.ErrorHook = func(ctx context.Context, requestContext RequestContext, session *Session, err error) *Error {
+			log.Printf("request %s failed: %v", requestContext.RequestID, err)
+			return &Error{Code: ErrorCodeInternal, RequestID: types.NewString(requestContext.RequestID)}
+		}
+	}
+`)},
+		}
+		buf := &bytes.Buffer{}
+
+		// Act
+		err := GenerateServerWithOptions(buf, createTestServiceWithEndpoints(), Options{Templates: templates})
+
+		// Assert
+		assert.Nil(t, err, "Expected no error when generating server with overrides")
+		code := buf.String()
+		assert.True(t, strings.HasPrefix(code, "// Code generated for "+testServiceName+". DO NOT EDIT."), "Should use the header override")
+		assert.Contains(t, code, `log.Printf("request %s failed: %v", requestContext.RequestID, err)`, "Should use the error hook override")
+		assert.Contains(t, code, "func handleRequest[", "Should use the default middleware template")
+	})
+
+	t.Run("unknown template", func(t *testing.T) {
+		// Arrange
+		templates := fstest.MapFS{"errorhook.go.tmpl": {Data: []byte("")}}
+
+		// Act
+		err := GenerateServerWithOptions(&bytes.Buffer{}, createTestServiceWithEndpoints(), Options{Templates: templates})
+
+		// Assert
+		assert.NotNil(t, err, "A misspelled template should not silently fall back to the default")
+		assert.Contains(t, err.Error(), errorUnknownTemplate)
+	})
+
+	t.Run("invalid template", func(t *testing.T) {
+		// Arrange
+		templates := fstest.MapFS{TemplateMiddleware: {Data: []byte("{{.Unknown}}")}}
+
+		// Act
+		err := GenerateServerWithOptions(&bytes.Buffer{}, createTestServiceWithEndpoints(), Options{Templates: templates})
+
+		// Assert
+		assert.NotNil(t, err, "Expected an error for a template referencing unknown data")
+		assert.Contains(t, err.Error(), errorTemplate)
+		assert.Contains(t, err.Error(), TemplateMiddleware)
+	})
+
+	t.Run("invalid Go", func(t *testing.T) {
+		// Arrange
+		templates := fstest.MapFS{TemplateErrorHook: {Data: []byte("\tif {\n")}}
+
+		// Act
+		err := GenerateServerWithOptions(&bytes.Buffer{}, createTestServiceWithEndpoints(), Options{Templates: templates})
+
+		// Assert
+		assert.NotNil(t, err, "Expected an error when an override produces invalid Go")
+		assert.Contains(t, err.Error(), "gofmt failed")
+	})
+}
+
+func TestNewGenerator(t *testing.T) {
+	t.Run("reused concurrently", func(t *testing.T) {
+		// Arrange
+		service := createTestServiceWithEndpoints()
+		expected := &bytes.Buffer{}
+		errExpected := GenerateServer(expected, service)
+		expectedFiles, errExpectedFiles := GenerateServerFiles(service, Options{})
+		generator, err := NewGenerator(Options{})
+		results := make([]string, 8)
+		files := make([]map[string][]byte, len(results))
+		errs := make([]error, 2*len(results))
+
+		// Act
+		var wg sync.WaitGroup
+		for i := range results {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				buf := &bytes.Buffer{}
+				errs[2*i] = generator.Generate(buf, service)
+				results[i] = buf.String()
+				files[i], errs[2*i+1] = generator.GenerateFiles(service)
+			}()
+		}
+		wg.Wait()
+
+		// Assert
+		assert.Nil(t, errExpected)
+		assert.Nil(t, errExpectedFiles)
+		assert.Nil(t, err, "Expected no error when creating a generator")
+		for i := range results {
+			assert.Nil(t, errs[2*i])
+			assert.Nil(t, errs[2*i+1])
+			assert.Equal(t, expected.String(), results[i], "A reused generator should generate the same server")
+			assert.Equal(t, expectedFiles, files[i], "A reused generator should generate the same files")
+		}
+	})
+
+	t.Run("template that does not parse", func(t *testing.T) {
+		// Arrange
+		templates := fstest.MapFS{TemplateHeader: {Data: []byte("{{.Service.Name")}}
+
+		// Act
+		generator, err := NewGenerator(Options{Templates: templates})
+
+		// Assert
+		assert.Nil(t, generator)
+		assert.NotNil(t, err, "Templates should be parsed when the generator is created")
+		assert.Contains(t, err.Error(), errorTemplate)
+		assert.Contains(t, err.Error(), TemplateHeader)
+	})
+}
+
+// ============================================================================
+// GenerateServerFiles Tests
+// ============================================================================
+
+// parseDeclarations parses Go source and returns its declarations other than imports, formatted and sorted.
+func parseDeclarations(t *testing.T, source []byte) []string {
+	t.Helper()
+
+	fileSet := token.NewFileSet()
+	file, err := parser.ParseFile(fileSet, "", source, 0)
+	assert.Nil(t, err, "Expected the generated file to parse as Go")
+
+	var declarations []string
+	for _, decl := range file.Decls {
+		if genDecl, ok := decl.(*ast.GenDecl); ok && genDecl.Tok == token.IMPORT {
+			continue
+		}
+		var buf bytes.Buffer
+		assert.Nil(t, format.Node(&buf, fileSet, decl))
+		declarations = append(declarations, buf.String())
+	}
+	sort.Strings(declarations)
+
+	return declarations
+}
+
+func TestGenerateServerFiles(t *testing.T) {
+	t.Run("splits the declarations of GenerateServer across files", func(t *testing.T) {
+		// Arrange
+		service := createTestServiceWithEndpoints()
+		single := &bytes.Buffer{}
+		assert.Nil(t, GenerateServer(single, service))
+
+		// Act
+		files, err := GenerateServerFiles(service, Options{})
+
+		// Assert
+		assert.Nil(t, err, "Expected no error when generating server files")
+		expectedNames := []string{FileServer, FileTypes}
+		for _, resource := range service.Resources {
+			expectedNames = append(expectedNames, ResourceFileName(resource.Name))
+		}
+		assert.Len(t, files, len(expectedNames))
+
+		var declarations []string
+		for _, name := range expectedNames {
+			assert.Contains(t, files, name)
+			declarations = append(declarations, parseDeclarations(t, files[name])...)
+		}
+		sort.Strings(declarations)
+		assert.Equal(t, parseDeclarations(t, single.Bytes()), declarations, "The files together should declare exactly what the single file declares")
+	})
+
+	t.Run("places resource types in the resource file", func(t *testing.T) {
+		// Arrange
+		service := createTestServiceWithEndpoints()
+		resource := service.Resources[0]
+
+		// Act
+		files, err := GenerateServerFiles(service, Options{})
+
+		// Assert
+		assert.Nil(t, err)
+		code := string(files[ResourceFileName(resource.Name)])
+		assert.Contains(t, code, "type "+resource.Name+"API[Session any] interface {")
+		assert.Contains(t, code, "package api")
+		assert.NotContains(t, string(files[FileServer]), "type "+resource.Name+"API[Session any] interface {")
+	})
+
+	t.Run("keeps only the used imports", func(t *testing.T) {
+		// Act
+		files, err := GenerateServerFiles(createTestServiceWithEndpoints(), Options{})
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Contains(t, string(files[FileServer]), `"github.com/gin-gonic/gin"`)
+		assert.NotContains(t, string(files[FileTypes]), `"github.com/gin-gonic/gin"`, "The types do not use gin")
+		assert.Contains(t, string(files[FileTypes]), `"github.com/meitner-se/go-types"`)
+	})
+
+	t.Run("generates the lifecycle file with the lifecycle option", func(t *testing.T) {
+		// Arrange
+		service := createTestServiceWithEndpoints()
+		single := &bytes.Buffer{}
+		assert.Nil(t, GenerateServerWithOptions(single, service, Options{Lifecycle: true}))
+
+		// Act
+		files, err := GenerateServerFiles(service, Options{Lifecycle: true})
+
+		// Assert
+		assert.Nil(t, err)
+		assert.Contains(t, string(files[FileLifecycle]), "func RunServer[Session any](")
+		assert.NotContains(t, string(files[FileServer]), "func RunServer[Session any](")
+
+		var declarations []string
+		for _, source := range files {
+			declarations = append(declarations, parseDeclarations(t, source)...)
+		}
+		sort.Strings(declarations)
+		assert.Equal(t, parseDeclarations(t, single.Bytes()), declarations, "The files together should declare exactly what the single file declares")
+	})
 
-		// Count occurrences to ensure the pattern is in both serve functions
-		getRequestIDUsageCount := strings.Count(generatedCode, "getRequestID := server.GetRequestIDFunc")
-		assert.Equal(t, 2, getRequestIDUsageCount,
-			"GetRequestIDFunc assignment should be in both serveWithResponse and serveWithoutResponse")
+	t.Run("uses the header template in every file", func(t *testing.T) {
+		// Arrange
+		templates := fstest.MapFS{
+			TemplateHeader: {Data: []byte("// Custom header.\n\npackage api\n\nimport (\n{{range .StandardImports}}\t\"{{.}}\"\n{{end}}{{range .Imports}}\t\"{{.}}\"\n{{end}})\n")},
+		}
+
+		// Act
+		files, err := GenerateServerFiles(createTestServiceWithEndpoints(), Options{Templates: templates})
+
+		// Assert
+		assert.Nil(t, err)
+		for name, source := range files {
+			assert.True(t, strings.HasPrefix(string(source), "// Custom header."), "File %s should use the header override", name)
+		}
 	})
 }
 
+func TestResourceFileName(t *testing.T) {
+	testCases := []struct {
+		resourceName string
+		expected     string
+	}{
+		{resourceName: "Users", expected: "users_resource.go"},
+		{resourceName: "UserRoles", expected: "user_roles_resource.go"},
+		{resourceName: "APIKeys", expected: "api_keys_resource.go"},
+		{resourceName: "LoadTest", expected: "load_test_resource.go"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.resourceName, func(t *testing.T) {
+			assert.Equal(t, tc.expected, ResourceFileName(tc.resourceName))
+		})
+	}
+}
+
 // ============================================================================
 // generateEnums Tests
 // ============================================================================
@@ -252,7 +589,7 @@ func TestGenerateEnums(t *testing.T) {
 	buf := &bytes.Buffer{}
 
 	// Act
-	err := generateEnums(buf, enums)
+	err := generateEnums(buf, enums, Types{})
 
 	// Assert
 	assert.Nil(t, err, "Expected no error when generating enums")
@@ -263,13 +600,65 @@ func TestGenerateEnums(t *testing.T) {
 	assert.Contains(t, generatedCode, "UserRoleUser = types.NewString(\"User\") // Regular user role",
 		"Should generate all enum values")
 
+	t.Run("wire values and aliases", func(t *testing.T) {
+		// Arrange
+		enums := []specification.Enum{
+			{
+				Name: "Status",
+				Values: []specification.EnumValue{
+					{Name: "Active", Description: "Active", Value: "active", Aliases: []string{"enabled", "on"}},
+					{Name: "Inactive", Description: "Inactive", Value: "inactive"},
+				},
+			},
+		}
+		buf := &bytes.Buffer{}
+
+		// Act
+		err := generateEnums(buf, enums, Types{Stdlib: true})
+
+		// Assert
+		assert.Nil(t, err, "Expected no error when generating enums")
+		generatedCode := buf.String()
+		assert.Contains(t, generatedCode, "\tStatusActive = \"active\" // Active\n", "Constant should have the wire value")
+		assert.Contains(t, generatedCode, "var StatusAliases = map[string]string{\n\t\"enabled\": \"active\",\n\t\"on\": \"active\",\n}",
+			"Should map the aliases to the wire values")
+	})
+
+	t.Run("int enum", func(t *testing.T) {
+		// Arrange
+		enums := []specification.Enum{
+			{
+				Name:     "Grade",
+				EnumType: specification.EnumTypeInt,
+				Values: []specification.EnumValue{
+					{Name: "Pass", Description: "Passed", Value: "1", Aliases: []string{"10"}},
+					{Name: "Fail", Description: "Failed", Value: "0"},
+				},
+			},
+		}
+		buf := &bytes.Buffer{}
+
+		// Act
+		err := generateEnums(buf, enums, Types{})
+
+		// Assert
+		assert.Nil(t, err, "Expected no error when generating enums")
+		generatedCode := buf.String()
+		assert.Contains(t, generatedCode, "type Grade int64\n", "Should generate a named int type")
+		assert.Contains(t, generatedCode, "\tGradePass Grade = 1 // Passed\n", "Constant should be typed with the wire value")
+		assert.Contains(t, generatedCode, "\tcase 1, 10:\n\t\treturn GradePass, nil\n", "Parsing should accept the aliases")
+		assert.Contains(t, generatedCode, "func (e Grade) Int() types.Int {\n\treturn types.NewInt(int64(e))\n}", "Should convert to an Int field")
+		assert.Contains(t, generatedCode, "func (e *Grade) UnmarshalJSON(data []byte) error {", "Should decode from JSON")
+		assert.NotContains(t, generatedCode, "GradeAliases", "Should not generate the map of string aliases")
+	})
+
 	t.Run("edge cases", func(t *testing.T) {
 		t.Run("empty enums slice", func(t *testing.T) {
 			// Arrange
 			buf := &bytes.Buffer{}
 
 			// Act
-			err := generateEnums(buf, []specification.Enum{})
+			err := generateEnums(buf, []specification.Enum{}, Types{})
 
 			// Assert
 			assert.Nil(t, err, "Expected no error with empty enums")
@@ -288,7 +677,7 @@ func TestGenerateEnums(t *testing.T) {
 			buf := &bytes.Buffer{}
 
 			// Act
-			err := generateEnums(buf, enumsNoValues)
+			err := generateEnums(buf, enumsNoValues, Types{})
 
 			// Assert
 			assert.Nil(t, err, "Expected no error")
@@ -310,13 +699,13 @@ func TestGenerateEnums(t *testing.T) {
 			buf := &bytes.Buffer{}
 
 			// Act
-			err := generateEnums(buf, specialEnums)
+			err := generateEnums(buf, specialEnums, Types{})
 
 			// Assert
 			assert.Nil(t, err, "Expected no error")
 			generatedCode := buf.String()
-			assert.Contains(t, generatedCode, `StatusIn-Progress = types.NewString("In-Progress")`,
-				"Should handle special characters in enum names")
+			assert.Contains(t, generatedCode, `StatusInProgress = types.NewString("In-Progress")`,
+				"Should sanitize special characters in enum names while keeping the value")
 		})
 	})
 }
@@ -402,12 +791,72 @@ func TestGetTypeForGo(t *testing.T) {
 			},
 			expectedType: "types.String",
 		},
+		{
+			name: "optional object type",
+			field: specification.Field{
+				Name:      "Address",
+				Type:      testObjectName,
+				Modifiers: []string{specification.ModifierOptional},
+			},
+			expectedType: "*" + testObjectName,
+		},
+		{
+			name: "optional primitive type",
+			field: specification.Field{
+				Name:      "Name",
+				Type:      testFieldType,
+				Modifiers: []string{specification.ModifierOptional},
+			},
+			expectedType: "types.String",
+		},
+		{
+			name: "primitive Int32 type",
+			field: specification.Field{
+				Name: "Quantity",
+				Type: specification.FieldTypeInt32,
+			},
+			expectedType: "types.Int32",
+		},
+		{
+			name: "primitive UInt type",
+			field: specification.Field{
+				Name: "Count",
+				Type: specification.FieldTypeUInt,
+			},
+			expectedType: "types.UInt",
+		},
+		{
+			name: "decimal type",
+			field: specification.Field{
+				Name: "Price",
+				Type: specification.FieldTypeDecimal,
+			},
+			expectedType: "decimal.Decimal",
+		},
+		{
+			name: "nullable decimal type",
+			field: specification.Field{
+				Name:      "Discount",
+				Type:      specification.FieldTypeDecimal,
+				Modifiers: []string{"Nullable"},
+			},
+			expectedType: "decimal.NullDecimal",
+		},
+		{
+			name: "array of decimals",
+			field: specification.Field{
+				Name:      "Prices",
+				Type:      specification.FieldTypeDecimal,
+				Modifiers: []string{"Array"},
+			},
+			expectedType: "[]decimal.Decimal",
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			// Act
-			result := getTypeForGo(tc.field, service)
+			result := getTypeForGo(tc.field, service, Types{})
 
 			// Assert
 			assert.Equal(t, tc.expectedType, result,
@@ -424,7 +873,7 @@ func TestGetTypeForGo(t *testing.T) {
 			}
 
 			// Act
-			result := getTypeForGo(field, service)
+			result := getTypeForGo(field, service, Types{})
 
 			// Assert
 			assert.Equal(t, "types.UnknownType", result,
@@ -440,12 +889,29 @@ func TestGetTypeForGo(t *testing.T) {
 			}
 
 			// Act
-			result := getTypeForGo(field, service)
+			result := getTypeForGo(field, service, Types{})
 
 			// Assert
 			assert.Equal(t, "[]"+testObjectName, result,
 				"Should handle both array and nullable modifiers correctly (no pointer per INF-407)")
 		})
+
+		t.Run("int enum type", func(t *testing.T) {
+			// Arrange
+			intEnumService := &specification.Service{
+				Enums: []specification.Enum{{Name: "Grade", EnumType: specification.EnumTypeInt}},
+			}
+			field := specification.Field{
+				Name: "Grade",
+				Type: "Grade",
+			}
+
+			// Act
+			result := getTypeForGo(field, intEnumService, Types{})
+
+			// Assert
+			assert.Equal(t, "types.Int", result, "Int enums should be Int fields")
+		})
 	})
 }
 
@@ -515,7 +981,7 @@ func TestGetTypeForGoFilter(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			// Act
-			result := getTypeForGoFilter(tc.field, service, tc.parentObject)
+			result := getTypeForGoFilter(tc.field, service, tc.parentObject, Types{})
 
 			// Assert
 			assert.Equal(t, tc.expectedType, result,
@@ -535,7 +1001,7 @@ func TestGetTypeForGoFilter(t *testing.T) {
 		}
 
 		// Act
-		result := getTypeForGoFilter(field, service, parentObject)
+		result := getTypeForGoFilter(field, service, parentObject, Types{})
 
 		// Assert
 		assert.Equal(t, testObjectName, result,
@@ -553,7 +1019,7 @@ func TestGenerateFilterObjects(t *testing.T) {
 	buf := &bytes.Buffer{}
 
 	// Act
-	err := generateObjects(buf, service)
+	err := generateObjects(buf, service, Types{})
 
 	// Assert
 	assert.Nil(t, err, "Expected no error when generating filter objects")
@@ -576,6 +1042,31 @@ func TestGenerateFilterObjects(t *testing.T) {
 	assert.Contains(t, generatedCode, "OrCondition types.Bool `json:\"orCondition\"`",
 		"Primitive types in filter objects should work normally")
 
+	t.Run("prefix filters of searchable resources", func(t *testing.T) {
+		// Arrange
+		searchable := specification.ApplyOverlay(&specification.Service{
+			Name: testServiceName,
+			Resources: []specification.Resource{
+				{
+					Name:       "School",
+					Operations: []string{"Search"},
+					Fields: []specification.ResourceField{
+						{Field: specification.Field{Name: "Name", Type: "String"}, Operations: []string{"Read"}},
+					},
+				},
+			},
+		})
+		buf := &bytes.Buffer{}
+
+		// Act
+		err := generateObjects(buf, searchable, Types{})
+
+		// Assert
+		assert.Nil(t, err, "Expected no error when generating filter objects")
+		assert.Contains(t, buf.String(), "StartsWith *SchoolFilterLike `json:\"startsWith\"`", "Prefix filters should use pointers")
+		assert.Contains(t, buf.String(), "NotStartsWith *SchoolFilterLike `json:\"notStartsWith\"`", "Negated prefix filters should use pointers")
+	})
+
 	t.Run("generated filter code is properly formatted", func(t *testing.T) {
 		// Verify the generated code can be parsed as valid Go
 		assert.NotContains(t, generatedCode, "**", "Should not have double pointers")
@@ -646,7 +1137,7 @@ func TestGetTypePrefix(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			// Act
-			result := getTypePrefix(tc.field, service)
+			result := getTypePrefix(tc.field, service, Types{})
 
 			// Assert
 			assert.Equal(t, tc.expectedPrefix, result,
@@ -663,7 +1154,7 @@ func TestGetTypePrefix(t *testing.T) {
 			}
 
 			// Act
-			result := getTypePrefix(field, service)
+			result := getTypePrefix(field, service, Types{})
 
 			// Assert
 			assert.Equal(t, "types.", result,
@@ -677,7 +1168,7 @@ func TestGetTypePrefix(t *testing.T) {
 			}
 
 			// Act
-			result := getTypePrefix(field, service)
+			result := getTypePrefix(field, service, Types{})
 
 			// Assert
 			assert.Equal(t, "types.", result,
@@ -696,7 +1187,7 @@ func TestGenerateObjects(t *testing.T) {
 	buf := &bytes.Buffer{}
 
 	// Act
-	err := generateObjects(buf, service)
+	err := generateObjects(buf, service, Types{})
 
 	// Assert
 	assert.Nil(t, err, "Expected no error when generating objects")
@@ -718,7 +1209,7 @@ func TestGenerateObjects(t *testing.T) {
 			buf := &bytes.Buffer{}
 
 			// Act
-			err := generateObjects(buf, serviceNoObjects)
+			err := generateObjects(buf, serviceNoObjects, Types{})
 
 			// Assert
 			assert.Nil(t, err, "Expected no error with empty objects")
@@ -741,7 +1232,7 @@ func TestGenerateObjects(t *testing.T) {
 			buf := &bytes.Buffer{}
 
 			// Act
-			err := generateObjects(buf, serviceEmptyObject)
+			err := generateObjects(buf, serviceEmptyObject, Types{})
 
 			// Assert
 			assert.Nil(t, err, "Expected no error")
@@ -769,7 +1260,7 @@ func TestGenerateObjects(t *testing.T) {
 			buf := &bytes.Buffer{}
 
 			// Act
-			err := generateObjects(buf, serviceWithError)
+			err := generateObjects(buf, serviceWithError, Types{})
 
 			// Assert
 			assert.Nil(t, err, "Expected no error")
@@ -782,6 +1273,29 @@ func TestGenerateObjects(t *testing.T) {
 	})
 }
 
+func TestGenerateObjects_Extends(t *testing.T) {
+	// Arrange
+	service := specification.ApplyOverlay(&specification.Service{
+		Name: testServiceName,
+		Objects: []specification.Object{
+			{Name: "Audited", Fields: []specification.Field{{Name: "ArchivedAt", Type: specification.FieldTypeTimestamp}}},
+			{Name: "Person", Extends: "Audited", Fields: []specification.Field{{Name: "Name", Type: specification.FieldTypeString}}},
+		},
+	})
+	buf := &bytes.Buffer{}
+
+	// Act
+	err := generateObjects(buf, service, Types{})
+
+	// Assert
+	assert.Nil(t, err, "Expected no error when generating objects")
+
+	formatted, err := format.Source(buf.Bytes())
+	assert.Nil(t, err, "Generated objects should parse as valid Go")
+	assert.Contains(t, string(formatted), "type Person struct {\n\tAudited\n\n", "Should embed the parent object")
+	assert.Equal(t, 1, strings.Count(string(formatted), "ArchivedAt types.Timestamp"), "Inherited fields should only be declared by the parent")
+}
+
 // ============================================================================
 // generateServer Tests
 // ============================================================================
@@ -792,7 +1306,7 @@ func TestGenerateServerFunc(t *testing.T) {
 	buf := &bytes.Buffer{}
 
 	// Act
-	err := generateServer(buf, service)
+	err := generateServer(buf, service, Types{}, templateSet{})
 
 	// Assert
 	assert.Nil(t, err, "Expected no error when generating server function")
@@ -806,9 +1320,9 @@ func TestGenerateServerFunc(t *testing.T) {
 	assert.Contains(t, generatedCode, expectedOpenAPIRoute, "Should register OpenAPI route")
 
 	// Check endpoint registration (note: generates singular paths)
-	assert.Contains(t, generatedCode, `routerGroup.POST("/user", serveWithResponse(201, api.Server, api.User.CreateUser))`,
+	assert.Contains(t, generatedCode, `routerGroup.POST("/user", serveWithResponse(201, api.Server, api.User.CreateUser, api.UserMiddlewares...))`,
 		"Should register POST endpoint with response")
-	assert.Contains(t, generatedCode, `routerGroup.DELETE("/user/:id", serveWithoutResponse(204, api.Server, api.User.DeleteUser))`,
+	assert.Contains(t, generatedCode, `routerGroup.DELETE("/user/:id", serveWithoutResponse(204, api.Server, api.User.DeleteUser, api.UserMiddlewares...))`,
 		"Should register DELETE endpoint without response")
 
 	// Check type definitions
@@ -830,7 +1344,7 @@ func TestGenerateServerFunc(t *testing.T) {
 			buf := &bytes.Buffer{}
 
 			// Act
-			err := generateServer(buf, serviceNoResources)
+			err := generateServer(buf, serviceNoResources, Types{}, templateSet{})
 
 			// Assert
 			assert.Nil(t, err, "Expected no error")
@@ -845,7 +1359,7 @@ func TestGenerateServerFunc(t *testing.T) {
 			buf := &bytes.Buffer{}
 
 			// Act
-			err := generateServer(buf, serviceVariousMethods)
+			err := generateServer(buf, serviceVariousMethods, Types{}, templateSet{})
 
 			// Assert
 			assert.Nil(t, err, "Expected no error")
@@ -858,748 +1372,2647 @@ func TestGenerateServerFunc(t *testing.T) {
 	})
 }
 
-// ============================================================================
-// generateRequestTypes Tests
-// ============================================================================
-
-func TestGenerateRequestTypes(t *testing.T) {
+func TestGenerateServerFunc_Scopes(t *testing.T) {
 	// Arrange
 	service := createTestServiceWithEndpoints()
+	service.Resources[0].Scopes = []string{"users:read"}
+	service.Resources[0].Endpoints[0].Scopes = []string{"users:write", "users:admin"}
 	buf := &bytes.Buffer{}
 
 	// Act
-	err := generateRequestTypes(buf, service)
+	err := generateServer(buf, service, Types{}, templateSet{})
 
 	// Assert
-	assert.Nil(t, err, "Expected no error when generating request types")
+	assert.Nil(t, err, "Expected no error when generating server function")
 
 	generatedCode := buf.String()
-	assert.Contains(t, generatedCode, expectedRequestContextType, "Should generate RequestContext struct")
-	assert.Contains(t, generatedCode, expectedRequestType, "Should generate Request generic type")
-	assert.Contains(t, generatedCode, expectedContextMethod, "Should generate Context() method")
-	assert.Contains(t, generatedCode, "return r.requestContext", "Context method should return requestContext field")
+	assert.Contains(t, generatedCode, `panic("CheckScopesFunc is nil")`, "Should require CheckScopesFunc when endpoints have scopes")
+	assert.Contains(t, generatedCode, `routerGroup.POST("/user", requireScopes("users:write", "users:admin"), serveWithResponse(201, api.Server, api.User.CreateUser, api.UserMiddlewares...))`,
+		"Should register endpoint scopes before the handler")
+	assert.Contains(t, generatedCode, `routerGroup.DELETE("/user/:id", requireScopes("users:read"), serveWithoutResponse(204, api.Server, api.User.DeleteUser, api.UserMiddlewares...))`,
+		"Should fall back to resource scopes")
+	assert.Contains(t, generatedCode, "CheckScopesFunc CheckScopesFunc[Session]", "Server should have CheckScopesFunc field")
+
+	t.Run("service without scopes", func(t *testing.T) {
+		// Arrange
+		buf := &bytes.Buffer{}
 
-	// Check path params type generation
-	assert.Contains(t, generatedCode, "type UserDeleteUserPathParams struct {", "Should generate path params type")
-	assert.Contains(t, generatedCode, `ID types.UUID `+"`json:\"id\"`", "Should include path param fields")
-
-	// Check query params type generation
-	assert.Contains(t, generatedCode, "type UserListUsersQueryParams struct {", "Should generate query params type")
-	assert.Contains(t, generatedCode, `Limit types.Int `+"`form:\"limit\" json:\"limit\"`", "Should use both form and json tags for query params")
+		// Act
+		err := generateServer(buf, createTestServiceWithEndpoints(), Types{}, templateSet{})
 
-	// Check body params type generation
-	assert.Contains(t, generatedCode, "type UserCreateUserBodyParams struct {", "Should generate body params type")
-	assert.Contains(t, generatedCode, `Name types.String `+"`json:\"name\"`", "Should use json tag for body params")
+		// Assert
+		assert.Nil(t, err, "Expected no error")
+		assert.NotContains(t, buf.String(), `panic("CheckScopesFunc is nil")`, "Should not require CheckScopesFunc")
+		assert.NotContains(t, buf.String(), "requireScopes(", "Should not register scope handlers")
+	})
+}
 
-	t.Run("edge cases", func(t *testing.T) {
-		t.Run("endpoint with no params", func(t *testing.T) {
-			// Arrange
-			serviceNoParams := &specification.Service{
-				Name:    testServiceName,
-				Version: testServiceVersion,
-				Resources: []specification.Resource{
-					{
-						Name: testResourceName,
-						Endpoints: []specification.Endpoint{
-							{
-								Name:   "Simple",
-								Method: "GET",
-								Path:   "/simple",
-								Request: specification.EndpointRequest{
-									PathParams:  []specification.Field{},
-									QueryParams: []specification.Field{},
-									BodyParams:  []specification.Field{},
-								},
-							},
-						},
-					},
-				},
-			}
-			buf := &bytes.Buffer{}
+func TestGenerateServer_OperationalEndpoints(t *testing.T) {
+	// Arrange
+	service := createTestServiceWithEndpoints()
+	service.Operational = &specification.OperationalEndpoints{Health: true, Readiness: true, Version: true}
+	buf := &bytes.Buffer{}
 
-			// Act
-			err := generateRequestTypes(buf, serviceNoParams)
+	// Act
+	err := GenerateServer(buf, service)
 
-			// Assert
-			assert.Nil(t, err, "Expected no error")
-			generatedCode := buf.String()
-			assert.NotContains(t, generatedCode, "type UserSimplePathParams", "Should not generate empty param types")
-			assert.NotContains(t, generatedCode, "type UserSimpleQueryParams", "Should not generate empty param types")
-			assert.NotContains(t, generatedCode, "type UserSimpleBodyParams", "Should not generate empty param types")
-		})
+	// Assert
+	assert.Nil(t, err, "Expected no error when generating server")
 
-		t.Run("fields with custom objects", func(t *testing.T) {
-			// Arrange
-			serviceCustomFields := &specification.Service{
-				Name:    testServiceName,
-				Version: testServiceVersion,
-				Objects: []specification.Object{
-					{Name: testObjectName},
-				},
-				Resources: []specification.Resource{
-					{
-						Name: testResourceName,
-						Endpoints: []specification.Endpoint{
-							{
-								Name:   "CreateWithAddress",
-								Method: "POST",
-								Request: specification.EndpointRequest{
-									BodyParams: []specification.Field{
-										{
-											Name: "HomeAddress",
-											Type: testObjectName,
-										},
-									},
-								},
-							},
-						},
-					},
-				},
-			}
-			buf := &bytes.Buffer{}
+	generatedCode := buf.String()
+	assert.Contains(t, generatedCode, `routerGroup.GET("/healthz", serveCheck(api.Server.HealthCheckFunc))`, "Should register the health endpoint")
+	assert.Contains(t, generatedCode, `routerGroup.GET("/readyz", serveCheck(api.Server.ReadinessCheckFunc))`, "Should register the readiness endpoint")
+	assert.Contains(t, generatedCode, `routerGroup.GET("/version", serveVersion)`, "Should register the version endpoint")
+	assert.Contains(t, generatedCode, "HealthCheckFunc CheckFunc", "Server should have HealthCheckFunc field")
+	assert.Contains(t, generatedCode, "ReadinessCheckFunc CheckFunc", "Server should have ReadinessCheckFunc field")
+	assert.Contains(t, generatedCode, "func serveCheck(check CheckFunc) gin.HandlerFunc", "Should generate the check handler")
+	assert.Contains(t, generatedCode, `VersionResponse{Name: "TestService", Version: "v1"}`, "Should respond with the service name and version")
+
+	t.Run("service without operational endpoints", func(t *testing.T) {
+		// Arrange
+		buf := &bytes.Buffer{}
 
-			// Act
-			err := generateRequestTypes(buf, serviceCustomFields)
+		// Act
+		err := GenerateServer(buf, createTestServiceWithEndpoints())
 
-			// Assert
-			assert.Nil(t, err, "Expected no error")
-			generatedCode := buf.String()
-			assert.Contains(t, generatedCode, "HomeAddress Address `json:\"homeAddress\"`",
-				"Should handle custom object types correctly")
-		})
+		// Assert
+		assert.Nil(t, err, "Expected no error")
+		assert.NotContains(t, buf.String(), "serveCheck", "Should not generate the check handler")
+		assert.NotContains(t, buf.String(), "serveVersion", "Should not generate the version handler")
 	})
 }
 
-// ============================================================================
-// generateResponseTypes Tests
-// ============================================================================
-
-func TestGenerateResponseTypes(t *testing.T) {
+func TestGenerateServer_DocsUI(t *testing.T) {
 	// Arrange
 	service := createTestServiceWithEndpoints()
+	service.Name = "Docs `Test` <Service>"
 	buf := &bytes.Buffer{}
 
 	// Act
-	err := generateResponseTypes(buf, service)
+	err := GenerateServer(buf, service)
 
 	// Assert
-	assert.Nil(t, err, "Expected no error when generating response types")
+	assert.Nil(t, err, "Expected no error when generating server")
 
 	generatedCode := buf.String()
-	assert.Contains(t, generatedCode, "type UserListUsersResponse struct {", "Should generate response type")
-	assert.Contains(t, generatedCode, `Data []types.User `+"`json:\"data\"`", "Should include array field")
-	assert.Contains(t, generatedCode, `Pagination Pagination `+"`json:\"pagination\"`", "Should include pagination field")
-
-	t.Run("edge cases", func(t *testing.T) {
-		t.Run("endpoint with no response body", func(t *testing.T) {
-			// Arrange
-			serviceNoResponse := &specification.Service{
-				Name:    testServiceName,
-				Version: testServiceVersion,
-				Resources: []specification.Resource{
-					{
-						Name: testResourceName,
-						Endpoints: []specification.Endpoint{
-							{
-								Name:   "Delete",
-								Method: "DELETE",
-								Response: specification.EndpointResponse{
-									StatusCode: 204,
-									BodyFields: []specification.Field{},
-								},
-							},
-						},
-					},
-				},
-			}
-			buf := &bytes.Buffer{}
-
-			// Act
-			err := generateResponseTypes(buf, serviceNoResponse)
+	assert.Contains(t, generatedCode, `routerGroup.StaticFileFS("/openapi.json", "openapi.json", http.FS(api.OpenAPI_JSON))`, "Should serve the embedded OpenAPI specification")
+	assert.Contains(t, generatedCode, `routerGroup.GET("/docs", serveDocsUI(api.Server.DocsUI))`, "Should register the documentation page")
+	assert.Contains(t, generatedCode, "DocsUI DocsUI", "Server should have DocsUI field")
+	assert.Contains(t, generatedCode, swaggerUIBundleURL, "Should generate the Swagger UI page")
+	assert.Contains(t, generatedCode, redocBundleURL, "Should generate the Redoc page")
+	assert.Contains(t, generatedCode, "<title>Docs &#96;Test&#96; &lt;Service&gt; API</title>", "Should escape the service name in the page title")
+}
 
-			// Assert
-			assert.Nil(t, err, "Expected no error")
-			assert.NotContains(t, buf.String(), "type UserDeleteResponse",
-				"Should not generate response type for endpoints with no body")
-		})
+func TestGenerateServer_EndpointTypeName(t *testing.T) {
+	// Arrange
+	service := createTestServiceWithEndpoints()
+	service.Resources[0].Endpoints[0].TypeName = "UserSignup"
+	buf := &bytes.Buffer{}
 
-		t.Run("response with custom object fields", func(t *testing.T) {
-			// Arrange
-			serviceCustomResponse := &specification.Service{
-				Name:    testServiceName,
-				Version: testServiceVersion,
-				Objects: []specification.Object{
-					{Name: "Stats"},
-				},
-				Resources: []specification.Resource{
-					{
-						Name: testResourceName,
-						Endpoints: []specification.Endpoint{
-							{
-								Name: "GetStats",
-								Response: specification.EndpointResponse{
-									StatusCode: 200,
-									BodyFields: []specification.Field{
-										{
-											Name: "UserStats",
-											Type: "Stats",
-										},
-									},
-								},
-							},
-						},
-					},
-				},
-			}
-			buf := &bytes.Buffer{}
+	// Act
+	err := GenerateServer(buf, service)
 
-			// Act
-			err := generateResponseTypes(buf, serviceCustomResponse)
+	// Assert
+	assert.Nil(t, err, "Expected no error when generating server")
 
-			// Assert
-			assert.Nil(t, err, "Expected no error")
-			generatedCode := buf.String()
-			assert.Contains(t, generatedCode, "UserStats Stats `json:\"userStats\"`",
-				"Should handle custom object types in responses")
-		})
-	})
+	generatedCode := buf.String()
+	assert.Contains(t, generatedCode, "type UserSignupBodyParams struct {", "Should name the body params after the type name")
+	assert.NotContains(t, generatedCode, "CreateUserBodyParams", "Should not use the resource and endpoint names")
 }
 
-// ============================================================================
-// generateUtils Tests
-// ============================================================================
-
-func TestGenerateUtils(t *testing.T) {
+func TestGenerateServer_GoIdentifiers(t *testing.T) {
 	// Arrange
+	service := createTestServiceWithEndpoints()
+	service.Objects = append(service.Objects, specification.Object{
+		Name: "Ranking",
+		Fields: []specification.Field{
+			{Name: "type", Type: specification.FieldTypeString},
+			{Name: "1stPlace", Type: specification.FieldTypeString},
+			{Name: "func", Type: specification.FieldTypeInt},
+		},
+	})
+	service.Resources[0].Endpoints[0].Request.BodyParams = append(service.Resources[0].Endpoints[0].Request.BodyParams,
+		specification.Field{Name: "type", Type: specification.FieldTypeString})
+	service.ResponseHeaders = []specification.Field{{Name: "X-Request-ID", Type: specification.FieldTypeString}}
 	buf := &bytes.Buffer{}
-	service := createTestService()
 
 	// Act
-	err := generateUtils(buf, service)
+	err := GenerateServer(buf, service)
 
 	// Assert
-	assert.Nil(t, err, "Expected no error when generating utils")
+	assert.Nil(t, err, "Expected no error when generating server")
+
+	formatted, err := format.Source(buf.Bytes())
+	assert.Nil(t, err, "Generated server should parse as valid Go")
+	generatedCode := string(formatted)
+	assert.Contains(t, generatedCode, "Type types.String `json:\"type\"`", "Should capitalize a field named after a Go keyword")
+	assert.Contains(t, generatedCode, "X1stPlace types.String `json:\"1stPlace\"`", "Should prefix a field starting with a digit")
+	assert.Contains(t, generatedCode, "Func types.Int `json:\"func\"`", "Should capitalize a field named after a Go keyword")
+	assert.Contains(t, generatedCode, "XRequestID types.String", "Should remove the hyphens of header names")
+	assert.Contains(t, generatedCode, `"X-Request-ID"`, "Should keep the name of the header")
+}
 
-	generatedCode := buf.String()
+// findRepeatedStructFields returns the fields of the structs in the Go source that repeat the name or the JSON tag of
+// a field before them in the same struct, like the structtag check of go vet, since encoding/json drops both fields.
+func findRepeatedStructFields(t *testing.T, source []byte) []string {
+	t.Helper()
 
-	// Check all utility functions are generated
-	assert.Contains(t, generatedCode, expectedGetRequestContext, "Should generate getRequestContext")
-	assert.Contains(t, generatedCode, expectedServeWithResponse, "Should generate serveWithResponse")
-	assert.Contains(t, generatedCode, expectedServeWithoutResponse, "Should generate serveWithoutResponse")
-	assert.Contains(t, generatedCode, expectedHandleRequest, "Should generate handleRequest")
-	assert.Contains(t, generatedCode, expectedDecodeBodyParams, "Should generate decodeBodyParams")
-	assert.Contains(t, generatedCode, expectedDecodePathParams, "Should generate decodePathParams")
-	assert.Contains(t, generatedCode, expectedDecodeQueryParams, "Should generate decodeQueryParams")
+	file, err := parser.ParseFile(token.NewFileSet(), "", source, 0)
+	assert.Nil(t, err, "Expected the generated file to parse as Go")
+	if err != nil {
+		return nil
+	}
 
-	// Check function implementations
-	assert.Contains(t, generatedCode, `getRequestID := server.GetRequestIDFunc`, "Should assign GetRequestIDFunc to local variable")
-	assert.Contains(t, generatedCode, `if getRequestID == nil {`, "Should check if GetRequestIDFunc is nil")
-	assert.Contains(t, generatedCode, `getRequestID = defaultGetRequestID`, "Should use defaultGetRequestID when nil")
-	assert.Contains(t, generatedCode, `requestID := getRequestID(c.Request.Context())`, "Should call getRequestID with context")
-	assert.Contains(t, generatedCode, "handleRequest[sessionType, pathParamsType, queryParamsType, bodyParamsType]",
-		"Should call handleRequest with generic types")
-	assert.Contains(t, generatedCode, "c.JSON(successStatusCode, response)",
-		"Should return JSON response with success code")
-	assert.Contains(t, generatedCode, "c.JSON(server.ErrorHook(c.Request.Context(), requestContext, nil, err).Response())",
-		"Should return pre-auth error using Response() method with nil session")
-	assert.Contains(t, generatedCode, "c.JSON(server.ErrorHook(c.Request.Context(), requestContext, &request.Session, err).Response())",
-		"Should return post-auth error using Response() method with session")
+	var repeated []string
+	ast.Inspect(file, func(node ast.Node) bool {
+		typeSpec, ok := node.(*ast.TypeSpec)
+		if !ok {
+			return true
+		}
+		structType, ok := typeSpec.Type.(*ast.StructType)
+		if !ok {
+			return true
+		}
 
-	// Check that requestContext is built in serve functions
-	assert.Contains(t, generatedCode, "requestContext := getRequestContext(c, requestID)",
-		"Should call getRequestContext to build RequestContext in serve functions")
+		names, tags := map[string]bool{}, map[string]bool{}
+		for _, field := range structType.Fields.List {
+			for _, name := range field.Names {
+				if names[name.Name] {
+					repeated = append(repeated, typeSpec.Name.Name+"."+name.Name)
+				}
+				names[name.Name] = true
+			}
 
-	// Check handleRequest implementation
-	assert.Contains(t, generatedCode, "handleRequest[sessionType, pathParamsType, queryParamsType, bodyParamsType](c, requestContext, server)",
-		"Should call handleRequest with requestContext parameter")
-	assert.Contains(t, generatedCode, "if _, ok := any(request.BodyParams).(struct{}); !ok {",
-		"Should check if body params exist")
-	assert.Contains(t, generatedCode, "if _, ok := any(request.PathParams).(struct{}); !ok {",
-		"Should check if path params exist")
-	assert.Contains(t, generatedCode, "if _, ok := any(request.QueryParams).(struct{}); !ok {",
-		"Should check if query params exist")
+			if field.Tag == nil {
+				continue
+			}
+			tag, err := strconv.Unquote(field.Tag.Value)
+			assert.Nil(t, err)
+			jsonName, _, _ := strings.Cut(reflect.StructTag(tag).Get("json"), ",")
+			if jsonName == "" || jsonName == "-" {
+				continue
+			}
+			if tags[jsonName] {
+				repeated = append(repeated, typeSpec.Name.Name+" json:\""+jsonName+"\"")
+			}
+			tags[jsonName] = true
+		}
+		return true
+	})
 
-	// Check decode functions
-	assert.Contains(t, generatedCode, "json.NewDecoder(r.Body).Decode(&v)",
-		"Should decode JSON body")
-	assert.Contains(t, generatedCode, "c.ShouldBindQuery(&result)",
-		"Should bind query parameters")
-	assert.Contains(t, generatedCode, "m := make(map[string]string, len(c.Params))",
-		"Should create map for path params")
+	return repeated
+}
 
-	t.Run("consistency", func(t *testing.T) {
-		// Generate twice and compare
-		buf1 := &bytes.Buffer{}
-		buf2 := &bytes.Buffer{}
-		service := createTestService()
+func TestGenerateServer_StructFields(t *testing.T) {
+	// Arrange
+	service, err := specification.ParseServiceFromFile("../../testdata/school-management-api.yaml")
+	assert.Nil(t, err, "Expected the specification to parse")
+
+	for _, types := range []Types{{}, {Stdlib: true}} {
+		buf := &bytes.Buffer{}
 
-		err1 := generateUtils(buf1, service)
-		err2 := generateUtils(buf2, service)
+		// Act
+		err := GenerateServerWithOptions(buf, service, Options{Types: types})
 
-		assert.Nil(t, err1, "First generation should not error")
-		assert.Nil(t, err2, "Second generation should not error")
-		assert.Equal(t, buf1.String(), buf2.String(), "Utils should be generated consistently")
-	})
+		// Assert
+		assert.Nil(t, err, "Expected no error when generating server")
+		assert.Empty(t, findRepeatedStructFields(t, buf.Bytes()), "No struct should repeat a field name or JSON tag")
+		assert.Contains(t, buf.String(), "\t// ID: Unique student identifier\n", "The id of the resource should be the ID auto-column")
+	}
+}
+
+func TestGenerateServer_NonASCIINames(t *testing.T) {
+	// Arrange
+	service := createTestServiceWithEndpoints()
+	service.Enums = append(service.Enums, specification.Enum{
+		Name:   "Ärendestatus",
+		Values: []specification.EnumValue{{Name: "Öppen", Description: "Ärendet är öppet"}},
+	})
+	service.Objects = append(service.Objects, specification.Object{
+		Name:   "Adress",
+		Fields: []specification.Field{{Name: "Förnamn", Description: "Elevens förnamn", Type: specification.FieldTypeString}},
+	})
+	buf := &bytes.Buffer{}
+
+	// Act
+	err := GenerateServer(buf, service)
+
+	// Assert
+	assert.Nil(t, err, "Expected no error when generating server")
+
+	formatted, err := format.Source(buf.Bytes())
+	assert.Nil(t, err, "Generated server should parse as valid Go")
+	generatedCode := string(formatted)
+	assert.Contains(t, generatedCode, "Fornamn types.String `json:\"förnamn\"`", "Should transliterate the Go name and keep the JSON name")
+	assert.Contains(t, generatedCode, "// Förnamn: Elevens förnamn", "Should keep the letters of the description")
+	assert.Contains(t, generatedCode, `ÄrendestatusOppen = types.NewString("Öppen")`, "Should transliterate the constant and keep the value")
+}
+
+func TestGenerateServer_JSONNaming(t *testing.T) {
+	// Arrange
+	service := specification.ApplyDefaultOverlays(&specification.Service{
+		Name:    "TestService",
+		Version: "v1",
+		Naming:  &specification.Naming{JSON: specification.JSONCaseSnake},
+		Resources: []specification.Resource{{
+			Name:       "User",
+			Operations: []string{specification.OperationCreate, specification.OperationGet},
+			Fields: []specification.ResourceField{
+				{Field: specification.Field{Name: "FirstName", Type: specification.FieldTypeString}, Operations: []string{specification.OperationCreate, specification.OperationRead}},
+			},
+		}},
+	})
+	buf := &bytes.Buffer{}
+
+	// Act
+	err := GenerateServer(buf, service)
+
+	// Assert
+	assert.Nil(t, err, "Expected no error when generating server")
+	assert.Contains(t, buf.String(), "FirstName types.String `json:\"first_name\"`", "Should tag the fields with the JSON names of the naming")
+	assert.Contains(t, buf.String(), "RequestID types.String `json:\"request_id\"`", "Should tag the error fields with the JSON names of the naming")
+	assert.NotContains(t, buf.String(), `json:"firstName"`, "Should not use camelCase")
+}
+
+func TestGenerateServer_ResponseHeaderNames(t *testing.T) {
+	// Arrange
+	service := createTestServiceWithEndpoints()
+	service.ResponseHeaders = []specification.Field{
+		{Name: "TotalCount", HeaderName: "X-Total-Count", Type: specification.FieldTypeInt},
+		{Name: "TraceID", HeaderName: "X-Trace-ID", Type: specification.FieldTypeString},
+		{Name: "RateLimit", Type: specification.FieldTypeInt},
+	}
+	buf := &bytes.Buffer{}
+
+	// Act
+	err := GenerateServer(buf, service)
+
+	// Assert
+	assert.Nil(t, err, "Expected no error when generating server")
+	assert.Contains(t, buf.String(), "TotalCount types.Int", "Should name the struct field after the field name")
+	assert.Contains(t, buf.String(), `c.Header("X-Total-Count", headers.TotalCount.String())`, "Should set a canonical header name with gin")
+	assert.Contains(t, buf.String(), "if v := headers.TraceID.String(); v != \"\" {\n\t\tc.Writer.Header()[\"X-Trace-ID\"] = []string{v}\n", "Should keep the casing of a header name that is not canonical")
+	assert.Contains(t, buf.String(), "if v := headers.RateLimit.String(); v != \"\" {\n\t\tc.Writer.Header()[\"RateLimit\"] = []string{v}\n", "Should default the header name to the field name")
+	_, err = format.Source(buf.Bytes())
+	assert.Nil(t, err, "Generated code should be valid Go")
+}
+
+func TestGenerateServer_EmptyResponseHeaders(t *testing.T) {
+	for _, types := range []Types{{}, {Stdlib: true}} {
+		// Arrange
+		service := createTestServiceWithEndpoints()
+		service.ResponseHeaders = []specification.Field{
+			{Name: "TraceID", HeaderName: "X-Trace-ID", Type: specification.FieldTypeString, Modifiers: []string{specification.ModifierNullable}},
+		}
+		buf := &bytes.Buffer{}
+
+		// Act
+		err := GenerateServerWithOptions(buf, service, Options{Types: types})
+
+		// Assert
+		assert.Nil(t, err, "Expected no error when generating server")
+		formatted, err := format.Source(buf.Bytes())
+		assert.Nil(t, err, "Generated code should be valid Go")
+		assert.Regexp(t, `if v := \*?headers\.TraceID(\.String\(\))?; v != "" \{\n\s+c\.Writer\.Header\(\)\["X-Trace-ID"\] = \[\]string\{v\}\n\s+\} else \{\n\s+delete\(c\.Writer\.Header\(\), "X-Trace-ID"\)\n`,
+			string(formatted), "Should leave out a header that is not canonical when its value is empty, like gin")
+	}
+}
+
+func TestGenerateServer_TotalCountHeader(t *testing.T) {
+	createService := func(totalCount string) *specification.Service {
+		return specification.ApplyDefaultOverlays(&specification.Service{
+			Name:    "TestService",
+			Version: "v1",
+			Resources: []specification.Resource{{
+				Name:       "User",
+				Operations: []string{specification.OperationList, specification.OperationSearch},
+				TotalCount: totalCount,
+				Fields: []specification.ResourceField{
+					{Field: specification.Field{Name: "Name", Type: specification.FieldTypeString}, Operations: []string{specification.OperationRead}},
+				},
+			}},
+		})
+	}
+
+	t.Run("total in the header", func(t *testing.T) {
+		// Arrange
+		buf := &bytes.Buffer{}
+
+		// Act
+		err := GenerateServer(buf, createService(specification.TotalCountHeader))
+
+		// Assert
+		assert.Nil(t, err, "Expected no error when generating server")
+		assert.Contains(t, buf.String(), "Pagination PaginationWithoutTotal `json:\"pagination\"`", "Should respond with the pagination without total")
+		assert.Contains(t, buf.String(), "TotalCount types.Int `json:\"-\"`", "Should not encode the total in the body")
+		assert.Contains(t, buf.String(), "func (response *UserListResponse) writeResponseHeaders(c *gin.Context) {\n\tc.Header(\"X-Total-Count\", response.TotalCount.String())\n}", "Should write the total of List to the header")
+		assert.Contains(t, buf.String(), "func (response *UserSearchResponse) writeResponseHeaders(c *gin.Context) {", "Should write the total of Search to the header")
+		assert.Contains(t, buf.String(), "if headers, ok := any(response).(responseHeadersWriter); ok && response != nil {", "Should write the headers of the responses")
+		_, err = format.Source(buf.Bytes())
+		assert.Nil(t, err, "Generated code should be valid Go")
+	})
+
+	t.Run("total in the pagination", func(t *testing.T) {
+		// Arrange
+		buf := &bytes.Buffer{}
+
+		// Act
+		err := GenerateServer(buf, createService(""))
+
+		// Assert
+		assert.Nil(t, err, "Expected no error when generating server")
+		assert.Contains(t, buf.String(), "Pagination Pagination `json:\"pagination\"`", "Should respond with the pagination with total")
+		assert.NotContains(t, buf.String(), "responseHeadersWriter", "Should not write headers from the responses")
+	})
+}
+
+func TestGenerateServer_DecimalImport(t *testing.T) {
+	t.Run("imports decimal when a field uses it", func(t *testing.T) {
+		// Arrange
+		service := createTestServiceWithEndpoints()
+		service.Objects = append(service.Objects, specification.Object{
+			Name:   "Price",
+			Fields: []specification.Field{{Name: "Amount", Type: specification.FieldTypeDecimal}},
+		})
+		buf := &bytes.Buffer{}
+
+		// Act
+		err := GenerateServer(buf, service)
+
+		// Assert
+		assert.Nil(t, err, "Expected no error when generating server")
+		assert.Contains(t, buf.String(), `"github.com/shopspring/decimal"`, "Should import the decimal package")
+		assert.Contains(t, buf.String(), "Amount decimal.Decimal", "Should use decimal.Decimal for the field")
+	})
+
+	t.Run("does not import decimal otherwise", func(t *testing.T) {
+		// Arrange
+		service := createTestServiceWithEndpoints()
+		buf := &bytes.Buffer{}
+
+		// Act
+		err := GenerateServer(buf, service)
+
+		// Assert
+		assert.Nil(t, err, "Expected no error when generating server")
+		assert.NotContains(t, buf.String(), "shopspring/decimal", "Should not import the decimal package")
+	})
+}
+
+func TestGenerateServerFunc_Tenancy(t *testing.T) {
+	// Arrange
+	service := createTestServiceWithEndpoints()
+	service.Tenancy = &specification.Tenancy{In: specification.TenancyInPath, Name: "TenantID"}
+	buf := &bytes.Buffer{}
+
+	// Act
+	err := generateServer(buf, service, Types{}, templateSet{})
+
+	// Assert
+	assert.Nil(t, err, "Expected no error when generating server function")
+	assert.Contains(t, buf.String(), `routerGroup.DELETE("/:tenantID/user/:id", serveWithoutResponse(204, api.Server, api.User.DeleteUser, api.UserMiddlewares...))`,
+		"Should prefix routes with the tenant parameter")
+
+	t.Run("header tenancy extracts the tenant", func(t *testing.T) {
+		// Arrange
+		service := createTestServiceWithEndpoints()
+		service.Tenancy = &specification.Tenancy{In: specification.TenancyInHeader, Name: "X-Tenant-ID"}
+		buf := &bytes.Buffer{}
+
+		// Act
+		err := GenerateServer(buf, service)
+
+		// Assert
+		assert.Nil(t, err, "Expected no error when generating server")
+		generatedCode := buf.String()
+		assert.Contains(t, generatedCode, `c.GetHeader("X-Tenant-ID")`, "Should read the tenant from the header")
+		assert.Contains(t, generatedCode, "TenantID:       requestContext.TenantID,", "Should copy the tenant into the request")
+		assert.Contains(t, generatedCode, `routerGroup.DELETE("/user/:id"`, "Should not prefix routes")
+	})
+}
+
+func TestGenerateServer_Localization(t *testing.T) {
+	// Arrange
+	service := createTestServiceWithEndpoints()
+	service.Localization = &specification.Localization{
+		DefaultLocale: "en",
+		Messages: map[string]map[string]string{
+			"NotFound": {"en": "Not found", "sv-SE": "Hittades inte"},
+		},
+	}
+	buf := &bytes.Buffer{}
+
+	// Act
+	err := GenerateServer(buf, service)
+
+	// Assert
+	assert.Nil(t, err, "Expected no error when generating server")
+	generatedCode := buf.String()
+	assert.Contains(t, generatedCode, `const defaultLocale = "en"`, "Should declare the default locale")
+	assert.Contains(t, generatedCode, `var locales = []string{"en", "sv-SE"}`, "Should declare the locales with the default first")
+	assert.Contains(t, generatedCode, `"NotFound": "Hittades inte",`, "Should declare the localized messages")
+	assert.Contains(t, generatedCode, `resolveLocale(c.GetHeader("Accept-Language"))`, "Should resolve the locale of the request")
+	assert.Contains(t, generatedCode, "localizeError(requestContext.Locale, ", "Should localize the written errors")
+	assert.Contains(t, generatedCode, "err.Message = types.NewString(message)", "Should replace the message of the error")
+
+	t.Run("error code wire values", func(t *testing.T) {
+		// Arrange
+		service := createTestServiceWithEndpoints()
+		service.Enums = append(service.Enums, specification.Enum{Name: "ErrorCode", Values: []specification.EnumValue{{Name: "NotFound", Value: "not_found"}}})
+		service.Localization = &specification.Localization{
+			DefaultLocale: "en",
+			Messages:      map[string]map[string]string{"NotFound": {"en": "Not found"}},
+		}
+		buf := &bytes.Buffer{}
+
+		// Act
+		err := GenerateServer(buf, service)
+
+		// Assert
+		assert.Nil(t, err, "Expected no error when generating server")
+		assert.Contains(t, buf.String(), `"not_found": "Not found",`, "Should key the messages by the wire value of the error code")
+	})
+
+	t.Run("no localization", func(t *testing.T) {
+		// Arrange
+		service := createTestServiceWithEndpoints()
+		buf := &bytes.Buffer{}
+
+		// Act
+		err := GenerateServer(buf, service)
+
+		// Assert
+		assert.Nil(t, err, "Expected no error when generating server")
+		assert.NotContains(t, buf.String(), "localizeError", "Should not localize errors")
+		assert.NotContains(t, buf.String(), "Accept-Language", "Should not read the Accept-Language header")
+	})
+}
+
+func TestGenerateServer_Middlewares(t *testing.T) {
+	// Arrange
+	service := createTestServiceWithEndpoints()
+	buf := &bytes.Buffer{}
+
+	// Act
+	err := GenerateServer(buf, service)
+
+	// Assert
+	assert.Nil(t, err, "Expected no error when generating server")
+	generatedCode := buf.String()
+	assert.Contains(t, generatedCode, "type Middleware[Session any] struct {", "Should define the Middleware type")
+	assert.Contains(t, generatedCode, "\tMiddlewares []Middleware[Session]\n", "Server should have global middlewares")
+	assert.Contains(t, generatedCode, "\tUserMiddlewares []Middleware[Session]", "API should have middlewares per resource")
+	assert.Contains(t, generatedCode, "func PreHandleOf[Session, P, Q, B any](", "Should define the typed PreHandle helper")
+	assert.Contains(t, generatedCode, "func PostHandleOf[Session, P, Q, B, R any](", "Should define the typed PostHandle helper")
+	assert.Contains(t, generatedCode, "middlewares = append(server.Middlewares[:len(server.Middlewares):len(server.Middlewares)], middlewares...)",
+		"Should run the global middlewares before the middlewares of the resource")
+	assert.Equal(t, 2, strings.Count(generatedCode, "if err := runPreHandle(c.Request.Context(), requestContext, middlewares, request); err != nil {"),
+		"Both serve functions should run the PreHandle hooks")
+	assert.Contains(t, generatedCode, "err = runPostHandle(c.Request.Context(), requestContext, middlewares, request, response, err)")
+	assert.Contains(t, generatedCode, "err = runPostHandle(c.Request.Context(), requestContext, middlewares, request, nil, err)")
+}
+
+func TestGenerateServer_Authorize(t *testing.T) {
+	// Arrange
+	service := specification.ApplyOverlay(&specification.Service{
+		Name:    "TestService",
+		Version: "v1",
+		Resources: []specification.Resource{
+			{
+				Name:        "Users",
+				Operations:  []string{"Get", "Delete"},
+				Permissions: []string{"users:view"},
+				Endpoints: []specification.Endpoint{
+					{Name: "Archive", Method: "POST", Path: "/{id}/archive", Permissions: []string{"users:archive"}, Response: specification.EndpointResponse{StatusCode: 204}},
+				},
+			},
+			{Name: "Schools", Operations: []string{"List"}},
+		},
+	})
+	buf := &bytes.Buffer{}
+
+	// Act
+	err := GenerateServer(buf, service)
+
+	// Assert
+	assert.Nil(t, err, "Expected no error when generating server")
+	generatedCode := buf.String()
+	assert.Contains(t, generatedCode, "type EndpointInfo struct {", "Should define the EndpointInfo type")
+	assert.Contains(t, generatedCode, "type AuthorizeFunc[Session any] func(ctx context.Context, session Session, endpoint EndpointInfo) error", "Should define the AuthorizeFunc type")
+	assert.Contains(t, generatedCode, "\tAuthorizeFunc AuthorizeFunc[Session]\n", "Server should have the AuthorizeFunc hook")
+	// The entries of endpointInfos are aligned by gofmt
+	compactCode := strings.Join(strings.Fields(generatedCode), " ")
+	assert.Contains(t, compactCode, `"GET /test-service/v1/users/:id": {Resource: "Users", Operation: "Get", Permissions: []string{"users:view"}},`, "Generated endpoints should require the resource permissions")
+	assert.Contains(t, compactCode, `"POST /test-service/v1/users/:id/archive": {Resource: "Users", Operation: "Archive", Permissions: []string{"users:archive"}},`, "Endpoint permissions should override the resource permissions")
+	assert.Contains(t, compactCode, `"GET /test-service/v1/schools": {Resource: "Schools", Operation: "List"},`, "Endpoints without permissions should be listed too")
+	assert.Contains(t, generatedCode, "server.AuthorizeFunc(c.Request.Context(), session, getEndpointInfo(c))", "handleRequest should authorize the session")
+	assert.Contains(t, generatedCode, "endpointInfos[c.Request.Method+\" \"+c.FullPath()]", "getEndpointInfo should look up the route of the request")
+}
+
+func TestJoinGinPaths(t *testing.T) {
+	assert.Equal(t, "/api/v1/users/:id", joinGinPaths("/api/v1", "/users/:id"))
+	assert.Equal(t, "/users/", joinGinPaths("", "/users/"), "Should keep the trailing slash of the route like gin")
+	assert.Equal(t, "/users", joinGinPaths("/", "/users"))
+}
+
+func TestGenerateServer_FieldScopes(t *testing.T) {
+	// Arrange
+	service := specification.ApplyOverlay(&specification.Service{
+		Name:    "TestService",
+		Version: "v1",
+		Objects: []specification.Object{
+			{
+				Name: "Contact",
+				Fields: []specification.Field{
+					{Name: "Email", Type: "String", Modifiers: []string{"Nullable"}, Scopes: []string{"users:contact"}},
+				},
+			},
+		},
+		Resources: []specification.Resource{
+			{
+				Name:       "Users",
+				Operations: []string{"Get", "List"},
+				Fields: []specification.ResourceField{
+					{Field: specification.Field{Name: "SSN", Type: "String", Modifiers: []string{"Nullable"}, Scopes: []string{"users:ssn", "users:pii"}}, Operations: []string{"Read"}},
+					{Field: specification.Field{Name: "Contact", Type: "Contact"}, Operations: []string{"Read"}},
+					{Field: specification.Field{Name: "Contacts", Type: "Contact", Modifiers: []string{"Array"}}, Operations: []string{"Read"}},
+				},
+			},
+		},
+	})
+	buf := &bytes.Buffer{}
+
+	// Act
+	err := GenerateServer(buf, service)
+
+	// Assert
+	assert.Nil(t, err, "Expected no error when generating server")
+	generatedCode := buf.String()
+	assert.Contains(t, generatedCode, "type HasScopeFunc[Session any] func(ctx context.Context, session Session, scope string) bool", "Should define the HasScopeFunc type")
+	assert.Contains(t, generatedCode, "\tHasScopeFunc HasScopeFunc[Session]\n", "Server should have the HasScopeFunc hook")
+	assert.Contains(t, generatedCode, "panic(\"HasScopeFunc is nil\")", "Register should require HasScopeFunc")
+	assert.Contains(t, generatedCode, "func (v *Contact) redact(hasScope func(scope string) bool) {", "Objects with scoped fields should be redacted")
+	assert.Contains(t, generatedCode, "\tif !hasScope(\"users:contact\") {\n\t\tredactField(&v.Email)\n\t}\n")
+	assert.Contains(t, generatedCode, "\tif !hasScope(\"users:ssn\") || !hasScope(\"users:pii\") {\n\t\tredactField(&v.SSN)\n\t}\n", "Fields should require all of their scopes")
+	assert.Contains(t, generatedCode, "\tv.Contact.redact(hasScope)\n", "Nested objects should be redacted")
+	assert.Contains(t, generatedCode, "\tfor i := range v.Contacts {\n\t\tv.Contacts[i].redact(hasScope)\n\t}\n", "Arrays of objects should be redacted")
+	assert.Contains(t, generatedCode, "func (v *UsersListResponse) redact(hasScope func(scope string) bool) {", "Response types holding redacted objects should be redacted")
+	assert.Contains(t, generatedCode, "redactResponse(response, newHasScope(c.Request.Context(), server.HasScopeFunc, request.Session))", "serveWithResponse should redact the response")
+	assert.NotContains(t, generatedCode, "func (v *UsersFilter) redact(", "Filter objects are never part of a response")
+}
+
+func TestGenerateServer_WithoutFieldScopes(t *testing.T) {
+	// Arrange
+	service := specification.ApplyOverlay(&specification.Service{
+		Name:      "TestService",
+		Version:   "v1",
+		Resources: []specification.Resource{{Name: "Users", Operations: []string{"Get"}}},
+	})
+	buf := &bytes.Buffer{}
+
+	// Act
+	err := GenerateServer(buf, service)
+
+	// Assert
+	assert.Nil(t, err, "Expected no error when generating server")
+	assert.NotContains(t, buf.String(), "HasScopeFunc", "Should not generate the HasScopeFunc hook without scoped fields")
+	assert.NotContains(t, buf.String(), "redact", "Should not redact without scoped fields")
+}
+
+func TestGenerateServer_Audit(t *testing.T) {
+	// Arrange
+	service := specification.ApplyOverlay(&specification.Service{
+		Name:    "TestService",
+		Version: "v1",
+		Resources: []specification.Resource{
+			{
+				Name:       "Users",
+				Operations: []string{"Create", "Get", "Update", "Delete"},
+				Fields: []specification.ResourceField{
+					{Field: specification.Field{Name: "Name", Type: "String"}, Operations: []string{"Create", "Read", "Update"}},
+				},
+			},
+		},
+	})
+	buf := &bytes.Buffer{}
+
+	// Act
+	err := GenerateServer(buf, service)
+
+	// Assert
+	assert.Nil(t, err, "Expected no error when generating server")
+	generatedCode := buf.String()
+	assert.Contains(t, generatedCode, "type AuditEvent[Session any] struct {", "Should define the AuditEvent type")
+	assert.Contains(t, generatedCode, "type AuditFunc[Session any] func(ctx context.Context, event AuditEvent[Session])", "Should define the AuditFunc type")
+	assert.Contains(t, generatedCode, "\tAuditFunc AuditFunc[Session]\n", "Server should have the AuditFunc hook")
+	assert.Contains(t, generatedCode, `routerGroup.POST("/users", audit("Users", "Create"), serveWithResponse(201, api.Server, api.Users.Create, api.UsersMiddlewares...))`)
+	assert.Contains(t, generatedCode, `routerGroup.PATCH("/users/:id", audit("Users", "Update"), serveWithResponse(200, api.Server, api.Users.Update, api.UsersMiddlewares...))`)
+	assert.Contains(t, generatedCode, `routerGroup.DELETE("/users/:id", audit("Users", "Delete"), serveWithoutResponse(204, api.Server, api.Users.Delete, api.UsersMiddlewares...))`)
+	assert.Contains(t, generatedCode, `routerGroup.GET("/users/:id", serveWithResponse(200, api.Server, api.Users.Get, api.UsersMiddlewares...))`, "Should not audit Get")
+	assert.Contains(t, generatedCode, "writeAuditEvent(c, server.AuditFunc, request, response)", "serveWithResponse should audit the successful requests")
+	assert.Contains(t, generatedCode, "writeAuditEvent(c, server.AuditFunc, request, nil)", "serveWithoutResponse should audit the successful requests")
+	assert.NotContains(t, generatedCode, "TenantID:  request.TenantID", "Should not have the tenant without tenancy")
+}
+
+func TestGenerateServer_WithoutAudit(t *testing.T) {
+	// Arrange
+	service := specification.ApplyOverlay(&specification.Service{
+		Name:      "TestService",
+		Version:   "v1",
+		Resources: []specification.Resource{{Name: "Users", Operations: []string{"Get", "List"}}},
+	})
+	buf := &bytes.Buffer{}
+
+	// Act
+	err := GenerateServer(buf, service)
+
+	// Assert
+	assert.Nil(t, err, "Expected no error when generating server")
+	assert.NotContains(t, buf.String(), "AuditFunc", "Should not generate the audit hook without mutating endpoints")
+	assert.NotContains(t, buf.String(), "writeAuditEvent", "Should not audit without mutating endpoints")
+}
+
+func TestGenerateServer_RequestLimits(t *testing.T) {
+	// Arrange
+	service := specification.ApplyOverlay(&specification.Service{
+		Name:           "TestService",
+		Version:        "v1",
+		MaxBodyBytes:   1024,
+		HandlerTimeout: 5000,
+		Resources: []specification.Resource{
+			{
+				Name:       "Users",
+				Operations: []string{"Create", "Get"},
+				Fields: []specification.ResourceField{
+					{Field: specification.Field{Name: "Name", Type: "String"}, Operations: []string{"Create", "Read"}},
+				},
+				Endpoints: []specification.Endpoint{
+					{
+						Name:           "Import",
+						Method:         "POST",
+						Path:           "/import",
+						MaxBodyBytes:   1 << 20,
+						HandlerTimeout: 60000,
+						Response:       specification.EndpointResponse{StatusCode: 204},
+					},
+				},
+			},
+		},
+	})
+	buf := &bytes.Buffer{}
+
+	// Act
+	err := GenerateServer(buf, service)
+
+	// Assert
+	assert.Nil(t, err, "Expected no error when generating server")
+	generatedCode := buf.String()
+	assert.Contains(t, generatedCode, "func limitRequest(maxBodyBytes int64, timeout time.Duration) gin.HandlerFunc {", "Should generate the limitRequest handler")
+	assert.Contains(t, generatedCode, "c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxBodyBytes)", "Should limit the size of the request body")
+	assert.Contains(t, generatedCode, "ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)", "Should limit the time of the handler")
+	assert.Contains(t, generatedCode, `routerGroup.POST("/users", limitRequest(1024, 5000*time.Millisecond), audit("Users", "Create"), serveWithResponse(201, api.Server, api.Users.Create, api.UsersMiddlewares...))`, "Generated endpoints should have the limits of the service")
+	assert.Contains(t, generatedCode, `routerGroup.POST("/users/import", limitRequest(1048576, 60000*time.Millisecond), serveWithoutResponse(204, api.Server, api.Users.Import, api.UsersMiddlewares...))`, "Endpoint limits should override the limits of the service")
+	assert.Contains(t, generatedCode, "err = requestTimeoutError(c.Request.Context(), err)", "The errors of handlers should be mapped to timeouts")
+	assert.Contains(t, generatedCode, "case ErrorCodeRequestTimeout:\n\t\treturn http.StatusRequestTimeout", "RequestTimeout should be responded with 408")
+	assert.Contains(t, generatedCode, "case ErrorCodePayloadTooLarge:\n\t\treturn http.StatusRequestEntityTooLarge", "PayloadTooLarge should be responded with 413")
+	assert.Contains(t, generatedCode, "if errors.As(err, &maxBytesError) {", "Too large bodies should be responded with PayloadTooLarge")
+}
+
+func TestGenerateServer_WithoutRequestLimits(t *testing.T) {
+	// Arrange
+	service := specification.ApplyOverlay(&specification.Service{
+		Name:      "TestService",
+		Version:   "v1",
+		Resources: []specification.Resource{{Name: "Users", Operations: []string{"Get", "List"}}},
+	})
+	buf := &bytes.Buffer{}
+
+	// Act
+	err := GenerateServer(buf, service)
+
+	// Assert
+	assert.Nil(t, err, "Expected no error when generating server")
+	assert.NotContains(t, buf.String(), "limitRequest", "Should not limit the requests without limits")
+	assert.NotContains(t, buf.String(), "requestTimeoutError", "Should not map timeouts without handler timeouts")
+	assert.NotContains(t, buf.String(), "maxBytesError", "Should not map too large bodies without body limits")
+}
+
+func TestGenerateServer_Compression(t *testing.T) {
+	// Arrange
+	service := createTestServiceWithEndpoints()
+	buf := &bytes.Buffer{}
+
+	// Act
+	err := GenerateServer(buf, service)
+
+	// Assert
+	assert.Nil(t, err, "Expected no error when generating server")
+	generatedCode := buf.String()
+	assert.Contains(t, generatedCode, "\tCompression bool\n", "Server should have the Compression option")
+	assert.Contains(t, generatedCode, "if api.Server.Compression {\n\t\trouterGroup.Use(compressResponses)\n\t}", "Should compress the responses when enabled")
+	assert.Contains(t, generatedCode, "func negotiateEncoding(acceptEncoding string) string {", "Should negotiate the encoding with Accept-Encoding")
+	assert.Contains(t, generatedCode, "w.compressor = gzip.NewWriter(w.ResponseWriter)", "Should compress with gzip")
+	assert.Contains(t, generatedCode, "w.compressor = zlib.NewWriter(w.ResponseWriter)", "Should compress with deflate")
+	assert.NotContains(t, generatedCode, "ResponseEncoder", "Should not generate response encoders without alternative content types")
+	assert.NotContains(t, generatedCode, "offerContentTypes", "Should not negotiate the content type without alternative content types")
+}
+
+func TestGenerateServer_AlternativeContentTypes(t *testing.T) {
+	// Arrange
+	service := createTestServiceWithEndpoints()
+	service.Resources[0].Endpoints[2].Response.AlternativeContentTypes = []string{"text/csv", "application/xml"}
+	buf := &bytes.Buffer{}
+
+	// Act
+	err := GenerateServer(buf, service)
+
+	// Assert
+	assert.Nil(t, err, "Expected no error when generating server")
+	generatedCode := buf.String()
+	assert.Contains(t, generatedCode, "type ResponseEncoder func(ctx context.Context, w io.Writer, response any) error", "Should define the ResponseEncoder type")
+	assert.Contains(t, generatedCode, "\tResponseEncoders map[string]ResponseEncoder\n", "Server should have the response encoders")
+	assert.Contains(t, generatedCode, `panic("ResponseEncoders has no encoder for application/xml")`, "Should require an encoder for each content type")
+	assert.Contains(t, generatedCode, `panic("ResponseEncoders has no encoder for text/csv")`, "Should require an encoder for each content type")
+	assert.Contains(t, generatedCode, `routerGroup.GET("/user", offerContentTypes("text/csv", "application/xml"), serveWithResponse(`,
+		"Should negotiate the content type of the endpoint")
+	assert.Contains(t, generatedCode, "c.Data(successStatusCode, contentType, body.Bytes())", "Should write the encoded response")
+}
+
+func TestGenerateServer_XML(t *testing.T) {
+	t.Run("XML request and response", func(t *testing.T) {
+		// Arrange
+		service := createTestServiceWithEndpoints()
+		service.Resources[0].Endpoints[0].Request.ContentType = specification.ContentTypeXML
+		service.Resources[0].Endpoints[0].Response.ContentType = specification.ContentTypeXML
+		buf := &bytes.Buffer{}
+
+		// Act
+		err := GenerateServer(buf, service)
+
+		// Assert
+		assert.Nil(t, err, "Expected no error when generating server")
+		generatedCode := buf.String()
+		assert.Contains(t, generatedCode, "\t\"encoding/xml\"\n", "Should import encoding/xml")
+		assert.Contains(t, generatedCode, "`json:\"name\" xml:\"name\"`", "Should tag the fields for XML")
+		assert.Contains(t, generatedCode, `routerGroup.POST("/user", acceptXML, respondXML, serveWithResponse(`,
+			"Should decode the request and encode the response of the endpoint as XML")
+		assert.Contains(t, generatedCode, "decode = decodeXMLBodyParams[bodyParamsType]", "Should decode the body params of XML requests from XML")
+		assert.Contains(t, generatedCode, "return xml.NewEncoder(w).Encode(response)", "Should encode the XML responses with encoding/xml")
+		assert.Contains(t, generatedCode, "getResponseEncoder(server.ResponseEncoders, contentType)(c.Request.Context(), body, response)",
+			"Should fall back to encodeXML without an encoder for application/xml")
+		assert.Contains(t, generatedCode, "\tResponseEncoders map[string]ResponseEncoder\n", "Server should have the response encoders")
+		assert.NotContains(t, generatedCode, `panic("ResponseEncoders has no encoder for application/xml")`, "Should not require an encoder for XML responses")
+	})
+
+	t.Run("JSON only", func(t *testing.T) {
+		// Arrange
+		service := createTestServiceWithEndpoints()
+		buf := &bytes.Buffer{}
+
+		// Act
+		err := GenerateServer(buf, service)
+
+		// Assert
+		assert.Nil(t, err, "Expected no error when generating server")
+		generatedCode := buf.String()
+		assert.NotContains(t, generatedCode, "xml", "Should not generate XML support without XML bodies")
+		assert.Contains(t, generatedCode, `"cannot decode json body params: "`, "Should decode the body params from JSON")
+	})
+}
+
+func TestGenerateServer_Form(t *testing.T) {
+	// Arrange
+	service := createTestServiceWithEndpoints()
+	service.Resources[0].Endpoints[0].Request.ContentType = specification.ContentTypeForm
+	buf := &bytes.Buffer{}
+
+	// Act
+	err := GenerateServer(buf, service)
+
+	// Assert
+	assert.Nil(t, err, "Expected no error when generating server")
+	generatedCode := buf.String()
+	assert.Contains(t, generatedCode, "\t\"mime\"\n", "Should import mime to check the content type")
+	assert.Contains(t, generatedCode, "`form:\"name\" json:\"name\"`", "Should tag the body params for the form")
+	assert.Contains(t, generatedCode, "BodyParams) parseForm(form url.Values) error {", "Should parse the form into the body params")
+	assert.Contains(t, generatedCode, `routerGroup.POST("/user", acceptForm, serveWithResponse(`, "Should decode the request of the endpoint as a form")
+	assert.Contains(t, generatedCode, "decode = decodeFormBodyParams[bodyParamsType]", "Should decode the body params of form requests from the form")
+	assert.NotContains(t, generatedCode, "xml", "Should not generate XML support for forms")
+}
+
+func TestGenerateServer_ConditionalGet(t *testing.T) {
+	// Arrange
+	service := specification.ApplyOverlay(&specification.Service{
+		Name:    "TestService",
+		Version: "v1",
+		Resources: []specification.Resource{
+			{
+				Name:       "Users",
+				Operations: []string{"Get"},
+				Fields: []specification.ResourceField{
+					{Field: specification.Field{Name: "Name", Type: "String"}, Operations: []string{"Read"}},
+				},
+			},
+		},
+	})
+
+	t.Run("types package", func(t *testing.T) {
+		// Arrange
+		buf := &bytes.Buffer{}
+
+		// Act
+		err := GenerateServer(buf, service)
+
+		// Assert
+		assert.Nil(t, err, "Expected no error when generating server")
+		generatedCode := buf.String()
+		assert.Contains(t, generatedCode, "func (r *Users) lastModified() (time.Time, bool) {", "Should read the last updated time of the response")
+		assert.Contains(t, generatedCode, "value, err := time.Parse(time.RFC3339, r.Meta.UpdatedAt.String())", "Should parse the timestamp of the types package")
+		assert.Contains(t, generatedCode, `c.Header("Last-Modified", lastModified.Format(http.TimeFormat))`, "Should set the Last-Modified header")
+		assert.Contains(t, generatedCode, `http.ParseTime(c.GetHeader("If-Modified-Since"))`, "Should compare with the If-Modified-Since header")
+		assert.Contains(t, generatedCode, "if writeLastModified(c, response) {\n\t\t\tc.Status(http.StatusNotModified)", "Should answer with 304 Not Modified")
+	})
+
+	t.Run("stdlib types", func(t *testing.T) {
+		// Arrange
+		buf := &bytes.Buffer{}
+
+		// Act
+		err := GenerateServerWithOptions(buf, service, Options{Types: Types{Stdlib: true}})
+
+		// Assert
+		assert.Nil(t, err, "Expected no error when generating server")
+		assert.Contains(t, buf.String(), "if r.Meta.UpdatedAt == nil {\n\t\treturn time.Time{}, false\n\t}\n\n\treturn *r.Meta.UpdatedAt, true", "Should read the nullable time")
+	})
+
+	t.Run("no conditional get", func(t *testing.T) {
+		// Arrange
+		buf := &bytes.Buffer{}
+
+		// Act
+		err := GenerateServer(buf, createTestServiceWithEndpoints())
+
+		// Assert
+		assert.Nil(t, err, "Expected no error when generating server")
+		assert.NotContains(t, buf.String(), "writeLastModified", "Should not write Last-Modified without a resource with Meta")
+	})
+}
+
+func TestGenerateServer_SoftDelete(t *testing.T) {
+	// Arrange
+	service := specification.ApplyOverlay(&specification.Service{
+		Name:    "TestService",
+		Version: "v1",
+		Resources: []specification.Resource{
+			{
+				Name:       "Users",
+				Operations: []string{"Get", "List", "Delete"},
+				SoftDelete: true,
+				Fields: []specification.ResourceField{
+					{Field: specification.Field{Name: "Name", Type: "String"}, Operations: []string{"Read"}},
+				},
+			},
+		},
+	})
+	buf := &bytes.Buffer{}
+
+	// Act
+	err := GenerateServer(buf, service)
+
+	// Assert
+	assert.Nil(t, err, "Expected no error when generating server")
+	generatedCode := buf.String()
+	assert.Contains(t, generatedCode, "\tRestore(ctx context.Context, request Request[Session, UsersRestorePathParams, struct{}, struct{}]) (*Users, error)\n",
+		"Resource interface should have the Restore method")
+	assert.Contains(t, generatedCode, `routerGroup.POST("/users/:id/restore", serveWithResponse(200, api.Server, api.Users.Restore, api.UsersMiddlewares...))`,
+		"Should register the Restore endpoint")
+	assert.Contains(t, generatedCode, "\tIncludeDeleted types.Bool `form:\"includeDeleted\" json:\"includeDeleted\"`", "List should have the IncludeDeleted flag")
+	assert.Contains(t, generatedCode, "\tDeletedAt types.Timestamp `json:\"deletedAt\"`", "Users should have the DeletedAt field")
+}
+
+func TestGenerateServer_Exportable(t *testing.T) {
+	// Arrange
+	service := specification.ApplyOverlay(&specification.Service{
+		Name:    "TestService",
+		Version: "v1",
+		Resources: []specification.Resource{
+			{
+				Name:       "Users",
+				Operations: []string{"List"},
+				Exportable: true,
+				Fields: []specification.ResourceField{
+					{Field: specification.Field{Name: "Name", Type: "String"}, Operations: []string{"Read"}},
+				},
+			},
+		},
+	})
+	buf := &bytes.Buffer{}
+
+	// Act
+	err := GenerateServer(buf, service)
+
+	// Assert
+	assert.Nil(t, err, "Expected no error when generating server")
+	generatedCode := buf.String()
+	assert.Contains(t, generatedCode, "\tExport(ctx context.Context, request Request[Session, struct{}, struct{}, struct{}], write func(row *Users) error) error\n",
+		"Resource interface should have the streaming Export method")
+	assert.Contains(t, generatedCode, `routerGroup.GET("/users/_export", serveCSV(200, api.Server, api.Users.Export, csvHeaderUsers, csvRecordUsers, api.UsersMiddlewares...))`,
+		"Should register the Export endpoint")
+	assert.Contains(t, generatedCode, "var csvHeaderUsers = []string{\n\t\"id\",\n\t\"meta.createdAt\",", "Should flatten Meta into columns")
+	assert.Contains(t, generatedCode, "\t\tcsvValue(row.Meta.CreatedAt),\n", "Should write the fields of nested objects")
+	assert.Contains(t, generatedCode, "\t\tcsvValue(row.Name),\n", "Should write the read fields")
+	assert.Contains(t, generatedCode, "func serveCSV[", "Should generate the CSV handler")
+	assert.Contains(t, generatedCode, "\"encoding/csv\"", "Should import encoding/csv")
+}
+
+func TestGenerateServer_WithoutExportable(t *testing.T) {
+	// Arrange
+	service := specification.ApplyOverlay(&specification.Service{
+		Name:      "TestService",
+		Version:   "v1",
+		Resources: []specification.Resource{{Name: "Users", Operations: []string{"List"}}},
+	})
+	buf := &bytes.Buffer{}
+
+	// Act
+	err := GenerateServer(buf, service)
+
+	// Assert
+	assert.Nil(t, err, "Expected no error when generating server")
+	assert.NotContains(t, buf.String(), "serveCSV", "Should not generate the CSV handler without CSV responses")
+	assert.NotContains(t, buf.String(), "encoding/csv", "Should not import encoding/csv without CSV responses")
+}
+
+func TestGenerateServer_SubResource(t *testing.T) {
+	// Arrange
+	service := specification.ApplyOverlay(&specification.Service{
+		Name:    "TestService",
+		Version: "v1",
+		Resources: []specification.Resource{
+			{Name: "Post", Operations: []string{"Get"}},
+			{Name: "Comment", Parent: "Post", Operations: []string{"Get", "List"}},
+		},
+	})
+	buf := &bytes.Buffer{}
+
+	// Act
+	err := GenerateServer(buf, service)
+
+	// Assert
+	assert.Nil(t, err, "Expected no error when generating server")
+	generatedCode := buf.String()
+	assert.Contains(t, generatedCode, "type CommentGetPathParams struct {\n\tPostID types.UUID `json:\"postID\"`\n\tID     types.UUID `json:\"id\"`\n}",
+		"PathParams should have the ID of the parent")
+	assert.Contains(t, generatedCode, `routerGroup.GET("/post/:id", serveWithResponse(200, api.Server, api.Post.Get, api.PostMiddlewares...))`)
+	assert.Contains(t, generatedCode, `routerGroup.GET("/post/:id/comment/:id", renamePathParams("postID", "id"), serveWithResponse(200, api.Server, api.Comment.Get, api.CommentMiddlewares...))`,
+		"Should route the sub-resource with the wildcard of the parent and rename its path parameters")
+	assert.Contains(t, generatedCode, `routerGroup.GET("/post/:id/comment", renamePathParams("postID"), serveWithResponse(200, api.Server, api.Comment.List, api.CommentMiddlewares...))`)
+	assert.Contains(t, generatedCode, "func renamePathParams(names ...string) gin.HandlerFunc {")
+}
+
+func TestGenerateServer_WithoutRenamedPathParams(t *testing.T) {
+	// Arrange
+	service := specification.ApplyOverlay(&specification.Service{
+		Name:    "TestService",
+		Version: "v1",
+		Resources: []specification.Resource{
+			{Name: "Post", Operations: []string{"List"}},
+			{Name: "Comment", Parent: "Post", Operations: []string{"List"}},
+		},
+	})
+	buf := &bytes.Buffer{}
+
+	// Act
+	err := GenerateServer(buf, service)
+
+	// Assert
+	assert.Nil(t, err, "Expected no error when generating server")
+	generatedCode := buf.String()
+	assert.Contains(t, generatedCode, `routerGroup.GET("/post/:postID/comment", serveWithResponse(200, api.Server, api.Comment.List, api.CommentMiddlewares...))`,
+		"Should route the sub-resource as-is when no route shares its wildcards")
+	assert.NotContains(t, generatedCode, "renamePathParams")
+}
+
+func TestGenerateServer_BasePath(t *testing.T) {
+	// Arrange
+	service := createTestServiceWithEndpoints()
+	service.BasePath = "/api/v1"
+	buf := &bytes.Buffer{}
+
+	// Act
+	err := GenerateServer(buf, service)
+
+	// Assert
+	assert.Nil(t, err, "Expected no error when generating server")
+	generatedCode := buf.String()
+	assert.Contains(t, generatedCode, `routerGroup := router.Group("/api/v1")`, "Should prefix the routes with the base path")
+	assert.NotContains(t, generatedCode, expectedRouterGroup, "Should not prefix the routes with the service name and version")
+}
+
+func TestGenerateServer_Naming(t *testing.T) {
+	// Arrange
+	service := specification.ApplyOverlay(&specification.Service{
+		Name:      "TestService",
+		Version:   "v1",
+		Naming:    &specification.Naming{PathCase: specification.PathCaseSnake, PluralPaths: true},
+		Resources: []specification.Resource{{Name: "UserAccount", Operations: []string{"Get", "List"}}},
+	})
+	buf := &bytes.Buffer{}
+
+	// Act
+	err := GenerateServer(buf, service)
+
+	// Assert
+	assert.Nil(t, err, "Expected no error when generating server")
+	generatedCode := buf.String()
+	assert.Contains(t, generatedCode, `routerGroup := router.Group("/test_service/v1")`, "Should route the service name in snake_case")
+	assert.Contains(t, generatedCode, `routerGroup.GET("/user_accounts/:id", serveWithResponse(200, api.Server, api.UserAccount.Get, api.UserAccountMiddlewares...))`,
+		"Should route the resource name in snake_case and plural")
+	assert.Contains(t, generatedCode, `routerGroup.GET("/user_accounts", serveWithResponse(200, api.Server, api.UserAccount.List, api.UserAccountMiddlewares...))`)
+}
+
+func TestGenerateServer_StrictObjects(t *testing.T) {
+	// Arrange
+	strict := true
+	service := specification.ApplyOverlay(&specification.Service{
+		Name:    "TestService",
+		Version: "v1",
+		Objects: []specification.Object{
+			{Name: "Address", Strict: &strict, Fields: []specification.Field{{Name: "Street", Type: "String"}}},
+		},
+		Resources: []specification.Resource{
+			{
+				Name:       "Users",
+				Operations: []string{"Create"},
+				Fields: []specification.ResourceField{
+					{Field: specification.Field{Name: "Addresses", Type: "Address", Modifiers: []string{"Array"}}, Operations: []string{"Create", "Read"}},
+				},
+			},
+		},
+	})
+	buf := &bytes.Buffer{}
+
+	// Act
+	err := GenerateServer(buf, service)
+
+	// Assert
+	assert.Nil(t, err, "Expected no error when generating server")
+	generatedCode := buf.String()
+	assert.Contains(t, generatedCode, "func (Address) checkUnknownFields(data []byte) error {\n\treturn checkUnknownFields(data, true, map[string]func([]byte) error{\n\t\t\"street\": nil,\n",
+		"Strict object should reject unknown fields")
+	assert.Contains(t, generatedCode, "func (UsersCreateBodyParams) checkUnknownFields(data []byte) error {\n\treturn checkUnknownFields(data, false, map[string]func([]byte) error{\n",
+		"Request body should follow the lenient service")
+	assert.Contains(t, generatedCode, "\"addresses\": func(data []byte) error { return checkUnknownFieldsArray(data, Address{}.checkUnknownFields) },",
+		"Request body should check the objects of the array")
+	assert.Contains(t, generatedCode, "if checker, ok := any(v).(unknownFieldsChecker); ok {", "Body params should be checked before decoding")
+}
+
+func TestGenerateServer_WithoutStrictObjects(t *testing.T) {
+	// Arrange
+	service := createTestServiceWithEndpoints()
+	buf := &bytes.Buffer{}
+
+	// Act
+	err := GenerateServer(buf, service)
+
+	// Assert
+	assert.Nil(t, err, "Expected no error when generating server")
+	assert.NotContains(t, buf.String(), "checkUnknownFields", "Should not check unknown fields without strict objects")
+}
+
+func TestGenerateServer_ResponseValidation(t *testing.T) {
+	// Arrange
+	service := specification.ApplyOverlay(&specification.Service{
+		Name:    "TestService",
+		Version: "v1",
+		Enums: []specification.Enum{
+			{Name: "Status", Values: []specification.EnumValue{{Name: "Active"}, {Name: "Inactive"}}},
+			{Name: "Level", EnumType: specification.EnumTypeInt, Values: []specification.EnumValue{{Name: "Low", Value: "1"}, {Name: "High", Value: "2"}}},
+		},
+		Objects: []specification.Object{
+			{Name: "Address", Fields: []specification.Field{{Name: "Street", Type: "String"}}},
+		},
+		Resources: []specification.Resource{
+			{
+				Name:       "Users",
+				Operations: []string{"Get", "Search"},
+				Fields: []specification.ResourceField{
+					{Field: specification.Field{Name: "Status", Type: "Status"}, Operations: []string{"Read"}},
+					{Field: specification.Field{Name: "Level", Type: "Level", Modifiers: []string{"Nullable"}}, Operations: []string{"Read"}},
+					{Field: specification.Field{Name: "Addresses", Type: "Address", Modifiers: []string{"Array"}}, Operations: []string{"Read"}},
+				},
+			},
+		},
+	})
+	buf := &bytes.Buffer{}
+
+	// Act
+	err := GenerateServer(buf, service)
+
+	// Assert
+	assert.Nil(t, err, "Expected no error when generating server")
+	generatedCode := buf.String()
+	assert.Contains(t, generatedCode, "\tValidateResponses bool\n", "Server should have the flag to validate the responses")
+	assert.Contains(t, generatedCode, "\tInvalidResponseHook InvalidResponseHook\n", "Server should have the hook for invalid responses")
+	assert.Contains(t, generatedCode, "if server.ValidateResponses {\n\t\t\tvalidateResponse(c.Request.Context(), requestContext, server.InvalidResponseHook, response)\n",
+		"Responses should be validated when enabled")
+	assert.Contains(t, generatedCode, "func (Address) validateResponse(data []byte) error {\n\treturn validateResponseFields(data, []responseFieldRule{\n\t\t{name: \"street\", required: true, validate: nil},\n",
+		"Required fields should be validated")
+	assert.Contains(t, generatedCode, "{name: \"status\", required: true, validate: validateEnumValue(\"Active\", \"Inactive\")},",
+		"String enum fields should be validated against the wire values")
+	assert.Contains(t, generatedCode, "{name: \"level\", required: false, validate: validateIntEnumValue(1, 2)},",
+		"Nullable int enum fields should be optional")
+	assert.Contains(t, generatedCode, "{name: \"addresses\", required: false, validate: func(data []byte) error { return validateResponseArray(data, Address{}.validateResponse) }},",
+		"Arrays should validate every object")
+	assert.Contains(t, generatedCode, "type UsersFilter struct", "Filter objects should be generated")
+	assert.NotContains(t, generatedCode, "func (UsersFilter) validateResponse", "Filter objects should not validate responses")
+}
+
+func TestGenerateServerWithOptions_Lifecycle(t *testing.T) {
+	// Arrange
+	service := createTestServiceWithEndpoints()
+	buf := &bytes.Buffer{}
+
+	// Act
+	err := GenerateServerWithOptions(buf, service, Options{Lifecycle: true})
+
+	// Assert
+	assert.Nil(t, err, "Expected no error when generating server with lifecycle")
+	generatedCode := buf.String()
+	assert.Contains(t, generatedCode, "func RunServer[Session any](ctx context.Context, api *TestServiceAPI[Session], config ServerConfig) error {")
+	assert.Contains(t, generatedCode, "\tRegisterTestServiceAPI(router, api)\n", "Should register the API on the router")
+	assert.Contains(t, generatedCode, "ctx, stop := signal.NotifyContext(ctx, signals...)", "Should stop on the signals")
+	assert.Contains(t, generatedCode, "shutdownErr := server.Shutdown(shutdownCtx)", "Should shut down gracefully")
+	assert.Contains(t, generatedCode, "err = server.ListenAndServeTLS(config.TLSCertFile, config.TLSKeyFile)", "Should serve HTTPS with TLS")
+	assert.Contains(t, generatedCode, `"os/signal"`)
+
+	t.Run("no lifecycle", func(t *testing.T) {
+		// Arrange
+		buf := &bytes.Buffer{}
+
+		// Act
+		err := GenerateServer(buf, createTestServiceWithEndpoints())
+
+		// Assert
+		assert.Nil(t, err)
+		assert.NotContains(t, buf.String(), "RunServer")
+		assert.NotContains(t, buf.String(), `"os/signal"`, "Should not keep the imports of the lifecycle")
+	})
+}
+
+// ============================================================================
+// generateRequestTypes Tests
+// ============================================================================
+
+func TestGenerateRequestTypes_Defaults(t *testing.T) {
+	// Arrange
+	service := createTestServiceWithEndpoints()
+	service.Objects = append(service.Objects, specification.Object{
+		Name: "Settings",
+		Fields: []specification.Field{
+			{Name: "Language", Type: specification.FieldTypeString, Default: "en"},
+			{Name: "Tags", Type: specification.FieldTypeString, Modifiers: []string{"Array"}},
+		},
+	})
+	service.Resources[0].Endpoints[0].Request.BodyParams = append(service.Resources[0].Endpoints[0].Request.BodyParams,
+		specification.Field{Name: "Active", Type: specification.FieldTypeBool, Default: "true"},
+		specification.Field{Name: "Settings", Type: "Settings"},
+	)
+	buf := &bytes.Buffer{}
+
+	// Act
+	err := generateRequestTypes(buf, service, Types{})
+
+	// Assert
+	assert.Nil(t, err, "Expected no error when generating request types")
+
+	formatted, err := format.Source(buf.Bytes())
+	assert.Nil(t, err, "Generated request types should parse as valid Go")
+	generatedCode := string(formatted)
+	assert.Contains(t, generatedCode, "func (v *UserCreateUserBodyParams) setDefaults() {\n\tv.Active = types.NewBool(true)\n\tv.Settings.setDefaults()\n}",
+		"Should set the body defaults and the defaults of nested objects")
+	assert.Contains(t, generatedCode, "func (v *UserListUsersQueryParams) setDefaults() {\n\tv.Limit = types.NewInt(50)\n}",
+		"Should set the query param defaults")
+	assert.NotContains(t, generatedCode, "func (v *UserDeleteUserPathParams) setDefaults()", "Should not generate setDefaults without defaults")
+
+	t.Run("objects", func(t *testing.T) {
+		// Arrange
+		buf := &bytes.Buffer{}
+
+		// Act
+		err := generateObjects(buf, service, Types{})
+
+		// Assert
+		assert.Nil(t, err, "Expected no error when generating objects")
+		assert.Contains(t, buf.String(), "func (v *Settings) setDefaults() {\n\tv.Language = types.NewString(\"en\")\n}", "Should set the object defaults")
+		assert.NotContains(t, buf.String(), "func (v *Pagination) setDefaults()", "Should not generate setDefaults without defaults")
+	})
+}
+
+func TestGenerateRequestTypes_ParseParams(t *testing.T) {
+	// Arrange
+	service := createTestServiceWithEndpoints()
+	service.Resources[0].Endpoints[2].Request.QueryParams = append(service.Resources[0].Endpoints[2].Request.QueryParams,
+		specification.Field{Name: "IDs", Type: specification.FieldTypeUUID, Modifiers: []string{specification.ModifierArray}},
+	)
+	buf := &bytes.Buffer{}
+
+	// Act
+	err := generateRequestTypes(buf, service, Types{})
+
+	// Assert
+	assert.Nil(t, err, "Expected no error when generating request types")
+
+	formatted, err := format.Source(buf.Bytes())
+	assert.Nil(t, err, "Generated request types should parse as valid Go")
+	generatedCode := string(formatted)
+	assert.Contains(t, generatedCode, "func (v *UserDeleteUserPathParams) parsePathParams(params gin.Params) error {\n"+
+		"\tif value, ok := params.Get(\"id\"); ok {\n\t\tparsed, err := uuid.Parse(value)\n\t\tif err != nil {\n"+
+		"\t\t\treturn fmt.Errorf(\"id: %w\", err)\n\t\t}\n\t\tv.ID = types.NewUUID(parsed)\n\t}\n\n\treturn nil\n}",
+		"Should parse the path params directly")
+	assert.Contains(t, generatedCode, "func (v *UserListUsersQueryParams) parseQueryParams(query url.Values) error {\n"+
+		"\tif values := query[\"limit\"]; len(values) > 0 {\n\t\tvalue := values[0]\n\t\tparsed, err := parseIntParam(value, 64)\n",
+		"Should parse the first value of a scalar query param")
+	assert.Contains(t, generatedCode, "\t\tv.IDs = make([]types.UUID, 0, len(values))\n\t\tfor _, value := range values {\n",
+		"Should parse every value of an array query param")
+
+	t.Run("object query param", func(t *testing.T) {
+		// Arrange
+		service.Resources[0].Endpoints[2].Request.QueryParams = append(service.Resources[0].Endpoints[2].Request.QueryParams,
+			specification.Field{Name: "Page", Type: "Pagination"},
+		)
+		buf := &bytes.Buffer{}
+
+		// Act
+		err := generateRequestTypes(buf, service, Types{})
+
+		// Assert
+		assert.Nil(t, err, "Expected no error when generating request types")
+		assert.NotContains(t, buf.String(), "func (v *UserListUsersQueryParams) parseQueryParams",
+			"Query params with objects should be decoded by the form binding")
+	})
+}
+
+func TestGenerateRequestTypes_ParameterGroups(t *testing.T) {
+	// Arrange
+	service := createTestServiceWithEndpoints()
+	service.ParameterGroups = []specification.ParameterGroup{
+		{Name: "Include", Description: "Include flags", Fields: []specification.Field{{Name: "IncludeArchived", Type: specification.FieldTypeBool}}},
+	}
+	service.Resources[0].Endpoints[1].Request.ParameterGroups = []string{"Include"}
+	service.Resources[0].Endpoints[1].Request.QueryParams = []specification.Field{
+		{Name: "Force", Type: specification.FieldTypeBool},
+		{Name: "IncludeArchived", Type: specification.FieldTypeBool},
+	}
+	buf := &bytes.Buffer{}
+
+	// Act
+	err := generateRequestTypes(buf, service, Types{})
+
+	// Assert
+	assert.Nil(t, err, "Expected no error when generating request types")
+
+	formatted, err := format.Source(buf.Bytes())
+	assert.Nil(t, err, "Generated request types should parse as valid Go")
+	generatedCode := string(formatted)
+	assert.Contains(t, generatedCode, "type IncludeParameterGroup struct {", "Should generate the parameter group once")
+	assert.Contains(t, generatedCode, "type UserDeleteUserQueryParams struct {\n\tIncludeParameterGroup\n\tForce", "Should embed the parameter group")
+	assert.Equal(t, 1, strings.Count(generatedCode, "IncludeArchived types.Bool"), "Group fields should only be declared by the group")
+	assert.Contains(t, generatedCode, "\t\tv.IncludeArchived = types.NewBool(parsed)\n", "Group fields should be parsed with the query params")
+}
+
+func TestGenerateRequestTypes(t *testing.T) {
+	// Arrange
+	service := createTestServiceWithEndpoints()
+	buf := &bytes.Buffer{}
+
+	// Act
+	err := generateRequestTypes(buf, service, Types{})
+
+	// Assert
+	assert.Nil(t, err, "Expected no error when generating request types")
+
+	generatedCode := buf.String()
+	assert.Contains(t, generatedCode, expectedRequestContextType, "Should generate RequestContext struct")
+	assert.Contains(t, generatedCode, expectedRequestType, "Should generate Request generic type")
+	assert.Contains(t, generatedCode, expectedContextMethod, "Should generate Context() method")
+	assert.Contains(t, generatedCode, "return r.requestContext", "Context method should return requestContext field")
+
+	// Check path params type generation
+	assert.Contains(t, generatedCode, "type UserDeleteUserPathParams struct {", "Should generate path params type")
+	assert.Contains(t, generatedCode, `ID types.UUID `+"`json:\"id\"`", "Should include path param fields")
+
+	// Check query params type generation
+	assert.Contains(t, generatedCode, "type UserListUsersQueryParams struct {", "Should generate query params type")
+	assert.Contains(t, generatedCode, `Limit types.Int `+"`form:\"limit\" json:\"limit\"`", "Should use both form and json tags for query params")
+
+	// Check body params type generation
+	assert.Contains(t, generatedCode, "type UserCreateUserBodyParams struct {", "Should generate body params type")
+	assert.Contains(t, generatedCode, `Name types.String `+"`json:\"name\"`", "Should use json tag for body params")
+
+	t.Run("edge cases", func(t *testing.T) {
+		t.Run("endpoint with no params", func(t *testing.T) {
+			// Arrange
+			serviceNoParams := &specification.Service{
+				Name:    testServiceName,
+				Version: testServiceVersion,
+				Resources: []specification.Resource{
+					{
+						Name: testResourceName,
+						Endpoints: []specification.Endpoint{
+							{
+								Name:   "Simple",
+								Method: "GET",
+								Path:   "/simple",
+								Request: specification.EndpointRequest{
+									PathParams:  []specification.Field{},
+									QueryParams: []specification.Field{},
+									BodyParams:  []specification.Field{},
+								},
+							},
+						},
+					},
+				},
+			}
+			buf := &bytes.Buffer{}
+
+			// Act
+			err := generateRequestTypes(buf, serviceNoParams, Types{})
+
+			// Assert
+			assert.Nil(t, err, "Expected no error")
+			generatedCode := buf.String()
+			assert.NotContains(t, generatedCode, "type UserSimplePathParams", "Should not generate empty param types")
+			assert.NotContains(t, generatedCode, "type UserSimpleQueryParams", "Should not generate empty param types")
+			assert.NotContains(t, generatedCode, "type UserSimpleBodyParams", "Should not generate empty param types")
+		})
+
+		t.Run("fields with custom objects", func(t *testing.T) {
+			// Arrange
+			serviceCustomFields := &specification.Service{
+				Name:    testServiceName,
+				Version: testServiceVersion,
+				Objects: []specification.Object{
+					{Name: testObjectName},
+				},
+				Resources: []specification.Resource{
+					{
+						Name: testResourceName,
+						Endpoints: []specification.Endpoint{
+							{
+								Name:   "CreateWithAddress",
+								Method: "POST",
+								Request: specification.EndpointRequest{
+									BodyParams: []specification.Field{
+										{
+											Name: "HomeAddress",
+											Type: testObjectName,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+			buf := &bytes.Buffer{}
+
+			// Act
+			err := generateRequestTypes(buf, serviceCustomFields, Types{})
+
+			// Assert
+			assert.Nil(t, err, "Expected no error")
+			generatedCode := buf.String()
+			assert.Contains(t, generatedCode, "HomeAddress Address `json:\"homeAddress\"`",
+				"Should handle custom object types correctly")
+		})
+	})
+}
+
+// ============================================================================
+// generateResponseTypes Tests
+// ============================================================================
+
+func TestGenerateResponseTypes(t *testing.T) {
+	// Arrange
+	service := createTestServiceWithEndpoints()
+	buf := &bytes.Buffer{}
+
+	// Act
+	err := generateResponseTypes(buf, service, Types{})
+
+	// Assert
+	assert.Nil(t, err, "Expected no error when generating response types")
+
+	generatedCode := buf.String()
+	assert.Contains(t, generatedCode, "type UserListUsersResponse struct {", "Should generate response type")
+	assert.Contains(t, generatedCode, `Data []types.User `+"`json:\"data\"`", "Should include array field")
+	assert.Contains(t, generatedCode, `Pagination Pagination `+"`json:\"pagination\"`", "Should include pagination field")
+
+	t.Run("edge cases", func(t *testing.T) {
+		t.Run("endpoint with no response body", func(t *testing.T) {
+			// Arrange
+			serviceNoResponse := &specification.Service{
+				Name:    testServiceName,
+				Version: testServiceVersion,
+				Resources: []specification.Resource{
+					{
+						Name: testResourceName,
+						Endpoints: []specification.Endpoint{
+							{
+								Name:   "Delete",
+								Method: "DELETE",
+								Response: specification.EndpointResponse{
+									StatusCode: 204,
+									BodyFields: []specification.Field{},
+								},
+							},
+						},
+					},
+				},
+			}
+			buf := &bytes.Buffer{}
+
+			// Act
+			err := generateResponseTypes(buf, serviceNoResponse, Types{})
+
+			// Assert
+			assert.Nil(t, err, "Expected no error")
+			assert.NotContains(t, buf.String(), "type UserDeleteResponse",
+				"Should not generate response type for endpoints with no body")
+		})
+
+		t.Run("response with custom object fields", func(t *testing.T) {
+			// Arrange
+			serviceCustomResponse := &specification.Service{
+				Name:    testServiceName,
+				Version: testServiceVersion,
+				Objects: []specification.Object{
+					{Name: "Stats"},
+				},
+				Resources: []specification.Resource{
+					{
+						Name: testResourceName,
+						Endpoints: []specification.Endpoint{
+							{
+								Name: "GetStats",
+								Response: specification.EndpointResponse{
+									StatusCode: 200,
+									BodyFields: []specification.Field{
+										{
+											Name: "UserStats",
+											Type: "Stats",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+			buf := &bytes.Buffer{}
+
+			// Act
+			err := generateResponseTypes(buf, serviceCustomResponse, Types{})
+
+			// Assert
+			assert.Nil(t, err, "Expected no error")
+			generatedCode := buf.String()
+			assert.Contains(t, generatedCode, "UserStats Stats `json:\"userStats\"`",
+				"Should handle custom object types in responses")
+		})
+	})
+}
+
+// ============================================================================
+// generateUtils Tests
+// ============================================================================
+
+func TestGenerateUtils(t *testing.T) {
+	// Arrange
+	buf := &bytes.Buffer{}
+	service := createTestService()
+
+	// Act
+	err := generateUtils(buf, service, Types{}, templateSet{})
+
+	// Assert
+	assert.Nil(t, err, "Expected no error when generating utils")
+
+	generatedCode := buf.String()
+
+	// Check all utility functions are generated
+	assert.Contains(t, generatedCode, expectedGetRequestContext, "Should generate getRequestContext")
+	assert.Contains(t, generatedCode, expectedServeWithResponse, "Should generate serveWithResponse")
+	assert.Contains(t, generatedCode, expectedServeWithoutResponse, "Should generate serveWithoutResponse")
+	assert.Contains(t, generatedCode, expectedHandleRequest, "Should generate handleRequest")
+	assert.Contains(t, generatedCode, expectedDecodeBodyParams, "Should generate decodeBodyParams")
+	assert.Contains(t, generatedCode, expectedDecodePathParams, "Should generate decodePathParams")
+	assert.Contains(t, generatedCode, expectedDecodeQueryParams, "Should generate decodeQueryParams")
+
+	// Check function implementations
+	assert.Contains(t, generatedCode, `requestID := c.GetHeader(requestIDHeader)`, "Should read the request ID from the request")
+	assert.Contains(t, generatedCode, `if getRequestID == nil {`, "Should check if GetRequestIDFunc is nil")
+	assert.Contains(t, generatedCode, `getRequestID = defaultGetRequestID`, "Should use defaultGetRequestID when nil")
+	assert.Contains(t, generatedCode, `c.Header(requestIDHeader, requestID)`, "Should set the request ID on the response")
+	assert.Contains(t, generatedCode, "handleRequest[sessionType, pathParamsType, queryParamsType, bodyParamsType]",
+		"Should call handleRequest with generic types")
+	assert.Contains(t, generatedCode, "writeJSON(c, successStatusCode, response)",
+		"Should return JSON response with success code")
+	assert.Contains(t, generatedCode, "c.JSON(server.ErrorHook(c.Request.Context(), requestContext, nil, err).Response())",
+		"Should return pre-auth error using Response() method with nil session")
+	assert.Contains(t, generatedCode, "c.JSON(server.ErrorHook(c.Request.Context(), requestContext, &request.Session, err).Response())",
+		"Should return post-auth error using Response() method with session")
+
+	// Check that requestContext is built in serve functions
+	assert.Contains(t, generatedCode, "requestContext := getRequestContext(c, getContextRequestID(c, server.GetRequestIDFunc))",
+		"Should call getRequestContext to build RequestContext in serve functions")
+
+	// Check handleRequest implementation
+	assert.Contains(t, generatedCode, "handleRequest[sessionType, pathParamsType, queryParamsType, bodyParamsType](c, requestContext, server)",
+		"Should call handleRequest with requestContext parameter")
+	assert.Contains(t, generatedCode, "if _, ok := any(request.BodyParams).(struct{}); !ok {",
+		"Should check if body params exist")
+	assert.Contains(t, generatedCode, "if _, ok := any(request.PathParams).(struct{}); !ok {",
+		"Should check if path params exist")
+	assert.Contains(t, generatedCode, "if _, ok := any(request.QueryParams).(struct{}); !ok {",
+		"Should check if query params exist")
+
+	// Check decode functions
+	assert.Contains(t, generatedCode, "json.NewDecoder(r.Body).Decode(&v)",
+		"Should decode JSON body")
+	assert.Contains(t, generatedCode, "c.ShouldBindQuery(&result)",
+		"Should bind query parameters")
+	assert.Contains(t, generatedCode, "m := make(map[string]string, len(c.Params))",
+		"Should create map for path params")
+
+	t.Run("consistency", func(t *testing.T) {
+		// Generate twice and compare
+		buf1 := &bytes.Buffer{}
+		buf2 := &bytes.Buffer{}
+		service := createTestService()
+
+		err1 := generateUtils(buf1, service, Types{}, templateSet{})
+		err2 := generateUtils(buf2, service, Types{}, templateSet{})
+
+		assert.Nil(t, err1, "First generation should not error")
+		assert.Nil(t, err2, "Second generation should not error")
+		assert.Equal(t, buf1.String(), buf2.String(), "Utils should be generated consistently")
+	})
+}
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+func createTestService() *specification.Service {
+	return &specification.Service{
+		Name:    testServiceName,
+		Version: testServiceVersion,
+		Enums: []specification.Enum{
+			{
+				Name:        testEnumName,
+				Description: testEnumDesc,
+				Values: []specification.EnumValue{
+					{Name: testEnumValueAdmin, Description: testEnumDescAdmin},
+					{Name: testEnumValueUser, Description: testEnumDescUser},
+				},
+			},
+		},
+		Objects: []specification.Object{
+			{
+				Name:        testObjectName,
+				Description: testObjectDesc,
+				Fields: []specification.Field{
+					{
+						Name:        testFieldName,
+						Description: testFieldDesc,
+						Type:        testFieldType,
+					},
+				},
+			},
+			// Add filter objects for testing
+			{
+				Name:        "SchoolFilter",
+				Description: "Filter object for School",
+				Fields: []specification.Field{
+					{
+						Name:      "Equals",
+						Type:      "SchoolFilterEquals",
+						Modifiers: []string{"Nullable"},
+					},
+					{
+						Name: "OrCondition",
+						Type: "Bool",
+					},
+					{
+						Name:      "NestedFilters",
+						Type:      "SchoolFilter",
+						Modifiers: []string{"Array"},
+					},
+				},
+			},
+			{
+				Name:        "SchoolFilterEquals",
+				Description: "Equality filter fields for School",
+				Fields: []specification.Field{
+					{
+						Name: "ID",
+						Type: "UUID",
+					},
+					{
+						Name:      "Meta",
+						Type:      "MetaFilterEquals",
+						Modifiers: []string{"Nullable"},
+					},
+				},
+			},
+			{
+				Name:        "MetaFilterEquals",
+				Description: "Equality filter fields for Meta",
+				Fields: []specification.Field{
+					{
+						Name: "Version",
+						Type: "String",
+					},
+				},
+			},
+		},
+		Resources: []specification.Resource{
+			{
+				Name:        testResourceName,
+				Description: testResourceDesc,
+			},
+		},
+	}
+}
+
+func createTestServiceWithEndpoints() *specification.Service {
+	service := createTestService()
+	service.Resources[0].Endpoints = []specification.Endpoint{
+		{
+			Name:        "CreateUser",
+			Method:      "POST",
+			Path:        "",
+			Summary:     testEndpointSummary,
+			Description: testEndpointDesc,
+			Request: specification.EndpointRequest{
+				ContentType: "application/json",
+				BodyParams: []specification.Field{
+					{
+						Name:        testFieldName,
+						Description: testFieldDesc,
+						Type:        testFieldType,
+					},
+				},
+			},
+			Response: specification.EndpointResponse{
+				ContentType: "application/json",
+				StatusCode:  testEndpointResponseCode,
+				BodyObject:  &service.Resources[0].Name,
+			},
+		},
+		{
+			Name:   "DeleteUser",
+			Method: "DELETE",
+			Path:   "/{id}",
+			Request: specification.EndpointRequest{
+				PathParams: []specification.Field{
+					{
+						Name: "ID",
+						Type: testFieldTypeUUID,
+					},
+				},
+			},
+			Response: specification.EndpointResponse{
+				StatusCode: 204,
+			},
+		},
+		{
+			Name:   "ListUsers",
+			Method: "GET",
+			Path:   "",
+			Request: specification.EndpointRequest{
+				QueryParams: []specification.Field{
+					{
+						Name:    "Limit",
+						Type:    testFieldTypeInt,
+						Default: "50",
+					},
+				},
+			},
+			Response: specification.EndpointResponse{
+				StatusCode: 200,
+				BodyFields: []specification.Field{
+					{
+						Name:      "Data",
+						Type:      testResourceName,
+						Modifiers: []string{"Array"},
+					},
+					{
+						Name: "Pagination",
+						Type: "Pagination",
+					},
+				},
+			},
+		},
+	}
+
+	// Add Pagination object for list response
+	service.Objects = append(service.Objects, specification.Object{
+		Name: "Pagination",
+		Fields: []specification.Field{
+			{Name: "Offset", Type: testFieldTypeInt},
+			{Name: "Limit", Type: testFieldTypeInt},
+			{Name: "Total", Type: testFieldTypeInt},
+		},
+	})
+
+	return service
+}
+
+func createTestServiceWithVariousHTTPMethods() *specification.Service {
+	return &specification.Service{
+		Name:    testServiceName,
+		Version: testServiceVersion,
+		Resources: []specification.Resource{
+			{
+				Name: testResourceName,
+				Endpoints: []specification.Endpoint{
+					{
+						Name:   "Get",
+						Method: "GET",
+						Path:   "/{id}",
+						Response: specification.EndpointResponse{
+							StatusCode: 200,
+						},
+					},
+					{
+						Name:   "Create",
+						Method: "POST",
+						Path:   "",
+						Response: specification.EndpointResponse{
+							StatusCode: 201,
+						},
+					},
+					{
+						Name:   "Update",
+						Method: "PATCH",
+						Path:   "/{id}",
+						Response: specification.EndpointResponse{
+							StatusCode: 200,
+						},
+					},
+					{
+						Name:   "Delete",
+						Method: "DELETE",
+						Path:   "/{id}",
+						Response: specification.EndpointResponse{
+							StatusCode: 204,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// ============================================================================
+// Path Conversion Tests
+// ============================================================================
+
+func TestConvertOpenAPIPathToGin(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "single path parameter",
+			input:    "/user/{id}",
+			expected: "/user/:id",
+		},
+		{
+			name:     "multiple path parameters",
+			input:    "/user/{userId}/posts/{postId}",
+			expected: "/user/:userId/posts/:postId",
+		},
+		{
+			name:     "no path parameters",
+			input:    "/users",
+			expected: "/users",
+		},
+		{
+			name:     "empty path",
+			input:    "",
+			expected: "",
+		},
+		{
+			name:     "path with query-like syntax but no parameters",
+			input:    "/search?query=test",
+			expected: "/search?query=test",
+		},
+		{
+			name:     "parameter at the beginning",
+			input:    "/{id}/children",
+			expected: "/:id/children",
+		},
+		{
+			name:     "parameter at the end",
+			input:    "/users/{id}",
+			expected: "/users/:id",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := convertOpenAPIPathToGin(tc.input)
+			assert.Equal(t, tc.expected, result, "Path conversion should match expected result")
+		})
+	}
+
+	t.Run("edge cases", func(t *testing.T) {
+		t.Run("malformed parameter missing closing brace", func(t *testing.T) {
+			result := convertOpenAPIPathToGin("/user/{id")
+			assert.Equal(t, "/user/{id", result, "Should not modify malformed parameter")
+		})
+
+		t.Run("malformed parameter missing opening brace", func(t *testing.T) {
+			result := convertOpenAPIPathToGin("/user/id}")
+			assert.Equal(t, "/user/id}", result, "Should not modify malformed parameter")
+		})
+
+		t.Run("empty parameter name", func(t *testing.T) {
+			result := convertOpenAPIPathToGin("/user/{}")
+			assert.Equal(t, "/user/:", result, "Should convert empty parameter to colon")
+		})
+	})
 }
 
 // ============================================================================
-// Helper Functions
+// Development Flag Tests
 // ============================================================================
 
-func createTestService() *specification.Service {
-	return &specification.Service{
-		Name:    testServiceName,
-		Version: testServiceVersion,
-		Enums: []specification.Enum{
-			{
-				Name:        testEnumName,
-				Description: testEnumDesc,
-				Values: []specification.EnumValue{
-					{Name: testEnumValueAdmin, Description: testEnumDescAdmin},
-					{Name: testEnumValueUser, Description: testEnumDescUser},
+// TestGenerateServer_DevelopmentFlag tests that resources with Development: true are still fully
+// generated into server code. The development flag is an OpenAPI documentation gate only — it
+// must never suppress interface definitions, API struct fields, or route registrations.
+func TestGenerateServer_DevelopmentFlag(t *testing.T) {
+	const (
+		devResourceName    = "GradeElementary"
+		stableResourceName = "Students"
+		devResourceDesc    = "Grade elementary resource in development"
+		stableResourceDesc = "Student management operations"
+	)
+
+	stableEndpoint := specification.Endpoint{
+		Name:   "ListStudents",
+		Method: "GET",
+		Path:   "",
+		Response: specification.EndpointResponse{
+			StatusCode: 200,
+			BodyFields: []specification.Field{
+				{Name: "Total", Type: "Int"},
+			},
+		},
+	}
+
+	devEndpoint := specification.Endpoint{
+		Name:   "ListGrades",
+		Method: "GET",
+		Path:   "",
+		Response: specification.EndpointResponse{
+			StatusCode: 200,
+			BodyFields: []specification.Field{
+				{Name: "Grade", Type: "String"},
+			},
+		},
+	}
+
+	buildService := func() *specification.Service {
+		return &specification.Service{
+			Name:    testServiceName,
+			Version: testServiceVersion,
+			Resources: []specification.Resource{
+				{Name: stableResourceName, Description: stableResourceDesc, Endpoints: []specification.Endpoint{stableEndpoint}},
+				{Name: devResourceName, Description: devResourceDesc, Development: true, Endpoints: []specification.Endpoint{devEndpoint}},
+			},
+		}
+	}
+
+	t.Run("development resource route is registered", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		err := GenerateServer(buf, buildService())
+		assert.Nil(t, err, "Should not return error when generating server with a development resource")
+
+		generated := buf.String()
+		assert.Contains(t, generated, stableResourceName, "Stable resource should appear in generated code")
+		assert.Contains(t, generated, devResourceName, "Development resource MUST appear in generated server code")
+		assert.Contains(t, generated, "api."+devResourceName+".ListGrades",
+			"Development resource route should be registered in server code")
+	})
+
+	t.Run("development resource has API struct field", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		err := GenerateServer(buf, buildService())
+		assert.Nil(t, err, "Should not return error when generating server with a development resource")
+
+		generated := buf.String()
+
+		assert.Contains(t, generated, "StudentsAPI[Session]", "Stable resource API field should appear in generated struct")
+		assert.Contains(t, generated, "GradeElementaryAPI[Session]", "Development resource API field MUST appear in generated struct")
+	})
+
+	t.Run("development resource has interface definition", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		err := GenerateServer(buf, buildService())
+		assert.Nil(t, err, "Should not return error when generating server with a development resource")
+
+		generated := buf.String()
+
+		expectedStableInterface := "type " + stableResourceName + "API[Session any] interface {"
+		assert.Contains(t, generated, expectedStableInterface, "Stable resource interface should appear in generated code")
+
+		expectedDevInterface := "type " + devResourceName + "API[Session any] interface {"
+		assert.Contains(t, generated, expectedDevInterface, "Development resource interface MUST appear in generated code")
+	})
+
+	t.Run("non-development resource is unaffected when other resources are in development", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		err := GenerateServer(buf, buildService())
+		assert.Nil(t, err, "Should not return error when generating server with a development resource")
+
+		generated := buf.String()
+
+		assert.Contains(t, generated, stableResourceName, "Stable resource should still appear in generated code")
+		assert.Contains(t, generated, "ListStudents", "Stable resource endpoint should still appear in generated code")
+	})
+}
+
+// TestGenerateServer_DevelopmentFlagEnumsObjects tests that development enums and objects
+// are still included in generated server Go code (servergen is intentionally unaffected).
+func TestGenerateServer_DevelopmentFlagEnumsObjects(t *testing.T) {
+	const (
+		devEnumName    = "InternalGradeType"
+		stableEnumName = "Status"
+		devObjectName  = "GradeElementary"
+		stableObjName  = "Student"
+	)
+
+	t.Run("development enum is still generated as Go type in server code", func(t *testing.T) {
+		service := &specification.Service{
+			Name:    testServiceName,
+			Version: testServiceVersion,
+			Enums: []specification.Enum{
+				{
+					Name:        stableEnumName,
+					Description: "Stable status enum",
+					Values: []specification.EnumValue{
+						{Name: "Active", Description: "Active state"},
+					},
+				},
+				{
+					Name:        devEnumName,
+					Description: "Internal grade type in development",
+					Development: true,
+					Values: []specification.EnumValue{
+						{Name: "Grade1", Description: "Grade 1"},
+					},
+				},
+			},
+		}
+
+		buf := &bytes.Buffer{}
+		err := GenerateServer(buf, service)
+		assert.Nil(t, err, "Should not return error when generating server with development enum")
+
+		generated := buf.String()
+		assert.Contains(t, generated, stableEnumName, "Stable enum type should appear in generated Go code")
+		assert.Contains(t, generated, devEnumName, "Development enum type should still appear in generated Go code (servergen unaffected)")
+	})
+
+	t.Run("development object is still generated as Go type in server code", func(t *testing.T) {
+		service := &specification.Service{
+			Name:    testServiceName,
+			Version: testServiceVersion,
+			Objects: []specification.Object{
+				{
+					Name:        stableObjName,
+					Description: "Stable student object",
+					Fields: []specification.Field{
+						{Name: "Name", Description: "Student name", Type: "String"},
+					},
+				},
+				{
+					Name:        devObjectName,
+					Description: "Development grade object",
+					Development: true,
+					Fields: []specification.Field{
+						{Name: "Grade", Description: "Grade value", Type: "String"},
+					},
 				},
 			},
+		}
+
+		buf := &bytes.Buffer{}
+		err := GenerateServer(buf, service)
+		assert.Nil(t, err, "Should not return error when generating server with development object")
+
+		generated := buf.String()
+		assert.Contains(t, generated, stableObjName, "Stable object type should appear in generated Go code")
+		assert.Contains(t, generated, devObjectName, "Development object type should still appear in generated Go code (servergen unaffected)")
+	})
+}
+
+func TestGenerateServer_SharedPackages(t *testing.T) {
+	// Arrange
+	service := specification.ApplyOverlay(&specification.Service{
+		Name:    "TestService",
+		Version: "v1",
+		Enums: []specification.Enum{
+			{Name: "Country", Library: "Common", Values: []specification.EnumValue{{Name: "SE", Description: "Sweden"}}},
 		},
 		Objects: []specification.Object{
 			{
-				Name:        testObjectName,
-				Description: testObjectDesc,
+				Name:    "Address",
+				Library: "Common",
 				Fields: []specification.Field{
-					{
-						Name:        testFieldName,
-						Description: testFieldDesc,
-						Type:        testFieldType,
-					},
+					{Name: "Street", Type: "String"},
+					{Name: "Country", Type: "Country", Default: "SE"},
 				},
 			},
-			// Add filter objects for testing
+		},
+		Resources: []specification.Resource{
 			{
-				Name:        "SchoolFilter",
-				Description: "Filter object for School",
-				Fields: []specification.Field{
-					{
-						Name:      "Equals",
-						Type:      "SchoolFilterEquals",
-						Modifiers: []string{"Nullable"},
-					},
-					{
-						Name: "OrCondition",
-						Type: "Bool",
-					},
-					{
-						Name:      "NestedFilters",
-						Type:      "SchoolFilter",
-						Modifiers: []string{"Array"},
-					},
+				Name:       "Users",
+				Operations: []string{"Get"},
+				Fields: []specification.ResourceField{
+					{Field: specification.Field{Name: "Address", Type: "Address"}, Operations: []string{"Read"}},
 				},
 			},
+		},
+	})
+	options := Options{Types: Types{Stdlib: true}, SharedPackages: map[string]string{"Common": "example.com/shared/common"}}
+
+	t.Run("server imports the shared types", func(t *testing.T) {
+		// Arrange
+		buf := &bytes.Buffer{}
+
+		// Act
+		err := GenerateServerWithOptions(buf, service, options)
+
+		// Assert
+		assert.Nil(t, err, "Expected no error when generating server")
+		generatedCode := buf.String()
+		assert.Contains(t, generatedCode, "\t\"example.com/shared/common\"\n", "Should import the shared package")
+		assert.Contains(t, generatedCode, "type Address = common.Address\n", "Should alias the shared object")
+		assert.Contains(t, generatedCode, "CountrySE = common.CountrySE // Sweden", "Should alias the values of the shared enum")
+		assert.Contains(t, generatedCode, "func setDefaultsAddress(v *Address) {", "Should set the defaults of the shared object with a function")
+		assert.Contains(t, generatedCode, "\tsetDefaultsAddress(&v.Address)\n", "Should call the function for the nested shared object")
+		assert.Contains(t, generatedCode, "func validateResponseAddress(data []byte) error {", "Should validate the responses of the shared object with a function")
+		assert.Contains(t, generatedCode, "case *Address:\n\t\treturn responseValidatorFunc(validateResponseAddress), true", "Should validate shared objects returned as responses")
+		assert.NotContains(t, generatedCode, "type Address struct", "Should not declare the shared object")
+	})
+
+	t.Run("server without shared packages declares the types", func(t *testing.T) {
+		// Arrange
+		buf := &bytes.Buffer{}
+
+		// Act
+		err := GenerateServerWithOptions(buf, service, Options{Types: Types{Stdlib: true}})
+
+		// Assert
+		assert.Nil(t, err, "Expected no error when generating server")
+		generatedCode := buf.String()
+		assert.Contains(t, generatedCode, "type Address struct", "Should declare the object")
+		assert.NotContains(t, generatedCode, "common.", "Should not reference the shared package")
+	})
+
+	t.Run("shared package declares the types of the library", func(t *testing.T) {
+		// Arrange
+		buf := &bytes.Buffer{}
+
+		// Act
+		err := GenerateSharedPackage(buf, service, "Common", "example.com/shared/common", options.Types)
+
+		// Assert
+		assert.Nil(t, err, "Expected no error when generating the shared package")
+		generatedCode := buf.String()
+		assert.Contains(t, generatedCode, "package common\n", "Should name the package after the import path")
+		assert.Contains(t, generatedCode, "CountrySE = \"SE\" // Sweden", "Should declare the enum values")
+		assert.Contains(t, generatedCode, "type Address struct {", "Should declare the object")
+		assert.Contains(t, generatedCode, "`json:\"street\" xml:\"street\"`", "Should tag the fields for every content type")
+		assert.NotContains(t, generatedCode, "type Users struct", "Should not declare the types of the service")
+	})
+
+	t.Run("shared package of an unknown library", func(t *testing.T) {
+		// Arrange
+		buf := &bytes.Buffer{}
+
+		// Act
+		err := GenerateSharedPackage(buf, service, "Billing", "example.com/shared/billing", options.Types)
+
+		// Assert
+		assert.ErrorContains(t, err, errorUnknownLibrary, "Should fail for a library without types in the service")
+	})
+}
+
+func TestGenerateServer_TypedIDs(t *testing.T) {
+	// Arrange
+	service := specification.ApplyOverlay(&specification.Service{
+		Name:    "TestService",
+		Version: "v1",
+		Resources: []specification.Resource{
+			{Name: "Users", Operations: []string{"Get"}},
 			{
-				Name:        "SchoolFilterEquals",
-				Description: "Equality filter fields for School",
-				Fields: []specification.Field{
-					{
-						Name: "ID",
-						Type: "UUID",
-					},
+				Name:       "Orders",
+				Operations: []string{"Get"},
+				Fields: []specification.ResourceField{
+					{Field: specification.Field{Name: "BuyerID", Type: "UUID", References: "Users"}, Operations: []string{"Read"}},
+					{Field: specification.Field{Name: "ReviewerIDs", Type: "UUID", References: "Users", Modifiers: []string{"Array"}}, Operations: []string{"Read"}},
+				},
+				Endpoints: []specification.Endpoint{
 					{
-						Name:      "Meta",
-						Type:      "MetaFilterEquals",
-						Modifiers: []string{"Nullable"},
+						Name:   "ByBuyer",
+						Method: "GET",
+						Path:   "/by-buyer/{buyerID}",
+						Request: specification.EndpointRequest{
+							PathParams: []specification.Field{{Name: "BuyerID", Type: "UUID", References: "Users"}},
+						},
+						Response: specification.EndpointResponse{StatusCode: 200},
 					},
 				},
 			},
+		},
+	})
+
+	t.Run("types package", func(t *testing.T) {
+		// Arrange
+		buf := &bytes.Buffer{}
+
+		// Act
+		err := GenerateServerWithOptions(buf, service, Options{Types: Types{TypedIDs: true}})
+
+		// Assert
+		assert.Nil(t, err, "Expected no error when generating server")
+		generatedCode := buf.String()
+		assert.Contains(t, generatedCode, "type UsersID struct {\n\ttypes.UUID\n}", "Should declare the ID type of the referenced resource")
+		assert.Contains(t, generatedCode, "\tBuyerID UsersID `json:\"buyerID\"`\n", "Should declare the referencing field with the ID type")
+		assert.Contains(t, generatedCode, "\tReviewerIDs []UsersID `json:\"reviewerIDs\"`\n", "Should declare arrays of the ID type")
+		assert.Contains(t, generatedCode, "v.BuyerID = UsersID{types.NewUUID(parsed)}", "Should parse the path parameter into the ID type")
+		assert.NotContains(t, generatedCode, "type OrdersID struct", "Should not declare ID types of resources that are not referenced")
+	})
+
+	t.Run("stdlib types", func(t *testing.T) {
+		// Arrange
+		buf := &bytes.Buffer{}
+
+		// Act
+		err := GenerateServerWithOptions(buf, service, Options{Types: Types{Stdlib: true, TypedIDs: true}})
+
+		// Assert
+		assert.Nil(t, err, "Expected no error when generating server")
+		generatedCode := buf.String()
+		assert.Contains(t, generatedCode, "type UsersID struct {\n\tuuid.UUID\n}", "Should embed the UUID of the standard library types")
+		assert.Contains(t, generatedCode, "v.BuyerID = UsersID{parsed}", "Should parse the path parameter into the ID type")
+	})
+
+	t.Run("without typed IDs", func(t *testing.T) {
+		// Arrange
+		buf := &bytes.Buffer{}
+
+		// Act
+		err := GenerateServer(buf, service)
+
+		// Assert
+		assert.Nil(t, err, "Expected no error when generating server")
+		generatedCode := buf.String()
+		assert.NotContains(t, generatedCode, "UsersID", "Should not declare ID types")
+		assert.Contains(t, generatedCode, "\tBuyerID types.UUID `json:\"buyerID\"`\n", "Should declare the referencing field with the UUID type")
+	})
+}
+
+func TestGenerateServer_Aggregations(t *testing.T) {
+	// Arrange
+	service := specification.ApplyOverlay(&specification.Service{
+		Name:    "TestService",
+		Version: "v1",
+		Resources: []specification.Resource{
 			{
-				Name:        "MetaFilterEquals",
-				Description: "Equality filter fields for Meta",
-				Fields: []specification.Field{
-					{
-						Name: "Version",
-						Type: "String",
-					},
+				Name:         "Orders",
+				Operations:   []string{"List"},
+				Aggregations: &specification.Aggregations{CountBy: []string{"Status"}, Sum: []string{"Total"}},
+				Fields: []specification.ResourceField{
+					{Field: specification.Field{Name: "Status", Type: "String"}, Operations: []string{"Read"}},
+					{Field: specification.Field{Name: "Total", Type: "Int"}, Operations: []string{"Read"}},
 				},
 			},
 		},
+	})
+	buf := &bytes.Buffer{}
+
+	// Act
+	err := GenerateServer(buf, service)
+
+	// Assert
+	assert.Nil(t, err, "Expected no error when generating server")
+	generatedCode := buf.String()
+	assert.Contains(t, generatedCode, "\tAggregate(ctx context.Context, request Request[Session, struct{}, struct{}, struct{}]) (*OrdersAggregation, error)\n", "Should add the Aggregate method to the interface")
+	assert.Contains(t, generatedCode, `routerGroup.GET("/orders/_aggregate", serveWithResponse(200, api.Server, api.Orders.Aggregate`, "Should route the Aggregate endpoint")
+	assert.Contains(t, generatedCode, "\tSumTotal types.Int `json:\"sumTotal\"`\n", "Should declare the sums")
+	assert.Contains(t, generatedCode, "\tCountByStatus []OrdersStatusCount `json:\"countByStatus\"`\n", "Should declare the counts per value")
+	assert.Contains(t, generatedCode, "type OrdersStatusCount struct {", "Should declare the type of the counts per value")
+}
+
+func TestGenerateServer_AsyncEndpoints(t *testing.T) {
+	// Arrange
+	service := specification.ApplyOverlay(&specification.Service{
+		Name:     "TestService",
+		Version:  "v1",
+		BasePath: "/api",
+		Tenancy:  &specification.Tenancy{Name: "TenantID", In: specification.TenancyInPath},
 		Resources: []specification.Resource{
 			{
-				Name:        testResourceName,
-				Description: testResourceDesc,
+				Name:      "Reports",
+				Endpoints: []specification.Endpoint{{Name: "Generate", Method: "POST", Path: "/_generate", Async: true}},
 			},
 		},
-	}
+	})
+	buf := &bytes.Buffer{}
+
+	// Act
+	err := GenerateServerWithOptions(buf, service, Options{Types: Types{Stdlib: true}})
+
+	// Assert
+	assert.Nil(t, err, "Expected no error when generating server")
+	generatedCode := buf.String()
+	assert.Contains(t, generatedCode, "\tGenerate(ctx context.Context, request Request[Session, struct{}, struct{}, struct{}]) (*Operation, error)\n", "Should start the operation in the async endpoint")
+	assert.Contains(t, generatedCode, "\tGet(ctx context.Context, request Request[Session, OperationGetPathParams, struct{}, struct{}]) (*Operation, error)\n", "Should poll the status of the operation")
+	assert.Contains(t, generatedCode, `routerGroup.POST("/:tenantID/reports/_generate", serveWithResponse(202, api.Server, api.Reports.Generate`, "Should respond with 202 Accepted")
+	assert.Contains(t, generatedCode, `routerGroup.GET("/:tenantID/operation/:id", serveWithResponse(200, api.Server, api.Operation.Get`, "Should route the polling endpoint")
+	assert.Contains(t, generatedCode, `c.Header("Location", "/api/"+c.Param("tenantID")+"/operation/"+operation.ID.String())`, "Should write the path the operation is polled at")
+	assert.Contains(t, generatedCode, `c.Header("Retry-After", strconv.FormatInt(*operation.RetryAfter, 10))`, "Should write the seconds to wait before polling again")
+	assert.Contains(t, generatedCode, "writeOperationHeaders(c, successStatusCode, response)", "Should write the headers of the operations")
 }
 
-func createTestServiceWithEndpoints() *specification.Service {
+func TestGenerateServer_WithoutAsyncEndpoints(t *testing.T) {
+	// Arrange
 	service := createTestService()
-	service.Resources[0].Endpoints = []specification.Endpoint{
-		{
-			Name:        "CreateUser",
-			Method:      "POST",
-			Path:        "",
-			Summary:     testEndpointSummary,
-			Description: testEndpointDesc,
-			Request: specification.EndpointRequest{
-				ContentType: "application/json",
-				BodyParams: []specification.Field{
-					{
-						Name:        testFieldName,
-						Description: testFieldDesc,
-						Type:        testFieldType,
-					},
-				},
-			},
-			Response: specification.EndpointResponse{
-				ContentType: "application/json",
-				StatusCode:  testEndpointResponseCode,
-				BodyObject:  &service.Resources[0].Name,
-			},
-		},
-		{
-			Name:   "DeleteUser",
-			Method: "DELETE",
-			Path:   "/{id}",
-			Request: specification.EndpointRequest{
-				PathParams: []specification.Field{
-					{
-						Name: "ID",
-						Type: testFieldTypeUUID,
-					},
-				},
-			},
-			Response: specification.EndpointResponse{
-				StatusCode: 204,
-			},
-		},
-		{
-			Name:   "ListUsers",
-			Method: "GET",
-			Path:   "",
-			Request: specification.EndpointRequest{
-				QueryParams: []specification.Field{
-					{
-						Name:    "Limit",
-						Type:    testFieldTypeInt,
-						Default: "50",
-					},
-				},
-			},
-			Response: specification.EndpointResponse{
-				StatusCode: 200,
-				BodyFields: []specification.Field{
-					{
-						Name:      "Data",
-						Type:      testResourceName,
-						Modifiers: []string{"Array"},
-					},
-					{
-						Name: "Pagination",
-						Type: "Pagination",
-					},
-				},
-			},
-		},
-	}
+	buf := &bytes.Buffer{}
 
-	// Add Pagination object for list response
-	service.Objects = append(service.Objects, specification.Object{
-		Name: "Pagination",
-		Fields: []specification.Field{
-			{Name: "Offset", Type: testFieldTypeInt},
-			{Name: "Limit", Type: testFieldTypeInt},
-			{Name: "Total", Type: testFieldTypeInt},
-		},
-	})
+	// Act
+	err := GenerateServer(buf, service)
 
-	return service
+	// Assert
+	assert.Nil(t, err, "Expected no error when generating server")
+	assert.NotContains(t, buf.String(), "writeOperationHeaders", "Should only write the headers of the operations of async endpoints")
 }
 
-func createTestServiceWithVariousHTTPMethods() *specification.Service {
-	return &specification.Service{
-		Name:    testServiceName,
-		Version: testServiceVersion,
+func TestGenerateServer_Callbacks(t *testing.T) {
+	// Arrange
+	service := specification.ApplyOverlay(&specification.Service{
+		Name:    "TestService",
+		Version: "v1",
+		Objects: []specification.Object{{Name: "Shipment", Fields: []specification.Field{{Name: "TrackingCode", Type: "String"}}}},
 		Resources: []specification.Resource{
 			{
-				Name: testResourceName,
+				Name: "Orders",
 				Endpoints: []specification.Endpoint{
 					{
-						Name:   "Get",
-						Method: "GET",
-						Path:   "/{id}",
-						Response: specification.EndpointResponse{
-							StatusCode: 200,
-						},
-					},
-					{
-						Name:   "Create",
-						Method: "POST",
-						Path:   "",
-						Response: specification.EndpointResponse{
-							StatusCode: 201,
-						},
-					},
-					{
-						Name:   "Update",
-						Method: "PATCH",
-						Path:   "/{id}",
-						Response: specification.EndpointResponse{
-							StatusCode: 200,
-						},
-					},
-					{
-						Name:   "Delete",
-						Method: "DELETE",
-						Path:   "/{id}",
-						Response: specification.EndpointResponse{
-							StatusCode: 204,
-						},
+						Name:      "Subscribe",
+						Method:    "POST",
+						Path:      "/_subscribe",
+						Request:   specification.EndpointRequest{BodyParams: []specification.Field{{Name: "CallbackURL", Type: "String"}}},
+						Response:  specification.EndpointResponse{StatusCode: 204},
+						Callbacks: []specification.Callback{{Name: "OrderShipped", Description: "Sent when the order is shipped", URLField: "CallbackURL", Method: "put", Payload: "Shipment"}},
 					},
 				},
 			},
 		},
-	}
-}
+	})
+	buf := &bytes.Buffer{}
 
-// ============================================================================
-// Path Conversion Tests
-// ============================================================================
+	// Act
+	err := GenerateServer(buf, service)
 
-func TestConvertOpenAPIPathToGin(t *testing.T) {
-	testCases := []struct {
-		name     string
-		input    string
-		expected string
-	}{
-		{
-			name:     "single path parameter",
-			input:    "/user/{id}",
-			expected: "/user/:id",
-		},
-		{
-			name:     "multiple path parameters",
-			input:    "/user/{userId}/posts/{postId}",
-			expected: "/user/:userId/posts/:postId",
-		},
-		{
-			name:     "no path parameters",
-			input:    "/users",
-			expected: "/users",
-		},
-		{
-			name:     "empty path",
-			input:    "",
-			expected: "",
-		},
-		{
-			name:     "path with query-like syntax but no parameters",
-			input:    "/search?query=test",
-			expected: "/search?query=test",
-		},
-		{
-			name:     "parameter at the beginning",
-			input:    "/{id}/children",
-			expected: "/:id/children",
-		},
-		{
-			name:     "parameter at the end",
-			input:    "/users/{id}",
-			expected: "/users/:id",
-		},
-	}
+	// Assert
+	assert.Nil(t, err, "Expected no error when generating server")
+	generatedCode := buf.String()
+	assert.Contains(t, generatedCode, "type CallbackClient struct {", "Should generate the callback client")
+	assert.Contains(t, generatedCode, "// Sent when the order is shipped\n", "Should document the callback")
+	assert.Contains(t, generatedCode, "func (c CallbackClient) OrdersSubscribeOrderShipped(ctx context.Context, subscriberURL string, payload *Shipment) error {\n\treturn c.send(ctx, http.MethodPut, subscriberURL, payload)\n}", "Should send the payload of the callback with its method")
+	assert.Contains(t, generatedCode, "func (c CallbackClient) send(ctx context.Context, method, subscriberURL string, payload any) error {", "Should send the callbacks as JSON")
+}
+
+func TestGenerateServer_RetryPolicy(t *testing.T) {
+	t.Run("service with retry configuration", func(t *testing.T) {
+		// Arrange
+		service := createTestService()
+		service.Retry = &specification.RetryConfiguration{
+			Backoff:               specification.RetryBackoffConfiguration{InitialInterval: 100, Exponent: 2},
+			StatusCodes:           []string{"5XX", "429"},
+			RetryConnectionErrors: true,
+		}
+		buf := &bytes.Buffer{}
 
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			result := convertOpenAPIPathToGin(tc.input)
-			assert.Equal(t, tc.expected, result, "Path conversion should match expected result")
-		})
-	}
+		// Act
+		err := GenerateServer(buf, service)
 
-	t.Run("edge cases", func(t *testing.T) {
-		t.Run("malformed parameter missing closing brace", func(t *testing.T) {
-			result := convertOpenAPIPathToGin("/user/{id")
-			assert.Equal(t, "/user/{id", result, "Should not modify malformed parameter")
-		})
+		// Assert
+		assert.Nil(t, err, "Expected no error when generating server")
+		generatedCode := buf.String()
+		assert.Contains(t, generatedCode, "type RetryTransport struct {", "Should generate the retrying transport")
+		assert.Contains(t, generatedCode, "\tInitialInterval:       100 * time.Millisecond,\n", "Should retry with the initial interval of the specification")
+		assert.Contains(t, generatedCode, "\tMaxInterval:           60000 * time.Millisecond,\n", "Should default the intervals like the SDKs")
+		assert.Contains(t, generatedCode, "\tExponent:              2,\n", "Should retry with the exponent of the specification")
+		assert.Contains(t, generatedCode, "\tStatusCodes:           []string{\"5XX\", \"429\"},\n", "Should retry the status codes of the specification")
+		assert.Contains(t, generatedCode, "\tRetryConnectionErrors: true,\n", "Should retry the connection errors")
+	})
 
-		t.Run("malformed parameter missing opening brace", func(t *testing.T) {
-			result := convertOpenAPIPathToGin("/user/id}")
-			assert.Equal(t, "/user/id}", result, "Should not modify malformed parameter")
-		})
+	t.Run("service without retry configuration", func(t *testing.T) {
+		// Arrange
+		buf := &bytes.Buffer{}
 
-		t.Run("empty parameter name", func(t *testing.T) {
-			result := convertOpenAPIPathToGin("/user/{}")
-			assert.Equal(t, "/user/:", result, "Should convert empty parameter to colon")
-		})
+		// Act
+		err := GenerateServer(buf, createTestService())
+
+		// Assert
+		assert.Nil(t, err, "Expected no error when generating server")
+		assert.NotContains(t, buf.String(), "RetryTransport", "Should only generate the retrying transport with a retry configuration")
 	})
 }
 
-// ============================================================================
-// Development Flag Tests
-// ============================================================================
+func TestGenerateServer_FeatureFlags(t *testing.T) {
+	t.Run("service with feature flags", func(t *testing.T) {
+		// Arrange
+		service := specification.ApplyOverlay(&specification.Service{
+			Name:    "TestService",
+			Version: "v1",
+			Resources: []specification.Resource{
+				{
+					Name: "Users",
+					Endpoints: []specification.Endpoint{
+						{
+							Name:        "Export",
+							Method:      "POST",
+							Path:        "/_export",
+							FeatureFlag: "users-export",
+							Response:    specification.EndpointResponse{StatusCode: 204},
+						},
+					},
+				},
+			},
+		})
+		buf := &bytes.Buffer{}
 
-// TestGenerateServer_DevelopmentFlag tests that resources with Development: true are still fully
-// generated into server code. The development flag is an OpenAPI documentation gate only — it
-// must never suppress interface definitions, API struct fields, or route registrations.
-func TestGenerateServer_DevelopmentFlag(t *testing.T) {
-	const (
-		devResourceName    = "GradeElementary"
-		stableResourceName = "Students"
-		devResourceDesc    = "Grade elementary resource in development"
-		stableResourceDesc = "Student management operations"
-	)
+		// Act
+		err := GenerateServer(buf, service)
 
-	stableEndpoint := specification.Endpoint{
-		Name:   "ListStudents",
-		Method: "GET",
-		Path:   "",
-		Response: specification.EndpointResponse{
-			StatusCode: 200,
-			BodyFields: []specification.Field{
-				{Name: "Total", Type: "Int"},
-			},
-		},
-	}
+		// Assert
+		assert.Nil(t, err, "Expected no error when generating server")
+		generatedCode := buf.String()
+		assert.Contains(t, generatedCode, "type FeatureEnabledFunc func(ctx context.Context, flag string) bool", "Should generate the feature flag function type")
+		assert.Contains(t, generatedCode, "\tFeatureEnabledFunc FeatureEnabledFunc\n", "Should configure the feature flags on the server")
+		assert.Contains(t, generatedCode, "panic(\"FeatureEnabledFunc is nil\")", "Should require the feature flag function")
+		assert.Contains(t, generatedCode, `requireFeature("users-export"), `, "Should gate the endpoint behind its feature flag")
+		assert.Contains(t, generatedCode, "!server.FeatureEnabledFunc(c.Request.Context(), flag)", "Should check the feature flag before handling the request")
+		assert.Contains(t, generatedCode, "Code:      ErrorCodeNotFound,", "Should respond not found while the feature flag is disabled")
+	})
 
-	devEndpoint := specification.Endpoint{
-		Name:   "ListGrades",
-		Method: "GET",
-		Path:   "",
-		Response: specification.EndpointResponse{
-			StatusCode: 200,
-			BodyFields: []specification.Field{
-				{Name: "Grade", Type: "String"},
+	t.Run("service without feature flags", func(t *testing.T) {
+		// Arrange
+		buf := &bytes.Buffer{}
+
+		// Act
+		err := GenerateServer(buf, createTestService())
+
+		// Assert
+		assert.Nil(t, err, "Expected no error when generating server")
+		assert.NotContains(t, buf.String(), "FeatureEnabledFunc", "Should only check feature flags when an endpoint is flagged")
+		assert.NotContains(t, buf.String(), "requireFeature", "Should only gate the flagged endpoints")
+	})
+}
+
+func TestGenerateServer_SearchableText(t *testing.T) {
+	// Arrange
+	service := specification.ApplyOverlay(&specification.Service{
+		Name:    "TestService",
+		Version: "v1",
+		Resources: []specification.Resource{
+			{
+				Name:       "Users",
+				Operations: []string{"Search"},
+				Fields: []specification.ResourceField{
+					{Field: specification.Field{Name: "Name", Type: "String"}, Operations: []string{"Read"}, SearchableText: true},
+				},
 			},
 		},
-	}
+	})
+	buf := &bytes.Buffer{}
 
-	buildService := func() *specification.Service {
-		return &specification.Service{
-			Name:    testServiceName,
-			Version: testServiceVersion,
+	// Act
+	err := GenerateServerWithOptions(buf, service, Options{Types: Types{Stdlib: true}})
+
+	// Assert
+	assert.Nil(t, err, "Expected no error when generating server")
+	assert.Contains(t, buf.String(), "type UsersSearchBodyParams struct {\n\tFilter UsersFilter `json:\"filter\"`\n\tQuery  *string     `json:\"query\"`\n}", "Search body params should have the optional Query")
+}
+
+func TestGenerateServer_FilterMaxDepth(t *testing.T) {
+	// Arrange
+	createService := func(maxDepth int) *specification.Service {
+		return specification.ApplyOverlay(&specification.Service{
+			Name:           "TestService",
+			Version:        "v1",
+			FilterMaxDepth: maxDepth,
 			Resources: []specification.Resource{
-				{Name: stableResourceName, Description: stableResourceDesc, Endpoints: []specification.Endpoint{stableEndpoint}},
-				{Name: devResourceName, Description: devResourceDesc, Development: true, Endpoints: []specification.Endpoint{devEndpoint}},
+				{
+					Name:       "Users",
+					Operations: []string{"Search"},
+					Fields: []specification.ResourceField{
+						{Field: specification.Field{Name: "Name", Type: "String"}, Operations: []string{"Read"}},
+					},
+				},
 			},
-		}
+		})
 	}
 
-	t.Run("development resource route is registered", func(t *testing.T) {
+	t.Run("limited depth", func(t *testing.T) {
+		// Arrange
 		buf := &bytes.Buffer{}
-		err := GenerateServer(buf, buildService())
-		assert.Nil(t, err, "Should not return error when generating server with a development resource")
 
-		generated := buf.String()
-		assert.Contains(t, generated, stableResourceName, "Stable resource should appear in generated code")
-		assert.Contains(t, generated, devResourceName, "Development resource MUST appear in generated server code")
-		assert.Contains(t, generated, "api."+devResourceName+".ListGrades",
-			"Development resource route should be registered in server code")
+		// Act
+		err := GenerateServer(buf, createService(3))
+
+		// Assert
+		assert.Nil(t, err, "Expected no error when generating server")
+		generatedCode := buf.String()
+		assert.Contains(t, generatedCode, "\tNotCondition types.Bool `json:\"notCondition\"`\n", "Filters should be negatable")
+		assert.Contains(t, generatedCode, "func (f UsersFilter) depth() int {", "Should count the levels of the nested filters")
+		assert.Contains(t, generatedCode, "func (v UsersSearchBodyParams) checkFilterDepth() error {\n\tif depth := v.Filter.depth(); depth > 3 {", "Should reject filters nested deeper than the max depth")
+		assert.Contains(t, generatedCode, "type filterDepthChecker interface {", "Should declare the checker of the body params")
+		assert.Contains(t, generatedCode, "if checker, ok := any(bodyParams).(filterDepthChecker); ok {", "Should check the depth after decoding the body")
 	})
 
-	t.Run("development resource has API struct field", func(t *testing.T) {
+	t.Run("unlimited depth", func(t *testing.T) {
+		// Arrange
 		buf := &bytes.Buffer{}
-		err := GenerateServer(buf, buildService())
-		assert.Nil(t, err, "Should not return error when generating server with a development resource")
 
-		generated := buf.String()
+		// Act
+		err := GenerateServer(buf, createService(0))
 
-		assert.Contains(t, generated, "StudentsAPI[Session]", "Stable resource API field should appear in generated struct")
-		assert.Contains(t, generated, "GradeElementaryAPI[Session]", "Development resource API field MUST appear in generated struct")
+		// Assert
+		assert.Nil(t, err, "Expected no error when generating server")
+		assert.NotContains(t, buf.String(), "filterDepthChecker", "Should not check the depth without a max depth")
+		assert.Contains(t, buf.String(), "NestedFilters []UsersFilter", "Nested filters should be recursive")
 	})
+}
 
-	t.Run("development resource has interface definition", func(t *testing.T) {
+func TestGenerateServer_PaginationHelpers(t *testing.T) {
+	createService := func(maxLimit int, totalCount string, operations ...string) *specification.Service {
+		return specification.ApplyDefaultOverlays(&specification.Service{
+			Name:     "TestService",
+			Version:  "v1",
+			MaxLimit: maxLimit,
+			Resources: []specification.Resource{{
+				Name:       "Users",
+				Operations: operations,
+				TotalCount: totalCount,
+				Fields: []specification.ResourceField{
+					{Field: specification.Field{Name: "Name", Type: specification.FieldTypeString}, Operations: []string{specification.OperationRead}},
+				},
+			}},
+		})
+	}
+
+	t.Run("max limit", func(t *testing.T) {
+		// Arrange
 		buf := &bytes.Buffer{}
-		err := GenerateServer(buf, buildService())
-		assert.Nil(t, err, "Should not return error when generating server with a development resource")
 
-		generated := buf.String()
+		// Act
+		err := GenerateServer(buf, createService(100, "", specification.OperationList))
 
-		expectedStableInterface := "type " + stableResourceName + "API[Session any] interface {"
-		assert.Contains(t, generated, expectedStableInterface, "Stable resource interface should appear in generated code")
+		// Assert
+		assert.Nil(t, err, "Expected no error when generating server")
+		generatedCode := buf.String()
+		assert.Contains(t, generatedCode, "const DefaultLimit = 50\n", "Should declare the default limit")
+		assert.Contains(t, generatedCode, "const MaxLimit = 100\n", "Should declare the max limit")
+		assert.Contains(t, generatedCode, "func ClampPagination(offset, limit int64) (int64, int64) {", "Should clamp the pagination")
+		assert.Contains(t, generatedCode, "\tif limit > MaxLimit {\n\t\tlimit = MaxLimit\n\t}\n", "Should clamp the limit to the max limit")
+		assert.Contains(t, generatedCode, "func PageItems[T any](items []T, offset, limit int64) []T {", "Should window the items of a page")
+		assert.Contains(t, generatedCode, "func NewPage[T any](items []T, total, offset, limit int64) ([]T, Pagination) {", "Should build the page")
+		assert.Contains(t, generatedCode, "\t\tTotal:  types.NewInt(total),\n", "Should fill the total of the pagination")
+		assert.NotContains(t, generatedCode, "func NewPageWithoutTotal", "Should not build pages without total")
+		assert.Contains(t, generatedCode, "\t\tparsed = min(parsed, MaxLimit)\n\t\tv.Limit = types.NewInt(parsed)\n", "Should clamp the limit of the request to the max limit")
+		_, err = format.Source(buf.Bytes())
+		assert.Nil(t, err, "Generated code should be valid Go")
+	})
 
-		expectedDevInterface := "type " + devResourceName + "API[Session any] interface {"
-		assert.Contains(t, generated, expectedDevInterface, "Development resource interface MUST appear in generated code")
+	t.Run("max limit with standard library types", func(t *testing.T) {
+		// Arrange
+		buf := &bytes.Buffer{}
+
+		// Act
+		err := GenerateServerWithOptions(buf, createService(100, "", specification.OperationSearch), Options{Types: Types{Stdlib: true}})
+
+		// Assert
+		assert.Nil(t, err, "Expected no error when generating server")
+		assert.Contains(t, buf.String(), "func (v *UsersSearchQueryParams) parseQueryParams(query url.Values) error {", "Should parse the query parameters of Search")
+		assert.Contains(t, buf.String(), "\t\tparsed = min(parsed, MaxLimit)\n\t\tv.Limit = parsed\n", "Should clamp the limit of the request to the max limit")
 	})
 
-	t.Run("non-development resource is unaffected when other resources are in development", func(t *testing.T) {
+	t.Run("without max limit", func(t *testing.T) {
+		// Arrange
 		buf := &bytes.Buffer{}
-		err := GenerateServer(buf, buildService())
-		assert.Nil(t, err, "Should not return error when generating server with a development resource")
 
-		generated := buf.String()
+		// Act
+		err := GenerateServer(buf, createService(0, "", specification.OperationSearch))
 
-		assert.Contains(t, generated, stableResourceName, "Stable resource should still appear in generated code")
-		assert.Contains(t, generated, "ListStudents", "Stable resource endpoint should still appear in generated code")
+		// Assert
+		assert.Nil(t, err, "Expected no error when generating server")
+		assert.Contains(t, buf.String(), "func ClampPagination(offset, limit int64) (int64, int64) {", "Should clamp the pagination")
+		assert.NotContains(t, buf.String(), "MaxLimit", "Should not clamp the limit without a max limit")
 	})
-}
 
-// TestGenerateServer_DevelopmentFlagEnumsObjects tests that development enums and objects
-// are still included in generated server Go code (servergen is intentionally unaffected).
-func TestGenerateServer_DevelopmentFlagEnumsObjects(t *testing.T) {
-	const (
-		devEnumName    = "InternalGradeType"
-		stableEnumName = "Status"
-		devObjectName  = "GradeElementary"
-		stableObjName  = "Student"
-	)
+	t.Run("total in the header with standard library types", func(t *testing.T) {
+		// Arrange
+		buf := &bytes.Buffer{}
 
-	t.Run("development enum is still generated as Go type in server code", func(t *testing.T) {
-		service := &specification.Service{
-			Name:    testServiceName,
-			Version: testServiceVersion,
-			Enums: []specification.Enum{
-				{
-					Name:        stableEnumName,
-					Description: "Stable status enum",
-					Values: []specification.EnumValue{
-						{Name: "Active", Description: "Active state"},
-					},
-				},
-				{
-					Name:        devEnumName,
-					Description: "Internal grade type in development",
-					Development: true,
-					Values: []specification.EnumValue{
-						{Name: "Grade1", Description: "Grade 1"},
-					},
-				},
-			},
-		}
+		// Act
+		err := GenerateServerWithOptions(buf, createService(100, specification.TotalCountHeader, specification.OperationList), Options{Types: Types{Stdlib: true}})
+
+		// Assert
+		assert.Nil(t, err, "Expected no error when generating server")
+		generatedCode := buf.String()
+		assert.Contains(t, generatedCode, "func NewPageWithoutTotal[T any](items []T, offset, limit int64) ([]T, PaginationWithoutTotal) {", "Should build pages without total")
+		assert.Contains(t, generatedCode, "\t\tOffset: offset,\n\t\tLimit:  limit,\n\t}\n", "Should fill the pagination with standard library types")
+		_, err = format.Source(buf.Bytes())
+		assert.Nil(t, err, "Generated code should be valid Go")
+	})
 
+	t.Run("without paginated endpoints", func(t *testing.T) {
+		// Arrange
 		buf := &bytes.Buffer{}
-		err := GenerateServer(buf, service)
-		assert.Nil(t, err, "Should not return error when generating server with development enum")
 
-		generated := buf.String()
-		assert.Contains(t, generated, stableEnumName, "Stable enum type should appear in generated Go code")
-		assert.Contains(t, generated, devEnumName, "Development enum type should still appear in generated Go code (servergen unaffected)")
+		// Act
+		err := GenerateServer(buf, createService(100, "", specification.OperationRead))
+
+		// Assert
+		assert.Nil(t, err, "Expected no error when generating server")
+		assert.NotContains(t, buf.String(), "ClampPagination", "Should not generate the pagination helpers")
 	})
+}
 
-	t.Run("development object is still generated as Go type in server code", func(t *testing.T) {
-		service := &specification.Service{
-			Name:    testServiceName,
-			Version: testServiceVersion,
-			Objects: []specification.Object{
-				{
-					Name:        stableObjName,
-					Description: "Stable student object",
-					Fields: []specification.Field{
-						{Name: "Name", Description: "Student name", Type: "String"},
-					},
+func TestGenerateServer_Expansions(t *testing.T) {
+	// Arrange
+	service := specification.ApplyOverlay(&specification.Service{
+		Name:    "TestService",
+		Version: "v1",
+		Resources: []specification.Resource{
+			{Name: "Users", Operations: []string{"Get"}},
+			{
+				Name:       "Orders",
+				Operations: []string{"Get", "List"},
+				Fields: []specification.ResourceField{
+					{Field: specification.Field{Name: "BuyerID", Type: "UUID", References: "Users"}, Operations: []string{"Read"}},
+					{Field: specification.Field{Name: "ReviewerIDs", Type: "UUID", References: "Users", Modifiers: []string{"Array"}}, Operations: []string{"Read"}},
 				},
-				{
-					Name:        devObjectName,
-					Description: "Development grade object",
-					Development: true,
-					Fields: []specification.Field{
-						{Name: "Grade", Description: "Grade value", Type: "String"},
-					},
+				Expansions: []specification.Expansion{
+					{Name: "Buyer", Field: "BuyerID"},
+					{Name: "Reviewers", Field: "ReviewerIDs"},
 				},
 			},
-		}
+		},
+	})
 
+	t.Run("types package", func(t *testing.T) {
+		// Arrange
 		buf := &bytes.Buffer{}
+
+		// Act
 		err := GenerateServer(buf, service)
-		assert.Nil(t, err, "Should not return error when generating server with development object")
 
-		generated := buf.String()
-		assert.Contains(t, generated, stableObjName, "Stable object type should appear in generated Go code")
-		assert.Contains(t, generated, devObjectName, "Development object type should still appear in generated Go code (servergen unaffected)")
+		// Assert
+		assert.Nil(t, err, "Expected no error when generating server")
+		generatedCode := buf.String()
+		assert.Contains(t, generatedCode, "type OrdersExpansions struct {", "Should declare the flags of the expansions")
+		assert.Contains(t, generatedCode, "\tBuyer bool\n", "Should declare a flag per expansion")
+		assert.Contains(t, generatedCode, "\tReviewers bool\n", "Should declare a flag per expansion")
+		assert.Contains(t, generatedCode, "func (v OrdersGetQueryParams) Expansions() OrdersExpansions {", "Get should return the expansions")
+		assert.Contains(t, generatedCode, "func (v OrdersListQueryParams) Expansions() OrdersExpansions {", "List should return the expansions")
+		assert.Contains(t, generatedCode, "\t\tswitch value.String() {\n\t\tcase OrdersExpansionBuyer:\n\t\t\texpansions.Buyer = true\n", "Should set the flag of each expand value")
+		assert.Contains(t, generatedCode, "\tBuyer *Users `json:\"buyer", "Should include the expanded resource in the response")
+		assert.NotContains(t, generatedCode, "UsersExpansions", "Should not declare expansions of resources without them")
+	})
+
+	t.Run("stdlib types", func(t *testing.T) {
+		// Arrange
+		buf := &bytes.Buffer{}
+
+		// Act
+		err := GenerateServerWithOptions(buf, service, Options{Types: Types{Stdlib: true}})
+
+		// Assert
+		assert.Nil(t, err, "Expected no error when generating server")
+		assert.Contains(t, buf.String(), "\t\tswitch value {\n\t\tcase OrdersExpansionBuyer:\n", "Should switch on the plain string value")
 	})
 }
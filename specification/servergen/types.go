@@ -0,0 +1,353 @@
+package servergen
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/meitner-se/publicapis-gen/specification"
+)
+
+// DefaultTypesPackage is the import path of the package the fields are declared with by default
+const DefaultTypesPackage = "github.com/meitner-se/go-types"
+
+// typesPackageName is the name the generated code references the types package by
+const typesPackageName = "types"
+
+// Types selects the Go types the fields of the generated code are declared with. The zero value declares them
+// with DefaultTypesPackage, which tells absent and null values apart.
+type Types struct {
+	// Package is the import path of a package named types with the API of DefaultTypesPackage,
+	// used instead of DefaultTypesPackage when set.
+	Package string
+
+	// Stdlib declares the fields with standard library types instead of a types package: string, int64, int32,
+	// uint64, float64, bool, uuid.UUID and time.Time, with pointers for nullable and optional fields. Dates are
+	// declared as strings in the YYYY-MM-DD format. Package is ignored.
+	Stdlib bool
+
+	// TypedIDs declares the UUID fields referencing a resource with a type of the IDs of the resource, e.g. UserID,
+	// so that the IDs of different resources cannot be mixed up. The type embeds the UUID type of the other fields.
+	TypedIDs bool
+
+	// shared are the import paths of the packages of the shared libraries, see Options.SharedPackages
+	shared map[string]string
+}
+
+// sharedPackage returns the name of the package the types of the shared library are imported from, or an empty
+// string when they are generated with the server.
+func (t Types) sharedPackage(library string) string {
+	importPath := t.shared[library]
+	if library == "" || importPath == "" {
+		return ""
+	}
+
+	return packageName(importPath)
+}
+
+// idType returns the name of the ID type of the UUID field referencing a resource, or an empty string when the
+// field is declared with the UUID type.
+func (t Types) idType(field specification.Field) string {
+	if !t.TypedIDs || field.References == "" || field.Type != specification.FieldTypeUUID {
+		return ""
+	}
+
+	return field.References + "ID"
+}
+
+// ImportPath returns the import path of the types package, or an empty string for standard library types.
+func (t Types) ImportPath() string {
+	if t.Stdlib {
+		return ""
+	}
+
+	if t.Package != "" {
+		return t.Package
+	}
+
+	return DefaultTypesPackage
+}
+
+// typeName returns the name of the Go type of the scalar field type, without the package of the types package.
+func (t Types) typeName(fieldType string) string {
+	if !t.Stdlib {
+		return fieldType
+	}
+
+	switch fieldType {
+	case specification.FieldTypeInt:
+		return "int64"
+	case specification.FieldTypeInt32:
+		return "int32"
+	case specification.FieldTypeUInt:
+		return "uint64"
+	case specification.FieldTypeFloat64:
+		return "float64"
+	case specification.FieldTypeBool:
+		return "bool"
+	case specification.FieldTypeUUID:
+		return "uuid.UUID"
+	case specification.FieldTypeTimestamp:
+		return "time.Time"
+	default:
+		return "string"
+	}
+}
+
+// prefix returns the prefix of the Go type of a scalar field, which is the types package or, with standard library
+// types, a pointer when the field can be absent.
+func (t Types) prefix(pointer bool) string {
+	if !t.Stdlib {
+		return typesPackageName + "."
+	}
+
+	if pointer {
+		return "*"
+	}
+
+	return ""
+}
+
+// isPointer reports whether a scalar field is declared as a pointer with standard library types.
+func (t Types) isPointer(field specification.Field) bool {
+	return t.Stdlib && !field.IsArray() && (field.IsNullable() || field.IsOptional())
+}
+
+// String returns the Go expression converting the string expression to a non-nullable String field,
+// e.g. types.NewString(err.Error()). The templates use it to fill the fields of Error.
+func (t Types) String(expr string) string {
+	if t.Stdlib {
+		return expr
+	}
+
+	return typesPackageName + ".NewString(" + expr + ")"
+}
+
+// Int returns the Go expression converting the int64 expression to a non-nullable Int field.
+func (t Types) Int(expr string) string {
+	if t.Stdlib {
+		return expr
+	}
+
+	return typesPackageName + ".NewInt(" + expr + ")"
+}
+
+// StringValue returns the Go expression reading the string of a non-nullable String field.
+func (t Types) StringValue(expr string) string {
+	if t.Stdlib {
+		return expr
+	}
+
+	return expr + ".String()"
+}
+
+// Literal returns the Go expression of a value of the field, given as it is written in the specification, e.g. a
+// default value. Fields of an unknown type are treated as enums, whose values are strings. It returns false if the
+// value cannot be represented, such as a timestamp that is not in the RFC 3339 format with standard library types.
+func (t Types) Literal(field specification.Field, value string) (string, bool) {
+	fieldType := field.Type
+
+	if fieldType == specification.FieldTypeDecimal {
+		if field.IsNullable() {
+			return fmt.Sprintf("decimal.NewNullDecimal(decimal.RequireFromString(%q))", value), true
+		}
+		return fmt.Sprintf("decimal.RequireFromString(%q)", value), true
+	}
+
+	if !t.Stdlib {
+		switch fieldType {
+		case specification.FieldTypeInt, specification.FieldTypeInt32, specification.FieldTypeUInt,
+			specification.FieldTypeFloat64, specification.FieldTypeBool:
+			return fmt.Sprintf("%s.New%s(%s)", typesPackageName, fieldType, value), true
+		case specification.FieldTypeUUID:
+			expr := fmt.Sprintf("%s.NewUUID(uuid.MustParse(%q))", typesPackageName, value)
+			if id := t.idType(field); id != "" {
+				expr = id + "{" + expr + "}"
+			}
+			return expr, true
+		case specification.FieldTypeDate, specification.FieldTypeTimestamp, specification.FieldTypeString:
+			return fmt.Sprintf("%s.New%s(%q)", typesPackageName, fieldType, value), true
+		default:
+			return fmt.Sprintf("%s.NewString(%q)", typesPackageName, value), true
+		}
+	}
+
+	var expr string
+	switch fieldType {
+	case specification.FieldTypeInt, specification.FieldTypeInt32, specification.FieldTypeUInt, specification.FieldTypeFloat64:
+		expr = fmt.Sprintf("%s(%s)", t.typeName(fieldType), value)
+	case specification.FieldTypeBool:
+		expr = value
+	case specification.FieldTypeUUID:
+		expr = fmt.Sprintf("uuid.MustParse(%q)", value)
+		if id := t.idType(field); id != "" {
+			expr = id + "{" + expr + "}"
+		}
+	case specification.FieldTypeTimestamp:
+		timestamp, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return "", false
+		}
+		timestamp = timestamp.UTC()
+		expr = fmt.Sprintf("time.Date(%d, time.%s, %d, %d, %d, %d, %d, time.UTC)",
+			timestamp.Year(), timestamp.Month(), timestamp.Day(), timestamp.Hour(), timestamp.Minute(), timestamp.Second(), timestamp.Nanosecond())
+	default:
+		expr = strconv.Quote(value)
+	}
+
+	if t.isPointer(field) {
+		return "ptr(" + expr + ")", true
+	}
+
+	return expr, true
+}
+
+// pathParamTag returns the JSON tag of the path parameter, which is decoded from a JSON string,
+// so numbers and booleans are quoted with standard library types.
+func (t Types) pathParamTag(field specification.Field) string {
+	if !t.Stdlib {
+		return field.TagJSON()
+	}
+
+	switch field.Type {
+	case specification.FieldTypeInt, specification.FieldTypeInt32, specification.FieldTypeUInt,
+		specification.FieldTypeFloat64, specification.FieldTypeBool:
+		return field.TagJSON() + ",string"
+	default:
+		return field.TagJSON()
+	}
+}
+
+// parseParam returns the Go statements parsing the string variable value into the scalar path or query parameter and
+// assigning it with assign, the format of the assignment statement of the parsed value, or false for the parameters
+// of object types, which are not parsed directly. The statements are indented with indent and return the parse error
+// prefixed with the name of the parameter.
+func (t Types) parseParam(field specification.Field, service *specification.Service, assign, indent string) (string, bool) {
+	fieldType := service.GetPrimitiveType(field.Type)
+	if service.IsObject(fieldType) {
+		return "", false
+	}
+
+	// parse is the expression parsing value into parsed and err, and result the value of the field from parsed.
+	// The dates and timestamps of the types package are only validated, since they are created from the string.
+	var parse, result string
+	parsed := "parsed"
+	switch fieldType {
+	case specification.FieldTypeInt:
+		parse, result = "parseIntParam(value, 64)", t.wrap(fieldType, "parsed")
+	case specification.FieldTypeInt32:
+		parse, result = "parseIntParam(value, 32)", t.wrap(fieldType, "int32(parsed)")
+	case specification.FieldTypeUInt:
+		parse, result = "parseUintParam(value)", t.wrap(fieldType, "parsed")
+	case specification.FieldTypeFloat64:
+		parse, result = "parseFloatParam(value)", t.wrap(fieldType, "parsed")
+	case specification.FieldTypeBool:
+		parse, result = "parseBoolParam(value)", t.wrap(fieldType, "parsed")
+	case specification.FieldTypeUUID:
+		parse, result = "uuid.Parse(value)", t.wrap(fieldType, "parsed")
+		if id := t.idType(field); id != "" {
+			result = id + "{" + result + "}"
+		}
+	case specification.FieldTypeDecimal:
+		parse, result = "decimal.NewFromString(value)", "parsed"
+		if field.IsNullable() {
+			result = "decimal.NewNullDecimal(parsed)"
+		}
+	case specification.FieldTypeTimestamp:
+		parse, result = "time.Parse(time.RFC3339, value)", "parsed"
+		if !t.Stdlib {
+			parsed, result = "_", t.wrap(fieldType, "value")
+		}
+	case specification.FieldTypeDate:
+		result = t.wrap(fieldType, "value")
+		if !t.Stdlib {
+			parse, parsed = "time.Parse(time.DateOnly, value)", "_"
+		}
+	default:
+		result = t.wrap(specification.FieldTypeString, "value")
+	}
+
+	if t.isPointer(field) && fieldType != specification.FieldTypeDecimal {
+		result = "ptr(" + result + ")"
+	}
+
+	assignment := indent + fmt.Sprintf(assign, result) + "\n"
+	if parse == "" {
+		return assignment, true
+	}
+
+	return fmt.Sprintf("%[1]s%[2]s, err := %[3]s\n%[1]sif err != nil {\n%[1]s\treturn fmt.Errorf(\"%[4]s: %%w\", err)\n%[1]s}\n",
+		indent, parsed, parse, field.TagJSON()) + assignment, true
+}
+
+// wrap returns the Go expression of a non-nullable field of the scalar field type from the standard library value
+// expression, which is the value itself with standard library types.
+func (t Types) wrap(fieldType, expr string) string {
+	if t.Stdlib {
+		return expr
+	}
+
+	return fmt.Sprintf("%s.New%s(%s)", typesPackageName, fieldType, expr)
+}
+
+// headerAssignment returns the Go statement setting the response header to the value of the field expression,
+// skipping nil values of nullable and optional fields with standard library types.
+func (t Types) headerAssignment(header string, field specification.Field, expr string) string {
+	if !t.Stdlib || field.Type == specification.FieldTypeDecimal {
+		return setHeader("\t", header, expr+".String()")
+	}
+
+	if t.isPointer(field) {
+		return fmt.Sprintf("\tif %s != nil {\n%s\t}\n", expr, setHeader("\t\t", header, t.formatScalar(field.Type, "*"+expr)))
+	}
+
+	return setHeader("\t", header, t.formatScalar(field.Type, expr))
+}
+
+// setHeader returns the indented Go statements setting the response header to the string expression. Gin canonicalizes
+// the header names, so a header whose name is not canonical, such as X-Request-ID, is set in the header map
+// to keep its casing on the wire, and deleted when the value is empty like gin does.
+func setHeader(indent, header, value string) string {
+	if http.CanonicalHeaderKey(header) == header {
+		return fmt.Sprintf("%sc.Header(%q, %s)\n", indent, header, value)
+	}
+	return fmt.Sprintf("%[1]sif v := %[3]s; v != \"\" {\n%[1]s\tc.Writer.Header()[%[2]q] = []string{v}\n%[1]s} else {\n%[1]s\tdelete(c.Writer.Header(), %[2]q)\n%[1]s}\n",
+		indent, header, value)
+}
+
+// timeValue returns the Go statements returning the time.Time of the Timestamp field expression and whether it is set,
+// parsing the RFC 3339 string of the types package.
+func (t Types) timeValue(field specification.Field, expr string) string {
+	if !t.Stdlib {
+		return fmt.Sprintf("\tvalue, err := time.Parse(time.RFC3339, %s.String())\n\treturn value, err == nil\n", expr)
+	}
+
+	if t.isPointer(field) {
+		return fmt.Sprintf("\tif %s == nil {\n\t\treturn time.Time{}, false\n\t}\n\n\treturn *%s, true\n", expr, expr)
+	}
+
+	return fmt.Sprintf("\treturn %s, !%s.IsZero()\n", expr, expr)
+}
+
+// formatScalar returns the Go expression formatting a standard library scalar value as a string.
+func (t Types) formatScalar(fieldType, expr string) string {
+	switch fieldType {
+	case specification.FieldTypeInt:
+		return "strconv.FormatInt(" + expr + ", 10)"
+	case specification.FieldTypeInt32:
+		return "strconv.FormatInt(int64(" + expr + "), 10)"
+	case specification.FieldTypeUInt:
+		return "strconv.FormatUint(" + expr + ", 10)"
+	case specification.FieldTypeFloat64:
+		return "strconv.FormatFloat(" + expr + ", 'f', -1, 64)"
+	case specification.FieldTypeBool:
+		return "strconv.FormatBool(" + expr + ")"
+	case specification.FieldTypeUUID:
+		return expr + ".String()"
+	case specification.FieldTypeTimestamp:
+		return expr + ".Format(time.RFC3339)"
+	default:
+		return expr
+	}
+}
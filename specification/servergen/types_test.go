@@ -0,0 +1,203 @@
+package servergen
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/meitner-se/publicapis-gen/specification"
+	"github.com/stretchr/testify/assert"
+)
+
+// ============================================================================
+// Types Tests
+// ============================================================================
+
+func TestTypes_ImportPath(t *testing.T) {
+	testCases := []struct {
+		name     string
+		types    Types
+		expected string
+	}{
+		{name: "default package", types: Types{}, expected: DefaultTypesPackage},
+		{name: "custom package", types: Types{Package: "example.com/adopter/types"}, expected: "example.com/adopter/types"},
+		{name: "standard library types", types: Types{Package: "example.com/adopter/types", Stdlib: true}, expected: ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			result := tc.types.ImportPath()
+
+			// Assert
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestTypes_Literal(t *testing.T) {
+	nullable := []string{specification.ModifierNullable}
+
+	testCases := []struct {
+		name     string
+		types    Types
+		field    specification.Field
+		value    string
+		expected string
+	}{
+		{name: "go-types int", types: Types{}, field: specification.Field{Type: specification.FieldTypeInt}, value: "50", expected: "types.NewInt(50)"},
+		{name: "go-types uuid", types: Types{}, field: specification.Field{Type: specification.FieldTypeUUID}, value: "5f8b1c2e-3d4a-4b6c-9e7f-1a2b3c4d5e6f", expected: `types.NewUUID(uuid.MustParse("5f8b1c2e-3d4a-4b6c-9e7f-1a2b3c4d5e6f"))`},
+		{name: "go-types enum", types: Types{}, field: specification.Field{Type: "Status"}, value: "Active", expected: `types.NewString("Active")`},
+		{name: "stdlib int", types: Types{Stdlib: true}, field: specification.Field{Type: specification.FieldTypeInt}, value: "50", expected: "int64(50)"},
+		{name: "stdlib nullable int32", types: Types{Stdlib: true}, field: specification.Field{Type: specification.FieldTypeInt32, Modifiers: nullable}, value: "5", expected: "ptr(int32(5))"},
+		{name: "stdlib bool", types: Types{Stdlib: true}, field: specification.Field{Type: specification.FieldTypeBool}, value: "false", expected: "false"},
+		{name: "stdlib date", types: Types{Stdlib: true}, field: specification.Field{Type: specification.FieldTypeDate}, value: "2024-01-15", expected: `"2024-01-15"`},
+		{name: "stdlib timestamp", types: Types{Stdlib: true}, field: specification.Field{Type: specification.FieldTypeTimestamp}, value: "2024-01-15T10:30:00Z", expected: "time.Date(2024, time.January, 15, 10, 30, 0, 0, time.UTC)"},
+		{name: "stdlib enum", types: Types{Stdlib: true}, field: specification.Field{Type: "Status", Modifiers: nullable}, value: "Active", expected: `ptr("Active")`},
+		{name: "stdlib decimal", types: Types{Stdlib: true}, field: specification.Field{Type: specification.FieldTypeDecimal}, value: "19.99", expected: `decimal.RequireFromString("19.99")`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			result, ok := tc.types.Literal(tc.field, tc.value)
+
+			// Assert
+			assert.True(t, ok)
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+
+	t.Run("stdlib timestamp that is not RFC 3339", func(t *testing.T) {
+		// Act
+		_, ok := Types{Stdlib: true}.Literal(specification.Field{Type: specification.FieldTypeTimestamp}, "yesterday")
+
+		// Assert
+		assert.False(t, ok)
+	})
+}
+
+func TestTypes_parseParam(t *testing.T) {
+	nullable := []string{specification.ModifierNullable}
+	service := &specification.Service{
+		Enums:   []specification.Enum{{Name: "Status", Values: []specification.EnumValue{{Name: "Active"}}}},
+		Objects: []specification.Object{{Name: "Address"}},
+	}
+
+	testCases := []struct {
+		name     string
+		types    Types
+		field    specification.Field
+		expected string
+	}{
+		{name: "go-types int", types: Types{}, field: specification.Field{Name: "Limit", Type: specification.FieldTypeInt},
+			expected: "parsed, err := parseIntParam(value, 64)\nif err != nil {\n\treturn fmt.Errorf(\"limit: %w\", err)\n}\nv.Limit = types.NewInt(parsed)\n"},
+		{name: "go-types date", types: Types{}, field: specification.Field{Name: "Day", Type: specification.FieldTypeDate},
+			expected: "_, err := time.Parse(time.DateOnly, value)\nif err != nil {\n\treturn fmt.Errorf(\"day: %w\", err)\n}\nv.Day = types.NewDate(value)\n"},
+		{name: "go-types enum", types: Types{}, field: specification.Field{Name: "Status", Type: "Status"},
+			expected: "v.Status = types.NewString(value)\n"},
+		{name: "stdlib nullable int32", types: Types{Stdlib: true}, field: specification.Field{Name: "Count", Type: specification.FieldTypeInt32, Modifiers: nullable},
+			expected: "parsed, err := parseIntParam(value, 32)\nif err != nil {\n\treturn fmt.Errorf(\"count: %w\", err)\n}\nv.Count = ptr(int32(parsed))\n"},
+		{name: "stdlib nullable decimal", types: Types{Stdlib: true}, field: specification.Field{Name: "Amount", Type: specification.FieldTypeDecimal, Modifiers: nullable},
+			expected: "parsed, err := decimal.NewFromString(value)\nif err != nil {\n\treturn fmt.Errorf(\"amount: %w\", err)\n}\nv.Amount = decimal.NewNullDecimal(parsed)\n"},
+		{name: "stdlib date", types: Types{Stdlib: true}, field: specification.Field{Name: "Day", Type: specification.FieldTypeDate},
+			expected: "v.Day = value\n"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			result, ok := tc.types.parseParam(tc.field, service, "v."+tc.field.Name+" = %s", "")
+
+			// Assert
+			assert.True(t, ok)
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+
+	t.Run("object", func(t *testing.T) {
+		// Act
+		_, ok := Types{}.parseParam(specification.Field{Name: "Address", Type: "Address"}, service, "v.Address = %s", "")
+
+		// Assert
+		assert.False(t, ok)
+	})
+}
+
+func TestGetTypeForGo_StdlibTypes(t *testing.T) {
+	service := createTestService()
+	types := Types{Stdlib: true}
+
+	testCases := []struct {
+		name     string
+		field    specification.Field
+		expected string
+	}{
+		{name: "string", field: specification.Field{Type: specification.FieldTypeString}, expected: "string"},
+		{name: "nullable timestamp", field: specification.Field{Type: specification.FieldTypeTimestamp, Modifiers: []string{specification.ModifierNullable}}, expected: "*time.Time"},
+		{name: "array of uuids", field: specification.Field{Type: specification.FieldTypeUUID, Modifiers: []string{specification.ModifierArray}}, expected: "[]uuid.UUID"},
+		{name: "enum", field: specification.Field{Type: testEnumName}, expected: "string"},
+		{name: "object", field: specification.Field{Type: testObjectName}, expected: testObjectName},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			result := getTypeForGo(tc.field, service, types)
+
+			// Assert
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+
+	t.Run("scalar fields of filter objects are pointers", func(t *testing.T) {
+		// Arrange
+		filter := *service.GetObject("SchoolFilterEquals")
+
+		// Act
+		result := getTypeForGoFilter(filter.Fields[0], service, filter, types)
+
+		// Assert
+		assert.Equal(t, "*uuid.UUID", result)
+	})
+}
+
+func TestGenerateServerWithOptions_Types(t *testing.T) {
+	t.Run("standard library types", func(t *testing.T) {
+		// Arrange
+		service := createTestServiceWithEndpoints()
+		service.ResponseHeaders = []specification.Field{
+			{Name: "X-Rate-Limit", Type: specification.FieldTypeInt},
+			{Name: "X-Trace-ID", Type: specification.FieldTypeString, Modifiers: []string{specification.ModifierNullable}},
+		}
+		buf := &bytes.Buffer{}
+
+		// Act
+		err := GenerateServerWithOptions(buf, service, Options{Types: Types{Stdlib: true}})
+
+		// Assert
+		assert.Nil(t, err)
+		code := buf.String()
+		assert.NotContains(t, code, "types.")
+		assert.NotContains(t, code, DefaultTypesPackage)
+		assert.Contains(t, code, "Message:   err.Error(),")
+		assert.Contains(t, code, `c.Header("X-Rate-Limit", strconv.FormatInt(headers.XRateLimit, 10))`)
+		assert.Contains(t, code, "if headers.XTraceID != nil {")
+		assert.Contains(t, code, "func ptr[T any](v T) *T {")
+	})
+
+	t.Run("custom types package", func(t *testing.T) {
+		// Arrange
+		service := createTestServiceWithEndpoints()
+		buf := &bytes.Buffer{}
+
+		// Act
+		err := GenerateServerWithOptions(buf, service, Options{Types: Types{Package: "example.com/adopter/go-types"}})
+
+		// Assert
+		assert.Nil(t, err)
+		code := buf.String()
+		assert.Contains(t, code, `"example.com/adopter/go-types"`)
+		assert.NotContains(t, code, DefaultTypesPackage)
+		assert.Contains(t, code, "Message:   types.NewString(err.Error()),")
+	})
+}
@@ -1,13 +1,26 @@
 package specification
 
 import (
+	"bytes"
+	"encoding/binary"
 	"encoding/json"
+	"errors"
 	"fmt"
+	gotoken "go/token"
+	"hash/fnv"
 	"log/slog"
+	"maps"
+	"mime"
+	"net/url"
 	"os"
 	"path/filepath"
+	"regexp"
 	"slices"
+	"strconv"
 	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/aarondl/strmangle"
 	yaml "github.com/goccy/go-yaml"
@@ -38,18 +51,25 @@ const (
 	FieldTypeTimestamp = "Timestamp"
 	FieldTypeString    = "String"
 	FieldTypeInt       = "Int"
+	FieldTypeInt32     = "Int32"
+	FieldTypeUInt      = "UInt"
 	FieldTypeFloat64   = "Float64"
+	FieldTypeDecimal   = "Decimal"
 	FieldTypeBool      = "Bool"
 )
 
-// Default field examples for primitive types
+// Default field examples for primitive types. UUID, Date and Timestamp fields get examples derived from the name
+// of the field and of the object or resource it belongs to instead, so that the examples of different fields differ.
 const (
 	defaultExampleUUID      = "123e4567-e89b-12d3-a456-426614174000"
 	defaultExampleDate      = "2024-01-15"
 	defaultExampleTimestamp = "2024-01-15T10:30:00Z"
 	defaultExampleString    = "example"
 	defaultExampleInt       = "42"
+	defaultExampleInt32     = "42"
+	defaultExampleUInt      = "42"
 	defaultExampleFloat64   = "3.14"
+	defaultExampleDecimal   = "19.99"
 	defaultExampleBool      = "true"
 )
 
@@ -58,6 +78,134 @@ const (
 	ModifierNullable = "Nullable"
 	ModifierArray    = "Array"
 	ModifierRequired = "Required"
+
+	// ModifierOptional is set by the overlay on body params that are optional for the operation of the endpoint,
+	// see ResourceField.RequiredOn
+	ModifierOptional = "Optional"
+)
+
+// Security Scheme Types
+const (
+	SecuritySchemeTypeHTTP          = "http"
+	SecuritySchemeTypeAPIKey        = "apiKey"
+	SecuritySchemeTypeOAuth2        = "oauth2"
+	SecuritySchemeTypeOpenIDConnect = "openIdConnect"
+	SecuritySchemeTypeMutualTLS     = "mutualTLS"
+)
+
+// Enum Types, the types of the wire values of enums
+const (
+	EnumTypeString = "string"
+	EnumTypeInt    = "int"
+)
+
+// Path Cases of the names in paths
+const (
+	PathCaseKebab = "kebab-case"
+	PathCaseSnake = "snake_case"
+)
+
+// JSON Cases of the names of the fields in JSON
+const (
+	JSONCaseCamel = "camelCase"
+	JSONCaseSnake = "snake_case"
+	JSONCaseAsIs  = "as-is"
+)
+
+// Type Collisions strategies for the generated types of endpoints that have the name of another type
+const (
+	TypeCollisionsError  = "error"
+	TypeCollisionsRename = "rename"
+)
+
+// Tenancy Locations
+const (
+	TenancyInHeader = "header"
+	TenancyInPath   = "path"
+)
+
+// Stabilities of the endpoints, from the least to the most stable
+const (
+	StabilityExperimental = "experimental"
+	StabilityBeta         = "beta"
+	StabilityStable       = "stable"
+)
+
+// Error Formats
+const (
+	// ErrorFormatProblemJSON writes the errors as Problem Details (RFC 9457) with the application/problem+json content type
+	ErrorFormatProblemJSON = "problem+json"
+)
+
+// Request ID
+const (
+	// RequestIDHeaderName is the header the request ID is read from, and written to on every response
+	RequestIDHeaderName = "X-Request-Id"
+)
+
+// Conditional GET, see Endpoint.IsConditionalGet
+const (
+	// LastModifiedHeaderName is the header the time the response was last updated is written to
+	LastModifiedHeaderName = "Last-Modified"
+
+	// IfModifiedSinceHeaderName is the request header answered with 304 Not Modified when the response is not newer
+	IfModifiedSinceHeaderName = "If-Modified-Since"
+)
+
+// Async endpoints, see Endpoint.Async
+const (
+	// OperationObjectName is the object the asynchronous endpoints respond with, polled until it is completed
+	OperationObjectName = "Operation"
+
+	// OperationRetryAfterFieldName is the field of the Operation written to the Retry-After header
+	OperationRetryAfterFieldName = "RetryAfter"
+
+	// OperationStatusSucceeded and OperationStatusFailed are the statuses of the completed operations
+	OperationStatusSucceeded = "Succeeded"
+	OperationStatusFailed    = "Failed"
+
+	// LocationHeaderName is the header the path the operation of an asynchronous endpoint is polled at is written to
+	LocationHeaderName = "Location"
+
+	// RetryAfterHeaderName is the header the seconds to wait before polling the operation again are written to
+	RetryAfterHeaderName = "Retry-After"
+)
+
+// Total counts, see Resource.TotalCount
+const (
+	// TotalCountPagination returns the total number of items of List and Search in their Pagination object
+	TotalCountPagination = "pagination"
+
+	// TotalCountHeader returns the total number of items of List and Search in the X-Total-Count header,
+	// with the PaginationWithoutTotal object in their bodies
+	TotalCountHeader = "header"
+
+	// TotalCountHeaderName is the header the total number of items is written to with TotalCountHeader
+	TotalCountHeaderName = "X-Total-Count"
+
+	// PaginationWithoutTotalObjectName is the pagination object of List and Search with TotalCountHeader
+	PaginationWithoutTotalObjectName = "PaginationWithoutTotal"
+)
+
+// Pagination, see Service.MaxLimit
+const (
+	// PaginationObjectName is the pagination object of List and Search
+	PaginationObjectName = paginationObjectName
+
+	// DefaultLimit is the limit of List and Search without a limit in the request
+	DefaultLimit = 50
+)
+
+// Operational endpoint paths
+const (
+	OperationalPathHealth    = "/healthz"
+	OperationalPathReadiness = "/readyz"
+	OperationalPathVersion   = "/version"
+)
+
+// Default tenancy values
+const (
+	defaultTenancyDescription = "Identifier of the tenant the request is scoped to"
 )
 
 // Retry Strategies
@@ -97,9 +245,12 @@ const (
 	filterFieldNotContains    = "NotContains"
 	filterFieldLike           = "Like"
 	filterFieldNotLike        = "NotLike"
+	filterFieldStartsWith     = "StartsWith"
+	filterFieldNotStartsWith  = "NotStartsWith"
 	filterFieldNull           = "Null"
 	filterFieldNotNull        = "NotNull"
 	filterFieldOrCondition    = "OrCondition"
+	filterFieldNotCondition   = "NotCondition"
 	filterFieldNestedFilters  = "NestedFilters"
 )
 
@@ -116,11 +267,15 @@ const (
 	descriptionNotContainsFilters             = "Not contains filters for "
 	descriptionLikeFilters                    = "LIKE filters for "
 	descriptionNotLikeFilters                 = "NOT LIKE filters for "
+	descriptionStartsWithFilters              = "Prefix filters for "
+	descriptionNotStartsWithFilters           = "Negated prefix filters for "
 	descriptionNullFilters                    = "Null filters for "
 	descriptionNotNullFilters                 = "Not null filters for "
 	descriptionOrCondition                    = "OrCondition decides if this filter is within an OR-condition or AND-condition"
+	descriptionNotCondition                   = "NotCondition negates this filter, including its nested filters"
 	descriptionNestedFiltersTemplate          = "NestedFilters of the "
 	descriptionNestedFiltersSuffix            = ", useful for more complex filters"
+	descriptionNestedFiltersMaxDepthTemplate  = ", nested at most %d levels deep"
 	descriptionEqualityInequalityFilterFields = "Equality/Inequality filter fields for "
 	descriptionRangeFilterFields              = "Range filter fields for "
 	descriptionContainsFilterFields           = "Contains filter fields for "
@@ -138,6 +293,8 @@ const (
 	errorCodeUnprocessableEntity = "UnprocessableEntity"
 	errorCodeRateLimited         = "RateLimited"
 	errorCodeInternal            = "Internal"
+	errorCodeRequestTimeout      = "RequestTimeout"
+	errorCodePayloadTooLarge     = "PayloadTooLarge"
 )
 
 // Error Code Descriptions
@@ -151,6 +308,8 @@ const (
 	descriptionErrorCodeUnprocessableEntity = "The request was well-formed but failed validation (e.g. invalid field format or constraints), 422 status code"
 	descriptionErrorCodeRateLimited         = "When the rate limit has been exceeded, 429 status code"
 	descriptionErrorCodeInternal            = "Some serverside issue, 5xx status code"
+	descriptionErrorCodeRequestTimeout      = "The handler did not complete within the timeout of the endpoint, 408 status code"
+	descriptionErrorCodePayloadTooLarge     = "The request body exceeds the size limit of the endpoint, 413 status code"
 )
 
 // Error object constants
@@ -163,9 +322,38 @@ const (
 	errorMessageFieldDescription   = "Human-readable error message providing additional details"
 	errorRequestIDFieldName        = "RequestID"
 	errorRequestIDFieldDescription = "Unique identifier for the request that generated this error, used for logging and debugging"
+	errorFieldsFieldName           = "Fields"
+	errorFieldsFieldDescription    = "Validation errors of the individual fields of the request, empty when the error is not about specific fields"
 	errorCodeEnumName              = "ErrorCode"
 )
 
+// Problem Details error object constants, the members of RFC 9457 used instead of Message with ErrorFormatProblemJSON
+const (
+	problemObjectDescription        = "Problem Details (RFC 9457) error response object, extended with the error code, request ID and field errors"
+	problemTypeFieldName            = "Type"
+	problemTypeFieldDescription     = "URI reference identifying the problem type, about:blank when the problem has no semantics beyond the status code"
+	problemTitleFieldName           = "Title"
+	problemTitleFieldDescription    = "Short human-readable summary of the problem type, the reason phrase of the status code"
+	problemStatusFieldName          = "Status"
+	problemStatusFieldDescription   = "HTTP status code of the response"
+	problemDetailFieldName          = "Detail"
+	problemDetailFieldDescription   = "Human-readable explanation specific to this occurrence of the problem"
+	problemInstanceFieldName        = "Instance"
+	problemInstanceFieldDescription = "URI reference identifying this occurrence of the problem, the path of the request"
+)
+
+// Error field object constants
+const (
+	errorFieldObjectName              = "ErrorField"
+	errorFieldObjectDescription       = "Validation error of a single field of the request"
+	errorFieldPathFieldName           = "Path"
+	errorFieldPathFieldDescription    = "Path of the invalid field in the request, with dots between nested fields and array indexes, e.g. address.street or items.0.name"
+	errorFieldCodeFieldName           = "Code"
+	errorFieldCodeFieldDescription    = "Machine-readable code of the validation rule the field failed, e.g. required or invalid_format"
+	errorFieldMessageFieldName        = "Message"
+	errorFieldMessageFieldDescription = "Human-readable message describing why the field is invalid"
+)
+
 // Pagination object constants
 const (
 	paginationObjectName        = "Pagination"
@@ -176,6 +364,10 @@ const (
 	limitFieldDescription       = "Maximum number of items to return in the result set"
 	totalFieldName              = "Total"
 	totalFieldDescription       = "Total number of items available for pagination"
+	totalCountFieldName         = "TotalCount"
+
+	paginationWithoutTotalObjectDescription = "Pagination parameters for controlling result sets in list operations, " +
+		"whose total number of items is returned in the X-Total-Count header"
 )
 
 // Auto-column constants
@@ -212,6 +404,18 @@ const (
 	contentTypeJSON = "application/json"
 )
 
+// ContentTypeCSV is the content type of the responses streamed as CSV, with a row per body object,
+// see Endpoint.HasCSVResponse
+const ContentTypeCSV = "text/csv"
+
+// ContentTypeXML is the content type of the requests and responses of the endpoints serialized as XML instead of
+// JSON, see Endpoint.HasXMLRequest and Endpoint.HasXMLResponse
+const ContentTypeXML = "application/xml"
+
+// ContentTypeForm is the content type of the requests of the endpoints whose body params are sent as an HTML form
+// instead of JSON, see Endpoint.HasFormRequest
+const ContentTypeForm = "application/x-www-form-urlencoded"
+
 // Create Endpoint Constants
 const (
 	createEndpointName          = "Create"
@@ -243,6 +447,99 @@ const (
 	deleteIDParamDescTemplate   = "The unique identifier of the %s to delete"
 )
 
+// Restore Endpoint Constants, generated for resources with SoftDelete
+const (
+	restoreEndpointName          = "Restore"
+	restoreEndpointPath          = "/{id}/restore"
+	restoreEndpointSummaryPrefix = "Restore a "
+	restoreEndpointDescTemplate  = "Restores the soft deleted `%s` with the given ID."
+	restoreIDParamName           = "ID"
+	restoreIDParamDescTemplate   = "The unique identifier of the %s to restore"
+)
+
+// Soft delete constants
+const (
+	softDeleteEndpointDescTemplate  = "Soft deletes the `%s` with the given ID. It is left out of List and Search unless includeDeleted is set, and can be restored with Restore."
+	includeDeletedParamName         = "IncludeDeleted"
+	includeDeletedParamDescTemplate = "Whether to include the soft deleted %s (default: false)"
+	includeDeletedDefaultValue      = "false"
+	softDeleteColumnName            = "DeletedAt"
+	softDeleteColumnTemplate        = "Timestamp when the %s was soft deleted, null unless it is deleted"
+)
+
+// Aggregate Endpoint Constants, generated for resources with Aggregations
+const (
+	aggregateEndpointName          = "Aggregate"
+	aggregateEndpointPath          = "/_aggregate"
+	aggregateEndpointSummaryPrefix = "Aggregate "
+	aggregateEndpointDescTemplate  = "Counts the `%s`, with the sums and counts of the fields of its aggregations."
+	aggregateResponseStatusCode    = 200
+	aggregateResponseDescTemplate  = "Aggregation of the %s"
+	aggregationObjectSuffix        = "Aggregation"
+	aggregationObjectDescTemplate  = "Aggregation of the %s"
+	aggregationCountFieldName      = "Count"
+	aggregationCountFieldDesc      = "Number of the %s"
+	aggregationSumFieldPrefix      = "Sum"
+	aggregationSumFieldDesc        = "Sum of the %s of the %s"
+	aggregationCountByFieldPrefix  = "CountBy"
+	aggregationCountByFieldDesc    = "Number of the %s per %s"
+	aggregationGroupObjectSuffix   = "Count"
+	aggregationGroupObjectDesc     = "Number of the %s with a %s"
+	aggregationGroupValueFieldName = "Value"
+	aggregationGroupValueFieldDesc = "Value of the %s"
+	aggregationGroupCountFieldDesc = "Number of the %s with the value"
+)
+
+// Async Endpoint Constants, the Operation the asynchronous endpoints respond with and the resource it is polled from
+const (
+	asyncResponseStatusCode         = 202
+	asyncResponseDescription        = "The operation was started, poll it until it is completed"
+	operationResourceName           = "Operation"
+	operationResourceDescription    = "Operations started by the asynchronous endpoints"
+	operationEndpointSummary        = "Get an Operation"
+	operationEndpointDescription    = "Retrieves the `Operation` with the given ID, to poll it until it is completed. Wait for the seconds of the Retry-After header before polling it again."
+	operationResponseDescription    = "The operation with the given ID"
+	operationObjectDescription      = "Operation started by an asynchronous endpoint, polled until it is completed"
+	operationStatusEnumName         = "OperationStatus"
+	operationStatusEnumDescription  = "Status of an operation started by an asynchronous endpoint"
+	operationStatusPending          = "Pending"
+	operationStatusRunning          = "Running"
+	operationIDFieldDescription     = "Unique identifier of the operation"
+	operationStatusFieldName        = "Status"
+	operationStatusFieldDescription = "Status of the operation"
+	operationRetryAfterDescription  = "Seconds to wait before polling the operation again, null once it is completed"
+	operationErrorFieldDescription  = "Error the operation failed with, null unless it failed"
+	operationCreatedAtFieldName     = "CreatedAt"
+	operationCreatedAtDescription   = "Timestamp when the operation was started"
+	operationCompletedAtFieldName   = "CompletedAt"
+	operationCompletedAtDescription = "Timestamp when the operation was completed, null until it succeeds or fails"
+)
+
+// Export Endpoint Constants, generated for resources with Exportable
+const (
+	exportEndpointName          = "Export"
+	exportEndpointPath          = "/_export"
+	exportEndpointSummaryPrefix = "Export "
+	exportEndpointDescTemplate  = "Streams all `%s` as CSV, with a header row and a column per field."
+	exportResponseStatusCode    = 200
+	exportResponseDescTemplate  = "CSV file of the %s"
+)
+
+// Expansion constants, the query parameter and the enum of its values of resources with Expansions
+const (
+	expandParamName            = "Expand"
+	expandParamDesc            = "The related resources to include in the response"
+	expansionEnumSuffix        = "Expansion"
+	expansionEnumDescTemplate  = "Related resources that can be included in the %s"
+	expansionFieldDescTemplate = "The %s referenced by %s, only included when it is expanded"
+)
+
+// Sub-resource constants, the path parameters of the parents of resources with a Parent
+const (
+	parentIDParamSuffix       = "ID"
+	parentIDParamDescTemplate = "The unique identifier of the parent %s"
+)
+
 // Get Endpoint Constants
 const (
 	getEndpointName          = "Get"
@@ -265,6 +562,7 @@ const (
 	listLimitParamDescTemplate  = "The maximum number of %s to return (default: 50) when listing %s"
 	listLimitDefaultValue       = "50"
 	listLimitExampleValue       = "1"
+	maxLimitParamDescTemplate   = ", at most %d"
 	listOffsetParamName         = "Offset"
 	listOffsetParamDesc         = "The number of items to skip before starting to return results (default: 0)"
 	listOffsetParamDescTemplate = "The number of %s to skip before starting to return results (default: 0) when listing %s"
@@ -281,18 +579,31 @@ const (
 	searchResponseStatusCode      = 200
 	searchFilterParamName         = "Filter"
 	searchFilterParamDesc         = "Filter criteria to search for specific records"
+	searchQueryParamName          = "Query"
+	searchQueryParamDescTemplate  = "Text to search for in the %s, matching the fields %s"
 	searchLimitParamDescTemplate  = "The maximum number of %s to return (default: 50) when searching %s"
 	searchOffsetParamDescTemplate = "The number of %s to skip before starting to return results (default: 0) when searching %s"
 )
 
 // Response Description Constants
 const (
-	createResponseDescTemplate = "Successfully created the %s"
-	updateResponseDescTemplate = "Successfully updated the %s"
-	deleteResponseDescTemplate = "Successfully deleted the %s"
-	getResponseDescTemplate    = "Successfully retrieved the %s"
-	listResponseDescTemplate   = "Successfully retrieved the list of %s"
-	searchResponseDescTemplate = "Successfully searched for %s"
+	createResponseDescTemplate  = "Successfully created the %s"
+	updateResponseDescTemplate  = "Successfully updated the %s"
+	deleteResponseDescTemplate  = "Successfully deleted the %s"
+	restoreResponseDescTemplate = "Successfully restored the %s"
+	getResponseDescTemplate     = "Successfully retrieved the %s"
+	listResponseDescTemplate    = "Successfully retrieved the list of %s"
+	searchResponseDescTemplate  = "Successfully searched for %s"
+)
+
+// Parameter group constants
+const (
+	parameterGroupSuffix = "ParameterGroup"
+)
+
+// Go identifier constants
+const (
+	goIdentifierPrefix = "X"
 )
 
 // Comment formatting constants
@@ -311,11 +622,49 @@ const (
 	errorFileParse         = "failed to parse file"
 
 	// Validation error constants
-	errorInvalidOperation = "invalid operation"
-	errorInvalidFieldType = "invalid field type"
-	errorInvalidModifier  = "invalid modifier"
-	errorValidationFailed = "validation failed"
-	errorYAMLParsing      = "YAML parsing failed"
+	errorInvalidOperation  = "invalid operation"
+	errorDuplicateEndpoint = "duplicate endpoint"
+	errorNameCollision     = "name collision"
+	errorInvalidTypeName   = "invalid type name"
+	errorInvalidFieldType  = "invalid field type"
+	errorInvalidModifier   = "invalid modifier"
+	errorInvalidSecurity   = "invalid security"
+	errorUndefinedScope    = "undefined scope"
+	errorInvalidPermission = "invalid permission"
+	errorInvalidLimit      = "invalid limit"
+	errorInvalidTenancy    = "invalid tenancy"
+	errorInvalidFormat     = "invalid error format"
+	errorInvalidCatalog    = "invalid message catalog"
+	errorInvalidMediaType  = "invalid content type"
+	errorPathConflict      = "path conflict"
+	errorInvalidExtends    = "invalid extends"
+	errorInvalidGroup      = "invalid parameter group"
+	errorInvalidDefault    = "invalid default"
+	errorInvalidExample    = "invalid example"
+	errorInvalidSDKName    = "invalid SDK name"
+	errorInvalidSchema     = "invalid schema version"
+	errorInvalidParent     = "invalid parent"
+	errorInvalidBasePath   = "invalid base path"
+	errorInvalidNaming     = "invalid naming"
+	errorInvalidEnum       = "invalid enum"
+	errorInvalidDocs       = "invalid external docs"
+	errorInvalidDescFile   = "invalid description file"
+	errorInvalidTerms      = "invalid terms of service"
+	errorInvalidStability  = "invalid stability"
+	errorInvalidTagGroup   = "invalid tag group"
+	errorInvalidShared     = "invalid shared library"
+	errorInvalidReference  = "invalid reference"
+	errorInvalidExpansion  = "invalid expansion"
+	errorInvalidSearchText = "invalid searchable text"
+	errorInvalidAggregate  = "invalid aggregation"
+	errorInvalidAsync      = "invalid async endpoint"
+	errorInvalidCallback   = "invalid callback"
+	errorInvalidFeature    = "invalid feature flag"
+	errorInvalidServer     = "invalid server"
+	errorInvalidHeader     = "invalid header"
+	errorInvalidTotalCount = "invalid total count"
+	errorValidationFailed  = "validation failed"
+	errorYAMLParsing       = "YAML parsing failed"
 )
 
 // File extension constants
@@ -335,6 +684,81 @@ type ServiceServer struct {
 
 	// ID is a unique identifier for the server for SDK generation
 	ID string `json:"id,omitempty"`
+
+	// Variables are substituted for their {name} placeholders in the URL, e.g. https://{region}.api.example.com
+	Variables []ServerVariable `json:"variables,omitempty"`
+}
+
+// ServerVariable is a variable of the URL of a server, such as the region or the environment of the API.
+type ServerVariable struct {
+	// Name of the variable, used as the {name} placeholder in the URL of the server
+	Name string `json:"name"`
+
+	// Description of the variable
+	Description string `json:"description,omitempty"`
+
+	// Default is the value of the variable unless another one is given (required)
+	Default string `json:"default"`
+
+	// Enum are the values the variable is limited to, if any
+	Enum []string `json:"enum,omitempty"`
+}
+
+// GetVariable returns the variable of the server with the name, or nil if it has none.
+func (s ServiceServer) GetVariable(name string) *ServerVariable {
+	for i := range s.Variables {
+		if s.Variables[i].Name == name {
+			return &s.Variables[i]
+		}
+	}
+	return nil
+}
+
+// GetURL returns the URL of the server with the values substituted for the placeholders of its variables,
+// using the default of the variables without a value.
+func (s ServiceServer) GetURL(values map[string]string) string {
+	return serverVariablePattern.ReplaceAllStringFunc(s.URL, func(placeholder string) string {
+		name := strings.Trim(placeholder, "{}")
+		if value, ok := values[name]; ok {
+			return value
+		}
+		if variable := s.GetVariable(name); variable != nil {
+			return variable.Default
+		}
+		return placeholder
+	})
+}
+
+// GetDefaultURL returns the URL of the server with the defaults of its variables, see GetURL.
+func (s ServiceServer) GetDefaultURL() string {
+	return s.GetURL(nil)
+}
+
+// GetEnumeratedURLs returns the URLs of the server for every combination of the enum values of its variables,
+// in order, or nil if a variable is not limited to an enum and the URLs cannot all be known.
+func (s ServiceServer) GetEnumeratedURLs() []string {
+	combinations := []map[string]string{{}}
+	for _, variable := range s.Variables {
+		if len(variable.Enum) == 0 {
+			return nil
+		}
+
+		var next []map[string]string
+		for _, combination := range combinations {
+			for _, value := range variable.Enum {
+				values := maps.Clone(combination)
+				values[variable.Name] = value
+				next = append(next, values)
+			}
+		}
+		combinations = next
+	}
+
+	urls := make([]string, len(combinations))
+	for i, values := range combinations {
+		urls[i] = s.GetURL(values)
+	}
+	return urls
 }
 
 // ServiceContact represents the contact information for the API service.
@@ -361,6 +785,15 @@ type ServiceLicense struct {
 	Identifier string `json:"identifier,omitempty"`
 }
 
+// ExternalDocs links documentation hosted elsewhere, such as guides, from the service, a resource or an endpoint.
+type ExternalDocs struct {
+	// URL of the documentation (required)
+	URL string `json:"url"`
+
+	// Description of the documentation (can be in markdown format)
+	Description string `json:"description,omitempty"`
+}
+
 // OAuth2Flow represents a single OAuth2 authorization flow definition.
 type OAuth2Flow struct {
 	// TokenURL is the token endpoint URL (required for clientCredentials flow)
@@ -400,6 +833,9 @@ type SecurityScheme struct {
 	Name         string       `json:"name,omitempty"`
 	In           string       `json:"in,omitempty"`
 	Flows        *OAuth2Flows `json:"flows,omitempty"`
+
+	// OpenIDConnectURL is the OpenID Connect discovery URL (required for openIdConnect schemes)
+	OpenIDConnectURL string `json:"openIdConnectUrl,omitempty"`
 }
 
 // SecurityRequirement represents scheme names that must be satisfied together.
@@ -441,6 +877,72 @@ type TimeoutConfiguration struct {
 	Timeout int `json:"timeout"`
 }
 
+// Tenancy defines how the tenant of a request is identified.
+type Tenancy struct {
+	// In is the location of the tenant identifier ("header" or "path")
+	In string `json:"in"`
+
+	// Name is the header name or path parameter name of the tenant identifier
+	Name string `json:"name"`
+
+	// Description of the tenant identifier
+	Description string `json:"description,omitempty"`
+}
+
+// Localization defines the localized messages of the error codes, the message catalog of the service.
+type Localization struct {
+	// DefaultLocale is the locale used when the request accepts none of the locales of the messages
+	DefaultLocale string `json:"defaultLocale"`
+
+	// Messages of the error codes, keyed by error code and then by locale, for example NotFound: {en: ..., sv: ...}
+	Messages map[string]map[string]string `json:"messages"`
+}
+
+// OperationalEndpoints defines which operational endpoints are generated for the service.
+type OperationalEndpoints struct {
+	// Health generates the /healthz liveness endpoint
+	Health bool `json:"health,omitempty"`
+
+	// Readiness generates the /readyz readiness endpoint
+	Readiness bool `json:"readiness,omitempty"`
+
+	// Version generates the /version endpoint returning the service name and version
+	Version bool `json:"version,omitempty"`
+
+	// ExcludeFromSDK marks the operational endpoints to be ignored by SDK generators
+	ExcludeFromSDK bool `json:"excludeFromSdk,omitempty"`
+}
+
+// Naming defines the conventions of the paths and the generated types derived from the names of the service
+// and its resources.
+type Naming struct {
+	// PathCase of the names in paths, "kebab-case" (default) or "snake_case"
+	PathCase string `json:"path_case,omitempty"`
+
+	// PluralPaths pluralizes the resource names in paths, e.g. /users/{id} for the User resource
+	PluralPaths bool `json:"plural_paths,omitempty"`
+
+	// TypeCollisions is what happens when a generated request or response type of an endpoint has the name of
+	// another type, "error" (default) fails the validation and "rename" sets the type name of the endpoint
+	TypeCollisions string `json:"type_collisions,omitempty"`
+
+	// JSON is the case of the names of the fields of objects, resources and request and response bodies in JSON,
+	// "camelCase" (default), "snake_case" or "as-is" for the names as they are written
+	JSON string `json:"json,omitempty"`
+}
+
+// ParameterGroup is a named set of query parameters that can be reused by endpoints.
+type ParameterGroup struct {
+	// Name of the parameter group, should be unique in the service
+	Name string `json:"name"`
+
+	// Description about the parameter group
+	Description string `json:"description,omitempty"`
+
+	// Fields are the query parameters of the group
+	Fields []Field `json:"fields"`
+}
+
 // Service is the definition of an API service.
 type Service struct {
 	// Name of the service
@@ -449,15 +951,34 @@ type Service struct {
 	// Version of the service
 	Version string `json:"version,omitempty"`
 
+	// SchemaVersion is the version of the specification schema the service is written in
+	SchemaVersion int `json:"schema_version,omitempty"`
+
 	// Contact information for the service
 	Contact *ServiceContact `json:"contact,omitempty"`
 
 	// License information for the service
 	License *ServiceLicense `json:"license,omitempty"`
 
+	// ExternalDocs links documentation of the service hosted elsewhere
+	ExternalDocs *ExternalDocs `json:"external_docs,omitempty"`
+
+	// TermsOfService is the URL of the terms of service of the API
+	TermsOfService string `json:"terms_of_service,omitempty"`
+
 	// Servers that are part of the service
 	Servers []ServiceServer `json:"servers,omitempty"`
 
+	// BasePath prefixes all routes of the service, e.g. /api/v1, or / for no prefix.
+	// Defaults to /{service name in kebab-case}/{version}
+	BasePath string `json:"base_path,omitempty"`
+
+	// Naming conventions of the paths, defaults to kebab-case names as-is
+	Naming *Naming `json:"naming,omitempty"`
+
+	// Strict makes the objects and request bodies reject unknown fields, unless an object sets strict itself
+	Strict bool `json:"strict,omitempty"`
+
 	// SecuritySchemes defines available security schemes
 	SecuritySchemes map[string]SecurityScheme `json:"securitySchemes,omitempty"`
 
@@ -470,9 +991,42 @@ type Service struct {
 	// Timeout configuration for the service
 	Timeout *TimeoutConfiguration `json:"timeout,omitempty"`
 
+	// MaxBodyBytes limits the size of the request bodies in bytes, unless an endpoint sets its own limit
+	MaxBodyBytes int64 `json:"max_body_bytes,omitempty"`
+
+	// HandlerTimeout limits the time of the handlers in milliseconds, unless an endpoint sets its own timeout
+	HandlerTimeout int `json:"handler_timeout,omitempty"`
+
+	// FilterMaxDepth limits how many levels deep the NestedFilters of the search filters can be nested, 0 for no limit
+	FilterMaxDepth int `json:"filter_max_depth,omitempty"`
+
+	// MaxLimit is the maximum limit of the List and Search endpoints, which the pagination helpers of the generated
+	// server clamp larger limits to, 0 for no maximum
+	MaxLimit int `json:"max_limit,omitempty"`
+
+	// Tenancy configuration for the service, scopes every endpoint to a tenant
+	Tenancy *Tenancy `json:"tenancy,omitempty"`
+
+	// Operational endpoints (health, readiness and version) generated for the service
+	Operational *OperationalEndpoints `json:"operational,omitempty"`
+
+	// ErrorFormat of the error responses, empty for the default format with the Error object wrapped in an
+	// error member, or ErrorFormatProblemJSON for Problem Details
+	ErrorFormat string `json:"error_format,omitempty"`
+
+	// Localization of the error messages, resolved from the Accept-Language header of the request
+	Localization *Localization `json:"localization,omitempty"`
+
 	// ResponseHeaders are common headers returned by all endpoints
 	ResponseHeaders []Field `json:"responseHeaders,omitempty"`
 
+	// ParameterGroups are reusable sets of query parameters referenced by endpoints
+	ParameterGroups []ParameterGroup `json:"parameterGroups,omitempty"`
+
+	// Shared are the files of the shared libraries whose enums and objects the service uses by name, relative to
+	// the specification file. Parsing merges their types into Enums and Objects and clears Shared
+	Shared []string `json:"shared,omitempty"`
+
 	// Enums that are used in the service
 	Enums []Enum `json:"enums"`
 
@@ -491,10 +1045,20 @@ type Enum struct {
 	// Description of the enum
 	Description string `json:"description"`
 
+	// DescriptionFile is a markdown file the description is loaded from, relative to the specification file
+	DescriptionFile string `json:"description_file,omitempty"`
+
 	// Development indicates this enum is not ready for public use and should
 	// be excluded from the generated OpenAPI output.
 	Development bool `json:"development,omitempty" yaml:"development,omitempty"`
 
+	// EnumType is the type of the wire values, "string" (default) or "int" for enums backed by integers,
+	// whose values must all set an integer value
+	EnumType string `json:"enum_type,omitempty"`
+
+	// Library is the name of the shared library the enum comes from, set when the shared libraries are merged
+	Library string `json:"library,omitempty"`
+
 	// Values that are possible for the enum
 	Values []EnumValue `json:"values"`
 }
@@ -506,6 +1070,12 @@ type EnumValue struct {
 
 	// Description for the enum value
 	Description string `json:"description"`
+
+	// Value is the wire value of the enum value in requests and responses, defaults to the name
+	Value string `json:"value,omitempty"`
+
+	// Aliases are deprecated wire values that are still accepted for the enum value, e.g. previous values
+	Aliases []string `json:"aliases,omitempty" jsonschema:"uniqueItems=true"`
 }
 
 // Object is a shared object within the service,
@@ -517,14 +1087,40 @@ type Object struct {
 	// Description about the object
 	Description string `json:"description"`
 
+	// DescriptionFile is a markdown file the description is loaded from, relative to the specification file
+	DescriptionFile string `json:"description_file,omitempty"`
+
 	// Development indicates this object is not ready for public use and should
 	// be excluded from the generated OpenAPI output.
 	Development bool `json:"development,omitempty" yaml:"development,omitempty"`
 
+	// Extends is the name of an object whose fields are inherited by this object.
+	// The overlay flattens the inherited fields into Fields, before the fields of the object itself.
+	Extends string `json:"extends,omitempty"`
+
+	// Strict overrides the strict setting of the service for the object, rejecting unknown fields when true
+	Strict *bool `json:"strict,omitempty"`
+
+	// Library is the name of the shared library the object comes from, set when the shared libraries are merged
+	Library string `json:"library,omitempty"`
+
 	// Fields in the object
 	Fields []Field `json:"fields"`
 }
 
+// SharedLibrary is a file of enums and objects shared by several services, which list it in Service.Shared and
+// reference its types by name as if they declared them. A library cannot reference the types of another library.
+type SharedLibrary struct {
+	// Name of the library, unique among the libraries of a service
+	Name string `json:"name"`
+
+	// Enums of the library
+	Enums []Enum `json:"enums"`
+
+	// Objects of the library
+	Objects []Object `json:"objects"`
+}
+
 // Resource represents a resource in the API with its operations and fields.
 type Resource struct {
 	// Name of the resource, should be unique within the service
@@ -533,6 +1129,9 @@ type Resource struct {
 	// Description about the resource
 	Description string `json:"description"`
 
+	// DescriptionFile is a markdown file the description is loaded from, relative to the specification file
+	DescriptionFile string `json:"description_file,omitempty"`
+
 	// Development indicates this resource is not ready for public use.
 	// Resources with Development set to true are excluded from the generated OpenAPI output,
 	// allowing resources to be defined and implemented without being surfaced to third parties
@@ -551,6 +1150,96 @@ type Resource struct {
 
 	// SkipAutoColumns indicates whether to skip generating auto columns (ID, CreatedAt, etc.) for this resource
 	SkipAutoColumns bool `json:"skip_auto_columns,omitempty"`
+
+	// Scopes are the OAuth2 / OpenID Connect scopes required for all endpoints of the resource,
+	// unless an endpoint defines its own scopes
+	Scopes []string `json:"scopes,omitempty"`
+
+	// Permissions are the application permissions required for all endpoints of the resource,
+	// unless an endpoint defines its own permissions. They are documented with the x-permissions extension
+	// and passed to the authorization hook of the generated server, which enforces them.
+	Permissions []string `json:"permissions,omitempty"`
+
+	// Examples are named, full-entity examples of the resource, used as the examples of the
+	// request and response bodies instead of the examples synthesized from the fields
+	Examples []ResourceExample `json:"examples,omitempty"`
+
+	// SDKGroup is the name of the group of the resource methods in generated SDKs,
+	// defaults to the plural resource name in camelCase
+	SDKGroup string `json:"sdk_group,omitempty"`
+
+	// SoftDelete makes Delete a soft delete: the overlay adds a DeletedAt field to the object of the resource,
+	// a Restore endpoint, and an IncludeDeleted query parameter to List and Search. Requires the Delete operation.
+	SoftDelete bool `json:"soft_delete,omitempty"`
+
+	// Exportable makes the overlay add an Export endpoint, GET /_export, streaming the List results as CSV with a
+	// column per field of the object of the resource, see Service.GetCSVColumns. Requires the List operation.
+	Exportable bool `json:"exportable,omitempty"`
+
+	// TotalCount is where List and Search return the total number of items, pagination (default) in the
+	// Pagination object, or header in the X-Total-Count header. Requires the List or Search operation.
+	TotalCount string `json:"total_count,omitempty"`
+
+	// Parent is the name of the resource this resource is nested under, the paths of its endpoints are prefixed
+	// with the path of the parent and its ID, e.g. /post/{postID}/comment, and every endpoint gets the ID of
+	// the parent (and of its parents) as path parameter
+	Parent string `json:"parent,omitempty"`
+
+	// ExternalDocs links documentation of the resource hosted elsewhere
+	ExternalDocs *ExternalDocs `json:"external_docs,omitempty"`
+
+	// Stability of all endpoints of the resource, experimental, beta or stable,
+	// unless an endpoint sets its own stability
+	Stability string `json:"stability,omitempty"`
+
+	// TagGroup is the section the tag of the resource is grouped under in documentation portals,
+	// defaults to the tag group of the parent of a sub-resource
+	TagGroup string `json:"tag_group,omitempty"`
+
+	// Expansions are the related resources that can be included in the responses of Get, List and Search. The
+	// overlay adds an Expand query parameter to these endpoints and a field per expansion to the object of the
+	// resource, which is only set when the client asks for it.
+	Expansions []Expansion `json:"expansions,omitempty"`
+
+	// Aggregations makes the overlay add an Aggregate endpoint, GET /_aggregate, responding with the number of
+	// records of the resource, the sums of numeric fields and the number of records per value of other fields
+	Aggregations *Aggregations `json:"aggregations,omitempty"`
+}
+
+// Aggregations are the fields the Aggregate endpoint of a resource aggregates, see Resource.Aggregations.
+type Aggregations struct {
+	// CountBy are the names of the fields whose values the records are counted by
+	CountBy []string `json:"count_by,omitempty"`
+
+	// Sum are the names of the numeric fields that are summed
+	Sum []string `json:"sum,omitempty"`
+}
+
+// Expansion is a related resource that can be included in the responses of a resource, see Resource.Expansions.
+type Expansion struct {
+	// Name of the expansion, which is also the name of the field the related resource is included in
+	Name string `json:"name"`
+
+	// Description of the expansion, defaults to a description of the related resource
+	Description string `json:"description,omitempty"`
+
+	// Field is the name of the field of the resource that references the related resource, see Field.References
+	Field string `json:"field"`
+}
+
+// ResourceExample is a named, full-entity example of a resource.
+type ResourceExample struct {
+	// Name of the example, should be unique within the resource
+	Name string `json:"name"`
+
+	// Summary is a short description of the example
+	Summary string `json:"summary,omitempty"`
+
+	// Description of the example (can be in markdown format)
+	Description string `json:"description,omitempty"`
+
+	// Values of the example keyed by field name, objects and arrays are written as nested values
+	Values map[string]any `json:"values"`
 }
 
 // Field contains information about a field within an endpoint or resource or Object.
@@ -558,6 +1247,14 @@ type Field struct {
 	// Name of the field, should be unique in the Resource or Object or Endpoint
 	Name string `json:"name"`
 
+	// JSONName is the name of the field in JSON, set by the overlay when the JSON case of the naming is not
+	// camelCase, see TagJSON
+	JSONName string `json:"json_name,omitempty"`
+
+	// HeaderName is the name of the response header on the wire with its exact casing, such as X-Total-Count,
+	// for the response headers of the service, see GetHeaderName
+	HeaderName string `json:"header_name,omitempty"`
+
 	// Description of the field, explain the reason what it is used for and why it's needed
 	Description string `json:"description"`
 
@@ -572,6 +1269,13 @@ type Field struct {
 
 	// Modifiers of the field, can be nullable or array
 	Modifiers []string `json:"modifiers,omitempty"`
+
+	// Scopes are the OAuth2 / OpenID Connect scopes required to read the field. The generated server redacts
+	// the field to null in the responses to the sessions without all of them, so the field must be nullable.
+	Scopes []string `json:"scopes,omitempty"`
+
+	// References is the name of the resource whose ID the field holds, only for UUID fields
+	References string `json:"references,omitempty"`
 }
 
 // ResourceField is used within a resource it extends the field with an operations configuration.
@@ -580,6 +1284,14 @@ type ResourceField struct {
 
 	// Operations that the field is allowed in (Create,Update,Delete,Read)
 	Operations []string `json:"operations"`
+
+	// RequiredOn are the operations (Create,Update) in which the field is required.
+	// When set, the field is optional in the other operations it's allowed in,
+	// for example required on Create but optional on Update for PATCH semantics.
+	RequiredOn []string `json:"required_on,omitempty"`
+
+	// SearchableText makes the String field part of the full-text search of the Query of the Search endpoint
+	SearchableText bool `json:"searchable_text,omitempty"`
 }
 
 // Endpoint represents an API endpoint within a resource.
@@ -595,6 +1307,9 @@ type Endpoint struct {
 	// Description of the endpoint (can be in markdown format for longer explanations)
 	Description string `json:"description"`
 
+	// DescriptionFile is a markdown file the description is loaded from, relative to the specification file
+	DescriptionFile string `json:"description_file,omitempty"`
+
 	// HTTP method of the endpoint
 	Method string `json:"method"`
 
@@ -606,11 +1321,73 @@ type Endpoint struct {
 
 	// Response that is used in the endpoint on success
 	Response EndpointResponse `json:"response"`
+
+	// Scopes are the OAuth2 / OpenID Connect scopes required to call the endpoint,
+	// overriding the scopes of the resource
+	Scopes []string `json:"scopes,omitempty"`
+
+	// Permissions are the application permissions required to call the endpoint,
+	// overriding the permissions of the resource
+	Permissions []string `json:"permissions,omitempty"`
+
+	// MaxBodyBytes limits the size of the request body in bytes, overriding the limit of the service
+	MaxBodyBytes int64 `json:"max_body_bytes,omitempty"`
+
+	// HandlerTimeout limits the time of the handler in milliseconds, overriding the timeout of the service
+	HandlerTimeout int `json:"handler_timeout,omitempty"`
+
+	// SDKMethodName is the name of the endpoint method in generated SDKs,
+	// defaults to the endpoint name in camelCase
+	SDKMethodName string `json:"sdk_method_name,omitempty"`
+
+	// TypeName is the prefix of the generated request and response types of the endpoint, e.g. UserCreateBodyParams,
+	// defaults to the resource name followed by the endpoint name
+	TypeName string `json:"type_name,omitempty"`
+
+	// ExternalDocs links documentation of the endpoint hosted elsewhere
+	ExternalDocs *ExternalDocs `json:"external_docs,omitempty"`
+
+	// Stability of the endpoint, experimental, beta or stable, overriding the stability of the resource.
+	// It's documented with the x-stability extension, and the changelog warns about incompatible changes
+	// of stable endpoints.
+	Stability string `json:"stability,omitempty"`
+
+	// Async makes the endpoint respond with 202 Accepted and the Operation it started, which is polled at the
+	// Get endpoint of the Operation resource the overlay adds until it is completed.
+	Async bool `json:"async,omitempty"`
+
+	// Callbacks are the requests the API sends to the URLs of the subscribers given in the request of the endpoint,
+	// documented as the OpenAPI callbacks of the operation.
+	Callbacks []Callback `json:"callbacks,omitempty"`
+
+	// FeatureFlag gates the endpoint behind a feature flag of the server, which responds 404 Not Found
+	// while the flag is disabled. The flagged endpoints can be excluded from the published OpenAPI document.
+	FeatureFlag string `json:"feature_flag,omitempty"`
+}
+
+// Callback is a request the API sends to the URL of a subscriber given in the request of an endpoint, e.g. when a
+// subscribed event happens, see Endpoint.Callbacks.
+type Callback struct {
+	// Name of the callback, unique within the endpoint, e.g. "OrderShipped"
+	Name string `json:"name"`
+
+	// Description of the callback
+	Description string `json:"description,omitempty"`
+
+	// URLField is the String body param of the request holding the URL of the subscriber, e.g. "CallbackURL"
+	URLField string `json:"url_field"`
+
+	// Method of the callback request, POST unless set
+	Method string `json:"method,omitempty"`
+
+	// Payload is the object or resource sent as the JSON body of the callback request
+	Payload string `json:"payload"`
 }
 
 // EndpointRequest represents the request structure for an API endpoint.
 type EndpointRequest struct {
-	// Content-Type of the request
+	// Content-Type of the request, application/json, application/xml or application/x-www-form-urlencoded,
+	// where empty means JSON
 	ContentType string `json:"content_type"`
 
 	// Headers that are used in the request
@@ -624,11 +1401,14 @@ type EndpointRequest struct {
 
 	// Body parameters that are used in the endpoint
 	BodyParams []Field `json:"body_params"`
+
+	// ParameterGroups are the names of the parameter groups whose fields are added to the query parameters
+	ParameterGroups []string `json:"parameter_groups,omitempty"`
 }
 
 // EndpointResponse represents the response structure for an API endpoint.
 type EndpointResponse struct {
-	// Content-Type of the response
+	// Content-Type of the response, application/json, application/xml or text/csv, where empty means JSON
 	ContentType string `json:"content_type"`
 
 	// HTTP status code this response represents (e.g. 200, 201, 400)
@@ -645,6 +1425,10 @@ type EndpointResponse struct {
 
 	// If a full object is returned (instead of individual fields) - can be object or Resource
 	BodyObject *string `json:"body_object,omitempty"`
+
+	// Content types the response body is also available in besides JSON, e.g. text/csv for an export,
+	// selected by the Accept header of the request
+	AlternativeContentTypes []string `json:"alternative_content_types,omitempty"`
 }
 
 // ApplyOverlay applies an overlay to a specification, generating Objects and endpoints from Resources.
@@ -662,14 +1446,29 @@ func ApplyOverlay(input *Service) *Service {
 	result := &Service{
 		Name:            input.Name,
 		Version:         input.Version,
+		SchemaVersion:   input.SchemaVersion,
 		Contact:         input.Contact,                               // Copy contact information
 		License:         input.License,                               // Copy license information
+		ExternalDocs:    input.ExternalDocs,                          // Copy external documentation
+		TermsOfService:  input.TermsOfService,                        // Copy terms of service
 		Servers:         append([]ServiceServer{}, input.Servers...), // Copy servers slice
+		BasePath:        input.BasePath,                              // Copy base path
+		Naming:          input.Naming,                                // Copy naming conventions
+		Strict:          input.Strict,                                // Copy strict setting
 		SecuritySchemes: input.SecuritySchemes,                       // Copy security schemes
 		Security:        input.Security,                              // Copy security requirements
 		Retry:           input.Retry,                                 // Copy retry configuration
 		Timeout:         input.Timeout,                               // Copy timeout configuration
+		MaxBodyBytes:    input.MaxBodyBytes,                          // Copy request body size limit
+		HandlerTimeout:  input.HandlerTimeout,                        // Copy handler timeout
+		FilterMaxDepth:  input.FilterMaxDepth,                        // Copy max depth of the nested filters
+		MaxLimit:        input.MaxLimit,                              // Copy max limit of the pagination
+		Tenancy:         input.Tenancy,                               // Copy tenancy configuration
+		Operational:     input.Operational,                           // Copy operational endpoints configuration
+		ErrorFormat:     input.ErrorFormat,                           // Copy error format
+		Localization:    input.Localization,                          // Copy localization of the error messages
 		ResponseHeaders: append([]Field{}, input.ResponseHeaders...), // Copy response headers
+		ParameterGroups: input.ParameterGroups,                       // Copy parameter groups
 		Enums:           make([]Enum, 0, len(input.Enums)+1),         // +1 for ErrorCode enum
 		Objects:         make([]Object, 0, len(input.Objects)+3),     // +3 for Error, Pagination, and Meta objects
 		Resources:       make([]Resource, len(input.Resources)),
@@ -678,6 +1477,9 @@ func ApplyOverlay(input *Service) *Service {
 	// Add default enums and objects if they don't already exist
 	addDefaultEnumsAndObjects(result, input)
 
+	// Flatten the fields inherited by objects that extend other objects
+	flattenExtendedObjects(result)
+
 	// Copy resources
 	copy(result.Resources, input.Resources)
 
@@ -686,29 +1488,337 @@ func ApplyOverlay(input *Service) *Service {
 	// Generate filter objects for resources that have Read operations (needed for search endpoints)
 	generateFilterObjectsForSearchableResources(result, input.Resources)
 	generateEndpointsFromResources(result, input.Resources)
+	// Prefix the path parameters of sub-resources with the IDs of their parents
+	addParentPathParams(result)
+	// Expand the parameter groups referenced by endpoints into their query parameters
+	expandParameterGroups(result)
+	// Add the related resources of the expansions to the objects and read endpoints of the resources
+	addExpansions(result)
+	// Respond with an Operation from the asynchronous endpoints, polled at the Operation resource
+	addAsyncOperations(result)
 
 	return result
 }
 
-// addDefaultEnumsAndObjects adds the default error, pagination, and meta objects to the service if they don't already exist.
-func addDefaultEnumsAndObjects(result *Service, input *Service) {
-	// Check if ErrorCode enum, Error object, Pagination object, and Meta object already exist
-	errorCodeEnumExists := false
-	errorObjectExists := false
-	paginationObjectExists := false
-	metaObjectExists := false
-	for _, enum := range input.Enums {
-		if enum.Name == errorCodeEnumName {
-			errorCodeEnumExists = true
+// addExpansions adds the enum of the expansions of every resource with expansions, a field per expansion to the
+// object of the resource and the Expand query parameter to its Get, List and Search endpoints. The fields are added
+// after the filter objects of the resources are generated and are skipped by ApplyFilterOverlay, since the related
+// resources cannot be filtered on. Adding is idempotent, enums, fields and query parameters that already exist are
+// not duplicated.
+func addExpansions(service *Service) {
+	for i := range service.Resources {
+		resource := service.Resources[i]
+		if len(resource.Expansions) == 0 {
+			continue
+		}
+
+		if !service.HasEnum(resource.GetExpansionEnumName()) {
+			service.Enums = append(service.Enums, createExpansionEnum(resource))
+		}
+
+		if index := slices.IndexFunc(service.Objects, func(object Object) bool { return object.Name == resource.Name }); index != -1 {
+			fields := append([]Field{}, service.Objects[index].Fields...)
+			for _, expansion := range resource.Expansions {
+				if !containsFieldName(fields, expansion.Name) {
+					fields = append(fields, createExpansionField(resource, expansion))
+				}
+			}
+			service.Objects[index].Fields = fields
+		}
+
+		endpoints := make([]Endpoint, len(resource.Endpoints))
+		copy(endpoints, resource.Endpoints)
+		for j := range endpoints {
+			if !slices.Contains([]string{getEndpointName, listEndpointName, searchEndpointName}, endpoints[j].Name) {
+				continue
+			}
+			if !containsFieldName(endpoints[j].Request.QueryParams, expandParamName) {
+				endpoints[j].Request.QueryParams = append(append([]Field{}, endpoints[j].Request.QueryParams...), Field{
+					Name:        expandParamName,
+					Description: expandParamDesc,
+					Type:        resource.GetExpansionEnumName(),
+					Modifiers:   []string{ModifierArray},
+				})
+			}
+		}
+		service.Resources[i].Endpoints = endpoints
+	}
+}
+
+// renameCollidingEndpointTypes sets the type name of the endpoints whose request or response types have the name of
+// another type of the generated server, when the naming of the service renames the collisions. The type name is the
+// resource and endpoint names followed by Endpoint, and by a number from 2 while that collides too, so it is
+// deterministic. Renaming is idempotent, endpoints with a type name that does not collide are not renamed again.
+func renameCollidingEndpointTypes(service *Service) {
+	if !service.Naming.RenamesTypeCollisions() {
+		return
+	}
+
+	// The types of the endpoints are checked against all other types, and the types of the endpoints before them
+	taken := map[string]bool{}
+	for _, name := range serverTypeNames {
+		taken[name] = true
+	}
+	for _, declaration := range service.getTypeDeclarations() {
+		if !declaration.endpoint {
+			taken[declaration.name] = true
+		}
+	}
+
+	collides := func(names []string) bool {
+		return slices.ContainsFunc(names, func(name string) bool { return taken[name] })
+	}
+
+	for i := range service.Resources {
+		resource := service.Resources[i]
+		endpoints := slices.Clone(resource.Endpoints)
+		for j := range endpoints {
+			base := resource.Name + endpoints[j].Name + "Endpoint"
+			for n := 1; collides(endpoints[j].getDeclaredTypes(resource.Name)); n++ {
+				endpoints[j].TypeName = base
+				if n > 1 {
+					endpoints[j].TypeName = base + strconv.Itoa(n)
+				}
+			}
+
+			for _, name := range endpoints[j].getDeclaredTypes(resource.Name) {
+				taken[name] = true
+			}
+		}
+		service.Resources[i].Endpoints = endpoints
+	}
+}
+
+// addAsyncOperations makes the asynchronous endpoints respond with 202 Accepted and the Operation they started, and
+// adds the OperationStatus enum, the Operation object and the Operation resource polling it when the service has any.
+// Adding is idempotent, the enum, object and resource are not duplicated.
+func addAsyncOperations(service *Service) {
+	if !service.HasAsyncEndpoints() {
+		return
+	}
+
+	for i := range service.Resources {
+		endpoints := make([]Endpoint, len(service.Resources[i].Endpoints))
+		copy(endpoints, service.Resources[i].Endpoints)
+		for j := range endpoints {
+			if endpoints[j].Async {
+				operationObjectName := OperationObjectName
+				endpoints[j].Response = createStandardResponse(asyncResponseStatusCode, asyncResponseDescription, &operationObjectName)
+			}
+		}
+		service.Resources[i].Endpoints = endpoints
+	}
+
+	if !service.HasEnum(operationStatusEnumName) {
+		service.Enums = append(service.Enums, Enum{
+			Name:        operationStatusEnumName,
+			Description: operationStatusEnumDescription,
+			Values: []EnumValue{
+				{Name: operationStatusPending, Description: "The operation has not started yet"},
+				{Name: operationStatusRunning, Description: "The operation is running"},
+				{Name: OperationStatusSucceeded, Description: "The operation completed successfully"},
+				{Name: OperationStatusFailed, Description: "The operation failed, see its error"},
+			},
+		})
+	}
+
+	if !service.HasObject(OperationObjectName) {
+		service.Objects = append(service.Objects, Object{
+			Name:        OperationObjectName,
+			Description: operationObjectDescription,
+			Fields: []Field{
+				{Name: autoColumnIDName, Description: fmt.Sprintf(autoColumnIDDescTemplate, "operation"), Type: FieldTypeUUID},
+				{Name: operationStatusFieldName, Description: operationStatusFieldDescription, Type: operationStatusEnumName},
+				{Name: OperationRetryAfterFieldName, Description: operationRetryAfterDescription, Type: FieldTypeInt, Modifiers: []string{ModifierNullable}},
+				{Name: errorObjectName, Description: operationErrorFieldDescription, Type: errorObjectName, Modifiers: []string{ModifierNullable}},
+				{Name: operationCreatedAtFieldName, Description: operationCreatedAtDescription, Type: FieldTypeTimestamp},
+				{Name: operationCompletedAtFieldName, Description: operationCompletedAtDescription, Type: FieldTypeTimestamp, Modifiers: []string{ModifierNullable}},
+			},
+		})
+	}
+
+	if service.GetResource(operationResourceName) == nil {
+		operationObjectName := OperationObjectName
+		service.Resources = append(service.Resources, Resource{
+			Name:        operationResourceName,
+			Description: operationResourceDescription,
+			Endpoints: []Endpoint{
+				{
+					Name:        getEndpointName,
+					Summary:     operationEndpointSummary,
+					Description: operationEndpointDescription,
+					Method:      httpMethodGet,
+					Path:        getEndpointPath,
+					Request: createStandardRequest([]Field{{
+						Name:        getIDParamName,
+						Description: fmt.Sprintf(getIDParamDescTemplate, operationResourceName),
+						Type:        FieldTypeUUID,
+					}}, []Field{}, []Field{}),
+					Response: createStandardResponse(getResponseStatusCode, operationResponseDescription, &operationObjectName),
+				},
+			},
+		})
+	}
+}
+
+// createExpansionEnum creates the enum of the values of the Expand query parameter of the resource.
+func createExpansionEnum(resource Resource) Enum {
+	enum := Enum{
+		Name:        resource.GetExpansionEnumName(),
+		Description: fmt.Sprintf(expansionEnumDescTemplate, resource.GetPluralName()),
+	}
+	for _, expansion := range resource.Expansions {
+		enum.Values = append(enum.Values, EnumValue{Name: expansion.Name, Description: resource.getExpansionDescription(expansion)})
+	}
+	return enum
+}
+
+// createExpansionField creates the field of the object of the resource that the related resource is included in.
+// The field is optional, so that it is null unless the expansion is requested, and an array for array references.
+func createExpansionField(resource Resource, expansion Expansion) Field {
+	field := Field{
+		Name:        expansion.Name,
+		Description: resource.getExpansionDescription(expansion),
+		Modifiers:   []string{ModifierNullable, ModifierOptional},
+	}
+	for _, resourceField := range resource.Fields {
+		if resourceField.Name == expansion.Field {
+			field.Type = resourceField.References
+			if resourceField.IsArray() {
+				field.Modifiers = []string{ModifierArray, ModifierNullable}
+			}
+		}
+	}
+	return field
+}
+
+// expandParameterGroups appends the fields of the referenced parameter groups to the query parameters of every endpoint.
+// Expansion is idempotent, query parameters that already exist are not duplicated.
+func expandParameterGroups(service *Service) {
+	if len(service.ParameterGroups) == 0 {
+		return
+	}
+
+	for i := range service.Resources {
+		endpoints := make([]Endpoint, len(service.Resources[i].Endpoints))
+		copy(endpoints, service.Resources[i].Endpoints)
+
+		for j := range endpoints {
+			queryParams := append([]Field{}, endpoints[j].Request.QueryParams...)
+			for _, groupName := range endpoints[j].Request.ParameterGroups {
+				group := service.GetParameterGroup(groupName)
+				if group == nil {
+					continue
+				}
+				for _, field := range group.Fields {
+					if !containsFieldName(queryParams, field.Name) {
+						queryParams = append(queryParams, field)
+					}
+				}
+			}
+			endpoints[j].Request.QueryParams = queryParams
+		}
+
+		service.Resources[i].Endpoints = endpoints
+	}
+}
+
+// addParentPathParams prepends the ID parameters of the parents of sub-resources to the path parameters of their endpoints.
+// Adding is idempotent, path parameters that already exist are not duplicated.
+func addParentPathParams(service *Service) {
+	for i := range service.Resources {
+		parentParams := service.GetParentPathParams(service.Resources[i])
+		if len(parentParams) == 0 {
+			continue
+		}
+
+		endpoints := make([]Endpoint, len(service.Resources[i].Endpoints))
+		copy(endpoints, service.Resources[i].Endpoints)
+
+		for j := range endpoints {
+			pathParams := make([]Field, 0, len(parentParams)+len(endpoints[j].Request.PathParams))
+			for _, param := range parentParams {
+				if !containsFieldName(endpoints[j].Request.PathParams, param.Name) {
+					pathParams = append(pathParams, param)
+				}
+			}
+			endpoints[j].Request.PathParams = append(pathParams, endpoints[j].Request.PathParams...)
+		}
+
+		service.Resources[i].Endpoints = endpoints
+	}
+}
+
+// addDefaultEnumsAndObjects adds the default error, pagination, and meta objects to the service if they don't already exist.
+// flattenExtendedObjects prepends the inherited fields to the fields of every object that extends another object.
+// Flattening is idempotent, fields that are already inherited are not duplicated.
+func flattenExtendedObjects(service *Service) {
+	for i := range service.Objects {
+		if service.Objects[i].HasParent() {
+			service.Objects[i].Fields = service.getFlattenedFields(service.Objects[i], map[string]bool{})
+		}
+	}
+}
+
+// getFlattenedFields returns the inherited fields followed by the fields of the object itself.
+func (s *Service) getFlattenedFields(object Object, visited map[string]bool) []Field {
+	if !object.HasParent() || visited[object.Name] {
+		return object.Fields
+	}
+	visited[object.Name] = true
+
+	parent := s.GetObject(object.Extends)
+	if parent == nil {
+		return object.Fields
+	}
+
+	inherited := s.getFlattenedFields(*parent, visited)
+	fields := make([]Field, 0, len(inherited)+len(object.Fields))
+	fields = append(fields, inherited...)
+	for _, field := range object.Fields {
+		if !containsFieldName(inherited, field.Name) {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// containsFieldName returns true if a field with the given name exists in fields.
+func containsFieldName(fields []Field, name string) bool {
+	for _, field := range fields {
+		if field.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func addDefaultEnumsAndObjects(result *Service, input *Service) {
+	// Check if ErrorCode enum, Error object, Pagination object, and Meta object already exist
+	errorCodeEnumExists := false
+	errorObjectExists := false
+	errorFieldObjectExists := false
+	paginationObjectExists := false
+	paginationWithoutTotalObjectExists := false
+	metaObjectExists := false
+	for _, enum := range input.Enums {
+		if enum.Name == errorCodeEnumName {
+			errorCodeEnumExists = true
 		}
 	}
 	for _, object := range input.Objects {
 		if object.Name == errorObjectName {
 			errorObjectExists = true
 		}
+		if object.Name == errorFieldObjectName {
+			errorFieldObjectExists = true
+		}
 		if object.Name == paginationObjectName {
 			paginationObjectExists = true
 		}
+		if object.Name == PaginationWithoutTotalObjectName {
+			paginationWithoutTotalObjectExists = true
+		}
 		if object.Name == metaObjectName {
 			metaObjectExists = true
 		}
@@ -719,21 +1829,7 @@ func addDefaultEnumsAndObjects(result *Service, input *Service) {
 
 	// Add default ErrorCode enum if it doesn't exist
 	if !errorCodeEnumExists {
-		errorCodeEnum := Enum{
-			Name:        errorCodeEnumName,
-			Description: descriptionErrorCodeEnum,
-			Values: []EnumValue{
-				{Name: errorCodeBadRequest, Description: descriptionErrorCodeBadRequest},
-				{Name: errorCodeUnauthorized, Description: descriptionErrorCodeUnauthorized},
-				{Name: errorCodeForbidden, Description: descriptionErrorCodeForbidden},
-				{Name: errorCodeNotFound, Description: descriptionErrorCodeNotFound},
-				{Name: errorCodeConflict, Description: descriptionErrorCodeConflict},
-				{Name: errorCodeUnprocessableEntity, Description: descriptionErrorCodeUnprocessableEntity},
-				{Name: errorCodeRateLimited, Description: descriptionErrorCodeRateLimited},
-				{Name: errorCodeInternal, Description: descriptionErrorCodeInternal},
-			},
-		}
-		result.Enums = append(result.Enums, errorCodeEnum)
+		result.Enums = append(result.Enums, createDefaultErrorCodeEnum(input))
 	}
 
 	// Copy existing objects first to preserve order
@@ -761,38 +1857,33 @@ func addDefaultEnumsAndObjects(result *Service, input *Service) {
 					Type:        FieldTypeString,
 					Example:     "550e8400-e29b-41d4-a716-446655440000",
 				},
+				{
+					Name:        errorFieldsFieldName,
+					Description: errorFieldsFieldDescription,
+					Type:        errorFieldObjectName,
+					Modifiers:   []string{ModifierArray},
+				},
 			},
 		}
+		if input.IsProblemDetails() {
+			errorObject = createDefaultProblem(errorObject)
+		}
 		result.Objects = append(result.Objects, errorObject)
+
+		// The default Error object references ErrorField for its field errors
+		if !errorFieldObjectExists {
+			result.Objects = append(result.Objects, createDefaultErrorField())
+		}
 	}
 
 	// Add default Pagination object if it doesn't exist
 	if !paginationObjectExists {
-		paginationObject := Object{
-			Name:        paginationObjectName,
-			Description: paginationObjectDescription,
-			Fields: []Field{
-				{
-					Name:        offsetFieldName,
-					Description: offsetFieldDescription,
-					Type:        FieldTypeInt,
-					Example:     "0",
-				},
-				{
-					Name:        limitFieldName,
-					Description: limitFieldDescription,
-					Type:        FieldTypeInt,
-					Example:     "1",
-				},
-				{
-					Name:        totalFieldName,
-					Description: totalFieldDescription,
-					Type:        FieldTypeInt,
-					Example:     "100",
-				},
-			},
-		}
-		result.Objects = append(result.Objects, paginationObject)
+		result.Objects = append(result.Objects, createDefaultPagination())
+	}
+
+	// Add the PaginationWithoutTotal object of the resources returning the total in a header if it doesn't exist
+	if !paginationWithoutTotalObjectExists && slices.ContainsFunc(input.Resources, Resource.HasTotalCountHeader) {
+		result.Objects = append(result.Objects, createPaginationWithoutTotal())
 	}
 
 	// Add default Meta object if it doesn't exist
@@ -802,6 +1893,148 @@ func addDefaultEnumsAndObjects(result *Service, input *Service) {
 	}
 }
 
+// createDefaultPagination creates the default Pagination object of the List and Search responses.
+func createDefaultPagination() Object {
+	return Object{
+		Name:        paginationObjectName,
+		Description: paginationObjectDescription,
+		Fields: []Field{
+			{
+				Name:        offsetFieldName,
+				Description: offsetFieldDescription,
+				Type:        FieldTypeInt,
+				Example:     "0",
+			},
+			{
+				Name:        limitFieldName,
+				Description: limitFieldDescription,
+				Type:        FieldTypeInt,
+				Example:     "1",
+			},
+			{
+				Name:        totalFieldName,
+				Description: totalFieldDescription,
+				Type:        FieldTypeInt,
+				Example:     "100",
+			},
+		},
+	}
+}
+
+// createPaginationWithoutTotal creates the PaginationWithoutTotal object, the default Pagination object without the
+// total, which the resources with TotalCountHeader return in the X-Total-Count header.
+func createPaginationWithoutTotal() Object {
+	pagination := createDefaultPagination()
+	return Object{
+		Name:        PaginationWithoutTotalObjectName,
+		Description: paginationWithoutTotalObjectDescription,
+		Fields:      slices.DeleteFunc(pagination.Fields, func(field Field) bool { return field.Name == totalFieldName }),
+	}
+}
+
+// createDefaultErrorCodeEnum creates the default ErrorCode enum,
+// with the codes of the request limits when the service sets any.
+func createDefaultErrorCodeEnum(service *Service) Enum {
+	enum := Enum{
+		Name:        errorCodeEnumName,
+		Description: descriptionErrorCodeEnum,
+		Values: []EnumValue{
+			{Name: errorCodeBadRequest, Description: descriptionErrorCodeBadRequest},
+			{Name: errorCodeUnauthorized, Description: descriptionErrorCodeUnauthorized},
+			{Name: errorCodeForbidden, Description: descriptionErrorCodeForbidden},
+			{Name: errorCodeNotFound, Description: descriptionErrorCodeNotFound},
+			{Name: errorCodeConflict, Description: descriptionErrorCodeConflict},
+			{Name: errorCodeUnprocessableEntity, Description: descriptionErrorCodeUnprocessableEntity},
+			{Name: errorCodeRateLimited, Description: descriptionErrorCodeRateLimited},
+			{Name: errorCodeInternal, Description: descriptionErrorCodeInternal},
+		},
+	}
+
+	if service.HasHandlerTimeouts() {
+		enum.Values = append(enum.Values, EnumValue{Name: errorCodeRequestTimeout, Description: descriptionErrorCodeRequestTimeout})
+	}
+	if service.HasBodyLimits() {
+		enum.Values = append(enum.Values, EnumValue{Name: errorCodePayloadTooLarge, Description: descriptionErrorCodePayloadTooLarge})
+	}
+
+	return enum
+}
+
+// createDefaultProblem creates the default Error object of ErrorFormatProblemJSON from the default Error object,
+// with the members of RFC 9457 first and Message replaced by Detail. The other fields are kept as extension members.
+func createDefaultProblem(errorObject Object) Object {
+	fields := []Field{
+		{
+			Name:        problemTypeFieldName,
+			Description: problemTypeFieldDescription,
+			Type:        FieldTypeString,
+			Example:     "about:blank",
+		},
+		{
+			Name:        problemTitleFieldName,
+			Description: problemTitleFieldDescription,
+			Type:        FieldTypeString,
+			Example:     "Unprocessable Entity",
+		},
+		{
+			Name:        problemStatusFieldName,
+			Description: problemStatusFieldDescription,
+			Type:        FieldTypeInt,
+			Example:     "422",
+		},
+		{
+			Name:        problemDetailFieldName,
+			Description: problemDetailFieldDescription,
+			Type:        FieldTypeString,
+		},
+		{
+			Name:        problemInstanceFieldName,
+			Description: problemInstanceFieldDescription,
+			Type:        FieldTypeString,
+			Example:     "/v1/resources/123",
+		},
+	}
+	for _, field := range errorObject.Fields {
+		if field.Name != errorMessageFieldName {
+			fields = append(fields, field)
+		}
+	}
+
+	return Object{
+		Name:        errorObject.Name,
+		Description: problemObjectDescription,
+		Fields:      fields,
+	}
+}
+
+// createDefaultErrorField creates the ErrorField object of the field errors of the default Error object.
+func createDefaultErrorField() Object {
+	return Object{
+		Name:        errorFieldObjectName,
+		Description: errorFieldObjectDescription,
+		Fields: []Field{
+			{
+				Name:        errorFieldPathFieldName,
+				Description: errorFieldPathFieldDescription,
+				Type:        FieldTypeString,
+				Example:     "address.street",
+			},
+			{
+				Name:        errorFieldCodeFieldName,
+				Description: errorFieldCodeFieldDescription,
+				Type:        FieldTypeString,
+				Example:     "required",
+			},
+			{
+				Name:        errorFieldMessageFieldName,
+				Description: errorFieldMessageFieldDescription,
+				Type:        FieldTypeString,
+				Example:     "Street is required",
+			},
+		},
+	}
+}
+
 // generateObjectsFromResources generates Objects from Resources that have Read operations.
 func generateObjectsFromResources(result *Service, resources []Resource) {
 	for _, resource := range resources {
@@ -814,8 +2047,12 @@ func generateObjectsFromResources(result *Service, resources []Resource) {
 
 				// Add auto-columns to the object if not skipped
 				if !resource.ShouldSkipAutoColumns() {
-					autoColumns := createAutoColumnsWithMeta(resource.Name)
-					fields = append(autoColumns, fields...)
+					fields = withAutoColumns(createAutoColumnsWithMeta(resource.Name), fields)
+				}
+
+				// Soft deleted resources are told apart by the time they were deleted
+				if resource.SoftDelete {
+					fields = append(fields, createSoftDeleteColumn(resource.Name))
 				}
 
 				// Create a new Object based on the Resource
@@ -839,9 +2076,12 @@ func generateEndpointsFromResources(result *Service, resources []Resource) {
 		generateCreateEndpoint(result, resource)
 		generateUpdateEndpoint(result, resource)
 		generateDeleteEndpoint(result, resource)
+		generateRestoreEndpoint(result, resource)
 		generateGetEndpoint(result, resource)
 		generateListEndpoint(result, resource)
 		generateSearchEndpoint(result, resource)
+		generateExportEndpoint(result, resource)
+		generateAggregateEndpoint(result, resource)
 	}
 }
 
@@ -905,11 +2145,42 @@ func generateDeleteEndpoint(result *Service, resource Resource) {
 			Request:     createStandardRequest([]Field{idParam}, []Field{}, []Field{}),
 			Response:    createStandardResponse(deleteResponseStatusCode, fmt.Sprintf(deleteResponseDescTemplate, resource.Name), nil),
 		}
+		if resource.SoftDelete {
+			deleteEndpoint.Description = fmt.Sprintf(softDeleteEndpointDescTemplate, resource.Name)
+		}
 
 		addEndpointToResource(result, resource.Name, deleteEndpoint)
 	}
 }
 
+// generateRestoreEndpoint generates a Restore endpoint for resources with SoftDelete, responding with the restored
+// resource when the resource has Read fields and with no content otherwise.
+func generateRestoreEndpoint(result *Service, resource Resource) {
+	if resource.SoftDelete && resource.HasDeleteOperation() && !resource.HasEndpoint(restoreEndpointName) {
+		idParam := Field{
+			Name:        restoreIDParamName,
+			Description: fmt.Sprintf(restoreIDParamDescTemplate, resource.Name),
+			Type:        FieldTypeUUID,
+		}
+		resourceName := resource.Name
+		response := createStandardResponse(deleteResponseStatusCode, fmt.Sprintf(restoreResponseDescTemplate, resourceName), nil)
+		if resource.HasReadOperation() {
+			response = createStandardResponse(getResponseStatusCode, fmt.Sprintf(restoreResponseDescTemplate, resourceName), &resourceName)
+		}
+		restoreEndpoint := Endpoint{
+			Name:        restoreEndpointName,
+			Summary:     restoreEndpointSummaryPrefix + resource.Name,
+			Description: fmt.Sprintf(restoreEndpointDescTemplate, resource.Name),
+			Method:      httpMethodPost,
+			Path:        restoreEndpointPath,
+			Request:     createStandardRequest([]Field{idParam}, []Field{}, []Field{}),
+			Response:    response,
+		}
+
+		addEndpointToResource(result, resource.Name, restoreEndpoint)
+	}
+}
+
 // generateGetEndpoint generates a Get endpoint for resources that have Get operations.
 func generateGetEndpoint(result *Service, resource Resource) {
 	if resource.HasGetOperation() && !resource.HasEndpoint(getEndpointName) {
@@ -936,7 +2207,7 @@ func generateGetEndpoint(result *Service, resource Resource) {
 // generateListEndpoint generates a List endpoint for resources that have List operations.
 func generateListEndpoint(result *Service, resource Resource) {
 	if resource.HasListOperation() && !resource.HasEndpoint(listEndpointName) {
-		limitParam := createListLimitParamForResource(resource)
+		limitParam := withMaxLimit(createListLimitParamForResource(resource), result.MaxLimit)
 		offsetParam := createListOffsetParamForResource(resource)
 		paginationField := createPaginationField()
 		dataField := createDataField(resource.Name)
@@ -948,18 +2219,34 @@ func generateListEndpoint(result *Service, resource Resource) {
 			Description: fmt.Sprintf(listEndpointDescTemplate, pluralResourceName),
 			Method:      httpMethodGet,
 			Path:        listEndpointPath,
-			Request:     createStandardRequest([]Field{}, []Field{limitParam, offsetParam}, []Field{}),
-			Response:    createListResponse(listResponseStatusCode, fmt.Sprintf(listResponseDescTemplate, pluralResourceName), dataField, paginationField),
+			Request:     createStandardRequest([]Field{}, createPaginationQueryParams(resource, limitParam, offsetParam), []Field{}),
+			Response:    withTotalCount(resource, createListResponse(listResponseStatusCode, fmt.Sprintf(listResponseDescTemplate, pluralResourceName), dataField, paginationField)),
 		}
 
 		addEndpointToResource(result, resource.Name, listEndpoint)
 	}
 }
 
+// createSearchQueryParam creates the Query body parameter of the Search endpoint, whose description lists the
+// fields of the full-text search.
+func createSearchQueryParam(resource Resource, searchableFields []ResourceField) Field {
+	names := make([]string, len(searchableFields))
+	for i, field := range searchableFields {
+		names[i] = field.TagJSON()
+	}
+
+	return Field{
+		Name:        searchQueryParamName,
+		Description: fmt.Sprintf(searchQueryParamDescTemplate, resource.GetPluralName(), strings.Join(names, ", ")),
+		Type:        FieldTypeString,
+		Modifiers:   []string{ModifierOptional},
+	}
+}
+
 // generateSearchEndpoint generates a Search endpoint for resources that have Search operations.
 func generateSearchEndpoint(result *Service, resource Resource) {
 	if resource.HasSearchOperation() && !resource.HasEndpoint(searchEndpointName) {
-		limitParam := createSearchLimitParamForResource(resource)
+		limitParam := withMaxLimit(createSearchLimitParamForResource(resource), result.MaxLimit)
 		offsetParam := createSearchOffsetParamForResource(resource)
 		filterParam := Field{
 			Name:        searchFilterParamName,
@@ -967,6 +2254,10 @@ func generateSearchEndpoint(result *Service, resource Resource) {
 			Type:        resource.Name + filterSuffix,
 			Modifiers:   []string{ModifierRequired},
 		}
+		bodyParams := []Field{filterParam}
+		if searchableFields := resource.GetSearchableTextFields(); len(searchableFields) > 0 {
+			bodyParams = append(bodyParams, createSearchQueryParam(resource, searchableFields))
+		}
 		paginationField := createPaginationField()
 		dataField := createDataField(resource.Name)
 		pluralResourceName := resource.GetPluralName()
@@ -977,44 +2268,159 @@ func generateSearchEndpoint(result *Service, resource Resource) {
 			Description: fmt.Sprintf(searchEndpointDescTemplate, pluralResourceName),
 			Method:      httpMethodPost,
 			Path:        searchEndpointPath,
-			Request:     createStandardRequest([]Field{}, []Field{limitParam, offsetParam}, []Field{filterParam}),
-			Response:    createListResponse(searchResponseStatusCode, fmt.Sprintf(searchResponseDescTemplate, pluralResourceName), dataField, paginationField),
+			Request:     createStandardRequest([]Field{}, createPaginationQueryParams(resource, limitParam, offsetParam), bodyParams),
+			Response:    withTotalCount(resource, createListResponse(searchResponseStatusCode, fmt.Sprintf(searchResponseDescTemplate, pluralResourceName), dataField, paginationField)),
 		}
 
 		addEndpointToResource(result, resource.Name, searchEndpoint)
 	}
 }
 
-// addEndpointToResource adds an endpoint to a resource by name.
-func addEndpointToResource(result *Service, resourceName string, endpoint Endpoint) {
-	for i := range result.Resources {
-		if result.Resources[i].Name == resourceName {
-			result.Resources[i].Endpoints = append(result.Resources[i].Endpoints, endpoint)
-			break
+// generateExportEndpoint generates an Export endpoint for resources with Exportable, streaming the results of List
+// as CSV with the object of the resource as the rows. It takes the query parameters of List except the pagination.
+func generateExportEndpoint(result *Service, resource Resource) {
+	if resource.Exportable && resource.HasListOperation() && !resource.HasEndpoint(exportEndpointName) {
+		resourceName := resource.Name
+		pluralResourceName := resource.GetPluralName()
+
+		exportEndpoint := Endpoint{
+			Name:        exportEndpointName,
+			Summary:     exportEndpointSummaryPrefix + pluralResourceName,
+			Description: fmt.Sprintf(exportEndpointDescTemplate, pluralResourceName),
+			Method:      httpMethodGet,
+			Path:        exportEndpointPath,
+			Request:     createStandardRequest([]Field{}, createListQueryParams(resource), []Field{}),
+			Response: EndpointResponse{
+				ContentType: ContentTypeCSV,
+				StatusCode:  exportResponseStatusCode,
+				Description: fmt.Sprintf(exportResponseDescTemplate, pluralResourceName),
+				Headers:     []Field{},
+				BodyFields:  []Field{},
+				BodyObject:  &resourceName,
+			},
 		}
+
+		addEndpointToResource(result, resource.Name, exportEndpoint)
 	}
 }
 
-// createStandardRequest creates a standard endpoint request with the given path and body parameters.
-func createStandardRequest(pathParams []Field, queryParams []Field, bodyParams []Field) EndpointRequest {
-	return EndpointRequest{
-		ContentType: contentTypeJSON,
-		Headers:     []Field{},
-		PathParams:  pathParams,
-		QueryParams: queryParams,
-		BodyParams:  bodyParams,
+// generateAggregateEndpoint generates an Aggregate endpoint for resources with Aggregations, and the objects of its
+// response. It takes the query parameters of List except the pagination.
+func generateAggregateEndpoint(result *Service, resource Resource) {
+	if resource.Aggregations == nil || resource.HasEndpoint(aggregateEndpointName) {
+		return
 	}
-}
 
-// createStandardResponse creates a standard endpoint response with the given status code, description, and optional body object.
-func createStandardResponse(statusCode int, description string, bodyObject *string) EndpointResponse {
-	return EndpointResponse{
-		ContentType: contentTypeJSON,
-		StatusCode:  statusCode,
-		Description: description,
-		Headers:     []Field{},
-		BodyFields:  []Field{},
-		BodyObject:  bodyObject,
+	for _, object := range createAggregationObjects(resource) {
+		if !result.HasObject(object.Name) {
+			result.Objects = append(result.Objects, object)
+		}
+	}
+
+	aggregationObjectName := resource.GetAggregationObjectName()
+	pluralResourceName := resource.GetPluralName()
+	aggregateEndpoint := Endpoint{
+		Name:        aggregateEndpointName,
+		Summary:     aggregateEndpointSummaryPrefix + pluralResourceName,
+		Description: fmt.Sprintf(aggregateEndpointDescTemplate, pluralResourceName),
+		Method:      httpMethodGet,
+		Path:        aggregateEndpointPath,
+		Request:     createStandardRequest([]Field{}, createListQueryParams(resource), []Field{}),
+		Response:    createStandardResponse(aggregateResponseStatusCode, fmt.Sprintf(aggregateResponseDescTemplate, pluralResourceName), &aggregationObjectName),
+	}
+
+	addEndpointToResource(result, resource.Name, aggregateEndpoint)
+}
+
+// createAggregationObjects creates the object of the response of the Aggregate endpoint of the resource, with the
+// count of the records, a sum per Sum field and a count per value of each CountBy field, and the objects of the
+// counts per value.
+func createAggregationObjects(resource Resource) []Object {
+	pluralResourceName := resource.GetPluralName()
+	aggregation := Object{
+		Name:        resource.GetAggregationObjectName(),
+		Description: fmt.Sprintf(aggregationObjectDescTemplate, pluralResourceName),
+		Fields: []Field{
+			{Name: aggregationCountFieldName, Description: fmt.Sprintf(aggregationCountFieldDesc, pluralResourceName), Type: FieldTypeInt},
+		},
+	}
+
+	for _, name := range resource.Aggregations.Sum {
+		field := resource.getResourceField(name)
+		if field == nil {
+			continue
+		}
+		sumType := field.Type
+		if sumType == FieldTypeInt32 {
+			sumType = FieldTypeInt
+		}
+		aggregation.Fields = append(aggregation.Fields, Field{
+			Name:        aggregationSumFieldPrefix + field.Name,
+			Description: fmt.Sprintf(aggregationSumFieldDesc, field.TagJSON(), pluralResourceName),
+			Type:        sumType,
+		})
+	}
+
+	objects := []Object{}
+	for _, name := range resource.Aggregations.CountBy {
+		field := resource.getResourceField(name)
+		if field == nil {
+			continue
+		}
+		groupObjectName := resource.Name + field.Name + aggregationGroupObjectSuffix
+		valueModifiers := []string{}
+		if field.IsNullable() {
+			valueModifiers = append(valueModifiers, ModifierNullable)
+		}
+		objects = append(objects, Object{
+			Name:        groupObjectName,
+			Description: fmt.Sprintf(aggregationGroupObjectDesc, pluralResourceName, field.TagJSON()),
+			Fields: []Field{
+				{Name: aggregationGroupValueFieldName, Description: fmt.Sprintf(aggregationGroupValueFieldDesc, field.TagJSON()), Type: field.Type, Modifiers: valueModifiers},
+				{Name: aggregationCountFieldName, Description: fmt.Sprintf(aggregationGroupCountFieldDesc, pluralResourceName), Type: FieldTypeInt},
+			},
+		})
+		aggregation.Fields = append(aggregation.Fields, Field{
+			Name:        aggregationCountByFieldPrefix + field.Name,
+			Description: fmt.Sprintf(aggregationCountByFieldDesc, pluralResourceName, field.TagJSON()),
+			Type:        groupObjectName,
+			Modifiers:   []string{ModifierArray},
+		})
+	}
+
+	return append([]Object{aggregation}, objects...)
+}
+
+// addEndpointToResource adds an endpoint to a resource by name.
+func addEndpointToResource(result *Service, resourceName string, endpoint Endpoint) {
+	for i := range result.Resources {
+		if result.Resources[i].Name == resourceName {
+			result.Resources[i].Endpoints = append(result.Resources[i].Endpoints, endpoint)
+			break
+		}
+	}
+}
+
+// createStandardRequest creates a standard endpoint request with the given path and body parameters.
+func createStandardRequest(pathParams []Field, queryParams []Field, bodyParams []Field) EndpointRequest {
+	return EndpointRequest{
+		ContentType: contentTypeJSON,
+		Headers:     []Field{},
+		PathParams:  pathParams,
+		QueryParams: queryParams,
+		BodyParams:  bodyParams,
+	}
+}
+
+// createStandardResponse creates a standard endpoint response with the given status code, description, and optional body object.
+func createStandardResponse(statusCode int, description string, bodyObject *string) EndpointResponse {
+	return EndpointResponse{
+		ContentType: contentTypeJSON,
+		StatusCode:  statusCode,
+		Description: description,
+		Headers:     []Field{},
+		BodyFields:  []Field{},
+		BodyObject:  bodyObject,
 	}
 }
 
@@ -1030,10 +2436,43 @@ func createListResponse(statusCode int, description string, dataField Field, pag
 	}
 }
 
+// withTotalCount returns the List or Search response of the resource with the total number of items in the
+// X-Total-Count header and the PaginationWithoutTotal object in the body when the resource has TotalCountHeader,
+// otherwise the response as it is.
+func withTotalCount(resource Resource, response EndpointResponse) EndpointResponse {
+	if !resource.HasTotalCountHeader() {
+		return response
+	}
+
+	for i, field := range response.BodyFields {
+		if field.Type == paginationObjectName {
+			response.BodyFields[i].Type = PaginationWithoutTotalObjectName
+		}
+	}
+	response.Headers = append(response.Headers, Field{
+		Name:        totalCountFieldName,
+		HeaderName:  TotalCountHeaderName,
+		Description: totalFieldDescription,
+		Type:        FieldTypeInt,
+		Example:     "100",
+	})
+	return response
+}
+
 // isComparableType returns true if the field type supports range operations.
 func isComparableType(fieldType string) bool {
 	switch fieldType {
-	case FieldTypeInt, FieldTypeFloat64, FieldTypeDate, FieldTypeTimestamp:
+	case FieldTypeInt, FieldTypeInt32, FieldTypeUInt, FieldTypeFloat64, FieldTypeDecimal, FieldTypeDate, FieldTypeTimestamp:
+		return true
+	default:
+		return false
+	}
+}
+
+// isNumericType returns true if the field type is a number that can be summed.
+func isNumericType(fieldType string) bool {
+	switch fieldType {
+	case FieldTypeInt, FieldTypeInt32, FieldTypeUInt, FieldTypeFloat64, FieldTypeDecimal:
 		return true
 	default:
 		return false
@@ -1053,7 +2492,8 @@ func canBeNull(field Field) bool {
 // isPrimitiveType returns true if the field type is a primitive type.
 func isPrimitiveType(fieldType string) bool {
 	switch fieldType {
-	case FieldTypeUUID, FieldTypeDate, FieldTypeTimestamp, FieldTypeString, FieldTypeInt, FieldTypeFloat64, FieldTypeBool:
+	case FieldTypeUUID, FieldTypeDate, FieldTypeTimestamp, FieldTypeString,
+		FieldTypeInt, FieldTypeInt32, FieldTypeUInt, FieldTypeFloat64, FieldTypeDecimal, FieldTypeBool:
 		return true
 	default:
 		return false
@@ -1131,7 +2571,7 @@ func generateFilterObjectsForSearchableResources(service *Service, resources []R
 			for _, obj := range service.Objects {
 				if obj.Name == resource.Name {
 					// Generate all filter objects for this resource object
-					filterObjects := generateFilterObjectsForObject(obj, service.Objects)
+					filterObjects := generateFilterObjectsForObject(obj, service.Objects, service.FilterMaxDepth)
 					service.Objects = append(service.Objects, filterObjects...)
 					break
 				}
@@ -1152,14 +2592,29 @@ func ApplyFilterOverlay(input *Service) *Service {
 	result := &Service{
 		Name:            input.Name,
 		Version:         input.Version,
+		SchemaVersion:   input.SchemaVersion,
 		Contact:         input.Contact,                               // Copy contact information
 		License:         input.License,                               // Copy license information
+		ExternalDocs:    input.ExternalDocs,                          // Copy external documentation
+		TermsOfService:  input.TermsOfService,                        // Copy terms of service
 		Servers:         append([]ServiceServer{}, input.Servers...), // Copy servers slice
+		BasePath:        input.BasePath,                              // Copy base path
+		Naming:          input.Naming,                                // Copy naming conventions
+		Strict:          input.Strict,                                // Copy strict setting
 		SecuritySchemes: input.SecuritySchemes,                       // Copy security schemes
 		Security:        input.Security,                              // Copy security requirements
 		Retry:           input.Retry,                                 // Copy retry configuration
 		Timeout:         input.Timeout,                               // Copy timeout configuration
+		MaxBodyBytes:    input.MaxBodyBytes,                          // Copy request body size limit
+		HandlerTimeout:  input.HandlerTimeout,                        // Copy handler timeout
+		FilterMaxDepth:  input.FilterMaxDepth,                        // Copy max depth of the nested filters
+		MaxLimit:        input.MaxLimit,                              // Copy max limit of the pagination
+		Tenancy:         input.Tenancy,                               // Copy tenancy configuration
+		Operational:     input.Operational,                           // Copy operational endpoints configuration
+		ErrorFormat:     input.ErrorFormat,                           // Copy error format
+		Localization:    input.Localization,                          // Copy localization of the error messages
 		ResponseHeaders: append([]Field{}, input.ResponseHeaders...), // Copy response headers
+		ParameterGroups: input.ParameterGroups,                       // Copy parameter groups
 		Enums:           make([]Enum, len(input.Enums)),
 		Objects:         make([]Object, 0, len(input.Objects)*7), // Estimate for filter objects
 		Resources:       make([]Resource, len(input.Resources)),
@@ -1189,7 +2644,7 @@ func ApplyFilterOverlay(input *Service) *Service {
 			}
 
 			for _, field := range object.Fields {
-				if hasFilter[field.Type] {
+				if hasFilter[field.Type] || resource.hasExpansion(field.Name) {
 					continue
 				}
 
@@ -1198,7 +2653,7 @@ func ApplyFilterOverlay(input *Service) *Service {
 						continue
 					}
 
-					filterObjects := generateFilterObjectsForObject(fieldObject, result.Objects)
+					filterObjects := generateFilterObjectsForObject(fieldObject, result.Objects, result.FilterMaxDepth)
 					result.Objects = append(result.Objects, filterObjects...)
 
 					hasFilter[fieldObject.Name] = true
@@ -1211,11 +2666,11 @@ func ApplyFilterOverlay(input *Service) *Service {
 }
 
 // generateFilterObjectsForObject generates all filter-related objects for a given object.
-func generateFilterObjectsForObject(obj Object, allObjects []Object) []Object {
+func generateFilterObjectsForObject(obj Object, allObjects []Object, maxDepth int) []Object {
 	var filterObjects []Object
 
 	// Generate main filter object
-	mainFilter := generateMainFilterObject(obj)
+	mainFilter := generateMainFilterObject(obj, maxDepth)
 	filterObjects = append(filterObjects, mainFilter)
 
 	// Generate specialized filter objects
@@ -1231,7 +2686,13 @@ func generateFilterObjectsForObject(obj Object, allObjects []Object) []Object {
 }
 
 // generateMainFilterObject creates the main filter object with all filter type references.
-func generateMainFilterObject(obj Object) Object {
+// The description of the nested filters states the max depth, if the service limits it.
+func generateMainFilterObject(obj Object, maxDepth int) Object {
+	nestedFiltersDescription := descriptionNestedFiltersTemplate + obj.Name + descriptionNestedFiltersSuffix
+	if maxDepth > 0 {
+		nestedFiltersDescription += fmt.Sprintf(descriptionNestedFiltersMaxDepthTemplate, maxDepth)
+	}
+
 	return Object{
 		Name:        obj.Name + filterSuffix,
 		Description: descriptionFilterObject + obj.Name,
@@ -1246,10 +2707,13 @@ func generateMainFilterObject(obj Object) Object {
 			createFilterField(filterFieldNotContains, descriptionNotContainsFilters+obj.Name, obj.Name+filterContainsSuffix, true),
 			createFilterField(filterFieldLike, descriptionLikeFilters+obj.Name, obj.Name+filterLikeSuffix, true),
 			createFilterField(filterFieldNotLike, descriptionNotLikeFilters+obj.Name, obj.Name+filterLikeSuffix, true),
+			createFilterField(filterFieldStartsWith, descriptionStartsWithFilters+obj.Name, obj.Name+filterLikeSuffix, true),
+			createFilterField(filterFieldNotStartsWith, descriptionNotStartsWithFilters+obj.Name, obj.Name+filterLikeSuffix, true),
 			createFilterField(filterFieldNull, descriptionNullFilters+obj.Name, obj.Name+filterNullSuffix, true),
 			createFilterField(filterFieldNotNull, descriptionNotNullFilters+obj.Name, obj.Name+filterNullSuffix, true),
 			createFilterField(filterFieldOrCondition, descriptionOrCondition, FieldTypeBool, false),
-			createFilterField(filterFieldNestedFilters, descriptionNestedFiltersTemplate+obj.Name+descriptionNestedFiltersSuffix, obj.Name+filterSuffix, false, ModifierArray),
+			createFilterField(filterFieldNotCondition, descriptionNotCondition, FieldTypeBool, false),
+			createFilterField(filterFieldNestedFilters, nestedFiltersDescription, obj.Name+filterSuffix, false, ModifierArray),
 		},
 	}
 }
@@ -1407,6 +2871,59 @@ func (f ResourceField) HasUpdateOperation() bool {
 	return slices.Contains(f.Operations, OperationUpdate)
 }
 
+// GetValue returns the value of the field in the example, looked up by the field name or its JSON tag.
+func (e ResourceExample) GetValue(field Field) (any, bool) {
+	if value, ok := e.Values[field.Name]; ok {
+		return value, true
+	}
+
+	value, ok := e.Values[field.TagJSON()]
+	return value, ok
+}
+
+// IsRequiredOn checks if the ResourceField is required in the given operation.
+func (f ResourceField) IsRequiredOn(operation string) bool {
+	return slices.Contains(f.RequiredOn, operation)
+}
+
+// Enum methods
+
+// GetGoName returns the name of the Go constant of the enum value, the enum name followed by the value name
+// as a Go identifier, e.g. StatusInProgress for the in_progress value of Status.
+func (v EnumValue) GetGoName(enumName string) string {
+	return enumName + capitalizeWords(Transliterate(v.Name))
+}
+
+// GetValue returns the wire value of the enum value, the name unless a value is set.
+func (v EnumValue) GetValue() string {
+	if v.Value != "" {
+		return v.Value
+	}
+	return v.Name
+}
+
+// IsInt checks if the Enum is backed by integers.
+func (e Enum) IsInt() bool {
+	return e.EnumType == EnumTypeInt
+}
+
+// HasAliases checks if any value of the Enum has deprecated aliases.
+func (e Enum) HasAliases() bool {
+	return slices.ContainsFunc(e.Values, func(value EnumValue) bool {
+		return len(value.Aliases) > 0
+	})
+}
+
+// GetWireValue returns the wire value of the enum value with the given name, or the name if there is no such value.
+func (e Enum) GetWireValue(name string) string {
+	for _, value := range e.Values {
+		if value.Name == name {
+			return value.GetValue()
+		}
+	}
+	return name
+}
+
 // Field methods
 
 // IsArray checks if the Field has the array modifier.
@@ -1419,8 +2936,30 @@ func (t Field) IsNullable() bool {
 	return slices.Contains(t.Modifiers, ModifierNullable)
 }
 
-// TagJSON returns the JSON tag name for the field in camelCase.
+// IsOptional checks if the Field has been marked as optional for the operation of its endpoint.
+func (t Field) IsOptional() bool {
+	return slices.Contains(t.Modifiers, ModifierOptional)
+}
+
+// GetGoName returns the name of the field in Go code, the name as an exported Go identifier, see GoIdentifier.
+func (t Field) GetGoName() string {
+	return GoIdentifier(t.Name)
+}
+
+// GetHeaderName returns the name of the response header of the field on the wire, the header name if set,
+// otherwise the name.
+func (t Field) GetHeaderName() string {
+	if t.HeaderName != "" {
+		return t.HeaderName
+	}
+	return t.Name
+}
+
+// TagJSON returns the JSON tag name for the field, the JSON name if set, otherwise the name in camelCase.
 func (t Field) TagJSON() string {
+	if t.JSONName != "" {
+		return t.JSONName
+	}
 	return CamelCase(t.Name)
 }
 
@@ -1436,6 +2975,10 @@ func (f Field) IsRequired(service *Service) bool {
 		return true
 	}
 
+	if f.IsOptional() {
+		return false
+	}
+
 	if f.IsNullable() {
 		return false
 	}
@@ -1468,8 +3011,14 @@ func getDefaultExample(fieldType string) string {
 		return defaultExampleString
 	case FieldTypeInt:
 		return defaultExampleInt
+	case FieldTypeInt32:
+		return defaultExampleInt32
+	case FieldTypeUInt:
+		return defaultExampleUInt
 	case FieldTypeFloat64:
 		return defaultExampleFloat64
+	case FieldTypeDecimal:
+		return defaultExampleDecimal
 	case FieldTypeBool:
 		return defaultExampleBool
 	default:
@@ -1478,11 +3027,42 @@ func getDefaultExample(fieldType string) string {
 	}
 }
 
-// ensureExample ensures that the field has an example, setting a default one for primitive types if none exists.
-func (f *Field) ensureExample() {
+// getDerivedExample returns the example of a UUID, Date or Timestamp field of the owner, which is an object or
+// a resource, derived from a hash of their names. The example is stable between runs, and differs between fields.
+// Returns the default example for other field types.
+func getDerivedExample(owner, fieldName, fieldType string) string {
+	hash := fnv.New128a()
+	hash.Write([]byte(owner))
+	hash.Write([]byte{0})
+	hash.Write([]byte(fieldName))
+	sum := hash.Sum(nil)
+
+	switch fieldType {
+	case FieldTypeUUID:
+		// Formatted as a version 4 UUID with the RFC 9562 variant
+		sum[6] = sum[6]&0x0f | 0x40
+		sum[8] = sum[8]&0x3f | 0x80
+		return fmt.Sprintf("%x-%x-%x-%x-%x", sum[0:4], sum[4:6], sum[6:8], sum[8:10], sum[10:16])
+	case FieldTypeDate, FieldTypeTimestamp:
+		// A second in the year of the default examples
+		base := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+		seconds := binary.BigEndian.Uint64(sum[:8]) % uint64(365*24*time.Hour/time.Second)
+		timestamp := base.Add(time.Duration(seconds) * time.Second)
+		if fieldType == FieldTypeDate {
+			return timestamp.Format(time.DateOnly)
+		}
+		return timestamp.Format(time.RFC3339)
+	default:
+		return getDefaultExample(fieldType)
+	}
+}
+
+// ensureExample ensures that the field of the owner, which is an object or a resource, has an example,
+// setting a default one for primitive types if none exists.
+func (f *Field) ensureExample(owner string) {
 	// Only set default examples for primitive types and only if no example already exists
 	if f.Example == "" && isPrimitiveType(f.Type) {
-		f.Example = getDefaultExample(f.Type)
+		f.Example = getDerivedExample(owner, f.Name, f.Type)
 	}
 }
 
@@ -1505,14 +3085,15 @@ func (e EndpointRequest) GetRequiredBodyParams(service *Service) []string {
 
 // Endpoint methods
 
-// GetFullPath returns the full path for the endpoint including the resource name.
+// GetFullPath returns the full path for the endpoint including the resource name in kebab-case,
+// see Service.GetEndpointPath for the path following the naming conventions and parents of the resource.
 func (e Endpoint) GetFullPath(resourceName string) string {
 	return pathSeparator + toKebabCase(resourceName) + e.Path
 }
 
 func (e Endpoint) GetPathParamsType(resourceName string) string {
 	if len(e.Request.PathParams) > 0 {
-		return resourceName + e.Name + "PathParams"
+		return e.GetTypeName(resourceName) + "PathParams"
 	}
 
 	return "struct{}"
@@ -1520,7 +3101,7 @@ func (e Endpoint) GetPathParamsType(resourceName string) string {
 
 func (e Endpoint) GetQueryParamsType(resourceName string) string {
 	if len(e.Request.QueryParams) > 0 {
-		return resourceName + e.Name + "QueryParams"
+		return e.GetTypeName(resourceName) + "QueryParams"
 	}
 
 	return "struct{}"
@@ -1528,14 +3109,79 @@ func (e Endpoint) GetQueryParamsType(resourceName string) string {
 
 func (e Endpoint) GetBodyParamsType(resourceName string) string {
 	if len(e.Request.BodyParams) > 0 {
-		return resourceName + e.Name + "BodyParams"
+		return e.GetTypeName(resourceName) + "BodyParams"
 	}
 
 	return "struct{}"
 }
 
 func (e Endpoint) GetRequestType(resourceName string) string {
-	return resourceName + e.Name + "Request"
+	return e.GetTypeName(resourceName) + "Request"
+}
+
+// GetTypeName returns the prefix of the generated request and response types of the endpoint, the type name
+// if it is set and otherwise the resource name followed by the endpoint name.
+func (e Endpoint) GetTypeName(resourceName string) string {
+	if e.TypeName != "" {
+		return e.TypeName
+	}
+
+	return resourceName + e.Name
+}
+
+// getDeclaredTypes returns the request and response types the generated server declares for the endpoint,
+// leaving out the empty ones and the response objects.
+func (e Endpoint) getDeclaredTypes(resourceName string) []string {
+	var names []string
+	for _, name := range []string{e.GetPathParamsType(resourceName), e.GetQueryParamsType(resourceName), e.GetBodyParamsType(resourceName)} {
+		if name != "struct{}" {
+			names = append(names, name)
+		}
+	}
+
+	if e.Response.BodyObject == nil && len(e.Response.BodyFields) > 0 {
+		names = append(names, e.GetResponseType(resourceName))
+	}
+
+	return names
+}
+
+// GetSDKMethodName returns the name of the endpoint method in generated SDKs.
+func (e Endpoint) GetSDKMethodName() string {
+	if e.SDKMethodName != "" {
+		return e.SDKMethodName
+	}
+
+	return CamelCase(e.Name)
+}
+
+// HasCSVResponse returns whether the endpoint streams its response as CSV, with a row per BodyObject.
+func (e Endpoint) HasCSVResponse() bool {
+	return e.Response.ContentType == ContentTypeCSV
+}
+
+// GetHeaderFields returns the response headers that the handler sets through fields of the response type, which
+// are the headers of responses with body fields.
+func (r EndpointResponse) GetHeaderFields() []Field {
+	if len(r.BodyFields) == 0 {
+		return nil
+	}
+	return r.Headers
+}
+
+// HasXMLRequest returns whether the body params of the endpoint are sent as XML instead of JSON.
+func (e Endpoint) HasXMLRequest() bool {
+	return e.Request.ContentType == ContentTypeXML
+}
+
+// HasFormRequest returns whether the body params of the endpoint are sent as a form instead of JSON.
+func (e Endpoint) HasFormRequest() bool {
+	return e.Request.ContentType == ContentTypeForm
+}
+
+// HasXMLResponse returns whether the endpoint responds with its body as XML instead of JSON.
+func (e Endpoint) HasXMLResponse() bool {
+	return e.Response.ContentType == ContentTypeXML
 }
 
 func (e Endpoint) HasResponseType() bool {
@@ -1548,7 +3194,7 @@ func (e Endpoint) GetResponseType(resourceName string) string {
 	}
 
 	if len(e.Response.BodyFields) > 0 {
-		return resourceName + e.Name + "Response"
+		return e.GetTypeName(resourceName) + "Response"
 	}
 
 	return "struct{}"
@@ -1576,6 +3222,12 @@ func (r Resource) HasListOperation() bool {
 	return slices.Contains(r.Operations, OperationList)
 }
 
+// HasTotalCountHeader returns whether List and Search return the total number of items in the X-Total-Count
+// header instead of the Pagination object, see TotalCountHeader.
+func (r Resource) HasTotalCountHeader() bool {
+	return r.TotalCount == TotalCountHeader
+}
+
 // HasSearchOperation checks if the Resource supports Search operations.
 func (r Resource) HasSearchOperation() bool {
 	return slices.Contains(r.Operations, OperationSearch)
@@ -1602,14 +3254,28 @@ func (r Resource) GetPluralName() string {
 	return strmangle.Plural(r.Name)
 }
 
+// HasParent checks if the Resource is nested under a parent resource.
+func (r Resource) HasParent() bool {
+	return r.Parent != ""
+}
+
+// GetSDKGroup returns the name of the group of the resource methods in generated SDKs.
+func (r Resource) GetSDKGroup() string {
+	if r.SDKGroup != "" {
+		return r.SDKGroup
+	}
+
+	return CamelCase(r.GetPluralName())
+}
+
 // GetCreateBodyParams returns all fields that support Create operations.
 func (r Resource) GetCreateBodyParams() []Field {
-	return r.getFieldsByOperation(ResourceField.HasCreateOperation)
+	return r.getBodyParamsByOperation(OperationCreate, ResourceField.HasCreateOperation)
 }
 
 // GetUpdateBodyParams returns all fields that support Update operations.
 func (r Resource) GetUpdateBodyParams() []Field {
-	return r.getFieldsByOperation(ResourceField.HasUpdateOperation)
+	return r.getBodyParamsByOperation(OperationUpdate, ResourceField.HasUpdateOperation)
 }
 
 // GetReadableFields returns all fields that support Read operations.
@@ -1629,6 +3295,32 @@ func (r Resource) getFieldsByOperation(operationCheck func(ResourceField) bool)
 	return result
 }
 
+// getBodyParamsByOperation filters ResourceFields by operation like getFieldsByOperation,
+// and marks the fields declaring RequiredOn as required or optional for the operation.
+func (r Resource) getBodyParamsByOperation(operation string, operationCheck func(ResourceField) bool) []Field {
+	var result []Field
+	for _, resourceField := range r.Fields {
+		if !operationCheck(resourceField) {
+			continue
+		}
+
+		field := r.convertResourceFieldToField(resourceField)
+
+		// The scopes only restrict reading the field, it is written like the other fields
+		field.Scopes = nil
+
+		if len(resourceField.RequiredOn) > 0 {
+			if resourceField.IsRequiredOn(operation) {
+				field.Modifiers = append(field.Modifiers, ModifierRequired)
+			} else {
+				field.Modifiers = append(field.Modifiers, ModifierOptional)
+			}
+		}
+		result = append(result, field)
+	}
+	return result
+}
+
 // convertResourceFieldToField converts a ResourceField to a Field by copying the embedded Field data.
 func (r Resource) convertResourceFieldToField(resourceField ResourceField) Field {
 	field := Field{
@@ -1638,9 +3330,11 @@ func (r Resource) convertResourceFieldToField(resourceField ResourceField) Field
 		Default:     resourceField.Default,
 		Example:     resourceField.Example,
 		Modifiers:   make([]string, len(resourceField.Modifiers)),
+		Scopes:      slices.Clone(resourceField.Scopes),
+		References:  resourceField.References,
 	}
 	copy(field.Modifiers, resourceField.Modifiers)
-	field.ensureExample()
+	field.ensureExample(r.Name)
 	return field
 }
 
@@ -1654,937 +3348,3876 @@ func (r Resource) HasEndpoint(name string) bool {
 	return false
 }
 
-// Service methods
+// GetAggregationObjectName returns the name of the object of the response of the Aggregate endpoint of the resource.
+func (r Resource) GetAggregationObjectName() string {
+	return r.Name + aggregationObjectSuffix
+}
 
-// IsObject checks if the given field type represents a custom object.
-func (s *Service) IsObject(fieldType string) bool {
-	return isObjectType(fieldType, s.Objects)
+// getResourceField returns the field of the resource with the given name, or nil if there is none.
+func (r Resource) getResourceField(name string) *ResourceField {
+	for i := range r.Fields {
+		if r.Fields[i].Name == name {
+			return &r.Fields[i]
+		}
+	}
+	return nil
 }
 
-// HasObject checks if the service contains an object with the given name.
-func (s *Service) HasObject(name string) bool {
-	for _, obj := range s.Objects {
-		if obj.Name == name {
-			return true
+// GetSearchableTextFields returns the fields of the resource that are part of the full-text search of its Search endpoint.
+func (r Resource) GetSearchableTextFields() []ResourceField {
+	var fields []ResourceField
+	for _, field := range r.Fields {
+		if field.SearchableText {
+			fields = append(fields, field)
 		}
 	}
-	return false
+	return fields
 }
 
-// HasEnum checks if the service contains an enum with the given name.
-func (s *Service) HasEnum(name string) bool {
-	for _, enum := range s.Enums {
-		if enum.Name == name {
-			return true
+// GetExpansionEnumName returns the name of the enum of the expansions of the resource, e.g. OrdersExpansion.
+func (r Resource) GetExpansionEnumName() string {
+	return r.Name + expansionEnumSuffix
+}
+
+// hasExpansion returns true if the resource has an expansion with the given name.
+func (r Resource) hasExpansion(name string) bool {
+	return slices.ContainsFunc(r.Expansions, func(expansion Expansion) bool { return expansion.Name == name })
+}
+
+// getExpansionDescription returns the description of the expansion, or a description of the related resource.
+func (r Resource) getExpansionDescription(expansion Expansion) string {
+	if expansion.Description != "" {
+		return expansion.Description
+	}
+
+	for _, field := range r.Fields {
+		if field.Name == expansion.Field {
+			return fmt.Sprintf(expansionFieldDescTemplate, field.References, field.Name)
 		}
 	}
-	return false
+	return ""
 }
 
-// GetObject returns the object with the given name, or nil if not found.
-func (s *Service) GetObject(name string) *Object {
-	for _, obj := range s.Objects {
-		if obj.Name == name {
-			return &obj
+// GetExpandParam returns the Expand query parameter the overlay adds to the endpoint of a resource with expansions,
+// or nil if the endpoint has none.
+func (e Endpoint) GetExpandParam(resource Resource) *Field {
+	for i, param := range e.Request.QueryParams {
+		if param.Name == expandParamName && param.Type == resource.GetExpansionEnumName() {
+			return &e.Request.QueryParams[i]
 		}
 	}
 	return nil
 }
 
-// Object methods
+// GetFilterParam returns the Filter body parameter the overlay adds to the Search endpoint of a resource,
+// or nil if the endpoint has none.
+func (e Endpoint) GetFilterParam(resource Resource) *Field {
+	for i, param := range e.Request.BodyParams {
+		if param.Name == searchFilterParamName && param.Type == resource.Name+filterSuffix {
+			return &e.Request.BodyParams[i]
+		}
+	}
+	return nil
+}
 
-// HasField checks if the object contains a field with the given name.
-func (o Object) HasField(name string) bool {
-	for _, field := range o.Fields {
-		if field.Name == name {
+// Service methods
+
+// IsObject checks if the given field type represents a custom object.
+func (s *Service) IsObject(fieldType string) bool {
+	return isObjectType(fieldType, s.Objects)
+}
+
+// HasFieldType checks if any object, endpoint or response header in the service uses the given field type.
+func (s *Service) HasFieldType(fieldType string) bool {
+	hasType := func(fields []Field) bool {
+		return slices.ContainsFunc(fields, func(field Field) bool {
+			return field.Type == fieldType
+		})
+	}
+
+	if hasType(s.ResponseHeaders) {
+		return true
+	}
+
+	for _, object := range s.Objects {
+		if hasType(object.Fields) {
 			return true
 		}
 	}
+
+	for _, resource := range s.Resources {
+		for _, endpoint := range resource.Endpoints {
+			if hasType(endpoint.Request.Headers) || hasType(endpoint.Request.PathParams) ||
+				hasType(endpoint.Request.QueryParams) || hasType(endpoint.Request.BodyParams) {
+				return true
+			}
+			if hasType(endpoint.Response.Headers) || hasType(endpoint.Response.BodyFields) {
+				return true
+			}
+		}
+	}
+
 	return false
 }
 
-// GetField returns the field with the given name, or nil if not found.
-func (o Object) GetField(name string) *Field {
-	for _, field := range o.Fields {
-		if field.Name == name {
-			return &field
+// GetReferencedResources returns the sorted names of the resources referenced by the fields of any object, endpoint
+// or response header in the service.
+func (s *Service) GetReferencedResources() []string {
+	var names []string
+	add := func(fields []Field) {
+		for _, field := range fields {
+			if field.References != "" && !slices.Contains(names, field.References) {
+				names = append(names, field.References)
+			}
 		}
 	}
-	return nil
-}
 
-// IsFilter checks if the object is a filter object.
-func (o Object) IsFilter() bool {
-	return strings.HasSuffix(o.Name, filterSuffix) ||
-		strings.HasSuffix(o.Name, filterEqualsSuffix) ||
-		strings.HasSuffix(o.Name, filterRangeSuffix) ||
-		strings.HasSuffix(o.Name, filterContainsSuffix) ||
-		strings.HasSuffix(o.Name, filterLikeSuffix) ||
-		strings.HasSuffix(o.Name, filterNullSuffix)
-}
+	add(s.ResponseHeaders)
+	for _, object := range s.Objects {
+		add(object.Fields)
+	}
+	for _, resource := range s.Resources {
+		for _, endpoint := range resource.Endpoints {
+			add(endpoint.Request.Headers)
+			add(endpoint.Request.PathParams)
+			add(endpoint.Request.QueryParams)
+			add(endpoint.Request.BodyParams)
+			add(endpoint.Response.Headers)
+			add(endpoint.Response.BodyFields)
+		}
+	}
 
-func (o Object) GetComment() string {
-	return getComment("", o.Description, o.Name)
+	slices.Sort(names)
+	return names
 }
 
-// Utility factory methods
+// GetUnusedEnums returns the names of the enums that are not referenced by any resource, endpoint, response header,
+// parameter group or used object, in the order they are declared. Enums of shared libraries are never unused,
+// since other services may reference them.
+func (s *Service) GetUnusedEnums() []string {
+	used := s.getUsedDefinitions()
 
-// createLimitParam creates a standard limit parameter for pagination.
-func createLimitParam() Field {
-	return Field{
-		Name:        listLimitParamName,
-		Description: listLimitParamDesc,
-		Type:        FieldTypeInt,
-		Default:     listLimitDefaultValue,
-		Example:     listLimitExampleValue,
+	var names []string
+	for _, enum := range s.Enums {
+		if enum.Library == "" && !used[enum.Name] {
+			names = append(names, enum.Name)
+		}
 	}
+	return names
 }
 
-// createListLimitParamForResource creates a limit parameter with resource-specific description for List operations.
-func createListLimitParamForResource(resource Resource) Field {
-	pluralResourceName := resource.GetPluralName()
-	resourceSpecificDescription := fmt.Sprintf(listLimitParamDescTemplate, pluralResourceName, pluralResourceName)
-	return Field{
-		Name:        listLimitParamName,
-		Description: resourceSpecificDescription,
-		Type:        FieldTypeInt,
-		Default:     listLimitDefaultValue,
-		Example:     listLimitExampleValue,
+// GetUnusedObjects returns the names of the objects that are not referenced by any resource, endpoint, response
+// header, parameter group or used object, in the order they are declared. An object only referenced by other unused
+// objects is also unused. Objects of shared libraries are never unused, since other services may reference them.
+func (s *Service) GetUnusedObjects() []string {
+	used := s.getUsedDefinitions()
+
+	var names []string
+	for _, object := range s.Objects {
+		if object.Library == "" && !used[object.Name] {
+			names = append(names, object.Name)
+		}
 	}
+	return names
 }
 
-// createSearchLimitParamForResource creates a limit parameter with resource-specific description for Search operations.
-func createSearchLimitParamForResource(resource Resource) Field {
-	pluralResourceName := resource.GetPluralName()
-	resourceSpecificDescription := fmt.Sprintf(searchLimitParamDescTemplate, pluralResourceName, pluralResourceName)
-	return Field{
-		Name:        listLimitParamName,
-		Description: resourceSpecificDescription,
-		Type:        FieldTypeInt,
-		Default:     listLimitDefaultValue,
-		Example:     listLimitExampleValue,
+// getUsedDefinitions returns the names of the enums and objects reachable from the resources, endpoints, response
+// headers and parameter groups of the service, through the fields, the parents and the filter objects of the objects.
+// The definitions the generators use for every service, such as the error objects, are always used.
+func (s *Service) getUsedDefinitions() map[string]bool {
+	used := map[string]bool{
+		errorCodeEnumName:       true,
+		errorObjectName:         true,
+		errorFieldObjectName:    true,
+		paginationObjectName:    true,
+		metaObjectName:          true,
+		OperationObjectName:     true,
+		operationStatusEnumName: true,
+	}
+
+	var pending []string
+	use := func(name string) {
+		if name != "" && !used[name] {
+			used[name] = true
+			pending = append(pending, name)
+		}
+	}
+	useFields := func(fields []Field) {
+		for _, field := range fields {
+			use(field.Type)
+		}
+	}
+
+	useFields(s.ResponseHeaders)
+	for _, group := range s.ParameterGroups {
+		useFields(group.Fields)
+	}
+	for _, resource := range s.Resources {
+		for _, field := range resource.Fields {
+			use(field.Type)
+		}
+		for _, endpoint := range resource.Endpoints {
+			useFields(endpoint.Request.Headers)
+			useFields(endpoint.Request.PathParams)
+			useFields(endpoint.Request.QueryParams)
+			useFields(endpoint.Request.BodyParams)
+			useFields(endpoint.Response.Headers)
+			useFields(endpoint.Response.BodyFields)
+			if endpoint.Response.BodyObject != nil {
+				use(*endpoint.Response.BodyObject)
+			}
+			for _, callback := range endpoint.Callbacks {
+				use(callback.Payload)
+			}
+		}
+	}
+
+	// The always used objects may reference other definitions too
+	for name := range used {
+		pending = append(pending, name)
 	}
+
+	for len(pending) > 0 {
+		name := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+
+		object := s.GetObject(name)
+		if object == nil {
+			continue
+		}
+		use(object.Extends)
+		useFields(object.Fields)
+
+		// The filter overlay generates the filter object of every object again when the service is parsed
+		use(object.Name + filterSuffix)
+	}
+
+	return used
 }
 
-// createOffsetParam creates a standard offset parameter for pagination.
-func createOffsetParam() Field {
-	return Field{
-		Name:        listOffsetParamName,
-		Description: listOffsetParamDesc,
-		Type:        FieldTypeInt,
-		Default:     listOffsetDefaultValue,
-		Example:     listOffsetExampleValue,
+// PruneUnusedDefinitions returns a copy of the service without the enums and objects that are not used,
+// see GetUnusedEnums and GetUnusedObjects.
+func PruneUnusedDefinitions(service *Service) *Service {
+	unusedEnums, unusedObjects := service.GetUnusedEnums(), service.GetUnusedObjects()
+
+	pruned := *service
+	pruned.Enums = slices.DeleteFunc(slices.Clone(service.Enums), func(enum Enum) bool {
+		return slices.Contains(unusedEnums, enum.Name)
+	})
+	pruned.Objects = slices.DeleteFunc(slices.Clone(service.Objects), func(object Object) bool {
+		return slices.Contains(unusedObjects, object.Name)
+	})
+	return &pruned
+}
+
+// hasEnumValue checks if the enum with the given name contains the given wire value.
+func (s *Service) hasEnumValue(enumName, value string) bool {
+	for _, enum := range s.Enums {
+		if enum.Name == enumName {
+			return slices.ContainsFunc(enum.Values, func(enumValue EnumValue) bool {
+				return enumValue.GetValue() == value
+			})
+		}
 	}
+	return false
 }
 
-// createListOffsetParamForResource creates an offset parameter with resource-specific description for List operations.
-func createListOffsetParamForResource(resource Resource) Field {
-	pluralResourceName := resource.GetPluralName()
-	resourceSpecificDescription := fmt.Sprintf(listOffsetParamDescTemplate, pluralResourceName, pluralResourceName)
-	return Field{
-		Name:        listOffsetParamName,
-		Description: resourceSpecificDescription,
-		Type:        FieldTypeInt,
-		Default:     listOffsetDefaultValue,
-		Example:     listOffsetExampleValue,
+// HasObject checks if the service contains an object with the given name.
+func (s *Service) HasObject(name string) bool {
+	for i := range s.Objects {
+		if s.Objects[i].Name == name {
+			return true
+		}
 	}
+	return false
 }
 
-// createSearchOffsetParamForResource creates an offset parameter with resource-specific description for Search operations.
-func createSearchOffsetParamForResource(resource Resource) Field {
-	pluralResourceName := resource.GetPluralName()
-	resourceSpecificDescription := fmt.Sprintf(searchOffsetParamDescTemplate, pluralResourceName, pluralResourceName)
-	return Field{
-		Name:        listOffsetParamName,
-		Description: resourceSpecificDescription,
-		Type:        FieldTypeInt,
-		Default:     listOffsetDefaultValue,
-		Example:     listOffsetExampleValue,
+// GetEnum returns the enum with the given name, or nil if not found.
+func (s *Service) GetEnum(name string) *Enum {
+	for i := range s.Enums {
+		if s.Enums[i].Name == name {
+			enum := s.Enums[i]
+			return &enum
+		}
 	}
+	return nil
 }
 
-// createPaginationField creates a standard pagination field for responses.
-func createPaginationField() Field {
-	return Field{
-		Name:        paginationObjectName,
-		Description: "Pagination information",
-		Type:        paginationObjectName,
+// GetPrimitiveType returns the primitive field type the wire values of the field type are represented with:
+// String for enums, Int for enums backed by integers, and the field type itself for other types.
+func (s *Service) GetPrimitiveType(fieldType string) string {
+	enum := s.GetEnum(fieldType)
+	switch {
+	case enum == nil:
+		return fieldType
+	case enum.IsInt():
+		return FieldTypeInt
+	default:
+		return FieldTypeString
 	}
 }
 
-// createDataField creates a standard data field for array responses.
-func createDataField(resourceName string) Field {
-	return Field{
-		Name:        "Data",
-		Description: fmt.Sprintf("Array of %s objects", resourceName),
-		Type:        resourceName,
-		Modifiers:   []string{ModifierArray},
+// HasEnum checks if the service contains an enum with the given name.
+func (s *Service) HasEnum(name string) bool {
+	for i := range s.Enums {
+		if s.Enums[i].Name == name {
+			return true
+		}
 	}
+	return false
 }
 
-// createIDParam creates a standard ID parameter for path parameters.
-func createIDParam(description string) Field {
-	return Field{
-		Name:        "ID",
-		Description: description,
-		Type:        FieldTypeUUID,
+// GetSharedLibraries returns the names of the shared libraries the enums and objects of the service come from,
+// in the order of their first type.
+func (s *Service) GetSharedLibraries() []string {
+	var libraries []string
+	add := func(library string) {
+		if library != "" && !slices.Contains(libraries, library) {
+			libraries = append(libraries, library)
+		}
 	}
+	for _, enum := range s.Enums {
+		add(enum.Library)
+	}
+	for _, object := range s.Objects {
+		add(object.Library)
+	}
+	return libraries
 }
 
-// createAutoColumnID creates a standard ID field for auto-columns.
-func createAutoColumnID(resourceName string) Field {
+// GetSharedLibrary returns the enums and objects of the service that come from the shared library with the given
+// name, or nil if none does.
+func (s *Service) GetSharedLibrary(name string) *SharedLibrary {
+	library := SharedLibrary{Name: name}
+	for _, enum := range s.Enums {
+		if enum.Library == name {
+			library.Enums = append(library.Enums, enum)
+		}
+	}
+	for _, object := range s.Objects {
+		if object.Library == name {
+			library.Objects = append(library.Objects, object)
+		}
+	}
+	if name == "" || (len(library.Enums) == 0 && len(library.Objects) == 0) {
+		return nil
+	}
+	return &library
+}
+
+// GetResource returns the resource with the given name, or nil if not found.
+func (s *Service) GetResource(name string) *Resource {
+	for i := range s.Resources {
+		if s.Resources[i].Name == name {
+			resource := s.Resources[i]
+			return &resource
+		}
+	}
+	return nil
+}
+
+// GetParentResources returns the parents of the resource, from the outermost to the direct parent.
+// Parents that are not defined and cyclic parents end the chain.
+func (s *Service) GetParentResources(resource Resource) []Resource {
+	parents := []Resource{}
+	visited := map[string]bool{resource.Name: true}
+	for current := resource; current.HasParent() && !visited[current.Parent]; {
+		parent := s.GetResource(current.Parent)
+		if parent == nil {
+			break
+		}
+		visited[parent.Name] = true
+		parents = append([]Resource{*parent}, parents...)
+		current = *parent
+	}
+	return parents
+}
+
+// GetTagGroup returns the tag group of the resource, or of its closest parent with one, empty when there is none.
+func (s *Service) GetTagGroup(resource Resource) string {
+	if resource.TagGroup != "" {
+		return resource.TagGroup
+	}
+	parents := s.GetParentResources(resource)
+	for i := len(parents) - 1; i >= 0; i-- {
+		if parents[i].TagGroup != "" {
+			return parents[i].TagGroup
+		}
+	}
+	return ""
+}
+
+// GetParentPathParams returns the ID path parameters of the parents of the resource, from the outermost to the direct parent.
+func (s *Service) GetParentPathParams(resource Resource) []Field {
+	parents := s.GetParentResources(resource)
+	params := make([]Field, 0, len(parents))
+	for _, parent := range parents {
+		params = append(params, createParentIDParam(parent))
+	}
+	return params
+}
+
+// createParentIDParam creates the ID path parameter of the parent of a sub-resource.
+func createParentIDParam(parent Resource) Field {
 	return Field{
-		Name:        autoColumnIDName,
-		Description: fmt.Sprintf(autoColumnIDDescTemplate, resourceName),
+		Name:        parent.Name + parentIDParamSuffix,
+		Description: fmt.Sprintf(parentIDParamDescTemplate, parent.Name),
 		Type:        FieldTypeUUID,
-		Example:     "123e4567-e89b-12d3-a456-426614174000",
+		References:  parent.Name,
+	}
+}
+
+// GetEndpointPath returns the full path of the endpoint of the resource, prefixed with the paths of the parents
+// of the resource and their ID parameters, e.g. /post/{postID}/comment/{id}.
+func (s *Service) GetEndpointPath(resource Resource, endpoint Endpoint) string {
+	var prefix strings.Builder
+	for _, parent := range s.GetParentResources(resource) {
+		prefix.WriteString(pathSeparator + s.GetResourcePathName(parent) + pathSeparator + "{" + createParentIDParam(parent).TagJSON() + "}")
+	}
+	return prefix.String() + pathSeparator + s.GetResourcePathName(resource) + endpoint.Path
+}
+
+// GetResourcePathName returns the name of the resource in paths, following the naming conventions of the service,
+// e.g. user-account, or user_accounts with snake_case and plural paths.
+func (s *Service) GetResourcePathName(resource Resource) string {
+	name := resource.Name
+	if s.Naming.HasPluralPaths() {
+		name = resource.GetPluralName()
+	}
+	return s.Naming.FormatPathName(name)
+}
+
+// GetObject returns the object with the given name, or nil if not found.
+func (s *Service) GetObject(name string) *Object {
+	// Indexing instead of ranging by value only copies the matching object, which matters for large services
+	for i := range s.Objects {
+		if s.Objects[i].Name == name {
+			obj := s.Objects[i]
+			return &obj
+		}
+	}
+	return nil
+}
+
+// GetParameterGroup returns the parameter group with the given name, or nil if not found.
+func (s *Service) GetParameterGroup(name string) *ParameterGroup {
+	for i := range s.ParameterGroups {
+		if s.ParameterGroups[i].Name == name {
+			group := s.ParameterGroups[i]
+			return &group
+		}
+	}
+	return nil
+}
+
+// IsProblemDetails returns true if the errors of the service are written as Problem Details (RFC 9457).
+func (s *Service) IsProblemDetails() bool {
+	return s.ErrorFormat == ErrorFormatProblemJSON
+}
+
+// GetErrorMessageFieldName returns the name of the field of the Error object holding the human-readable message,
+// which is Detail for Problem Details when the Error object has it.
+func (s *Service) GetErrorMessageFieldName() string {
+	if s.IsProblemDetails() {
+		if errorObject := s.GetObject(errorObjectName); errorObject != nil && errorObject.HasField(problemDetailFieldName) {
+			return problemDetailFieldName
+		}
+	}
+	return errorMessageFieldName
+}
+
+// GetLastModifiedField returns the UpdatedAt field of the Meta of the object, the time the object was last updated,
+// or nil if the object has no Meta field or its Meta has no UpdatedAt timestamp.
+func (s *Service) GetLastModifiedField(objectName string) *Field {
+	object := s.GetObject(objectName)
+	if object == nil {
+		return nil
+	}
+
+	metaField := object.GetField(metaObjectName)
+	if metaField == nil || metaField.Type != metaObjectName || metaField.IsArray() || metaField.IsNullable() {
+		return nil
+	}
+
+	metaObject := s.GetObject(metaObjectName)
+	if metaObject == nil {
+		return nil
+	}
+
+	updatedAtField := metaObject.GetField(autoColumnUpdatedAtName)
+	if updatedAtField == nil || updatedAtField.Type != FieldTypeTimestamp || updatedAtField.IsArray() {
+		return nil
+	}
+	return updatedAtField
+}
+
+// CSVColumn is a column of the CSV responses with rows of an object, see Service.GetCSVColumns.
+type CSVColumn struct {
+	// Name of the column in the header row, the JSON names of the fields joined by dots, e.g. "meta.createdAt"
+	Name string
+
+	// FieldPath is the Go names of the fields from the object of the row to the field of the column, e.g. ["Meta", "CreatedAt"]
+	FieldPath []string
+
+	// Field of the column
+	Field Field
+}
+
+// GetCSVColumns returns the columns of the CSV responses with rows of the object, a column per field in the order of
+// the fields. The fields of nested objects are flattened into a column each, unless the nested object is nullable
+// or an array, whose column holds it as JSON. The expanded fields of resources are left out, since the exports
+// do not expand them.
+func (s *Service) GetCSVColumns(objectName string) []CSVColumn {
+	return s.getCSVColumns(objectName, "", nil, map[string]bool{})
+}
+
+// getCSVColumns returns the CSV columns of the fields of the object, prefixed with the name and path of the field
+// holding it, skipping the objects that are already being flattened.
+func (s *Service) getCSVColumns(objectName, namePrefix string, pathPrefix []string, visited map[string]bool) []CSVColumn {
+	object := s.GetObject(objectName)
+	if object == nil || visited[objectName] {
+		return nil
+	}
+	visited[objectName] = true
+	defer delete(visited, objectName)
+
+	resource := s.GetResource(objectName)
+
+	var columns []CSVColumn
+	for _, field := range object.Fields {
+		if resource != nil && slices.ContainsFunc(resource.Expansions, func(expansion Expansion) bool { return expansion.Name == field.Name }) {
+			continue
+		}
+
+		name := namePrefix + field.TagJSON()
+		path := append(slices.Clone(pathPrefix), field.GetGoName())
+
+		if s.HasObject(field.Type) && !field.IsNullable() && !field.IsArray() && !visited[field.Type] {
+			columns = append(columns, s.getCSVColumns(field.Type, name+".", path, visited)...)
+			continue
+		}
+
+		columns = append(columns, CSVColumn{Name: name, FieldPath: path, Field: field})
+	}
+	return columns
+}
+
+// HasXMLRequests returns whether any endpoint of the service has its body params sent as XML.
+func (s *Service) HasXMLRequests() bool {
+	for _, resource := range s.Resources {
+		for _, endpoint := range resource.Endpoints {
+			if endpoint.HasXMLRequest() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// HasFormRequests returns whether any endpoint of the service has its body params sent as a form.
+func (s *Service) HasFormRequests() bool {
+	for _, resource := range s.Resources {
+		for _, endpoint := range resource.Endpoints {
+			if endpoint.HasFormRequest() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// HasXMLResponses returns whether any endpoint of the service responds with XML.
+func (s *Service) HasXMLResponses() bool {
+	for _, resource := range s.Resources {
+		for _, endpoint := range resource.Endpoints {
+			if endpoint.HasXMLResponse() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// HasXML returns whether any request or response of the service is serialized as XML, including the responses
+// with XML as an alternative content type.
+func (s *Service) HasXML() bool {
+	return s.HasXMLRequests() || s.HasXMLResponses() || slices.Contains(s.GetAlternativeContentTypes(), ContentTypeXML)
+}
+
+// GetAlternativeContentTypes returns the alternative content types of the responses of the service, sorted and
+// without duplicates.
+func (s *Service) GetAlternativeContentTypes() []string {
+	var contentTypes []string
+	for _, resource := range s.Resources {
+		for _, endpoint := range resource.Endpoints {
+			contentTypes = append(contentTypes, endpoint.Response.AlternativeContentTypes...)
+		}
+	}
+
+	slices.Sort(contentTypes)
+	return slices.Compact(contentTypes)
+}
+
+// GetTypeName returns the name of the type holding the query parameters of the group.
+func (g ParameterGroup) GetTypeName() string {
+	return g.Name + parameterGroupSuffix
+}
+
+// Object methods
+
+// HasField checks if the object contains a field with the given name.
+func (o Object) HasField(name string) bool {
+	for _, field := range o.Fields {
+		if field.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// GetField returns the field with the given name, or nil if not found.
+func (o Object) GetField(name string) *Field {
+	for i := range o.Fields {
+		if o.Fields[i].Name == name {
+			field := o.Fields[i]
+			return &field
+		}
+	}
+	return nil
+}
+
+// IsStrict returns true if the object rejects unknown fields, which is the strict setting of the service
+// unless the object sets it.
+func (s *Service) IsStrict(object Object) bool {
+	if object.Strict != nil {
+		return *object.Strict
+	}
+	return s.Strict
+}
+
+// HasStrictObjects returns true if the request bodies or any of the objects of the service reject unknown fields.
+func (s *Service) HasStrictObjects() bool {
+	for _, object := range s.Objects {
+		if s.IsStrict(object) {
+			return true
+		}
+	}
+	return s.Strict
+}
+
+// HasParent returns true if the object extends another object.
+func (o Object) HasParent() bool {
+	return o.Extends != ""
+}
+
+// GetOwnFields returns the fields declared by the object itself, excluding the fields inherited from its parent.
+func (o Object) GetOwnFields(service *Service) []Field {
+	if !o.HasParent() {
+		return o.Fields
+	}
+
+	parent := service.GetObject(o.Extends)
+	if parent == nil {
+		return o.Fields
+	}
+
+	inherited := service.getFlattenedFields(*parent, map[string]bool{o.Name: true})
+	var fields []Field
+	for _, field := range o.Fields {
+		if !containsFieldName(inherited, field.Name) {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// IsFilter checks if the object is a filter object.
+func (o Object) IsFilter() bool {
+	return strings.HasSuffix(o.Name, filterSuffix) ||
+		strings.HasSuffix(o.Name, filterEqualsSuffix) ||
+		strings.HasSuffix(o.Name, filterRangeSuffix) ||
+		strings.HasSuffix(o.Name, filterContainsSuffix) ||
+		strings.HasSuffix(o.Name, filterLikeSuffix) ||
+		strings.HasSuffix(o.Name, filterNullSuffix)
+}
+
+func (o Object) GetComment() string {
+	return getComment("", o.Description, o.Name)
+}
+
+// Utility factory methods
+
+// createLimitParam creates a standard limit parameter for pagination.
+func createLimitParam() Field {
+	return Field{
+		Name:        listLimitParamName,
+		Description: listLimitParamDesc,
+		Type:        FieldTypeInt,
+		Default:     listLimitDefaultValue,
+		Example:     listLimitExampleValue,
+	}
+}
+
+// createListLimitParamForResource creates a limit parameter with resource-specific description for List operations.
+func createListLimitParamForResource(resource Resource) Field {
+	pluralResourceName := resource.GetPluralName()
+	resourceSpecificDescription := fmt.Sprintf(listLimitParamDescTemplate, pluralResourceName, pluralResourceName)
+	return Field{
+		Name:        listLimitParamName,
+		Description: resourceSpecificDescription,
+		Type:        FieldTypeInt,
+		Default:     listLimitDefaultValue,
+		Example:     listLimitExampleValue,
+	}
+}
+
+// createSearchLimitParamForResource creates a limit parameter with resource-specific description for Search operations.
+func createSearchLimitParamForResource(resource Resource) Field {
+	pluralResourceName := resource.GetPluralName()
+	resourceSpecificDescription := fmt.Sprintf(searchLimitParamDescTemplate, pluralResourceName, pluralResourceName)
+	return Field{
+		Name:        listLimitParamName,
+		Description: resourceSpecificDescription,
+		Type:        FieldTypeInt,
+		Default:     listLimitDefaultValue,
+		Example:     listLimitExampleValue,
+	}
+}
+
+// createOffsetParam creates a standard offset parameter for pagination.
+func createOffsetParam() Field {
+	return Field{
+		Name:        listOffsetParamName,
+		Description: listOffsetParamDesc,
+		Type:        FieldTypeInt,
+		Default:     listOffsetDefaultValue,
+		Example:     listOffsetExampleValue,
+	}
+}
+
+// createListOffsetParamForResource creates an offset parameter with resource-specific description for List operations.
+func createListOffsetParamForResource(resource Resource) Field {
+	pluralResourceName := resource.GetPluralName()
+	resourceSpecificDescription := fmt.Sprintf(listOffsetParamDescTemplate, pluralResourceName, pluralResourceName)
+	return Field{
+		Name:        listOffsetParamName,
+		Description: resourceSpecificDescription,
+		Type:        FieldTypeInt,
+		Default:     listOffsetDefaultValue,
+		Example:     listOffsetExampleValue,
+	}
+}
+
+// createSearchOffsetParamForResource creates an offset parameter with resource-specific description for Search operations.
+func createSearchOffsetParamForResource(resource Resource) Field {
+	pluralResourceName := resource.GetPluralName()
+	resourceSpecificDescription := fmt.Sprintf(searchOffsetParamDescTemplate, pluralResourceName, pluralResourceName)
+	return Field{
+		Name:        listOffsetParamName,
+		Description: resourceSpecificDescription,
+		Type:        FieldTypeInt,
+		Default:     listOffsetDefaultValue,
+		Example:     listOffsetExampleValue,
+	}
+}
+
+// withMaxLimit returns the limit parameter with the max limit of the service in its description, unchanged without one.
+func withMaxLimit(limitParam Field, maxLimit int) Field {
+	if maxLimit > 0 {
+		limitParam.Description += fmt.Sprintf(maxLimitParamDescTemplate, maxLimit)
+	}
+	return limitParam
+}
+
+// createPaginationQueryParams creates the query parameters of List and Search, the limit and offset followed by
+// the query parameters of createListQueryParams.
+func createPaginationQueryParams(resource Resource, limitParam, offsetParam Field) []Field {
+	return append([]Field{limitParam, offsetParam}, createListQueryParams(resource)...)
+}
+
+// createListQueryParams creates the query parameters of List and Search besides the pagination, which are the
+// query parameters of Export: the IncludeDeleted flag for resources with SoftDelete.
+func createListQueryParams(resource Resource) []Field {
+	queryParams := []Field{}
+	if resource.SoftDelete {
+		queryParams = append(queryParams, Field{
+			Name:        includeDeletedParamName,
+			Description: fmt.Sprintf(includeDeletedParamDescTemplate, resource.GetPluralName()),
+			Type:        FieldTypeBool,
+			Default:     includeDeletedDefaultValue,
+			Example:     includeDeletedDefaultValue,
+		})
+	}
+	return queryParams
+}
+
+// createPaginationField creates a standard pagination field for responses.
+func createPaginationField() Field {
+	return Field{
+		Name:        paginationObjectName,
+		Description: "Pagination information",
+		Type:        paginationObjectName,
+	}
+}
+
+// createDataField creates a standard data field for array responses.
+func createDataField(resourceName string) Field {
+	return Field{
+		Name:        "Data",
+		Description: fmt.Sprintf("Array of %s objects", resourceName),
+		Type:        resourceName,
+		Modifiers:   []string{ModifierArray},
+	}
+}
+
+// createIDParam creates a standard ID parameter for path parameters.
+func createIDParam(description string) Field {
+	return Field{
+		Name:        "ID",
+		Description: description,
+		Type:        FieldTypeUUID,
+	}
+}
+
+// createAutoColumnID creates a standard ID field for auto-columns.
+func createAutoColumnID(resourceName string) Field {
+	return Field{
+		Name:        autoColumnIDName,
+		Description: fmt.Sprintf(autoColumnIDDescTemplate, resourceName),
+		Type:        FieldTypeUUID,
+		Example:     getDerivedExample(resourceName, autoColumnIDName, FieldTypeUUID),
+	}
+}
+
+// createAutoColumnCreatedAt creates a standard CreatedAt field for auto-columns.
+func createAutoColumnCreatedAt(resourceName string) Field {
+	return Field{
+		Name:        autoColumnCreatedAtName,
+		Description: fmt.Sprintf(autoColumnCreatedAtTemplate, resourceName),
+		Type:        FieldTypeTimestamp,
+		Example:     "2024-01-15T10:30:00Z",
+	}
+}
+
+// createAutoColumnCreatedBy creates a standard CreatedBy field for auto-columns.
+func createAutoColumnCreatedBy(resourceName string) Field {
+	return Field{
+		Name:        autoColumnCreatedByName,
+		Description: fmt.Sprintf(autoColumnCreatedByTemplate, resourceName),
+		Type:        FieldTypeUUID,
+		Modifiers:   []string{ModifierNullable},
+		Example:     "987fcdeb-51a2-43d1-b567-123456789abc",
+	}
+}
+
+// createAutoColumnUpdatedAt creates a standard UpdatedAt field for auto-columns.
+func createAutoColumnUpdatedAt(resourceName string) Field {
+	return Field{
+		Name:        autoColumnUpdatedAtName,
+		Description: fmt.Sprintf(autoColumnUpdatedAtTemplate, resourceName),
+		Type:        FieldTypeTimestamp,
+		Example:     "2024-01-15T14:45:00Z",
+	}
+}
+
+// createAutoColumnUpdatedBy creates a standard UpdatedBy field for auto-columns.
+func createAutoColumnUpdatedBy(resourceName string) Field {
+	return Field{
+		Name:        autoColumnUpdatedByName,
+		Description: fmt.Sprintf(autoColumnUpdatedByTemplate, resourceName),
+		Type:        FieldTypeUUID,
+		Modifiers:   []string{ModifierNullable},
+		Example:     "987fcdeb-51a2-43d1-b567-123456789abc",
+	}
+}
+
+// createSoftDeleteColumn creates the DeletedAt field of the objects of resources with SoftDelete.
+func createSoftDeleteColumn(resourceName string) Field {
+	return Field{
+		Name:        softDeleteColumnName,
+		Description: fmt.Sprintf(softDeleteColumnTemplate, resourceName),
+		Type:        FieldTypeTimestamp,
+		Modifiers:   []string{ModifierNullable},
+		Example:     "2024-01-20T09:15:00Z",
+	}
+}
+
+// createAutoColumns creates all standard auto-column fields for resources.
+func createAutoColumns(resourceName string) []Field {
+	return []Field{
+		createAutoColumnID(resourceName),
+		createAutoColumnCreatedAt(resourceName),
+		createAutoColumnCreatedBy(resourceName),
+		createAutoColumnUpdatedAt(resourceName),
+		createAutoColumnUpdatedBy(resourceName),
+	}
+}
+
+// createDefaultMeta creates a standard Meta object containing creation and update metadata fields.
+func createDefaultMeta() Object {
+	return Object{
+		Name:        metaObjectName,
+		Description: metaObjectDescription,
+		Fields: []Field{
+			{
+				Name:        autoColumnCreatedAtName,
+				Description: "Timestamp when the resource was created",
+				Type:        FieldTypeTimestamp,
+				Example:     "2024-01-15T10:30:00Z",
+			},
+			{
+				Name:        autoColumnCreatedByName,
+				Description: "User who created the resource",
+				Type:        FieldTypeUUID,
+				Modifiers:   []string{ModifierNullable},
+				Example:     "987fcdeb-51a2-43d1-b567-123456789abc",
+			},
+			{
+				Name:        autoColumnUpdatedAtName,
+				Description: "Timestamp when the resource was last updated",
+				Type:        FieldTypeTimestamp,
+				Modifiers:   []string{ModifierNullable},
+				Example:     "2024-01-15T14:45:00Z",
+			},
+			{
+				Name:        autoColumnUpdatedByName,
+				Description: "User who last updated the resource",
+				Type:        FieldTypeUUID,
+				Modifiers:   []string{ModifierNullable},
+				Example:     "987fcdeb-51a2-43d1-b567-123456789abc",
+			},
+		},
+	}
+}
+
+// withAutoColumns returns the auto-columns followed by the fields of the resource, leaving out the fields that declare
+// an auto-column themselves, such as an id of type UUID, which would get the Go name or JSON name of the auto-column.
+// The description of such a field replaces the one of the auto-column. Fields with another type are kept, and
+// reported as a name collision by ValidateSemantics.
+func withAutoColumns(autoColumns []Field, fields []Field) []Field {
+	result := append([]Field{}, autoColumns...)
+	for _, field := range fields {
+		index := slices.IndexFunc(autoColumns, func(autoColumn Field) bool {
+			return autoColumn.GetGoName() == field.GetGoName() || autoColumn.TagJSON() == field.TagJSON()
+		})
+		if index < 0 || autoColumns[index].Type != field.Type || autoColumns[index].IsArray() != field.IsArray() {
+			result = append(result, field)
+			continue
+		}
+
+		if field.Description != "" {
+			result[index].Description = field.Description
+		}
+	}
+	return result
+}
+
+// createAutoColumnsWithMeta creates auto-column fields using Meta object for metadata fields.
+func createAutoColumnsWithMeta(resourceName string) []Field {
+	return []Field{
+		createAutoColumnID(resourceName),
+		{
+			Name:        metaObjectName,
+			Description: fmt.Sprintf("Metadata information for the %s", resourceName),
+			Type:        metaObjectName,
+		},
+	}
+}
+
+// Helper functions
+
+// CamelCase converts a string to camelCase format.
+// Special cases:
+// - "ID" becomes "ID" instead of "iD"
+// - Consecutive capital letters at the start are lowercased (e.g., "CSNSchoolCode" -> "csnSchoolCode")
+func CamelCase(s string) string {
+	if s == "ID" {
+		return "id"
+	}
+
+	// strmangle lowercases the first byte, which would break a name starting with a multi-byte letter,
+	// so such a letter is replaced by an ASCII one while converting the rest, and lowercased here instead
+	first, size := utf8.DecodeRuneInString(s)
+	if first >= utf8.RuneSelf && unicode.IsLetter(first) {
+		return string(unicode.ToLower(first)) + strings.TrimPrefix(CamelCase("x"+s[size:]), "x")
+	}
+
+	result := strmangle.CamelCase(s)
+
+	// Handle consecutive capital letters at the beginning
+	// Convert sequences like "cSNSchool" to "csnSchool"
+	if len(result) > 1 {
+		runes := []rune(result)
+
+		// Find the end of consecutive uppercase letters at the beginning (after first char)
+		consecutiveEnd := 1
+		for consecutiveEnd < len(runes) && runes[consecutiveEnd] >= 'A' && runes[consecutiveEnd] <= 'Z' {
+			consecutiveEnd++
+		}
+
+		// If we have consecutive uppercase letters, convert them to lowercase
+		// except possibly the last one if it's followed by lowercase letters
+		if consecutiveEnd > 2 {
+			// If the sequence is followed by lowercase letters, keep the last uppercase letter
+			// Example: "cSNSchool" -> "csnSchool" (convert "SN" to "sn", keep "S" before "chool")
+			if consecutiveEnd < len(runes) && runes[consecutiveEnd] >= 'a' && runes[consecutiveEnd] <= 'z' {
+				consecutiveEnd--
+			}
+
+			// Convert consecutive uppercase letters to lowercase
+			for i := 1; i < consecutiveEnd; i++ {
+				if runes[i] >= 'A' && runes[i] <= 'Z' {
+					runes[i] = runes[i] - 'A' + 'a'
+				}
+			}
+
+			result = string(runes)
+		}
+	}
+
+	return result
+}
+
+// GoIdentifier returns the name as an exported Go identifier, for the names of fields and enum values that the
+// generators declare in Go code. A name that already is one, such as CreatedAt, is returned as it is. Characters
+// other than letters and digits separate words whose first letters are capitalized, so user_name and X-Request-ID
+// become UserName and XRequestID, and Go keywords such as type become Type. Letters with diacritics are
+// transliterated, so Förnamn becomes Fornamn, see Transliterate. A name that does not start with an upper case
+// letter after that, such as 2FA, is prefixed with X. The JSON names are not affected, see Field.TagJSON.
+func GoIdentifier(name string) string {
+	identifier := capitalizeWords(Transliterate(name))
+	if first, _ := utf8.DecodeRuneInString(identifier); !unicode.IsUpper(first) {
+		return goIdentifierPrefix + identifier
+	}
+	return identifier
+}
+
+// Transliterate returns the text with the Latin letters with diacritics replaced by their ASCII letters, such as
+// å, é and ß by a, e and ss, for the identifiers and paths derived from names written in other languages than
+// English. Combining marks are removed, and the other characters are kept as they are.
+func Transliterate(text string) string {
+	var builder strings.Builder
+	for _, r := range text {
+		if replacement, ok := transliterations[r]; ok {
+			builder.WriteString(replacement)
+		} else if !unicode.Is(unicode.Mn, r) {
+			builder.WriteRune(r)
+		}
+	}
+	return builder.String()
+}
+
+// capitalizeWords returns the letters and digits of the name, capitalizing the first letter of every word,
+// where the words are separated by the other characters.
+func capitalizeWords(name string) string {
+	var builder strings.Builder
+	capitalize := true
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			capitalize = true
+			continue
+		}
+		if capitalize {
+			r = unicode.ToUpper(r)
+			capitalize = false
+		}
+		builder.WriteRune(r)
+	}
+	return builder.String()
+}
+
+// toKebabCase converts a string to kebab-case format, transliterating the letters with diacritics.
+func toKebabCase(s string) string {
+	return kebabCase(Transliterate(s))
+}
+
+// toSnakeCase converts a string to snake_case format, keeping the letters with diacritics.
+func toSnakeCase(s string) string {
+	return strings.ReplaceAll(kebabCase(s), "-", "_")
+}
+
+// kebabCase converts a string to kebab-case format.
+func kebabCase(s string) string {
+	// Handle empty string
+	if s == "" {
+		return s
+	}
+
+	// First normalize spaces and underscores to hyphens, then handle PascalCase
+	normalized := strings.ReplaceAll(s, "_", "-")
+	normalized = strings.ReplaceAll(normalized, " ", "-")
+
+	// Convert PascalCase/camelCase to kebab-case
+	var result strings.Builder
+	runes := []rune(normalized)
+
+	for i, r := range runes {
+		// Insert hyphen before uppercase letters in these cases:
+		// 1. Before an uppercase letter that follows a lowercase letter or digit
+		// 2. Before the last uppercase letter in a sequence of uppercase letters if followed by lowercase
+		if i > 0 && r >= 'A' && r <= 'Z' && runes[i-1] != '-' {
+			prev := runes[i-1]
+
+			// Case 1: Previous character is lowercase or digit
+			if (prev >= 'a' && prev <= 'z') || (prev >= '0' && prev <= '9') {
+				result.WriteByte('-')
+			} else if prev >= 'A' && prev <= 'Z' {
+				// Case 2: Previous character is uppercase, check if current is followed by lowercase
+				if i+1 < len(runes) && runes[i+1] >= 'a' && runes[i+1] <= 'z' {
+					result.WriteByte('-')
+				}
+			}
+		}
+		result.WriteRune(r)
+	}
+
+	// Convert to lowercase
+	return strings.ToLower(result.String())
+}
+
+func getComment(tabs string, description string, name string) string {
+	comment := description
+
+	if !strings.HasPrefix(description, name) {
+		comment = name + ": " + description
+	}
+
+	// Every new line should be prefixed with a //
+	lines := strings.Split(comment, "\n")
+	for i, line := range lines {
+		lines[i] = tabs + "// " + line
+	}
+
+	comment = strings.Join(lines, "\n")
+	comment = strings.TrimSuffix(comment, tabs+"\n// ")
+
+	return comment
+}
+
+// Validation functions
+
+// ValidationError represents a validation error with position information.
+type ValidationError struct {
+	Message string
+	Line    int
+	Column  int
+
+	// Path of the offending node, e.g. $.resources[0].fields[1].type
+	Path string
+
+	// File is the path of the specification file, set when the service is parsed from a file
+	File string
+
+	// Code identifies the rule broken by the errors of ValidateSemantics, and is empty for the other errors
+	Code ValidationErrorCode
+}
+
+// ValidationErrorCode identifies the semantic rule broken by a validation error.
+type ValidationErrorCode string
+
+// Semantic validation error codes
+const (
+	// ValidationErrorUnknownType is a field type that is neither a primitive type nor a declared enum or object,
+	// or a response body object that is not a declared object
+	ValidationErrorUnknownType ValidationErrorCode = "unknown_type"
+
+	// ValidationErrorDuplicateEndpoint is an endpoint with the same method and path as another endpoint,
+	// where the names of the path parameters do not matter
+	ValidationErrorDuplicateEndpoint ValidationErrorCode = "duplicate_endpoint"
+
+	// ValidationErrorUnsupportedOperation is a Create, Update or Delete operation of a resource field
+	// that its resource does not support
+	ValidationErrorUnsupportedOperation ValidationErrorCode = "unsupported_operation"
+
+	// ValidationErrorNameCollision is an enum, object or generated type with the name of another type
+	// of the generated server
+	ValidationErrorNameCollision ValidationErrorCode = "name_collision"
+
+	// ValidationErrorInvalidIdentifier is an enum, object or generated type whose name is not a valid Go
+	// identifier, such as a name starting with a digit or a Go keyword
+	ValidationErrorInvalidIdentifier ValidationErrorCode = "invalid_identifier"
+)
+
+func (e *ValidationError) Error() string {
+	if e.Line > 0 && e.Column > 0 {
+		return fmt.Sprintf("validation error at line %d, column %d (%s): %s", e.Line, e.Column, e.Path, e.Message)
+	}
+	return fmt.Sprintf("validation error (%s): %s", e.Path, e.Message)
+}
+
+// ValidationErrors are all the validation errors of a service, in the order of the specification.
+type ValidationErrors []*ValidationError
+
+func (e ValidationErrors) Error() string {
+	if len(e) == 1 {
+		return e[0].Error()
+	}
+
+	lines := make([]string, len(e))
+	for i, err := range e {
+		lines[i] = "  - " + err.Error()
+	}
+	return fmt.Sprintf("%d validation errors:\n%s", len(e), strings.Join(lines, "\n"))
+}
+
+// Unwrap returns the validation errors, for errors.Is and errors.As.
+func (e ValidationErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+	return errs
+}
+
+// add adds the error of the node at the path, relative to the path of the errors, with the message prefix of the node.
+// The validation errors of nested nodes are added one by one, keeping their paths below the node.
+func (e *ValidationErrors) add(path, prefix string, err error) {
+	if err == nil {
+		return
+	}
+
+	nested, ok := err.(ValidationErrors)
+	if !ok {
+		nested = ValidationErrors{{Message: err.Error()}}
+	}
+
+	for _, validationErr := range nested {
+		message := validationErr.Message
+		if prefix != "" {
+			message = prefix + ": " + message
+		}
+		*e = append(*e, &ValidationError{Message: message, Path: path + validationErr.Path})
+	}
+}
+
+// err returns the validation errors as an error, or nil when there are none.
+func (e ValidationErrors) err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// ValidateServiceWithPosition validates a service, returning all the validation errors as ValidationErrors
+// with the line and column of the offending YAML nodes.
+// Shared libraries are resolved relative to the working directory.
+func ValidateServiceWithPosition(data []byte, fileExtension string) error {
+	libraries, err := loadSharedLibraries(data, fileExtension, "")
+	if err != nil {
+		return err
+	}
+
+	return validateWithPosition(data, fileExtension, func(service *Service) error {
+		return validateServiceWithLibraries(service, libraries)
+	})
+}
+
+// validateWithPosition unmarshals the data as a service and validates it with validate, adding the line and column
+// of the offending YAML nodes to the validation errors.
+func validateWithPosition(data []byte, fileExtension string, validate func(service *Service) error) error {
+	if fileExtension != extYAML && fileExtension != extYML {
+		// For non-YAML files, fall back to regular validation
+		var service Service
+		if fileExtension == extJSON {
+			if err := json.Unmarshal(data, &service); err != nil {
+				return fmt.Errorf("%s: JSON parsing error: %w", errorFileParse, err)
+			}
+		}
+		return validate(&service)
+	}
+
+	// Parse YAML into struct first
+	var service Service
+	if err := yaml.Unmarshal(data, &service); err != nil {
+		return fmt.Errorf("%s: YAML parsing error: %w", errorFileParse, err)
+	}
+
+	// Parse YAML into AST to get position information for errors
+	file, err := parser.ParseBytes(data, parser.ParseComments)
+	if err != nil {
+		return fmt.Errorf("%s: %w", errorYAMLParsing, err)
+	}
+
+	// Run validation and add the positions of the offending nodes to the errors
+	validationErr := validate(&service)
+	if validationErrors, ok := validationErr.(ValidationErrors); ok {
+		for _, err := range validationErrors {
+			if position := findPosition(file, err.Path); position != nil {
+				err.Line = position.Line
+				err.Column = position.Column
+			}
+		}
+	}
+
+	return validationErr
+}
+
+// findPosition returns the position of the YAML node at the path, or of its closest ancestor in the file
+// when the node itself is omitted, e.g. a default value that is missing.
+func findPosition(file *ast.File, path string) *token.Position {
+	for path != "" {
+		yamlPath, err := yaml.PathString(path)
+		if err == nil {
+			if node, err := yamlPath.FilterFile(file); err == nil && node != nil {
+				// A mapping starts at its first key, not at the token of the mapping
+				if mapping, ok := node.(*ast.MappingNode); ok && len(mapping.Values) > 0 {
+					node = mapping.Values[0].Key
+				}
+				if node.GetToken() != nil {
+					return node.GetToken().Position
+				}
+			}
+		}
+
+		// Continue with the parent, the path without its last ".key" or "[index]"
+		path = path[:max(strings.LastIndexAny(path, ".["), 0)]
+	}
+
+	return nil
+}
+
+// ValidateService validates a service declared in Go, before the overlays are applied, against the rules
+// of the specification files.
+func ValidateService(service *Service) error {
+	return validateService(service)
+}
+
+// ValidateSemantics validates the rules of a complete service, after the overlays are applied, that are not
+// about the structure of a single node: the field types must be declared, no two endpoints may have the same
+// method and path, the fields of a resource may only be in the operations that the resource supports, no
+// two types of the generated server may have the same name, and no two fields of a type may have the same Go name
+// or JSON name.
+// The errors are ValidationErrors with a Code.
+func ValidateSemantics(service *Service) error {
+	var errs ValidationErrors
+
+	validateSemanticTypes(service, &errs)
+	validateSemanticEndpoints(service, &errs)
+	validateSemanticFieldOperations(service, &errs)
+	validateSemanticTypeNames(service, &errs)
+	validateSemanticFieldNames(service, &errs)
+
+	return errs.err()
+}
+
+// validateSemanticTypes adds an error for each field type and response body object that is not declared.
+func validateSemanticTypes(service *Service, errs *ValidationErrors) {
+	addFields := func(path string, fields []Field) {
+		for i, field := range fields {
+			if validateFieldType(service, field.Type) != nil {
+				*errs = append(*errs, &ValidationError{
+					Code:    ValidationErrorUnknownType,
+					Path:    fmt.Sprintf("%s[%d].type", path, i),
+					Message: fmt.Sprintf("%s: field '%s' has the unknown type '%s'", errorInvalidFieldType, field.Name, field.Type),
+				})
+			}
+		}
+	}
+
+	for i, object := range service.Objects {
+		addFields(fmt.Sprintf("$.objects[%d].fields", i), object.Fields)
+	}
+
+	for i, resource := range service.Resources {
+		fields := make([]Field, len(resource.Fields))
+		for j, field := range resource.Fields {
+			fields[j] = field.Field
+		}
+		addFields(fmt.Sprintf("$.resources[%d].fields", i), fields)
+
+		for j, endpoint := range resource.Endpoints {
+			path := fmt.Sprintf("$.resources[%d].endpoints[%d]", i, j)
+			addFields(path+".request.headers", endpoint.Request.Headers)
+			addFields(path+".request.path_params", endpoint.Request.PathParams)
+			addFields(path+".request.query_params", endpoint.Request.QueryParams)
+			addFields(path+".request.body_params", endpoint.Request.BodyParams)
+			addFields(path+".response.headers", endpoint.Response.Headers)
+			addFields(path+".response.body_fields", endpoint.Response.BodyFields)
+
+			if endpoint.Response.BodyObject != nil && !service.HasObject(*endpoint.Response.BodyObject) {
+				*errs = append(*errs, &ValidationError{
+					Code:    ValidationErrorUnknownType,
+					Path:    path + ".response.body_object",
+					Message: fmt.Sprintf("%s: endpoint '%s' responds with the unknown object '%s'", errorInvalidFieldType, endpoint.Name, *endpoint.Response.BodyObject),
+				})
+			}
+		}
+	}
+}
+
+// validateSemanticEndpoints adds an error for each endpoint with the same method and path as an endpoint before it.
+// The names of the path parameters do not matter, since a router cannot tell /users/{id} and /users/{userId} apart.
+func validateSemanticEndpoints(service *Service, errs *ValidationErrors) {
+	seen := map[string]string{}
+
+	for i, resource := range service.Resources {
+		for j, endpoint := range resource.Endpoints {
+			method := strings.ToUpper(endpoint.Method)
+			path := service.GetEndpointPath(resource, endpoint)
+			key := method + " " + serverVariablePattern.ReplaceAllString(path, "{}")
+			name := fmt.Sprintf("endpoint '%s' of resource '%s'", endpoint.Name, resource.Name)
+
+			if previous, ok := seen[key]; ok {
+				*errs = append(*errs, &ValidationError{
+					Code:    ValidationErrorDuplicateEndpoint,
+					Path:    fmt.Sprintf("$.resources[%d].endpoints[%d]", i, j),
+					Message: fmt.Sprintf("%s: %s has the same method and path '%s %s' as %s", errorDuplicateEndpoint, name, method, path, previous),
+				})
+				continue
+			}
+
+			seen[key] = name
+		}
+	}
+}
+
+// validateSemanticFieldOperations adds an error for each Create, Update or Delete operation of a resource field
+// that the resource does not support. Read is always allowed, since custom endpoints can respond with the resource.
+func validateSemanticFieldOperations(service *Service, errs *ValidationErrors) {
+	for i, resource := range service.Resources {
+		for j, field := range resource.Fields {
+			for k, operation := range field.Operations {
+				if operation == OperationRead || slices.Contains(resource.Operations, operation) {
+					continue
+				}
+
+				*errs = append(*errs, &ValidationError{
+					Code:    ValidationErrorUnsupportedOperation,
+					Path:    fmt.Sprintf("$.resources[%d].fields[%d].operations[%d]", i, j, k),
+					Message: fmt.Sprintf("%s: field '%s' is in the operation '%s' that resource '%s' does not support", errorInvalidOperation, field.Name, operation, resource.Name),
+				})
+			}
+		}
+	}
+}
+
+// serverTypeNames are the types that every generated server declares, whatever the service
+var serverTypeNames = []string{
+	"AuditEvent", "AuditFunc", "AuthorizeFunc", "CallbackClient", "CheckFunc", "CheckResponse", "CheckScopesFunc",
+	"DocsUI", "EndpointInfo", "ErrorHook", "FeatureEnabledFunc", "HasScopeFunc", "InvalidResponseHook", "PreHook",
+	"PreHooks", "Request", "RequestContext", "ResponseEncoder", "ResponseHeaderHook", "ResponseHeaders", "RetryPolicy",
+	"RetryTransport", "Server", "ServerConfig", "SessionHook", "SessionHooks", "VersionResponse",
+}
+
+// typeDeclaration is a type the generated server declares for a node of the specification.
+type typeDeclaration struct {
+	// name of the type
+	name string
+
+	// owner describes the node the type is declared for, e.g. "object 'User'"
+	owner string
+
+	// path of the node in the specification
+	path string
+
+	// endpoint is set for the request and response types of endpoints, which can be renamed with a type name
+	endpoint bool
+}
+
+// getTypeDeclarations returns the types the generated server declares for the service in the order of the
+// specification: the API of the service, the enums, the objects, the parameter groups, and the API, expansions
+// and request and response types of every resource.
+func (s *Service) getTypeDeclarations() []typeDeclaration {
+	declarations := []typeDeclaration{{name: strmangle.TitleCase(s.Name) + "API", owner: fmt.Sprintf("service '%s'", s.Name), path: "$.name"}}
+
+	for i, enum := range s.Enums {
+		declarations = append(declarations, typeDeclaration{name: enum.Name, owner: fmt.Sprintf("enum '%s'", enum.Name), path: fmt.Sprintf("$.enums[%d].name", i)})
+	}
+	for i, object := range s.Objects {
+		declarations = append(declarations, typeDeclaration{name: object.Name, owner: fmt.Sprintf("object '%s'", object.Name), path: fmt.Sprintf("$.objects[%d].name", i)})
+	}
+	for i, group := range s.ParameterGroups {
+		declarations = append(declarations, typeDeclaration{name: group.GetTypeName(), owner: fmt.Sprintf("parameter group '%s'", group.Name), path: fmt.Sprintf("$.parameter_groups[%d].name", i)})
+	}
+
+	for i, resource := range s.Resources {
+		owner, path := fmt.Sprintf("resource '%s'", resource.Name), fmt.Sprintf("$.resources[%d]", i)
+		declarations = append(declarations, typeDeclaration{name: resource.Name + "API", owner: owner, path: path + ".name"})
+		if len(resource.Expansions) > 0 {
+			declarations = append(declarations, typeDeclaration{name: resource.Name + "Expansions", owner: owner, path: path + ".expansions"})
+		}
+
+		for j, endpoint := range resource.Endpoints {
+			for _, name := range endpoint.getDeclaredTypes(resource.Name) {
+				declarations = append(declarations, typeDeclaration{
+					name:     name,
+					owner:    fmt.Sprintf("endpoint '%s' of resource '%s'", endpoint.Name, resource.Name),
+					path:     fmt.Sprintf("%s.endpoints[%d].name", path, j),
+					endpoint: true,
+				})
+			}
+		}
+	}
+
+	return declarations
+}
+
+// validateSemanticTypeNames adds an error for each type of the generated server whose name is not a Go identifier
+// or is the same as the name of a type before it, since the server would not compile. The types of endpoints come
+// last, so they are the ones reported when they collide, with a hint to rename them.
+func validateSemanticTypeNames(service *Service, errs *ValidationErrors) {
+	seen := map[string]string{}
+	for _, name := range serverTypeNames {
+		seen[name] = "every generated server"
+	}
+
+	for _, declaration := range service.getTypeDeclarations() {
+		if !gotoken.IsIdentifier(declaration.name) {
+			*errs = append(*errs, &ValidationError{
+				Code:    ValidationErrorInvalidIdentifier,
+				Path:    declaration.path,
+				Message: fmt.Sprintf("%s: %s declares the type '%s' that is not a valid Go identifier", errorInvalidTypeName, declaration.owner, declaration.name),
+			})
+			continue
+		}
+
+		previous, ok := seen[declaration.name]
+		if !ok {
+			seen[declaration.name] = declaration.owner
+			continue
+		}
+
+		message := fmt.Sprintf("%s: %s declares the type '%s' that %s already declares", errorNameCollision, declaration.owner, declaration.name, previous)
+		if declaration.endpoint {
+			message += ", set the type_name of the endpoint or the type_collisions of the naming to 'rename'"
+		}
+		*errs = append(*errs, &ValidationError{
+			Code:    ValidationErrorNameCollision,
+			Path:    declaration.path,
+			Message: message,
+		})
+	}
+}
+
+// validateSemanticFieldNames adds an error for each field whose Go name or JSON name is the same as the one of a field
+// before it in the same type of the generated server: the objects, the parameter groups and the request and response
+// types of the endpoints. The server would not compile with the same Go name twice, and encoding/json drops both
+// fields with the same JSON name, such as the fields id and ID.
+func validateSemanticFieldNames(service *Service, errs *ValidationErrors) {
+	addFields := func(path, owner string, fields []Field) {
+		goNames, jsonNames := map[string]string{}, map[string]string{}
+		for i, field := range fields {
+			var message string
+			if previous, ok := goNames[field.GetGoName()]; ok {
+				message = fmt.Sprintf("%s: field '%s' of %s has the Go name '%s' of the field '%s'", errorNameCollision, field.Name, owner, field.GetGoName(), previous)
+			} else if previous, ok := jsonNames[field.TagJSON()]; ok {
+				message = fmt.Sprintf("%s: field '%s' of %s has the JSON name '%s' of the field '%s'", errorNameCollision, field.Name, owner, field.TagJSON(), previous)
+			} else {
+				goNames[field.GetGoName()] = field.Name
+				jsonNames[field.TagJSON()] = field.Name
+				continue
+			}
+
+			*errs = append(*errs, &ValidationError{
+				Code:    ValidationErrorNameCollision,
+				Path:    fmt.Sprintf("%s[%d].name", path, i),
+				Message: message,
+			})
+		}
+	}
+
+	for i, object := range service.Objects {
+		addFields(fmt.Sprintf("$.objects[%d].fields", i), fmt.Sprintf("object '%s'", object.Name), object.Fields)
+	}
+	for i, group := range service.ParameterGroups {
+		addFields(fmt.Sprintf("$.parameter_groups[%d].fields", i), fmt.Sprintf("parameter group '%s'", group.Name), group.Fields)
+	}
+
+	for i, resource := range service.Resources {
+		for j, endpoint := range resource.Endpoints {
+			path := fmt.Sprintf("$.resources[%d].endpoints[%d]", i, j)
+			owner := fmt.Sprintf("endpoint '%s' of resource '%s'", endpoint.Name, resource.Name)
+			addFields(path+".request.path_params", "the path params of "+owner, endpoint.Request.PathParams)
+			addFields(path+".request.query_params", "the query params of "+owner, endpoint.Request.QueryParams)
+			addFields(path+".request.body_params", "the body params of "+owner, endpoint.Request.BodyParams)
+			addFields(path+".response.body_fields", "the response of "+owner, endpoint.Response.BodyFields)
+		}
+	}
+}
+
+// validateService validates the entire service specification against the defined rules,
+// returning all the validation errors as ValidationErrors.
+func validateService(service *Service) error {
+	var errs ValidationErrors
+
+	// Validate schema version
+	errs.add("$.schema_version", "", validateSchemaVersion(service.SchemaVersion))
+
+	// Validate external documentation
+	errs.add("$.external_docs", "", validateExternalDocs(service.ExternalDocs))
+
+	// Validate terms of service
+	if service.TermsOfService != "" && !isAbsoluteURL(service.TermsOfService) {
+		errs.add("$.terms_of_service", "", fmt.Errorf("%s: terms_of_service '%s' must be an absolute URL", errorInvalidTerms, service.TermsOfService))
+	}
+
+	// Validate servers
+	for i, server := range service.Servers {
+		errs.add(fmt.Sprintf("$.servers[%d]", i), fmt.Sprintf("server %d (%s)", i, server.URL), validateServer(server))
+	}
+
+	// Validate retry configuration
+	if service.Retry != nil {
+		errs.add("$.retry", "retry configuration", validateRetryConfiguration(service.Retry))
+	}
+
+	// Validate security schemes and requirements
+	errs.add("$", "security", validateSecurity(service))
+
+	// Validate tenancy configuration
+	if service.Tenancy != nil {
+		errs.add("$.tenancy", "tenancy", validateTenancy(service.Tenancy))
+	}
+
+	// Validate error format
+	errs.add("$.error_format", "", validateErrorFormat(service.ErrorFormat))
+
+	// Validate base path
+	errs.add("$.base_path", "", validateBasePath(service.BasePath))
+
+	// Validate naming conventions
+	if service.Naming != nil {
+		errs.add("$.naming", "naming", validateNaming(service.Naming))
+	}
+
+	// Validate localization of the error messages
+	if service.Localization != nil {
+		errs.add("$.localization", "localization", validateLocalization(service))
+	}
+
+	// Validate operational endpoints
+	errs.add("$.operational", "operational", validateOperational(service))
+
+	// Validate asynchronous endpoints
+	errs.add("$.resources", "async endpoints", validateAsyncEndpoints(service))
+
+	// Validate request limits
+	errs.add("$", "request limits", validateRequestLimits(service.MaxBodyBytes, service.HandlerTimeout))
+	errs.add("$.filter_max_depth", "filter max depth", validateFilterMaxDepth(service.FilterMaxDepth))
+	errs.add("$.max_limit", "max limit", validateMaxLimit(service.MaxLimit))
+	errs.add("$.enums", "request limits", validateRequestLimitErrorCodes(service))
+
+	// Validate response headers
+	errs.add("$.responseHeaders", "response headers", validateResponseHeaders(service.ResponseHeaders))
+
+	// Validate enums
+	for i, enum := range service.Enums {
+		errs.add(fmt.Sprintf("$.enums[%d]", i), fmt.Sprintf("enum %d (%s)", i, enum.Name), validateEnum(&enum))
+		errs.add(fmt.Sprintf("$.enums[%d].description_file", i), fmt.Sprintf("enum %d (%s)", i, enum.Name), validateDescriptionFile(enum.Description, enum.DescriptionFile))
+	}
+
+	// Validate parameter groups
+	for i, group := range service.ParameterGroups {
+		errs.add(fmt.Sprintf("$.parameterGroups[%d]", i), fmt.Sprintf("parameter group %d (%s)", i, group.Name), validateParameterGroup(service, &group, i))
+	}
+
+	// Validate resources
+	for i, resource := range service.Resources {
+		errs.add(fmt.Sprintf("$.resources[%d]", i), fmt.Sprintf("resource %d (%s)", i, resource.Name), validateResource(service, &resource))
+	}
+
+	// Validate objects
+	for i, object := range service.Objects {
+		errs.add(fmt.Sprintf("$.objects[%d]", i), fmt.Sprintf("object %d (%s)", i, object.Name), validateObject(service, &object))
+	}
+
+	return errs.err()
+}
+
+// validateServiceWithLibraries merges the shared libraries into the service and validates it, so that the service
+// can reference the types of the libraries.
+func validateServiceWithLibraries(service *Service, libraries []SharedLibrary) error {
+	if err := mergeSharedLibraries(service, libraries); err != nil {
+		return ValidationErrors{{Message: err.Error(), Path: "$.shared"}}
+	}
+	return validateService(service)
+}
+
+// validateSharedLibrary validates a shared library, read as a service with only a name, enums and objects. The names
+// of the enums and objects that the overlay adds to every service are reserved.
+func validateSharedLibrary(library *Service) error {
+	var errs ValidationErrors
+
+	if library.Name == "" {
+		errs.add("$.name", "", fmt.Errorf("%s: name is required", errorInvalidShared))
+	}
+	if len(library.Resources) > 0 || len(library.Shared) > 0 {
+		errs.add("$", "", fmt.Errorf("%s: a shared library can only declare enums and objects", errorInvalidShared))
+	}
+
+	reserved := []string{errorCodeEnumName, errorObjectName, errorFieldObjectName, paginationObjectName, PaginationWithoutTotalObjectName, metaObjectName}
+	for i, enum := range library.Enums {
+		if slices.Contains(reserved, enum.Name) {
+			errs.add(fmt.Sprintf("$.enums[%d].name", i), "", fmt.Errorf("%s: the name '%s' is reserved", errorInvalidShared, enum.Name))
+		}
+	}
+	for i, object := range library.Objects {
+		if slices.Contains(reserved, object.Name) {
+			errs.add(fmt.Sprintf("$.objects[%d].name", i), "", fmt.Errorf("%s: the name '%s' is reserved", errorInvalidShared, object.Name))
+		}
+	}
+
+	errs.add("", "", validateService(library))
+
+	return errs.err()
+}
+
+// validateResource validates a resource and its fields against the defined rules.
+func validateResource(service *Service, resource *Resource) error {
+	var errs ValidationErrors
+
+	// Validate resource operations (Get, List, Search, Create, Update, Delete)
+	errs.add(".operations", "resource operations", validateResourceOperations(resource.Operations))
+
+	// Validate resource scopes
+	errs.add(".scopes", "resource scopes", validateScopes(service, resource.Scopes))
+
+	// Validate resource permissions
+	errs.add(".permissions", "resource permissions", validatePermissions(resource.Permissions))
+
+	// Validate resource fields
+	for i, field := range resource.Fields {
+		errs.add(fmt.Sprintf(".fields[%d]", i), fmt.Sprintf("field %d (%s)", i, field.Name), validateResourceField(service, &field))
+	}
+
+	// Validate endpoints
+	for i, endpoint := range resource.Endpoints {
+		errs.add(fmt.Sprintf(".endpoints[%d]", i), fmt.Sprintf("endpoint %d (%s)", i, endpoint.Name), validateEndpoint(service, &endpoint))
+	}
+
+	// Validate examples
+	errs.add(".examples", "examples", validateResourceExamples(resource))
+
+	// Validate SDK names
+	errs.add("", "SDK names", validateResourceSDKNames(resource))
+
+	// Soft delete changes the Delete operation
+	if resource.SoftDelete && !resource.HasDeleteOperation() {
+		errs.add(".soft_delete", "", fmt.Errorf("%s: soft_delete requires the Delete operation", errorInvalidOperation))
+	}
+
+	// Full-text search is part of the Search operation
+	errs.add("", "searchable text", validateSearchableText(resource))
+
+	// Validate the fields of the aggregations
+	errs.add(".aggregations", "aggregations", validateAggregations(service, resource))
+
+	// Exports stream the results of List
+	if resource.Exportable && !resource.HasListOperation() {
+		errs.add(".exportable", "", fmt.Errorf("%s: exportable requires the List operation", errorInvalidOperation))
+	}
+
+	// Totals are returned by List and Search
+	errs.add(".total_count", "", validateTotalCount(resource))
+
+	// Validate the parent of sub-resources
+	errs.add(".parent", "", validateResourceParent(service, resource))
+
+	// Validate the related resources that can be included in the responses
+	for i, expansion := range resource.Expansions {
+		errs.add(fmt.Sprintf(".expansions[%d]", i), fmt.Sprintf("expansion %d (%s)", i, expansion.Name), validateExpansion(service, resource, expansion))
+	}
+
+	// Validate external documentation
+	errs.add(".external_docs", "", validateExternalDocs(resource.ExternalDocs))
+
+	// Validate stability
+	errs.add(".stability", "", validateStability(resource.Stability))
+
+	// Validate tag group
+	if resource.TagGroup != "" && strings.TrimSpace(resource.TagGroup) == "" {
+		errs.add(".tag_group", "", fmt.Errorf("%s: tag_group cannot be blank", errorInvalidTagGroup))
+	}
+
+	// Validate the description file
+	errs.add(".description_file", "", validateDescriptionFile(resource.Description, resource.DescriptionFile))
+
+	return errs.err()
+}
+
+// validateTotalCount validates that the total count of a resource is pagination or header, and that a resource
+// returning the total in a header has the List or Search operation.
+func validateTotalCount(resource *Resource) error {
+	switch resource.TotalCount {
+	case "", TotalCountPagination:
+		return nil
+	case TotalCountHeader:
+		if !resource.HasListOperation() && !resource.HasSearchOperation() {
+			return fmt.Errorf("%s: total_count '%s' requires the List or Search operation", errorInvalidTotalCount, resource.TotalCount)
+		}
+		return nil
+	default:
+		return fmt.Errorf("%s: total_count '%s' must be '%s' or '%s'", errorInvalidTotalCount, resource.TotalCount, TotalCountPagination, TotalCountHeader)
+	}
+}
+
+// validateExpansion validates that an expansion has a unique name, and that its field references a resource with
+// an object, which is readable from a resource that has an endpoint returning it.
+func validateExpansion(service *Service, resource *Resource, expansion Expansion) error {
+	if expansion.Name == "" {
+		return fmt.Errorf("%s: name is required", errorInvalidExpansion)
+	}
+
+	if slices.ContainsFunc(resource.Fields, func(field ResourceField) bool { return field.Name == expansion.Name }) {
+		return fmt.Errorf("%s: '%s' is already the name of a field of the resource", errorInvalidExpansion, expansion.Name)
+	}
+
+	if slices.IndexFunc(resource.Expansions, func(other Expansion) bool { return other.Name == expansion.Name }) !=
+		slices.IndexFunc(resource.Expansions, func(other Expansion) bool { return other == expansion }) {
+		return fmt.Errorf("%s: duplicate expansion '%s'", errorInvalidExpansion, expansion.Name)
+	}
+
+	if !resource.HasGetOperation() && !resource.HasListOperation() && !resource.HasSearchOperation() {
+		return fmt.Errorf("%s: expansions require the Get, List or Search operation", errorInvalidOperation)
+	}
+
+	index := slices.IndexFunc(resource.Fields, func(field ResourceField) bool { return field.Name == expansion.Field })
+	if index == -1 {
+		return fmt.Errorf("%s: unknown field '%s'", errorInvalidExpansion, expansion.Field)
+	}
+
+	field := resource.Fields[index]
+	if field.References == "" {
+		return fmt.Errorf("%s: field '%s' does not reference a resource", errorInvalidExpansion, field.Name)
+	}
+
+	if !field.HasReadOperation() {
+		return fmt.Errorf("%s: field '%s' is not readable", errorInvalidExpansion, field.Name)
+	}
+
+	if related := service.GetResource(field.References); related != nil && !related.HasReadOperation() {
+		return fmt.Errorf("%s: '%s' has no Get, List or Search operation to be expanded", errorInvalidExpansion, related.Name)
+	}
+
+	return nil
+}
+
+// validateAggregations validates that the aggregated fields are readable fields of the resource, that the summed
+// fields are numeric and that the fields counted by are single values that are not objects or timestamps.
+func validateAggregations(service *Service, resource *Resource) error {
+	if resource.Aggregations == nil {
+		return nil
+	}
+
+	for _, name := range resource.Aggregations.Sum {
+		field := resource.getResourceField(name)
+		if field == nil {
+			return fmt.Errorf("%s: unknown sum field '%s'", errorInvalidAggregate, name)
+		}
+		if !field.HasReadOperation() {
+			return fmt.Errorf("%s: sum field '%s' is not readable", errorInvalidAggregate, name)
+		}
+		if !isNumericType(field.Type) || field.IsArray() {
+			return fmt.Errorf("%s: sum field '%s' must be a number, got '%s'", errorInvalidAggregate, name, field.Type)
+		}
+	}
+
+	for _, name := range resource.Aggregations.CountBy {
+		field := resource.getResourceField(name)
+		if field == nil {
+			return fmt.Errorf("%s: unknown count_by field '%s'", errorInvalidAggregate, name)
+		}
+		if !field.HasReadOperation() {
+			return fmt.Errorf("%s: count_by field '%s' is not readable", errorInvalidAggregate, name)
+		}
+		if field.IsArray() || field.Type == FieldTypeTimestamp || service.IsObject(field.Type) {
+			return fmt.Errorf("%s: count_by field '%s' must be a single value that is not an object or timestamp", errorInvalidAggregate, name)
+		}
+	}
+
+	return nil
+}
+
+// validateSearchableText validates that the fields of the full-text search are readable String fields,
+// and that the resource has the Search operation whose Query searches them.
+func validateSearchableText(resource *Resource) error {
+	searchableFields := resource.GetSearchableTextFields()
+	if len(searchableFields) == 0 {
+		return nil
+	}
+
+	if !resource.HasSearchOperation() {
+		return fmt.Errorf("%s: searchable_text requires the Search operation", errorInvalidOperation)
+	}
+
+	for _, field := range searchableFields {
+		if field.Type != FieldTypeString {
+			return fmt.Errorf("%s: field '%s' must be a String, got '%s'", errorInvalidSearchText, field.Name, field.Type)
+		}
+		if !field.HasReadOperation() {
+			return fmt.Errorf("%s: field '%s' is not readable", errorInvalidSearchText, field.Name)
+		}
+	}
+
+	return nil
+}
+
+// validateResourceParent validates that the parent of a sub-resource is another defined resource,
+// and that the resource is not its own ancestor.
+func validateResourceParent(service *Service, resource *Resource) error {
+	if !resource.HasParent() {
+		return nil
+	}
+
+	visited := map[string]bool{resource.Name: true}
+	for current := *resource; current.HasParent(); {
+		if visited[current.Parent] {
+			return fmt.Errorf("%s: resource '%s' is its own ancestor through parent '%s'", errorInvalidParent, resource.Name, current.Parent)
+		}
+		parent := service.GetResource(current.Parent)
+		if parent == nil {
+			return fmt.Errorf("%s: parent '%s' of resource '%s' is not defined", errorInvalidParent, current.Parent, current.Name)
+		}
+		visited[parent.Name] = true
+		current = *parent
+	}
+
+	return nil
+}
+
+// validateResourceSDKNames validates that the SDK group and method names of a resource are identifiers,
+// and that no two endpoints of the resource get the same SDK method name.
+func validateResourceSDKNames(resource *Resource) error {
+	if resource.SDKGroup != "" && !sdkNamePattern.MatchString(resource.SDKGroup) {
+		return fmt.Errorf("%s: sdk_group '%s' must start with a letter and contain only letters and digits", errorInvalidSDKName, resource.SDKGroup)
+	}
+
+	methodNames := make(map[string]string, len(resource.Endpoints))
+	for _, endpoint := range resource.Endpoints {
+		if endpoint.SDKMethodName != "" && !sdkNamePattern.MatchString(endpoint.SDKMethodName) {
+			return fmt.Errorf("%s: sdk_method_name '%s' of endpoint '%s' must start with a letter and contain only letters and digits", errorInvalidSDKName, endpoint.SDKMethodName, endpoint.Name)
+		}
+
+		methodName := endpoint.GetSDKMethodName()
+		if previous, exists := methodNames[methodName]; exists {
+			return fmt.Errorf("%s: endpoints '%s' and '%s' have the same SDK method name '%s'", errorInvalidSDKName, previous, endpoint.Name, methodName)
+		}
+		methodNames[methodName] = endpoint.Name
+	}
+
+	return nil
+}
+
+// validateResourceExamples validates that the examples of a resource have unique names and values.
+func validateResourceExamples(resource *Resource) error {
+	names := make(map[string]bool, len(resource.Examples))
+	for i, example := range resource.Examples {
+		if example.Name == "" {
+			return fmt.Errorf("%s: example %d must have a name", errorInvalidExample, i)
+		}
+		if names[example.Name] {
+			return fmt.Errorf("%s: duplicate example name '%s'", errorInvalidExample, example.Name)
+		}
+		names[example.Name] = true
+
+		if len(example.Values) == 0 {
+			return fmt.Errorf("%s: example '%s' must have values", errorInvalidExample, example.Name)
+		}
+	}
+
+	return nil
+}
+
+// validateEnum validates that the names of the values of an enum are unique,
+// and that the wire values and aliases are unique across all values of the enum.
+// The values of enums backed by integers must set a value, and their values and aliases must be integers.
+func validateEnum(enum *Enum) error {
+	if enum.EnumType != "" && enum.EnumType != EnumTypeString && enum.EnumType != EnumTypeInt {
+		return fmt.Errorf("%s: enum_type '%s' must be one of: %v", errorInvalidEnum, enum.EnumType, []string{EnumTypeString, EnumTypeInt})
+	}
+
+	names := make(map[string]bool, len(enum.Values))
+	wireValues := make(map[string]string, len(enum.Values))
+	for _, value := range enum.Values {
+		if names[value.Name] {
+			return fmt.Errorf("%s: duplicate value name '%s'", errorInvalidEnum, value.Name)
+		}
+		names[value.Name] = true
+
+		if enum.IsInt() && value.Value == "" {
+			return fmt.Errorf("%s: value '%s' of an int enum must set a value", errorInvalidEnum, value.Name)
+		}
+
+		for _, wireValue := range append([]string{value.GetValue()}, value.Aliases...) {
+			if wireValue == "" {
+				return fmt.Errorf("%s: value '%s' has an empty alias", errorInvalidEnum, value.Name)
+			}
+			if _, err := strconv.ParseInt(wireValue, 10, 64); enum.IsInt() && err != nil {
+				return fmt.Errorf("%s: wire value '%s' of value '%s' must be an integer", errorInvalidEnum, wireValue, value.Name)
+			}
+			if previous, exists := wireValues[wireValue]; exists {
+				return fmt.Errorf("%s: wire value '%s' of value '%s' is already used by value '%s'", errorInvalidEnum, wireValue, value.Name, previous)
+			}
+			wireValues[wireValue] = value.Name
+		}
+	}
+
+	return nil
+}
+
+// validateObject validates an object and its fields against the defined rules.
+func validateObject(service *Service, object *Object) error {
+	var errs ValidationErrors
+
+	// Validate the extended object
+	if object.HasParent() {
+		errs.add(".extends", "extends", validateExtends(service, object))
+	}
+
+	// Validate object fields
+	for i, field := range object.Fields {
+		errs.add(fmt.Sprintf(".fields[%d]", i), fmt.Sprintf("field %d (%s)", i, field.Name), validateField(service, &field))
+	}
+
+	// Validate the description file
+	errs.add(".description_file", "", validateDescriptionFile(object.Description, object.DescriptionFile))
+
+	return errs.err()
+}
+
+// validateParameterGroup validates a parameter group and its fields.
+func validateParameterGroup(service *Service, group *ParameterGroup, index int) error {
+	if group.Name == "" {
+		return fmt.Errorf("%s: name is required", errorInvalidGroup)
+	}
+
+	var errs ValidationErrors
+
+	for _, other := range service.ParameterGroups[:index] {
+		if other.Name == group.Name {
+			errs.add(".name", "", fmt.Errorf("%s: name '%s' is already defined", errorInvalidGroup, group.Name))
+			break
+		}
+	}
+
+	for i, field := range group.Fields {
+		errs.add(fmt.Sprintf(".fields[%d]", i), fmt.Sprintf("field %d (%s)", i, field.Name), validateField(service, &field))
+	}
+
+	return errs.err()
+}
+
+// validateEndpointParameterGroups validates that the parameter groups referenced by an endpoint exist,
+// and that the endpoint does not redeclare a group field as a query parameter with a different type.
+func validateEndpointParameterGroups(service *Service, endpoint *Endpoint) error {
+	for _, groupName := range endpoint.Request.ParameterGroups {
+		group := service.GetParameterGroup(groupName)
+		if group == nil {
+			return fmt.Errorf("%s: endpoint references undefined parameter group '%s'", errorInvalidGroup, groupName)
+		}
+
+		for _, groupField := range group.Fields {
+			for _, field := range endpoint.Request.QueryParams {
+				if field.Name == groupField.Name && field.Type != groupField.Type {
+					return fmt.Errorf("%s: query param '%s' conflicts with parameter group '%s'", errorInvalidGroup, field.Name, group.Name)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateExtends validates that the parent of an object exists, that the inheritance chain has no cycles,
+// and that the object does not redeclare an inherited field with a different type.
+func validateExtends(service *Service, object *Object) error {
+	visited := map[string]bool{object.Name: true}
+	for current := object; current.HasParent(); {
+		parent := service.GetObject(current.Extends)
+		if parent == nil {
+			return fmt.Errorf("%s: object '%s' extends undefined object '%s'", errorInvalidExtends, current.Name, current.Extends)
+		}
+		if visited[parent.Name] {
+			return fmt.Errorf("%s: object '%s' has a cyclic inheritance through '%s'", errorInvalidExtends, object.Name, parent.Name)
+		}
+		visited[parent.Name] = true
+		current = parent
+	}
+
+	inherited := service.getFlattenedFields(*service.GetObject(object.Extends), map[string]bool{object.Name: true})
+	for _, field := range object.Fields {
+		for _, inheritedField := range inherited {
+			if field.Name == inheritedField.Name && field.Type != inheritedField.Type {
+				return fmt.Errorf("%s: field '%s' redeclares the inherited field with type '%s' instead of '%s'", errorInvalidExtends, field.Name, field.Type, inheritedField.Type)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateResourceField validates a resource field against the defined rules.
+func validateResourceField(service *Service, field *ResourceField) error {
+	var errs ValidationErrors
+
+	// Validate field operations (Create, Read, Update, Delete)
+	errs.add(".operations", "field operations", validateFieldOperations(field.Operations))
+
+	// Validate the operations the field is required in (Create, Update)
+	errs.add(".required_on", "field required_on", validateRequiredOn(field))
+
+	// Validate the embedded field
+	errs.add("", "", validateField(service, &field.Field))
+
+	return errs.err()
+}
+
+// validateField validates a field against the defined rules.
+func validateField(service *Service, field *Field) error {
+	var errs ValidationErrors
+
+	// Validate field type
+	errs.add(".type", "field type", validateFieldType(service, field.Type))
+
+	// Validate modifiers
+	errs.add(".modifiers", "field modifiers", validateModifiers(field.Modifiers))
+
+	// Validate default value
+	errs.add(".default", "field default", validateFieldDefault(service, field))
+
+	// Validate the scopes required to read the field
+	errs.add(".scopes", "field scopes", validateFieldScopes(service, field))
+
+	// Validate the resource the field references
+	errs.add(".references", "field references", validateFieldReferences(service, field))
+
+	return errs.err()
+}
+
+// validateFieldReferences validates that a field referencing a resource is a UUID field and that the resource exists.
+func validateFieldReferences(service *Service, field *Field) error {
+	if field.References == "" {
+		return nil
+	}
+
+	if field.Type != FieldTypeUUID {
+		return fmt.Errorf("%s: only %s fields can reference a resource, not %s", errorInvalidReference, FieldTypeUUID, field.Type)
+	}
+
+	if service.GetResource(field.References) == nil {
+		return fmt.Errorf("%s: unknown resource '%s'", errorInvalidReference, field.References)
+	}
+
+	return nil
+}
+
+// validateEndpoint validates an endpoint against the defined rules.
+func validateEndpoint(service *Service, endpoint *Endpoint) error {
+	var errs ValidationErrors
+
+	// Validate endpoint scopes
+	errs.add(".scopes", "endpoint scopes", validateScopes(service, endpoint.Scopes))
+
+	// Validate endpoint permissions
+	errs.add(".permissions", "endpoint permissions", validatePermissions(endpoint.Permissions))
+
+	// Validate endpoint request limits
+	errs.add("", "endpoint request limits", validateRequestLimits(endpoint.MaxBodyBytes, endpoint.HandlerTimeout))
+
+	// Validate endpoint type name
+	if endpoint.TypeName != "" && !sdkNamePattern.MatchString(endpoint.TypeName) {
+		errs.add(".type_name", "endpoint type name", fmt.Errorf("%s: type_name '%s' must start with a letter and contain only letters and digits", errorInvalidTypeName, endpoint.TypeName))
+	}
+
+	// Validate request body params
+	for i, field := range endpoint.Request.BodyParams {
+		errs.add(fmt.Sprintf(".request.body_params[%d]", i), fmt.Sprintf("request body param %d (%s)", i, field.Name), validateField(service, &field))
+	}
+
+	// Validate request query params
+	for i, field := range endpoint.Request.QueryParams {
+		errs.add(fmt.Sprintf(".request.query_params[%d]", i), fmt.Sprintf("request query param %d (%s)", i, field.Name), validateField(service, &field))
+	}
+
+	// Validate referenced parameter groups
+	errs.add(".request.parameter_groups", "parameter groups", validateEndpointParameterGroups(service, endpoint))
+
+	// Validate request headers
+	for i, field := range endpoint.Request.Headers {
+		errs.add(fmt.Sprintf(".request.headers[%d]", i), fmt.Sprintf("request header %d (%s)", i, field.Name), validateField(service, &field))
+	}
+
+	// Validate response body fields
+	for i, field := range endpoint.Response.BodyFields {
+		errs.add(fmt.Sprintf(".response.body_fields[%d]", i), fmt.Sprintf("response body field %d (%s)", i, field.Name), validateField(service, &field))
+	}
+
+	// Validate response headers
+	errs.add(".response.headers", "response headers", validateEndpointResponseHeaders(&endpoint.Response))
+
+	// Validate alternative response content types
+	errs.add(".response.alternative_content_types", "response", validateAlternativeContentTypes(&endpoint.Response))
+
+	// Validate the content types of the request and response
+	errs.add(".request.content_type", "request", validateRequestContentType(&endpoint.Request))
+	errs.add(".request.body_params", "request", validateFormRequest(service, &endpoint.Request))
+	errs.add(".response.content_type", "response", validateResponseContentType(&endpoint.Response))
+
+	// Validate CSV responses
+	errs.add(".response.body_object", "response", validateCSVResponse(service, &endpoint.Response))
+
+	// Validate external documentation
+	errs.add(".external_docs", "", validateExternalDocs(endpoint.ExternalDocs))
+
+	// Validate stability
+	errs.add(".stability", "", validateStability(endpoint.Stability))
+
+	// Validate the description file
+	errs.add(".description_file", "", validateDescriptionFile(endpoint.Description, endpoint.DescriptionFile))
+
+	// Validate callbacks
+	for i, callback := range endpoint.Callbacks {
+		errs.add(fmt.Sprintf(".callbacks[%d]", i), fmt.Sprintf("callback %d (%s)", i, callback.Name), validateCallback(service, endpoint, i))
+	}
+
+	// Validate the feature flag
+	errs.add(".feature_flag", "", validateFeatureFlag(endpoint.FeatureFlag))
+
+	return errs.err()
+}
+
+// validateFeatureFlag validates that the feature flag of an endpoint, if any, is a name without whitespace.
+func validateFeatureFlag(featureFlag string) error {
+	if featureFlag != "" && strings.ContainsFunc(featureFlag, unicode.IsSpace) {
+		return fmt.Errorf("%s: feature flag '%s' cannot contain whitespace", errorInvalidFeature, featureFlag)
+	}
+	return nil
+}
+
+// validateCallback validates that the callback at the index of the endpoint has a unique name, that its URL is a String body param of the request
+// and that its payload is an object, or a resource with the object the overlay generates for it.
+func validateCallback(service *Service, endpoint *Endpoint, index int) error {
+	callback := endpoint.Callbacks[index]
+	if callback.Name == "" {
+		return fmt.Errorf("%s: name is required", errorInvalidCallback)
+	}
+	if slices.ContainsFunc(endpoint.Callbacks[:index], func(other Callback) bool { return other.Name == callback.Name }) {
+		return fmt.Errorf("%s: name '%s' is used by another callback of the endpoint", errorInvalidCallback, callback.Name)
+	}
+
+	urlField := endpoint.GetCallbackURLField(callback)
+	if urlField == nil {
+		return fmt.Errorf("%s: url_field '%s' must be a body param of the request", errorInvalidCallback, callback.URLField)
+	}
+	if urlField.Type != FieldTypeString || urlField.IsArray() {
+		return fmt.Errorf("%s: url_field '%s' must be a single String, got '%s'", errorInvalidCallback, callback.URLField, urlField.Type)
+	}
+
+	if !slices.Contains([]string{httpMethodPost, httpMethodPut, httpMethodPatch}, callback.GetMethod()) {
+		return fmt.Errorf("%s: method '%s' must be %s, %s or %s", errorInvalidCallback, callback.Method, httpMethodPost, httpMethodPut, httpMethodPatch)
+	}
+
+	if resource := service.GetResource(callback.Payload); !service.HasObject(callback.Payload) && (resource == nil || !resource.HasReadOperation()) {
+		return fmt.Errorf("%s: payload '%s' must be an object, or a resource with the Read operation", errorInvalidCallback, callback.Payload)
+	}
+	return nil
+}
+
+// validateRequestContentType validates that the request is sent as JSON, XML or a form, where empty means JSON.
+func validateRequestContentType(request *EndpointRequest) error {
+	switch request.ContentType {
+	case "", contentTypeJSON, ContentTypeXML, ContentTypeForm:
+		return nil
+	}
+	return fmt.Errorf("%s: '%s' must be %s, %s or %s", errorInvalidMediaType, request.ContentType, contentTypeJSON, ContentTypeXML, ContentTypeForm)
+}
+
+// validateFormRequest validates that the body params of a form request are not objects, since the values of a form
+// are flat, with a value per element of the arrays.
+func validateFormRequest(service *Service, request *EndpointRequest) error {
+	if request.ContentType != ContentTypeForm {
+		return nil
+	}
+	for _, field := range request.BodyParams {
+		if service.IsObject(service.GetPrimitiveType(field.Type)) {
+			return fmt.Errorf("%s: the body params of a %s request cannot be objects, such as '%s'", errorInvalidMediaType, ContentTypeForm, field.Name)
+		}
+	}
+	return nil
+}
+
+// validateResponseContentType validates that the response is JSON, XML or CSV, where empty means JSON, and that an
+// XML response has no other content types.
+func validateResponseContentType(response *EndpointResponse) error {
+	switch response.ContentType {
+	case "", contentTypeJSON, ContentTypeCSV:
+		return nil
+	case ContentTypeXML:
+		if len(response.AlternativeContentTypes) > 0 {
+			return fmt.Errorf("%s: a %s response cannot have alternative content types", errorInvalidMediaType, ContentTypeXML)
+		}
+		return nil
+	}
+	return fmt.Errorf("%s: '%s' must be %s, %s or %s", errorInvalidMediaType, response.ContentType, contentTypeJSON, ContentTypeXML, ContentTypeCSV)
+}
+
+// validateCSVResponse validates that a CSV response has an object as its body, which is the type of the rows,
+// and no other content types.
+func validateCSVResponse(service *Service, response *EndpointResponse) error {
+	if response.ContentType != ContentTypeCSV {
+		return nil
+	}
+	if response.BodyObject == nil || !service.HasObject(*response.BodyObject) || len(response.BodyFields) > 0 {
+		return fmt.Errorf("%s: a %s response must have an object as body_object, the type of its rows, and no body_fields", errorInvalidMediaType, ContentTypeCSV)
+	}
+	if len(response.AlternativeContentTypes) > 0 {
+		return fmt.Errorf("%s: a %s response cannot have alternative content types", errorInvalidMediaType, ContentTypeCSV)
+	}
+	return nil
+}
+
+// validateAlternativeContentTypes validates that the alternative content types of the response are distinct media
+// types without parameters other than JSON, for a response with a body.
+func validateAlternativeContentTypes(response *EndpointResponse) error {
+	if len(response.AlternativeContentTypes) == 0 {
+		return nil
+	}
+	if response.BodyObject == nil && len(response.BodyFields) == 0 {
+		return fmt.Errorf("%s: alternative content types require a response body", errorInvalidMediaType)
+	}
+
+	seen := map[string]bool{}
+	for _, contentType := range response.AlternativeContentTypes {
+		mediaType, params, err := mime.ParseMediaType(contentType)
+		if err != nil || len(params) > 0 || mediaType != contentType || !strings.Contains(mediaType, "/") || strings.Contains(mediaType, "*") {
+			return fmt.Errorf("%s: '%s' must be a lower case media type without parameters, for example text/csv", errorInvalidMediaType, contentType)
+		}
+		if mediaType == contentTypeJSON {
+			return fmt.Errorf("%s: '%s' is the default content type", errorInvalidMediaType, contentType)
+		}
+		if seen[mediaType] {
+			return fmt.Errorf("%s: '%s' is listed more than once", errorInvalidMediaType, contentType)
+		}
+		seen[mediaType] = true
+	}
+	return nil
+}
+
+// validateOperational validates that the operational endpoints do not conflict with resource endpoints.
+func validateOperational(service *Service) error {
+	operationalPaths := map[string]bool{
+		OperationalPathHealth:    service.Operational.HasHealth(),
+		OperationalPathReadiness: service.Operational.HasReadiness(),
+		OperationalPathVersion:   service.Operational.HasVersion(),
+	}
+
+	for _, resource := range service.Resources {
+		for _, endpoint := range resource.Endpoints {
+			fullPath := service.Tenancy.GetPathPrefix() + service.GetEndpointPath(resource, endpoint)
+			if operationalPaths[fullPath] && strings.EqualFold(endpoint.Method, httpMethodGet) {
+				return fmt.Errorf("%s: endpoint '%s%s' conflicts with the operational endpoint %s", errorPathConflict, resource.Name, endpoint.Name, fullPath)
+			}
+		}
+	}
+	return nil
+}
+
+// validateAsyncEndpoints validates that the asynchronous endpoints do not declare a response body, since they respond
+// with the Operation they started, and that the names of the Operation resource, object and enum are not taken.
+func validateAsyncEndpoints(service *Service) error {
+	if !service.HasAsyncEndpoints() {
+		return nil
+	}
+
+	for _, resource := range service.Resources {
+		if resource.Name == operationResourceName {
+			return fmt.Errorf("%s: the resource name '%s' is reserved for polling the operations of the async endpoints", errorInvalidAsync, resource.Name)
+		}
+		for _, endpoint := range resource.Endpoints {
+			if endpoint.Async && (endpoint.Response.BodyObject != nil || len(endpoint.Response.BodyFields) > 0) {
+				return fmt.Errorf("%s: endpoint '%s%s' responds with the %s it started and cannot declare a response body", errorInvalidAsync, resource.Name, endpoint.Name, OperationObjectName)
+			}
+		}
+	}
+	if service.HasObject(OperationObjectName) {
+		return fmt.Errorf("%s: the object name '%s' is reserved for the operations of the async endpoints", errorInvalidAsync, OperationObjectName)
+	}
+	if service.HasEnum(operationStatusEnumName) {
+		return fmt.Errorf("%s: the enum name '%s' is reserved for the operations of the async endpoints", errorInvalidAsync, operationStatusEnumName)
+	}
+	return nil
+}
+
+// validateBasePath validates the base path of the service, which is empty for the default base path.
+// It must start with a slash, not end with one unless it is the root path, and cannot have path parameters.
+func validateBasePath(basePath string) error {
+	if basePath == "" || basePath == pathSeparator {
+		return nil
+	}
+	if !strings.HasPrefix(basePath, pathSeparator) || strings.HasSuffix(basePath, pathSeparator) {
+		return fmt.Errorf("%s: base_path '%s' must start with '/' and not end with '/'", errorInvalidBasePath, basePath)
+	}
+	if strings.ContainsAny(basePath, "{}:*?# ") {
+		return fmt.Errorf("%s: base_path '%s' cannot have path parameters, wildcards, queries or spaces", errorInvalidBasePath, basePath)
+	}
+	return nil
+}
+
+// validateResponseHeaders validates that the header names of the response headers are HTTP tokens and that
+// no two response headers have the same name on the wire, where header names are case-insensitive.
+func validateResponseHeaders(headers []Field) error {
+	seen := make(map[string]string)
+	for _, header := range headers {
+		name := header.GetHeaderName()
+		if header.HeaderName != "" && !isHeaderToken(name) {
+			return fmt.Errorf("%s: header_name '%s' of the response header '%s' must be an HTTP token", errorInvalidHeader, name, header.Name)
+		}
+		if other, ok := seen[strings.ToLower(name)]; ok {
+			return fmt.Errorf("%s: the response headers '%s' and '%s' have the same header name '%s'", errorInvalidHeader, other, header.Name, name)
+		}
+		seen[strings.ToLower(name)] = header.Name
+	}
+	return nil
+}
+
+// validateEndpointResponseHeaders validates the header names of the response headers of an endpoint, see
+// validateResponseHeaders, and that no response header has the Go name of a body field, since the response type of
+// the endpoint has a field for each of them.
+func validateEndpointResponseHeaders(response *EndpointResponse) error {
+	if err := validateResponseHeaders(response.Headers); err != nil {
+		return err
+	}
+
+	for _, header := range response.Headers {
+		if slices.ContainsFunc(response.BodyFields, func(field Field) bool { return field.GetGoName() == header.GetGoName() }) {
+			return fmt.Errorf("%s: the response header '%s' has the name of a body field", errorInvalidHeader, header.Name)
+		}
+	}
+	return nil
+}
+
+// isHeaderToken reports whether the name is a token of RFC 9110, the characters allowed in a header name.
+func isHeaderToken(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		if r > unicode.MaxASCII || (!unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune("!#$%&'*+-.^_`|~", r)) {
+			return false
+		}
+	}
+	return true
+}
+
+// validateExternalDocs validates that external documentation, when set, links an absolute URL.
+func validateExternalDocs(docs *ExternalDocs) error {
+	if docs == nil {
+		return nil
+	}
+	if docs.URL == "" {
+		return fmt.Errorf("%s: url is required", errorInvalidDocs)
+	}
+	if !isAbsoluteURL(docs.URL) {
+		return fmt.Errorf("%s: url '%s' must be an absolute URL", errorInvalidDocs, docs.URL)
+	}
+	return nil
+}
+
+// isAbsoluteURL reports whether the value is an absolute URL with a host.
+func isAbsoluteURL(value string) bool {
+	parsed, err := url.Parse(value)
+	return err == nil && parsed.IsAbs() && parsed.Host != ""
+}
+
+// validateStability validates the stability of a resource or endpoint, which is empty when not set.
+func validateStability(stability string) error {
+	stabilities := []string{StabilityExperimental, StabilityBeta, StabilityStable}
+	if stability != "" && !slices.Contains(stabilities, stability) {
+		return fmt.Errorf("%s: stability '%s' must be one of: %v", errorInvalidStability, stability, stabilities)
+	}
+	return nil
+}
+
+// validateDescriptionFile validates that a description is either written inline or loaded from a markdown file.
+func validateDescriptionFile(description, descriptionFile string) error {
+	if descriptionFile == "" {
+		return nil
+	}
+	if description != "" {
+		return fmt.Errorf("%s: description and description_file cannot both be set", errorInvalidDescFile)
+	}
+	if filepath.IsAbs(descriptionFile) {
+		return fmt.Errorf("%s: description_file '%s' must be relative to the specification file", errorInvalidDescFile, descriptionFile)
+	}
+	return nil
+}
+
+// validateErrorFormat validates the error format of the service, which is empty for the default format.
+func validateErrorFormat(format string) error {
+	if format != "" && format != ErrorFormatProblemJSON {
+		return fmt.Errorf("%s: error_format '%s' must be one of: %v", errorInvalidFormat, format, []string{ErrorFormatProblemJSON})
+	}
+	return nil
+}
+
+// validateLocalization validates the message catalog, every message must be of an error code and have a valid
+// locale, and every error code must have a message in the default locale.
+func validateLocalization(service *Service) error {
+	localization := service.Localization
+	if !localePattern.MatchString(localization.DefaultLocale) {
+		return fmt.Errorf("%s: defaultLocale '%s' must be a language tag, for example en or sv-SE", errorInvalidCatalog, localization.DefaultLocale)
+	}
+
+	// The codes are those of the ErrorCode enum of the specification, or of the default one when it has none
+	errorCodes := createDefaultErrorCodeEnum(service).Values
+	for _, enum := range service.Enums {
+		if enum.Name == errorCodeEnumName {
+			errorCodes = enum.Values
+		}
+	}
+
+	for _, code := range slices.Sorted(maps.Keys(localization.Messages)) {
+		if !slices.ContainsFunc(errorCodes, func(value EnumValue) bool { return value.Name == code }) {
+			return fmt.Errorf("%s: '%s' is not a value of the %s enum", errorInvalidCatalog, code, errorCodeEnumName)
+		}
+
+		messages := localization.Messages[code]
+		for _, locale := range slices.Sorted(maps.Keys(messages)) {
+			if !localePattern.MatchString(locale) {
+				return fmt.Errorf("%s: locale '%s' of '%s' must be a language tag, for example en or sv-SE", errorInvalidCatalog, locale, code)
+			}
+		}
+
+		if _, ok := messages[localization.DefaultLocale]; !ok {
+			return fmt.Errorf("%s: '%s' has no message in the default locale '%s'", errorInvalidCatalog, code, localization.DefaultLocale)
+		}
+	}
+	return nil
+}
+
+// validateServer validates that every placeholder in the URL of a server is a variable with a default,
+// and that every variable is used in the URL with a default among its enum values.
+func validateServer(server ServiceServer) error {
+	for _, placeholder := range serverVariablePattern.FindAllString(server.URL, -1) {
+		if server.GetVariable(strings.Trim(placeholder, "{}")) == nil {
+			return fmt.Errorf("%s: placeholder '%s' of the URL is not a variable of the server", errorInvalidServer, placeholder)
+		}
+	}
+
+	for i, variable := range server.Variables {
+		if variable.Name == "" {
+			return fmt.Errorf("%s: variable name is required", errorInvalidServer)
+		}
+		if slices.ContainsFunc(server.Variables[:i], func(other ServerVariable) bool { return other.Name == variable.Name }) {
+			return fmt.Errorf("%s: variable '%s' is declared more than once", errorInvalidServer, variable.Name)
+		}
+		if !strings.Contains(server.URL, "{"+variable.Name+"}") {
+			return fmt.Errorf("%s: variable '%s' is not used in the URL '%s'", errorInvalidServer, variable.Name, server.URL)
+		}
+		if variable.Default == "" {
+			return fmt.Errorf("%s: variable '%s' requires a default", errorInvalidServer, variable.Name)
+		}
+		if len(variable.Enum) > 0 && !slices.Contains(variable.Enum, variable.Default) {
+			return fmt.Errorf("%s: default '%s' of variable '%s' must be one of: %v", errorInvalidServer, variable.Default, variable.Name, variable.Enum)
+		}
+	}
+
+	return nil
+}
+
+// validateTenancy validates the tenancy configuration.
+func validateTenancy(tenancy *Tenancy) error {
+	if tenancy.In != TenancyInHeader && tenancy.In != TenancyInPath {
+		return fmt.Errorf("%s: in '%s' must be one of: %v", errorInvalidTenancy, tenancy.In, []string{TenancyInHeader, TenancyInPath})
+	}
+	if tenancy.Name == "" {
+		return fmt.Errorf("%s: name is required", errorInvalidTenancy)
+	}
+	return nil
+}
+
+// validateNaming validates the naming conventions of the paths.
+func validateNaming(naming *Naming) error {
+	if naming.PathCase != "" && naming.PathCase != PathCaseKebab && naming.PathCase != PathCaseSnake {
+		return fmt.Errorf("%s: path_case '%s' must be one of: %v", errorInvalidNaming, naming.PathCase, []string{PathCaseKebab, PathCaseSnake})
+	}
+	if naming.TypeCollisions != "" && naming.TypeCollisions != TypeCollisionsError && naming.TypeCollisions != TypeCollisionsRename {
+		return fmt.Errorf("%s: type_collisions '%s' must be one of: %v", errorInvalidNaming, naming.TypeCollisions, []string{TypeCollisionsError, TypeCollisionsRename})
+	}
+	if naming.JSON != "" && naming.JSON != JSONCaseCamel && naming.JSON != JSONCaseSnake && naming.JSON != JSONCaseAsIs {
+		return fmt.Errorf("%s: json '%s' must be one of: %v", errorInvalidNaming, naming.JSON, []string{JSONCaseCamel, JSONCaseSnake, JSONCaseAsIs})
+	}
+	return nil
+}
+
+// validateSecurity validates the security schemes and the security requirements of the service.
+func validateSecurity(service *Service) error {
+	for _, name := range slices.Sorted(maps.Keys(service.SecuritySchemes)) {
+		scheme := service.SecuritySchemes[name]
+		switch scheme.Type {
+		case SecuritySchemeTypeHTTP, SecuritySchemeTypeAPIKey, SecuritySchemeTypeMutualTLS:
+		case SecuritySchemeTypeOAuth2:
+			if !scheme.Flows.HasFlow() {
+				return fmt.Errorf("%s: scheme '%s' of type %s must define at least one flow", errorInvalidSecurity, name, scheme.Type)
+			}
+		case SecuritySchemeTypeOpenIDConnect:
+			if scheme.OpenIDConnectURL == "" {
+				return fmt.Errorf("%s: scheme '%s' of type %s must define openIdConnectUrl", errorInvalidSecurity, name, scheme.Type)
+			}
+		default:
+			return fmt.Errorf("%s: scheme '%s' has unsupported type '%s'", errorInvalidSecurity, name, scheme.Type)
+		}
+	}
+
+	for i, requirement := range service.Security {
+		for _, schemeName := range requirement {
+			if _, exists := service.SecuritySchemes[schemeName]; !exists {
+				return fmt.Errorf("%s: requirement %d references undefined scheme '%s'", errorInvalidSecurity, i, schemeName)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateScopes validates that required scopes are declared by an OAuth2 flow of the service.
+// Scopes cannot be checked when an OpenID Connect scheme is defined, since those are discovered at runtime.
+func validateScopes(service *Service, scopes []string) error {
+	if len(scopes) == 0 {
+		return nil
+	}
+
+	if !service.HasScopedSecurityScheme() {
+		return fmt.Errorf("%s: scopes require an %s or %s security scheme", errorInvalidSecurity, SecuritySchemeTypeOAuth2, SecuritySchemeTypeOpenIDConnect)
+	}
+
+	for _, scheme := range service.SecuritySchemes {
+		if scheme.Type == SecuritySchemeTypeOpenIDConnect {
+			return nil
+		}
+	}
+
+	for _, scope := range scopes {
+		if !service.HasScope(scope) {
+			return fmt.Errorf("%s: scope '%s' is not declared by any OAuth2 flow", errorUndefinedScope, scope)
+		}
+	}
+
+	return nil
+}
+
+// validateFieldScopes validates the scopes required to read a field like the scopes of the endpoints,
+// and that the field is nullable, since it is redacted to null for the sessions without them.
+func validateFieldScopes(service *Service, field *Field) error {
+	if len(field.Scopes) == 0 {
+		return nil
+	}
+
+	if !field.IsNullable() {
+		return fmt.Errorf("%s: a field with scopes must be %s, since it is redacted to null", errorInvalidModifier, ModifierNullable)
+	}
+
+	return validateScopes(service, field.Scopes)
+}
+
+// validateRequestLimits validates that the request body size limit and the handler timeout are not negative,
+// zero means that there is no limit.
+func validateRequestLimits(maxBodyBytes int64, handlerTimeout int) error {
+	if maxBodyBytes < 0 {
+		return fmt.Errorf("%s: max_body_bytes cannot be negative, got %d", errorInvalidLimit, maxBodyBytes)
+	}
+	if handlerTimeout < 0 {
+		return fmt.Errorf("%s: handler_timeout cannot be negative, got %d", errorInvalidLimit, handlerTimeout)
+	}
+	return nil
+}
+
+// validateFilterMaxDepth validates that the max depth of the nested search filters is not negative.
+func validateFilterMaxDepth(maxDepth int) error {
+	if maxDepth < 0 {
+		return fmt.Errorf("%s: filter_max_depth cannot be negative, got %d", errorInvalidLimit, maxDepth)
+	}
+	return nil
+}
+
+// validateMaxLimit validates that the max limit of the pagination is not negative, and not below the default limit,
+// which the List and Search endpoints would otherwise exceed without a limit in the request.
+func validateMaxLimit(maxLimit int) error {
+	if maxLimit < 0 {
+		return fmt.Errorf("%s: max_limit cannot be negative, got %d", errorInvalidLimit, maxLimit)
+	}
+	if maxLimit > 0 && maxLimit < DefaultLimit {
+		return fmt.Errorf("%s: max_limit cannot be less than the default limit %d, got %d", errorInvalidLimit, DefaultLimit, maxLimit)
+	}
+	return nil
+}
+
+// validateRequestLimitErrorCodes validates that an ErrorCode enum of the specification has the codes
+// that the request limits respond with, since the default enum is not added when one is defined.
+func validateRequestLimitErrorCodes(service *Service) error {
+	for _, enum := range service.Enums {
+		if enum.Name != errorCodeEnumName {
+			continue
+		}
+		if service.HasHandlerTimeouts() && !slices.ContainsFunc(enum.Values, func(value EnumValue) bool { return value.Name == errorCodeRequestTimeout }) {
+			return fmt.Errorf("%s: handler_timeout requires the value '%s' in the %s enum", errorInvalidLimit, errorCodeRequestTimeout, errorCodeEnumName)
+		}
+		if service.HasBodyLimits() && !slices.ContainsFunc(enum.Values, func(value EnumValue) bool { return value.Name == errorCodePayloadTooLarge }) {
+			return fmt.Errorf("%s: max_body_bytes requires the value '%s' in the %s enum", errorInvalidLimit, errorCodePayloadTooLarge, errorCodeEnumName)
+		}
+	}
+	return nil
+}
+
+// validatePermissions validates that the permissions are neither empty nor duplicated.
+func validatePermissions(permissions []string) error {
+	seen := make(map[string]bool, len(permissions))
+	for _, permission := range permissions {
+		if strings.TrimSpace(permission) == "" {
+			return fmt.Errorf("%s: permission cannot be empty", errorInvalidPermission)
+		}
+		if seen[permission] {
+			return fmt.Errorf("%s: duplicate permission '%s'", errorInvalidPermission, permission)
+		}
+		seen[permission] = true
+	}
+
+	return nil
+}
+
+// validateRetryConfiguration validates a retry configuration against the defined rules.
+func validateRetryConfiguration(retry *RetryConfiguration) error {
+	if retry == nil {
+		return nil
+	}
+
+	// Validate strategy
+	if retry.Strategy != "" && retry.Strategy != RetryStrategyBackoff {
+		return fmt.Errorf("retry strategy '%s' must be 'backoff'", retry.Strategy)
+	}
+
+	// Validate backoff configuration
+	if retry.Backoff.InitialInterval < 0 {
+		return fmt.Errorf("retry backoff initial interval must be non-negative, got: %d", retry.Backoff.InitialInterval)
+	}
+
+	if retry.Backoff.MaxInterval < 0 {
+		return fmt.Errorf("retry backoff max interval must be non-negative, got: %d", retry.Backoff.MaxInterval)
+	}
+
+	if retry.Backoff.MaxElapsedTime < 0 {
+		return fmt.Errorf("retry backoff max elapsed time must be non-negative, got: %d", retry.Backoff.MaxElapsedTime)
+	}
+
+	if retry.Backoff.Exponent < 0 {
+		return fmt.Errorf("retry backoff exponent must be non-negative, got: %f", retry.Backoff.Exponent)
+	}
+
+	// Validate that max interval is not less than initial interval (if both are specified)
+	if retry.Backoff.InitialInterval > 0 && retry.Backoff.MaxInterval > 0 &&
+		retry.Backoff.InitialInterval > retry.Backoff.MaxInterval {
+		return fmt.Errorf("retry backoff initial interval (%d) cannot be greater than max interval (%d)",
+			retry.Backoff.InitialInterval, retry.Backoff.MaxInterval)
+	}
+
+	// Validate status codes
+	for _, statusCode := range retry.StatusCodes {
+		if statusCode == "" {
+			return fmt.Errorf("retry status codes cannot contain empty strings")
+		}
+		// Allow common patterns like "5XX", "4XX", or specific codes like "429", "503"
+		if !isValidStatusCode(statusCode) {
+			return fmt.Errorf("retry status code '%s' is not valid", statusCode)
+		}
+	}
+
+	return nil
+}
+
+// isValidStatusCode checks if a status code is valid (either a pattern like "5XX" or specific code).
+func isValidStatusCode(code string) bool {
+	// Allow patterns like "5XX", "4XX", "3XX", "2XX", "1XX"
+	if len(code) == 3 && (code[1] == 'X' && code[2] == 'X') {
+		first := code[0]
+		return first >= '1' && first <= '5'
+	}
+
+	// Allow specific HTTP status codes (100-599)
+	if len(code) == 3 {
+		for _, r := range code {
+			if r < '0' || r > '9' {
+				return false
+			}
+		}
+		// Convert to int and check range
+		var statusCode int
+		for i, r := range code {
+			if i == 0 {
+				statusCode = int(r-'0') * 100
+			} else if i == 1 {
+				statusCode += int(r-'0') * 10
+			} else {
+				statusCode += int(r - '0')
+			}
+		}
+		return statusCode >= 100 && statusCode <= 599
+	}
+
+	return false
+}
+
+// validateResourceOperations validates that all operations are valid resource operations.
+// Resource operations are: Create, Get, List, Search, Update, Delete
+func validateResourceOperations(operations []string) error {
+	validOperations := []string{OperationCreate, OperationGet, OperationList, OperationSearch, OperationUpdate, OperationDelete}
+
+	for _, operation := range operations {
+		if !slices.Contains(validOperations, operation) {
+			return fmt.Errorf("%s: operation '%s' must be one of: %v", errorInvalidOperation, operation, validOperations)
+		}
+	}
+
+	return nil
+}
+
+// validateFieldOperations validates that all operations are valid field operations.
+// Field operations are: Create, Read, Update, Delete
+func validateFieldOperations(operations []string) error {
+	validOperations := []string{OperationCreate, OperationRead, OperationUpdate, OperationDelete}
+
+	for _, operation := range operations {
+		if !slices.Contains(validOperations, operation) {
+			return fmt.Errorf("%s: operation '%s' must be one of: %v", errorInvalidOperation, operation, validOperations)
+		}
+	}
+
+	return nil
+}
+
+// validateRequiredOn validates that the field is only required in Create or Update operations it is allowed in.
+func validateRequiredOn(field *ResourceField) error {
+	validOperations := []string{OperationCreate, OperationUpdate}
+
+	for _, operation := range field.RequiredOn {
+		if !slices.Contains(validOperations, operation) {
+			return fmt.Errorf("%s: operation '%s' must be one of: %v", errorInvalidOperation, operation, validOperations)
+		}
+		if !slices.Contains(field.Operations, operation) {
+			return fmt.Errorf("%s: field '%s' cannot be required on '%s' since it is not in its operations", errorInvalidOperation, field.Name, operation)
+		}
+	}
+
+	return nil
+}
+
+// validateFieldType validates that the field type is either a valid primitive type, enum, or object.
+func validateFieldType(service *Service, fieldType string) error {
+	// Check if it's a primitive type
+	validPrimitiveTypes := []string{
+		FieldTypeUUID, FieldTypeDate, FieldTypeTimestamp,
+		FieldTypeString, FieldTypeInt, FieldTypeInt32, FieldTypeUInt,
+		FieldTypeFloat64, FieldTypeDecimal, FieldTypeBool,
+	}
+
+	if slices.Contains(validPrimitiveTypes, fieldType) {
+		return nil
+	}
+
+	// Check if it's a valid enum
+	if service.HasEnum(fieldType) {
+		return nil
+	}
+
+	// Check if it's a valid object
+	if service.HasObject(fieldType) {
+		return nil
+	}
+
+	return fmt.Errorf("%s: field type '%s' must be one of the primitive types %v, or a valid enum/object", errorInvalidFieldType, fieldType, validPrimitiveTypes)
+}
+
+// Patterns used to validate default values of string based primitive types
+var (
+	decimalDefaultPattern = regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?$`)
+	sdkNamePattern        = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9]*$`)
+	uuidDefaultPattern    = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	localePattern         = regexp.MustCompile(`^[a-zA-Z]{2,3}(-[a-zA-Z0-9]{2,8})*$`)
+)
+
+// transliterations maps the Latin letters with diacritics to their ASCII letters, see Transliterate
+var transliterations = func() map[rune]string {
+	result := map[rune]string{
+		'Æ': "Ae", 'æ': "ae", 'Œ': "Oe", 'œ': "oe", 'ß': "ss", 'Þ': "Th", 'þ': "th",
+		'Ð': "D", 'ð': "d", 'Đ': "D", 'đ': "d", 'Ħ': "H", 'ħ': "h", 'ı': "i", 'Ł': "L", 'ł': "l", 'Ø': "O", 'ø': "o",
+	}
+	for ascii, letters := range map[string]string{
+		"A": "ÀÁÂÃÄÅĀĂĄ", "a": "àáâãäåāăą",
+		"C": "ÇĆĈĊČ", "c": "çćĉċč",
+		"D": "Ď", "d": "ď",
+		"E": "ÈÉÊËĒĔĖĘĚ", "e": "èéêëēĕėęě",
+		"G": "ĜĞĠĢ", "g": "ĝğġģ",
+		"H": "Ĥ", "h": "ĥ",
+		"I": "ÌÍÎÏĨĪĬĮİ", "i": "ìíîïĩīĭį",
+		"J": "Ĵ", "j": "ĵ",
+		"K": "Ķ", "k": "ķ",
+		"L": "ĹĻĽĿ", "l": "ĺļľŀ",
+		"N": "ÑŃŅŇ", "n": "ñńņň",
+		"O": "ÒÓÔÕÖŌŎŐ", "o": "òóôõöōŏő",
+		"R": "ŔŖŘ", "r": "ŕŗř",
+		"S": "ŚŜŞŠȘ", "s": "śŝşšș",
+		"T": "ŢŤȚ", "t": "ţťț",
+		"U": "ÙÚÛÜŨŪŬŮŰŲ", "u": "ùúûüũūŭůűų",
+		"W": "Ŵ", "w": "ŵ",
+		"Y": "ÝŸŶ", "y": "ýÿŷ",
+		"Z": "ŹŻŽ", "z": "źżž",
+	} {
+		for _, letter := range letters {
+			result[letter] = ascii
+		}
+	}
+	return result
+}()
+
+// serverVariablePattern matches the {name} placeholders of the variables in the URL of a server
+var serverVariablePattern = regexp.MustCompile(`\{[^{}]+\}`)
+
+// validateFieldDefault validates that the default value of a field can be parsed as the field type.
+// Defaults are only supported on primitive and enum fields that are not arrays.
+func validateFieldDefault(service *Service, field *Field) error {
+	if field.Default == "" {
+		return nil
+	}
+
+	if field.IsArray() || service.HasObject(field.Type) {
+		return fmt.Errorf("%s: defaults are only supported on primitive and enum fields, got '%s' for field '%s'", errorInvalidDefault, field.Default, field.Name)
+	}
+
+	var err error
+	switch field.Type {
+	case FieldTypeInt:
+		_, err = strconv.ParseInt(field.Default, 10, 64)
+	case FieldTypeInt32:
+		_, err = strconv.ParseInt(field.Default, 10, 32)
+	case FieldTypeUInt:
+		_, err = strconv.ParseUint(field.Default, 10, 64)
+	case FieldTypeFloat64:
+		_, err = strconv.ParseFloat(field.Default, 64)
+	case FieldTypeDecimal:
+		if !decimalDefaultPattern.MatchString(field.Default) {
+			err = fmt.Errorf("not a decimal")
+		}
+	case FieldTypeBool:
+		if field.Default != "true" && field.Default != "false" {
+			err = fmt.Errorf("must be true or false")
+		}
+	case FieldTypeUUID:
+		if !uuidDefaultPattern.MatchString(field.Default) {
+			err = fmt.Errorf("not a UUID")
+		}
+	case FieldTypeDate:
+		_, err = time.Parse(time.DateOnly, field.Default)
+	case FieldTypeTimestamp:
+		_, err = time.Parse(time.RFC3339, field.Default)
+	default:
+		if service.HasEnum(field.Type) && !service.hasEnumValue(field.Type, field.Default) {
+			err = fmt.Errorf("not a value of enum '%s'", field.Type)
+		}
+	}
+
+	if err != nil {
+		return fmt.Errorf("%s: '%s' is not a valid %s for field '%s': %w", errorInvalidDefault, field.Default, field.Type, field.Name, err)
+	}
+
+	return nil
+}
+
+// validateModifiers validates that all modifiers are in PascalCase and are valid modifiers.
+func validateModifiers(modifiers []string) error {
+	validModifiers := []string{ModifierNullable, ModifierArray}
+
+	for _, modifier := range modifiers {
+		if !slices.Contains(validModifiers, modifier) {
+			return fmt.Errorf("%s: modifier '%s' must be one of: %v", errorInvalidModifier, modifier, validModifiers)
+		}
+	}
+
+	return nil
+}
+
+// Parsing functions
+
+// ParseServiceFromFile reads and parses a YAML or JSON specification file,
+// automatically applying overlays to ensure complete specification.
+// The file is parsed and validated like ParseServiceFromBytesInDir, relative to its directory.
+func ParseServiceFromFile(filePath string) (*Service, error) {
+	// Check if file exists
+	if _, err := os.Stat(filePath); os.IsNotExist(err) {
+		return nil, fmt.Errorf("%s: file does not exist: %s", errorInvalidFile, filePath)
+	}
+
+	// Read file contents
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errorFileRead, err)
+	}
+
+	// Parse based on file extension, loading the description files next to the specification file
+	ext := strings.ToLower(filepath.Ext(filePath))
+	service, err := ParseServiceFromBytesInDir(data, ext, filepath.Dir(filePath))
+	if err != nil {
+		// Annotate the validation errors with the file, to print them as file:line:column
+		var validationErrors ValidationErrors
+		if errors.As(err, &validationErrors) {
+			for _, validationErr := range validationErrors {
+				validationErr.File = filePath
+			}
+		}
+		return nil, err
 	}
+
+	return service, nil
 }
 
-// createAutoColumnCreatedAt creates a standard CreatedAt field for auto-columns.
-func createAutoColumnCreatedAt(resourceName string) Field {
-	return Field{
-		Name:        autoColumnCreatedAtName,
-		Description: fmt.Sprintf(autoColumnCreatedAtTemplate, resourceName),
-		Type:        FieldTypeTimestamp,
-		Example:     "2024-01-15T10:30:00Z",
-	}
+// ParseServiceFromBytes parses a service from byte data and file extension,
+// automatically applying overlays to ensure complete specification.
+// Description files are resolved relative to the working directory.
+func ParseServiceFromBytes(data []byte, fileExtension string) (*Service, error) {
+	return ParseServiceFromBytesInDir(data, fileExtension, "")
 }
 
-// createAutoColumnCreatedBy creates a standard CreatedBy field for auto-columns.
-func createAutoColumnCreatedBy(resourceName string) Field {
-	return Field{
-		Name:        autoColumnCreatedByName,
-		Description: fmt.Sprintf(autoColumnCreatedByTemplate, resourceName),
-		Type:        FieldTypeUUID,
-		Modifiers:   []string{ModifierNullable},
-		Example:     "987fcdeb-51a2-43d1-b567-123456789abc",
+// ParseServiceFromBytesInDir parses a service from byte data and file extension, read from a file in dir,
+// automatically applying overlays to ensure complete specification.
+// Description files are resolved relative to dir. The complete specification is validated with ValidateSemantics,
+// which all the other parsing functions go through too.
+func ParseServiceFromBytesInDir(data []byte, fileExtension, dir string) (*Service, error) {
+	service, err := parseServiceFromBytesInDir(data, fileExtension, dir)
+	if err != nil {
+		return nil, err
 	}
+
+	return completeService(service)
 }
 
-// createAutoColumnUpdatedAt creates a standard UpdatedAt field for auto-columns.
-func createAutoColumnUpdatedAt(resourceName string) Field {
-	return Field{
-		Name:        autoColumnUpdatedAtName,
-		Description: fmt.Sprintf(autoColumnUpdatedAtTemplate, resourceName),
-		Type:        FieldTypeTimestamp,
-		Example:     "2024-01-15T14:45:00Z",
-	}
+// ParseServiceFromJSON parses a service from JSON data,
+// automatically applying overlays to ensure complete specification.
+func ParseServiceFromJSON(data []byte) (*Service, error) {
+	return ParseServiceFromBytes(data, extJSON)
 }
 
-// createAutoColumnUpdatedBy creates a standard UpdatedBy field for auto-columns.
-func createAutoColumnUpdatedBy(resourceName string) Field {
-	return Field{
-		Name:        autoColumnUpdatedByName,
-		Description: fmt.Sprintf(autoColumnUpdatedByTemplate, resourceName),
-		Type:        FieldTypeUUID,
-		Modifiers:   []string{ModifierNullable},
-		Example:     "987fcdeb-51a2-43d1-b567-123456789abc",
-	}
+// ParseServiceFromYAML parses a service from YAML data,
+// automatically applying overlays to ensure complete specification.
+func ParseServiceFromYAML(data []byte) (*Service, error) {
+	return ParseServiceFromBytes(data, extYAML)
 }
 
-// createAutoColumns creates all standard auto-column fields for resources.
-func createAutoColumns(resourceName string) []Field {
-	return []Field{
-		createAutoColumnID(resourceName),
-		createAutoColumnCreatedAt(resourceName),
-		createAutoColumnCreatedBy(resourceName),
-		createAutoColumnUpdatedAt(resourceName),
-		createAutoColumnUpdatedBy(resourceName),
+// completeService applies the overlays to the parsed service and validates the semantics of the complete service.
+func completeService(service *Service) (*Service, error) {
+	// Apply overlays to ensure complete specification, whose semantics are then validated
+	service = ApplyDefaultOverlays(service)
+	if err := ValidateSemantics(service); err != nil {
+		return nil, fmt.Errorf("%s: %w", errorValidationFailed, err)
 	}
+
+	return service, nil
 }
 
-// createDefaultMeta creates a standard Meta object containing creation and update metadata fields.
-func createDefaultMeta() Object {
-	return Object{
-		Name:        metaObjectName,
-		Description: metaObjectDescription,
-		Fields: []Field{
-			{
-				Name:        autoColumnCreatedAtName,
-				Description: "Timestamp when the resource was created",
-				Type:        FieldTypeTimestamp,
-				Example:     "2024-01-15T10:30:00Z",
-			},
-			{
-				Name:        autoColumnCreatedByName,
-				Description: "User who created the resource",
-				Type:        FieldTypeUUID,
-				Modifiers:   []string{ModifierNullable},
-				Example:     "987fcdeb-51a2-43d1-b567-123456789abc",
-			},
-			{
-				Name:        autoColumnUpdatedAtName,
-				Description: "Timestamp when the resource was last updated",
-				Type:        FieldTypeTimestamp,
-				Modifiers:   []string{ModifierNullable},
-				Example:     "2024-01-15T14:45:00Z",
-			},
-			{
-				Name:        autoColumnUpdatedByName,
-				Description: "User who last updated the resource",
-				Type:        FieldTypeUUID,
-				Modifiers:   []string{ModifierNullable},
-				Example:     "987fcdeb-51a2-43d1-b567-123456789abc",
-			},
-		},
+// Canonical serialization of a service, so that the output only changes when the service changes:
+//   - "name", "version", "summary" and "description" come first, in that order,
+//   - then all other scalar keys, in alphabetical order,
+//   - then all keys holding objects or lists, in alphabetical order,
+//   - enums and objects of the service are sorted by name, since their order has no meaning,
+//     while resources, fields, endpoints and enum values keep the order of the specification.
+var (
+	canonicalLeadingKeys       = []string{"name", "version", "summary", "description"}
+	canonicalSortedCollections = []string{"enums", "objects"}
+	canonicalCollectionSortKey = "name"
+)
+
+// MarshalServiceYAML marshals a service to YAML in canonical order.
+func MarshalServiceYAML(service *Service) ([]byte, error) {
+	value, err := canonicalServiceValue(service)
+	if err != nil {
+		return nil, err
 	}
+
+	return yaml.Marshal(value)
 }
 
-// createAutoColumnsWithMeta creates auto-column fields using Meta object for metadata fields.
-func createAutoColumnsWithMeta(resourceName string) []Field {
-	return []Field{
-		createAutoColumnID(resourceName),
-		{
-			Name:        metaObjectName,
-			Description: fmt.Sprintf("Metadata information for the %s", resourceName),
-			Type:        metaObjectName,
-		},
+// MarshalServiceJSON marshals a service to indented JSON in canonical order.
+func MarshalServiceJSON(service *Service) ([]byte, error) {
+	value, err := canonicalServiceValue(service)
+	if err != nil {
+		return nil, err
 	}
-}
 
-// Helper functions
+	return json.MarshalIndent(canonicalJSONValue(value), "", "  ")
+}
 
-// CamelCase converts a string to camelCase format.
-// Special cases:
-// - "ID" becomes "ID" instead of "iD"
-// - Consecutive capital letters at the start are lowercased (e.g., "CSNSchoolCode" -> "csnSchoolCode")
-func CamelCase(s string) string {
-	if s == "ID" {
-		return "id"
+// canonicalServiceValue converts a service to a tree of yaml.MapSlice in canonical order.
+// The service is converted through JSON, so both formats omit the same empty values.
+func canonicalServiceValue(service *Service) (any, error) {
+	data, err := json.Marshal(service)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal specification: %w", err)
 	}
 
-	result := strmangle.CamelCase(s)
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
 
-	// Handle consecutive capital letters at the beginning
-	// Convert sequences like "cSNSchool" to "csnSchool"
-	if len(result) > 1 {
-		runes := []rune(result)
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, fmt.Errorf("failed to decode specification: %w", err)
+	}
 
-		// Find the end of consecutive uppercase letters at the beginning (after first char)
-		consecutiveEnd := 1
-		for consecutiveEnd < len(runes) && runes[consecutiveEnd] >= 'A' && runes[consecutiveEnd] <= 'Z' {
-			consecutiveEnd++
+	root := canonicalValue(value).(yaml.MapSlice)
+	for i, item := range root {
+		if slices.Contains(canonicalSortedCollections, item.Key.(string)) {
+			root[i].Value = sortCanonicalCollection(item.Value)
 		}
+	}
 
-		// If we have consecutive uppercase letters, convert them to lowercase
-		// except possibly the last one if it's followed by lowercase letters
-		if consecutiveEnd > 2 {
-			// If the sequence is followed by lowercase letters, keep the last uppercase letter
-			// Example: "cSNSchool" -> "csnSchool" (convert "SN" to "sn", keep "S" before "chool")
-			if consecutiveEnd < len(runes) && runes[consecutiveEnd] >= 'a' && runes[consecutiveEnd] <= 'z' {
-				consecutiveEnd--
-			}
+	return root, nil
+}
 
-			// Convert consecutive uppercase letters to lowercase
-			for i := 1; i < consecutiveEnd; i++ {
-				if runes[i] >= 'A' && runes[i] <= 'Z' {
-					runes[i] = runes[i] - 'A' + 'a'
-				}
-			}
+// canonicalValue converts a decoded JSON value to a tree of yaml.MapSlice in canonical key order.
+func canonicalValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		slices.SortFunc(keys, func(a, b string) int {
+			return compareCanonicalKeys(a, b, v)
+		})
 
-			result = string(runes)
+		result := make(yaml.MapSlice, 0, len(keys))
+		for _, key := range keys {
+			result = append(result, yaml.MapItem{Key: key, Value: canonicalValue(v[key])})
+		}
+		return result
+	case []any:
+		result := make([]any, len(v))
+		for i, item := range v {
+			result[i] = canonicalValue(item)
 		}
+		return result
+	case json.Number:
+		if integer, err := v.Int64(); err == nil {
+			return integer
+		}
+		if float, err := v.Float64(); err == nil {
+			return float
+		}
+		return v.String()
+	default:
+		return v
 	}
-
-	return result
 }
 
-// toKebabCase converts a string to kebab-case format.
-func toKebabCase(s string) string {
-	// Handle empty string
-	if s == "" {
-		return s
+// compareCanonicalKeys orders leading keys first, then scalar keys, then nested keys, each alphabetically.
+func compareCanonicalKeys(a, b string, values map[string]any) int {
+	rank := func(key string) int {
+		if index := slices.Index(canonicalLeadingKeys, key); index >= 0 {
+			return index
+		}
+		switch values[key].(type) {
+		case map[string]any, []any:
+			return len(canonicalLeadingKeys) + 1
+		default:
+			return len(canonicalLeadingKeys)
+		}
 	}
 
-	// First normalize spaces and underscores to hyphens, then handle PascalCase
-	normalized := strings.ReplaceAll(s, "_", "-")
-	normalized = strings.ReplaceAll(normalized, " ", "-")
+	if rankA, rankB := rank(a), rank(b); rankA != rankB {
+		return rankA - rankB
+	}
 
-	// Convert PascalCase/camelCase to kebab-case
-	var result strings.Builder
-	runes := []rune(normalized)
+	return strings.Compare(a, b)
+}
 
-	for i, r := range runes {
-		// Insert hyphen before uppercase letters in these cases:
-		// 1. Before an uppercase letter that follows a lowercase letter or digit
-		// 2. Before the last uppercase letter in a sequence of uppercase letters if followed by lowercase
-		if i > 0 && r >= 'A' && r <= 'Z' && runes[i-1] != '-' {
-			prev := runes[i-1]
+// sortCanonicalCollection sorts a list of objects by name.
+func sortCanonicalCollection(value any) any {
+	items, ok := value.([]any)
+	if !ok {
+		return value
+	}
 
-			// Case 1: Previous character is lowercase or digit
-			if (prev >= 'a' && prev <= 'z') || (prev >= '0' && prev <= '9') {
-				result.WriteByte('-')
-			} else if prev >= 'A' && prev <= 'Z' {
-				// Case 2: Previous character is uppercase, check if current is followed by lowercase
-				if i+1 < len(runes) && runes[i+1] >= 'a' && runes[i+1] <= 'z' {
-					result.WriteByte('-')
+	name := func(item any) string {
+		object, ok := item.(yaml.MapSlice)
+		if !ok {
+			return ""
+		}
+		for _, entry := range object {
+			if entry.Key == canonicalCollectionSortKey {
+				if value, ok := entry.Value.(string); ok {
+					return value
 				}
 			}
 		}
-		result.WriteRune(r)
+		return ""
 	}
 
-	// Convert to lowercase
-	return strings.ToLower(result.String())
-}
-
-func getComment(tabs string, description string, name string) string {
-	comment := description
+	slices.SortStableFunc(items, func(a, b any) int {
+		return strings.Compare(name(a), name(b))
+	})
 
-	if !strings.HasPrefix(description, name) {
-		comment = name + ": " + description
-	}
+	return items
+}
 
-	// Every new line should be prefixed with a //
-	lines := strings.Split(comment, "\n")
-	for i, line := range lines {
-		lines[i] = tabs + "// " + line
-	}
+// canonicalJSONObject is a yaml.MapSlice that marshals to a JSON object, keeping the order of its keys.
+type canonicalJSONObject yaml.MapSlice
 
-	comment = strings.Join(lines, "\n")
-	comment = strings.TrimSuffix(comment, tabs+"\n// ")
+// MarshalJSON marshals the object with its keys in order.
+func (o canonicalJSONObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, item := range o {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
 
-	return comment
-}
+		key, err := json.Marshal(item.Key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(item.Value)
+		if err != nil {
+			return nil, err
+		}
 
-// Validation functions
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
 
-// ValidationError represents a validation error with position information.
-type ValidationError struct {
-	Message string
-	Line    int
-	Column  int
-	Path    string
+	return buf.Bytes(), nil
 }
 
-func (e *ValidationError) Error() string {
-	if e.Line > 0 && e.Column > 0 {
-		return fmt.Sprintf("validation error at line %d, column %d (%s): %s", e.Line, e.Column, e.Path, e.Message)
+// canonicalJSONValue converts a tree of yaml.MapSlice to a tree of canonicalJSONObject.
+func canonicalJSONValue(value any) any {
+	switch v := value.(type) {
+	case yaml.MapSlice:
+		result := make(canonicalJSONObject, len(v))
+		for i, item := range v {
+			result[i] = yaml.MapItem{Key: item.Key, Value: canonicalJSONValue(item.Value)}
+		}
+		return result
+	case []any:
+		result := make([]any, len(v))
+		for i, item := range v {
+			result[i] = canonicalJSONValue(item)
+		}
+		return result
+	default:
+		return v
 	}
-	return fmt.Sprintf("validation error (%s): %s", e.Path, e.Message)
 }
 
-// ValidateServiceWithPosition validates a service using YAML node position information.
-func ValidateServiceWithPosition(data []byte, fileExtension string) error {
-	if fileExtension != extYAML && fileExtension != extYML {
-		// For non-YAML files, fall back to regular validation
-		var service Service
-		if fileExtension == extJSON {
-			if err := json.Unmarshal(data, &service); err != nil {
-				return fmt.Errorf("%s: JSON parsing error: %w", errorFileParse, err)
-			}
-		}
-		return validateService(&service)
+// FormatService formats the data of a specification file: keys in canonical order (see MarshalServiceYAML),
+// enums and objects sorted by name, and the casing of field types, operations, modifiers and HTTP methods
+// normalized. YAML keeps its comments and styles, JSON is indented with two spaces. Overlays are not applied.
+func FormatService(data []byte, fileExtension string) ([]byte, error) {
+	file, err := parser.ParseBytes(data, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errorFileParse, err)
 	}
 
-	// Parse YAML into struct first
 	var service Service
 	if err := yaml.Unmarshal(data, &service); err != nil {
-		return fmt.Errorf("%s: YAML parsing error: %w", errorFileParse, err)
-	}
-
-	// Parse YAML into AST to get position information for errors
-	file, err := parser.ParseBytes(data, parser.ParseComments)
-	if err != nil {
-		return fmt.Errorf("%s: %w", errorYAMLParsing, err)
+		return nil, fmt.Errorf("%s: %w", errorFileParse, err)
 	}
 
-	// Create a position tracker to map validation errors to line numbers
-	positionTracker := newPositionTracker(file)
-
-	// Run validation and enhance errors with position information
-	if validationErr := validateService(&service); validationErr != nil {
-		enhancedErr := positionTracker.EnhanceError(validationErr, &service)
-		return enhancedErr
+	formatter := newServiceFormatter(&service)
+	var header string
+	for i, doc := range file.Docs {
+		if root, ok := doc.Body.(*ast.MappingNode); ok {
+			if i == 0 {
+				header = extractHeaderComment(root)
+			}
+			formatter.formatRoot(root)
+		}
 	}
 
-	return nil
+	return marshalServiceFile(file, header, fileExtension)
 }
 
-// PositionTracker helps map validation errors to line numbers in YAML.
-type PositionTracker struct {
-	file *ast.File
-}
+// marshalServiceFile prints the AST of a specification file as JSON or YAML, based on the file extension.
+// The header comment is put first in YAML, see extractHeaderComment.
+func marshalServiceFile(file *ast.File, header string, fileExtension string) ([]byte, error) {
+	switch strings.ToLower(fileExtension) {
+	case extJSON:
+		if len(file.Docs) == 0 || file.Docs[0].Body == nil {
+			return nil, fmt.Errorf("%s: empty specification", errorInvalidFile)
+		}
 
-// newPositionTracker creates a new position tracker from a parsed YAML file.
-func newPositionTracker(file *ast.File) *PositionTracker {
-	return &PositionTracker{file: file}
-}
+		var value any
+		if err := yaml.NodeToValue(file.Docs[0].Body, &value, yaml.UseOrderedMap()); err != nil {
+			return nil, fmt.Errorf("%s: %w", errorFileParse, err)
+		}
 
-// EnhanceError enhances a validation error with position information.
-func (pt *PositionTracker) EnhanceError(err error, service *Service) error {
-	if err == nil || pt.file == nil {
-		return err
+		formatted, err := json.MarshalIndent(canonicalJSONValue(value), "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(formatted, '\n'), nil
+	case extYAML, extYML:
+		formatted := strings.TrimRight(file.String(), newlineChar) + newlineChar
+		if header != "" {
+			formatted = header + strings.TrimLeft(formatted, newlineChar)
+		}
+		return []byte(formatted), nil
+	default:
+		return nil, fmt.Errorf("%s: %s", errorUnsupportedFormat, fileExtension)
 	}
+}
 
-	// Try to extract meaningful error information and find corresponding YAML position
-	errorMsg := err.Error()
+// serviceFormatter formats the AST of a specification file, see FormatService.
+type serviceFormatter struct {
+	// names maps the lowercase names of field types, operations, modifiers and HTTP methods by key to their canonical casing
+	names map[string]map[string]string
+}
 
-	// Handle different error patterns
-	if strings.Contains(errorMsg, "invalid operation") {
-		return pt.findOperationError(errorMsg, service)
+// newServiceFormatter creates a formatter that knows the types defined by the service.
+func newServiceFormatter(service *Service) *serviceFormatter {
+	types := []string{
+		FieldTypeUUID, FieldTypeDate, FieldTypeTimestamp, FieldTypeString, FieldTypeInt, FieldTypeInt32,
+		FieldTypeUInt, FieldTypeFloat64, FieldTypeDecimal, FieldTypeBool,
+	}
+	for _, enum := range service.Enums {
+		types = append(types, enum.Name)
 	}
-	if strings.Contains(errorMsg, "invalid field type") {
-		return pt.findFieldTypeError(errorMsg, service)
+	for _, object := range service.Objects {
+		types = append(types, object.Name)
 	}
-	if strings.Contains(errorMsg, "invalid modifier") {
-		return pt.findModifierError(errorMsg, service)
+	for _, resource := range service.Resources {
+		types = append(types, resource.Name)
 	}
 
-	return err
-}
+	operations := []string{
+		OperationCreate, OperationRead, OperationUpdate, OperationDelete, OperationGet, OperationList, OperationSearch,
+	}
 
-// findOperationError tries to find line number for operation validation errors.
-func (pt *PositionTracker) findOperationError(errorMsg string, service *Service) error {
-	// Extract the invalid operation from the error message
-	// Error format: "resource 0 (Students): resource operations: invalid operation: operation 'create' must be one of: [Create Read Update Delete]"
-	if pos := pt.findInvalidValue("operations", errorMsg); pos != nil {
-		return &ValidationError{
-			Message: errorMsg,
-			Line:    pos.Line,
-			Column:  pos.Column,
-			Path:    "operations",
-		}
+	return &serviceFormatter{
+		names: map[string]map[string]string{
+			"type":        lowercaseIndex(types),
+			"operations":  lowercaseIndex(operations),
+			"required_on": lowercaseIndex(operations),
+			"modifiers":   lowercaseIndex([]string{ModifierNullable, ModifierArray, ModifierRequired}),
+			"method":      lowercaseIndex([]string{httpMethodGet, httpMethodPost, httpMethodPatch, httpMethodPut, httpMethodDelete}),
+		},
 	}
-	// If we couldn't find a position, still return the original error message
-	return fmt.Errorf("%s", errorMsg)
 }
 
-// findFieldTypeError tries to find line number for field type validation errors.
-func (pt *PositionTracker) findFieldTypeError(errorMsg string, service *Service) error {
-	// Extract the invalid type from error message
-	if pos := pt.findInvalidValue("type", errorMsg); pos != nil {
-		return &ValidationError{
-			Message: errorMsg,
-			Line:    pos.Line,
-			Column:  pos.Column,
-			Path:    "type",
-		}
+// lowercaseIndex maps the lowercase values to the values.
+func lowercaseIndex(values []string) map[string]string {
+	index := make(map[string]string, len(values))
+	for _, value := range values {
+		index[strings.ToLower(value)] = value
 	}
-	return fmt.Errorf("%s", errorMsg)
+	return index
 }
 
-// findModifierError tries to find line number for modifier validation errors.
-func (pt *PositionTracker) findModifierError(errorMsg string, service *Service) error {
-	// Extract the invalid modifier from error message
-	if pos := pt.findInvalidValue("modifiers", errorMsg); pos != nil {
-		return &ValidationError{
-			Message: errorMsg,
-			Line:    pos.Line,
-			Column:  pos.Column,
-			Path:    "modifiers",
+// formatRoot formats the root mapping of a specification file, sorting enums and objects by name.
+func (f *serviceFormatter) formatRoot(root *ast.MappingNode) {
+	f.formatNode(root)
+
+	for _, value := range root.Values {
+		if !slices.Contains(canonicalSortedCollections, value.Key.GetToken().Value) {
+			continue
+		}
+		if sequence, ok := value.Value.(*ast.SequenceNode); ok {
+			sortSequenceByName(sequence)
 		}
 	}
-	return fmt.Errorf("%s", errorMsg)
 }
 
-// findInvalidValue searches for the first occurrence of a value in the YAML that might be invalid.
-func (pt *PositionTracker) findInvalidValue(fieldName string, errorMsg string) *token.Position {
-	// This is a simplified implementation that finds the first occurrence
-	// of specific field names in the YAML and returns their position
-	if len(pt.file.Docs) > 0 {
-		return pt.findFieldInAST(fieldName, pt.file.Docs[0].Body)
+// extractHeaderComment removes the comment of the first key of a file and returns it as the header that stays at the
+// top of the file, since sorting the keys would move it below the key that comes first. The empty lines between the
+// comment lines and after the comment are kept. Returns an empty string if the first key has no comment.
+func extractHeaderComment(root *ast.MappingNode) string {
+	if len(root.Values) == 0 {
+		return ""
 	}
-	return nil
-}
 
-// findFieldInAST recursively searches for a field name in the AST.
-func (pt *PositionTracker) findFieldInAST(fieldName string, node ast.Node) *token.Position {
-	if node == nil {
-		return nil
+	first := root.Values[0]
+	comment := first.GetComment()
+	key := first.Key.GetToken()
+	if comment == nil || len(comment.Comments) == 0 || key == nil || key.Position == nil {
+		return ""
 	}
 
-	nodeType := node.Type()
-	if nodeType.String() == "Mapping" {
-		if mappingNode, ok := node.(*ast.MappingNode); ok {
-			for _, mappingValue := range mappingNode.Values {
-				// Check if this is the field we're looking for
-				if keyNode := mappingValue.Key; keyNode != nil && keyNode.Type().String() == "String" {
-					if stringNode, ok := keyNode.(*ast.StringNode); ok {
-						keyValue := stringNode.GetToken().Value
-						if keyValue == fieldName {
-							// Return position of the key (where the field name is)
-							if token := stringNode.GetToken(); token != nil && token.Position != nil {
-								return token.Position
-							}
-						}
-					}
-				}
-				// Recursively search in the value
-				if pos := pt.findFieldInAST(fieldName, mappingValue.Value); pos != nil {
-					return pos
-				}
-			}
+	var header strings.Builder
+	previousLine := 0
+	for _, line := range comment.Comments {
+		token := line.GetToken()
+		if token == nil || token.Position == nil {
+			return ""
 		}
-	} else if nodeType.String() == "Sequence" {
-		if sequenceNode, ok := node.(*ast.SequenceNode); ok {
-			for _, entry := range sequenceNode.Values {
-				if pos := pt.findFieldInAST(fieldName, entry); pos != nil {
-					return pos
-				}
-			}
+		if previousLine > 0 && token.Position.Line-previousLine > 1 {
+			header.WriteString(newlineChar)
 		}
+		header.WriteString(line.String() + newlineChar)
+		previousLine = token.Position.Line
+	}
+	if key.Position.Line-previousLine > 1 {
+		header.WriteString(newlineChar)
 	}
 
-	return nil
-}
+	// The empty line after the header is written with the header, not before the key
+	first.SetComment(nil)
+	key.Prev = nil
 
-// validateService validates the entire service specification against the defined rules.
-func validateService(service *Service) error {
-	// Validate retry configuration
-	if service.Retry != nil {
-		if err := validateRetryConfiguration(service.Retry); err != nil {
-			return fmt.Errorf("retry configuration: %w", err)
-		}
-	}
+	return header.String()
+}
 
-	// Validate resources
-	for i, resource := range service.Resources {
-		if err := validateResource(service, &resource); err != nil {
-			return fmt.Errorf("resource %d (%s): %w", i, resource.Name, err)
+// formatNode sorts the keys of all mappings in canonical order and normalizes the casing of known values.
+func (f *serviceFormatter) formatNode(node ast.Node) {
+	switch n := node.(type) {
+	case *ast.MappingNode:
+		for _, value := range n.Values {
+			f.formatMappingValue(value)
 		}
-	}
-
-	// Validate objects
-	for i, object := range service.Objects {
-		if err := validateObject(service, &object); err != nil {
-			return fmt.Errorf("object %d (%s): %w", i, object.Name, err)
+		slices.SortStableFunc(n.Values, compareCanonicalMappingValues)
+	case *ast.MappingValueNode:
+		f.formatMappingValue(n)
+	case *ast.SequenceNode:
+		for _, value := range n.Values {
+			f.formatNode(value)
 		}
 	}
-
-	return nil
 }
 
-// validateResource validates a resource and its fields against the defined rules.
-func validateResource(service *Service, resource *Resource) error {
-	// Validate resource operations (Get, List, Search, Create, Update, Delete)
-	if err := validateResourceOperations(resource.Operations); err != nil {
-		return fmt.Errorf("resource operations: %w", err)
+// formatMappingValue normalizes the casing of the value of a key, and formats nested values.
+func (f *serviceFormatter) formatMappingValue(value *ast.MappingValueNode) {
+	names, ok := f.names[value.Key.GetToken().Value]
+	if !ok {
+		f.formatNode(value.Value)
+		return
 	}
 
-	// Validate resource fields
-	for i, field := range resource.Fields {
-		if err := validateResourceField(service, &field); err != nil {
-			return fmt.Errorf("field %d (%s): %w", i, field.Name, err)
+	normalize := func(node ast.Node) {
+		if str, ok := node.(*ast.StringNode); ok {
+			if name, ok := names[strings.ToLower(str.Value)]; ok {
+				str.Value = name
+			}
 		}
 	}
 
-	// Validate endpoints
-	for i, endpoint := range resource.Endpoints {
-		if err := validateEndpoint(service, &endpoint); err != nil {
-			return fmt.Errorf("endpoint %d (%s): %w", i, endpoint.Name, err)
+	switch v := value.Value.(type) {
+	case *ast.SequenceNode:
+		for _, item := range v.Values {
+			normalize(item)
 		}
+	default:
+		normalize(v)
 	}
-
-	return nil
 }
 
-// validateObject validates an object and its fields against the defined rules.
-func validateObject(service *Service, object *Object) error {
-	// Validate object fields
-	for i, field := range object.Fields {
-		if err := validateField(service, &field); err != nil {
-			return fmt.Errorf("field %d (%s): %w", i, field.Name, err)
+// compareCanonicalMappingValues orders the keys of a mapping like compareCanonicalKeys.
+func compareCanonicalMappingValues(a, b *ast.MappingValueNode) int {
+	rank := func(value *ast.MappingValueNode) int {
+		if index := slices.Index(canonicalLeadingKeys, value.Key.GetToken().Value); index >= 0 {
+			return index
+		}
+		switch value.Value.(type) {
+		case *ast.MappingNode, *ast.MappingValueNode, *ast.SequenceNode:
+			return len(canonicalLeadingKeys) + 1
+		default:
+			return len(canonicalLeadingKeys)
 		}
 	}
 
-	return nil
-}
-
-// validateResourceField validates a resource field against the defined rules.
-func validateResourceField(service *Service, field *ResourceField) error {
-	// Validate field operations (Create, Read, Update, Delete)
-	if err := validateFieldOperations(field.Operations); err != nil {
-		return fmt.Errorf("field operations: %w", err)
+	if rankA, rankB := rank(a), rank(b); rankA != rankB {
+		return rankA - rankB
 	}
 
-	// Validate the embedded field
-	return validateField(service, &field.Field)
+	return strings.Compare(a.Key.GetToken().Value, b.Key.GetToken().Value)
 }
 
-// validateField validates a field against the defined rules.
-func validateField(service *Service, field *Field) error {
-	// Validate field type
-	if err := validateFieldType(service, field.Type); err != nil {
-		return fmt.Errorf("field type: %w", err)
+// sortSequenceByName sorts a sequence of mappings by their name key.
+func sortSequenceByName(sequence *ast.SequenceNode) {
+	name := func(node ast.Node) string {
+		mapping, ok := node.(*ast.MappingNode)
+		if !ok {
+			return ""
+		}
+		for _, value := range mapping.Values {
+			if value.Key.GetToken().Value == canonicalCollectionSortKey {
+				return value.Value.GetToken().Value
+			}
+		}
+		return ""
 	}
 
-	// Validate modifiers
-	if err := validateModifiers(field.Modifiers); err != nil {
-		return fmt.Errorf("field modifiers: %w", err)
+	// Comments above an item belong to the item, so they are sorted along with it.
+	// The comment above the first item is the comment of the sequence.
+	type item struct {
+		node    ast.Node
+		comment *ast.CommentGroupNode
 	}
-
-	return nil
-}
-
-// validateEndpoint validates an endpoint against the defined rules.
-func validateEndpoint(service *Service, endpoint *Endpoint) error {
-	// Validate request body params
-	for i, field := range endpoint.Request.BodyParams {
-		if err := validateField(service, &field); err != nil {
-			return fmt.Errorf("request body param %d (%s): %w", i, field.Name, err)
+	items := make([]item, len(sequence.Values))
+	for i, value := range sequence.Values {
+		items[i] = item{node: value}
+		if len(sequence.ValueHeadComments) == len(sequence.Values) {
+			items[i].comment = sequence.ValueHeadComments[i]
 		}
 	}
-
-	// Validate request query params
-	for i, field := range endpoint.Request.QueryParams {
-		if err := validateField(service, &field); err != nil {
-			return fmt.Errorf("request query param %d (%s): %w", i, field.Name, err)
-		}
+	if len(items) > 0 && items[0].comment == nil {
+		items[0].comment = sequence.Comment
 	}
 
-	// Validate request headers
-	for i, field := range endpoint.Request.Headers {
-		if err := validateField(service, &field); err != nil {
-			return fmt.Errorf("request header %d (%s): %w", i, field.Name, err)
-		}
+	slices.SortStableFunc(items, func(a, b item) int {
+		return strings.Compare(name(a.node), name(b.node))
+	})
+
+	sequence.Comment = nil
+	sequence.ValueHeadComments = make([]*ast.CommentGroupNode, len(items))
+	for i := range items {
+		sequence.Values[i] = items[i].node
+		sequence.ValueHeadComments[i] = items[i].comment
 	}
+}
 
-	// Validate response body fields
-	for i, field := range endpoint.Response.BodyFields {
-		if err := validateField(service, &field); err != nil {
-			return fmt.Errorf("response body field %d (%s): %w", i, field.Name, err)
-		}
+// Schema version constants
+const (
+	// CurrentSchemaVersion is the version of the specification schema supported by this version of the package.
+	// A specification without schema_version is read as version 1, the schema from before versioning.
+	CurrentSchemaVersion = 1
+
+	initialSchemaVersion = 1
+	schemaVersionKey     = "schema_version"
+)
+
+// schemaMigration upgrades the root mapping of a specification file from one schema version to the next.
+type schemaMigration func(root *ast.MappingNode) error
+
+// schemaMigrations holds the migration from each schema version to the next, keyed by the version it upgrades from.
+// A version without a migration only needs its schema_version updated.
+var schemaMigrations = map[int]schemaMigration{}
+
+// GetSchemaVersion returns the schema version of the service, defaulting to the initial version when not set.
+func (s *Service) GetSchemaVersion() int {
+	if s.SchemaVersion == 0 {
+		return initialSchemaVersion
 	}
 
-	return nil
+	return s.SchemaVersion
 }
 
-// validateRetryConfiguration validates a retry configuration against the defined rules.
-func validateRetryConfiguration(retry *RetryConfiguration) error {
-	if retry == nil {
+// validateSchemaVersion validates that the schema version is supported, older versions must be migrated first.
+func validateSchemaVersion(version int) error {
+	if version == 0 || version == CurrentSchemaVersion {
 		return nil
 	}
 
-	// Validate strategy
-	if retry.Strategy != "" && retry.Strategy != RetryStrategyBackoff {
-		return fmt.Errorf("retry strategy '%s' must be 'backoff'", retry.Strategy)
+	if version < initialSchemaVersion {
+		return fmt.Errorf("%s: %s must be at least %d, got %d", errorInvalidSchema, schemaVersionKey, initialSchemaVersion, version)
 	}
 
-	// Validate backoff configuration
-	if retry.Backoff.InitialInterval < 0 {
-		return fmt.Errorf("retry backoff initial interval must be non-negative, got: %d", retry.Backoff.InitialInterval)
+	if version > CurrentSchemaVersion {
+		return fmt.Errorf("%s: %s %d is newer than the supported version %d, upgrade publicapis-gen", errorInvalidSchema, schemaVersionKey, version, CurrentSchemaVersion)
 	}
 
-	if retry.Backoff.MaxInterval < 0 {
-		return fmt.Errorf("retry backoff max interval must be non-negative, got: %d", retry.Backoff.MaxInterval)
-	}
+	return fmt.Errorf("%s: %s %d is older than the supported version %d, run 'publicapis-gen migrate' to upgrade the specification", errorInvalidSchema, schemaVersionKey, version, CurrentSchemaVersion)
+}
 
-	if retry.Backoff.MaxElapsedTime < 0 {
-		return fmt.Errorf("retry backoff max elapsed time must be non-negative, got: %d", retry.Backoff.MaxElapsedTime)
-	}
+// MigrateService migrates the source of a specification file to CurrentSchemaVersion, by applying the migrations
+// from its schema version in order and setting schema_version. Comments and the order of the file are kept.
+// The file extension determines the output format: JSON (.json) or YAML (.yaml, .yml).
+func MigrateService(data []byte, fileExtension string) ([]byte, error) {
+	return migrateService(data, fileExtension, schemaMigrations, CurrentSchemaVersion)
+}
 
-	if retry.Backoff.Exponent < 0 {
-		return fmt.Errorf("retry backoff exponent must be non-negative, got: %f", retry.Backoff.Exponent)
+// migrateService migrates the source of a specification file to the current version with the migrations,
+// see MigrateService. A file already at the current version is only reformatted.
+func migrateService(data []byte, fileExtension string, migrations map[int]schemaMigration, currentVersion int) ([]byte, error) {
+	file, err := parser.ParseBytes(data, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errorFileParse, err)
 	}
 
-	// Validate that max interval is not less than initial interval (if both are specified)
-	if retry.Backoff.InitialInterval > 0 && retry.Backoff.MaxInterval > 0 &&
-		retry.Backoff.InitialInterval > retry.Backoff.MaxInterval {
-		return fmt.Errorf("retry backoff initial interval (%d) cannot be greater than max interval (%d)",
-			retry.Backoff.InitialInterval, retry.Backoff.MaxInterval)
+	if len(file.Docs) == 0 {
+		return nil, fmt.Errorf("%s: empty specification", errorInvalidFile)
 	}
 
-	// Validate status codes
-	for _, statusCode := range retry.StatusCodes {
-		if statusCode == "" {
-			return fmt.Errorf("retry status codes cannot contain empty strings")
-		}
-		// Allow common patterns like "5XX", "4XX", or specific codes like "429", "503"
-		if !isValidStatusCode(statusCode) {
-			return fmt.Errorf("retry status code '%s' is not valid", statusCode)
-		}
+	root, ok := file.Docs[0].Body.(*ast.MappingNode)
+	if !ok {
+		return nil, fmt.Errorf("%s: specification must be a mapping", errorInvalidFile)
 	}
 
-	return nil
-}
+	header := extractHeaderComment(root)
 
-// isValidStatusCode checks if a status code is valid (either a pattern like "5XX" or specific code).
-func isValidStatusCode(code string) bool {
-	// Allow patterns like "5XX", "4XX", "3XX", "2XX", "1XX"
-	if len(code) == 3 && (code[1] == 'X' && code[2] == 'X') {
-		first := code[0]
-		return first >= '1' && first <= '5'
+	versionNode := findMappingValue(root, schemaVersionKey)
+	version := initialSchemaVersion
+	if versionNode != nil {
+		version, err = strconv.Atoi(versionNode.Value.GetToken().Value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s must be an integer: %w", errorInvalidSchema, schemaVersionKey, err)
+		}
+		if version < initialSchemaVersion {
+			return nil, fmt.Errorf("%s: %s must be at least %d, got %d", errorInvalidSchema, schemaVersionKey, initialSchemaVersion, version)
+		}
+		if version > currentVersion {
+			return nil, fmt.Errorf("%s: %s %d is newer than the supported version %d, upgrade publicapis-gen", errorInvalidSchema, schemaVersionKey, version, currentVersion)
+		}
 	}
 
-	// Allow specific HTTP status codes (100-599)
-	if len(code) == 3 {
-		for _, r := range code {
-			if r < '0' || r > '9' {
-				return false
-			}
-		}
-		// Convert to int and check range
-		var statusCode int
-		for i, r := range code {
-			if i == 0 {
-				statusCode = int(r-'0') * 100
-			} else if i == 1 {
-				statusCode += int(r-'0') * 10
-			} else {
-				statusCode += int(r - '0')
+	for from := version; from < currentVersion; from++ {
+		if migrate, ok := migrations[from]; ok {
+			if err := migrate(root); err != nil {
+				return nil, fmt.Errorf("failed to migrate from %s %d: %w", schemaVersionKey, from, err)
 			}
 		}
-		return statusCode >= 100 && statusCode <= 599
 	}
 
-	return false
-}
+	if err := setSchemaVersion(root, versionNode, currentVersion); err != nil {
+		return nil, err
+	}
 
-// validateResourceOperations validates that all operations are valid resource operations.
-// Resource operations are: Create, Get, List, Search, Update, Delete
-func validateResourceOperations(operations []string) error {
-	validOperations := []string{OperationCreate, OperationGet, OperationList, OperationSearch, OperationUpdate, OperationDelete}
+	return marshalServiceFile(file, header, fileExtension)
+}
 
-	for _, operation := range operations {
-		if !slices.Contains(validOperations, operation) {
-			return fmt.Errorf("%s: operation '%s' must be one of: %v", errorInvalidOperation, operation, validOperations)
+// findMappingValue returns the value of the mapping with the given key, or nil if the key does not exist.
+func findMappingValue(mapping *ast.MappingNode, key string) *ast.MappingValueNode {
+	for _, value := range mapping.Values {
+		if value.Key.GetToken().Value == key {
+			return value
 		}
 	}
 
 	return nil
 }
 
-// validateFieldOperations validates that all operations are valid field operations.
-// Field operations are: Create, Read, Update, Delete
-func validateFieldOperations(operations []string) error {
-	validOperations := []string{OperationCreate, OperationRead, OperationUpdate, OperationDelete}
+// setSchemaVersion sets the schema_version of the root mapping, adding it at its canonical position if it does not exist.
+func setSchemaVersion(root *ast.MappingNode, versionNode *ast.MappingValueNode, version int) error {
+	if versionNode != nil {
+		token := versionNode.Value.GetToken()
+		token.Value = strconv.Itoa(version)
+		token.Origin = token.Value
+		return nil
+	}
 
-	for _, operation := range operations {
-		if !slices.Contains(validOperations, operation) {
-			return fmt.Errorf("%s: operation '%s' must be one of: %v", errorInvalidOperation, operation, validOperations)
-		}
+	file, err := parser.ParseBytes([]byte(fmt.Sprintf("%s: %d\n", schemaVersionKey, version)), 0)
+	if err != nil {
+		return err
+	}
+	mapping, ok := file.Docs[0].Body.(*ast.MappingNode)
+	if !ok || len(mapping.Values) != 1 {
+		return fmt.Errorf("%s: failed to create %s", errorInvalidSchema, schemaVersionKey)
+	}
+	versionNode = mapping.Values[0]
+
+	index := slices.IndexFunc(root.Values, func(value *ast.MappingValueNode) bool {
+		return compareCanonicalMappingValues(versionNode, value) < 0
+	})
+	if index < 0 {
+		index = len(root.Values)
 	}
+	root.Values = slices.Insert(root.Values, index, versionNode)
 
 	return nil
 }
 
-// validateFieldType validates that the field type is either a valid primitive type, enum, or object.
-func validateFieldType(service *Service, fieldType string) error {
-	// Check if it's a primitive type
-	validPrimitiveTypes := []string{
-		FieldTypeUUID, FieldTypeDate, FieldTypeTimestamp,
-		FieldTypeString, FieldTypeInt, FieldTypeFloat64, FieldTypeBool,
+// parseServiceFromBytes is the internal parsing function without overlay application,
+// merging the enums and objects of the shared libraries into the service.
+func parseServiceFromBytes(data []byte, fileExtension string, libraries []SharedLibrary) (*Service, error) {
+	// Validate with position information first
+	err := validateWithPosition(data, fileExtension, func(service *Service) error {
+		return validateServiceWithLibraries(service, libraries)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errorValidationFailed, err)
 	}
 
-	if slices.Contains(validPrimitiveTypes, fieldType) {
-		return nil
-	}
+	// If validation passed, parse normally
+	var service Service
 
-	// Check if it's a valid enum
-	if service.HasEnum(fieldType) {
-		return nil
+	switch fileExtension {
+	case extYAML, extYML:
+		if err := yaml.Unmarshal(data, &service); err != nil {
+			return nil, fmt.Errorf("%s: YAML parsing error: %w", errorFileParse, err)
+		}
+	case extJSON:
+		if err := json.Unmarshal(data, &service); err != nil {
+			return nil, fmt.Errorf("%s: JSON parsing error: %w", errorFileParse, err)
+		}
+	default:
+		return nil, fmt.Errorf("%s: file must have .yaml, .yml, or .json extension", errorUnsupportedFormat)
 	}
 
-	// Check if it's a valid object
-	if service.HasObject(fieldType) {
-		return nil
+	if err := mergeSharedLibraries(&service, libraries); err != nil {
+		return nil, fmt.Errorf("%s: %w", errorValidationFailed, err)
 	}
 
-	return fmt.Errorf("%s: field type '%s' must be one of the primitive types %v, or a valid enum/object", errorInvalidFieldType, fieldType, validPrimitiveTypes)
+	return &service, nil
 }
 
-// validateModifiers validates that all modifiers are in PascalCase and are valid modifiers.
-func validateModifiers(modifiers []string) error {
-	validModifiers := []string{ModifierNullable, ModifierArray}
+// parseServiceFromBytesInDir parses a service without overlay application,
+// loading the shared libraries and the description files relative to dir.
+func parseServiceFromBytesInDir(data []byte, fileExtension, dir string) (*Service, error) {
+	libraries, err := loadSharedLibraries(data, fileExtension, dir)
+	if err != nil {
+		return nil, err
+	}
 
-	for _, modifier := range modifiers {
-		if !slices.Contains(validModifiers, modifier) {
-			return fmt.Errorf("%s: modifier '%s' must be one of: %v", errorInvalidModifier, modifier, validModifiers)
-		}
+	service, err := parseServiceFromBytes(data, fileExtension, libraries)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	if err := loadDescriptionFiles(service, dir); err != nil {
+		return nil, err
+	}
+
+	return service, nil
 }
 
-// Parsing functions
+// loadSharedLibraries parses and validates the shared libraries listed by the service in the data, relative to dir.
+// Nothing is loaded when the data cannot be parsed, which the validation of the service reports.
+func loadSharedLibraries(data []byte, fileExtension, dir string) ([]SharedLibrary, error) {
+	var service struct {
+		Shared []string `json:"shared"`
+	}
 
-// ParseServiceFromFile reads and parses a YAML or JSON specification file,
-// automatically applying overlays to ensure complete specification.
-func ParseServiceFromFile(filePath string) (*Service, error) {
-	// Check if file exists
-	if _, err := os.Stat(filePath); os.IsNotExist(err) {
-		return nil, fmt.Errorf("%s: file does not exist: %s", errorInvalidFile, filePath)
+	var err error
+	switch fileExtension {
+	case extYAML, extYML:
+		err = yaml.Unmarshal(data, &service)
+	case extJSON:
+		err = json.Unmarshal(data, &service)
+	}
+	if err != nil {
+		return nil, nil
 	}
 
-	// Read file contents
+	libraries := make([]SharedLibrary, 0, len(service.Shared))
+	for _, file := range service.Shared {
+		library, err := parseSharedLibraryFromFile(filepath.Join(dir, file))
+		if err != nil {
+			return nil, err
+		}
+		libraries = append(libraries, *library)
+	}
+
+	return libraries, nil
+}
+
+// parseSharedLibraryFromFile reads, validates and parses a YAML or JSON shared library file, loading the description
+// files of its types relative to the library file.
+func parseSharedLibraryFromFile(filePath string) (*SharedLibrary, error) {
 	data, err := os.ReadFile(filePath)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", errorFileRead, err)
+		return nil, fmt.Errorf("%s: shared library: %w", errorFileRead, err)
 	}
 
-	// Parse based on file extension
 	ext := strings.ToLower(filepath.Ext(filePath))
-	service, err := parseServiceFromBytes(data, ext)
-	if err != nil {
-		return nil, err
+	if err := validateWithPosition(data, ext, validateSharedLibrary); err != nil {
+		// Annotate the validation errors with the library file, to print them as file:line:column
+		var validationErrors ValidationErrors
+		if errors.As(err, &validationErrors) {
+			for _, validationErr := range validationErrors {
+				validationErr.File = filePath
+			}
+		}
+		return nil, fmt.Errorf("%s: %w", errorValidationFailed, err)
 	}
 
-	// Apply overlays to ensure complete specification
-	return applyDefaultOverlays(service), nil
-}
+	var library SharedLibrary
+	switch ext {
+	case extYAML, extYML:
+		err = yaml.Unmarshal(data, &library)
+	case extJSON:
+		err = json.Unmarshal(data, &library)
+	default:
+		return nil, fmt.Errorf("%s: shared library '%s' must have .yaml, .yml, or .json extension", errorUnsupportedFormat, filePath)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: shared library '%s': %w", errorFileParse, filePath, err)
+	}
 
-// ParseServiceFromBytes parses a service from byte data and file extension,
-// automatically applying overlays to ensure complete specification.
-func ParseServiceFromBytes(data []byte, fileExtension string) (*Service, error) {
-	service, err := parseServiceFromBytes(data, fileExtension)
+	err = loadDescriptionFiles(&Service{Enums: library.Enums, Objects: library.Objects}, filepath.Dir(filePath))
 	if err != nil {
 		return nil, err
 	}
 
-	// Apply overlays to ensure complete specification
-	return applyDefaultOverlays(service), nil
+	return &library, nil
 }
 
-// ParseServiceFromJSON parses a service from JSON data,
-// automatically applying overlays to ensure complete specification.
-func ParseServiceFromJSON(data []byte) (*Service, error) {
-	service, err := parseServiceFromBytes(data, extJSON)
-	if err != nil {
-		return nil, err
+// mergeSharedLibraries appends the enums and objects of the shared libraries to the service, with their Library set,
+// and clears the shared libraries of the service, so the rest of the generators only see enums and objects.
+func mergeSharedLibraries(service *Service, libraries []SharedLibrary) error {
+	owners := make(map[string]string, len(service.Enums)+len(service.Objects))
+	for _, enum := range service.Enums {
+		owners[enum.Name] = "the service"
+	}
+	for _, object := range service.Objects {
+		owners[object.Name] = "the service"
 	}
 
-	// Apply overlays to ensure complete specification
-	return applyDefaultOverlays(service), nil
-}
+	names := make(map[string]bool, len(libraries))
+	for _, library := range libraries {
+		if names[library.Name] {
+			return fmt.Errorf("%s: library '%s' is listed twice", errorInvalidShared, library.Name)
+		}
+		names[library.Name] = true
 
-// ParseServiceFromYAML parses a service from YAML data,
-// automatically applying overlays to ensure complete specification.
-func ParseServiceFromYAML(data []byte) (*Service, error) {
-	service, err := parseServiceFromBytes(data, extYAML)
-	if err != nil {
-		return nil, err
+		owner := "library '" + library.Name + "'"
+		for _, enum := range library.Enums {
+			if defined, ok := owners[enum.Name]; ok {
+				return fmt.Errorf("%s: enum '%s' of %s is already defined by %s", errorInvalidShared, enum.Name, owner, defined)
+			}
+			owners[enum.Name] = owner
+			enum.Library = library.Name
+			service.Enums = append(service.Enums, enum)
+		}
+		for _, object := range library.Objects {
+			if defined, ok := owners[object.Name]; ok {
+				return fmt.Errorf("%s: object '%s' of %s is already defined by %s", errorInvalidShared, object.Name, owner, defined)
+			}
+			owners[object.Name] = owner
+			object.Library = library.Name
+			service.Objects = append(service.Objects, object)
+		}
 	}
 
-	// Apply overlays to ensure complete specification
-	return applyDefaultOverlays(service), nil
+	service.Shared = nil
+	return nil
 }
 
-// parseServiceFromBytes is the internal parsing function without overlay application.
-func parseServiceFromBytes(data []byte, fileExtension string) (*Service, error) {
-	// Validate with position information first
-	if err := ValidateServiceWithPosition(data, fileExtension); err != nil {
-		return nil, fmt.Errorf("%s: %w", errorValidationFailed, err)
+// loadDescriptionFiles replaces the description files of the enums, objects, resources and endpoints
+// with their contents, so the rest of the generators only see descriptions.
+func loadDescriptionFiles(service *Service, dir string) error {
+	load := func(description *string, descriptionFile *string, owner string) error {
+		if *descriptionFile == "" {
+			return nil
+		}
+		content, err := os.ReadFile(filepath.Join(dir, *descriptionFile))
+		if err != nil {
+			return fmt.Errorf("%s: description_file of %s: %w", errorFileRead, owner, err)
+		}
+		*description = strings.TrimSpace(string(content))
+		*descriptionFile = ""
+		return nil
 	}
 
-	// If validation passed, parse normally
-	var service Service
-
-	switch fileExtension {
-	case extYAML, extYML:
-		if err := yaml.Unmarshal(data, &service); err != nil {
-			return nil, fmt.Errorf("%s: YAML parsing error: %w", errorFileParse, err)
+	for i := range service.Enums {
+		enum := &service.Enums[i]
+		if err := load(&enum.Description, &enum.DescriptionFile, "enum '"+enum.Name+"'"); err != nil {
+			return err
 		}
-	case extJSON:
-		if err := json.Unmarshal(data, &service); err != nil {
-			return nil, fmt.Errorf("%s: JSON parsing error: %w", errorFileParse, err)
+	}
+	for i := range service.Objects {
+		object := &service.Objects[i]
+		if err := load(&object.Description, &object.DescriptionFile, "object '"+object.Name+"'"); err != nil {
+			return err
+		}
+	}
+	for i := range service.Resources {
+		resource := &service.Resources[i]
+		if err := load(&resource.Description, &resource.DescriptionFile, "resource '"+resource.Name+"'"); err != nil {
+			return err
+		}
+		for j := range resource.Endpoints {
+			endpoint := &resource.Endpoints[j]
+			if err := load(&endpoint.Description, &endpoint.DescriptionFile, "endpoint '"+resource.Name+endpoint.Name+"'"); err != nil {
+				return err
+			}
 		}
-	default:
-		return nil, fmt.Errorf("%s: file must have .yaml, .yml, or .json extension", errorUnsupportedFormat)
 	}
 
-	return &service, nil
+	return nil
 }
 
 // ensureAllFieldsHaveExamples ensures that all fields in the service have examples set.
@@ -2597,43 +7230,87 @@ func ensureAllFieldsHaveExamples(service *Service) {
 	// Apply to object fields
 	for i := range service.Objects {
 		for j := range service.Objects[i].Fields {
-			service.Objects[i].Fields[j].ensureExample()
+			service.Objects[i].Fields[j].ensureExample(service.Objects[i].Name)
 		}
 	}
 
 	// Apply to resource fields
 	for i := range service.Resources {
 		for j := range service.Resources[i].Fields {
-			service.Resources[i].Fields[j].Field.ensureExample()
+			service.Resources[i].Fields[j].Field.ensureExample(service.Resources[i].Name)
 		}
 		// Also apply to endpoint fields
 		for j := range service.Resources[i].Endpoints {
+			resourceName := service.Resources[i].Name
 			endpoint := &service.Resources[i].Endpoints[j]
 			for k := range endpoint.Request.PathParams {
-				endpoint.Request.PathParams[k].ensureExample()
+				endpoint.Request.PathParams[k].ensureExample(resourceName)
 			}
 			for k := range endpoint.Request.QueryParams {
-				endpoint.Request.QueryParams[k].ensureExample()
+				endpoint.Request.QueryParams[k].ensureExample(resourceName)
 			}
 			for k := range endpoint.Request.BodyParams {
-				endpoint.Request.BodyParams[k].ensureExample()
+				endpoint.Request.BodyParams[k].ensureExample(resourceName)
 			}
 			for k := range endpoint.Request.Headers {
-				endpoint.Request.Headers[k].ensureExample()
+				endpoint.Request.Headers[k].ensureExample(resourceName)
 			}
 			for k := range endpoint.Response.BodyFields {
-				endpoint.Response.BodyFields[k].ensureExample()
+				endpoint.Response.BodyFields[k].ensureExample(resourceName)
 			}
 			for k := range endpoint.Response.Headers {
-				endpoint.Response.Headers[k].ensureExample()
+				endpoint.Response.Headers[k].ensureExample(resourceName)
+			}
+		}
+	}
+}
+
+// applyJSONNaming sets the JSON names of the fields of objects, resources and the bodies of endpoints without one to
+// their names in the JSON case of the naming, so that every generator uses the same names. Path and query parameters
+// keep their names in camelCase, and nothing is set for camelCase, which TagJSON falls back to. The fields are
+// cloned, since their slices may be shared with the input of the overlays.
+func applyJSONNaming(service *Service) {
+	if service.Naming.GetJSONCase() == JSONCaseCamel {
+		return
+	}
+
+	nameFields := func(fields []Field) []Field {
+		fields = slices.Clone(fields)
+		for i := range fields {
+			if fields[i].JSONName == "" {
+				fields[i].JSONName = service.Naming.FormatJSONName(fields[i].Name)
+			}
+		}
+		return fields
+	}
+
+	service.Objects = slices.Clone(service.Objects)
+	for i := range service.Objects {
+		service.Objects[i].Fields = nameFields(service.Objects[i].Fields)
+	}
+
+	service.Resources = slices.Clone(service.Resources)
+	for i := range service.Resources {
+		resource := &service.Resources[i]
+		resource.Fields = slices.Clone(resource.Fields)
+		for j := range resource.Fields {
+			if resource.Fields[j].JSONName == "" {
+				resource.Fields[j].JSONName = service.Naming.FormatJSONName(resource.Fields[j].Name)
 			}
 		}
+
+		resource.Endpoints = slices.Clone(resource.Endpoints)
+		for j := range resource.Endpoints {
+			endpoint := &resource.Endpoints[j]
+			endpoint.Request.BodyParams = nameFields(endpoint.Request.BodyParams)
+			endpoint.Response.BodyFields = nameFields(endpoint.Response.BodyFields)
+		}
 	}
 }
 
-// applyDefaultOverlays applies the standard overlays to a service to ensure
-// resource objects and CRUD endpoints are generated.
-func applyDefaultOverlays(service *Service) *Service {
+// ApplyDefaultOverlays applies the standard overlays to a service to ensure
+// resource objects and CRUD endpoints are generated, as the parsing functions do.
+func ApplyDefaultOverlays(service *Service) *Service {
 	// Apply overlay to generate resource objects and standard endpoints
 	overlayedService := ApplyOverlay(service)
 	if overlayedService == nil {
@@ -2648,6 +7325,12 @@ func applyDefaultOverlays(service *Service) *Service {
 		return overlayedService
 	}
 
+	// Rename the request and response types of the endpoints that collide with other types, filter objects included
+	renameCollidingEndpointTypes(finalService)
+
+	// Name the fields in JSON following the naming, filter objects included
+	applyJSONNaming(finalService)
+
 	// Ensure all fields have examples
 	ensureAllFieldsHaveExamples(finalService)
 
@@ -2702,6 +7385,422 @@ func (s Service) PathName() string {
 	return toKebabCase(s.Name)
 }
 
+// GetBasePath returns the path prefixing all routes of the service, the base path if set (empty for /),
+// otherwise the service name in the path case and the version, e.g. /users-api/v1.
+func (s Service) GetBasePath() string {
+	if s.BasePath != "" {
+		return strings.TrimSuffix(s.BasePath, pathSeparator)
+	}
+	return pathSeparator + s.Naming.FormatPathName(s.Name) + pathSeparator + s.Version
+}
+
+// HasScopedSecurityScheme returns true if the service defines an OAuth2 or OpenID Connect security scheme.
+func (s Service) HasScopedSecurityScheme() bool {
+	for _, scheme := range s.SecuritySchemes {
+		if scheme.HasScopes() {
+			return true
+		}
+	}
+	return false
+}
+
+// HasScopedFields returns true if any field of the objects or of the response bodies of the endpoints requires scopes.
+func (s Service) HasScopedFields() bool {
+	hasScopes := func(field Field) bool {
+		return len(field.Scopes) > 0
+	}
+
+	for _, object := range s.Objects {
+		if slices.ContainsFunc(object.Fields, hasScopes) {
+			return true
+		}
+	}
+
+	for _, resource := range s.Resources {
+		for _, endpoint := range resource.Endpoints {
+			if slices.ContainsFunc(endpoint.Response.BodyFields, hasScopes) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// HasFilterMaxDepth returns true if the service limits the depth of the nested filters and has Search endpoints.
+func (s Service) HasFilterMaxDepth() bool {
+	return s.FilterMaxDepth > 0 && slices.ContainsFunc(s.Resources, func(resource Resource) bool { return resource.HasSearchOperation() })
+}
+
+// HasPaginatedEndpoints returns true if any resource of the service has List or Search operations, whose responses
+// are paginated with the Pagination object.
+func (s Service) HasPaginatedEndpoints() bool {
+	return slices.ContainsFunc(s.Resources, func(resource Resource) bool {
+		return resource.HasListOperation() || resource.HasSearchOperation()
+	})
+}
+
+// HasAsyncEndpoints returns true if any endpoint of the service is asynchronous, see Endpoint.Async.
+func (s Service) HasAsyncEndpoints() bool {
+	return s.hasEndpoint(func(endpoint Endpoint) bool { return endpoint.Async })
+}
+
+// IsOperationPoll returns true if the endpoint is the Get endpoint of the Operation resource the overlay adds to
+// services with asynchronous endpoints, which the operations they started are polled at.
+func (s Service) IsOperationPoll(resource Resource, endpoint Endpoint) bool {
+	return resource.Name == operationResourceName && endpoint.Name == getEndpointName && s.HasAsyncEndpoints()
+}
+
+// HasCallbacks returns true if any endpoint of the service has callbacks, see Endpoint.Callbacks.
+func (s Service) HasCallbacks() bool {
+	return s.hasEndpoint(func(endpoint Endpoint) bool { return len(endpoint.Callbacks) > 0 })
+}
+
+// HasFeatureFlags returns true if any endpoint of the service is gated behind a feature flag, see Endpoint.FeatureFlag.
+func (s Service) HasFeatureFlags() bool {
+	return s.hasEndpoint(func(endpoint Endpoint) bool { return endpoint.FeatureFlag != "" })
+}
+
+// HasBodyLimits returns true if the service or any of its endpoints limits the size of the request body.
+func (s Service) HasBodyLimits() bool {
+	return s.MaxBodyBytes > 0 || s.hasEndpoint(func(endpoint Endpoint) bool { return endpoint.MaxBodyBytes > 0 })
+}
+
+// HasHandlerTimeouts returns true if the service or any of its endpoints limits the time of the handler.
+func (s Service) HasHandlerTimeouts() bool {
+	return s.HandlerTimeout > 0 || s.hasEndpoint(func(endpoint Endpoint) bool { return endpoint.HandlerTimeout > 0 })
+}
+
+// HasRequestLimits returns true if the service limits the size of any request body or the time of any handler.
+func (s Service) HasRequestLimits() bool {
+	return s.HasBodyLimits() || s.HasHandlerTimeouts()
+}
+
+// hasEndpoint returns true if any endpoint of the service matches.
+func (s Service) hasEndpoint(match func(endpoint Endpoint) bool) bool {
+	for _, resource := range s.Resources {
+		if slices.ContainsFunc(resource.Endpoints, match) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasScope returns true if the scope is declared by any OAuth2 flow of the service.
+func (s Service) HasScope(scope string) bool {
+	for _, scheme := range s.SecuritySchemes {
+		for _, flow := range scheme.Flows.GetFlows() {
+			if _, exists := flow.Scopes[scope]; exists {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// HasScopedEndpoints returns true if any endpoint of the service requires OAuth2 scopes, see Endpoint.GetRequiredScopes.
+func (s Service) HasScopedEndpoints() bool {
+	for _, resource := range s.Resources {
+		for _, endpoint := range resource.Endpoints {
+			if len(endpoint.GetRequiredScopes(resource)) > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// HasScopes returns true if the security scheme supports scopes (OAuth2 or OpenID Connect).
+func (s SecurityScheme) HasScopes() bool {
+	return s.Type == SecuritySchemeTypeOAuth2 || s.Type == SecuritySchemeTypeOpenIDConnect
+}
+
+// HasFlow returns true if at least one OAuth2 flow is defined.
+func (f *OAuth2Flows) HasFlow() bool {
+	return len(f.GetFlows()) > 0
+}
+
+// GetFlows returns all defined OAuth2 flows.
+func (f *OAuth2Flows) GetFlows() []*OAuth2Flow {
+	if f == nil {
+		return nil
+	}
+
+	var flows []*OAuth2Flow
+	for _, flow := range []*OAuth2Flow{f.ClientCredentials, f.AuthorizationCode, f.Implicit, f.Password} {
+		if flow != nil {
+			flows = append(flows, flow)
+		}
+	}
+	return flows
+}
+
+// GetParameterGroupName returns the name of the referenced parameter group that provides the query parameter,
+// or an empty string if the query parameter is declared by the endpoint itself.
+func (e Endpoint) GetParameterGroupName(service *Service, fieldName string) string {
+	for _, groupName := range e.Request.ParameterGroups {
+		group := service.GetParameterGroup(groupName)
+		if group != nil && containsFieldName(group.Fields, fieldName) {
+			return group.Name
+		}
+	}
+	return ""
+}
+
+// GetOwnQueryParams returns the query parameters declared by the endpoint itself, excluding parameter group fields.
+func (e Endpoint) GetOwnQueryParams(service *Service) []Field {
+	var fields []Field
+	for _, field := range e.Request.QueryParams {
+		if e.GetParameterGroupName(service, field.Name) == "" {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// GetCallbackURLField returns the body param of the request holding the URL of the subscriber of the callback,
+// or nil if the request has no such body param.
+func (e Endpoint) GetCallbackURLField(callback Callback) *Field {
+	for i := range e.Request.BodyParams {
+		if e.Request.BodyParams[i].Name == callback.URLField {
+			field := e.Request.BodyParams[i]
+			return &field
+		}
+	}
+	return nil
+}
+
+// Callback methods
+
+// GetMethod returns the HTTP method of the callback request in upper case, POST unless set.
+func (c Callback) GetMethod() string {
+	if c.Method == "" {
+		return httpMethodPost
+	}
+	return strings.ToUpper(c.Method)
+}
+
+// IsConditionalGet returns true if the endpoint is a GET endpoint responding with an object that has a last updated
+// timestamp, other than a CSV response, see Service.GetLastModifiedField. Its responses have the Last-Modified header, and requests with an
+// If-Modified-Since header that is not older are answered with 304 Not Modified.
+func (e Endpoint) IsConditionalGet(service *Service) bool {
+	if !strings.EqualFold(e.Method, httpMethodGet) || e.Response.BodyObject == nil || e.HasCSVResponse() {
+		return false
+	}
+	return service.GetLastModifiedField(*e.Response.BodyObject) != nil
+}
+
+// GetAuditOperation returns the operation of the resource the endpoint mutates, Create, Update or Delete for the
+// endpoints the overlay generates for them, or an empty string for the other endpoints. The successful requests of
+// these endpoints are audited by the generated server.
+func (e Endpoint) GetAuditOperation(resource Resource) string {
+	switch {
+	case e.Name == createEndpointName && resource.HasCreateOperation():
+		return OperationCreate
+	case e.Name == updateEndpointName && resource.HasUpdateOperation():
+		return OperationUpdate
+	case e.Name == deleteEndpointName && resource.HasDeleteOperation():
+		return OperationDelete
+	default:
+		return ""
+	}
+}
+
+// GetRequiredScopes returns the scopes required to call the endpoint,
+// falling back to the scopes of the resource when the endpoint does not define any.
+func (e Endpoint) GetRequiredScopes(resource Resource) []string {
+	if len(e.Scopes) > 0 {
+		return e.Scopes
+	}
+	return resource.Scopes
+}
+
+// GetRequiredPermissions returns the permissions required to call the endpoint,
+// falling back to the permissions of the resource when the endpoint does not define any.
+func (e Endpoint) GetRequiredPermissions(resource Resource) []string {
+	if len(e.Permissions) > 0 {
+		return e.Permissions
+	}
+	return resource.Permissions
+}
+
+// GetStability returns the stability of the endpoint,
+// falling back to the stability of the resource when the endpoint does not set one.
+func (e Endpoint) GetStability(resource Resource) string {
+	if e.Stability != "" {
+		return e.Stability
+	}
+	return resource.Stability
+}
+
+// GetMaxBodyBytes returns the size limit of the request body in bytes,
+// falling back to the limit of the service when the endpoint does not set one. Zero means no limit.
+func (e Endpoint) GetMaxBodyBytes(service *Service) int64 {
+	if e.MaxBodyBytes > 0 {
+		return e.MaxBodyBytes
+	}
+	return service.MaxBodyBytes
+}
+
+// GetHandlerTimeout returns the time limit of the handler in milliseconds,
+// falling back to the timeout of the service when the endpoint does not set one. Zero means no timeout.
+func (e Endpoint) GetHandlerTimeout(service *Service) int {
+	if e.HandlerTimeout > 0 {
+		return e.HandlerTimeout
+	}
+	return service.HandlerTimeout
+}
+
+// IsHeader returns true if the tenant identifier is read from a request header.
+func (t *Tenancy) IsHeader() bool {
+	return t != nil && t.In == TenancyInHeader
+}
+
+// IsPath returns true if the tenant identifier is read from a path prefix.
+func (t *Tenancy) IsPath() bool {
+	return t != nil && t.In == TenancyInPath
+}
+
+// GetPathCase returns the case of the names in paths, kebab-case unless set.
+func (n *Naming) GetPathCase() string {
+	if n == nil || n.PathCase == "" {
+		return PathCaseKebab
+	}
+	return n.PathCase
+}
+
+// HasPluralPaths returns true if the resource names are pluralized in paths.
+func (n *Naming) HasPluralPaths() bool {
+	return n != nil && n.PluralPaths
+}
+
+// RenamesTypeCollisions returns true if the generated types of endpoints colliding with other types are renamed.
+func (n *Naming) RenamesTypeCollisions() bool {
+	return n != nil && n.TypeCollisions == TypeCollisionsRename
+}
+
+// GetJSONCase returns the case of the names of the fields in JSON, camelCase by default.
+func (n *Naming) GetJSONCase() string {
+	if n == nil || n.JSON == "" {
+		return JSONCaseCamel
+	}
+	return n.JSON
+}
+
+// FormatJSONName returns the name in the JSON case, e.g. FirstName as firstName, first_name or FirstName.
+func (n *Naming) FormatJSONName(name string) string {
+	switch n.GetJSONCase() {
+	case JSONCaseSnake:
+		return toSnakeCase(name)
+	case JSONCaseAsIs:
+		return name
+	default:
+		return CamelCase(name)
+	}
+}
+
+// FormatPathName returns the name in the path case, e.g. UserAccount as user-account or user_account.
+func (n *Naming) FormatPathName(name string) string {
+	if n.GetPathCase() == PathCaseSnake {
+		return strings.ReplaceAll(toKebabCase(name), "-", "_")
+	}
+	return toKebabCase(name)
+}
+
+// GetField returns the tenant identifier as a field, used for parameter documentation.
+func (t *Tenancy) GetField() Field {
+	description := t.Description
+	if description == "" {
+		description = defaultTenancyDescription
+	}
+	return Field{
+		Name:        t.Name,
+		Description: description,
+		Type:        FieldTypeString,
+	}
+}
+
+// GetPathPrefix returns the path prefix containing the tenant parameter,
+// or an empty string when the tenant is not part of the path.
+func (t *Tenancy) GetPathPrefix() string {
+	if !t.IsPath() {
+		return ""
+	}
+	return pathSeparator + "{" + t.GetParameterName() + "}"
+}
+
+// GetParameterName returns the name of the tenant parameter,
+// the header name as-is or the path parameter name in camelCase.
+func (t *Tenancy) GetParameterName() string {
+	if t.IsPath() {
+		return t.GetField().TagJSON()
+	}
+	return t.Name
+}
+
+// GetLocales returns the locales of the messages, the default locale first and then the others in alphabetical order.
+func (l *Localization) GetLocales() []string {
+	if l == nil {
+		return nil
+	}
+
+	others := map[string]bool{}
+	for _, messages := range l.Messages {
+		for locale := range messages {
+			if locale != l.DefaultLocale {
+				others[locale] = true
+			}
+		}
+	}
+
+	return append([]string{l.DefaultLocale}, slices.Sorted(maps.Keys(others))...)
+}
+
+// GetMessages returns the messages of the locale keyed by error code.
+func (l *Localization) GetMessages(locale string) map[string]string {
+	if l == nil {
+		return nil
+	}
+
+	messages := map[string]string{}
+	for code, localized := range l.Messages {
+		if message, ok := localized[locale]; ok {
+			messages[code] = message
+		}
+	}
+	return messages
+}
+
+// HasHealth returns true if the /healthz endpoint should be generated.
+func (o *OperationalEndpoints) HasHealth() bool {
+	return o != nil && o.Health
+}
+
+// HasReadiness returns true if the /readyz endpoint should be generated.
+func (o *OperationalEndpoints) HasReadiness() bool {
+	return o != nil && o.Readiness
+}
+
+// HasVersion returns true if the /version endpoint should be generated.
+func (o *OperationalEndpoints) HasVersion() bool {
+	return o != nil && o.Version
+}
+
+// HasChecks returns true if the /healthz or /readyz endpoint should be generated.
+func (o *OperationalEndpoints) HasChecks() bool {
+	return o.HasHealth() || o.HasReadiness()
+}
+
+// HasEndpoints returns true if any operational endpoint should be generated.
+func (o *OperationalEndpoints) HasEndpoints() bool {
+	return o.HasChecks() || o.HasVersion()
+}
+
+// IsExcludedFromSDK returns true if the operational endpoints should be ignored by SDK generators.
+func (o *OperationalEndpoints) IsExcludedFromSDK() bool {
+	return o != nil && o.ExcludeFromSDK
+}
+
 // createDefaultRetryConfiguration creates a retry configuration with all default values.
 func createDefaultRetryConfiguration() RetryConfiguration {
 	return RetryConfiguration{
@@ -3,10 +3,16 @@ package specification
 import (
 	"encoding/json"
 	"fmt"
+	"go/token"
+	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	yaml "github.com/goccy/go-yaml"
+	"github.com/goccy/go-yaml/ast"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -853,6 +859,49 @@ func TestField_TagJSON(t *testing.T) {
 	}
 }
 
+func TestField_GetGoName(t *testing.T) {
+	testCases := []struct {
+		fieldName      string
+		expectedGoName string
+		expectedTag    string
+	}{
+		{"CreatedAt", "CreatedAt", "createdAt"},
+		{"type", "Type", "type"},
+		{"1stPlace", "X1stPlace", "1stPlace"},
+		{"Förnamn", "Fornamn", "förnamn"},
+		{"Ärendetyp", "Arendetyp", "ärendetyp"},
+		{"X-Request-ID", "XRequestID", "xRequestID"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.fieldName, func(t *testing.T) {
+			field := Field{Name: tc.fieldName}
+			assert.Equal(t, tc.expectedGoName, field.GetGoName(), "Go name for field '%s' should be '%s'", tc.fieldName, tc.expectedGoName)
+			assert.Equal(t, tc.expectedTag, field.TagJSON(), "JSON tag for field '%s' should keep the original name", tc.fieldName)
+		})
+	}
+}
+
+func TestEnumValue_GetGoName(t *testing.T) {
+	testCases := []struct {
+		valueName      string
+		expectedGoName string
+	}{
+		{"Active", "StatusActive"},
+		{"in_progress", "StatusInProgress"},
+		{"In-Progress", "StatusInProgress"},
+		{"2FA", "Status2FA"},
+		{"Xavier", "StatusXavier"},
+		{"Öppen", "StatusOppen"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.valueName, func(t *testing.T) {
+			assert.Equal(t, tc.expectedGoName, EnumValue{Name: tc.valueName}.GetGoName("Status"))
+		})
+	}
+}
+
 func TestField_IsRequired(t *testing.T) {
 	service := &Service{
 		Objects: []Object{
@@ -1183,7 +1232,7 @@ func TestCreateAutoColumnID(t *testing.T) {
 	assert.Equal(t, FieldTypeUUID, idField.Type, "Auto-column ID should have UUID type")
 	assert.Empty(t, idField.Modifiers, "Auto-column ID should have no modifiers")
 	assert.Empty(t, idField.Default, "Auto-column ID should have no default value")
-	assert.Equal(t, "123e4567-e89b-12d3-a456-426614174000", idField.Example, "Auto-column ID should have example UUID")
+	assert.Equal(t, getDerivedExample(resourceName, autoColumnIDName, FieldTypeUUID), idField.Example, "Auto-column ID should have an example UUID derived from the resource")
 
 	t.Run("consistency", func(t *testing.T) {
 		id1 := createAutoColumnID(resourceName)
@@ -1191,6 +1240,64 @@ func TestCreateAutoColumnID(t *testing.T) {
 
 		assert.Equal(t, id1, id2, "CreateAutoColumnID should return consistent results")
 	})
+
+	t.Run("differs between resources", func(t *testing.T) {
+		assert.NotEqual(t, createAutoColumnID("User").Example, createAutoColumnID("Group").Example)
+	})
+}
+
+func TestGetDerivedExample(t *testing.T) {
+	t.Run("UUID", func(t *testing.T) {
+		example := getDerivedExample("User", "GroupID", FieldTypeUUID)
+
+		assert.Regexp(t, `^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`, example, "Should be a version 4 UUID")
+		assert.Equal(t, example, getDerivedExample("User", "GroupID", FieldTypeUUID), "Should be stable")
+		assert.NotEqual(t, example, getDerivedExample("User", "ManagerID", FieldTypeUUID), "Should differ between fields")
+		assert.NotEqual(t, example, getDerivedExample("Group", "GroupID", FieldTypeUUID), "Should differ between owners")
+	})
+
+	t.Run("Timestamp", func(t *testing.T) {
+		example := getDerivedExample("User", "LastLoginAt", FieldTypeTimestamp)
+
+		timestamp, err := time.Parse(time.RFC3339, example)
+		assert.NoError(t, err, "Should be an RFC 3339 timestamp")
+		assert.Equal(t, 2024, timestamp.Year())
+		assert.NotEqual(t, example, getDerivedExample("User", "ArchivedAt", FieldTypeTimestamp), "Should differ between fields")
+	})
+
+	t.Run("Date", func(t *testing.T) {
+		example := getDerivedExample("User", "BirthDate", FieldTypeDate)
+
+		_, err := time.Parse(time.DateOnly, example)
+		assert.NoError(t, err, "Should be a date")
+	})
+
+	t.Run("other types use the default example", func(t *testing.T) {
+		assert.Equal(t, defaultExampleString, getDerivedExample("User", "Name", FieldTypeString))
+		assert.Equal(t, defaultExampleInt, getDerivedExample("User", "Age", FieldTypeInt))
+	})
+
+	t.Run("applied to fields without examples", func(t *testing.T) {
+		service := ApplyDefaultOverlays(&Service{
+			Name: "Test",
+			Resources: []Resource{
+				{
+					Name:       "User",
+					Operations: []string{OperationGet},
+					Fields: []ResourceField{
+						{Field: Field{Name: "GroupID", Type: FieldTypeUUID}, Operations: []string{OperationRead}},
+						{Field: Field{Name: "ManagerID", Type: FieldTypeUUID}, Operations: []string{OperationRead}},
+					},
+				},
+			},
+		})
+
+		user := service.GetObject("User")
+		assert.NotEqual(t, user.GetField("GroupID").Example, user.GetField("ManagerID").Example, "Fields should not share an example")
+
+		get := service.GetResource("User").Endpoints[0]
+		assert.Equal(t, user.GetField(autoColumnIDName).Example, get.Request.PathParams[0].Example, "The ID parameter should have the example of the ID field")
+	})
 }
 
 func TestCreateAutoColumnCreatedAt(t *testing.T) {
@@ -1406,6 +1513,34 @@ func TestCreateAutoColumnsWithMeta(t *testing.T) {
 	})
 }
 
+func TestWithAutoColumns(t *testing.T) {
+	autoColumns := createAutoColumnsWithMeta("User")
+
+	t.Run("leaves out the fields declaring an auto-column", func(t *testing.T) {
+		// Arrange
+		fields := []Field{
+			{Name: "id", Description: "Unique user identifier", Type: FieldTypeUUID},
+			{Name: "Name", Type: FieldTypeString},
+		}
+
+		// Act
+		result := withAutoColumns(autoColumns, fields)
+
+		// Assert
+		assert.Equal(t, []string{autoColumnIDName, metaObjectName, "Name"}, fieldNames(result))
+		assert.Equal(t, "Unique user identifier", result[0].Description, "The description of the field should replace the one of the auto-column")
+		assert.Equal(t, fmt.Sprintf(autoColumnIDDescTemplate, "User"), autoColumns[0].Description, "The auto-columns should not be modified")
+	})
+
+	t.Run("keeps the fields of another type", func(t *testing.T) {
+		// Act
+		result := withAutoColumns(autoColumns, []Field{{Name: "id", Type: FieldTypeString}})
+
+		// Assert
+		assert.Equal(t, []string{autoColumnIDName, metaObjectName, "id"}, fieldNames(result))
+	})
+}
+
 // ============================================================================
 // Utility Function Tests
 // ============================================================================
@@ -1426,6 +1561,10 @@ func TestCamelCase(t *testing.T) {
 		{"CSNSchoolCode", "csnSchoolCode"},     // Special case: consecutive capitals should be lowercased
 		{"APIKey", "apiKey"},                   // Another case with consecutive capitals
 		{"HTTPSConnection", "httpsConnection"}, // Multiple consecutive capitals
+		{"Ärende", "ärende"},                   // A multi-byte first letter should stay valid UTF-8
+		{"Élève_nom", "élèveNom"},
+		{"ÉTAT", "état"},
+		{"förnamn", "förnamn"},
 	}
 
 	for _, tc := range testCases {
@@ -1459,6 +1598,58 @@ func TestCamelCase(t *testing.T) {
 	})
 }
 
+func TestGoIdentifier(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"CreatedAt", "CreatedAt"},
+		{"user_name", "UserName"},
+		{"X-Request-ID", "XRequestID"},
+		{"type", "Type"},
+		{"func", "Func"},
+		{"2FA", "X2FA"},
+		{"first name", "FirstName"},
+		{"Förnamn", "Fornamn"},
+		{"élève", "Eleve"},
+		{"Fo\u0308rnamn", "Fornamn"},
+		{"Straße", "Strasse"},
+		{"имя", "Имя"},
+		{"名前", "X名前"},
+		{"", "X"},
+		{"-", "X"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			result := GoIdentifier(tc.input)
+			assert.Equal(t, tc.expected, result, "GoIdentifier conversion for '%s' should be '%s'", tc.input, tc.expected)
+			assert.True(t, token.IsIdentifier(result) && token.IsExported(result), "'%s' should be an exported Go identifier", result)
+		})
+	}
+}
+
+func TestTransliterate(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"UserName", "UserName"},
+		{"Ärende på svenska", "Arende pa svenska"},
+		{"Élève Français", "Eleve Francais"},
+		{"Straße", "Strasse"},
+		{"Ærø Łódź", "Aero Lodz"},
+		{"Fo\u0308rnamn", "Fornamn"},
+		{"日本語", "日本語"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			assert.Equal(t, tc.expected, Transliterate(tc.input), "Transliterate of '%s' should be '%s'", tc.input, tc.expected)
+		})
+	}
+}
+
 func TestToKebabCase(t *testing.T) {
 	testCases := []struct {
 		input    string
@@ -1472,6 +1663,9 @@ func TestToKebabCase(t *testing.T) {
 		{"simple", "simple"},
 		{"Multi Word String", "multi-word-string"},
 		{"StudentPlacement", "student-placement"},
+		{"Ärende", "arende"},
+		{"ÅrsKurs", "ars-kurs"},
+		{"Skolans tjänst", "skolans-tjanst"},
 	}
 
 	for _, tc := range testCases {
@@ -1621,15 +1815,15 @@ func TestApplyOverlay(t *testing.T) {
 		require.NotNil(t, result)
 		assert.Equal(t, input.Name, result.Name)
 
-		// Should have default ErrorCode enum, Error, Pagination, and Meta objects
+		// Should have default ErrorCode enum, Error, ErrorField, Pagination, and Meta objects
 		assert.Equal(t, 1, len(result.Enums))   // ErrorCode enum
-		assert.Equal(t, 3, len(result.Objects)) // Error, Pagination, and Meta objects
+		assert.Equal(t, 4, len(result.Objects)) // Error, ErrorField, Pagination, and Meta objects
 		assert.Equal(t, 0, len(result.Resources))
 
 		// Verify Error object has RequestID field
 		errorObj := result.GetObject("Error")
 		require.NotNil(t, errorObj, "Error object should exist")
-		assert.Equal(t, 3, len(errorObj.Fields), "Error object should have 3 fields: Code, Message, and RequestID")
+		assert.Equal(t, 4, len(errorObj.Fields), "Error object should have 4 fields: Code, Message, RequestID, and Fields")
 
 		var hasRequestID bool
 		for _, field := range errorObj.Fields {
@@ -1641,6 +1835,67 @@ func TestApplyOverlay(t *testing.T) {
 			}
 		}
 		assert.True(t, hasRequestID, "Error object should have RequestID field")
+
+		// Verify the field errors of the Error object
+		fieldsField := errorObj.Fields[3]
+		assert.Equal(t, "Fields", fieldsField.Name)
+		assert.Equal(t, "ErrorField", fieldsField.Type)
+		assert.True(t, fieldsField.IsArray(), "Fields should be an array")
+
+		errorFieldObj := result.GetObject("ErrorField")
+		require.NotNil(t, errorFieldObj, "ErrorField object should exist")
+		assert.Equal(t, []string{"path", "code", "message"}, []string{errorFieldObj.Fields[0].TagJSON(), errorFieldObj.Fields[1].TagJSON(), errorFieldObj.Fields[2].TagJSON()})
+	})
+
+	t.Run("custom Error object does not get ErrorField", func(t *testing.T) {
+		input := &Service{
+			Name: "CustomErrorService",
+			Objects: []Object{
+				{Name: "Error", Fields: []Field{{Name: "Message", Type: FieldTypeString}}},
+			},
+		}
+
+		result := ApplyOverlay(input)
+		require.NotNil(t, result)
+
+		assert.Nil(t, result.GetObject("ErrorField"), "ErrorField should only be added for the default Error object")
+	})
+
+	t.Run("problem+json error format", func(t *testing.T) {
+		input := &Service{
+			Name:        "ProblemService",
+			ErrorFormat: ErrorFormatProblemJSON,
+		}
+
+		result := ApplyOverlay(input)
+		require.NotNil(t, result)
+
+		errorObj := result.GetObject("Error")
+		require.NotNil(t, errorObj, "Error object should exist")
+		fieldNames := make([]string, len(errorObj.Fields))
+		for i, field := range errorObj.Fields {
+			fieldNames[i] = field.Name
+		}
+		assert.Equal(t, []string{"Type", "Title", "Status", "Detail", "Instance", "Code", "RequestID", "Fields"}, fieldNames)
+		assert.Equal(t, FieldTypeInt, errorObj.Fields[2].Type, "Status should be an integer")
+		assert.Equal(t, "Detail", result.GetErrorMessageFieldName())
+		assert.NotNil(t, result.GetObject("ErrorField"), "ErrorField object should exist")
+	})
+
+	t.Run("problem+json error format with custom Error object", func(t *testing.T) {
+		input := &Service{
+			Name:        "CustomProblemService",
+			ErrorFormat: ErrorFormatProblemJSON,
+			Objects: []Object{
+				{Name: "Error", Fields: []Field{{Name: "Message", Type: FieldTypeString}}},
+			},
+		}
+
+		result := ApplyOverlay(input)
+		require.NotNil(t, result)
+
+		assert.Len(t, result.GetObject("Error").Fields, 1, "Custom Error object should be kept as is")
+		assert.Equal(t, "Message", result.GetErrorMessageFieldName())
 	})
 
 	t.Run("service with resources", func(t *testing.T) {
@@ -2057,7 +2312,10 @@ func Test_isComparableType(t *testing.T) {
 	}{
 		{FieldTypeString, false},
 		{FieldTypeInt, true},
+		{FieldTypeInt32, true},
+		{FieldTypeUInt, true},
 		{FieldTypeFloat64, true},
+		{FieldTypeDecimal, true},
 		{FieldTypeDate, true},
 		{FieldTypeTimestamp, true},
 		{FieldTypeUUID, false},
@@ -2522,6 +2780,58 @@ func TestResource_GetUpdateBodyParams(t *testing.T) {
 	assert.Equal(t, "email", updateParams[0].Name, "Field name should match")
 }
 
+func TestResourceExample_GetValue(t *testing.T) {
+	example := ResourceExample{Name: "alice", Values: map[string]any{"Name": "Alice", "birthDate": "1990-01-01"}}
+
+	value, ok := example.GetValue(Field{Name: "Name"})
+	assert.True(t, ok, "Value should be found by field name")
+	assert.Equal(t, "Alice", value)
+
+	value, ok = example.GetValue(Field{Name: "BirthDate"})
+	assert.True(t, ok, "Value should be found by JSON tag")
+	assert.Equal(t, "1990-01-01", value)
+
+	_, ok = example.GetValue(Field{Name: "Email"})
+	assert.False(t, ok, "Missing value should not be found")
+}
+
+func TestResource_GetSDKGroup(t *testing.T) {
+	assert.Equal(t, "users", Resource{Name: "User"}.GetSDKGroup(), "Default group should be the plural name in camelCase")
+	assert.Equal(t, "people", Resource{Name: "User", SDKGroup: "people"}.GetSDKGroup(), "sdk_group should override the group")
+}
+
+func TestEndpoint_GetSDKMethodName(t *testing.T) {
+	assert.Equal(t, "getByEmail", Endpoint{Name: "GetByEmail"}.GetSDKMethodName(), "Default method name should be the endpoint name in camelCase")
+	assert.Equal(t, "lookup", Endpoint{Name: "GetByEmail", SDKMethodName: "lookup"}.GetSDKMethodName(), "sdk_method_name should override the method name")
+}
+
+func TestResource_GetBodyParams_RequiredOn(t *testing.T) {
+	service := &Service{}
+	resource := Resource{
+		Name: "Users",
+		Fields: []ResourceField{
+			{
+				Field:      Field{Name: "email", Type: FieldTypeString},
+				Operations: []string{OperationCreate, OperationUpdate, OperationRead},
+				RequiredOn: []string{OperationCreate},
+			},
+			{
+				Field:      Field{Name: "name", Type: FieldTypeString},
+				Operations: []string{OperationCreate, OperationUpdate, OperationRead},
+			},
+		},
+	}
+
+	createParams := resource.GetCreateBodyParams()
+	updateParams := resource.GetUpdateBodyParams()
+
+	assert.True(t, createParams[0].IsRequired(service), "Field should be required on Create")
+	assert.False(t, updateParams[0].IsRequired(service), "Field should be optional on Update")
+	assert.True(t, updateParams[0].IsOptional(), "Field should be marked as optional on Update")
+	assert.Empty(t, updateParams[1].Modifiers, "Fields without RequiredOn should keep their modifiers")
+	assert.Empty(t, resource.Fields[0].Modifiers, "Resource field modifiers should not be changed")
+}
+
 func TestResource_GetReadableFields(t *testing.T) {
 	resource := Resource{
 		Name: "Users",
@@ -2638,6 +2948,208 @@ func TestEndpoint_GetFullPath(t *testing.T) {
 	}
 }
 
+func TestEndpoint_GetRequiredScopes(t *testing.T) {
+	resource := Resource{Name: "Users", Scopes: []string{"users:read"}}
+
+	t.Run("falls back to resource scopes", func(t *testing.T) {
+		endpoint := Endpoint{Name: "Get"}
+		assert.Equal(t, []string{"users:read"}, endpoint.GetRequiredScopes(resource))
+	})
+
+	t.Run("endpoint scopes override resource scopes", func(t *testing.T) {
+		endpoint := Endpoint{Name: "Archive", Scopes: []string{"users:write"}}
+		assert.Equal(t, []string{"users:write"}, endpoint.GetRequiredScopes(resource))
+	})
+
+	t.Run("no scopes", func(t *testing.T) {
+		endpoint := Endpoint{Name: "Get"}
+		assert.Empty(t, endpoint.GetRequiredScopes(Resource{Name: "Users"}))
+	})
+}
+
+func TestEndpoint_GetRequiredPermissions(t *testing.T) {
+	resource := Resource{Name: "Users", Permissions: []string{"users:view"}}
+
+	t.Run("falls back to resource permissions", func(t *testing.T) {
+		endpoint := Endpoint{Name: "Get"}
+		assert.Equal(t, []string{"users:view"}, endpoint.GetRequiredPermissions(resource))
+	})
+
+	t.Run("endpoint permissions override resource permissions", func(t *testing.T) {
+		endpoint := Endpoint{Name: "Archive", Permissions: []string{"users:archive"}}
+		assert.Equal(t, []string{"users:archive"}, endpoint.GetRequiredPermissions(resource))
+	})
+
+	t.Run("no permissions", func(t *testing.T) {
+		endpoint := Endpoint{Name: "Get"}
+		assert.Empty(t, endpoint.GetRequiredPermissions(Resource{Name: "Users"}))
+	})
+}
+
+func TestEndpoint_GetStability(t *testing.T) {
+	resource := Resource{Name: "Users", Stability: StabilityStable}
+
+	t.Run("falls back to resource stability", func(t *testing.T) {
+		assert.Equal(t, StabilityStable, Endpoint{Name: "Get"}.GetStability(resource))
+	})
+
+	t.Run("endpoint stability overrides resource stability", func(t *testing.T) {
+		assert.Equal(t, StabilityBeta, Endpoint{Name: "Archive", Stability: StabilityBeta}.GetStability(resource))
+	})
+
+	t.Run("no stability", func(t *testing.T) {
+		assert.Empty(t, Endpoint{Name: "Get"}.GetStability(Resource{Name: "Users"}))
+	})
+}
+
+func TestEndpoint_GetRequestLimits(t *testing.T) {
+	service := &Service{Name: "TestService", MaxBodyBytes: 1024, HandlerTimeout: 5000}
+
+	t.Run("falls back to service limits", func(t *testing.T) {
+		endpoint := Endpoint{Name: "Create"}
+		assert.Equal(t, int64(1024), endpoint.GetMaxBodyBytes(service))
+		assert.Equal(t, 5000, endpoint.GetHandlerTimeout(service))
+	})
+
+	t.Run("endpoint limits override service limits", func(t *testing.T) {
+		endpoint := Endpoint{Name: "Import", MaxBodyBytes: 1 << 20, HandlerTimeout: 30000}
+		assert.Equal(t, int64(1<<20), endpoint.GetMaxBodyBytes(service))
+		assert.Equal(t, 30000, endpoint.GetHandlerTimeout(service))
+	})
+
+	t.Run("no limits", func(t *testing.T) {
+		endpoint := Endpoint{Name: "Get"}
+		assert.Zero(t, endpoint.GetMaxBodyBytes(&Service{}))
+		assert.Zero(t, endpoint.GetHandlerTimeout(&Service{}))
+	})
+}
+
+func TestEndpoint_GetAuditOperation(t *testing.T) {
+	resource := Resource{Name: "Users", Operations: []string{OperationCreate, OperationUpdate, OperationDelete, OperationGet}}
+
+	t.Run("mutating endpoints", func(t *testing.T) {
+		assert.Equal(t, OperationCreate, Endpoint{Name: "Create"}.GetAuditOperation(resource))
+		assert.Equal(t, OperationUpdate, Endpoint{Name: "Update"}.GetAuditOperation(resource))
+		assert.Equal(t, OperationDelete, Endpoint{Name: "Delete"}.GetAuditOperation(resource))
+	})
+
+	t.Run("other endpoints", func(t *testing.T) {
+		assert.Empty(t, Endpoint{Name: "Get"}.GetAuditOperation(resource))
+		assert.Empty(t, Endpoint{Name: "Archive"}.GetAuditOperation(resource))
+	})
+
+	t.Run("custom endpoint named like an operation of the resource it does not have", func(t *testing.T) {
+		assert.Empty(t, Endpoint{Name: "Create"}.GetAuditOperation(Resource{Name: "Users", Operations: []string{OperationGet}}))
+	})
+}
+
+func TestEndpoint_IsConditionalGet(t *testing.T) {
+	service := ApplyOverlay(&Service{
+		Name: "TestService",
+		Resources: []Resource{
+			{
+				Name:       "Users",
+				Operations: []string{"Create", "Get"},
+				Fields: []ResourceField{
+					{Field: Field{Name: "Name", Type: FieldTypeString}, Operations: []string{"Create", "Read"}},
+				},
+			},
+		},
+	})
+	getEndpoint := func(name string) *Endpoint {
+		for _, endpoint := range service.GetResource("Users").Endpoints {
+			if endpoint.Name == name {
+				return &endpoint
+			}
+		}
+		return nil
+	}
+
+	t.Run("get endpoint of a resource with meta", func(t *testing.T) {
+		endpoint := getEndpoint("Get")
+		require.NotNil(t, endpoint)
+		assert.True(t, endpoint.IsConditionalGet(service))
+		assert.Equal(t, "UpdatedAt", service.GetLastModifiedField("Users").Name)
+	})
+
+	t.Run("create endpoint", func(t *testing.T) {
+		endpoint := getEndpoint("Create")
+		require.NotNil(t, endpoint)
+		assert.False(t, endpoint.IsConditionalGet(service), "Only GET endpoints should be conditional")
+	})
+
+	t.Run("get endpoint of an object without meta", func(t *testing.T) {
+		service := &Service{Objects: []Object{{Name: "Report", Fields: []Field{{Name: "Total", Type: FieldTypeInt}}}}}
+		endpoint := Endpoint{Method: "GET", Response: EndpointResponse{StatusCode: 200, BodyObject: &service.Objects[0].Name}}
+		assert.False(t, endpoint.IsConditionalGet(service))
+		assert.Nil(t, service.GetLastModifiedField("Report"))
+	})
+}
+
+func TestTenancy_GetPathPrefix(t *testing.T) {
+	t.Run("path tenancy", func(t *testing.T) {
+		tenancy := &Tenancy{In: TenancyInPath, Name: "TenantID"}
+		assert.Equal(t, "/{tenantID}", tenancy.GetPathPrefix())
+		assert.Equal(t, "tenantID", tenancy.GetParameterName())
+	})
+
+	t.Run("header tenancy", func(t *testing.T) {
+		tenancy := &Tenancy{In: TenancyInHeader, Name: "X-Tenant-ID"}
+		assert.Empty(t, tenancy.GetPathPrefix())
+		assert.Equal(t, "X-Tenant-ID", tenancy.GetParameterName())
+	})
+
+	t.Run("no tenancy", func(t *testing.T) {
+		var tenancy *Tenancy
+		assert.Empty(t, tenancy.GetPathPrefix())
+		assert.False(t, tenancy.IsHeader())
+		assert.False(t, tenancy.IsPath())
+	})
+}
+
+func TestLocalization_GetLocales(t *testing.T) {
+	t.Run("default locale first", func(t *testing.T) {
+		localization := &Localization{
+			DefaultLocale: "en",
+			Messages: map[string]map[string]string{
+				"NotFound":   {"en": "Not found", "sv": "Hittades inte", "de": "Nicht gefunden"},
+				"BadRequest": {"en": "Bad request", "sv": "Felaktig begäran"},
+			},
+		}
+		assert.Equal(t, []string{"en", "de", "sv"}, localization.GetLocales())
+		assert.Equal(t, map[string]string{"NotFound": "Hittades inte", "BadRequest": "Felaktig begäran"}, localization.GetMessages("sv"))
+		assert.Equal(t, map[string]string{"NotFound": "Nicht gefunden"}, localization.GetMessages("de"))
+	})
+
+	t.Run("no localization", func(t *testing.T) {
+		var localization *Localization
+		assert.Nil(t, localization.GetLocales())
+		assert.Nil(t, localization.GetMessages("en"))
+	})
+}
+
+func TestOperationalEndpoints_HasEndpoints(t *testing.T) {
+	t.Run("nil configuration", func(t *testing.T) {
+		var operational *OperationalEndpoints
+		assert.False(t, operational.HasEndpoints())
+		assert.False(t, operational.HasChecks())
+		assert.False(t, operational.IsExcludedFromSDK())
+	})
+
+	t.Run("version only", func(t *testing.T) {
+		operational := &OperationalEndpoints{Version: true}
+		assert.True(t, operational.HasEndpoints())
+		assert.False(t, operational.HasChecks())
+	})
+
+	t.Run("readiness only", func(t *testing.T) {
+		operational := &OperationalEndpoints{Readiness: true}
+		assert.True(t, operational.HasEndpoints())
+		assert.True(t, operational.HasChecks())
+		assert.False(t, operational.HasHealth())
+	})
+}
+
 func TestEndpoint_SummaryField(t *testing.T) {
 	t.Run("endpoint with summary field marshaling and unmarshaling", func(t *testing.T) {
 		endpoint := Endpoint{
@@ -2947,46 +3459,735 @@ func TestApplyOverlay_UpdateDeleteOperations(t *testing.T) {
 	})
 }
 
-func TestApplyOverlay_SearchEndpointFilterObjects(t *testing.T) {
-	t.Run("should generate filter objects before search endpoints", func(t *testing.T) {
-		input := &Service{
-			Name:  "TestService",
-			Enums: []Enum{},
-			Objects: []Object{
-				{
-					Name:        "School",
-					Description: "School object",
-					Fields: []Field{
-						{
-							Name:        "Name",
-							Type:        FieldTypeString,
-							Description: "School name",
-						},
-						{
-							Name:        "Location",
-							Type:        FieldTypeString,
-							Description: "School location",
-						},
-					},
-				},
-			},
+func TestApplyOverlay_SoftDelete(t *testing.T) {
+	createInput := func(operations ...string) *Service {
+		return &Service{
+			Name: "TestService",
 			Resources: []Resource{
 				{
-					Name:        "School",
-					Description: "School management",
-					Operations:  []string{OperationGet, OperationList, OperationSearch}, // Read operation should trigger search endpoint generation
-					Fields:      []ResourceField{},
-					Endpoints:   []Endpoint{},
+					Name:       "Users",
+					Operations: operations,
+					SoftDelete: true,
+					Fields: []ResourceField{
+						{Field: Field{Name: "Name", Type: FieldTypeString}, Operations: []string{OperationCreate, OperationRead}},
+					},
 				},
 			},
 		}
+	}
+	getEndpoint := func(resource Resource, name string) *Endpoint {
+		for _, endpoint := range resource.Endpoints {
+			if endpoint.Name == name {
+				return &endpoint
+			}
+		}
+		return nil
+	}
 
-		result := ApplyOverlay(input)
+	t.Run("readable resource", func(t *testing.T) {
+		result := ApplyOverlay(createInput(OperationGet, OperationList, OperationSearch, OperationDelete))
 		require.NotNil(t, result)
+		users := result.Resources[0]
+
+		deleteEndpoint := getEndpoint(users, "Delete")
+		require.NotNil(t, deleteEndpoint)
+		assert.Contains(t, deleteEndpoint.Description, "Soft deletes the `Users`")
+
+		restoreEndpoint := getEndpoint(users, "Restore")
+		require.NotNil(t, restoreEndpoint, "Should generate the Restore endpoint")
+		assert.Equal(t, "POST", restoreEndpoint.Method)
+		assert.Equal(t, "/{id}/restore", restoreEndpoint.Path)
+		assert.Equal(t, 200, restoreEndpoint.Response.StatusCode)
+		require.NotNil(t, restoreEndpoint.Response.BodyObject)
+		assert.Equal(t, "Users", *restoreEndpoint.Response.BodyObject, "Should respond with the restored resource")
+
+		for _, name := range []string{"List", "Search"} {
+			endpoint := getEndpoint(users, name)
+			require.NotNil(t, endpoint)
+			includeDeleted := endpoint.Request.QueryParams[len(endpoint.Request.QueryParams)-1]
+			assert.Equal(t, "IncludeDeleted", includeDeleted.Name, "%s should have the IncludeDeleted flag", name)
+			assert.Equal(t, FieldTypeBool, includeDeleted.Type)
+			assert.Equal(t, "false", includeDeleted.Default)
+		}
 
-		// Verify that filter objects were generated
-		schoolFilterExists := false
-		for _, obj := range result.Objects {
+		object := result.GetObject("Users")
+		require.NotNil(t, object)
+		deletedAt := object.GetField("DeletedAt")
+		require.NotNil(t, deletedAt, "Object should have the DeletedAt field")
+		assert.True(t, deletedAt.IsNullable())
+	})
+
+	t.Run("resource without read operations", func(t *testing.T) {
+		result := ApplyOverlay(createInput(OperationDelete))
+		require.NotNil(t, result)
+
+		restoreEndpoint := getEndpoint(result.Resources[0], "Restore")
+		require.NotNil(t, restoreEndpoint, "Should generate the Restore endpoint")
+		assert.Equal(t, 204, restoreEndpoint.Response.StatusCode)
+		assert.Nil(t, restoreEndpoint.Response.BodyObject)
+	})
+
+	t.Run("resource without soft delete", func(t *testing.T) {
+		input := createInput(OperationList, OperationDelete)
+		input.Resources[0].SoftDelete = false
+		result := ApplyOverlay(input)
+		require.NotNil(t, result)
+
+		assert.False(t, result.Resources[0].HasEndpoint("Restore"))
+		listEndpoint := getEndpoint(result.Resources[0], "List")
+		require.NotNil(t, listEndpoint)
+		assert.Len(t, listEndpoint.Request.QueryParams, 2, "List should only have the pagination parameters")
+	})
+}
+
+func TestApplyOverlay_FieldScopes(t *testing.T) {
+	// Arrange
+	input := &Service{
+		Name: "TestService",
+		Resources: []Resource{
+			{
+				Name:       "Users",
+				Operations: []string{OperationCreate, OperationGet},
+				Fields: []ResourceField{
+					{Field: Field{Name: "Name", Type: FieldTypeString}, Operations: []string{OperationCreate, OperationRead}},
+					{Field: Field{Name: "SSN", Type: FieldTypeString, Modifiers: []string{ModifierNullable}, Scopes: []string{"users:ssn"}}, Operations: []string{OperationCreate, OperationRead}},
+				},
+			},
+		},
+	}
+
+	// Act
+	result := ApplyOverlay(input)
+
+	// Assert
+	require.NotNil(t, result)
+	assert.True(t, result.HasScopedFields(), "Should have scoped fields")
+
+	usersObject := result.GetObject("Users")
+	require.NotNil(t, usersObject)
+	for _, field := range usersObject.Fields {
+		if field.Name == "SSN" {
+			assert.Equal(t, []string{"users:ssn"}, field.Scopes, "Readable fields should keep their scopes")
+		}
+	}
+
+	for _, endpoint := range result.Resources[0].Endpoints {
+		if endpoint.Name != "Create" {
+			continue
+		}
+		for _, field := range endpoint.Request.BodyParams {
+			assert.Empty(t, field.Scopes, "Body params should not require scopes, they only restrict reading")
+		}
+	}
+}
+
+func TestService_HasScopedFields(t *testing.T) {
+	scopedField := Field{Name: "SSN", Type: FieldTypeString, Modifiers: []string{ModifierNullable}, Scopes: []string{"users:ssn"}}
+
+	t.Run("object field", func(t *testing.T) {
+		service := Service{Objects: []Object{{Name: "User", Fields: []Field{scopedField}}}}
+		assert.True(t, service.HasScopedFields())
+	})
+
+	t.Run("response body field", func(t *testing.T) {
+		service := Service{Resources: []Resource{{Name: "Users", Endpoints: []Endpoint{{Name: "Stats", Response: EndpointResponse{BodyFields: []Field{scopedField}}}}}}}
+		assert.True(t, service.HasScopedFields())
+	})
+
+	t.Run("no scoped fields", func(t *testing.T) {
+		service := Service{Objects: []Object{{Name: "User", Fields: []Field{{Name: "Name", Type: FieldTypeString}}}}}
+		assert.False(t, service.HasScopedFields())
+	})
+}
+
+func TestService_HasRequestLimits(t *testing.T) {
+	t.Run("service limits", func(t *testing.T) {
+		service := Service{MaxBodyBytes: 1024}
+		assert.True(t, service.HasBodyLimits())
+		assert.False(t, service.HasHandlerTimeouts())
+		assert.True(t, service.HasRequestLimits())
+	})
+
+	t.Run("endpoint limits", func(t *testing.T) {
+		service := Service{Resources: []Resource{{Name: "Users", Endpoints: []Endpoint{{Name: "Import", HandlerTimeout: 30000}}}}}
+		assert.False(t, service.HasBodyLimits())
+		assert.True(t, service.HasHandlerTimeouts())
+		assert.True(t, service.HasRequestLimits())
+	})
+
+	t.Run("no limits", func(t *testing.T) {
+		service := Service{Resources: []Resource{{Name: "Users", Endpoints: []Endpoint{{Name: "Get"}}}}}
+		assert.False(t, service.HasRequestLimits())
+	})
+}
+
+func TestService_HasXML(t *testing.T) {
+	t.Run("XML request", func(t *testing.T) {
+		service := Service{Resources: []Resource{{Name: "Orders", Endpoints: []Endpoint{{Name: "Submit", Request: EndpointRequest{ContentType: ContentTypeXML}}}}}}
+		assert.True(t, service.HasXMLRequests())
+		assert.False(t, service.HasXMLResponses())
+		assert.True(t, service.HasXML())
+	})
+
+	t.Run("XML response", func(t *testing.T) {
+		service := Service{Resources: []Resource{{Name: "Orders", Endpoints: []Endpoint{{Name: "Get", Response: EndpointResponse{ContentType: ContentTypeXML}}}}}}
+		assert.False(t, service.HasXMLRequests())
+		assert.True(t, service.HasXMLResponses())
+		assert.True(t, service.HasXML())
+	})
+
+	t.Run("XML as alternative content type", func(t *testing.T) {
+		service := Service{Resources: []Resource{{Name: "Orders", Endpoints: []Endpoint{{Name: "Get", Response: EndpointResponse{ContentType: contentTypeJSON, AlternativeContentTypes: []string{ContentTypeXML}}}}}}}
+		assert.False(t, service.HasXMLResponses())
+		assert.True(t, service.HasXML())
+	})
+
+	t.Run("JSON only", func(t *testing.T) {
+		service := Service{Resources: []Resource{{Name: "Orders", Endpoints: []Endpoint{{Name: "Get", Request: EndpointRequest{ContentType: contentTypeJSON}, Response: EndpointResponse{ContentType: contentTypeJSON}}}}}}
+		assert.False(t, service.HasXML())
+	})
+}
+
+func TestService_HasFormRequests(t *testing.T) {
+	t.Run("form request", func(t *testing.T) {
+		service := Service{Resources: []Resource{{Name: "Orders", Endpoints: []Endpoint{{Name: "Submit", Request: EndpointRequest{ContentType: ContentTypeForm}}}}}}
+		assert.True(t, service.HasFormRequests())
+		assert.False(t, service.HasXML())
+	})
+
+	t.Run("JSON only", func(t *testing.T) {
+		service := Service{Resources: []Resource{{Name: "Orders", Endpoints: []Endpoint{{Name: "Submit", Request: EndpointRequest{ContentType: contentTypeJSON}}}}}}
+		assert.False(t, service.HasFormRequests())
+	})
+}
+
+func TestService_GetReferencedResources(t *testing.T) {
+	t.Run("references of resource fields and params", func(t *testing.T) {
+		service := ApplyOverlay(&Service{
+			Name: "TestService",
+			Resources: []Resource{
+				{Name: "User", Operations: []string{OperationGet}},
+				{Name: "Group", Operations: []string{OperationGet}},
+				{
+					Name:       "Membership",
+					Operations: []string{OperationGet},
+					Fields: []ResourceField{
+						{Field: Field{Name: "UserID", Type: FieldTypeUUID, References: "User"}, Operations: []string{OperationRead}},
+					},
+					Endpoints: []Endpoint{
+						{Name: "ByGroup", Request: EndpointRequest{PathParams: []Field{{Name: "GroupID", Type: FieldTypeUUID, References: "Group"}}}},
+					},
+				},
+			},
+		})
+		assert.Equal(t, []string{"Group", "User"}, service.GetReferencedResources())
+		fields := service.GetObject("Membership").Fields
+		userID := slices.IndexFunc(fields, func(field Field) bool { return field.Name == "UserID" })
+		assert.Equal(t, "User", fields[userID].References, "Resource objects should keep the references of their fields")
+	})
+
+	t.Run("no references", func(t *testing.T) {
+		service := Service{Resources: []Resource{{Name: "User"}}}
+		assert.Empty(t, service.GetReferencedResources())
+	})
+}
+
+func createUnusedDefinitionsService() *Service {
+	return ApplyDefaultOverlays(&Service{
+		Name: "TestService",
+		Enums: []Enum{
+			{Name: "Status", Values: []EnumValue{{Name: "Active"}}},
+			{Name: "Color", Values: []EnumValue{{Name: "Red"}}},
+			{Name: "Shape", Values: []EnumValue{{Name: "Circle"}}},
+			{Name: "Region", Library: "common", Values: []EnumValue{{Name: "North"}}},
+		},
+		Objects: []Object{
+			{Name: "Address", Fields: []Field{{Name: "Street", Type: FieldTypeString}}},
+			{Name: "Location", Extends: "Coordinates", Fields: []Field{{Name: "Shape", Type: "Shape"}}},
+			{Name: "Coordinates", Fields: []Field{{Name: "Latitude", Type: FieldTypeInt}}},
+			{Name: "Legacy", Fields: []Field{{Name: "Color", Type: "Color"}, {Name: "Location", Type: "Location"}}},
+		},
+		Resources: []Resource{
+			{
+				Name:       "User",
+				Operations: []string{OperationGet, OperationSearch},
+				Fields: []ResourceField{
+					{Field: Field{Name: "Status", Type: "Status"}, Operations: []string{OperationRead}},
+					{Field: Field{Name: "Address", Type: "Address"}, Operations: []string{OperationRead}},
+				},
+			},
+		},
+	})
+}
+
+func TestService_GetUnusedDefinitions(t *testing.T) {
+	t.Run("definitions only referenced by unused objects", func(t *testing.T) {
+		service := createUnusedDefinitionsService()
+		assert.Equal(t, []string{"Color", "Shape"}, service.GetUnusedEnums(), "Enums of shared libraries should never be unused")
+		assert.Equal(t, []string{"Location", "Coordinates", "Legacy"}, service.GetUnusedObjects(),
+			"Parents of unused objects should be unused, the default objects and the filter objects of used objects should be used")
+	})
+
+	t.Run("no unused definitions", func(t *testing.T) {
+		service := ApplyDefaultOverlays(&Service{Name: "TestService", Resources: []Resource{{Name: "User", Operations: []string{OperationGet}}}})
+		assert.Empty(t, service.GetUnusedEnums())
+		assert.Empty(t, service.GetUnusedObjects())
+	})
+}
+
+func TestPruneUnusedDefinitions(t *testing.T) {
+	// Arrange
+	service := createUnusedDefinitionsService()
+	enums, objects := len(service.Enums), len(service.Objects)
+
+	// Act
+	pruned := PruneUnusedDefinitions(service)
+
+	// Assert
+	assert.Len(t, pruned.Enums, enums-2)
+	assert.Len(t, pruned.Objects, objects-3)
+	assert.True(t, pruned.HasEnum("Status"))
+	assert.True(t, pruned.HasObject("AddressFilter"))
+	assert.False(t, pruned.HasObject("Legacy"))
+	assert.Empty(t, pruned.GetUnusedEnums())
+	assert.Empty(t, pruned.GetUnusedObjects())
+	assert.Len(t, service.Objects, objects, "The service should not be modified")
+}
+
+func TestApplyOverlay_RequestLimits(t *testing.T) {
+	errorCodeNames := func(service *Service) []string {
+		var names []string
+		for _, enum := range service.Enums {
+			if enum.Name == errorCodeEnumName {
+				for _, value := range enum.Values {
+					names = append(names, value.Name)
+				}
+			}
+		}
+		return names
+	}
+
+	t.Run("limits add their error codes", func(t *testing.T) {
+		// Act
+		result := ApplyOverlay(&Service{Name: "TestService", MaxBodyBytes: 1024, HandlerTimeout: 5000})
+
+		// Assert
+		assert.Equal(t, int64(1024), result.MaxBodyBytes)
+		assert.Equal(t, 5000, result.HandlerTimeout)
+		assert.Contains(t, errorCodeNames(result), errorCodeRequestTimeout)
+		assert.Contains(t, errorCodeNames(result), errorCodePayloadTooLarge)
+	})
+
+	t.Run("no limits keep the default error codes", func(t *testing.T) {
+		// Act
+		result := ApplyOverlay(&Service{Name: "TestService"})
+
+		// Assert
+		assert.NotContains(t, errorCodeNames(result), errorCodeRequestTimeout)
+		assert.NotContains(t, errorCodeNames(result), errorCodePayloadTooLarge)
+	})
+}
+
+func TestApplyOverlay_Exportable(t *testing.T) {
+	createInput := func(softDelete bool) *Service {
+		return &Service{
+			Name: "TestService",
+			Resources: []Resource{
+				{
+					Name:       "Users",
+					Operations: []string{OperationList, OperationDelete},
+					Exportable: true,
+					SoftDelete: softDelete,
+					Fields: []ResourceField{
+						{Field: Field{Name: "Name", Type: FieldTypeString}, Operations: []string{OperationCreate, OperationRead}},
+					},
+				},
+			},
+		}
+	}
+	getExportEndpoint := func(resource Resource) *Endpoint {
+		for _, endpoint := range resource.Endpoints {
+			if endpoint.Name == "Export" {
+				return &endpoint
+			}
+		}
+		return nil
+	}
+
+	t.Run("exportable resource", func(t *testing.T) {
+		result := ApplyOverlay(createInput(false))
+		require.NotNil(t, result)
+
+		exportEndpoint := getExportEndpoint(result.Resources[0])
+		require.NotNil(t, exportEndpoint, "Should generate the Export endpoint")
+		assert.Equal(t, "GET", exportEndpoint.Method)
+		assert.Equal(t, "/_export", exportEndpoint.Path)
+		assert.Empty(t, exportEndpoint.Request.QueryParams, "Export should not be paginated")
+		assert.True(t, exportEndpoint.HasCSVResponse())
+		assert.Equal(t, ContentTypeCSV, exportEndpoint.Response.ContentType)
+		assert.Equal(t, 200, exportEndpoint.Response.StatusCode)
+		require.NotNil(t, exportEndpoint.Response.BodyObject)
+		assert.Equal(t, "Users", *exportEndpoint.Response.BodyObject, "Should have a row per resource")
+		assert.False(t, exportEndpoint.IsConditionalGet(result), "CSV responses are not conditional")
+	})
+
+	t.Run("exportable soft delete resource", func(t *testing.T) {
+		result := ApplyOverlay(createInput(true))
+		require.NotNil(t, result)
+
+		exportEndpoint := getExportEndpoint(result.Resources[0])
+		require.NotNil(t, exportEndpoint)
+		require.Len(t, exportEndpoint.Request.QueryParams, 1)
+		assert.Equal(t, "IncludeDeleted", exportEndpoint.Request.QueryParams[0].Name, "Export should have the IncludeDeleted flag of List")
+	})
+
+	t.Run("resource without list operation", func(t *testing.T) {
+		input := createInput(false)
+		input.Resources[0].Operations = []string{OperationDelete}
+		result := ApplyOverlay(input)
+		require.NotNil(t, result)
+
+		assert.False(t, result.Resources[0].HasEndpoint("Export"))
+	})
+}
+
+func TestApplyOverlay_TotalCount(t *testing.T) {
+	createInput := func(totalCount string) *Service {
+		return &Service{
+			Name: "TestService",
+			Resources: []Resource{
+				{
+					Name:       "Users",
+					Operations: []string{OperationList, OperationSearch},
+					TotalCount: totalCount,
+					Fields: []ResourceField{
+						{Field: Field{Name: "Name", Type: FieldTypeString}, Operations: []string{OperationCreate, OperationRead}},
+					},
+				},
+			},
+		}
+	}
+
+	getEndpoint := func(resource Resource, name string) *Endpoint {
+		for _, endpoint := range resource.Endpoints {
+			if endpoint.Name == name {
+				return &endpoint
+			}
+		}
+		return nil
+	}
+
+	t.Run("total in the pagination", func(t *testing.T) {
+		result := ApplyOverlay(createInput(""))
+		require.NotNil(t, result)
+
+		for _, name := range []string{"List", "Search"} {
+			endpoint := getEndpoint(result.Resources[0], name)
+			require.NotNil(t, endpoint)
+			assert.Empty(t, endpoint.Response.Headers, "%s should not have the X-Total-Count header", name)
+			assert.Equal(t, paginationObjectName, endpoint.Response.BodyFields[1].Type)
+		}
+		assert.Nil(t, result.GetObject(PaginationWithoutTotalObjectName), "Should not add the pagination without total")
+	})
+
+	t.Run("total in the header", func(t *testing.T) {
+		result := ApplyOverlay(createInput(TotalCountHeader))
+		require.NotNil(t, result)
+
+		for _, name := range []string{"List", "Search"} {
+			endpoint := getEndpoint(result.Resources[0], name)
+			require.NotNil(t, endpoint)
+			require.Len(t, endpoint.Response.Headers, 1, "%s should have the X-Total-Count header", name)
+			assert.Equal(t, "TotalCount", endpoint.Response.Headers[0].Name)
+			assert.Equal(t, TotalCountHeaderName, endpoint.Response.Headers[0].GetHeaderName())
+			assert.Equal(t, FieldTypeInt, endpoint.Response.Headers[0].Type)
+			assert.Equal(t, endpoint.Response.Headers, endpoint.Response.GetHeaderFields(), "The handler should set the header through the response")
+			assert.Equal(t, PaginationWithoutTotalObjectName, endpoint.Response.BodyFields[1].Type)
+		}
+
+		pagination := result.GetObject(PaginationWithoutTotalObjectName)
+		require.NotNil(t, pagination, "Should add the pagination without total")
+		assert.True(t, pagination.HasField("Offset"))
+		assert.True(t, pagination.HasField("Limit"))
+		assert.False(t, pagination.HasField("Total"))
+		assert.True(t, result.GetObject(paginationObjectName).HasField("Total"), "Should keep the total of the Pagination object")
+	})
+}
+
+func TestApplyOverlay_Expansions(t *testing.T) {
+	input := &Service{
+		Name: "TestService",
+		Resources: []Resource{
+			{
+				Name:       "Users",
+				Operations: []string{OperationGet},
+				Fields: []ResourceField{
+					{Field: Field{Name: "Name", Type: FieldTypeString}, Operations: []string{OperationCreate, OperationRead}},
+				},
+			},
+			{
+				Name:       "Orders",
+				Operations: []string{OperationCreate, OperationGet, OperationList},
+				Fields: []ResourceField{
+					{Field: Field{Name: "BuyerID", Type: FieldTypeUUID, References: "Users"}, Operations: []string{OperationCreate, OperationRead}},
+					{Field: Field{Name: "ReviewerIDs", Type: FieldTypeUUID, References: "Users", Modifiers: []string{ModifierArray}}, Operations: []string{OperationCreate, OperationRead}},
+				},
+				Expansions: []Expansion{
+					{Name: "Buyer", Field: "BuyerID"},
+					{Name: "Reviewers", Field: "ReviewerIDs", Description: "The users reviewing the order"},
+				},
+			},
+		},
+	}
+
+	result := ApplyOverlay(input)
+	require.NotNil(t, result)
+
+	t.Run("expansion enum", func(t *testing.T) {
+		enum := result.GetEnum("OrdersExpansion")
+		require.NotNil(t, enum, "Should generate the enum of the expansions")
+		require.Len(t, enum.Values, 2)
+		assert.Equal(t, "Buyer", enum.Values[0].Name)
+		assert.Equal(t, "The users reviewing the order", enum.Values[1].Description)
+	})
+
+	t.Run("expanded fields", func(t *testing.T) {
+		object := result.GetObject("Orders")
+		require.NotNil(t, object)
+
+		buyer := object.GetField("Buyer")
+		require.NotNil(t, buyer, "Should add the expanded resource to the object")
+		assert.Equal(t, "Users", buyer.Type)
+		assert.Equal(t, []string{ModifierNullable, ModifierOptional}, buyer.Modifiers)
+
+		reviewers := object.GetField("Reviewers")
+		require.NotNil(t, reviewers)
+		assert.Equal(t, "Users", reviewers.Type)
+		assert.Equal(t, []string{ModifierArray, ModifierNullable}, reviewers.Modifiers, "Array references should be expanded into arrays")
+	})
+
+	t.Run("expand query parameter", func(t *testing.T) {
+		resource := result.GetResource("Orders")
+		require.NotNil(t, resource)
+
+		for _, endpoint := range resource.Endpoints {
+			expand := endpoint.GetExpandParam(*resource)
+			if endpoint.Name == "Create" {
+				assert.Nil(t, expand, "Create should not be expandable")
+				continue
+			}
+			require.NotNil(t, expand, "Endpoint %s should be expandable", endpoint.Name)
+			assert.Equal(t, "OrdersExpansion", expand.Type)
+			assert.Equal(t, []string{ModifierArray}, expand.Modifiers)
+		}
+
+		users := result.GetResource("Users")
+		require.NotNil(t, users)
+		assert.Nil(t, users.Endpoints[0].GetExpandParam(*users), "Resources without expansions should not be expandable")
+	})
+
+	t.Run("idempotent", func(t *testing.T) {
+		again := ApplyOverlay(result)
+		require.NotNil(t, again)
+
+		assert.Len(t, again.GetObject("Orders").Fields, len(result.GetObject("Orders").Fields))
+		assert.Len(t, again.GetResource("Orders").Endpoints[1].Request.QueryParams, len(result.GetResource("Orders").Endpoints[1].Request.QueryParams))
+	})
+}
+
+func TestApplyFilterOverlay_Expansions(t *testing.T) {
+	input := &Service{
+		Name: "TestService",
+		Resources: []Resource{
+			{
+				Name:       "Users",
+				Operations: []string{OperationGet, OperationSearch},
+				Fields: []ResourceField{
+					{Field: Field{Name: "Name", Type: FieldTypeString}, Operations: []string{OperationCreate, OperationRead}},
+				},
+			},
+			{
+				Name:       "Orders",
+				Operations: []string{OperationGet, OperationSearch},
+				Fields: []ResourceField{
+					{Field: Field{Name: "BuyerID", Type: FieldTypeUUID, References: "Users"}, Operations: []string{OperationCreate, OperationRead}},
+				},
+				Expansions: []Expansion{
+					{Name: "Buyer", Field: "BuyerID"},
+				},
+			},
+		},
+	}
+
+	result := ApplyFilterOverlay(ApplyOverlay(input))
+	require.NotNil(t, result)
+
+	for _, name := range []string{"UsersFilter", "UsersFilterEquals", "UsersFilterRange", "UsersFilterContains", "UsersFilterLike", "UsersFilterNull"} {
+		count := 0
+		for _, object := range result.Objects {
+			if object.Name == name {
+				count++
+			}
+		}
+		assert.Equal(t, 1, count, "The expanded resource should not generate the filter object %s again", name)
+	}
+
+	ordersFilterEquals := result.GetObject("OrdersFilterEquals")
+	require.NotNil(t, ordersFilterEquals)
+	assert.False(t, ordersFilterEquals.HasField("Buyer"), "The expanded resource should not be filterable")
+}
+
+func TestService_GetCSVColumns(t *testing.T) {
+	service := &Service{
+		Objects: []Object{
+			{Name: "Address", Fields: []Field{{Name: "Street", Type: FieldTypeString}}},
+			{Name: "Node", Fields: []Field{{Name: "Name", Type: FieldTypeString}, {Name: "Parent", Type: "Node"}}},
+			{Name: "User", Fields: []Field{
+				{Name: "ID", Type: FieldTypeUUID},
+				{Name: "Address", Type: "Address"},
+				{Name: "PreviousAddress", Type: "Address", Modifiers: []string{ModifierNullable}},
+				{Name: "Tags", Type: FieldTypeString, Modifiers: []string{ModifierArray}},
+			}},
+		},
+	}
+
+	t.Run("nested objects", func(t *testing.T) {
+		columns := service.GetCSVColumns("User")
+
+		names := make([]string, len(columns))
+		for i, column := range columns {
+			names[i] = column.Name
+		}
+		assert.Equal(t, []string{"id", "address.street", "previousAddress", "tags"}, names, "Should flatten the nested objects that are not nullable")
+		assert.Equal(t, []string{"Address", "Street"}, columns[1].FieldPath)
+		assert.Equal(t, "Street", columns[1].Field.Name)
+	})
+
+	t.Run("recursive object", func(t *testing.T) {
+		columns := service.GetCSVColumns("Node")
+
+		require.Len(t, columns, 2)
+		assert.Equal(t, "parent", columns[1].Name, "Should not flatten an object into itself")
+	})
+
+	t.Run("expanded fields", func(t *testing.T) {
+		expanded := &Service{
+			Resources: []Resource{{Name: "Order", Expansions: []Expansion{{Name: "Buyer", Field: "BuyerID"}}}},
+			Objects: []Object{
+				{Name: "User", Fields: []Field{{Name: "Name", Type: FieldTypeString}}},
+				{Name: "Order", Fields: []Field{
+					{Name: "BuyerID", Type: FieldTypeUUID},
+					{Name: "Buyer", Type: "User", Modifiers: []string{ModifierNullable, ModifierOptional}},
+				}},
+			},
+		}
+
+		columns := expanded.GetCSVColumns("Order")
+
+		require.Len(t, columns, 1, "Should leave out the expanded fields")
+		assert.Equal(t, "buyerID", columns[0].Name)
+	})
+
+	t.Run("undefined object", func(t *testing.T) {
+		assert.Empty(t, service.GetCSVColumns("Undefined"))
+	})
+}
+
+func TestService_GetTagGroup(t *testing.T) {
+	service := &Service{
+		Name: "Blogs",
+		Resources: []Resource{
+			{Name: "Blog", TagGroup: "Publishing"},
+			{Name: "Post", Parent: "Blog"},
+			{Name: "Comment", Parent: "Post", TagGroup: "Community"},
+			{Name: "User"},
+		},
+	}
+
+	assert.Equal(t, "Publishing", service.GetTagGroup(*service.GetResource("Blog")), "Resource should use its own tag group")
+	assert.Equal(t, "Publishing", service.GetTagGroup(*service.GetResource("Post")), "Sub-resource should fall back to the tag group of its parent")
+	assert.Equal(t, "Community", service.GetTagGroup(*service.GetResource("Comment")), "Sub-resource tag group should override the parent")
+	assert.Empty(t, service.GetTagGroup(*service.GetResource("User")), "Resource without tag group should have none")
+}
+
+func TestApplyOverlay_SubResource(t *testing.T) {
+	input := &Service{
+		Name: "TestService",
+		Resources: []Resource{
+			{Name: "Comment", Parent: "Post", Operations: []string{OperationGet, OperationList}, Endpoints: []Endpoint{
+				{Name: "Approve", Method: "POST", Path: "/{id}/approve", Request: EndpointRequest{PathParams: []Field{{Name: "ID", Type: FieldTypeUUID}}}},
+			}},
+			{Name: "Post", Parent: "Blog", Operations: []string{OperationGet}},
+			{Name: "Blog", Operations: []string{OperationGet}},
+		},
+	}
+
+	result := ApplyOverlay(input)
+	require.NotNil(t, result)
+
+	comment := *result.GetResource("Comment")
+	require.Len(t, comment.Endpoints, 3)
+	for _, endpoint := range comment.Endpoints {
+		require.GreaterOrEqual(t, len(endpoint.Request.PathParams), 2, "%s should have the parent path parameters", endpoint.Name)
+		assert.Equal(t, "BlogID", endpoint.Request.PathParams[0].Name, "Outermost parent should come first")
+		assert.Equal(t, FieldTypeUUID, endpoint.Request.PathParams[0].Type)
+		assert.Equal(t, "PostID", endpoint.Request.PathParams[1].Name)
+	}
+	assert.Equal(t, "/blog/{blogID}/post/{postID}/comment/{id}/approve", result.GetEndpointPath(comment, comment.Endpoints[0]))
+	assert.Equal(t, "/blog/{blogID}/post/{postID}/comment", result.GetEndpointPath(comment, comment.Endpoints[2]))
+
+	blog := *result.GetResource("Blog")
+	require.Len(t, blog.Endpoints, 1)
+	assert.Len(t, blog.Endpoints[0].Request.PathParams, 1, "Resources without parent should keep their path parameters")
+	assert.Equal(t, "/blog/{id}", result.GetEndpointPath(blog, blog.Endpoints[0]))
+
+	// Applying the overlay again does not duplicate the parent path parameters
+	again := ApplyOverlay(result)
+	require.NotNil(t, again)
+	assert.Equal(t, comment.Endpoints, again.GetResource("Comment").Endpoints)
+	assert.Len(t, input.Resources[0].Endpoints[0].Request.PathParams, 1, "Input should not be modified")
+}
+
+func TestApplyOverlay_SearchEndpointFilterObjects(t *testing.T) {
+	t.Run("should generate filter objects before search endpoints", func(t *testing.T) {
+		input := &Service{
+			Name:  "TestService",
+			Enums: []Enum{},
+			Objects: []Object{
+				{
+					Name:        "School",
+					Description: "School object",
+					Fields: []Field{
+						{
+							Name:        "Name",
+							Type:        FieldTypeString,
+							Description: "School name",
+						},
+						{
+							Name:        "Location",
+							Type:        FieldTypeString,
+							Description: "School location",
+						},
+					},
+				},
+			},
+			Resources: []Resource{
+				{
+					Name:        "School",
+					Description: "School management",
+					Operations:  []string{OperationGet, OperationList, OperationSearch}, // Read operation should trigger search endpoint generation
+					Fields:      []ResourceField{},
+					Endpoints:   []Endpoint{},
+				},
+			},
+		}
+
+		result := ApplyOverlay(input)
+		require.NotNil(t, result)
+
+		// Verify that filter objects were generated
+		schoolFilterExists := false
+		for _, obj := range result.Objects {
 			if obj.Name == "SchoolFilter" {
 				schoolFilterExists = true
 				break
@@ -3023,6 +4224,294 @@ func TestApplyOverlay_SearchEndpointFilterObjects(t *testing.T) {
 
 		// Verify that the service recognizes SchoolFilter as an existing object
 		assert.True(t, result.HasObject("SchoolFilter"), "Service should recognize SchoolFilter as existing object")
+
+		// Verify that the filter can be negated
+		notCondition := result.GetObject("SchoolFilter").GetField("NotCondition")
+		require.NotNil(t, notCondition, "SchoolFilter should have the NotCondition flag")
+		assert.Equal(t, FieldTypeBool, notCondition.Type)
+
+		// Verify that the prefix filters use the string fields of the LIKE filter
+		schoolFilter := result.GetObject("SchoolFilter")
+		for _, name := range []string{"StartsWith", "NotStartsWith"} {
+			field := schoolFilter.GetField(name)
+			require.NotNil(t, field, "SchoolFilter should have the %s filter", name)
+			assert.Equal(t, "SchoolFilterLike", field.Type)
+			assert.True(t, field.IsNullable(), "%s filter should be nullable", name)
+		}
+	})
+}
+
+func TestApplyOverlay_Aggregations(t *testing.T) {
+	input := &Service{
+		Name:  "TestService",
+		Enums: []Enum{{Name: "OrderStatus", Values: []EnumValue{{Name: "Open"}, {Name: "Closed"}}}},
+		Resources: []Resource{
+			{
+				Name:         "Orders",
+				Operations:   []string{OperationList},
+				SoftDelete:   true,
+				Aggregations: &Aggregations{CountBy: []string{"Status"}, Sum: []string{"Total", "Quantity"}},
+				Fields: []ResourceField{
+					{Field: Field{Name: "Status", Type: "OrderStatus", Modifiers: []string{ModifierNullable}}, Operations: []string{OperationRead}},
+					{Field: Field{Name: "Total", Type: FieldTypeDecimal}, Operations: []string{OperationRead}},
+					{Field: Field{Name: "Quantity", Type: FieldTypeInt32}, Operations: []string{OperationRead}},
+				},
+			},
+		},
+	}
+
+	result := ApplyOverlay(input)
+	require.NotNil(t, result)
+
+	t.Run("aggregate endpoint", func(t *testing.T) {
+		resource := result.GetResource("Orders")
+		require.True(t, resource.HasEndpoint("Aggregate"), "Should generate the Aggregate endpoint")
+
+		var aggregate Endpoint
+		for _, endpoint := range resource.Endpoints {
+			if endpoint.Name == "Aggregate" {
+				aggregate = endpoint
+			}
+		}
+		assert.Equal(t, "GET", aggregate.Method)
+		assert.Equal(t, "/_aggregate", aggregate.Path)
+		require.Len(t, aggregate.Request.QueryParams, 1, "Aggregate should take the query parameters of List without pagination")
+		assert.Equal(t, "IncludeDeleted", aggregate.Request.QueryParams[0].Name)
+		require.NotNil(t, aggregate.Response.BodyObject)
+		assert.Equal(t, "OrdersAggregation", *aggregate.Response.BodyObject)
+	})
+
+	t.Run("aggregation objects", func(t *testing.T) {
+		aggregation := result.GetObject("OrdersAggregation")
+		require.NotNil(t, aggregation, "Should generate the object of the response")
+
+		names := make([]string, len(aggregation.Fields))
+		for i, field := range aggregation.Fields {
+			names[i] = field.Name
+		}
+		assert.Equal(t, []string{"Count", "SumTotal", "SumQuantity", "CountByStatus"}, names)
+		assert.Equal(t, FieldTypeDecimal, aggregation.GetField("SumTotal").Type, "Sums should keep the type of the field")
+		assert.Equal(t, FieldTypeInt, aggregation.GetField("SumQuantity").Type, "Sums of Int32 should not overflow")
+		assert.Equal(t, "OrdersStatusCount", aggregation.GetField("CountByStatus").Type)
+		assert.True(t, aggregation.GetField("CountByStatus").IsArray())
+
+		group := result.GetObject("OrdersStatusCount")
+		require.NotNil(t, group, "Should generate the object of the counts per value")
+		assert.Equal(t, "OrderStatus", group.GetField("Value").Type)
+		assert.True(t, group.GetField("Value").IsNullable(), "Values of nullable fields should be nullable")
+		assert.Equal(t, FieldTypeInt, group.GetField("Count").Type)
+	})
+
+	t.Run("idempotent", func(t *testing.T) {
+		again := ApplyOverlay(result)
+		require.NotNil(t, again)
+
+		assert.Len(t, again.Objects, len(result.Objects))
+		assert.Len(t, again.GetResource("Orders").Endpoints, len(result.GetResource("Orders").Endpoints))
+	})
+}
+
+func TestApplyOverlay_AsyncEndpoints(t *testing.T) {
+	input := &Service{
+		Name: "TestService",
+		Resources: []Resource{
+			{
+				Name:       "Reports",
+				Operations: []string{OperationGet},
+				Fields:     []ResourceField{{Field: Field{Name: "Title", Type: FieldTypeString}, Operations: []string{OperationRead}}},
+				Endpoints: []Endpoint{
+					{Name: "Generate", Method: "POST", Path: "/_generate", Async: true},
+				},
+			},
+		},
+	}
+
+	result := ApplyOverlay(input)
+	require.NotNil(t, result)
+
+	t.Run("async endpoint", func(t *testing.T) {
+		generate := result.GetResource("Reports").Endpoints[0]
+		assert.Equal(t, 202, generate.Response.StatusCode, "Async endpoints should respond with 202 Accepted")
+		require.NotNil(t, generate.Response.BodyObject)
+		assert.Equal(t, OperationObjectName, *generate.Response.BodyObject)
+		assert.Nil(t, input.Resources[0].Endpoints[0].Response.BodyObject, "Should not modify the input")
+	})
+
+	t.Run("operation object", func(t *testing.T) {
+		require.True(t, result.HasEnum("OperationStatus"))
+		operation := result.GetObject(OperationObjectName)
+		require.NotNil(t, operation, "Should add the Operation object")
+
+		names := make([]string, len(operation.Fields))
+		for i, field := range operation.Fields {
+			names[i] = field.Name
+		}
+		assert.Equal(t, []string{"ID", "Status", "RetryAfter", "Error", "CreatedAt", "CompletedAt"}, names)
+		assert.True(t, operation.GetField(OperationRetryAfterFieldName).IsNullable())
+		assert.True(t, operation.GetField("Error").IsNullable())
+	})
+
+	t.Run("operation resource", func(t *testing.T) {
+		resource := result.GetResource("Operation")
+		require.NotNil(t, resource, "Should add the resource polling the operations")
+		require.Len(t, resource.Endpoints, 1)
+
+		poll := resource.Endpoints[0]
+		assert.True(t, result.IsOperationPoll(*resource, poll))
+		assert.Equal(t, "GET", poll.Method)
+		assert.Equal(t, "/operation/{id}", result.GetEndpointPath(*resource, poll))
+		require.Len(t, poll.Request.PathParams, 1)
+		assert.Equal(t, "ID", poll.Request.PathParams[0].Name)
+		require.NotNil(t, poll.Response.BodyObject)
+		assert.Equal(t, OperationObjectName, *poll.Response.BodyObject)
+		assert.False(t, result.IsOperationPoll(*result.GetResource("Reports"), poll))
+	})
+
+	t.Run("idempotent", func(t *testing.T) {
+		again := ApplyOverlay(result)
+		require.NotNil(t, again)
+
+		assert.Len(t, again.Enums, len(result.Enums))
+		assert.Len(t, again.Objects, len(result.Objects))
+		assert.Len(t, again.Resources, len(result.Resources))
+	})
+
+	t.Run("services without async endpoints", func(t *testing.T) {
+		result := ApplyOverlay(&Service{Name: "TestService", Resources: []Resource{{Name: "Reports", Operations: []string{OperationGet}}}})
+		require.NotNil(t, result)
+
+		assert.Nil(t, result.GetResource("Operation"))
+		assert.False(t, result.HasObject(OperationObjectName))
+	})
+}
+
+func TestApplyOverlay_SearchableText(t *testing.T) {
+	createInput := func(searchable bool) *Service {
+		return &Service{
+			Name: "TestService",
+			Resources: []Resource{
+				{
+					Name:       "Users",
+					Operations: []string{OperationSearch},
+					Fields: []ResourceField{
+						{Field: Field{Name: "Name", Type: FieldTypeString}, Operations: []string{OperationRead}, SearchableText: searchable},
+						{Field: Field{Name: "Email", Type: FieldTypeString}, Operations: []string{OperationRead}},
+						{Field: Field{Name: "Bio", Type: FieldTypeString}, Operations: []string{OperationRead}, SearchableText: searchable},
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("searchable fields", func(t *testing.T) {
+		result := ApplyOverlay(createInput(true))
+		require.NotNil(t, result)
+
+		bodyParams := result.Resources[0].Endpoints[0].Request.BodyParams
+		require.Len(t, bodyParams, 2, "Search should have the Filter and Query body parameters")
+		query := bodyParams[1]
+		assert.Equal(t, "Query", query.Name)
+		assert.Equal(t, FieldTypeString, query.Type)
+		assert.False(t, query.IsRequired(result), "Query should be optional")
+		assert.Equal(t, "Text to search for in the Users, matching the fields name, bio", query.Description, "Should list the searchable fields")
+	})
+
+	t.Run("no searchable fields", func(t *testing.T) {
+		result := ApplyOverlay(createInput(false))
+		require.NotNil(t, result)
+
+		bodyParams := result.Resources[0].Endpoints[0].Request.BodyParams
+		require.Len(t, bodyParams, 1)
+		assert.Equal(t, "Filter", bodyParams[0].Name)
+	})
+}
+
+func TestApplyOverlay_FilterMaxDepth(t *testing.T) {
+	createInput := func(maxDepth int) *Service {
+		return &Service{
+			Name:           "TestService",
+			FilterMaxDepth: maxDepth,
+			Resources: []Resource{
+				{
+					Name:       "Users",
+					Operations: []string{OperationSearch},
+					Fields: []ResourceField{
+						{Field: Field{Name: "Name", Type: FieldTypeString}, Operations: []string{OperationRead}},
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("limited depth", func(t *testing.T) {
+		result := ApplyOverlay(createInput(3))
+		require.NotNil(t, result)
+
+		assert.Equal(t, 3, result.FilterMaxDepth, "Should copy the max depth")
+		assert.True(t, result.HasFilterMaxDepth())
+		nestedFilters := result.GetObject("UsersFilter").GetField("NestedFilters")
+		require.NotNil(t, nestedFilters)
+		assert.True(t, strings.HasSuffix(nestedFilters.Description, ", nested at most 3 levels deep"), "Should document the max depth")
+
+		search := result.GetResource("Users").Endpoints[0]
+		filter := search.GetFilterParam(*result.GetResource("Users"))
+		require.NotNil(t, filter, "Search should have the Filter body parameter")
+		assert.Equal(t, "UsersFilter", filter.Type)
+	})
+
+	t.Run("unlimited depth", func(t *testing.T) {
+		result := ApplyOverlay(createInput(0))
+		require.NotNil(t, result)
+
+		assert.False(t, result.HasFilterMaxDepth())
+		assert.NotContains(t, result.GetObject("UsersFilter").GetField("NestedFilters").Description, "at most")
+	})
+}
+
+func TestApplyOverlay_MaxLimit(t *testing.T) {
+	createInput := func(maxLimit int) *Service {
+		return &Service{
+			Name:     "TestService",
+			MaxLimit: maxLimit,
+			Resources: []Resource{
+				{
+					Name:       "Users",
+					Operations: []string{OperationList, OperationSearch},
+					Fields: []ResourceField{
+						{Field: Field{Name: "Name", Type: FieldTypeString}, Operations: []string{OperationRead}},
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("limited", func(t *testing.T) {
+		result := ApplyOverlay(createInput(100))
+		require.NotNil(t, result)
+
+		assert.Equal(t, 100, result.MaxLimit, "Should copy the max limit")
+		assert.True(t, result.HasPaginatedEndpoints())
+		for _, endpoint := range result.GetResource("Users").Endpoints {
+			limit := endpoint.Request.QueryParams[0]
+			assert.Equal(t, listLimitParamName, limit.Name)
+			assert.True(t, strings.HasSuffix(limit.Description, ", at most 100"), "Should document the max limit of %s", endpoint.Name)
+		}
+	})
+
+	t.Run("unlimited", func(t *testing.T) {
+		result := ApplyOverlay(createInput(0))
+		require.NotNil(t, result)
+
+		for _, endpoint := range result.GetResource("Users").Endpoints {
+			assert.NotContains(t, endpoint.Request.QueryParams[0].Description, "at most")
+		}
+	})
+
+	t.Run("without paginated endpoints", func(t *testing.T) {
+		input := createInput(100)
+		input.Resources[0].Operations = []string{OperationRead}
+
+		assert.False(t, ApplyOverlay(input).HasPaginatedEndpoints())
 	})
 }
 
@@ -3251,7 +4740,7 @@ func TestApplyFilterOverlay_MetaObjectFilters(t *testing.T) {
 
 		// Verify all Meta filter objects are generated with correct field counts
 		expectedMetaFilters := map[string]int{
-			"MetaFilter":         14, // Main filter object with all filter type references
+			"MetaFilter":         17, // Main filter object with all filter type references
 			"MetaFilterEquals":   4,  // All fields: CreatedAt, CreatedBy, UpdatedAt, UpdatedBy
 			"MetaFilterRange":    2,  // Only Timestamp fields: CreatedAt, UpdatedAt
 			"MetaFilterContains": 2,  // Only UUID fields: CreatedBy, UpdatedBy (Timestamp excluded)
@@ -3575,20 +5064,256 @@ resources:
 	t.Run("JSON extension parses correctly", func(t *testing.T) {
 		jsonData := `{"name": "TestService", "resources": [{"name": "User", "operations": ["Get"], "fields": []}]}`
 
-		service, err := ParseServiceFromBytes([]byte(jsonData), ".json")
-		assert.NoError(t, err, "Should parse JSON bytes successfully")
-		assert.NotNil(t, service, "Service should not be nil")
-		assert.Equal(t, "TestService", service.Name, "Service name should match")
+		service, err := ParseServiceFromBytes([]byte(jsonData), ".json")
+		assert.NoError(t, err, "Should parse JSON bytes successfully")
+		assert.NotNil(t, service, "Service should not be nil")
+		assert.Equal(t, "TestService", service.Name, "Service name should match")
+
+		// Verify overlays were applied
+		hasUserObject := false
+		for _, obj := range service.Objects {
+			if obj.Name == "User" {
+				hasUserObject = true
+				break
+			}
+		}
+		assert.True(t, hasUserObject, "Should have User object from overlay")
+	})
+}
+
+func TestParseServiceFromFile_DescriptionFiles(t *testing.T) {
+	writeFile := func(t *testing.T, path, content string) {
+		t.Helper()
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	t.Run("descriptions are loaded relative to the specification file", func(t *testing.T) {
+		// Arrange
+		dir := t.TempDir()
+		writeFile(t, filepath.Join(dir, "docs", "users.md"), "# Users\n\nPeople with an account.\n\nThey can sign in.\n")
+		writeFile(t, filepath.Join(dir, "docs", "invite.md"), "Sends an invitation by email.\n")
+		writeFile(t, filepath.Join(dir, "docs", "address.md"), "A postal address.")
+		writeFile(t, filepath.Join(dir, "docs", "role.md"), "Role of a user.")
+		specPath := filepath.Join(dir, "api", "service.yaml")
+		writeFile(t, specPath, `
+name: "TestService"
+enums:
+  - name: "Role"
+    description_file: "../docs/role.md"
+    values:
+      - name: "Admin"
+        description: "Administrator"
+objects:
+  - name: "Address"
+    description_file: "../docs/address.md"
+    fields:
+      - name: "Street"
+        description: "Street"
+        type: "String"
+resources:
+  - name: "User"
+    description_file: "../docs/users.md"
+    operations: ["Get"]
+    fields: []
+    endpoints:
+      - name: "Invite"
+        summary: "Invite a user"
+        description_file: "../docs/invite.md"
+        method: "POST"
+        path: "/invite"
+        response:
+          status_code: 204
+`)
+
+		// Act
+		service, err := ParseServiceFromFile(specPath)
+
+		// Assert
+		assert.NoError(t, err, "Should parse the specification with description files")
+		resource := service.GetResource("User")
+		assert.Equal(t, "# Users\n\nPeople with an account.\n\nThey can sign in.", resource.Description, "Resource description should be loaded from the file")
+		assert.Empty(t, resource.DescriptionFile, "Loaded description files should be cleared")
+		invite := slices.IndexFunc(resource.Endpoints, func(endpoint Endpoint) bool { return endpoint.Name == "Invite" })
+		assert.Equal(t, "Sends an invitation by email.", resource.Endpoints[invite].Description)
+		assert.Equal(t, "A postal address.", service.GetObject("Address").Description)
+		assert.Equal(t, "Role of a user.", service.GetEnum("Role").Description)
+		assert.Equal(t, resource.Description, service.GetObject("User").Description, "Overlay objects should get the loaded description")
+	})
+
+	t.Run("missing description file fails parsing", func(t *testing.T) {
+		// Arrange
+		specPath := filepath.Join(t.TempDir(), "service.yaml")
+		writeFile(t, specPath, `
+name: "TestService"
+resources:
+  - name: "User"
+    description_file: "users.md"
+    operations: ["Get"]
+    fields: []
+`)
+
+		// Act
+		_, err := ParseServiceFromFile(specPath)
+
+		// Assert
+		assert.Error(t, err, "Should fail when the description file does not exist")
+		assert.Contains(t, err.Error(), "description_file of resource 'User'")
+	})
+
+	t.Run("description and description file cannot both be set", func(t *testing.T) {
+		// Act
+		_, err := ParseServiceFromYAML([]byte(`
+name: "TestService"
+resources:
+  - name: "User"
+    description: "Users"
+    description_file: "users.md"
+    operations: ["Get"]
+    fields: []
+`))
+
+		// Assert
+		assert.Error(t, err, "Should fail when both the description and the description file are set")
+		assert.Contains(t, err.Error(), errorInvalidDescFile)
+	})
+}
+
+func TestParseServiceFromFile_SharedLibraries(t *testing.T) {
+	writeFile := func(t *testing.T, path, content string) {
+		t.Helper()
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	library := `
+name: "Common"
+enums:
+  - name: "Country"
+    description: "Country of an address"
+    values:
+      - name: "SE"
+        description: "Sweden"
+objects:
+  - name: "Address"
+    description_file: "address.md"
+    fields:
+      - name: "Street"
+        description: "Street"
+        type: "String"
+      - name: "Country"
+        description: "Country"
+        type: "Country"
+`
+
+	t.Run("types of the shared libraries are merged into the service", func(t *testing.T) {
+		// Arrange
+		dir := t.TempDir()
+		writeFile(t, filepath.Join(dir, "shared", "common.yaml"), library)
+		writeFile(t, filepath.Join(dir, "shared", "address.md"), "A postal address.")
+		specPath := filepath.Join(dir, "api", "service.yaml")
+		writeFile(t, specPath, `
+name: "TestService"
+shared: ["../shared/common.yaml"]
+resources:
+  - name: "User"
+    description: "Users"
+    operations: ["Get"]
+    fields:
+      - name: "Address"
+        description: "Address of the user"
+        type: "Address"
+        operations: ["Read"]
+`)
+
+		// Act
+		service, err := ParseServiceFromFile(specPath)
+
+		// Assert
+		assert.NoError(t, err, "Should parse the specification with a shared library")
+		assert.Empty(t, service.Shared, "Merged shared libraries should be cleared")
+		assert.Equal(t, "Common", service.GetEnum("Country").Library)
+		assert.Equal(t, "Common", service.GetObject("Address").Library)
+		assert.Empty(t, service.GetObject("User").Library, "Objects of the service should not belong to a library")
+		assert.Equal(t, "A postal address.", service.GetObject("Address").Description, "Descriptions should be loaded relative to the library")
+		assert.Equal(t, []string{"Common"}, service.GetSharedLibraries())
+	})
+
+	t.Run("type defined by both the service and a library fails parsing", func(t *testing.T) {
+		// Arrange
+		dir := t.TempDir()
+		writeFile(t, filepath.Join(dir, "common.yaml"), strings.Replace(library, `description_file: "address.md"`, `description: "Address"`, 1))
+		specPath := filepath.Join(dir, "service.yaml")
+		writeFile(t, specPath, `
+name: "TestService"
+shared: ["common.yaml"]
+enums:
+  - name: "Country"
+    description: "Country"
+    values:
+      - name: "NO"
+        description: "Norway"
+`)
+
+		// Act
+		_, err := ParseServiceFromFile(specPath)
+
+		// Assert
+		assert.Error(t, err, "Should fail when a library type is also defined by the service")
+		assert.Contains(t, err.Error(), "$.shared")
+		assert.Contains(t, err.Error(), "Country")
+	})
+
+	t.Run("library with reserved names fails parsing", func(t *testing.T) {
+		// Arrange
+		dir := t.TempDir()
+		writeFile(t, filepath.Join(dir, "common.yaml"), `
+name: "Common"
+objects:
+  - name: "Pagination"
+    description: "Pagination"
+    fields:
+      - name: "Offset"
+        description: "Offset"
+        type: "Int"
+`)
+		specPath := filepath.Join(dir, "service.yaml")
+		writeFile(t, specPath, `
+name: "TestService"
+shared: ["common.yaml"]
+`)
+
+		// Act
+		_, err := ParseServiceFromFile(specPath)
+
+		// Assert
+		assert.Error(t, err, "Should fail when the library declares a reserved name")
+		assert.Contains(t, err.Error(), "the name 'Pagination' is reserved")
+	})
+
+	t.Run("library with resources fails parsing", func(t *testing.T) {
+		// Arrange
+		dir := t.TempDir()
+		writeFile(t, filepath.Join(dir, "common.yaml"), `
+name: "Common"
+resources:
+  - name: "User"
+    description: "Users"
+    operations: ["Get"]
+    fields: []
+`)
+		specPath := filepath.Join(dir, "service.yaml")
+		writeFile(t, specPath, `
+name: "TestService"
+shared: ["common.yaml"]
+`)
+
+		// Act
+		_, err := ParseServiceFromFile(specPath)
 
-		// Verify overlays were applied
-		hasUserObject := false
-		for _, obj := range service.Objects {
-			if obj.Name == "User" {
-				hasUserObject = true
-				break
-			}
-		}
-		assert.True(t, hasUserObject, "Should have User object from overlay")
+		// Assert
+		assert.Error(t, err, "Should fail when the library declares resources")
+		assert.Contains(t, err.Error(), "a shared library can only declare enums and objects")
 	})
 }
 
@@ -3979,6 +5704,93 @@ objects:
 	})
 }
 
+// TestApplyOverlay_ExtendedObjects tests that the overlay flattens inherited fields into extending objects.
+func TestApplyOverlay_ExtendedObjects(t *testing.T) {
+	input := &Service{
+		Name: "TestService",
+		Objects: []Object{
+			{Name: "Student", Extends: "Person", Fields: []Field{{Name: "Grade", Type: FieldTypeInt}}},
+			{Name: "Person", Extends: "Audited", Fields: []Field{{Name: "Name", Type: FieldTypeString}}},
+			{Name: "Audited", Fields: []Field{{Name: "CreatedAt", Type: FieldTypeTimestamp}}},
+		},
+	}
+
+	t.Run("inherited fields are flattened before own fields", func(t *testing.T) {
+		// Act
+		result := ApplyOverlay(input)
+
+		// Assert
+		student := result.GetObject("Student")
+		assert.Equal(t, []string{"CreatedAt", "Name", "Grade"}, fieldNames(student.Fields))
+		assert.Equal(t, []string{"CreatedAt", "Name"}, fieldNames(result.GetObject("Person").Fields))
+		assert.Equal(t, []string{"Grade"}, fieldNames(student.GetOwnFields(result)), "Own fields should exclude inherited fields")
+		assert.Len(t, input.Objects[0].Fields, 1, "Input objects should not be modified")
+	})
+
+	t.Run("flattening is idempotent", func(t *testing.T) {
+		// Act
+		result := ApplyOverlay(ApplyOverlay(input))
+
+		// Assert
+		assert.Equal(t, []string{"CreatedAt", "Name", "Grade"}, fieldNames(result.GetObject("Student").Fields))
+	})
+}
+
+// fieldNames returns the names of the fields, used to compare field order.
+func fieldNames(fields []Field) []string {
+	names := make([]string, len(fields))
+	for i, field := range fields {
+		names[i] = field.Name
+	}
+	return names
+}
+
+// TestApplyOverlay_ParameterGroups tests that the overlay expands parameter groups into query parameters.
+func TestApplyOverlay_ParameterGroups(t *testing.T) {
+	input := &Service{
+		Name: "TestService",
+		ParameterGroups: []ParameterGroup{
+			{Name: "Include", Fields: []Field{{Name: "IncludeArchived", Type: FieldTypeBool}}},
+		},
+		Resources: []Resource{
+			{
+				Name: "Student",
+				Endpoints: []Endpoint{
+					{
+						Name:   "Export",
+						Method: "GET",
+						Path:   "/export",
+						Request: EndpointRequest{
+							QueryParams:     []Field{{Name: "Format", Type: FieldTypeString}},
+							ParameterGroups: []string{"Include"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	t.Run("group fields are appended to query params", func(t *testing.T) {
+		// Act
+		result := ApplyOverlay(input)
+
+		// Assert
+		endpoint := result.Resources[0].Endpoints[0]
+		assert.Equal(t, []string{"Format", "IncludeArchived"}, fieldNames(endpoint.Request.QueryParams))
+		assert.Equal(t, []string{"Format"}, fieldNames(endpoint.GetOwnQueryParams(result)), "Own query params should exclude group fields")
+		assert.Equal(t, "Include", endpoint.GetParameterGroupName(result, "IncludeArchived"))
+		assert.Len(t, input.Resources[0].Endpoints[0].Request.QueryParams, 1, "Input endpoints should not be modified")
+	})
+
+	t.Run("expansion is idempotent", func(t *testing.T) {
+		// Act
+		result := ApplyOverlay(ApplyOverlay(input))
+
+		// Assert
+		assert.Equal(t, []string{"Format", "IncludeArchived"}, fieldNames(result.Resources[0].Endpoints[0].Request.QueryParams))
+	})
+}
+
 // TestApplyOverlay_DevelopmentFlagPropagation tests that the Development flag is propagated
 // from Resource to its auto-generated Object in generateObjectsFromResources.
 func TestApplyOverlay_DevelopmentFlagPropagation(t *testing.T) {
@@ -4117,3 +5929,528 @@ func TestApplyOverlay_DevelopmentFlagPropagation(t *testing.T) {
 		assert.True(t, gradeObj.Development, "GradeElementary object should have Development=true")
 	})
 }
+
+// ============================================================================
+// Canonical Serialization Tests
+// ============================================================================
+
+func TestMarshalServiceYAML(t *testing.T) {
+	// Arrange
+	service := ApplyOverlay(&Service{
+		Name:    "Canonical API",
+		Version: "1.0.0",
+		Enums: []Enum{
+			{Name: "Status", Values: []EnumValue{{Name: "Inactive"}, {Name: "Active"}}},
+			{Name: "Level", Values: []EnumValue{{Name: "High"}}},
+		},
+		Objects: []Object{
+			{Name: "Zone", Fields: []Field{{Name: "Code", Type: FieldTypeString}}},
+			{Name: "Address", Fields: []Field{{Name: "City", Type: FieldTypeString}}},
+		},
+		Resources: []Resource{
+			{Name: "Users", Operations: []string{OperationGet}},
+			{Name: "Groups", Operations: []string{OperationGet}},
+		},
+	})
+
+	// Act
+	data, err := MarshalServiceYAML(service)
+
+	// Assert
+	require.NoError(t, err)
+	output := string(data)
+	assert.True(t, strings.HasPrefix(output, "name: Canonical API\nversion: 1.0.0\n"), "Name and version should come first")
+	assert.Less(t, strings.Index(output, "- name: Address"), strings.Index(output, "- name: Zone"), "Objects should be sorted by name")
+	assert.Less(t, strings.Index(output, "- name: Level"), strings.Index(output, "- name: Status"), "Enums should be sorted by name")
+	assert.Less(t, strings.Index(output, "- name: Inactive"), strings.Index(output, "- name: Active"), "Enum values should keep their order")
+	resources := output[strings.Index(output, "\nresources:"):]
+	assert.Less(t, strings.Index(resources, "- name: Users"), strings.Index(resources, "- name: Groups"), "Resources should keep their order")
+
+	t.Run("round trip is stable", func(t *testing.T) {
+		var parsed Service
+		require.NoError(t, yaml.Unmarshal(data, &parsed))
+
+		again, err := MarshalServiceYAML(&parsed)
+		require.NoError(t, err)
+		assert.Equal(t, output, string(again), "Marshaling a parsed canonical output should give the same output")
+	})
+
+	t.Run("JSON uses the same order", func(t *testing.T) {
+		jsonData, err := MarshalServiceJSON(service)
+		require.NoError(t, err)
+
+		var fromJSON, fromYAML Service
+		require.NoError(t, json.Unmarshal(jsonData, &fromJSON))
+		require.NoError(t, yaml.Unmarshal(data, &fromYAML))
+		assert.Equal(t, fromYAML, fromJSON, "JSON and YAML should describe the same service")
+		assert.True(t, strings.HasPrefix(string(jsonData), "{\n  \"name\": \"Canonical API\",\n  \"version\": \"1.0.0\","), "JSON should use the canonical order")
+	})
+}
+
+func TestCompareCanonicalKeys(t *testing.T) {
+	values := map[string]any{
+		"name": "", "description": "", "type": "", "default": "", "modifiers": []any{}, "fields": []any{},
+	}
+	keys := []string{"modifiers", "type", "fields", "description", "default", "name"}
+
+	slices.SortFunc(keys, func(a, b string) int {
+		return compareCanonicalKeys(a, b, values)
+	})
+
+	assert.Equal(t, []string{"name", "description", "default", "type", "fields", "modifiers"}, keys, "Leading keys, then scalar keys, then nested keys")
+}
+
+func TestFormatService(t *testing.T) {
+	t.Run("YAML", func(t *testing.T) {
+		// Arrange
+		input := `# School API specification
+
+version: 1.0.0
+name: School API
+objects:
+  # Second object
+  - name: Zone
+    fields:
+      - type: string
+        name: Code
+  - name: Address
+    fields:
+      - name: City
+        type: String
+enums:
+  - name: Status
+    values:
+      - name: Inactive
+      - name: Active
+resources:
+  - operations: [create, get]
+    name: Students
+    fields:
+      - field:
+          type: address # nested object
+          name: Address
+          modifiers: [nullable]
+        operations: [create, read]
+    endpoints:
+      - path: /{id}/archive
+        method: post
+        name: Archive
+`
+		expected := `# School API specification
+
+name: School API
+version: 1.0.0
+enums:
+  - name: Status
+    values:
+      - name: Inactive
+      - name: Active
+objects:
+  - name: Address
+    fields:
+      - name: City
+        type: String
+  # Second object
+  - name: Zone
+    fields:
+      - name: Code
+        type: String
+resources:
+  - name: Students
+    endpoints:
+      - name: Archive
+        method: POST
+        path: /{id}/archive
+    fields:
+      - field:
+          name: Address
+          type: Address # nested object
+          modifiers: [Nullable]
+        operations: [Create, Read]
+    operations: [Create, Get]
+`
+
+		// Act
+		formatted, err := FormatService([]byte(input), extYAML)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, expected, string(formatted))
+
+		again, err := FormatService(formatted, extYAML)
+		require.NoError(t, err)
+		assert.Equal(t, string(formatted), string(again), "Formatting should be idempotent")
+	})
+
+	t.Run("YAML header comment", func(t *testing.T) {
+		testCases := []struct {
+			name     string
+			input    string
+			expected string
+		}{
+			{
+				name:     "before name",
+				input:    "# School API specification\n# Maintained by the platform team\nname: School API\nversion: 1.0.0\n",
+				expected: "# School API specification\n# Maintained by the platform team\nname: School API\nversion: 1.0.0\n",
+			},
+			{
+				name:     "before a key sorted after name",
+				input:    "# School API specification\nversion: 1.0.0\nname: School API\n",
+				expected: "# School API specification\nname: School API\nversion: 1.0.0\n",
+			},
+			{
+				name:     "with empty lines",
+				input:    "# School API specification\n\n# The version of the API\nversion: 1.0.0\nname: School API\n",
+				expected: "# School API specification\n\n# The version of the API\nname: School API\nversion: 1.0.0\n",
+			},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				// Act
+				formatted, err := FormatService([]byte(tc.input), extYAML)
+
+				// Assert
+				require.NoError(t, err)
+				assert.Equal(t, tc.expected, string(formatted), "The header comment should stay at the top of the file")
+			})
+		}
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		input := `{"version": "1.0.0", "name": "School API", "resources": [{"operations": ["get"], "name": "Students"}]}`
+		expected := `{
+  "name": "School API",
+  "version": "1.0.0",
+  "resources": [
+    {
+      "name": "Students",
+      "operations": [
+        "Get"
+      ]
+    }
+  ]
+}
+`
+
+		formatted, err := FormatService([]byte(input), extJSON)
+
+		require.NoError(t, err)
+		assert.Equal(t, expected, string(formatted))
+	})
+
+	t.Run("invalid YAML", func(t *testing.T) {
+		_, err := FormatService([]byte("name: [unclosed"), extYAML)
+
+		assert.ErrorContains(t, err, errorFileParse)
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		_, err := FormatService([]byte("name: Test"), ".txt")
+
+		assert.ErrorContains(t, err, errorUnsupportedFormat)
+	})
+}
+
+func TestMigrateService(t *testing.T) {
+	t.Run("YAML without schema version", func(t *testing.T) {
+		// Arrange
+		input := `# School API specification
+
+version: 1.0.0
+name: School API
+# Enums of the API
+enums:
+  - name: Status
+    values:
+      - name: Active
+`
+		expected := `# School API specification
+
+version: 1.0.0
+name: School API
+schema_version: 1
+# Enums of the API
+enums:
+  - name: Status
+    values:
+      - name: Active
+`
+
+		// Act
+		migrated, err := MigrateService([]byte(input), extYAML)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, expected, string(migrated))
+
+		again, err := MigrateService(migrated, extYAML)
+		require.NoError(t, err)
+		assert.Equal(t, string(migrated), string(again), "Migrating a migrated specification should not change it")
+
+		service, err := ParseServiceFromYAML(migrated)
+		require.NoError(t, err)
+		assert.Equal(t, CurrentSchemaVersion, service.GetSchemaVersion())
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		input := `{"name": "School API", "enums": []}`
+		expected := `{
+  "name": "School API",
+  "schema_version": 1,
+  "enums": []
+}
+`
+
+		migrated, err := MigrateService([]byte(input), extJSON)
+
+		require.NoError(t, err)
+		assert.Equal(t, expected, string(migrated))
+	})
+
+	t.Run("newer schema version", func(t *testing.T) {
+		_, err := MigrateService([]byte("name: Test\nschema_version: 99\n"), extYAML)
+
+		assert.ErrorContains(t, err, errorInvalidSchema)
+	})
+
+	t.Run("schema version is not an integer", func(t *testing.T) {
+		_, err := MigrateService([]byte("name: Test\nschema_version: one\n"), extYAML)
+
+		assert.ErrorContains(t, err, errorInvalidSchema)
+	})
+
+	t.Run("not a mapping", func(t *testing.T) {
+		_, err := MigrateService([]byte("- name: Test\n"), extYAML)
+
+		assert.ErrorContains(t, err, errorInvalidFile)
+	})
+}
+
+func TestMigrateService_Migrations(t *testing.T) {
+	// The migration to version 2 renames the title of the service to name
+	renameTitle := func(root *ast.MappingNode) error {
+		title := findMappingValue(root, "title")
+		if title == nil {
+			return nil
+		}
+		key, ok := title.Key.(*ast.StringNode)
+		if !ok {
+			return fmt.Errorf("title must be a string key")
+		}
+		key.Value = "name"
+		key.Token.Value = key.Value
+		key.Token.Origin = key.Value
+		return nil
+	}
+	migrations := map[int]schemaMigration{1: renameTitle}
+
+	t.Run("applies the migrations without schema version", func(t *testing.T) {
+		// Arrange
+		input := "# School API specification\n\ntitle: School API\nversion: 1.0.0\n"
+		expected := "# School API specification\n\nname: School API\nversion: 1.0.0\nschema_version: 2\n"
+
+		// Act
+		migrated, err := migrateService([]byte(input), extYAML, migrations, 2)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, expected, string(migrated))
+	})
+
+	t.Run("applies the migrations from the schema version", func(t *testing.T) {
+		migrated, err := migrateService([]byte("schema_version: 1\ntitle: School API\n"), extYAML, migrations, 2)
+
+		require.NoError(t, err)
+		assert.Equal(t, "schema_version: 2\nname: School API\n", string(migrated))
+	})
+
+	t.Run("skips a specification at the current version", func(t *testing.T) {
+		input := "title: School API\nschema_version: 2\n"
+
+		migrated, err := migrateService([]byte(input), extYAML, migrations, 2)
+
+		require.NoError(t, err)
+		assert.Equal(t, input, string(migrated), "The migrations from older versions should not be applied")
+	})
+
+	t.Run("rejects unknown versions", func(t *testing.T) {
+		_, err := migrateService([]byte("name: Test\nschema_version: 3\n"), extYAML, migrations, 2)
+		assert.ErrorContains(t, err, "schema_version 3 is newer than the supported version 2")
+
+		_, err = migrateService([]byte("name: Test\nschema_version: 0\n"), extYAML, migrations, 2)
+		assert.ErrorContains(t, err, "schema_version must be at least 1, got 0")
+	})
+
+	t.Run("failing migration", func(t *testing.T) {
+		failing := map[int]schemaMigration{1: func(root *ast.MappingNode) error { return fmt.Errorf("unsupported field") }}
+
+		_, err := migrateService([]byte("name: Test\n"), extYAML, failing, 2)
+
+		assert.ErrorContains(t, err, "failed to migrate from schema_version 1: unsupported field")
+	})
+}
+
+func TestService_GetBasePath(t *testing.T) {
+	assert.Equal(t, "/users-api/v1", Service{Name: "UsersAPI", Version: "v1"}.GetBasePath(), "Unset base path should default to the service name and version")
+	assert.Equal(t, "/api/v1", Service{Name: "UsersAPI", Version: "v1", BasePath: "/api/v1"}.GetBasePath())
+	assert.Equal(t, "", Service{Name: "UsersAPI", Version: "v1", BasePath: "/"}.GetBasePath(), "Root base path should not prefix the routes")
+	assert.Equal(t, "/api/v1", ApplyOverlay(&Service{Name: "UsersAPI", BasePath: "/api/v1"}).BasePath, "Overlay should keep the base path")
+}
+
+func TestService_GetEndpointPathWithNaming(t *testing.T) {
+	resources := []Resource{
+		{Name: "SchoolClass", Operations: []string{OperationGet}},
+		{Name: "Pupil", Parent: "SchoolClass", Operations: []string{OperationGet}},
+	}
+	endpoint := Endpoint{Name: "Get", Path: "/{id}"}
+
+	tests := []struct {
+		name             string
+		naming           *Naming
+		expectedPath     string
+		expectedBasePath string
+	}{
+		{name: "default", naming: nil, expectedPath: "/school-class/{schoolClassID}/pupil/{id}", expectedBasePath: "/school-api/v1"},
+		{name: "snake case", naming: &Naming{PathCase: PathCaseSnake}, expectedPath: "/school_class/{schoolClassID}/pupil/{id}", expectedBasePath: "/school_api/v1"},
+		{name: "plural paths", naming: &Naming{PluralPaths: true}, expectedPath: "/school-classes/{schoolClassID}/pupils/{id}", expectedBasePath: "/school-api/v1"},
+		{name: "snake case and plural paths", naming: &Naming{PathCase: PathCaseSnake, PluralPaths: true}, expectedPath: "/school_classes/{schoolClassID}/pupils/{id}", expectedBasePath: "/school_api/v1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := ApplyOverlay(&Service{Name: "SchoolAPI", Version: "v1", Naming: tt.naming, Resources: resources})
+			require.NotNil(t, service)
+
+			assert.Equal(t, tt.naming, service.Naming, "Overlay should keep the naming conventions")
+			assert.Equal(t, tt.expectedPath, service.GetEndpointPath(service.Resources[1], endpoint))
+			assert.Equal(t, tt.expectedBasePath, service.GetBasePath())
+		})
+	}
+}
+
+func TestNaming_FormatJSONName(t *testing.T) {
+	tests := []struct {
+		name     string
+		naming   *Naming
+		expected []string
+	}{
+		{name: "default", naming: nil, expected: []string{"firstName", "id", "userID"}},
+		{name: "camel case", naming: &Naming{JSON: JSONCaseCamel}, expected: []string{"firstName", "id", "userID"}},
+		{name: "snake case", naming: &Naming{JSON: JSONCaseSnake}, expected: []string{"first_name", "id", "user_id"}},
+		{name: "as-is", naming: &Naming{JSON: JSONCaseAsIs}, expected: []string{"FirstName", "ID", "UserID"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i, name := range []string{"FirstName", "ID", "UserID"} {
+				assert.Equal(t, tt.expected[i], tt.naming.FormatJSONName(name), "JSON name of '%s'", name)
+			}
+		})
+	}
+}
+
+func TestApplyDefaultOverlays_JSONNaming(t *testing.T) {
+	input := &Service{
+		Name:    "SchoolAPI",
+		Version: "v1",
+		Naming:  &Naming{JSON: JSONCaseSnake},
+		Objects: []Object{{Name: "Address", Fields: []Field{{Name: "StreetName", Type: FieldTypeString}, {Name: "ZipCode", JSONName: "zip", Type: FieldTypeString}}}},
+		Resources: []Resource{{
+			Name:       "Pupil",
+			Operations: []string{OperationCreate, OperationGet, OperationSearch},
+			Fields: []ResourceField{
+				{Field: Field{Name: "FirstName", Type: FieldTypeString}, Operations: []string{OperationCreate, OperationRead}},
+				{Field: Field{Name: "Address", Type: "Address"}, Operations: []string{OperationCreate, OperationRead}},
+			},
+		}},
+	}
+
+	service := ApplyDefaultOverlays(input)
+
+	pupil := service.GetObject("Pupil")
+	require.NotNil(t, pupil)
+	assert.Equal(t, "first_name", pupil.Fields[slices.IndexFunc(pupil.Fields, func(field Field) bool { return field.Name == "FirstName" })].TagJSON(), "Resource object fields should follow the naming")
+	assert.Equal(t, "first_name", service.Resources[0].Fields[0].TagJSON(), "Resource fields should follow the naming")
+
+	address := service.GetObject("Address")
+	require.NotNil(t, address)
+	assert.Equal(t, "street_name", address.Fields[0].TagJSON(), "Object fields should follow the naming")
+	assert.Equal(t, "zip", address.Fields[1].TagJSON(), "Explicit JSON names should be kept")
+
+	for _, endpoint := range service.Resources[0].Endpoints {
+		for _, field := range endpoint.Request.BodyParams {
+			assert.Equal(t, service.Naming.FormatJSONName(field.Name), field.TagJSON(), "Body parameter %s of %s should follow the naming", field.Name, endpoint.Name)
+		}
+		for _, field := range append(endpoint.Request.PathParams, endpoint.Request.QueryParams...) {
+			assert.Empty(t, field.JSONName, "Parameter %s of %s should keep its camelCase name", field.Name, endpoint.Name)
+		}
+	}
+
+	filter := service.GetObject("PupilFilterEquals")
+	require.NotNil(t, filter, "The filter objects should be generated")
+	for _, field := range filter.Fields {
+		assert.Equal(t, service.Naming.FormatJSONName(field.Name), field.TagJSON(), "Filter field %s should follow the naming", field.Name)
+	}
+
+	assert.Empty(t, input.Objects[0].Fields[0].JSONName, "The input should not be modified")
+	renamed := *service
+	applyJSONNaming(&renamed)
+	assert.Equal(t, service.Objects, renamed.Objects, "Naming should be idempotent")
+	assert.Equal(t, service.Resources, renamed.Resources, "Naming should be idempotent")
+}
+
+func TestService_IsStrict(t *testing.T) {
+	strict, lenient := true, false
+	objects := []Object{
+		{Name: "Address"},
+		{Name: "Contact", Strict: &strict},
+		{Name: "Metadata", Strict: &lenient},
+	}
+
+	t.Run("lenient service", func(t *testing.T) {
+		service := &Service{Objects: objects}
+
+		assert.False(t, service.IsStrict(objects[0]), "Object should follow the service by default")
+		assert.True(t, service.IsStrict(objects[1]), "Object should override the service")
+		assert.True(t, service.HasStrictObjects(), "Service should have a strict object")
+		assert.False(t, (&Service{Objects: objects[:1]}).HasStrictObjects())
+	})
+
+	t.Run("strict service", func(t *testing.T) {
+		service := ApplyOverlay(&Service{Name: "TestService", Strict: true, Objects: objects})
+		require.NotNil(t, service)
+
+		assert.True(t, service.Strict, "Overlay should keep the strict setting")
+		assert.True(t, service.IsStrict(objects[0]), "Object should follow the service by default")
+		assert.False(t, service.IsStrict(objects[2]), "Object should override the service")
+		assert.True(t, service.HasStrictObjects())
+	})
+}
+
+func TestService_GetSchemaVersion(t *testing.T) {
+	assert.Equal(t, 1, (&Service{}).GetSchemaVersion(), "Unset schema version should default to the initial version")
+	assert.Equal(t, CurrentSchemaVersion, (&Service{SchemaVersion: CurrentSchemaVersion}).GetSchemaVersion())
+}
+
+func TestServiceServer_GetURL(t *testing.T) {
+	server := ServiceServer{
+		URL: "https://{region}.api.example.com/{stage}",
+		Variables: []ServerVariable{
+			{Name: "region", Default: "eu", Enum: []string{"eu", "us"}},
+			{Name: "stage", Default: "live", Enum: []string{"live", "sandbox"}},
+		},
+	}
+
+	assert.Equal(t, "https://eu.api.example.com/live", server.GetDefaultURL(), "Variables should default to their defaults")
+	assert.Equal(t, "https://us.api.example.com/live", server.GetURL(map[string]string{"region": "us"}), "Given values should replace the defaults")
+	assert.Equal(t, "https://{tenant}.example.com", ServiceServer{URL: "https://{tenant}.example.com"}.GetDefaultURL(), "Undeclared placeholders should be kept")
+	assert.Equal(t, []string{
+		"https://eu.api.example.com/live",
+		"https://eu.api.example.com/sandbox",
+		"https://us.api.example.com/live",
+		"https://us.api.example.com/sandbox",
+	}, server.GetEnumeratedURLs(), "Every combination of the enum values should have a URL")
+	assert.Equal(t, []string{"https://api.example.com"}, ServiceServer{URL: "https://api.example.com"}.GetEnumeratedURLs())
+	assert.Nil(t, ServiceServer{URL: "https://{tenant}.example.com", Variables: []ServerVariable{{Name: "tenant", Default: "acme"}}}.GetEnumeratedURLs(), "Variables without an enum cannot be enumerated")
+}
@@ -0,0 +1,31 @@
+// Package terraformgen generates Terraform provider resource schemas from a specification.
+//
+// The schemas are written in the format of the Terraform provider code specification, which the Terraform
+// framework code generator turns into the Go schemas of a provider, so fields added to the specification
+// flow to the provider without editing it by hand:
+//   - A resource per specification resource that can be created and read back, named in snake_case
+//   - An attribute per field of the resource, with the closest Terraform type: strings for UUID, Date,
+//     Timestamp, Decimal and string enums, int64 for integers and integer enums, float64 and bool
+//   - Lists for arrays, and nested attributes for objects
+//   - Fields required on create are required, the other writable fields are optional, and the fields that are
+//     only read, such as the ID and the metadata, are computed. Optional fields with a default are computed too,
+//     since the API fills them in
+//   - The ID parameters of the parents of sub-resources are required attributes
+//
+// Development resources are left out, like in the OpenAPI document.
+//
+// # Usage
+//
+//	var buf bytes.Buffer
+//	if err := terraformgen.GenerateSchema(&buf, service); err != nil {
+//	    log.Fatal(err)
+//	}
+//
+// The provider is named after the service in snake_case and exposes every resource it can manage. Options
+// overrides them:
+//
+//	err := terraformgen.GenerateSchemaWithOptions(&buf, service, terraformgen.Options{
+//	    Provider:  "users",
+//	    Resources: []string{"User", "Team"},
+//	})
+package terraformgen
@@ -0,0 +1,352 @@
+package terraformgen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/meitner-se/publicapis-gen/specification"
+)
+
+// Error messages
+const (
+	errorInvalidService  = "invalid service"
+	errorInvalidResource = "invalid resource"
+	errorMarshalSchema   = "failed to marshal provider schema"
+)
+
+// Provider code specification constants
+const (
+	specificationVersion = "0.1"
+	jsonIndent           = "  "
+	nameSeparator        = "_"
+)
+
+// Values of computed_optional_required, which tell Terraform who sets the value of an attribute
+const (
+	modeRequired         = "required"
+	modeOptional         = "optional"
+	modeComputed         = "computed"
+	modeComputedOptional = "computed_optional"
+)
+
+// invalidNameCharacters matches the characters that are not allowed in the names of providers and attributes
+var invalidNameCharacters = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// snakeNaming formats the names of the specification in snake_case, like Terraform names its attributes
+var snakeNaming = &specification.Naming{PathCase: specification.PathCaseSnake}
+
+// Options configures the generated provider schema.
+type Options struct {
+	// Provider is the name of the Terraform provider, defaults to the service name in snake_case
+	Provider string
+
+	// Resources are the names of the resources exposed through the provider, defaults to every resource that
+	// can be created and read back
+	Resources []string
+}
+
+// providerSpecification is a Terraform provider code specification, reduced to the parts the generator sets.
+type providerSpecification struct {
+	Version   string        `json:"version"`
+	Provider  provider      `json:"provider"`
+	Resources []resourceDef `json:"resources"`
+}
+
+type provider struct {
+	Name string `json:"name"`
+}
+
+type resourceDef struct {
+	Name   string `json:"name"`
+	Schema schema `json:"schema"`
+}
+
+type schema struct {
+	Attributes  []attribute `json:"attributes"`
+	Description string      `json:"description,omitempty"`
+}
+
+// attribute is an attribute of a schema, with exactly one of its types set.
+type attribute struct {
+	Name         string              `json:"name"`
+	Bool         *primitiveAttribute `json:"bool,omitempty"`
+	Float64      *primitiveAttribute `json:"float64,omitempty"`
+	Int64        *primitiveAttribute `json:"int64,omitempty"`
+	String       *primitiveAttribute `json:"string,omitempty"`
+	List         *listAttribute      `json:"list,omitempty"`
+	ListNested   *listNestedAttr     `json:"list_nested,omitempty"`
+	SingleNested *singleNestedAttr   `json:"single_nested,omitempty"`
+}
+
+type primitiveAttribute struct {
+	ComputedOptionalRequired string `json:"computed_optional_required"`
+	Description              string `json:"description,omitempty"`
+}
+
+type listAttribute struct {
+	ComputedOptionalRequired string      `json:"computed_optional_required"`
+	ElementType              elementType `json:"element_type"`
+	Description              string      `json:"description,omitempty"`
+}
+
+// elementType is the type of the elements of a list, with exactly one of its types set.
+type elementType struct {
+	Bool    *struct{} `json:"bool,omitempty"`
+	Float64 *struct{} `json:"float64,omitempty"`
+	Int64   *struct{} `json:"int64,omitempty"`
+	String  *struct{} `json:"string,omitempty"`
+}
+
+type listNestedAttr struct {
+	ComputedOptionalRequired string       `json:"computed_optional_required"`
+	NestedObject             nestedObject `json:"nested_object"`
+	Description              string       `json:"description,omitempty"`
+}
+
+type nestedObject struct {
+	Attributes []attribute `json:"attributes"`
+}
+
+type singleNestedAttr struct {
+	Attributes               []attribute `json:"attributes"`
+	ComputedOptionalRequired string      `json:"computed_optional_required"`
+	Description              string      `json:"description,omitempty"`
+}
+
+// GenerateSchema writes the Terraform provider schema of the service as JSON to the buffer, with the default options.
+func GenerateSchema(buf *bytes.Buffer, service *specification.Service) error {
+	return GenerateSchemaWithOptions(buf, service, Options{})
+}
+
+// GenerateSchemaWithOptions writes the Terraform provider schema of the service as JSON to the buffer, in the
+// format of the Terraform provider code specification: a resource per specification resource, with an attribute
+// per field of the resource. Development resources are left out, like in the OpenAPI document.
+func GenerateSchemaWithOptions(buf *bytes.Buffer, service *specification.Service, options Options) error {
+	if service == nil {
+		return fmt.Errorf("%s: service cannot be nil", errorInvalidService)
+	}
+
+	resources, err := selectResources(service, options.Resources)
+	if err != nil {
+		return err
+	}
+
+	providerName := options.Provider
+	if providerName == "" {
+		providerName = terraformName(service.Name)
+	}
+
+	spec := providerSpecification{
+		Version:   specificationVersion,
+		Provider:  provider{Name: providerName},
+		Resources: make([]resourceDef, 0, len(resources)),
+	}
+	for _, resource := range resources {
+		spec.Resources = append(spec.Resources, resourceDef{
+			Name: terraformName(resource.Name),
+			Schema: schema{
+				Attributes:  createResourceAttributes(service, resource),
+				Description: resource.Description,
+			},
+		})
+	}
+
+	data, err := json.MarshalIndent(spec, "", jsonIndent)
+	if err != nil {
+		return fmt.Errorf("%s: %w", errorMarshalSchema, err)
+	}
+
+	buf.Write(data)
+	buf.WriteString("\n")
+
+	return nil
+}
+
+// selectResources returns the resources exposed through the provider: the named resources, or every resource
+// that can be created and read back when no names are given. Terraform needs both to manage a resource.
+func selectResources(service *specification.Service, names []string) ([]specification.Resource, error) {
+	for _, name := range names {
+		resource := service.GetResource(name)
+		if resource == nil {
+			return nil, fmt.Errorf("%s: '%s' is not a resource of the service", errorInvalidResource, name)
+		}
+
+		if !isManageable(service, *resource) {
+			return nil, fmt.Errorf("%s: '%s' must have the %s and %s operations to be managed by Terraform", errorInvalidResource, name, specification.OperationCreate, specification.OperationGet)
+		}
+	}
+
+	var resources []specification.Resource
+	for _, resource := range service.Resources {
+		if len(names) > 0 && !slices.Contains(names, resource.Name) {
+			continue
+		}
+
+		if resource.Development || !isManageable(service, resource) {
+			continue
+		}
+
+		resources = append(resources, resource)
+	}
+	return resources, nil
+}
+
+// isManageable checks if Terraform can manage the resource: it can be created and read back.
+func isManageable(service *specification.Service, resource specification.Resource) bool {
+	return resource.HasCreateOperation() && resource.HasGetOperation() && service.GetObject(resource.Name) != nil
+}
+
+// createResourceAttributes creates the attributes of a resource. The ID parameters of the parents of sub-resources
+// come first and are required, followed by the fields read back from the API and the fields that are only written.
+func createResourceAttributes(service *specification.Service, resource specification.Resource) []attribute {
+	var attributes []attribute
+	for _, param := range service.GetParentPathParams(resource) {
+		attributes = append(attributes, createAttribute(service, param, modeRequired, nil))
+	}
+
+	createParams := resource.GetCreateBodyParams()
+	for _, field := range service.GetObject(resource.Name).Fields {
+		mode := modeComputed
+		if index := slices.IndexFunc(resource.Fields, func(f specification.ResourceField) bool { return f.Name == field.Name }); index >= 0 {
+			mode = getWriteMode(service, resource.Fields[index], createParams)
+		}
+
+		if attr, ok := createNestedAttribute(service, field, mode, nil); ok {
+			attributes = append(attributes, attr)
+		}
+	}
+
+	// Write-only fields, such as passwords, are not read back but still configured in Terraform
+	for _, resourceField := range resource.Fields {
+		if resourceField.HasReadOperation() || (!resourceField.HasCreateOperation() && !resourceField.HasUpdateOperation()) {
+			continue
+		}
+
+		if attr, ok := createNestedAttribute(service, resourceField.Field, getWriteMode(service, resourceField, createParams), nil); ok {
+			attributes = append(attributes, attr)
+		}
+	}
+
+	return attributes
+}
+
+// getWriteMode returns who sets the value of a resource field: the fields required on create are required, the
+// other writable fields are optional, and computed too when the API fills in a default or when they can only
+// be set on update. Fields that are not writable are computed.
+func getWriteMode(service *specification.Service, resourceField specification.ResourceField, createParams []specification.Field) string {
+	if !resourceField.HasCreateOperation() && !resourceField.HasUpdateOperation() {
+		return modeComputed
+	}
+
+	if !resourceField.HasCreateOperation() {
+		if resourceField.HasReadOperation() {
+			return modeComputedOptional
+		}
+		return modeOptional
+	}
+
+	if index := slices.IndexFunc(createParams, func(f specification.Field) bool { return f.Name == resourceField.Name }); index >= 0 && createParams[index].IsRequired(service) {
+		return modeRequired
+	}
+
+	return getOptionalMode(resourceField.Field)
+}
+
+// getOptionalMode returns the mode of an optional field, computed too when the API fills in a default.
+func getOptionalMode(field specification.Field) string {
+	if field.Default != "" {
+		return modeComputedOptional
+	}
+	return modeOptional
+}
+
+// createNestedAttribute creates the attribute of a field, with the nested attributes of objects. The fields of
+// writable objects are required or optional on their own, the fields of computed objects are computed. Fields of
+// objects that reference themselves are left out, since Terraform schemas can not recurse.
+func createNestedAttribute(service *specification.Service, field specification.Field, mode string, parents []string) (attribute, bool) {
+	object := service.GetObject(field.Type)
+	if object == nil {
+		return createAttribute(service, field, mode, nil), true
+	}
+
+	if slices.Contains(parents, object.Name) {
+		return attribute{}, false
+	}
+
+	var nested []attribute
+	for _, objectField := range object.Fields {
+		nestedMode := modeComputed
+		if mode != modeComputed {
+			nestedMode = getOptionalMode(objectField)
+			if objectField.IsRequired(service) {
+				nestedMode = modeRequired
+			}
+		}
+
+		if attr, ok := createNestedAttribute(service, objectField, nestedMode, append(slices.Clone(parents), object.Name)); ok {
+			nested = append(nested, attr)
+		}
+	}
+
+	return createAttribute(service, field, mode, nested), true
+}
+
+// createAttribute creates the attribute of a field with its type. Objects are given their nested attributes,
+// enums are strings or integers like their values, and the other types are the closest Terraform type.
+func createAttribute(service *specification.Service, field specification.Field, mode string, nested []attribute) attribute {
+	attr := attribute{Name: terraformName(field.Name)}
+
+	if nested != nil || service.IsObject(field.Type) {
+		if field.IsArray() {
+			attr.ListNested = &listNestedAttr{ComputedOptionalRequired: mode, NestedObject: nestedObject{Attributes: nested}, Description: field.Description}
+		} else {
+			attr.SingleNested = &singleNestedAttr{Attributes: nested, ComputedOptionalRequired: mode, Description: field.Description}
+		}
+		return attr
+	}
+
+	fieldType := service.GetPrimitiveType(field.Type)
+	if field.IsArray() {
+		attr.List = &listAttribute{ComputedOptionalRequired: mode, ElementType: createElementType(fieldType), Description: field.Description}
+		return attr
+	}
+
+	primitive := &primitiveAttribute{ComputedOptionalRequired: mode, Description: field.Description}
+	switch fieldType {
+	case specification.FieldTypeBool:
+		attr.Bool = primitive
+	case specification.FieldTypeInt, specification.FieldTypeInt32, specification.FieldTypeUInt:
+		attr.Int64 = primitive
+	case specification.FieldTypeFloat64:
+		attr.Float64 = primitive
+	default:
+		// UUID, Date, Timestamp and Decimal values are strings in the API
+		attr.String = primitive
+	}
+	return attr
+}
+
+// createElementType creates the element type of a list of a primitive type.
+func createElementType(fieldType string) elementType {
+	switch fieldType {
+	case specification.FieldTypeBool:
+		return elementType{Bool: &struct{}{}}
+	case specification.FieldTypeInt, specification.FieldTypeInt32, specification.FieldTypeUInt:
+		return elementType{Int64: &struct{}{}}
+	case specification.FieldTypeFloat64:
+		return elementType{Float64: &struct{}{}}
+	default:
+		return elementType{String: &struct{}{}}
+	}
+}
+
+// terraformName returns the name in snake_case, the case of the names of Terraform providers, resources and
+// attributes, e.g. UserAccount as user_account.
+func terraformName(name string) string {
+	name = invalidNameCharacters.ReplaceAllString(snakeNaming.FormatPathName(name), nameSeparator)
+	return strings.Trim(name, nameSeparator)
+}
@@ -0,0 +1,250 @@
+package terraformgen
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/meitner-se/publicapis-gen/specification"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createTestService creates a service with a field for each attribute mode and type of the schema, a sub-resource,
+// a read-only resource and a development resource, which the provider leaves out.
+func createTestService() *specification.Service {
+	return specification.ApplyDefaultOverlays(&specification.Service{
+		Name:    "Users API",
+		Version: "1.0.0",
+		Enums: []specification.Enum{
+			{Name: "Role", Description: "Role of a user", Values: []specification.EnumValue{{Name: "Admin"}, {Name: "Member"}}},
+		},
+		Objects: []specification.Object{
+			{
+				Name:        "Address",
+				Description: "Postal address",
+				Fields: []specification.Field{
+					{Name: "Street", Description: "Street", Type: specification.FieldTypeString},
+					{Name: "ZipCode", Description: "Zip code", Type: specification.FieldTypeString, Modifiers: []string{specification.ModifierNullable}},
+				},
+			},
+		},
+		Resources: []specification.Resource{
+			{
+				Name:        "User",
+				Description: "Users",
+				Operations:  []string{specification.OperationCreate, specification.OperationGet, specification.OperationUpdate, specification.OperationDelete},
+				Fields: []specification.ResourceField{
+					{Field: specification.Field{Name: "Name", Description: "Name of the user", Type: specification.FieldTypeString}, Operations: []string{specification.OperationCreate, specification.OperationUpdate, specification.OperationRead}},
+					{Field: specification.Field{Name: "Role", Description: "Role of the user", Type: "Role", Default: "Member"}, Operations: []string{specification.OperationCreate, specification.OperationUpdate, specification.OperationRead}},
+					{Field: specification.Field{Name: "Age", Description: "Age of the user", Type: specification.FieldTypeInt, Modifiers: []string{specification.ModifierNullable}}, Operations: []string{specification.OperationCreate, specification.OperationRead}},
+					{Field: specification.Field{Name: "Tags", Description: "Tags of the user", Type: specification.FieldTypeString, Modifiers: []string{specification.ModifierArray}}, Operations: []string{specification.OperationCreate, specification.OperationRead}},
+					{Field: specification.Field{Name: "Address", Description: "Address of the user", Type: "Address"}, Operations: []string{specification.OperationCreate, specification.OperationRead}},
+					{Field: specification.Field{Name: "Nickname", Description: "Nickname of the user", Type: specification.FieldTypeString, Modifiers: []string{specification.ModifierNullable}}, Operations: []string{specification.OperationUpdate, specification.OperationRead}},
+					{Field: specification.Field{Name: "Password", Description: "Password of the user", Type: specification.FieldTypeString}, Operations: []string{specification.OperationCreate}},
+					{Field: specification.Field{Name: "Score", Description: "Score of the user", Type: specification.FieldTypeFloat64}, Operations: []string{specification.OperationRead}},
+				},
+			},
+			{
+				Name:        "Comment",
+				Description: "Comments of a user",
+				Parent:      "User",
+				Operations:  []string{specification.OperationCreate, specification.OperationGet},
+				Fields: []specification.ResourceField{
+					{Field: specification.Field{Name: "Text", Description: "Text of the comment", Type: specification.FieldTypeString}, Operations: []string{specification.OperationCreate, specification.OperationRead}},
+				},
+			},
+			{
+				Name:        "Report",
+				Description: "Reports can only be read",
+				Operations:  []string{specification.OperationGet},
+				Fields: []specification.ResourceField{
+					{Field: specification.Field{Name: "Title", Description: "Title", Type: specification.FieldTypeString}, Operations: []string{specification.OperationRead}},
+				},
+			},
+			{
+				Name:        "Draft",
+				Description: "Drafts",
+				Development: true,
+				Operations:  []string{specification.OperationCreate, specification.OperationGet},
+				Fields: []specification.ResourceField{
+					{Field: specification.Field{Name: "Title", Description: "Title", Type: specification.FieldTypeString}, Operations: []string{specification.OperationCreate, specification.OperationRead}},
+				},
+			},
+		},
+	})
+}
+
+// decodeSchema decodes the generated provider specification.
+func decodeSchema(t *testing.T, data []byte) providerSpecification {
+	t.Helper()
+
+	var spec providerSpecification
+	require.NoError(t, json.Unmarshal(data, &spec))
+	return spec
+}
+
+// findAttribute returns the attribute with the name, failing the test when it is missing.
+func findAttribute(t *testing.T, attributes []attribute, name string) attribute {
+	t.Helper()
+
+	for _, attr := range attributes {
+		if attr.Name == name {
+			return attr
+		}
+	}
+	require.Failf(t, "attribute not found", "attribute '%s' is missing", name)
+	return attribute{}
+}
+
+func TestGenerateSchema(t *testing.T) {
+	t.Run("generates a resource per manageable resource", func(t *testing.T) {
+		// Arrange
+		service := createTestService()
+		var buf bytes.Buffer
+
+		// Act
+		err := GenerateSchema(&buf, service)
+
+		// Assert
+		require.NoError(t, err)
+		spec := decodeSchema(t, buf.Bytes())
+		assert.Equal(t, specificationVersion, spec.Version)
+		assert.Equal(t, "users_api", spec.Provider.Name)
+		require.Len(t, spec.Resources, 2, "Read-only and development resources should be left out")
+		assert.Equal(t, "user", spec.Resources[0].Name)
+		assert.Equal(t, "comment", spec.Resources[1].Name)
+		assert.Equal(t, "Users", spec.Resources[0].Schema.Description)
+	})
+
+	t.Run("maps the fields to attributes", func(t *testing.T) {
+		// Arrange
+		service := createTestService()
+		var buf bytes.Buffer
+
+		// Act
+		err := GenerateSchema(&buf, service)
+
+		// Assert
+		require.NoError(t, err)
+		attributes := decodeSchema(t, buf.Bytes()).Resources[0].Schema.Attributes
+		assert.Equal(t, &primitiveAttribute{ComputedOptionalRequired: modeComputed, Description: "Unique identifier for the User"}, findAttribute(t, attributes, "id").String)
+		assert.Equal(t, &primitiveAttribute{ComputedOptionalRequired: modeRequired, Description: "Name of the user"}, findAttribute(t, attributes, "name").String)
+		assert.Equal(t, modeComputedOptional, findAttribute(t, attributes, "role").String.ComputedOptionalRequired, "Enums with a default should be computed by the API")
+		assert.Equal(t, modeOptional, findAttribute(t, attributes, "age").Int64.ComputedOptionalRequired)
+		assert.Equal(t, &listAttribute{ComputedOptionalRequired: modeOptional, ElementType: elementType{String: &struct{}{}}, Description: "Tags of the user"}, findAttribute(t, attributes, "tags").List)
+		assert.Equal(t, modeComputedOptional, findAttribute(t, attributes, "nickname").String.ComputedOptionalRequired, "Fields only set on update should be computed on create")
+		assert.Equal(t, modeRequired, findAttribute(t, attributes, "password").String.ComputedOptionalRequired, "Write-only fields should be in the schema")
+		assert.Equal(t, modeComputed, findAttribute(t, attributes, "score").Float64.ComputedOptionalRequired)
+
+		address := findAttribute(t, attributes, "address").SingleNested
+		require.NotNil(t, address)
+		assert.Equal(t, modeOptional, address.ComputedOptionalRequired, "Objects are optional like in the API")
+		assert.Equal(t, modeRequired, findAttribute(t, address.Attributes, "street").String.ComputedOptionalRequired)
+		assert.Equal(t, modeOptional, findAttribute(t, address.Attributes, "zip_code").String.ComputedOptionalRequired)
+
+		meta := findAttribute(t, attributes, "meta").SingleNested
+		require.NotNil(t, meta)
+		assert.Equal(t, modeComputed, meta.ComputedOptionalRequired)
+		for _, attr := range meta.Attributes {
+			assert.NotNil(t, attr.String, "Metadata fields should be strings")
+			assert.Equal(t, modeComputed, attr.String.ComputedOptionalRequired, "Fields of computed objects should be computed")
+		}
+	})
+
+	t.Run("sub-resources require the IDs of their parents", func(t *testing.T) {
+		// Arrange
+		service := createTestService()
+		var buf bytes.Buffer
+
+		// Act
+		err := GenerateSchema(&buf, service)
+
+		// Assert
+		require.NoError(t, err)
+		attributes := decodeSchema(t, buf.Bytes()).Resources[1].Schema.Attributes
+		assert.Equal(t, "user_id", attributes[0].Name)
+		assert.Equal(t, modeRequired, attributes[0].String.ComputedOptionalRequired)
+	})
+
+	t.Run("options select the provider name and resources", func(t *testing.T) {
+		// Arrange
+		service := createTestService()
+		var buf bytes.Buffer
+
+		// Act
+		err := GenerateSchemaWithOptions(&buf, service, Options{Provider: "users", Resources: []string{"Comment"}})
+
+		// Assert
+		require.NoError(t, err)
+		spec := decodeSchema(t, buf.Bytes())
+		assert.Equal(t, "users", spec.Provider.Name)
+		require.Len(t, spec.Resources, 1)
+		assert.Equal(t, "comment", spec.Resources[0].Name)
+	})
+
+	t.Run("unknown resource returns error", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := GenerateSchemaWithOptions(&buf, createTestService(), Options{Resources: []string{"Unknown"}})
+		assert.ErrorContains(t, err, errorInvalidResource)
+	})
+
+	t.Run("resource that can not be created returns error", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := GenerateSchemaWithOptions(&buf, createTestService(), Options{Resources: []string{"Report"}})
+		assert.ErrorContains(t, err, errorInvalidResource)
+	})
+
+	t.Run("nil service returns error", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := GenerateSchema(&buf, nil)
+		assert.ErrorContains(t, err, errorInvalidService)
+	})
+}
+
+func TestCreateNestedAttribute(t *testing.T) {
+	t.Run("self-referencing objects stop recursing", func(t *testing.T) {
+		// Arrange
+		service := &specification.Service{
+			Objects: []specification.Object{
+				{Name: "Node", Fields: []specification.Field{
+					{Name: "Value", Type: specification.FieldTypeString},
+					{Name: "Children", Type: "Node", Modifiers: []string{specification.ModifierArray}},
+				}},
+			},
+		}
+
+		// Act
+		attr, ok := createNestedAttribute(service, specification.Field{Name: "Root", Type: "Node"}, modeOptional, nil)
+
+		// Assert
+		assert.True(t, ok)
+		require.NotNil(t, attr.SingleNested)
+		assert.Len(t, attr.SingleNested.Attributes, 1, "The recursive field should be left out")
+		assert.Equal(t, "value", attr.SingleNested.Attributes[0].Name)
+	})
+
+	t.Run("arrays of objects are nested lists", func(t *testing.T) {
+		// Arrange
+		service := &specification.Service{
+			Objects: []specification.Object{{Name: "Item", Fields: []specification.Field{{Name: "Count", Type: specification.FieldTypeInt}}}},
+		}
+
+		// Act
+		attr, ok := createNestedAttribute(service, specification.Field{Name: "Items", Type: "Item", Modifiers: []string{specification.ModifierArray}}, modeComputed, nil)
+
+		// Assert
+		assert.True(t, ok)
+		require.NotNil(t, attr.ListNested)
+		assert.Equal(t, modeComputed, attr.ListNested.NestedObject.Attributes[0].Int64.ComputedOptionalRequired)
+	})
+}
+
+func TestTerraformName(t *testing.T) {
+	assert.Equal(t, "user_account", terraformName("UserAccount"))
+	assert.Equal(t, "users_api", terraformName("Users API"))
+	assert.Equal(t, "id", terraformName("ID"))
+	assert.Equal(t, "user_id", terraformName("UserID"))
+	assert.Equal(t, "skolans_tjanst", terraformName("Skolans tjänst"))
+	assert.Equal(t, "arende_typ", terraformName("ÄrendeTyp"))
+}
@@ -4,4 +4,23 @@
 // and perform requests to test that the server implementation
 // properly handles HTTP requests and passes them through to
 // the service interface.
+//
+// Each endpoint gets a test with a subtest per case, so that a failure is reported for its case and
+// go test -run selects a single one. Endpoints with a JSON body also get a table of body cases: the valid
+// body, a missing required field, a value of the wrong type, an unknown value of a string enum and null for a
+// nullable field. Only the values that the server cannot decode are expected to be rejected with 400 Bad Request,
+// since the required fields and the values of enums are left to the service.
+//
+// The tests and their subtests run in parallel: each case serves its request from its own httptest.Server
+// with its own mocks, and gin is put in test mode once by an init function, since the mode is global.
+//
+// With Options.GoldenFiles, the tests also compare the JSON responses of the endpoints with golden files
+// under testdata/golden, named after the resource and the endpoint, catching changes of the response shape
+// that the assertions miss. A missing golden file is recorded on the first run, and running the tests with
+// the -update flag records the files again after an intended change:
+//
+//	go test ./api -update
+//
+// The generated code is run through go/format before it is returned, so the
+// output is gofmt-formatted and generation fails if it does not parse as Go.
 package testgen
@@ -2,25 +2,58 @@ package testgen
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"go/format"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/aarondl/strmangle"
 	"github.com/meitner-se/publicapis-gen/specification"
+	"github.com/meitner-se/publicapis-gen/specification/examplegen"
+	"github.com/meitner-se/publicapis-gen/specification/servergen"
 )
 
 const (
 	disclaimerComment = "// Code generated by publicapis-gen testgen. DO NOT EDIT.\n// This file is automatically generated from the API specification.\n// Any changes made to this file will be overwritten on the next generation.\n\n"
+	testTenantID      = "test-tenant"
+
+	// Last updated time of the responses of conditional GET endpoints, in RFC 3339 and as an HTTP date
+	testLastModified         = "2024-01-15T14:45:00Z"
+	testLastModifiedHTTPDate = "Mon, 15 Jan 2024 14:45:00 GMT"
 )
 
+// Options configures the generation of the tests.
+type Options struct {
+	// Types must match the servergen.Options the tested server is generated with
+	Types servergen.Types
+
+	// Benchmarks also generates a benchmark of the request parsing and response serialization of each endpoint,
+	// with payloads built from the examples of the specification
+	Benchmarks bool
+
+	// GoldenFiles also compares the JSON responses of the endpoints with golden files under testdata/golden, which
+	// are recorded when they do not exist yet, or when the tests are run with the -update flag
+	GoldenFiles bool
+}
+
 // GenerateInternalTests generates internal HTTP API tests from a service specification.
 func GenerateInternalTests(buf *bytes.Buffer, service *specification.Service, packageName string) error {
+	return GenerateInternalTestsWithOptions(buf, service, packageName, Options{})
+}
+
+// GenerateInternalTestsWithOptions generates internal HTTP API tests for a server generated with the types of the options.
+func GenerateInternalTestsWithOptions(buf *bytes.Buffer, service *specification.Service, packageName string, options Options) error {
+	types := options.Types
+
 	buf.WriteString(disclaimerComment)
 	buf.WriteString(fmt.Sprintf("package %s\n\n", packageName))
 
 	// Generate imports (no API package import needed for internal tests)
-	err := generateInternalImports(buf)
+	err := generateInternalImports(buf, service, options)
 	if err != nil {
 		return err
 	}
@@ -31,27 +64,41 @@ func GenerateInternalTests(buf *bytes.Buffer, service *specification.Service, pa
 		return err
 	}
 
+	generateTestMode(buf)
+
 	// Generate tests for each resource endpoint
 	for _, resource := range service.Resources {
 		if resource.Development {
 			continue
 		}
 		for _, endpoint := range resource.Endpoints {
-			err = generateInternalEndpointTest(buf, service, resource, endpoint)
+			err = generateInternalEndpointTest(buf, service, resource, endpoint, options)
 			if err != nil {
 				return err
 			}
 		}
 	}
 
+	// Generate benchmarks for each resource endpoint
+	if options.Benchmarks {
+		err = generateBenchmarks(buf, service, "")
+		if err != nil {
+			return err
+		}
+	}
+
 	// Generate helper functions (no API package prefixes needed)
 	err = generateInternalHelperFunctions(buf, service)
 	if err != nil {
 		return err
 	}
 
+	if options.GoldenFiles {
+		generateGoldenFileHelpers(buf)
+	}
+
 	// Generate utility function tests (no API package prefixes needed)
-	err = generateInternalUtilityTests(buf, service)
+	err = generateInternalUtilityTests(buf, service, types)
 	if err != nil {
 		return err
 	}
@@ -74,11 +121,18 @@ func GenerateInternalTests(buf *bytes.Buffer, service *specification.Service, pa
 
 // GenerateTests generates HTTP API tests from a service specification.
 func GenerateTests(buf *bytes.Buffer, service *specification.Service, packageName string, apiPackageName string, apiPackageImport string) error {
+	return GenerateTestsWithOptions(buf, service, packageName, apiPackageName, apiPackageImport, Options{})
+}
+
+// GenerateTestsWithOptions generates HTTP API tests for a server generated with the types of the options.
+func GenerateTestsWithOptions(buf *bytes.Buffer, service *specification.Service, packageName string, apiPackageName string, apiPackageImport string, options Options) error {
+	types := options.Types
+
 	buf.WriteString(disclaimerComment)
 	buf.WriteString(fmt.Sprintf("package %s\n\n", packageName))
 
 	// Generate imports
-	err := generateImports(buf, apiPackageName, apiPackageImport)
+	err := generateImports(buf, service, apiPackageName, apiPackageImport, options)
 	if err != nil {
 		return err
 	}
@@ -89,27 +143,49 @@ func GenerateTests(buf *bytes.Buffer, service *specification.Service, packageNam
 		return err
 	}
 
+	generateTestMode(buf)
+
 	// Generate tests for each resource endpoint
 	for _, resource := range service.Resources {
 		if resource.Development {
 			continue
 		}
 		for _, endpoint := range resource.Endpoints {
-			err = generateEndpointTest(buf, service, resource, endpoint, apiPackageName)
+			err = generateEndpointTest(buf, service, resource, endpoint, apiPackageName, options)
 			if err != nil {
 				return err
 			}
 		}
 	}
 
+	// Generate benchmarks for each resource endpoint
+	if options.Benchmarks {
+		err = generateBenchmarks(buf, service, apiPackageName+".")
+		if err != nil {
+			return err
+		}
+	}
+
 	// Generate helper functions
 	err = generateHelperFunctions(buf, service, apiPackageName)
 	if err != nil {
 		return err
 	}
 
+	if options.GoldenFiles {
+		generateGoldenFileHelpers(buf)
+	}
+
+	// The ptr helper of the server is unexported, so the tests need their own for the nullable response headers
+	if types.Stdlib {
+		buf.WriteString("// ptr returns a pointer to v\n")
+		buf.WriteString("func ptr[T any](v T) *T {\n")
+		buf.WriteString("\treturn &v\n")
+		buf.WriteString("}\n\n")
+	}
+
 	// Generate utility function tests
-	err = generateUtilityTests(buf, service, apiPackageName)
+	err = generateUtilityTests(buf, service, apiPackageName, types)
 	if err != nil {
 		return err
 	}
@@ -144,21 +220,45 @@ func generateImportGuards(buf *bytes.Buffer) {
 }
 
 // generateInternalImports generates the import section for internal test files.
-func generateInternalImports(buf *bytes.Buffer) error {
+func generateInternalImports(buf *bytes.Buffer, service *specification.Service, options Options) error {
+	types := options.Types
+
 	buf.WriteString("import (\n")
 	buf.WriteString("\t\"bytes\"\n")
 	buf.WriteString("\t\"context\"\n")
+	if hasCSVResponse(service) {
+		buf.WriteString("\t\"encoding/csv\"\n")
+	}
 	buf.WriteString("\t\"encoding/json\"\n")
+	if hasXMLBodies(service) {
+		buf.WriteString("\t\"encoding/xml\"\n")
+	}
+	if options.GoldenFiles {
+		buf.WriteString("\t\"flag\"\n")
+	}
 	buf.WriteString("\t\"fmt\"\n")
 	buf.WriteString("\t\"io\"\n")
 	buf.WriteString("\t\"net/http\"\n")
 	buf.WriteString("\t\"net/http/httptest\"\n")
 	buf.WriteString("\t\"net/url\"\n")
+	if options.GoldenFiles {
+		buf.WriteString("\t\"os\"\n")
+		buf.WriteString("\t\"path/filepath\"\n")
+	}
 	buf.WriteString("\t\"strings\"\n")
-	buf.WriteString("\t\"testing\"\n\n")
+	buf.WriteString("\t\"testing\"\n")
+	if types.Stdlib && (hasResponseHeaderType(service, specification.FieldTypeTimestamp) || hasConditionalGet(service)) {
+		buf.WriteString("\t\"time\"\n")
+	}
+	buf.WriteString("\n")
 	buf.WriteString("\t\"github.com/gin-gonic/gin\"\n")
 	buf.WriteString("\t\"github.com/google/uuid\"\n")
-	buf.WriteString("\t\"github.com/meitner-se/go-types\"\n")
+	if importPath := types.ImportPath(); importPath != "" {
+		buf.WriteString(fmt.Sprintf("\t%q\n", importPath))
+	}
+	if hasDecimalResponseHeader(service) {
+		buf.WriteString("\t\"github.com/shopspring/decimal\"\n")
+	}
 	buf.WriteString("\t\"github.com/stretchr/testify/assert\"\n")
 	buf.WriteString(")\n\n")
 
@@ -166,21 +266,45 @@ func generateInternalImports(buf *bytes.Buffer) error {
 }
 
 // generateImports generates the import section for the test file.
-func generateImports(buf *bytes.Buffer, apiPackageName string, apiPackageImport string) error {
+func generateImports(buf *bytes.Buffer, service *specification.Service, apiPackageName string, apiPackageImport string, options Options) error {
+	types := options.Types
+
 	buf.WriteString("import (\n")
 	buf.WriteString("\t\"bytes\"\n")
 	buf.WriteString("\t\"context\"\n")
+	if hasCSVResponse(service) {
+		buf.WriteString("\t\"encoding/csv\"\n")
+	}
 	buf.WriteString("\t\"encoding/json\"\n")
+	if hasXMLBodies(service) {
+		buf.WriteString("\t\"encoding/xml\"\n")
+	}
+	if options.GoldenFiles {
+		buf.WriteString("\t\"flag\"\n")
+	}
 	buf.WriteString("\t\"fmt\"\n")
 	buf.WriteString("\t\"io\"\n")
 	buf.WriteString("\t\"net/http\"\n")
 	buf.WriteString("\t\"net/http/httptest\"\n")
 	buf.WriteString("\t\"net/url\"\n")
+	if options.GoldenFiles {
+		buf.WriteString("\t\"os\"\n")
+		buf.WriteString("\t\"path/filepath\"\n")
+	}
 	buf.WriteString("\t\"strings\"\n")
-	buf.WriteString("\t\"testing\"\n\n")
+	buf.WriteString("\t\"testing\"\n")
+	if types.Stdlib && (hasResponseHeaderType(service, specification.FieldTypeTimestamp) || hasConditionalGet(service)) {
+		buf.WriteString("\t\"time\"\n")
+	}
+	buf.WriteString("\n")
 	buf.WriteString("\t\"github.com/gin-gonic/gin\"\n")
 	buf.WriteString("\t\"github.com/google/uuid\"\n")
-	buf.WriteString("\t\"github.com/meitner-se/go-types\"\n")
+	if importPath := types.ImportPath(); importPath != "" {
+		buf.WriteString(fmt.Sprintf("\t%q\n", importPath))
+	}
+	if hasDecimalResponseHeader(service) {
+		buf.WriteString("\t\"github.com/shopspring/decimal\"\n")
+	}
 	buf.WriteString("\t\"github.com/stretchr/testify/assert\"\n")
 	if apiPackageImport != "" {
 		if apiPackageName != "" && apiPackageName != "api" {
@@ -206,14 +330,26 @@ func generateTestConstants(buf *bytes.Buffer, service *specification.Service) er
 	return nil
 }
 
+// generateTestMode generates the init function that puts gin in test mode once for the package, since the mode is
+// global and setting it from the tests would race when they run in parallel.
+func generateTestMode(buf *bytes.Buffer) {
+	buf.WriteString("// init puts gin in test mode once, since the tests run in parallel\n")
+	buf.WriteString("func init() {\n")
+	buf.WriteString("\tgin.SetMode(gin.TestMode)\n")
+	buf.WriteString("}\n\n")
+}
+
 // generateEndpointTest generates a test function for a specific endpoint.
-func generateEndpointTest(buf *bytes.Buffer, service *specification.Service, resource specification.Resource, endpoint specification.Endpoint, apiPackageName string) error {
+func generateEndpointTest(buf *bytes.Buffer, service *specification.Service, resource specification.Resource, endpoint specification.Endpoint, apiPackageName string, options Options) error {
+	types := options.Types
 	serviceName := strmangle.TitleCase(service.Name)
 	testName := fmt.Sprintf("Test%s%s", resource.Name, endpoint.Name)
 
 	buf.WriteString(fmt.Sprintf("// %s tests the %s endpoint for %s\n", testName, endpoint.Name, resource.Name))
 	buf.WriteString(fmt.Sprintf("func %s(t *testing.T) {\n", testName))
+	buf.WriteString("\tt.Parallel()\n")
 	buf.WriteString("\tt.Run(\"Request\", func(t *testing.T) {\n")
+	buf.WriteString("\t\tt.Parallel()\n")
 
 	// Generate test setup (without parameters)
 	err := generateTestSetup(buf, service, resource, endpoint)
@@ -228,7 +364,7 @@ func generateEndpointTest(buf *bytes.Buffer, service *specification.Service, res
 	}
 
 	// Generate server setup
-	err = generateServerSetup(buf, serviceName, service, resource, endpoint, apiPackageName)
+	err = generateServerSetup(buf, serviceName, service, resource, endpoint, apiPackageName, types)
 	if err != nil {
 		return err
 	}
@@ -245,7 +381,126 @@ func generateEndpointTest(buf *bytes.Buffer, service *specification.Service, res
 		return err
 	}
 
+	if options.GoldenFiles {
+		generateGoldenFileAssertion(buf, resource, endpoint)
+	}
+
 	buf.WriteString("\t})\n")
+
+	if endpoint.IsConditionalGet(service) {
+		buf.WriteString("\n\tt.Run(\"NotModified\", func(t *testing.T) {\n")
+		buf.WriteString("\t\tt.Parallel()\n")
+
+		err = generateTestSetup(buf, service, resource, endpoint)
+		if err != nil {
+			return err
+		}
+
+		err = generateMockSetup(buf, service, resource, endpoint, apiPackageName)
+		if err != nil {
+			return err
+		}
+
+		err = generateServerSetup(buf, serviceName, service, resource, endpoint, apiPackageName, types)
+		if err != nil {
+			return err
+		}
+
+		err = generateNotModifiedRequest(buf, service, resource, endpoint, types)
+		if err != nil {
+			return err
+		}
+
+		buf.WriteString("\t})\n")
+	}
+
+	if service.Strict && hasJSONRequestBody(endpoint) {
+		buf.WriteString("\n\tt.Run(\"UnknownField\", func(t *testing.T) {\n")
+		buf.WriteString("\t\tt.Parallel()\n")
+
+		err = generateTestSetup(buf, service, resource, endpoint)
+		if err != nil {
+			return err
+		}
+
+		err = generateMockSetup(buf, service, resource, endpoint, apiPackageName)
+		if err != nil {
+			return err
+		}
+
+		err = generateServerSetup(buf, serviceName, service, resource, endpoint, apiPackageName, types)
+		if err != nil {
+			return err
+		}
+
+		err = generateUnknownFieldRequest(buf, service, resource, endpoint)
+		if err != nil {
+			return err
+		}
+
+		buf.WriteString("\t})\n")
+	}
+
+	if servergen.ClampsLimit(service, endpoint) {
+		buf.WriteString("\n\tt.Run(\"MaxLimit\", func(t *testing.T) {\n")
+		buf.WriteString("\t\tt.Parallel()\n")
+
+		err = generateTestSetup(buf, service, resource, endpoint)
+		if err != nil {
+			return err
+		}
+
+		err = generateMockSetup(buf, service, resource, endpoint, apiPackageName)
+		if err != nil {
+			return err
+		}
+
+		err = generateServerSetup(buf, serviceName, service, resource, endpoint, apiPackageName, types)
+		if err != nil {
+			return err
+		}
+
+		err = generateMaxLimitRequest(buf, service, resource, endpoint, apiPackageName+".", types)
+		if err != nil {
+			return err
+		}
+
+		buf.WriteString("\t})\n")
+	}
+
+	if hasJSONRequestBody(endpoint) {
+		buf.WriteString("\n\tt.Run(\"Body\", func(t *testing.T) {\n")
+		buf.WriteString("\t\tt.Parallel()\n")
+		generateBodyCases(buf, service, endpoint)
+		buf.WriteString("\t\tfor _, tt := range tests {\n")
+		buf.WriteString("\t\t\tt.Run(tt.name, func(t *testing.T) {\n")
+		buf.WriteString("\t\t\t\tt.Parallel()\n")
+
+		err = generateTestSetup(buf, service, resource, endpoint)
+		if err != nil {
+			return err
+		}
+
+		err = generateMockSetup(buf, service, resource, endpoint, apiPackageName)
+		if err != nil {
+			return err
+		}
+
+		err = generateServerSetup(buf, serviceName, service, resource, endpoint, apiPackageName, types)
+		if err != nil {
+			return err
+		}
+
+		err = generateBodyCaseRequest(buf, service, resource, endpoint)
+		if err != nil {
+			return err
+		}
+
+		buf.WriteString("\t\t\t})\n")
+		buf.WriteString("\t\t}\n")
+		buf.WriteString("\t})\n")
+	}
+
 	buf.WriteString("}\n\n")
 
 	return nil
@@ -254,7 +509,6 @@ func generateEndpointTest(buf *bytes.Buffer, service *specification.Service, res
 // generateTestSetup generates the test setup section.
 func generateTestSetup(buf *bytes.Buffer, service *specification.Service, resource specification.Resource, endpoint specification.Endpoint) error {
 	buf.WriteString("\t\t// Arrange\n")
-	buf.WriteString("\t\tgin.SetMode(gin.TestMode)\n")
 	buf.WriteString("\t\tctx := context.Background()\n\n")
 
 	return nil
@@ -262,7 +516,7 @@ func generateTestSetup(buf *bytes.Buffer, service *specification.Service, resour
 
 // generateTestParameterValue generates a test value for a parameter.
 func generateTestParameterValue(buf *bytes.Buffer, param specification.Field, paramType string) error {
-	varName := fmt.Sprintf("test%s%s", strmangle.TitleCase(paramType), strmangle.TitleCase(param.Name))
+	varName := fmt.Sprintf("test%s%s", strmangle.TitleCase(paramType), param.GetGoName())
 
 	switch param.Type {
 	case "UUID":
@@ -273,13 +527,15 @@ func generateTestParameterValue(buf *bytes.Buffer, param specification.Field, pa
 			defaultValue = param.Example
 		}
 		buf.WriteString(fmt.Sprintf("\t\t%s := \"%s\"\n", varName, defaultValue))
-	case "Int":
+	case "Int", "Int32", "UInt":
 		defaultValue := "123"
 		if param.Example != "" {
 			defaultValue = param.Example
 		}
 		// Use float64 for consistency with JSON unmarshaling
 		buf.WriteString(fmt.Sprintf("\t\t%s := float64(%s)\n", varName, defaultValue))
+	case "Decimal":
+		buf.WriteString(fmt.Sprintf("\t\t%s := \"%s\"\n", varName, decimalExample(param.Example)))
 	case "Float64":
 		defaultValue := "3.14"
 		if param.Example != "" {
@@ -312,12 +568,67 @@ func generateTestParameterValue(buf *bytes.Buffer, param specification.Field, pa
 	return nil
 }
 
+// getExpectedQueryValue returns the expression of the value the query parameter is expected to be decoded into,
+// as it is unmarshaled from JSON. The test value is sent once, so an array parameter holds just that value, such as
+// the expand parameter of the resources with expansions.
+func getExpectedQueryValue(param specification.Field, varName string) string {
+	if param.IsArray() {
+		return fmt.Sprintf("[]any{%s}", varName)
+	}
+	return varName
+}
+
+// resolveIntEnumField returns the field as an Int field if its type is an integer-backed enum, since the values
+// of those enums are sent as integers. The first value of the enum is used as the example if the field has none.
+func resolveIntEnumField(field specification.Field, service *specification.Service) specification.Field {
+	enum := service.GetEnum(field.Type)
+	if enum == nil || !enum.IsInt() {
+		return field
+	}
+
+	field.Type = specification.FieldTypeInt
+	if field.Example == "" && len(enum.Values) > 0 {
+		field.Example = enum.Values[0].GetValue()
+	}
+
+	return field
+}
+
+// seedBodyParams returns a copy of the body parameters where the examples are taken from the first example
+// of the resource, so that the request payloads of the tests use the data of the specification.
+// Only scalar values are used, objects and arrays keep their generated test data.
+func seedBodyParams(resource specification.Resource, bodyParams []specification.Field) []specification.Field {
+	if len(resource.Examples) == 0 {
+		return bodyParams
+	}
+
+	seeded := make([]specification.Field, len(bodyParams))
+	copy(seeded, bodyParams)
+
+	for i, param := range seeded {
+		value, ok := resource.Examples[0].GetValue(param)
+		if !ok || param.IsArray() {
+			continue
+		}
+
+		switch v := value.(type) {
+		case string, bool, int, int64, uint64:
+			seeded[i].Example = fmt.Sprint(v)
+		case float64:
+			seeded[i].Example = strconv.FormatFloat(v, 'f', -1, 64)
+		}
+	}
+
+	return seeded
+}
+
 // generateTestBody generates test data for request body.
 func generateTestBody(buf *bytes.Buffer, bodyParams []specification.Field, service *specification.Service) error {
 	buf.WriteString("\t\ttestBody := map[string]interface{}{\n")
 
 	for _, param := range bodyParams {
-		jsonKey := getJSONKey(param.Name)
+		jsonKey := getJSONKey(param)
+		param = resolveIntEnumField(param, service)
 
 		if param.IsArray() {
 			// Handle array types
@@ -330,12 +641,14 @@ func generateTestBody(buf *bytes.Buffer, bodyParams []specification.Field, servi
 					defaultValue = param.Example
 				}
 				buf.WriteString(fmt.Sprintf("\t\t\t\"%s\": []interface{}{\"%s\"},\n", jsonKey, defaultValue))
-			case "Int":
+			case "Int", "Int32", "UInt":
 				defaultValue := "123"
 				if param.Example != "" {
 					defaultValue = param.Example
 				}
 				buf.WriteString(fmt.Sprintf("\t\t\t\"%s\": []interface{}{float64(%s)},\n", jsonKey, defaultValue))
+			case "Decimal":
+				buf.WriteString(fmt.Sprintf("\t\t\t\"%s\": []interface{}{\"%s\"},\n", jsonKey, decimalExample(param.Example)))
 			case "Float64":
 				defaultValue := "3.14"
 				if param.Example != "" {
@@ -381,12 +694,14 @@ func generateTestBody(buf *bytes.Buffer, bodyParams []specification.Field, servi
 					defaultValue = param.Example
 				}
 				buf.WriteString(fmt.Sprintf("\t\t\t\"%s\": \"%s\",\n", jsonKey, defaultValue))
-			case "Int":
+			case "Int", "Int32", "UInt":
 				defaultValue := "123"
 				if param.Example != "" {
 					defaultValue = param.Example
 				}
 				buf.WriteString(fmt.Sprintf("\t\t\t\"%s\": float64(%s),\n", jsonKey, defaultValue))
+			case "Decimal":
+				buf.WriteString(fmt.Sprintf("\t\t\t\"%s\": \"%s\",\n", jsonKey, decimalExample(param.Example)))
 			case "Float64":
 				defaultValue := "3.14"
 				if param.Example != "" {
@@ -448,7 +763,7 @@ func generateMockSetup(buf *bytes.Buffer, service *specification.Service, resour
 }
 
 // generateServerSetup generates HTTP server setup.
-func generateServerSetup(buf *bytes.Buffer, serviceName string, service *specification.Service, currentResource specification.Resource, currentEndpoint specification.Endpoint, apiPackageName string) error {
+func generateServerSetup(buf *bytes.Buffer, serviceName string, service *specification.Service, currentResource specification.Resource, currentEndpoint specification.Endpoint, apiPackageName string, types servergen.Types) error {
 	buf.WriteString("\t\t// Server setup\n")
 	buf.WriteString("\t\trouter := gin.New()\n")
 
@@ -456,10 +771,23 @@ func generateServerSetup(buf *bytes.Buffer, serviceName string, service *specifi
 	buf.WriteString(fmt.Sprintf("\t\tmock%sAPI := &Mock%sAPI{}\n", currentResource.Name, currentResource.Name))
 
 	methodName := currentEndpoint.Name
-	if currentEndpoint.HasResponseType() {
+	if currentEndpoint.HasCSVResponse() {
+		rowType := *currentEndpoint.Response.BodyObject
+		buf.WriteString(fmt.Sprintf("\t\texpectedRow := &%s.%s{}\n", apiPackageName, rowType))
+		buf.WriteString(fmt.Sprintf("\t\tmock%sAPI.%sFunc = func(ctx context.Context, request %s.Request[any, %s, %s, %s], write func(row *%s.%s) error) error {\n",
+			currentResource.Name, methodName, apiPackageName,
+			getAPITypeReference(currentEndpoint.GetPathParamsType(currentResource.Name), apiPackageName),
+			getAPITypeReference(currentEndpoint.GetQueryParamsType(currentResource.Name), apiPackageName),
+			getAPITypeReference(currentEndpoint.GetBodyParamsType(currentResource.Name), apiPackageName),
+			apiPackageName, rowType))
+		buf.WriteString("\t\t\tcapturedRequest = request\n")
+		buf.WriteString("\t\t\treturn write(expectedRow)\n")
+		buf.WriteString("\t\t}\n")
+	} else if currentEndpoint.HasResponseType() {
 		responseType := currentEndpoint.GetResponseType(currentResource.Name)
 		buf.WriteString(fmt.Sprintf("\t\texpected%s := &%s.%s{\n", responseType, apiPackageName, responseType))
 		buf.WriteString("\t\t\t// Add expected response fields here based on your needs\n")
+		generateResponseHeaderFields(buf, currentEndpoint, types)
 		buf.WriteString("\t\t}\n")
 		buf.WriteString(fmt.Sprintf("\t\tmock%sAPI.%sFunc = func(ctx context.Context, request %s.Request[any, %s, %s, %s]) (*%s.%s, error) {\n",
 			currentResource.Name, methodName, apiPackageName,
@@ -488,12 +816,20 @@ func generateServerSetup(buf *bytes.Buffer, serviceName string, service *specifi
 	buf.WriteString("\t\t\t\t\treturn testSessionUserID, nil\n")
 	buf.WriteString("\t\t\t\t},\n")
 	buf.WriteString(fmt.Sprintf("\t\t\t\tErrorHook: func(ctx context.Context, requestContext %s.RequestContext, session *any, err error) *%s.Error {\n", apiPackageName, apiPackageName))
+	// The errors of the request handling, such as a body that cannot be decoded, keep their status code
+	buf.WriteString(fmt.Sprintf("\t\t\t\t\tif apiError, ok := err.(*%s.Error); ok {\n", apiPackageName))
+	buf.WriteString("\t\t\t\t\t\treturn apiError\n")
+	buf.WriteString("\t\t\t\t\t}\n")
 	buf.WriteString(fmt.Sprintf("\t\t\t\t\treturn &%s.Error{\n", apiPackageName))
 	buf.WriteString(fmt.Sprintf("\t\t\t\t\t\tCode:      %s.ErrorCodeInternal,\n", apiPackageName))
-	buf.WriteString("\t\t\t\t\t\tMessage:   types.NewString(err.Error()),\n")
-	buf.WriteString("\t\t\t\t\t\tRequestID: types.NewString(requestContext.RequestID),\n")
+	buf.WriteString("\t\t\t\t\t\t" + service.GetErrorMessageFieldName() + ":   " + types.String("err.Error()") + ",\n")
+	buf.WriteString("\t\t\t\t\t\tRequestID: " + types.String("requestContext.RequestID") + ",\n")
 	buf.WriteString("\t\t\t\t\t}\n")
 	buf.WriteString("\t\t\t\t},\n")
+	generateResponseEncoders(buf, service, apiPackageName+".")
+	generateHasScopeFunc(buf, service)
+	generateCheckScopesFunc(buf, service, apiPackageName+".")
+	generateFeatureEnabledFunc(buf, service)
 	buf.WriteString("\t\t\t},\n")
 
 	// Add all resource mocks to the API struct
@@ -512,26 +848,522 @@ func generateServerSetup(buf *bytes.Buffer, serviceName string, service *specifi
 		}
 	}
 
-	buf.WriteString("\t\t})\n")
-	buf.WriteString("\t\tserver := httptest.NewServer(router)\n")
-	buf.WriteString("\t\tdefer server.Close()\n\n")
+	buf.WriteString("\t\t})\n")
+	buf.WriteString("\t\tserver := httptest.NewServer(router)\n")
+	buf.WriteString("\t\tdefer server.Close()\n\n")
+
+	return nil
+}
+
+// generateBenchmarks generates a benchmark for each endpoint of the service, see generateEndpointBenchmark.
+// The types of the server are prefixed with packagePrefix, empty for internal tests.
+func generateBenchmarks(buf *bytes.Buffer, service *specification.Service, packagePrefix string) error {
+	buf.WriteString("// ============================================================================\n")
+	buf.WriteString("// Benchmarks of the request parsing and response serialization of the endpoints\n")
+	buf.WriteString("// ============================================================================\n\n")
+
+	generator := examplegen.New(service, examplegen.DefaultSeed)
+	for _, resource := range service.Resources {
+		if resource.Development {
+			continue
+		}
+		for _, endpoint := range resource.Endpoints {
+			if err := generateEndpointBenchmark(buf, service, generator, resource, endpoint, packagePrefix); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// generateEndpointBenchmark generates a benchmark serving a request of the endpoint through the router, without a
+// network, with a service method returning its response right away. So the benchmark measures the code generated
+// for the endpoint: the request parsing, the hooks and the response serialization. The request and the response are
+// built from the examples of the specification, and decoded once before the benchmark loop.
+func generateEndpointBenchmark(buf *bytes.Buffer, service *specification.Service, generator *examplegen.Generator, resource specification.Resource, endpoint specification.Endpoint, packagePrefix string) error {
+	typeReference := func(typeName string) string {
+		if typeName == "struct{}" {
+			return typeName
+		}
+		return packagePrefix + typeName
+	}
+	serviceName := strmangle.TitleCase(service.Name)
+	benchmarkName := fmt.Sprintf("Benchmark%s%s", resource.Name, endpoint.Name)
+	requestType := fmt.Sprintf("%sRequest[any, %s, %s, %s]", packagePrefix,
+		typeReference(endpoint.GetPathParamsType(resource.Name)),
+		typeReference(endpoint.GetQueryParamsType(resource.Name)),
+		typeReference(endpoint.GetBodyParamsType(resource.Name)))
+
+	buf.WriteString(fmt.Sprintf("// %s measures the request parsing and response serialization of the %s endpoint for %s\n", benchmarkName, endpoint.Name, resource.Name))
+	buf.WriteString(fmt.Sprintf("func %s(b *testing.B) {\n", benchmarkName))
+
+	// The service method returns the example response, decoded once
+	buf.WriteString(fmt.Sprintf("\tmock%sAPI := &Mock%sAPI{}\n", resource.Name, resource.Name))
+	switch {
+	case endpoint.HasCSVResponse():
+		rowType := typeReference(*endpoint.Response.BodyObject)
+		if err := generateBenchmarkExample(buf, "row", rowType, generator.Object(*endpoint.Response.BodyObject)); err != nil {
+			return err
+		}
+		buf.WriteString(fmt.Sprintf("\tmock%sAPI.%sFunc = func(ctx context.Context, request %s, write func(row *%s) error) error {\n", resource.Name, endpoint.Name, requestType, rowType))
+		buf.WriteString("\t\treturn write(&row)\n")
+		buf.WriteString("\t}\n\n")
+	case endpoint.HasResponseType():
+		responseType := typeReference(endpoint.GetResponseType(resource.Name))
+		if err := generateBenchmarkExample(buf, "response", responseType, getResponseExample(generator, endpoint)); err != nil {
+			return err
+		}
+		buf.WriteString(fmt.Sprintf("\tmock%sAPI.%sFunc = func(ctx context.Context, request %s) (*%s, error) {\n", resource.Name, endpoint.Name, requestType, responseType))
+		buf.WriteString("\t\treturn &response, nil\n")
+		buf.WriteString("\t}\n\n")
+	default:
+		buf.WriteString(fmt.Sprintf("\tmock%sAPI.%sFunc = func(ctx context.Context, request %s) error {\n", resource.Name, endpoint.Name, requestType))
+		buf.WriteString("\t\treturn nil\n")
+		buf.WriteString("\t}\n\n")
+	}
+
+	// The server with the default ErrorHook, since the errors fail the benchmark
+	buf.WriteString("\trouter := gin.New()\n")
+	buf.WriteString(fmt.Sprintf("\t%sRegister%sAPI(router, &%s%sAPI[any]{\n", packagePrefix, serviceName, packagePrefix, serviceName))
+	buf.WriteString(fmt.Sprintf("\t\tServer: %sServer[any]{\n", packagePrefix))
+	buf.WriteString("\t\t\tGetSessionFunc: func(ctx context.Context, headers http.Header) (any, error) {\n")
+	buf.WriteString("\t\t\t\treturn testSessionUserID, nil\n")
+	buf.WriteString("\t\t\t},\n")
+	generateResponseEncoders(buf, service, packagePrefix)
+	generateHasScopeFunc(buf, service)
+	generateCheckScopesFunc(buf, service, packagePrefix)
+	generateFeatureEnabledFunc(buf, service)
+	buf.WriteString("\t\t},\n")
+	for _, other := range service.Resources {
+		if other.Development || len(other.Endpoints) == 0 {
+			continue
+		}
+		if other.Name == resource.Name {
+			buf.WriteString(fmt.Sprintf("\t\t%s: mock%sAPI,\n", other.Name, other.Name))
+		} else {
+			buf.WriteString(fmt.Sprintf("\t\t%s: &Mock%sAPI{},\n", other.Name, other.Name))
+		}
+	}
+	buf.WriteString("\t})\n\n")
+
+	// The request of the endpoint with the examples of its parameters
+	target, err := getBenchmarkTarget(service, generator, resource, endpoint)
+	if err != nil {
+		return err
+	}
+	buf.WriteString(fmt.Sprintf("\ttarget := %q\n", target))
+	hasBody := len(endpoint.Request.BodyParams) > 0
+	if hasBody {
+		body := make(map[string]any, len(endpoint.Request.BodyParams))
+		for _, param := range seedBodyParams(resource, endpoint.Request.BodyParams) {
+			if value := generator.Value(resolveIntEnumField(param, service)); value != nil {
+				body[param.TagJSON()] = value
+			}
+		}
+		if endpoint.HasXMLRequest() {
+			// The example is decoded into the body params type and encoded with its xml tags
+			if err := generateBenchmarkExample(buf, "bodyParams", typeReference(endpoint.GetBodyParamsType(resource.Name)), body); err != nil {
+				return err
+			}
+			buf.WriteString("\tbody, err := xml.Marshal(bodyParams)\n")
+			buf.WriteString("\tif err != nil {\n")
+			buf.WriteString("\t\tb.Fatalf(\"Failed to encode the body as XML: %v\", err)\n")
+			buf.WriteString("\t}\n")
+		} else if endpoint.HasFormRequest() {
+			form, err := formValues(body)
+			if err != nil {
+				return fmt.Errorf("cannot encode the example body of %s %s: %w", resource.Name, endpoint.Name, err)
+			}
+			buf.WriteString(fmt.Sprintf("\tbody := []byte(%q)\n", form.Encode()))
+		} else {
+			bodyBytes, err := json.Marshal(body)
+			if err != nil {
+				return fmt.Errorf("cannot encode the example body of %s %s: %w", resource.Name, endpoint.Name, err)
+			}
+			buf.WriteString(fmt.Sprintf("\tbody := []byte(%q)\n", bodyBytes))
+		}
+	}
+	buf.WriteString("\n")
+
+	buf.WriteString("\tb.ReportAllocs()\n")
+	buf.WriteString("\tb.ResetTimer()\n")
+	buf.WriteString("\tfor range b.N {\n")
+	if hasBody {
+		buf.WriteString(fmt.Sprintf("\t\treq := httptest.NewRequest(%q, target, bytes.NewReader(body))\n", strings.ToUpper(endpoint.Method)))
+		if endpoint.HasXMLRequest() {
+			buf.WriteString(fmt.Sprintf("\t\treq.Header.Set(\"Content-Type\", %q)\n", specification.ContentTypeXML))
+		} else if endpoint.HasFormRequest() {
+			buf.WriteString(fmt.Sprintf("\t\treq.Header.Set(\"Content-Type\", %q)\n", specification.ContentTypeForm))
+		} else {
+			buf.WriteString("\t\treq.Header.Set(\"Content-Type\", \"application/json\")\n")
+		}
+	} else {
+		buf.WriteString(fmt.Sprintf("\t\treq := httptest.NewRequest(%q, target, nil)\n", strings.ToUpper(endpoint.Method)))
+	}
+	if service.Tenancy.IsHeader() {
+		buf.WriteString(fmt.Sprintf("\t\treq.Header.Set(%q, %q)\n", service.Tenancy.GetParameterName(), testTenantID))
+	}
+	buf.WriteString("\t\trecorder := httptest.NewRecorder()\n")
+	buf.WriteString("\t\trouter.ServeHTTP(recorder, req)\n")
+	buf.WriteString(fmt.Sprintf("\t\tif recorder.Code != %d {\n", endpoint.Response.StatusCode))
+	buf.WriteString(fmt.Sprintf("\t\t\tb.Fatalf(\"Expected HTTP status %%d, got %%d. Response body: %%s\", %d, recorder.Code, recorder.Body.String())\n", endpoint.Response.StatusCode))
+	buf.WriteString("\t\t}\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("}\n\n")
+
+	return nil
+}
+
+// generateBenchmarkExample generates the declaration of a variable of the type decoded from the JSON of the example.
+func generateBenchmarkExample(buf *bytes.Buffer, varName, typeName string, example any) error {
+	exampleBytes, err := json.Marshal(example)
+	if err != nil {
+		return fmt.Errorf("cannot encode the example %s of type %s: %w", varName, typeName, err)
+	}
+
+	buf.WriteString(fmt.Sprintf("\tvar %s %s\n", varName, typeName))
+	buf.WriteString(fmt.Sprintf("\tif err := json.Unmarshal([]byte(%q), &%s); err != nil {\n", exampleBytes, varName))
+	buf.WriteString(fmt.Sprintf("\t\tb.Fatalf(\"Failed to decode the example %s: %%v\", err)\n", varName))
+	buf.WriteString("\t}\n")
+
+	return nil
+}
+
+// formValues returns the example body as form values, repeating the key for each value of an array and writing
+// the values other than strings as in JSON, the same way as the generated tests encode their forms.
+func formValues(body map[string]any) (url.Values, error) {
+	form := url.Values{}
+	for key, value := range body {
+		values, ok := value.([]any)
+		if !ok {
+			values = []any{value}
+		}
+		for _, v := range values {
+			text, ok := v.(string)
+			if !ok {
+				encoded, err := json.Marshal(v)
+				if err != nil {
+					return nil, fmt.Errorf("cannot encode the form value %s: %w", key, err)
+				}
+				text = string(encoded)
+			}
+			form.Add(key, text)
+		}
+	}
+	return form, nil
+}
+
+// getResponseExample returns the example of the response body of the endpoint, of its object or of its fields.
+func getResponseExample(generator *examplegen.Generator, endpoint specification.Endpoint) map[string]any {
+	if endpoint.Response.BodyObject != nil {
+		return generator.Object(*endpoint.Response.BodyObject)
+	}
+
+	example := make(map[string]any, len(endpoint.Response.BodyFields))
+	for _, field := range endpoint.Response.BodyFields {
+		if value := generator.Value(field); value != nil {
+			example[field.TagJSON()] = value
+		}
+	}
+	return example
+}
+
+// getBenchmarkTarget returns the path and query of the request of an endpoint, with the examples of its parameters.
+func getBenchmarkTarget(service *specification.Service, generator *examplegen.Generator, resource specification.Resource, endpoint specification.Endpoint) (string, error) {
+	path := service.GetEndpointPath(resource, endpoint)
+	if service.Tenancy.IsPath() {
+		path = "/" + testTenantID + path
+	}
+
+	for _, param := range endpoint.Request.PathParams {
+		value := getParameterExample(service, generator, param)
+		if value == "" {
+			return "", fmt.Errorf("cannot generate an example of path parameter %s of %s %s", param.Name, resource.Name, endpoint.Name)
+		}
+		path = strings.ReplaceAll(path, "{"+param.TagJSON()+"}", url.PathEscape(value))
+	}
+
+	query := url.Values{}
+	for _, param := range endpoint.Request.QueryParams {
+		if value := getParameterExample(service, generator, param); value != "" {
+			query.Add(param.TagJSON(), value)
+		}
+	}
+
+	target := service.GetBasePath() + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+	return target, nil
+}
+
+// getParameterExample returns the example of a path or query parameter, generated when the parameter has none.
+// Returns an empty string for parameters of object types.
+func getParameterExample(service *specification.Service, generator *examplegen.Generator, param specification.Field) string {
+	param = resolveIntEnumField(param, service)
+	if param.Example != "" {
+		return param.Example
+	}
+	return generator.Field(param)
+}
+
+// generateResponseEncoders generates the ResponseEncoders of the server setup, which Register requires for every
+// alternative content type of the service. The responses are encoded as JSON, since only the negotiation is tested.
+func generateResponseEncoders(buf *bytes.Buffer, service *specification.Service, packagePrefix string) {
+	contentTypes := service.GetAlternativeContentTypes()
+	if len(contentTypes) == 0 {
+		return
+	}
+
+	buf.WriteString(fmt.Sprintf("\t\t\t\tResponseEncoders: map[string]%sResponseEncoder{\n", packagePrefix))
+	for _, contentType := range contentTypes {
+		buf.WriteString(fmt.Sprintf("\t\t\t\t\t%q: func(ctx context.Context, w io.Writer, response any) error {\n", contentType))
+		buf.WriteString("\t\t\t\t\t\treturn json.NewEncoder(w).Encode(response)\n")
+		buf.WriteString("\t\t\t\t\t},\n")
+	}
+	buf.WriteString("\t\t\t\t},\n")
+}
+
+// generateHasScopeFunc generates the HasScopeFunc of the server setup, which Register requires if any field requires
+// scopes. Every scope is granted, so that the fields are not redacted from the responses the tests compare.
+func generateHasScopeFunc(buf *bytes.Buffer, service *specification.Service) {
+	if !service.HasScopedFields() {
+		return
+	}
+
+	buf.WriteString("\t\t\t\tHasScopeFunc: func(ctx context.Context, session any, scope string) bool {\n")
+	buf.WriteString("\t\t\t\t\treturn true\n")
+	buf.WriteString("\t\t\t\t},\n")
+}
+
+// generateCheckScopesFunc generates the CheckScopesFunc of the server setup, which Register requires if any endpoint
+// requires scopes. Every scope is granted, so that the scoped endpoints are tested.
+func generateCheckScopesFunc(buf *bytes.Buffer, service *specification.Service, packagePrefix string) {
+	if !service.HasScopedEndpoints() {
+		return
+	}
+
+	buf.WriteString(fmt.Sprintf("\t\t\t\tCheckScopesFunc: func(ctx context.Context, requestContext %sRequestContext, session any, requiredScopes []string) error {\n", packagePrefix))
+	buf.WriteString("\t\t\t\t\treturn nil\n")
+	buf.WriteString("\t\t\t\t},\n")
+}
+
+// generateFeatureEnabledFunc generates the FeatureEnabledFunc of the server setup, which Register requires if any
+// endpoint is gated behind a feature flag. Every flag is enabled, so that the flagged endpoints are tested.
+func generateFeatureEnabledFunc(buf *bytes.Buffer, service *specification.Service) {
+	if !service.HasFeatureFlags() {
+		return
+	}
+
+	buf.WriteString("\t\t\t\tFeatureEnabledFunc: func(ctx context.Context, flag string) bool {\n")
+	buf.WriteString("\t\t\t\t\treturn true\n")
+	buf.WriteString("\t\t\t\t},\n")
+}
+
+// generateHTTPRequest generates the HTTP request execution.
+func generateHTTPRequest(buf *bytes.Buffer, service *specification.Service, resource specification.Resource, endpoint specification.Endpoint) error {
+	err := generateRequest(buf, service, resource, endpoint)
+	if err != nil {
+		return err
+	}
+
+	generateDoRequest(buf)
+
+	return nil
+}
+
+// generateNotModifiedRequest generates the HTTP request of a conditional GET endpoint with an If-Modified-Since header
+// at the last updated time of the response, and the assertions that it is answered with 304 Not Modified.
+func generateNotModifiedRequest(buf *bytes.Buffer, service *specification.Service, resource specification.Resource, endpoint specification.Endpoint, types servergen.Types) error {
+	field := service.GetLastModifiedField(*endpoint.Response.BodyObject)
+	value, ok := types.Literal(*field, testLastModified)
+	if !ok {
+		return fmt.Errorf("cannot represent the last modified time of %s", *endpoint.Response.BodyObject)
+	}
+	buf.WriteString(fmt.Sprintf("\t\texpected%s.Meta.%s = %s\n\n", endpoint.GetResponseType(resource.Name), field.GetGoName(), value))
+
+	err := generateRequest(buf, service, resource, endpoint)
+	if err != nil {
+		return err
+	}
+
+	buf.WriteString(fmt.Sprintf("\t\treq.Header.Set(%q, %q)\n", specification.IfModifiedSinceHeaderName, testLastModifiedHTTPDate))
+	generateDoRequest(buf)
+
+	buf.WriteString("\t\t// Assert\n")
+	buf.WriteString("\t\tresponseBodyBytes, err := io.ReadAll(resp.Body)\n")
+	buf.WriteString("\t\tassert.NoError(t, err, \"Failed to read response body\")\n")
+	buf.WriteString("\t\tassert.Equal(t, http.StatusNotModified, resp.StatusCode, \"Should answer with 304 Not Modified when not updated since If-Modified-Since\")\n")
+	buf.WriteString(fmt.Sprintf("\t\tassert.Equal(t, %q, resp.Header.Get(%q), \"Should set the Last-Modified header\")\n", testLastModifiedHTTPDate, specification.LastModifiedHeaderName))
+	buf.WriteString("\t\tassert.Empty(t, responseBodyBytes, \"Should not write the response body\")\n")
+	buf.WriteString("\t\tassert.NotNil(t, capturedRequest, \"Service method should have been called with a request\")\n")
+
+	return nil
+}
+
+// generateUnknownFieldRequest generates a request whose body has a field that is not a body parameter,
+// which the server of a strict service rejects before calling the service.
+func generateUnknownFieldRequest(buf *bytes.Buffer, service *specification.Service, resource specification.Resource, endpoint specification.Endpoint) error {
+	err := generateRequest(buf, service, resource, endpoint)
+	if err != nil {
+		return err
+	}
+
+	buf.WriteString("\n\t\t// Add a field that is not a body parameter\n")
+	buf.WriteString("\t\ttestBody[\"unknownField\"] = \"test-unknown-value\"\n")
+	buf.WriteString("\t\tunknownBodyBytes, err := json.Marshal(testBody)\n")
+	buf.WriteString("\t\tassert.NoError(t, err, \"Failed to marshal test body\")\n")
+	buf.WriteString("\t\treq.Body = io.NopCloser(bytes.NewReader(unknownBodyBytes))\n")
+	buf.WriteString("\t\treq.ContentLength = int64(len(unknownBodyBytes))\n")
+	generateDoRequest(buf)
+
+	buf.WriteString("\t\t// Assert\n")
+	buf.WriteString("\t\tresponseBodyBytes, err := io.ReadAll(resp.Body)\n")
+	buf.WriteString("\t\tassert.NoError(t, err, \"Failed to read response body\")\n")
+	buf.WriteString("\t\tassert.Equal(t, http.StatusBadRequest, resp.StatusCode, \"Should reject the unknown field. Response body: %s\", string(responseBodyBytes))\n")
+	buf.WriteString("\t\tassert.Empty(t, capturedRequest, \"Service method should not have been called\")\n")
+
+	return nil
+}
+
+// generateMaxLimitRequest generates a request with a limit above the MaxLimit of the service, prefixed with
+// packagePrefix, and the assertion that the service method is called with the limit clamped to MaxLimit.
+func generateMaxLimitRequest(buf *bytes.Buffer, service *specification.Service, resource specification.Resource, endpoint specification.Endpoint, packagePrefix string, types servergen.Types) error {
+	err := generateRequest(buf, service, resource, endpoint)
+	if err != nil {
+		return err
+	}
+
+	limitKey := "limit"
+	if index := slices.IndexFunc(endpoint.Request.QueryParams, func(param specification.Field) bool { return param.Name == "Limit" }); index >= 0 {
+		limitKey = endpoint.Request.QueryParams[index].TagJSON()
+	}
+
+	buf.WriteString("\n\t\t// Request a limit above the max limit\n")
+	buf.WriteString("\t\tmaxLimitQuery := req.URL.Query()\n")
+	buf.WriteString(fmt.Sprintf("\t\tmaxLimitQuery.Set(%q, fmt.Sprint(%sMaxLimit+1))\n", limitKey, packagePrefix))
+	buf.WriteString("\t\treq.URL.RawQuery = maxLimitQuery.Encode()\n")
+	generateDoRequest(buf)
+
+	buf.WriteString("\t\t// Assert\n")
+	buf.WriteString("\t\tresponseBodyBytes, err := io.ReadAll(resp.Body)\n")
+	buf.WriteString("\t\tassert.NoError(t, err, \"Failed to read response body\")\n")
+	buf.WriteString("\t\tassert.Equal(t, http.StatusOK, resp.StatusCode, \"Should accept a limit above the max limit. Response body: %s\", string(responseBodyBytes))\n")
+	buf.WriteString(fmt.Sprintf("\t\tassert.Equal(t, %s, capturedRequest.QueryParams.Limit, \"Should clamp the limit to the max limit\")\n", types.Int("int64("+packagePrefix+"MaxLimit)")))
+
+	return nil
+}
+
+// hasJSONRequestBody checks if the endpoint has body parameters that are sent as JSON.
+func hasJSONRequestBody(endpoint specification.Endpoint) bool {
+	return len(endpoint.Request.BodyParams) > 0 && !endpoint.HasXMLRequest() && !endpoint.HasFormRequest()
+}
+
+// generateBodyCases generates the table of the cases of the request body, each turning the valid body into the
+// body of the case. The server leaves the required fields and the values of enums to the service,
+// so only the cases with a value that cannot be decoded are rejected with 400 Bad Request.
+func generateBodyCases(buf *bytes.Buffer, service *specification.Service, endpoint specification.Endpoint) {
+	buf.WriteString("\t\t// The server decodes the body without checking the required fields and the values of enums,\n")
+	buf.WriteString("\t\t// which is left to the service, so only the values that cannot be decoded are rejected\n")
+	buf.WriteString("\t\ttests := []struct {\n")
+	buf.WriteString("\t\t\tname       string\n")
+	buf.WriteString("\t\t\tmodify     func(body map[string]interface{})\n")
+	buf.WriteString("\t\t\tbadRequest bool\n")
+	buf.WriteString("\t\t}{\n")
+	generateBodyCase(buf, "Valid", "", false)
+
+	for _, param := range endpoint.Request.BodyParams {
+		if param.IsRequired(service) {
+			generateBodyCase(buf, "MissingRequired"+strmangle.TitleCase(param.Name), fmt.Sprintf("delete(body, %q)", getJSONKey(param)), false)
+		}
+	}
+
+	for _, param := range endpoint.Request.BodyParams {
+		value := "map[string]interface{}{}"
+		if param.IsArray() || service.IsObject(param.Type) {
+			value = "\"test-wrong-type\""
+		}
+		generateBodyCase(buf, "WrongType"+strmangle.TitleCase(param.Name), fmt.Sprintf("body[%q] = %s", getJSONKey(param), value), true)
+	}
+
+	// The fields of integer-backed enums are Int fields, so any integer is accepted like any string of a string enum
+	for _, param := range endpoint.Request.BodyParams {
+		enum := service.GetEnum(param.Type)
+		if enum == nil || enum.IsInt() || param.IsArray() || param.IsNullable() {
+			continue
+		}
+		generateBodyCase(buf, "UnknownEnumValue"+strmangle.TitleCase(param.Name), fmt.Sprintf("body[%q] = \"test-unknown-value\"", getJSONKey(param)), false)
+	}
+
+	for _, param := range endpoint.Request.BodyParams {
+		if param.IsNullable() {
+			generateBodyCase(buf, "Null"+strmangle.TitleCase(param.Name), fmt.Sprintf("body[%q] = nil", getJSONKey(param)), false)
+		}
+	}
+
+	buf.WriteString("\t\t}\n\n")
+}
+
+// generateBodyCase generates a case of the table of the request body, where modify is the statement changing the body.
+func generateBodyCase(buf *bytes.Buffer, name, modify string, badRequest bool) {
+	buf.WriteString(fmt.Sprintf("\t\t\t{name: %q, modify: func(body map[string]interface{}) {", name))
+	if modify != "" {
+		buf.WriteString(" " + modify + " ")
+	}
+	buf.WriteString("}")
+	if badRequest {
+		buf.WriteString(", badRequest: true")
+	}
+	buf.WriteString("},\n")
+}
+
+// generateBodyCaseRequest generates the request of a case of the table of the request body,
+// and the assertions that the body is rejected before calling the service or accepted.
+func generateBodyCaseRequest(buf *bytes.Buffer, service *specification.Service, resource specification.Resource, endpoint specification.Endpoint) error {
+	err := generateRequest(buf, service, resource, endpoint)
+	if err != nil {
+		return err
+	}
+
+	buf.WriteString("\n\t\t// Turn the valid body into the body of the case\n")
+	buf.WriteString("\t\ttt.modify(testBody)\n")
+	buf.WriteString("\t\tcaseBodyBytes, err := json.Marshal(testBody)\n")
+	buf.WriteString("\t\tassert.NoError(t, err, \"Failed to marshal test body\")\n")
+	buf.WriteString("\t\treq.Body = io.NopCloser(bytes.NewReader(caseBodyBytes))\n")
+	buf.WriteString("\t\treq.ContentLength = int64(len(caseBodyBytes))\n")
+	generateDoRequest(buf)
+
+	buf.WriteString("\t\t// Assert\n")
+	buf.WriteString("\t\tresponseBodyBytes, err := io.ReadAll(resp.Body)\n")
+	buf.WriteString("\t\tassert.NoError(t, err, \"Failed to read response body\")\n")
+	buf.WriteString("\t\tif tt.badRequest {\n")
+	buf.WriteString("\t\t\tassert.Equal(t, http.StatusBadRequest, resp.StatusCode, \"Should reject the body. Response body: %s\", string(responseBodyBytes))\n")
+	buf.WriteString("\t\t\tassert.Empty(t, capturedRequest, \"Service method should not have been called\")\n")
+	buf.WriteString("\t\t\treturn\n")
+	buf.WriteString("\t\t}\n")
+	buf.WriteString(fmt.Sprintf("\t\tassert.Equal(t, %d, resp.StatusCode, \"Should accept the body. Response body: %%s\", string(responseBodyBytes))\n", endpoint.Response.StatusCode))
+	buf.WriteString("\t\tassert.NotEmpty(t, capturedRequest, \"Service method should have been called\")\n")
 
 	return nil
 }
 
-// generateHTTPRequest generates the HTTP request execution.
-func generateHTTPRequest(buf *bytes.Buffer, service *specification.Service, resource specification.Resource, endpoint specification.Endpoint) error {
+// generateRequest generates the HTTP request of the endpoint with its parameters, without executing it.
+func generateRequest(buf *bytes.Buffer, service *specification.Service, resource specification.Resource, endpoint specification.Endpoint) error {
 	buf.WriteString("\t\t// Act - Execute HTTP request\n")
 
 	// Build URL
-	path := endpoint.GetFullPath(resource.Name)
-	buf.WriteString(fmt.Sprintf("\t\trequestURL := server.URL + \"/%s/%s%s\"\n", service.PathName(), service.Version, path))
+	path := service.GetEndpointPath(resource, endpoint)
+	if service.Tenancy.IsPath() {
+		path = "/" + testTenantID + path
+	}
+	buf.WriteString(fmt.Sprintf("\t\trequestURL := server.URL + \"%s%s\"\n", service.GetBasePath(), path))
 
 	// Generate and use path parameters
 	if len(endpoint.Request.PathParams) > 0 {
 		buf.WriteString("\t\t// Path parameters\n")
 		for _, param := range endpoint.Request.PathParams {
-			err := generateTestParameterValue(buf, param, "path")
+			err := generateTestParameterValue(buf, resolveIntEnumField(param, service), "path")
 			if err != nil {
 				return err
 			}
@@ -540,8 +1372,8 @@ func generateHTTPRequest(buf *bytes.Buffer, service *specification.Service, reso
 
 		// Replace path parameters in URL
 		for _, param := range endpoint.Request.PathParams {
-			paramName := fmt.Sprintf("{%s}", strings.ToLower(param.Name))
-			varName := fmt.Sprintf("test%s%s", "Path", strmangle.TitleCase(param.Name))
+			paramName := fmt.Sprintf("{%s}", param.TagJSON())
+			varName := fmt.Sprintf("test%s%s", "Path", param.GetGoName())
 			buf.WriteString(fmt.Sprintf("\t\trequestURL = strings.ReplaceAll(requestURL, \"%s\", %s)\n", paramName, varName))
 		}
 		buf.WriteString("\n")
@@ -551,7 +1383,7 @@ func generateHTTPRequest(buf *bytes.Buffer, service *specification.Service, reso
 	if len(endpoint.Request.QueryParams) > 0 {
 		buf.WriteString("\t\t// Query parameters\n")
 		for _, param := range endpoint.Request.QueryParams {
-			err := generateTestParameterValue(buf, param, "query")
+			err := generateTestParameterValue(buf, resolveIntEnumField(param, service), "query")
 			if err != nil {
 				return err
 			}
@@ -561,8 +1393,8 @@ func generateHTTPRequest(buf *bytes.Buffer, service *specification.Service, reso
 		buf.WriteString("\t\t// Add query parameters to URL\n")
 		buf.WriteString("\t\tquery := url.Values{}\n")
 		for _, param := range endpoint.Request.QueryParams {
-			varName := fmt.Sprintf("test%s%s", "Query", strmangle.TitleCase(param.Name))
-			jsonKey := getJSONKey(param.Name)
+			varName := fmt.Sprintf("test%s%s", "Query", param.GetGoName())
+			jsonKey := getJSONKey(param)
 			buf.WriteString(fmt.Sprintf("\t\tquery.Add(\"%s\", fmt.Sprintf(\"%%v\", %s))\n", jsonKey, varName))
 		}
 		buf.WriteString("\t\tif len(query) > 0 {\n")
@@ -574,10 +1406,21 @@ func generateHTTPRequest(buf *bytes.Buffer, service *specification.Service, reso
 	// Generate and use body parameters
 	if len(endpoint.Request.BodyParams) > 0 {
 		buf.WriteString("\t\t// Body parameters\n")
-		err := generateTestBody(buf, endpoint.Request.BodyParams, service)
+		err := generateTestBody(buf, seedBodyParams(resource, endpoint.Request.BodyParams), service)
 		if err != nil {
 			return err
 		}
+		if endpoint.HasXMLRequest() {
+			buf.WriteString("\t\t// Encode the body as XML with the xml tags of the body params type\n")
+			buf.WriteString("\t\txmlBody := capturedRequest.BodyParams\n")
+			buf.WriteString("\t\terr = json.Unmarshal(testBodyBytes, &xmlBody)\n")
+			buf.WriteString("\t\tassert.NoError(t, err, \"Failed to decode test body\")\n")
+			buf.WriteString("\t\ttestBodyBytes, err = xml.Marshal(xmlBody)\n")
+			buf.WriteString("\t\tassert.NoError(t, err, \"Failed to marshal test body as XML\")\n")
+		}
+		if endpoint.HasFormRequest() {
+			generateFormBody(buf)
+		}
 		buf.WriteString("\n")
 	}
 
@@ -591,16 +1434,49 @@ func generateHTTPRequest(buf *bytes.Buffer, service *specification.Service, reso
 
 	buf.WriteString("\t\tassert.NoError(t, err, \"Failed to create HTTP request\")\n")
 
-	if len(endpoint.Request.BodyParams) > 0 {
+	if endpoint.HasXMLRequest() {
+		buf.WriteString(fmt.Sprintf("\t\treq.Header.Set(\"Content-Type\", %q)\n", specification.ContentTypeXML))
+	} else if endpoint.HasFormRequest() {
+		buf.WriteString(fmt.Sprintf("\t\treq.Header.Set(\"Content-Type\", %q)\n", specification.ContentTypeForm))
+	} else if len(endpoint.Request.BodyParams) > 0 {
 		buf.WriteString("\t\treq.Header.Set(\"Content-Type\", \"application/json\")\n")
 	}
 
-	// Execute request
+	if service.Tenancy.IsHeader() {
+		buf.WriteString(fmt.Sprintf("\t\treq.Header.Set(%q, %q)\n", service.Tenancy.GetParameterName(), testTenantID))
+	}
+
+	return nil
+}
+
+// generateFormBody generates the encoding of the test body as a form, where each value of an array repeats the key.
+// Values other than strings are written as in JSON, so numbers are never written with an exponent.
+func generateFormBody(buf *bytes.Buffer) {
+	buf.WriteString("\t\t// Encode the body as a form, repeating the key for each value of an array\n")
+	buf.WriteString("\t\tform := url.Values{}\n")
+	buf.WriteString("\t\tfor key, value := range testBody {\n")
+	buf.WriteString("\t\t\tvalues, ok := value.([]interface{})\n")
+	buf.WriteString("\t\t\tif !ok {\n")
+	buf.WriteString("\t\t\t\tvalues = []interface{}{value}\n")
+	buf.WriteString("\t\t\t}\n")
+	buf.WriteString("\t\t\tfor _, v := range values {\n")
+	buf.WriteString("\t\t\t\ttext, ok := v.(string)\n")
+	buf.WriteString("\t\t\t\tif !ok {\n")
+	buf.WriteString("\t\t\t\t\tencoded, err := json.Marshal(v)\n")
+	buf.WriteString("\t\t\t\t\tassert.NoError(t, err, \"Failed to encode form value\")\n")
+	buf.WriteString("\t\t\t\t\ttext = string(encoded)\n")
+	buf.WriteString("\t\t\t\t}\n")
+	buf.WriteString("\t\t\t\tform.Add(key, text)\n")
+	buf.WriteString("\t\t\t}\n")
+	buf.WriteString("\t\t}\n")
+	buf.WriteString("\t\ttestBodyBytes = []byte(form.Encode())\n")
+}
+
+// generateDoRequest generates the execution of the HTTP request.
+func generateDoRequest(buf *bytes.Buffer) {
 	buf.WriteString("\t\tresp, err := http.DefaultClient.Do(req)\n")
 	buf.WriteString("\t\tassert.NoError(t, err, \"Failed to execute HTTP request\")\n")
 	buf.WriteString("\t\tdefer resp.Body.Close()\n\n")
-
-	return nil
 }
 
 // generateAssertions generates test assertions.
@@ -616,7 +1492,17 @@ func generateAssertions(buf *bytes.Buffer, service *specification.Service, resou
 	buf.WriteString("\t\t\treturn\n")
 	buf.WriteString("\t\t}\n\n")
 
-	if endpoint.HasResponseType() {
+	generateResponseHeaderFieldAssertions(buf, endpoint)
+
+	if endpoint.HasCSVResponse() {
+		buf.WriteString("\t\t// Verify response body, a header row and the row written by the service method\n")
+		buf.WriteString("\t\tassert.Equal(t, \"text/csv; charset=utf-8\", resp.Header.Get(\"Content-Type\"), \"Response should be CSV\")\n")
+		buf.WriteString("\t\trecords, err := csv.NewReader(bytes.NewReader(responseBodyBytes)).ReadAll()\n")
+		buf.WriteString("\t\tassert.NoError(t, err, \"Failed to decode response body\")\n")
+		buf.WriteString("\t\tassert.Len(t, records, 2, \"Response body should have a header row and a row\")\n")
+	} else if endpoint.HasXMLResponse() {
+		generateXMLResponseAssertions(buf, resource, endpoint)
+	} else if endpoint.HasResponseType() {
 		buf.WriteString("\t\t// Verify response body\n")
 		buf.WriteString("\t\tvar responseBody map[string]interface{}\n")
 		buf.WriteString("\t\terr = json.Unmarshal(responseBodyBytes, &responseBody)\n")
@@ -638,8 +1524,8 @@ func generateAssertions(buf *bytes.Buffer, service *specification.Service, resou
 		buf.WriteString("\t\tassert.NoError(t, err, \"Failed to unmarshal captured path params\")\n\n")
 
 		for _, param := range endpoint.Request.PathParams {
-			varName := fmt.Sprintf("test%s%s", "Path", strmangle.TitleCase(param.Name))
-			jsonKey := getJSONKey(param.Name)
+			varName := fmt.Sprintf("test%s%s", "Path", param.GetGoName())
+			jsonKey := getJSONKey(param)
 			buf.WriteString(fmt.Sprintf("\t\tassert.Equal(t, %s, capturedPathParams[\"%s\"], \"Path parameter %s should match\")\n",
 				varName, jsonKey, param.Name))
 		}
@@ -656,10 +1542,10 @@ func generateAssertions(buf *bytes.Buffer, service *specification.Service, resou
 		buf.WriteString("\t\tassert.NoError(t, err, \"Failed to unmarshal captured query params\")\n\n")
 
 		for _, param := range endpoint.Request.QueryParams {
-			varName := fmt.Sprintf("test%s%s", "Query", strmangle.TitleCase(param.Name))
-			jsonKey := getJSONKey(param.Name)
+			varName := fmt.Sprintf("test%s%s", "Query", param.GetGoName())
+			jsonKey := getJSONKey(param)
 			buf.WriteString(fmt.Sprintf("\t\tassert.Equal(t, %s, capturedQueryParams[\"%s\"], \"Query parameter %s should match\")\n",
-				varName, jsonKey, param.Name))
+				getExpectedQueryValue(param, varName), jsonKey, param.Name))
 		}
 		buf.WriteString("\n")
 	}
@@ -674,7 +1560,7 @@ func generateAssertions(buf *bytes.Buffer, service *specification.Service, resou
 		buf.WriteString("\t\tassert.NoError(t, err, \"Failed to unmarshal captured body params\")\n\n")
 
 		for _, param := range endpoint.Request.BodyParams {
-			jsonKey := getJSONKey(param.Name)
+			jsonKey := getJSONKey(param)
 			buf.WriteString(fmt.Sprintf("\t\tassert.Equal(t, testBody[\"%s\"], capturedRequestBody[\"%s\"], \"Body parameter %s should match\")\n",
 				jsonKey, jsonKey, param.Name))
 		}
@@ -684,6 +1570,18 @@ func generateAssertions(buf *bytes.Buffer, service *specification.Service, resou
 	return nil
 }
 
+// generateXMLResponseAssertions generates the assertions that the response body of an endpoint with XML responses is
+// an XML element named by its response type.
+func generateXMLResponseAssertions(buf *bytes.Buffer, resource specification.Resource, endpoint specification.Endpoint) {
+	responseType := endpoint.GetResponseType(resource.Name)
+	buf.WriteString("\t\t// Verify response body, an XML element named by the response type\n")
+	buf.WriteString(fmt.Sprintf("\t\tassert.Equal(t, %q, resp.Header.Get(\"Content-Type\"), \"Response should be XML\")\n", specification.ContentTypeXML))
+	buf.WriteString("\t\tvar responseBody struct{ XMLName xml.Name }\n")
+	buf.WriteString("\t\terr = xml.Unmarshal(responseBodyBytes, &responseBody)\n")
+	buf.WriteString("\t\tassert.NoError(t, err, \"Failed to decode response body\")\n")
+	buf.WriteString(fmt.Sprintf("\t\tassert.Equal(t, %q, responseBody.XMLName.Local, \"Response body should be the %s element\")\n", responseType, responseType))
+}
+
 // generateHelperFunctions generates helper functions and mock interfaces.
 func generateHelperFunctions(buf *bytes.Buffer, service *specification.Service, apiPackageName string) error {
 	buf.WriteString("// ============================================================================\n")
@@ -706,7 +1604,14 @@ func generateHelperFunctions(buf *bytes.Buffer, service *specification.Service,
 		for _, endpoint := range resource.Endpoints {
 			methodName := endpoint.Name
 
-			if endpoint.HasResponseType() {
+			if endpoint.HasCSVResponse() {
+				buf.WriteString(fmt.Sprintf("\t%sFunc func(ctx context.Context, request %s.Request[any, %s, %s, %s], write func(row *%s.%s) error) error\n",
+					methodName, apiPackageName,
+					getAPITypeReference(endpoint.GetPathParamsType(resource.Name), apiPackageName),
+					getAPITypeReference(endpoint.GetQueryParamsType(resource.Name), apiPackageName),
+					getAPITypeReference(endpoint.GetBodyParamsType(resource.Name), apiPackageName),
+					apiPackageName, *endpoint.Response.BodyObject))
+			} else if endpoint.HasResponseType() {
 				responseType := endpoint.GetResponseType(resource.Name)
 				buf.WriteString(fmt.Sprintf("\t%sFunc func(ctx context.Context, request %s.Request[any, %s, %s, %s]) (*%s.%s, error)\n",
 					methodName, apiPackageName,
@@ -741,7 +1646,18 @@ func generateHelperFunctions(buf *bytes.Buffer, service *specification.Service,
 func generateMockMethod(buf *bytes.Buffer, resource specification.Resource, endpoint specification.Endpoint, apiPackageName string) error {
 	methodName := endpoint.Name
 
-	if endpoint.HasResponseType() {
+	if endpoint.HasCSVResponse() {
+		buf.WriteString(fmt.Sprintf("func (m *Mock%sAPI) %s(ctx context.Context, request %s.Request[any, %s, %s, %s], write func(row *%s.%s) error) error {\n",
+			resource.Name, methodName, apiPackageName,
+			getAPITypeReference(endpoint.GetPathParamsType(resource.Name), apiPackageName),
+			getAPITypeReference(endpoint.GetQueryParamsType(resource.Name), apiPackageName),
+			getAPITypeReference(endpoint.GetBodyParamsType(resource.Name), apiPackageName),
+			apiPackageName, *endpoint.Response.BodyObject))
+		buf.WriteString(fmt.Sprintf("\tif m.%sFunc != nil {\n", methodName))
+		buf.WriteString(fmt.Sprintf("\t\treturn m.%sFunc(ctx, request, write)\n", methodName))
+		buf.WriteString("\t}\n")
+		buf.WriteString("\treturn nil\n")
+	} else if endpoint.HasResponseType() {
 		responseType := endpoint.GetResponseType(resource.Name)
 		buf.WriteString(fmt.Sprintf("func (m *Mock%sAPI) %s(ctx context.Context, request %s.Request[any, %s, %s, %s]) (*%s.%s, error) {\n",
 			resource.Name, methodName, apiPackageName,
@@ -791,7 +1707,8 @@ func getObjectTestDataWithVisited(objectType string, service *specification.Serv
 		if obj.Name == objectType {
 			var fields []string
 			for _, field := range obj.Fields {
-				jsonKey := getJSONKey(field.Name)
+				jsonKey := getJSONKey(field)
+				field = resolveIntEnumField(field, service)
 
 				// Include nullable fields with nil values to match JSON marshaling behavior
 				if field.IsNullable() {
@@ -810,12 +1727,14 @@ func getObjectTestDataWithVisited(objectType string, service *specification.Serv
 							defaultValue = field.Example
 						}
 						fields = append(fields, fmt.Sprintf("\n\t\t\t\t\"%s\": []interface{}{\"%s\"}", jsonKey, defaultValue))
-					case "Int":
+					case "Int", "Int32", "UInt":
 						defaultValue := "123"
 						if field.Example != "" {
 							defaultValue = field.Example
 						}
 						fields = append(fields, fmt.Sprintf("\n\t\t\t\t\"%s\": []interface{}{float64(%s)}", jsonKey, defaultValue))
+					case "Decimal":
+						fields = append(fields, fmt.Sprintf("\n\t\t\t\t\"%s\": []interface{}{\"%s\"}", jsonKey, decimalExample(field.Example)))
 					case "Float64":
 						defaultValue := "3.14"
 						if field.Example != "" {
@@ -865,12 +1784,14 @@ func getObjectTestDataWithVisited(objectType string, service *specification.Serv
 							defaultValue = field.Example
 						}
 						fields = append(fields, fmt.Sprintf("\n\t\t\t\t\"%s\": \"%s\"", jsonKey, defaultValue))
-					case "Int":
+					case "Int", "Int32", "UInt":
 						defaultValue := "123"
 						if field.Example != "" {
 							defaultValue = field.Example
 						}
 						fields = append(fields, fmt.Sprintf("\n\t\t\t\t\"%s\": float64(%s)", jsonKey, defaultValue))
+					case "Decimal":
+						fields = append(fields, fmt.Sprintf("\n\t\t\t\t\"%s\": \"%s\"", jsonKey, decimalExample(field.Example)))
 					case "Float64":
 						defaultValue := "3.14"
 						if field.Example != "" {
@@ -923,25 +1844,25 @@ func getObjectTestDataWithVisited(objectType string, service *specification.Serv
 }
 
 // generateUtilityTests generates tests for utility functions.
-func generateUtilityTests(buf *bytes.Buffer, service *specification.Service, apiPackageName string) error {
+func generateUtilityTests(buf *bytes.Buffer, service *specification.Service, apiPackageName string, types servergen.Types) error {
 	buf.WriteString("// ============================================================================\n")
 	buf.WriteString("// Utility function tests\n")
 	buf.WriteString("// ============================================================================\n\n")
 
 	// Test serveWithResponse
-	err := generateServeWithResponseTest(buf, apiPackageName)
+	err := generateServeWithResponseTest(buf, service, apiPackageName, types)
 	if err != nil {
 		return err
 	}
 
 	// Test serveWithoutResponse
-	err = generateServeWithoutResponseTest(buf, apiPackageName)
+	err = generateServeWithoutResponseTest(buf, service, apiPackageName, types)
 	if err != nil {
 		return err
 	}
 
 	// Test handleRequest
-	err = generateHandleRequestTest(buf, apiPackageName)
+	err = generateHandleRequestTest(buf, service, apiPackageName, types)
 	if err != nil {
 		return err
 	}
@@ -965,19 +1886,19 @@ func generateUtilityTests(buf *bytes.Buffer, service *specification.Service, api
 	}
 
 	// Test PreHooks functionality
-	err = generatePreHookTests(buf, apiPackageName)
+	err = generatePreHookTests(buf, service, apiPackageName, types)
 	if err != nil {
 		return err
 	}
 
 	// Test SessionHooks functionality
-	err = generateSessionHookTests(buf, apiPackageName)
+	err = generateSessionHookTests(buf, service, apiPackageName, types)
 	if err != nil {
 		return err
 	}
 
 	// Test ResponseHeaderHook functionality
-	err = generateResponseHeaderHookTests(buf, service, apiPackageName)
+	err = generateResponseHeaderHookTests(buf, service, apiPackageName, types)
 	if err != nil {
 		return err
 	}
@@ -986,9 +1907,9 @@ func generateUtilityTests(buf *bytes.Buffer, service *specification.Service, api
 }
 
 // generateServeWithResponseTest generates test for serveWithResponse function.
-func generateServeWithResponseTest(buf *bytes.Buffer, apiPackageName string) error {
+func generateServeWithResponseTest(buf *bytes.Buffer, service *specification.Service, apiPackageName string, types servergen.Types) error {
 	buf.WriteString("func Test_serveWithResponse(t *testing.T) {\n")
-	buf.WriteString("\tgin.SetMode(gin.TestMode)\n")
+	buf.WriteString("\tt.Parallel()\n")
 	buf.WriteString("\trouter := gin.New()\n\n")
 
 	buf.WriteString("\t// Mock function that returns a response\n")
@@ -1004,8 +1925,8 @@ func generateServeWithResponseTest(buf *bytes.Buffer, apiPackageName string) err
 	buf.WriteString("\t\tErrorHook: func(ctx context.Context, requestContext " + apiPackageName + ".RequestContext, session *any, err error) *" + apiPackageName + ".Error {\n")
 	buf.WriteString("\t\t\treturn &" + apiPackageName + ".Error{\n")
 	buf.WriteString("\t\t\t\tCode:      " + apiPackageName + ".ErrorCodeInternal,\n")
-	buf.WriteString("\t\t\t\tMessage:   types.NewString(err.Error()),\n")
-	buf.WriteString("\t\t\t\tRequestID: types.NewString(requestContext.RequestID),\n")
+	buf.WriteString("\t\t\t\t" + service.GetErrorMessageFieldName() + ":   " + types.String("err.Error()") + ",\n")
+	buf.WriteString("\t\t\t\tRequestID: " + types.String("requestContext.RequestID") + ",\n")
 	buf.WriteString("\t\t\t}\n")
 	buf.WriteString("\t\t},\n")
 	buf.WriteString("\t}\n\n")
@@ -1029,9 +1950,9 @@ func generateServeWithResponseTest(buf *bytes.Buffer, apiPackageName string) err
 }
 
 // generateServeWithoutResponseTest generates test for serveWithoutResponse function.
-func generateServeWithoutResponseTest(buf *bytes.Buffer, apiPackageName string) error {
+func generateServeWithoutResponseTest(buf *bytes.Buffer, service *specification.Service, apiPackageName string, types servergen.Types) error {
 	buf.WriteString("func Test_serveWithoutResponse(t *testing.T) {\n")
-	buf.WriteString("\tgin.SetMode(gin.TestMode)\n")
+	buf.WriteString("\tt.Parallel()\n")
 	buf.WriteString("\trouter := gin.New()\n\n")
 
 	buf.WriteString("\t// Mock function that doesn't return a response\n")
@@ -1047,8 +1968,8 @@ func generateServeWithoutResponseTest(buf *bytes.Buffer, apiPackageName string)
 	buf.WriteString("\t\tErrorHook: func(ctx context.Context, requestContext " + apiPackageName + ".RequestContext, session *any, err error) *" + apiPackageName + ".Error {\n")
 	buf.WriteString("\t\t\treturn &" + apiPackageName + ".Error{\n")
 	buf.WriteString("\t\t\t\tCode:      " + apiPackageName + ".ErrorCodeInternal,\n")
-	buf.WriteString("\t\t\t\tMessage:   types.NewString(err.Error()),\n")
-	buf.WriteString("\t\t\t\tRequestID: types.NewString(requestContext.RequestID),\n")
+	buf.WriteString("\t\t\t\t" + service.GetErrorMessageFieldName() + ":   " + types.String("err.Error()") + ",\n")
+	buf.WriteString("\t\t\t\tRequestID: " + types.String("requestContext.RequestID") + ",\n")
 	buf.WriteString("\t\t\t}\n")
 	buf.WriteString("\t\t},\n")
 	buf.WriteString("\t}\n\n")
@@ -1071,11 +1992,12 @@ func generateServeWithoutResponseTest(buf *bytes.Buffer, apiPackageName string)
 }
 
 // generateHandleRequestTest generates test for handleRequest function.
-func generateHandleRequestTest(buf *bytes.Buffer, apiPackageName string) error {
+func generateHandleRequestTest(buf *bytes.Buffer, service *specification.Service, apiPackageName string, types servergen.Types) error {
 	buf.WriteString("func Test_handleRequest(t *testing.T) {\n")
-	buf.WriteString("\tgin.SetMode(gin.TestMode)\n\n")
+	buf.WriteString("\tt.Parallel()\n\n")
 
 	buf.WriteString("\tt.Run(\"successful request parsing\", func(t *testing.T) {\n")
+	buf.WriteString("\t\tt.Parallel()\n")
 	buf.WriteString("\t\tc, _ := gin.CreateTestContext(httptest.NewRecorder())\n\n")
 
 	buf.WriteString("\t\t// Create test request with JSON body\n")
@@ -1094,8 +2016,8 @@ func generateHandleRequestTest(buf *bytes.Buffer, apiPackageName string) error {
 	buf.WriteString("\t\t\tErrorHook: func(ctx context.Context, requestContext " + apiPackageName + ".RequestContext, session *any, err error) *" + apiPackageName + ".Error {\n")
 	buf.WriteString("\t\t\t\treturn &" + apiPackageName + ".Error{\n")
 	buf.WriteString("\t\t\t\t\tCode:      " + apiPackageName + ".ErrorCodeInternal,\n")
-	buf.WriteString("\t\t\t\t\tMessage:   types.NewString(err.Error()),\n")
-	buf.WriteString("\t\t\t\t\tRequestID: types.NewString(requestContext.RequestID),\n")
+	buf.WriteString("\t\t\t\t\t" + service.GetErrorMessageFieldName() + ":   " + types.String("err.Error()") + ",\n")
+	buf.WriteString("\t\t\t\t\tRequestID: " + types.String("requestContext.RequestID") + ",\n")
 	buf.WriteString("\t\t\t\t}\n")
 	buf.WriteString("\t\t\t},\n")
 	buf.WriteString("\t\t}\n\n")
@@ -1109,6 +2031,7 @@ func generateHandleRequestTest(buf *bytes.Buffer, apiPackageName string) error {
 	buf.WriteString("\t})\n\n")
 
 	buf.WriteString("\tt.Run(\"session function error returns API error\", func(t *testing.T) {\n")
+	buf.WriteString("\t\tt.Parallel()\n")
 	buf.WriteString("\t\tc, _ := gin.CreateTestContext(httptest.NewRecorder())\n")
 	buf.WriteString("\t\treq, err := http.NewRequest(\"POST\", \"/test\", nil)\n")
 	buf.WriteString("\t\tassert.NoError(t, err, \"Failed to create request\")\n")
@@ -1122,8 +2045,8 @@ func generateHandleRequestTest(buf *bytes.Buffer, apiPackageName string) error {
 	buf.WriteString("\t\t\tErrorHook: func(ctx context.Context, requestContext " + apiPackageName + ".RequestContext, session *any, err error) *" + apiPackageName + ".Error {\n")
 	buf.WriteString("\t\t\t\treturn &" + apiPackageName + ".Error{\n")
 	buf.WriteString("\t\t\t\t\tCode:      " + apiPackageName + ".ErrorCodeInternal,\n")
-	buf.WriteString("\t\t\t\t\tMessage:   types.NewString(err.Error()),\n")
-	buf.WriteString("\t\t\t\t\tRequestID: types.NewString(requestContext.RequestID),\n")
+	buf.WriteString("\t\t\t\t\t" + service.GetErrorMessageFieldName() + ":   " + types.String("err.Error()") + ",\n")
+	buf.WriteString("\t\t\t\t\tRequestID: " + types.String("requestContext.RequestID") + ",\n")
 	buf.WriteString("\t\t\t\t}\n")
 	buf.WriteString("\t\t\t},\n")
 	buf.WriteString("\t\t}\n\n")
@@ -1135,11 +2058,13 @@ func generateHandleRequestTest(buf *bytes.Buffer, apiPackageName string) error {
 	buf.WriteString("\t\tapiError, ok := err.(*" + apiPackageName + ".Error)\n")
 	buf.WriteString("\t\tassert.True(t, ok, \"Error should be of type *Error\")\n")
 	buf.WriteString("\t\tassert.Equal(t, " + apiPackageName + ".ErrorCodeUnauthorized, apiError.Code, \"Should return Unauthorized error code\")\n")
-	buf.WriteString("\t\tassert.Contains(t, apiError.Message.String(), \"authentication failed\", \"Error message should contain session error\")\n")
-	buf.WriteString("\t\tassert.Equal(t, \"test-456\", apiError.RequestID.String(), \"Request ID should be set in error\")\n")
+	buf.WriteString("\t\tassert.Contains(t, " + types.StringValue("apiError."+service.GetErrorMessageFieldName()) + ", \"authentication failed\", \"Error message should contain session error\")\n")
+	buf.WriteString("\t\tassert.Equal(t, \"test-456\", " + types.StringValue("apiError.RequestID") + ", \"Request ID should be set in error\")\n")
+	buf.WriteString("\t\tassert.EqualError(t, apiError.Unwrap(), \"authentication failed\", \"Error should wrap the session error\")\n")
 	buf.WriteString("\t})\n\n")
 
 	buf.WriteString("\tt.Run(\"invalid JSON body returns API error\", func(t *testing.T) {\n")
+	buf.WriteString("\t\tt.Parallel()\n")
 	buf.WriteString("\t\t// Define test struct for body params\n")
 	buf.WriteString("\t\ttype TestBodyParams struct {\n")
 	buf.WriteString("\t\t\tName string `json:\"name\"`\n")
@@ -1160,8 +2085,8 @@ func generateHandleRequestTest(buf *bytes.Buffer, apiPackageName string) error {
 	buf.WriteString("\t\t\tErrorHook: func(ctx context.Context, requestContext " + apiPackageName + ".RequestContext, session *any, err error) *" + apiPackageName + ".Error {\n")
 	buf.WriteString("\t\t\t\treturn &" + apiPackageName + ".Error{\n")
 	buf.WriteString("\t\t\t\t\tCode:      " + apiPackageName + ".ErrorCodeInternal,\n")
-	buf.WriteString("\t\t\t\t\tMessage:   types.NewString(err.Error()),\n")
-	buf.WriteString("\t\t\t\t\tRequestID: types.NewString(requestContext.RequestID),\n")
+	buf.WriteString("\t\t\t\t\t" + service.GetErrorMessageFieldName() + ":   " + types.String("err.Error()") + ",\n")
+	buf.WriteString("\t\t\t\t\tRequestID: " + types.String("requestContext.RequestID") + ",\n")
 	buf.WriteString("\t\t\t\t}\n")
 	buf.WriteString("\t\t\t},\n")
 	buf.WriteString("\t\t}\n\n")
@@ -1173,11 +2098,13 @@ func generateHandleRequestTest(buf *bytes.Buffer, apiPackageName string) error {
 	buf.WriteString("\t\tapiError, ok := err.(*" + apiPackageName + ".Error)\n")
 	buf.WriteString("\t\tassert.True(t, ok, \"Error should be of type *Error\")\n")
 	buf.WriteString("\t\tassert.Equal(t, " + apiPackageName + ".ErrorCodeBadRequest, apiError.Code, \"Should return BadRequest error code\")\n")
-	buf.WriteString("\t\tassert.Contains(t, apiError.Message.String(), \"cannot decode json body params\", \"Error message should mention JSON decoding\")\n")
-	buf.WriteString("\t\tassert.Equal(t, \"test-789\", apiError.RequestID.String(), \"Request ID should be set in error\")\n")
+	buf.WriteString("\t\tassert.Contains(t, " + types.StringValue("apiError."+service.GetErrorMessageFieldName()) + ", " + strconv.Quote(servergen.BodyParamsDecodeError(service)) + ", \"Error message should mention decoding the body\")\n")
+	buf.WriteString("\t\tassert.Equal(t, \"test-789\", " + types.StringValue("apiError.RequestID") + ", \"Request ID should be set in error\")\n")
+	buf.WriteString("\t\tassert.Error(t, apiError.Unwrap(), \"Error should wrap the decoding error\")\n")
 	buf.WriteString("\t})\n\n")
 
 	buf.WriteString("\tt.Run(\"pre-hooks are executed in handleRequest\", func(t *testing.T) {\n")
+	buf.WriteString("\t\tt.Parallel()\n")
 	buf.WriteString("\t\tc, _ := gin.CreateTestContext(httptest.NewRecorder())\n")
 	buf.WriteString("\t\treq, err := http.NewRequest(\"POST\", \"/test\", nil)\n")
 	buf.WriteString("\t\tassert.NoError(t, err, \"Failed to create request\")\n")
@@ -1194,8 +2121,8 @@ func generateHandleRequestTest(buf *bytes.Buffer, apiPackageName string) error {
 	buf.WriteString("\t\t\tErrorHook: func(ctx context.Context, requestContext " + apiPackageName + ".RequestContext, session *any, err error) *" + apiPackageName + ".Error {\n")
 	buf.WriteString("\t\t\t\treturn &" + apiPackageName + ".Error{\n")
 	buf.WriteString("\t\t\t\t\tCode:      " + apiPackageName + ".ErrorCodeInternal,\n")
-	buf.WriteString("\t\t\t\t\tMessage:   types.NewString(err.Error()),\n")
-	buf.WriteString("\t\t\t\t\tRequestID: types.NewString(requestContext.RequestID),\n")
+	buf.WriteString("\t\t\t\t\t" + service.GetErrorMessageFieldName() + ":   " + types.String("err.Error()") + ",\n")
+	buf.WriteString("\t\t\t\t\tRequestID: " + types.String("requestContext.RequestID") + ",\n")
 	buf.WriteString("\t\t\t\t}\n")
 	buf.WriteString("\t\t\t},\n")
 	buf.WriteString("\t\t\tPreHooks: []" + apiPackageName + ".PreHook{\n")
@@ -1217,6 +2144,7 @@ func generateHandleRequestTest(buf *bytes.Buffer, apiPackageName string) error {
 	buf.WriteString("\t})\n\n")
 
 	buf.WriteString("\tt.Run(\"pre-hook error aborts handleRequest\", func(t *testing.T) {\n")
+	buf.WriteString("\t\tt.Parallel()\n")
 	buf.WriteString("\t\tc, _ := gin.CreateTestContext(httptest.NewRecorder())\n")
 	buf.WriteString("\t\treq, err := http.NewRequest(\"POST\", \"/test\", nil)\n")
 	buf.WriteString("\t\tassert.NoError(t, err, \"Failed to create request\")\n")
@@ -1230,8 +2158,8 @@ func generateHandleRequestTest(buf *bytes.Buffer, apiPackageName string) error {
 	buf.WriteString("\t\t\tErrorHook: func(ctx context.Context, requestContext " + apiPackageName + ".RequestContext, session *any, err error) *" + apiPackageName + ".Error {\n")
 	buf.WriteString("\t\t\t\treturn &" + apiPackageName + ".Error{\n")
 	buf.WriteString("\t\t\t\t\tCode:      " + apiPackageName + ".ErrorCodeForbidden,\n")
-	buf.WriteString("\t\t\t\t\tMessage:   types.NewString(err.Error()),\n")
-	buf.WriteString("\t\t\t\t\tRequestID: types.NewString(requestContext.RequestID),\n")
+	buf.WriteString("\t\t\t\t\t" + service.GetErrorMessageFieldName() + ":   " + types.String("err.Error()") + ",\n")
+	buf.WriteString("\t\t\t\t\tRequestID: " + types.String("requestContext.RequestID") + ",\n")
 	buf.WriteString("\t\t\t\t}\n")
 	buf.WriteString("\t\t\t},\n")
 	buf.WriteString("\t\t\tPreHooks: []" + apiPackageName + ".PreHook{\n")
@@ -1249,6 +2177,7 @@ func generateHandleRequestTest(buf *bytes.Buffer, apiPackageName string) error {
 	buf.WriteString("\t})\n\n")
 
 	buf.WriteString("\tt.Run(\"session-hooks are executed after authentication\", func(t *testing.T) {\n")
+	buf.WriteString("\t\tt.Parallel()\n")
 	buf.WriteString("\t\tc, _ := gin.CreateTestContext(httptest.NewRecorder())\n")
 	buf.WriteString("\t\treq, err := http.NewRequest(\"POST\", \"/test\", nil)\n")
 	buf.WriteString("\t\tassert.NoError(t, err, \"Failed to create request\")\n")
@@ -1266,8 +2195,8 @@ func generateHandleRequestTest(buf *bytes.Buffer, apiPackageName string) error {
 	buf.WriteString("\t\t\tErrorHook: func(ctx context.Context, requestContext " + apiPackageName + ".RequestContext, session *string, err error) *" + apiPackageName + ".Error {\n")
 	buf.WriteString("\t\t\t\treturn &" + apiPackageName + ".Error{\n")
 	buf.WriteString("\t\t\t\t\tCode:      " + apiPackageName + ".ErrorCodeInternal,\n")
-	buf.WriteString("\t\t\t\t\tMessage:   types.NewString(err.Error()),\n")
-	buf.WriteString("\t\t\t\t\tRequestID: types.NewString(requestContext.RequestID),\n")
+	buf.WriteString("\t\t\t\t\t" + service.GetErrorMessageFieldName() + ":   " + types.String("err.Error()") + ",\n")
+	buf.WriteString("\t\t\t\t\tRequestID: " + types.String("requestContext.RequestID") + ",\n")
 	buf.WriteString("\t\t\t\t}\n")
 	buf.WriteString("\t\t\t},\n")
 	buf.WriteString("\t\t\tSessionHooks: []" + apiPackageName + ".SessionHook[string]{\n")
@@ -1290,6 +2219,7 @@ func generateHandleRequestTest(buf *bytes.Buffer, apiPackageName string) error {
 	buf.WriteString("\t})\n\n")
 
 	buf.WriteString("\tt.Run(\"session-hook error aborts request\", func(t *testing.T) {\n")
+	buf.WriteString("\t\tt.Parallel()\n")
 	buf.WriteString("\t\tc, _ := gin.CreateTestContext(httptest.NewRecorder())\n")
 	buf.WriteString("\t\treq, err := http.NewRequest(\"POST\", \"/test\", nil)\n")
 	buf.WriteString("\t\tassert.NoError(t, err, \"Failed to create request\")\n")
@@ -1303,8 +2233,8 @@ func generateHandleRequestTest(buf *bytes.Buffer, apiPackageName string) error {
 	buf.WriteString("\t\t\tErrorHook: func(ctx context.Context, requestContext " + apiPackageName + ".RequestContext, session *string, err error) *" + apiPackageName + ".Error {\n")
 	buf.WriteString("\t\t\t\treturn &" + apiPackageName + ".Error{\n")
 	buf.WriteString("\t\t\t\t\tCode:      " + apiPackageName + ".ErrorCodeForbidden,\n")
-	buf.WriteString("\t\t\t\t\tMessage:   types.NewString(err.Error()),\n")
-	buf.WriteString("\t\t\t\t\tRequestID: types.NewString(requestContext.RequestID),\n")
+	buf.WriteString("\t\t\t\t\t" + service.GetErrorMessageFieldName() + ":   " + types.String("err.Error()") + ",\n")
+	buf.WriteString("\t\t\t\t\tRequestID: " + types.String("requestContext.RequestID") + ",\n")
 	buf.WriteString("\t\t\t\t}\n")
 	buf.WriteString("\t\t\t},\n")
 	buf.WriteString("\t\t\tSessionHooks: []" + apiPackageName + ".SessionHook[string]{\n")
@@ -1328,6 +2258,7 @@ func generateHandleRequestTest(buf *bytes.Buffer, apiPackageName string) error {
 // generateDecodeBodyParamsTest generates test for decodeBodyParams function.
 func generateDecodeBodyParamsTest(buf *bytes.Buffer, apiPackageName string) error {
 	buf.WriteString("func Test_decodeBodyParams(t *testing.T) {\n")
+	buf.WriteString("\tt.Parallel()\n")
 	buf.WriteString("\t// Define test struct\n")
 	buf.WriteString("\ttype TestBody struct {\n")
 	buf.WriteString("\t\tName string `json:\"name\"`\n")
@@ -1352,13 +2283,13 @@ func generateDecodeBodyParamsTest(buf *bytes.Buffer, apiPackageName string) erro
 // generateDecodePathParamsTest generates test for decodePathParams function.
 func generateDecodePathParamsTest(buf *bytes.Buffer, apiPackageName string) error {
 	buf.WriteString("func Test_decodePathParams(t *testing.T) {\n")
+	buf.WriteString("\tt.Parallel()\n")
 	buf.WriteString("\t// Define test struct\n")
 	buf.WriteString("\ttype TestPathParams struct {\n")
 	buf.WriteString("\t\tID   string `json:\"id\"`\n")
 	buf.WriteString("\t\tName string `json:\"name\"`\n")
 	buf.WriteString("\t}\n\n")
 
-	buf.WriteString("\tgin.SetMode(gin.TestMode)\n")
 	buf.WriteString("\tc, _ := gin.CreateTestContext(httptest.NewRecorder())\n")
 	buf.WriteString("\tc.Params = []gin.Param{\n")
 	buf.WriteString("\t\t{Key: \"id\", Value: \"123\"},\n")
@@ -1378,6 +2309,7 @@ func generateDecodePathParamsTest(buf *bytes.Buffer, apiPackageName string) erro
 // generateDecodeQueryParamsTest generates test for decodeQueryParams function.
 func generateDecodeQueryParamsTest(buf *bytes.Buffer, apiPackageName string) error {
 	buf.WriteString("func Test_decodeQueryParams(t *testing.T) {\n")
+	buf.WriteString("\tt.Parallel()\n")
 	buf.WriteString("\t// Define test struct\n")
 	buf.WriteString("\ttype TestQueryParams struct {\n")
 	buf.WriteString("\t\tLimit  int  `form:\"limit\"`\n")
@@ -1385,7 +2317,6 @@ func generateDecodeQueryParamsTest(buf *bytes.Buffer, apiPackageName string) err
 	buf.WriteString("\t\tActive bool `form:\"active\"`\n")
 	buf.WriteString("\t}\n\n")
 
-	buf.WriteString("\tgin.SetMode(gin.TestMode)\n")
 	buf.WriteString("\tc, _ := gin.CreateTestContext(httptest.NewRecorder())\n")
 	buf.WriteString("\treq, err := http.NewRequest(\"GET\", \"/test?limit=10&offset=0&active=true\", nil)\n")
 	buf.WriteString("\tassert.NoError(t, err, \"Failed to create request\")\n")
@@ -1403,13 +2334,16 @@ func generateDecodeQueryParamsTest(buf *bytes.Buffer, apiPackageName string) err
 }
 
 // generateInternalEndpointTest generates an internal test function for a specific endpoint.
-func generateInternalEndpointTest(buf *bytes.Buffer, service *specification.Service, resource specification.Resource, endpoint specification.Endpoint) error {
+func generateInternalEndpointTest(buf *bytes.Buffer, service *specification.Service, resource specification.Resource, endpoint specification.Endpoint, options Options) error {
+	types := options.Types
 	serviceName := strmangle.TitleCase(service.Name)
 	testName := fmt.Sprintf("Test%s%s", resource.Name, endpoint.Name)
 
 	buf.WriteString(fmt.Sprintf("// %s tests the %s endpoint for %s\n", testName, endpoint.Name, resource.Name))
 	buf.WriteString(fmt.Sprintf("func %s(t *testing.T) {\n", testName))
+	buf.WriteString("\tt.Parallel()\n")
 	buf.WriteString("\tt.Run(\"Request\", func(t *testing.T) {\n")
+	buf.WriteString("\t\tt.Parallel()\n")
 
 	// Generate test setup (without parameters)
 	err := generateTestSetup(buf, service, resource, endpoint)
@@ -1424,7 +2358,7 @@ func generateInternalEndpointTest(buf *bytes.Buffer, service *specification.Serv
 	}
 
 	// Generate internal server setup (no package prefixes)
-	err = generateInternalServerSetup(buf, serviceName, service, resource, endpoint)
+	err = generateInternalServerSetup(buf, serviceName, service, resource, endpoint, types)
 	if err != nil {
 		return err
 	}
@@ -1441,12 +2375,180 @@ func generateInternalEndpointTest(buf *bytes.Buffer, service *specification.Serv
 		return err
 	}
 
+	if options.GoldenFiles {
+		generateGoldenFileAssertion(buf, resource, endpoint)
+	}
+
 	buf.WriteString("\t})\n")
+
+	if endpoint.IsConditionalGet(service) {
+		buf.WriteString("\n\tt.Run(\"NotModified\", func(t *testing.T) {\n")
+		buf.WriteString("\t\tt.Parallel()\n")
+
+		err = generateTestSetup(buf, service, resource, endpoint)
+		if err != nil {
+			return err
+		}
+
+		err = generateInternalMockSetup(buf, service, resource, endpoint)
+		if err != nil {
+			return err
+		}
+
+		err = generateInternalServerSetup(buf, serviceName, service, resource, endpoint, types)
+		if err != nil {
+			return err
+		}
+
+		err = generateNotModifiedRequest(buf, service, resource, endpoint, types)
+		if err != nil {
+			return err
+		}
+
+		buf.WriteString("\t})\n")
+	}
+
+	if service.Strict && hasJSONRequestBody(endpoint) {
+		buf.WriteString("\n\tt.Run(\"UnknownField\", func(t *testing.T) {\n")
+		buf.WriteString("\t\tt.Parallel()\n")
+
+		err = generateTestSetup(buf, service, resource, endpoint)
+		if err != nil {
+			return err
+		}
+
+		err = generateInternalMockSetup(buf, service, resource, endpoint)
+		if err != nil {
+			return err
+		}
+
+		err = generateInternalServerSetup(buf, serviceName, service, resource, endpoint, types)
+		if err != nil {
+			return err
+		}
+
+		err = generateUnknownFieldRequest(buf, service, resource, endpoint)
+		if err != nil {
+			return err
+		}
+
+		buf.WriteString("\t})\n")
+	}
+
+	if servergen.ClampsLimit(service, endpoint) {
+		buf.WriteString("\n\tt.Run(\"MaxLimit\", func(t *testing.T) {\n")
+		buf.WriteString("\t\tt.Parallel()\n")
+
+		err = generateTestSetup(buf, service, resource, endpoint)
+		if err != nil {
+			return err
+		}
+
+		err = generateInternalMockSetup(buf, service, resource, endpoint)
+		if err != nil {
+			return err
+		}
+
+		err = generateInternalServerSetup(buf, serviceName, service, resource, endpoint, types)
+		if err != nil {
+			return err
+		}
+
+		err = generateMaxLimitRequest(buf, service, resource, endpoint, "", types)
+		if err != nil {
+			return err
+		}
+
+		buf.WriteString("\t})\n")
+	}
+
+	if hasJSONRequestBody(endpoint) {
+		buf.WriteString("\n\tt.Run(\"Body\", func(t *testing.T) {\n")
+		buf.WriteString("\t\tt.Parallel()\n")
+		generateBodyCases(buf, service, endpoint)
+		buf.WriteString("\t\tfor _, tt := range tests {\n")
+		buf.WriteString("\t\t\tt.Run(tt.name, func(t *testing.T) {\n")
+		buf.WriteString("\t\t\t\tt.Parallel()\n")
+
+		err = generateTestSetup(buf, service, resource, endpoint)
+		if err != nil {
+			return err
+		}
+
+		err = generateInternalMockSetup(buf, service, resource, endpoint)
+		if err != nil {
+			return err
+		}
+
+		err = generateInternalServerSetup(buf, serviceName, service, resource, endpoint, types)
+		if err != nil {
+			return err
+		}
+
+		err = generateBodyCaseRequest(buf, service, resource, endpoint)
+		if err != nil {
+			return err
+		}
+
+		buf.WriteString("\t\t\t})\n")
+		buf.WriteString("\t\t}\n")
+		buf.WriteString("\t})\n")
+	}
+
 	buf.WriteString("}\n\n")
 
 	return nil
 }
 
+// generateGoldenFileAssertion generates the comparison of the JSON response of the endpoint with its golden file,
+// named after the resource and the endpoint. Endpoints without a JSON response have no golden file.
+func generateGoldenFileAssertion(buf *bytes.Buffer, resource specification.Resource, endpoint specification.Endpoint) {
+	if !endpoint.HasResponseType() || endpoint.HasCSVResponse() || endpoint.HasXMLResponse() {
+		return
+	}
+
+	buf.WriteString("\t\t// Verify the shape of the response against the golden file\n")
+	buf.WriteString(fmt.Sprintf("\t\tassertGoldenFile(t, %q, responseBodyBytes)\n", resource.Name+endpoint.Name))
+}
+
+// generateGoldenFileHelpers generates the -update flag and the assertGoldenFile helper of the golden files.
+func generateGoldenFileHelpers(buf *bytes.Buffer) {
+	buf.WriteString(`// updateGoldenFiles records the responses into the golden files instead of comparing them, after an intended change
+var updateGoldenFiles = flag.Bool("update", false, "record the responses into the golden files")
+
+// assertGoldenFile compares the JSON response body with the golden file testdata/golden/<name>.json, regardless of
+// the order of the keys. The file is recorded, indented, when it does not exist yet or the tests are run with -update.
+func assertGoldenFile(t *testing.T, name string, body []byte) {
+	t.Helper()
+
+	path := filepath.Join("testdata", "golden", name+".json")
+	golden, err := os.ReadFile(path)
+	if *updateGoldenFiles || os.IsNotExist(err) {
+		var indented bytes.Buffer
+		if err := json.Indent(&indented, body, "", "  "); err != nil {
+			t.Fatalf("Failed to indent the response body: %v", err)
+		}
+		indented.WriteString("\n")
+
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("Failed to create the golden file directory: %v", err)
+		}
+		if err := os.WriteFile(path, indented.Bytes(), 0o644); err != nil {
+			t.Fatalf("Failed to record the golden file: %v", err)
+		}
+		t.Logf("Recorded the response into %s", path)
+		return
+	}
+	if err != nil {
+		t.Fatalf("Failed to read the golden file: %v", err)
+	}
+
+	assert.JSONEq(t, string(golden), string(body), "Response should match the golden file %s, run the tests with -update to record an intended change", path)
+}
+
+`)
+}
+
 // generateInternalMockSetup generates internal mock service setup.
 func generateInternalMockSetup(buf *bytes.Buffer, service *specification.Service, resource specification.Resource, endpoint specification.Endpoint) error {
 	buf.WriteString("\t\t// Mock service setup\n")
@@ -1462,7 +2564,7 @@ func generateInternalMockSetup(buf *bytes.Buffer, service *specification.Service
 }
 
 // generateInternalServerSetup generates internal HTTP server setup.
-func generateInternalServerSetup(buf *bytes.Buffer, serviceName string, service *specification.Service, currentResource specification.Resource, currentEndpoint specification.Endpoint) error {
+func generateInternalServerSetup(buf *bytes.Buffer, serviceName string, service *specification.Service, currentResource specification.Resource, currentEndpoint specification.Endpoint, types servergen.Types) error {
 	buf.WriteString("\t\t// Server setup\n")
 	buf.WriteString("\t\trouter := gin.New()\n")
 
@@ -1470,10 +2572,23 @@ func generateInternalServerSetup(buf *bytes.Buffer, serviceName string, service
 	buf.WriteString(fmt.Sprintf("\t\tmock%sAPI := &Mock%sAPI{}\n", currentResource.Name, currentResource.Name))
 
 	methodName := currentEndpoint.Name
-	if currentEndpoint.HasResponseType() {
+	if currentEndpoint.HasCSVResponse() {
+		rowType := *currentEndpoint.Response.BodyObject
+		buf.WriteString(fmt.Sprintf("\t\texpectedRow := &%s{}\n", rowType))
+		buf.WriteString(fmt.Sprintf("\t\tmock%sAPI.%sFunc = func(ctx context.Context, request Request[any, %s, %s, %s], write func(row *%s) error) error {\n",
+			currentResource.Name, methodName,
+			getInternalTypeReference(currentEndpoint.GetPathParamsType(currentResource.Name)),
+			getInternalTypeReference(currentEndpoint.GetQueryParamsType(currentResource.Name)),
+			getInternalTypeReference(currentEndpoint.GetBodyParamsType(currentResource.Name)),
+			rowType))
+		buf.WriteString("\t\t\tcapturedRequest = request\n")
+		buf.WriteString("\t\t\treturn write(expectedRow)\n")
+		buf.WriteString("\t\t}\n")
+	} else if currentEndpoint.HasResponseType() {
 		responseType := currentEndpoint.GetResponseType(currentResource.Name)
 		buf.WriteString(fmt.Sprintf("\t\texpected%s := &%s{\n", responseType, responseType))
 		buf.WriteString("\t\t\t// Add expected response fields here based on your needs\n")
+		generateResponseHeaderFields(buf, currentEndpoint, types)
 		buf.WriteString("\t\t}\n")
 		buf.WriteString(fmt.Sprintf("\t\tmock%sAPI.%sFunc = func(ctx context.Context, request Request[any, %s, %s, %s]) (*%s, error) {\n",
 			currentResource.Name, methodName,
@@ -1502,12 +2617,20 @@ func generateInternalServerSetup(buf *bytes.Buffer, serviceName string, service
 	buf.WriteString("\t\t\t\t\treturn testSessionUserID, nil\n")
 	buf.WriteString("\t\t\t\t},\n")
 	buf.WriteString("\t\t\t\tErrorHook: func(ctx context.Context, requestContext RequestContext, session *any, err error) *Error {\n")
+	// The errors of the request handling, such as a body that cannot be decoded, keep their status code
+	buf.WriteString("\t\t\t\t\tif apiError, ok := err.(*Error); ok {\n")
+	buf.WriteString("\t\t\t\t\t\treturn apiError\n")
+	buf.WriteString("\t\t\t\t\t}\n")
 	buf.WriteString("\t\t\t\t\treturn &Error{\n")
 	buf.WriteString("\t\t\t\t\t\tCode:      ErrorCodeInternal,\n")
-	buf.WriteString("\t\t\t\t\t\tMessage:   types.NewString(err.Error()),\n")
-	buf.WriteString("\t\t\t\t\t\tRequestID: types.NewString(requestContext.RequestID),\n")
+	buf.WriteString("\t\t\t\t\t\t" + service.GetErrorMessageFieldName() + ":   " + types.String("err.Error()") + ",\n")
+	buf.WriteString("\t\t\t\t\t\tRequestID: " + types.String("requestContext.RequestID") + ",\n")
 	buf.WriteString("\t\t\t\t\t}\n")
 	buf.WriteString("\t\t\t\t},\n")
+	generateResponseEncoders(buf, service, "")
+	generateHasScopeFunc(buf, service)
+	generateCheckScopesFunc(buf, service, "")
+	generateFeatureEnabledFunc(buf, service)
 	buf.WriteString("\t\t\t},\n")
 
 	// Add all resource mocks to the API struct
@@ -1546,7 +2669,17 @@ func generateInternalAssertions(buf *bytes.Buffer, service *specification.Servic
 	buf.WriteString("\t\t\treturn\n")
 	buf.WriteString("\t\t}\n\n")
 
-	if endpoint.HasResponseType() {
+	generateResponseHeaderFieldAssertions(buf, endpoint)
+
+	if endpoint.HasCSVResponse() {
+		buf.WriteString("\t\t// Verify response body, a header row and the row written by the service method\n")
+		buf.WriteString("\t\tassert.Equal(t, \"text/csv; charset=utf-8\", resp.Header.Get(\"Content-Type\"), \"Response should be CSV\")\n")
+		buf.WriteString("\t\trecords, err := csv.NewReader(bytes.NewReader(responseBodyBytes)).ReadAll()\n")
+		buf.WriteString("\t\tassert.NoError(t, err, \"Failed to decode response body\")\n")
+		buf.WriteString("\t\tassert.Len(t, records, 2, \"Response body should have a header row and a row\")\n")
+	} else if endpoint.HasXMLResponse() {
+		generateXMLResponseAssertions(buf, resource, endpoint)
+	} else if endpoint.HasResponseType() {
 		buf.WriteString("\t\t// Verify response body\n")
 		buf.WriteString("\t\tvar responseBody map[string]interface{}\n")
 		buf.WriteString("\t\terr = json.Unmarshal(responseBodyBytes, &responseBody)\n")
@@ -1569,8 +2702,8 @@ func generateInternalAssertions(buf *bytes.Buffer, service *specification.Servic
 		buf.WriteString("\t\tassert.NoError(t, err, \"Failed to unmarshal captured path params\")\n\n")
 
 		for _, param := range endpoint.Request.PathParams {
-			varName := fmt.Sprintf("test%s%s", "Path", strmangle.TitleCase(param.Name))
-			jsonKey := getJSONKey(param.Name)
+			varName := fmt.Sprintf("test%s%s", "Path", param.GetGoName())
+			jsonKey := getJSONKey(param)
 			buf.WriteString(fmt.Sprintf("\t\tassert.Equal(t, %s, capturedPathParams[\"%s\"], \"Path parameter %s should match\")\n",
 				varName, jsonKey, param.Name))
 		}
@@ -1587,10 +2720,10 @@ func generateInternalAssertions(buf *bytes.Buffer, service *specification.Servic
 		buf.WriteString("\t\tassert.NoError(t, err, \"Failed to unmarshal captured query params\")\n\n")
 
 		for _, param := range endpoint.Request.QueryParams {
-			varName := fmt.Sprintf("test%s%s", "Query", strmangle.TitleCase(param.Name))
-			jsonKey := getJSONKey(param.Name)
+			varName := fmt.Sprintf("test%s%s", "Query", param.GetGoName())
+			jsonKey := getJSONKey(param)
 			buf.WriteString(fmt.Sprintf("\t\tassert.Equal(t, %s, capturedQueryParams[\"%s\"], \"Query parameter %s should match\")\n",
-				varName, jsonKey, param.Name))
+				getExpectedQueryValue(param, varName), jsonKey, param.Name))
 		}
 		buf.WriteString("\n")
 	}
@@ -1605,7 +2738,7 @@ func generateInternalAssertions(buf *bytes.Buffer, service *specification.Servic
 		buf.WriteString("\t\tassert.NoError(t, err, \"Failed to unmarshal captured body params\")\n\n")
 
 		for _, param := range endpoint.Request.BodyParams {
-			jsonKey := getJSONKey(param.Name)
+			jsonKey := getJSONKey(param)
 			buf.WriteString(fmt.Sprintf("\t\tassert.Equal(t, testBody[\"%s\"], capturedRequestBody[\"%s\"], \"Body parameter %s should match\")\n",
 				jsonKey, jsonKey, param.Name))
 		}
@@ -1637,7 +2770,14 @@ func generateInternalHelperFunctions(buf *bytes.Buffer, service *specification.S
 		for _, endpoint := range resource.Endpoints {
 			methodName := endpoint.Name
 
-			if endpoint.HasResponseType() {
+			if endpoint.HasCSVResponse() {
+				buf.WriteString(fmt.Sprintf("\t%sFunc func(ctx context.Context, request Request[any, %s, %s, %s], write func(row *%s) error) error\n",
+					methodName,
+					getInternalTypeReference(endpoint.GetPathParamsType(resource.Name)),
+					getInternalTypeReference(endpoint.GetQueryParamsType(resource.Name)),
+					getInternalTypeReference(endpoint.GetBodyParamsType(resource.Name)),
+					*endpoint.Response.BodyObject))
+			} else if endpoint.HasResponseType() {
 				responseType := endpoint.GetResponseType(resource.Name)
 				buf.WriteString(fmt.Sprintf("\t%sFunc func(ctx context.Context, request Request[any, %s, %s, %s]) (*%s, error)\n",
 					methodName,
@@ -1672,7 +2812,18 @@ func generateInternalHelperFunctions(buf *bytes.Buffer, service *specification.S
 func generateInternalMockMethod(buf *bytes.Buffer, resource specification.Resource, endpoint specification.Endpoint) error {
 	methodName := endpoint.Name
 
-	if endpoint.HasResponseType() {
+	if endpoint.HasCSVResponse() {
+		buf.WriteString(fmt.Sprintf("func (m *Mock%sAPI) %s(ctx context.Context, request Request[any, %s, %s, %s], write func(row *%s) error) error {\n",
+			resource.Name, methodName,
+			getInternalTypeReference(endpoint.GetPathParamsType(resource.Name)),
+			getInternalTypeReference(endpoint.GetQueryParamsType(resource.Name)),
+			getInternalTypeReference(endpoint.GetBodyParamsType(resource.Name)),
+			*endpoint.Response.BodyObject))
+		buf.WriteString(fmt.Sprintf("\tif m.%sFunc != nil {\n", methodName))
+		buf.WriteString(fmt.Sprintf("\t\treturn m.%sFunc(ctx, request, write)\n", methodName))
+		buf.WriteString("\t}\n")
+		buf.WriteString("\treturn nil\n")
+	} else if endpoint.HasResponseType() {
 		responseType := endpoint.GetResponseType(resource.Name)
 		buf.WriteString(fmt.Sprintf("func (m *Mock%sAPI) %s(ctx context.Context, request Request[any, %s, %s, %s]) (*%s, error) {\n",
 			resource.Name, methodName,
@@ -1702,14 +2853,14 @@ func generateInternalMockMethod(buf *bytes.Buffer, resource specification.Resour
 }
 
 // generateInternalUtilityTests generates internal tests for utility functions.
-func generateInternalUtilityTests(buf *bytes.Buffer, service *specification.Service) error {
+func generateInternalUtilityTests(buf *bytes.Buffer, service *specification.Service, types servergen.Types) error {
 	buf.WriteString("// ============================================================================\n")
 	buf.WriteString("// Utility function tests\n")
 	buf.WriteString("// ============================================================================\n\n")
 
 	// Test serveWithResponse (no package prefixes)
 	buf.WriteString("func Test_serveWithResponse(t *testing.T) {\n")
-	buf.WriteString("\tgin.SetMode(gin.TestMode)\n")
+	buf.WriteString("\tt.Parallel()\n")
 	buf.WriteString("\trouter := gin.New()\n\n")
 
 	buf.WriteString("\t// Mock function that returns a response\n")
@@ -1725,8 +2876,8 @@ func generateInternalUtilityTests(buf *bytes.Buffer, service *specification.Serv
 	buf.WriteString("\t\tErrorHook: func(ctx context.Context, requestContext RequestContext, session *any, err error) *Error {\n")
 	buf.WriteString("\t\t\treturn &Error{\n")
 	buf.WriteString("\t\t\t\tCode:      ErrorCodeInternal,\n")
-	buf.WriteString("\t\t\t\tMessage:   types.NewString(err.Error()),\n")
-	buf.WriteString("\t\t\t\tRequestID: types.NewString(requestContext.RequestID),\n")
+	buf.WriteString("\t\t\t\t" + service.GetErrorMessageFieldName() + ":   " + types.String("err.Error()") + ",\n")
+	buf.WriteString("\t\t\t\tRequestID: " + types.String("requestContext.RequestID") + ",\n")
 	buf.WriteString("\t\t\t}\n")
 	buf.WriteString("\t\t},\n")
 	buf.WriteString("\t}\n\n")
@@ -1748,7 +2899,7 @@ func generateInternalUtilityTests(buf *bytes.Buffer, service *specification.Serv
 
 	// Test serveWithoutResponse
 	buf.WriteString("func Test_serveWithoutResponse(t *testing.T) {\n")
-	buf.WriteString("\tgin.SetMode(gin.TestMode)\n")
+	buf.WriteString("\tt.Parallel()\n")
 	buf.WriteString("\trouter := gin.New()\n\n")
 
 	buf.WriteString("\t// Mock function that doesn't return a response\n")
@@ -1764,8 +2915,8 @@ func generateInternalUtilityTests(buf *bytes.Buffer, service *specification.Serv
 	buf.WriteString("\t\tErrorHook: func(ctx context.Context, requestContext RequestContext, session *any, err error) *Error {\n")
 	buf.WriteString("\t\t\treturn &Error{\n")
 	buf.WriteString("\t\t\t\tCode:      ErrorCodeInternal,\n")
-	buf.WriteString("\t\t\t\tMessage:   types.NewString(err.Error()),\n")
-	buf.WriteString("\t\t\t\tRequestID: types.NewString(requestContext.RequestID),\n")
+	buf.WriteString("\t\t\t\t" + service.GetErrorMessageFieldName() + ":   " + types.String("err.Error()") + ",\n")
+	buf.WriteString("\t\t\t\tRequestID: " + types.String("requestContext.RequestID") + ",\n")
 	buf.WriteString("\t\t\t}\n")
 	buf.WriteString("\t\t},\n")
 	buf.WriteString("\t}\n\n")
@@ -1786,9 +2937,10 @@ func generateInternalUtilityTests(buf *bytes.Buffer, service *specification.Serv
 
 	// Test handleRequest
 	buf.WriteString("func Test_handleRequest(t *testing.T) {\n")
-	buf.WriteString("\tgin.SetMode(gin.TestMode)\n\n")
+	buf.WriteString("\tt.Parallel()\n\n")
 
 	buf.WriteString("\tt.Run(\"successful request parsing\", func(t *testing.T) {\n")
+	buf.WriteString("\t\tt.Parallel()\n")
 	buf.WriteString("\t\tc, _ := gin.CreateTestContext(httptest.NewRecorder())\n\n")
 
 	buf.WriteString("\t\t// Create test request with JSON body\n")
@@ -1807,8 +2959,8 @@ func generateInternalUtilityTests(buf *bytes.Buffer, service *specification.Serv
 	buf.WriteString("\t\t\tErrorHook: func(ctx context.Context, requestContext RequestContext, session *any, err error) *Error {\n")
 	buf.WriteString("\t\t\t\treturn &Error{\n")
 	buf.WriteString("\t\t\t\t\tCode:      ErrorCodeInternal,\n")
-	buf.WriteString("\t\t\t\t\tMessage:   types.NewString(err.Error()),\n")
-	buf.WriteString("\t\t\t\t\tRequestID: types.NewString(requestContext.RequestID),\n")
+	buf.WriteString("\t\t\t\t\t" + service.GetErrorMessageFieldName() + ":   " + types.String("err.Error()") + ",\n")
+	buf.WriteString("\t\t\t\t\tRequestID: " + types.String("requestContext.RequestID") + ",\n")
 	buf.WriteString("\t\t\t\t}\n")
 	buf.WriteString("\t\t\t},\n")
 	buf.WriteString("\t\t}\n\n")
@@ -1822,6 +2974,7 @@ func generateInternalUtilityTests(buf *bytes.Buffer, service *specification.Serv
 	buf.WriteString("\t})\n\n")
 
 	buf.WriteString("\tt.Run(\"session function error returns API error\", func(t *testing.T) {\n")
+	buf.WriteString("\t\tt.Parallel()\n")
 	buf.WriteString("\t\tc, _ := gin.CreateTestContext(httptest.NewRecorder())\n")
 	buf.WriteString("\t\treq, err := http.NewRequest(\"POST\", \"/test\", nil)\n")
 	buf.WriteString("\t\tassert.NoError(t, err, \"Failed to create request\")\n")
@@ -1835,8 +2988,8 @@ func generateInternalUtilityTests(buf *bytes.Buffer, service *specification.Serv
 	buf.WriteString("\t\t\tErrorHook: func(ctx context.Context, requestContext RequestContext, session *any, err error) *Error {\n")
 	buf.WriteString("\t\t\t\treturn &Error{\n")
 	buf.WriteString("\t\t\t\t\tCode:      ErrorCodeInternal,\n")
-	buf.WriteString("\t\t\t\t\tMessage:   types.NewString(err.Error()),\n")
-	buf.WriteString("\t\t\t\t\tRequestID: types.NewString(requestContext.RequestID),\n")
+	buf.WriteString("\t\t\t\t\t" + service.GetErrorMessageFieldName() + ":   " + types.String("err.Error()") + ",\n")
+	buf.WriteString("\t\t\t\t\tRequestID: " + types.String("requestContext.RequestID") + ",\n")
 	buf.WriteString("\t\t\t\t}\n")
 	buf.WriteString("\t\t\t},\n")
 	buf.WriteString("\t\t}\n\n")
@@ -1848,11 +3001,13 @@ func generateInternalUtilityTests(buf *bytes.Buffer, service *specification.Serv
 	buf.WriteString("\t\tapiError, ok := err.(*Error)\n")
 	buf.WriteString("\t\tassert.True(t, ok, \"Error should be of type *Error\")\n")
 	buf.WriteString("\t\tassert.Equal(t, ErrorCodeUnauthorized, apiError.Code, \"Should return Unauthorized error code\")\n")
-	buf.WriteString("\t\tassert.Contains(t, apiError.Message.String(), \"authentication failed\", \"Error message should contain session error\")\n")
-	buf.WriteString("\t\tassert.Equal(t, \"test-456\", apiError.RequestID.String(), \"Request ID should be set in error\")\n")
+	buf.WriteString("\t\tassert.Contains(t, " + types.StringValue("apiError."+service.GetErrorMessageFieldName()) + ", \"authentication failed\", \"Error message should contain session error\")\n")
+	buf.WriteString("\t\tassert.Equal(t, \"test-456\", " + types.StringValue("apiError.RequestID") + ", \"Request ID should be set in error\")\n")
+	buf.WriteString("\t\tassert.EqualError(t, apiError.Unwrap(), \"authentication failed\", \"Error should wrap the session error\")\n")
 	buf.WriteString("\t})\n\n")
 
 	buf.WriteString("\tt.Run(\"invalid JSON body returns API error\", func(t *testing.T) {\n")
+	buf.WriteString("\t\tt.Parallel()\n")
 	buf.WriteString("\t\t// Define test struct for body params\n")
 	buf.WriteString("\t\ttype TestBodyParams struct {\n")
 	buf.WriteString("\t\t\tName string `json:\"name\"`\n")
@@ -1873,8 +3028,8 @@ func generateInternalUtilityTests(buf *bytes.Buffer, service *specification.Serv
 	buf.WriteString("\t\t\tErrorHook: func(ctx context.Context, requestContext RequestContext, session *any, err error) *Error {\n")
 	buf.WriteString("\t\t\t\treturn &Error{\n")
 	buf.WriteString("\t\t\t\t\tCode:      ErrorCodeInternal,\n")
-	buf.WriteString("\t\t\t\t\tMessage:   types.NewString(err.Error()),\n")
-	buf.WriteString("\t\t\t\t\tRequestID: types.NewString(requestContext.RequestID),\n")
+	buf.WriteString("\t\t\t\t\t" + service.GetErrorMessageFieldName() + ":   " + types.String("err.Error()") + ",\n")
+	buf.WriteString("\t\t\t\t\tRequestID: " + types.String("requestContext.RequestID") + ",\n")
 	buf.WriteString("\t\t\t\t}\n")
 	buf.WriteString("\t\t\t},\n")
 	buf.WriteString("\t\t}\n\n")
@@ -1886,11 +3041,13 @@ func generateInternalUtilityTests(buf *bytes.Buffer, service *specification.Serv
 	buf.WriteString("\t\tapiError, ok := err.(*Error)\n")
 	buf.WriteString("\t\tassert.True(t, ok, \"Error should be of type *Error\")\n")
 	buf.WriteString("\t\tassert.Equal(t, ErrorCodeBadRequest, apiError.Code, \"Should return BadRequest error code\")\n")
-	buf.WriteString("\t\tassert.Contains(t, apiError.Message.String(), \"cannot decode json body params\", \"Error message should mention JSON decoding\")\n")
-	buf.WriteString("\t\tassert.Equal(t, \"test-789\", apiError.RequestID.String(), \"Request ID should be set in error\")\n")
+	buf.WriteString("\t\tassert.Contains(t, " + types.StringValue("apiError."+service.GetErrorMessageFieldName()) + ", " + strconv.Quote(servergen.BodyParamsDecodeError(service)) + ", \"Error message should mention decoding the body\")\n")
+	buf.WriteString("\t\tassert.Equal(t, \"test-789\", " + types.StringValue("apiError.RequestID") + ", \"Request ID should be set in error\")\n")
+	buf.WriteString("\t\tassert.Error(t, apiError.Unwrap(), \"Error should wrap the decoding error\")\n")
 	buf.WriteString("\t})\n\n")
 
 	buf.WriteString("\tt.Run(\"pre-hooks are executed in handleRequest\", func(t *testing.T) {\n")
+	buf.WriteString("\t\tt.Parallel()\n")
 	buf.WriteString("\t\tc, _ := gin.CreateTestContext(httptest.NewRecorder())\n")
 	buf.WriteString("\t\treq, err := http.NewRequest(\"POST\", \"/test\", nil)\n")
 	buf.WriteString("\t\tassert.NoError(t, err, \"Failed to create request\")\n")
@@ -1907,8 +3064,8 @@ func generateInternalUtilityTests(buf *bytes.Buffer, service *specification.Serv
 	buf.WriteString("\t\t\tErrorHook: func(ctx context.Context, requestContext RequestContext, session *any, err error) *Error {\n")
 	buf.WriteString("\t\t\t\treturn &Error{\n")
 	buf.WriteString("\t\t\t\t\tCode:      ErrorCodeInternal,\n")
-	buf.WriteString("\t\t\t\t\tMessage:   types.NewString(err.Error()),\n")
-	buf.WriteString("\t\t\t\t\tRequestID: types.NewString(requestContext.RequestID),\n")
+	buf.WriteString("\t\t\t\t\t" + service.GetErrorMessageFieldName() + ":   " + types.String("err.Error()") + ",\n")
+	buf.WriteString("\t\t\t\t\tRequestID: " + types.String("requestContext.RequestID") + ",\n")
 	buf.WriteString("\t\t\t\t}\n")
 	buf.WriteString("\t\t\t},\n")
 	buf.WriteString("\t\t\tPreHooks: []PreHook{\n")
@@ -1930,6 +3087,7 @@ func generateInternalUtilityTests(buf *bytes.Buffer, service *specification.Serv
 	buf.WriteString("\t})\n\n")
 
 	buf.WriteString("\tt.Run(\"pre-hook error aborts handleRequest\", func(t *testing.T) {\n")
+	buf.WriteString("\t\tt.Parallel()\n")
 	buf.WriteString("\t\tc, _ := gin.CreateTestContext(httptest.NewRecorder())\n")
 	buf.WriteString("\t\treq, err := http.NewRequest(\"POST\", \"/test\", nil)\n")
 	buf.WriteString("\t\tassert.NoError(t, err, \"Failed to create request\")\n")
@@ -1943,8 +3101,8 @@ func generateInternalUtilityTests(buf *bytes.Buffer, service *specification.Serv
 	buf.WriteString("\t\t\tErrorHook: func(ctx context.Context, requestContext RequestContext, session *any, err error) *Error {\n")
 	buf.WriteString("\t\t\t\treturn &Error{\n")
 	buf.WriteString("\t\t\t\t\tCode:      ErrorCodeForbidden,\n")
-	buf.WriteString("\t\t\t\t\tMessage:   types.NewString(err.Error()),\n")
-	buf.WriteString("\t\t\t\t\tRequestID: types.NewString(requestContext.RequestID),\n")
+	buf.WriteString("\t\t\t\t\t" + service.GetErrorMessageFieldName() + ":   " + types.String("err.Error()") + ",\n")
+	buf.WriteString("\t\t\t\t\tRequestID: " + types.String("requestContext.RequestID") + ",\n")
 	buf.WriteString("\t\t\t\t}\n")
 	buf.WriteString("\t\t\t},\n")
 	buf.WriteString("\t\t\tPreHooks: []PreHook{\n")
@@ -1964,6 +3122,7 @@ func generateInternalUtilityTests(buf *bytes.Buffer, service *specification.Serv
 
 	// Test decodeBodyParams
 	buf.WriteString("func Test_decodeBodyParams(t *testing.T) {\n")
+	buf.WriteString("\tt.Parallel()\n")
 	buf.WriteString("\t// Define test struct\n")
 	buf.WriteString("\ttype TestBody struct {\n")
 	buf.WriteString("\t\tName string `json:\"name\"`\n")
@@ -1984,13 +3143,13 @@ func generateInternalUtilityTests(buf *bytes.Buffer, service *specification.Serv
 
 	// Test decodePathParams
 	buf.WriteString("func Test_decodePathParams(t *testing.T) {\n")
+	buf.WriteString("\tt.Parallel()\n")
 	buf.WriteString("\t// Define test struct\n")
 	buf.WriteString("\ttype TestPathParams struct {\n")
 	buf.WriteString("\t\tID   string `json:\"id\"`\n")
 	buf.WriteString("\t\tName string `json:\"name\"`\n")
 	buf.WriteString("\t}\n\n")
 
-	buf.WriteString("\tgin.SetMode(gin.TestMode)\n")
 	buf.WriteString("\tc, _ := gin.CreateTestContext(httptest.NewRecorder())\n")
 	buf.WriteString("\tc.Params = []gin.Param{\n")
 	buf.WriteString("\t\t{Key: \"id\", Value: \"123\"},\n")
@@ -2006,6 +3165,7 @@ func generateInternalUtilityTests(buf *bytes.Buffer, service *specification.Serv
 
 	// Test decodeQueryParams
 	buf.WriteString("func Test_decodeQueryParams(t *testing.T) {\n")
+	buf.WriteString("\tt.Parallel()\n")
 	buf.WriteString("\t// Define test struct\n")
 	buf.WriteString("\ttype TestQueryParams struct {\n")
 	buf.WriteString("\t\tLimit  int  `form:\"limit\"`\n")
@@ -2013,7 +3173,6 @@ func generateInternalUtilityTests(buf *bytes.Buffer, service *specification.Serv
 	buf.WriteString("\t\tActive bool `form:\"active\"`\n")
 	buf.WriteString("\t}\n\n")
 
-	buf.WriteString("\tgin.SetMode(gin.TestMode)\n")
 	buf.WriteString("\tc, _ := gin.CreateTestContext(httptest.NewRecorder())\n")
 	buf.WriteString("\treq, err := http.NewRequest(\"GET\", \"/test?limit=10&offset=0&active=true\", nil)\n")
 	buf.WriteString("\tassert.NoError(t, err, \"Failed to create request\")\n")
@@ -2028,19 +3187,25 @@ func generateInternalUtilityTests(buf *bytes.Buffer, service *specification.Serv
 	buf.WriteString("}\n\n")
 
 	// Test PreHooks functionality
-	err := generateInternalPreHookTests(buf)
+	err := generateInternalPreHookTests(buf, service, types)
 	if err != nil {
 		return err
 	}
 
 	// Test SessionHooks functionality
-	err = generateInternalSessionHookTests(buf)
+	err = generateInternalSessionHookTests(buf, service, types)
 	if err != nil {
 		return err
 	}
 
 	// Test ResponseHeaderHook functionality
-	err = generateInternalResponseHeaderHookTests(buf, service)
+	err = generateInternalResponseHeaderHookTests(buf, service, types)
+	if err != nil {
+		return err
+	}
+
+	// Test operational endpoints
+	err = generateInternalOperationalTests(buf, service)
 	if err != nil {
 		return err
 	}
@@ -2048,23 +3213,76 @@ func generateInternalUtilityTests(buf *bytes.Buffer, service *specification.Serv
 	return nil
 }
 
+// generateInternalOperationalTests generates internal tests for the health, readiness and version endpoints.
+func generateInternalOperationalTests(buf *bytes.Buffer, service *specification.Service) error {
+	if service.Operational.HasChecks() {
+		buf.WriteString("func Test_serveCheck(t *testing.T) {\n")
+		buf.WriteString("\tt.Parallel()\n\n")
+
+		checks := []struct {
+			name           string
+			check          string
+			expectedStatus int
+			expectedBody   string
+		}{
+			{name: "nil check", check: "nil", expectedStatus: 200, expectedBody: "ok"},
+			{name: "passing check", check: "func(ctx context.Context) error { return nil }", expectedStatus: 200, expectedBody: "ok"},
+			{name: "failing check", check: "func(ctx context.Context) error { return fmt.Errorf(\"dependency unavailable\") }", expectedStatus: 503, expectedBody: "unavailable"},
+		}
+
+		for _, check := range checks {
+			buf.WriteString(fmt.Sprintf("\tt.Run(%q, func(t *testing.T) {\n", check.name))
+			buf.WriteString("\t\tt.Parallel()\n")
+			buf.WriteString("\t\trouter := gin.New()\n")
+			buf.WriteString(fmt.Sprintf("\t\trouter.GET(\"/check\", serveCheck(%s))\n\n", check.check))
+			buf.WriteString("\t\treq, err := http.NewRequest(\"GET\", \"/check\", nil)\n")
+			buf.WriteString("\t\tassert.NoError(t, err, \"Failed to create request\")\n")
+			buf.WriteString("\t\tw := httptest.NewRecorder()\n")
+			buf.WriteString("\t\trouter.ServeHTTP(w, req)\n\n")
+			buf.WriteString(fmt.Sprintf("\t\tassert.Equal(t, %d, w.Code, \"Expected %d status code\")\n", check.expectedStatus, check.expectedStatus))
+			buf.WriteString(fmt.Sprintf("\t\tassert.Contains(t, w.Body.String(), %q, \"Expected %s status\")\n", check.expectedBody, check.expectedBody))
+			buf.WriteString("\t})\n\n")
+		}
+
+		buf.WriteString("}\n\n")
+	}
+
+	if service.Operational.HasVersion() {
+		buf.WriteString("func Test_serveVersion(t *testing.T) {\n")
+		buf.WriteString("\tt.Parallel()\n")
+		buf.WriteString("\trouter := gin.New()\n")
+		buf.WriteString("\trouter.GET(\"/version\", serveVersion)\n\n")
+		buf.WriteString("\treq, err := http.NewRequest(\"GET\", \"/version\", nil)\n")
+		buf.WriteString("\tassert.NoError(t, err, \"Failed to create request\")\n")
+		buf.WriteString("\tw := httptest.NewRecorder()\n")
+		buf.WriteString("\trouter.ServeHTTP(w, req)\n\n")
+		buf.WriteString("\tassert.Equal(t, 200, w.Code, \"Expected 200 status code\")\n")
+		buf.WriteString(fmt.Sprintf("\tassert.JSONEq(t, %q, w.Body.String(), \"Expected service name and version\")\n",
+			fmt.Sprintf(`{"name":%q,"version":%q}`, service.Name, service.Version)))
+		buf.WriteString("}\n\n")
+	}
+
+	return nil
+}
+
 // getInternalTypeReference returns type names without package prefixes for internal tests.
 func getInternalTypeReference(typeName string) string {
 	return typeName // No package prefix needed for internal tests
 }
 
-// getJSONKey converts a field name to its JSON key (camelCase).
-func getJSONKey(fieldName string) string {
-	return specification.CamelCase(fieldName)
+// getJSONKey returns the JSON key of the field, see specification.Field.TagJSON.
+func getJSONKey(field specification.Field) string {
+	return field.TagJSON()
 }
 
 // generateInternalPreHookTests generates internal tests for PreHook functionality.
-func generateInternalPreHookTests(buf *bytes.Buffer) error {
+func generateInternalPreHookTests(buf *bytes.Buffer, service *specification.Service, types servergen.Types) error {
 	buf.WriteString("func Test_PreHooks(t *testing.T) {\n")
-	buf.WriteString("\tgin.SetMode(gin.TestMode)\n\n")
+	buf.WriteString("\tt.Parallel()\n\n")
 
 	// Test successful pre-hook execution
 	buf.WriteString("\tt.Run(\"successful pre-hook execution\", func(t *testing.T) {\n")
+	buf.WriteString("\t\tt.Parallel()\n")
 	buf.WriteString("\t\trouter := gin.New()\n")
 	buf.WriteString("\t\tvar capturedContext RequestContext\n\n")
 
@@ -2081,8 +3299,8 @@ func generateInternalPreHookTests(buf *bytes.Buffer) error {
 	buf.WriteString("\t\t\tErrorHook: func(ctx context.Context, requestContext RequestContext, session *any, err error) *Error {\n")
 	buf.WriteString("\t\t\t\treturn &Error{\n")
 	buf.WriteString("\t\t\t\t\tCode:      ErrorCodeInternal,\n")
-	buf.WriteString("\t\t\t\t\tMessage:   types.NewString(err.Error()),\n")
-	buf.WriteString("\t\t\t\t\tRequestID: types.NewString(requestContext.RequestID),\n")
+	buf.WriteString("\t\t\t\t\t" + service.GetErrorMessageFieldName() + ":   " + types.String("err.Error()") + ",\n")
+	buf.WriteString("\t\t\t\t\tRequestID: " + types.String("requestContext.RequestID") + ",\n")
 	buf.WriteString("\t\t\t\t}\n")
 	buf.WriteString("\t\t\t},\n")
 	buf.WriteString("\t\t\tPreHooks: []PreHook{\n")
@@ -2117,6 +3335,7 @@ func generateInternalPreHookTests(buf *bytes.Buffer) error {
 
 	// Test pre-hook aborting request
 	buf.WriteString("\tt.Run(\"pre-hook aborts request\", func(t *testing.T) {\n")
+	buf.WriteString("\t\tt.Parallel()\n")
 	buf.WriteString("\t\trouter := gin.New()\n\n")
 
 	buf.WriteString("\t\t// Mock function that should not be called\n")
@@ -2134,8 +3353,8 @@ func generateInternalPreHookTests(buf *bytes.Buffer) error {
 	buf.WriteString("\t\t\tErrorHook: func(ctx context.Context, requestContext RequestContext, session *any, err error) *Error {\n")
 	buf.WriteString("\t\t\t\treturn &Error{\n")
 	buf.WriteString("\t\t\t\t\tCode:      ErrorCodeForbidden,\n")
-	buf.WriteString("\t\t\t\t\tMessage:   types.NewString(err.Error()),\n")
-	buf.WriteString("\t\t\t\t\tRequestID: types.NewString(requestContext.RequestID),\n")
+	buf.WriteString("\t\t\t\t\t" + service.GetErrorMessageFieldName() + ":   " + types.String("err.Error()") + ",\n")
+	buf.WriteString("\t\t\t\t\tRequestID: " + types.String("requestContext.RequestID") + ",\n")
 	buf.WriteString("\t\t\t\t}\n")
 	buf.WriteString("\t\t\t},\n")
 	buf.WriteString("\t\t\tPreHooks: []PreHook{\n")
@@ -2163,6 +3382,7 @@ func generateInternalPreHookTests(buf *bytes.Buffer) error {
 
 	// Test multiple pre-hooks
 	buf.WriteString("\tt.Run(\"multiple pre-hooks execute in order\", func(t *testing.T) {\n")
+	buf.WriteString("\t\tt.Parallel()\n")
 	buf.WriteString("\t\trouter := gin.New()\n")
 	buf.WriteString("\t\tvar executionOrder []string\n\n")
 
@@ -2179,8 +3399,8 @@ func generateInternalPreHookTests(buf *bytes.Buffer) error {
 	buf.WriteString("\t\t\tErrorHook: func(ctx context.Context, requestContext RequestContext, session *any, err error) *Error {\n")
 	buf.WriteString("\t\t\t\treturn &Error{\n")
 	buf.WriteString("\t\t\t\t\tCode:      ErrorCodeInternal,\n")
-	buf.WriteString("\t\t\t\t\tMessage:   types.NewString(err.Error()),\n")
-	buf.WriteString("\t\t\t\t\tRequestID: types.NewString(requestContext.RequestID),\n")
+	buf.WriteString("\t\t\t\t\t" + service.GetErrorMessageFieldName() + ":   " + types.String("err.Error()") + ",\n")
+	buf.WriteString("\t\t\t\t\tRequestID: " + types.String("requestContext.RequestID") + ",\n")
 	buf.WriteString("\t\t\t\t}\n")
 	buf.WriteString("\t\t\t},\n")
 	buf.WriteString("\t\t\tPreHooks: []PreHook{\n")
@@ -2219,12 +3439,13 @@ func generateInternalPreHookTests(buf *bytes.Buffer) error {
 }
 
 // generateInternalSessionHookTests generates internal tests for SessionHook functionality.
-func generateInternalSessionHookTests(buf *bytes.Buffer) error {
+func generateInternalSessionHookTests(buf *bytes.Buffer, service *specification.Service, types servergen.Types) error {
 	buf.WriteString("func Test_SessionHooks(t *testing.T) {\n")
-	buf.WriteString("\tgin.SetMode(gin.TestMode)\n\n")
+	buf.WriteString("\tt.Parallel()\n\n")
 
 	// Test successful session hook execution
 	buf.WriteString("\tt.Run(\"successful session-hook execution\", func(t *testing.T) {\n")
+	buf.WriteString("\t\tt.Parallel()\n")
 	buf.WriteString("\t\trouter := gin.New()\n")
 	buf.WriteString("\t\tvar capturedContext RequestContext\n")
 	buf.WriteString("\t\tvar capturedSession string\n\n")
@@ -2242,8 +3463,8 @@ func generateInternalSessionHookTests(buf *bytes.Buffer) error {
 	buf.WriteString("\t\t\tErrorHook: func(ctx context.Context, requestContext RequestContext, session *string, err error) *Error {\n")
 	buf.WriteString("\t\t\t\treturn &Error{\n")
 	buf.WriteString("\t\t\t\t\tCode:      ErrorCodeInternal,\n")
-	buf.WriteString("\t\t\t\t\tMessage:   types.NewString(err.Error()),\n")
-	buf.WriteString("\t\t\t\t\tRequestID: types.NewString(requestContext.RequestID),\n")
+	buf.WriteString("\t\t\t\t\t" + service.GetErrorMessageFieldName() + ":   " + types.String("err.Error()") + ",\n")
+	buf.WriteString("\t\t\t\t\tRequestID: " + types.String("requestContext.RequestID") + ",\n")
 	buf.WriteString("\t\t\t\t}\n")
 	buf.WriteString("\t\t\t},\n")
 	buf.WriteString("\t\t\tSessionHooks: []SessionHook[string]{\n")
@@ -2276,6 +3497,7 @@ func generateInternalSessionHookTests(buf *bytes.Buffer) error {
 
 	// Test session hook error
 	buf.WriteString("\tt.Run(\"session-hook error aborts request\", func(t *testing.T) {\n")
+	buf.WriteString("\t\tt.Parallel()\n")
 	buf.WriteString("\t\trouter := gin.New()\n")
 	buf.WriteString("\t\tvar functionCalled bool\n\n")
 
@@ -2293,8 +3515,8 @@ func generateInternalSessionHookTests(buf *bytes.Buffer) error {
 	buf.WriteString("\t\t\tErrorHook: func(ctx context.Context, requestContext RequestContext, session *string, err error) *Error {\n")
 	buf.WriteString("\t\t\t\treturn &Error{\n")
 	buf.WriteString("\t\t\t\t\tCode:      ErrorCodeForbidden,\n")
-	buf.WriteString("\t\t\t\t\tMessage:   types.NewString(err.Error()),\n")
-	buf.WriteString("\t\t\t\t\tRequestID: types.NewString(requestContext.RequestID),\n")
+	buf.WriteString("\t\t\t\t\t" + service.GetErrorMessageFieldName() + ":   " + types.String("err.Error()") + ",\n")
+	buf.WriteString("\t\t\t\t\tRequestID: " + types.String("requestContext.RequestID") + ",\n")
 	buf.WriteString("\t\t\t\t}\n")
 	buf.WriteString("\t\t\t},\n")
 	buf.WriteString("\t\t\tSessionHooks: []SessionHook[string]{\n")
@@ -2322,6 +3544,7 @@ func generateInternalSessionHookTests(buf *bytes.Buffer) error {
 
 	// Test multiple session hooks
 	buf.WriteString("\tt.Run(\"multiple session-hooks execute in order\", func(t *testing.T) {\n")
+	buf.WriteString("\t\tt.Parallel()\n")
 	buf.WriteString("\t\trouter := gin.New()\n")
 	buf.WriteString("\t\tvar executionOrder []string\n\n")
 
@@ -2338,8 +3561,8 @@ func generateInternalSessionHookTests(buf *bytes.Buffer) error {
 	buf.WriteString("\t\t\tErrorHook: func(ctx context.Context, requestContext RequestContext, session *string, err error) *Error {\n")
 	buf.WriteString("\t\t\t\treturn &Error{\n")
 	buf.WriteString("\t\t\t\t\tCode:      ErrorCodeInternal,\n")
-	buf.WriteString("\t\t\t\t\tMessage:   types.NewString(err.Error()),\n")
-	buf.WriteString("\t\t\t\t\tRequestID: types.NewString(requestContext.RequestID),\n")
+	buf.WriteString("\t\t\t\t\t" + service.GetErrorMessageFieldName() + ":   " + types.String("err.Error()") + ",\n")
+	buf.WriteString("\t\t\t\t\tRequestID: " + types.String("requestContext.RequestID") + ",\n")
 	buf.WriteString("\t\t\t\t}\n")
 	buf.WriteString("\t\t\t},\n")
 	buf.WriteString("\t\t\tSessionHooks: []SessionHook[string]{\n")
@@ -2375,6 +3598,7 @@ func generateInternalSessionHookTests(buf *bytes.Buffer) error {
 
 	// Test session hooks run after authentication
 	buf.WriteString("\tt.Run(\"session-hooks run after authentication\", func(t *testing.T) {\n")
+	buf.WriteString("\t\tt.Parallel()\n")
 	buf.WriteString("\t\trouter := gin.New()\n")
 	buf.WriteString("\t\tvar sessionHookCalled bool\n\n")
 
@@ -2388,7 +3612,7 @@ func generateInternalSessionHookTests(buf *bytes.Buffer) error {
 	buf.WriteString("\t\t\tGetSessionFunc: func(ctx context.Context, headers http.Header) (string, error) {\n")
 	buf.WriteString("\t\t\t\treturn \"\", &Error{\n")
 	buf.WriteString("\t\t\t\t\tCode:    ErrorCodeUnauthorized,\n")
-	buf.WriteString("\t\t\t\t\tMessage: types.NewString(\"authentication failed\"),\n")
+	buf.WriteString("\t\t\t\t\t" + service.GetErrorMessageFieldName() + ": " + types.String(`"authentication failed"`) + ",\n")
 	buf.WriteString("\t\t\t\t}\n")
 	buf.WriteString("\t\t\t},\n")
 	buf.WriteString("\t\t\tErrorHook: func(ctx context.Context, requestContext RequestContext, session *string, err error) *Error {\n")
@@ -2397,8 +3621,8 @@ func generateInternalSessionHookTests(buf *bytes.Buffer) error {
 	buf.WriteString("\t\t\t\t}\n")
 	buf.WriteString("\t\t\t\treturn &Error{\n")
 	buf.WriteString("\t\t\t\t\tCode:      ErrorCodeInternal,\n")
-	buf.WriteString("\t\t\t\t\tMessage:   types.NewString(err.Error()),\n")
-	buf.WriteString("\t\t\t\t\tRequestID: types.NewString(requestContext.RequestID),\n")
+	buf.WriteString("\t\t\t\t\t" + service.GetErrorMessageFieldName() + ":   " + types.String("err.Error()") + ",\n")
+	buf.WriteString("\t\t\t\t\tRequestID: " + types.String("requestContext.RequestID") + ",\n")
 	buf.WriteString("\t\t\t\t}\n")
 	buf.WriteString("\t\t\t},\n")
 	buf.WriteString("\t\t\tSessionHooks: []SessionHook[string]{\n")
@@ -2430,12 +3654,13 @@ func generateInternalSessionHookTests(buf *bytes.Buffer) error {
 }
 
 // generatePreHookTests generates tests for PreHook functionality.
-func generatePreHookTests(buf *bytes.Buffer, apiPackageName string) error {
+func generatePreHookTests(buf *bytes.Buffer, service *specification.Service, apiPackageName string, types servergen.Types) error {
 	buf.WriteString("func Test_PreHooks(t *testing.T) {\n")
-	buf.WriteString("\tgin.SetMode(gin.TestMode)\n\n")
+	buf.WriteString("\tt.Parallel()\n\n")
 
 	// Test successful pre-hook execution
 	buf.WriteString("\tt.Run(\"successful pre-hook execution\", func(t *testing.T) {\n")
+	buf.WriteString("\t\tt.Parallel()\n")
 	buf.WriteString("\t\trouter := gin.New()\n")
 	buf.WriteString("\t\tvar capturedContext " + apiPackageName + ".RequestContext\n\n")
 
@@ -2452,8 +3677,8 @@ func generatePreHookTests(buf *bytes.Buffer, apiPackageName string) error {
 	buf.WriteString("\t\t\tErrorHook: func(ctx context.Context, requestContext " + apiPackageName + ".RequestContext, session *any, err error) *" + apiPackageName + ".Error {\n")
 	buf.WriteString("\t\t\t\treturn &" + apiPackageName + ".Error{\n")
 	buf.WriteString("\t\t\t\t\tCode:      " + apiPackageName + ".ErrorCodeInternal,\n")
-	buf.WriteString("\t\t\t\t\tMessage:   types.NewString(err.Error()),\n")
-	buf.WriteString("\t\t\t\t\tRequestID: types.NewString(requestContext.RequestID),\n")
+	buf.WriteString("\t\t\t\t\t" + service.GetErrorMessageFieldName() + ":   " + types.String("err.Error()") + ",\n")
+	buf.WriteString("\t\t\t\t\tRequestID: " + types.String("requestContext.RequestID") + ",\n")
 	buf.WriteString("\t\t\t\t}\n")
 	buf.WriteString("\t\t\t},\n")
 	buf.WriteString("\t\t\tPreHooks: []" + apiPackageName + ".PreHook{\n")
@@ -2488,6 +3713,7 @@ func generatePreHookTests(buf *bytes.Buffer, apiPackageName string) error {
 
 	// Test pre-hook aborting request
 	buf.WriteString("\tt.Run(\"pre-hook aborts request\", func(t *testing.T) {\n")
+	buf.WriteString("\t\tt.Parallel()\n")
 	buf.WriteString("\t\trouter := gin.New()\n\n")
 
 	buf.WriteString("\t\t// Mock function that should not be called\n")
@@ -2505,8 +3731,8 @@ func generatePreHookTests(buf *bytes.Buffer, apiPackageName string) error {
 	buf.WriteString("\t\t\tErrorHook: func(ctx context.Context, requestContext " + apiPackageName + ".RequestContext, session *any, err error) *" + apiPackageName + ".Error {\n")
 	buf.WriteString("\t\t\t\treturn &" + apiPackageName + ".Error{\n")
 	buf.WriteString("\t\t\t\t\tCode:      " + apiPackageName + ".ErrorCodeForbidden,\n")
-	buf.WriteString("\t\t\t\t\tMessage:   types.NewString(err.Error()),\n")
-	buf.WriteString("\t\t\t\t\tRequestID: types.NewString(requestContext.RequestID),\n")
+	buf.WriteString("\t\t\t\t\t" + service.GetErrorMessageFieldName() + ":   " + types.String("err.Error()") + ",\n")
+	buf.WriteString("\t\t\t\t\tRequestID: " + types.String("requestContext.RequestID") + ",\n")
 	buf.WriteString("\t\t\t\t}\n")
 	buf.WriteString("\t\t\t},\n")
 	buf.WriteString("\t\t\tPreHooks: []" + apiPackageName + ".PreHook{\n")
@@ -2534,6 +3760,7 @@ func generatePreHookTests(buf *bytes.Buffer, apiPackageName string) error {
 
 	// Test multiple pre-hooks
 	buf.WriteString("\tt.Run(\"multiple pre-hooks execute in order\", func(t *testing.T) {\n")
+	buf.WriteString("\t\tt.Parallel()\n")
 	buf.WriteString("\t\trouter := gin.New()\n")
 	buf.WriteString("\t\tvar executionOrder []string\n\n")
 
@@ -2550,8 +3777,8 @@ func generatePreHookTests(buf *bytes.Buffer, apiPackageName string) error {
 	buf.WriteString("\t\t\tErrorHook: func(ctx context.Context, requestContext " + apiPackageName + ".RequestContext, session *any, err error) *" + apiPackageName + ".Error {\n")
 	buf.WriteString("\t\t\t\treturn &" + apiPackageName + ".Error{\n")
 	buf.WriteString("\t\t\t\t\tCode:      " + apiPackageName + ".ErrorCodeInternal,\n")
-	buf.WriteString("\t\t\t\t\tMessage:   types.NewString(err.Error()),\n")
-	buf.WriteString("\t\t\t\t\tRequestID: types.NewString(requestContext.RequestID),\n")
+	buf.WriteString("\t\t\t\t\t" + service.GetErrorMessageFieldName() + ":   " + types.String("err.Error()") + ",\n")
+	buf.WriteString("\t\t\t\t\tRequestID: " + types.String("requestContext.RequestID") + ",\n")
 	buf.WriteString("\t\t\t\t}\n")
 	buf.WriteString("\t\t\t},\n")
 	buf.WriteString("\t\t\tPreHooks: []" + apiPackageName + ".PreHook{\n")
@@ -2590,12 +3817,13 @@ func generatePreHookTests(buf *bytes.Buffer, apiPackageName string) error {
 }
 
 // generateSessionHookTests generates tests for SessionHook functionality.
-func generateSessionHookTests(buf *bytes.Buffer, apiPackageName string) error {
+func generateSessionHookTests(buf *bytes.Buffer, service *specification.Service, apiPackageName string, types servergen.Types) error {
 	buf.WriteString("func Test_SessionHooks(t *testing.T) {\n")
-	buf.WriteString("\tgin.SetMode(gin.TestMode)\n\n")
+	buf.WriteString("\tt.Parallel()\n\n")
 
 	// Test successful session hook execution
 	buf.WriteString("\tt.Run(\"successful session-hook execution\", func(t *testing.T) {\n")
+	buf.WriteString("\t\tt.Parallel()\n")
 	buf.WriteString("\t\trouter := gin.New()\n")
 	buf.WriteString("\t\tvar capturedContext " + apiPackageName + ".RequestContext\n")
 	buf.WriteString("\t\tvar capturedSession string\n\n")
@@ -2613,8 +3841,8 @@ func generateSessionHookTests(buf *bytes.Buffer, apiPackageName string) error {
 	buf.WriteString("\t\t\tErrorHook: func(ctx context.Context, requestContext " + apiPackageName + ".RequestContext, session *string, err error) *" + apiPackageName + ".Error {\n")
 	buf.WriteString("\t\t\t\treturn &" + apiPackageName + ".Error{\n")
 	buf.WriteString("\t\t\t\t\tCode:      " + apiPackageName + ".ErrorCodeInternal,\n")
-	buf.WriteString("\t\t\t\t\tMessage:   types.NewString(err.Error()),\n")
-	buf.WriteString("\t\t\t\t\tRequestID: types.NewString(requestContext.RequestID),\n")
+	buf.WriteString("\t\t\t\t\t" + service.GetErrorMessageFieldName() + ":   " + types.String("err.Error()") + ",\n")
+	buf.WriteString("\t\t\t\t\tRequestID: " + types.String("requestContext.RequestID") + ",\n")
 	buf.WriteString("\t\t\t\t}\n")
 	buf.WriteString("\t\t\t},\n")
 	buf.WriteString("\t\t\tSessionHooks: []" + apiPackageName + ".SessionHook[string]{\n")
@@ -2647,6 +3875,7 @@ func generateSessionHookTests(buf *bytes.Buffer, apiPackageName string) error {
 
 	// Test session hook error
 	buf.WriteString("\tt.Run(\"session-hook error aborts request\", func(t *testing.T) {\n")
+	buf.WriteString("\t\tt.Parallel()\n")
 	buf.WriteString("\t\trouter := gin.New()\n")
 	buf.WriteString("\t\tvar functionCalled bool\n\n")
 
@@ -2664,8 +3893,8 @@ func generateSessionHookTests(buf *bytes.Buffer, apiPackageName string) error {
 	buf.WriteString("\t\t\tErrorHook: func(ctx context.Context, requestContext " + apiPackageName + ".RequestContext, session *string, err error) *" + apiPackageName + ".Error {\n")
 	buf.WriteString("\t\t\t\treturn &" + apiPackageName + ".Error{\n")
 	buf.WriteString("\t\t\t\t\tCode:      " + apiPackageName + ".ErrorCodeForbidden,\n")
-	buf.WriteString("\t\t\t\t\tMessage:   types.NewString(err.Error()),\n")
-	buf.WriteString("\t\t\t\t\tRequestID: types.NewString(requestContext.RequestID),\n")
+	buf.WriteString("\t\t\t\t\t" + service.GetErrorMessageFieldName() + ":   " + types.String("err.Error()") + ",\n")
+	buf.WriteString("\t\t\t\t\tRequestID: " + types.String("requestContext.RequestID") + ",\n")
 	buf.WriteString("\t\t\t\t}\n")
 	buf.WriteString("\t\t\t},\n")
 	buf.WriteString("\t\t\tSessionHooks: []" + apiPackageName + ".SessionHook[string]{\n")
@@ -2693,6 +3922,7 @@ func generateSessionHookTests(buf *bytes.Buffer, apiPackageName string) error {
 
 	// Test multiple session hooks
 	buf.WriteString("\tt.Run(\"multiple session-hooks execute in order\", func(t *testing.T) {\n")
+	buf.WriteString("\t\tt.Parallel()\n")
 	buf.WriteString("\t\trouter := gin.New()\n")
 	buf.WriteString("\t\tvar executionOrder []string\n\n")
 
@@ -2709,8 +3939,8 @@ func generateSessionHookTests(buf *bytes.Buffer, apiPackageName string) error {
 	buf.WriteString("\t\t\tErrorHook: func(ctx context.Context, requestContext " + apiPackageName + ".RequestContext, session *string, err error) *" + apiPackageName + ".Error {\n")
 	buf.WriteString("\t\t\t\treturn &" + apiPackageName + ".Error{\n")
 	buf.WriteString("\t\t\t\t\tCode:      " + apiPackageName + ".ErrorCodeInternal,\n")
-	buf.WriteString("\t\t\t\t\tMessage:   types.NewString(err.Error()),\n")
-	buf.WriteString("\t\t\t\t\tRequestID: types.NewString(requestContext.RequestID),\n")
+	buf.WriteString("\t\t\t\t\t" + service.GetErrorMessageFieldName() + ":   " + types.String("err.Error()") + ",\n")
+	buf.WriteString("\t\t\t\t\tRequestID: " + types.String("requestContext.RequestID") + ",\n")
 	buf.WriteString("\t\t\t\t}\n")
 	buf.WriteString("\t\t\t},\n")
 	buf.WriteString("\t\t\tSessionHooks: []" + apiPackageName + ".SessionHook[string]{\n")
@@ -2746,6 +3976,7 @@ func generateSessionHookTests(buf *bytes.Buffer, apiPackageName string) error {
 
 	// Test session hooks run after authentication
 	buf.WriteString("\tt.Run(\"session-hooks run after authentication\", func(t *testing.T) {\n")
+	buf.WriteString("\t\tt.Parallel()\n")
 	buf.WriteString("\t\trouter := gin.New()\n")
 	buf.WriteString("\t\tvar sessionHookCalled bool\n\n")
 
@@ -2759,7 +3990,7 @@ func generateSessionHookTests(buf *bytes.Buffer, apiPackageName string) error {
 	buf.WriteString("\t\t\tGetSessionFunc: func(ctx context.Context, headers http.Header) (string, error) {\n")
 	buf.WriteString("\t\t\t\treturn \"\", &" + apiPackageName + ".Error{\n")
 	buf.WriteString("\t\t\t\t\tCode:    " + apiPackageName + ".ErrorCodeUnauthorized,\n")
-	buf.WriteString("\t\t\t\t\tMessage: types.NewString(\"authentication failed\"),\n")
+	buf.WriteString("\t\t\t\t\t" + service.GetErrorMessageFieldName() + ": " + types.String(`"authentication failed"`) + ",\n")
 	buf.WriteString("\t\t\t\t}\n")
 	buf.WriteString("\t\t\t},\n")
 	buf.WriteString("\t\t\tErrorHook: func(ctx context.Context, requestContext " + apiPackageName + ".RequestContext, session *string, err error) *" + apiPackageName + ".Error {\n")
@@ -2768,8 +3999,8 @@ func generateSessionHookTests(buf *bytes.Buffer, apiPackageName string) error {
 	buf.WriteString("\t\t\t\t}\n")
 	buf.WriteString("\t\t\t\treturn &" + apiPackageName + ".Error{\n")
 	buf.WriteString("\t\t\t\t\tCode:      " + apiPackageName + ".ErrorCodeInternal,\n")
-	buf.WriteString("\t\t\t\t\tMessage:   types.NewString(err.Error()),\n")
-	buf.WriteString("\t\t\t\t\tRequestID: types.NewString(requestContext.RequestID),\n")
+	buf.WriteString("\t\t\t\t\t" + service.GetErrorMessageFieldName() + ":   " + types.String("err.Error()") + ",\n")
+	buf.WriteString("\t\t\t\t\tRequestID: " + types.String("requestContext.RequestID") + ",\n")
 	buf.WriteString("\t\t\t\t}\n")
 	buf.WriteString("\t\t\t},\n")
 	buf.WriteString("\t\t\tSessionHooks: []" + apiPackageName + ".SessionHook[string]{\n")
@@ -2801,12 +4032,13 @@ func generateSessionHookTests(buf *bytes.Buffer, apiPackageName string) error {
 }
 
 // generateResponseHeaderHookTests generates tests for ResponseHeaderHook functionality.
-func generateResponseHeaderHookTests(buf *bytes.Buffer, service *specification.Service, apiPackageName string) error {
+func generateResponseHeaderHookTests(buf *bytes.Buffer, service *specification.Service, apiPackageName string, types servergen.Types) error {
 	buf.WriteString("func Test_ResponseHeaderHook(t *testing.T) {\n")
-	buf.WriteString("\tgin.SetMode(gin.TestMode)\n\n")
+	buf.WriteString("\tt.Parallel()\n\n")
 
 	// Test headers set on success
 	buf.WriteString("\tt.Run(\"headers set on successful response\", func(t *testing.T) {\n")
+	buf.WriteString("\t\tt.Parallel()\n")
 	buf.WriteString("\t\trouter := gin.New()\n\n")
 
 	buf.WriteString("\t\t// Mock function that succeeds\n")
@@ -2822,13 +4054,13 @@ func generateResponseHeaderHookTests(buf *bytes.Buffer, service *specification.S
 	buf.WriteString("\t\t\tErrorHook: func(ctx context.Context, requestContext " + apiPackageName + ".RequestContext, session *any, err error) *" + apiPackageName + ".Error {\n")
 	buf.WriteString("\t\t\t\treturn &" + apiPackageName + ".Error{\n")
 	buf.WriteString("\t\t\t\t\tCode:      " + apiPackageName + ".ErrorCodeInternal,\n")
-	buf.WriteString("\t\t\t\t\tMessage:   types.NewString(err.Error()),\n")
-	buf.WriteString("\t\t\t\t\tRequestID: types.NewString(requestContext.RequestID),\n")
+	buf.WriteString("\t\t\t\t\t" + service.GetErrorMessageFieldName() + ":   " + types.String("err.Error()") + ",\n")
+	buf.WriteString("\t\t\t\t\tRequestID: " + types.String("requestContext.RequestID") + ",\n")
 	buf.WriteString("\t\t\t\t}\n")
 	buf.WriteString("\t\t\t},\n")
 	buf.WriteString("\t\t\tResponseHeaderHook: func(ctx context.Context, requestContext " + apiPackageName + ".RequestContext) " + apiPackageName + ".ResponseHeaders {\n")
 	buf.WriteString("\t\t\t\treturn " + apiPackageName + ".ResponseHeaders{\n")
-	generateHeaderPopulation(buf, service, apiPackageName+".")
+	generateHeaderPopulation(buf, service, apiPackageName+".", types)
 	buf.WriteString("\t\t\t\t}\n")
 	buf.WriteString("\t\t\t},\n")
 	buf.WriteString("\t\t}\n\n")
@@ -2852,6 +4084,7 @@ func generateResponseHeaderHookTests(buf *bytes.Buffer, service *specification.S
 
 	// Test headers set on error (from endpoint function)
 	buf.WriteString("\tt.Run(\"headers set on error response from endpoint\", func(t *testing.T) {\n")
+	buf.WriteString("\t\tt.Parallel()\n")
 	buf.WriteString("\t\trouter := gin.New()\n\n")
 
 	buf.WriteString("\t\t// Mock function that returns an error\n")
@@ -2867,13 +4100,13 @@ func generateResponseHeaderHookTests(buf *bytes.Buffer, service *specification.S
 	buf.WriteString("\t\t\tErrorHook: func(ctx context.Context, requestContext " + apiPackageName + ".RequestContext, session *any, err error) *" + apiPackageName + ".Error {\n")
 	buf.WriteString("\t\t\t\treturn &" + apiPackageName + ".Error{\n")
 	buf.WriteString("\t\t\t\t\tCode:      " + apiPackageName + ".ErrorCodeInternal,\n")
-	buf.WriteString("\t\t\t\t\tMessage:   types.NewString(err.Error()),\n")
-	buf.WriteString("\t\t\t\t\tRequestID: types.NewString(requestContext.RequestID),\n")
+	buf.WriteString("\t\t\t\t\t" + service.GetErrorMessageFieldName() + ":   " + types.String("err.Error()") + ",\n")
+	buf.WriteString("\t\t\t\t\tRequestID: " + types.String("requestContext.RequestID") + ",\n")
 	buf.WriteString("\t\t\t\t}\n")
 	buf.WriteString("\t\t\t},\n")
 	buf.WriteString("\t\t\tResponseHeaderHook: func(ctx context.Context, requestContext " + apiPackageName + ".RequestContext) " + apiPackageName + ".ResponseHeaders {\n")
 	buf.WriteString("\t\t\t\treturn " + apiPackageName + ".ResponseHeaders{\n")
-	generateHeaderPopulation(buf, service, apiPackageName+".")
+	generateHeaderPopulation(buf, service, apiPackageName+".", types)
 	buf.WriteString("\t\t\t\t}\n")
 	buf.WriteString("\t\t\t},\n")
 	buf.WriteString("\t\t}\n\n")
@@ -2897,6 +4130,7 @@ func generateResponseHeaderHookTests(buf *bytes.Buffer, service *specification.S
 
 	// Test headers set on authentication error
 	buf.WriteString("\tt.Run(\"headers set on authentication error\", func(t *testing.T) {\n")
+	buf.WriteString("\t\tt.Parallel()\n")
 	buf.WriteString("\t\trouter := gin.New()\n\n")
 
 	buf.WriteString("\t\t// Mock function (won't be called)\n")
@@ -2909,7 +4143,7 @@ func generateResponseHeaderHookTests(buf *bytes.Buffer, service *specification.S
 	buf.WriteString("\t\t\tGetSessionFunc: func(ctx context.Context, headers http.Header) (any, error) {\n")
 	buf.WriteString("\t\t\t\treturn nil, &" + apiPackageName + ".Error{\n")
 	buf.WriteString("\t\t\t\t\tCode:    " + apiPackageName + ".ErrorCodeUnauthorized,\n")
-	buf.WriteString("\t\t\t\t\tMessage: types.NewString(\"authentication failed\"),\n")
+	buf.WriteString("\t\t\t\t\t" + service.GetErrorMessageFieldName() + ": " + types.String(`"authentication failed"`) + ",\n")
 	buf.WriteString("\t\t\t\t}\n")
 	buf.WriteString("\t\t\t},\n")
 	buf.WriteString("\t\t\tErrorHook: func(ctx context.Context, requestContext " + apiPackageName + ".RequestContext, session *any, err error) *" + apiPackageName + ".Error {\n")
@@ -2918,13 +4152,13 @@ func generateResponseHeaderHookTests(buf *bytes.Buffer, service *specification.S
 	buf.WriteString("\t\t\t\t}\n")
 	buf.WriteString("\t\t\t\treturn &" + apiPackageName + ".Error{\n")
 	buf.WriteString("\t\t\t\t\tCode:      " + apiPackageName + ".ErrorCodeInternal,\n")
-	buf.WriteString("\t\t\t\t\tMessage:   types.NewString(err.Error()),\n")
-	buf.WriteString("\t\t\t\t\tRequestID: types.NewString(requestContext.RequestID),\n")
+	buf.WriteString("\t\t\t\t\t" + service.GetErrorMessageFieldName() + ":   " + types.String("err.Error()") + ",\n")
+	buf.WriteString("\t\t\t\t\tRequestID: " + types.String("requestContext.RequestID") + ",\n")
 	buf.WriteString("\t\t\t\t}\n")
 	buf.WriteString("\t\t\t},\n")
 	buf.WriteString("\t\t\tResponseHeaderHook: func(ctx context.Context, requestContext " + apiPackageName + ".RequestContext) " + apiPackageName + ".ResponseHeaders {\n")
 	buf.WriteString("\t\t\t\treturn " + apiPackageName + ".ResponseHeaders{\n")
-	generateHeaderPopulation(buf, service, apiPackageName+".")
+	generateHeaderPopulation(buf, service, apiPackageName+".", types)
 	buf.WriteString("\t\t\t\t}\n")
 	buf.WriteString("\t\t\t},\n")
 	buf.WriteString("\t\t}\n\n")
@@ -2948,6 +4182,7 @@ func generateResponseHeaderHookTests(buf *bytes.Buffer, service *specification.S
 
 	// Test that nil ResponseHeaderHook doesn't cause errors
 	buf.WriteString("\tt.Run(\"nil ResponseHeaderHook doesn't cause errors\", func(t *testing.T) {\n")
+	buf.WriteString("\t\tt.Parallel()\n")
 	buf.WriteString("\t\trouter := gin.New()\n\n")
 
 	buf.WriteString("\t\t// Mock function\n")
@@ -2963,8 +4198,8 @@ func generateResponseHeaderHookTests(buf *bytes.Buffer, service *specification.S
 	buf.WriteString("\t\t\tErrorHook: func(ctx context.Context, requestContext " + apiPackageName + ".RequestContext, session *any, err error) *" + apiPackageName + ".Error {\n")
 	buf.WriteString("\t\t\t\treturn &" + apiPackageName + ".Error{\n")
 	buf.WriteString("\t\t\t\t\tCode:      " + apiPackageName + ".ErrorCodeInternal,\n")
-	buf.WriteString("\t\t\t\t\tMessage:   types.NewString(err.Error()),\n")
-	buf.WriteString("\t\t\t\t\tRequestID: types.NewString(requestContext.RequestID),\n")
+	buf.WriteString("\t\t\t\t\t" + service.GetErrorMessageFieldName() + ":   " + types.String("err.Error()") + ",\n")
+	buf.WriteString("\t\t\t\t\tRequestID: " + types.String("requestContext.RequestID") + ",\n")
 	buf.WriteString("\t\t\t\t}\n")
 	buf.WriteString("\t\t\t},\n")
 	buf.WriteString("\t\t\t// No ResponseHeaderHook set\n")
@@ -2990,12 +4225,13 @@ func generateResponseHeaderHookTests(buf *bytes.Buffer, service *specification.S
 }
 
 // generateInternalResponseHeaderHookTests generates internal tests for ResponseHeaderHook functionality.
-func generateInternalResponseHeaderHookTests(buf *bytes.Buffer, service *specification.Service) error {
+func generateInternalResponseHeaderHookTests(buf *bytes.Buffer, service *specification.Service, types servergen.Types) error {
 	buf.WriteString("func Test_ResponseHeaderHook(t *testing.T) {\n")
-	buf.WriteString("\tgin.SetMode(gin.TestMode)\n\n")
+	buf.WriteString("\tt.Parallel()\n\n")
 
 	// Test headers set on success
 	buf.WriteString("\tt.Run(\"headers set on successful response\", func(t *testing.T) {\n")
+	buf.WriteString("\t\tt.Parallel()\n")
 	buf.WriteString("\t\trouter := gin.New()\n\n")
 
 	buf.WriteString("\t\t// Mock function that succeeds\n")
@@ -3011,13 +4247,13 @@ func generateInternalResponseHeaderHookTests(buf *bytes.Buffer, service *specifi
 	buf.WriteString("\t\t\tErrorHook: func(ctx context.Context, requestContext RequestContext, session *any, err error) *Error {\n")
 	buf.WriteString("\t\t\t\treturn &Error{\n")
 	buf.WriteString("\t\t\t\t\tCode:      ErrorCodeInternal,\n")
-	buf.WriteString("\t\t\t\t\tMessage:   types.NewString(err.Error()),\n")
-	buf.WriteString("\t\t\t\t\tRequestID: types.NewString(requestContext.RequestID),\n")
+	buf.WriteString("\t\t\t\t\t" + service.GetErrorMessageFieldName() + ":   " + types.String("err.Error()") + ",\n")
+	buf.WriteString("\t\t\t\t\tRequestID: " + types.String("requestContext.RequestID") + ",\n")
 	buf.WriteString("\t\t\t\t}\n")
 	buf.WriteString("\t\t\t},\n")
 	buf.WriteString("\t\t\tResponseHeaderHook: func(ctx context.Context, requestContext RequestContext) ResponseHeaders {\n")
 	buf.WriteString("\t\t\t\treturn ResponseHeaders{\n")
-	generateHeaderPopulation(buf, service, "")
+	generateHeaderPopulation(buf, service, "", types)
 	buf.WriteString("\t\t\t\t}\n")
 	buf.WriteString("\t\t\t},\n")
 	buf.WriteString("\t\t}\n\n")
@@ -3041,6 +4277,7 @@ func generateInternalResponseHeaderHookTests(buf *bytes.Buffer, service *specifi
 
 	// Test headers set on error (from endpoint function)
 	buf.WriteString("\tt.Run(\"headers set on error response from endpoint\", func(t *testing.T) {\n")
+	buf.WriteString("\t\tt.Parallel()\n")
 	buf.WriteString("\t\trouter := gin.New()\n\n")
 
 	buf.WriteString("\t\t// Mock function that returns an error\n")
@@ -3056,13 +4293,13 @@ func generateInternalResponseHeaderHookTests(buf *bytes.Buffer, service *specifi
 	buf.WriteString("\t\t\tErrorHook: func(ctx context.Context, requestContext RequestContext, session *any, err error) *Error {\n")
 	buf.WriteString("\t\t\t\treturn &Error{\n")
 	buf.WriteString("\t\t\t\t\tCode:      ErrorCodeInternal,\n")
-	buf.WriteString("\t\t\t\t\tMessage:   types.NewString(err.Error()),\n")
-	buf.WriteString("\t\t\t\t\tRequestID: types.NewString(requestContext.RequestID),\n")
+	buf.WriteString("\t\t\t\t\t" + service.GetErrorMessageFieldName() + ":   " + types.String("err.Error()") + ",\n")
+	buf.WriteString("\t\t\t\t\tRequestID: " + types.String("requestContext.RequestID") + ",\n")
 	buf.WriteString("\t\t\t\t}\n")
 	buf.WriteString("\t\t\t},\n")
 	buf.WriteString("\t\t\tResponseHeaderHook: func(ctx context.Context, requestContext RequestContext) ResponseHeaders {\n")
 	buf.WriteString("\t\t\t\treturn ResponseHeaders{\n")
-	generateHeaderPopulation(buf, service, "")
+	generateHeaderPopulation(buf, service, "", types)
 	buf.WriteString("\t\t\t\t}\n")
 	buf.WriteString("\t\t\t},\n")
 	buf.WriteString("\t\t}\n\n")
@@ -3086,6 +4323,7 @@ func generateInternalResponseHeaderHookTests(buf *bytes.Buffer, service *specifi
 
 	// Test headers set on authentication error
 	buf.WriteString("\tt.Run(\"headers set on authentication error\", func(t *testing.T) {\n")
+	buf.WriteString("\t\tt.Parallel()\n")
 	buf.WriteString("\t\trouter := gin.New()\n\n")
 
 	buf.WriteString("\t\t// Mock function (won't be called)\n")
@@ -3098,7 +4336,7 @@ func generateInternalResponseHeaderHookTests(buf *bytes.Buffer, service *specifi
 	buf.WriteString("\t\t\tGetSessionFunc: func(ctx context.Context, headers http.Header) (any, error) {\n")
 	buf.WriteString("\t\t\t\treturn nil, &Error{\n")
 	buf.WriteString("\t\t\t\t\tCode:    ErrorCodeUnauthorized,\n")
-	buf.WriteString("\t\t\t\t\tMessage: types.NewString(\"authentication failed\"),\n")
+	buf.WriteString("\t\t\t\t\t" + service.GetErrorMessageFieldName() + ": " + types.String(`"authentication failed"`) + ",\n")
 	buf.WriteString("\t\t\t\t}\n")
 	buf.WriteString("\t\t\t},\n")
 	buf.WriteString("\t\t\tErrorHook: func(ctx context.Context, requestContext RequestContext, session *any, err error) *Error {\n")
@@ -3107,13 +4345,13 @@ func generateInternalResponseHeaderHookTests(buf *bytes.Buffer, service *specifi
 	buf.WriteString("\t\t\t\t}\n")
 	buf.WriteString("\t\t\t\treturn &Error{\n")
 	buf.WriteString("\t\t\t\t\tCode:      ErrorCodeInternal,\n")
-	buf.WriteString("\t\t\t\t\tMessage:   types.NewString(err.Error()),\n")
-	buf.WriteString("\t\t\t\t\tRequestID: types.NewString(requestContext.RequestID),\n")
+	buf.WriteString("\t\t\t\t\t" + service.GetErrorMessageFieldName() + ":   " + types.String("err.Error()") + ",\n")
+	buf.WriteString("\t\t\t\t\tRequestID: " + types.String("requestContext.RequestID") + ",\n")
 	buf.WriteString("\t\t\t\t}\n")
 	buf.WriteString("\t\t\t},\n")
 	buf.WriteString("\t\t\tResponseHeaderHook: func(ctx context.Context, requestContext RequestContext) ResponseHeaders {\n")
 	buf.WriteString("\t\t\t\treturn ResponseHeaders{\n")
-	generateHeaderPopulation(buf, service, "")
+	generateHeaderPopulation(buf, service, "", types)
 	buf.WriteString("\t\t\t\t}\n")
 	buf.WriteString("\t\t\t},\n")
 	buf.WriteString("\t\t}\n\n")
@@ -3137,6 +4375,7 @@ func generateInternalResponseHeaderHookTests(buf *bytes.Buffer, service *specifi
 
 	// Test that nil ResponseHeaderHook doesn't cause errors
 	buf.WriteString("\tt.Run(\"nil ResponseHeaderHook doesn't cause errors\", func(t *testing.T) {\n")
+	buf.WriteString("\t\tt.Parallel()\n")
 	buf.WriteString("\t\trouter := gin.New()\n\n")
 
 	buf.WriteString("\t\t// Mock function\n")
@@ -3152,8 +4391,8 @@ func generateInternalResponseHeaderHookTests(buf *bytes.Buffer, service *specifi
 	buf.WriteString("\t\t\tErrorHook: func(ctx context.Context, requestContext RequestContext, session *any, err error) *Error {\n")
 	buf.WriteString("\t\t\t\treturn &Error{\n")
 	buf.WriteString("\t\t\t\t\tCode:      ErrorCodeInternal,\n")
-	buf.WriteString("\t\t\t\t\tMessage:   types.NewString(err.Error()),\n")
-	buf.WriteString("\t\t\t\t\tRequestID: types.NewString(requestContext.RequestID),\n")
+	buf.WriteString("\t\t\t\t\t" + service.GetErrorMessageFieldName() + ":   " + types.String("err.Error()") + ",\n")
+	buf.WriteString("\t\t\t\t\tRequestID: " + types.String("requestContext.RequestID") + ",\n")
 	buf.WriteString("\t\t\t\t}\n")
 	buf.WriteString("\t\t\t},\n")
 	buf.WriteString("\t\t\t// No ResponseHeaderHook set\n")
@@ -3178,29 +4417,124 @@ func generateInternalResponseHeaderHookTests(buf *bytes.Buffer, service *specifi
 	return nil
 }
 
+// hasDecimalResponseHeader returns true if any of the common response headers is a decimal,
+// which requires the decimal package when populating the headers in tests.
+func hasDecimalResponseHeader(service *specification.Service) bool {
+	return hasResponseHeaderType(service, specification.FieldTypeDecimal)
+}
+
+// hasConditionalGet returns whether the tests of the service include the 304 Not Modified test of a conditional GET
+// endpoint, which sets the last updated time of the response.
+func hasConditionalGet(service *specification.Service) bool {
+	for _, resource := range service.Resources {
+		if resource.Development {
+			continue
+		}
+		for _, endpoint := range resource.Endpoints {
+			if endpoint.IsConditionalGet(service) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hasXMLBodies returns whether any endpoint of the service that is tested has an XML request or response.
+func hasXMLBodies(service *specification.Service) bool {
+	for _, resource := range service.Resources {
+		if resource.Development {
+			continue
+		}
+		for _, endpoint := range resource.Endpoints {
+			if endpoint.HasXMLRequest() || endpoint.HasXMLResponse() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hasCSVResponse returns whether the tests of the service include an endpoint with a CSV response,
+// whose body is read as CSV.
+func hasCSVResponse(service *specification.Service) bool {
+	for _, resource := range service.Resources {
+		if resource.Development {
+			continue
+		}
+		for _, endpoint := range resource.Endpoints {
+			if endpoint.HasCSVResponse() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hasResponseHeaderType returns true if any of the common response headers, or of the header fields of the
+// responses of the tested endpoints, is of the field type.
+func hasResponseHeaderType(service *specification.Service, fieldType string) bool {
+	isType := func(field specification.Field) bool { return field.Type == fieldType }
+	if slices.ContainsFunc(service.ResponseHeaders, isType) {
+		return true
+	}
+	for _, resource := range service.Resources {
+		if resource.Development {
+			continue
+		}
+		for _, endpoint := range resource.Endpoints {
+			if slices.ContainsFunc(endpoint.Response.GetHeaderFields(), isType) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // generateHeaderPopulation generates code to populate ResponseHeaders struct fields
-func generateHeaderPopulation(buf *bytes.Buffer, service *specification.Service, packagePrefix string) {
+func generateHeaderPopulation(buf *bytes.Buffer, service *specification.Service, packagePrefix string, types servergen.Types) {
 	if len(service.ResponseHeaders) == 0 {
 		return
 	}
 
 	for _, field := range service.ResponseHeaders {
-		fieldName := sanitizeHeaderName(field.Name)
+		fieldName := field.GetGoName()
 		testValue := fmt.Sprintf("test-%s-value", strings.ToLower(field.Name))
 
+		// Standard library types are populated with the values checked by generateHeaderAssertions
+		if types.Stdlib {
+			value, _ := types.Literal(field, getHeaderTestValue(field, testValue))
+			buf.WriteString(fmt.Sprintf("\t\t\t\t\t%s: %s,\n", fieldName, value))
+			continue
+		}
+
 		// Generate field assignment based on type
 		switch field.Type {
 		case "String":
 			buf.WriteString(fmt.Sprintf("\t\t\t\t\t%s: types.NewString(\"%s\"),\n", fieldName, testValue))
 		case "Int":
 			buf.WriteString(fmt.Sprintf("\t\t\t\t\t%s: types.NewInt(12345),\n", fieldName))
+		case "Int32":
+			buf.WriteString(fmt.Sprintf("\t\t\t\t\t%s: types.NewInt32(12345),\n", fieldName))
+		case "UInt":
+			buf.WriteString(fmt.Sprintf("\t\t\t\t\t%s: types.NewUInt(12345),\n", fieldName))
 		case "Int64":
 			buf.WriteString(fmt.Sprintf("\t\t\t\t\t%s: types.NewInt64(67890),\n", fieldName))
 		case "Float64":
 			buf.WriteString(fmt.Sprintf("\t\t\t\t\t%s: types.NewFloat64(3.14),\n", fieldName))
+		case "Decimal":
+			if field.IsNullable() {
+				buf.WriteString(fmt.Sprintf("\t\t\t\t\t%s: decimal.NewNullDecimal(decimal.RequireFromString(\"19.99\")),\n", fieldName))
+			} else {
+				buf.WriteString(fmt.Sprintf("\t\t\t\t\t%s: decimal.RequireFromString(\"19.99\"),\n", fieldName))
+			}
 		case "Bool":
 			buf.WriteString(fmt.Sprintf("\t\t\t\t\t%s: types.NewBool(true),\n", fieldName))
 		case "UUID":
+			// The ID types of referenced resources wrap the UUID, see servergen.Types.TypedIDs
+			if types.TypedIDs && field.References != "" {
+				buf.WriteString(fmt.Sprintf("\t\t\t\t\t%s: %sID{types.NewUUID(uuid.New())},\n", fieldName, field.References))
+				continue
+			}
 			buf.WriteString(fmt.Sprintf("\t\t\t\t\t%s: types.NewUUID(uuid.New()),\n", fieldName))
 		case "Date":
 			buf.WriteString(fmt.Sprintf("\t\t\t\t\t%s: types.NewDate(\"2024-01-15\"),\n", fieldName))
@@ -3213,6 +4547,38 @@ func generateHeaderPopulation(buf *bytes.Buffer, service *specification.Service,
 	}
 }
 
+// decimalExample returns the example of a Decimal, or a default example if it has none. Decimals are serialized
+// as strings to keep their precision, so the example is quoted in the generated JSON.
+func decimalExample(example string) string {
+	if example != "" {
+		return example
+	}
+	return "19.99"
+}
+
+// getHeaderTestValue returns the value a response header of the field is populated with in tests,
+// as it is written in the specification.
+func getHeaderTestValue(field specification.Field, testValue string) string {
+	switch field.Type {
+	case specification.FieldTypeInt, specification.FieldTypeInt32, specification.FieldTypeUInt:
+		return "12345"
+	case specification.FieldTypeFloat64:
+		return "3.14"
+	case specification.FieldTypeDecimal:
+		return decimalExample("")
+	case specification.FieldTypeBool:
+		return "true"
+	case specification.FieldTypeUUID:
+		return "5f8b1c2e-3d4a-4b6c-9e7f-1a2b3c4d5e6f"
+	case specification.FieldTypeDate:
+		return "2024-01-15"
+	case specification.FieldTypeTimestamp:
+		return "2024-01-15T10:30:00Z"
+	default:
+		return testValue
+	}
+}
+
 // generateHeaderAssertions generates test assertions for response headers
 func generateHeaderAssertions(buf *bytes.Buffer, service *specification.Service) {
 	if len(service.ResponseHeaders) == 0 {
@@ -3221,32 +4587,74 @@ func generateHeaderAssertions(buf *bytes.Buffer, service *specification.Service)
 
 	for _, field := range service.ResponseHeaders {
 		testValue := fmt.Sprintf("test-%s-value", strings.ToLower(field.Name))
+		header := field.GetHeaderName()
+		value := getHeaderValueExpression("w.Header()", header)
 
 		switch field.Type {
 		case "String":
-			buf.WriteString(fmt.Sprintf("\t\tassert.Equal(t, \"%s\", w.Header().Get(\"%s\"), \"Header %s should be set\")\n", testValue, field.Name, field.Name))
-		case "Int":
-			buf.WriteString(fmt.Sprintf("\t\tassert.Equal(t, \"12345\", w.Header().Get(\"%s\"), \"Header %s should be set\")\n", field.Name, field.Name))
+			buf.WriteString(fmt.Sprintf("\t\tassert.Equal(t, \"%s\", %s, \"Header %s should be set\")\n", testValue, value, header))
+		case "Int", "Int32", "UInt":
+			buf.WriteString(fmt.Sprintf("\t\tassert.Equal(t, \"12345\", %s, \"Header %s should be set\")\n", value, header))
 		case "Int64":
-			buf.WriteString(fmt.Sprintf("\t\tassert.Equal(t, \"67890\", w.Header().Get(\"%s\"), \"Header %s should be set\")\n", field.Name, field.Name))
+			buf.WriteString(fmt.Sprintf("\t\tassert.Equal(t, \"67890\", %s, \"Header %s should be set\")\n", value, header))
 		case "Float64":
-			buf.WriteString(fmt.Sprintf("\t\tassert.Equal(t, \"3.14\", w.Header().Get(\"%s\"), \"Header %s should be set\")\n", field.Name, field.Name))
+			buf.WriteString(fmt.Sprintf("\t\tassert.Equal(t, \"3.14\", %s, \"Header %s should be set\")\n", value, header))
+		case "Decimal":
+			buf.WriteString(fmt.Sprintf("\t\tassert.Equal(t, \"19.99\", %s, \"Header %s should be set\")\n", value, header))
 		case "Bool":
-			buf.WriteString(fmt.Sprintf("\t\tassert.Equal(t, \"true\", w.Header().Get(\"%s\"), \"Header %s should be set\")\n", field.Name, field.Name))
+			buf.WriteString(fmt.Sprintf("\t\tassert.Equal(t, \"true\", %s, \"Header %s should be set\")\n", value, header))
 		case "UUID":
-			buf.WriteString(fmt.Sprintf("\t\tassert.NotEmpty(t, w.Header().Get(\"%s\"), \"Header %s should be set\")\n", field.Name, field.Name))
+			buf.WriteString(fmt.Sprintf("\t\tassert.NotEmpty(t, %s, \"Header %s should be set\")\n", value, header))
 		case "Date":
-			buf.WriteString(fmt.Sprintf("\t\tassert.Equal(t, \"2024-01-15\", w.Header().Get(\"%s\"), \"Header %s should be set\")\n", field.Name, field.Name))
+			buf.WriteString(fmt.Sprintf("\t\tassert.Equal(t, \"2024-01-15\", %s, \"Header %s should be set\")\n", value, header))
 		case "Timestamp":
-			buf.WriteString(fmt.Sprintf("\t\tassert.Equal(t, \"2024-01-15T10:30:00Z\", w.Header().Get(\"%s\"), \"Header %s should be set\")\n", field.Name, field.Name))
+			buf.WriteString(fmt.Sprintf("\t\tassert.Equal(t, \"2024-01-15T10:30:00Z\", %s, \"Header %s should be set\")\n", value, header))
 		default:
 			// For custom or unknown types, check that the header is set
-			buf.WriteString(fmt.Sprintf("\t\tassert.Equal(t, \"%s\", w.Header().Get(\"%s\"), \"Header %s should be set\")\n", testValue, field.Name, field.Name))
+			buf.WriteString(fmt.Sprintf("\t\tassert.Equal(t, \"%s\", %s, \"Header %s should be set\")\n", testValue, value, header))
 		}
 	}
 }
 
-// sanitizeHeaderName removes hyphens from header names to create valid Go identifiers
-func sanitizeHeaderName(name string) string {
-	return strings.ReplaceAll(name, "-", "")
+// getHeaderValueExpression returns the Go expression of the value of the response header in the http.Header
+// expression of the response. Header.Get canonicalizes the name, so the value of a header whose name is not
+// canonical is read from the header map, which asserts that the header keeps its casing on the wire.
+func getHeaderValueExpression(headers, header string) string {
+	if http.CanonicalHeaderKey(header) == header {
+		return fmt.Sprintf("%s.Get(%q)", headers, header)
+	}
+	return fmt.Sprintf("strings.Join(%s[%q], \", \")", headers, header)
+}
+
+// generateResponseHeaderFields generates the header fields of the expected response of an endpoint with response
+// headers, see specification.EndpointResponse.GetHeaderFields, with the values generateResponseHeaderFieldAssertions
+// checks the headers of the response for.
+func generateResponseHeaderFields(buf *bytes.Buffer, endpoint specification.Endpoint, types servergen.Types) {
+	for _, field := range endpoint.Response.GetHeaderFields() {
+		value, _ := types.Literal(field, getResponseHeaderFieldTestValue(field))
+		buf.WriteString(fmt.Sprintf("\t\t\t%s: %s,\n", field.GetGoName(), value))
+	}
+}
+
+// generateResponseHeaderFieldAssertions generates the assertions that the response has the headers of the header
+// fields of the expected response, see generateResponseHeaderFields.
+func generateResponseHeaderFieldAssertions(buf *bytes.Buffer, endpoint specification.Endpoint) {
+	headers := endpoint.Response.GetHeaderFields()
+	if len(headers) == 0 {
+		return
+	}
+
+	buf.WriteString("\t\t// Verify the response headers set from the header fields of the response\n")
+	for _, field := range headers {
+		header := field.GetHeaderName()
+		buf.WriteString(fmt.Sprintf("\t\tassert.Equal(t, %q, %s, \"Should set the %s header\")\n",
+			getResponseHeaderFieldTestValue(field), getHeaderValueExpression("resp.Header", header), header))
+	}
+	buf.WriteString("\n")
+}
+
+// getResponseHeaderFieldTestValue returns the value the header field of an expected response is set to in tests,
+// as it is written in the specification and in the response header.
+func getResponseHeaderFieldTestValue(field specification.Field) string {
+	return getHeaderTestValue(field, fmt.Sprintf("test-%s-value", strings.ToLower(field.Name)))
 }
@@ -2,9 +2,12 @@ package testgen
 
 import (
 	"bytes"
+	"go/format"
+	"strings"
 	"testing"
 
 	"github.com/meitner-se/publicapis-gen/specification"
+	"github.com/meitner-se/publicapis-gen/specification/servergen"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -121,6 +124,232 @@ func TestGenerateInternalTests(t *testing.T) {
 	assert.Contains(t, generatedCode, "decodeBodyParams[TestBody](", "Should call decodeBodyParams with struct type")
 	assert.Contains(t, generatedCode, "decodePathParams[TestPathParams](", "Should call decodePathParams with struct type")
 	assert.Contains(t, generatedCode, "decodeQueryParams[TestQueryParams](", "Should call decodeQueryParams with struct type")
+
+	// Check that the errors of handleRequest wrap their cause
+	assert.Contains(t, generatedCode, "assert.EqualError(t, apiError.Unwrap(), \"authentication failed\"", "Should assert the wrapped session error")
+}
+
+func TestGenerateInternalTests_BodyParamsDecodeError(t *testing.T) {
+	testCases := []struct {
+		name        string
+		contentType string
+		expected    string
+	}{
+		{name: "json bodies", contentType: "", expected: `"cannot decode json body params: "`},
+		{name: "xml bodies", contentType: specification.ContentTypeXML, expected: `"cannot decode body params: "`},
+		{name: "form bodies", contentType: specification.ContentTypeForm, expected: `"cannot decode body params: "`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			service := createTestService()
+			service.Resources[0].Endpoints[0].Request.ContentType = tc.contentType
+			buf := &bytes.Buffer{}
+
+			// Act
+			err := GenerateInternalTests(buf, service, "api")
+
+			// Assert
+			assert.Nil(t, err, "Expected no error when generating internal tests")
+			assert.Contains(t, buf.String(), "apiError.Message.String(), "+tc.expected+", \"Error message should mention decoding the body\")",
+				"Should expect the message of the server for the content types of the service")
+		})
+	}
+}
+
+func TestGenerateInternalTests_FormattedOutput(t *testing.T) {
+	// Arrange
+	service := createTestService()
+	first := &bytes.Buffer{}
+	second := &bytes.Buffer{}
+
+	// Act
+	errFirst := GenerateInternalTests(first, service, "api")
+	errSecond := GenerateInternalTests(second, service, "api")
+
+	// Assert
+	assert.Nil(t, errFirst, "Expected no error when generating internal tests")
+	assert.Nil(t, errSecond, "Expected no error when generating internal tests")
+
+	formatted, err := format.Source(first.Bytes())
+	assert.Nil(t, err, "Generated tests should parse as valid Go")
+	assert.Equal(t, string(formatted), first.String(), "Generated tests should already be gofmt-formatted")
+	assert.Equal(t, first.String(), second.String(), "Generated tests should be deterministic")
+}
+
+func TestGenerateInternalTestsWithOptions_StdlibTypes(t *testing.T) {
+	// Arrange
+	service := createTestService()
+	service.ResponseHeaders = []specification.Field{
+		{Name: "X-Created-At", Type: specification.FieldTypeTimestamp, Modifiers: []string{specification.ModifierNullable}},
+	}
+	buf := &bytes.Buffer{}
+
+	// Act
+	err := GenerateInternalTestsWithOptions(buf, service, "api", Options{Types: servergen.Types{Stdlib: true}})
+
+	// Assert
+	assert.Nil(t, err, "Expected no error when generating internal tests")
+	code := buf.String()
+	assert.NotContains(t, code, "types.")
+	assert.NotContains(t, code, servergen.DefaultTypesPackage)
+	assert.Contains(t, code, "\t\"time\"\n")
+	assert.Contains(t, code, "Message:   err.Error(),")
+	assert.Contains(t, code, "XCreatedAt: ptr(time.Date(2024, time.January, 15, 10, 30, 0, 0, time.UTC)),")
+}
+
+func TestGenerateInternalTestsWithOptions_Benchmarks(t *testing.T) {
+	// Arrange
+	service := createTestServiceWithQueryParams()
+	service.Resources[0].Endpoints[0].Request.QueryParams[0].Example = "25"
+	withBenchmarks := &bytes.Buffer{}
+	withoutBenchmarks := &bytes.Buffer{}
+
+	// Act
+	errWith := GenerateInternalTestsWithOptions(withBenchmarks, service, "api", Options{Benchmarks: true})
+	errWithout := GenerateInternalTestsWithOptions(withoutBenchmarks, service, "api", Options{})
+
+	// Assert
+	assert.Nil(t, errWith, "Expected no error when generating internal tests with benchmarks")
+	assert.Nil(t, errWithout, "Expected no error when generating internal tests without benchmarks")
+	code := withBenchmarks.String()
+	assert.Contains(t, code, "func BenchmarkStudentCreateStudent(b *testing.B) {")
+	assert.Contains(t, code, "target := \"/test-service/v1/student?limit=25\"", "Benchmarks should request the examples of the parameters")
+	assert.Contains(t, code, "b.ReportAllocs()")
+	assert.Contains(t, code, "router.ServeHTTP(recorder, req)")
+	assert.Contains(t, code, "if recorder.Code != 201 {")
+	assert.NotContains(t, withoutBenchmarks.String(), "Benchmark", "Benchmarks should only be generated when enabled")
+
+	formatted, err := format.Source(withBenchmarks.Bytes())
+	assert.Nil(t, err, "Generated benchmarks should parse as valid Go")
+	assert.Equal(t, string(formatted), code, "Generated benchmarks should already be gofmt-formatted")
+}
+
+func TestGenerateTestsWithOptions_Benchmarks(t *testing.T) {
+	// Arrange
+	service := createTestService()
+	buf := &bytes.Buffer{}
+
+	// Act
+	err := GenerateTestsWithOptions(buf, service, "api_test", "api", "./api", Options{Benchmarks: true})
+
+	// Assert
+	assert.Nil(t, err, "Expected no error when generating tests with benchmarks")
+	code := buf.String()
+	assert.Contains(t, code, "func BenchmarkStudentCreateStudent(b *testing.B) {")
+	assert.Contains(t, code, "var response api.Student", "External benchmarks should qualify the types with the API package")
+}
+
+func TestGenerateTestsWithOptions_GoldenFiles(t *testing.T) {
+	t.Run("internal tests", func(t *testing.T) {
+		// Arrange
+		service := createTestService()
+		withGoldenFiles := &bytes.Buffer{}
+		withoutGoldenFiles := &bytes.Buffer{}
+
+		// Act
+		errWith := GenerateInternalTestsWithOptions(withGoldenFiles, service, "api", Options{GoldenFiles: true})
+		errWithout := GenerateInternalTestsWithOptions(withoutGoldenFiles, service, "api", Options{})
+
+		// Assert
+		assert.Nil(t, errWith, "Expected no error when generating internal tests with golden files")
+		assert.Nil(t, errWithout, "Expected no error when generating internal tests without golden files")
+		code := withGoldenFiles.String()
+		assert.Contains(t, code, "\t\"flag\"\n", "Should import the flag package for the -update flag")
+		assert.Contains(t, code, "\t\"path/filepath\"\n", "Should import the filepath package for the golden file paths")
+		assert.Contains(t, code, `var updateGoldenFiles = flag.Bool("update", false, "record the responses into the golden files")`)
+		assert.Contains(t, code, "func assertGoldenFile(t *testing.T, name string, body []byte) {")
+		assert.Contains(t, code, `assertGoldenFile(t, "StudentCreateStudent", responseBodyBytes)`, "Should compare the response with the golden file of the endpoint")
+		assert.NotContains(t, withoutGoldenFiles.String(), "GoldenFile", "Golden files should only be compared when enabled")
+		assert.NotContains(t, withoutGoldenFiles.String(), "\t\"flag\"\n")
+	})
+
+	t.Run("external tests", func(t *testing.T) {
+		// Arrange
+		service := createTestService()
+		buf := &bytes.Buffer{}
+
+		// Act
+		err := GenerateTestsWithOptions(buf, service, "api_test", "api", "./api", Options{GoldenFiles: true})
+
+		// Assert
+		assert.Nil(t, err, "Expected no error when generating tests with golden files")
+		assert.Contains(t, buf.String(), `assertGoldenFile(t, "StudentCreateStudent", responseBodyBytes)`)
+	})
+}
+
+func TestGenerateTests_Parallel(t *testing.T) {
+	// Arrange
+	service := createTestService()
+	service.Strict = true
+	internal := &bytes.Buffer{}
+	external := &bytes.Buffer{}
+
+	// Act
+	errInternal := GenerateInternalTestsWithOptions(internal, service, "api", Options{Benchmarks: true})
+	errExternal := GenerateTests(external, service, "api_test", "api", "./api")
+
+	// Assert
+	assert.Nil(t, errInternal, "Expected no error when generating internal tests")
+	assert.Nil(t, errExternal, "Expected no error when generating tests")
+	for _, code := range []string{internal.String(), external.String()} {
+		assert.Contains(t, code, "func init() {\n\tgin.SetMode(gin.TestMode)\n}", "Should set the gin mode once for the package")
+		assert.Equal(t, 1, strings.Count(code, expectedGinTestMode), "Tests should not set the global gin mode")
+		assert.Equal(t, strings.Count(code, "(t *testing.T) {\n"), strings.Count(code, "t.Parallel()"), "Every test and subtest should run in parallel")
+	}
+}
+
+func TestGenerateTests_ProblemDetails(t *testing.T) {
+	// Arrange
+	service := createTestService()
+	service.ErrorFormat = specification.ErrorFormatProblemJSON
+	service = specification.ApplyOverlay(service)
+	internal := &bytes.Buffer{}
+	external := &bytes.Buffer{}
+
+	// Act
+	errInternal := GenerateInternalTests(internal, service, "api")
+	errExternal := GenerateTests(external, service, "api_test", "api", "./api")
+
+	// Assert
+	assert.Nil(t, errInternal, "Expected no error when generating internal tests")
+	assert.Nil(t, errExternal, "Expected no error when generating tests")
+	for _, code := range []string{internal.String(), external.String()} {
+		assert.Contains(t, code, "Detail:    types.NewString(err.Error()),", "Errors should hold their message in Detail")
+		assert.NotContains(t, code, "Message:   ")
+	}
+	assert.Contains(t, internal.String(), "assert.Contains(t, apiError.Detail.String(), \"authentication failed\"")
+}
+
+func TestGenerateInternalTests_OperationalEndpoints(t *testing.T) {
+	// Arrange
+	service := createTestService()
+	service.Operational = &specification.OperationalEndpoints{Health: true, Version: true}
+	buf := &bytes.Buffer{}
+
+	// Act
+	err := GenerateInternalTests(buf, service, "api")
+
+	// Assert
+	assert.Nil(t, err, "Expected no error when generating internal tests")
+
+	generatedCode := buf.String()
+	assert.Contains(t, generatedCode, "func Test_serveCheck(t *testing.T) {", "Should test the check handler")
+	assert.Contains(t, generatedCode, `t.Run("failing check"`, "Should test failing checks")
+	assert.Contains(t, generatedCode, "func Test_serveVersion(t *testing.T) {", "Should test the version handler")
+
+	t.Run("service without operational endpoints", func(t *testing.T) {
+		// Arrange
+		buf := &bytes.Buffer{}
+
+		// Act
+		err := GenerateInternalTests(buf, createTestService(), "api")
+
+		// Assert
+		assert.Nil(t, err, "Expected no error")
+		assert.NotContains(t, buf.String(), "Test_serveCheck", "Should not test the check handler")
+	})
 }
 
 // ============================================================================
@@ -239,7 +468,7 @@ func TestGenerateImports(t *testing.T) {
 	buf := &bytes.Buffer{}
 
 	// Act
-	err := generateImports(buf, "api", "./api")
+	err := generateImports(buf, &specification.Service{}, "api", "./api", Options{})
 
 	// Assert
 	assert.Nil(t, err, "Expected no error when generating imports")
@@ -292,14 +521,16 @@ func TestGenerateEndpointTest(t *testing.T) {
 	buf := &bytes.Buffer{}
 
 	// Act
-	err := generateEndpointTest(buf, service, resource, endpoint, "api")
+	err := generateEndpointTest(buf, service, resource, endpoint, "api", Options{})
 
 	// Assert
 	assert.Nil(t, err, "Expected no error when generating endpoint test")
 
 	generatedCode := buf.String()
 	assert.Contains(t, generatedCode, expectedTestFunction, "Should generate test function")
-	assert.Contains(t, generatedCode, expectedGinTestMode, "Should set gin test mode")
+	assert.NotContains(t, generatedCode, expectedGinTestMode, "Should leave the gin mode to the init function of the package")
+	assert.Contains(t, generatedCode, "func TestStudentCreateStudent(t *testing.T) {\n\tt.Parallel()\n", "Should run the test in parallel")
+	assert.Contains(t, generatedCode, "t.Run(\"Request\", func(t *testing.T) {\n\t\tt.Parallel()\n", "Should run the subtest in parallel")
 	assert.Contains(t, generatedCode, "// Arrange", "Should contain test sections")
 	assert.Contains(t, generatedCode, "// Act", "Should contain act section")
 	assert.Contains(t, generatedCode, "// Assert", "Should contain assert section")
@@ -313,7 +544,7 @@ func TestGenerateEndpointTest(t *testing.T) {
 			buf := &bytes.Buffer{}
 
 			// Act
-			err := generateEndpointTest(buf, service, resource, endpoint, "api")
+			err := generateEndpointTest(buf, service, resource, endpoint, "api", Options{})
 
 			// Assert
 			assert.Nil(t, err, "Expected no error")
@@ -330,7 +561,7 @@ func TestGenerateEndpointTest(t *testing.T) {
 			buf := &bytes.Buffer{}
 
 			// Act
-			err := generateEndpointTest(buf, service, resource, endpoint, "api")
+			err := generateEndpointTest(buf, service, resource, endpoint, "api", Options{})
 
 			// Assert
 			assert.Nil(t, err, "Expected no error")
@@ -347,7 +578,7 @@ func TestGenerateEndpointTest(t *testing.T) {
 			buf := &bytes.Buffer{}
 
 			// Act
-			err := generateEndpointTest(buf, service, resource, endpoint, "api")
+			err := generateEndpointTest(buf, service, resource, endpoint, "api", Options{})
 
 			// Assert
 			assert.Nil(t, err, "Expected no error")
@@ -358,6 +589,670 @@ func TestGenerateEndpointTest(t *testing.T) {
 	})
 }
 
+func TestGenerateHTTPRequest_Tenancy(t *testing.T) {
+	t.Run("path tenancy", func(t *testing.T) {
+		// Arrange
+		service := createTestService()
+		service.Tenancy = &specification.Tenancy{In: specification.TenancyInPath, Name: "TenantID"}
+		buf := &bytes.Buffer{}
+
+		// Act
+		err := generateHTTPRequest(buf, service, service.Resources[0], service.Resources[0].Endpoints[0])
+
+		// Assert
+		assert.Nil(t, err, "Expected no error when generating HTTP request")
+		assert.Contains(t, buf.String(), "/"+testTenantID+"/", "Should include the tenant in the request path")
+	})
+
+	t.Run("header tenancy", func(t *testing.T) {
+		// Arrange
+		service := createTestService()
+		service.Tenancy = &specification.Tenancy{In: specification.TenancyInHeader, Name: "X-Tenant-ID"}
+		buf := &bytes.Buffer{}
+
+		// Act
+		err := generateHTTPRequest(buf, service, service.Resources[0], service.Resources[0].Endpoints[0])
+
+		// Assert
+		assert.Nil(t, err, "Expected no error when generating HTTP request")
+		assert.Contains(t, buf.String(), `req.Header.Set("X-Tenant-ID", "test-tenant")`, "Should set the tenant header")
+	})
+}
+
+func TestGenerateEndpointTest_ConditionalGet(t *testing.T) {
+	// Arrange
+	service := specification.ApplyOverlay(&specification.Service{
+		Name:    "TestService",
+		Version: "v1",
+		Resources: []specification.Resource{
+			{
+				Name:       "Users",
+				Operations: []string{"Get"},
+				Fields: []specification.ResourceField{
+					{Field: specification.Field{Name: "Name", Type: "String"}, Operations: []string{"Read"}},
+				},
+			},
+		},
+	})
+	resource := service.Resources[0]
+	buf := &bytes.Buffer{}
+
+	// Act
+	err := generateEndpointTest(buf, service, resource, resource.Endpoints[0], "api", Options{Types: servergen.Types{Stdlib: true}})
+
+	// Assert
+	assert.Nil(t, err, "Expected no error when generating endpoint test")
+	generatedCode := buf.String()
+	assert.Contains(t, generatedCode, `t.Run("NotModified", func(t *testing.T) {`, "Should test the 304 path of the conditional GET")
+	assert.Contains(t, generatedCode, "expectedUsers.Meta.UpdatedAt = ptr(time.Date(2024, time.January, 15, 14, 45, 0, 0, time.UTC))", "Should set the last updated time of the response")
+	assert.Contains(t, generatedCode, `req.Header.Set("If-Modified-Since", "Mon, 15 Jan 2024 14:45:00 GMT")`, "Should send the If-Modified-Since header")
+	assert.Contains(t, generatedCode, "assert.Equal(t, http.StatusNotModified, resp.StatusCode", "Should assert the 304 Not Modified status")
+	assert.Contains(t, generatedCode, `assert.Equal(t, "Mon, 15 Jan 2024 14:45:00 GMT", resp.Header.Get("Last-Modified")`, "Should assert the Last-Modified header")
+}
+
+func TestGenerateEndpointTest_SubResource(t *testing.T) {
+	// Arrange
+	service := specification.ApplyOverlay(&specification.Service{
+		Name:    "TestService",
+		Version: "v1",
+		Resources: []specification.Resource{
+			{Name: "Post", Operations: []string{"Get"}},
+			{Name: "Comment", Parent: "Post", Operations: []string{"Delete"}},
+		},
+	})
+	resource := service.Resources[1]
+	buf := &bytes.Buffer{}
+
+	// Act
+	err := generateEndpointTest(buf, service, resource, resource.Endpoints[0], "api", Options{})
+
+	// Assert
+	assert.Nil(t, err, "Expected no error when generating endpoint test")
+	generatedCode := buf.String()
+	assert.Contains(t, generatedCode, `requestURL := server.URL + "/test-service/v1/post/{postID}/comment/{id}"`, "Should request the nested path")
+	assert.Contains(t, generatedCode, `requestURL = strings.ReplaceAll(requestURL, "{postID}", testPathPostID)`, "Should fill in the ID of the parent")
+	assert.Contains(t, generatedCode, `requestURL = strings.ReplaceAll(requestURL, "{id}", testPathID)`, "Should fill in the ID")
+}
+
+func TestGenerateEndpointTest_BasePath(t *testing.T) {
+	// Arrange
+	service := specification.ApplyOverlay(&specification.Service{
+		Name:      "TestService",
+		Version:   "v1",
+		BasePath:  "/api/v1",
+		Resources: []specification.Resource{{Name: "Users", Operations: []string{"Delete"}}},
+	})
+	resource := service.Resources[0]
+	buf := &bytes.Buffer{}
+
+	// Act
+	err := generateEndpointTest(buf, service, resource, resource.Endpoints[0], "api", Options{})
+
+	// Assert
+	assert.Nil(t, err, "Expected no error when generating endpoint test")
+	assert.Contains(t, buf.String(), `requestURL := server.URL + "/api/v1/users/{id}"`, "Should request the path under the base path")
+}
+
+func TestGenerateEndpointTest_Naming(t *testing.T) {
+	// Arrange
+	service := specification.ApplyOverlay(&specification.Service{
+		Name:      "TestService",
+		Version:   "v1",
+		Naming:    &specification.Naming{PathCase: specification.PathCaseSnake, PluralPaths: true},
+		Resources: []specification.Resource{{Name: "UserAccount", Operations: []string{"Delete"}}},
+	})
+	resource := service.Resources[0]
+	buf := &bytes.Buffer{}
+
+	// Act
+	err := generateEndpointTest(buf, service, resource, resource.Endpoints[0], "api", Options{})
+
+	// Assert
+	assert.Nil(t, err, "Expected no error when generating endpoint test")
+	assert.Contains(t, buf.String(), `requestURL := server.URL + "/test_service/v1/user_accounts/{id}"`, "Should request the path following the naming conventions")
+}
+
+func TestGenerateEndpointTest_GoIdentifiers(t *testing.T) {
+	// Arrange
+	service := createTestServiceWithQueryParams()
+	service.Resources[0].Endpoints[0].Request.QueryParams = append(service.Resources[0].Endpoints[0].Request.QueryParams,
+		specification.Field{Name: "type", Type: specification.FieldTypeString},
+		specification.Field{Name: "1st-place", Type: specification.FieldTypeString},
+	)
+	resource := service.Resources[0]
+	buf := &bytes.Buffer{}
+
+	// Act
+	err := generateEndpointTest(buf, service, resource, resource.Endpoints[0], "api", Options{})
+
+	// Assert
+	assert.Nil(t, err, "Expected no error when generating endpoint test")
+	assert.Contains(t, buf.String(), "testQueryType := ", "Should name the variable after the Go name of the parameter")
+	assert.Contains(t, buf.String(), "testQueryX1stPlace := ", "Should prefix the Go name of a parameter starting with a digit")
+	assert.Contains(t, buf.String(), `"1stPlace"`, "Should use the JSON name of the parameter in the request")
+}
+
+func TestGenerateEndpointTest_JSONNaming(t *testing.T) {
+	// Arrange
+	service := specification.ApplyDefaultOverlays(&specification.Service{
+		Name:    "TestService",
+		Version: "v1",
+		Naming:  &specification.Naming{JSON: specification.JSONCaseSnake},
+		Resources: []specification.Resource{{
+			Name:       "User",
+			Operations: []string{specification.OperationCreate},
+			Fields: []specification.ResourceField{
+				{Field: specification.Field{Name: "FirstName", Type: specification.FieldTypeString}, Operations: []string{specification.OperationCreate, specification.OperationRead}},
+			},
+		}},
+	})
+	resource := service.Resources[0]
+	buf := &bytes.Buffer{}
+
+	// Act
+	err := generateEndpointTest(buf, service, resource, resource.Endpoints[0], "api", Options{})
+
+	// Assert
+	assert.Nil(t, err, "Expected no error when generating endpoint test")
+	assert.Contains(t, buf.String(), `"first_name"`, "Should use the JSON names of the naming in the payloads")
+	assert.NotContains(t, buf.String(), `"firstName"`, "Should not use camelCase in the payloads")
+}
+
+func TestGenerateEndpointTest_TotalCountHeader(t *testing.T) {
+	// Arrange
+	service := specification.ApplyDefaultOverlays(&specification.Service{
+		Name:    "TestService",
+		Version: "v1",
+		Resources: []specification.Resource{{
+			Name:       "User",
+			Operations: []string{specification.OperationList},
+			TotalCount: specification.TotalCountHeader,
+			Fields: []specification.ResourceField{
+				{Field: specification.Field{Name: "Name", Type: specification.FieldTypeString}, Operations: []string{specification.OperationRead}},
+			},
+		}},
+	})
+	resource := service.Resources[0]
+	buf := &bytes.Buffer{}
+
+	// Act
+	err := generateEndpointTest(buf, service, resource, resource.Endpoints[0], "api", Options{})
+
+	// Assert
+	assert.Nil(t, err, "Expected no error when generating endpoint test")
+	assert.Contains(t, buf.String(), "TotalCount: types.NewInt(12345),", "Should set the total of the expected response")
+	assert.Contains(t, buf.String(), `assert.Equal(t, "12345", resp.Header.Get("X-Total-Count"), "Should set the X-Total-Count header")`, "Should assert the X-Total-Count header")
+}
+
+func TestGenerateEndpointTest_IntEnum(t *testing.T) {
+	// Arrange
+	service := specification.ApplyOverlay(&specification.Service{
+		Name:    "TestService",
+		Version: "v1",
+		Enums: []specification.Enum{
+			{Name: "Grade", EnumType: specification.EnumTypeInt, Values: []specification.EnumValue{{Name: "Pass", Value: "1"}}},
+		},
+		Resources: []specification.Resource{
+			{
+				Name:       "Results",
+				Operations: []string{"Create"},
+				Fields: []specification.ResourceField{
+					{Field: specification.Field{Name: "Grade", Type: "Grade"}, Operations: []string{"Create"}},
+				},
+			},
+		},
+	})
+	resource := service.Resources[0]
+	buf := &bytes.Buffer{}
+
+	// Act
+	err := generateEndpointTest(buf, service, resource, resource.Endpoints[0], "api", Options{})
+
+	// Assert
+	assert.Nil(t, err, "Expected no error when generating endpoint test")
+	assert.Contains(t, buf.String(), `"grade": float64(1),`, "Should send the first value of the int enum as an integer")
+}
+
+func TestGenerateEndpointTest_BodyCases(t *testing.T) {
+	// Arrange
+	service := specification.ApplyOverlay(&specification.Service{
+		Name:    "TestService",
+		Version: "v1",
+		Enums: []specification.Enum{
+			{Name: "Grade", EnumType: specification.EnumTypeInt, Values: []specification.EnumValue{{Name: "Pass", Value: "1", Aliases: []string{"3"}}, {Name: "Fail", Value: "2"}}},
+			{Name: "Role", Values: []specification.EnumValue{{Name: "Admin"}}},
+		},
+		Objects: []specification.Object{
+			{Name: "Address", Fields: []specification.Field{{Name: "City", Type: specification.FieldTypeString}}},
+		},
+		Resources: []specification.Resource{
+			{
+				Name:       "Results",
+				Operations: []string{"Create"},
+				Fields: []specification.ResourceField{
+					{Field: specification.Field{Name: "Name", Type: specification.FieldTypeString}, Operations: []string{"Create"}},
+					{Field: specification.Field{Name: "Grade", Type: "Grade"}, Operations: []string{"Create"}},
+					{Field: specification.Field{Name: "Role", Type: "Role"}, Operations: []string{"Create"}},
+					{Field: specification.Field{Name: "Nickname", Type: specification.FieldTypeString, Modifiers: []string{specification.ModifierNullable}}, Operations: []string{"Create"}},
+					{Field: specification.Field{Name: "Tags", Type: specification.FieldTypeString, Modifiers: []string{specification.ModifierArray}}, Operations: []string{"Create"}},
+					{Field: specification.Field{Name: "Address", Type: "Address"}, Operations: []string{"Create"}},
+				},
+			},
+		},
+	})
+	resource := service.Resources[0]
+	buf := &bytes.Buffer{}
+
+	// Act
+	err := generateEndpointTest(buf, service, resource, resource.Endpoints[0], "api", Options{})
+
+	// Assert
+	assert.Nil(t, err, "Expected no error when generating endpoint test")
+	code := buf.String()
+	assert.Contains(t, code, `t.Run("Body", func(t *testing.T) {`)
+	assert.Contains(t, code, "t.Run(tt.name, func(t *testing.T) {", "Each case should run as a subtest")
+	assert.Contains(t, code, `{name: "Valid", modify: func(body map[string]interface{}) {}},`)
+	assert.Contains(t, code, `{name: "MissingRequiredName", modify: func(body map[string]interface{}) { delete(body, "name") }},`, "A missing required field is left to the service")
+	assert.NotContains(t, code, `"MissingRequiredNickname"`, "Nullable fields are not required")
+	assert.Contains(t, code, `{name: "WrongTypeName", modify: func(body map[string]interface{}) { body["name"] = map[string]interface{}{} }, badRequest: true},`)
+	assert.Contains(t, code, `{name: "WrongTypeTags", modify: func(body map[string]interface{}) { body["tags"] = "test-wrong-type" }, badRequest: true},`)
+	assert.Contains(t, code, `{name: "WrongTypeAddress", modify: func(body map[string]interface{}) { body["address"] = "test-wrong-type" }, badRequest: true},`)
+	assert.NotContains(t, code, `"UnknownEnumValueGrade"`, "Integer-backed enums are Int fields, which accept any integer")
+	assert.Contains(t, code, `{name: "UnknownEnumValueRole", modify: func(body map[string]interface{}) { body["role"] = "test-unknown-value" }},`, "String enum values are left to the service")
+	assert.Contains(t, code, `{name: "NullNickname", modify: func(body map[string]interface{}) { body["nickname"] = nil }},`)
+	assert.Contains(t, code, "tt.modify(testBody)")
+	assert.Contains(t, code, "assert.Equal(t, 201, resp.StatusCode, \"Should accept the body. Response body: %s\", string(responseBodyBytes))")
+}
+
+func TestGenerateEndpointTest_BodyCasesWithoutJSONBody(t *testing.T) {
+	// Arrange
+	service := createTestService()
+	service.Resources[0].Endpoints[0].Request.ContentType = specification.ContentTypeForm
+	resource := service.Resources[0]
+	buf := &bytes.Buffer{}
+
+	// Act
+	err := generateInternalEndpointTest(buf, service, resource, resource.Endpoints[0], Options{})
+
+	// Assert
+	assert.Nil(t, err, "Expected no error when generating endpoint test")
+	assert.NotContains(t, buf.String(), `t.Run("Body"`, "Only JSON bodies have the table of body cases")
+}
+
+func TestGenerateEndpointTest_Strict(t *testing.T) {
+	// Arrange
+	service := specification.ApplyOverlay(&specification.Service{
+		Name:    "TestService",
+		Version: "v1",
+		Strict:  true,
+		Resources: []specification.Resource{
+			{
+				Name:       "Users",
+				Operations: []string{"Create", "Get"},
+				Fields: []specification.ResourceField{
+					{Field: specification.Field{Name: "Name", Type: "String"}, Operations: []string{"Create", "Read"}},
+				},
+			},
+		},
+	})
+	resource := service.Resources[0]
+
+	t.Run("endpoint with body", func(t *testing.T) {
+		// Arrange
+		buf := &bytes.Buffer{}
+
+		// Act
+		err := generateEndpointTest(buf, service, resource, resource.Endpoints[0], "api", Options{})
+
+		// Assert
+		assert.Nil(t, err, "Expected no error when generating endpoint test")
+		generatedCode := buf.String()
+		assert.Contains(t, generatedCode, `t.Run("UnknownField", func(t *testing.T) {`, "Should test a body with an unknown field")
+		assert.Contains(t, generatedCode, `testBody["unknownField"] = "test-unknown-value"`)
+		assert.Contains(t, generatedCode, "assert.Equal(t, http.StatusBadRequest, resp.StatusCode", "Should expect the request to be rejected")
+		assert.Contains(t, generatedCode, "if apiError, ok := err.(*api.Error); ok {", "Error hook should keep the status of the request errors")
+	})
+
+	t.Run("endpoint without body", func(t *testing.T) {
+		// Arrange
+		buf := &bytes.Buffer{}
+
+		// Act
+		err := generateEndpointTest(buf, service, resource, resource.Endpoints[1], "api", Options{})
+
+		// Assert
+		assert.Nil(t, err, "Expected no error when generating endpoint test")
+		assert.NotContains(t, buf.String(), "UnknownField", "Should not test unknown fields without a body")
+	})
+}
+
+func TestGenerateEndpointTest_MaxLimit(t *testing.T) {
+	createService := func(maxLimit int) *specification.Service {
+		return specification.ApplyDefaultOverlays(&specification.Service{
+			Name:     "TestService",
+			Version:  "v1",
+			MaxLimit: maxLimit,
+			Resources: []specification.Resource{
+				{
+					Name:       "Users",
+					Operations: []string{"List"},
+					Fields: []specification.ResourceField{
+						{Field: specification.Field{Name: "Name", Type: "String"}, Operations: []string{"Read"}},
+					},
+				},
+			},
+		})
+	}
+
+	t.Run("max limit", func(t *testing.T) {
+		// Arrange
+		service := createService(100)
+		resource := service.Resources[0]
+		buf := &bytes.Buffer{}
+
+		// Act
+		err := generateEndpointTest(buf, service, resource, resource.Endpoints[0], "api", Options{})
+
+		// Assert
+		assert.Nil(t, err, "Expected no error when generating endpoint test")
+		generatedCode := buf.String()
+		assert.Contains(t, generatedCode, `t.Run("MaxLimit", func(t *testing.T) {`, "Should test a limit above the max limit")
+		assert.Contains(t, generatedCode, `maxLimitQuery.Set("limit", fmt.Sprint(api.MaxLimit+1))`, "Should request a limit above the max limit")
+		assert.Contains(t, generatedCode, "assert.Equal(t, types.NewInt(int64(api.MaxLimit)), capturedRequest.QueryParams.Limit", "Should expect the limit to be clamped")
+	})
+
+	t.Run("without max limit", func(t *testing.T) {
+		// Arrange
+		service := createService(0)
+		resource := service.Resources[0]
+		buf := &bytes.Buffer{}
+
+		// Act
+		err := generateInternalEndpointTest(buf, service, resource, resource.Endpoints[0], Options{})
+
+		// Assert
+		assert.Nil(t, err, "Expected no error when generating endpoint test")
+		assert.NotContains(t, buf.String(), "MaxLimit", "Should not test the max limit without one")
+	})
+}
+
+func TestGenerateInternalTests_SoftDelete(t *testing.T) {
+	// Arrange
+	service := specification.ApplyOverlay(&specification.Service{
+		Name:    "TestService",
+		Version: "v1",
+		Resources: []specification.Resource{
+			{
+				Name:       "Users",
+				Operations: []string{"List", "Delete"},
+				SoftDelete: true,
+				Fields: []specification.ResourceField{
+					{Field: specification.Field{Name: "Name", Type: "String"}, Operations: []string{"Read"}},
+				},
+			},
+		},
+	})
+	buf := &bytes.Buffer{}
+
+	// Act
+	err := GenerateInternalTests(buf, service, "api")
+
+	// Assert
+	assert.Nil(t, err, "Expected no error when generating internal tests")
+	generatedCode := buf.String()
+	assert.Contains(t, generatedCode, "func TestUsersRestore(t *testing.T) {", "Should test the Restore endpoint")
+	assert.Contains(t, generatedCode, `req, err := http.NewRequestWithContext(ctx, "POST", requestURL, nil)`, "Should restore with a POST request")
+	assert.Contains(t, generatedCode, `query.Add("includeDeleted", fmt.Sprintf("%v", testQueryIncludeDeleted))`, "Should send the includeDeleted flag to List")
+}
+
+func TestGenerateInternalTests_Exportable(t *testing.T) {
+	// Arrange
+	service := specification.ApplyOverlay(&specification.Service{
+		Name:    "TestService",
+		Version: "v1",
+		Resources: []specification.Resource{
+			{
+				Name:       "Users",
+				Operations: []string{"List"},
+				Exportable: true,
+				Fields: []specification.ResourceField{
+					{Field: specification.Field{Name: "Name", Type: "String"}, Operations: []string{"Read"}},
+				},
+			},
+		},
+	})
+	buf := &bytes.Buffer{}
+
+	// Act
+	err := GenerateInternalTests(buf, service, "api")
+
+	// Assert
+	assert.Nil(t, err, "Expected no error when generating internal tests")
+	generatedCode := buf.String()
+	assert.Contains(t, generatedCode, "func TestUsersExport(t *testing.T) {", "Should test the Export endpoint")
+	assert.Contains(t, generatedCode, "\tExportFunc func(ctx context.Context, request Request[any, struct{}, struct{}, struct{}], write func(row *Users) error) error\n",
+		"Mock should have the streaming Export method")
+	assert.Contains(t, generatedCode, "\t\t\treturn write(expectedRow)\n", "Mock should write a row")
+	assert.Contains(t, generatedCode, "records, err := csv.NewReader(bytes.NewReader(responseBodyBytes)).ReadAll()", "Should read the response as CSV")
+	assert.Contains(t, generatedCode, "\t\"encoding/csv\"\n", "Should import encoding/csv")
+}
+
+func TestGenerateTests_Expansions(t *testing.T) {
+	// Arrange
+	service := specification.ApplyOverlay(&specification.Service{
+		Name:    "TestService",
+		Version: "v1",
+		Resources: []specification.Resource{
+			{Name: "Users", Operations: []string{"Get"}},
+			{
+				Name:       "Orders",
+				Operations: []string{"Get", "List"},
+				Fields: []specification.ResourceField{
+					{Field: specification.Field{Name: "BuyerID", Type: "UUID", References: "Users"}, Operations: []string{"Read"}},
+				},
+				Expansions: []specification.Expansion{{Name: "Buyer", Field: "BuyerID"}},
+			},
+		},
+	})
+	buf := &bytes.Buffer{}
+
+	// Act
+	err := GenerateTests(buf, service, "api_test", "api", "example.com/api")
+
+	// Assert
+	assert.Nil(t, err, "Expected no error when generating tests")
+	generatedCode := buf.String()
+	assert.Equal(t, 2, strings.Count(generatedCode, `assert.Equal(t, []any{testQueryExpand}, capturedQueryParams["expand"], "Query parameter Expand should match")`),
+		"Should expect the expand parameter of Get and List to be decoded into an array")
+	assert.NotContains(t, generatedCode, `assert.Equal(t, testQueryExpand, capturedQueryParams["expand"]`, "Should not compare the array with a string")
+}
+
+func TestGenerateTests_XML(t *testing.T) {
+	// Arrange
+	service := specification.ApplyOverlay(&specification.Service{
+		Name:    "TestService",
+		Version: "v1",
+		Strict:  true,
+		Resources: []specification.Resource{
+			{
+				Name:       "Users",
+				Operations: []string{"Create"},
+				Fields: []specification.ResourceField{
+					{Field: specification.Field{Name: "Name", Type: "String"}, Operations: []string{"Create", "Read"}},
+				},
+			},
+		},
+	})
+	service.Resources[0].Endpoints[0].Request.ContentType = specification.ContentTypeXML
+	service.Resources[0].Endpoints[0].Response.ContentType = specification.ContentTypeXML
+	buf := &bytes.Buffer{}
+
+	// Act
+	err := GenerateTestsWithOptions(buf, service, "api_test", "api", "example.com/api", Options{Benchmarks: true})
+
+	// Assert
+	assert.Nil(t, err, "Expected no error when generating tests")
+	generatedCode := buf.String()
+	assert.Contains(t, generatedCode, "\t\"encoding/xml\"\n", "Should import encoding/xml")
+	assert.Contains(t, generatedCode, "testBodyBytes, err = xml.Marshal(xmlBody)", "Should send the body as XML")
+	assert.Contains(t, generatedCode, `req.Header.Set("Content-Type", "application/xml")`, "Should send the XML content type")
+	assert.Contains(t, generatedCode, `assert.Equal(t, "application/xml", resp.Header.Get("Content-Type"), "Response should be XML")`, "Should expect an XML response")
+	assert.Contains(t, generatedCode, `assert.Equal(t, "Users", responseBody.XMLName.Local, "Response body should be the Users element")`, "Should expect the element of the response type")
+	assert.Contains(t, generatedCode, "body, err := xml.Marshal(bodyParams)", "Benchmark should send the body as XML")
+	assert.NotContains(t, generatedCode, "UnknownField", "Should not test unknown fields of XML bodies")
+}
+
+func TestGenerateTests_Form(t *testing.T) {
+	// Arrange
+	service := specification.ApplyOverlay(&specification.Service{
+		Name:    "TestService",
+		Version: "v1",
+		Strict:  true,
+		Resources: []specification.Resource{
+			{
+				Name:       "Users",
+				Operations: []string{"Create"},
+				Fields: []specification.ResourceField{
+					{Field: specification.Field{Name: "Name", Type: "String"}, Operations: []string{"Create", "Read"}},
+				},
+			},
+		},
+	})
+	service.Resources[0].Endpoints[0].Request.ContentType = specification.ContentTypeForm
+	buf := &bytes.Buffer{}
+
+	// Act
+	err := GenerateTestsWithOptions(buf, service, "api_test", "api", "example.com/api", Options{Benchmarks: true})
+
+	// Assert
+	assert.Nil(t, err, "Expected no error when generating tests")
+	generatedCode := buf.String()
+	assert.Contains(t, generatedCode, "testBodyBytes = []byte(form.Encode())", "Should send the body as a form")
+	assert.Contains(t, generatedCode, `req.Header.Set("Content-Type", "application/x-www-form-urlencoded")`, "Should send the form content type")
+	assert.Contains(t, generatedCode, `body := []byte("name=`, "Benchmark should send the body as a form")
+	assert.NotContains(t, generatedCode, "UnknownField", "Should not test unknown fields of forms")
+}
+
+func TestGenerateTests_SearchFilter(t *testing.T) {
+	// Arrange
+	service := specification.ApplyOverlay(&specification.Service{
+		Name:    "TestService",
+		Version: "v1",
+		Resources: []specification.Resource{
+			{
+				Name:       "Users",
+				Operations: []string{"Search"},
+				Fields: []specification.ResourceField{
+					{Field: specification.Field{Name: "Name", Type: "String"}, Operations: []string{"Create", "Read"}},
+				},
+			},
+		},
+	})
+	buf := &bytes.Buffer{}
+
+	// Act
+	err := GenerateTests(buf, service, "api_test", "api", "example.com/api")
+
+	// Assert
+	assert.Nil(t, err, "Expected no error when generating tests")
+	generatedCode := buf.String()
+	for _, operator := range []string{"equals", "greaterThan", "contains", "like", "startsWith", "notStartsWith", "null"} {
+		assert.Regexp(t, `"`+operator+`":\s+nil,`, generatedCode, "Search body should send the %s filter", operator)
+	}
+}
+
+func TestFormValues(t *testing.T) {
+	// Arrange
+	body := map[string]any{"name": "Alice", "age": float64(1000000), "tags": []any{"admin", "staff"}}
+
+	// Act
+	form, err := formValues(body)
+
+	// Assert
+	assert.NoError(t, err, "Expected no error when encoding the form")
+	assert.Equal(t, "Alice", form.Get("name"), "Should write strings as is")
+	assert.Equal(t, "1000000", form.Get("age"), "Should write numbers without exponent")
+	assert.Equal(t, []string{"admin", "staff"}, form["tags"], "Should repeat the key for each value of an array")
+}
+
+func TestGenerateServerSetup_ResponseEncoders(t *testing.T) {
+	// Arrange
+	service := createTestService()
+	service.Resources[0].Endpoints[0].Response.AlternativeContentTypes = []string{"text/csv"}
+	resource := service.Resources[0]
+	buf := &bytes.Buffer{}
+
+	// Act
+	err := generateServerSetup(buf, "TestService", service, resource, resource.Endpoints[0], "api", servergen.Types{})
+
+	// Assert
+	assert.Nil(t, err, "Expected no error when generating server setup")
+	assert.Contains(t, buf.String(), "ResponseEncoders: map[string]api.ResponseEncoder{", "Should register the response encoders Register requires")
+	assert.Contains(t, buf.String(), `"text/csv": func(ctx context.Context, w io.Writer, response any) error {`, "Should register an encoder for the content type")
+}
+
+func TestGenerateServerSetup_HasScopeFunc(t *testing.T) {
+	// Arrange
+	service := createTestService()
+	service.Objects = append(service.Objects, specification.Object{
+		Name:   "Secret",
+		Fields: []specification.Field{{Name: "Value", Type: "String", Modifiers: []string{"Nullable"}, Scopes: []string{"secrets:read"}}},
+	})
+	resource := service.Resources[0]
+	buf := &bytes.Buffer{}
+
+	// Act
+	err := generateServerSetup(buf, "TestService", service, resource, resource.Endpoints[0], "api", servergen.Types{})
+
+	// Assert
+	assert.Nil(t, err, "Expected no error when generating server setup")
+	assert.Contains(t, buf.String(), "HasScopeFunc: func(ctx context.Context, session any, scope string) bool {\n\t\t\t\t\treturn true\n",
+		"Should grant every scope, since Register requires HasScopeFunc")
+}
+
+func TestGenerateServerSetup_FeatureEnabledFunc(t *testing.T) {
+	// Arrange
+	service := createTestService()
+	service.Resources[0].Endpoints[0].FeatureFlag = "users-export"
+	resource := service.Resources[0]
+	buf := &bytes.Buffer{}
+
+	// Act
+	err := generateServerSetup(buf, "TestService", service, resource, resource.Endpoints[0], "api", servergen.Types{})
+
+	// Assert
+	assert.Nil(t, err, "Expected no error when generating server setup")
+	assert.Contains(t, buf.String(), "FeatureEnabledFunc: func(ctx context.Context, flag string) bool {\n\t\t\t\t\treturn true\n",
+		"Should enable every feature flag, since Register requires FeatureEnabledFunc")
+}
+
+func TestSeedBodyParams(t *testing.T) {
+	// Arrange
+	resource := specification.Resource{
+		Name: "Users",
+		Examples: []specification.ResourceExample{
+			{Name: "alice", Values: map[string]any{"name": "Alice", "age": float64(34), "tags": []any{"admin"}}},
+			{Name: "bob", Values: map[string]any{"name": "Bob"}},
+		},
+	}
+	bodyParams := []specification.Field{
+		{Name: "Name", Type: "String", Example: "example"},
+		{Name: "Age", Type: "Int"},
+		{Name: "Tags", Type: "String", Modifiers: []string{"Array"}},
+		{Name: "Email", Type: "String", Example: "user@example.com"},
+	}
+
+	// Act
+	seeded := seedBodyParams(resource, bodyParams)
+
+	// Assert
+	assert.Equal(t, "Alice", seeded[0].Example, "Should use the value of the first example")
+	assert.Equal(t, "34", seeded[1].Example, "Should format numbers without a decimal point")
+	assert.Empty(t, seeded[2].Example, "Should not seed arrays")
+	assert.Equal(t, "user@example.com", seeded[3].Example, "Should keep the field example without a value")
+	assert.Equal(t, "example", bodyParams[0].Example, "Should not modify the body params")
+}
+
 // ============================================================================
 // generateHelperFunctions Tests
 // ============================================================================
@@ -431,6 +1326,7 @@ func TestGetJSONKey(t *testing.T) {
 	testCases := []struct {
 		name        string
 		fieldName   string
+		jsonName    string
 		expectedKey string
 	}{
 		{
@@ -453,12 +1349,18 @@ func TestGetJSONKey(t *testing.T) {
 			fieldName:   "CreatedAt",
 			expectedKey: "createdAt",
 		},
+		{
+			name:        "field with a JSON name",
+			fieldName:   "CreatedAt",
+			jsonName:    "created_at",
+			expectedKey: "created_at",
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			// Act
-			result := getJSONKey(tc.fieldName)
+			result := getJSONKey(specification.Field{Name: tc.fieldName, JSONName: tc.jsonName})
 
 			// Assert
 			assert.Equal(t, tc.expectedKey, result,
@@ -645,7 +1547,7 @@ func TestGenerateHeaderPopulation(t *testing.T) {
 			buf := &bytes.Buffer{}
 
 			// Act
-			generateHeaderPopulation(buf, service, tc.packagePrefix)
+			generateHeaderPopulation(buf, service, tc.packagePrefix, servergen.Types{})
 
 			// Assert
 			generatedCode := buf.String()
@@ -662,7 +1564,7 @@ func TestGenerateHeaderPopulation(t *testing.T) {
 			}
 			buf := &bytes.Buffer{}
 
-			generateHeaderPopulation(buf, service, "")
+			generateHeaderPopulation(buf, service, "", servergen.Types{})
 
 			assert.Empty(t, buf.String(), "Should generate nothing for empty response headers")
 		})
@@ -673,7 +1575,7 @@ func TestGenerateHeaderPopulation(t *testing.T) {
 			}
 			buf := &bytes.Buffer{}
 
-			generateHeaderPopulation(buf, service, "")
+			generateHeaderPopulation(buf, service, "", servergen.Types{})
 
 			assert.Empty(t, buf.String(), "Should generate nothing for nil response headers")
 		})
@@ -710,7 +1612,7 @@ func TestGenerateHeaderAssertions(t *testing.T) {
 				{Name: "RateLimit-Reset", Type: "Int64"},
 			},
 			expectedContains: []string{
-				"assert.Equal(t, \"67890\", w.Header().Get(\"RateLimit-Reset\")",
+				"assert.Equal(t, \"67890\", strings.Join(w.Header()[\"RateLimit-Reset\"], \", \")",
 			},
 		},
 		{
@@ -728,7 +1630,7 @@ func TestGenerateHeaderAssertions(t *testing.T) {
 				{Name: "X-Request-ID", Type: "UUID"},
 			},
 			expectedContains: []string{
-				"assert.NotEmpty(t, w.Header().Get(\"X-Request-ID\")",
+				"assert.NotEmpty(t, strings.Join(w.Header()[\"X-Request-ID\"], \", \")",
 			},
 		},
 		{
@@ -749,6 +1651,17 @@ func TestGenerateHeaderAssertions(t *testing.T) {
 				"assert.Equal(t, \"2024-01-15T10:30:00Z\", w.Header().Get(\"X-Created-At\")",
 			},
 		},
+		{
+			name: "header name header assertion",
+			responseHeaders: []specification.Field{
+				{Name: "TotalCount", HeaderName: "X-Total-Count", Type: "Int"},
+				{Name: "TraceID", HeaderName: "x-trace-id", Type: "String"},
+			},
+			expectedContains: []string{
+				"assert.Equal(t, \"12345\", w.Header().Get(\"X-Total-Count\"), \"Header X-Total-Count should be set\")",
+				"assert.Equal(t, \"test-traceid-value\", strings.Join(w.Header()[\"x-trace-id\"], \", \"), \"Header x-trace-id should be set\")",
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -783,3 +1696,19 @@ func TestGenerateHeaderAssertions(t *testing.T) {
 		})
 	})
 }
+
+func TestGenerateServerSetup_CheckScopesFunc(t *testing.T) {
+	// Arrange
+	service := createTestService()
+	service.Resources[0].Scopes = []string{"users:read"}
+	resource := service.Resources[0]
+	buf := &bytes.Buffer{}
+
+	// Act
+	err := generateServerSetup(buf, "TestService", service, resource, resource.Endpoints[0], "api", servergen.Types{})
+
+	// Assert
+	assert.Nil(t, err, "Expected no error when generating server setup")
+	assert.Contains(t, buf.String(), "CheckScopesFunc: func(ctx context.Context, requestContext api.RequestContext, session any, requiredScopes []string) error {\n\t\t\t\t\treturn nil\n",
+		"Should grant every scope, since Register requires CheckScopesFunc")
+}
@@ -1,9 +1,14 @@
 package specification
 
 import (
+	"os"
+	"path/filepath"
+	"slices"
 	"testing"
 
+	"github.com/goccy/go-yaml/parser"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // ============================================================================
@@ -96,6 +101,81 @@ func TestValidateService(t *testing.T) {
 		assert.Error(t, err, "Service with invalid field type should fail validation")
 		assert.Contains(t, err.Error(), "field type")
 	})
+
+	t.Run("collects all errors with their paths", func(t *testing.T) {
+		service := &Service{
+			Name:     "TestService",
+			BasePath: "api",
+			Resources: []Resource{
+				{
+					Name:       "Users",
+					Operations: []string{"invalid"},
+					Fields: []ResourceField{
+						{Field: Field{Name: "name", Type: "InvalidType", Modifiers: []string{"invalid"}}, Operations: []string{OperationRead}},
+					},
+				},
+			},
+		}
+
+		err := validateService(service)
+
+		var validationErrors ValidationErrors
+		require.ErrorAs(t, err, &validationErrors)
+		paths := make([]string, len(validationErrors))
+		for i, validationErr := range validationErrors {
+			paths[i] = validationErr.Path
+		}
+		assert.Equal(t, []string{
+			"$.base_path",
+			"$.resources[0].operations",
+			"$.resources[0].fields[0].type",
+			"$.resources[0].fields[0].modifiers",
+		}, paths)
+		assert.Contains(t, validationErrors[2].Message, "resource 0 (Users): field 0 (name): field type: invalid field type")
+		assert.Contains(t, err.Error(), "4 validation errors:\n  - validation error ($.base_path): invalid base path")
+	})
+}
+
+// ============================================================================
+// ValidateServiceWithPosition Tests
+// ============================================================================
+
+func TestValidateServiceWithPosition(t *testing.T) {
+	data := []byte(`name: TestService
+resources:
+  - name: Users
+    description: User resource
+    operations: [Create, Fetch]
+    fields:
+      - name: age
+        description: User age
+        type: Int
+        default: old
+        operations: [Create, Read]
+`)
+
+	t.Run("adds the positions of the offending nodes", func(t *testing.T) {
+		err := ValidateServiceWithPosition(data, extYAML)
+
+		var validationErrors ValidationErrors
+		require.ErrorAs(t, err, &validationErrors)
+		require.Len(t, validationErrors, 2)
+		assert.Equal(t, 5, validationErrors[0].Line)
+		assert.Equal(t, 17, validationErrors[0].Column)
+		assert.Equal(t, 10, validationErrors[1].Line)
+		assert.Equal(t, 18, validationErrors[1].Column)
+	})
+
+	t.Run("uses the closest ancestor of omitted nodes", func(t *testing.T) {
+		file, err := parser.ParseBytes(data, parser.ParseComments)
+		require.NoError(t, err)
+
+		position := findPosition(file, "$.resources[0].fields[0].required_on")
+
+		require.NotNil(t, position)
+		assert.Equal(t, 7, position.Line)
+		assert.Equal(t, 9, position.Column)
+	})
 }
 
 // ============================================================================
@@ -427,6 +507,75 @@ func TestValidateResource(t *testing.T) {
 		assert.Contains(t, err.Error(), "resource operations")
 	})
 
+	t.Run("soft delete without delete operation", func(t *testing.T) {
+		invalidResource := validResource
+		invalidResource.SoftDelete = true
+
+		err := validateResource(service, &invalidResource)
+		assert.Error(t, err, "Soft delete of a resource without Delete should fail validation")
+		assert.Contains(t, err.Error(), "soft_delete requires the Delete operation")
+
+		invalidResource.Operations = append(invalidResource.Operations, OperationDelete)
+		assert.NoError(t, validateResource(service, &invalidResource), "Soft delete of a resource with Delete should pass validation")
+	})
+
+	t.Run("exportable without list operation", func(t *testing.T) {
+		invalidResource := validResource
+		invalidResource.Operations = []string{OperationCreate, OperationGet}
+		invalidResource.Exportable = true
+
+		err := validateResource(service, &invalidResource)
+		assert.Error(t, err, "Export of a resource without List should fail validation")
+		assert.Contains(t, err.Error(), "exportable requires the List operation")
+
+		invalidResource.Operations = append(invalidResource.Operations, OperationList)
+		assert.NoError(t, validateResource(service, &invalidResource), "Export of a resource with List should pass validation")
+	})
+
+	t.Run("total count", func(t *testing.T) {
+		for _, totalCount := range []string{"", TotalCountPagination, TotalCountHeader} {
+			resource := validResource
+			resource.TotalCount = totalCount
+			assert.NoError(t, validateResource(service, &resource), "Total count '%s' should pass validation", totalCount)
+		}
+
+		invalidResource := validResource
+		invalidResource.TotalCount = "body"
+		err := validateResource(service, &invalidResource)
+		assert.Error(t, err, "Unsupported total count should fail validation")
+		assert.Contains(t, err.Error(), errorInvalidTotalCount)
+
+		invalidResource.TotalCount = TotalCountHeader
+		invalidResource.Operations = []string{OperationCreate, OperationGet}
+		err = validateResource(service, &invalidResource)
+		assert.Error(t, err, "Total count header of a resource without List or Search should fail validation")
+		assert.Contains(t, err.Error(), "total_count 'header' requires the List or Search operation")
+	})
+
+	t.Run("resource with invalid parent", func(t *testing.T) {
+		parentService := &Service{
+			Resources: []Resource{
+				{Name: "Posts", Operations: []string{OperationGet}, Parent: "Comments"},
+				{Name: "Comments", Operations: []string{OperationGet}, Parent: "Posts"},
+				{Name: "Likes", Operations: []string{OperationGet}, Parent: "Comments"},
+			},
+		}
+
+		undefinedParent := Resource{Name: "Tags", Operations: []string{OperationGet}, Parent: "Authors"}
+		err := validateResource(parentService, &undefinedParent)
+		assert.Error(t, err, "Resource with an undefined parent should fail validation")
+		assert.Contains(t, err.Error(), "parent 'Authors' of resource 'Tags' is not defined")
+
+		err = validateResource(parentService, &parentService.Resources[2])
+		assert.Error(t, err, "Resource with cyclic parents should fail validation")
+		assert.Contains(t, err.Error(), "invalid parent")
+
+		ownParent := Resource{Name: "Tags", Operations: []string{OperationGet}, Parent: "Tags"}
+		err = validateResource(parentService, &ownParent)
+		assert.Error(t, err, "Resource that is its own parent should fail validation")
+		assert.Contains(t, err.Error(), "resource 'Tags' is its own ancestor")
+	})
+
 	t.Run("resource with invalid field", func(t *testing.T) {
 		invalidResource := validResource
 		invalidResource.Fields[0].Type = "InvalidType"
@@ -620,3 +769,1556 @@ resources:
 		assert.Contains(t, err.Error(), "invalid modifier")
 	})
 }
+
+// ============================================================================
+// validateSecurity Tests
+// ============================================================================
+
+func TestValidateSecurity(t *testing.T) {
+	oauthScheme := SecurityScheme{
+		Type: SecuritySchemeTypeOAuth2,
+		Flows: &OAuth2Flows{
+			ClientCredentials: &OAuth2Flow{
+				TokenURL: "https://auth.example.com/token",
+				Scopes:   map[string]string{"users:read": "Read users"},
+			},
+		},
+	}
+
+	t.Run("valid security schemes and requirements", func(t *testing.T) {
+		service := &Service{
+			SecuritySchemes: map[string]SecurityScheme{
+				"oauth": oauthScheme,
+				"oidc":  {Type: SecuritySchemeTypeOpenIDConnect, OpenIDConnectURL: "https://auth.example.com/.well-known/openid-configuration"},
+				"mtls":  {Type: SecuritySchemeTypeMutualTLS},
+			},
+			Security: []SecurityRequirement{{"oauth"}, {"oidc", "mtls"}},
+		}
+
+		err := validateSecurity(service)
+		assert.NoError(t, err, "Valid security configuration should pass validation")
+	})
+
+	t.Run("oauth2 scheme without flows", func(t *testing.T) {
+		service := &Service{
+			SecuritySchemes: map[string]SecurityScheme{"oauth": {Type: SecuritySchemeTypeOAuth2}},
+		}
+
+		err := validateSecurity(service)
+		assert.Error(t, err, "OAuth2 scheme without flows should fail validation")
+		assert.Contains(t, err.Error(), "at least one flow")
+	})
+
+	t.Run("openIdConnect scheme without URL", func(t *testing.T) {
+		service := &Service{
+			SecuritySchemes: map[string]SecurityScheme{"oidc": {Type: SecuritySchemeTypeOpenIDConnect}},
+		}
+
+		err := validateSecurity(service)
+		assert.Error(t, err, "OpenID Connect scheme without URL should fail validation")
+		assert.Contains(t, err.Error(), "openIdConnectUrl")
+	})
+
+	t.Run("unsupported scheme type", func(t *testing.T) {
+		service := &Service{
+			SecuritySchemes: map[string]SecurityScheme{"custom": {Type: "custom"}},
+		}
+
+		err := validateSecurity(service)
+		assert.Error(t, err, "Unsupported scheme type should fail validation")
+		assert.Contains(t, err.Error(), errorInvalidSecurity)
+	})
+
+	t.Run("invalid schemes are reported in name order", func(t *testing.T) {
+		service := &Service{
+			SecuritySchemes: map[string]SecurityScheme{
+				"c": {Type: "custom"},
+				"a": {Type: SecuritySchemeTypeOAuth2},
+				"b": {Type: SecuritySchemeTypeOpenIDConnect},
+			},
+		}
+
+		for range 10 {
+			err := validateSecurity(service)
+			assert.ErrorContains(t, err, "scheme 'a'", "The first invalid scheme by name should be reported")
+		}
+	})
+
+	t.Run("requirement references undefined scheme", func(t *testing.T) {
+		service := &Service{
+			SecuritySchemes: map[string]SecurityScheme{"oauth": oauthScheme},
+			Security:        []SecurityRequirement{{"missing"}},
+		}
+
+		err := validateSecurity(service)
+		assert.Error(t, err, "Requirement with undefined scheme should fail validation")
+		assert.Contains(t, err.Error(), "undefined scheme 'missing'")
+	})
+
+	t.Run("declared scope passes validation", func(t *testing.T) {
+		service := &Service{SecuritySchemes: map[string]SecurityScheme{"oauth": oauthScheme}}
+
+		err := validateScopes(service, []string{"users:read"})
+		assert.NoError(t, err, "Declared scope should pass validation")
+	})
+
+	t.Run("undeclared scope fails validation", func(t *testing.T) {
+		service := &Service{SecuritySchemes: map[string]SecurityScheme{"oauth": oauthScheme}}
+
+		err := validateScopes(service, []string{"users:delete"})
+		assert.Error(t, err, "Undeclared scope should fail validation")
+		assert.Contains(t, err.Error(), errorUndefinedScope)
+	})
+
+	t.Run("scopes without scoped security scheme fail validation", func(t *testing.T) {
+		service := &Service{SecuritySchemes: map[string]SecurityScheme{"mtls": {Type: SecuritySchemeTypeMutualTLS}}}
+
+		err := validateScopes(service, []string{"users:read"})
+		assert.Error(t, err, "Scopes require an OAuth2 or OpenID Connect scheme")
+	})
+
+	t.Run("scopes are not checked with openIdConnect scheme", func(t *testing.T) {
+		service := &Service{
+			SecuritySchemes: map[string]SecurityScheme{
+				"oidc": {Type: SecuritySchemeTypeOpenIDConnect, OpenIDConnectURL: "https://auth.example.com"},
+			},
+		}
+
+		err := validateScopes(service, []string{"anything"})
+		assert.NoError(t, err, "OpenID Connect scopes are discovered at runtime")
+	})
+}
+
+func TestValidateFieldScopes(t *testing.T) {
+	service := &Service{
+		SecuritySchemes: map[string]SecurityScheme{
+			"oauth": {
+				Type: SecuritySchemeTypeOAuth2,
+				Flows: &OAuth2Flows{
+					ClientCredentials: &OAuth2Flow{TokenURL: "https://auth.example.com/token", Scopes: map[string]string{"users:ssn": "Read social security numbers"}},
+				},
+			},
+		},
+	}
+
+	t.Run("nullable field with declared scope", func(t *testing.T) {
+		field := &Field{Name: "SSN", Type: FieldTypeString, Modifiers: []string{ModifierNullable}, Scopes: []string{"users:ssn"}}
+
+		err := validateFieldScopes(service, field)
+		assert.NoError(t, err, "Nullable field with declared scope should pass validation")
+	})
+
+	t.Run("field that is not nullable", func(t *testing.T) {
+		field := &Field{Name: "SSN", Type: FieldTypeString, Scopes: []string{"users:ssn"}}
+
+		err := validateFieldScopes(service, field)
+		assert.Error(t, err, "Field with scopes must be nullable")
+		assert.Contains(t, err.Error(), errorInvalidModifier)
+	})
+
+	t.Run("undeclared scope", func(t *testing.T) {
+		field := &Field{Name: "SSN", Type: FieldTypeString, Modifiers: []string{ModifierNullable}, Scopes: []string{"users:delete"}}
+
+		err := validateFieldScopes(service, field)
+		assert.Error(t, err, "Undeclared scope should fail validation")
+		assert.Contains(t, err.Error(), errorUndefinedScope)
+	})
+}
+
+func TestValidateFieldReferences(t *testing.T) {
+	service := &Service{
+		Resources: []Resource{{Name: "User"}},
+	}
+
+	t.Run("UUID field referencing a resource", func(t *testing.T) {
+		field := &Field{Name: "UserID", Type: FieldTypeUUID, References: "User"}
+
+		err := validateFieldReferences(service, field)
+		assert.NoError(t, err, "UUID field referencing an existing resource should pass validation")
+	})
+
+	t.Run("field that is not a UUID", func(t *testing.T) {
+		field := &Field{Name: "UserName", Type: FieldTypeString, References: "User"}
+
+		err := validateFieldReferences(service, field)
+		assert.Error(t, err, "Only UUID fields can reference a resource")
+		assert.Contains(t, err.Error(), errorInvalidReference)
+	})
+
+	t.Run("unknown resource", func(t *testing.T) {
+		field := &Field{Name: "GroupID", Type: FieldTypeUUID, References: "Group"}
+
+		err := validateFieldReferences(service, field)
+		assert.Error(t, err, "Referencing an undefined resource should fail validation")
+		assert.Contains(t, err.Error(), "unknown resource 'Group'")
+	})
+}
+
+func TestValidateAggregations(t *testing.T) {
+	service := &Service{
+		Objects: []Object{{Name: "Address", Fields: []Field{{Name: "City", Type: FieldTypeString}}}},
+	}
+	createResource := func(aggregations Aggregations) *Resource {
+		return &Resource{
+			Name:         "Orders",
+			Operations:   []string{OperationList},
+			Aggregations: &aggregations,
+			Fields: []ResourceField{
+				{Field: Field{Name: "Status", Type: FieldTypeString}, Operations: []string{OperationRead}},
+				{Field: Field{Name: "Total", Type: FieldTypeDecimal}, Operations: []string{OperationRead}},
+				{Field: Field{Name: "Cost", Type: FieldTypeInt}, Operations: []string{OperationCreate}},
+				{Field: Field{Name: "Tags", Type: FieldTypeString, Modifiers: []string{ModifierArray}}, Operations: []string{OperationRead}},
+				{Field: Field{Name: "Address", Type: "Address"}, Operations: []string{OperationRead}},
+				{Field: Field{Name: "PlacedAt", Type: FieldTypeTimestamp}, Operations: []string{OperationRead}},
+			},
+		}
+	}
+
+	t.Run("valid aggregations", func(t *testing.T) {
+		err := validateAggregations(service, createResource(Aggregations{CountBy: []string{"Status"}, Sum: []string{"Total"}}))
+		assert.NoError(t, err, "Sums of numbers and counts by single values should pass validation")
+
+		assert.NoError(t, validateAggregations(service, &Resource{Name: "Orders"}), "Resources without aggregations should pass validation")
+	})
+
+	t.Run("invalid aggregations", func(t *testing.T) {
+		tests := []struct {
+			name         string
+			aggregations Aggregations
+			expected     string
+		}{
+			{name: "unknown sum field", aggregations: Aggregations{Sum: []string{"Price"}}, expected: "unknown sum field 'Price'"},
+			{name: "sum field that is not readable", aggregations: Aggregations{Sum: []string{"Cost"}}, expected: "sum field 'Cost' is not readable"},
+			{name: "sum field that is not a number", aggregations: Aggregations{Sum: []string{"Status"}}, expected: "sum field 'Status' must be a number"},
+			{name: "unknown count_by field", aggregations: Aggregations{CountBy: []string{"Country"}}, expected: "unknown count_by field 'Country'"},
+			{name: "count_by array", aggregations: Aggregations{CountBy: []string{"Tags"}}, expected: "count_by field 'Tags' must be a single value"},
+			{name: "count_by object", aggregations: Aggregations{CountBy: []string{"Address"}}, expected: "count_by field 'Address' must be a single value"},
+			{name: "count_by timestamp", aggregations: Aggregations{CountBy: []string{"PlacedAt"}}, expected: "count_by field 'PlacedAt' must be a single value"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				err := validateAggregations(service, createResource(tt.aggregations))
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), errorInvalidAggregate)
+				assert.Contains(t, err.Error(), tt.expected)
+			})
+		}
+	})
+}
+
+func TestValidateAsyncEndpoints(t *testing.T) {
+	createService := func(endpoint Endpoint) *Service {
+		return &Service{
+			Resources: []Resource{{Name: "Reports", Endpoints: []Endpoint{endpoint}}},
+		}
+	}
+
+	t.Run("valid async endpoints", func(t *testing.T) {
+		err := validateAsyncEndpoints(createService(Endpoint{Name: "Generate", Method: "POST", Async: true}))
+		assert.NoError(t, err, "Async endpoints without a response body should pass validation")
+
+		service := createService(Endpoint{Name: "Generate", Method: "POST"})
+		service.Resources = append(service.Resources, Resource{Name: "Operation"})
+		assert.NoError(t, validateAsyncEndpoints(service), "The names are only reserved when the service has async endpoints")
+	})
+
+	t.Run("invalid async endpoints", func(t *testing.T) {
+		reportName := "Report"
+		tests := []struct {
+			name     string
+			service  func() *Service
+			expected string
+		}{
+			{
+				name: "response body object",
+				service: func() *Service {
+					return createService(Endpoint{Name: "Generate", Async: true, Response: EndpointResponse{BodyObject: &reportName}})
+				},
+				expected: "endpoint 'ReportsGenerate' responds with the Operation",
+			},
+			{
+				name: "response body fields",
+				service: func() *Service {
+					return createService(Endpoint{Name: "Generate", Async: true, Response: EndpointResponse{BodyFields: []Field{{Name: "Title", Type: FieldTypeString}}}})
+				},
+				expected: "endpoint 'ReportsGenerate' responds with the Operation",
+			},
+			{
+				name: "operation resource",
+				service: func() *Service {
+					service := createService(Endpoint{Name: "Generate", Async: true})
+					service.Resources = append(service.Resources, Resource{Name: "Operation"})
+					return service
+				},
+				expected: "resource name 'Operation' is reserved",
+			},
+			{
+				name: "operation object",
+				service: func() *Service {
+					service := createService(Endpoint{Name: "Generate", Async: true})
+					service.Objects = []Object{{Name: "Operation"}}
+					return service
+				},
+				expected: "object name 'Operation' is reserved",
+			},
+			{
+				name: "operation status enum",
+				service: func() *Service {
+					service := createService(Endpoint{Name: "Generate", Async: true})
+					service.Enums = []Enum{{Name: "OperationStatus"}}
+					return service
+				},
+				expected: "enum name 'OperationStatus' is reserved",
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				err := validateAsyncEndpoints(tt.service())
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), errorInvalidAsync)
+				assert.Contains(t, err.Error(), tt.expected)
+			})
+		}
+	})
+}
+
+func TestValidateCallback(t *testing.T) {
+	service := &Service{
+		Objects:   []Object{{Name: "Shipment", Fields: []Field{{Name: "TrackingCode", Type: FieldTypeString}}}},
+		Resources: []Resource{{Name: "Orders", Operations: []string{OperationGet}}, {Name: "Carts", Operations: []string{OperationCreate}}},
+	}
+	createEndpoint := func(callbacks ...Callback) *Endpoint {
+		return &Endpoint{
+			Name: "Subscribe",
+			Request: EndpointRequest{BodyParams: []Field{
+				{Name: "CallbackURL", Type: FieldTypeString},
+				{Name: "CallbackURLs", Type: FieldTypeString, Modifiers: []string{ModifierArray}},
+				{Name: "Attempts", Type: FieldTypeInt},
+			}},
+			Callbacks: callbacks,
+		}
+	}
+
+	t.Run("valid callbacks", func(t *testing.T) {
+		endpoint := createEndpoint(
+			Callback{Name: "OrderShipped", URLField: "CallbackURL", Payload: "Shipment"},
+			Callback{Name: "OrderUpdated", URLField: "CallbackURL", Method: "put", Payload: "Orders"},
+		)
+		for i := range endpoint.Callbacks {
+			assert.NoError(t, validateCallback(service, endpoint, i), "Callbacks with objects and readable resources as payload should pass validation")
+		}
+	})
+
+	t.Run("invalid callbacks", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			callback Callback
+			expected string
+		}{
+			{name: "missing name", callback: Callback{URLField: "CallbackURL", Payload: "Shipment"}, expected: "name is required"},
+			{name: "duplicate name", callback: Callback{Name: "OrderShipped", URLField: "CallbackURL", Payload: "Shipment"}, expected: "name 'OrderShipped' is used by another callback"},
+			{name: "unknown url field", callback: Callback{Name: "Shipped", URLField: "URL", Payload: "Shipment"}, expected: "url_field 'URL' must be a body param"},
+			{name: "url field that is not a string", callback: Callback{Name: "Shipped", URLField: "Attempts", Payload: "Shipment"}, expected: "url_field 'Attempts' must be a single String"},
+			{name: "url field that is an array", callback: Callback{Name: "Shipped", URLField: "CallbackURLs", Payload: "Shipment"}, expected: "url_field 'CallbackURLs' must be a single String"},
+			{name: "unsupported method", callback: Callback{Name: "Shipped", URLField: "CallbackURL", Method: "GET", Payload: "Shipment"}, expected: "method 'GET' must be POST, PUT or PATCH"},
+			{name: "unknown payload", callback: Callback{Name: "Shipped", URLField: "CallbackURL", Payload: "Parcel"}, expected: "payload 'Parcel' must be an object"},
+			{name: "payload of a resource without reads", callback: Callback{Name: "Shipped", URLField: "CallbackURL", Payload: "Carts"}, expected: "payload 'Carts' must be an object"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				endpoint := createEndpoint(Callback{Name: "OrderShipped", URLField: "CallbackURL", Payload: "Shipment"}, tt.callback)
+				err := validateCallback(service, endpoint, 1)
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), errorInvalidCallback)
+				assert.Contains(t, err.Error(), tt.expected)
+			})
+		}
+	})
+}
+
+func TestValidateServer(t *testing.T) {
+	t.Run("valid servers", func(t *testing.T) {
+		assert.NoError(t, validateServer(ServiceServer{URL: "https://api.example.com"}), "Servers without variables should pass validation")
+		assert.NoError(t, validateServer(ServiceServer{
+			URL:       "https://{region}.api.example.com",
+			Variables: []ServerVariable{{Name: "region", Default: "eu", Enum: []string{"eu", "us"}}},
+		}), "Servers with variables used in the URL should pass validation")
+	})
+
+	t.Run("invalid servers", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			server   ServiceServer
+			expected string
+		}{
+			{name: "undeclared placeholder", server: ServiceServer{URL: "https://{region}.api.example.com"}, expected: "placeholder '{region}' of the URL is not a variable"},
+			{name: "missing name", server: ServiceServer{URL: "https://api.example.com", Variables: []ServerVariable{{Default: "eu"}}}, expected: "variable name is required"},
+			{name: "duplicate variable", server: ServiceServer{URL: "https://{region}.api.example.com", Variables: []ServerVariable{{Name: "region", Default: "eu"}, {Name: "region", Default: "us"}}}, expected: "variable 'region' is declared more than once"},
+			{name: "unused variable", server: ServiceServer{URL: "https://api.example.com", Variables: []ServerVariable{{Name: "region", Default: "eu"}}}, expected: "variable 'region' is not used in the URL"},
+			{name: "missing default", server: ServiceServer{URL: "https://{region}.api.example.com", Variables: []ServerVariable{{Name: "region"}}}, expected: "variable 'region' requires a default"},
+			{name: "default outside the enum", server: ServiceServer{URL: "https://{region}.api.example.com", Variables: []ServerVariable{{Name: "region", Default: "ap", Enum: []string{"eu", "us"}}}}, expected: "default 'ap' of variable 'region' must be one of"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				err := validateServer(tt.server)
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), errorInvalidServer)
+				assert.Contains(t, err.Error(), tt.expected)
+			})
+		}
+	})
+}
+
+func TestValidateFeatureFlag(t *testing.T) {
+	assert.NoError(t, validateFeatureFlag(""), "Endpoints without a feature flag should pass validation")
+	assert.NoError(t, validateFeatureFlag("users-export"), "Feature flags without whitespace should pass validation")
+
+	err := validateFeatureFlag("users export")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), errorInvalidFeature)
+	assert.Contains(t, err.Error(), "feature flag 'users export' cannot contain whitespace")
+}
+
+func TestValidateSearchableText(t *testing.T) {
+	createResource := func() *Resource {
+		return &Resource{
+			Name:       "Users",
+			Operations: []string{OperationSearch},
+			Fields: []ResourceField{
+				{Field: Field{Name: "Name", Type: FieldTypeString}, Operations: []string{OperationRead}, SearchableText: true},
+				{Field: Field{Name: "Age", Type: FieldTypeInt}, Operations: []string{OperationRead}},
+			},
+		}
+	}
+
+	t.Run("readable String field", func(t *testing.T) {
+		assert.NoError(t, validateSearchableText(createResource()), "Readable String fields should be searchable")
+	})
+
+	t.Run("resource without search operation", func(t *testing.T) {
+		resource := createResource()
+		resource.Operations = []string{OperationList}
+
+		err := validateSearchableText(resource)
+		assert.Error(t, err, "Searchable text is searched by the Search endpoint")
+		assert.Contains(t, err.Error(), "searchable_text requires the Search operation")
+	})
+
+	t.Run("field that is not a String", func(t *testing.T) {
+		resource := createResource()
+		resource.Fields[1].SearchableText = true
+
+		err := validateSearchableText(resource)
+		assert.Error(t, err, "Only String fields should be searchable")
+		assert.Contains(t, err.Error(), errorInvalidSearchText)
+		assert.Contains(t, err.Error(), "'Age' must be a String")
+	})
+
+	t.Run("field that is not readable", func(t *testing.T) {
+		resource := createResource()
+		resource.Fields[0].Operations = []string{OperationCreate}
+
+		err := validateSearchableText(resource)
+		assert.Error(t, err, "Fields that are not returned should not be searchable")
+		assert.Contains(t, err.Error(), "'Name' is not readable")
+	})
+}
+
+func TestValidateExpansion(t *testing.T) {
+	createService := func() (*Service, *Resource) {
+		service := &Service{
+			Resources: []Resource{
+				{Name: "Users", Operations: []string{OperationGet}},
+				{
+					Name:       "Orders",
+					Operations: []string{OperationGet, OperationList},
+					Fields: []ResourceField{
+						{Field: Field{Name: "BuyerID", Type: FieldTypeUUID, References: "Users"}, Operations: []string{OperationCreate, OperationRead}},
+						{Field: Field{Name: "SellerID", Type: FieldTypeUUID, References: "Users"}, Operations: []string{OperationCreate}},
+						{Field: Field{Name: "Note", Type: FieldTypeString}, Operations: []string{OperationRead}},
+					},
+					Expansions: []Expansion{{Name: "Buyer", Field: "BuyerID"}},
+				},
+			},
+		}
+		return service, &service.Resources[1]
+	}
+
+	t.Run("valid expansion", func(t *testing.T) {
+		service, resource := createService()
+
+		err := validateExpansion(service, resource, resource.Expansions[0])
+		assert.NoError(t, err, "Expansion of a readable reference should pass validation")
+	})
+
+	t.Run("invalid expansions", func(t *testing.T) {
+		tests := []struct {
+			name      string
+			expansion Expansion
+			expected  string
+		}{
+			{name: "missing name", expansion: Expansion{Field: "BuyerID"}, expected: "name is required"},
+			{name: "name of a field", expansion: Expansion{Name: "Note", Field: "BuyerID"}, expected: "already the name of a field"},
+			{name: "unknown field", expansion: Expansion{Name: "Group", Field: "GroupID"}, expected: "unknown field 'GroupID'"},
+			{name: "field without reference", expansion: Expansion{Name: "Notes", Field: "Note"}, expected: "does not reference a resource"},
+			{name: "field that is not readable", expansion: Expansion{Name: "Seller", Field: "SellerID"}, expected: "is not readable"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				service, resource := createService()
+
+				err := validateExpansion(service, resource, tt.expansion)
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), errorInvalidExpansion)
+				assert.Contains(t, err.Error(), tt.expected)
+			})
+		}
+	})
+
+	t.Run("duplicate expansion", func(t *testing.T) {
+		service, resource := createService()
+		resource.Expansions = append(resource.Expansions, Expansion{Name: "Buyer", Field: "BuyerID", Description: "Again"})
+
+		err := validateExpansion(service, resource, resource.Expansions[1])
+		assert.Error(t, err, "Expansion names should be unique within a resource")
+		assert.Contains(t, err.Error(), "duplicate expansion 'Buyer'")
+	})
+
+	t.Run("resource without read operation", func(t *testing.T) {
+		service, resource := createService()
+		resource.Operations = []string{OperationCreate}
+
+		err := validateExpansion(service, resource, resource.Expansions[0])
+		assert.Error(t, err, "Expansions are only returned by Get, List and Search")
+		assert.Contains(t, err.Error(), errorInvalidOperation)
+	})
+
+	t.Run("related resource without read operation", func(t *testing.T) {
+		service, resource := createService()
+		service.Resources[0].Operations = []string{OperationCreate}
+
+		err := validateExpansion(service, resource, resource.Expansions[0])
+		assert.Error(t, err, "A resource that cannot be read cannot be expanded")
+		assert.Contains(t, err.Error(), "'Users' has no Get, List or Search operation")
+	})
+}
+
+func TestValidatePermissions(t *testing.T) {
+	t.Run("valid permissions", func(t *testing.T) {
+		err := validatePermissions([]string{"users:read", "users:write"})
+		assert.NoError(t, err, "Distinct permissions should pass validation")
+	})
+
+	t.Run("empty permission", func(t *testing.T) {
+		err := validatePermissions([]string{"users:read", " "})
+		assert.Error(t, err, "Empty permission should fail validation")
+		assert.Contains(t, err.Error(), errorInvalidPermission)
+	})
+
+	t.Run("duplicate permission", func(t *testing.T) {
+		err := validatePermissions([]string{"users:read", "users:read"})
+		assert.Error(t, err, "Duplicate permission should fail validation")
+		assert.Contains(t, err.Error(), "duplicate permission 'users:read'")
+	})
+}
+
+func TestValidateStability(t *testing.T) {
+	for _, stability := range []string{"", StabilityExperimental, StabilityBeta, StabilityStable} {
+		assert.NoError(t, validateStability(stability), "Stability '%s' should pass validation", stability)
+	}
+
+	err := validateStability("alpha")
+	assert.Error(t, err, "Unknown stability should fail validation")
+	assert.Contains(t, err.Error(), errorInvalidStability)
+}
+
+func TestValidateTermsOfService(t *testing.T) {
+	assert.NoError(t, ValidateService(&Service{Name: "Test", TermsOfService: "https://example.com/terms"}), "Absolute terms of service URL should pass validation")
+
+	err := ValidateService(&Service{Name: "Test", TermsOfService: "terms.html"})
+	assert.Error(t, err, "Relative terms of service URL should fail validation")
+	assert.Contains(t, err.Error(), errorInvalidTerms)
+}
+
+func TestValidateRequestLimits(t *testing.T) {
+	t.Run("valid limits", func(t *testing.T) {
+		assert.NoError(t, validateRequestLimits(1<<20, 5000), "Positive limits should pass validation")
+		assert.NoError(t, validateRequestLimits(0, 0), "No limits should pass validation")
+	})
+
+	t.Run("negative max body bytes", func(t *testing.T) {
+		err := validateRequestLimits(-1, 0)
+		assert.Error(t, err, "Negative max_body_bytes should fail validation")
+		assert.Contains(t, err.Error(), errorInvalidLimit)
+	})
+
+	t.Run("negative handler timeout", func(t *testing.T) {
+		err := validateRequestLimits(0, -1)
+		assert.Error(t, err, "Negative handler_timeout should fail validation")
+		assert.Contains(t, err.Error(), "handler_timeout cannot be negative")
+	})
+}
+
+func TestValidateFilterMaxDepth(t *testing.T) {
+	assert.NoError(t, validateFilterMaxDepth(3), "Positive max depth should pass validation")
+	assert.NoError(t, validateFilterMaxDepth(0), "No max depth should pass validation")
+
+	err := validateFilterMaxDepth(-1)
+	assert.Error(t, err, "Negative filter_max_depth should fail validation")
+	assert.Contains(t, err.Error(), "filter_max_depth cannot be negative")
+}
+
+func TestValidateMaxLimit(t *testing.T) {
+	assert.NoError(t, validateMaxLimit(100), "Max limit above the default limit should pass validation")
+	assert.NoError(t, validateMaxLimit(DefaultLimit), "Max limit of the default limit should pass validation")
+	assert.NoError(t, validateMaxLimit(0), "No max limit should pass validation")
+
+	err := validateMaxLimit(-1)
+	assert.Error(t, err, "Negative max_limit should fail validation")
+	assert.Contains(t, err.Error(), "max_limit cannot be negative")
+
+	err = validateMaxLimit(10)
+	assert.Error(t, err, "Max limit below the default limit should fail validation")
+	assert.Contains(t, err.Error(), "max_limit cannot be less than the default limit 50")
+}
+
+func TestValidateRequestLimitErrorCodes(t *testing.T) {
+	customErrorCodes := Enum{Name: errorCodeEnumName, Values: []EnumValue{{Name: errorCodeBadRequest}, {Name: errorCodeInternal}}}
+
+	t.Run("default error codes", func(t *testing.T) {
+		service := &Service{MaxBodyBytes: 1024, HandlerTimeout: 5000}
+		assert.NoError(t, validateRequestLimitErrorCodes(service), "The default ErrorCode enum gets the codes of the limits")
+	})
+
+	t.Run("custom error codes without limits", func(t *testing.T) {
+		service := &Service{Enums: []Enum{customErrorCodes}}
+		assert.NoError(t, validateRequestLimitErrorCodes(service), "No limits should not require any codes")
+	})
+
+	t.Run("custom error codes without PayloadTooLarge", func(t *testing.T) {
+		service := &Service{
+			Enums:     []Enum{customErrorCodes},
+			Resources: []Resource{{Name: "Users", Endpoints: []Endpoint{{Name: "Import", MaxBodyBytes: 1024}}}},
+		}
+
+		err := validateRequestLimitErrorCodes(service)
+		assert.Error(t, err, "A body limit should require the PayloadTooLarge code")
+		assert.Contains(t, err.Error(), "max_body_bytes requires the value 'PayloadTooLarge'")
+	})
+
+	t.Run("custom error codes without RequestTimeout", func(t *testing.T) {
+		service := &Service{Enums: []Enum{customErrorCodes}, HandlerTimeout: 5000}
+
+		err := validateRequestLimitErrorCodes(service)
+		assert.Error(t, err, "A handler timeout should require the RequestTimeout code")
+		assert.Contains(t, err.Error(), "handler_timeout requires the value 'RequestTimeout'")
+	})
+}
+
+func TestValidateParameterGroups(t *testing.T) {
+	group := ParameterGroup{Name: "Include", Fields: []Field{{Name: "IncludeArchived", Type: FieldTypeBool}}}
+
+	t.Run("valid reference", func(t *testing.T) {
+		service := &Service{ParameterGroups: []ParameterGroup{group}}
+		endpoint := &Endpoint{Request: EndpointRequest{ParameterGroups: []string{"Include"}}}
+
+		assert.NoError(t, validateParameterGroup(service, &group, 0), "Valid group should pass validation")
+		assert.NoError(t, validateEndpointParameterGroups(service, endpoint), "Existing group should pass validation")
+	})
+
+	t.Run("duplicate group name", func(t *testing.T) {
+		service := &Service{ParameterGroups: []ParameterGroup{group, group}}
+
+		err := validateParameterGroup(service, &service.ParameterGroups[1], 1)
+		assert.Error(t, err, "Duplicate group name should fail validation")
+		assert.Contains(t, err.Error(), "already defined")
+	})
+
+	t.Run("undefined group", func(t *testing.T) {
+		endpoint := &Endpoint{Request: EndpointRequest{ParameterGroups: []string{"Missing"}}}
+
+		err := validateEndpointParameterGroups(&Service{}, endpoint)
+		assert.Error(t, err, "Undefined group should fail validation")
+		assert.Contains(t, err.Error(), "undefined parameter group 'Missing'")
+	})
+
+	t.Run("conflicting query param", func(t *testing.T) {
+		service := &Service{ParameterGroups: []ParameterGroup{group}}
+		endpoint := &Endpoint{Request: EndpointRequest{
+			QueryParams:     []Field{{Name: "IncludeArchived", Type: FieldTypeString}},
+			ParameterGroups: []string{"Include"},
+		}}
+
+		err := validateEndpointParameterGroups(service, endpoint)
+		assert.Error(t, err, "Conflicting query param should fail validation")
+		assert.Contains(t, err.Error(), errorInvalidGroup)
+	})
+}
+
+func TestValidateExtends(t *testing.T) {
+	audited := Object{Name: "Audited", Fields: []Field{{Name: "CreatedAt", Type: FieldTypeTimestamp}}}
+
+	t.Run("valid parent", func(t *testing.T) {
+		child := Object{Name: "Person", Extends: "Audited", Fields: []Field{{Name: "CreatedAt", Type: FieldTypeTimestamp}, {Name: "Name", Type: FieldTypeString}}}
+		service := &Service{Objects: []Object{audited, child}}
+
+		err := validateExtends(service, &child)
+		assert.NoError(t, err, "Existing parent and identical inherited field should pass validation")
+	})
+
+	t.Run("undefined parent", func(t *testing.T) {
+		child := Object{Name: "Person", Extends: "Missing"}
+		service := &Service{Objects: []Object{child}}
+
+		err := validateExtends(service, &child)
+		assert.Error(t, err, "Undefined parent should fail validation")
+		assert.Contains(t, err.Error(), "undefined object 'Missing'")
+	})
+
+	t.Run("cyclic inheritance", func(t *testing.T) {
+		first := Object{Name: "First", Extends: "Second"}
+		second := Object{Name: "Second", Extends: "First"}
+		service := &Service{Objects: []Object{first, second}}
+
+		err := validateExtends(service, &first)
+		assert.Error(t, err, "Cyclic inheritance should fail validation")
+		assert.Contains(t, err.Error(), "cyclic inheritance")
+	})
+
+	t.Run("redeclared field with different type", func(t *testing.T) {
+		child := Object{Name: "Person", Extends: "Audited", Fields: []Field{{Name: "CreatedAt", Type: FieldTypeString}}}
+		service := &Service{Objects: []Object{audited, child}}
+
+		err := validateExtends(service, &child)
+		assert.Error(t, err, "Redeclared field with different type should fail validation")
+		assert.Contains(t, err.Error(), errorInvalidExtends)
+	})
+}
+
+func TestValidateOperational(t *testing.T) {
+	createService := func(operational *OperationalEndpoints, resourceName string) *Service {
+		return &Service{
+			Operational: operational,
+			Resources: []Resource{
+				{
+					Name:      resourceName,
+					Endpoints: []Endpoint{{Name: "Get", Method: "GET", Path: ""}},
+				},
+			},
+		}
+	}
+
+	t.Run("no conflicts", func(t *testing.T) {
+		service := createService(&OperationalEndpoints{Health: true, Readiness: true, Version: true}, "User")
+
+		err := validateOperational(service)
+		assert.NoError(t, err, "Operational endpoints without conflicts should pass validation")
+	})
+
+	t.Run("resource endpoint conflicts with version endpoint", func(t *testing.T) {
+		service := createService(&OperationalEndpoints{Version: true}, "Version")
+
+		err := validateOperational(service)
+		assert.Error(t, err, "Conflicting paths should fail validation")
+		assert.Contains(t, err.Error(), errorPathConflict)
+	})
+
+	t.Run("conflicting resource without the operational endpoint", func(t *testing.T) {
+		service := createService(&OperationalEndpoints{Health: true}, "Version")
+
+		err := validateOperational(service)
+		assert.NoError(t, err, "Disabled operational endpoints should not conflict")
+	})
+}
+
+func TestValidateTenancy(t *testing.T) {
+	t.Run("valid header tenancy", func(t *testing.T) {
+		err := validateTenancy(&Tenancy{In: TenancyInHeader, Name: "X-Tenant-ID"})
+		assert.NoError(t, err, "Header tenancy should pass validation")
+	})
+
+	t.Run("valid path tenancy", func(t *testing.T) {
+		err := validateTenancy(&Tenancy{In: TenancyInPath, Name: "TenantID"})
+		assert.NoError(t, err, "Path tenancy should pass validation")
+	})
+
+	t.Run("unsupported location", func(t *testing.T) {
+		err := validateTenancy(&Tenancy{In: "query", Name: "tenant"})
+		assert.Error(t, err, "Unsupported tenancy location should fail validation")
+		assert.Contains(t, err.Error(), errorInvalidTenancy)
+	})
+
+	t.Run("missing name", func(t *testing.T) {
+		err := validateTenancy(&Tenancy{In: TenancyInHeader})
+		assert.Error(t, err, "Tenancy without name should fail validation")
+		assert.Contains(t, err.Error(), "name is required")
+	})
+}
+
+func TestValidateErrorFormat(t *testing.T) {
+	t.Run("default format", func(t *testing.T) {
+		err := validateErrorFormat("")
+		assert.NoError(t, err, "Empty error format should pass validation")
+	})
+
+	t.Run("problem details", func(t *testing.T) {
+		err := validateErrorFormat(ErrorFormatProblemJSON)
+		assert.NoError(t, err, "problem+json error format should pass validation")
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		err := validateErrorFormat("xml")
+		assert.Error(t, err, "Unsupported error format should fail validation")
+		assert.Contains(t, err.Error(), errorInvalidFormat)
+	})
+}
+
+func TestValidateEnum(t *testing.T) {
+	t.Run("valid enum", func(t *testing.T) {
+		enum := Enum{Name: "Status", Values: []EnumValue{
+			{Name: "Active", Value: "active", Aliases: []string{"enabled", "Active"}},
+			{Name: "Inactive"},
+		}}
+		assert.NoError(t, validateEnum(&enum), "Enum with unique wire values should pass validation")
+	})
+
+	invalidCases := []struct {
+		name          string
+		values        []EnumValue
+		expectedError string
+	}{
+		{name: "duplicate name", values: []EnumValue{{Name: "Active"}, {Name: "Active", Value: "active"}}, expectedError: "duplicate value name 'Active'"},
+		{name: "duplicate value", values: []EnumValue{{Name: "Active", Value: "on"}, {Name: "Enabled", Value: "on"}}, expectedError: "wire value 'on' of value 'Enabled' is already used by value 'Active'"},
+		{name: "value of another name", values: []EnumValue{{Name: "Active"}, {Name: "Enabled", Value: "Active"}}, expectedError: "already used by value 'Active'"},
+		{name: "alias of another value", values: []EnumValue{{Name: "Active"}, {Name: "Inactive", Aliases: []string{"Active"}}}, expectedError: "already used by value 'Active'"},
+		{name: "empty alias", values: []EnumValue{{Name: "Active", Aliases: []string{""}}}, expectedError: "value 'Active' has an empty alias"},
+	}
+
+	for _, tt := range invalidCases {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateEnum(&Enum{Name: "Status", Values: tt.values})
+			assert.Error(t, err, "Enum should fail validation")
+			assert.Contains(t, err.Error(), errorInvalidEnum)
+			assert.Contains(t, err.Error(), tt.expectedError)
+		})
+	}
+
+	t.Run("valid int enum", func(t *testing.T) {
+		enum := Enum{Name: "Grade", EnumType: EnumTypeInt, Values: []EnumValue{
+			{Name: "Pass", Value: "1", Aliases: []string{"10"}},
+			{Name: "Fail", Value: "-1"},
+		}}
+		assert.NoError(t, validateEnum(&enum), "Int enum with integer wire values should pass validation")
+	})
+
+	invalidIntCases := []struct {
+		name          string
+		enum          Enum
+		expectedError string
+	}{
+		{name: "unknown enum type", enum: Enum{Name: "Grade", EnumType: "float", Values: []EnumValue{{Name: "Pass"}}}, expectedError: "enum_type 'float' must be one of"},
+		{name: "missing value", enum: Enum{Name: "Grade", EnumType: EnumTypeInt, Values: []EnumValue{{Name: "Pass"}}}, expectedError: "value 'Pass' of an int enum must set a value"},
+		{name: "non-integer value", enum: Enum{Name: "Grade", EnumType: EnumTypeInt, Values: []EnumValue{{Name: "Pass", Value: "pass"}}}, expectedError: "wire value 'pass' of value 'Pass' must be an integer"},
+		{name: "non-integer alias", enum: Enum{Name: "Grade", EnumType: EnumTypeInt, Values: []EnumValue{{Name: "Pass", Value: "1", Aliases: []string{"P"}}}}, expectedError: "wire value 'P' of value 'Pass' must be an integer"},
+	}
+
+	for _, tt := range invalidIntCases {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateEnum(&tt.enum)
+			assert.Error(t, err, "Enum should fail validation")
+			assert.Contains(t, err.Error(), errorInvalidEnum)
+			assert.Contains(t, err.Error(), tt.expectedError)
+		})
+	}
+}
+
+func TestValidateBasePath(t *testing.T) {
+	for _, basePath := range []string{"", "/", "/api", "/api/v1"} {
+		assert.NoError(t, validateBasePath(basePath), "Base path '%s' should pass validation", basePath)
+	}
+
+	for _, basePath := range []string{"api/v1", "/api/v1/", "/api/{version}", "/api/:version", "/api v1"} {
+		err := validateBasePath(basePath)
+		assert.Error(t, err, "Base path '%s' should fail validation", basePath)
+		assert.Contains(t, err.Error(), errorInvalidBasePath)
+	}
+}
+
+func TestValidateResponseHeaders(t *testing.T) {
+	valid := [][]Field{
+		nil,
+		{{Name: "RateLimit-Reset"}, {Name: "TotalCount", HeaderName: "X-Total-Count"}},
+		{{Name: "Trace ID", HeaderName: "x-trace_id.v2"}},
+	}
+	for _, headers := range valid {
+		assert.NoError(t, validateResponseHeaders(headers), "Response headers %v should pass validation", headers)
+	}
+
+	invalid := [][]Field{
+		{{Name: "TotalCount", HeaderName: "X Total Count"}},
+		{{Name: "TotalCount", HeaderName: "X-Total-Count:"}},
+		{{Name: "Arende", HeaderName: "X-Ärende"}},
+		{{Name: "X-Total-Count"}, {Name: "TotalCount", HeaderName: "x-total-count"}},
+	}
+	for _, headers := range invalid {
+		err := validateResponseHeaders(headers)
+		assert.Error(t, err, "Response headers %v should fail validation", headers)
+		assert.Contains(t, err.Error(), errorInvalidHeader)
+	}
+}
+
+func TestValidateEndpointResponseHeaders(t *testing.T) {
+	valid := EndpointResponse{
+		Headers:    []Field{{Name: "TotalCount", HeaderName: "X-Total-Count", Type: FieldTypeInt}},
+		BodyFields: []Field{{Name: "Data", Type: FieldTypeString}},
+	}
+	assert.NoError(t, validateEndpointResponseHeaders(&valid))
+
+	invalid := []EndpointResponse{
+		{Headers: []Field{{Name: "TotalCount", HeaderName: "X Total Count"}}},
+		{Headers: []Field{{Name: "Total-Count"}}, BodyFields: []Field{{Name: "TotalCount", Type: FieldTypeInt}}},
+	}
+	for _, response := range invalid {
+		err := validateEndpointResponseHeaders(&response)
+		assert.Error(t, err, "Response headers %v should fail validation", response.Headers)
+		assert.Contains(t, err.Error(), errorInvalidHeader)
+	}
+}
+
+func TestValidateExternalDocs(t *testing.T) {
+	for _, docs := range []*ExternalDocs{nil, {URL: "https://docs.example.com/guides/users"}, {URL: "http://localhost:8080/docs", Description: "Local guides"}} {
+		assert.NoError(t, validateExternalDocs(docs), "External docs %v should pass validation", docs)
+	}
+
+	for _, docs := range []*ExternalDocs{{Description: "Guides"}, {URL: "/guides/users"}, {URL: "docs.example.com"}, {URL: "https://exa mple.com"}} {
+		err := validateExternalDocs(docs)
+		assert.Error(t, err, "External docs %v should fail validation", docs)
+		assert.Contains(t, err.Error(), errorInvalidDocs)
+	}
+
+	// Errors point at the external docs of the endpoint
+	service := &Service{
+		Name: "Test",
+		Resources: []Resource{{
+			Name:       "User",
+			Operations: []string{OperationGet},
+			Endpoints:  []Endpoint{{Name: "Invite", ExternalDocs: &ExternalDocs{URL: "guides"}}},
+		}},
+	}
+	err := ValidateService(service)
+	assert.Error(t, err, "Invalid external docs of an endpoint should fail validation")
+	assert.Contains(t, err.Error(), "$.resources[0].endpoints[0].external_docs")
+}
+
+func TestValidateDescriptionFile(t *testing.T) {
+	assert.NoError(t, validateDescriptionFile("Users", ""), "Inline descriptions should pass validation")
+	assert.NoError(t, validateDescriptionFile("", "docs/users.md"), "Relative description files should pass validation")
+
+	for _, tc := range []struct{ description, descriptionFile string }{{"Users", "docs/users.md"}, {"", "/docs/users.md"}} {
+		err := validateDescriptionFile(tc.description, tc.descriptionFile)
+		assert.Error(t, err, "Description file '%s' with description '%s' should fail validation", tc.descriptionFile, tc.description)
+		assert.Contains(t, err.Error(), errorInvalidDescFile)
+	}
+}
+
+func TestValidateNaming(t *testing.T) {
+	for _, pathCase := range []string{"", PathCaseKebab, PathCaseSnake} {
+		assert.NoError(t, validateNaming(&Naming{PathCase: pathCase}), "Path case '%s' should pass validation", pathCase)
+	}
+
+	err := validateNaming(&Naming{PathCase: "camelCase", PluralPaths: true})
+	assert.Error(t, err, "Unsupported path case should fail validation")
+	assert.Contains(t, err.Error(), errorInvalidNaming)
+
+	for _, typeCollisions := range []string{"", TypeCollisionsError, TypeCollisionsRename} {
+		assert.NoError(t, validateNaming(&Naming{TypeCollisions: typeCollisions}), "Type collisions '%s' should pass validation", typeCollisions)
+	}
+
+	err = validateNaming(&Naming{TypeCollisions: "suffix"})
+	assert.Error(t, err, "Unsupported type collisions should fail validation")
+	assert.Contains(t, err.Error(), errorInvalidNaming)
+
+	for _, jsonCase := range []string{"", JSONCaseCamel, JSONCaseSnake, JSONCaseAsIs} {
+		assert.NoError(t, validateNaming(&Naming{JSON: jsonCase}), "JSON case '%s' should pass validation", jsonCase)
+	}
+
+	err = validateNaming(&Naming{JSON: "kebab-case"})
+	assert.Error(t, err, "Unsupported JSON case should fail validation")
+	assert.Contains(t, err.Error(), errorInvalidNaming)
+}
+
+func TestValidateEndpoint_TypeName(t *testing.T) {
+	service := &Service{Name: "TestService"}
+
+	assert.NoError(t, validateEndpoint(service, &Endpoint{Name: "Create", TypeName: "UserSignup"}), "Identifier type name should pass validation")
+
+	err := validateEndpoint(service, &Endpoint{Name: "Create", TypeName: "User-Signup"})
+	assert.Error(t, err, "Type name with a dash should fail validation")
+	assert.Contains(t, err.Error(), errorInvalidTypeName)
+}
+
+func TestValidateLocalization(t *testing.T) {
+	createService := func(localization *Localization) *Service {
+		return &Service{Name: "TestService", Localization: localization}
+	}
+
+	t.Run("valid catalog", func(t *testing.T) {
+		err := validateLocalization(createService(&Localization{
+			DefaultLocale: "en",
+			Messages: map[string]map[string]string{
+				"NotFound": {"en": "Not found", "sv-SE": "Hittades inte"},
+			},
+		}))
+		assert.NoError(t, err, "Catalog with default messages should pass validation")
+	})
+
+	t.Run("invalid default locale", func(t *testing.T) {
+		err := validateLocalization(createService(&Localization{DefaultLocale: "english!"}))
+		assert.Error(t, err, "Default locale that is not a language tag should fail validation")
+		assert.Contains(t, err.Error(), errorInvalidCatalog)
+	})
+
+	t.Run("unknown error code", func(t *testing.T) {
+		err := validateLocalization(createService(&Localization{
+			DefaultLocale: "en",
+			Messages:      map[string]map[string]string{"Teapot": {"en": "I'm a teapot"}},
+		}))
+		assert.Error(t, err, "Message of a code that is not an ErrorCode value should fail validation")
+		assert.Contains(t, err.Error(), "'Teapot' is not a value")
+	})
+
+	t.Run("invalid locale", func(t *testing.T) {
+		err := validateLocalization(createService(&Localization{
+			DefaultLocale: "en",
+			Messages:      map[string]map[string]string{"NotFound": {"en": "Not found", "swedish": "Hittades inte"}},
+		}))
+		assert.Error(t, err, "Locale that is not a language tag should fail validation")
+		assert.Contains(t, err.Error(), "locale 'swedish'")
+	})
+
+	t.Run("missing default message", func(t *testing.T) {
+		err := validateLocalization(createService(&Localization{
+			DefaultLocale: "en",
+			Messages:      map[string]map[string]string{"NotFound": {"sv": "Hittades inte"}},
+		}))
+		assert.Error(t, err, "Code without a message in the default locale should fail validation")
+		assert.Contains(t, err.Error(), "no message in the default locale 'en'")
+	})
+}
+
+func TestValidateAlternativeContentTypes(t *testing.T) {
+	createResponse := func(contentTypes ...string) *EndpointResponse {
+		return &EndpointResponse{
+			StatusCode:              200,
+			BodyFields:              []Field{{Name: "total", Type: FieldTypeInt}},
+			AlternativeContentTypes: contentTypes,
+		}
+	}
+
+	t.Run("valid content types", func(t *testing.T) {
+		err := validateAlternativeContentTypes(createResponse("text/csv", "application/xml"))
+		assert.NoError(t, err, "Lower case media types should pass validation")
+	})
+
+	t.Run("response without body", func(t *testing.T) {
+		err := validateAlternativeContentTypes(&EndpointResponse{StatusCode: 204, AlternativeContentTypes: []string{"text/csv"}})
+		assert.Error(t, err, "Alternative content types of a response without body should fail validation")
+		assert.Contains(t, err.Error(), errorInvalidMediaType)
+	})
+
+	t.Run("invalid media types", func(t *testing.T) {
+		for _, contentType := range []string{"csv", "Text/CSV", "text/*", "text/csv; charset=utf-8"} {
+			err := validateAlternativeContentTypes(createResponse(contentType))
+			assert.Error(t, err, "Media type '%s' should fail validation", contentType)
+		}
+	})
+
+	t.Run("default content type", func(t *testing.T) {
+		err := validateAlternativeContentTypes(createResponse("application/json"))
+		assert.Error(t, err, "JSON as alternative content type should fail validation")
+		assert.Contains(t, err.Error(), "is the default content type")
+	})
+
+	t.Run("duplicate content type", func(t *testing.T) {
+		err := validateAlternativeContentTypes(createResponse("text/csv", "text/csv"))
+		assert.Error(t, err, "Duplicate content types should fail validation")
+		assert.Contains(t, err.Error(), "is listed more than once")
+	})
+}
+
+func TestValidateCSVResponse(t *testing.T) {
+	service := &Service{Objects: []Object{{Name: "User", Fields: []Field{{Name: "Name", Type: FieldTypeString}}}}}
+	userName := "User"
+	undefinedName := "Undefined"
+
+	t.Run("valid CSV response", func(t *testing.T) {
+		err := validateCSVResponse(service, &EndpointResponse{ContentType: ContentTypeCSV, StatusCode: 200, BodyObject: &userName})
+		assert.NoError(t, err, "CSV response with rows of an object should pass validation")
+	})
+
+	t.Run("JSON response", func(t *testing.T) {
+		err := validateCSVResponse(service, &EndpointResponse{ContentType: "application/json", StatusCode: 200, BodyObject: &undefinedName})
+		assert.NoError(t, err, "JSON responses are not validated as CSV responses")
+	})
+
+	t.Run("invalid body", func(t *testing.T) {
+		for name, response := range map[string]*EndpointResponse{
+			"without body object": {ContentType: ContentTypeCSV, StatusCode: 200},
+			"undefined object":    {ContentType: ContentTypeCSV, StatusCode: 200, BodyObject: &undefinedName},
+			"with body fields":    {ContentType: ContentTypeCSV, StatusCode: 200, BodyObject: &userName, BodyFields: []Field{{Name: "Total", Type: FieldTypeInt}}},
+		} {
+			err := validateCSVResponse(service, response)
+			assert.Error(t, err, "CSV response %s should fail validation", name)
+			assert.Contains(t, err.Error(), errorInvalidMediaType)
+		}
+	})
+
+	t.Run("alternative content types", func(t *testing.T) {
+		err := validateCSVResponse(service, &EndpointResponse{ContentType: ContentTypeCSV, StatusCode: 200, BodyObject: &userName, AlternativeContentTypes: []string{"application/xml"}})
+		assert.Error(t, err, "CSV response with alternative content types should fail validation")
+		assert.Contains(t, err.Error(), "cannot have alternative content types")
+	})
+}
+
+func TestValidateRequestContentType(t *testing.T) {
+	t.Run("supported content types", func(t *testing.T) {
+		for _, contentType := range []string{"", "application/json", ContentTypeXML, ContentTypeForm} {
+			err := validateRequestContentType(&EndpointRequest{ContentType: contentType})
+			assert.NoError(t, err, "Request content type '%s' should pass validation", contentType)
+		}
+	})
+
+	t.Run("unsupported content type", func(t *testing.T) {
+		err := validateRequestContentType(&EndpointRequest{ContentType: "multipart/form-data"})
+		assert.Error(t, err, "Unsupported request content type should fail validation")
+		assert.Contains(t, err.Error(), errorInvalidMediaType)
+	})
+}
+
+func TestValidateFormRequest(t *testing.T) {
+	service := &Service{
+		Enums:   []Enum{{Name: "Role", Values: []EnumValue{{Name: "Admin"}}}},
+		Objects: []Object{{Name: "Address", Fields: []Field{{Name: "Street", Type: FieldTypeString}}}},
+	}
+
+	t.Run("primitive and enum fields", func(t *testing.T) {
+		request := &EndpointRequest{ContentType: ContentTypeForm, BodyParams: []Field{
+			{Name: "Name", Type: FieldTypeString},
+			{Name: "Roles", Type: "Role", Modifiers: []string{ModifierArray}},
+		}}
+
+		err := validateFormRequest(service, request)
+		assert.NoError(t, err, "Primitive and enum fields should pass validation")
+	})
+
+	t.Run("object field", func(t *testing.T) {
+		request := &EndpointRequest{ContentType: ContentTypeForm, BodyParams: []Field{{Name: "Address", Type: "Address"}}}
+
+		err := validateFormRequest(service, request)
+		assert.Error(t, err, "Object fields cannot be sent in a form")
+		assert.Contains(t, err.Error(), "Address")
+	})
+
+	t.Run("object field of a JSON request", func(t *testing.T) {
+		request := &EndpointRequest{BodyParams: []Field{{Name: "Address", Type: "Address"}}}
+
+		err := validateFormRequest(service, request)
+		assert.NoError(t, err, "Object fields of JSON requests should pass validation")
+	})
+}
+
+func TestValidateResponseContentType(t *testing.T) {
+	t.Run("supported content types", func(t *testing.T) {
+		for _, contentType := range []string{"", "application/json", ContentTypeXML, ContentTypeCSV} {
+			err := validateResponseContentType(&EndpointResponse{ContentType: contentType, StatusCode: 200})
+			assert.NoError(t, err, "Response content type '%s' should pass validation", contentType)
+		}
+	})
+
+	t.Run("unsupported content type", func(t *testing.T) {
+		err := validateResponseContentType(&EndpointResponse{ContentType: "text/html", StatusCode: 200})
+		assert.Error(t, err, "Unsupported response content type should fail validation")
+		assert.Contains(t, err.Error(), errorInvalidMediaType)
+	})
+
+	t.Run("XML with alternative content types", func(t *testing.T) {
+		err := validateResponseContentType(&EndpointResponse{ContentType: ContentTypeXML, StatusCode: 200, AlternativeContentTypes: []string{"text/csv"}})
+		assert.Error(t, err, "XML response with alternative content types should fail validation")
+		assert.Contains(t, err.Error(), "cannot have alternative content types")
+	})
+}
+
+func TestValidateRequiredOn(t *testing.T) {
+	t.Run("required on allowed operation", func(t *testing.T) {
+		field := &ResourceField{Operations: []string{OperationCreate, OperationUpdate}, RequiredOn: []string{OperationCreate}}
+
+		err := validateRequiredOn(field)
+		assert.NoError(t, err, "Required on an allowed operation should pass validation")
+	})
+
+	t.Run("required on read", func(t *testing.T) {
+		field := &ResourceField{Operations: []string{OperationRead}, RequiredOn: []string{OperationRead}}
+
+		err := validateRequiredOn(field)
+		assert.Error(t, err, "Only Create and Update can be required")
+		assert.Contains(t, err.Error(), errorInvalidOperation)
+	})
+
+	t.Run("required on operation the field is not in", func(t *testing.T) {
+		field := &ResourceField{Field: Field{Name: "email"}, Operations: []string{OperationCreate}, RequiredOn: []string{OperationUpdate}}
+
+		err := validateRequiredOn(field)
+		assert.Error(t, err, "Required on a missing operation should fail validation")
+		assert.Contains(t, err.Error(), "not in its operations")
+	})
+}
+
+func TestValidateFieldDefault(t *testing.T) {
+	service := &Service{
+		Enums: []Enum{
+			{Name: "Status", Values: []EnumValue{{Name: "Active"}, {Name: "Inactive"}}},
+			{Name: "WireStatus", Values: []EnumValue{{Name: "Active", Value: "active"}}},
+		},
+		Objects: []Object{{Name: "Address"}},
+	}
+
+	validCases := []Field{
+		{Name: "Limit", Type: FieldTypeInt, Default: "50"},
+		{Name: "Quantity", Type: FieldTypeInt32, Default: "-1"},
+		{Name: "Count", Type: FieldTypeUInt, Default: "0"},
+		{Name: "Ratio", Type: FieldTypeFloat64, Default: "0.5"},
+		{Name: "Price", Type: FieldTypeDecimal, Default: "19.99"},
+		{Name: "Active", Type: FieldTypeBool, Default: "false"},
+		{Name: "ID", Type: FieldTypeUUID, Default: defaultExampleUUID},
+		{Name: "Date", Type: FieldTypeDate, Default: defaultExampleDate},
+		{Name: "Time", Type: FieldTypeTimestamp, Default: defaultExampleTimestamp},
+		{Name: "Name", Type: FieldTypeString, Default: "anything"},
+		{Name: "Status", Type: "Status", Default: "Active"},
+		{Name: "WireStatus", Type: "WireStatus", Default: "active"},
+		{Name: "Address", Type: "Address"},
+	}
+
+	for _, field := range validCases {
+		t.Run("valid "+field.Type, func(t *testing.T) {
+			err := validateFieldDefault(service, &field)
+			assert.NoError(t, err, "Default '%s' should be valid for type %s", field.Default, field.Type)
+		})
+	}
+
+	invalidCases := []Field{
+		{Name: "Limit", Type: FieldTypeInt, Default: "fifty"},
+		{Name: "Quantity", Type: FieldTypeInt32, Default: "3000000000"},
+		{Name: "Count", Type: FieldTypeUInt, Default: "-1"},
+		{Name: "Price", Type: FieldTypeDecimal, Default: "1e3"},
+		{Name: "Active", Type: FieldTypeBool, Default: "1"},
+		{Name: "ID", Type: FieldTypeUUID, Default: "not-a-uuid"},
+		{Name: "Date", Type: FieldTypeDate, Default: "15/01/2024"},
+		{Name: "Time", Type: FieldTypeTimestamp, Default: "2024-01-15"},
+		{Name: "Status", Type: "Status", Default: "Deleted"},
+		{Name: "WireStatus", Type: "WireStatus", Default: "Active"},
+		{Name: "Address", Type: "Address", Default: "Main Street"},
+		{Name: "Tags", Type: FieldTypeString, Default: "a", Modifiers: []string{ModifierArray}},
+	}
+
+	for _, field := range invalidCases {
+		t.Run("invalid "+field.Type+" "+field.Default, func(t *testing.T) {
+			err := validateFieldDefault(service, &field)
+			assert.Error(t, err, "Default '%s' should be invalid for field %s", field.Default, field.Name)
+			assert.Contains(t, err.Error(), errorInvalidDefault)
+		})
+	}
+}
+
+func TestValidateResourceExamples(t *testing.T) {
+	t.Run("valid examples", func(t *testing.T) {
+		resource := &Resource{Examples: []ResourceExample{
+			{Name: "alice", Values: map[string]any{"name": "Alice"}},
+			{Name: "bob", Values: map[string]any{"name": "Bob"}},
+		}}
+
+		err := validateResourceExamples(resource)
+		assert.NoError(t, err, "Named examples with values should pass validation")
+	})
+
+	t.Run("missing name", func(t *testing.T) {
+		resource := &Resource{Examples: []ResourceExample{{Values: map[string]any{"name": "Alice"}}}}
+
+		err := validateResourceExamples(resource)
+		assert.Error(t, err, "Example without name should fail validation")
+		assert.Contains(t, err.Error(), errorInvalidExample)
+	})
+
+	t.Run("duplicate name", func(t *testing.T) {
+		resource := &Resource{Examples: []ResourceExample{
+			{Name: "alice", Values: map[string]any{"name": "Alice"}},
+			{Name: "alice", Values: map[string]any{"name": "Alice"}},
+		}}
+
+		err := validateResourceExamples(resource)
+		assert.Error(t, err, "Duplicate example names should fail validation")
+		assert.Contains(t, err.Error(), "duplicate example name 'alice'")
+	})
+
+	t.Run("missing values", func(t *testing.T) {
+		resource := &Resource{Examples: []ResourceExample{{Name: "empty"}}}
+
+		err := validateResourceExamples(resource)
+		assert.Error(t, err, "Example without values should fail validation")
+	})
+}
+
+func TestValidateResourceSDKNames(t *testing.T) {
+	t.Run("valid names", func(t *testing.T) {
+		resource := &Resource{
+			SDKGroup:  "people",
+			Endpoints: []Endpoint{{Name: "Get"}, {Name: "Delete", SDKMethodName: "remove"}},
+		}
+
+		err := validateResourceSDKNames(resource)
+		assert.NoError(t, err, "Identifier SDK names should pass validation")
+	})
+
+	t.Run("invalid group", func(t *testing.T) {
+		resource := &Resource{SDKGroup: "user-accounts"}
+
+		err := validateResourceSDKNames(resource)
+		assert.Error(t, err, "SDK group with a dash should fail validation")
+		assert.Contains(t, err.Error(), errorInvalidSDKName)
+	})
+
+	t.Run("invalid method name", func(t *testing.T) {
+		resource := &Resource{Endpoints: []Endpoint{{Name: "Get", SDKMethodName: "1get"}}}
+
+		err := validateResourceSDKNames(resource)
+		assert.Error(t, err, "SDK method name starting with a digit should fail validation")
+	})
+
+	t.Run("duplicate method name", func(t *testing.T) {
+		resource := &Resource{Endpoints: []Endpoint{{Name: "Get"}, {Name: "Fetch", SDKMethodName: "get"}}}
+
+		err := validateResourceSDKNames(resource)
+		assert.Error(t, err, "Endpoints with the same SDK method name should fail validation")
+		assert.Contains(t, err.Error(), "endpoints 'Get' and 'Fetch' have the same SDK method name 'get'")
+	})
+}
+
+func TestValidateSchemaVersion(t *testing.T) {
+	t.Run("unset and current versions", func(t *testing.T) {
+		assert.NoError(t, validateSchemaVersion(0), "Unset schema version should pass validation")
+		assert.NoError(t, validateSchemaVersion(CurrentSchemaVersion), "Current schema version should pass validation")
+	})
+
+	t.Run("newer version", func(t *testing.T) {
+		err := validateSchemaVersion(CurrentSchemaVersion + 1)
+		assert.Error(t, err, "Schema version newer than the supported version should fail validation")
+		assert.Contains(t, err.Error(), errorInvalidSchema)
+		assert.Contains(t, err.Error(), "upgrade publicapis-gen")
+	})
+
+	t.Run("negative version", func(t *testing.T) {
+		err := validateSchemaVersion(-1)
+		assert.Error(t, err, "Negative schema version should fail validation")
+	})
+
+	t.Run("parsing rejects newer version", func(t *testing.T) {
+		_, err := ParseServiceFromYAML([]byte("name: Test\nschema_version: 99\n"))
+		assert.Error(t, err, "Parsing a specification with a newer schema version should fail")
+		assert.Contains(t, err.Error(), errorInvalidSchema)
+	})
+}
+
+func TestValidateSemantics(t *testing.T) {
+	createService := func() *Service {
+		return &Service{
+			Name:    "TestService",
+			Version: "1.0.0",
+			Resources: []Resource{
+				{
+					Name:       "Users",
+					Operations: []string{OperationCreate, OperationGet},
+					Fields: []ResourceField{
+						{Field: Field{Name: "Name", Type: FieldTypeString}, Operations: []string{OperationCreate, OperationRead}},
+					},
+					Endpoints: []Endpoint{
+						{Name: "Activate", Method: "POST", Path: "/{id}/activate", Response: EndpointResponse{StatusCode: 204}},
+					},
+				},
+			},
+		}
+	}
+	codes := func(err error) []ValidationErrorCode {
+		var validationErrors ValidationErrors
+		require.ErrorAs(t, err, &validationErrors)
+		result := make([]ValidationErrorCode, len(validationErrors))
+		for i, validationErr := range validationErrors {
+			result[i] = validationErr.Code
+		}
+		return result
+	}
+
+	t.Run("complete service", func(t *testing.T) {
+		assert.NoError(t, ValidateSemantics(ApplyDefaultOverlays(createService())), "A service with its overlays applied should pass validation")
+	})
+
+	t.Run("unknown types", func(t *testing.T) {
+		service := ApplyDefaultOverlays(createService())
+		service.Resources[0].Fields[0].Type = "Name"
+		bodyObject := "Activation"
+		service.Resources[0].Endpoints[0].Response.BodyObject = &bodyObject
+
+		err := ValidateSemantics(service)
+
+		assert.Equal(t, []ValidationErrorCode{ValidationErrorUnknownType, ValidationErrorUnknownType}, codes(err))
+		assert.Contains(t, err.Error(), "($.resources[0].fields[0].type): invalid field type: field 'Name' has the unknown type 'Name'")
+		assert.Contains(t, err.Error(), "($.resources[0].endpoints[0].response.body_object): invalid field type: endpoint 'Activate' responds with the unknown object 'Activation'")
+	})
+
+	t.Run("duplicate endpoints", func(t *testing.T) {
+		service := createService()
+		service.Resources[0].Endpoints = append(service.Resources[0].Endpoints, Endpoint{Name: "Fetch", Method: "get", Path: "/{userId}", Response: EndpointResponse{StatusCode: 200}})
+		service = ApplyDefaultOverlays(service)
+
+		err := ValidateSemantics(service)
+
+		assert.Equal(t, []ValidationErrorCode{ValidationErrorDuplicateEndpoint}, codes(err), "Path parameters with other names should not tell the endpoints apart")
+		assert.Contains(t, err.Error(), errorDuplicateEndpoint)
+		assert.Contains(t, err.Error(), "endpoint 'Get' of resource 'Users'")
+		assert.Contains(t, err.Error(), "endpoint 'Fetch' of resource 'Users'")
+	})
+
+	t.Run("unsupported field operations", func(t *testing.T) {
+		service := ApplyDefaultOverlays(createService())
+		service.Resources[0].Fields[0].Operations = []string{OperationRead, OperationUpdate, OperationDelete}
+
+		err := ValidateSemantics(service)
+
+		assert.Equal(t, []ValidationErrorCode{ValidationErrorUnsupportedOperation, ValidationErrorUnsupportedOperation}, codes(err), "Read should always be allowed")
+		assert.Contains(t, err.Error(), "($.resources[0].fields[0].operations[1]): invalid operation: field 'Name' is in the operation 'Update' that resource 'Users' does not support")
+	})
+
+	t.Run("name collisions", func(t *testing.T) {
+		service := createService()
+		service.Enums = []Enum{{Name: "Server", Values: []EnumValue{{Name: "Primary"}}}}
+		service.Objects = []Object{{Name: "UsersCreateBodyParams", Fields: []Field{{Name: "Name", Type: FieldTypeString}}}}
+		service = ApplyDefaultOverlays(service)
+
+		err := ValidateSemantics(service)
+
+		assert.Equal(t, []ValidationErrorCode{ValidationErrorNameCollision, ValidationErrorNameCollision}, codes(err))
+		assert.Contains(t, err.Error(), "($.enums[0].name): name collision: enum 'Server' declares the type 'Server' that every generated server already declares")
+		assert.Contains(t, err.Error(), "name collision: endpoint 'Create' of resource 'Users' declares the type 'UsersCreateBodyParams' that object 'UsersCreateBodyParams' already declares, set the type_name")
+	})
+
+	t.Run("field name collisions", func(t *testing.T) {
+		service := createService()
+		service.Resources[0].Fields = append(service.Resources[0].Fields, ResourceField{Field: Field{Name: "id", Type: FieldTypeString}, Operations: []string{OperationRead}})
+		service.Objects = []Object{{Name: "Address", Fields: []Field{
+			{Name: "postal_code", Type: FieldTypeString},
+			{Name: "postalCode", Type: FieldTypeString},
+		}}}
+		service = ApplyDefaultOverlays(service)
+
+		err := ValidateSemantics(service)
+
+		assert.Equal(t, []ValidationErrorCode{ValidationErrorNameCollision, ValidationErrorNameCollision}, codes(err))
+		assert.Contains(t, err.Error(), "($.objects[0].fields[1].name): name collision: field 'postalCode' of object 'Address' has the Go name 'PostalCode' of the field 'postal_code'")
+		assert.Contains(t, err.Error(), "name collision: field 'id' of object 'Users' has the JSON name 'id' of the field 'ID'")
+	})
+
+	t.Run("field name collisions of endpoints", func(t *testing.T) {
+		service := createService()
+		service.Resources[0].Endpoints[0].Request.QueryParams = []Field{
+			{Name: "dryRun", Type: FieldTypeBool},
+			{Name: "DryRun", Type: FieldTypeBool},
+		}
+		service = ApplyDefaultOverlays(service)
+
+		err := ValidateSemantics(service)
+
+		assert.Equal(t, []ValidationErrorCode{ValidationErrorNameCollision}, codes(err))
+		assert.Contains(t, err.Error(), "($.resources[0].endpoints[0].request.query_params[1].name): name collision: field 'DryRun' of the query params of endpoint 'Activate' of resource 'Users' has the Go name 'DryRun' of the field 'dryRun'")
+	})
+
+	t.Run("invalid identifiers", func(t *testing.T) {
+		service := createService()
+		service.Enums = []Enum{{Name: "func", Values: []EnumValue{{Name: "Primary"}}}}
+		service.Objects = []Object{{Name: "1stPlace", Fields: []Field{{Name: "type", Type: FieldTypeString}}}}
+		service = ApplyDefaultOverlays(service)
+
+		err := ValidateSemantics(service)
+
+		assert.Equal(t, []ValidationErrorCode{ValidationErrorInvalidIdentifier, ValidationErrorInvalidIdentifier}, codes(err))
+		assert.Contains(t, err.Error(), "($.enums[0].name): invalid type name: enum 'func' declares the type 'func' that is not a valid Go identifier")
+		assert.Contains(t, err.Error(), "($.objects[0].name): invalid type name: object '1stPlace' declares the type '1stPlace' that is not a valid Go identifier")
+	})
+
+	t.Run("renamed name collisions", func(t *testing.T) {
+		input := createService()
+		input.Naming = &Naming{TypeCollisions: TypeCollisionsRename}
+		input.Objects = []Object{
+			{Name: "UsersCreateBodyParams", Fields: []Field{{Name: "Name", Type: FieldTypeString}}},
+			{Name: "UsersCreateEndpointBodyParams", Fields: []Field{{Name: "Name", Type: FieldTypeString}}},
+		}
+
+		service := ApplyDefaultOverlays(input)
+
+		require.NoError(t, ValidateSemantics(service))
+		create := service.Resources[0].Endpoints[slices.IndexFunc(service.Resources[0].Endpoints, func(endpoint Endpoint) bool { return endpoint.Name == "Create" })]
+		assert.Equal(t, "UsersCreateEndpoint2", create.TypeName, "A type name that collides too should get a number")
+		assert.Equal(t, "UsersCreateEndpoint2BodyParams", create.GetBodyParamsType("Users"))
+		assert.Empty(t, input.Resources[0].Endpoints[0].TypeName, "The input should not be modified")
+		for _, endpoint := range service.Resources[0].Endpoints {
+			if endpoint.Name != "Create" {
+				assert.Empty(t, endpoint.TypeName, "Endpoints without collisions should not be renamed")
+			}
+		}
+		assert.Equal(t, service.Resources, ApplyDefaultOverlays(service).Resources, "Renaming should be idempotent")
+	})
+
+	t.Run("parsing a file validates the semantics", func(t *testing.T) {
+		specPath := filepath.Join(t.TempDir(), "service.yaml")
+		require.NoError(t, os.WriteFile(specPath, []byte(`
+name: "TestService"
+resources:
+  - name: "Users"
+    description: "Users"
+    operations: ["Get"]
+    fields:
+      - name: "Name"
+        description: "Name"
+        type: "String"
+        operations: ["Read", "Update"]
+`), 0644))
+
+		_, err := ParseServiceFromFile(specPath)
+
+		assert.Equal(t, []ValidationErrorCode{ValidationErrorUnsupportedOperation}, codes(err))
+		assert.Contains(t, err.Error(), errorValidationFailed)
+		var validationErrors ValidationErrors
+		require.ErrorAs(t, err, &validationErrors)
+		assert.Equal(t, specPath, validationErrors[0].File)
+	})
+
+	t.Run("parsing bytes validates the semantics", func(t *testing.T) {
+		_, err := ParseServiceFromBytesInDir([]byte(`
+name: "TestService"
+resources:
+  - name: "Users"
+    description: "Users"
+    operations: ["Get"]
+    fields:
+      - name: "Name"
+        description: "Name"
+        type: "String"
+        operations: ["Read", "Update"]
+`), extYAML, t.TempDir())
+
+		assert.Equal(t, []ValidationErrorCode{ValidationErrorUnsupportedOperation}, codes(err))
+		assert.Contains(t, err.Error(), errorValidationFailed)
+	})
+}